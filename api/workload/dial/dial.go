@@ -76,6 +76,18 @@ func parseTCPAddr(u *url.URL) (net.Addr, error) {
 }
 
 func parseUDSAddr(u *url.URL) (net.Addr, error) {
+	// Sockets in the abstract namespace (Linux only) are given as
+	// "unix:@name"
+	if strings.HasPrefix(u.Opaque, "@") {
+		if len(u.Opaque) == 1 {
+			return nil, errors.New("no name defined for abstract unix socket")
+		}
+		return &net.UnixAddr{
+			Net:  "unix",
+			Name: u.Opaque,
+		}, nil
+	}
+
 	if u.Host != "" {
 		return nil, fmt.Errorf("unexpected authority component in unix uri: %v", u.Host)
 	}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
+	"fmt"
 	"net"
 	"time"
 
@@ -10,11 +12,17 @@ import (
 	workload_dial "github.com/spiffe/spire/api/workload/dial"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
 	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/util"
+	workload_private "github.com/spiffe/spire/proto/private/agent/workload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type workloadClient struct {
 	workload.SpiffeWorkloadAPIClient
+	conn    grpc.ClientConnInterface
 	timeout time.Duration
 }
 
@@ -31,10 +39,23 @@ func newWorkloadClient(ctx context.Context, socketPath string, timeout time.Dura
 	}
 	return &workloadClient{
 		SpiffeWorkloadAPIClient: workload.NewSpiffeWorkloadAPIClient(conn),
+		conn:                    conn,
 		timeout:                 timeout,
 	}, nil
 }
 
+// FetchX509Bundles calls the FetchX509Bundles RPC, which is not provided by
+// the go-spiffe Workload API client.
+func (c *workloadClient) FetchX509Bundles(ctx context.Context, in *workload_private.X509BundlesRequest) (workload_private.FetchX509BundlesClient, error) {
+	return workload_private.FetchX509Bundles(ctx, c.conn, in)
+}
+
+// WatchJWTSVID calls the WatchJWTSVID RPC, an extension to the Workload API
+// served by SPIRE agents.
+func (c *workloadClient) WatchJWTSVID(ctx context.Context, in *workload_private.WatchJWTSVIDRequest) (workload_private.WatchJWTSVIDClient, error) {
+	return workload_private.WatchJWTSVID(ctx, c.conn, in)
+}
+
 func (c *workloadClient) prepareContext(ctx context.Context) (context.Context, func()) {
 	header := metadata.Pairs("workload.spiffe.io", "true")
 	ctx = metadata.NewOutgoingContext(ctx, header)
@@ -44,6 +65,55 @@ func (c *workloadClient) prepareContext(ctx context.Context) (context.Context, f
 	return ctx, func() {}
 }
 
+// prepareStreamContext prepares the context for a streaming call. The timeout
+// does not apply when watching, since the stream is kept open until the
+// command is interrupted.
+func (c *workloadClient) prepareStreamContext(ctx context.Context, watch bool) (context.Context, func()) {
+	if watch {
+		header := metadata.Pairs("workload.spiffe.io", "true")
+		return context.WithCancel(metadata.NewOutgoingContext(ctx, header))
+	}
+	return c.prepareContext(ctx)
+}
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// outputFlags holds the flags shared by the fetch commands to control how
+// the responses are printed.
+type outputFlags struct {
+	output string
+	watch  bool
+}
+
+func (o *outputFlags) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.output, "output", outputText, "Desired output format (text, json)")
+	fs.BoolVar(&o.watch, "watch", false, "Keep running and print updates as they are received")
+}
+
+func (o *outputFlags) validate() error {
+	switch o.output {
+	case outputText, outputJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q: expected text or json", o.output)
+	}
+}
+
+// printJSON prints the value as a single line of JSON, so updates printed in
+// watch mode can be consumed line by line.
+func printJSON(env *cli.Env, v interface{}) error {
+	return json.NewEncoder(env.Stdout).Encode(v)
+}
+
+// isInterrupted returns true if the error was caused by the command being
+// interrupted while watching.
+func isInterrupted(ctx context.Context, err error) bool {
+	return ctx.Err() != nil && status.Code(err) == codes.Canceled
+}
+
 // command is a common interface for commands in this package. the adapter
 // can adapter this interface to the Command interface from github.com/mitchellh/cli.
 type command interface {
@@ -83,7 +153,9 @@ func adaptCommand(env *cli.Env, clientsMaker workloadClientMaker, cmd command) *
 }
 
 func (a *adapter) Run(args []string) int {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	util.SignalListener(ctx, cancel)
 
 	if err := a.flags.Parse(args); err != nil {
 		_ = a.env.ErrPrintln(err)
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	workload_private "github.com/spiffe/spire/proto/private/agent/workload"
+)
+
+func NewFetchBundlesCommand() cli.Command {
+	return newFetchBundlesCommand(common_cli.DefaultEnv, newWorkloadClient)
+}
+
+func newFetchBundlesCommand(env *common_cli.Env, clientMaker workloadClientMaker) cli.Command {
+	return adaptCommand(env, clientMaker, new(fetchBundlesCommand))
+}
+
+type fetchBundlesCommand struct {
+	outputFlags
+	silent    bool
+	writePath string
+}
+
+func (*fetchBundlesCommand) name() string {
+	return "fetch bundles"
+}
+
+func (*fetchBundlesCommand) synopsis() string {
+	return "Fetches X.509 bundles from the Workload API"
+}
+
+func (c *fetchBundlesCommand) run(ctx context.Context, env *common_cli.Env, client *workloadClient) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := client.prepareStreamContext(ctx, c.watch)
+	defer cancel()
+
+	start := time.Now()
+	stream, err := client.FetchX509Bundles(ctx, &workload_private.X509BundlesRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		respTime := time.Since(start)
+		switch {
+		case err != nil && c.watch && isInterrupted(ctx, err):
+			return nil
+		case err != nil:
+			return err
+		}
+
+		if err := c.handleResponse(env, resp, respTime); err != nil {
+			if !c.watch {
+				return err
+			}
+			// Keep watching, the next update may be valid
+			_ = env.ErrPrintln(err)
+		}
+
+		if !c.watch {
+			return nil
+		}
+		start = time.Now()
+	}
+}
+
+func (c *fetchBundlesCommand) handleResponse(env *common_cli.Env, resp *workload_private.X509BundlesResponse, respTime time.Duration) error {
+	bundles, err := parseX509BundlesResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	trustDomains := make([]string, 0, len(bundles))
+	for trustDomain := range bundles {
+		trustDomains = append(trustDomains, trustDomain)
+	}
+	sort.Strings(trustDomains)
+
+	if !c.silent {
+		if c.output == outputJSON {
+			if err := printJSON(env, x509BundlesResponseToJSON(bundles)); err != nil {
+				return err
+			}
+		} else if err := printX509BundlesResponse(env, trustDomains, bundles, respTime); err != nil {
+			return err
+		}
+	}
+
+	if c.writePath != "" {
+		for _, trustDomain := range trustDomains {
+			bundlePath := path.Join(c.writePath, fmt.Sprintf("%s.pem", strings.TrimPrefix(trustDomain, "spiffe://")))
+			if c.output != outputJSON {
+				if err := env.Printf("Writing bundle for trust domain %s to file %s.\n", trustDomain, bundlePath); err != nil {
+					return err
+				}
+			}
+			if err := ioutil.WriteFile(bundlePath, pemutil.EncodeCertificates(bundles[trustDomain]), 0644); err != nil { // nolint: gosec // expected permission for certificates
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *fetchBundlesCommand) appendFlags(fs *flag.FlagSet) {
+	c.outputFlags.appendFlags(fs)
+	fs.BoolVar(&c.silent, "silent", false, "Suppress stdout")
+	fs.StringVar(&c.writePath, "write", "", "Write bundles to the specified path (optional)")
+}
+
+func parseX509BundlesResponse(resp *workload_private.X509BundlesResponse) (map[string][]*x509.Certificate, error) {
+	if len(resp.Bundles) == 0 {
+		return nil, errors.New("workload response contains no bundles")
+	}
+
+	bundles := make(map[string][]*x509.Certificate, len(resp.Bundles))
+	for trustDomain, bundleDER := range resp.Bundles {
+		bundle, err := x509.ParseCertificates(bundleDER)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bundle for trust domain %q: %v", trustDomain, err)
+		}
+		if len(bundle) == 0 {
+			return nil, fmt.Errorf("no certificates in bundle for trust domain %q", trustDomain)
+		}
+		bundles[trustDomain] = bundle
+	}
+	return bundles, nil
+}
+
+func printX509BundlesResponse(env *common_cli.Env, trustDomains []string, bundles map[string][]*x509.Certificate, respTime time.Duration) error {
+	lenMsg := fmt.Sprintf("Received %d bundle", len(bundles))
+	if len(bundles) != 1 {
+		lenMsg += "s"
+	}
+	lenMsg += fmt.Sprintf(" after %s", respTime)
+	if err := env.Println(lenMsg); err != nil {
+		return err
+	}
+
+	for _, trustDomain := range trustDomains {
+		if err := env.Println(); err != nil {
+			return err
+		}
+		if err := env.Printf("Trust domain:\t\t%s\n", trustDomain); err != nil {
+			return err
+		}
+		for i, ca := range bundles[trustDomain] {
+			num := i + 1
+			if err := env.Printf("CA #%v Valid After:\t%v\n", num, ca.NotBefore); err != nil {
+				return err
+			}
+			if err := env.Printf("CA #%v Valid Until:\t%v\n", num, ca.NotAfter); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type x509BundlesResponseJSON struct {
+	Bundles map[string]string `json:"bundles"`
+}
+
+// x509BundlesResponseToJSON converts the bundles to their JSON
+// representation, with the certificates of each bundle PEM encoded.
+func x509BundlesResponseToJSON(bundles map[string][]*x509.Certificate) x509BundlesResponseJSON {
+	resp := x509BundlesResponseJSON{
+		Bundles: make(map[string]string, len(bundles)),
+	}
+	for trustDomain, bundle := range bundles {
+		resp.Bundles[trustDomain] = string(pemutil.EncodeCertificates(bundle))
+	}
+	return resp
+}
@@ -2,13 +2,25 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"time"
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	workload_private "github.com/spiffe/spire/proto/private/agent/workload"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	// minJWTRefreshInterval is the minimum amount of time to wait between
+	// fetches of the JWT SVIDs when watching.
+	minJWTRefreshInterval = 5 * time.Second
 )
 
 func NewFetchJWTCommand() cli.Command {
@@ -20,6 +32,7 @@ func newFetchJWTCommand(env *common_cli.Env, clientMaker workloadClientMaker) cl
 }
 
 type fetchJWTCommand struct {
+	outputFlags
 	audience common_cli.CommaStringsFlag
 	spiffeID string
 }
@@ -36,18 +49,146 @@ func (c *fetchJWTCommand) run(ctx context.Context, env *common_cli.Env, client *
 	if len(c.audience) == 0 {
 		return errors.New("audience must be specified")
 	}
+	if err := c.validate(); err != nil {
+		return err
+	}
 
-	bundlesResp, err := c.fetchJWTBundles(ctx, client)
+	if c.watch {
+		err := c.watchAndPrint(ctx, env, client)
+		if status.Code(err) != codes.Unimplemented {
+			return err
+		}
+		// The agent does not stream JWT-SVIDs; poll them instead
+	}
+
+	for {
+		refreshIn, err := c.fetchAndPrint(ctx, env, client)
+		switch {
+		case err != nil && c.watch && isInterrupted(ctx, err):
+			return nil
+		case err != nil && c.watch:
+			// Keep watching, the next fetch may succeed
+			_ = env.ErrPrintln(err)
+		case err != nil:
+			return err
+		}
+
+		if !c.watch {
+			return nil
+		}
+
+		timer := time.NewTimer(refreshIn)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// watchAndPrint prints the JWT-SVIDs streamed by the agent, which renews
+// them before they expire, along with the current bundles, until the command
+// is interrupted.
+func (c *fetchJWTCommand) watchAndPrint(ctx context.Context, env *common_cli.Env, client *workloadClient) error {
+	streamCtx, cancel := client.prepareStreamContext(ctx, true)
+	defer cancel()
+
+	stream, err := client.WatchJWTSVID(streamCtx, &workload_private.WatchJWTSVIDRequest{
+		Audience: c.audience,
+		SpiffeId: c.spiffeID,
+	})
 	if err != nil {
 		return err
 	}
+
+	for {
+		svidResp, err := stream.Recv()
+		switch {
+		case isInterrupted(ctx, err):
+			return nil
+		case err != nil:
+			return err
+		}
+
+		bundlesResp, err := c.fetchJWTBundles(ctx, client)
+		switch {
+		case isInterrupted(ctx, err):
+			return nil
+		case err != nil:
+			// Keep watching, the bundles may be fetched along with the next
+			// JWT-SVIDs
+			_ = env.ErrPrintln(err)
+			continue
+		}
+
+		svids := make([]jwtSVIDJSON, 0, len(svidResp.Svids))
+		for _, svid := range svidResp.Svids {
+			svids = append(svids, jwtSVIDJSON{
+				SPIFFEID:  svid.SpiffeId,
+				SVID:      svid.Svid,
+				Hint:      svid.Hint,
+				ExpiresAt: time.Unix(svid.ExpiresAt, 0),
+			})
+		}
+		if err := c.print(env, svids, bundlesResp); err != nil {
+			return err
+		}
+	}
+}
+
+// fetchAndPrint fetches and prints the JWT SVIDs and bundles. It returns how
+// long to wait before fetching the SVIDs again when watching, which is half
+// of the lifetime of the shortest lived SVID.
+func (c *fetchJWTCommand) fetchAndPrint(ctx context.Context, env *common_cli.Env, client *workloadClient) (time.Duration, error) {
+	bundlesResp, err := c.fetchJWTBundles(ctx, client)
+	if err != nil {
+		return minJWTRefreshInterval, err
+	}
 	svidResp, err := c.fetchJWTSVID(ctx, client)
 	if err != nil {
-		return err
+		return minJWTRefreshInterval, err
 	}
 
+	svids := make([]jwtSVIDJSON, 0, len(svidResp.Svids))
+	refreshIn := time.Duration(0)
 	for _, svid := range svidResp.Svids {
-		fmt.Printf("token(%s):\n\t%s\n", svid.SpiffeId, svid.Svid)
+		issuedAt, expiresAt, err := parseJWTSVIDLifetime(svid.Svid)
+		if err != nil {
+			return minJWTRefreshInterval, err
+		}
+		if halfLife := expiresAt.Sub(issuedAt) / 2; refreshIn == 0 || halfLife < refreshIn {
+			refreshIn = halfLife
+		}
+		svids = append(svids, jwtSVIDJSON{
+			SPIFFEID:  svid.SpiffeId,
+			SVID:      svid.Svid,
+			Hint:      workload_private.JWTSVIDHint(svid),
+			ExpiresAt: expiresAt,
+		})
+	}
+
+	if refreshIn < minJWTRefreshInterval {
+		refreshIn = minJWTRefreshInterval
+	}
+
+	return refreshIn, c.print(env, svids, bundlesResp)
+}
+
+func (c *fetchJWTCommand) print(env *common_cli.Env, svids []jwtSVIDJSON, bundlesResp *workload.JWTBundlesResponse) error {
+	if c.output == outputJSON {
+		resp := jwtResponseJSON{
+			SVIDs:   svids,
+			Bundles: make(map[string]json.RawMessage, len(bundlesResp.Bundles)),
+		}
+		for trustDomainID, jwksJSON := range bundlesResp.Bundles {
+			resp.Bundles[trustDomainID] = json.RawMessage(jwksJSON)
+		}
+		return printJSON(env, resp)
+	}
+
+	for _, svid := range svids {
+		fmt.Printf("token(%s):\n\t%s\n", svid.SPIFFEID, svid.SVID)
 	}
 
 	for trustDomainID, jwksJSON := range bundlesResp.Bundles {
@@ -58,6 +199,7 @@ func (c *fetchJWTCommand) run(ctx context.Context, env *common_cli.Env, client *
 }
 
 func (c *fetchJWTCommand) appendFlags(fs *flag.FlagSet) {
+	c.outputFlags.appendFlags(fs)
 	fs.Var(&c.audience, "audience", "comma separated list of audience values")
 	fs.StringVar(&c.spiffeID, "spiffeID", "", "SPIFFE ID subject (optional)")
 }
@@ -80,3 +222,38 @@ func (c *fetchJWTCommand) fetchJWTBundles(ctx context.Context, client *workloadC
 	}
 	return stream.Recv()
 }
+
+// parseJWTSVIDLifetime returns the issued at and expiration times of a JWT
+// SVID. The signature is not verified since the token is only inspected to
+// decide when to fetch it again.
+func parseJWTSVIDLifetime(token string) (time.Time, time.Time, error) {
+	tok, err := jwt.ParseSigned(token)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("unable to parse JWT SVID: %v", err)
+	}
+	var claims jwt.Claims
+	if err := tok.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("unable to parse JWT SVID claims: %v", err)
+	}
+	if claims.Expiry == nil {
+		return time.Time{}, time.Time{}, errors.New("JWT SVID has no expiration")
+	}
+	expiresAt := claims.Expiry.Time()
+	issuedAt := expiresAt
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time()
+	}
+	return issuedAt, expiresAt, nil
+}
+
+type jwtSVIDJSON struct {
+	SPIFFEID  string    `json:"spiffe_id"`
+	SVID      string    `json:"svid"`
+	Hint      string    `json:"hint,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type jwtResponseJSON struct {
+	SVIDs   []jwtSVIDJSON              `json:"svids"`
+	Bundles map[string]json.RawMessage `json:"bundles"`
+}
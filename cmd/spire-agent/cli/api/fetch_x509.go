@@ -18,6 +18,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/pemutil"
 )
 
 func NewFetchX509Command() cli.Command {
@@ -29,6 +30,7 @@ func newFetchX509Command(env *common_cli.Env, clientMaker workloadClientMaker) c
 }
 
 type fetchX509Command struct {
+	outputFlags
 	silent    bool
 	writePath string
 }
@@ -42,20 +44,58 @@ func (*fetchX509Command) synopsis() string {
 }
 
 func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client *workloadClient) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := client.prepareStreamContext(ctx, c.watch)
+	defer cancel()
+
 	start := time.Now()
-	resp, err := c.fetchX509SVID(ctx, client)
-	respTime := time.Since(start)
+	stream, err := client.FetchX509SVID(ctx, &workload.X509SVIDRequest{})
 	if err != nil {
 		return err
 	}
 
+	for {
+		resp, err := stream.Recv()
+		respTime := time.Since(start)
+		switch {
+		case err != nil && c.watch && isInterrupted(ctx, err):
+			return nil
+		case err != nil:
+			return err
+		}
+
+		if err := c.handleResponse(env, resp, respTime); err != nil {
+			if !c.watch {
+				return err
+			}
+			// Keep watching, the next update may be valid
+			_ = env.ErrPrintln(err)
+		}
+
+		if !c.watch {
+			return nil
+		}
+		start = time.Now()
+	}
+}
+
+func (c *fetchX509Command) handleResponse(env *common_cli.Env, resp *workload.X509SVIDResponse, respTime time.Duration) error {
 	svids, err := parseAndValidateX509SVIDResponse(resp)
 	if err != nil {
 		return err
 	}
 
 	if !c.silent {
-		printX509SVIDResponse(svids, respTime)
+		if c.output == outputJSON {
+			if err := printJSON(env, x509SVIDResponseToJSON(svids)); err != nil {
+				return err
+			}
+		} else {
+			printX509SVIDResponse(svids, respTime)
+		}
 	}
 
 	if c.writePath != "" {
@@ -68,20 +108,17 @@ func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client
 }
 
 func (c *fetchX509Command) appendFlags(fs *flag.FlagSet) {
+	c.outputFlags.appendFlags(fs)
 	fs.BoolVar(&c.silent, "silent", false, "Suppress stdout")
 	fs.StringVar(&c.writePath, "write", "", "Write SVID data to the specified path (optional)")
 }
 
-func (c *fetchX509Command) fetchX509SVID(ctx context.Context, client *workloadClient) (*workload.X509SVIDResponse, error) {
-	ctx, cancel := client.prepareContext(ctx)
-	defer cancel()
-
-	stream, err := client.FetchX509SVID(ctx, &workload.X509SVIDRequest{})
-	if err != nil {
-		return nil, err
+// printf prints the progress messages of the command. They are omitted when
+// printing JSON so the output can be parsed.
+func (c *fetchX509Command) printf(format string, args ...interface{}) {
+	if c.output != outputJSON {
+		fmt.Printf(format, args...)
 	}
-
-	return stream.Recv()
 }
 
 func (c *fetchX509Command) writeResponse(svids []*X509SVID) error {
@@ -90,19 +127,19 @@ func (c *fetchX509Command) writeResponse(svids []*X509SVID) error {
 		keyPath := path.Join(c.writePath, fmt.Sprintf("svid.%v.key", i))
 		bundlePath := path.Join(c.writePath, fmt.Sprintf("bundle.%v.pem", i))
 
-		fmt.Printf("Writing SVID #%d to file %s.\n", i, svidPath)
+		c.printf("Writing SVID #%d to file %s.\n", i, svidPath)
 		err := c.writeCerts(svidPath, svid.Certificates)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("Writing key #%d to file %s.\n", i, keyPath)
+		c.printf("Writing key #%d to file %s.\n", i, keyPath)
 		err = c.writeKey(keyPath, svid.PrivateKey)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("Writing bundle #%d to file %s.\n", i, bundlePath)
+		c.printf("Writing bundle #%d to file %s.\n", i, bundlePath)
 		err = c.writeCerts(bundlePath, svid.Bundle)
 		if err != nil {
 			return err
@@ -116,7 +153,7 @@ func (c *fetchX509Command) writeResponse(svids []*X509SVID) error {
 
 		for j, trustDomain := range federatedDomains {
 			bundlePath := path.Join(c.writePath, fmt.Sprintf("federated_bundle.%d.%d.pem", i, j))
-			fmt.Printf("Writing federated bundle #%d for trust domain %s to file %s.\n", j, trustDomain, bundlePath)
+			c.printf("Writing federated bundle #%d for trust domain %s to file %s.\n", j, trustDomain, bundlePath)
 			err = c.writeCerts(bundlePath, svid.FederatedBundles[trustDomain])
 			if err != nil {
 				return err
@@ -265,3 +302,40 @@ func validateX509SVID(svid *X509SVID) error {
 
 	return nil
 }
+
+type x509SVIDJSON struct {
+	SPIFFEID         string            `json:"spiffe_id"`
+	X509SVID         string            `json:"x509_svid"`
+	ExpiresAt        time.Time         `json:"expires_at"`
+	Bundle           string            `json:"bundle"`
+	FederatedBundles map[string]string `json:"federated_bundles,omitempty"`
+}
+
+type x509SVIDResponseJSON struct {
+	SVIDs []x509SVIDJSON `json:"svids"`
+}
+
+// x509SVIDResponseToJSON converts the SVIDs to their JSON representation.
+// Certificates are PEM encoded. Private keys are never printed, they can only
+// be written to disk with the -write flag.
+func x509SVIDResponseToJSON(svids []*X509SVID) x509SVIDResponseJSON {
+	resp := x509SVIDResponseJSON{
+		SVIDs: []x509SVIDJSON{},
+	}
+	for _, svid := range svids {
+		svidJSON := x509SVIDJSON{
+			SPIFFEID:  svid.SPIFFEID,
+			X509SVID:  string(pemutil.EncodeCertificates(svid.Certificates)),
+			ExpiresAt: svid.Certificates[0].NotAfter,
+			Bundle:    string(pemutil.EncodeCertificates(svid.Bundle)),
+		}
+		if len(svid.FederatedBundles) > 0 {
+			svidJSON.FederatedBundles = make(map[string]string, len(svid.FederatedBundles))
+			for trustDomain, bundle := range svid.FederatedBundles {
+				svidJSON.FederatedBundles[trustDomain] = string(pemutil.EncodeCertificates(bundle))
+			}
+		}
+		resp.SVIDs = append(resp.SVIDs, svidJSON)
+	}
+	return resp
+}
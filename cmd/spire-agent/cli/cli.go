@@ -5,9 +5,12 @@ import (
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/api"
+	"github.com/spiffe/spire/cmd/spire-agent/cli/debug"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/run"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/validate"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/version"
 )
@@ -27,6 +30,9 @@ func (cc *CLI) Run(args []string) int {
 		"api fetch x509": func() (cli.Command, error) {
 			return api.NewFetchX509Command(), nil
 		},
+		"api fetch bundles": func() (cli.Command, error) {
+			return api.NewFetchBundlesCommand(), nil
+		},
 		"api fetch jwt": func() (cli.Command, error) {
 			return api.NewFetchJWTCommand(), nil
 		},
@@ -36,6 +42,9 @@ func (cc *CLI) Run(args []string) int {
 		"api watch": func() (cli.Command, error) {
 			return &api.WatchCLI{}, nil
 		},
+		"debug dump": func() (cli.Command, error) {
+			return debug.NewDumpCommand(), nil
+		},
 		"run": func() (cli.Command, error) {
 			return run.NewRunCommand(cc.LogOptions, cc.AllowUnknownConfig), nil
 		},
@@ -45,6 +54,9 @@ func (cc *CLI) Run(args []string) int {
 		"validate": func() (cli.Command, error) {
 			return validate.NewValidateCommand(), nil
 		},
+		"feature-flags": func() (cli.Command, error) {
+			return common_cli.NewFeatureFlagsCommand(common_cli.DefaultEnv, fflag.ScopeAgent, "spire-agent"), nil
+		},
 	}
 
 	exitStatus, err := c.Run()
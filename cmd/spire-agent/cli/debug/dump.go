@@ -0,0 +1,166 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	debug_pb "github.com/spiffe/spire/proto/spire/api/agent/debug/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc"
+)
+
+func NewDumpCommand() cli.Command {
+	return newDumpCommand(common_cli.DefaultEnv)
+}
+
+func newDumpCommand(env *common_cli.Env) *dumpCommand {
+	return &dumpCommand{
+		env:     env,
+		timeout: common_cli.DurationFlag(time.Second * 5),
+	}
+}
+
+type dumpCommand struct {
+	env *common_cli.Env
+
+	socketPath string
+	timeout    common_cli.DurationFlag
+}
+
+func (c *dumpCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *dumpCommand) Synopsis() string {
+	return "Dumps the agent entry cache and the most recent workload attestations"
+}
+
+func (c *dumpCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well
+		// be reported
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+	return 0
+}
+
+func (c *dumpCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet("debug dump", flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	fs.StringVar(&c.socketPath, "socketPath", "", "Path to the agent admin API socket (the admin_socket_path configurable)")
+	fs.Var(&c.timeout, "timeout", "Time to wait for a response")
+	return fs.Parse(args)
+}
+
+func (c *dumpCommand) run() error {
+	if c.socketPath == "" {
+		return errors.New("socketPath must be specified")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.timeout))
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, c.socketPath,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", addr)
+		}),
+		grpc.WithBlock(),
+		grpc.FailOnNonTempDialError(true),
+		grpc.WithReturnConnectionError())
+	if err != nil {
+		return fmt.Errorf("unable to connect to the admin API: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := debug_pb.NewDebugClient(conn).Dump(ctx, &debug_pb.DumpRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to dump agent state: %v", err)
+	}
+
+	return c.print(resp)
+}
+
+func (c *dumpCommand) print(resp *debug_pb.DumpResponse) error {
+	if err := c.env.Printf("Agent SVID expires at : %s\n", formatTime(resp.AgentSvidExpiresAt)); err != nil {
+		return err
+	}
+
+	if err := c.env.Printf("\nCached entries: %d\n", len(resp.Entries)); err != nil {
+		return err
+	}
+	for _, entry := range resp.Entries {
+		svidExpiresAt := "no X509-SVID"
+		if entry.SvidExpiresAt != 0 {
+			svidExpiresAt = formatTime(entry.SvidExpiresAt)
+		}
+		if err := c.env.Printf("\nEntry ID         : %s\nSPIFFE ID        : %s\nParent ID        : %s\nSelectors        : %s\nSVID expires at  : %s\n",
+			entry.Id,
+			formatID(entry.SpiffeId),
+			formatID(entry.ParentId),
+			formatSelectors(entry.Selectors),
+			svidExpiresAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := c.env.Printf("\nRecent workload attestations: %d\n", len(resp.Attestations)); err != nil {
+		return err
+	}
+	for _, attestation := range resp.Attestations {
+		matching := "none"
+		if len(attestation.EntryIds) > 0 {
+			matching = strings.Join(attestation.EntryIds, ", ")
+		}
+		if err := c.env.Printf("\nPID              : %d\nAttested at      : %s\nSelectors        : %s\nMatching entries : %s\n",
+			attestation.Pid,
+			formatTime(attestation.AttestedAt),
+			formatSelectors(attestation.Selectors),
+			matching,
+		); err != nil {
+			return err
+		}
+		for _, attestorErr := range attestation.Errors {
+			if err := c.env.Printf("Attestor error   : %s\n", attestorErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatTime(seconds int64) string {
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+}
+
+func formatID(id *types.SPIFFEID) string {
+	if id == nil {
+		return ""
+	}
+	return fmt.Sprintf("spiffe://%s%s", id.TrustDomain, id.Path)
+}
+
+func formatSelectors(selectors []*types.Selector) string {
+	if len(selectors) == 0 {
+		return "none"
+	}
+	var out []string
+	for _, selector := range selectors {
+		out = append(out, selector.Type+":"+selector.Value)
+	}
+	return strings.Join(out, ", ")
+}
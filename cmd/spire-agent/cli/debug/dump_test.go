@@ -0,0 +1,168 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	debug_pb "github.com/spiffe/spire/proto/spire/api/agent/debug/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDumpSynopsis(t *testing.T) {
+	cmd := newDumpCommand(common_cli.DefaultEnv)
+	require.Equal(t, "Dumps the agent entry cache and the most recent workload attestations", cmd.Synopsis())
+}
+
+func TestDumpHelp(t *testing.T) {
+	stderr := new(bytes.Buffer)
+	cmd := newDumpCommand(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: new(bytes.Buffer),
+		Stderr: stderr,
+	})
+	require.Equal(t, "", cmd.Help())
+	require.Equal(t, `Usage of debug dump:
+  -socketPath string
+    	Path to the agent admin API socket (the admin_socket_path configurable)
+  -timeout value
+    	Time to wait for a response (default 5s)
+`, stderr.String())
+}
+
+func TestDump(t *testing.T) {
+	expiresAt := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC).Unix()
+	server := &fakeDebugServer{
+		resp: &debug_pb.DumpResponse{
+			AgentSvidExpiresAt: expiresAt,
+			Entries: []*debug_pb.DumpResponse_Entry{
+				{
+					Id:            "ENTRY1",
+					SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+					ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/foo"},
+					Selectors:     []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+					SvidExpiresAt: expiresAt,
+				},
+				{
+					Id:       "ENTRY2",
+					SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/other"},
+					ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/foo"},
+					Selectors: []*types.Selector{
+						{Type: "unix", Value: "uid:1000"},
+						{Type: "unix", Value: "gid:1000"},
+					},
+				},
+			},
+			Attestations: []*debug_pb.DumpResponse_Attestation{
+				{
+					Pid:        1,
+					AttestedAt: expiresAt,
+					Selectors:  []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+					Errors:     []string{`workload attestor "k8s" failed`},
+					EntryIds:   []string{"ENTRY1"},
+				},
+				{
+					Pid:        2,
+					AttestedAt: expiresAt,
+				},
+			},
+		},
+	}
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
+		debug_pb.RegisterDebugServer(s, server)
+	})
+
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		err            error
+		expectedCode   int
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "missing socket path",
+			expectedCode:   1,
+			expectedStderr: "socketPath must be specified\n",
+		},
+		{
+			name:           "dump fails",
+			args:           []string{"-socketPath", socketPath},
+			err:            status.Error(codes.Internal, "oh no"),
+			expectedCode:   1,
+			expectedStderr: "unable to dump agent state: rpc error: code = Internal desc = oh no\n",
+		},
+		{
+			name: "success",
+			args: []string{"-socketPath", socketPath},
+			expectedStdout: `Agent SVID expires at : 2021-01-02T03:04:05Z
+
+Cached entries: 2
+
+Entry ID         : ENTRY1
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/spire/agent/foo
+Selectors        : unix:uid:1000
+SVID expires at  : 2021-01-02T03:04:05Z
+
+Entry ID         : ENTRY2
+SPIFFE ID        : spiffe://example.org/other
+Parent ID        : spiffe://example.org/spire/agent/foo
+Selectors        : unix:uid:1000, unix:gid:1000
+SVID expires at  : no X509-SVID
+
+Recent workload attestations: 2
+
+PID              : 1
+Attested at      : 2021-01-02T03:04:05Z
+Selectors        : unix:uid:1000
+Matching entries : ENTRY1
+Attestor error   : workload attestor "k8s" failed
+
+PID              : 2
+Attested at      : 2021-01-02T03:04:05Z
+Selectors        : none
+Matching entries : none
+`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server.err = tt.err
+
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			cmd := newDumpCommand(&common_cli.Env{
+				Stdin:  new(bytes.Buffer),
+				Stdout: stdout,
+				Stderr: stderr,
+			})
+
+			code := cmd.Run(tt.args)
+			require.Equal(t, tt.expectedCode, code)
+			require.Equal(t, tt.expectedStdout, stdout.String())
+			require.Equal(t, tt.expectedStderr, stderr.String())
+		})
+	}
+}
+
+type fakeDebugServer struct {
+	debug_pb.UnimplementedDebugServer
+
+	resp *debug_pb.DumpResponse
+	err  error
+}
+
+func (s *fakeDebugServer) Dump(context.Context, *debug_pb.DumpRequest) (*debug_pb.DumpResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.resp, nil
+}
@@ -1,15 +1,18 @@
 package healthcheck
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"net"
+	"net/http"
 	"time"
 
 	"github.com/mitchellh/cli"
 	api_workload "github.com/spiffe/spire/api/workload"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/health"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -32,6 +35,9 @@ type healthCheckCommand struct {
 	timeout    common_cli.DurationFlag
 	shallow    bool
 	verbose    bool
+	readyURL   string
+	json       bool
+	checks     common_cli.StringsFlag
 }
 
 func (c *healthCheckCommand) Help() string {
@@ -63,16 +69,79 @@ func (c *healthCheckCommand) parseFlags(args []string) error {
 	fs.StringVar(&c.socketPath, "socketPath", common.DefaultSocketPath, "Path to Workload API socket")
 	fs.BoolVar(&c.shallow, "shallow", false, "Perform a less stringent health check")
 	fs.BoolVar(&c.verbose, "verbose", false, "Print verbose information")
+	fs.StringVar(&c.readyURL, "readyURL", "", "URL of the agent readiness endpoint (e.g. http://localhost:80/ready) used to report the health of each agent subsystem")
+	fs.BoolVar(&c.json, "json", false, "Print the health of each subsystem as JSON")
+	fs.Var(&c.checks, "check", "Name of a check to report on, in which case the exit code only reflects the named checks. Can be used more than once")
 	return fs.Parse(args)
 }
 
 func (c *healthCheckCommand) run() error {
+	report := health.NewReport()
+	if c.readyURL != "" {
+		if c.verbose {
+			c.env.Printf("Contacting readiness endpoint...\n")
+		}
+		var err error
+		report, err = health.FetchReport(&http.Client{Timeout: time.Duration(c.timeout)}, c.readyURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	registry := new(health.CheckRegistry)
+	registry.Register("workload_api", func() (interface{}, error) {
+		return nil, c.checkWorkloadAPI()
+	})
+	registry.Run(report)
+
+	if len(c.checks) > 0 {
+		var err error
+		report, err = report.Filter(c.checks)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.json {
+		if err := json.NewEncoder(c.env.Stdout).Encode(report); err != nil {
+			return err
+		}
+		if report.Failed() {
+			return errors.New("Agent is unhealthy.") //nolint: golint // error is (ab)used for CLI output
+		}
+		return nil
+	}
+
+	if c.verbose && c.readyURL != "" {
+		for _, line := range report.Summary() {
+			if err := c.env.Println(line); err != nil {
+				return err
+			}
+		}
+	}
+
+	workloadAPIState, ok := report.Details["workload_api"]
+	switch {
+	case ok && workloadAPIState.Status != "ok":
+		return errors.New("Agent is unavailable.") //nolint: golint // error is (ab)used for CLI output
+	case report.Failed():
+		return errors.New("Agent is unhealthy.") //nolint: golint // error is (ab)used for CLI output
+	}
+
+	if err := c.env.Println("Agent is healthy."); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkWorkloadAPI checks that the agent is serving the Workload API
+func (c *healthCheckCommand) checkWorkloadAPI() error {
 	addr := &net.UnixAddr{
 		Name: c.socketPath,
 		Net:  "unix",
 	}
 
-	if c.verbose {
+	if c.verbose && !c.json {
 		c.env.Printf("Contacting Workload API...\n")
 	}
 
@@ -89,22 +158,18 @@ func (c *healthCheckCommand) run() error {
 
 	select {
 	case err := <-errCh:
-		if c.verbose {
+		if c.verbose && !c.json {
 			c.env.Printf("Workload API returned %s\n", err)
 		}
 		if status.Code(err) == codes.Unavailable {
-			return errors.New("Agent is unavailable.") //nolint: golint // error is (ab)used for CLI output
+			return err
 		}
 	case <-client.UpdateChan():
-		if c.verbose {
+		if c.verbose && !c.json {
 			if err := c.env.Println("SVID received over Workload API."); err != nil {
 				return err
 			}
 		}
 	}
-
-	if err := c.env.Println("Agent is healthy."); err != nil {
-		return err
-	}
 	return nil
 }
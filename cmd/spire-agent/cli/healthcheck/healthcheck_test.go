@@ -2,11 +2,15 @@ package healthcheck
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/test/fakes/fakeworkloadapi"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc/codes"
@@ -46,6 +50,12 @@ func (s *HealthCheckSuite) TestSynopsis() {
 func (s *HealthCheckSuite) TestHelp() {
 	s.Equal("", s.cmd.Help())
 	s.Equal(`Usage of health:
+  -check value
+    	Name of a check to report on, in which case the exit code only reflects the named checks. Can be used more than once
+  -json
+    	Print the health of each subsystem as JSON
+  -readyURL string
+    	URL of the agent readiness endpoint (e.g. http://localhost:80/ready) used to report the health of each agent subsystem
   -shallow
     	Perform a less stringent health check
   -socketPath string
@@ -61,6 +71,12 @@ func (s *HealthCheckSuite) TestBadFlags() {
 	s.Equal("", s.stdout.String(), "stdout")
 	s.Equal(`flag provided but not defined: -badflag
 Usage of health:
+  -check value
+    	Name of a check to report on, in which case the exit code only reflects the named checks. Can be used more than once
+  -json
+    	Print the health of each subsystem as JSON
+  -readyURL string
+    	URL of the agent readiness endpoint (e.g. http://localhost:80/ready) used to report the health of each agent subsystem
   -shallow
     	Perform a less stringent health check
   -socketPath string
@@ -121,6 +137,91 @@ Agent is healthy.
 	s.Equal("", s.stderr.String(), "stderr")
 }
 
+func (s *HealthCheckSuite) TestReportsSubsystemsVerbose() {
+	w := s.makeGoodWorkloadAPI()
+	ready := s.makeReadyEndpoint(`{"status":"failed","details":{"cache_sync":{"name":"cache_sync","status":"failed","error":"cache has not been synchronized"},"attestation":{"name":"attestation","status":"ok"}}}`)
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--readyURL", ready.URL, "--verbose"})
+	s.NotEqual(0, code, "exit code")
+	s.Equal(`Contacting readiness endpoint...
+Contacting Workload API...
+SVID received over Workload API.
+attestation: ok
+cache_sync: failed (cache has not been synchronized)
+workload_api: ok
+`, s.stdout.String(), "stdout")
+	s.Equal("Agent is unhealthy.\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestReportsSubsystemsJSON() {
+	w := s.makeGoodWorkloadAPI()
+	ready := s.makeReadyEndpoint(`{"status":"ok","details":{"attestation":{"name":"attestation","status":"ok","details":{"agent_id":"spiffe://example.org/spire/agent/test"}}}}`)
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--readyURL", ready.URL, "--json"})
+	s.Equal(0, code, "exit code")
+	s.Equal("", s.stderr.String(), "stderr")
+
+	report := new(health.Report)
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), report))
+	s.Equal("ok", report.Status)
+	s.Require().Len(report.Details, 2)
+	s.Equal("ok", report.Details["attestation"].Status)
+	s.Equal(map[string]interface{}{"agent_id": "spiffe://example.org/spire/agent/test"}, report.Details["attestation"].Details)
+	s.Equal("ok", report.Details["workload_api"].Status)
+}
+
+func (s *HealthCheckSuite) TestReportsUnavailableJSON() {
+	code := s.cmd.Run([]string{"--socketPath", "doesnotexist.sock", "--json"})
+	s.NotEqual(0, code, "exit code")
+	s.Equal("Agent is unhealthy.\n", s.stderr.String(), "stderr")
+
+	report := new(health.Report)
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), report))
+	s.Equal("failed", report.Status)
+	s.Equal("failed", report.Details["workload_api"].Status)
+	s.Contains(report.Details["workload_api"].Err, "code = Unavailable")
+}
+
+func (s *HealthCheckSuite) TestReportsOnNamedChecks() {
+	w := s.makeGoodWorkloadAPI()
+	ready := s.makeReadyEndpoint(`{"status":"failed","details":{"cache_sync":{"name":"cache_sync","status":"failed","error":"cache has not been synchronized"},"vault.token_ttl":{"name":"vault.token_ttl","status":"ok"}}}`)
+
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--readyURL", ready.URL, "--check", "vault.token_ttl", "--check", "workload_api"})
+	s.Equal(0, code, "exit code")
+	s.Equal("Agent is healthy.\n", s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestReportsOnNamedFailedCheckJSON() {
+	w := s.makeGoodWorkloadAPI()
+	ready := s.makeReadyEndpoint(`{"status":"failed","details":{"cache_sync":{"name":"cache_sync","status":"failed","error":"cache has not been synchronized"}}}`)
+
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--readyURL", ready.URL, "--check", "cache_sync", "--json"})
+	s.NotEqual(0, code, "exit code")
+	s.Equal("Agent is unhealthy.\n", s.stderr.String(), "stderr")
+
+	report := new(health.Report)
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), report))
+	s.Equal("failed", report.Status)
+	s.Require().Len(report.Details, 1)
+	s.Equal("cache has not been synchronized", report.Details["cache_sync"].Err)
+}
+
+func (s *HealthCheckSuite) TestFailsOnUnknownCheck() {
+	w := s.makeGoodWorkloadAPI()
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--check", "cache_sync"})
+	s.NotEqual(0, code, "exit code")
+	s.Equal("", s.stdout.String(), "stdout")
+	s.Equal("unknown check \"cache_sync\"\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) makeReadyEndpoint(body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	s.T().Cleanup(server.Close)
+	return server
+}
+
 func (s *HealthCheckSuite) makeFailedWorkloadAPI(err error) *fakeworkloadapi.WorkloadAPI {
 	return fakeworkloadapi.New(s.T(), fakeworkloadapi.FetchX509SVIDErrorOnce(err))
 }
@@ -2,6 +2,7 @@ package run
 
 import (
 	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"flag"
@@ -12,9 +13,12 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/hashicorp/hcl"
@@ -23,14 +27,24 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
 	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/endpoints"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/nodelabels"
+	"github.com/spiffe/spire/pkg/agent/svidfile"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	common_pb "github.com/spiffe/spire/proto/spire/common"
 )
 
 const (
@@ -39,10 +53,16 @@ const (
 	defaultConfigPath = "conf/agent/agent.conf"
 
 	// TODO: Make my defaults sane
-	defaultDataDir           = "."
-	defaultLogLevel          = "INFO"
-	defaultDefaultSVIDName   = "default"
-	defaultDefaultBundleName = "ROOTCA"
+	defaultDataDir               = "."
+	defaultLogLevel              = "INFO"
+	defaultDefaultSVIDName       = "default"
+	defaultDefaultBundleName     = "ROOTCA"
+	defaultDefaultAllBundlesName = "ALL"
+
+	defaultWorkloadAPITCPBindAddress = "127.0.0.1"
+
+	bundleFormatPEM    = "pem"
+	bundleFormatSPIFFE = "spiffe"
 )
 
 // Config contains all available configurables, arranged by section
@@ -55,21 +75,36 @@ type Config struct {
 }
 
 type agentConfig struct {
-	DataDir             string    `hcl:"data_dir"`
-	AdminSocketPath     string    `hcl:"admin_socket_path"`
-	DeprecatedEnableSDS *bool     `hcl:"enable_sds"`
-	InsecureBootstrap   bool      `hcl:"insecure_bootstrap"`
-	JoinToken           string    `hcl:"join_token"`
-	LogFile             string    `hcl:"log_file"`
-	LogFormat           string    `hcl:"log_format"`
-	LogLevel            string    `hcl:"log_level"`
-	SDS                 sdsConfig `hcl:"sds"`
-	ServerAddress       string    `hcl:"server_address"`
-	ServerPort          int       `hcl:"server_port"`
-	SocketPath          string    `hcl:"socket_path"`
-	TrustBundlePath     string    `hcl:"trust_bundle_path"`
-	TrustBundleURL      string    `hcl:"trust_bundle_url"`
-	TrustDomain         string    `hcl:"trust_domain"`
+	DataDir                   string                 `hcl:"data_dir"`
+	AdditionalSocketPaths     []string               `hcl:"additional_socket_paths"`
+	AdminSocketPath           string                 `hcl:"admin_socket_path"`
+	AgentSVIDRotation         *rotationutil.Config   `hcl:"agent_svid_rotation"`
+	DeprecatedEnableSDS       *bool                  `hcl:"enable_sds"`
+	GRPCHealthEnabled         bool                   `hcl:"grpc_health_enabled"`
+	GRPCReflectionEnabled     bool                   `hcl:"grpc_reflection_enabled"`
+	InsecureBootstrap         bool                   `hcl:"insecure_bootstrap"`
+	JoinToken                 string                 `hcl:"join_token"`
+	LogFile                   string                 `hcl:"log_file"`
+	LogFormat                 string                 `hcl:"log_format"`
+	LogLevel                  string                 `hcl:"log_level"`
+	LogSubsystemLevels        map[string]string      `hcl:"log_subsystem_levels"`
+	NodeLabels                *nodeLabelsConfig      `hcl:"node_labels"`
+	RateLimit                 rateLimitConfig        `hcl:"ratelimit"`
+	RequiredWorkloadAttestors []string               `hcl:"required_workload_attestors"`
+	SDS                       sdsConfig              `hcl:"sds"`
+	ServerAddress             string                 `hcl:"server_address"`
+	ServerGRPCTuning          *grpcutil.ClientConfig `hcl:"server_grpc_tuning"`
+	ServerPort                int                    `hcl:"server_port"`
+	ServerSync                *serverSyncConfig      `hcl:"server_sync"`
+	SocketPath                string                 `hcl:"socket_path"`
+	SVIDFileSinks             []svidFileSinkConfig   `hcl:"svid_file_sink"`
+	TrustBundleFormat         string                 `hcl:"trust_bundle_format"`
+	TrustBundlePath           string                 `hcl:"trust_bundle_path"`
+	TrustBundleURL            string                 `hcl:"trust_bundle_url"`
+	TrustDomain               string                 `hcl:"trust_domain"`
+	WorkloadAPIHTTP           *httpGatewayConfig     `hcl:"workload_api_http"`
+	WorkloadAPITCP            *workloadAPITCPConfig  `hcl:"workload_api_tcp"`
+	WorkloadSVIDRotation      *rotationutil.Config   `hcl:"workload_svid_rotation"`
 
 	ConfigPath string
 	ExpandEnv  bool
@@ -84,13 +119,86 @@ type agentConfig struct {
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
+type serverSyncConfig struct {
+	Interval    string  `hcl:"interval"`
+	MaxInterval string  `hcl:"max_interval"`
+	Multiplier  float64 `hcl:"multiplier"`
+	Jitter      float64 `hcl:"jitter"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type nodeLabelsConfig struct {
+	Labels        map[string]string    `hcl:"labels"`
+	CloudMetadata *cloudMetadataConfig `hcl:"cloud_metadata"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type cloudMetadataConfig struct {
+	Provider        string   `hcl:"provider"`
+	Tags            []string `hcl:"tags"`
+	RefreshInterval string   `hcl:"refresh_interval"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
 type sdsConfig struct {
-	DefaultSVIDName   string `hcl:"default_svid_name"`
-	DefaultBundleName string `hcl:"default_bundle_name"`
+	DefaultSVIDName       string `hcl:"default_svid_name"`
+	DefaultBundleName     string `hcl:"default_bundle_name"`
+	DefaultAllBundlesName string `hcl:"default_all_bundles_name"`
+}
+
+type workloadAPITCPConfig struct {
+	BindAddress  string `hcl:"bind_address"`
+	BindPort     int    `hcl:"bind_port"`
+	CertFile     string `hcl:"cert_file"`
+	KeyFile      string `hcl:"key_file"`
+	ClientCAFile string `hcl:"client_ca_file"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type httpGatewayConfig struct {
+	SocketPath string `hcl:"socket_path"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type rateLimitConfig struct {
+	WorkloadAPI  int `hcl:"workload_api"`
+	FetchJWTSVID int `hcl:"fetch_jwt_svid"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type svidFileSinkConfig struct {
+	Selectors               []string `hcl:"selectors"`
+	Format                  string   `hcl:"format"`
+	SVIDPath                string   `hcl:"svid_path"`
+	KeyPath                 string   `hcl:"key_path"`
+	BundlePath              string   `hcl:"bundle_path"`
+	KeystorePath            string   `hcl:"keystore_path"`
+	Password                string   `hcl:"password"`
+	IncludeFederatedBundles bool     `hcl:"include_federated_bundles"`
+	FileMode                string   `hcl:"file_mode"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
 type experimentalConfig struct {
-	SyncInterval string `hcl:"sync_interval"`
+	SyncInterval         string                  `hcl:"sync_interval"`
+	X509SVIDCacheMaxSize int                     `hcl:"x509_svid_cache_max_size"`
+	PersistSVIDCache     bool                    `hcl:"persist_svid_cache"`
+	JWTSVIDPrefetch      []jwtSVIDPrefetchConfig `hcl:"jwt_svid_prefetch"`
+	FeatureFlags         []string                `hcl:"feature_flags"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type jwtSVIDPrefetchConfig struct {
+	SpiffeID string   `hcl:"spiffe_id"`
+	Audience []string `hcl:"audience"`
 
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
@@ -127,6 +235,16 @@ func Help(name string, writer io.Writer) string {
 }
 
 func LoadConfig(name string, args []string, logOptions []log.Option, output io.Writer, allowUnknownConfig bool) (*agent.Config, error) {
+	input, err := loadInput(name, args, output)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAgentConfig(input, logOptions, allowUnknownConfig)
+}
+
+// loadInput loads the configuration file and merges it with the CLI flags.
+func loadInput(name string, args []string, output io.Writer) (*Config, error) {
 	// First parse the CLI flags so we can get the config
 	// file path, if set
 	cliInput, err := parseFlags(name, args, output)
@@ -141,31 +259,40 @@ func LoadConfig(name string, args []string, logOptions []log.Option, output io.W
 		return nil, err
 	}
 
-	input, err := mergeInput(fileInput, cliInput)
-	if err != nil {
-		return nil, err
-	}
-
-	return NewAgentConfig(input, logOptions, allowUnknownConfig)
+	return mergeInput(fileInput, cliInput)
 }
 
 func (cmd *Command) Run(args []string) int {
-	c, err := LoadConfig(commandName, args, cmd.logOptions, cmd.env.Stderr, cmd.allowUnknownConfig)
+	input, err := loadInput(commandName, args, cmd.env.Stderr)
 	if err != nil {
 		_, _ = fmt.Fprintln(cmd.env.Stderr, err)
 		return 1
 	}
 
-	// Create uds dir and parents if not exists
-	dir := filepath.Dir(c.BindAddress.String())
-	if _, statErr := os.Stat(dir); os.IsNotExist(statErr) {
-		c.Log.WithField("dir", dir).Infof("Creating spire agent UDS directory")
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			fmt.Fprintln(cmd.env.Stderr, err)
-			return 1
+	c, err := NewAgentConfig(input, cmd.logOptions, cmd.allowUnknownConfig)
+	if err != nil {
+		_, _ = fmt.Fprintln(cmd.env.Stderr, err)
+		return 1
+	}
+
+	// Create uds dirs and parents if not exists
+	for _, addr := range append([]*net.UnixAddr{c.BindAddress}, c.AdditionalBindAddresses...) {
+		dir := filepath.Dir(addr.String())
+		if _, statErr := os.Stat(dir); os.IsNotExist(statErr) {
+			c.Log.WithField("dir", dir).Infof("Creating spire agent UDS directory")
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				fmt.Fprintln(cmd.env.Stderr, err)
+				return 1
+			}
 		}
 	}
 
+	if err := fflag.Load(c.FeatureFlags, fflag.ScopeAgent); err != nil {
+		fmt.Fprintln(cmd.env.Stderr, err)
+		return 1
+	}
+	defer fflag.Unload()
+
 	// Set umask before starting up the agent
 	common_cli.SetUmask(c.Log)
 
@@ -186,6 +313,7 @@ func (cmd *Command) Run(args []string) int {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	util.SignalListener(ctx, cancel)
+	go cmd.reloadOnSignal(ctx, args, input, c, a)
 
 	err = a.Run(ctx)
 	if err != nil {
@@ -197,6 +325,105 @@ func (cmd *Command) Run(args []string) int {
 	return 0
 }
 
+// reloadableSettings are the settings that are applied without restarting
+// the agent when the configuration is reloaded.
+var reloadableSettings = map[string]bool{
+	"agent.log_level":            true,
+	"agent.log_subsystem_levels": true,
+	"telemetry":                  true,
+}
+
+// reloadable is the part of the agent that can be reconfigured while it
+// runs.
+type reloadable interface {
+	SetTelemetry(telemetry.FileConfig) error
+}
+
+// reloadOnSignal reloads the configuration every time the process receives
+// a SIGHUP. The settings that are safe to change are applied without a
+// restart, and changes to any other setting are reported as requiring one.
+func (cmd *Command) reloadOnSignal(ctx context.Context, args []string, input *Config, c *agent.Config, a reloadable) {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGHUP)
+	defer signal.Stop(signalCh)
+
+	running := input
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signalCh:
+		}
+
+		reloaded, err := loadInput(commandName, args, cmd.env.Stderr)
+		if err == nil {
+			err = validateConfig(reloaded)
+		}
+		if err != nil {
+			c.Log.WithError(err).Error("Failed to reload configuration; keeping current configuration")
+			continue
+		}
+		running = reloadConfig(input, running, reloaded, c, a)
+	}
+}
+
+// reloadConfig applies the reloadable settings that changed since the
+// running configuration, and reports the other settings that changed since
+// the agent started. It returns the new running configuration.
+func reloadConfig(initial, running, reloaded *Config, c *agent.Config, a reloadable) *Config {
+	var applied, failed []string
+	apply := func(setting string, fn func() error) {
+		if err := fn(); err != nil {
+			c.Log.WithError(err).WithField(telemetry.Setting, setting).Error("Failed to apply reloaded setting")
+			failed = append(failed, setting)
+			return
+		}
+		applied = append(applied, setting)
+	}
+
+	levelsApplied := false
+	for _, setting := range common_cli.ChangedConfigKeys(running, reloaded, "agent") {
+		switch setting {
+		case "agent.log_level", "agent.log_subsystem_levels":
+			if levelsApplied || c.LogLevels == nil {
+				continue
+			}
+			levelsApplied = true
+			apply("agent.log_level", func() error {
+				levels, err := log.ParseLevels(reloaded.Agent.LogLevel, reloaded.Agent.LogSubsystemLevels)
+				if err != nil {
+					return err
+				}
+				c.LogLevels.SetLevels(levels)
+				return nil
+			})
+		case "telemetry":
+			apply(setting, func() error {
+				return a.SetTelemetry(reloaded.Telemetry)
+			})
+		}
+	}
+
+	var restartRequired []string
+	for _, setting := range common_cli.ChangedConfigKeys(initial, reloaded, "agent") {
+		if !reloadableSettings[setting] {
+			restartRequired = append(restartRequired, setting)
+		}
+	}
+
+	c.Log.WithFields(logrus.Fields{
+		telemetry.AppliedSettings:         applied,
+		telemetry.RestartRequiredSettings: restartRequired,
+	}).Info("Configuration reloaded")
+
+	if len(failed) > 0 {
+		// Keep the running configuration so the failed settings are applied
+		// again on the next reload.
+		return running
+	}
+	return reloaded
+}
+
 func (*Command) Synopsis() string {
 	return "Runs the agent"
 }
@@ -254,6 +481,7 @@ func parseFlags(name string, args []string, output io.Writer) (*agentConfig, err
 	flags.StringVar(&c.TrustDomain, "trustDomain", "", "The trust domain that this agent belongs to")
 	flags.StringVar(&c.TrustBundlePath, "trustBundle", "", "Path to the SPIRE server CA bundle")
 	flags.StringVar(&c.TrustBundleURL, "trustBundleUrl", "", "URL to download the SPIRE server CA bundle")
+	flags.StringVar(&c.TrustBundleFormat, "trustBundleFormat", "", fmt.Sprintf("Format of the bootstrap trust bundle, %q or %q", bundleFormatPEM, bundleFormatSPIFFE))
 	flags.BoolVar(&c.InsecureBootstrap, "insecureBootstrap", false, "If true, the agent bootstraps without verifying the server's identity")
 	flags.BoolVar(&c.ExpandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
 
@@ -287,7 +515,7 @@ func mergeInput(fileInput *Config, cliInput *agentConfig) (*Config, error) {
 	return c, nil
 }
 
-func downloadTrustBundle(trustBundleURL string) ([]*x509.Certificate, error) {
+func downloadTrustBundle(trustBundleURL, bundleFormat, trustDomain string) ([]*x509.Certificate, error) {
 	// Download the trust bundle URL from the user specified URL
 	// We use gosec -- the annotation below will disable a security check that URLs are not tainted
 	/* #nosec G107 */
@@ -301,17 +529,12 @@ func downloadTrustBundle(trustBundleURL string) ([]*x509.Certificate, error) {
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("error downloading trust bundle: %s", resp.Status)
 	}
-	pemBytes, err := ioutil.ReadAll(resp.Body)
+	bundleBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read from trust bundle URL %s: %v", trustBundleURL, err)
 	}
 
-	bundle, err := pemutil.ParseCertificates(pemBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	return bundle, nil
+	return parseTrustBundle(bundleBytes, bundleFormat, trustDomain)
 }
 
 func setupTrustBundle(ac *agent.Config, c *Config) error {
@@ -321,13 +544,13 @@ func setupTrustBundle(ac *agent.Config, c *Config) error {
 
 	switch {
 	case c.Agent.TrustBundleURL != "":
-		bundle, err := downloadTrustBundle(c.Agent.TrustBundleURL)
+		bundle, err := downloadTrustBundle(c.Agent.TrustBundleURL, c.Agent.TrustBundleFormat, c.Agent.TrustDomain)
 		if err != nil {
 			return err
 		}
 		ac.TrustBundle = bundle
 	case c.Agent.TrustBundlePath != "":
-		bundle, err := parseTrustBundle(c.Agent.TrustBundlePath)
+		bundle, err := loadTrustBundle(c.Agent.TrustBundlePath, c.Agent.TrustBundleFormat, c.Agent.TrustDomain)
 		if err != nil {
 			return fmt.Errorf("could not parse trust bundle: %v", err)
 		}
@@ -344,6 +567,11 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 		return nil, err
 	}
 
+	if err := fflag.Validate(c.Agent.Experimental.FeatureFlags, fflag.ScopeAgent); err != nil {
+		return nil, err
+	}
+	ac.FeatureFlags = c.Agent.Experimental.FeatureFlags
+
 	if c.Agent.Experimental.SyncInterval != "" {
 		var err error
 		ac.SyncInterval, err = time.ParseDuration(c.Agent.Experimental.SyncInterval)
@@ -352,6 +580,57 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 		}
 	}
 
+	if ss := c.Agent.ServerSync; ss != nil {
+		if err := parseServerSyncConfig(ss, ac); err != nil {
+			return nil, fmt.Errorf("invalid server_sync: %v", err)
+		}
+	}
+
+	agentSVIDRotation, err := c.Agent.AgentSVIDRotation.Threshold()
+	if err != nil {
+		return nil, fmt.Errorf("invalid agent_svid_rotation: %v", err)
+	}
+	ac.AgentSVIDRotation = agentSVIDRotation
+
+	workloadSVIDRotation, err := c.Agent.WorkloadSVIDRotation.Threshold()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workload_svid_rotation: %v", err)
+	}
+	ac.WorkloadSVIDRotation = workloadSVIDRotation
+
+	serverGRPCTuning, err := c.Agent.ServerGRPCTuning.Tuning()
+	if err != nil {
+		return nil, fmt.Errorf("invalid server_grpc_tuning: %v", err)
+	}
+	ac.ServerGRPCTuning = serverGRPCTuning
+
+	if c.Agent.Experimental.X509SVIDCacheMaxSize < 0 {
+		return nil, errors.New("x509_svid_cache_max_size should not be negative")
+	}
+	ac.X509SVIDCacheMaxSize = c.Agent.Experimental.X509SVIDCacheMaxSize
+	ac.PersistSVIDCache = c.Agent.Experimental.PersistSVIDCache
+
+	for _, prefetch := range c.Agent.Experimental.JWTSVIDPrefetch {
+		if _, err := idutil.ParseSpiffeID(prefetch.SpiffeID, idutil.AllowAnyTrustDomainWorkload()); err != nil {
+			return nil, fmt.Errorf("invalid jwt_svid_prefetch spiffe_id %q: %v", prefetch.SpiffeID, err)
+		}
+		if len(prefetch.Audience) == 0 {
+			return nil, fmt.Errorf("jwt_svid_prefetch for %q must have at least one audience", prefetch.SpiffeID)
+		}
+		ac.JWTSVIDPrefetch = append(ac.JWTSVIDPrefetch, manager.JWTSVIDPrefetch{
+			SpiffeID: prefetch.SpiffeID,
+			Audience: prefetch.Audience,
+		})
+	}
+
+	for _, sinkConfig := range c.Agent.SVIDFileSinks {
+		sink, err := newSVIDFileSinkConfig(sinkConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid svid_file_sink: %v", err)
+		}
+		ac.SVIDFileSinks = append(ac.SVIDFileSinks, sink)
+	}
+
 	serverHostPort := net.JoinHostPort(c.Agent.ServerAddress, strconv.Itoa(c.Agent.ServerPort))
 	ac.ServerAddress = fmt.Sprintf("dns:///%s", serverHostPort)
 
@@ -367,17 +646,21 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 	}
 
 	if c.Agent.AdminSocketPath != "" {
-		socketPathAbs, err := filepath.Abs(c.Agent.SocketPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get absolute path for socket_path: %v", err)
-		}
-		adminSocketPathAbs, err := filepath.Abs(c.Agent.AdminSocketPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get absolute path for admin_socket_path: %v", err)
-		}
+		// Abstract sockets do not live in a directory the admin socket could
+		// share, so the check only applies to socket files
+		if !isAbstractSocketPath(c.Agent.SocketPath) {
+			socketPathAbs, err := filepath.Abs(c.Agent.SocketPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get absolute path for socket_path: %v", err)
+			}
+			adminSocketPathAbs, err := filepath.Abs(c.Agent.AdminSocketPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get absolute path for admin_socket_path: %v", err)
+			}
 
-		if strings.HasPrefix(adminSocketPathAbs, filepath.Dir(socketPathAbs)+"/") {
-			return nil, errors.New("admin socket cannot be in the same directory or a subdirectory as that containing the Workload API socket")
+			if strings.HasPrefix(adminSocketPathAbs, filepath.Dir(socketPathAbs)+"/") {
+				return nil, errors.New("admin socket cannot be in the same directory or a subdirectory as that containing the Workload API socket")
+			}
 		}
 
 		ac.AdminBindAddress = &net.UnixAddr{
@@ -385,13 +668,46 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 			Net:  "unix",
 		}
 	}
+	for _, socketPath := range c.Agent.AdditionalSocketPaths {
+		ac.AdditionalBindAddresses = append(ac.AdditionalBindAddresses, &net.UnixAddr{
+			Name: socketPath,
+			Net:  "unix",
+		})
+	}
+
+	if c.Agent.WorkloadAPITCP != nil {
+		ac.TCPBindAddress, ac.TCPTLSConfig, err = newWorkloadAPITCPConfig(c.Agent.WorkloadAPITCP)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Agent.WorkloadAPIHTTP != nil {
+		ac.HTTPBindAddress, err = newHTTPGatewayAddr(c.Agent.WorkloadAPIHTTP)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Agent.RateLimit.WorkloadAPI < 0 || c.Agent.RateLimit.FetchJWTSVID < 0 {
+		return nil, errors.New("ratelimit values cannot be negative")
+	}
+	ac.WorkloadAPIRateLimit = endpoints.RateLimitConfig{
+		WorkloadAPI:  c.Agent.RateLimit.WorkloadAPI,
+		FetchJWTSVID: c.Agent.RateLimit.FetchJWTSVID,
+	}
+	ac.GRPCHealth = c.Agent.GRPCHealthEnabled
+	ac.GRPCReflection = c.Agent.GRPCReflectionEnabled
+
 	ac.JoinToken = c.Agent.JoinToken
 	ac.DataDir = c.Agent.DataDir
 	ac.DefaultSVIDName = c.Agent.SDS.DefaultSVIDName
 	ac.DefaultBundleName = c.Agent.SDS.DefaultBundleName
+	ac.DefaultAllBundlesName = c.Agent.SDS.DefaultAllBundlesName
 
 	logOptions = append(logOptions,
 		log.WithLevel(c.Agent.LogLevel),
+		log.WithSubsystemLevels(c.Agent.LogSubsystemLevels),
 		log.WithFormat(c.Agent.LogFormat),
 		log.WithOutputFile(c.Agent.LogFile))
 
@@ -400,6 +716,7 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 		return nil, fmt.Errorf("could not start logger: %s", err)
 	}
 	ac.Log = logger
+	ac.LogLevels = logger
 
 	err = setupTrustBundle(ac, c)
 	if err != nil {
@@ -415,6 +732,22 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 	ac.Telemetry = c.Telemetry
 	ac.HealthChecks = c.HealthChecks
 
+	workloadAttestors := ac.PluginConfigs["WorkloadAttestor"]
+	for _, name := range c.Agent.RequiredWorkloadAttestors {
+		if pluginConfig, ok := workloadAttestors[name]; !ok || !pluginConfig.IsEnabled() {
+			return nil, fmt.Errorf("required workload attestor %q is not configured", name)
+		}
+	}
+	ac.RequiredWorkloadAttestors = c.Agent.RequiredWorkloadAttestors
+
+	if nl := c.Agent.NodeLabels; nl != nil {
+		nodeLabels, err := newNodeLabelsConfig(nl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node_labels: %v", err)
+		}
+		ac.NodeLabels = nodeLabels
+	}
+
 	// TODO: remove deprecated configurable in 0.12.0
 	if c.Agent.DeprecatedEnableSDS != nil {
 		ac.Log.Warn("SDS support is now always on. The enable_sds configurable is ignored and should be removed")
@@ -468,13 +801,231 @@ func validateConfig(c *Config) error {
 			return errors.New("trust bundle URL must start with https://")
 		}
 	}
+
+	switch c.Agent.TrustBundleFormat {
+	case bundleFormatPEM, bundleFormatSPIFFE:
+	default:
+		return fmt.Errorf("trust_bundle_format must be %q or %q", bundleFormatPEM, bundleFormatSPIFFE)
+	}
+
 	if c.Plugins == nil {
 		return errors.New("plugins section must be configured")
 	}
 
+	if err := validateAbstractSocket("socket_path", c.Agent.SocketPath); err != nil {
+		return err
+	}
+
+	for _, socketPath := range c.Agent.AdditionalSocketPaths {
+		if socketPath == "" {
+			return errors.New("additional_socket_paths cannot contain empty paths")
+		}
+		if socketPath == c.Agent.SocketPath {
+			return fmt.Errorf("additional socket path %q is already used by socket_path", socketPath)
+		}
+		if err := validateAbstractSocket("additional_socket_paths", socketPath); err != nil {
+			return err
+		}
+	}
+
+	// The admin API relies on the permissions of the socket file to restrict
+	// its callers, which abstract sockets do not have
+	if isAbstractSocketPath(c.Agent.AdminSocketPath) {
+		return errors.New("admin_socket_path cannot be an abstract socket")
+	}
+
+	if h := c.Agent.WorkloadAPIHTTP; h != nil && h.SocketPath != "" {
+		if err := validateAbstractSocket("workload_api_http socket_path", h.SocketPath); err != nil {
+			return err
+		}
+		for _, socketPath := range append([]string{c.Agent.SocketPath}, c.Agent.AdditionalSocketPaths...) {
+			if h.SocketPath == socketPath {
+				return fmt.Errorf("workload_api_http socket path %q is already used by the Workload API", socketPath)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isAbstractSocketPath(socketPath string) bool {
+	return util.IsAbstractUnixSocket(&net.UnixAddr{Name: socketPath, Net: "unix"})
+}
+
+// validateAbstractSocket checks that an abstract socket, configured by
+// prefixing its name with "@", is named and only used on Linux, the only
+// platform providing the abstract namespace.
+func validateAbstractSocket(key, socketPath string) error {
+	if !isAbstractSocketPath(socketPath) {
+		return nil
+	}
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("%s %q is an abstract socket, which is only supported on Linux", key, socketPath)
+	}
+	if socketPath == "@" {
+		return fmt.Errorf("%s abstract socket name cannot be empty", key)
+	}
+	return nil
+}
+
+// newWorkloadAPITCPConfig returns the address and TLS configuration used to
+// serve the Workload API over TCP. Callers are attested by looking up the
+// process on the other end of the connection, so only loopback addresses are
+// allowed. Clients must present a certificate signed by the configured CA.
+func newWorkloadAPITCPConfig(c *workloadAPITCPConfig) (*net.TCPAddr, *tls.Config, error) {
+	bindAddress := c.BindAddress
+	if bindAddress == "" {
+		bindAddress = defaultWorkloadAPITCPBindAddress
+	}
+	ip := net.ParseIP(bindAddress)
+	if ip == nil || !ip.IsLoopback() {
+		return nil, nil, fmt.Errorf("workload_api_tcp bind_address %q must be a loopback IP address", bindAddress)
+	}
+	if c.BindPort <= 0 {
+		return nil, nil, errors.New("workload_api_tcp bind_port must be configured")
+	}
+
+	if c.CertFile == "" || c.KeyFile == "" || c.ClientCAFile == "" {
+		return nil, nil, errors.New("workload_api_tcp requires cert_file, key_file and client_ca_file to be configured")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load workload_api_tcp certificate: %v", err)
+	}
+	clientCAs, err := pemutil.LoadCertificates(c.ClientCAFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load workload_api_tcp client CA: %v", err)
+	}
+
+	addr := &net.TCPAddr{
+		IP:   ip,
+		Port: c.BindPort,
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    util.NewCertPool(clientCAs...),
+		MinVersion:   tls.VersionTLS12,
+	}
+	return addr, tlsConfig, nil
+}
+
+// newHTTPGatewayAddr returns the address the Workload API HTTP gateway is
+// served on. Callers are attested using the process the kernel reports on the
+// other end of the connection. The gateway has no transport security of its
+// own to fall back on, so it is only served on UNIX domain sockets, where the
+// kernel reports the peer reliably.
+func newHTTPGatewayAddr(c *httpGatewayConfig) (*net.UnixAddr, error) {
+	if c.SocketPath == "" {
+		return nil, errors.New("workload_api_http socket_path must be configured")
+	}
+	return &net.UnixAddr{
+		Name: c.SocketPath,
+		Net:  "unix",
+	}, nil
+}
+
+func newSVIDFileSinkConfig(c svidFileSinkConfig) (svidfile.Config, error) {
+	config := svidfile.Config{
+		Format:                  svidfile.FormatPEM,
+		SVIDPath:                c.SVIDPath,
+		KeyPath:                 c.KeyPath,
+		BundlePath:              c.BundlePath,
+		KeystorePath:            c.KeystorePath,
+		Password:                c.Password,
+		IncludeFederatedBundles: c.IncludeFederatedBundles,
+	}
+	if c.Format != "" {
+		config.Format = svidfile.Format(c.Format)
+	}
+
+	for _, s := range c.Selectors {
+		parts := strings.SplitN(s, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return svidfile.Config{}, fmt.Errorf("selector %q must be formatted as type:value", s)
+		}
+		config.Selectors = append(config.Selectors, &common_pb.Selector{
+			Type:  parts[0],
+			Value: parts[1],
+		})
+	}
+
+	if c.FileMode != "" {
+		mode, err := strconv.ParseUint(c.FileMode, 8, 32)
+		if err != nil || mode > 0777 {
+			return svidfile.Config{}, fmt.Errorf("file_mode %q must be an octal permission mode", c.FileMode)
+		}
+		config.FileMode = os.FileMode(mode)
+	}
+
+	if err := config.Validate(); err != nil {
+		return svidfile.Config{}, err
+	}
+	return config, nil
+}
+
+func parseServerSyncConfig(c *serverSyncConfig, ac *agent.Config) error {
+	if c.Interval != "" {
+		if ac.SyncInterval != 0 {
+			return errors.New("interval cannot be used with the experimental sync_interval")
+		}
+		interval, err := time.ParseDuration(c.Interval)
+		if err != nil {
+			return fmt.Errorf("could not parse interval %q: %v", c.Interval, err)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("interval must be positive: got %v", interval)
+		}
+		ac.SyncInterval = interval
+	}
+
+	policy := backoff.Policy{
+		Multiplier: c.Multiplier,
+		Jitter:     c.Jitter,
+	}
+	if c.MaxInterval != "" {
+		maxInterval, err := time.ParseDuration(c.MaxInterval)
+		if err != nil {
+			return fmt.Errorf("could not parse max_interval %q: %v", c.MaxInterval, err)
+		}
+		policy.MaxInterval = maxInterval
+	}
+
+	interval := ac.SyncInterval
+	if interval == 0 {
+		interval = manager.DefaultSyncInterval
+	}
+	if err := policy.Validate(interval); err != nil {
+		return err
+	}
+	ac.SyncBackoff = policy
 	return nil
 }
 
+func newNodeLabelsConfig(c *nodeLabelsConfig) (*nodelabels.Config, error) {
+	config := &nodelabels.Config{
+		Labels: c.Labels,
+	}
+	if cm := c.CloudMetadata; cm != nil {
+		config.CloudProvider = cm.Provider
+		config.CloudTags = cm.Tags
+		if cm.RefreshInterval != "" {
+			refreshInterval, err := time.ParseDuration(cm.RefreshInterval)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse refresh_interval %q: %v", cm.RefreshInterval, err)
+			}
+			config.RefreshInterval = refreshInterval
+		}
+		if config.CloudProvider == "" {
+			return nil, errors.New("cloud_metadata requires a provider")
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 	detectedUnknown := func(section string, keys []string) {
 		l.WithFields(logrus.Fields{
@@ -492,6 +1043,56 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 		detectedUnknown("agent", a.UnusedKeys)
 	}
 
+	if a := c.Agent; a != nil && len(a.RateLimit.UnusedKeys) != 0 {
+		detectedUnknown("ratelimit", a.RateLimit.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.AgentSVIDRotation != nil && len(a.AgentSVIDRotation.UnusedKeys) != 0 {
+		detectedUnknown("agent_svid_rotation", a.AgentSVIDRotation.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.WorkloadSVIDRotation != nil && len(a.WorkloadSVIDRotation.UnusedKeys) != 0 {
+		detectedUnknown("workload_svid_rotation", a.WorkloadSVIDRotation.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.ServerSync != nil && len(a.ServerSync.UnusedKeys) != 0 {
+		detectedUnknown("server_sync", a.ServerSync.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.ServerGRPCTuning != nil && len(a.ServerGRPCTuning.UnusedKeys) != 0 {
+		detectedUnknown("server_grpc_tuning", a.ServerGRPCTuning.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.NodeLabels != nil {
+		if len(a.NodeLabels.UnusedKeys) != 0 {
+			detectedUnknown("node_labels", a.NodeLabels.UnusedKeys)
+		}
+		if cm := a.NodeLabels.CloudMetadata; cm != nil && len(cm.UnusedKeys) != 0 {
+			detectedUnknown("node_labels cloud_metadata", cm.UnusedKeys)
+		}
+	}
+
+	if a := c.Agent; a != nil && a.WorkloadAPIHTTP != nil && len(a.WorkloadAPIHTTP.UnusedKeys) != 0 {
+		detectedUnknown("workload_api_http", a.WorkloadAPIHTTP.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.WorkloadAPITCP != nil && len(a.WorkloadAPITCP.UnusedKeys) != 0 {
+		detectedUnknown("workload_api_tcp", a.WorkloadAPITCP.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil {
+		for _, sink := range a.SVIDFileSinks {
+			if len(sink.UnusedKeys) != 0 {
+				detectedUnknown("svid_file_sink", sink.UnusedKeys)
+			}
+		}
+		for _, prefetch := range a.Experimental.JWTSVIDPrefetch {
+			if len(prefetch.UnusedKeys) != 0 {
+				detectedUnknown("jwt_svid_prefetch", prefetch.UnusedKeys)
+			}
+		}
+	}
+
 	// TODO: Re-enable unused key detection for telemetry. See
 	// https://github.com/spiffe/spire/issues/1101 for more information
 	//
@@ -525,6 +1126,10 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 		detectedUnknown("InMem", p.UnusedKeys)
 	}
 
+	if p := c.Telemetry.OTLP; p != nil && len(p.UnusedKeys) != 0 {
+		detectedUnknown("OTLP", p.UnusedKeys)
+	}
+
 	if len(c.HealthChecks.UnusedKeys) != 0 {
 		detectedUnknown("health check", c.HealthChecks.UnusedKeys)
 	}
@@ -535,24 +1140,51 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 func defaultConfig() *Config {
 	return &Config{
 		Agent: &agentConfig{
-			DataDir:    defaultDataDir,
-			LogLevel:   defaultLogLevel,
-			LogFormat:  log.DefaultFormat,
-			SocketPath: common.DefaultSocketPath,
+			DataDir:           defaultDataDir,
+			LogLevel:          defaultLogLevel,
+			LogFormat:         log.DefaultFormat,
+			SocketPath:        common.DefaultSocketPath,
+			TrustBundleFormat: bundleFormatPEM,
 			SDS: sdsConfig{
-				DefaultBundleName: defaultDefaultBundleName,
-				DefaultSVIDName:   defaultDefaultSVIDName,
+				DefaultBundleName:     defaultDefaultBundleName,
+				DefaultSVIDName:       defaultDefaultSVIDName,
+				DefaultAllBundlesName: defaultDefaultAllBundlesName,
 			},
 		},
 	}
 }
 
-func parseTrustBundle(path string) ([]*x509.Certificate, error) {
-	bundle, err := pemutil.LoadCertificates(path)
+func loadTrustBundle(path, bundleFormat, trustDomain string) ([]*x509.Certificate, error) {
+	bundleBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
+	return parseTrustBundle(bundleBytes, bundleFormat, trustDomain)
+}
+
+// parseTrustBundle parses the X.509 authorities of a bootstrap trust bundle
+// in the given format. The "spiffe" format is the SPIFFE bundle document
+// served by the bundle endpoint of the SPIRE server.
+func parseTrustBundle(bundleBytes []byte, bundleFormat, trustDomain string) ([]*x509.Certificate, error) {
+	var bundle []*x509.Certificate
+	switch bundleFormat {
+	case bundleFormatPEM:
+		var err error
+		bundle, err = pemutil.ParseCertificates(bundleBytes)
+		if err != nil {
+			return nil, err
+		}
+	case bundleFormatSPIFFE:
+		spiffeBundle, err := bundleutil.Unmarshal(idutil.TrustDomainID(trustDomain), bundleBytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse SPIFFE bundle: %v", err)
+		}
+		bundle = spiffeBundle.RootCAs()
+	default:
+		return nil, fmt.Errorf("unsupported trust bundle format %q", bundleFormat)
+	}
+
 	if len(bundle) == 0 {
 		return nil, errors.New("no certificates found in trust bundle")
 	}
@@ -2,21 +2,35 @@ package run
 
 import (
 	"bytes"
+	"crypto/tls"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/hcl/hcl/printer"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/endpoints"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/nodelabels"
+	"github.com/spiffe/spire/pkg/agent/svidfile"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
+	common_pb "github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
@@ -25,42 +39,74 @@ import (
 
 func TestDownloadTrustBundle(t *testing.T) {
 	testTB, _ := ioutil.ReadFile(path.Join(util.ProjectRoot(), "conf/agent/dummy_root_ca.crt"))
+	rootCAs, err := pemutil.ParseCertificates(testTB)
+	require.NoError(t, err)
+	testSPIFFEBundle, err := bundleutil.Marshal(bundleutil.BundleFromRootCAs("spiffe://example.org", rootCAs))
+	require.NoError(t, err)
+
 	cases := []struct {
 		msg          string
 		status       int
+		format       string
 		fileContents string
 		expectError  bool
 	}{
 		{
 			msg:          "if URL is not found, should be an error",
 			status:       http.StatusNotFound,
+			format:       bundleFormatPEM,
 			fileContents: "",
 			expectError:  true,
 		},
 		{
 			msg:          "if URL returns error 500, should be an error",
 			status:       http.StatusInternalServerError,
+			format:       bundleFormatPEM,
 			fileContents: "",
 			expectError:  true,
 		},
 		{
 			msg:          "if file is not parseable, should be an error",
 			status:       http.StatusOK,
+			format:       bundleFormatPEM,
 			fileContents: "NON PEM PARSEABLE TEXT HERE",
 			expectError:  true,
 		},
 		{
 			msg:          "if file is empty, should be error",
 			status:       http.StatusOK,
+			format:       bundleFormatPEM,
 			fileContents: "",
 			expectError:  true,
 		},
 		{
 			msg:          "if file is valid, should be error",
 			status:       http.StatusOK,
+			format:       bundleFormatPEM,
 			fileContents: string(testTB),
 			expectError:  false,
 		},
+		{
+			msg:          "if SPIFFE bundle is valid, should not be an error",
+			status:       http.StatusOK,
+			format:       bundleFormatSPIFFE,
+			fileContents: string(testSPIFFEBundle),
+			expectError:  false,
+		},
+		{
+			msg:          "if SPIFFE bundle is not parseable, should be an error",
+			status:       http.StatusOK,
+			format:       bundleFormatSPIFFE,
+			fileContents: string(testTB),
+			expectError:  true,
+		},
+		{
+			msg:          "if SPIFFE bundle has no X.509 authorities, should be an error",
+			status:       http.StatusOK,
+			format:       bundleFormatSPIFFE,
+			fileContents: `{"keys":[]}`,
+			expectError:  true,
+		},
 	}
 
 	for _, testCase := range cases {
@@ -76,11 +122,12 @@ func TestDownloadTrustBundle(t *testing.T) {
 					//}
 				}))
 			defer testServer.Close()
-			_, err := downloadTrustBundle(testServer.URL)
+			bundle, err := downloadTrustBundle(testServer.URL, testCase.format, "example.org")
 			if testCase.expectError {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, rootCAs, bundle)
 			}
 		})
 	}
@@ -96,6 +143,13 @@ func TestParseConfigGood(t *testing.T) {
 	assert.Equal(t, c.Agent.SocketPath, "/tmp/agent.sock")
 	assert.Equal(t, c.Agent.TrustBundlePath, "conf/agent/dummy_root_ca.crt")
 	assert.Equal(t, c.Agent.TrustDomain, "example.org")
+	assert.Equal(t, &nodeLabelsConfig{
+		Labels: map[string]string{"env": "prod"},
+		CloudMetadata: &cloudMetadataConfig{
+			Provider: "aws",
+			Tags:     []string{"tier"},
+		},
+	}, c.Agent.NodeLabels)
 
 	// Check for plugins configurations
 	pluginConfigs := *c.Plugins
@@ -256,6 +310,26 @@ func TestMergeInput(t *testing.T) {
 				require.Equal(t, "foo", c.Agent.SDS.DefaultBundleName)
 			},
 		},
+		{
+			msg:       "default_all_bundles_name should default value of ALL",
+			fileInput: func(c *Config) {},
+			cliInput:  func(c *agentConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "ALL", c.Agent.SDS.DefaultAllBundlesName)
+			},
+		},
+		{
+			msg: "default_all_bundles_name should be configurable by file",
+			fileInput: func(c *Config) {
+				c.Agent.SDS = sdsConfig{
+					DefaultAllBundlesName: "foo",
+				}
+			},
+			cliInput: func(c *agentConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "foo", c.Agent.SDS.DefaultAllBundlesName)
+			},
+		},
 		{
 			msg: "insecure_bootstrap should be configurable by file",
 			fileInput: func(c *Config) {
@@ -699,6 +773,25 @@ func TestNewAgentConfig(t *testing.T) {
 				require.True(t, c.InsecureBootstrap)
 			},
 		},
+		{
+			msg:   "grpc health and reflection services are disabled by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *agent.Config) {
+				require.False(t, c.GRPCHealth)
+				require.False(t, c.GRPCReflection)
+			},
+		},
+		{
+			msg: "grpc health and reflection services can be enabled",
+			input: func(c *Config) {
+				c.Agent.GRPCHealthEnabled = true
+				c.Agent.GRPCReflectionEnabled = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.GRPCHealth)
+				require.True(t, c.GRPCReflection)
+			},
+		},
 		{
 			msg: "join_token should be correctly configured",
 			input: func(c *Config) {
@@ -754,6 +847,31 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Equal(t, &logrus.TextFormatter{}, l.Formatter)
 			},
 		},
+		{
+			msg: "log_subsystem_levels are applied",
+			input: func(c *Config) {
+				c.Agent.LogLevel = "INFO"
+				c.Agent.LogSubsystemLevels = map[string]string{"manager": "DEBUG"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				l := c.Log.(*log.Logger)
+				require.Equal(t, logrus.DebugLevel, l.Level)
+				require.Equal(t, log.Levels{
+					Level:      logrus.InfoLevel,
+					Subsystems: map[string]logrus.Level{"manager": logrus.DebugLevel},
+				}, l.GetLevels())
+			},
+		},
+		{
+			msg:         "invalid log_subsystem_levels returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.LogSubsystemLevels = map[string]string{"manager": "not-a-valid-level"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg:         "trust_bundle_path and trust_bundle_url cannot both be set",
 			expectError: true,
@@ -776,6 +894,25 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg:         "trust_bundle_format defaults to pem",
+			expectError: false,
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Len(t, c.TrustBundle, 1)
+			},
+		},
+		{
+			msg:         "unknown trust_bundle_format returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.TrustBundleFormat = "der"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg:         "invalid log_level returns an error",
 			expectError: true,
@@ -815,6 +952,372 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "server_sync is parsed",
+			input: func(c *Config) {
+				c.Agent.ServerSync = &serverSyncConfig{
+					Interval:    "30s",
+					MaxInterval: "10m",
+					Multiplier:  2,
+					Jitter:      0.5,
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, 30*time.Second, c.SyncInterval)
+				require.Equal(t, backoff.Policy{
+					MaxInterval: 10 * time.Minute,
+					Multiplier:  2,
+					Jitter:      0.5,
+				}, c.SyncBackoff)
+			},
+		},
+		{
+			msg:         "server_sync max_interval less than the default interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerSync = &serverSyncConfig{MaxInterval: "1s"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "server_sync interval with the experimental sync_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.SyncInterval = "10s"
+				c.Agent.ServerSync = &serverSyncConfig{Interval: "10s"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "invalid server_sync jitter returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerSync = &serverSyncConfig{Jitter: 1.5}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "node_labels is not set by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c.NodeLabels)
+			},
+		},
+		{
+			msg: "node_labels is parsed",
+			input: func(c *Config) {
+				c.Agent.NodeLabels = &nodeLabelsConfig{
+					Labels: map[string]string{"env": "prod"},
+					CloudMetadata: &cloudMetadataConfig{
+						Provider:        "gcp",
+						Tags:            []string{"tier"},
+						RefreshInterval: "1m",
+					},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, &nodelabels.Config{
+					Labels:          map[string]string{"env": "prod"},
+					CloudProvider:   "gcp",
+					CloudTags:       []string{"tier"},
+					RefreshInterval: time.Minute,
+				}, c.NodeLabels)
+			},
+		},
+		{
+			msg:         "node_labels with an invalid refresh_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.NodeLabels = &nodeLabelsConfig{
+					CloudMetadata: &cloudMetadataConfig{
+						Provider:        "gcp",
+						Tags:            []string{"tier"},
+						RefreshInterval: "moo",
+					},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "node_labels with an unsupported provider returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.NodeLabels = &nodeLabelsConfig{
+					CloudMetadata: &cloudMetadataConfig{
+						Provider: "moo",
+						Tags:     []string{"tier"},
+					},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "node_labels cloud_metadata without provider returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.NodeLabels = &nodeLabelsConfig{
+					CloudMetadata: &cloudMetadataConfig{
+						Tags: []string{"tier"},
+					},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "agent_svid_rotation and workload_svid_rotation are parsed",
+			input: func(c *Config) {
+				c.Agent.AgentSVIDRotation = &rotationutil.Config{Fraction: 0.3, Jitter: 0.1}
+				c.Agent.WorkloadSVIDRotation = &rotationutil.Config{Before: "10m"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, rotationutil.Threshold{Fraction: 0.3, Jitter: 0.1}, c.AgentSVIDRotation)
+				require.Equal(t, rotationutil.Threshold{Before: 10 * time.Minute}, c.WorkloadSVIDRotation)
+			},
+		},
+		{
+			msg:         "invalid workload_svid_rotation returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadSVIDRotation = &rotationutil.Config{Fraction: 0.9, Jitter: 0.2}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "server_grpc_tuning is parsed",
+			input: func(c *Config) {
+				c.Agent.ServerGRPCTuning = &grpcutil.ClientConfig{
+					KeepaliveTime:                "30s",
+					KeepaliveTimeout:             "10s",
+					PermitKeepaliveWithoutStream: true,
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, grpcutil.ClientTuning{
+					KeepaliveTime:                30 * time.Second,
+					KeepaliveTimeout:             10 * time.Second,
+					PermitKeepaliveWithoutStream: true,
+				}, c.ServerGRPCTuning)
+			},
+		},
+		{
+			msg:         "invalid server_grpc_tuning returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerGRPCTuning = &grpcutil.ClientConfig{KeepaliveTime: "1s"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "persist_svid_cache is set",
+			input: func(c *Config) {
+				c.Agent.Experimental.PersistSVIDCache = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.PersistSVIDCache)
+			},
+		},
+		{
+			msg: "x509_svid_cache_max_size is set",
+			input: func(c *Config) {
+				c.Agent.Experimental.X509SVIDCacheMaxSize = 100
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, 100, c.X509SVIDCacheMaxSize)
+			},
+		},
+		{
+			msg:         "negative x509_svid_cache_max_size returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.X509SVIDCacheMaxSize = -1
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "jwt_svid_prefetch is set",
+			input: func(c *Config) {
+				c.Agent.Experimental.JWTSVIDPrefetch = []jwtSVIDPrefetchConfig{
+					{SpiffeID: "spiffe://example.org/foo", Audience: []string{"aud1", "aud2"}},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, []manager.JWTSVIDPrefetch{
+					{SpiffeID: "spiffe://example.org/foo", Audience: []string{"aud1", "aud2"}},
+				}, c.JWTSVIDPrefetch)
+			},
+		},
+		{
+			msg:         "jwt_svid_prefetch with invalid SPIFFE ID returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.JWTSVIDPrefetch = []jwtSVIDPrefetchConfig{
+					{SpiffeID: "foo", Audience: []string{"aud1"}},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "jwt_svid_prefetch without audience returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.JWTSVIDPrefetch = []jwtSVIDPrefetchConfig{
+					{SpiffeID: "spiffe://example.org/foo"},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ratelimit is not set",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, endpoints.RateLimitConfig{}, c.WorkloadAPIRateLimit)
+			},
+		},
+		{
+			msg: "ratelimit is set",
+			input: func(c *Config) {
+				c.Agent.RateLimit = rateLimitConfig{
+					WorkloadAPI:  100,
+					FetchJWTSVID: 10,
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, endpoints.RateLimitConfig{
+					WorkloadAPI:  100,
+					FetchJWTSVID: 10,
+				}, c.WorkloadAPIRateLimit)
+			},
+		},
+		{
+			msg:         "negative ratelimit returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.RateLimit.FetchJWTSVID = -1
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "svid_file_sink is set",
+			input: func(c *Config) {
+				c.Agent.SVIDFileSinks = []svidFileSinkConfig{
+					{
+						Selectors:  []string{"unix:uid:1000", "unix:gid:1000"},
+						SVIDPath:   "/certs/svid.pem",
+						KeyPath:    "/certs/svid_key.pem",
+						BundlePath: "/certs/bundle.pem",
+					},
+					{
+						Selectors:               []string{"docker:label:app:legacy"},
+						Format:                  "jks",
+						KeystorePath:            "/certs/svid.jks",
+						Password:                "changeit",
+						IncludeFederatedBundles: true,
+						FileMode:                "0640",
+					},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, []svidfile.Config{
+					{
+						Selectors: []*common_pb.Selector{
+							{Type: "unix", Value: "uid:1000"},
+							{Type: "unix", Value: "gid:1000"},
+						},
+						Format:     svidfile.FormatPEM,
+						SVIDPath:   "/certs/svid.pem",
+						KeyPath:    "/certs/svid_key.pem",
+						BundlePath: "/certs/bundle.pem",
+					},
+					{
+						Selectors: []*common_pb.Selector{
+							{Type: "docker", Value: "label:app:legacy"},
+						},
+						Format:                  svidfile.FormatJKS,
+						KeystorePath:            "/certs/svid.jks",
+						Password:                "changeit",
+						IncludeFederatedBundles: true,
+						FileMode:                0640,
+					},
+				}, c.SVIDFileSinks)
+			},
+		},
+		{
+			msg:         "svid_file_sink with malformed selector returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SVIDFileSinks = []svidFileSinkConfig{
+					{
+						Selectors:    []string{"uid"},
+						Format:       "pkcs12",
+						KeystorePath: "/certs/svid.p12",
+						Password:     "changeit",
+					},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "svid_file_sink with invalid file_mode returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SVIDFileSinks = []svidFileSinkConfig{
+					{
+						Selectors:    []string{"unix:uid:1000"},
+						Format:       "pkcs12",
+						KeystorePath: "/certs/svid.p12",
+						Password:     "changeit",
+						FileMode:     "rw-------",
+					},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "svid_file_sink without password returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SVIDFileSinks = []svidFileSinkConfig{
+					{
+						Selectors:    []string{"unix:uid:1000"},
+						Format:       "pkcs12",
+						KeystorePath: "/certs/svid.p12",
+					},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "admin_socket_path should be correctly configured",
 			input: func(c *Config) {
@@ -880,6 +1383,206 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "additional_socket_paths should be correctly configured",
+			input: func(c *Config) {
+				c.Agent.AdditionalSocketPaths = []string{"/tmp/other/agent.sock"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, []*net.UnixAddr{{Name: "/tmp/other/agent.sock", Net: "unix"}}, c.AdditionalBindAddresses)
+			},
+		},
+		{
+			msg:         "additional_socket_paths same as socket_path",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SocketPath = "/tmp/workload.sock"
+				c.Agent.AdditionalSocketPaths = []string{"/tmp/workload.sock"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "socket_path as an abstract socket",
+			expectError: runtime.GOOS != "linux",
+			input: func(c *Config) {
+				c.Agent.SocketPath = "@spire-agent"
+				c.Agent.AdminSocketPath = "/tmp/admin.sock"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				if runtime.GOOS != "linux" {
+					require.Nil(t, c)
+					return
+				}
+				require.Equal(t, &net.UnixAddr{Name: "@spire-agent", Net: "unix"}, c.BindAddress)
+				require.Equal(t, &net.UnixAddr{Name: "/tmp/admin.sock", Net: "unix"}, c.AdminBindAddress)
+			},
+		},
+		{
+			msg:         "additional_socket_paths with an abstract socket",
+			expectError: runtime.GOOS != "linux",
+			input: func(c *Config) {
+				c.Agent.AdditionalSocketPaths = []string{"@spire-agent"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				if runtime.GOOS != "linux" {
+					require.Nil(t, c)
+					return
+				}
+				require.Equal(t, []*net.UnixAddr{{Name: "@spire-agent", Net: "unix"}}, c.AdditionalBindAddresses)
+			},
+		},
+		{
+			msg:         "abstract socket without a name",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SocketPath = "@"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "admin_socket_path as an abstract socket",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.AdminSocketPath = "@spire-agent-admin"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "required_workload_attestors should be correctly configured",
+			input: func(c *Config) {
+				c.Plugins = &catalog.HCLPluginConfigMap{
+					"WorkloadAttestor": {
+						"k8s":  {},
+						"unix": {},
+					},
+				}
+				c.Agent.RequiredWorkloadAttestors = []string{"k8s"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, []string{"k8s"}, c.RequiredWorkloadAttestors)
+			},
+		},
+		{
+			msg:         "required_workload_attestors with an attestor that is not configured",
+			expectError: true,
+			input: func(c *Config) {
+				c.Plugins = &catalog.HCLPluginConfigMap{
+					"WorkloadAttestor": {
+						"unix": {},
+					},
+				}
+				c.Agent.RequiredWorkloadAttestors = []string{"k8s"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "workload_api_tcp should be correctly configured",
+			input: func(c *Config) {
+				c.Agent.WorkloadAPITCP = &workloadAPITCPConfig{
+					BindPort:     8082,
+					CertFile:     path.Join(util.ProjectRoot(), "conf/server/dummy_upstream_ca.crt"),
+					KeyFile:      path.Join(util.ProjectRoot(), "conf/server/dummy_upstream_ca.key"),
+					ClientCAFile: path.Join(util.ProjectRoot(), "conf/agent/dummy_root_ca.crt"),
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, "127.0.0.1:8082", c.TCPBindAddress.String())
+				require.NotNil(t, c.TCPTLSConfig)
+				require.Len(t, c.TCPTLSConfig.Certificates, 1)
+				require.Equal(t, tls.RequireAndVerifyClientCert, c.TCPTLSConfig.ClientAuth)
+			},
+		},
+		{
+			msg: "workload_api_tcp not provided",
+			input: func(c *Config) {
+				c.Agent.WorkloadAPITCP = nil
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c.TCPBindAddress)
+				require.Nil(t, c.TCPTLSConfig)
+			},
+		},
+		{
+			msg:         "workload_api_tcp with non-loopback bind_address",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPITCP = &workloadAPITCPConfig{
+					BindAddress:  "0.0.0.0",
+					BindPort:     8082,
+					CertFile:     path.Join(util.ProjectRoot(), "conf/server/dummy_upstream_ca.crt"),
+					KeyFile:      path.Join(util.ProjectRoot(), "conf/server/dummy_upstream_ca.key"),
+					ClientCAFile: path.Join(util.ProjectRoot(), "conf/agent/dummy_root_ca.crt"),
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "workload_api_tcp without client_ca_file",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPITCP = &workloadAPITCPConfig{
+					BindPort: 8082,
+					CertFile: path.Join(util.ProjectRoot(), "conf/server/dummy_upstream_ca.crt"),
+					KeyFile:  path.Join(util.ProjectRoot(), "conf/server/dummy_upstream_ca.key"),
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "workload_api_http should be correctly configured with a socket",
+			input: func(c *Config) {
+				c.Agent.WorkloadAPIHTTP = &httpGatewayConfig{
+					SocketPath: "/tmp/workload-http.sock",
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, &net.UnixAddr{Name: "/tmp/workload-http.sock", Net: "unix"}, c.HTTPBindAddress)
+			},
+		},
+		{
+			msg: "workload_api_http not provided",
+			input: func(c *Config) {
+				c.Agent.WorkloadAPIHTTP = nil
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c.HTTPBindAddress)
+			},
+		},
+		{
+			msg:         "workload_api_http socket_path same as socket_path",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SocketPath = "/tmp/workload.sock"
+				c.Agent.WorkloadAPIHTTP = &httpGatewayConfig{
+					SocketPath: "/tmp/workload.sock",
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "workload_api_http without socket_path",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPIHTTP = &httpGatewayConfig{}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg:         "admin_socket_path relative folder",
 			expectError: true,
@@ -3,16 +3,21 @@ package agent_test
 import (
 	"bytes"
 	"context"
+	"strconv"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	agentpb "github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	entrypb "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
@@ -21,6 +26,35 @@ import (
 
 var (
 	testAgents = []*types.Agent{{Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent1"}}}
+
+	filterAgents = []*types.Agent{
+		{
+			Id:                &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent1"},
+			AttestationType:   "k8s_psat",
+			X509SvidExpiresAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+			Selectors: []*types.Selector{
+				{Type: "k8s_psat", Value: "cluster:prod"},
+				{Type: "k8s_psat", Value: "agent_ns:spire"},
+			},
+		},
+		{
+			Id:                &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent2"},
+			AttestationType:   "k8s_psat",
+			X509SvidExpiresAt: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+			Banned:            true,
+			Selectors: []*types.Selector{
+				{Type: "k8s_psat", Value: "cluster:prod"},
+			},
+		},
+		{
+			Id:                &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent3"},
+			AttestationType:   "k8s_psat",
+			X509SvidExpiresAt: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+			Selectors: []*types.Selector{
+				{Type: "k8s_psat", Value: "cluster:staging"},
+			},
+		},
+	}
 )
 
 type agentTest struct {
@@ -28,8 +62,9 @@ type agentTest struct {
 	stdout *bytes.Buffer
 	stderr *bytes.Buffer
 
-	args   []string
-	server *fakeAgentServer
+	args        []string
+	server      *fakeAgentServer
+	entryServer *fakeEntryServer
 
 	client cli.Command
 }
@@ -46,8 +81,18 @@ func TestEvictHelp(t *testing.T) {
 
 	test.client.Help()
 	require.Equal(t, `Usage of agent evict:
+  -attestationType string
+    	Filters agents to those with the given attestation type
+  -banned
+    	Filters agents to those that are banned (or, if set to false, to those that are not)
+  -expiresAfter string
+    	Filters agents to those whose SVID expires at or after the given RFC3339 timestamp
+  -expiresBefore string
+    	Filters agents to those whose SVID expires before the given RFC3339 timestamp
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -selector value
+    	Filters agents to those with the given colon-delimited type:value selector. Can be used more than once
   -spiffeID string
     	The SPIFFE ID of the agent to evict (agent identity)
 `, test.stderr.String())
@@ -72,7 +117,13 @@ func TestEvict(t *testing.T) {
 		{
 			name:               "no spiffe id",
 			expectedReturnCode: 1,
-			expectedStderr:     "a SPIFFE ID is required\n",
+			expectedStderr:     "a SPIFFE ID or a filter is required\n",
+		},
+		{
+			name:               "spiffe id and filters",
+			args:               []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1", "-banned"},
+			expectedReturnCode: 1,
+			expectedStderr:     "the -spiffeID flag can't be combined with filters\n",
 		},
 		{
 			name:               "wrong UDS path",
@@ -101,13 +152,222 @@ func TestEvict(t *testing.T) {
 	}
 }
 
+func TestEvictWithFilters(t *testing.T) {
+	test := setupTest(t, agent.NewEvictCommandWithEnv)
+	test.server.agents = filterAgents
+
+	returnCode := test.client.Run(append(test.args, "-selector", "k8s_psat:cluster:prod"))
+	require.Equal(t, 0, returnCode)
+	require.Equal(t, `Agent spiffe://example.org/spire/agent/agent1 evicted
+Agent spiffe://example.org/spire/agent/agent2 evicted
+2 agents evicted successfully
+`, test.stdout.String())
+	require.Equal(t, []string{"/spire/agent/agent1", "/spire/agent/agent2"}, test.server.deleted)
+}
+
+func TestEvictWithFiltersFailure(t *testing.T) {
+	test := setupTest(t, agent.NewEvictCommandWithEnv)
+	test.server.agents = filterAgents
+	test.server.failOn = "/spire/agent/agent1"
+
+	returnCode := test.client.Run(append(test.args, "-attestationType", "k8s_psat"))
+	require.Equal(t, 1, returnCode)
+	require.Equal(t, `Agent spiffe://example.org/spire/agent/agent2 evicted
+Agent spiffe://example.org/spire/agent/agent3 evicted
+`, test.stdout.String())
+	require.Equal(t, `Failed to evict agent spiffe://example.org/spire/agent/agent1: rpc error: code = Internal desc = oh no
+failed to evict 1 of 3 agents
+`, test.stderr.String())
+}
+
+func TestBanHelp(t *testing.T) {
+	test := setupTest(t, agent.NewBanCommandWithEnv)
+
+	test.client.Help()
+	require.Equal(t, `Usage of agent ban:
+  -attestationType string
+    	Filters agents to those with the given attestation type
+  -banned
+    	Filters agents to those that are banned (or, if set to false, to those that are not)
+  -expiresAfter string
+    	Filters agents to those whose SVID expires at or after the given RFC3339 timestamp
+  -expiresBefore string
+    	Filters agents to those whose SVID expires before the given RFC3339 timestamp
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -selector value
+    	Filters agents to those with the given colon-delimited type:value selector. Can be used more than once
+  -spiffeID string
+    	The SPIFFE ID of the agent to ban (agent identity)
+`, test.stderr.String())
+}
+
+func TestBan(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		args               []string
+		expectedReturnCode int
+		expectedStdout     string
+		expectedStderr     string
+		expectedBanned     []string
+	}{
+		{
+			name:               "success",
+			args:               []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1"},
+			expectedReturnCode: 0,
+			expectedStdout:     "Agent banned successfully\n",
+			expectedBanned:     []string{"/spire/agent/agent1"},
+		},
+		{
+			name:               "with filters",
+			args:               []string{"-expiresBefore", "2021-01-01T00:00:00Z", "-banned=false"},
+			expectedReturnCode: 0,
+			expectedStdout:     "Agent spiffe://example.org/spire/agent/agent1 banned\n1 agent banned successfully\n",
+			expectedBanned:     []string{"/spire/agent/agent1"},
+		},
+		{
+			name:               "no matching agents",
+			args:               []string{"-selector", "k8s_psat:cluster:dev"},
+			expectedReturnCode: 0,
+			expectedStdout:     "No attested agents found\n",
+		},
+		{
+			name:               "invalid expiresBefore",
+			args:               []string{"-expiresBefore", "yesterday"},
+			expectedReturnCode: 1,
+			expectedStderr:     "invalid expiresBefore timestamp: parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\"\n",
+		},
+		{
+			name:               "invalid selector",
+			args:               []string{"-selector", "k8s_psat"},
+			expectedReturnCode: 1,
+			expectedStderr:     "selector \"k8s_psat\" must be formatted as type:value\n",
+		},
+		{
+			name:               "no spiffe id",
+			expectedReturnCode: 1,
+			expectedStderr:     "a SPIFFE ID or a filter is required\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, agent.NewBanCommandWithEnv)
+			test.server.agents = filterAgents
+
+			returnCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+			require.Equal(t, tt.expectedStderr, test.stderr.String())
+			require.Equal(t, tt.expectedReturnCode, returnCode)
+			require.Equal(t, tt.expectedBanned, test.server.banned)
+		})
+	}
+}
+
+func TestReattestHelp(t *testing.T) {
+	test := setupTest(t, agent.NewReattestCommandWithEnv)
+
+	test.client.Help()
+	require.Equal(t, `Usage of agent reattest:
+  -attestationType string
+    	Filters agents to those with the given attestation type
+  -banned
+    	Filters agents to those that are banned (or, if set to false, to those that are not)
+  -expiresAfter string
+    	Filters agents to those whose SVID expires at or after the given RFC3339 timestamp
+  -expiresBefore string
+    	Filters agents to those whose SVID expires before the given RFC3339 timestamp
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -selector value
+    	Filters agents to those with the given colon-delimited type:value selector. Can be used more than once
+  -spiffeID string
+    	The SPIFFE ID of the agent to force to re-attest (agent identity)
+`, test.stderr.String())
+}
+
+func TestReattest(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		args               []string
+		failOn             string
+		expectedReturnCode int
+		expectedStdout     string
+		expectedStderr     string
+		expectedReattested []string
+	}{
+		{
+			name:               "success",
+			args:               []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1"},
+			expectedReturnCode: 0,
+			expectedStdout:     "Agent forced to re-attest successfully\n",
+			expectedReattested: []string{"/spire/agent/agent1"},
+		},
+		{
+			name:               "with filters",
+			args:               []string{"-expiresBefore", "2021-01-01T00:00:00Z", "-banned=false"},
+			expectedReturnCode: 0,
+			expectedStdout:     "Agent spiffe://example.org/spire/agent/agent1 forced to re-attest\n1 agent forced to re-attest successfully\n",
+			expectedReattested: []string{"/spire/agent/agent1"},
+		},
+		{
+			name:               "server error",
+			args:               []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1"},
+			failOn:             "/spire/agent/agent1",
+			expectedReturnCode: 1,
+			expectedStderr:     "rpc error: code = FailedPrecondition desc = agent is banned\n",
+		},
+		{
+			name:               "spiffe id with filters",
+			args:               []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1", "-banned"},
+			expectedReturnCode: 1,
+			expectedStderr:     "the -spiffeID flag can't be combined with filters\n",
+		},
+		{
+			name:               "no spiffe id",
+			expectedReturnCode: 1,
+			expectedStderr:     "a SPIFFE ID or a filter is required\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, agent.NewReattestCommandWithEnv)
+			test.server.agents = filterAgents
+			test.server.failOn = tt.failOn
+
+			returnCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+			require.Equal(t, tt.expectedStderr, test.stderr.String())
+			require.Equal(t, tt.expectedReturnCode, returnCode)
+			require.Equal(t, tt.expectedReattested, test.server.reattested)
+		})
+	}
+}
+
 func TestListHelp(t *testing.T) {
 	test := setupTest(t, agent.NewListCommandWithEnv)
 
 	test.client.Help()
 	require.Equal(t, `Usage of agent list:
+  -attestationType string
+    	Filters agents to those with the given attestation type
+  -banned
+    	Filters agents to those that are banned (or, if set to false, to those that are not)
+  -countOnly
+    	Only prints the number of agents matching the filter
+  -expiresAfter string
+    	Filters agents to those whose SVID expires at or after the given RFC3339 timestamp
+  -expiresBefore string
+    	Filters agents to those whose SVID expires before the given RFC3339 timestamp
+  -output string
+    	The output format. Either "text" or "json". (default "text")
+  -pageSize int
+    	Lists a single page of at most this many agents. The server may return fewer.
+  -pageToken string
+    	Lists the page of agents following a previous paginated listing, using the page token it printed
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -selector value
+    	Filters agents to those with the given colon-delimited type:value selector. Can be used more than once
 `, test.stderr.String())
 }
 
@@ -131,6 +391,95 @@ func TestList(t *testing.T) {
 			name:               "no agents",
 			expectedReturnCode: 0,
 		},
+		{
+			name:               "filtered agents",
+			args:               []string{"-attestationType", "k8s_psat", "-banned", "-selector", "k8s_psat:cluster:prod"},
+			expectedReturnCode: 0,
+			existentAgents:     filterAgents,
+			expectedStdout:     "Found 1 attested agent:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent2",
+		},
+		{
+			name:               "filtered by expiration",
+			args:               []string{"-expiresAfter", "2021-01-01T00:00:00Z", "-expiresBefore", "2023-01-01T00:00:00Z", "-banned"},
+			expectedReturnCode: 0,
+			existentAgents:     filterAgents,
+			expectedStdout:     "Found 1 attested agent:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent2",
+		},
+		{
+			name:               "first page",
+			args:               []string{"-pageSize", "1", "-selector", "k8s_psat:cluster:prod"},
+			expectedReturnCode: 0,
+			existentAgents:     filterAgents,
+			expectedStdout:     "Found 1 attested agent:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent1\nAttestation type  : k8s_psat\nExpiration time   : 2020-01-01 00:00:00 +0000 UTC\nSerial number     : \n\nNext page token   : 1\n",
+		},
+		{
+			name:               "second page",
+			args:               []string{"-pageSize", "1", "-pageToken", "1"},
+			expectedReturnCode: 0,
+			existentAgents:     filterAgents,
+			expectedStdout:     "Found 1 attested agent:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent2",
+		},
+		{
+			name:               "json output",
+			args:               []string{"-output", "json", "-pageSize", "1", "-selector", "k8s_psat:cluster:prod"},
+			expectedReturnCode: 0,
+			existentAgents:     filterAgents,
+			expectedStdout: `{
+    "agents": [
+        {
+            "spiffe_id": "spiffe://example.org/spire/agent/agent1",
+            "attestation_type": "k8s_psat",
+            "expires_at": "2020-01-01T00:00:00Z",
+            "serial_number": "",
+            "banned": false,
+            "selectors": [
+                "k8s_psat:cluster:prod",
+                "k8s_psat:agent_ns:spire"
+            ]
+        }
+    ],
+    "next_page_token": "1"
+}
+`,
+		},
+		{
+			name:               "json output with no agents",
+			args:               []string{"-output", "json"},
+			expectedReturnCode: 0,
+			expectedStdout:     "{\n    \"agents\": []\n}\n",
+		},
+		{
+			name:               "count only",
+			args:               []string{"-countOnly", "-expiresAfter", "2021-01-01T00:00:00Z"},
+			expectedReturnCode: 0,
+			existentAgents:     filterAgents,
+			expectedStdout:     "Found 2 attested agents\n",
+		},
+		{
+			name:               "count only with selectors",
+			args:               []string{"-countOnly", "-selector", "k8s_psat:cluster:prod", "-output", "json"},
+			expectedReturnCode: 0,
+			existentAgents:     filterAgents,
+			expectedStdout:     "{\n    \"count\": 2\n}\n",
+		},
+		{
+			name:               "count only with pagination",
+			args:               []string{"-countOnly", "-pageSize", "1"},
+			expectedReturnCode: 1,
+			expectedStderr:     "-countOnly cannot be combined with -pageSize or -pageToken\n",
+		},
+		{
+			name:               "invalid output format",
+			args:               []string{"-output", "yaml"},
+			expectedReturnCode: 1,
+			expectedStderr:     "invalid output format: \"yaml\"\n",
+		},
+		{
+			name:               "invalid expiresAfter",
+			args:               []string{"-expiresAfter", "tomorrow"},
+			expectedReturnCode: 1,
+			expectedStderr:     "invalid expiresAfter timestamp: parsing time \"tomorrow\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"tomorrow\" as \"2006\"\n",
+		},
 		{
 			name:               "server error",
 			expectedReturnCode: 1,
@@ -150,6 +499,7 @@ func TestList(t *testing.T) {
 			test.server.agents = tt.existentAgents
 			test.server.err = tt.serverErr
 			returnCode := test.client.Run(append(test.args, tt.args...))
+			require.NotContains(t, test.stdout.String(), "agent3")
 			require.Contains(t, test.stdout.String(), tt.expectedStdout)
 			require.Equal(t, tt.expectedStderr, test.stderr.String())
 			require.Equal(t, tt.expectedReturnCode, returnCode)
@@ -162,6 +512,8 @@ func TestShowHelp(t *testing.T) {
 
 	test.client.Help()
 	require.Equal(t, `Usage of agent show:
+  -entries
+    	Shows the registration entries the agent is authorized for
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
   -spiffeID string
@@ -220,11 +572,56 @@ func TestShow(t *testing.T) {
 	}
 }
 
+func TestShowEntries(t *testing.T) {
+	test := setupTest(t, agent.NewShowCommandWithEnv)
+	test.server.agents = filterAgents[1:2]
+	agentID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent2"}
+	aliasID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/prod"}
+	workloadID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"}
+	test.entryServer.entries = []*types.Entry{
+		{
+			Id:        "alias",
+			SpiffeId:  aliasID,
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/server"},
+			Selectors: []*types.Selector{{Type: "k8s_psat", Value: "cluster:prod"}},
+		},
+		{
+			Id:        "workload",
+			SpiffeId:  workloadID,
+			ParentId:  aliasID,
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		{
+			Id:        "direct",
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/direct"},
+			ParentId:  agentID,
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1001"}},
+		},
+		{
+			Id:        "other",
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/other"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent3"},
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:1002"}},
+		},
+	}
+
+	returnCode := test.client.Run(append(test.args, "-spiffeID", "spiffe://example.org/spire/agent/agent2", "-entries"))
+	require.Equal(t, 0, returnCode)
+	require.Contains(t, test.stdout.String(), "The agent is authorized for 3 registration entries\n\n")
+	require.Contains(t, test.stdout.String(), "Entry ID         : alias\n")
+	require.Contains(t, test.stdout.String(), "Entry ID         : workload\n")
+	require.Contains(t, test.stdout.String(), "Entry ID         : direct\n")
+	require.NotContains(t, test.stdout.String(), "Entry ID         : other\n")
+	require.Contains(t, test.stdout.String(), "Banned            : true\n")
+}
+
 func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *agentTest {
 	server := &fakeAgentServer{}
+	entryServer := &fakeEntryServer{}
 
 	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
 		agentpb.RegisterAgentServer(s, server)
+		entrypb.RegisterEntryServer(s, entryServer)
 	})
 
 	stdin := new(bytes.Buffer)
@@ -238,12 +635,13 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *agent
 	})
 
 	test := &agentTest{
-		stdin:  stdin,
-		stdout: stdout,
-		stderr: stderr,
-		args:   []string{"-registrationUDSPath", socketPath},
-		server: server,
-		client: client,
+		stdin:       stdin,
+		stdout:      stdout,
+		stderr:      stderr,
+		args:        []string{"-registrationUDSPath", socketPath},
+		server:      server,
+		entryServer: entryServer,
+		client:      client,
 	}
 
 	t.Cleanup(func() {
@@ -256,20 +654,101 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *agent
 type fakeAgentServer struct {
 	agentpb.UnimplementedAgentServer
 
-	agents []*types.Agent
-	err    error
+	agents     []*types.Agent
+	err        error
+	failOn     string
+	deleted    []string
+	banned     []string
+	reattested []string
 }
 
 func (s *fakeAgentServer) DeleteAgent(ctx context.Context, req *agentpb.DeleteAgentRequest) (*empty.Empty, error) {
+	if req.Id.Path == s.failOn {
+		return nil, status.Error(codes.Internal, "oh no")
+	}
+	if s.err == nil {
+		s.deleted = append(s.deleted, req.Id.Path)
+	}
 	return &empty.Empty{}, s.err
 }
 
+func (s *fakeAgentServer) BanAgent(ctx context.Context, req *agentpb.BanAgentRequest) (*empty.Empty, error) {
+	s.banned = append(s.banned, req.Id.Path)
+	return &empty.Empty{}, nil
+}
+
+func (s *fakeAgentServer) ReattestAgent(ctx context.Context, req *agentpb.ReattestAgentRequest) (*empty.Empty, error) {
+	if req.Id.Path == s.failOn {
+		return nil, status.Error(codes.FailedPrecondition, "agent is banned")
+	}
+	s.reattested = append(s.reattested, req.Id.Path)
+	return &empty.Empty{}, nil
+}
+
 func (s *fakeAgentServer) ListAgents(ctx context.Context, req *agentpb.ListAgentsRequest) (*agentpb.ListAgentsResponse, error) {
-	return &agentpb.ListAgentsResponse{
-		Agents: s.agents,
+	// Only the server-side filters are applied
+	var agents []*types.Agent
+	for _, a := range s.agents {
+		if filter := req.Filter; filter != nil {
+			if !matchesFilter(a, filter.ByAttestationType, filter.ByBanned, filter.ByExpiresBefore, filter.ByExpiresAfter) {
+				continue
+			}
+		}
+		agents = append(agents, a)
+	}
+
+	// Page tokens are the index of the first agent of the page
+	resp := &agentpb.ListAgentsResponse{}
+	start := 0
+	if req.PageToken != "" {
+		var err error
+		start, err = strconv.Atoi(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+	if start > len(agents) {
+		start = len(agents)
+	}
+	end := len(agents)
+	if req.PageSize > 0 && start+int(req.PageSize) < end {
+		end = start + int(req.PageSize)
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	resp.Agents = agents[start:end]
+	return resp, s.err
+}
+
+func (s *fakeAgentServer) CountAgents(ctx context.Context, req *agentpb.CountAgentsRequest) (*agentpb.CountAgentsResponse, error) {
+	count := 0
+	for _, a := range s.agents {
+		if filter := req.Filter; filter != nil {
+			if !matchesFilter(a, filter.ByAttestationType, filter.ByBanned, filter.ByExpiresBefore, filter.ByExpiresAfter) {
+				continue
+			}
+		}
+		count++
+	}
+	return &agentpb.CountAgentsResponse{
+		Count: int32(count),
 	}, s.err
 }
 
+func matchesFilter(a *types.Agent, attestationType string, banned *wrappers.BoolValue, expiresBefore, expiresAfter int64) bool {
+	switch {
+	case attestationType != "" && a.AttestationType != attestationType:
+		return false
+	case banned != nil && a.Banned != banned.Value:
+		return false
+	case expiresBefore != 0 && a.X509SvidExpiresAt >= expiresBefore:
+		return false
+	case expiresAfter != 0 && a.X509SvidExpiresAt < expiresAfter:
+		return false
+	default:
+		return true
+	}
+}
+
 func (s *fakeAgentServer) GetAgent(ctx context.Context, req *agentpb.GetAgentRequest) (*types.Agent, error) {
 	if len(s.agents) > 0 {
 		return s.agents[0], s.err
@@ -277,3 +756,44 @@ func (s *fakeAgentServer) GetAgent(ctx context.Context, req *agentpb.GetAgentReq
 
 	return nil, s.err
 }
+
+type fakeEntryServer struct {
+	entrypb.UnimplementedEntryServer
+
+	entries []*types.Entry
+}
+
+func (s *fakeEntryServer) ListEntries(ctx context.Context, req *entrypb.ListEntriesRequest) (*entrypb.ListEntriesResponse, error) {
+	var entries []*types.Entry
+	for _, e := range s.entries {
+		switch {
+		case req.Filter.ByParentId != nil:
+			if proto.Equal(e.ParentId, req.Filter.ByParentId) {
+				entries = append(entries, e)
+			}
+		case req.Filter.BySelectors != nil:
+			if isSubset(e.Selectors, req.Filter.BySelectors.Selectors) {
+				entries = append(entries, e)
+			}
+		}
+	}
+	return &entrypb.ListEntriesResponse{
+		Entries: entries,
+	}, nil
+}
+
+func isSubset(selectors, of []*types.Selector) bool {
+	for _, s := range selectors {
+		found := false
+		for _, o := range of {
+			if proto.Equal(s, o) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+
+	"golang.org/x/net/context"
+)
+
+type banCommand struct {
+	// SPIFFE ID of the agent being banned
+	spiffeID string
+
+	// Filter selecting the agents being banned, when no SPIFFE ID is given
+	filter agentFilter
+}
+
+// NewBanCommand creates a new "ban" subcommand for "agent" command.
+func NewBanCommand() cli.Command {
+	return NewBanCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewBanCommandWithEnv creates a new "ban" subcommand for "agent" command
+// using the environment specified
+func NewBanCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(banCommand))
+}
+
+func (*banCommand) Name() string {
+	return "agent ban"
+}
+
+func (banCommand) Synopsis() string {
+	return "Bans an attested agent given its SPIFFE ID, or the agents matching the filters"
+}
+
+//Run bans an agent given its SPIFFE ID, or the agents matching the filters
+func (c *banCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.spiffeID != "" && c.filter.isSet() {
+		return errors.New("the -spiffeID flag can't be combined with filters")
+	}
+
+	agentClient := serverClient.NewAgentClient()
+	ban := func(id *types.SPIFFEID) error {
+		_, err := agentClient.BanAgent(ctx, &agent.BanAgentRequest{Id: id})
+		return err
+	}
+
+	if c.filter.isSet() {
+		return applyToAgents(ctx, env, agentClient, &c.filter, "ban", "banned", ban)
+	}
+
+	if c.spiffeID == "" {
+		return errors.New("a SPIFFE ID or a filter is required")
+	}
+
+	id, err := spiffeid.FromString(c.spiffeID)
+	if err != nil {
+		return err
+	}
+
+	if err := ban(api.ProtoFromID(id)); err != nil {
+		return err
+	}
+
+	return env.Println("Agent banned successfully")
+}
+
+func (c *banCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent to ban (agent identity)")
+	c.filter.appendFlags(fs)
+}
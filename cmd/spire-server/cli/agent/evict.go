@@ -11,6 +11,7 @@ import (
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"github.com/spiffe/spire/proto/spire/types"
 
 	"golang.org/x/net/context"
 )
@@ -18,6 +19,9 @@ import (
 type evictCommand struct {
 	// SPIFFE ID of the agent being evicted
 	spiffeID string
+
+	// Filter selecting the agents being evicted, when no SPIFFE ID is given
+	filter agentFilter
 }
 
 // NewEvictCommand creates a new "evict" subcommand for "agent" command.
@@ -36,13 +40,27 @@ func (*evictCommand) Name() string {
 }
 
 func (evictCommand) Synopsis() string {
-	return "Evicts an attested agent given its SPIFFE ID"
+	return "Evicts an attested agent given its SPIFFE ID, or the agents matching the filters"
 }
 
-//Run evicts an agent given its SPIFFE ID
+//Run evicts an agent given its SPIFFE ID, or the agents matching the filters
 func (c *evictCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.spiffeID != "" && c.filter.isSet() {
+		return errors.New("the -spiffeID flag can't be combined with filters")
+	}
+
+	agentClient := serverClient.NewAgentClient()
+	evict := func(id *types.SPIFFEID) error {
+		_, err := agentClient.DeleteAgent(ctx, &agent.DeleteAgentRequest{Id: id})
+		return err
+	}
+
+	if c.filter.isSet() {
+		return applyToAgents(ctx, env, agentClient, &c.filter, "evict", "evicted", evict)
+	}
+
 	if c.spiffeID == "" {
-		return errors.New("a SPIFFE ID is required")
+		return errors.New("a SPIFFE ID or a filter is required")
 	}
 
 	id, err := spiffeid.FromString(c.spiffeID)
@@ -50,9 +68,7 @@ func (c *evictCommand) Run(ctx context.Context, env *common_cli.Env, serverClien
 		return err
 	}
 
-	agentClient := serverClient.NewAgentClient()
-	_, err = agentClient.DeleteAgent(ctx, &agent.DeleteAgentRequest{Id: api.ProtoFromID(id)})
-	if err != nil {
+	if err := evict(api.ProtoFromID(id)); err != nil {
 		return err
 	}
 
@@ -61,4 +77,5 @@ func (c *evictCommand) Run(ctx context.Context, env *common_cli.Env, serverClien
 
 func (c *evictCommand) AppendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent to evict (agent identity)")
+	c.filter.appendFlags(fs)
 }
@@ -0,0 +1,266 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+
+	"golang.org/x/net/context"
+)
+
+const listAgentsPageSize = 1000
+
+// agentFilter selects the agents an agent command operates on
+type agentFilter struct {
+	// Attestation type of the agents
+	attestationType string
+
+	// Selectors the agents must have. Type and value are delimited by a
+	// colon (:), e.g. "k8s_psat:cluster:prod"
+	selectors common_cli.StringsFlag
+
+	// Whether the agents are banned or not
+	banned optionalBool
+
+	// Agents whose SVID expires before this RFC3339 timestamp
+	expiresBefore string
+
+	// Agents whose SVID expires at or after this RFC3339 timestamp
+	expiresAfter string
+}
+
+func (f *agentFilter) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.attestationType, "attestationType", "", "Filters agents to those with the given attestation type")
+	fs.Var(&f.selectors, "selector", "Filters agents to those with the given colon-delimited type:value selector. Can be used more than once")
+	fs.Var(&f.banned, "banned", "Filters agents to those that are banned (or, if set to false, to those that are not)")
+	fs.StringVar(&f.expiresBefore, "expiresBefore", "", "Filters agents to those whose SVID expires before the given RFC3339 timestamp")
+	fs.StringVar(&f.expiresAfter, "expiresAfter", "", "Filters agents to those whose SVID expires at or after the given RFC3339 timestamp")
+}
+
+func (f *agentFilter) isSet() bool {
+	return f.attestationType != "" || len(f.selectors) > 0 || f.banned.set || f.expiresBefore != "" || f.expiresAfter != ""
+}
+
+// parsedAgentFilter is the agent filter with its flags parsed
+type parsedAgentFilter struct {
+	attestationType string
+	selectors       []*types.Selector
+	banned          *wrappers.BoolValue
+	expiresBefore   int64
+	expiresAfter    int64
+}
+
+func (f *agentFilter) parse() (*parsedAgentFilter, error) {
+	parsed := &parsedAgentFilter{
+		attestationType: f.attestationType,
+		selectors:       make([]*types.Selector, 0, len(f.selectors)),
+	}
+	for _, s := range f.selectors {
+		selector, err := parseSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed.selectors = append(parsed.selectors, selector)
+	}
+	if f.banned.set {
+		parsed.banned = &wrappers.BoolValue{Value: f.banned.value}
+	}
+	if f.expiresBefore != "" {
+		expiresBefore, err := time.Parse(time.RFC3339, f.expiresBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiresBefore timestamp: %v", err)
+		}
+		parsed.expiresBefore = expiresBefore.Unix()
+	}
+	if f.expiresAfter != "" {
+		expiresAfter, err := time.Parse(time.RFC3339, f.expiresAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiresAfter timestamp: %v", err)
+		}
+		parsed.expiresAfter = expiresAfter.Unix()
+	}
+	return parsed, nil
+}
+
+// listAgents lists the agents matching the filter.
+func (f *agentFilter) listAgents(ctx context.Context, client agent.AgentClient) ([]*types.Agent, error) {
+	parsed, err := f.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	var agents []*types.Agent
+	pageToken := ""
+	for {
+		page, nextPageToken, err := parsed.listPage(ctx, client, listAgentsPageSize, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, page...)
+		if nextPageToken == "" {
+			return agents, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// listPage lists a single page of agents matching the filter. Agents are
+// filtered by attestation type, banned state and expiration on the server,
+// and by selectors on the client, since the server cannot filter agents
+// having some selectors among others. A page may therefore hold fewer agents
+// than the page size even when more pages follow.
+func (f *parsedAgentFilter) listPage(ctx context.Context, client agent.AgentClient, pageSize int32, pageToken string) ([]*types.Agent, string, error) {
+	resp, err := client.ListAgents(ctx, &agent.ListAgentsRequest{
+		Filter: &agent.ListAgentsRequest_Filter{
+			ByAttestationType: f.attestationType,
+			ByBanned:          f.banned,
+			ByExpiresBefore:   f.expiresBefore,
+			ByExpiresAfter:    f.expiresAfter,
+		},
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var agents []*types.Agent
+	for _, a := range resp.Agents {
+		if hasSelectors(a, f.selectors) {
+			agents = append(agents, a)
+		}
+	}
+	return agents, resp.NextPageToken, nil
+}
+
+// countAgents counts the agents matching the filter. The count is done on
+// the server unless the filter has selectors, in which case the agents are
+// listed and counted on the client.
+func (f *agentFilter) countAgents(ctx context.Context, client agent.AgentClient) (int, error) {
+	parsed, err := f.parse()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(parsed.selectors) > 0 {
+		agents, err := f.listAgents(ctx, client)
+		if err != nil {
+			return 0, err
+		}
+		return len(agents), nil
+	}
+
+	resp, err := client.CountAgents(ctx, &agent.CountAgentsRequest{
+		Filter: &agent.CountAgentsRequest_Filter{
+			ByAttestationType: parsed.attestationType,
+			ByBanned:          parsed.banned,
+			ByExpiresBefore:   parsed.expiresBefore,
+			ByExpiresAfter:    parsed.expiresAfter,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Count), nil
+}
+
+func hasSelectors(a *types.Agent, selectors []*types.Selector) bool {
+	type selectorKey struct {
+		Type  string
+		Value string
+	}
+	has := make(map[selectorKey]bool, len(a.Selectors))
+	for _, s := range a.Selectors {
+		has[selectorKey{Type: s.Type, Value: s.Value}] = true
+	}
+	for _, s := range selectors {
+		if !has[selectorKey{Type: s.Type, Value: s.Value}] {
+			return false
+		}
+	}
+	return true
+}
+
+func parseSelector(str string) (*types.Selector, error) {
+	parts := strings.SplitN(str, ":", 2)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("selector %q must be formatted as type:value", str)
+	}
+	return &types.Selector{
+		Type:  parts[0],
+		Value: parts[1],
+	}, nil
+}
+
+// optionalBool is a boolean flag that tracks whether it was set
+type optionalBool struct {
+	set   bool
+	value bool
+}
+
+func (b *optionalBool) String() string {
+	if !b.set {
+		return ""
+	}
+	return strconv.FormatBool(b.value)
+}
+
+func (b *optionalBool) Set(s string) error {
+	value, err := strconv.ParseBool(s)
+	if err != nil {
+		return errors.New("must be true or false")
+	}
+	b.set = true
+	b.value = value
+	return nil
+}
+
+func (b *optionalBool) IsBoolFlag() bool {
+	return true
+}
+
+// applyToAgents calls apply on each agent matching the filter, reporting the
+// agents it fails on without stopping. The action and past strings describe
+// the operation, e.g. "evict" and "evicted".
+func applyToAgents(ctx context.Context, env *common_cli.Env, client agent.AgentClient, filter *agentFilter, action, past string, apply func(*types.SPIFFEID) error) error {
+	agents, err := filter.listAgents(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if len(agents) == 0 {
+		return env.Printf("No attested agents found\n")
+	}
+
+	failed := 0
+	for _, a := range agents {
+		id, err := spiffeid.New(a.Id.TrustDomain, a.Id.Path)
+		if err != nil {
+			return err
+		}
+		if err := apply(a.Id); err != nil {
+			failed++
+			env.ErrPrintf("Failed to %s agent %s: %v\n", action, id, err)
+			continue
+		}
+		env.Printf("Agent %s %s\n", id, past)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d agents", action, failed, len(agents))
+	}
+
+	msg := fmt.Sprintf("%d ", len(agents))
+	msg = util.Pluralizer(msg, "agent", "agents", len(agents))
+	return env.Printf("%s %s successfully\n", msg, past)
+}
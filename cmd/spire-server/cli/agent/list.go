@@ -1,6 +1,8 @@
 package agent
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"time"
@@ -10,13 +12,50 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
-	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 
 	"golang.org/x/net/context"
 )
 
-type listCommand struct{}
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+type listCommand struct {
+	filter agentFilter
+
+	// Maximum number of agents to list. If set, or if pageToken is set, only
+	// a single page is listed.
+	pageSize int
+
+	// Page token returned by a previous paginated listing
+	pageToken string
+
+	// Output format (text or json)
+	output string
+
+	// Whether to only print the number of agents
+	countOnly bool
+}
+
+type listResult struct {
+	Agents        []listAgentResult `json:"agents"`
+	NextPageToken string            `json:"next_page_token,omitempty"`
+}
+
+type listAgentResult struct {
+	SPIFFEID        string   `json:"spiffe_id"`
+	AttestationType string   `json:"attestation_type"`
+	ExpiresAt       string   `json:"expires_at"`
+	SerialNumber    string   `json:"serial_number"`
+	Banned          bool     `json:"banned"`
+	Selectors       []string `json:"selectors,omitempty"`
+}
+
+type countResult struct {
+	Count int `json:"count"`
+}
 
 // NewListCommand creates a new "list" subcommand for "agent" command.
 func NewListCommand() cli.Command {
@@ -34,29 +73,123 @@ func (*listCommand) Name() string {
 }
 
 func (listCommand) Synopsis() string {
-	return "Lists attested agents and their SPIFFE IDs"
+	return "Lists attested agents and their SPIFFE IDs, optionally filtered"
 }
 
 //Run lists attested agents
 func (c *listCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
-	agentClient := serverClient.NewAgentClient()
-	listResponse, err := agentClient.ListAgents(ctx, &agent.ListAgentsRequest{})
-	if err != nil {
-		return err
+	if c.output != outputText && c.output != outputJSON {
+		return fmt.Errorf("invalid output format: %q", c.output)
+	}
+	if c.pageSize < 0 {
+		return errors.New("page size must not be negative")
 	}
+	paginated := c.pageSize > 0 || c.pageToken != ""
+	if c.countOnly && paginated {
+		return errors.New("-countOnly cannot be combined with -pageSize or -pageToken")
+	}
+
+	client := serverClient.NewAgentClient()
 
-	if len(listResponse.Agents) == 0 {
-		return env.Printf("No attested agents found\n")
+	if c.countOnly {
+		count, err := c.filter.countAgents(ctx, client)
+		if err != nil {
+			return err
+		}
+		if c.output == outputJSON {
+			return printJSON(env, countResult{Count: count})
+		}
+		msg := fmt.Sprintf("Found %d attested ", count)
+		msg = util.Pluralizer(msg, "agent", "agents", count)
+		return env.Println(msg)
 	}
 
-	msg := fmt.Sprintf("Found %d attested ", len(listResponse.Agents))
-	msg = util.Pluralizer(msg, "agent", "agents", len(listResponse.Agents))
-	env.Printf(msg + ":\n\n")
+	var agents []*types.Agent
+	var nextPageToken string
+	if paginated {
+		filter, err := c.filter.parse()
+		if err != nil {
+			return err
+		}
+		pageSize := int32(c.pageSize)
+		if pageSize == 0 {
+			pageSize = listAgentsPageSize
+		}
+		agents, nextPageToken, err = filter.listPage(ctx, client, pageSize, c.pageToken)
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		agents, err = c.filter.listAgents(ctx, client)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.output == outputJSON {
+		return printAgentsJSON(env, agents, nextPageToken)
+	}
 
-	return printAgents(env, listResponse.Agents...)
+	if len(agents) == 0 {
+		if err := env.Printf("No attested agents found\n"); err != nil {
+			return err
+		}
+	} else {
+		msg := fmt.Sprintf("Found %d attested ", len(agents))
+		msg = util.Pluralizer(msg, "agent", "agents", len(agents))
+		env.Printf(msg + ":\n\n")
+
+		if err := printAgents(env, agents...); err != nil {
+			return err
+		}
+	}
+
+	if nextPageToken != "" {
+		return env.Printf("Next page token   : %s\n", nextPageToken)
+	}
+	return nil
 }
 
 func (c *listCommand) AppendFlags(fs *flag.FlagSet) {
+	c.filter.appendFlags(fs)
+	fs.IntVar(&c.pageSize, "pageSize", 0, "Lists a single page of at most this many agents. The server may return fewer.")
+	fs.StringVar(&c.pageToken, "pageToken", "", "Lists the page of agents following a previous paginated listing, using the page token it printed")
+	fs.StringVar(&c.output, "output", outputText, fmt.Sprintf("The output format. Either %q or %q.", outputText, outputJSON))
+	fs.BoolVar(&c.countOnly, "countOnly", false, "Only prints the number of agents matching the filter")
+}
+
+func printAgentsJSON(env *common_cli.Env, agents []*types.Agent, nextPageToken string) error {
+	result := listResult{
+		Agents:        []listAgentResult{},
+		NextPageToken: nextPageToken,
+	}
+	for _, agent := range agents {
+		id, err := spiffeid.New(agent.Id.TrustDomain, agent.Id.Path)
+		if err != nil {
+			return err
+		}
+		a := listAgentResult{
+			SPIFFEID:        id.String(),
+			AttestationType: agent.AttestationType,
+			ExpiresAt:       time.Unix(agent.X509SvidExpiresAt, 0).UTC().Format(time.RFC3339),
+			SerialNumber:    agent.X509SvidSerialNumber,
+			Banned:          agent.Banned,
+		}
+		for _, s := range agent.Selectors {
+			a.Selectors = append(a.Selectors, s.Type+":"+s.Value)
+		}
+		result.Agents = append(result.Agents, a)
+	}
+	return printJSON(env, result)
+}
+
+func printJSON(env *common_cli.Env, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	return env.Println(string(data))
 }
 
 func printAgents(env *common_cli.Env, agents ...*types.Agent) error {
@@ -78,6 +211,12 @@ func printAgents(env *common_cli.Env, agents ...*types.Agent) error {
 		if err := env.Printf("Serial number     : %s\n", agent.X509SvidSerialNumber); err != nil {
 			return err
 		}
+		// banned agents are rare, so only show it when set
+		if agent.Banned {
+			if err := env.Printf("Banned            : %t\n", agent.Banned); err != nil {
+				return err
+			}
+		}
 		if err := env.Println(); err != nil {
 			return err
 		}
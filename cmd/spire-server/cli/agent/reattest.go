@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+
+	"golang.org/x/net/context"
+)
+
+type reattestCommand struct {
+	// SPIFFE ID of the agent being forced to re-attest
+	spiffeID string
+
+	// Filter selecting the agents being forced to re-attest, when no SPIFFE
+	// ID is given
+	filter agentFilter
+}
+
+// NewReattestCommand creates a new "reattest" subcommand for "agent" command.
+func NewReattestCommand() cli.Command {
+	return NewReattestCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewReattestCommandWithEnv creates a new "reattest" subcommand for "agent"
+// command using the environment specified
+func NewReattestCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(reattestCommand))
+}
+
+func (*reattestCommand) Name() string {
+	return "agent reattest"
+}
+
+func (reattestCommand) Synopsis() string {
+	return "Forces an attested agent given its SPIFFE ID, or the agents matching the filters, to re-attest and renew its SVIDs with new keys"
+}
+
+//Run forces an agent given its SPIFFE ID, or the agents matching the filters, to re-attest
+func (c *reattestCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.spiffeID != "" && c.filter.isSet() {
+		return errors.New("the -spiffeID flag can't be combined with filters")
+	}
+
+	agentClient := serverClient.NewAgentClient()
+	reattest := func(id *types.SPIFFEID) error {
+		_, err := agentClient.ReattestAgent(ctx, &agent.ReattestAgentRequest{Id: id})
+		return err
+	}
+
+	if c.filter.isSet() {
+		return applyToAgents(ctx, env, agentClient, &c.filter, "force to re-attest", "forced to re-attest", reattest)
+	}
+
+	if c.spiffeID == "" {
+		return errors.New("a SPIFFE ID or a filter is required")
+	}
+
+	id, err := spiffeid.FromString(c.spiffeID)
+	if err != nil {
+		return err
+	}
+
+	if err := reattest(api.ProtoFromID(id)); err != nil {
+		return err
+	}
+
+	return env.Println("Agent forced to re-attest successfully")
+}
+
+func (c *reattestCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent to force to re-attest (agent identity)")
+	c.filter.appendFlags(fs)
+}
@@ -3,14 +3,19 @@ package agent
 import (
 	"errors"
 	"flag"
+	"fmt"
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 
+	entry_cli "github.com/spiffe/spire/cmd/spire-server/cli/entry"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	commonutil "github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
 
 	"golang.org/x/net/context"
 )
@@ -18,6 +23,9 @@ import (
 type showCommand struct {
 	// SPIFFE ID of the agent being showed
 	spiffeID string
+
+	// Whether to show the registration entries the agent is authorized for
+	entries bool
 }
 
 // NewShowCommand creates a new "show" subcommand for "agent" command.
@@ -62,9 +70,85 @@ func (c *showCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 		return err
 	}
 
+	if !c.entries {
+		return nil
+	}
+
+	entries, err := fetchAuthorizedEntries(ctx, serverClient.NewEntryClient(), agent)
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("The agent is authorized for %d registration ", len(entries))
+	msg = util.Pluralizer(msg, "entry", "entries", len(entries))
+	env.Printf(msg + "\n\n")
+	for _, e := range entries {
+		entry_cli.PrintEntry(e, env)
+	}
+
 	return nil
 }
 
 func (c *showCommand) AppendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent to show (agent identity)")
+	fs.BoolVar(&c.entries, "entries", false, "Shows the registration entries the agent is authorized for")
+}
+
+// fetchAuthorizedEntries returns the registration entries the agent is
+// authorized for, following the same rules as the server: the entries
+// parented to the agent or mapped to it through its selectors, and their
+// descendants.
+func fetchAuthorizedEntries(ctx context.Context, client entry.EntryClient, agent *types.Agent) ([]*types.Entry, error) {
+	listEntries := func(filter *entry.ListEntriesRequest_Filter) ([]*types.Entry, error) {
+		resp, err := client.ListEntries(ctx, &entry.ListEntriesRequest{Filter: filter})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Entries, nil
+	}
+
+	direct, err := listEntries(&entry.ListEntriesRequest_Filter{ByParentId: agent.Id})
+	if err != nil {
+		return nil, err
+	}
+	if len(agent.Selectors) > 0 {
+		mapped, err := listEntries(&entry.ListEntriesRequest_Filter{
+			BySelectors: &types.SelectorMatch{
+				Selectors: agent.Selectors,
+				Match:     types.SelectorMatch_MATCH_SUBSET,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		direct = append(direct, mapped...)
+	}
+
+	var entries []*types.Entry
+	seenEntries := make(map[string]bool)
+	seenParents := make(map[string]bool)
+	for pending := direct; len(pending) > 0; {
+		e := pending[0]
+		pending = pending[1:]
+		if seenEntries[e.Id] {
+			continue
+		}
+		seenEntries[e.Id] = true
+		entries = append(entries, e)
+
+		parentID := e.SpiffeId.TrustDomain + e.SpiffeId.Path
+		if seenParents[parentID] {
+			continue
+		}
+		seenParents[parentID] = true
+
+		children, err := listEntries(&entry.ListEntriesRequest_Filter{ByParentId: e.SpiffeId})
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, children...)
+	}
+
+	commonutil.SortTypesEntries(entries)
+	return entries, nil
 }
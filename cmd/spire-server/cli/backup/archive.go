@@ -0,0 +1,171 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+)
+
+const (
+	defaultConfigPath = "conf/server/server.conf"
+
+	// archiveVersion is the version of the archive format written by the
+	// backup command. It is bumped on incompatible changes.
+	archiveVersion = 1
+
+	dataStoreType  = "DataStore"
+	keyManagerType = "KeyManager"
+
+	sqlPluginName    = "sql"
+	diskKeyManager   = "disk"
+	memoryKeyManager = "memory"
+)
+
+// archive is the portable document written by the backup command and read
+// by the restore command
+type archive struct {
+	Version     int       `json:"version"`
+	TrustDomain string    `json:"trust_domain"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	CA caArchive `json:"ca"`
+
+	Bundles                 []*common.Bundle                    `json:"bundles"`
+	FederationRelationships []*datastore.FederationRelationship `json:"federation_relationships"`
+	EntryTemplates          []*datastore.EntryTemplate          `json:"entry_templates"`
+	Entries                 []*common.RegistrationEntry         `json:"entries"`
+	// Agents hold the attested nodes along with their selectors
+	Agents []*common.AttestedNode `json:"agents"`
+}
+
+// caArchive holds the CA material of the server. Private keys are only
+// included for the disk key manager. Other key managers keep their keys
+// outside of SPIRE (e.g. in a KMS), so only the references to the keys held
+// in the journal are archived.
+type caArchive struct {
+	// Journal is the PEM encoded CA journal, holding the CA certificates and
+	// the IDs of their keys in the key manager
+	Journal string `json:"journal,omitempty"`
+
+	// KeyManager is the name of the key manager plugin the keys live in
+	KeyManager string `json:"key_manager"`
+
+	// DiskKeys is the content of the keys file of the disk key manager
+	DiskKeys string `json:"disk_keys,omitempty"`
+}
+
+func (a *archive) summary() string {
+	return fmt.Sprintf("%d bundles, %d federation relationships, %d entry templates, %d entries, %d agents",
+		len(a.Bundles), len(a.FederationRelationships), len(a.EntryTemplates), len(a.Entries), len(a.Agents))
+}
+
+// commandFlags are the flags shared by the backup and restore commands
+type commandFlags struct {
+	configPath string
+	expandEnv  bool
+	file       string
+}
+
+func (c *commandFlags) parse(env *common_cli.Env, name, fileUsage string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.Stderr)
+	fs.StringVar(&c.configPath, "config", defaultConfigPath, "Path to the SPIRE server config file")
+	fs.BoolVar(&c.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+	fs.StringVar(&c.file, "file", "", fileUsage)
+	return fs.Parse(args)
+}
+
+// serverConfig holds the parts of the server config file the backup and
+// restore commands work with
+type serverConfig struct {
+	trustDomain string
+	dataDir     string
+
+	dataStoreData string
+
+	keyManager     string
+	keyManagerData string
+}
+
+func (c *commandFlags) loadServerConfig() (*serverConfig, error) {
+	if c.file == "" {
+		return nil, errors.New("an archive file path is required")
+	}
+
+	config, err := run.ParseFile(c.configPath, c.expandEnv)
+	if err != nil {
+		return nil, err
+	}
+	if config.Server == nil || config.Server.TrustDomain == "" {
+		return nil, errors.New("trust_domain must be configured")
+	}
+	if config.Server.DataDir == "" {
+		return nil, errors.New("data_dir must be configured")
+	}
+
+	dataStores, err := run.PluginConfigs(config, dataStoreType)
+	if err != nil {
+		return nil, err
+	}
+	dataStore, ok := dataStores[sqlPluginName]
+	if !ok {
+		return nil, errors.New("the sql DataStore plugin is not configured")
+	}
+
+	keyManagers, err := run.PluginConfigs(config, keyManagerType)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyManagers) != 1 {
+		return nil, errors.New("exactly one KeyManager plugin must be configured")
+	}
+
+	sc := &serverConfig{
+		trustDomain:   config.Server.TrustDomain,
+		dataDir:       config.Server.DataDir,
+		dataStoreData: dataStore.Data,
+	}
+	for name, keyManager := range keyManagers {
+		sc.keyManager = name
+		sc.keyManagerData = keyManager.Data
+	}
+	return sc, nil
+}
+
+// diskKeysPath returns the keys file of the disk key manager
+func (c *serverConfig) diskKeysPath() (string, error) {
+	config := struct {
+		KeysPath string `hcl:"keys_path"`
+	}{}
+	if err := hcl.Decode(&config, c.keyManagerData); err != nil {
+		return "", fmt.Errorf("unable to decode disk key manager configuration: %v", err)
+	}
+	if config.KeysPath == "" {
+		return "", errors.New("keys_path of the disk key manager is not configured")
+	}
+	return config.KeysPath, nil
+}
+
+// openDataStore loads the sql datastore in process. Like the server, it
+// creates or migrates the schema as configured.
+func (c *serverConfig) openDataStore(ctx context.Context) (datastore.DataStore, error) {
+	ds := sql.New()
+	ds.SetLogger(hclog.NewNullLogger())
+	if _, err := ds.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: c.dataStoreData,
+	}); err != nil {
+		return nil, fmt.Errorf("unable to open datastore: %v", err)
+	}
+	return ds, nil
+}
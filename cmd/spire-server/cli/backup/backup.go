@@ -0,0 +1,178 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+// NewBackupCommand creates a new "backup" command.
+func NewBackupCommand() cli.Command {
+	return newBackupCommand(common_cli.DefaultEnv)
+}
+
+func newBackupCommand(env *common_cli.Env) *backupCommand {
+	return &backupCommand{env: env}
+}
+
+type backupCommand struct {
+	env *common_cli.Env
+	commandFlags
+}
+
+func (c *backupCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *backupCommand) Synopsis() string {
+	return "Exports the trust domain state of the server into a portable archive"
+}
+
+func (c *backupCommand) parseFlags(args []string) error {
+	return c.parse(c.env, "backup", "Path of the archive file to create. The file must not exist.", args)
+}
+
+func (c *backupCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(context.Background()); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+	return 0
+}
+
+func (c *backupCommand) run(ctx context.Context) error {
+	config, err := c.loadServerConfig()
+	if err != nil {
+		return err
+	}
+
+	a := &archive{
+		Version:     archiveVersion,
+		TrustDomain: config.trustDomain,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := exportCA(config, &a.CA); err != nil {
+		return err
+	}
+
+	ds, err := config.openDataStore(ctx)
+	if err != nil {
+		return err
+	}
+	if err := exportDataStore(ctx, ds, a); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(a, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := writeNewFile(c.file, data); err != nil {
+		return err
+	}
+
+	if err := c.env.Printf("Trust domain %q backed up to %s: %s\n", a.TrustDomain, c.file, a.summary()); err != nil {
+		return err
+	}
+	switch a.CA.KeyManager {
+	case diskKeyManager:
+		return c.env.Println("The archive contains the private keys of the disk key manager and must be kept secret.")
+	case memoryKeyManager:
+		return c.env.Println("The memory key manager does not persist keys, so the server will prepare a new CA after a restore.")
+	default:
+		return c.env.Printf("Private keys of the %q key manager are not included. They must remain available to the restored server.\n", a.CA.KeyManager)
+	}
+}
+
+func exportCA(config *serverConfig, out *caArchive) error {
+	out.KeyManager = config.keyManager
+
+	journal, err := ioutil.ReadFile(ca.JournalPath(config.dataDir))
+	switch {
+	case err == nil:
+		out.Journal = string(journal)
+	case !os.IsNotExist(err):
+		return fmt.Errorf("unable to read CA journal: %v", err)
+	}
+
+	if config.keyManager != diskKeyManager {
+		return nil
+	}
+	keysPath, err := config.diskKeysPath()
+	if err != nil {
+		return err
+	}
+	keys, err := ioutil.ReadFile(keysPath)
+	switch {
+	case err == nil:
+		out.DiskKeys = string(keys)
+	case !os.IsNotExist(err):
+		return fmt.Errorf("unable to read disk key manager keys: %v", err)
+	}
+	return nil
+}
+
+func exportDataStore(ctx context.Context, ds datastore.DataStore, a *archive) error {
+	bundles, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list bundles: %v", err)
+	}
+	a.Bundles = bundles.Bundles
+
+	relationships, err := ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list federation relationships: %v", err)
+	}
+	a.FederationRelationships = relationships.FederationRelationships
+
+	templates, err := ds.ListEntryTemplates(ctx, &datastore.ListEntryTemplatesRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list entry templates: %v", err)
+	}
+	a.EntryTemplates = templates.EntryTemplates
+
+	entries, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list entries: %v", err)
+	}
+	a.Entries = entries.Entries
+
+	nodes, err := ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		FetchSelectors: true,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list agents: %v", err)
+	}
+	a.Agents = nodes.Nodes
+	return nil
+}
+
+// writeNewFile writes data to a file that must not exist yet, readable only
+// by the current user
+func writeNewFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
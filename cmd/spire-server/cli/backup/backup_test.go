@@ -0,0 +1,334 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupHelp(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	newBackupCommand(env).Help()
+	require.Equal(t, `Usage of backup:
+  -config string
+    	Path to the SPIRE server config file (default "conf/server/server.conf")
+  -expandEnv
+    	Expand environment variables in SPIRE config file
+  -file string
+    	Path of the archive file to create. The file must not exist.
+`, stderr.String())
+}
+
+func TestRestoreHelp(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	newRestoreCommand(env).Help()
+	require.Equal(t, `Usage of restore:
+  -config string
+    	Path to the SPIRE server config file (default "conf/server/server.conf")
+  -expandEnv
+    	Expand environment variables in SPIRE config file
+  -file string
+    	Path of the archive file to restore
+`, stderr.String())
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := spiretest.TempDir(t)
+	archivePath := filepath.Join(dir, "backup.json")
+
+	source := newTestServer(t, filepath.Join(dir, "source"), "example.org", "disk")
+	require.NoError(t, os.MkdirAll(source.dataDir, 0755))
+	require.NoError(t, ioutil.WriteFile(ca.JournalPath(source.dataDir), []byte("JOURNAL"), 0600))
+	require.NoError(t, ioutil.WriteFile(source.keysPath, []byte("KEYS"), 0600))
+	populateDataStore(ctx, t, source)
+
+	stdout := runBackup(t, "-config", source.configPath, "-file", archivePath)
+	require.Equal(t, `Trust domain "example.org" backed up to `+archivePath+`: 2 bundles, 1 federation relationships, 1 entry templates, 1 entries, 1 agents
+The archive contains the private keys of the disk key manager and must be kept secret.
+`, stdout)
+
+	info, err := os.Stat(archivePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// The archive is never overwritten
+	env, _, stderr := newTestEnv()
+	require.Equal(t, 1, newBackupCommand(env).Run([]string{"-config", source.configPath, "-file", archivePath}))
+	require.Contains(t, stderr.String(), "file exists")
+
+	target := newTestServer(t, filepath.Join(dir, "target"), "example.org", "disk")
+	stdout = runRestore(t, "-config", target.configPath, "-file", archivePath)
+	require.Equal(t, `Trust domain "example.org" restored from `+archivePath+`: 2 bundles, 1 federation relationships, 1 entry templates, 1 entries, 1 agents
+`, stdout)
+
+	journal, err := ioutil.ReadFile(ca.JournalPath(target.dataDir))
+	require.NoError(t, err)
+	require.Equal(t, "JOURNAL", string(journal))
+	keys, err := ioutil.ReadFile(target.keysPath)
+	require.NoError(t, err)
+	require.Equal(t, "KEYS", string(keys))
+
+	sourceDS := openTestDataStore(ctx, t, source)
+	targetDS := openTestDataStore(ctx, t, target)
+	expected := new(archive)
+	require.NoError(t, exportDataStore(ctx, sourceDS, expected))
+	actual := new(archive)
+	require.NoError(t, exportDataStore(ctx, targetDS, actual))
+
+	spiretest.AssertProtoListEqual(t, expected.Bundles, actual.Bundles)
+	spiretest.AssertProtoListEqual(t, expected.FederationRelationships, actual.FederationRelationships)
+	spiretest.AssertProtoListEqual(t, expected.EntryTemplates, actual.EntryTemplates)
+	spiretest.AssertProtoListEqual(t, expected.Agents, actual.Agents)
+
+	// Entries are restored with new IDs
+	require.Len(t, actual.Entries, 1)
+	require.NotEqual(t, expected.Entries[0].EntryId, actual.Entries[0].EntryId)
+	actual.Entries[0].EntryId = expected.Entries[0].EntryId
+	spiretest.AssertProtoListEqual(t, expected.Entries, actual.Entries)
+
+	// Restoring again fails since the datastore is no longer empty
+	env, stdout2, stderr := newTestEnv()
+	require.Equal(t, 1, newRestoreCommand(env).Run([]string{"-config", target.configPath, "-file", archivePath}))
+	require.Empty(t, stdout2.String())
+	require.Equal(t, "CA journal already exists at "+ca.JournalPath(target.dataDir)+"\n", stderr.String())
+}
+
+func TestBackupWithKMSKeyManager(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	archivePath := filepath.Join(dir, "backup.json")
+	source := newTestServer(t, filepath.Join(dir, "source"), "example.org", "aws_kms")
+
+	stdout := runBackup(t, "-config", source.configPath, "-file", archivePath)
+	require.Contains(t, stdout, `Private keys of the "aws_kms" key manager are not included.`)
+
+	a, err := readArchive(archivePath)
+	require.NoError(t, err)
+	require.Equal(t, caArchive{KeyManager: "aws_kms"}, a.CA)
+}
+
+func TestRestoreRequiresEmptyDataStore(t *testing.T) {
+	ctx := context.Background()
+	dir := spiretest.TempDir(t)
+	archivePath := filepath.Join(dir, "backup.json")
+
+	source := newTestServer(t, filepath.Join(dir, "source"), "example.org", "memory")
+	populateDataStore(ctx, t, source)
+	runBackup(t, "-config", source.configPath, "-file", archivePath)
+
+	target := newTestServer(t, filepath.Join(dir, "target"), "example.org", "memory")
+	createBundle(ctx, t, openTestDataStore(ctx, t, target), "spiffe://example.org")
+
+	env, _, stderr := newTestEnv()
+	require.Equal(t, 1, newRestoreCommand(env).Run([]string{"-config", target.configPath, "-file", archivePath}))
+	require.Equal(t, "the datastore is not empty; restore requires a new datastore\n", stderr.String())
+}
+
+func TestRestoreRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	dir := spiretest.TempDir(t)
+	archivePath := filepath.Join(dir, "backup.json")
+	badArchivePath := filepath.Join(dir, "bad-backup.json")
+
+	source := newTestServer(t, filepath.Join(dir, "source"), "example.org", "disk")
+	require.NoError(t, os.MkdirAll(source.dataDir, 0755))
+	require.NoError(t, ioutil.WriteFile(ca.JournalPath(source.dataDir), []byte("JOURNAL"), 0600))
+	populateDataStore(ctx, t, source)
+	runBackup(t, "-config", source.configPath, "-file", archivePath)
+
+	// Agents are restored last. A duplicated agent makes the import fail
+	// after every other record was created.
+	a, err := readArchive(archivePath)
+	require.NoError(t, err)
+	a.Agents = append(a.Agents, a.Agents[0])
+	data, err := json.Marshal(a)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(badArchivePath, data, 0600))
+
+	target := newTestServer(t, filepath.Join(dir, "target"), "example.org", "disk")
+	env, stdout, stderr := newTestEnv()
+	require.Equal(t, 1, newRestoreCommand(env).Run([]string{"-config", target.configPath, "-file", badArchivePath}))
+	require.Empty(t, stdout.String())
+	require.Contains(t, stderr.String(), `unable to restore agent "spiffe://example.org/agent"`)
+	require.NotContains(t, stderr.String(), "unable to roll back")
+
+	// Nothing was left behind
+	restored := new(archive)
+	require.NoError(t, exportDataStore(ctx, openTestDataStore(ctx, t, target), restored))
+	require.Empty(t, restored.Bundles)
+	require.Empty(t, restored.FederationRelationships)
+	require.Empty(t, restored.EntryTemplates)
+	require.Empty(t, restored.Entries)
+	require.Empty(t, restored.Agents)
+	_, err = os.Stat(ca.JournalPath(target.dataDir))
+	require.True(t, os.IsNotExist(err), "CA journal was not removed: %v", err)
+
+	// The restore can be attempted again
+	runRestore(t, "-config", target.configPath, "-file", archivePath)
+}
+
+func TestRestoreRejectsOtherTrustDomain(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	archivePath := filepath.Join(dir, "backup.json")
+
+	source := newTestServer(t, filepath.Join(dir, "source"), "example.org", "memory")
+	runBackup(t, "-config", source.configPath, "-file", archivePath)
+
+	target := newTestServer(t, filepath.Join(dir, "target"), "other.org", "memory")
+	env, _, stderr := newTestEnv()
+	require.Equal(t, 1, newRestoreCommand(env).Run([]string{"-config", target.configPath, "-file", archivePath}))
+	require.Equal(t, `archive is for trust domain "example.org" but the server is configured for "other.org"`+"\n", stderr.String())
+}
+
+func TestBackupRequiresFile(t *testing.T) {
+	source := newTestServer(t, spiretest.TempDir(t), "example.org", "memory")
+	env, _, stderr := newTestEnv()
+	require.Equal(t, 1, newBackupCommand(env).Run([]string{"-config", source.configPath}))
+	require.Equal(t, "an archive file path is required\n", stderr.String())
+}
+
+type testServer struct {
+	configPath string
+	dataDir    string
+	keysPath   string
+}
+
+func newTestServer(t *testing.T, dir, trustDomain, keyManager string) *testServer {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	s := &testServer{
+		configPath: filepath.Join(dir, "server.conf"),
+		dataDir:    filepath.Join(dir, "data"),
+		keysPath:   filepath.Join(dir, "keys.json"),
+	}
+	require.NoError(t, ioutil.WriteFile(s.configPath, []byte(fmt.Sprintf(`
+server {
+	trust_domain = %q
+	data_dir = %q
+}
+
+plugins {
+	DataStore "sql" {
+		plugin_data {
+			database_type = "sqlite3"
+			connection_string = %q
+		}
+	}
+	KeyManager %q {
+		plugin_data {
+			keys_path = %q
+		}
+	}
+}
+`, trustDomain, s.dataDir, filepath.Join(dir, "datastore.sqlite3"), keyManager, s.keysPath)), 0600))
+	return s
+}
+
+func openTestDataStore(ctx context.Context, t *testing.T, s *testServer) datastore.DataStore {
+	flags := &commandFlags{configPath: s.configPath, file: "unused"}
+	config, err := flags.loadServerConfig()
+	require.NoError(t, err)
+	ds, err := config.openDataStore(ctx)
+	require.NoError(t, err)
+	return ds
+}
+
+func populateDataStore(ctx context.Context, t *testing.T, s *testServer) {
+	ds := openTestDataStore(ctx, t, s)
+
+	createBundle(ctx, t, ds, "spiffe://example.org")
+	createBundle(ctx, t, ds, "spiffe://federated.org")
+
+	_, err := ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: &datastore.FederationRelationship{
+			TrustDomainId:         "spiffe://federated.org",
+			BundleEndpointUrl:     "https://federated.org/bundle",
+			BundleEndpointProfile: "https_web",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: &datastore.EntryTemplate{
+			TemplateId:    "web",
+			SpiffeIdPath:  "/web/*",
+			Ttl:           600,
+			FederatesWith: []string{"spiffe://federated.org"},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:   "spiffe://example.org/agent",
+			SpiffeId:   "spiffe://example.org/web/frontend",
+			Selectors:  []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			TemplateId: "web",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            "spiffe://example.org/agent",
+			AttestationDataType: "join_token",
+			CertSerialNumber:    "1234",
+			CertNotAfter:        1000000000,
+		},
+	})
+	require.NoError(t, err)
+	_, err = ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  "spiffe://example.org/agent",
+			Selectors: []*common.Selector{{Type: "node", Value: "az:us-east-1a"}},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func createBundle(ctx context.Context, t *testing.T, ds datastore.DataStore, trustDomainID string) {
+	_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId: trustDomainID,
+			RootCas:       []*common.Certificate{{DerBytes: []byte(trustDomainID)}},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func runBackup(t *testing.T, args ...string) string {
+	env, stdout, stderr := newTestEnv()
+	exitCode := newBackupCommand(env).Run(args)
+	require.Equal(t, 0, exitCode, "stderr: %s", stderr.String())
+	require.Empty(t, stderr.String())
+	return stdout.String()
+}
+
+func runRestore(t *testing.T, args ...string) string {
+	env, stdout, stderr := newTestEnv()
+	exitCode := newRestoreCommand(env).Run(args)
+	require.Equal(t, 0, exitCode, "stderr: %s", stderr.String())
+	require.Empty(t, stderr.String())
+	return stdout.String()
+}
+
+func newTestEnv() (*common_cli.Env, *bytes.Buffer, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	return &common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	}, stdout, stderr
+}
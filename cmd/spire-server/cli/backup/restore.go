@@ -0,0 +1,314 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+// NewRestoreCommand creates a new "restore" command.
+func NewRestoreCommand() cli.Command {
+	return newRestoreCommand(common_cli.DefaultEnv)
+}
+
+func newRestoreCommand(env *common_cli.Env) *restoreCommand {
+	return &restoreCommand{env: env}
+}
+
+type restoreCommand struct {
+	env *common_cli.Env
+	commandFlags
+}
+
+func (c *restoreCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *restoreCommand) Synopsis() string {
+	return "Restores the trust domain state of the server from an archive created by the backup command"
+}
+
+func (c *restoreCommand) parseFlags(args []string) error {
+	return c.parse(c.env, "restore", "Path of the archive file to restore", args)
+}
+
+func (c *restoreCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(context.Background()); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+	return 0
+}
+
+func (c *restoreCommand) run(ctx context.Context) error {
+	config, err := c.loadServerConfig()
+	if err != nil {
+		return err
+	}
+
+	a, err := readArchive(c.file)
+	if err != nil {
+		return err
+	}
+	if a.TrustDomain != config.trustDomain {
+		return fmt.Errorf("archive is for trust domain %q but the server is configured for %q", a.TrustDomain, config.trustDomain)
+	}
+
+	files, err := caFiles(config, &a.CA)
+	if err != nil {
+		return err
+	}
+
+	ds, err := config.openDataStore(ctx)
+	if err != nil {
+		return err
+	}
+	if err := requireEmptyDataStore(ctx, ds); err != nil {
+		return err
+	}
+
+	if err := restoreCAFiles(files); err != nil {
+		return err
+	}
+	if err := importDataStore(ctx, ds, a); err != nil {
+		// Remove the CA files so the restore can be attempted again
+		removeCAFiles(files)
+		return err
+	}
+
+	if a.CA.KeyManager != config.keyManager {
+		if err := c.env.Printf("Warning: the archive was created with the %q key manager but the server is configured with %q. The CA keys in the journal will not be found and the server will prepare a new CA.\n", a.CA.KeyManager, config.keyManager); err != nil {
+			return err
+		}
+	}
+	return c.env.Printf("Trust domain %q restored from %s: %s\n", a.TrustDomain, c.file, a.summary())
+}
+
+func readArchive(path string) (*archive, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	a := new(archive)
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("unable to parse archive: %v", err)
+	}
+	if a.Version != archiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", a.Version)
+	}
+	return a, nil
+}
+
+type caFile struct {
+	name string
+	path string
+	data string
+}
+
+// caFiles returns the CA files to restore. None of them may exist so that a
+// restore never overwrites the state of a server.
+func caFiles(config *serverConfig, in *caArchive) ([]caFile, error) {
+	var files []caFile
+	if in.Journal != "" {
+		files = append(files, caFile{
+			name: "CA journal",
+			path: ca.JournalPath(config.dataDir),
+			data: in.Journal,
+		})
+	}
+	if in.DiskKeys != "" && config.keyManager == diskKeyManager {
+		keysPath, err := config.diskKeysPath()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, caFile{
+			name: "disk key manager keys",
+			path: keysPath,
+			data: in.DiskKeys,
+		})
+	}
+
+	for _, f := range files {
+		switch _, err := os.Stat(f.path); {
+		case err == nil:
+			return nil, fmt.Errorf("%s already exists at %s", f.name, f.path)
+		case !os.IsNotExist(err):
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// restoreCAFiles writes the CA files. If a file cannot be written, the files
+// written so far are removed.
+func restoreCAFiles(files []caFile) error {
+	for i, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+			removeCAFiles(files[:i])
+			return err
+		}
+		if err := writeNewFile(f.path, []byte(f.data)); err != nil {
+			removeCAFiles(files[:i])
+			return fmt.Errorf("unable to restore %s: %v", f.name, err)
+		}
+	}
+	return nil
+}
+
+func removeCAFiles(files []caFile) {
+	for _, f := range files {
+		_ = os.Remove(f.path)
+	}
+}
+
+func requireEmptyDataStore(ctx context.Context, ds datastore.DataStore) error {
+	bundles, err := ds.CountBundles(ctx, &datastore.CountBundlesRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to count bundles: %v", err)
+	}
+	entries, err := ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to count entries: %v", err)
+	}
+	nodes, err := ds.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to count agents: %v", err)
+	}
+	if bundles.Bundles != 0 || entries.Entries != 0 || nodes.Nodes != 0 {
+		return errors.New("the datastore is not empty; restore requires a new datastore")
+	}
+	return nil
+}
+
+// importDataStore creates the archived records. Bundles are created first
+// since entries federating with other trust domains reference them, and entry
+// templates before the entries using them. The datastore has no transactions
+// spanning several operations, so if a record cannot be created, the records
+// created so far are deleted to leave the datastore empty.
+func importDataStore(ctx context.Context, ds datastore.DataStore, a *archive) (err error) {
+	var undo []func() error
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(undo) - 1; i >= 0; i-- {
+			if undoErr := undo[i](); undoErr != nil {
+				err = fmt.Errorf("%v; unable to roll back the restore, the datastore must be recreated: %v", err, undoErr)
+				return
+			}
+		}
+	}()
+
+	for _, bundle := range a.Bundles {
+		if _, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+			Bundle: bundle,
+		}); err != nil {
+			return fmt.Errorf("unable to restore bundle %q: %v", bundle.TrustDomainId, err)
+		}
+		trustDomainID := bundle.TrustDomainId
+		undo = append(undo, func() error {
+			_, err := ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+				TrustDomainId: trustDomainID,
+			})
+			return err
+		})
+	}
+
+	for _, relationship := range a.FederationRelationships {
+		if _, err := ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+			FederationRelationship: relationship,
+		}); err != nil {
+			return fmt.Errorf("unable to restore federation relationship %q: %v", relationship.TrustDomainId, err)
+		}
+		trustDomainID := relationship.TrustDomainId
+		undo = append(undo, func() error {
+			_, err := ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+				TrustDomainId: trustDomainID,
+			})
+			return err
+		})
+	}
+
+	for _, template := range a.EntryTemplates {
+		if _, err := ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+			EntryTemplate: template,
+		}); err != nil {
+			return fmt.Errorf("unable to restore entry template %q: %v", template.TemplateId, err)
+		}
+		templateID := template.TemplateId
+		undo = append(undo, func() error {
+			_, err := ds.DeleteEntryTemplate(ctx, &datastore.DeleteEntryTemplateRequest{
+				TemplateId: templateID,
+			})
+			return err
+		})
+	}
+
+	// The datastore assigns new IDs to the restored entries
+	for _, entry := range a.Entries {
+		resp, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+			Entry: entry,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to restore entry %q: %v", entry.EntryId, err)
+		}
+		entryID := resp.Entry.EntryId
+		undo = append(undo, func() error {
+			_, err := ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+				EntryId: entryID,
+			})
+			return err
+		})
+	}
+
+	for _, node := range a.Agents {
+		if _, err := ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+			Node: node,
+		}); err != nil {
+			return fmt.Errorf("unable to restore agent %q: %v", node.SpiffeId, err)
+		}
+		spiffeID := node.SpiffeId
+		undo = append(undo, func() error {
+			_, err := ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{
+				SpiffeId: spiffeID,
+			})
+			return err
+		})
+		if len(node.Selectors) == 0 {
+			continue
+		}
+		if _, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{
+				SpiffeId:  node.SpiffeId,
+				Selectors: node.Selectors,
+			},
+		}); err != nil {
+			return fmt.Errorf("unable to restore selectors of agent %q: %v", node.SpiffeId, err)
+		}
+		undo = append(undo, func() error {
+			_, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+				Selectors: &datastore.NodeSelectors{
+					SpiffeId: spiffeID,
+				},
+			})
+			return err
+		})
+	}
+	return nil
+}
@@ -22,7 +22,7 @@ func TestShowHelp(t *testing.T) {
 
 	require.Equal(t, `Usage of bundle show:
   -format string
-    	The format to show the bundle. Either "pem" or "spiffe". (default "pem")
+    	The format to show the bundle. One of "pem", "spiffe" or "jwks". (default "pem")
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
 `, test.stderr.String())
@@ -55,6 +55,11 @@ func TestShow(t *testing.T) {
 			args:        []string{"-format", formatSPIFFE},
 			expectedOut: cert1JWKS,
 		},
+		{
+			name:        "jwks",
+			args:        []string{"-format", formatJWKS},
+			expectedOut: "{\n    \"keys\": []\n}\n",
+		},
 		{
 			name:          "server fails",
 			serverErr:     errors.New("some error"),
@@ -93,7 +98,7 @@ func TestSetHelp(t *testing.T) {
 	test.client.Help()
 	require.Equal(t, `Usage of bundle set:
   -format string
-    	The format of the bundle data. Either "pem" or "spiffe". (default "pem")
+    	The format of the bundle data. One of "pem", "spiffe" or "jwks". (default "pem")
   -id string
     	SPIFFE ID of the trust domain
   -path string
@@ -122,6 +127,7 @@ func TestSet(t *testing.T) {
 		stdin          string
 		fileData       string
 		serverErr      error
+		bundles        []*types.Bundle
 		toSet          *types.Bundle
 		setResponse    *bundle.BatchSetFederatedBundleResponse
 	}{
@@ -266,6 +272,81 @@ func TestSet(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "invalid bundle (standard jwks)",
+			stdin:          "invalid bundle",
+			args:           []string{"-id", "spiffe://otherdomain.test", "-format", formatJWKS},
+			expectedStderr: "unable to parse JWKS: invalid character 'i' looking for beginning of value\n",
+		},
+		{
+			name:           "standard jwks without key ID",
+			stdin:          `{"keys": [{"kty": "EC", "crv": "P-256", "x": "fK-wKTnKL7KFLM27lqq5DC-bxrVaH6rDV-IcCSEOeL4", "y": "wq-g3TQWxYlV51TCPH030yXsRxvujD4hUUaIQrXk4KI"}]}`,
+			args:           []string{"-id", "spiffe://otherdomain.test", "-format", formatJWKS},
+			expectedStderr: "unable to parse JWKS: missing key ID in entry 0\n",
+		},
+		{
+			name:  "set bundle (standard jwks)",
+			stdin: key1JWKS,
+			args:  []string{"-id", "spiffe://otherdomain.test", "-format", formatJWKS},
+			toSet: &types.Bundle{
+				TrustDomain: "spiffe://otherdomain.test",
+				JwtAuthorities: []*types.JWTKey{
+					{
+						KeyId:     "KID",
+						PublicKey: key1Pkix,
+					},
+				},
+			},
+			setResponse: &bundle.BatchSetFederatedBundleResponse{
+				Results: []*bundle.BatchSetFederatedBundleResponse_Result{
+					{
+						Status: &types.Status{Code: int32(codes.OK)},
+					},
+				},
+			},
+		},
+		{
+			name:  "set bundle (standard jwks) preserves X.509 authorities",
+			stdin: key1JWKS,
+			args:  []string{"-id", "spiffe://otherdomain.test", "-format", formatJWKS},
+			bundles: []*types.Bundle{
+				{
+					TrustDomain: "spiffe://otherdomain.test",
+					X509Authorities: []*types.X509Certificate{
+						{
+							Asn1: cert1.Raw,
+						},
+					},
+					JwtAuthorities: []*types.JWTKey{
+						{
+							KeyId:     "OLDKID",
+							PublicKey: key1Pkix,
+						},
+					},
+				},
+			},
+			toSet: &types.Bundle{
+				TrustDomain: "spiffe://otherdomain.test",
+				X509Authorities: []*types.X509Certificate{
+					{
+						Asn1: cert1.Raw,
+					},
+				},
+				JwtAuthorities: []*types.JWTKey{
+					{
+						KeyId:     "KID",
+						PublicKey: key1Pkix,
+					},
+				},
+			},
+			setResponse: &bundle.BatchSetFederatedBundleResponse{
+				Results: []*bundle.BatchSetFederatedBundleResponse_Result{
+					{
+						Status: &types.Status{Code: int32(codes.OK)},
+					},
+				},
+			},
+		},
 		{
 			name:           "invalid file name",
 			expectedStderr: "unable to load bundle data: open /not/a/real/path/to/a/bundle: no such file or directory\n",
@@ -354,6 +435,7 @@ func TestSet(t *testing.T) {
 			test.server.expectedSetBundle = tt.toSet
 			test.server.setResponse = tt.setResponse
 			test.server.err = tt.serverErr
+			test.server.bundles = tt.bundles
 
 			test.stdin.WriteString(tt.stdin)
 			if tt.fileData != "" {
@@ -384,7 +466,7 @@ func TestListHelp(t *testing.T) {
 
 	require.Equal(t, `Usage of bundle list:
   -format string
-    	The format to list federated bundles. Either "pem" or "spiffe". (default "pem")
+    	The format to list federated bundles. One of "pem", "spiffe" or "jwks". (default "pem")
   -id string
     	SPIFFE ID of the trust domain
   -registrationUDSPath string
@@ -429,6 +511,11 @@ func TestList(t *testing.T) {
 			args:           []string{"-format", formatSPIFFE},
 			expectedStdout: allBundlesJWKS,
 		},
+		{
+			name:           "all bundles (standard jwks)",
+			args:           []string{"-format", formatJWKS},
+			expectedStdout: allBundlesStandardJWKS,
+		},
 		{
 			name:           "one bundle (default)",
 			args:           []string{"-id", "spiffe://domain2.test"},
@@ -460,6 +547,11 @@ func TestList(t *testing.T) {
 			args:           []string{"-id", "spiffe://domain2.test", "-format", formatSPIFFE},
 			expectedStdout: cert2JWKS,
 		},
+		{
+			name:           "one bundle (standard jwks)",
+			args:           []string{"-id", "spiffe://domain1.test", "-format", formatJWKS},
+			expectedStdout: key1JWKS,
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
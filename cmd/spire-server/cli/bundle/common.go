@@ -18,6 +18,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/zeebo/errs"
+	"gopkg.in/square/go-jose.v2"
 )
 
 const (
@@ -27,8 +28,13 @@ const (
 `
 	formatPEM    = "pem"
 	formatSPIFFE = "spiffe"
+	// formatJWKS is a standard JWKS document holding the JWT authorities
+	formatJWKS = "jwks"
 )
 
+// formatsUsage describes the supported formats in flag usages
+var formatsUsage = fmt.Sprintf("One of %q, %q or %q.", formatPEM, formatSPIFFE, formatJWKS)
+
 // loadParamData loads the data from a parameter. If the parameter is empty then
 // data is ready from "in", otherwise the parameter is used as a filename to
 // read file contents.
@@ -95,6 +101,63 @@ func printBundle(out io.Writer, bundle *types.Bundle) error {
 	return nil
 }
 
+// printJWTAuthorities prints the JWT authorities as a standard JWKS document
+// using the provided writer
+func printJWTAuthorities(out io.Writer, keys []*types.JWTKey) error {
+	jwks := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{},
+	}
+	for i, key := range keys {
+		publicKey, err := x509.ParsePKIXPublicKey(key.PublicKey)
+		if err != nil {
+			return fmt.Errorf("unable to parse JWT signing key %d: %v", i, err)
+		}
+		jwks.Keys = append(jwks.Keys, jose.JSONWebKey{
+			Key:   publicKey,
+			KeyID: key.KeyId,
+		})
+	}
+
+	docBytes, err := json.MarshalIndent(jwks, "", "    ")
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	if _, err := fmt.Fprintln(out, string(docBytes)); err != nil {
+		return errs.Wrap(err)
+	}
+
+	return nil
+}
+
+// jwtAuthoritiesFromJWKS parses the JWT authorities out of a standard JWKS
+// document. Every key must have a key ID.
+func jwtAuthoritiesFromJWKS(data []byte) ([]*types.JWTKey, error) {
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+
+	var keys []*types.JWTKey
+	for i, key := range jwks.Keys {
+		if key.KeyID == "" {
+			return nil, fmt.Errorf("missing key ID in entry %d", i)
+		}
+		if !key.IsPublic() {
+			return nil, fmt.Errorf("entry %d is not a public key", i)
+		}
+		pkixBytes, err := x509.MarshalPKIXPublicKey(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal entry %d: %v", i, err)
+		}
+		keys = append(keys, &types.JWTKey{
+			KeyId:     key.KeyID,
+			PublicKey: pkixBytes,
+		})
+	}
+	return keys, nil
+}
+
 // bundleFromProto converts a bundle from the given *types.Bundle to *spiffebundle.Bundle
 func bundleFromProto(bundleProto *types.Bundle) (*spiffebundle.Bundle, error) {
 	td, err := spiffeid.TrustDomainFromString(bundleProto.TrustDomain)
@@ -230,11 +293,14 @@ func printBundleWithFormat(out io.Writer, bundle *types.Bundle, format string, h
 		}
 	}
 
-	if format == formatPEM {
+	switch format {
+	case formatPEM:
 		return printX509Authorities(out, bundle.X509Authorities)
+	case formatJWKS:
+		return printJWTAuthorities(out, bundle.JwtAuthorities)
+	default:
+		return printBundle(out, bundle)
 	}
-
-	return printBundle(out, bundle)
 }
 
 // validateFormat validates that the provided format is a valid format.
@@ -249,6 +315,7 @@ func validateFormat(format string) (string, error) {
 	switch format {
 	case formatPEM:
 	case formatSPIFFE:
+	case formatJWKS:
 	default:
 		return "", fmt.Errorf("invalid format: %q", format)
 	}
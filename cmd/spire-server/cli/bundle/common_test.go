@@ -96,6 +96,42 @@ diIqWtxAqBLFrx8zNS4=
         }
     ]
 }
+`
+
+	key1JWKS = `{
+    "keys": [
+        {
+            "kty": "EC",
+            "kid": "KID",
+            "crv": "P-256",
+            "x": "fK-wKTnKL7KFLM27lqq5DC-bxrVaH6rDV-IcCSEOeL4",
+            "y": "wq-g3TQWxYlV51TCPH030yXsRxvujD4hUUaIQrXk4KI"
+        }
+    ]
+}
+`
+
+	allBundlesStandardJWKS = `****************************************
+* spiffe://domain1.test
+****************************************
+{
+    "keys": [
+        {
+            "kty": "EC",
+            "kid": "KID",
+            "crv": "P-256",
+            "x": "fK-wKTnKL7KFLM27lqq5DC-bxrVaH6rDV-IcCSEOeL4",
+            "y": "wq-g3TQWxYlV51TCPH030yXsRxvujD4hUUaIQrXk4KI"
+        }
+    ]
+}
+
+****************************************
+* spiffe://domain2.test
+****************************************
+{
+    "keys": []
+}
 `
 
 	allBundlesPEM = `****************************************
@@ -249,6 +285,7 @@ type fakeBundleServer struct {
 
 	t                 testing.TB
 	bundles           []*types.Bundle
+	federatedBundles  []*types.Bundle
 	deleteResults     []*bundle.BatchDeleteFederatedBundleResponse_Result
 	err               error
 	expectedSetBundle *types.Bundle
@@ -279,8 +316,12 @@ func (f *fakeBundleServer) ListFederatedBundles(context.Context, *bundle.ListFed
 	if f.err != nil {
 		return nil, f.err
 	}
+	bundles := f.bundles
+	if f.federatedBundles != nil {
+		bundles = f.federatedBundles
+	}
 	return &bundle.ListFederatedBundlesResponse{
-		Bundles: f.bundles,
+		Bundles: bundles,
 	}, nil
 }
 
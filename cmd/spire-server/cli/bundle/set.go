@@ -16,6 +16,7 @@ import (
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // NewSetCommand creates a new "set" subcommand for "bundle" command.
@@ -48,7 +49,7 @@ func (c *setCommand) Synopsis() string {
 func (c *setCommand) AppendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.id, "id", "", "SPIFFE ID of the trust domain")
 	fs.StringVar(&c.path, "path", "", "Path to the bundle data")
-	fs.StringVar(&c.format, "format", formatPEM, fmt.Sprintf("The format of the bundle data. Either %q or %q.", formatPEM, formatSPIFFE))
+	fs.StringVar(&c.format, "format", formatPEM, "The format of the bundle data. "+formatsUsage)
 }
 
 func (c *setCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
@@ -81,6 +82,21 @@ func (c *setCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 		}
 
 		federatedBundles = append(federatedBundles, bundleProtoFromX509Authorities(id, rootCAs))
+	case formatJWKS:
+		jwtAuthorities, err := jwtAuthoritiesFromJWKS(bundleBytes)
+		if err != nil {
+			return fmt.Errorf("unable to parse JWKS: %v", err)
+		}
+
+		// A JWKS only holds the JWT authorities, so the X.509 authorities
+		// of the current bundle, if any, are preserved.
+		current, err := fetchFederatedBundle(ctx, serverClient.NewBundleClient(), id)
+		if err != nil {
+			return err
+		}
+		current.JwtAuthorities = jwtAuthorities
+
+		federatedBundles = append(federatedBundles, current)
 	default:
 		td, err := spiffeid.TrustDomainFromString(c.id)
 		if err != nil {
@@ -117,3 +133,19 @@ func (c *setCommand) Run(ctx context.Context, env *common_cli.Env, serverClient
 		return fmt.Errorf("failed to set federated bundle: %s", result.Status.Message)
 	}
 }
+
+// fetchFederatedBundle returns the federated bundle of the trust domain, or
+// an empty bundle if there is none yet.
+func fetchFederatedBundle(ctx context.Context, bundleClient bundle.BundleClient, id string) (*types.Bundle, error) {
+	resp, err := bundleClient.GetFederatedBundle(ctx, &bundle.GetFederatedBundleRequest{
+		TrustDomain: id,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return resp, nil
+	case codes.NotFound:
+		return &types.Bundle{TrustDomain: id}, nil
+	default:
+		return nil, fmt.Errorf("failed to fetch federated bundle: %v", err)
+	}
+}
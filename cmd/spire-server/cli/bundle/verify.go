@@ -0,0 +1,349 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+
+	severityError   = "error"
+	severityWarning = "warning"
+
+	// checkInvalidAuthority reports authorities that cannot be parsed
+	checkInvalidAuthority = "invalid_authority"
+	// checkDuplicateAuthority reports authorities present more than once
+	checkDuplicateAuthority = "duplicate_authority"
+	// checkMismatchedKeyUse reports keys that cannot be used for the purpose
+	// of the authority they are published as
+	checkMismatchedKeyUse = "mismatched_key_use"
+	// checkExpiredAuthority reports expired authorities that have not been
+	// pruned yet
+	checkExpiredAuthority = "expired_authority"
+	// checkExpiringAuthorities reports bundles whose authorities all expire
+	// within the expiry threshold
+	checkExpiringAuthorities = "expiring_authorities"
+	// checkNoValidAuthority reports bundles without any valid X.509 authority
+	checkNoValidAuthority = "no_valid_authority"
+
+	authorityTypeX509 = "x509"
+	authorityTypeJWT  = "jwt"
+
+	defaultExpiryThreshold = 6 * time.Hour
+)
+
+// NewVerifyCommand creates a new "verify" subcommand for "bundle" command.
+func NewVerifyCommand() cli.Command {
+	return newVerifyCommand(common_cli.DefaultEnv, clock.New())
+}
+
+func newVerifyCommand(env *common_cli.Env, clk clock.Clock) cli.Command {
+	return util.AdaptCommand(env, &verifyCommand{clk: clk})
+}
+
+type verifyCommand struct {
+	clk clock.Clock
+
+	// Bundles whose authorities all expire within this threshold are
+	// reported
+	expiryThreshold time.Duration
+
+	// Output format (text or json)
+	output string
+}
+
+// verifyResult is the outcome of the verification of the bundles.
+type verifyResult struct {
+	// Healthy is false if any finding has the error severity
+	Healthy bool                 `json:"healthy"`
+	Bundles []verifyBundleResult `json:"bundles"`
+}
+
+type verifyBundleResult struct {
+	TrustDomain     string          `json:"trust_domain"`
+	Federated       bool            `json:"federated"`
+	Stale           bool            `json:"stale"`
+	X509Authorities int             `json:"x509_authorities"`
+	JWTAuthorities  int             `json:"jwt_authorities"`
+	Findings        []verifyFinding `json:"findings"`
+}
+
+type verifyFinding struct {
+	Severity      string `json:"severity"`
+	Check         string `json:"check"`
+	AuthorityType string `json:"authority_type,omitempty"`
+	AuthorityID   string `json:"authority_id,omitempty"`
+	Message       string `json:"message"`
+}
+
+func (c *verifyCommand) Name() string {
+	return "bundle verify"
+}
+
+func (c *verifyCommand) Synopsis() string {
+	return "Verifies the content of the server and federated bundles"
+}
+
+func (c *verifyCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&c.expiryThreshold, "expiry-threshold", defaultExpiryThreshold, "Report bundles whose authorities all expire within this duration. Federated bundles in that state are reported as stale.")
+	fs.StringVar(&c.output, "output", outputText, fmt.Sprintf("The output format. Either %q or %q.", outputText, outputJSON))
+}
+
+func (c *verifyCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.output != outputText && c.output != outputJSON {
+		return fmt.Errorf("invalid output format: %q", c.output)
+	}
+	if c.expiryThreshold < 0 {
+		return fmt.Errorf("expiry threshold must not be negative: got %v", c.expiryThreshold)
+	}
+
+	bundleClient := serverClient.NewBundleClient()
+	serverBundle, err := bundleClient.GetBundle(ctx, &bundle.GetBundleRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch bundle: %v", err)
+	}
+
+	var federatedBundles []*types.Bundle
+	req := &bundle.ListFederatedBundlesRequest{}
+	for {
+		resp, err := bundleClient.ListFederatedBundles(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to list federated bundles: %v", err)
+		}
+		federatedBundles = append(federatedBundles, resp.Bundles...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	now := c.clk.Now()
+	var result verifyResult
+	result.Bundles = append(result.Bundles, c.verifyBundle(now, serverBundle, false))
+	for _, federatedBundle := range federatedBundles {
+		result.Bundles = append(result.Bundles, c.verifyBundle(now, federatedBundle, true))
+	}
+
+	errCount := 0
+	for _, b := range result.Bundles {
+		for _, finding := range b.Findings {
+			if finding.Severity == severityError {
+				errCount++
+			}
+		}
+	}
+	result.Healthy = errCount == 0
+
+	if err := printVerifyResult(env, c.output, result); err != nil {
+		return err
+	}
+
+	if errCount > 0 {
+		return errors.New(util.Pluralizer(fmt.Sprintf("bundle verification found %d ", errCount), "error", "errors", errCount))
+	}
+	return nil
+}
+
+func (c *verifyCommand) verifyBundle(now time.Time, b *types.Bundle, federated bool) verifyBundleResult {
+	result := verifyBundleResult{
+		TrustDomain:     b.TrustDomain,
+		Federated:       federated,
+		X509Authorities: len(b.X509Authorities),
+		JWTAuthorities:  len(b.JwtAuthorities),
+		Findings:        []verifyFinding{},
+	}
+	addFinding := func(severity, check, authorityType, authorityID, format string, args ...interface{}) {
+		result.Findings = append(result.Findings, verifyFinding{
+			Severity:      severity,
+			Check:         check,
+			AuthorityType: authorityType,
+			AuthorityID:   authorityID,
+			Message:       fmt.Sprintf(format, args...),
+		})
+	}
+
+	// The public keys of the X.509 authorities, used to detect keys that are
+	// also published as JWT authorities
+	x509Keys := make(map[string]string)
+
+	seenCerts := make(map[string]bool)
+	var latestNotAfter time.Time
+	for i, authority := range b.X509Authorities {
+		cert, err := x509.ParseCertificate(authority.Asn1)
+		if err != nil {
+			addFinding(severityError, checkInvalidAuthority, authorityTypeX509, "",
+				"X.509 authority %d cannot be parsed: %v", i, err)
+			continue
+		}
+
+		fingerprint := certificateFingerprint(cert)
+		if seenCerts[fingerprint] {
+			addFinding(severityWarning, checkDuplicateAuthority, authorityTypeX509, fingerprint,
+				"X.509 authority is present more than once")
+			continue
+		}
+		seenCerts[fingerprint] = true
+
+		if pkixBytes, err := x509.MarshalPKIXPublicKey(cert.PublicKey); err == nil {
+			x509Keys[string(pkixBytes)] = fingerprint
+		}
+
+		switch {
+		case !cert.IsCA:
+			addFinding(severityError, checkMismatchedKeyUse, authorityTypeX509, fingerprint,
+				"X.509 authority is not a CA certificate")
+		case cert.KeyUsage != 0 && cert.KeyUsage&x509.KeyUsageCertSign == 0:
+			addFinding(severityError, checkMismatchedKeyUse, authorityTypeX509, fingerprint,
+				"X.509 authority key usage does not allow signing certificates")
+		}
+
+		if !now.Before(cert.NotAfter) {
+			addFinding(severityWarning, checkExpiredAuthority, authorityTypeX509, fingerprint,
+				"X.509 authority expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+		if cert.NotAfter.After(latestNotAfter) {
+			latestNotAfter = cert.NotAfter
+		}
+	}
+
+	switch {
+	case !now.Before(latestNotAfter):
+		// Also covers bundles without any X.509 authority
+		result.Stale = federated
+		addFinding(severityError, checkNoValidAuthority, "", "",
+			"bundle has no valid X.509 authority")
+	case latestNotAfter.Sub(now) < c.expiryThreshold:
+		result.Stale = federated
+		msg := "every X.509 authority expires within %s; the latest expires at %s"
+		if federated {
+			msg += "; the bundle is stale"
+		}
+		addFinding(severityWarning, checkExpiringAuthorities, authorityTypeX509, "",
+			msg, c.expiryThreshold, latestNotAfter.UTC().Format(time.RFC3339))
+	}
+
+	seenKeyIDs := make(map[string][]byte)
+	neverExpires := false
+	var latestExpiresAt time.Time
+	for i, authority := range b.JwtAuthorities {
+		if authority.KeyId == "" {
+			addFinding(severityError, checkInvalidAuthority, authorityTypeJWT, "",
+				"JWT authority %d has no key ID", i)
+			continue
+		}
+		if _, err := x509.ParsePKIXPublicKey(authority.PublicKey); err != nil {
+			addFinding(severityError, checkInvalidAuthority, authorityTypeJWT, authority.KeyId,
+				"JWT authority cannot be parsed: %v", err)
+			continue
+		}
+
+		if publicKey, ok := seenKeyIDs[authority.KeyId]; ok {
+			if bytes.Equal(publicKey, authority.PublicKey) {
+				addFinding(severityWarning, checkDuplicateAuthority, authorityTypeJWT, authority.KeyId,
+					"JWT authority is present more than once")
+			} else {
+				addFinding(severityError, checkDuplicateAuthority, authorityTypeJWT, authority.KeyId,
+					"key ID is used by JWT authorities with different keys")
+			}
+			continue
+		}
+		seenKeyIDs[authority.KeyId] = authority.PublicKey
+
+		if fingerprint, ok := x509Keys[string(authority.PublicKey)]; ok {
+			addFinding(severityError, checkMismatchedKeyUse, authorityTypeJWT, authority.KeyId,
+				"JWT authority key is also the key of X.509 authority %s", fingerprint)
+		}
+
+		if authority.ExpiresAt == 0 {
+			neverExpires = true
+			continue
+		}
+		expiresAt := time.Unix(authority.ExpiresAt, 0)
+		if !now.Before(expiresAt) {
+			addFinding(severityWarning, checkExpiredAuthority, authorityTypeJWT, authority.KeyId,
+				"JWT authority expired at %s", expiresAt.UTC().Format(time.RFC3339))
+		}
+		if expiresAt.After(latestExpiresAt) {
+			latestExpiresAt = expiresAt
+		}
+	}
+
+	if len(seenKeyIDs) > 0 && !neverExpires && latestExpiresAt.Sub(now) < c.expiryThreshold {
+		addFinding(severityWarning, checkExpiringAuthorities, authorityTypeJWT, "",
+			"every JWT authority expires within %s", c.expiryThreshold)
+	}
+
+	return result
+}
+
+// certificateFingerprint returns the hex encoded SHA-256 digest of the
+// certificate, used to identify X.509 authorities in the findings
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func printVerifyResult(env *common_cli.Env, output string, result verifyResult) error {
+	if output == outputJSON {
+		data, err := json.MarshalIndent(result, "", "    ")
+		if err != nil {
+			return err
+		}
+		return env.Println(string(data))
+	}
+
+	for i, b := range result.Bundles {
+		if i != 0 {
+			if err := env.Println(); err != nil {
+				return err
+			}
+		}
+
+		title := "Trust domain"
+		if b.Federated {
+			title = "Federated trust domain"
+		}
+		if b.Stale {
+			title += " (stale)"
+		}
+		if err := env.Printf("%s: %s\n", title, b.TrustDomain); err != nil {
+			return err
+		}
+		if err := env.Printf("  X.509 authorities : %d\n  JWT authorities   : %d\n", b.X509Authorities, b.JWTAuthorities); err != nil {
+			return err
+		}
+		if len(b.Findings) == 0 {
+			if err := env.Println("  No issues found"); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, finding := range b.Findings {
+			authority := ""
+			if finding.AuthorityID != "" {
+				authority = fmt.Sprintf(" [%s %s]", finding.AuthorityType, finding.AuthorityID)
+			}
+			if err := env.Printf("  %s: %s%s: %s\n", finding.Severity, finding.Check, authority, finding.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package bundle
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyHelp(t *testing.T) {
+	test := setupTest(t, newTestVerifyCommand(clock.NewMock(t)))
+	test.client.Help()
+
+	require.Equal(t, `Usage of bundle verify:
+  -expiry-threshold duration
+    	Report bundles whose authorities all expire within this duration. Federated bundles in that state are reported as stale. (default 6h0m0s)
+  -output string
+    	The output format. Either "text" or "json". (default "text")
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, test.stderr.String())
+}
+
+func TestVerifySynopsis(t *testing.T) {
+	test := setupTest(t, newTestVerifyCommand(clock.NewMock(t)))
+	require.Equal(t, "Verifies the content of the server and federated bundles", test.client.Synopsis())
+}
+
+func TestVerify(t *testing.T) {
+	clk := clock.NewMock(t)
+	now := clk.Now()
+
+	validCA, validCASigner := testca.CreateCACertificate(t, nil, nil,
+		testca.WithLifetime(now.Add(-time.Hour), now.Add(24*time.Hour)))
+	expiringCA, _ := testca.CreateCACertificate(t, nil, nil,
+		testca.WithLifetime(now.Add(-time.Hour), now.Add(time.Hour)))
+	expiredCA, _ := testca.CreateCACertificate(t, nil, nil,
+		testca.WithLifetime(now.Add(-2*time.Hour), now.Add(-time.Hour)))
+	leaf, _ := testca.CreateX509Certificate(t, validCA, validCASigner,
+		testca.WithLifetime(now.Add(-time.Hour), now.Add(24*time.Hour)))
+
+	validCAKey, err := x509.MarshalPKIXPublicKey(validCA.PublicKey)
+	require.NoError(t, err)
+	leafKey, err := x509.MarshalPKIXPublicKey(leaf.PublicKey)
+	require.NoError(t, err)
+
+	x509Authority := func(cert *x509.Certificate) *types.X509Certificate {
+		return &types.X509Certificate{Asn1: cert.Raw}
+	}
+
+	for _, tt := range []struct {
+		name             string
+		args             []string
+		bundle           *types.Bundle
+		federatedBundles []*types.Bundle
+		expectedStdout   string
+		expectedStderr   string
+		expectedChecks   map[string][]string
+		expectedStale    []string
+	}{
+		{
+			name:           "invalid output",
+			args:           []string{"-output", "yaml"},
+			expectedStderr: "invalid output format: \"yaml\"\n",
+		},
+		{
+			name: "healthy bundles",
+			bundle: &types.Bundle{
+				TrustDomain:     "example.test",
+				X509Authorities: []*types.X509Certificate{x509Authority(validCA)},
+				JwtAuthorities: []*types.JWTKey{
+					{KeyId: "KID", PublicKey: leafKey, ExpiresAt: now.Add(24 * time.Hour).Unix()},
+				},
+			},
+			federatedBundles: []*types.Bundle{
+				{
+					TrustDomain:     "domain.test",
+					X509Authorities: []*types.X509Certificate{x509Authority(validCA)},
+				},
+			},
+			expectedStdout: `Trust domain: example.test
+  X.509 authorities : 1
+  JWT authorities   : 1
+  No issues found
+
+Federated trust domain: domain.test
+  X.509 authorities : 1
+  JWT authorities   : 0
+  No issues found
+`,
+		},
+		{
+			name: "warnings",
+			bundle: &types.Bundle{
+				TrustDomain: "example.test",
+				X509Authorities: []*types.X509Certificate{
+					x509Authority(expiringCA),
+					x509Authority(expiringCA),
+					x509Authority(expiredCA),
+				},
+				JwtAuthorities: []*types.JWTKey{
+					{KeyId: "KID", PublicKey: leafKey, ExpiresAt: now.Add(time.Hour).Unix()},
+					{KeyId: "KID", PublicKey: leafKey, ExpiresAt: now.Add(time.Hour).Unix()},
+				},
+			},
+			federatedBundles: []*types.Bundle{
+				{
+					TrustDomain:     "domain.test",
+					X509Authorities: []*types.X509Certificate{x509Authority(expiringCA)},
+				},
+			},
+			expectedChecks: map[string][]string{
+				"example.test": {
+					"warning:duplicate_authority",
+					"warning:expired_authority",
+					"warning:expiring_authorities",
+					"warning:duplicate_authority",
+					"warning:expiring_authorities",
+				},
+				"domain.test": {
+					"warning:expiring_authorities",
+				},
+			},
+			expectedStale: []string{"domain.test"},
+		},
+		{
+			name: "errors",
+			bundle: &types.Bundle{
+				TrustDomain: "example.test",
+				X509Authorities: []*types.X509Certificate{
+					x509Authority(validCA),
+					x509Authority(leaf),
+					{Asn1: []byte("malformed")},
+				},
+				JwtAuthorities: []*types.JWTKey{
+					{KeyId: "KID", PublicKey: validCAKey},
+					{KeyId: "KID", PublicKey: leafKey},
+					{PublicKey: leafKey},
+				},
+			},
+			federatedBundles: []*types.Bundle{
+				{
+					TrustDomain:     "domain.test",
+					X509Authorities: []*types.X509Certificate{x509Authority(expiredCA)},
+				},
+			},
+			expectedChecks: map[string][]string{
+				"example.test": {
+					"error:mismatched_key_use",
+					"error:invalid_authority",
+					"error:mismatched_key_use",
+					"error:duplicate_authority",
+					"error:invalid_authority",
+				},
+				"domain.test": {
+					"warning:expired_authority",
+					"error:no_valid_authority",
+				},
+			},
+			expectedStale:  []string{"domain.test"},
+			expectedStderr: "bundle verification found 6 errors\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newTestVerifyCommand(clk))
+			test.server.bundles = []*types.Bundle{tt.bundle}
+			test.server.federatedBundles = tt.federatedBundles
+
+			args := test.args
+			if tt.expectedChecks != nil {
+				args = append(args, "-output", "json")
+			}
+			rc := test.client.Run(append(args, tt.args...))
+
+			if tt.expectedStderr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expectedStderr, test.stderr.String())
+			} else {
+				require.Equal(t, 0, rc)
+				require.Empty(t, test.stderr.String())
+			}
+
+			if tt.expectedStdout != "" {
+				require.Equal(t, tt.expectedStdout, test.stdout.String())
+			}
+
+			if tt.expectedChecks != nil {
+				var result verifyResult
+				require.NoError(t, json.Unmarshal(test.stdout.Bytes(), &result))
+				require.Equal(t, tt.expectedStderr == "", result.Healthy)
+
+				checks := make(map[string][]string)
+				var stale []string
+				for _, b := range result.Bundles {
+					for _, finding := range b.Findings {
+						checks[b.TrustDomain] = append(checks[b.TrustDomain], finding.Severity+":"+finding.Check)
+					}
+					if b.Stale {
+						stale = append(stale, b.TrustDomain)
+					}
+				}
+				require.Equal(t, tt.expectedChecks, checks)
+				require.Equal(t, tt.expectedStale, stale)
+			}
+		})
+	}
+}
+
+func newTestVerifyCommand(clk *clock.Mock) func(*common_cli.Env) cli.Command {
+	return func(env *common_cli.Env) cli.Command {
+		return newVerifyCommand(env, clk)
+	}
+}
@@ -0,0 +1,299 @@
+package ca_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/cli/ca"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	localauthoritypb "github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	x509Authority = &localauthoritypb.AuthorityState{
+		AuthorityId: "0102030405",
+		ExpiresAt:   1552410266,
+	}
+	jwtAuthority = &localauthoritypb.AuthorityState{
+		AuthorityId: "KID",
+		ExpiresAt:   1552413866,
+	}
+
+	authoritiesOutput = `X509 authority  : 0102030405
+Expires at      : 2019-03-12T17:04:26Z
+JWT authority   : KID
+Expires at      : 2019-03-12T18:04:26Z
+`
+)
+
+type caTest struct {
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+
+	args   []string
+	server *fakeLocalAuthorityServer
+
+	client cli.Command
+}
+
+func TestPrepareHelp(t *testing.T) {
+	test := setupTest(t, ca.NewPrepareCommandWithEnv)
+
+	test.client.Help()
+	require.Equal(t, `Usage of ca prepare:
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, test.stderr.String())
+}
+
+func TestPrepare(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		serverErr      error
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "success",
+			expectStdout: "Prepared next authorities:\n" + authoritiesOutput,
+		},
+		{
+			name:           "server error",
+			serverErr:      status.Error(codes.Internal, "oh no"),
+			expectStderr:   "rpc error: code = Internal desc = oh no\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, ca.NewPrepareCommandWithEnv)
+			test.server.err = tt.serverErr
+
+			exitCode := test.client.Run(test.args)
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+			require.Equal(t, tt.serverErr == nil, test.server.prepared)
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		serverErr      error
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "success",
+			expectStdout: "Activated authorities:\n" + authoritiesOutput,
+		},
+		{
+			name:           "server error",
+			serverErr:      status.Error(codes.Internal, "oh no"),
+			expectStderr:   "rpc error: code = Internal desc = oh no\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, ca.NewRotateCommandWithEnv)
+			test.server.err = tt.serverErr
+
+			exitCode := test.client.Run(test.args)
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+			require.Equal(t, tt.serverErr == nil, test.server.rotated)
+		})
+	}
+}
+
+func TestTaintHelp(t *testing.T) {
+	test := setupTest(t, ca.NewTaintCommandWithEnv)
+
+	test.client.Help()
+	require.Equal(t, `Usage of ca taint:
+  -authorityID string
+    	ID of the authority. For X509 authorities, it is the subject key ID of the CA certificate. For JWT authorities, it is the key ID.
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -type string
+    	Type of the authority (x509 or jwt) (default "x509")
+`, test.stderr.String())
+}
+
+func TestTaint(t *testing.T) {
+	testAuthorityCommand(t, ca.NewTaintCommandWithEnv, "Tainted authority:\n", map[string]string{
+		"x509": "TaintX509Authority",
+		"jwt":  "TaintJWTAuthority",
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	testAuthorityCommand(t, ca.NewRevokeCommandWithEnv, "Revoked authority:\n", map[string]string{
+		"x509": "RevokeX509Authority",
+		"jwt":  "RevokeJWTAuthority",
+	})
+}
+
+func testAuthorityCommand(t *testing.T, newClient func(*common_cli.Env) cli.Command, header string, expectCalls map[string]string) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		serverErr      error
+		expectCall     string
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "x509 authority",
+			args:         []string{"-authorityID", "0102030405"},
+			expectCall:   expectCalls["x509"],
+			expectStdout: header + "X509 authority  : 0102030405\nExpires at      : 2019-03-12T17:04:26Z\n",
+		},
+		{
+			name:         "jwt authority",
+			args:         []string{"-type", "jwt", "-authorityID", "KID"},
+			expectCall:   expectCalls["jwt"],
+			expectStdout: header + "JWT authority   : KID\nExpires at      : 2019-03-12T18:04:26Z\n",
+		},
+		{
+			name:           "missing authority ID",
+			expectStderr:   "an authority ID is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "unknown authority type",
+			args:           []string{"-type", "foo", "-authorityID", "KID"},
+			expectStderr:   `unknown authority type "foo": must be "x509" or "jwt"` + "\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "server error",
+			args:           []string{"-authorityID", "0102030405"},
+			serverErr:      status.Error(codes.FailedPrecondition, "oh no"),
+			expectStderr:   "rpc error: code = FailedPrecondition desc = oh no\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newClient)
+			test.server.err = tt.serverErr
+
+			exitCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+			require.Equal(t, tt.expectCall, test.server.call)
+		})
+	}
+}
+
+func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *caTest {
+	server := &fakeLocalAuthorityServer{}
+
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
+		localauthoritypb.RegisterLocalAuthorityServer(s, server)
+	})
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	client := newClient(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	return &caTest{
+		stdout: stdout,
+		stderr: stderr,
+		args:   []string{"-registrationUDSPath", socketPath},
+		server: server,
+		client: client,
+	}
+}
+
+type fakeLocalAuthorityServer struct {
+	localauthoritypb.UnimplementedLocalAuthorityServer
+
+	err error
+
+	prepared bool
+	rotated  bool
+	call     string
+}
+
+func (s *fakeLocalAuthorityServer) PrepareNextAuthority(ctx context.Context, req *localauthoritypb.PrepareNextAuthorityRequest) (*localauthoritypb.PrepareNextAuthorityResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.prepared = true
+	return &localauthoritypb.PrepareNextAuthorityResponse{
+		X509Authority: x509Authority,
+		JwtAuthority:  jwtAuthority,
+	}, nil
+}
+
+func (s *fakeLocalAuthorityServer) RotateAuthority(ctx context.Context, req *localauthoritypb.RotateAuthorityRequest) (*localauthoritypb.RotateAuthorityResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.rotated = true
+	return &localauthoritypb.RotateAuthorityResponse{
+		X509Authority: x509Authority,
+		JwtAuthority:  jwtAuthority,
+	}, nil
+}
+
+func (s *fakeLocalAuthorityServer) TaintX509Authority(ctx context.Context, req *localauthoritypb.TaintX509AuthorityRequest) (*localauthoritypb.TaintX509AuthorityResponse, error) {
+	if err := s.authorityCall("TaintX509Authority", req.AuthorityId, x509Authority); err != nil {
+		return nil, err
+	}
+	return &localauthoritypb.TaintX509AuthorityResponse{TaintedAuthority: x509Authority}, nil
+}
+
+func (s *fakeLocalAuthorityServer) RevokeX509Authority(ctx context.Context, req *localauthoritypb.RevokeX509AuthorityRequest) (*localauthoritypb.RevokeX509AuthorityResponse, error) {
+	if err := s.authorityCall("RevokeX509Authority", req.AuthorityId, x509Authority); err != nil {
+		return nil, err
+	}
+	return &localauthoritypb.RevokeX509AuthorityResponse{RevokedAuthority: x509Authority}, nil
+}
+
+func (s *fakeLocalAuthorityServer) TaintJWTAuthority(ctx context.Context, req *localauthoritypb.TaintJWTAuthorityRequest) (*localauthoritypb.TaintJWTAuthorityResponse, error) {
+	if err := s.authorityCall("TaintJWTAuthority", req.AuthorityId, jwtAuthority); err != nil {
+		return nil, err
+	}
+	return &localauthoritypb.TaintJWTAuthorityResponse{TaintedAuthority: jwtAuthority}, nil
+}
+
+func (s *fakeLocalAuthorityServer) RevokeJWTAuthority(ctx context.Context, req *localauthoritypb.RevokeJWTAuthorityRequest) (*localauthoritypb.RevokeJWTAuthorityResponse, error) {
+	if err := s.authorityCall("RevokeJWTAuthority", req.AuthorityId, jwtAuthority); err != nil {
+		return nil, err
+	}
+	return &localauthoritypb.RevokeJWTAuthorityResponse{RevokedAuthority: jwtAuthority}, nil
+}
+
+func (s *fakeLocalAuthorityServer) authorityCall(call, authorityID string, authority *localauthoritypb.AuthorityState) error {
+	if s.err != nil {
+		return s.err
+	}
+	if authorityID != authority.AuthorityId {
+		return status.Errorf(codes.NotFound, "unexpected authority ID %q", authorityID)
+	}
+	s.call = call
+	return nil
+}
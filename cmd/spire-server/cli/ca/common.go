@@ -0,0 +1,62 @@
+package ca
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
+)
+
+// printAuthorities prints the X509 and JWT authorities returned by the
+// local authority API.
+func printAuthorities(env *common_cli.Env, x509Authority, jwtAuthority *localauthority.AuthorityState) error {
+	if err := printAuthority(env, "X509 authority", x509Authority); err != nil {
+		return err
+	}
+	return printAuthority(env, "JWT authority", jwtAuthority)
+}
+
+func printAuthority(env *common_cli.Env, name string, authority *localauthority.AuthorityState) error {
+	if err := env.Printf("%-16s: %s\n", name, authority.GetAuthorityId()); err != nil {
+		return err
+	}
+	return env.Printf("%-16s: %s\n", "Expires at", time.Unix(authority.GetExpiresAt(), 0).UTC().Format(time.RFC3339))
+}
+
+const (
+	x509AuthorityType = "x509"
+	jwtAuthorityType  = "jwt"
+)
+
+// authorityFlags are the flags used to identify a single authority.
+type authorityFlags struct {
+	authorityType string
+	authorityID   string
+}
+
+func (f *authorityFlags) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.authorityType, "type", x509AuthorityType, "Type of the authority (x509 or jwt)")
+	fs.StringVar(&f.authorityID, "authorityID", "", "ID of the authority. For X509 authorities, it is the subject key ID of the CA certificate. For JWT authorities, it is the key ID.")
+}
+
+func (f *authorityFlags) validate() error {
+	if f.authorityID == "" {
+		return errors.New("an authority ID is required")
+	}
+	switch f.authorityType {
+	case x509AuthorityType, jwtAuthorityType:
+		return nil
+	default:
+		return fmt.Errorf("unknown authority type %q: must be %q or %q", f.authorityType, x509AuthorityType, jwtAuthorityType)
+	}
+}
+
+func (f *authorityFlags) authorityName() string {
+	if f.authorityType == jwtAuthorityType {
+		return "JWT authority"
+	}
+	return "X509 authority"
+}
@@ -0,0 +1,48 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
+)
+
+type prepareCommand struct{}
+
+// NewPrepareCommand creates a new "prepare" subcommand for "ca" command.
+func NewPrepareCommand() cli.Command {
+	return NewPrepareCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewPrepareCommandWithEnv creates a new "prepare" subcommand for "ca"
+// command using the environment specified
+func NewPrepareCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(prepareCommand))
+}
+
+func (*prepareCommand) Name() string {
+	return "ca prepare"
+}
+
+func (*prepareCommand) Synopsis() string {
+	return "Prepares the next X509 CA and JWT signing key of the server"
+}
+
+func (*prepareCommand) AppendFlags(fs *flag.FlagSet) {
+}
+
+func (*prepareCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	client := serverClient.NewLocalAuthorityClient()
+	resp, err := client.PrepareNextAuthority(ctx, &localauthority.PrepareNextAuthorityRequest{})
+	if err != nil {
+		return err
+	}
+
+	if err := env.Println("Prepared next authorities:"); err != nil {
+		return err
+	}
+	return printAuthorities(env, resp.X509Authority, resp.JwtAuthority)
+}
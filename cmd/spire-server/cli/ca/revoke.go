@@ -0,0 +1,71 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
+)
+
+type revokeCommand struct {
+	authorityFlags
+}
+
+// NewRevokeCommand creates a new "revoke" subcommand for "ca" command.
+func NewRevokeCommand() cli.Command {
+	return NewRevokeCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewRevokeCommandWithEnv creates a new "revoke" subcommand for "ca" command
+// using the environment specified
+func NewRevokeCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(revokeCommand))
+}
+
+func (*revokeCommand) Name() string {
+	return "ca revoke"
+}
+
+func (*revokeCommand) Synopsis() string {
+	return "Removes a tainted X509 CA or JWT signing key from the trust bundle"
+}
+
+func (c *revokeCommand) AppendFlags(fs *flag.FlagSet) {
+	c.appendFlags(fs)
+}
+
+func (c *revokeCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	client := serverClient.NewLocalAuthorityClient()
+
+	var authority *localauthority.AuthorityState
+	switch c.authorityType {
+	case jwtAuthorityType:
+		resp, err := client.RevokeJWTAuthority(ctx, &localauthority.RevokeJWTAuthorityRequest{
+			AuthorityId: c.authorityID,
+		})
+		if err != nil {
+			return err
+		}
+		authority = resp.RevokedAuthority
+	default:
+		resp, err := client.RevokeX509Authority(ctx, &localauthority.RevokeX509AuthorityRequest{
+			AuthorityId: c.authorityID,
+		})
+		if err != nil {
+			return err
+		}
+		authority = resp.RevokedAuthority
+	}
+
+	if err := env.Println("Revoked authority:"); err != nil {
+		return err
+	}
+	return printAuthority(env, c.authorityName(), authority)
+}
@@ -0,0 +1,48 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
+)
+
+type rotateCommand struct{}
+
+// NewRotateCommand creates a new "rotate" subcommand for "ca" command.
+func NewRotateCommand() cli.Command {
+	return NewRotateCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewRotateCommandWithEnv creates a new "rotate" subcommand for "ca" command
+// using the environment specified
+func NewRotateCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(rotateCommand))
+}
+
+func (*rotateCommand) Name() string {
+	return "ca rotate"
+}
+
+func (*rotateCommand) Synopsis() string {
+	return "Activates the next X509 CA and JWT signing key of the server"
+}
+
+func (*rotateCommand) AppendFlags(fs *flag.FlagSet) {
+}
+
+func (*rotateCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	client := serverClient.NewLocalAuthorityClient()
+	resp, err := client.RotateAuthority(ctx, &localauthority.RotateAuthorityRequest{})
+	if err != nil {
+		return err
+	}
+
+	if err := env.Println("Activated authorities:"); err != nil {
+		return err
+	}
+	return printAuthorities(env, resp.X509Authority, resp.JwtAuthority)
+}
@@ -0,0 +1,71 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
+)
+
+type taintCommand struct {
+	authorityFlags
+}
+
+// NewTaintCommand creates a new "taint" subcommand for "ca" command.
+func NewTaintCommand() cli.Command {
+	return NewTaintCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewTaintCommandWithEnv creates a new "taint" subcommand for "ca" command
+// using the environment specified
+func NewTaintCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(taintCommand))
+}
+
+func (*taintCommand) Name() string {
+	return "ca taint"
+}
+
+func (*taintCommand) Synopsis() string {
+	return "Marks an old X509 CA or JWT signing key of the server as tainted"
+}
+
+func (c *taintCommand) AppendFlags(fs *flag.FlagSet) {
+	c.appendFlags(fs)
+}
+
+func (c *taintCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	client := serverClient.NewLocalAuthorityClient()
+
+	var authority *localauthority.AuthorityState
+	switch c.authorityType {
+	case jwtAuthorityType:
+		resp, err := client.TaintJWTAuthority(ctx, &localauthority.TaintJWTAuthorityRequest{
+			AuthorityId: c.authorityID,
+		})
+		if err != nil {
+			return err
+		}
+		authority = resp.TaintedAuthority
+	default:
+		resp, err := client.TaintX509Authority(ctx, &localauthority.TaintX509AuthorityRequest{
+			AuthorityId: c.authorityID,
+		})
+		if err != nil {
+			return err
+		}
+		authority = resp.TaintedAuthority
+	}
+
+	if err := env.Println("Tainted authority:"); err != nil {
+		return err
+	}
+	return printAuthority(env, c.authorityName(), authority)
+}
@@ -5,14 +5,23 @@ import (
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
+	"github.com/spiffe/spire/cmd/spire-server/cli/backup"
 	"github.com/spiffe/spire/cmd/spire-server/cli/bundle"
+	"github.com/spiffe/spire/cmd/spire-server/cli/ca"
+	"github.com/spiffe/spire/cmd/spire-server/cli/datastore"
+	"github.com/spiffe/spire/cmd/spire-server/cli/drain"
 	"github.com/spiffe/spire/cmd/spire-server/cli/entry"
+	"github.com/spiffe/spire/cmd/spire-server/cli/entrytemplate"
+	"github.com/spiffe/spire/cmd/spire-server/cli/federation"
 	"github.com/spiffe/spire/cmd/spire-server/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-server/cli/jwt"
+	"github.com/spiffe/spire/cmd/spire-server/cli/migrate"
 	"github.com/spiffe/spire/cmd/spire-server/cli/run"
 	"github.com/spiffe/spire/cmd/spire-server/cli/token"
 	"github.com/spiffe/spire/cmd/spire-server/cli/validate"
 	"github.com/spiffe/spire/cmd/spire-server/cli/x509"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/version"
 )
@@ -26,12 +35,18 @@ func (cc *CLI) Run(args []string) int {
 	c := cli.NewCLI("spire-server", version.Version())
 	c.Args = args
 	c.Commands = map[string]cli.CommandFactory{
+		"agent ban": func() (cli.Command, error) {
+			return agent.NewBanCommand(), nil
+		},
 		"agent evict": func() (cli.Command, error) {
 			return agent.NewEvictCommand(), nil
 		},
 		"agent list": func() (cli.Command, error) {
 			return agent.NewListCommand(), nil
 		},
+		"agent reattest": func() (cli.Command, error) {
+			return agent.NewReattestCommand(), nil
+		},
 		"agent show": func() (cli.Command, error) {
 			return agent.NewShowCommand(), nil
 		},
@@ -47,6 +62,9 @@ func (cc *CLI) Run(args []string) int {
 		"bundle delete": func() (cli.Command, error) {
 			return bundle.NewDeleteCommand(), nil
 		},
+		"bundle verify": func() (cli.Command, error) {
+			return bundle.NewVerifyCommand(), nil
+		},
 		"experimental bundle show": func() (cli.Command, error) {
 			return bundle.NewExperimentalShowCommand(), nil
 		},
@@ -56,6 +74,24 @@ func (cc *CLI) Run(args []string) int {
 		"experimental bundle set": func() (cli.Command, error) {
 			return bundle.NewExperimentalSetCommand(), nil
 		},
+		"ca prepare": func() (cli.Command, error) {
+			return ca.NewPrepareCommand(), nil
+		},
+		"ca revoke": func() (cli.Command, error) {
+			return ca.NewRevokeCommand(), nil
+		},
+		"ca rotate": func() (cli.Command, error) {
+			return ca.NewRotateCommand(), nil
+		},
+		"ca taint": func() (cli.Command, error) {
+			return ca.NewTaintCommand(), nil
+		},
+		"datastore backup": func() (cli.Command, error) {
+			return datastore.NewBackupCommand(), nil
+		},
+		"drain": func() (cli.Command, error) {
+			return drain.NewDrainCommand(), nil
+		},
 		"entry create": func() (cli.Command, error) {
 			return entry.NewCreateCommand(), nil
 		},
@@ -65,9 +101,69 @@ func (cc *CLI) Run(args []string) int {
 		"entry delete": func() (cli.Command, error) {
 			return entry.NewDeleteCommand(), nil
 		},
+		"entry rotate": func() (cli.Command, error) {
+			return entry.NewRotateCommand(), nil
+		},
+		"entry rewrite": func() (cli.Command, error) {
+			return entry.NewRewriteCommand(), nil
+		},
 		"entry show": func() (cli.Command, error) {
 			return entry.NewShowCommand(), nil
 		},
+		"entry match": func() (cli.Command, error) {
+			return entry.NewMatchCommand(), nil
+		},
+		"entry export": func() (cli.Command, error) {
+			return entry.NewExportCommand(), nil
+		},
+		"entry import": func() (cli.Command, error) {
+			return entry.NewImportCommand(), nil
+		},
+		"entrytemplate create": func() (cli.Command, error) {
+			return entrytemplate.NewCreateCommand(), nil
+		},
+		"entrytemplate delete": func() (cli.Command, error) {
+			return entrytemplate.NewDeleteCommand(), nil
+		},
+		"entrytemplate list": func() (cli.Command, error) {
+			return entrytemplate.NewListCommand(), nil
+		},
+		"entrytemplate show": func() (cli.Command, error) {
+			return entrytemplate.NewShowCommand(), nil
+		},
+		"entrytemplate update": func() (cli.Command, error) {
+			return entrytemplate.NewUpdateCommand(), nil
+		},
+		"federation create": func() (cli.Command, error) {
+			return federation.NewCreateCommand(), nil
+		},
+		"federation delete": func() (cli.Command, error) {
+			return federation.NewDeleteCommand(), nil
+		},
+		"federation list": func() (cli.Command, error) {
+			return federation.NewListCommand(), nil
+		},
+		"federation show": func() (cli.Command, error) {
+			return federation.NewShowCommand(), nil
+		},
+		"federation update": func() (cli.Command, error) {
+			return federation.NewUpdateCommand(), nil
+		},
+		"backup": func() (cli.Command, error) {
+			return backup.NewBackupCommand(), nil
+		},
+		"restore": func() (cli.Command, error) {
+			return backup.NewRestoreCommand(), nil
+		},
+		"migrate status": func() (cli.Command, error) {
+			return migrate.NewStatusCommand(), nil
+		},
+		"migrate run": func() (cli.Command, error) {
+			return migrate.NewRunCommand(), nil
+		},
+		"migrate rollback": func() (cli.Command, error) {
+			return migrate.NewRollbackCommand(), nil
+		},
 		"run": func() (cli.Command, error) {
 			return run.NewRunCommand(cc.LogOptions, cc.AllowUnknownConfig), nil
 		},
@@ -83,9 +179,15 @@ func (cc *CLI) Run(args []string) int {
 		"jwt mint": func() (cli.Command, error) {
 			return jwt.NewMintCommand(), nil
 		},
+		"jwt validate": func() (cli.Command, error) {
+			return jwt.NewValidateCommand(), nil
+		},
 		"validate": func() (cli.Command, error) {
 			return validate.NewValidateCommand(), nil
 		},
+		"feature-flags": func() (cli.Command, error) {
+			return common_cli.NewFeatureFlagsCommand(common_cli.DefaultEnv, fflag.ScopeServer, "spire-server"), nil
+		},
 	}
 
 	exitStatus, err := c.Run()
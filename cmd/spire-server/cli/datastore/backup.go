@@ -0,0 +1,64 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"path/filepath"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+)
+
+type backupCommand struct {
+	// Path of the file the backup is written to
+	path string
+}
+
+// NewBackupCommand creates a new "datastore backup" command.
+func NewBackupCommand() cli.Command {
+	return NewBackupCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewBackupCommandWithEnv creates a new "datastore backup" command using the
+// environment specified
+func NewBackupCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(backupCommand))
+}
+
+func (*backupCommand) Name() string {
+	return "datastore backup"
+}
+
+func (*backupCommand) Synopsis() string {
+	return "Writes a consistent copy of the datastore to a file without stopping the server"
+}
+
+func (c *backupCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.path, "path", "", "Path of the backup file to create on the server host. The file must not exist.")
+}
+
+// Run asks the server to snapshot its datastore into a new file
+func (c *backupCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.path == "" {
+		return errors.New("a backup path is required")
+	}
+
+	// The server resolves the path, so relative paths are made absolute
+	// against the working directory of the command instead.
+	path, err := filepath.Abs(c.path)
+	if err != nil {
+		return err
+	}
+
+	client := serverClient.NewDebugClient()
+	if _, err := client.BackupDatastore(ctx, &debug.BackupDatastoreRequest{
+		Path: path,
+	}); err != nil {
+		return err
+	}
+
+	return env.Printf("Datastore backed up to %s\n", path)
+}
@@ -0,0 +1,112 @@
+package datastore_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/cmd/spire-server/cli/datastore"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	debugpb "github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHelp(t *testing.T) {
+	stderr := new(bytes.Buffer)
+	cmd := datastore.NewBackupCommandWithEnv(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: new(bytes.Buffer),
+		Stderr: stderr,
+	})
+
+	cmd.Help()
+	require.Equal(t, `Usage of datastore backup:
+  -path string
+    	Path of the backup file to create on the server host. The file must not exist.
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, stderr.String())
+}
+
+func TestBackup(t *testing.T) {
+	relativePath, err := filepath.Abs("backup.sqlite3")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		serverErr      error
+		expectPath     string
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "success",
+			args:         []string{"-path", "/backups/spire.sqlite3"},
+			expectPath:   "/backups/spire.sqlite3",
+			expectStdout: "Datastore backed up to /backups/spire.sqlite3\n",
+		},
+		{
+			name:         "relative path",
+			args:         []string{"-path", "backup.sqlite3"},
+			expectPath:   relativePath,
+			expectStdout: "Datastore backed up to " + relativePath + "\n",
+		},
+		{
+			name:           "missing path",
+			expectStderr:   "a backup path is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "server error",
+			args:           []string{"-path", "/backups/spire.sqlite3"},
+			serverErr:      status.Error(codes.AlreadyExists, "backup file already exists"),
+			expectPath:     "/backups/spire.sqlite3",
+			expectStderr:   "rpc error: code = AlreadyExists desc = backup file already exists\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeDebugServer{err: tt.serverErr}
+			socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
+				debugpb.RegisterDebugServer(s, server)
+			})
+
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			cmd := datastore.NewBackupCommandWithEnv(&common_cli.Env{
+				Stdin:  new(bytes.Buffer),
+				Stdout: stdout,
+				Stderr: stderr,
+			})
+
+			exitCode := cmd.Run(append([]string{"-registrationUDSPath", socketPath}, tt.args...))
+			require.Equal(t, tt.expectStdout, stdout.String())
+			require.Equal(t, tt.expectStderr, stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+			require.Equal(t, tt.expectPath, server.path)
+		})
+	}
+}
+
+type fakeDebugServer struct {
+	debugpb.UnimplementedDebugServer
+
+	err  error
+	path string
+}
+
+func (s *fakeDebugServer) BackupDatastore(ctx context.Context, req *debugpb.BackupDatastoreRequest) (*debugpb.BackupDatastoreResponse, error) {
+	s.path = req.Path
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &debugpb.BackupDatastoreResponse{}, nil
+}
@@ -0,0 +1,45 @@
+package drain
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+)
+
+type drainCommand struct{}
+
+// NewDrainCommand creates a new "drain" command.
+func NewDrainCommand() cli.Command {
+	return NewDrainCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewDrainCommandWithEnv creates a new "drain" command using the environment
+// specified
+func NewDrainCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(drainCommand))
+}
+
+func (*drainCommand) Name() string {
+	return "drain"
+}
+
+func (*drainCommand) Synopsis() string {
+	return "Gracefully drains the agent connections of the server"
+}
+
+func (*drainCommand) AppendFlags(fs *flag.FlagSet) {}
+
+// Run stops the server from accepting new agent connections and tells the
+// connected agents to reconnect elsewhere
+func (*drainCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	client := serverClient.NewDebugClient()
+	if _, err := client.Drain(ctx, &debug.DrainRequest{}); err != nil {
+		return err
+	}
+
+	return env.Println("Server is draining agent connections")
+}
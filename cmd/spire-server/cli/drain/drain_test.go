@@ -0,0 +1,89 @@
+package drain_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spiffe/spire/cmd/spire-server/cli/drain"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	debugpb "github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHelp(t *testing.T) {
+	stderr := new(bytes.Buffer)
+	cmd := drain.NewDrainCommandWithEnv(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: new(bytes.Buffer),
+		Stderr: stderr,
+	})
+
+	cmd.Help()
+	require.Equal(t, `Usage of drain:
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, stderr.String())
+}
+
+func TestDrain(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		serverErr      error
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "success",
+			expectStdout: "Server is draining agent connections\n",
+		},
+		{
+			name:           "server error",
+			serverErr:      status.Error(codes.Internal, "oh no"),
+			expectStderr:   "rpc error: code = Internal desc = oh no\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeDebugServer{err: tt.serverErr}
+			socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
+				debugpb.RegisterDebugServer(s, server)
+			})
+
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			cmd := drain.NewDrainCommandWithEnv(&common_cli.Env{
+				Stdin:  new(bytes.Buffer),
+				Stdout: stdout,
+				Stderr: stderr,
+			})
+
+			exitCode := cmd.Run([]string{"-registrationUDSPath", socketPath})
+			require.Equal(t, tt.expectStdout, stdout.String())
+			require.Equal(t, tt.expectStderr, stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+			require.Equal(t, tt.serverErr == nil, server.drained)
+		})
+	}
+}
+
+type fakeDebugServer struct {
+	debugpb.UnimplementedDebugServer
+
+	err     error
+	drained bool
+}
+
+func (s *fakeDebugServer) Drain(ctx context.Context, req *debugpb.DrainRequest) (*debugpb.DrainResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.drained = true
+	return &debugpb.DrainResponse{}, nil
+}
@@ -59,6 +59,35 @@ type createCommand struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	dnsNames StringsFlag
+
+	// Additional claims included in JWT-SVIDs, delimited by an equals sign (=)
+	// ex. "role=admin"
+	jwtSVIDClaims StringsFlag
+
+	// Default audience of JWT-SVIDs issued based on this entry
+	jwtSVIDAudience StringsFlag
+
+	// Key type of X509-SVIDs issued based on this entry
+	x509SVIDKeyType string
+
+	// Templates rendered into DNS names of X509-SVIDs issued based on this entry
+	dnsNameTemplates StringsFlag
+
+	// Subject fields of X509-SVIDs issued based on this entry
+	subjectCommonName         string
+	subjectOrganization       StringsFlag
+	subjectOrganizationalUnit StringsFlag
+	subjectCountry            StringsFlag
+
+	// Hint returned to workloads along with the SVIDs issued based on this entry
+	hint string
+
+	// Expression over the workload selectors that must also be satisfied for
+	// the entry to match a workload
+	selectorExpression string
+
+	// ID of the entry template the entry is bound to
+	templateID string
 }
 
 func (*createCommand) Name() string {
@@ -81,6 +110,17 @@ func (c *createCommand) AppendFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.downstream, "downstream", false, "A boolean value that, when set, indicates that the entry describes a downstream SPIRE server")
 	f.Int64Var(&c.entryExpiry, "entryExpiry", 0, "An expiry, from epoch in seconds, for the resulting registration entry to be pruned")
 	f.Var(&c.dnsNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
+	f.Var(&c.jwtSVIDClaims, "jwtSVIDClaim", "An equals-delimited name=value claim that will be included in JWT-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.jwtSVIDAudience, "jwtSVIDAudience", "An audience used for JWT-SVIDs issued based on this entry when the request does not specify one. Can be used more than once")
+	f.StringVar(&c.x509SVIDKeyType, "x509SVIDKeyType", "", "The key type of X509-SVIDs issued based on this entry (ec-p256, ec-p384, rsa-2048 or rsa-4096). Defaults to ec-p256")
+	f.Var(&c.dnsNameTemplates, "dnsTemplate", "A Go text/template rendered into a DNS name that will be included in X509-SVIDs issued based on this entry. Can be used more than once")
+	f.StringVar(&c.subjectCommonName, "subjectCN", "", "The subject common name of X509-SVIDs issued based on this entry. Replaced by the first DNS name, if any")
+	f.Var(&c.subjectOrganization, "subjectO", "A subject organization of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.subjectOrganizationalUnit, "subjectOU", "A subject organizational unit of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.subjectCountry, "subjectC", "A subject country of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.StringVar(&c.hint, "hint", "", "A hint returned to workloads along with the SVIDs issued based on this entry, to tell them apart from the SVIDs of other entries (e.g. internal, external)")
+	f.StringVar(&c.selectorExpression, "selectorExpression", "", "An expression over the workload selectors that must also be satisfied for the entry to match a workload, combining type:value selectors with &&, || and !, parentheses and * wildcards in values (e.g. '!k8s:sa:admin')")
+	f.StringVar(&c.templateID, "templateID", "", "The ID of an entry template that sets the TTL, federated trust domains and DNS name templates of this entry, and constrains its SPIFFE ID path")
 }
 
 func (c *createCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
@@ -106,7 +146,7 @@ func (c *createCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 
 	// Print entries that succeeded to be created
 	for _, r := range succeeded {
-		printEntry(r.Entry, env)
+		PrintEntry(r.Entry, env)
 	}
 
 	// Print entries that failed to be created
@@ -114,7 +154,7 @@ func (c *createCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 		env.Printf("FAILED to create the following %s:\n", util.Pluralizer("", "entry", "entries", len(failed)))
 	}
 	for _, r := range failed {
-		printEntry(r.Entry, env)
+		PrintEntry(r.Entry, env)
 		env.Printf("%s\n", r.Status.Message)
 	}
 
@@ -203,9 +243,22 @@ func (c *createCommand) parseConfig() ([]*types.Entry, error) {
 		selectors = append(selectors, cs)
 	}
 
+	jwtSVIDClaims, err := parseJWTSVIDClaims(c.jwtSVIDClaims)
+	if err != nil {
+		return nil, err
+	}
+
 	e.Selectors = selectors
 	e.FederatesWith = c.federatesWith
 	e.Admin = c.admin
+	e.JwtSvidClaims = jwtSVIDClaims
+	e.JwtSvidAudience = c.jwtSVIDAudience
+	e.X509SvidKeyType = c.x509SVIDKeyType
+	e.DnsNameTemplates = c.dnsNameTemplates
+	e.X509SvidSubject = makeX509SVIDSubject(c.subjectCommonName, c.subjectOrganization, c.subjectOrganizationalUnit, c.subjectCountry)
+	e.Hint = c.hint
+	e.SelectorExpression = c.selectorExpression
+	e.TemplateId = c.templateID
 	return []*types.Entry{e}, nil
 }
 
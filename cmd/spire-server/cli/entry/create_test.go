@@ -23,12 +23,20 @@ func TestCreateHelp(t *testing.T) {
     	Path to a file containing registration JSON (optional). If set to '-', read the JSON from stdin.
   -dns value
     	A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once
+  -dnsTemplate value
+    	A Go text/template rendered into a DNS name that will be included in X509-SVIDs issued based on this entry. Can be used more than once
   -downstream
     	A boolean value that, when set, indicates that the entry describes a downstream SPIRE server
   -entryExpiry int
     	An expiry, from epoch in seconds, for the resulting registration entry to be pruned
   -federatesWith value
     	SPIFFE ID of a trust domain to federate with. Can be used more than once
+  -hint string
+    	A hint returned to workloads along with the SVIDs issued based on this entry, to tell them apart from the SVIDs of other entries (e.g. internal, external)
+  -jwtSVIDAudience value
+    	An audience used for JWT-SVIDs issued based on this entry when the request does not specify one. Can be used more than once
+  -jwtSVIDClaim value
+    	An equals-delimited name=value claim that will be included in JWT-SVIDs issued based on this entry. Can be used more than once
   -node
     	If set, this entry will be applied to matching nodes rather than workloads
   -parentID string
@@ -37,10 +45,24 @@ func TestCreateHelp(t *testing.T) {
     	Registration API UDS path (default "/tmp/spire-registration.sock")
   -selector value
     	A colon-delimited type:value selector. Can be used more than once
+  -selectorExpression string
+    	An expression over the workload selectors that must also be satisfied for the entry to match a workload, combining type:value selectors with &&, || and !, parentheses and * wildcards in values (e.g. '!k8s:sa:admin')
   -spiffeID string
     	The SPIFFE ID that this record represents
+  -subjectC value
+    	A subject country of X509-SVIDs issued based on this entry. Can be used more than once
+  -subjectCN string
+    	The subject common name of X509-SVIDs issued based on this entry. Replaced by the first DNS name, if any
+  -subjectO value
+    	A subject organization of X509-SVIDs issued based on this entry. Can be used more than once
+  -subjectOU value
+    	A subject organizational unit of X509-SVIDs issued based on this entry. Can be used more than once
+  -templateID string
+    	The ID of an entry template that sets the TTL, federated trust domains and DNS name templates of this entry, and constrains its SPIFFE ID path
   -ttl int
     	The lifetime, in seconds, for SVIDs issued based on this registration entry
+  -x509SVIDKeyType string
+    	The key type of X509-SVIDs issued based on this entry (ec-p256, ec-p384, rsa-2048 or rsa-4096). Defaults to ec-p256
 `, test.stderr.String())
 }
 
@@ -239,6 +261,268 @@ Admin            : true
 
 `, time.Unix(1552410266, 0).UTC()),
 		},
+		{
+			name: "Create succeeds with JWT-SVID claims and audience",
+			args: []string{
+				"-spiffeID", "spiffe://example.org/workload",
+				"-parentID", "spiffe://example.org/parent",
+				"-selector", "zebra:zebra:2000",
+				"-jwtSVIDClaim", "tenant=acme",
+				"-jwtSVIDClaim", "role=admin",
+				"-jwtSVIDAudience", "aud1",
+				"-jwtSVIDAudience", "aud2",
+			},
+			expReq: &entry.BatchCreateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						SpiffeId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+						ParentId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+						Selectors:       []*types.Selector{{Type: "zebra", Value: "zebra:2000"}},
+						JwtSvidClaims:   map[string]string{"role": "admin", "tenant": "acme"},
+						JwtSvidAudience: []string{"aud1", "aud2"},
+					},
+				},
+			},
+			fakeResp: &entry.BatchCreateEntryResponse{
+				Results: []*entry.BatchCreateEntryResponse_Result{
+					{
+						Entry: &types.Entry{
+							Id:              "entry-id",
+							SpiffeId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+							ParentId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+							Selectors:       []*types.Selector{{Type: "zebra", Value: "zebra:2000"}},
+							JwtSvidClaims:   map[string]string{"role": "admin", "tenant": "acme"},
+							JwtSvidAudience: []string{"aud1", "aud2"},
+						},
+						Status: &types.Status{
+							Code:    int32(codes.OK),
+							Message: "OK",
+						},
+					},
+				},
+			},
+			expOut: `Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 0
+TTL              : default
+Selector         : zebra:zebra:2000
+JWT-SVID claim   : role=admin
+JWT-SVID claim   : tenant=acme
+JWT-SVID audience: aud1
+JWT-SVID audience: aud2
+
+`,
+		},
+		{
+			name: "Create succeeds with X509-SVID key type, DNS name templates and subject",
+			args: []string{
+				"-spiffeID", "spiffe://example.org/workload",
+				"-parentID", "spiffe://example.org/parent",
+				"-selector", "zebra:zebra:2000",
+				"-x509SVIDKeyType", "rsa-2048",
+				"-dnsTemplate", "{{ .TrustDomain }}",
+				"-subjectCN", "workload",
+				"-subjectO", "ACME",
+				"-subjectC", "US",
+			},
+			expReq: &entry.BatchCreateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						SpiffeId:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+						ParentId:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+						Selectors:        []*types.Selector{{Type: "zebra", Value: "zebra:2000"}},
+						X509SvidKeyType:  "rsa-2048",
+						DnsNameTemplates: []string{"{{ .TrustDomain }}"},
+						X509SvidSubject: &types.X509SVIDSubject{
+							CommonName:   "workload",
+							Organization: []string{"ACME"},
+							Country:      []string{"US"},
+						},
+					},
+				},
+			},
+			fakeResp: &entry.BatchCreateEntryResponse{
+				Results: []*entry.BatchCreateEntryResponse_Result{
+					{
+						Entry: &types.Entry{
+							Id:               "entry-id",
+							SpiffeId:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+							ParentId:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+							Selectors:        []*types.Selector{{Type: "zebra", Value: "zebra:2000"}},
+							X509SvidKeyType:  "rsa-2048",
+							DnsNameTemplates: []string{"{{ .TrustDomain }}"},
+							X509SvidSubject: &types.X509SVIDSubject{
+								CommonName:   "workload",
+								Organization: []string{"ACME"},
+								Country:      []string{"US"},
+							},
+						},
+						Status: &types.Status{
+							Code:    int32(codes.OK),
+							Message: "OK",
+						},
+					},
+				},
+			},
+			expOut: `Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 0
+TTL              : default
+Selector         : zebra:zebra:2000
+X509-SVID key    : rsa-2048
+DNS template     : {{ .TrustDomain }}
+X509-SVID subject: CN=workload,O=ACME,C=US
+
+`,
+		},
+		{
+			name: "Create succeeds with hint",
+			args: []string{
+				"-spiffeID", "spiffe://example.org/workload",
+				"-parentID", "spiffe://example.org/parent",
+				"-selector", "zebra:zebra:2000",
+				"-hint", "internal",
+			},
+			expReq: &entry.BatchCreateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+						ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+						Selectors: []*types.Selector{{Type: "zebra", Value: "zebra:2000"}},
+						Hint:      "internal",
+					},
+				},
+			},
+			fakeResp: &entry.BatchCreateEntryResponse{
+				Results: []*entry.BatchCreateEntryResponse_Result{
+					{
+						Entry: &types.Entry{
+							Id:        "entry-id",
+							SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+							ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+							Selectors: []*types.Selector{{Type: "zebra", Value: "zebra:2000"}},
+							Hint:      "internal",
+						},
+						Status: &types.Status{
+							Code:    int32(codes.OK),
+							Message: "OK",
+						},
+					},
+				},
+			},
+			expOut: `Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 0
+TTL              : default
+Selector         : zebra:zebra:2000
+Hint             : internal
+
+`,
+		},
+		{
+			name: "Create succeeds with selector expression",
+			args: []string{
+				"-spiffeID", "spiffe://example.org/workload",
+				"-parentID", "spiffe://example.org/parent",
+				"-selector", "k8s:ns:prod",
+				"-selectorExpression", "!k8s:sa:admin",
+			},
+			expReq: &entry.BatchCreateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						SpiffeId:           &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+						ParentId:           &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+						Selectors:          []*types.Selector{{Type: "k8s", Value: "ns:prod"}},
+						SelectorExpression: "!k8s:sa:admin",
+					},
+				},
+			},
+			fakeResp: &entry.BatchCreateEntryResponse{
+				Results: []*entry.BatchCreateEntryResponse_Result{
+					{
+						Entry: &types.Entry{
+							Id:                 "entry-id",
+							SpiffeId:           &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+							ParentId:           &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+							Selectors:          []*types.Selector{{Type: "k8s", Value: "ns:prod"}},
+							SelectorExpression: "!k8s:sa:admin",
+						},
+						Status: &types.Status{
+							Code:    int32(codes.OK),
+							Message: "OK",
+						},
+					},
+				},
+			},
+			expOut: `Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 0
+TTL              : default
+Selector         : k8s:ns:prod
+Selector expr    : !k8s:sa:admin
+
+`,
+		},
+		{
+			name: "Create succeeds with entry template",
+			args: []string{
+				"-spiffeID", "spiffe://example.org/ns/web/workload",
+				"-parentID", "spiffe://example.org/parent",
+				"-selector", "k8s:ns:web",
+				"-templateID", "web",
+			},
+			expReq: &entry.BatchCreateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						SpiffeId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/ns/web/workload"},
+						ParentId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+						Selectors:  []*types.Selector{{Type: "k8s", Value: "ns:web"}},
+						TemplateId: "web",
+					},
+				},
+			},
+			fakeResp: &entry.BatchCreateEntryResponse{
+				Results: []*entry.BatchCreateEntryResponse_Result{
+					{
+						Entry: &types.Entry{
+							Id:         "entry-id",
+							SpiffeId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/ns/web/workload"},
+							ParentId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+							Selectors:  []*types.Selector{{Type: "k8s", Value: "ns:web"}},
+							Ttl:        60,
+							TemplateId: "web",
+						},
+						Status: &types.Status{
+							Code:    int32(codes.OK),
+							Message: "OK",
+						},
+					},
+				},
+			},
+			expOut: `Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/ns/web/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 0
+TTL              : 60
+Selector         : k8s:ns:web
+Entry template   : web
+
+`,
+		},
+		{
+			name: "Create fails with malformed JWT-SVID claim",
+			args: []string{
+				"-spiffeID", "spiffe://example.org/workload",
+				"-parentID", "spiffe://example.org/parent",
+				"-selector", "zebra:zebra:2000",
+				"-jwtSVIDClaim", "role",
+			},
+			expErr: "JWT-SVID claim \"role\" must be formatted as name=value\n",
+		},
 		{
 			name: "Create succeeds using data file",
 			args: []string{
@@ -1,9 +1,13 @@
 package entry
 
 import (
+	"bufio"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/util"
@@ -24,8 +28,17 @@ func newDeleteCommand(env *common_cli.Env) cli.Command {
 }
 
 type deleteCommand struct {
-	// ID of the record to delete
-	entryID string
+	// IDs of the records to delete
+	entryIDs StringsFlag
+
+	// Path of a file to read the IDs of the records to delete from
+	path string
+
+	// Query selecting the records to delete
+	query entryQuery
+
+	// Only print the records that would be deleted
+	dryRun bool
 }
 
 func (*deleteCommand) Name() string {
@@ -37,7 +50,11 @@ func (*deleteCommand) Synopsis() string {
 }
 
 func (c *deleteCommand) AppendFlags(f *flag.FlagSet) {
-	f.StringVar(&c.entryID, "entryID", "", "The Registration Entry ID of the record to delete")
+	f.Var(&c.entryIDs, "entryID", "The Registration Entry ID of the record to delete. Can be used more than once")
+	f.StringVar(&c.path, "file", "", "Path to a file containing the Registration Entry IDs of the records to delete, one per line. If set to '-', read the IDs from stdin.")
+	f.StringVar(&c.query.spiffeID, "spiffeID", "", "The SPIFFE ID of the records to delete, where '*' matches any sequence of characters")
+	f.Var(&c.query.selectors, "selector", "A colon-delimited type:value selector the records to delete have. Can be used more than once")
+	f.BoolVar(&c.dryRun, "dryRun", false, "Print the records matching -spiffeID and -selector without deleting them")
 }
 
 func (c *deleteCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
@@ -45,27 +62,106 @@ func (c *deleteCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 		return err
 	}
 
-	req := &entry.BatchDeleteEntryRequest{Ids: []string{c.entryID}}
-	resp, err := serverClient.NewEntryClient().BatchDeleteEntry(ctx, req)
-	if err != nil {
-		return err
+	client := serverClient.NewEntryClient()
+	ids := c.entryIDs
+	if c.path != "" {
+		fileIDs, err := parseEntryIDs(env.Stdin, c.path)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, fileIDs...)
+	}
+	if c.query.isSet() {
+		entries, err := c.query.fetchEntries(ctx, client)
+		if err != nil {
+			return err
+		}
+		if c.dryRun {
+			printEntries(entries, env)
+			return nil
+		}
+		for _, e := range entries {
+			ids = append(ids, e.Id)
+		}
 	}
 
-	sts := resp.Results[0].Status
-	switch sts.Code {
-	case int32(codes.OK):
-		env.Printf("Deleted entry with ID: %s\n", c.entryID)
+	var failed []*entry.BatchDeleteEntryResponse_Result
+	for len(ids) > 0 {
+		n := batchSize
+		if n > len(ids) {
+			n = len(ids)
+		}
+
+		resp, err := client.BatchDeleteEntry(ctx, &entry.BatchDeleteEntryRequest{Ids: ids[:n]})
+		if err != nil {
+			return err
+		}
+
+		for _, r := range resp.Results {
+			if r.Status.Code == int32(codes.OK) {
+				env.Printf("Deleted entry with ID: %s\n", r.Id)
+				continue
+			}
+			failed = append(failed, r)
+		}
+		ids = ids[n:]
+	}
+
+	switch {
+	case len(failed) == 0:
 		return nil
+	case len(c.entryIDs) == 1 && c.path == "":
+		return fmt.Errorf("failed to delete entry: %s", failed[0].Status.Message)
 	default:
-		return fmt.Errorf("failed to delete entry: %s", sts.Message)
+		for _, r := range failed {
+			env.ErrPrintf("Failed to delete entry with ID %s (code: %s, msg: %q)\n", r.Id, codes.Code(r.Status.Code), r.Status.Message)
+		}
+		return fmt.Errorf("failed to delete %d %s", len(failed), util.Pluralizer("", "entry", "entries", len(failed)))
 	}
 }
 
 // Perform basic validation.
 func (c *deleteCommand) validate() error {
-	if c.entryID == "" {
+	if c.query.isSet() {
+		if len(c.entryIDs) > 0 || c.path != "" {
+			return errors.New("the -spiffeID and -selector flags can't be combined with -entryID or -file")
+		}
+		return nil
+	}
+
+	if c.dryRun {
+		return errors.New("the -dryRun flag requires -spiffeID or -selector")
+	}
+
+	if len(c.entryIDs) == 0 && c.path == "" {
 		return errors.New("an entry ID is required")
 	}
 
 	return nil
 }
+
+// parseEntryIDs reads entry IDs, one per line, from the file at the given
+// path or from the reader if the path is "-". Blank lines are ignored.
+func parseEntryIDs(in io.Reader, path string) ([]string, error) {
+	r := in
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -15,10 +15,18 @@ func TestDeleteHelp(t *testing.T) {
 	test.client.Help()
 
 	require.Equal(t, `Usage of entry delete:
-  -entryID string
-    	The Registration Entry ID of the record to delete
+  -dryRun
+    	Print the records matching -spiffeID and -selector without deleting them
+  -entryID value
+    	The Registration Entry ID of the record to delete. Can be used more than once
+  -file string
+    	Path to a file containing the Registration Entry IDs of the records to delete, one per line. If set to '-', read the IDs from stdin.
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -selector value
+    	A colon-delimited type:value selector the records to delete have. Can be used more than once
+  -spiffeID string
+    	The SPIFFE ID of the records to delete, where '*' matches any sequence of characters
 `, test.stderr.String())
 }
 
@@ -52,9 +60,27 @@ func TestDelete(t *testing.T) {
 		},
 	}
 
+	fakeRespBatch := &entry.BatchDeleteEntryResponse{
+		Results: []*entry.BatchDeleteEntryResponse_Result{
+			{
+				Id:     "entry-1",
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+			},
+			{
+				Id:     "entry-2",
+				Status: &types.Status{Code: int32(codes.NotFound), Message: "entry not found"},
+			},
+			{
+				Id:     "entry-3",
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+			},
+		},
+	}
+
 	for _, tt := range []struct {
-		name string
-		args []string
+		name  string
+		args  []string
+		stdin string
 
 		expReq    *entry.BatchDeleteEntryRequest
 		fakeResp  *entry.BatchDeleteEntryResponse
@@ -88,11 +114,161 @@ func TestDelete(t *testing.T) {
 			fakeResp: fakeRespOK,
 			expOut:   "Deleted entry with ID: entry-id\n",
 		},
+		{
+			name:     "Delete many entries reports each failure",
+			args:     []string{"-entryID", "entry-1", "-file", "-"},
+			stdin:    "entry-2\n\nentry-3\n",
+			expReq:   &entry.BatchDeleteEntryRequest{Ids: []string{"entry-1", "entry-2", "entry-3"}},
+			fakeResp: fakeRespBatch,
+			expOut:   "Deleted entry with ID: entry-1\nDeleted entry with ID: entry-3\n",
+			expErr:   "Failed to delete entry with ID entry-2 (code: NotFound, msg: \"entry not found\")\nfailed to delete 1 entry\n",
+		},
+		{
+			name:   "Entry IDs file does not exist",
+			args:   []string{"-file", "/not/a/real/path"},
+			expErr: "open /not/a/real/path: no such file or directory\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newDeleteCommand)
+			test.server.err = tt.serverErr
+			test.server.expBatchDeleteEntryReq = tt.expReq
+			test.server.batchDeleteEntryResp = tt.fakeResp
+			test.stdin.WriteString(tt.stdin)
+
+			args := append(test.args, tt.args...)
+			rc := test.client.Run(args)
+			if tt.expErr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expErr, test.stderr.String())
+				require.Equal(t, tt.expOut, test.stdout.String())
+				return
+			}
+
+			require.Equal(t, 0, rc)
+			require.Equal(t, tt.expOut, test.stdout.String())
+		})
+	}
+}
+
+func TestDeleteByQuery(t *testing.T) {
+	entries := []*types.Entry{
+		{
+			Id:        "entry-1",
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/app/web"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+			Selectors: []*types.Selector{{Type: "k8s", Value: "ns:legacy"}, {Type: "k8s", Value: "sa:web"}},
+		},
+		{
+			Id:        "entry-2",
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/app/db"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+			Selectors: []*types.Selector{{Type: "k8s", Value: "ns:prod"}},
+		},
+		{
+			Id:        "entry-3",
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/other"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+			Selectors: []*types.Selector{{Type: "k8s", Value: "ns:legacy"}},
+		},
+	}
+
+	fakeResp := &entry.BatchDeleteEntryResponse{
+		Results: []*entry.BatchDeleteEntryResponse_Result{
+			{
+				Id:     "entry-1",
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+			},
+			{
+				Id:     "entry-2",
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expListReq *entry.ListEntriesRequest
+		entries    []*types.Entry
+		expReq     *entry.BatchDeleteEntryRequest
+		fakeResp   *entry.BatchDeleteEntryResponse
+		serverErr  error
+
+		expOut string
+		expErr string
+	}{
+		{
+			name:   "Query combined with entry ID",
+			args:   []string{"-entryID", "entry-1", "-spiffeID", "spiffe://example.org/app/*"},
+			expErr: "the -spiffeID and -selector flags can't be combined with -entryID or -file\n",
+		},
+		{
+			name:   "Dry run without query",
+			args:   []string{"-entryID", "entry-1", "-dryRun"},
+			expErr: "the -dryRun flag requires -spiffeID or -selector\n",
+		},
+		{
+			name:   "Invalid selector",
+			args:   []string{"-selector", "k8s"},
+			expErr: "error parsing selectors: selector \"k8s\" must be formatted as type:value\n",
+		},
+		{
+			name:       "Server error",
+			args:       []string{"-selector", "k8s:ns:legacy"},
+			expListReq: &entry.ListEntriesRequest{Filter: &entry.ListEntriesRequest_Filter{}, PageSize: exportPageSize},
+			serverErr:  errors.New("server-error"),
+			expErr:     "error fetching entries: rpc error: code = Unknown desc = server-error\n",
+		},
+		{
+			name:       "Delete by SPIFFE ID pattern",
+			args:       []string{"-spiffeID", "spiffe://example.org/app/*"},
+			expListReq: &entry.ListEntriesRequest{Filter: &entry.ListEntriesRequest_Filter{}, PageSize: exportPageSize},
+			expReq:     &entry.BatchDeleteEntryRequest{Ids: []string{"entry-1", "entry-2"}},
+			fakeResp:   fakeResp,
+			expOut:     "Deleted entry with ID: entry-1\nDeleted entry with ID: entry-2\n",
+		},
+		{
+			name: "Delete by SPIFFE ID",
+			args: []string{"-spiffeID", "spiffe://example.org/app/web"},
+			expListReq: &entry.ListEntriesRequest{
+				Filter: &entry.ListEntriesRequest_Filter{
+					BySpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/app/web"},
+				},
+				PageSize: exportPageSize,
+			},
+			entries:  entries[:1],
+			expReq:   &entry.BatchDeleteEntryRequest{Ids: []string{"entry-1"}},
+			fakeResp: &entry.BatchDeleteEntryResponse{Results: fakeResp.Results[:1]},
+			expOut:   "Deleted entry with ID: entry-1\n",
+		},
+		{
+			name:       "Dry run by SPIFFE ID pattern and selector",
+			args:       []string{"-spiffeID", "spiffe://example.org/app/*", "-selector", "k8s:ns:legacy", "-dryRun"},
+			expListReq: &entry.ListEntriesRequest{Filter: &entry.ListEntriesRequest_Filter{}, PageSize: exportPageSize},
+			expOut: `Found 1 entry
+Entry ID         : entry-1
+SPIFFE ID        : spiffe://example.org/app/web
+Parent ID        : spiffe://example.org/agent
+Revision         : 0
+TTL              : default
+Selector         : k8s:ns:legacy
+Selector         : k8s:sa:web
+
+`,
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			test := setupTest(t, newDeleteCommand)
 			test.server.err = tt.serverErr
+			test.server.expListEntriesReq = tt.expListReq
+			test.server.listEntriesResp = &entry.ListEntriesResponse{Entries: entries}
+			if tt.entries != nil {
+				test.server.listEntriesResp.Entries = tt.entries
+			}
 			test.server.expBatchDeleteEntryReq = tt.expReq
 			test.server.batchDeleteEntryResp = tt.fakeResp
 
@@ -101,6 +277,7 @@ func TestDelete(t *testing.T) {
 			if tt.expErr != "" {
 				require.Equal(t, 1, rc)
 				require.Equal(t, tt.expErr, test.stderr.String())
+				require.Equal(t, tt.expOut, test.stdout.String())
 				return
 			}
 
@@ -0,0 +1,108 @@
+package entry
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/common"
+	"sigs.k8s.io/yaml"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+
+	// exportPageSize is the number of entries requested per page when
+	// exporting entries.
+	exportPageSize = 500
+)
+
+// NewExportCommand creates a new "export" subcommand for "entry" command.
+func NewExportCommand() cli.Command {
+	return newExportCommand(common_cli.DefaultEnv)
+}
+
+func newExportCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(exportCommand))
+}
+
+type exportCommand struct {
+	// Path of the file to write the entries to
+	path string
+
+	// Format of the exported entries (json or yaml)
+	format string
+}
+
+func (*exportCommand) Name() string {
+	return "entry export"
+}
+
+func (*exportCommand) Synopsis() string {
+	return "Exports all registration entries"
+}
+
+func (c *exportCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.path, "file", "-", "Path of the file to write the entries to. If set to '-', the entries are written to stdout")
+	f.StringVar(&c.format, "format", formatJSON, "Format of the exported entries (json or yaml)")
+}
+
+func (c *exportCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.format != formatJSON && c.format != formatYAML {
+		return fmt.Errorf("unsupported format %q", c.format)
+	}
+	if c.path == "" {
+		return errors.New("a file path is required")
+	}
+
+	entries := &common.RegistrationEntries{}
+	client := serverClient.NewEntryClient()
+	pageToken := ""
+	for {
+		resp, err := client.ListEntries(ctx, &entry.ListEntriesRequest{
+			PageSize:  exportPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return fmt.Errorf("error fetching entries: %v", err)
+		}
+		for _, e := range resp.Entries {
+			entries.Entries = append(entries.Entries, protoToRegistrationEntry(e))
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	data, err := json.MarshalIndent(entries, "", "    ")
+	if err != nil {
+		return err
+	}
+	if c.format == formatYAML {
+		data, err = yaml.JSONToYAML(data)
+		if err != nil {
+			return err
+		}
+	} else {
+		data = append(data, '\n')
+	}
+
+	if c.path == "-" {
+		_, err = env.Stdout.Write(data)
+		return err
+	}
+	if err := ioutil.WriteFile(c.path, data, 0600); err != nil {
+		return err
+	}
+	return env.Printf("Exported %d entries to %s\n", len(entries.Entries), c.path)
+}
@@ -0,0 +1,146 @@
+package entry
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportHelp(t *testing.T) {
+	test := setupTest(t, newExportCommand)
+	test.client.Help()
+
+	require.Equal(t, `Usage of entry export:
+  -file string
+    	Path of the file to write the entries to. If set to '-', the entries are written to stdout (default "-")
+  -format string
+    	Format of the exported entries (json or yaml) (default "json")
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, test.stderr.String())
+}
+
+func TestExportSynopsis(t *testing.T) {
+	test := setupTest(t, newExportCommand)
+	require.Equal(t, "Exports all registration entries", test.client.Synopsis())
+}
+
+func TestExport(t *testing.T) {
+	entries := []*types.Entry{
+		{
+			Id:       "entry-1",
+			ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+			SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+			Selectors: []*types.Selector{
+				{Type: "unix", Value: "uid:1000"},
+			},
+			Ttl:            60,
+			FederatesWith:  []string{"domain1.org"},
+			DnsNames:       []string{"dns1"},
+			RevisionNumber: 3,
+		},
+	}
+
+	expJSON := `{
+    "entries": [
+        {
+            "selectors": [
+                {
+                    "type": "unix",
+                    "value": "uid:1000"
+                }
+            ],
+            "parent_id": "spiffe://example.org/parent",
+            "spiffe_id": "spiffe://example.org/workload",
+            "ttl": 60,
+            "federates_with": [
+                "spiffe://domain1.org"
+            ],
+            "dns_names": [
+                "dns1"
+            ]
+        }
+    ]
+}
+`
+	expYAML := `entries:
+- dns_names:
+  - dns1
+  federates_with:
+  - spiffe://domain1.org
+  parent_id: spiffe://example.org/parent
+  selectors:
+  - type: unix
+    value: uid:1000
+  spiffe_id: spiffe://example.org/workload
+  ttl: 60
+`
+	expReq := &entry.ListEntriesRequest{PageSize: exportPageSize}
+
+	for _, tt := range []struct {
+		name      string
+		args      []string
+		serverErr error
+
+		expOut string
+		expErr string
+	}{
+		{
+			name:   "Unsupported format",
+			args:   []string{"-format", "xml"},
+			expErr: "unsupported format \"xml\"\n",
+		},
+		{
+			name:      "Server error",
+			serverErr: errors.New("server-error"),
+			expErr:    "error fetching entries: rpc error: code = Unknown desc = server-error\n",
+		},
+		{
+			name:   "Export JSON",
+			expOut: expJSON,
+		},
+		{
+			name:   "Export YAML",
+			args:   []string{"-format", "yaml"},
+			expOut: expYAML,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newExportCommand)
+			test.server.err = tt.serverErr
+			test.server.expListEntriesReq = expReq
+			test.server.listEntriesResp = &entry.ListEntriesResponse{Entries: entries}
+
+			rc := test.client.Run(append(test.args, tt.args...))
+			if tt.expErr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expErr, test.stderr.String())
+				return
+			}
+
+			require.Equal(t, 0, rc)
+			require.Equal(t, tt.expOut, test.stdout.String())
+		})
+	}
+}
+
+func TestExportToFile(t *testing.T) {
+	test := setupTest(t, newExportCommand)
+	test.server.expListEntriesReq = &entry.ListEntriesRequest{PageSize: exportPageSize}
+	test.server.listEntriesResp = &entry.ListEntriesResponse{}
+
+	path := filepath.Join(t.TempDir(), "entries.json")
+	rc := test.client.Run(append(test.args, "-file", path))
+	require.Equal(t, 0, rc)
+	require.Equal(t, "Exported 0 entries to "+path+"\n", test.stdout.String())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "{}\n", string(data))
+}
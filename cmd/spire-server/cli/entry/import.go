@@ -0,0 +1,92 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"google.golang.org/grpc/codes"
+
+	"golang.org/x/net/context"
+)
+
+// NewImportCommand creates a new "import" subcommand for "entry" command.
+func NewImportCommand() cli.Command {
+	return newImportCommand(common_cli.DefaultEnv)
+}
+
+func newImportCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(importCommand))
+}
+
+type importCommand struct {
+	// Path of the file to read the entries from
+	path string
+}
+
+func (*importCommand) Name() string {
+	return "entry import"
+}
+
+func (*importCommand) Synopsis() string {
+	return "Imports registration entries"
+}
+
+func (c *importCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.path, "file", "", "Path to a file containing registration entries in JSON or YAML. If set to '-', read the entries from stdin.")
+}
+
+func (c *importCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.path == "" {
+		return errors.New("a file path is required")
+	}
+
+	entries, err := parseEntryData(env.Stdin, c.path)
+	if err != nil {
+		return err
+	}
+
+	client := serverClient.NewEntryClient()
+	var applied, unchanged, failed int
+	for len(entries) > 0 {
+		n := batchSize
+		if n > len(entries) {
+			n = len(entries)
+		}
+
+		resp, err := client.BatchCreateEntry(ctx, &entry.BatchCreateEntryRequest{
+			Entries: entries[:n],
+			Upsert:  true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, r := range resp.Results {
+			switch r.Status.Code {
+			case int32(codes.OK):
+				applied++
+			case int32(codes.AlreadyExists):
+				unchanged++
+			default:
+				failed++
+				e := entries[i]
+				env.ErrPrintf("Failed to import entry (code: %s, msg: %q): spiffeID=%s parentID=%s\n",
+					codes.Code(r.Status.Code), r.Status.Message, protoToIDString(e.SpiffeId), protoToIDString(e.ParentId))
+			}
+		}
+		entries = entries[n:]
+	}
+
+	if err := env.Printf("Applied: %d, Unchanged: %d, Failed: %d\n", applied, unchanged, failed); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d entries", failed)
+	}
+	return nil
+}
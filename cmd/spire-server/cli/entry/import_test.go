@@ -0,0 +1,144 @@
+package entry
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestImportHelp(t *testing.T) {
+	test := setupTest(t, newImportCommand)
+	test.client.Help()
+
+	require.Equal(t, `Usage of entry import:
+  -file string
+    	Path to a file containing registration entries in JSON or YAML. If set to '-', read the entries from stdin.
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, test.stderr.String())
+}
+
+func TestImportSynopsis(t *testing.T) {
+	test := setupTest(t, newImportCommand)
+	require.Equal(t, "Imports registration entries", test.client.Synopsis())
+}
+
+func TestImport(t *testing.T) {
+	entry1 := &types.Entry{
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/Blog"},
+		ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/join_token/TokenBlog"},
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:1111"}},
+		Ttl:       200,
+		Admin:     true,
+	}
+	entry2 := &types.Entry{
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/Database"},
+		ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/join_token/TokenDatabase"},
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:1111"}},
+		Ttl:       200,
+	}
+	expReq := &entry.BatchCreateEntryRequest{
+		Entries: []*types.Entry{entry1, entry2},
+		Upsert:  true,
+	}
+	yamlData := `entries:
+- spiffe_id: spiffe://example.org/Blog
+  parent_id: spiffe://example.org/spire/agent/join_token/TokenBlog
+  selectors:
+  - type: unix
+    value: uid:1111
+  ttl: 200
+  admin: true
+- spiffe_id: spiffe://example.org/Database
+  parent_id: spiffe://example.org/spire/agent/join_token/TokenDatabase
+  selectors:
+  - type: unix
+    value: uid:1111
+  ttl: 200
+`
+
+	for _, tt := range []struct {
+		name      string
+		args      []string
+		stdin     string
+		serverErr error
+		fakeResp  *entry.BatchCreateEntryResponse
+
+		expOut string
+		expErr string
+	}{
+		{
+			name:   "Missing file",
+			expErr: "a file path is required\n",
+		},
+		{
+			name:      "Server error",
+			args:      []string{"-file", path.Join(util.ProjectRoot(), "test/fixture/registration/good.json")},
+			serverErr: errors.New("server-error"),
+			expErr:    "rpc error: code = Unknown desc = server-error\n",
+		},
+		{
+			name: "Import JSON",
+			args: []string{"-file", path.Join(util.ProjectRoot(), "test/fixture/registration/good.json")},
+			fakeResp: &entry.BatchCreateEntryResponse{
+				Results: []*entry.BatchCreateEntryResponse_Result{
+					{Status: &types.Status{Code: int32(codes.OK)}, Entry: entry1},
+					{Status: &types.Status{Code: int32(codes.AlreadyExists)}, Entry: entry2},
+				},
+			},
+			expOut: "Applied: 1, Unchanged: 1, Failed: 0\n",
+		},
+		{
+			name:  "Import YAML from stdin",
+			args:  []string{"-file", "-"},
+			stdin: yamlData,
+			fakeResp: &entry.BatchCreateEntryResponse{
+				Results: []*entry.BatchCreateEntryResponse_Result{
+					{Status: &types.Status{Code: int32(codes.OK)}, Entry: entry1},
+					{Status: &types.Status{Code: int32(codes.OK)}, Entry: entry2},
+				},
+			},
+			expOut: "Applied: 2, Unchanged: 0, Failed: 0\n",
+		},
+		{
+			name:  "Import fails for some entries",
+			args:  []string{"-file", "-"},
+			stdin: yamlData,
+			fakeResp: &entry.BatchCreateEntryResponse{
+				Results: []*entry.BatchCreateEntryResponse_Result{
+					{Status: &types.Status{Code: int32(codes.OK)}, Entry: entry1},
+					{Status: &types.Status{Code: int32(codes.Internal), Message: "oh no"}},
+				},
+			},
+			expOut: "Applied: 1, Unchanged: 0, Failed: 1\n",
+			expErr: `Failed to import entry (code: Internal, msg: "oh no"): spiffeID=spiffe://example.org/Database parentID=spiffe://example.org/spire/agent/join_token/TokenDatabase
+failed to import 1 entries
+`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newImportCommand)
+			test.server.err = tt.serverErr
+			test.server.expBatchCreateEntryReq = expReq
+			test.server.batchCreateEntryResp = tt.fakeResp
+			test.stdin.WriteString(tt.stdin)
+
+			rc := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expOut, test.stdout.String())
+			if tt.expErr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expErr, test.stderr.String())
+				return
+			}
+
+			require.Equal(t, 0, rc)
+		})
+	}
+}
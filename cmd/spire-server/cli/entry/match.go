@@ -0,0 +1,79 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	commonutil "github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+
+	"golang.org/x/net/context"
+)
+
+// NewMatchCommand creates a new "match" subcommand for "entry" command.
+func NewMatchCommand() cli.Command {
+	return newMatchCommand(common_cli.DefaultEnv)
+}
+
+func newMatchCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(matchCommand))
+}
+
+type matchCommand struct {
+	// SPIFFE ID of the agent the entries are matched for
+	agentID string
+
+	// Selectors of the hypothetical workload
+	// ex. "unix:uid:1000" or "k8s:ns:default"
+	selectors StringsFlag
+}
+
+func (c *matchCommand) Name() string {
+	return "entry match"
+}
+
+func (*matchCommand) Synopsis() string {
+	return "Previews the registration entries an agent or a workload with the given selectors would receive"
+}
+
+func (c *matchCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.agentID, "agentID", "", "The SPIFFE ID of the agent to match the entries for")
+	f.Var(&c.selectors, "selector", "A colon-delimited type:value selector of the workload. Can be used more than once")
+}
+
+// Run executes all logic associated with a single invocation of the
+// `spire-server entry match` CLI command
+func (c *matchCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.agentID == "" && len(c.selectors) == 0 {
+		return errors.New("an agent ID or at least one selector is required")
+	}
+
+	req := &debug.MatchEntriesRequest{}
+	if c.agentID != "" {
+		id, err := idStringToProto(c.agentID)
+		if err != nil {
+			return fmt.Errorf("error parsing agent ID %q: %v", c.agentID, err)
+		}
+		req.AgentId = id
+	}
+	for _, s := range c.selectors {
+		selector, err := parseSelector(s)
+		if err != nil {
+			return fmt.Errorf("error parsing selectors: %v", err)
+		}
+		req.Selectors = append(req.Selectors, selector)
+	}
+
+	resp, err := serverClient.NewDebugClient().MatchEntries(ctx, req)
+	if err != nil {
+		return fmt.Errorf("error matching entries: %v", err)
+	}
+
+	commonutil.SortTypesEntries(resp.Entries)
+	printEntries(resp.Entries, env)
+	return nil
+}
@@ -0,0 +1,127 @@
+package entry
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMatchHelp(t *testing.T) {
+	test := setupTest(t, newMatchCommand)
+	test.client.Help()
+
+	require.Equal(t, `Usage of entry match:
+  -agentID string
+    	The SPIFFE ID of the agent to match the entries for
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -selector value
+    	A colon-delimited type:value selector of the workload. Can be used more than once
+`, test.stderr.String())
+}
+
+func TestMatchSynopsis(t *testing.T) {
+	test := setupTest(t, newMatchCommand)
+	require.Equal(t, "Previews the registration entries an agent or a workload with the given selectors would receive", test.client.Synopsis())
+}
+
+func TestMatch(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expReq    *debug.MatchEntriesRequest
+		fakeResp  *debug.MatchEntriesResponse
+		serverErr error
+
+		expOut string
+		expErr string
+	}{
+		{
+			name: "Match by agent ID",
+			args: []string{"-agentID", "spiffe://example.org/father"},
+			expReq: &debug.MatchEntriesRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/father"},
+			},
+			fakeResp: &debug.MatchEntriesResponse{
+				Entries: getEntries(2),
+			},
+			expOut: fmt.Sprintf("Found 2 entries\n%s%s",
+				getPrintedEntry(1),
+				getPrintedEntry(0),
+			),
+		},
+		{
+			name: "Match by agent ID and selectors",
+			args: []string{"-agentID", "spiffe://example.org/father", "-selector", "foo:bar"},
+			expReq: &debug.MatchEntriesRequest{
+				AgentId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/father"},
+				Selectors: []*types.Selector{{Type: "foo", Value: "bar"}},
+			},
+			fakeResp: &debug.MatchEntriesResponse{
+				Entries: getEntries(1),
+			},
+			expOut: fmt.Sprintf("Found 1 entry\n%s",
+				getPrintedEntry(0),
+			),
+		},
+		{
+			name: "No match",
+			args: []string{"-selector", "foo:bar", "-selector", "bar:baz"},
+			expReq: &debug.MatchEntriesRequest{
+				Selectors: []*types.Selector{
+					{Type: "foo", Value: "bar"},
+					{Type: "bar", Value: "baz"},
+				},
+			},
+			fakeResp: &debug.MatchEntriesResponse{},
+			expOut:   "Found 0 entries\n",
+		},
+		{
+			name:   "Missing agent ID and selectors",
+			expErr: "an agent ID or at least one selector is required\n",
+		},
+		{
+			name:   "Invalid agent ID",
+			args:   []string{"-agentID", "invalid-id"},
+			expErr: "error parsing agent ID \"invalid-id\": spiffeid: invalid scheme\n",
+		},
+		{
+			name:   "Invalid selector",
+			args:   []string{"-selector", "invalid-selector"},
+			expErr: "error parsing selectors: selector \"invalid-selector\" must be formatted as type:value\n",
+		},
+		{
+			name: "Server error",
+			args: []string{"-selector", "foo:bar"},
+			expReq: &debug.MatchEntriesRequest{
+				Selectors: []*types.Selector{{Type: "foo", Value: "bar"}},
+			},
+			serverErr: status.Error(codes.Internal, "internal server error"),
+			expErr:    "error matching entries: rpc error: code = Internal desc = internal server error\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newMatchCommand)
+			test.debugServer.err = tt.serverErr
+			test.debugServer.expMatchEntriesReq = tt.expReq
+			test.debugServer.matchEntriesResp = tt.fakeResp
+
+			rc := test.client.Run(append(test.args, tt.args...))
+			if tt.expErr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expErr, test.stderr.String())
+				return
+			}
+
+			require.Equal(t, 0, rc)
+			require.Equal(t, tt.expOut, test.stdout.String())
+		})
+	}
+}
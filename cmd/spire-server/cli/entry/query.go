@@ -0,0 +1,100 @@
+package entry
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+
+	"golang.org/x/net/context"
+)
+
+// entryQuery selects the registration entries operated on by the commands
+// working on sets of entries, like delete and update.
+type entryQuery struct {
+	// SPIFFE ID of the entries, where "*" matches any sequence of characters
+	spiffeID string
+
+	// Selectors the entries must have, among others
+	selectors StringsFlag
+}
+
+func (q *entryQuery) isSet() bool {
+	return q.spiffeID != "" || len(q.selectors) > 0
+}
+
+// fetchEntries lists the entries matching the query.
+func (q *entryQuery) fetchEntries(ctx context.Context, client entry.EntryClient) ([]*types.Entry, error) {
+	filter := &entry.ListEntriesRequest_Filter{}
+
+	var spiffeIDPattern *regexp.Regexp
+	switch {
+	case strings.Contains(q.spiffeID, "*"):
+		quoted := strings.Split(q.spiffeID, "*")
+		for i := range quoted {
+			quoted[i] = regexp.QuoteMeta(quoted[i])
+		}
+		spiffeIDPattern = regexp.MustCompile("^" + strings.Join(quoted, ".*") + "$")
+	case q.spiffeID != "":
+		id, err := idStringToProto(q.spiffeID)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing SPIFFE ID %q: %v", q.spiffeID, err)
+		}
+		filter.BySpiffeId = id
+	}
+
+	selectors := make([]*types.Selector, 0, len(q.selectors))
+	for _, s := range q.selectors {
+		selector, err := parseSelector(s)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing selectors: %v", err)
+		}
+		selectors = append(selectors, selector)
+	}
+
+	var entries []*types.Entry
+	pageToken := ""
+	for {
+		resp, err := client.ListEntries(ctx, &entry.ListEntriesRequest{
+			Filter:    filter,
+			PageSize:  exportPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching entries: %v", err)
+		}
+		for _, e := range resp.Entries {
+			if spiffeIDPattern != nil && !spiffeIDPattern.MatchString(protoToIDString(e.SpiffeId)) {
+				continue
+			}
+			if !hasSelectors(e, selectors) {
+				continue
+			}
+			entries = append(entries, e)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	return entries, nil
+}
+
+// hasSelectors returns whether the entry has all of the selectors.
+func hasSelectors(e *types.Entry, selectors []*types.Selector) bool {
+	for _, s := range selectors {
+		found := false
+		for _, es := range e.Selectors {
+			if es.Type == s.Type && es.Value == s.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
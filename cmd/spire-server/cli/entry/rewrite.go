@@ -0,0 +1,160 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc/codes"
+
+	"golang.org/x/net/context"
+)
+
+// NewRewriteCommand creates a new "rewrite" subcommand for "entry" command.
+func NewRewriteCommand() cli.Command {
+	return newRewriteCommand(common_cli.DefaultEnv)
+}
+
+func newRewriteCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(rewriteCommand))
+}
+
+type rewriteCommand struct {
+	// Trust domain to move the SPIFFE IDs out of
+	from string
+
+	// Trust domain to move the SPIFFE IDs into
+	to string
+
+	// Only print the entries that would be rewritten
+	dryRun bool
+}
+
+func (*rewriteCommand) Name() string {
+	return "entry rewrite"
+}
+
+func (*rewriteCommand) Synopsis() string {
+	return "Moves the SPIFFE IDs of registration entries to another trust domain"
+}
+
+func (c *rewriteCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.from, "from", "", "The trust domain to move the SPIFFE IDs and parent IDs of the entries out of")
+	f.StringVar(&c.to, "to", "", "The trust domain to move the SPIFFE IDs and parent IDs of the entries into")
+	f.BoolVar(&c.dryRun, "dryRun", false, "Print the entries that would be rewritten without updating them")
+}
+
+func (c *rewriteCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	from, to, err := c.parseTrustDomains()
+	if err != nil {
+		return err
+	}
+
+	client := serverClient.NewEntryClient()
+	var entries []*types.Entry
+	pageToken := ""
+	for {
+		resp, err := client.ListEntries(ctx, &entry.ListEntriesRequest{
+			PageSize:  exportPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return fmt.Errorf("error fetching entries: %v", err)
+		}
+		for _, e := range resp.Entries {
+			if rewriteEntryTrustDomain(e, from, to) {
+				entries = append(entries, e)
+			}
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	if c.dryRun {
+		for _, e := range entries {
+			env.Printf("Would rewrite entry with ID %s to SPIFFE ID %s and parent ID %s\n", e.Id, protoToIDString(e.SpiffeId), protoToIDString(e.ParentId))
+		}
+		return env.Printf("%d %s would be rewritten\n", len(entries), util.Pluralizer("", "entry", "entries", len(entries)))
+	}
+
+	var failed []*entry.BatchUpdateEntryResponse_Result
+	rewritten := 0
+	for len(entries) > 0 {
+		n := batchSize
+		if n > len(entries) {
+			n = len(entries)
+		}
+
+		resp, err := client.BatchUpdateEntry(ctx, &entry.BatchUpdateEntryRequest{
+			Entries: entries[:n],
+			InputMask: &types.EntryMask{
+				SpiffeId: true,
+				ParentId: true,
+			},
+			OutputMask: &types.EntryMask{
+				SpiffeId: true,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, r := range resp.Results {
+			if r.Status.Code == int32(codes.OK) {
+				env.Printf("Rewrote entry with ID %s to SPIFFE ID %s\n", entries[i].Id, protoToIDString(r.Entry.GetSpiffeId()))
+				rewritten++
+				continue
+			}
+			failed = append(failed, r)
+			env.ErrPrintf("Failed to rewrite entry with ID %s (code: %s, msg: %q)\n", entries[i].Id, codes.Code(r.Status.Code), r.Status.Message)
+		}
+		entries = entries[n:]
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to rewrite %d %s", len(failed), util.Pluralizer("", "entry", "entries", len(failed)))
+	}
+	return env.Printf("Rewrote %d %s\n", rewritten, util.Pluralizer("", "entry", "entries", rewritten))
+}
+
+func (c *rewriteCommand) parseTrustDomains() (spiffeid.TrustDomain, spiffeid.TrustDomain, error) {
+	if c.from == "" {
+		return spiffeid.TrustDomain{}, spiffeid.TrustDomain{}, errors.New("a trust domain to rewrite from is required")
+	}
+	if c.to == "" {
+		return spiffeid.TrustDomain{}, spiffeid.TrustDomain{}, errors.New("a trust domain to rewrite to is required")
+	}
+	from, err := spiffeid.TrustDomainFromString(c.from)
+	if err != nil {
+		return spiffeid.TrustDomain{}, spiffeid.TrustDomain{}, fmt.Errorf("invalid trust domain to rewrite from: %v", err)
+	}
+	to, err := spiffeid.TrustDomainFromString(c.to)
+	if err != nil {
+		return spiffeid.TrustDomain{}, spiffeid.TrustDomain{}, fmt.Errorf("invalid trust domain to rewrite to: %v", err)
+	}
+	if from == to {
+		return spiffeid.TrustDomain{}, spiffeid.TrustDomain{}, errors.New("the trust domains to rewrite from and to must be different")
+	}
+	return from, to, nil
+}
+
+// rewriteEntryTrustDomain moves the SPIFFE ID and parent ID of the entry
+// from one trust domain to another, returning whether any was moved.
+func rewriteEntryTrustDomain(e *types.Entry, from, to spiffeid.TrustDomain) bool {
+	rewritten := false
+	for _, id := range []*types.SPIFFEID{e.SpiffeId, e.ParentId} {
+		if id != nil && id.TrustDomain == from.String() {
+			id.TrustDomain = to.String()
+			rewritten = true
+		}
+	}
+	return rewritten
+}
@@ -0,0 +1,183 @@
+package entry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRewriteHelp(t *testing.T) {
+	test := setupTest(t, newRewriteCommand)
+	test.client.Help()
+
+	require.Equal(t, `Usage of entry rewrite:
+  -dryRun
+    	Print the entries that would be rewritten without updating them
+  -from string
+    	The trust domain to move the SPIFFE IDs and parent IDs of the entries out of
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -to string
+    	The trust domain to move the SPIFFE IDs and parent IDs of the entries into
+`, test.stderr.String())
+}
+
+func TestRewriteSynopsis(t *testing.T) {
+	test := setupTest(t, newRewriteCommand)
+	require.Equal(t, "Moves the SPIFFE IDs of registration entries to another trust domain", test.client.Synopsis())
+}
+
+func TestRewrite(t *testing.T) {
+	listEntriesResp := func() *entry.ListEntriesResponse {
+		return &entry.ListEntriesResponse{
+			Entries: []*types.Entry{
+				{
+					Id:       "entry-1",
+					SpiffeId: &types.SPIFFEID{TrustDomain: "old.org", Path: "/workload"},
+					ParentId: &types.SPIFFEID{TrustDomain: "old.org", Path: "/agent"},
+				},
+				{
+					Id:       "entry-2",
+					SpiffeId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/workload"},
+					ParentId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/agent"},
+				},
+				{
+					Id:       "entry-3",
+					SpiffeId: &types.SPIFFEID{TrustDomain: "old.org", Path: "/other"},
+					ParentId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/agent"},
+				},
+			},
+		}
+	}
+
+	expUpdateReq := &entry.BatchUpdateEntryRequest{
+		Entries: []*types.Entry{
+			{
+				Id:       "entry-1",
+				SpiffeId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/workload"},
+				ParentId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/agent"},
+			},
+			{
+				Id:       "entry-3",
+				SpiffeId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/other"},
+				ParentId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/agent"},
+			},
+		},
+		InputMask:  &types.EntryMask{SpiffeId: true, ParentId: true},
+		OutputMask: &types.EntryMask{SpiffeId: true},
+	}
+
+	fakeRespOK := &entry.BatchUpdateEntryResponse{
+		Results: []*entry.BatchUpdateEntryResponse_Result{
+			{
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+				Entry:  &types.Entry{Id: "entry-1", SpiffeId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/workload"}},
+			},
+			{
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+				Entry:  &types.Entry{Id: "entry-3", SpiffeId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/other"}},
+			},
+		},
+	}
+
+	fakeRespErr := &entry.BatchUpdateEntryResponse{
+		Results: []*entry.BatchUpdateEntryResponse_Result{
+			{
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+				Entry:  &types.Entry{Id: "entry-1", SpiffeId: &types.SPIFFEID{TrustDomain: "new.org", Path: "/workload"}},
+			},
+			{
+				Status: &types.Status{Code: int32(codes.AlreadyExists), Message: "similar entry already exists"},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expUpdateReq *entry.BatchUpdateEntryRequest
+		fakeResp     *entry.BatchUpdateEntryResponse
+		serverErr    error
+
+		expOut string
+		expErr string
+	}{
+		{
+			name:   "Missing from",
+			args:   []string{"-to", "new.org"},
+			expErr: "a trust domain to rewrite from is required\n",
+		},
+		{
+			name:   "Missing to",
+			args:   []string{"-from", "old.org"},
+			expErr: "a trust domain to rewrite to is required\n",
+		},
+		{
+			name:   "Invalid trust domain",
+			args:   []string{"-from", "http://old.org", "-to", "new.org"},
+			expErr: "invalid trust domain to rewrite from: spiffeid: invalid scheme\n",
+		},
+		{
+			name:   "Same trust domains",
+			args:   []string{"-from", "old.org", "-to", "spiffe://old.org"},
+			expErr: "the trust domains to rewrite from and to must be different\n",
+		},
+		{
+			name:      "Server error",
+			args:      []string{"-from", "old.org", "-to", "new.org"},
+			serverErr: errors.New("server-error"),
+			expErr:    "error fetching entries: rpc error: code = Unknown desc = server-error\n",
+		},
+		{
+			name: "Dry run",
+			args: []string{"-from", "old.org", "-to", "new.org", "-dryRun"},
+			expOut: "Would rewrite entry with ID entry-1 to SPIFFE ID spiffe://new.org/workload and parent ID spiffe://new.org/agent\n" +
+				"Would rewrite entry with ID entry-3 to SPIFFE ID spiffe://new.org/other and parent ID spiffe://new.org/agent\n" +
+				"2 entries would be rewritten\n",
+		},
+		{
+			name:         "Rewrite succeeds",
+			args:         []string{"-from", "old.org", "-to", "new.org"},
+			expUpdateReq: expUpdateReq,
+			fakeResp:     fakeRespOK,
+			expOut: "Rewrote entry with ID entry-1 to SPIFFE ID spiffe://new.org/workload\n" +
+				"Rewrote entry with ID entry-3 to SPIFFE ID spiffe://new.org/other\n" +
+				"Rewrote 2 entries\n",
+		},
+		{
+			name:         "Rewrite reports each failure",
+			args:         []string{"-from", "old.org", "-to", "new.org"},
+			expUpdateReq: expUpdateReq,
+			fakeResp:     fakeRespErr,
+			expOut:       "Rewrote entry with ID entry-1 to SPIFFE ID spiffe://new.org/workload\n",
+			expErr:       "Failed to rewrite entry with ID entry-3 (code: AlreadyExists, msg: \"similar entry already exists\")\nfailed to rewrite 1 entry\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newRewriteCommand)
+			test.server.err = tt.serverErr
+			test.server.expListEntriesReq = &entry.ListEntriesRequest{PageSize: exportPageSize}
+			test.server.listEntriesResp = listEntriesResp()
+			test.server.expBatchUpdateEntryReq = tt.expUpdateReq
+			test.server.batchUpdateEntryResp = tt.fakeResp
+
+			args := append(test.args, tt.args...)
+			rc := test.client.Run(args)
+			if tt.expErr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expErr, test.stderr.String())
+				require.Equal(t, tt.expOut, test.stdout.String())
+				return
+			}
+
+			require.Equal(t, 0, rc)
+			require.Equal(t, tt.expOut, test.stdout.String())
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"google.golang.org/grpc/codes"
+
+	"golang.org/x/net/context"
+)
+
+// NewRotateCommand creates a new "rotate" subcommand for "entry" command.
+func NewRotateCommand() cli.Command {
+	return newRotateCommand(common_cli.DefaultEnv)
+}
+
+func newRotateCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(rotateCommand))
+}
+
+type rotateCommand struct {
+	// IDs of the records to rotate the SVIDs of
+	entryIDs StringsFlag
+
+	// Path of a file to read the IDs of the records to rotate the SVIDs of from
+	path string
+}
+
+func (*rotateCommand) Name() string {
+	return "entry rotate"
+}
+
+func (*rotateCommand) Synopsis() string {
+	return "Forces agents to renew the SVIDs of registration entries with new keys"
+}
+
+func (c *rotateCommand) AppendFlags(f *flag.FlagSet) {
+	f.Var(&c.entryIDs, "entryID", "The Registration Entry ID of the record to rotate the SVIDs of. Can be used more than once")
+	f.StringVar(&c.path, "file", "", "Path to a file containing the Registration Entry IDs of the records to rotate the SVIDs of, one per line. If set to '-', read the IDs from stdin.")
+}
+
+func (c *rotateCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	ids := c.entryIDs
+	if c.path != "" {
+		fileIDs, err := parseEntryIDs(env.Stdin, c.path)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, fileIDs...)
+	}
+
+	client := serverClient.NewEntryClient()
+	var failed []*entry.BatchRotateEntryResponse_Result
+	for len(ids) > 0 {
+		n := batchSize
+		if n > len(ids) {
+			n = len(ids)
+		}
+
+		resp, err := client.BatchRotateEntry(ctx, &entry.BatchRotateEntryRequest{Ids: ids[:n]})
+		if err != nil {
+			return err
+		}
+
+		for _, r := range resp.Results {
+			if r.Status.Code == int32(codes.OK) {
+				env.Printf("Rotated SVIDs of entry with ID: %s\n", r.Id)
+				continue
+			}
+			failed = append(failed, r)
+		}
+		ids = ids[n:]
+	}
+
+	switch {
+	case len(failed) == 0:
+		return nil
+	case len(c.entryIDs) == 1 && c.path == "":
+		return fmt.Errorf("failed to rotate entry: %s", failed[0].Status.Message)
+	default:
+		for _, r := range failed {
+			env.ErrPrintf("Failed to rotate entry with ID %s (code: %s, msg: %q)\n", r.Id, codes.Code(r.Status.Code), r.Status.Message)
+		}
+		return fmt.Errorf("failed to rotate %d %s", len(failed), util.Pluralizer("", "entry", "entries", len(failed)))
+	}
+}
+
+// Perform basic validation.
+func (c *rotateCommand) validate() error {
+	if len(c.entryIDs) == 0 && c.path == "" {
+		return errors.New("an entry ID is required")
+	}
+
+	return nil
+}
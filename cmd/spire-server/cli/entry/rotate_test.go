@@ -0,0 +1,132 @@
+package entry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRotateHelp(t *testing.T) {
+	test := setupTest(t, newRotateCommand)
+	test.client.Help()
+
+	require.Equal(t, `Usage of entry rotate:
+  -entryID value
+    	The Registration Entry ID of the record to rotate the SVIDs of. Can be used more than once
+  -file string
+    	Path to a file containing the Registration Entry IDs of the records to rotate the SVIDs of, one per line. If set to '-', read the IDs from stdin.
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, test.stderr.String())
+}
+
+func TestRotateSynopsis(t *testing.T) {
+	test := setupTest(t, newRotateCommand)
+	require.Equal(t, "Forces agents to renew the SVIDs of registration entries with new keys", test.client.Synopsis())
+}
+
+func TestRotate(t *testing.T) {
+	fakeRespOK := &entry.BatchRotateEntryResponse{
+		Results: []*entry.BatchRotateEntryResponse_Result{
+			{
+				Id:     "entry-id",
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+			},
+		},
+	}
+
+	fakeRespErr := &entry.BatchRotateEntryResponse{
+		Results: []*entry.BatchRotateEntryResponse_Result{
+			{
+				Id:     "entry-id",
+				Status: &types.Status{Code: int32(codes.NotFound), Message: "entry not found"},
+			},
+		},
+	}
+
+	fakeRespBatch := &entry.BatchRotateEntryResponse{
+		Results: []*entry.BatchRotateEntryResponse_Result{
+			{
+				Id:     "entry-1",
+				Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+			},
+			{
+				Id:     "entry-2",
+				Status: &types.Status{Code: int32(codes.NotFound), Message: "entry not found"},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name  string
+		args  []string
+		stdin string
+
+		expReq    *entry.BatchRotateEntryRequest
+		fakeResp  *entry.BatchRotateEntryResponse
+		serverErr error
+
+		expOut string
+		expErr string
+	}{
+		{
+			name:   "Empty entry ID",
+			expErr: "an entry ID is required\n",
+		},
+		{
+			name:     "Entry not found",
+			args:     []string{"-entryID", "entry-id"},
+			expReq:   &entry.BatchRotateEntryRequest{Ids: []string{"entry-id"}},
+			fakeResp: fakeRespErr,
+			expErr:   "failed to rotate entry: entry not found\n",
+		},
+		{
+			name:      "Server error",
+			args:      []string{"-entryID", "entry-id"},
+			expReq:    &entry.BatchRotateEntryRequest{Ids: []string{"entry-id"}},
+			serverErr: errors.New("server-error"),
+			expErr:    "rpc error: code = Unknown desc = server-error\n",
+		},
+		{
+			name:     "Rotate succeeds",
+			args:     []string{"-entryID", "entry-id"},
+			expReq:   &entry.BatchRotateEntryRequest{Ids: []string{"entry-id"}},
+			fakeResp: fakeRespOK,
+			expOut:   "Rotated SVIDs of entry with ID: entry-id\n",
+		},
+		{
+			name:     "Rotate many entries reports each failure",
+			args:     []string{"-file", "-"},
+			stdin:    "entry-1\nentry-2\n",
+			expReq:   &entry.BatchRotateEntryRequest{Ids: []string{"entry-1", "entry-2"}},
+			fakeResp: fakeRespBatch,
+			expOut:   "Rotated SVIDs of entry with ID: entry-1\n",
+			expErr:   "Failed to rotate entry with ID entry-2 (code: NotFound, msg: \"entry not found\")\nfailed to rotate 1 entry\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newRotateCommand)
+			test.server.err = tt.serverErr
+			test.server.expBatchRotateEntryReq = tt.expReq
+			test.server.batchRotateEntryResp = tt.fakeResp
+			test.stdin.WriteString(tt.stdin)
+
+			args := append(test.args, tt.args...)
+			rc := test.client.Run(args)
+			if tt.expErr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expErr, test.stderr.String())
+				require.Equal(t, tt.expOut, test.stdout.String())
+				return
+			}
+
+			require.Equal(t, 0, rc)
+			require.Equal(t, tt.expOut, test.stdout.String())
+		})
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"flag"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/protoutil"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"google.golang.org/grpc/codes"
@@ -59,6 +61,75 @@ type updateCommand struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	dnsNames StringsFlag
+
+	// Additional claims included in JWT-SVIDs, delimited by an equals sign (=)
+	// ex. "role=admin"
+	jwtSVIDClaims StringsFlag
+
+	// Default audience of JWT-SVIDs issued based on this entry
+	jwtSVIDAudience StringsFlag
+
+	// Key type of X509-SVIDs issued based on this entry
+	x509SVIDKeyType string
+
+	// Templates rendered into DNS names of X509-SVIDs issued based on this entry
+	dnsNameTemplates StringsFlag
+
+	// Subject fields of X509-SVIDs issued based on this entry
+	subjectCommonName         string
+	subjectOrganization       StringsFlag
+	subjectOrganizationalUnit StringsFlag
+	subjectCountry            StringsFlag
+
+	// Hint returned to workloads along with the SVIDs issued based on this entry
+	hint string
+
+	// Expression over the workload selectors that must also be satisfied for
+	// the entry to match a workload
+	selectorExpression string
+
+	// ID of the entry template the entry is bound to
+	templateID string
+
+	// Whether or not to only update the fields given by flags
+	partial bool
+
+	// Revision number the entry is expected to have
+	revision int64
+
+	// Query selecting the records to update instead of the entry ID
+	query entryQuery
+
+	// Only print the records that would be updated
+	dryRun bool
+
+	// Flags of the command, used to find out which fields were given
+	flags *flag.FlagSet
+}
+
+// entryMaskFields maps the flags of the command to the entry field they set.
+// The subject flags all set the whole subject.
+var entryMaskFields = map[string]func(*types.EntryMask){
+	"parentID":           func(m *types.EntryMask) { m.ParentId = true },
+	"spiffeID":           func(m *types.EntryMask) { m.SpiffeId = true },
+	"ttl":                func(m *types.EntryMask) { m.Ttl = true },
+	"selector":           func(m *types.EntryMask) { m.Selectors = true },
+	"federatesWith":      func(m *types.EntryMask) { m.FederatesWith = true },
+	"admin":              func(m *types.EntryMask) { m.Admin = true },
+	"downstream":         func(m *types.EntryMask) { m.Downstream = true },
+	"entryExpiry":        func(m *types.EntryMask) { m.ExpiresAt = true },
+	"dns":                func(m *types.EntryMask) { m.DnsNames = true },
+	"jwtSVIDClaim":       func(m *types.EntryMask) { m.JwtSvidClaims = true },
+	"jwtSVIDAudience":    func(m *types.EntryMask) { m.JwtSvidAudience = true },
+	"x509SVIDKeyType":    func(m *types.EntryMask) { m.X509SvidKeyType = true },
+	"dnsTemplate":        func(m *types.EntryMask) { m.DnsNameTemplates = true },
+	"subjectCN":          func(m *types.EntryMask) { m.X509SvidSubject = true },
+	"subjectO":           func(m *types.EntryMask) { m.X509SvidSubject = true },
+	"subjectOU":          func(m *types.EntryMask) { m.X509SvidSubject = true },
+	"subjectC":           func(m *types.EntryMask) { m.X509SvidSubject = true },
+	"hint":               func(m *types.EntryMask) { m.Hint = true },
+	"selectorExpression": func(m *types.EntryMask) { m.SelectorExpression = true },
+	"templateID":         func(m *types.EntryMask) { m.TemplateId = true },
 }
 
 func (*updateCommand) Name() string {
@@ -81,6 +152,23 @@ func (c *updateCommand) AppendFlags(f *flag.FlagSet) {
 	f.BoolVar(&c.downstream, "downstream", false, "A boolean value that, when set, indicates that the entry describes a downstream SPIRE server")
 	f.Int64Var(&c.entryExpiry, "entryExpiry", 0, "An expiry, from epoch in seconds, for the resulting registration entry to be pruned")
 	f.Var(&c.dnsNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
+	f.Var(&c.jwtSVIDClaims, "jwtSVIDClaim", "An equals-delimited name=value claim that will be included in JWT-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.jwtSVIDAudience, "jwtSVIDAudience", "An audience used for JWT-SVIDs issued based on this entry when the request does not specify one. Can be used more than once")
+	f.StringVar(&c.x509SVIDKeyType, "x509SVIDKeyType", "", "The key type of X509-SVIDs issued based on this entry (ec-p256, ec-p384, rsa-2048 or rsa-4096). Defaults to ec-p256")
+	f.Var(&c.dnsNameTemplates, "dnsTemplate", "A Go text/template rendered into a DNS name that will be included in X509-SVIDs issued based on this entry. Can be used more than once")
+	f.StringVar(&c.subjectCommonName, "subjectCN", "", "The subject common name of X509-SVIDs issued based on this entry. Replaced by the first DNS name, if any")
+	f.Var(&c.subjectOrganization, "subjectO", "A subject organization of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.subjectOrganizationalUnit, "subjectOU", "A subject organizational unit of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.subjectCountry, "subjectC", "A subject country of X509-SVIDs issued based on this entry. Can be used more than once")
+	f.StringVar(&c.hint, "hint", "", "A hint returned to workloads along with the SVIDs issued based on this entry, to tell them apart from the SVIDs of other entries (e.g. internal, external)")
+	f.StringVar(&c.selectorExpression, "selectorExpression", "", "An expression over the workload selectors that must also be satisfied for the entry to match a workload, combining type:value selectors with &&, || and !, parentheses and * wildcards in values (e.g. '!k8s:sa:admin')")
+	f.StringVar(&c.templateID, "templateID", "", "The ID of an entry template that sets the TTL, federated trust domains and DNS name templates of this entry, and constrains its SPIFFE ID path")
+	f.BoolVar(&c.partial, "partial", false, "If set, only the fields given by flags are updated, leaving the other fields of the entry unchanged")
+	f.Int64Var(&c.revision, "revision", 0, "If set, the update is rejected when the entry revision number does not match this one, i.e. when the entry was modified by someone else")
+	f.StringVar(&c.query.spiffeID, "matchSpiffeID", "", "The SPIFFE ID of the records to update instead of -entryID, where '*' matches any sequence of characters. Requires -partial")
+	f.Var(&c.query.selectors, "matchSelector", "A colon-delimited type:value selector the records to update instead of -entryID have. Can be used more than once. Requires -partial")
+	f.BoolVar(&c.dryRun, "dryRun", false, "Print the records matching -matchSpiffeID and -matchSelector without updating them")
+	c.flags = f
 }
 
 func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
@@ -89,6 +177,7 @@ func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 	}
 
 	var entries []*types.Entry
+	var inputMask *types.EntryMask
 	var err error
 	if c.path != "" {
 		entries, err = parseFile(c.path)
@@ -98,15 +187,49 @@ func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 	if err != nil {
 		return err
 	}
+	if c.partial {
+		inputMask = c.inputMask()
+	}
+	if c.isSet("revision") {
+		if inputMask == nil {
+			inputMask = proto.Clone(protoutil.AllTrueEntryMask).(*types.EntryMask)
+		}
+		inputMask.RevisionNumber = true
+		entries[0].RevisionNumber = c.revision
+	}
 
-	succeeded, failed, err := updateEntries(ctx, serverClient.NewEntryClient(), entries)
-	if err != nil {
-		return err
+	client := serverClient.NewEntryClient()
+	if c.query.isSet() {
+		matched, err := c.query.fetchEntries(ctx, client)
+		if err != nil {
+			return err
+		}
+		if c.dryRun {
+			printEntries(matched, env)
+			return nil
+		}
+		entries = applyToEntries(entries[0], matched)
+	}
+
+	var succeeded, failed []*entry.BatchUpdateEntryResponse_Result
+	for len(entries) > 0 {
+		n := batchSize
+		if n > len(entries) {
+			n = len(entries)
+		}
+
+		s, f, err := updateEntries(ctx, client, entries[:n], inputMask)
+		if err != nil {
+			return err
+		}
+		succeeded = append(succeeded, s...)
+		failed = append(failed, f...)
+		entries = entries[n:]
 	}
 
 	// Print entries that succeeded to be updated
 	for _, e := range succeeded {
-		printEntry(e.Entry, env)
+		PrintEntry(e.Entry, env)
 	}
 
 	// Print entries that failed to be updated
@@ -114,7 +237,7 @@ func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 		env.Printf("FAILED to update the following %s:\n", util.Pluralizer("", "entry", "entries", len(failed)))
 	}
 	for _, r := range failed {
-		printEntry(r.Entry, env)
+		PrintEntry(r.Entry, env)
 		env.Printf("%s\n", r.Status.Message)
 	}
 
@@ -126,13 +249,43 @@ func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClie
 func (c *updateCommand) validate() (err error) {
 	// If a path is set, we have all we need
 	if c.path != "" {
+		if c.partial {
+			return errors.New("the partial flag cannot be used with a data file")
+		}
+		if c.isSet("revision") {
+			return errors.New("the revision flag cannot be used with a data file")
+		}
+		if c.query.isSet() || c.dryRun {
+			return errors.New("the -matchSpiffeID, -matchSelector and -dryRun flags cannot be used with a data file")
+		}
 		return nil
 	}
 
+	if c.query.isSet() {
+		if c.entryID != "" {
+			return errors.New("the -matchSpiffeID and -matchSelector flags can't be combined with -entryID")
+		}
+		if !c.partial {
+			return errors.New("the -matchSpiffeID and -matchSelector flags require -partial")
+		}
+		if c.isSet("revision") {
+			return errors.New("the revision flag cannot be used with -matchSpiffeID or -matchSelector")
+		}
+		return c.validatePartial()
+	}
+
+	if c.dryRun {
+		return errors.New("the -dryRun flag requires -matchSpiffeID or -matchSelector")
+	}
+
 	if c.entryID == "" {
 		return errors.New("entry ID is required")
 	}
 
+	if c.partial {
+		return c.validatePartial()
+	}
+
 	if len(c.selectors) < 1 {
 		return errors.New("at least one selector is required")
 	}
@@ -168,15 +321,75 @@ func (c *updateCommand) validate() (err error) {
 	return nil
 }
 
+// validatePartial validates the fields given for a partial update, since the
+// other fields are left unchanged.
+func (c *updateCommand) validatePartial() (err error) {
+	if proto.Equal(c.inputMask(), &types.EntryMask{}) {
+		return errors.New("at least one field to update is required")
+	}
+
+	if c.isSet("ttl") && c.ttl < 0 {
+		return errors.New("a positive TTL is required")
+	}
+
+	if c.isSet("spiffeID") {
+		c.spiffeID, err = idutil.NormalizeSpiffeID(c.spiffeID, idutil.AllowAny())
+		if err != nil {
+			return err
+		}
+	}
+	if c.isSet("parentID") {
+		c.parentID, err = idutil.NormalizeSpiffeID(c.parentID, idutil.AllowAny())
+		if err != nil {
+			return err
+		}
+	}
+	for i := range c.federatesWith {
+		c.federatesWith[i], err = idutil.NormalizeSpiffeID(c.federatesWith[i], idutil.AllowAny())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inputMask returns the mask of the fields given by flags.
+func (c *updateCommand) inputMask() *types.EntryMask {
+	mask := &types.EntryMask{}
+	c.flags.Visit(func(f *flag.Flag) {
+		if set, ok := entryMaskFields[f.Name]; ok {
+			set(mask)
+		}
+	})
+	return mask
+}
+
+func (c *updateCommand) isSet(name string) bool {
+	set := false
+	c.flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
 // parseConfig builds a registration entry from the given config
 func (c *updateCommand) parseConfig() ([]*types.Entry, error) {
-	parentID, err := idStringToProto(c.parentID)
-	if err != nil {
-		return nil, err
+	var parentID, spiffeID *types.SPIFFEID
+	var err error
+	if !c.partial || c.isSet("parentID") {
+		parentID, err = idStringToProto(c.parentID)
+		if err != nil {
+			return nil, err
+		}
 	}
-	spiffeID, err := idStringToProto(c.spiffeID)
-	if err != nil {
-		return nil, err
+	if !c.partial || c.isSet("spiffeID") {
+		spiffeID, err = idStringToProto(c.spiffeID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	e := &types.Entry{
@@ -199,15 +412,40 @@ func (c *updateCommand) parseConfig() ([]*types.Entry, error) {
 		selectors = append(selectors, cs)
 	}
 
+	jwtSVIDClaims, err := parseJWTSVIDClaims(c.jwtSVIDClaims)
+	if err != nil {
+		return nil, err
+	}
+
 	e.Selectors = selectors
 	e.FederatesWith = c.federatesWith
 	e.Admin = c.admin
+	e.JwtSvidClaims = jwtSVIDClaims
+	e.JwtSvidAudience = c.jwtSVIDAudience
+	e.X509SvidKeyType = c.x509SVIDKeyType
+	e.DnsNameTemplates = c.dnsNameTemplates
+	e.X509SvidSubject = makeX509SVIDSubject(c.subjectCommonName, c.subjectOrganization, c.subjectOrganizationalUnit, c.subjectCountry)
+	e.Hint = c.hint
+	e.SelectorExpression = c.selectorExpression
+	e.TemplateId = c.templateID
 	return []*types.Entry{e}, nil
 }
 
-func updateEntries(ctx context.Context, c entry.EntryClient, entries []*types.Entry) (succeeded, failed []*entry.BatchUpdateEntryResponse_Result, err error) {
+// applyToEntries returns a copy of the update for each of the entries.
+func applyToEntries(update *types.Entry, entries []*types.Entry) []*types.Entry {
+	updates := make([]*types.Entry, 0, len(entries))
+	for _, e := range entries {
+		u := proto.Clone(update).(*types.Entry)
+		u.Id = e.Id
+		updates = append(updates, u)
+	}
+	return updates
+}
+
+func updateEntries(ctx context.Context, c entry.EntryClient, entries []*types.Entry, inputMask *types.EntryMask) (succeeded, failed []*entry.BatchUpdateEntryResponse_Result, err error) {
 	resp, err := c.BatchUpdateEntry(ctx, &entry.BatchUpdateEntryRequest{
-		Entries: entries,
+		Entries:   entries,
+		InputMask: inputMask,
 	})
 	if err != nil {
 		return
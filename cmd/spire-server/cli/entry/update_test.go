@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/spiffe/spire/pkg/common/protoutil"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/stretchr/testify/require"
@@ -23,24 +24,56 @@ func TestUpdateHelp(t *testing.T) {
     	Path to a file containing registration JSON (optional). If set to '-', read the JSON from stdin.
   -dns value
     	A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once
+  -dnsTemplate value
+    	A Go text/template rendered into a DNS name that will be included in X509-SVIDs issued based on this entry. Can be used more than once
   -downstream
     	A boolean value that, when set, indicates that the entry describes a downstream SPIRE server
+  -dryRun
+    	Print the records matching -matchSpiffeID and -matchSelector without updating them
   -entryExpiry int
     	An expiry, from epoch in seconds, for the resulting registration entry to be pruned
   -entryID string
     	The Registration Entry ID of the record to update
   -federatesWith value
     	SPIFFE ID of a trust domain to federate with. Can be used more than once
+  -hint string
+    	A hint returned to workloads along with the SVIDs issued based on this entry, to tell them apart from the SVIDs of other entries (e.g. internal, external)
+  -jwtSVIDAudience value
+    	An audience used for JWT-SVIDs issued based on this entry when the request does not specify one. Can be used more than once
+  -jwtSVIDClaim value
+    	An equals-delimited name=value claim that will be included in JWT-SVIDs issued based on this entry. Can be used more than once
+  -matchSelector value
+    	A colon-delimited type:value selector the records to update instead of -entryID have. Can be used more than once. Requires -partial
+  -matchSpiffeID string
+    	The SPIFFE ID of the records to update instead of -entryID, where '*' matches any sequence of characters. Requires -partial
   -parentID string
     	The SPIFFE ID of this record's parent
+  -partial
+    	If set, only the fields given by flags are updated, leaving the other fields of the entry unchanged
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -revision int
+    	If set, the update is rejected when the entry revision number does not match this one, i.e. when the entry was modified by someone else
   -selector value
     	A colon-delimited type:value selector. Can be used more than once
+  -selectorExpression string
+    	An expression over the workload selectors that must also be satisfied for the entry to match a workload, combining type:value selectors with &&, || and !, parentheses and * wildcards in values (e.g. '!k8s:sa:admin')
   -spiffeID string
     	The SPIFFE ID that this record represents
+  -subjectC value
+    	A subject country of X509-SVIDs issued based on this entry. Can be used more than once
+  -subjectCN string
+    	The subject common name of X509-SVIDs issued based on this entry. Replaced by the first DNS name, if any
+  -subjectO value
+    	A subject organization of X509-SVIDs issued based on this entry. Can be used more than once
+  -subjectOU value
+    	A subject organizational unit of X509-SVIDs issued based on this entry. Can be used more than once
+  -templateID string
+    	The ID of an entry template that sets the TTL, federated trust domains and DNS name templates of this entry, and constrains its SPIFFE ID path
   -ttl int
     	The lifetime, in seconds, for SVIDs issued based on this registration entry
+  -x509SVIDKeyType string
+    	The key type of X509-SVIDs issued based on this entry (ec-p256, ec-p384, rsa-2048 or rsa-4096). Defaults to ec-p256
 `, test.stderr.String())
 }
 
@@ -252,6 +285,225 @@ Selector         : unix:uid:1111
 
 `,
 		},
+		{
+			name: "Partial update only sends the given fields",
+			args: []string{
+				"-entryID", "entry-id",
+				"-partial",
+				"-ttl", "60",
+				"-dns", "unu1000",
+			},
+			expReq: &entry.BatchUpdateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						Id:        "entry-id",
+						Ttl:       60,
+						DnsNames:  []string{"unu1000"},
+						Selectors: []*types.Selector{},
+					},
+				},
+				InputMask: &types.EntryMask{
+					Ttl:      true,
+					DnsNames: true,
+				},
+			},
+			fakeResp: fakeRespOKFromCmd,
+			expOut: fmt.Sprintf(`Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 0
+Downstream       : true
+TTL              : 60
+Expiration time  : %s
+Selector         : zebra:zebra:2000
+Selector         : alpha:alpha:2000
+FederatesWith    : spiffe://domaina.test
+FederatesWith    : spiffe://domainb.test
+DNS name         : unu1000
+DNS name         : ung1000
+Admin            : true
+
+`, time.Unix(1552410266, 0).UTC()),
+		},
+		{
+			name: "Partial update with revision number",
+			args: []string{
+				"-entryID", "entry-id",
+				"-partial",
+				"-revision", "2",
+				"-ttl", "60",
+			},
+			expReq: &entry.BatchUpdateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						Id:             "entry-id",
+						Ttl:            60,
+						RevisionNumber: 2,
+						Selectors:      []*types.Selector{},
+					},
+				},
+				InputMask: &types.EntryMask{
+					Ttl:            true,
+					RevisionNumber: true,
+				},
+			},
+			fakeResp: fakeRespErr,
+			expOut: `FAILED to update the following entry:
+Entry ID         : entry-id
+SPIFFE ID        : 
+Parent ID        : 
+Revision         : 2
+TTL              : 60
+
+failed to update entry: datastore-sql: record not found
+`,
+		},
+		{
+			name: "Partial update of the hint",
+			args: []string{
+				"-entryID", "entry-id",
+				"-partial",
+				"-hint", "external",
+			},
+			expReq: &entry.BatchUpdateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						Id:        "entry-id",
+						Selectors: []*types.Selector{},
+						Hint:      "external",
+					},
+				},
+				InputMask: &types.EntryMask{
+					Hint: true,
+				},
+			},
+			fakeResp: fakeRespErr,
+			expOut: `FAILED to update the following entry:
+Entry ID         : entry-id
+SPIFFE ID        : 
+Parent ID        : 
+Revision         : 0
+TTL              : default
+Hint             : external
+
+failed to update entry: datastore-sql: record not found
+`,
+		},
+		{
+			name: "Partial update of the selector expression",
+			args: []string{
+				"-entryID", "entry-id",
+				"-partial",
+				"-selectorExpression", "k8s:sa:web || k8s:sa:api",
+			},
+			expReq: &entry.BatchUpdateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						Id:                 "entry-id",
+						Selectors:          []*types.Selector{},
+						SelectorExpression: "k8s:sa:web || k8s:sa:api",
+					},
+				},
+				InputMask: &types.EntryMask{
+					SelectorExpression: true,
+				},
+			},
+			fakeResp: fakeRespErr,
+			expOut: `FAILED to update the following entry:
+Entry ID         : entry-id
+SPIFFE ID        : 
+Parent ID        : 
+Revision         : 0
+TTL              : default
+Selector expr    : k8s:sa:web || k8s:sa:api
+
+failed to update entry: datastore-sql: record not found
+`,
+		},
+		{
+			name: "Partial update of the entry template",
+			args: []string{
+				"-entryID", "entry-id",
+				"-partial",
+				"-templateID", "web",
+			},
+			expReq: &entry.BatchUpdateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						Id:         "entry-id",
+						Selectors:  []*types.Selector{},
+						TemplateId: "web",
+					},
+				},
+				InputMask: &types.EntryMask{
+					TemplateId: true,
+				},
+			},
+			fakeResp: fakeRespErr,
+			expOut: `FAILED to update the following entry:
+Entry ID         : entry-id
+SPIFFE ID        : 
+Parent ID        : 
+Revision         : 0
+TTL              : default
+Entry template   : web
+
+failed to update entry: datastore-sql: record not found
+`,
+		},
+		{
+			name: "Update with revision number",
+			args: []string{
+				"-entryID", "entry-id",
+				"-revision", "2",
+				"-spiffeID", "spiffe://example.org/workload",
+				"-parentID", "spiffe://example.org/parent",
+				"-selector", "unix:uid:1",
+			},
+			expReq: &entry.BatchUpdateEntryRequest{
+				Entries: []*types.Entry{
+					{
+						Id:             "entry-id",
+						SpiffeId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+						ParentId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+						Selectors:      []*types.Selector{{Type: "unix", Value: "uid:1"}},
+						RevisionNumber: 2,
+					},
+				},
+				InputMask: protoutil.AllTrueEntryMask,
+			},
+			fakeResp: fakeRespErr,
+			expOut: `FAILED to update the following entry:
+Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 2
+TTL              : default
+Selector         : unix:uid:1
+
+failed to update entry: datastore-sql: record not found
+`,
+		},
+		{
+			name:   "Revision number with data file",
+			args:   []string{"-revision", "2", "-data", "../../../../test/fixture/registration/good-for-update.json"},
+			expErr: "the revision flag cannot be used with a data file\n",
+		},
+		{
+			name:   "Partial update without fields",
+			args:   []string{"-entryID", "entry-id", "-partial"},
+			expErr: "at least one field to update is required\n",
+		},
+		{
+			name:   "Partial update with wrong SPIFFE ID",
+			args:   []string{"-entryID", "entry-id", "-partial", "-spiffeID", "invalid-id"},
+			expErr: "\"invalid-id\" is not a valid SPIFFE ID: invalid scheme\n",
+		},
+		{
+			name:   "Partial update with data file",
+			args:   []string{"-partial", "-data", "../../../../test/fixture/registration/good-for-update.json"},
+			expErr: "the partial flag cannot be used with a data file\n",
+		},
 		{
 			name: "Entry not found",
 			args: []string{"-entryID", "non-existent-id", "-spiffeID", "spiffe://example.org/workload", "-parentID", "spiffe://example.org/parent", "-selector", "unix:uid:1"},
@@ -296,3 +548,130 @@ failed to update entry: datastore-sql: record not found
 		})
 	}
 }
+
+func TestUpdateByQuery(t *testing.T) {
+	entries := []*types.Entry{
+		{
+			Id:        "entry-1",
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/app/web"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+			Selectors: []*types.Selector{{Type: "k8s", Value: "ns:legacy"}},
+		},
+		{
+			Id:        "entry-2",
+			SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/app/db"},
+			ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+			Selectors: []*types.Selector{{Type: "k8s", Value: "ns:prod"}},
+		},
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expListReq *entry.ListEntriesRequest
+		expReq     *entry.BatchUpdateEntryRequest
+		fakeResp   *entry.BatchUpdateEntryResponse
+
+		expOut string
+		expErr string
+	}{
+		{
+			name:   "Query without partial",
+			args:   []string{"-matchSelector", "k8s:ns:legacy", "-ttl", "60"},
+			expErr: "the -matchSpiffeID and -matchSelector flags require -partial\n",
+		},
+		{
+			name:   "Query combined with entry ID",
+			args:   []string{"-entryID", "entry-1", "-matchSelector", "k8s:ns:legacy", "-partial", "-ttl", "60"},
+			expErr: "the -matchSpiffeID and -matchSelector flags can't be combined with -entryID\n",
+		},
+		{
+			name:   "Query with revision number",
+			args:   []string{"-matchSelector", "k8s:ns:legacy", "-partial", "-revision", "2", "-ttl", "60"},
+			expErr: "the revision flag cannot be used with -matchSpiffeID or -matchSelector\n",
+		},
+		{
+			name:   "Query with data file",
+			args:   []string{"-matchSelector", "k8s:ns:legacy", "-data", "../../../../test/fixture/registration/good-for-update.json"},
+			expErr: "the -matchSpiffeID, -matchSelector and -dryRun flags cannot be used with a data file\n",
+		},
+		{
+			name:   "Dry run without query",
+			args:   []string{"-entryID", "entry-1", "-partial", "-ttl", "60", "-dryRun"},
+			expErr: "the -dryRun flag requires -matchSpiffeID or -matchSelector\n",
+		},
+		{
+			name:       "Dry run",
+			args:       []string{"-matchSpiffeID", "spiffe://example.org/app/*", "-matchSelector", "k8s:ns:legacy", "-partial", "-ttl", "60", "-dryRun"},
+			expListReq: &entry.ListEntriesRequest{Filter: &entry.ListEntriesRequest_Filter{}, PageSize: exportPageSize},
+			expOut: `Found 1 entry
+Entry ID         : entry-1
+SPIFFE ID        : spiffe://example.org/app/web
+Parent ID        : spiffe://example.org/agent
+Revision         : 0
+TTL              : default
+Selector         : k8s:ns:legacy
+
+`,
+		},
+		{
+			name:       "Update by SPIFFE ID pattern",
+			args:       []string{"-matchSpiffeID", "spiffe://example.org/app/*", "-partial", "-ttl", "60"},
+			expListReq: &entry.ListEntriesRequest{Filter: &entry.ListEntriesRequest_Filter{}, PageSize: exportPageSize},
+			expReq: &entry.BatchUpdateEntryRequest{
+				Entries: []*types.Entry{
+					{Id: "entry-1", Ttl: 60, Selectors: []*types.Selector{}},
+					{Id: "entry-2", Ttl: 60, Selectors: []*types.Selector{}},
+				},
+				InputMask: &types.EntryMask{Ttl: true},
+			},
+			fakeResp: &entry.BatchUpdateEntryResponse{
+				Results: []*entry.BatchUpdateEntryResponse_Result{
+					{
+						Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+						Entry:  &types.Entry{Id: "entry-1", SpiffeId: entries[0].SpiffeId, ParentId: entries[0].ParentId, Ttl: 60},
+					},
+					{
+						Status: &types.Status{Code: int32(codes.NotFound), Message: "failed to update entry: datastore-sql: record not found"},
+					},
+				},
+			},
+			expOut: `Entry ID         : entry-1
+SPIFFE ID        : spiffe://example.org/app/web
+Parent ID        : spiffe://example.org/agent
+Revision         : 0
+TTL              : 60
+
+FAILED to update the following entry:
+Entry ID         : entry-2
+SPIFFE ID        : 
+Parent ID        : 
+Revision         : 0
+TTL              : 60
+
+failed to update entry: datastore-sql: record not found
+`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, newUpdateCommand)
+			test.server.expListEntriesReq = tt.expListReq
+			test.server.listEntriesResp = &entry.ListEntriesResponse{Entries: entries}
+			test.server.expBatchUpdateEntryReq = tt.expReq
+			test.server.batchUpdateEntryResp = tt.fakeResp
+
+			args := append(test.args, tt.args...)
+			rc := test.client.Run(args)
+			if tt.expErr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expErr, test.stderr.String())
+				return
+			}
+
+			require.Equal(t, 0, rc)
+			require.Equal(t, tt.expOut, test.stdout.String())
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package entry
 
 import (
+	"crypto/x509/pkix"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
+	"sigs.k8s.io/yaml"
 )
 
 // parseSelector parses a CLI string from type:value into a selector type.
@@ -32,7 +35,8 @@ func parseSelector(str string) (*types.Selector, error) {
 	return s, nil
 }
 
-func printEntry(e *types.Entry, env *common_cli.Env) {
+// PrintEntry prints the details of a registration entry
+func PrintEntry(e *types.Entry, env *common_cli.Env) {
 	env.Printf("Entry ID         : %s\n", e.Id)
 	env.Printf("SPIFFE ID        : %s\n", protoToIDString(e.SpiffeId))
 	env.Printf("Parent ID        : %s\n", protoToIDString(e.ParentId))
@@ -62,6 +66,37 @@ func printEntry(e *types.Entry, env *common_cli.Env) {
 		env.Printf("DNS name         : %s\n", dnsName)
 	}
 
+	claimNames := make([]string, 0, len(e.JwtSvidClaims))
+	for name := range e.JwtSvidClaims {
+		claimNames = append(claimNames, name)
+	}
+	sort.Strings(claimNames)
+	for _, name := range claimNames {
+		env.Printf("JWT-SVID claim   : %s=%s\n", name, e.JwtSvidClaims[name])
+	}
+	for _, audience := range e.JwtSvidAudience {
+		env.Printf("JWT-SVID audience: %s\n", audience)
+	}
+
+	if e.X509SvidKeyType != "" {
+		env.Printf("X509-SVID key    : %s\n", e.X509SvidKeyType)
+	}
+	for _, template := range e.DnsNameTemplates {
+		env.Printf("DNS template     : %s\n", template)
+	}
+	if e.X509SvidSubject != nil {
+		env.Printf("X509-SVID subject: %s\n", x509SVIDSubjectString(e.X509SvidSubject))
+	}
+	if e.Hint != "" {
+		env.Printf("Hint             : %s\n", e.Hint)
+	}
+	if e.SelectorExpression != "" {
+		env.Printf("Selector expr    : %s\n", e.SelectorExpression)
+	}
+	if e.TemplateId != "" {
+		env.Printf("Entry template   : %s\n", e.TemplateId)
+	}
+
 	// admin is rare, so only show admin if true to keep
 	// from muddying the output.
 	if e.Admin {
@@ -71,6 +106,45 @@ func printEntry(e *types.Entry, env *common_cli.Env) {
 	env.Println()
 }
 
+// makeX509SVIDSubject builds an X509-SVID subject from the subject flags. It
+// returns nil if no subject field is set.
+func makeX509SVIDSubject(commonName string, organization, organizationalUnit, country []string) *types.X509SVIDSubject {
+	if commonName == "" && len(organization) == 0 && len(organizationalUnit) == 0 && len(country) == 0 {
+		return nil
+	}
+	return &types.X509SVIDSubject{
+		CommonName:         commonName,
+		Organization:       organization,
+		OrganizationalUnit: organizationalUnit,
+		Country:            country,
+	}
+}
+
+func x509SVIDSubjectString(subject *types.X509SVIDSubject) string {
+	return pkix.Name{
+		CommonName:         subject.CommonName,
+		Organization:       subject.Organization,
+		OrganizationalUnit: subject.OrganizationalUnit,
+		Country:            subject.Country,
+	}.String()
+}
+
+// parseJWTSVIDClaims parses a list of equals-delimited name=value claims
+func parseJWTSVIDClaims(claims []string) (map[string]string, error) {
+	if len(claims) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(claims))
+	for _, claim := range claims {
+		parts := strings.SplitN(claim, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("JWT-SVID claim %q must be formatted as name=value", claim)
+		}
+		m[parts[0]] = parts[1]
+	}
+	return m, nil
+}
+
 // idStringToProto converts a SPIFFE ID from the given string to *types.SPIFFEID
 func idStringToProto(id string) (*types.SPIFFEID, error) {
 	idType, err := spiffeid.FromString(id)
@@ -121,6 +195,85 @@ func parseEntryJSON(in io.Reader, path string) ([]*types.Entry, error) {
 	return api.RegistrationEntriesToProto(entries.Entries)
 }
 
+// parseEntryData parses RegistrationEntries represented either in JSON or
+// YAML. If path is "-" the data is read from in.
+func parseEntryData(in io.Reader, path string) ([]*types.Entry, error) {
+	r := in
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	dat, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, so both formats are handled by converting to JSON
+	dat, err = yaml.YAMLToJSON(dat)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := &common.RegistrationEntries{}
+	if err := json.Unmarshal(dat, entries); err != nil {
+		return nil, err
+	}
+	return api.RegistrationEntriesToProto(entries.Entries)
+}
+
+// protoToRegistrationEntry converts an entry into the representation used by
+// entry data files. The entry ID and revision number are left out since they
+// are assigned by the server.
+func protoToRegistrationEntry(e *types.Entry) *common.RegistrationEntry {
+	selectors := make([]*common.Selector, 0, len(e.Selectors))
+	for _, s := range e.Selectors {
+		selectors = append(selectors, &common.Selector{Type: s.Type, Value: s.Value})
+	}
+
+	federatesWith := make([]string, 0, len(e.FederatesWith))
+	for _, td := range e.FederatesWith {
+		federatesWith = append(federatesWith, "spiffe://"+td)
+	}
+
+	var subject *common.X509SVIDSubject
+	if e.X509SvidSubject != nil {
+		subject = &common.X509SVIDSubject{
+			CommonName:         e.X509SvidSubject.CommonName,
+			Organization:       e.X509SvidSubject.Organization,
+			OrganizationalUnit: e.X509SvidSubject.OrganizationalUnit,
+			Country:            e.X509SvidSubject.Country,
+		}
+	}
+
+	return &common.RegistrationEntry{
+		ParentId:           protoToIDString(e.ParentId),
+		SpiffeId:           protoToIDString(e.SpiffeId),
+		Selectors:          selectors,
+		Ttl:                e.Ttl,
+		FederatesWith:      federatesWith,
+		Admin:              e.Admin,
+		Downstream:         e.Downstream,
+		EntryExpiry:        e.ExpiresAt,
+		DnsNames:           e.DnsNames,
+		JwtSvidClaims:      e.JwtSvidClaims,
+		JwtSvidAudience:    e.JwtSvidAudience,
+		X509SvidKeyType:    e.X509SvidKeyType,
+		DnsNameTemplates:   e.DnsNameTemplates,
+		X509SvidSubject:    subject,
+		Hint:               e.Hint,
+		SelectorExpression: e.SelectorExpression,
+	}
+}
+
+// batchSize is the number of entries sent per request by the commands
+// operating on many entries, like import and delete.
+const batchSize = 50
+
 // StringsFlag defines a custom type for string lists. Doing
 // this allows us to support repeatable string flags.
 type StringsFlag []string
@@ -8,6 +8,7 @@ import (
 
 	"github.com/mitchellh/cli"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/spiretest"
@@ -117,8 +118,9 @@ type entryTest struct {
 	stdout *bytes.Buffer
 	stderr *bytes.Buffer
 
-	args   []string
-	server *fakeEntryServer
+	args        []string
+	server      *fakeEntryServer
+	debugServer *fakeDebugServer
 
 	client cli.Command
 }
@@ -141,12 +143,14 @@ type fakeEntryServer struct {
 	expBatchDeleteEntryReq *entry.BatchDeleteEntryRequest
 	expBatchCreateEntryReq *entry.BatchCreateEntryRequest
 	expBatchUpdateEntryReq *entry.BatchUpdateEntryRequest
+	expBatchRotateEntryReq *entry.BatchRotateEntryRequest
 
 	getEntryResp         *types.Entry
 	listEntriesResp      *entry.ListEntriesResponse
 	batchDeleteEntryResp *entry.BatchDeleteEntryResponse
 	batchCreateEntryResp *entry.BatchCreateEntryResponse
 	batchUpdateEntryResp *entry.BatchUpdateEntryResponse
+	batchRotateEntryResp *entry.BatchRotateEntryResponse
 }
 
 func (f fakeEntryServer) ListEntries(ctx context.Context, req *entry.ListEntriesRequest) (*entry.ListEntriesResponse, error) {
@@ -189,6 +193,32 @@ func (f fakeEntryServer) BatchUpdateEntry(ctx context.Context, req *entry.BatchU
 	return f.batchUpdateEntryResp, nil
 }
 
+func (f fakeEntryServer) BatchRotateEntry(ctx context.Context, req *entry.BatchRotateEntryRequest) (*entry.BatchRotateEntryResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	spiretest.RequireProtoEqual(f.t, f.expBatchRotateEntryReq, req)
+	return f.batchRotateEntryResp, nil
+}
+
+type fakeDebugServer struct {
+	*debug.UnimplementedDebugServer
+
+	t   *testing.T
+	err error
+
+	expMatchEntriesReq *debug.MatchEntriesRequest
+	matchEntriesResp   *debug.MatchEntriesResponse
+}
+
+func (f *fakeDebugServer) MatchEntries(ctx context.Context, req *debug.MatchEntriesRequest) (*debug.MatchEntriesResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	spiretest.RequireProtoEqual(f.t, f.expMatchEntriesReq, req)
+	return f.matchEntriesResp, nil
+}
+
 func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *entryTest {
 	stdin := new(bytes.Buffer)
 	stdout := new(bytes.Buffer)
@@ -201,17 +231,20 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *entry
 	})
 
 	server := &fakeEntryServer{t: t}
+	debugServer := &fakeDebugServer{t: t}
 	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
 		entry.RegisterEntryServer(s, server)
+		debug.RegisterDebugServer(s, debugServer)
 	})
 
 	test := &entryTest{
-		stdin:  stdin,
-		stdout: stdout,
-		stderr: stderr,
-		args:   []string{"-registrationUDSPath", socketPath},
-		server: server,
-		client: client,
+		stdin:       stdin,
+		stdout:      stdout,
+		stderr:      stderr,
+		args:        []string{"-registrationUDSPath", socketPath},
+		server:      server,
+		debugServer: debugServer,
+		client:      client,
 	}
 
 	t.Cleanup(func() {
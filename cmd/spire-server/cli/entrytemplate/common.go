@@ -0,0 +1,83 @@
+package entrytemplate
+
+import (
+	"errors"
+	"flag"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/types"
+)
+
+// templateFlags holds the flags describing an entry template, shared by the
+// create and update commands.
+type templateFlags struct {
+	id               string
+	spiffeIDPath     string
+	ttl              int
+	federatesWith    common_cli.StringsFlag
+	dnsNameTemplates common_cli.StringsFlag
+}
+
+func (f *templateFlags) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.id, "id", "", "The ID of the entry template")
+	fs.StringVar(&f.spiffeIDPath, "spiffeIDPath", "", "The shape of the SPIFFE ID path of the entries bound to the template, where a * segment matches any single segment (e.g. /ns/*/sa/web)")
+	fs.IntVar(&f.ttl, "ttl", 0, "The lifetime, in seconds, for SVIDs issued based on the entries bound to the template")
+	fs.Var(&f.federatesWith, "federatesWith", "Name of a trust domain the entries bound to the template federate with (e.g., example.org). Can be used more than once")
+	fs.Var(&f.dnsNameTemplates, "dnsTemplate", "A Go text/template rendered into a DNS name that will be included in X509-SVIDs issued based on the entries bound to the template. Can be used more than once")
+}
+
+// toProto converts the flags into an entry template. The fields are
+// validated by the server.
+func (f *templateFlags) toProto() (*types.EntryTemplate, error) {
+	if f.id == "" {
+		return nil, errors.New("entry template ID is required")
+	}
+	if f.ttl < 0 {
+		return nil, errors.New("a positive TTL is required")
+	}
+
+	return &types.EntryTemplate{
+		Id:               f.id,
+		SpiffeIdPath:     f.spiffeIDPath,
+		Ttl:              int32(f.ttl),
+		FederatesWith:    f.federatesWith,
+		DnsNameTemplates: f.dnsNameTemplates,
+	}, nil
+}
+
+func printEntryTemplates(env *common_cli.Env, templates ...*types.EntryTemplate) error {
+	for _, t := range templates {
+		if err := env.Printf("Template ID      : %s\n", t.Id); err != nil {
+			return err
+		}
+		if t.SpiffeIdPath != "" {
+			if err := env.Printf("SPIFFE ID path   : %s\n", t.SpiffeIdPath); err != nil {
+				return err
+			}
+		}
+		if t.Ttl == 0 {
+			if err := env.Printf("TTL              : default\n"); err != nil {
+				return err
+			}
+		} else {
+			if err := env.Printf("TTL              : %d\n", t.Ttl); err != nil {
+				return err
+			}
+		}
+		for _, td := range t.FederatesWith {
+			if err := env.Printf("FederatesWith    : %s\n", td); err != nil {
+				return err
+			}
+		}
+		for _, template := range t.DnsNameTemplates {
+			if err := env.Printf("DNS template     : %s\n", template); err != nil {
+				return err
+			}
+		}
+		if err := env.Println(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
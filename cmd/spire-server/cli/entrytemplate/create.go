@@ -0,0 +1,64 @@
+package entrytemplate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc/codes"
+)
+
+type createCommand struct {
+	templateFlags
+}
+
+// NewCreateCommand creates a new "create" subcommand for "entrytemplate" command.
+func NewCreateCommand() cli.Command {
+	return NewCreateCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewCreateCommandWithEnv creates a new "create" subcommand for
+// "entrytemplate" command using the environment specified
+func NewCreateCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(createCommand))
+}
+
+func (*createCommand) Name() string {
+	return "entrytemplate create"
+}
+
+func (*createCommand) Synopsis() string {
+	return "Creates an entry template"
+}
+
+func (c *createCommand) AppendFlags(fs *flag.FlagSet) {
+	c.appendFlags(fs)
+}
+
+func (c *createCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	template, err := c.toProto()
+	if err != nil {
+		return err
+	}
+
+	client := serverClient.NewEntryTemplateClient()
+	resp, err := client.BatchCreateEntryTemplate(ctx, &entrytemplate.BatchCreateEntryTemplateRequest{
+		EntryTemplates: []*types.EntryTemplate{template},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create entry template: %w", err)
+	}
+
+	result := resp.Results[0]
+	if result.Status.Code != int32(codes.OK) {
+		return fmt.Errorf("failed to create entry template %q: %s", template.Id, result.Status.Message)
+	}
+
+	env.Printf("Entry template created.\n\n")
+	return printEntryTemplates(env, result.EntryTemplate)
+}
@@ -0,0 +1,63 @@
+package entrytemplate
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	"google.golang.org/grpc/codes"
+)
+
+type deleteCommand struct {
+	// ID of the entry template
+	id string
+}
+
+// NewDeleteCommand creates a new "delete" subcommand for "entrytemplate" command.
+func NewDeleteCommand() cli.Command {
+	return NewDeleteCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewDeleteCommandWithEnv creates a new "delete" subcommand for
+// "entrytemplate" command using the environment specified
+func NewDeleteCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(deleteCommand))
+}
+
+func (*deleteCommand) Name() string {
+	return "entrytemplate delete"
+}
+
+func (*deleteCommand) Synopsis() string {
+	return "Deletes an entry template that no registration entry is bound to"
+}
+
+func (c *deleteCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.id, "id", "", "The ID of the entry template to delete")
+}
+
+func (c *deleteCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.id == "" {
+		return errors.New("entry template ID is required")
+	}
+
+	client := serverClient.NewEntryTemplateClient()
+	resp, err := client.BatchDeleteEntryTemplate(ctx, &entrytemplate.BatchDeleteEntryTemplateRequest{
+		Ids: []string{c.id},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete entry template: %w", err)
+	}
+
+	result := resp.Results[0]
+	if result.Status.Code != int32(codes.OK) {
+		return fmt.Errorf("failed to delete entry template %q: %s", result.Id, result.Status.Message)
+	}
+
+	return env.Println("Entry template deleted.")
+}
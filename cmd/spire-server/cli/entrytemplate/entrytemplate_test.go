@@ -0,0 +1,429 @@
+package entrytemplate_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/cli/entrytemplate"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/api"
+	entrytemplatepb "github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	webTemplate = &types.EntryTemplate{
+		Id:            "web",
+		SpiffeIdPath:  "/ns/web",
+		Ttl:           60,
+		FederatesWith: []string{"domain1.org"},
+	}
+	dbTemplate = &types.EntryTemplate{
+		Id:               "db",
+		DnsNameTemplates: []string{"{{ index .PathSegments 0 }}.{{ .TrustDomain }}"},
+	}
+
+	webTemplateOutput = `Template ID      : web
+SPIFFE ID path   : /ns/web
+TTL              : 60
+FederatesWith    : domain1.org
+
+`
+	dbTemplateOutput = `Template ID      : db
+TTL              : default
+DNS template     : {{ index .PathSegments 0 }}.{{ .TrustDomain }}
+
+`
+)
+
+type entryTemplateTest struct {
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+
+	args   []string
+	server *fakeEntryTemplateServer
+
+	client cli.Command
+}
+
+func TestCreateHelp(t *testing.T) {
+	test := setupTest(t, entrytemplate.NewCreateCommandWithEnv)
+
+	test.client.Help()
+	require.Equal(t, `Usage of entrytemplate create:
+  -dnsTemplate value
+    	A Go text/template rendered into a DNS name that will be included in X509-SVIDs issued based on the entries bound to the template. Can be used more than once
+  -federatesWith value
+    	Name of a trust domain the entries bound to the template federate with (e.g., example.org). Can be used more than once
+  -id string
+    	The ID of the entry template
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -spiffeIDPath string
+    	The shape of the SPIFFE ID path of the entries bound to the template, where a * segment matches any single segment (e.g. /ns/*/sa/web)
+  -ttl int
+    	The lifetime, in seconds, for SVIDs issued based on the entries bound to the template
+`, test.stderr.String())
+}
+
+func TestCreate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		serverResult   *types.Status
+		serverErr      error
+		expectCreated  *types.EntryTemplate
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:          "success",
+			args:          []string{"-id", "web", "-spiffeIDPath", "/ns/web", "-ttl", "60", "-federatesWith", "domain1.org"},
+			expectCreated: webTemplate,
+			expectStdout:  "Entry template created.\n\n" + webTemplateOutput,
+		},
+		{
+			name:           "missing ID",
+			expectStderr:   "entry template ID is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "negative TTL",
+			args:           []string{"-id", "web", "-ttl", "-1"},
+			expectStderr:   "a positive TTL is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "template already exists",
+			args:           []string{"-id", "web", "-spiffeIDPath", "/ns/web", "-ttl", "60", "-federatesWith", "domain1.org"},
+			serverResult:   api.CreateStatus(codes.AlreadyExists, "entry template already exists"),
+			expectCreated:  webTemplate,
+			expectStderr:   "failed to create entry template \"web\": entry template already exists\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "server error",
+			args:           []string{"-id", "web"},
+			serverErr:      status.Error(codes.Internal, "oh no"),
+			expectStderr:   "failed to create entry template: rpc error: code = Internal desc = oh no\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, entrytemplate.NewCreateCommandWithEnv)
+			test.server.result = tt.serverResult
+			test.server.err = tt.serverErr
+
+			exitCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+			if tt.expectCreated != nil {
+				spiretest.RequireProtoEqual(t, tt.expectCreated, test.server.received)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		serverResult   *types.Status
+		entriesUpdated int32
+		expectUpdated  *types.EntryTemplate
+		expectMask     *types.EntryTemplateMask
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:           "update all fields",
+			args:           []string{"-id", "web", "-spiffeIDPath", "/ns/web", "-ttl", "60", "-federatesWith", "domain1.org", "-dnsTemplate", "web.{{ .TrustDomain }}"},
+			entriesUpdated: 3,
+			expectUpdated: &types.EntryTemplate{
+				Id:               "web",
+				SpiffeIdPath:     "/ns/web",
+				Ttl:              60,
+				FederatesWith:    []string{"domain1.org"},
+				DnsNameTemplates: []string{"web.{{ .TrustDomain }}"},
+			},
+			expectMask:   &types.EntryTemplateMask{SpiffeIdPath: true, Ttl: true, FederatesWith: true, DnsNameTemplates: true},
+			expectStdout: "Entry template updated along with 3 registration entries.\n\n" + "Template ID      : web\nSPIFFE ID path   : /ns/web\nTTL              : 60\nFederatesWith    : domain1.org\nDNS template     : web.{{ .TrustDomain }}\n\n",
+		},
+		{
+			name:           "update only the TTL",
+			args:           []string{"-id", "web", "-ttl", "60"},
+			entriesUpdated: 1,
+			expectUpdated:  &types.EntryTemplate{Id: "web", Ttl: 60},
+			expectMask:     &types.EntryTemplateMask{Ttl: true},
+			expectStdout:   "Entry template updated along with 1 registration entry.\n\nTemplate ID      : web\nTTL              : 60\n\n",
+		},
+		{
+			name:           "nothing to update",
+			args:           []string{"-id", "web"},
+			expectStderr:   "at least one of SPIFFE ID path, TTL, federated trust domain or DNS template is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "template not found",
+			args:           []string{"-id", "web", "-ttl", "60"},
+			serverResult:   api.CreateStatus(codes.NotFound, "entry template not found"),
+			expectStderr:   "failed to update entry template \"web\": entry template not found\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, entrytemplate.NewUpdateCommandWithEnv)
+			test.server.result = tt.serverResult
+			test.server.entriesUpdated = tt.entriesUpdated
+
+			exitCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+			if tt.expectUpdated != nil {
+				spiretest.RequireProtoEqual(t, tt.expectUpdated, test.server.received)
+				spiretest.RequireProtoEqual(t, tt.expectMask, test.server.receivedMask)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		serverResult   *types.Status
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "success",
+			args:         []string{"-id", "web"},
+			expectStdout: "Entry template deleted.\n",
+		},
+		{
+			name:           "missing ID",
+			expectStderr:   "entry template ID is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "template in use",
+			args:           []string{"-id", "web"},
+			serverResult:   api.CreateStatus(codes.FailedPrecondition, "entry template is in use"),
+			expectStderr:   "failed to delete entry template \"web\": entry template is in use\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, entrytemplate.NewDeleteCommandWithEnv)
+			test.server.result = tt.serverResult
+
+			exitCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		templates      []*types.EntryTemplate
+		serverErr      error
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "templates found",
+			templates:    []*types.EntryTemplate{webTemplate, dbTemplate},
+			expectStdout: "Found 2 entry templates:\n\n" + webTemplateOutput + dbTemplateOutput,
+		},
+		{
+			name:         "one template found",
+			templates:    []*types.EntryTemplate{webTemplate},
+			expectStdout: "Found 1 entry template:\n\n" + webTemplateOutput,
+		},
+		{
+			name:         "no templates",
+			expectStdout: "No entry templates found\n",
+		},
+		{
+			name:           "server error",
+			serverErr:      status.Error(codes.Internal, "oh no"),
+			expectStderr:   "rpc error: code = Internal desc = oh no\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, entrytemplate.NewListCommandWithEnv)
+			test.server.templates = tt.templates
+			test.server.err = tt.serverErr
+
+			exitCode := test.client.Run(test.args)
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+		})
+	}
+}
+
+func TestShow(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "success",
+			args:         []string{"-id", "db"},
+			expectStdout: dbTemplateOutput,
+		},
+		{
+			name:           "missing ID",
+			expectStderr:   "entry template ID is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "template not found",
+			args:           []string{"-id", "api"},
+			expectStderr:   "rpc error: code = NotFound desc = entry template not found\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, entrytemplate.NewShowCommandWithEnv)
+			test.server.templates = []*types.EntryTemplate{webTemplate, dbTemplate}
+
+			exitCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+		})
+	}
+}
+
+func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *entryTemplateTest {
+	server := &fakeEntryTemplateServer{}
+
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
+		entrytemplatepb.RegisterEntryTemplateServer(s, server)
+	})
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	client := newClient(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	return &entryTemplateTest{
+		stdout: stdout,
+		stderr: stderr,
+		args:   []string{"-registrationUDSPath", socketPath},
+		server: server,
+		client: client,
+	}
+}
+
+type fakeEntryTemplateServer struct {
+	entrytemplatepb.UnimplementedEntryTemplateServer
+
+	templates      []*types.EntryTemplate
+	result         *types.Status
+	entriesUpdated int32
+	err            error
+
+	received     *types.EntryTemplate
+	receivedMask *types.EntryTemplateMask
+}
+
+func (s *fakeEntryTemplateServer) ListEntryTemplates(ctx context.Context, req *entrytemplatepb.ListEntryTemplatesRequest) (*entrytemplatepb.ListEntryTemplatesResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &entrytemplatepb.ListEntryTemplatesResponse{
+		EntryTemplates: s.templates,
+	}, nil
+}
+
+func (s *fakeEntryTemplateServer) GetEntryTemplate(ctx context.Context, req *entrytemplatepb.GetEntryTemplateRequest) (*types.EntryTemplate, error) {
+	for _, template := range s.templates {
+		if template.Id == req.Id {
+			return template, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "entry template not found")
+}
+
+func (s *fakeEntryTemplateServer) BatchCreateEntryTemplate(ctx context.Context, req *entrytemplatepb.BatchCreateEntryTemplateRequest) (*entrytemplatepb.BatchCreateEntryTemplateResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.received = req.EntryTemplates[0]
+	return &entrytemplatepb.BatchCreateEntryTemplateResponse{
+		Results: []*entrytemplatepb.BatchCreateEntryTemplateResponse_Result{
+			s.makeResult(s.received),
+		},
+	}, nil
+}
+
+func (s *fakeEntryTemplateServer) BatchUpdateEntryTemplate(ctx context.Context, req *entrytemplatepb.BatchUpdateEntryTemplateRequest) (*entrytemplatepb.BatchUpdateEntryTemplateResponse, error) {
+	s.received = req.EntryTemplates[0]
+	s.receivedMask = req.InputMask
+	createResult := s.makeResult(s.received)
+	return &entrytemplatepb.BatchUpdateEntryTemplateResponse{
+		Results: []*entrytemplatepb.BatchUpdateEntryTemplateResponse_Result{
+			{
+				Status:         createResult.Status,
+				EntryTemplate:  createResult.EntryTemplate,
+				EntriesUpdated: s.entriesUpdated,
+			},
+		},
+	}, nil
+}
+
+func (s *fakeEntryTemplateServer) BatchDeleteEntryTemplate(ctx context.Context, req *entrytemplatepb.BatchDeleteEntryTemplateRequest) (*entrytemplatepb.BatchDeleteEntryTemplateResponse, error) {
+	result := &entrytemplatepb.BatchDeleteEntryTemplateResponse_Result{
+		Status: api.OK(),
+		Id:     req.Ids[0],
+	}
+	if s.result != nil {
+		result.Status = s.result
+	}
+	return &entrytemplatepb.BatchDeleteEntryTemplateResponse{
+		Results: []*entrytemplatepb.BatchDeleteEntryTemplateResponse_Result{result},
+	}, nil
+}
+
+func (s *fakeEntryTemplateServer) makeResult(template *types.EntryTemplate) *entrytemplatepb.BatchCreateEntryTemplateResponse_Result {
+	if s.result != nil {
+		return &entrytemplatepb.BatchCreateEntryTemplateResponse_Result{Status: s.result}
+	}
+	return &entrytemplatepb.BatchCreateEntryTemplateResponse_Result{
+		Status:        api.OK(),
+		EntryTemplate: proto.Clone(template).(*types.EntryTemplate),
+	}
+}
@@ -0,0 +1,65 @@
+package entrytemplate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+)
+
+type listCommand struct{}
+
+// NewListCommand creates a new "list" subcommand for "entrytemplate" command.
+func NewListCommand() cli.Command {
+	return NewListCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewListCommandWithEnv creates a new "list" subcommand for "entrytemplate"
+// command using the environment specified
+func NewListCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(listCommand))
+}
+
+func (*listCommand) Name() string {
+	return "entrytemplate list"
+}
+
+func (*listCommand) Synopsis() string {
+	return "Lists all entry templates"
+}
+
+func (*listCommand) AppendFlags(fs *flag.FlagSet) {
+}
+
+func (*listCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	client := serverClient.NewEntryTemplateClient()
+
+	var templates []*types.EntryTemplate
+	req := &entrytemplate.ListEntryTemplatesRequest{}
+	for {
+		resp, err := client.ListEntryTemplates(ctx, req)
+		if err != nil {
+			return err
+		}
+		templates = append(templates, resp.EntryTemplates...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	if len(templates) == 0 {
+		return env.Println("No entry templates found")
+	}
+
+	msg := fmt.Sprintf("Found %d entry ", len(templates))
+	msg = util.Pluralizer(msg, "template", "templates", len(templates))
+	env.Printf(msg + ":\n\n")
+
+	return printEntryTemplates(env, templates...)
+}
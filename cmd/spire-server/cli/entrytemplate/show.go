@@ -0,0 +1,56 @@
+package entrytemplate
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+)
+
+type showCommand struct {
+	// ID of the entry template
+	id string
+}
+
+// NewShowCommand creates a new "show" subcommand for "entrytemplate" command.
+func NewShowCommand() cli.Command {
+	return NewShowCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewShowCommandWithEnv creates a new "show" subcommand for "entrytemplate"
+// command using the environment specified
+func NewShowCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(showCommand))
+}
+
+func (*showCommand) Name() string {
+	return "entrytemplate show"
+}
+
+func (*showCommand) Synopsis() string {
+	return "Shows an entry template"
+}
+
+func (c *showCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.id, "id", "", "The ID of the entry template to show")
+}
+
+func (c *showCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.id == "" {
+		return errors.New("entry template ID is required")
+	}
+
+	client := serverClient.NewEntryTemplateClient()
+	template, err := client.GetEntryTemplate(ctx, &entrytemplate.GetEntryTemplateRequest{
+		Id: c.id,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printEntryTemplates(env, template)
+}
@@ -0,0 +1,91 @@
+package entrytemplate
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc/codes"
+)
+
+// templateMaskFields maps the flags of the command to the template field
+// they set.
+var templateMaskFields = map[string]func(*types.EntryTemplateMask){
+	"spiffeIDPath":  func(m *types.EntryTemplateMask) { m.SpiffeIdPath = true },
+	"ttl":           func(m *types.EntryTemplateMask) { m.Ttl = true },
+	"federatesWith": func(m *types.EntryTemplateMask) { m.FederatesWith = true },
+	"dnsTemplate":   func(m *types.EntryTemplateMask) { m.DnsNameTemplates = true },
+}
+
+type updateCommand struct {
+	templateFlags
+
+	flags *flag.FlagSet
+}
+
+// NewUpdateCommand creates a new "update" subcommand for "entrytemplate" command.
+func NewUpdateCommand() cli.Command {
+	return NewUpdateCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewUpdateCommandWithEnv creates a new "update" subcommand for
+// "entrytemplate" command using the environment specified
+func NewUpdateCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(updateCommand))
+}
+
+func (*updateCommand) Name() string {
+	return "entrytemplate update"
+}
+
+func (*updateCommand) Synopsis() string {
+	return "Updates an entry template and all the registration entries bound to it"
+}
+
+func (c *updateCommand) AppendFlags(fs *flag.FlagSet) {
+	c.appendFlags(fs)
+	c.flags = fs
+}
+
+func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	template, err := c.toProto()
+	if err != nil {
+		return err
+	}
+
+	// Only the fields given by flags are updated
+	mask := &types.EntryTemplateMask{}
+	c.flags.Visit(func(f *flag.Flag) {
+		if set, ok := templateMaskFields[f.Name]; ok {
+			set(mask)
+		}
+	})
+	if !mask.SpiffeIdPath && !mask.Ttl && !mask.FederatesWith && !mask.DnsNameTemplates {
+		return errors.New("at least one of SPIFFE ID path, TTL, federated trust domain or DNS template is required")
+	}
+
+	client := serverClient.NewEntryTemplateClient()
+	resp, err := client.BatchUpdateEntryTemplate(ctx, &entrytemplate.BatchUpdateEntryTemplateRequest{
+		EntryTemplates: []*types.EntryTemplate{template},
+		InputMask:      mask,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update entry template: %w", err)
+	}
+
+	result := resp.Results[0]
+	if result.Status.Code != int32(codes.OK) {
+		return fmt.Errorf("failed to update entry template %q: %s", template.Id, result.Status.Message)
+	}
+
+	msg := fmt.Sprintf("Entry template updated along with %d registration ", result.EntriesUpdated)
+	msg = util.Pluralizer(msg, "entry", "entries", int(result.EntriesUpdated))
+	env.Printf(msg + ".\n\n")
+	return printEntryTemplates(env, result.EntryTemplate)
+}
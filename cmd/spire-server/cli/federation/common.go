@@ -0,0 +1,100 @@
+package federation
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/types"
+)
+
+const (
+	profileHTTPSWeb    = "https_web"
+	profileHTTPSSPIFFE = "https_spiffe"
+)
+
+// relationshipFlags holds the flags describing a federation relationship,
+// shared by the create and update commands.
+type relationshipFlags struct {
+	trustDomain           string
+	bundleEndpointURL     string
+	bundleEndpointProfile string
+	endpointSpiffeID      string
+}
+
+func (f *relationshipFlags) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.trustDomain, "trustDomain", "", "Name of the trust domain to federate with (e.g., example.org)")
+	fs.StringVar(&f.bundleEndpointURL, "bundleEndpointURL", "", "URL of the SPIFFE bundle endpoint that provides the trust bundle (must use the HTTPS protocol)")
+	fs.StringVar(&f.bundleEndpointProfile, "bundleEndpointProfile", "", fmt.Sprintf("Endpoint profile type (either %q or %q)", profileHTTPSWeb, profileHTTPSSPIFFE))
+	fs.StringVar(&f.endpointSpiffeID, "endpointSpiffeID", "", fmt.Sprintf("SPIFFE ID of the SPIFFE bundle endpoint server. Only used for %q profile.", profileHTTPSSPIFFE))
+}
+
+// toProto converts the flags into a federation relationship. Only the
+// bundle endpoint profile is validated here; the server validates the rest.
+func (f *relationshipFlags) toProto() (*types.FederationRelationship, error) {
+	if f.trustDomain == "" {
+		return nil, errors.New("trust domain is required")
+	}
+
+	fr := &types.FederationRelationship{
+		TrustDomain:       f.trustDomain,
+		BundleEndpointUrl: f.bundleEndpointURL,
+	}
+
+	switch f.bundleEndpointProfile {
+	case "":
+		if f.endpointSpiffeID != "" {
+			return nil, errors.New("endpoint SPIFFE ID requires a bundle endpoint profile")
+		}
+	case profileHTTPSWeb:
+		if f.endpointSpiffeID != "" {
+			return nil, fmt.Errorf("endpoint SPIFFE ID is not allowed with the %q profile", profileHTTPSWeb)
+		}
+		fr.BundleEndpointProfile = &types.FederationRelationship_HttpsWeb{
+			HttpsWeb: &types.HTTPSWebProfile{},
+		}
+	case profileHTTPSSPIFFE:
+		if f.endpointSpiffeID == "" {
+			return nil, fmt.Errorf("endpoint SPIFFE ID is required with the %q profile", profileHTTPSSPIFFE)
+		}
+		fr.BundleEndpointProfile = &types.FederationRelationship_HttpsSpiffe{
+			HttpsSpiffe: &types.HTTPSSPIFFEProfile{
+				EndpointSpiffeId: f.endpointSpiffeID,
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unknown bundle endpoint profile %q", f.bundleEndpointProfile)
+	}
+
+	return fr, nil
+}
+
+func printFederationRelationships(env *common_cli.Env, frs ...*types.FederationRelationship) error {
+	for _, fr := range frs {
+		if err := env.Printf("Trust domain              : %s\n", fr.TrustDomain); err != nil {
+			return err
+		}
+		if err := env.Printf("Bundle endpoint URL       : %s\n", fr.BundleEndpointUrl); err != nil {
+			return err
+		}
+		switch profile := fr.BundleEndpointProfile.(type) {
+		case *types.FederationRelationship_HttpsWeb:
+			if err := env.Printf("Bundle endpoint profile   : %s\n", profileHTTPSWeb); err != nil {
+				return err
+			}
+		case *types.FederationRelationship_HttpsSpiffe:
+			if err := env.Printf("Bundle endpoint profile   : %s\n", profileHTTPSSPIFFE); err != nil {
+				return err
+			}
+			if err := env.Printf("Endpoint SPIFFE ID        : %s\n", profile.HttpsSpiffe.EndpointSpiffeId); err != nil {
+				return err
+			}
+		}
+		if err := env.Println(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package federation
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc/codes"
+)
+
+type createCommand struct {
+	relationshipFlags
+}
+
+// NewCreateCommand creates a new "create" subcommand for "federation" command.
+func NewCreateCommand() cli.Command {
+	return NewCreateCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewCreateCommandWithEnv creates a new "create" subcommand for "federation"
+// command using the environment specified
+func NewCreateCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(createCommand))
+}
+
+func (*createCommand) Name() string {
+	return "federation create"
+}
+
+func (*createCommand) Synopsis() string {
+	return "Creates a dynamic federation relationship with a foreign trust domain"
+}
+
+func (c *createCommand) AppendFlags(fs *flag.FlagSet) {
+	c.appendFlags(fs)
+}
+
+func (c *createCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	fr, err := c.toProto()
+	if err != nil {
+		return err
+	}
+
+	client := serverClient.NewTrustDomainClient()
+	resp, err := client.BatchCreateFederationRelationship(ctx, &trustdomain.BatchCreateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{fr},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create federation relationship: %w", err)
+	}
+
+	result := resp.Results[0]
+	if result.Status.Code != int32(codes.OK) {
+		return fmt.Errorf("failed to create federation relationship with %q: %s", fr.TrustDomain, result.Status.Message)
+	}
+
+	env.Printf("Federation relationship created.\n\n")
+	return printFederationRelationships(env, result.FederationRelationship)
+}
@@ -0,0 +1,63 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
+	"google.golang.org/grpc/codes"
+)
+
+type deleteCommand struct {
+	// Name of the trust domain of the relationship
+	trustDomain string
+}
+
+// NewDeleteCommand creates a new "delete" subcommand for "federation" command.
+func NewDeleteCommand() cli.Command {
+	return NewDeleteCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewDeleteCommandWithEnv creates a new "delete" subcommand for "federation"
+// command using the environment specified
+func NewDeleteCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(deleteCommand))
+}
+
+func (*deleteCommand) Name() string {
+	return "federation delete"
+}
+
+func (*deleteCommand) Synopsis() string {
+	return "Deletes a dynamic federation relationship"
+}
+
+func (c *deleteCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.trustDomain, "trustDomain", "", "Name of the trust domain to stop federating with (e.g., example.org)")
+}
+
+func (c *deleteCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.trustDomain == "" {
+		return errors.New("trust domain is required")
+	}
+
+	client := serverClient.NewTrustDomainClient()
+	resp, err := client.BatchDeleteFederationRelationship(ctx, &trustdomain.BatchDeleteFederationRelationshipRequest{
+		TrustDomains: []string{c.trustDomain},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete federation relationship: %w", err)
+	}
+
+	result := resp.Results[0]
+	if result.Status.Code != int32(codes.OK) {
+		return fmt.Errorf("failed to delete federation relationship with %q: %s", result.TrustDomain, result.Status.Message)
+	}
+
+	return env.Println("Federation relationship deleted.")
+}
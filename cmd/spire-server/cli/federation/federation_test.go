@@ -0,0 +1,438 @@
+package federation_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/cli/federation"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/api"
+	trustdomainpb "github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	webFR = &types.FederationRelationship{
+		TrustDomain:           "domain1.org",
+		BundleEndpointUrl:     "https://domain1.org/bundle",
+		BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+	}
+	spiffeFR = &types.FederationRelationship{
+		TrustDomain:       "domain2.org",
+		BundleEndpointUrl: "https://domain2.org/bundle",
+		BundleEndpointProfile: &types.FederationRelationship_HttpsSpiffe{
+			HttpsSpiffe: &types.HTTPSSPIFFEProfile{EndpointSpiffeId: "spiffe://domain2.org/spire/server"},
+		},
+	}
+
+	webFROutput = `Trust domain              : domain1.org
+Bundle endpoint URL       : https://domain1.org/bundle
+Bundle endpoint profile   : https_web
+
+`
+	spiffeFROutput = `Trust domain              : domain2.org
+Bundle endpoint URL       : https://domain2.org/bundle
+Bundle endpoint profile   : https_spiffe
+Endpoint SPIFFE ID        : spiffe://domain2.org/spire/server
+
+`
+)
+
+type federationTest struct {
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+
+	args   []string
+	server *fakeTrustDomainServer
+
+	client cli.Command
+}
+
+func TestCreateHelp(t *testing.T) {
+	test := setupTest(t, federation.NewCreateCommandWithEnv)
+
+	test.client.Help()
+	require.Equal(t, `Usage of federation create:
+  -bundleEndpointProfile string
+    	Endpoint profile type (either "https_web" or "https_spiffe")
+  -bundleEndpointURL string
+    	URL of the SPIFFE bundle endpoint that provides the trust bundle (must use the HTTPS protocol)
+  -endpointSpiffeID string
+    	SPIFFE ID of the SPIFFE bundle endpoint server. Only used for "https_spiffe" profile.
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -trustDomain string
+    	Name of the trust domain to federate with (e.g., example.org)
+`, test.stderr.String())
+}
+
+func TestCreate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		serverResult   *types.Status
+		serverErr      error
+		expectCreated  *types.FederationRelationship
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:          "https_web",
+			args:          []string{"-trustDomain", "domain1.org", "-bundleEndpointURL", "https://domain1.org/bundle", "-bundleEndpointProfile", "https_web"},
+			expectCreated: webFR,
+			expectStdout:  "Federation relationship created.\n\n" + webFROutput,
+		},
+		{
+			name:          "https_spiffe",
+			args:          []string{"-trustDomain", "domain2.org", "-bundleEndpointURL", "https://domain2.org/bundle", "-bundleEndpointProfile", "https_spiffe", "-endpointSpiffeID", "spiffe://domain2.org/spire/server"},
+			expectCreated: spiffeFR,
+			expectStdout:  "Federation relationship created.\n\n" + spiffeFROutput,
+		},
+		{
+			name:           "missing trust domain",
+			expectStderr:   "trust domain is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "unknown profile",
+			args:           []string{"-trustDomain", "domain1.org", "-bundleEndpointProfile", "http"},
+			expectStderr:   "unknown bundle endpoint profile \"http\"\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "https_spiffe without endpoint SPIFFE ID",
+			args:           []string{"-trustDomain", "domain2.org", "-bundleEndpointProfile", "https_spiffe"},
+			expectStderr:   "endpoint SPIFFE ID is required with the \"https_spiffe\" profile\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "https_web with endpoint SPIFFE ID",
+			args:           []string{"-trustDomain", "domain1.org", "-bundleEndpointProfile", "https_web", "-endpointSpiffeID", "spiffe://domain1.org/spire/server"},
+			expectStderr:   "endpoint SPIFFE ID is not allowed with the \"https_web\" profile\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "relationship already exists",
+			args:           []string{"-trustDomain", "domain1.org", "-bundleEndpointURL", "https://domain1.org/bundle", "-bundleEndpointProfile", "https_web"},
+			serverResult:   api.CreateStatus(codes.AlreadyExists, "federation relationship already exists"),
+			expectCreated:  webFR,
+			expectStderr:   "failed to create federation relationship with \"domain1.org\": federation relationship already exists\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "server error",
+			args:           []string{"-trustDomain", "domain1.org", "-bundleEndpointURL", "https://domain1.org/bundle", "-bundleEndpointProfile", "https_web"},
+			serverErr:      status.Error(codes.Internal, "oh no"),
+			expectStderr:   "failed to create federation relationship: rpc error: code = Internal desc = oh no\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, federation.NewCreateCommandWithEnv)
+			test.server.result = tt.serverResult
+			test.server.err = tt.serverErr
+
+			exitCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+			if tt.expectCreated != nil {
+				spiretest.RequireProtoEqual(t, tt.expectCreated, test.server.received)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		serverResult   *types.Status
+		expectUpdated  *types.FederationRelationship
+		expectMask     *types.FederationRelationshipMask
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:          "update all fields",
+			args:          []string{"-trustDomain", "domain1.org", "-bundleEndpointURL", "https://domain1.org/bundle", "-bundleEndpointProfile", "https_web"},
+			expectUpdated: webFR,
+			expectMask:    &types.FederationRelationshipMask{BundleEndpointUrl: true, BundleEndpointProfile: true},
+			expectStdout:  "Federation relationship updated.\n\n" + webFROutput,
+		},
+		{
+			name: "update only the URL",
+			args: []string{"-trustDomain", "domain1.org", "-bundleEndpointURL", "https://domain1.org/bundle"},
+			expectUpdated: &types.FederationRelationship{
+				TrustDomain:       "domain1.org",
+				BundleEndpointUrl: "https://domain1.org/bundle",
+			},
+			expectMask:   &types.FederationRelationshipMask{BundleEndpointUrl: true},
+			expectStdout: "Federation relationship updated.\n\nTrust domain              : domain1.org\nBundle endpoint URL       : https://domain1.org/bundle\n\n",
+		},
+		{
+			name:           "nothing to update",
+			args:           []string{"-trustDomain", "domain1.org"},
+			expectStderr:   "at least one of bundle endpoint URL or bundle endpoint profile is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "relationship not found",
+			args:           []string{"-trustDomain", "domain1.org", "-bundleEndpointURL", "https://domain1.org/bundle"},
+			serverResult:   api.CreateStatus(codes.NotFound, "federation relationship not found"),
+			expectStderr:   "failed to update federation relationship with \"domain1.org\": federation relationship not found\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, federation.NewUpdateCommandWithEnv)
+			test.server.result = tt.serverResult
+
+			exitCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+			if tt.expectUpdated != nil {
+				spiretest.RequireProtoEqual(t, tt.expectUpdated, test.server.received)
+				spiretest.RequireProtoEqual(t, tt.expectMask, test.server.receivedMask)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		serverResult   *types.Status
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "success",
+			args:         []string{"-trustDomain", "domain1.org"},
+			expectStdout: "Federation relationship deleted.\n",
+		},
+		{
+			name:           "missing trust domain",
+			expectStderr:   "trust domain is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "relationship not found",
+			args:           []string{"-trustDomain", "domain1.org"},
+			serverResult:   api.CreateStatus(codes.NotFound, "federation relationship not found"),
+			expectStderr:   "failed to delete federation relationship with \"domain1.org\": federation relationship not found\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, federation.NewDeleteCommandWithEnv)
+			test.server.result = tt.serverResult
+
+			exitCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		relationships  []*types.FederationRelationship
+		serverErr      error
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:          "relationships found",
+			relationships: []*types.FederationRelationship{webFR, spiffeFR},
+			expectStdout:  "Found 2 federation relationships:\n\n" + webFROutput + spiffeFROutput,
+		},
+		{
+			name:          "one relationship found",
+			relationships: []*types.FederationRelationship{webFR},
+			expectStdout:  "Found 1 federation relationship:\n\n" + webFROutput,
+		},
+		{
+			name:         "no relationships",
+			expectStdout: "No federation relationships found\n",
+		},
+		{
+			name:           "server error",
+			serverErr:      status.Error(codes.Internal, "oh no"),
+			expectStderr:   "rpc error: code = Internal desc = oh no\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, federation.NewListCommandWithEnv)
+			test.server.relationships = tt.relationships
+			test.server.err = tt.serverErr
+
+			exitCode := test.client.Run(test.args)
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+		})
+	}
+}
+
+func TestShow(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		expectStdout   string
+		expectStderr   string
+		expectExitCode int
+	}{
+		{
+			name:         "success",
+			args:         []string{"-trustDomain", "domain2.org"},
+			expectStdout: spiffeFROutput,
+		},
+		{
+			name:           "missing trust domain",
+			expectStderr:   "trust domain is required\n",
+			expectExitCode: 1,
+		},
+		{
+			name:           "relationship not found",
+			args:           []string{"-trustDomain", "domain3.org"},
+			expectStderr:   "rpc error: code = NotFound desc = federation relationship not found\n",
+			expectExitCode: 1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, federation.NewShowCommandWithEnv)
+			test.server.relationships = []*types.FederationRelationship{webFR, spiffeFR}
+
+			exitCode := test.client.Run(append(test.args, tt.args...))
+			require.Equal(t, tt.expectStdout, test.stdout.String())
+			require.Equal(t, tt.expectStderr, test.stderr.String())
+			require.Equal(t, tt.expectExitCode, exitCode)
+		})
+	}
+}
+
+func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *federationTest {
+	server := &fakeTrustDomainServer{}
+
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
+		trustdomainpb.RegisterTrustDomainServer(s, server)
+	})
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	client := newClient(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	return &federationTest{
+		stdout: stdout,
+		stderr: stderr,
+		args:   []string{"-registrationUDSPath", socketPath},
+		server: server,
+		client: client,
+	}
+}
+
+type fakeTrustDomainServer struct {
+	trustdomainpb.UnimplementedTrustDomainServer
+
+	relationships []*types.FederationRelationship
+	result        *types.Status
+	err           error
+
+	received     *types.FederationRelationship
+	receivedMask *types.FederationRelationshipMask
+}
+
+func (s *fakeTrustDomainServer) ListFederationRelationships(ctx context.Context, req *trustdomainpb.ListFederationRelationshipsRequest) (*trustdomainpb.ListFederationRelationshipsResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &trustdomainpb.ListFederationRelationshipsResponse{
+		FederationRelationships: s.relationships,
+	}, nil
+}
+
+func (s *fakeTrustDomainServer) GetFederationRelationship(ctx context.Context, req *trustdomainpb.GetFederationRelationshipRequest) (*types.FederationRelationship, error) {
+	for _, fr := range s.relationships {
+		if fr.TrustDomain == req.TrustDomain {
+			return fr, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "federation relationship not found")
+}
+
+func (s *fakeTrustDomainServer) BatchCreateFederationRelationship(ctx context.Context, req *trustdomainpb.BatchCreateFederationRelationshipRequest) (*trustdomainpb.BatchCreateFederationRelationshipResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.received = req.FederationRelationships[0]
+	return &trustdomainpb.BatchCreateFederationRelationshipResponse{
+		Results: []*trustdomainpb.BatchCreateFederationRelationshipResponse_Result{
+			s.makeResult(s.received),
+		},
+	}, nil
+}
+
+func (s *fakeTrustDomainServer) BatchUpdateFederationRelationship(ctx context.Context, req *trustdomainpb.BatchUpdateFederationRelationshipRequest) (*trustdomainpb.BatchUpdateFederationRelationshipResponse, error) {
+	s.received = req.FederationRelationships[0]
+	s.receivedMask = req.InputMask
+	createResult := s.makeResult(s.received)
+	return &trustdomainpb.BatchUpdateFederationRelationshipResponse{
+		Results: []*trustdomainpb.BatchUpdateFederationRelationshipResponse_Result{
+			{
+				Status:                 createResult.Status,
+				FederationRelationship: createResult.FederationRelationship,
+			},
+		},
+	}, nil
+}
+
+func (s *fakeTrustDomainServer) BatchDeleteFederationRelationship(ctx context.Context, req *trustdomainpb.BatchDeleteFederationRelationshipRequest) (*trustdomainpb.BatchDeleteFederationRelationshipResponse, error) {
+	result := &trustdomainpb.BatchDeleteFederationRelationshipResponse_Result{
+		Status:      api.OK(),
+		TrustDomain: req.TrustDomains[0],
+	}
+	if s.result != nil {
+		result.Status = s.result
+	}
+	return &trustdomainpb.BatchDeleteFederationRelationshipResponse{
+		Results: []*trustdomainpb.BatchDeleteFederationRelationshipResponse_Result{result},
+	}, nil
+}
+
+func (s *fakeTrustDomainServer) makeResult(fr *types.FederationRelationship) *trustdomainpb.BatchCreateFederationRelationshipResponse_Result {
+	if s.result != nil {
+		return &trustdomainpb.BatchCreateFederationRelationshipResponse_Result{Status: s.result}
+	}
+	return &trustdomainpb.BatchCreateFederationRelationshipResponse_Result{
+		Status:                 api.OK(),
+		FederationRelationship: proto.Clone(fr).(*types.FederationRelationship),
+	}
+}
@@ -0,0 +1,65 @@
+package federation
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+)
+
+type listCommand struct{}
+
+// NewListCommand creates a new "list" subcommand for "federation" command.
+func NewListCommand() cli.Command {
+	return NewListCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewListCommandWithEnv creates a new "list" subcommand for "federation"
+// command using the environment specified
+func NewListCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(listCommand))
+}
+
+func (*listCommand) Name() string {
+	return "federation list"
+}
+
+func (*listCommand) Synopsis() string {
+	return "Lists all dynamic federation relationships"
+}
+
+func (*listCommand) AppendFlags(fs *flag.FlagSet) {
+}
+
+func (*listCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	client := serverClient.NewTrustDomainClient()
+
+	var frs []*types.FederationRelationship
+	req := &trustdomain.ListFederationRelationshipsRequest{}
+	for {
+		resp, err := client.ListFederationRelationships(ctx, req)
+		if err != nil {
+			return err
+		}
+		frs = append(frs, resp.FederationRelationships...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	if len(frs) == 0 {
+		return env.Println("No federation relationships found")
+	}
+
+	msg := fmt.Sprintf("Found %d federation ", len(frs))
+	msg = util.Pluralizer(msg, "relationship", "relationships", len(frs))
+	env.Printf(msg + ":\n\n")
+
+	return printFederationRelationships(env, frs...)
+}
@@ -0,0 +1,56 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
+)
+
+type showCommand struct {
+	// Name of the trust domain of the relationship
+	trustDomain string
+}
+
+// NewShowCommand creates a new "show" subcommand for "federation" command.
+func NewShowCommand() cli.Command {
+	return NewShowCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewShowCommandWithEnv creates a new "show" subcommand for "federation"
+// command using the environment specified
+func NewShowCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(showCommand))
+}
+
+func (*showCommand) Name() string {
+	return "federation show"
+}
+
+func (*showCommand) Synopsis() string {
+	return "Shows a dynamic federation relationship"
+}
+
+func (c *showCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.trustDomain, "trustDomain", "", "Name of the trust domain of the relationship (e.g., example.org)")
+}
+
+func (c *showCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.trustDomain == "" {
+		return errors.New("trust domain is required")
+	}
+
+	client := serverClient.NewTrustDomainClient()
+	fr, err := client.GetFederationRelationship(ctx, &trustdomain.GetFederationRelationshipRequest{
+		TrustDomain: c.trustDomain,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printFederationRelationships(env, fr)
+}
@@ -0,0 +1,75 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc/codes"
+)
+
+type updateCommand struct {
+	relationshipFlags
+}
+
+// NewUpdateCommand creates a new "update" subcommand for "federation" command.
+func NewUpdateCommand() cli.Command {
+	return NewUpdateCommandWithEnv(common_cli.DefaultEnv)
+}
+
+// NewUpdateCommandWithEnv creates a new "update" subcommand for "federation"
+// command using the environment specified
+func NewUpdateCommandWithEnv(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(updateCommand))
+}
+
+func (*updateCommand) Name() string {
+	return "federation update"
+}
+
+func (*updateCommand) Synopsis() string {
+	return "Updates a dynamic federation relationship with a foreign trust domain"
+}
+
+func (c *updateCommand) AppendFlags(fs *flag.FlagSet) {
+	c.appendFlags(fs)
+}
+
+func (c *updateCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	fr, err := c.toProto()
+	if err != nil {
+		return err
+	}
+
+	// Only the provided fields are updated
+	mask := &types.FederationRelationshipMask{
+		BundleEndpointUrl:     c.bundleEndpointURL != "",
+		BundleEndpointProfile: c.bundleEndpointProfile != "",
+	}
+	if !mask.BundleEndpointUrl && !mask.BundleEndpointProfile {
+		return errors.New("at least one of bundle endpoint URL or bundle endpoint profile is required")
+	}
+
+	client := serverClient.NewTrustDomainClient()
+	resp, err := client.BatchUpdateFederationRelationship(ctx, &trustdomain.BatchUpdateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{fr},
+		InputMask:               mask,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update federation relationship: %w", err)
+	}
+
+	result := resp.Results[0]
+	if result.Status.Code != int32(codes.OK) {
+		return fmt.Errorf("failed to update federation relationship with %q: %s", fr.TrustDomain, result.Status.Message)
+	}
+
+	env.Printf("Federation relationship updated.\n\n")
+	return printFederationRelationships(env, result.FederationRelationship)
+}
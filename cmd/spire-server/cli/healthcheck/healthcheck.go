@@ -2,13 +2,18 @@ package healthcheck
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
 )
 
@@ -30,6 +35,9 @@ type healthCheckCommand struct {
 	timeout    common_cli.DurationFlag
 	shallow    bool
 	verbose    bool
+	readyURL   string
+	json       bool
+	checks     common_cli.StringsFlag
 }
 
 func (c *healthCheckCommand) Help() string {
@@ -52,6 +60,9 @@ func (c *healthCheckCommand) Run(args []string) int {
 		_ = c.env.ErrPrintf("Server is unhealthy: %v\n", err)
 		return 1
 	}
+	if c.json {
+		return 0
+	}
 	if err := c.env.Println("Server is healthy."); err != nil {
 		return 1
 	}
@@ -64,11 +75,65 @@ func (c *healthCheckCommand) parseFlags(args []string) error {
 	fs.StringVar(&c.socketPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	fs.BoolVar(&c.shallow, "shallow", false, "Perform a less stringent health check")
 	fs.BoolVar(&c.verbose, "verbose", false, "Print verbose information")
+	fs.StringVar(&c.readyURL, "readyURL", "", "URL of the server readiness endpoint (e.g. http://localhost:80/ready) used to report the health of each server subsystem, including the checks contributed by plugins")
+	fs.BoolVar(&c.json, "json", false, "Print the health of each subsystem as JSON")
+	fs.Var(&c.checks, "check", "Name of a check to report on, in which case the exit code only reflects the named checks. Can be used more than once")
 	return fs.Parse(args)
 }
 
 func (c *healthCheckCommand) run() error {
-	if c.verbose {
+	report := health.NewReport()
+	if c.readyURL != "" {
+		if c.verbose {
+			if err := c.env.Println("Contacting readiness endpoint..."); err != nil {
+				return err
+			}
+		}
+		var err error
+		report, err = health.FetchReport(&http.Client{Timeout: time.Duration(c.timeout)}, c.readyURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	registry := new(health.CheckRegistry)
+	registry.Register("bundle_api", func() (interface{}, error) {
+		return nil, c.checkBundleAPI()
+	})
+	registry.Run(report)
+
+	if len(c.checks) > 0 {
+		var err error
+		report, err = report.Filter(c.checks)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.json {
+		if err := json.NewEncoder(c.env.Stdout).Encode(report); err != nil {
+			return err
+		}
+	} else if c.verbose && c.readyURL != "" {
+		for _, line := range report.Summary() {
+			if err := c.env.Println(line); err != nil {
+				return err
+			}
+		}
+	}
+
+	if state, ok := report.Details["bundle_api"]; ok && state.Status != "ok" {
+		return errors.New(state.Err)
+	}
+	if report.Failed() {
+		return fmt.Errorf("failed checks: %s", strings.Join(report.FailedNames(), ", "))
+	}
+	return nil
+}
+
+// checkBundleAPI checks that the server is serving the Bundle API
+func (c *healthCheckCommand) checkBundleAPI() error {
+	if c.verbose && !c.json {
 		if err := c.env.Println("Fetching bundle via Bundle API..."); err != nil {
 			return err
 		}
@@ -76,7 +141,7 @@ func (c *healthCheckCommand) run() error {
 
 	client, err := util.NewServerClient(c.socketPath)
 	if err != nil {
-		if c.verbose {
+		if c.verbose && !c.json {
 			// Ignore error since a failure to write to stderr cannot very well
 			// be reported
 			_ = c.env.ErrPrintf("Failed to create client: %v\n", err)
@@ -90,14 +155,14 @@ func (c *healthCheckCommand) run() error {
 	// As currently coded however, the registration API isn't served until after
 	// the server CA has been signed by upstream.
 	if _, err := bundleClient.GetBundle(context.Background(), &bundle.GetBundleRequest{}); err != nil {
-		if c.verbose {
+		if c.verbose && !c.json {
 			// Ignore error since a failure to write to stderr cannot very well
 			// be reported
 			_ = c.env.ErrPrintf("Failed to fetch bundle: %v\n", err)
 		}
 		return errors.New("unable to fetch bundle")
 	}
-	if c.verbose {
+	if c.verbose && !c.json {
 		if err := c.env.Println("Successfully fetched bundle."); err != nil {
 			return err
 		}
@@ -3,10 +3,14 @@ package healthcheck
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/mitchellh/cli"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/spiretest"
@@ -47,6 +51,12 @@ func (s *HealthCheckSuite) TestSynopsis() {
 func (s *HealthCheckSuite) TestHelp() {
 	s.Equal("", s.cmd.Help())
 	s.Equal(`Usage of health:
+  -check value
+    	Name of a check to report on, in which case the exit code only reflects the named checks. Can be used more than once
+  -json
+    	Print the health of each subsystem as JSON
+  -readyURL string
+    	URL of the server readiness endpoint (e.g. http://localhost:80/ready) used to report the health of each server subsystem, including the checks contributed by plugins
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
   -shallow
@@ -62,6 +72,12 @@ func (s *HealthCheckSuite) TestBadFlags() {
 	s.Equal("", s.stdout.String(), "stdout")
 	s.Equal(`flag provided but not defined: -badflag
 Usage of health:
+  -check value
+    	Name of a check to report on, in which case the exit code only reflects the named checks. Can be used more than once
+  -json
+    	Print the health of each subsystem as JSON
+  -readyURL string
+    	URL of the server readiness endpoint (e.g. http://localhost:80/ready) used to report the health of each server subsystem, including the checks contributed by plugins
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
   -shallow
@@ -111,6 +127,64 @@ Server is healthy.
 	s.Equal("", s.stderr.String(), "stderr")
 }
 
+func (s *HealthCheckSuite) TestReportsSubsystemsVerbose() {
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(s.T(), func(srv *grpc.Server) {
+		bundle.RegisterBundleServer(srv, withBundle{})
+	})
+	ready := s.makeReadyEndpoint(`{"status":"failed","details":{"datastore":{"name":"datastore","status":"ok"},"vault.token_ttl":{"name":"vault.token_ttl","status":"failed","error":"unable to look up the token"}}}`)
+	code := s.cmd.Run([]string{"--registrationUDSPath", socketPath, "--readyURL", ready.URL, "--verbose"})
+	s.NotEqual(0, code, "exit code")
+	s.Equal(`Contacting readiness endpoint...
+Fetching bundle via Bundle API...
+Successfully fetched bundle.
+bundle_api: ok
+datastore: ok
+vault.token_ttl: failed (unable to look up the token)
+`, s.stdout.String(), "stdout")
+	s.Equal("Server is unhealthy: failed checks: vault.token_ttl\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestReportsOnNamedChecks() {
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(s.T(), func(srv *grpc.Server) {
+		bundle.RegisterBundleServer(srv, withBundle{})
+	})
+	ready := s.makeReadyEndpoint(`{"status":"failed","details":{"datastore":{"name":"datastore","status":"ok"},"vault.token_ttl":{"name":"vault.token_ttl","status":"failed","error":"unable to look up the token"}}}`)
+	code := s.cmd.Run([]string{"--registrationUDSPath", socketPath, "--readyURL", ready.URL, "--check", "datastore"})
+	s.Equal(0, code, "exit code")
+	s.Equal("Server is healthy.\n", s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestReportsSubsystemsJSON() {
+	ready := s.makeReadyEndpoint(`{"status":"ok","details":{"sql.latency":{"name":"sql.latency","status":"ok","details":{"latency":"1ms"}}}}`)
+	code := s.cmd.Run([]string{"--registrationUDSPath", "doesnotexist.sock", "--readyURL", ready.URL, "--json"})
+	s.NotEqual(0, code, "exit code")
+	s.Equal("Server is unhealthy: cannot create registration client\n", s.stderr.String(), "stderr")
+
+	report := new(health.Report)
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), report))
+	s.Equal("failed", report.Status)
+	s.Require().Len(report.Details, 2)
+	s.Equal(map[string]interface{}{"latency": "1ms"}, report.Details["sql.latency"].Details)
+	s.Equal("cannot create registration client", report.Details["bundle_api"].Err)
+}
+
+func (s *HealthCheckSuite) TestFailsOnUnknownCheck() {
+	code := s.cmd.Run([]string{"--registrationUDSPath", "doesnotexist.sock", "--check", "datastore"})
+	s.NotEqual(0, code, "exit code")
+	s.Equal("", s.stdout.String(), "stdout")
+	s.Equal("Server is unhealthy: unknown check \"datastore\"\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) makeReadyEndpoint(body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	s.T().Cleanup(server.Close)
+	return server
+}
+
 type withBundle struct {
 	bundle.BundleServer
 }
@@ -0,0 +1,84 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/server/svid/v1"
+)
+
+func NewValidateCommand() cli.Command {
+	return newValidateCommand(common_cli.DefaultEnv)
+}
+
+func newValidateCommand(env *common_cli.Env) cli.Command {
+	return util.AdaptCommand(env, new(validateCommand))
+}
+
+type validateCommand struct {
+	audience string
+	svid     string
+}
+
+func (c *validateCommand) Name() string {
+	return "jwt validate"
+}
+
+func (c *validateCommand) Synopsis() string {
+	return "Validates a JWT-SVID against the trust domain and federated bundles"
+}
+
+func (c *validateCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.audience, "audience", "", "Expected audience of the JWT-SVID")
+	fs.StringVar(&c.svid, "svid", "", "JWT-SVID to validate")
+}
+
+func (c *validateCommand) Run(ctx context.Context, env *common_cli.Env, serverClient util.ServerClient) error {
+	if c.audience == "" {
+		return errors.New("audience must be specified")
+	}
+	if c.svid == "" {
+		return errors.New("svid must be specified")
+	}
+
+	client := serverClient.NewSVIDClient()
+	resp, err := client.ValidateJWTSVID(ctx, &svid.ValidateJWTSVIDRequest{
+		Audience: c.audience,
+		Token:    c.svid,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to validate JWT-SVID: %v", err)
+	}
+
+	id, err := spiffeid.New(resp.Id.GetTrustDomain(), resp.Id.GetPath())
+	if err != nil {
+		return fmt.Errorf("server returned a malformed SPIFFE ID: %v", err)
+	}
+	claims, err := (&jsonpb.Marshaler{}).MarshalToString(resp.Claims)
+	if err != nil {
+		return fmt.Errorf("unable to marshal claims: %v", err)
+	}
+
+	if err := env.Println("SVID is valid."); err != nil {
+		return err
+	}
+	if err := env.Println("SPIFFE ID :", id); err != nil {
+		return err
+	}
+	if err := env.Println("Claims    :", claims); err != nil {
+		return err
+	}
+	for _, entry := range resp.Entries {
+		if err := env.Println("Entry ID  :", entry.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
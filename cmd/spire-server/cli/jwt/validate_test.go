@@ -0,0 +1,126 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	svidpb "github.com/spiffe/spire/proto/spire/api/server/svid/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const expectedValidateUsage = `Usage of jwt validate:
+  -audience string
+    	Expected audience of the JWT-SVID
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -svid string
+    	JWT-SVID to validate
+`
+
+func TestValidateSynopsis(t *testing.T) {
+	cmd := NewValidateCommand()
+	assert.Equal(t, "Validates a JWT-SVID against the trust domain and federated bundles", cmd.Synopsis())
+}
+
+func TestValidateHelp(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newValidateCommand(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	assert.Equal(t, "flag: help requested", cmd.Help())
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, expectedValidateUsage, stderr.String())
+}
+
+func TestValidateRun(t *testing.T) {
+	socketPath := spiretest.StartGRPCSocketServerOnTempSocket(t, func(s *grpc.Server) {
+		svidpb.RegisterSVIDServer(s, fakeValidateServer{})
+	})
+
+	for _, tt := range []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "missing audience",
+			args:           []string{"-svid", "TOKEN"},
+			expectedCode:   1,
+			expectedStderr: "audience must be specified\n",
+		},
+		{
+			name:           "missing svid",
+			args:           []string{"-audience", "AUDIENCE"},
+			expectedCode:   1,
+			expectedStderr: "svid must be specified\n",
+		},
+		{
+			name:           "invalid svid",
+			args:           []string{"-audience", "AUDIENCE", "-svid", "BAD"},
+			expectedCode:   1,
+			expectedStderr: "unable to validate JWT-SVID: rpc error: code = InvalidArgument desc = failed to validate JWT-SVID\n",
+		},
+		{
+			name:         "success",
+			args:         []string{"-audience", "AUDIENCE", "-svid", "TOKEN"},
+			expectedCode: 0,
+			expectedStdout: `SVID is valid.
+SPIFFE ID : spiffe://domain.test/workload
+Claims    : {"aud":"AUDIENCE"}
+Entry ID  : ENTRY1
+Entry ID  : ENTRY2
+`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			cmd := newValidateCommand(&common_cli.Env{
+				Stdin:  strings.NewReader(""),
+				Stdout: stdout,
+				Stderr: stderr,
+			})
+
+			code := cmd.Run(append([]string{"-registrationUDSPath", socketPath}, tt.args...))
+			require.Equal(t, tt.expectedCode, code, fmt.Sprintf("stderr: %s", stderr.String()))
+			require.Equal(t, tt.expectedStderr, stderr.String())
+			require.Equal(t, tt.expectedStdout, stdout.String())
+		})
+	}
+}
+
+type fakeValidateServer struct {
+	svidpb.SVIDServer
+}
+
+func (fakeValidateServer) ValidateJWTSVID(ctx context.Context, req *svidpb.ValidateJWTSVIDRequest) (*svidpb.ValidateJWTSVIDResponse, error) {
+	if req.Token != "TOKEN" {
+		return nil, status.Error(codes.InvalidArgument, "failed to validate JWT-SVID")
+	}
+	return &svidpb.ValidateJWTSVIDResponse{
+		Id: &types.SPIFFEID{TrustDomain: "domain.test", Path: "/workload"},
+		Claims: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"aud": {Kind: &structpb.Value_StringValue{StringValue: req.Audience}},
+			},
+		},
+		Entries: []*types.Entry{{Id: "ENTRY1"}, {Id: "ENTRY2"}},
+	}, nil
+}
@@ -0,0 +1,57 @@
+package migrate
+
+import (
+	"errors"
+	"flag"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
+)
+
+const (
+	defaultConfigPath = "conf/server/server.conf"
+
+	dataStoreType = "DataStore"
+	sqlPluginName = "sql"
+)
+
+// configFlags are the flags shared by the migrate commands to locate the
+// datastore configuration of the server
+type configFlags struct {
+	configPath string
+	expandEnv  bool
+}
+
+func (c *configFlags) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.configPath, "config", defaultConfigPath, "Path to the SPIRE server config file")
+	fs.BoolVar(&c.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+}
+
+// openMigrator connects to the database configured for the sql DataStore
+// plugin in the server config file.
+func (c *configFlags) openMigrator() (*sql.Migrator, error) {
+	config, err := run.ParseFile(c.configPath, c.expandEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	pluginConfigs, err := run.PluginConfigs(config, dataStoreType)
+	if err != nil {
+		return nil, err
+	}
+	pluginConfig, ok := pluginConfigs[sqlPluginName]
+	if !ok {
+		return nil, errors.New("the sql DataStore plugin is not configured")
+	}
+
+	return sql.NewMigrator(pluginConfig.Data, hclog.NewNullLogger())
+}
+
+func parseFlags(env *common_cli.Env, name string, args []string, appendFlags func(*flag.FlagSet)) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.Stderr)
+	appendFlags(fs)
+	return fs.Parse(args)
+}
@@ -0,0 +1,188 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusHelp(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	newStatusCommand(env).Help()
+	require.Equal(t, `Usage of migrate status:
+  -config string
+    	Path to the SPIRE server config file (default "conf/server/server.conf")
+  -expandEnv
+    	Expand environment variables in SPIRE config file
+`, stderr.String())
+}
+
+func TestRunHelp(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	newRunCommand(env).Help()
+	require.Equal(t, `Usage of migrate run:
+  -config string
+    	Path to the SPIRE server config file (default "conf/server/server.conf")
+  -dryRun
+    	Print the SQL statements of the pending migrations without applying them
+  -expandEnv
+    	Expand environment variables in SPIRE config file
+`, stderr.String())
+}
+
+func TestRollbackHelp(t *testing.T) {
+	env, _, stderr := newTestEnv()
+	newRollbackCommand(env).Help()
+	require.Equal(t, `Usage of migrate rollback:
+  -config string
+    	Path to the SPIRE server config file (default "conf/server/server.conf")
+  -dryRun
+    	Print the SQL statements of the rollbacks without applying them
+  -expandEnv
+    	Expand environment variables in SPIRE config file
+  -version int
+    	Schema version to roll back to (default -1)
+`, stderr.String())
+}
+
+func TestMigrate(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	configPath := filepath.Join(dir, "server.conf")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(fmt.Sprintf(`
+plugins {
+	DataStore "sql" {
+		plugin_data {
+			database_type = "sqlite3"
+			connection_string = "%s"
+		}
+	}
+}
+`, filepath.Join(dir, "datastore.sqlite3"))), 0600))
+
+	// A new database is reported as not initialized
+	stdout := runStatus(t, "-config", configPath)
+	require.Regexp(t, `^Database is not initialized. Running the migrations creates the schema at version \d+.\n$`, stdout)
+
+	// A dry run prints the statements without applying them
+	stdout = runMigrations(t, "-config", configPath, "-dryRun")
+	require.Regexp(t, `(?m)^-- Schema version \d+ \(checksum [0-9a-f]{64}\)$`, stdout)
+	require.Contains(t, stdout, `CREATE TABLE "bundles"`)
+	require.Regexp(t, `-- Dry run: no changes were made\n$`, stdout)
+
+	stdout = runStatus(t, "-config", configPath)
+	require.Contains(t, stdout, "Database is not initialized")
+
+	stdout = runMigrations(t, "-config", configPath)
+	matches := regexp.MustCompile(`^Migrated to schema version (\d+) \(checksum ([0-9a-f]{64})\)\nDatabase schema is at version (\d+)\n$`).FindStringSubmatch(stdout)
+	require.NotNil(t, matches, "unexpected output: %s", stdout)
+	version, checksum := matches[1], matches[2]
+	require.Equal(t, version, matches[3])
+
+	stdout = runStatus(t, "-config", configPath)
+	require.Regexp(t, fmt.Sprintf(`^Schema version     : %s
+Latest version     : %s
+Last migrated by   : \S+
+Pending migrations : none
+Applied migrations :
+  Version %s applied by \S+ at \S+ \(checksum %s\)
+$`, version, version, version, checksum), stdout)
+
+	// Running again has nothing left to do
+	stdout = runMigrations(t, "-config", configPath)
+	require.Equal(t, fmt.Sprintf("Database schema is at version %s\n", version), stdout)
+
+	// Roll back the last migration
+	latest, err := strconv.Atoi(version)
+	require.NoError(t, err)
+	previous := strconv.Itoa(latest - 1)
+
+	stdout = runRollback(t, "-config", configPath, "-version", previous, "-dryRun")
+	require.Regexp(t, fmt.Sprintf(`(?m)^-- Rollback to schema version %s \(checksum [0-9a-f]{64}\)$`, previous), stdout)
+	require.Contains(t, stdout, `DROP TABLE "entry_templates"`)
+	require.Regexp(t, `-- Dry run: no changes were made\n$`, stdout)
+
+	stdout = runRollback(t, "-config", configPath, "-version", previous)
+	matches = regexp.MustCompile(`^Rolled back to schema version (\d+) \(checksum ([0-9a-f]{64})\)\nDatabase schema is at version (\d+)\n$`).FindStringSubmatch(stdout)
+	require.NotNil(t, matches, "unexpected output: %s", stdout)
+	require.Equal(t, previous, matches[1])
+	require.Equal(t, previous, matches[3])
+	rollbackChecksum := matches[2]
+
+	stdout = runStatus(t, "-config", configPath)
+	require.Regexp(t, fmt.Sprintf(`(?m)^Pending migrations : %s
+Applied migrations :
+  Version %s applied by \S+ at \S+ \(checksum %s\)
+  Rolled back to version %s by \S+ at \S+ \(checksum %s\)
+$`, version, version, checksum, previous, rollbackChecksum), stdout)
+
+	// The migration can be applied again
+	stdout = runMigrations(t, "-config", configPath)
+	require.Regexp(t, fmt.Sprintf(`^Migrated to schema version %s `, version), stdout)
+}
+
+func TestRollbackRequiresVersion(t *testing.T) {
+	env, stdout, stderr := newTestEnv()
+	exitCode := newRollbackCommand(env).Run([]string{"-config", "unused"})
+	require.Equal(t, 1, exitCode)
+	require.Empty(t, stdout.String())
+	require.Equal(t, "a schema version is required\n", stderr.String())
+}
+
+func TestMigrateWithoutSQLDataStore(t *testing.T) {
+	configPath := filepath.Join(spiretest.TempDir(t), "server.conf")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`
+plugins {
+	KeyManager "memory" {
+		plugin_data {}
+	}
+}
+`), 0600))
+
+	env, stdout, stderr := newTestEnv()
+	exitCode := newStatusCommand(env).Run([]string{"-config", configPath})
+	require.Equal(t, 1, exitCode)
+	require.Empty(t, stdout.String())
+	require.Equal(t, "the sql DataStore plugin is not configured\n", stderr.String())
+}
+
+func runStatus(t *testing.T, args ...string) string {
+	env, stdout, stderr := newTestEnv()
+	exitCode := newStatusCommand(env).Run(args)
+	require.Equal(t, 0, exitCode, "stderr: %s", stderr.String())
+	require.Empty(t, stderr.String())
+	return stdout.String()
+}
+
+func runMigrations(t *testing.T, args ...string) string {
+	env, stdout, stderr := newTestEnv()
+	exitCode := newRunCommand(env).Run(args)
+	require.Equal(t, 0, exitCode, "stderr: %s", stderr.String())
+	require.Empty(t, stderr.String())
+	return stdout.String()
+}
+
+func runRollback(t *testing.T, args ...string) string {
+	env, stdout, stderr := newTestEnv()
+	exitCode := newRollbackCommand(env).Run(args)
+	require.Equal(t, 0, exitCode, "stderr: %s", stderr.String())
+	require.Empty(t, stderr.String())
+	return stdout.String()
+}
+
+func newTestEnv() (*common_cli.Env, *bytes.Buffer, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	return &common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	}, stdout, stderr
+}
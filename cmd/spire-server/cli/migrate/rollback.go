@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+)
+
+// NewRollbackCommand creates a new "migrate rollback" command.
+func NewRollbackCommand() cli.Command {
+	return newRollbackCommand(common_cli.DefaultEnv)
+}
+
+func newRollbackCommand(env *common_cli.Env) *rollbackCommand {
+	return &rollbackCommand{env: env}
+}
+
+type rollbackCommand struct {
+	env *common_cli.Env
+	configFlags
+	version int
+	dryRun  bool
+}
+
+func (c *rollbackCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *rollbackCommand) Synopsis() string {
+	return "Reverts the datastore schema migrations down to a previous schema version"
+}
+
+func (c *rollbackCommand) parseFlags(args []string) error {
+	return parseFlags(c.env, "migrate rollback", args, func(fs *flag.FlagSet) {
+		c.appendFlags(fs)
+		fs.IntVar(&c.version, "version", -1, "Schema version to roll back to")
+		fs.BoolVar(&c.dryRun, "dryRun", false, "Print the SQL statements of the rollbacks without applying them")
+	})
+}
+
+func (c *rollbackCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+	return 0
+}
+
+func (c *rollbackCommand) run() error {
+	if c.version < 0 {
+		return errors.New("a schema version is required")
+	}
+
+	migrator, err := c.openMigrator()
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	results, err := migrator.Rollback(c.version, c.dryRun)
+	if c.dryRun {
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			if err := c.env.Printf("-- Rollback to schema version %d (checksum %s)\n", result.Version, result.Checksum); err != nil {
+				return err
+			}
+			for _, statement := range result.Statements {
+				if err := c.env.Printf("%s;\n", statement); err != nil {
+					return err
+				}
+			}
+			if err := c.env.Println(); err != nil {
+				return err
+			}
+		}
+		return c.env.Println("-- Dry run: no changes were made")
+	}
+
+	// Report the rollbacks that were applied before any failure, since those
+	// were committed
+	for _, result := range results {
+		if printErr := c.env.Printf("Rolled back to schema version %d (checksum %s)\n", result.Version, result.Checksum); printErr != nil {
+			return printErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	return c.env.Printf("Database schema is at version %d\n", status.SchemaVersion)
+}
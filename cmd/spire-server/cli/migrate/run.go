@@ -0,0 +1,100 @@
+package migrate
+
+import (
+	"flag"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+)
+
+// NewRunCommand creates a new "migrate run" command.
+func NewRunCommand() cli.Command {
+	return newRunCommand(common_cli.DefaultEnv)
+}
+
+func newRunCommand(env *common_cli.Env) *runCommand {
+	return &runCommand{env: env}
+}
+
+type runCommand struct {
+	env *common_cli.Env
+	configFlags
+
+	dryRun bool
+}
+
+func (c *runCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *runCommand) Synopsis() string {
+	return "Applies the pending datastore schema migrations"
+}
+
+func (c *runCommand) parseFlags(args []string) error {
+	return parseFlags(c.env, "migrate run", args, func(fs *flag.FlagSet) {
+		c.appendFlags(fs)
+		fs.BoolVar(&c.dryRun, "dryRun", false, "Print the SQL statements of the pending migrations without applying them")
+	})
+}
+
+func (c *runCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+	return 0
+}
+
+func (c *runCommand) run() error {
+	migrator, err := c.openMigrator()
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	results, err := migrator.Migrate(c.dryRun)
+	if c.dryRun {
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			if err := c.env.Printf("-- Schema version %d (checksum %s)\n", result.Version, result.Checksum); err != nil {
+				return err
+			}
+			for _, statement := range result.Statements {
+				if err := c.env.Printf("%s;\n", statement); err != nil {
+					return err
+				}
+			}
+			if err := c.env.Println(); err != nil {
+				return err
+			}
+		}
+		return c.env.Println("-- Dry run: no changes were made")
+	}
+
+	// Report the migrations that were applied before any failure, since
+	// those were committed
+	for _, result := range results {
+		if printErr := c.env.Printf("Migrated to schema version %d (checksum %s)\n", result.Version, result.Checksum); printErr != nil {
+			return printErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	return c.env.Printf("Database schema is at version %d\n", status.SchemaVersion)
+}
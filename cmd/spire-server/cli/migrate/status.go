@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+)
+
+// NewStatusCommand creates a new "migrate status" command.
+func NewStatusCommand() cli.Command {
+	return newStatusCommand(common_cli.DefaultEnv)
+}
+
+func newStatusCommand(env *common_cli.Env) *statusCommand {
+	return &statusCommand{env: env}
+}
+
+type statusCommand struct {
+	env *common_cli.Env
+	configFlags
+}
+
+func (c *statusCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *statusCommand) Synopsis() string {
+	return "Shows the schema version of the datastore and the pending migrations"
+}
+
+func (c *statusCommand) parseFlags(args []string) error {
+	return parseFlags(c.env, "migrate status", args, func(fs *flag.FlagSet) {
+		c.appendFlags(fs)
+	})
+}
+
+func (c *statusCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+	return 0
+}
+
+func (c *statusCommand) run() error {
+	migrator, err := c.openMigrator()
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	if !status.Initialized {
+		return c.env.Printf("Database is not initialized. Running the migrations creates the schema at version %d.\n", status.LatestSchemaVersion)
+	}
+
+	pending := "none"
+	if len(status.Pending) > 0 {
+		versions := make([]string, 0, len(status.Pending))
+		for _, version := range status.Pending {
+			versions = append(versions, strconv.Itoa(version))
+		}
+		pending = strings.Join(versions, ", ")
+	}
+
+	if err := c.env.Printf("Schema version     : %d\n", status.SchemaVersion); err != nil {
+		return err
+	}
+	if err := c.env.Printf("Latest version     : %d\n", status.LatestSchemaVersion); err != nil {
+		return err
+	}
+	if err := c.env.Printf("Last migrated by   : %s\n", status.CodeVersion); err != nil {
+		return err
+	}
+	if err := c.env.Printf("Pending migrations : %s\n", pending); err != nil {
+		return err
+	}
+
+	if len(status.Applied) == 0 {
+		return nil
+	}
+	if err := c.env.Println("Applied migrations :"); err != nil {
+		return err
+	}
+	for _, applied := range status.Applied {
+		format := "  Version %d applied by %s at %s (checksum %s)\n"
+		if applied.Rollback {
+			format = "  Rolled back to version %d by %s at %s (checksum %s)\n"
+		}
+		if err := c.env.Printf(format,
+			applied.Version, applied.CodeVersion, applied.AppliedAt.UTC().Format(time.RFC3339), applied.Checksum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -8,29 +8,44 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net"
+	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/hashicorp/hcl"
 	"github.com/imdario/mergo"
 	"github.com/mitchellh/cli"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/dnspolicy"
+	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	oidcendpoint "github.com/spiffe/spire/pkg/server/endpoints/oidc"
+	"github.com/spiffe/spire/pkg/server/entrydefaults"
+	"github.com/spiffe/spire/pkg/server/entrypolicy"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
+	"github.com/spiffe/spire/proto/spire/types"
 )
 
 const (
@@ -40,6 +55,13 @@ const (
 	defaultSocketPath         = "/tmp/spire-registration.sock"
 	defaultLogLevel           = "INFO"
 	defaultBundleEndpointPort = 443
+	defaultOIDCDiscoveryPort  = 443
+
+	bundleEndpointProfileHTTPSWeb    = "https_web"
+	bundleEndpointProfileHTTPSSPIFFE = "https_spiffe"
+
+	defaultAuditLogFormat     = log.JSONFormat
+	defaultAuditLogMaxBackups = 5
 )
 
 var (
@@ -61,22 +83,49 @@ type Config struct {
 }
 
 type serverConfig struct {
-	BindAddress         string             `hcl:"bind_address"`
-	BindPort            int                `hcl:"bind_port"`
-	CAKeyType           string             `hcl:"ca_key_type"`
-	CASubject           *caSubjectConfig   `hcl:"ca_subject"`
-	CATTL               string             `hcl:"ca_ttl"`
-	DataDir             string             `hcl:"data_dir"`
-	Experimental        experimentalConfig `hcl:"experimental"`
-	Federation          *federationConfig  `hcl:"federation"`
-	JWTIssuer           string             `hcl:"jwt_issuer"`
-	LogFile             string             `hcl:"log_file"`
-	LogLevel            string             `hcl:"log_level"`
-	LogFormat           string             `hcl:"log_format"`
-	RateLimit           rateLimitConfig    `hcl:"ratelimit"`
-	RegistrationUDSPath string             `hcl:"registration_uds_path"`
-	DefaultSVIDTTL      string             `hcl:"default_svid_ttl"`
-	TrustDomain         string             `hcl:"trust_domain"`
+	AdminAPI            *adminAPIConfig                `hcl:"admin_api"`
+	AuditLog            *auditLogConfig                `hcl:"audit_log"`
+	BindAddress         string                         `hcl:"bind_address"`
+	BindPort            int                            `hcl:"bind_port"`
+	CAActivateThreshold float64                        `hcl:"ca_activate_threshold"`
+	CADNSNamePolicy     map[string]dnsNamePolicyConfig `hcl:"ca_dns_name_policy"`
+	CAFullUpstreamChain bool                           `hcl:"ca_full_upstream_chain"`
+	CAKeyType           string                         `hcl:"ca_key_type"`
+	CAMaxPathLen        *int                           `hcl:"ca_max_path_len"`
+	CAPrepareThreshold  float64                        `hcl:"ca_prepare_threshold"`
+	CASubject           *caSubjectConfig               `hcl:"ca_subject"`
+	CATTL               string                         `hcl:"ca_ttl"`
+	ClockSkewTolerance  string                         `hcl:"clock_skew_tolerance"`
+	DataDir             string                         `hcl:"data_dir"`
+	DrainTimeout        string                         `hcl:"drain_timeout"`
+	EntryDefaults       map[string]entryDefaultsConfig `hcl:"entry_defaults"`
+	EntryPolicy         *entryPolicyConfig             `hcl:"entry_policy"`
+	Experimental        experimentalConfig             `hcl:"experimental"`
+	Federation          *federationConfig              `hcl:"federation"`
+	GRPCHealth          bool                           `hcl:"grpc_health_enabled"`
+	GRPCReflection      bool                           `hcl:"grpc_reflection_enabled"`
+	GRPCTuning          *grpcutil.ServerConfig         `hcl:"grpc_tuning"`
+	IssuanceMetrics     []string                       `hcl:"issuance_metrics_prefixes"`
+	JWTIssuer           string                         `hcl:"jwt_issuer"`
+	JWTKeyType          string                         `hcl:"jwt_key_type"`
+	JWTSigningAlgorithm string                         `hcl:"jwt_signing_algorithm"`
+	LogFile             string                         `hcl:"log_file"`
+	LogLevel            string                         `hcl:"log_level"`
+	LogSubsystemLevels  map[string]string              `hcl:"log_subsystem_levels"`
+	LogFormat           string                         `hcl:"log_format"`
+	NodeAttestation     *nodeAttestationConfig         `hcl:"node_attestation"`
+	NodeResolverRefresh string                         `hcl:"node_resolver_refresh_interval"`
+	NotifierReconcile   string                         `hcl:"notifier_reconcile_interval"`
+	OIDCDiscovery       *oidcDiscoveryConfig           `hcl:"oidc_discovery"`
+	Pruning             *pruningConfig                 `hcl:"pruning"`
+	RateLimit           rateLimitConfig                `hcl:"ratelimit"`
+	RegistrationUDSPath string                         `hcl:"registration_uds_path"`
+	RoleBindings        map[string]roleBindingConfig   `hcl:"role_bindings"`
+	DefaultSVIDTTL      string                         `hcl:"default_svid_ttl"`
+	SVIDBackdate        string                         `hcl:"svid_backdate"`
+	SVIDRotation        *rotationutil.Config           `hcl:"svid_rotation"`
+	TrustDomain         string                         `hcl:"trust_domain"`
+	TrustDomainAliases  []string                       `hcl:"trust_domain_aliases"`
 
 	ConfigPath string
 	ExpandEnv  bool
@@ -91,7 +140,9 @@ type serverConfig struct {
 }
 
 type experimentalConfig struct {
-	AllowAgentlessNodeAttestors bool `hcl:"allow_agentless_node_attestors"`
+	AllowAgentlessNodeAttestors bool     `hcl:"allow_agentless_node_attestors"`
+	CacheReloadInterval         string   `hcl:"cache_reload_interval"`
+	FeatureFlags                []string `hcl:"feature_flags"`
 
 	DeprecatedBundleEndpointEnabled bool                                     `hcl:"bundle_endpoint_enabled"`
 	DeprecatedBundleEndpointAddress string                                   `hcl:"bundle_endpoint_address"`
@@ -102,6 +153,21 @@ type experimentalConfig struct {
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
+type auditLogConfig struct {
+	Path       string   `hcl:"path"`
+	Format     string   `hcl:"format"`
+	MaxSizeMB  int      `hcl:"max_size_mb"`
+	MaxBackups *int     `hcl:"max_backups"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type adminAPIConfig struct {
+	BindAddress   string   `hcl:"bind_address"`
+	BindPort      int      `hcl:"bind_port"`
+	AuthorizedIDs []string `hcl:"authorized_ids"`
+	UnusedKeys    []string `hcl:",unusedKeys"`
+}
+
 type caSubjectConfig struct {
 	Country      []string `hcl:"country"`
 	Organization []string `hcl:"organization"`
@@ -130,6 +196,30 @@ type bundleEndpointACMEConfig struct {
 	UnusedKeys   []string `hcl:",unusedKeys"`
 }
 
+type oidcDiscoveryConfig struct {
+	Address    string                    `hcl:"address"`
+	Port       int                       `hcl:"port"`
+	Domain     string                    `hcl:"domain"`
+	ACME       *bundleEndpointACMEConfig `hcl:"acme"`
+	UnusedKeys []string                  `hcl:",unusedKeys"`
+}
+
+type nodeAttestationConfig struct {
+	ChallengeRoundTimeout    string   `hcl:"challenge_round_timeout"`
+	MaxChallengeRounds       int      `hcl:"max_challenge_rounds"`
+	MaxChallengeSize         int      `hcl:"max_challenge_size"`
+	MaxChallengeResponseSize int      `hcl:"max_challenge_response_size"`
+	UnusedKeys               []string `hcl:",unusedKeys"`
+}
+
+type pruningConfig struct {
+	RetainExpiredEntries bool     `hcl:"retain_expired_entries"`
+	ExpiredAgentsAfter   string   `hcl:"expired_agents_after"`
+	OrphanedEntries      bool     `hcl:"orphaned_entries"`
+	DryRun               bool     `hcl:"dry_run"`
+	UnusedKeys           []string `hcl:",unusedKeys"`
+}
+
 type deprecatedFederatesWithConfig struct {
 	BundleEndpointAddress  string   `hcl:"bundle_endpoint_address"`
 	BundleEndpointPort     int      `hcl:"bundle_endpoint_port"`
@@ -147,15 +237,45 @@ type federatesWithBundleEndpointConfig struct {
 	Address    string   `hcl:"address"`
 	Port       int      `hcl:"port"`
 	SpiffeID   string   `hcl:"spiffe_id"`
+	Profile    string   `hcl:"profile"`
 	UseWebPKI  bool     `hcl:"use_web_pki"`
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
-type rateLimitConfig struct {
-	Attestation *bool    `hcl:"attestation"`
+type entryDefaultsConfig struct {
+	TTL              string   `hcl:"ttl"`
+	FederatesWith    []string `hcl:"federates_with"`
+	DNSNameTemplates []string `hcl:"dns_name_templates"`
+	UnusedKeys       []string `hcl:",unusedKeys"`
+}
+
+type dnsNamePolicyConfig struct {
+	AllowedSuffixes []string `hcl:"allowed_suffixes"`
+	UnusedKeys      []string `hcl:",unusedKeys"`
+}
+
+type entryPolicyConfig struct {
+	PolicyPaths []string `hcl:"policy_paths"`
+	Query       string   `hcl:"query"`
 	UnusedKeys  []string `hcl:",unusedKeys"`
 }
 
+type rateLimitConfig struct {
+	Attestation        *bool    `hcl:"attestation"`
+	AttestationPerIP   int      `hcl:"attestation_per_ip"`
+	SigningPerIP       int      `hcl:"signing_per_ip"`
+	SigningPerAgent    int      `hcl:"signing_per_agent"`
+	JWTSigningPerIP    int      `hcl:"jwt_signing_per_ip"`
+	JWTSigningPerAgent int      `hcl:"jwt_signing_per_agent"`
+	UnusedKeys         []string `hcl:",unusedKeys"`
+}
+
+type roleBindingConfig struct {
+	SPIFFEIDs  []string `hcl:"spiffe_ids"`
+	Selectors  []string `hcl:"selectors"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
 func NewRunCommand(logOptions []log.Option, allowUnknownConfig bool) cli.Command {
 	return newRunCommand(common_cli.DefaultEnv, logOptions, allowUnknownConfig)
 }
@@ -189,6 +309,16 @@ func Help(name string, writer io.Writer) string {
 }
 
 func LoadConfig(name string, args []string, logOptions []log.Option, output io.Writer, allowUnknownConfig bool) (*server.Config, error) {
+	input, err := loadInput(name, args, output)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewServerConfig(input, logOptions, allowUnknownConfig)
+}
+
+// loadInput loads the configuration file and merges it with the CLI flags.
+func loadInput(name string, args []string, output io.Writer) (*Config, error) {
 	// First parse the CLI flags so we can get the config
 	// file path, if set
 	cliInput, err := parseFlags(name, args, output)
@@ -203,22 +333,29 @@ func LoadConfig(name string, args []string, logOptions []log.Option, output io.W
 		return nil, err
 	}
 
-	input, err := mergeInput(fileInput, cliInput)
-	if err != nil {
-		return nil, err
-	}
-
-	return NewServerConfig(input, logOptions, allowUnknownConfig)
+	return mergeInput(fileInput, cliInput)
 }
 
 // Run the SPIFFE Server
 func (cmd *Command) Run(args []string) int {
-	c, err := LoadConfig(commandName, args, cmd.logOptions, cmd.env.Stderr, cmd.allowUnknownConfig)
+	input, err := loadInput(commandName, args, cmd.env.Stderr)
+	if err != nil {
+		_, _ = fmt.Fprintln(cmd.env.Stderr, err)
+		return 1
+	}
+
+	c, err := NewServerConfig(input, cmd.logOptions, cmd.allowUnknownConfig)
 	if err != nil {
 		_, _ = fmt.Fprintln(cmd.env.Stderr, err)
 		return 1
 	}
 
+	if err := fflag.Load(c.Experimental.FeatureFlags, fflag.ScopeServer); err != nil {
+		_, _ = fmt.Fprintln(cmd.env.Stderr, err)
+		return 1
+	}
+	defer fflag.Unload()
+
 	// Set umask before starting up the server
 	common_cli.SetUmask(c.Log)
 
@@ -226,7 +363,12 @@ func (cmd *Command) Run(args []string) int {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	util.SignalListener(ctx, cancel)
+	if c.DrainTimeout > 0 {
+		go drainOnSignal(ctx, cancel, c.Log, s)
+	} else {
+		util.SignalListener(ctx, cancel)
+	}
+	go cmd.reloadOnSignal(ctx, args, input, c, s)
 
 	err = s.Run(ctx)
 	if err != nil {
@@ -238,7 +380,214 @@ func (cmd *Command) Run(args []string) int {
 	return 0
 }
 
-//Synopsis of the command
+// drainOnSignal shuts the server down when the process receives a SIGINT or
+// a SIGTERM. On SIGTERM, the agent connections are drained first so agents
+// can move to other servers without all of them reconnecting at once.
+func drainOnSignal(ctx context.Context, cancel func(), log logrus.FieldLogger, s *server.Server) {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	var sig os.Signal
+	select {
+	case <-ctx.Done():
+		return
+	case sig = <-signalCh:
+	}
+
+	if sig == syscall.SIGTERM {
+		log.Info("Draining agent connections before shutting down")
+		s.Drain()
+		select {
+		case <-ctx.Done():
+		case <-s.Drained():
+		case <-signalCh:
+			log.Warn("Shutting down before draining finished")
+		}
+	}
+	cancel()
+}
+
+// reloadableSettings are the settings that are applied without restarting
+// the server when the configuration is reloaded.
+var reloadableSettings = map[string]bool{
+	"server.federation.federates_with": true,
+	"server.log_level":                 true,
+	"server.log_subsystem_levels":      true,
+	"server.ratelimit":                 true,
+	"telemetry":                        true,
+}
+
+// reloadable is the part of the server that can be reconfigured while it
+// runs.
+type reloadable interface {
+	SetRateLimits(endpoints.RateLimitConfig)
+	SetTelemetry(telemetry.FileConfig) error
+	SetFederatesWith(map[string]bundleClient.TrustDomainConfig) error
+}
+
+// reloadOnSignal reloads the configuration every time the process receives
+// a SIGHUP. The settings that are safe to change are applied without a
+// restart, and changes to any other setting are reported as requiring one.
+func (cmd *Command) reloadOnSignal(ctx context.Context, args []string, input *Config, c *server.Config, s reloadable) {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGHUP)
+	defer signal.Stop(signalCh)
+
+	running := input
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signalCh:
+		}
+
+		reloaded, err := loadInput(commandName, args, cmd.env.Stderr)
+		if err == nil {
+			err = validateConfig(reloaded)
+		}
+		if err != nil {
+			c.Log.WithError(err).Error("Failed to reload configuration; keeping current configuration")
+			continue
+		}
+		running = reloadConfig(input, running, reloaded, c, s)
+	}
+}
+
+// reloadConfig applies the reloadable settings that changed since the
+// running configuration, and reports the other settings that changed since
+// the server started. It returns the new running configuration.
+func reloadConfig(initial, running, reloaded *Config, c *server.Config, s reloadable) *Config {
+	var applied, failed []string
+	apply := func(setting string, fn func() error) {
+		if err := fn(); err != nil {
+			c.Log.WithError(err).WithField(telemetry.Setting, setting).Error("Failed to apply reloaded setting")
+			failed = append(failed, setting)
+			return
+		}
+		applied = append(applied, setting)
+	}
+
+	levelsApplied := false
+	for _, setting := range common_cli.ChangedConfigKeys(running, reloaded, "server", "server.federation") {
+		switch setting {
+		case "server.log_level", "server.log_subsystem_levels":
+			if levelsApplied || c.LogLevels == nil {
+				continue
+			}
+			levelsApplied = true
+			apply("server.log_level", func() error {
+				levels, err := log.ParseLevels(reloaded.Server.LogLevel, reloaded.Server.LogSubsystemLevels)
+				if err != nil {
+					return err
+				}
+				c.LogLevels.SetLevels(levels)
+				return nil
+			})
+		case "server.ratelimit":
+			apply(setting, func() error {
+				rateLimit, err := rateLimitFromConfig(reloaded.Server.RateLimit)
+				if err != nil {
+					return err
+				}
+				s.SetRateLimits(rateLimit)
+				return nil
+			})
+		case "server.federation.federates_with":
+			apply(setting, func() error {
+				var federatesWith map[string]bundleClient.TrustDomainConfig
+				if reloaded.Server.Federation != nil {
+					var err error
+					federatesWith, err = federatesWithFromConfig(reloaded.Server.Federation.FederatesWith)
+					if err != nil {
+						return err
+					}
+				}
+				return s.SetFederatesWith(federatesWith)
+			})
+		case "telemetry":
+			apply(setting, func() error {
+				return s.SetTelemetry(reloaded.Telemetry)
+			})
+		}
+	}
+
+	var restartRequired []string
+	for _, setting := range common_cli.ChangedConfigKeys(initial, reloaded, "server", "server.federation") {
+		if !reloadableSettings[setting] {
+			restartRequired = append(restartRequired, setting)
+		}
+	}
+
+	c.Log.WithFields(logrus.Fields{
+		telemetry.AppliedSettings:         applied,
+		telemetry.RestartRequiredSettings: restartRequired,
+	}).Info("Configuration reloaded")
+
+	if len(failed) > 0 {
+		// Keep the running configuration so the failed settings are applied
+		// again on the next reload.
+		return running
+	}
+	return reloaded
+}
+
+// federatesWithFromConfig returns the bundle client configuration of the
+// trust domains the server federates with.
+func federatesWithFromConfig(config map[string]federatesWithConfig) (map[string]bundleClient.TrustDomainConfig, error) {
+	federatesWith := map[string]bundleClient.TrustDomainConfig{}
+	for trustDomain, config := range config {
+		port := defaultBundleEndpointPort
+		if config.BundleEndpoint.Port != 0 {
+			port = config.BundleEndpoint.Port
+		}
+		useWebPKI, err := bundleEndpointUsesWebPKI(trustDomain, config.BundleEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		if useWebPKI && config.BundleEndpoint.SpiffeID != "" {
+			return nil, errors.New("usage of `bundle_endpoint.spiffe_id` is not allowed when authenticating with Web PKI")
+		}
+		federatesWith[trustDomain] = bundleClient.TrustDomainConfig{
+			EndpointAddress:  fmt.Sprintf("%s:%d", config.BundleEndpoint.Address, port),
+			EndpointSpiffeID: config.BundleEndpoint.SpiffeID,
+			UseWebPKI:        useWebPKI,
+		}
+	}
+	return federatesWith, nil
+}
+
+func trustDomainAliasesFromConfig(aliases []string, trustDomain url.URL, federatesWith map[string]bundleClient.TrustDomainConfig) ([]url.URL, error) {
+	var urls []url.URL
+	seen := make(map[string]bool)
+	for _, alias := range aliases {
+		id, err := idutil.ParseSpiffeID("spiffe://"+alias, idutil.AllowAnyTrustDomain())
+		if err != nil {
+			return nil, fmt.Errorf("could not parse trust_domain_aliases entry %q: %v", alias, err)
+		}
+		if id.String() == trustDomain.String() {
+			return nil, fmt.Errorf("trust_domain_aliases entry %q cannot be the server trust domain", alias)
+		}
+		if seen[id.String()] {
+			return nil, fmt.Errorf("trust_domain_aliases entry %q is duplicated", alias)
+		}
+		seen[id.String()] = true
+		urls = append(urls, *id)
+	}
+
+	for federated := range federatesWith {
+		td, err := spiffeid.TrustDomainFromString(federated)
+		if err != nil {
+			continue
+		}
+		if seen[td.IDString()] {
+			return nil, fmt.Errorf("trust domain %q cannot be both a trust domain alias and federated with", federated)
+		}
+	}
+	return urls, nil
+}
+
+// Synopsis of the command
 func (*Command) Synopsis() string {
 	return "Runs the server"
 }
@@ -279,6 +628,23 @@ func ParseFile(path string, expandEnv bool) (*Config, error) {
 	return c, nil
 }
 
+// PluginConfigs returns the configuration of the plugins of the given type
+// in the parsed config file, keyed by plugin name.
+func PluginConfigs(c *Config, pluginType string) (map[string]catalog.PluginConfig, error) {
+	pluginConfigs := make(map[string]catalog.PluginConfig)
+	if c.Plugins == nil {
+		return pluginConfigs, nil
+	}
+	for pluginName, hclPluginConfig := range (*c.Plugins)[pluginType] {
+		pluginConfig, err := catalog.PluginConfigFromHCL(pluginType, pluginName, hclPluginConfig)
+		if err != nil {
+			return nil, err
+		}
+		pluginConfigs[pluginName] = pluginConfig
+	}
+	return pluginConfigs, nil
+}
+
 func parseFlags(name string, args []string, output io.Writer) (*serverConfig, error) {
 	flags := flag.NewFlagSet(name, flag.ContinueOnError)
 	flags.SetOutput(output)
@@ -346,6 +712,14 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		Net:  "unix",
 	}
 
+	if c.Server.AdminAPI != nil {
+		adminAPI, err := adminAPIFromConfig(c.Server.AdminAPI, ip)
+		if err != nil {
+			return nil, err
+		}
+		sc.AdminAPI = adminAPI
+	}
+
 	sc.DataDir = c.Server.DataDir
 
 	td, err := idutil.ParseSpiffeID("spiffe://"+c.Server.TrustDomain, idutil.AllowAnyTrustDomain())
@@ -356,6 +730,7 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 
 	logOptions = append(logOptions,
 		log.WithLevel(c.Server.LogLevel),
+		log.WithSubsystemLevels(c.Server.LogSubsystemLevels),
 		log.WithFormat(c.Server.LogFormat),
 		log.WithOutputFile(c.Server.LogFile))
 
@@ -364,13 +739,48 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		return nil, fmt.Errorf("could not start logger: %s", err)
 	}
 	sc.Log = logger
+	sc.LogLevels = logger
+
+	if c.Server.AuditLog != nil {
+		auditLog, err := newAuditLogger(c.Server.AuditLog)
+		if err != nil {
+			return nil, fmt.Errorf("could not start audit logger: %v", err)
+		}
+		sc.AuditLog = auditLog
+	}
 
-	if c.Server.RateLimit.Attestation == nil {
-		c.Server.RateLimit.Attestation = &defaultRateLimitAttestation
+	sc.RateLimit, err = rateLimitFromConfig(c.Server.RateLimit)
+	if err != nil {
+		return nil, err
 	}
-	sc.RateLimit.Attestation = *c.Server.RateLimit.Attestation
+
+	if len(c.Server.RoleBindings) > 0 {
+		sc.RoleBindings, err = roleBindingsFromConfig(c.Server.RoleBindings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sc.GRPCHealth = c.Server.GRPCHealth
+	sc.GRPCReflection = c.Server.GRPCReflection
 
 	sc.Experimental.AllowAgentlessNodeAttestors = c.Server.Experimental.AllowAgentlessNodeAttestors
+
+	if err := fflag.Validate(c.Server.Experimental.FeatureFlags, fflag.ScopeServer); err != nil {
+		return nil, err
+	}
+	sc.Experimental.FeatureFlags = c.Server.Experimental.FeatureFlags
+
+	if c.Server.Experimental.CacheReloadInterval != "" {
+		interval, err := time.ParseDuration(c.Server.Experimental.CacheReloadInterval)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse cache reload interval %q: %v", c.Server.Experimental.CacheReloadInterval, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("cache reload interval must be positive: got %v", interval)
+		}
+		sc.Experimental.CacheReloadInterval = interval
+	}
 	if c.Server.Federation != nil {
 		if c.Server.Federation.BundleEndpoint != nil {
 			sc.Federation.BundleEndpoint = &bundle.EndpointConfig{
@@ -391,24 +801,18 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 			}
 		}
 
-		federatesWith := map[string]bundleClient.TrustDomainConfig{}
-		for trustDomain, config := range c.Server.Federation.FederatesWith {
-			port := defaultBundleEndpointPort
-			if config.BundleEndpoint.Port != 0 {
-				port = config.BundleEndpoint.Port
-			}
-			if config.BundleEndpoint.UseWebPKI && config.BundleEndpoint.SpiffeID != "" {
-				return nil, errors.New("usage of `bundle_endpoint.spiffe_id` is not allowed when authenticating with Web PKI")
-			}
-			federatesWith[trustDomain] = bundleClient.TrustDomainConfig{
-				EndpointAddress:  fmt.Sprintf("%s:%d", config.BundleEndpoint.Address, port),
-				EndpointSpiffeID: config.BundleEndpoint.SpiffeID,
-				UseWebPKI:        config.BundleEndpoint.UseWebPKI,
-			}
+		federatesWith, err := federatesWithFromConfig(c.Server.Federation.FederatesWith)
+		if err != nil {
+			return nil, err
 		}
 		sc.Federation.FederatesWith = federatesWith
 	}
 
+	sc.TrustDomainAliases, err = trustDomainAliasesFromConfig(c.Server.TrustDomainAliases, sc.TrustDomain, sc.Federation.FederatesWith)
+	if err != nil {
+		return nil, err
+	}
+
 	sc.ProfilingEnabled = c.Server.ProfilingEnabled
 	sc.ProfilingPort = c.Server.ProfilingPort
 	sc.ProfilingFreq = c.Server.ProfilingFreq
@@ -430,8 +834,120 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		sc.CATTL = ttl
 	}
 
-	if !hasExpectedTTLs(sc.CATTL, sc.SVIDTTL) {
-		sc.Log.Warnf("The configured SVID TTL cannot be guaranteed in all cases - SVIDs with shorter TTLs may be issued if the signing key is expiring soon. Set a CA TTL of at least 6x or reduce SVID TTL below 6x to avoid issuing SVIDs with a smaller TTL than specified")
+	if c.Server.ClockSkewTolerance != "" {
+		tolerance, err := time.ParseDuration(c.Server.ClockSkewTolerance)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse clock skew tolerance %q: %v", c.Server.ClockSkewTolerance, err)
+		}
+		if tolerance < 0 {
+			return nil, fmt.Errorf("clock skew tolerance must not be negative: got %v", tolerance)
+		}
+		sc.ClockSkewTolerance = tolerance
+	}
+
+	if c.Server.SVIDBackdate != "" {
+		backdate, err := time.ParseDuration(c.Server.SVIDBackdate)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse SVID backdate %q: %v", c.Server.SVIDBackdate, err)
+		}
+		if backdate <= 0 {
+			return nil, fmt.Errorf("SVID backdate must be positive: got %v", backdate)
+		}
+		sc.SVIDBackdate = backdate
+	}
+
+	if c.Server.DrainTimeout != "" {
+		timeout, err := time.ParseDuration(c.Server.DrainTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse drain timeout %q: %v", c.Server.DrainTimeout, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("drain timeout must be positive: got %v", timeout)
+		}
+		sc.DrainTimeout = timeout
+	}
+
+	if c.Server.NotifierReconcile != "" {
+		interval, err := time.ParseDuration(c.Server.NotifierReconcile)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse notifier reconcile interval %q: %v", c.Server.NotifierReconcile, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("notifier reconcile interval must be positive: got %v", interval)
+		}
+		sc.NotifierReconcileInterval = interval
+	}
+
+	svidRotation, err := c.Server.SVIDRotation.Threshold()
+	if err != nil {
+		return nil, fmt.Errorf("invalid svid_rotation: %v", err)
+	}
+	sc.SVIDRotation = svidRotation
+
+	grpcTuning, err := c.Server.GRPCTuning.Tuning()
+	if err != nil {
+		return nil, fmt.Errorf("invalid grpc_tuning: %v", err)
+	}
+	sc.GRPCTuning = grpcTuning
+
+	if na := c.Server.NodeAttestation; na != nil {
+		if na.ChallengeRoundTimeout != "" {
+			timeout, err := time.ParseDuration(na.ChallengeRoundTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse node_attestation challenge_round_timeout %q: %v", na.ChallengeRoundTimeout, err)
+			}
+			sc.NodeAttestationLimits.RoundTimeout = timeout
+		}
+		sc.NodeAttestationLimits.MaxRounds = na.MaxChallengeRounds
+		sc.NodeAttestationLimits.MaxChallengeSize = na.MaxChallengeSize
+		sc.NodeAttestationLimits.MaxResponseSize = na.MaxChallengeResponseSize
+		if err := sc.NodeAttestationLimits.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid node_attestation: %v", err)
+		}
+	}
+
+	if c.Server.NodeResolverRefresh != "" {
+		interval, err := time.ParseDuration(c.Server.NodeResolverRefresh)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse node resolver refresh interval %q: %v", c.Server.NodeResolverRefresh, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("node resolver refresh interval must be positive: got %v", interval)
+		}
+		sc.NodeResolverRefreshInterval = interval
+	}
+
+	if p := c.Server.Pruning; p != nil {
+		if p.ExpiredAgentsAfter != "" {
+			threshold, err := time.ParseDuration(p.ExpiredAgentsAfter)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse pruning expired_agents_after %q: %v", p.ExpiredAgentsAfter, err)
+			}
+			if threshold < 0 {
+				return nil, fmt.Errorf("pruning expired_agents_after must not be negative: got %v", threshold)
+			}
+			sc.Pruning.ExpiredAgentsAfter = threshold
+		}
+		sc.Pruning.RetainExpiredEntries = p.RetainExpiredEntries
+		sc.Pruning.OrphanedEntries = p.OrphanedEntries
+		sc.Pruning.DryRun = p.DryRun
+	}
+
+	sc.CAPrepareThreshold = c.Server.CAPrepareThreshold
+	if sc.CAPrepareThreshold == 0 {
+		sc.CAPrepareThreshold = ca.DefaultPrepareThreshold
+	}
+	sc.CAActivateThreshold = c.Server.CAActivateThreshold
+	if sc.CAActivateThreshold == 0 {
+		sc.CAActivateThreshold = ca.DefaultActivateThreshold
+	}
+	if sc.CAPrepareThreshold <= 0 || sc.CAPrepareThreshold >= sc.CAActivateThreshold || sc.CAActivateThreshold >= 1 {
+		return nil, fmt.Errorf("ca_prepare_threshold and ca_activate_threshold must satisfy 0 < ca_prepare_threshold < ca_activate_threshold < 1: got %v and %v", sc.CAPrepareThreshold, sc.CAActivateThreshold)
+	}
+
+	if !hasExpectedTTLs(sc.CATTL, sc.SVIDTTL, sc.CAActivateThreshold) {
+		factor := math.Round(1 / (1 - sc.CAActivateThreshold))
+		sc.Log.Warnf("The configured SVID TTL cannot be guaranteed in all cases - SVIDs with shorter TTLs may be issued if the signing key is expiring soon. Set a CA TTL of at least %[1]vx or reduce SVID TTL below %[1]vx to avoid issuing SVIDs with a smaller TTL than specified", factor)
 	}
 
 	if c.Server.CAKeyType != "" {
@@ -441,8 +957,76 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		}
 	}
 
+	if c.Server.JWTKeyType != "" {
+		sc.JWTKeyType, err = jwtKeyTypeFromString(c.Server.JWTKeyType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Server.JWTSigningAlgorithm != "" {
+		jwtKeyType := sc.JWTKeyType
+		if jwtKeyType == keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+			jwtKeyType = sc.CAKeyType
+		}
+		sc.JWTSigningAlgorithm, err = jwtSigningAlgorithmFromString(c.Server.JWTSigningAlgorithm, jwtKeyType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	sc.JWTIssuer = c.Server.JWTIssuer
 
+	if oidc := c.Server.OIDCDiscovery; oidc != nil {
+		port := defaultOIDCDiscoveryPort
+		if oidc.Port != 0 {
+			port = oidc.Port
+		}
+		sc.OIDCDiscovery = &oidcendpoint.EndpointConfig{
+			Address: &net.TCPAddr{
+				IP:   net.ParseIP(oidc.Address),
+				Port: port,
+			},
+			Domain: oidc.Domain,
+		}
+		if acme := oidc.ACME; acme != nil {
+			sc.OIDCDiscovery.ACME = &bundle.ACMEConfig{
+				DirectoryURL: acme.DirectoryURL,
+				DomainName:   oidc.Domain,
+				CacheDir:     filepath.Join(sc.DataDir, "oidc-acme"),
+				Email:        acme.Email,
+				ToSAccepted:  acme.ToSAccepted,
+			}
+		}
+		if issuer := "https://" + oidc.Domain; sc.JWTIssuer != issuer {
+			sc.Log.Warnf("The jwt_issuer configurable should be set to %q so JWT-SVIDs can be validated through the OIDC discovery endpoint", issuer)
+		}
+	}
+
+	if len(c.Server.EntryDefaults) > 0 {
+		sc.EntryDefaults, err = entryDefaultsFromConfig(c.Server.EntryDefaults)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.Server.CADNSNamePolicy) > 0 {
+		sc.DNSNamePolicy, err = dnsNamePolicyFromConfig(c.Server.CADNSNamePolicy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Server.EntryPolicy != nil {
+		sc.EntryPolicy, err = entrypolicy.New(context.Background(), entrypolicy.Config{
+			PolicyPaths: c.Server.EntryPolicy.PolicyPaths,
+			Query:       c.Server.EntryPolicy.Query,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry_policy configuration: %v", err)
+		}
+	}
+
 	if subject := c.Server.CASubject; subject != nil {
 		sc.CASubject = pkix.Name{
 			Organization: subject.Organization,
@@ -458,6 +1042,19 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		sc.CASubject = defaultCASubject
 	}
 
+	if c.Server.CAMaxPathLen != nil && *c.Server.CAMaxPathLen < 0 {
+		return nil, fmt.Errorf("ca_max_path_len must be non-negative: %d", *c.Server.CAMaxPathLen)
+	}
+	sc.CAMaxPathLen = c.Server.CAMaxPathLen
+	sc.CAFullUpstreamChain = c.Server.CAFullUpstreamChain
+
+	for _, prefix := range c.Server.IssuanceMetrics {
+		if !strings.HasPrefix(prefix, sc.TrustDomain.String()+"/") {
+			return nil, fmt.Errorf("issuance_metrics_prefixes: %q is not a SPIFFE ID prefix in trust domain %q", prefix, sc.TrustDomain.Host)
+		}
+	}
+	sc.IssuanceMetricsPrefixes = c.Server.IssuanceMetrics
+
 	sc.PluginConfigs = *c.Plugins
 	sc.Telemetry = c.Telemetry
 	sc.HealthChecks = c.HealthChecks
@@ -499,6 +1096,22 @@ func validateConfig(c *Config) error {
 		return errors.New("plugins section must be configured")
 	}
 
+	if oidc := c.Server.OIDCDiscovery; oidc != nil {
+		if oidc.Domain == "" {
+			return errors.New("oidc_discovery.domain must be configured")
+		}
+
+		if acme := oidc.ACME; acme != nil {
+			if acme.DomainName != "" && acme.DomainName != oidc.Domain {
+				return errors.New("oidc_discovery.acme.domain_name must match oidc_discovery.domain")
+			}
+
+			if acme.Email == "" {
+				return errors.New("oidc_discovery.acme.email must be configured")
+			}
+		}
+	}
+
 	if c.Server.Federation != nil {
 		// TODO: Remove this check once the deprecated experimental federation options are removed.
 		if isDeprecatedFederationConfigUsed(c.Server.Experimental) {
@@ -549,6 +1162,25 @@ func validateConfig(c *Config) error {
 	return nil
 }
 
+// bundleEndpointUsesWebPKI returns whether the bundle endpoint of the trust
+// domain is authenticated with Web PKI (https_web profile) instead of SPIFFE
+// authentication (https_spiffe profile).
+func bundleEndpointUsesWebPKI(trustDomain string, c federatesWithBundleEndpointConfig) (bool, error) {
+	switch c.Profile {
+	case "":
+		return c.UseWebPKI, nil
+	case bundleEndpointProfileHTTPSWeb:
+		return true, nil
+	case bundleEndpointProfileHTTPSSPIFFE:
+		if c.UseWebPKI {
+			return false, fmt.Errorf("federation.federates_with[%q].bundle_endpoint.use_web_pki cannot be set with the %q profile", trustDomain, c.Profile)
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("federation.federates_with[%q].bundle_endpoint.profile %q is not supported; expected %q or %q", trustDomain, c.Profile, bundleEndpointProfileHTTPSWeb, bundleEndpointProfileHTTPSSPIFFE)
+	}
+}
+
 // TODO: Remove this function once the deprecated experimental federation options are removed.
 func isDeprecatedFederationConfigUsed(ec experimentalConfig) bool {
 	return ec.DeprecatedBundleEndpointACME != nil ||
@@ -594,6 +1226,27 @@ func warnOnDeprecatedConfig(c *Config, l logrus.FieldLogger) {
 	}
 }
 
+// serverUnusedKeys returns the unused keys of the server block. The HCL
+// decoder reports the labels of the ca_dns_name_policy, entry_defaults and
+// role_bindings blocks as unused keys of the server block, so they are
+// filtered out.
+func serverUnusedKeys(c *serverConfig) []string {
+	var unusedKeys []string
+	for _, key := range c.UnusedKeys {
+		if _, ok := c.CADNSNamePolicy[key]; ok {
+			continue
+		}
+		if _, ok := c.EntryDefaults[key]; ok {
+			continue
+		}
+		if _, ok := c.RoleBindings[key]; ok {
+			continue
+		}
+		unusedKeys = append(unusedKeys, key)
+	}
+	return unusedKeys
+}
+
 func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 	detectedUnknown := func(section string, keys []string) {
 		l.WithFields(logrus.Fields{
@@ -608,18 +1261,74 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 	}
 
 	if c.Server != nil {
-		if len(c.Server.UnusedKeys) != 0 {
-			detectedUnknown("server", c.Server.UnusedKeys)
+		if unusedKeys := serverUnusedKeys(c.Server); len(unusedKeys) != 0 {
+			detectedUnknown("server", unusedKeys)
+		}
+
+		if aa := c.Server.AdminAPI; aa != nil && len(aa.UnusedKeys) != 0 {
+			detectedUnknown("admin_api", aa.UnusedKeys)
+		}
+
+		if al := c.Server.AuditLog; al != nil && len(al.UnusedKeys) != 0 {
+			detectedUnknown("audit_log", al.UnusedKeys)
 		}
 
 		if cs := c.Server.CASubject; cs != nil && len(cs.UnusedKeys) != 0 {
 			detectedUnknown("ca_subject", cs.UnusedKeys)
 		}
 
+		if sr := c.Server.SVIDRotation; sr != nil && len(sr.UnusedKeys) != 0 {
+			detectedUnknown("svid_rotation", sr.UnusedKeys)
+		}
+
+		if gt := c.Server.GRPCTuning; gt != nil && len(gt.UnusedKeys) != 0 {
+			detectedUnknown("grpc_tuning", gt.UnusedKeys)
+		}
+
+		for k, v := range c.Server.CADNSNamePolicy {
+			if len(v.UnusedKeys) != 0 {
+				detectedUnknown(fmt.Sprintf("ca_dns_name_policy %q", k), v.UnusedKeys)
+			}
+		}
+
+		for k, v := range c.Server.EntryDefaults {
+			if len(v.UnusedKeys) != 0 {
+				detectedUnknown(fmt.Sprintf("entry_defaults %q", k), v.UnusedKeys)
+			}
+		}
+
+		if ep := c.Server.EntryPolicy; ep != nil && len(ep.UnusedKeys) != 0 {
+			detectedUnknown("entry_policy", ep.UnusedKeys)
+		}
+
+		if na := c.Server.NodeAttestation; na != nil && len(na.UnusedKeys) != 0 {
+			detectedUnknown("node_attestation", na.UnusedKeys)
+		}
+
+		if p := c.Server.Pruning; p != nil && len(p.UnusedKeys) != 0 {
+			detectedUnknown("pruning", p.UnusedKeys)
+		}
+
 		if rl := c.Server.RateLimit; len(rl.UnusedKeys) != 0 {
 			detectedUnknown("ratelimit", rl.UnusedKeys)
 		}
 
+		for k, v := range c.Server.RoleBindings {
+			if len(v.UnusedKeys) != 0 {
+				detectedUnknown(fmt.Sprintf("role_bindings %q", k), v.UnusedKeys)
+			}
+		}
+
+		if oidc := c.Server.OIDCDiscovery; oidc != nil {
+			if len(oidc.UnusedKeys) != 0 {
+				detectedUnknown("oidc_discovery", oidc.UnusedKeys)
+			}
+
+			if acme := oidc.ACME; acme != nil && len(acme.UnusedKeys) != 0 {
+				detectedUnknown("oidc_discovery ACME", acme.UnusedKeys)
+			}
+		}
+
 		// TODO: Re-enable unused key detection for experimental config. See
 		// https://github.com/spiffe/spire/issues/1101 for more information
 		//
@@ -686,6 +1395,10 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 		detectedUnknown("InMem", p.UnusedKeys)
 	}
 
+	if p := c.Telemetry.OTLP; p != nil && len(p.UnusedKeys) != 0 {
+		detectedUnknown("OTLP", p.UnusedKeys)
+	}
+
 	if len(c.HealthChecks.UnusedKeys) != 0 {
 		detectedUnknown("health check", c.HealthChecks.UnusedKeys)
 	}
@@ -706,6 +1419,158 @@ func defaultConfig() *Config {
 	}
 }
 
+func entryDefaultsFromConfig(c map[string]entryDefaultsConfig) (*entrydefaults.Defaults, error) {
+	var rules []entrydefaults.Rule
+	for prefix, config := range c {
+		rule := entrydefaults.Rule{
+			ParentIDPrefix:   prefix,
+			FederatesWith:    config.FederatesWith,
+			DNSNameTemplates: config.DNSNameTemplates,
+		}
+		if config.TTL != "" {
+			ttl, err := time.ParseDuration(config.TTL)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse entry_defaults[%q] ttl %q: %v", prefix, config.TTL, err)
+			}
+			rule.TTL = int32(ttl / time.Second)
+		}
+		rules = append(rules, rule)
+	}
+
+	defaults, err := entrydefaults.New(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid entry_defaults configuration: %v", err)
+	}
+	return defaults, nil
+}
+
+func dnsNamePolicyFromConfig(c map[string]dnsNamePolicyConfig) (*dnspolicy.Policy, error) {
+	var rules []dnspolicy.Rule
+	for prefix, config := range c {
+		rules = append(rules, dnspolicy.Rule{
+			SPIFFEIDPrefix:  prefix,
+			AllowedSuffixes: config.AllowedSuffixes,
+		})
+	}
+
+	policy, err := dnspolicy.New(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ca_dns_name_policy configuration: %v", err)
+	}
+	return policy, nil
+}
+
+func rateLimitFromConfig(c rateLimitConfig) (endpoints.RateLimitConfig, error) {
+	if c.AttestationPerIP < 0 || c.SigningPerIP < 0 || c.SigningPerAgent < 0 || c.JWTSigningPerIP < 0 || c.JWTSigningPerAgent < 0 {
+		return endpoints.RateLimitConfig{}, errors.New("ratelimit values cannot be negative")
+	}
+
+	attestation := defaultRateLimitAttestation
+	if c.Attestation != nil {
+		attestation = *c.Attestation
+	}
+
+	return endpoints.RateLimitConfig{
+		Attestation:        attestation,
+		AttestationPerIP:   c.AttestationPerIP,
+		SigningPerIP:       c.SigningPerIP,
+		SigningPerAgent:    c.SigningPerAgent,
+		JWTSigningPerIP:    c.JWTSigningPerIP,
+		JWTSigningPerAgent: c.JWTSigningPerAgent,
+	}, nil
+}
+
+// adminAPIFromConfig returns the admin listener configuration. The listener
+// binds to the server bind address unless an address is configured.
+func adminAPIFromConfig(c *adminAPIConfig, serverIP net.IP) (*endpoints.AdminAPIConfig, error) {
+	if c.BindPort == 0 {
+		return nil, errors.New("admin_api bind_port must be configured")
+	}
+	if len(c.AuthorizedIDs) == 0 {
+		return nil, errors.New("admin_api authorized_ids must be configured")
+	}
+
+	ip := serverIP
+	if c.BindAddress != "" {
+		ip = net.ParseIP(c.BindAddress)
+		if ip == nil {
+			return nil, fmt.Errorf("could not parse admin_api bind_address %q", c.BindAddress)
+		}
+	}
+
+	config := &endpoints.AdminAPIConfig{
+		Address: &net.TCPAddr{
+			IP:   ip,
+			Port: c.BindPort,
+		},
+	}
+	for _, s := range c.AuthorizedIDs {
+		id, err := spiffeid.FromString(s)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse admin_api authorized ID %q: %v", s, err)
+		}
+		config.AuthorizedIDs = append(config.AuthorizedIDs, id)
+	}
+	return config, nil
+}
+
+func roleBindingsFromConfig(c map[string]roleBindingConfig) ([]middleware.RoleBinding, error) {
+	var bindings []middleware.RoleBinding
+	for name, config := range c {
+		role, err := middleware.ParseRole(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role_bindings configuration: %v", err)
+		}
+		if len(config.SPIFFEIDs) == 0 && len(config.Selectors) == 0 {
+			return nil, fmt.Errorf("role_bindings[%q] must configure spiffe_ids or selectors", name)
+		}
+
+		binding := middleware.RoleBinding{Role: role}
+		for _, s := range config.SPIFFEIDs {
+			id, err := spiffeid.FromString(s)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse role_bindings[%q] SPIFFE ID %q: %v", name, s, err)
+			}
+			binding.SPIFFEIDs = append(binding.SPIFFEIDs, id)
+		}
+		for _, s := range config.Selectors {
+			parts := strings.SplitN(s, ":", 2)
+			if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+				return nil, fmt.Errorf("role_bindings[%q] selector %q must be formatted as type:value", name, s)
+			}
+			binding.Selectors = append(binding.Selectors, &types.Selector{
+				Type:  parts[0],
+				Value: parts[1],
+			})
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
+
+func newAuditLogger(c *auditLogConfig) (*log.Logger, error) {
+	if c.Path == "" {
+		return nil, errors.New("audit_log path must be configured")
+	}
+	if c.MaxSizeMB < 0 {
+		return nil, fmt.Errorf("audit_log max_size_mb %d is invalid: must not be negative", c.MaxSizeMB)
+	}
+
+	format := c.Format
+	if format == "" {
+		format = defaultAuditLogFormat
+	}
+	maxBackups := defaultAuditLogMaxBackups
+	if c.MaxBackups != nil {
+		maxBackups = *c.MaxBackups
+	}
+
+	return log.NewLogger(
+		log.WithFormat(format),
+		log.WithRotatingOutputFile(c.Path, int64(c.MaxSizeMB)*1024*1024, maxBackups),
+	)
+}
+
 func caKeyTypeFromString(s string) (keymanager.KeyType, error) {
 	switch strings.ToLower(s) {
 	case "rsa-2048":
@@ -721,8 +1586,48 @@ func caKeyTypeFromString(s string) (keymanager.KeyType, error) {
 	}
 }
 
-// hasExpectedTTLs is a function that checks if ca_ttl is less than default_svid_ttl * 6. SPIRE Server prepares a new CA certificate when 1/2 of the CA lifetime has elapsed in order to give ample time for the new trust bundle to propagate. However, it does not start using it until 5/6th of the CA lifetime. So its normal for an SVID TTL to be capped to 1/6th of the CA TTL. In order to get the expected lifetime on SVID TTLs, the CA TTL should be 6x.
-func hasExpectedTTLs(caTTL, svidTTL time.Duration) bool {
+func jwtKeyTypeFromString(s string) (keymanager.KeyType, error) {
+	switch strings.ToLower(s) {
+	case "rsa-2048":
+		return keymanager.KeyType_RSA_2048, nil
+	case "rsa-4096":
+		return keymanager.KeyType_RSA_4096, nil
+	case "ec-p256":
+		return keymanager.KeyType_EC_P256, nil
+	case "ec-p384":
+		return keymanager.KeyType_EC_P384, nil
+	case "ed25519":
+		return keymanager.KeyType_ED25519, nil
+	default:
+		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("JWT key type %q is unknown; must be one of [rsa-2048, rsa-4096, ec-p256, ec-p384, ed25519]", s)
+	}
+}
+
+// jwtSigningAlgorithmFromString returns the JWT-SVID signing algorithm, making
+// sure that it can be used with the given JWT key type.
+func jwtSigningAlgorithmFromString(s string, keyType keymanager.KeyType) (string, error) {
+	var algs []string
+	switch keyType {
+	case keymanager.KeyType_RSA_2048, keymanager.KeyType_RSA_4096:
+		algs = []string{"RS256", "PS256"}
+	case keymanager.KeyType_EC_P384:
+		algs = []string{"ES384"}
+	case keymanager.KeyType_ED25519:
+		algs = []string{"EdDSA"}
+	default:
+		// EC P-256 is the default key type
+		algs = []string{"ES256"}
+	}
+	for _, alg := range algs {
+		if strings.EqualFold(s, alg) {
+			return alg, nil
+		}
+	}
+	return "", fmt.Errorf("JWT signing algorithm %q is not supported for the JWT key type; must be one of %v", s, algs)
+}
+
+// hasExpectedTTLs is a function that checks if the lifetime remaining on a CA once it is activated is enough to cover default_svid_ttl. SPIRE Server prepares a new CA certificate when ca_prepare_threshold (by default 1/2) of the CA lifetime has elapsed in order to give ample time for the new trust bundle to propagate. However, it does not start using it until ca_activate_threshold (by default 5/6th) of the CA lifetime. So with the defaults its normal for an SVID TTL to be capped to 1/6th of the CA TTL. In order to get the expected lifetime on SVID TTLs, the CA TTL should be 6x.
+func hasExpectedTTLs(caTTL, svidTTL time.Duration, activateThreshold float64) bool {
 	if caTTL == 0 {
 		caTTL = ca.DefaultCATTL
 	}
@@ -730,7 +1635,11 @@ func hasExpectedTTLs(caTTL, svidTTL time.Duration) bool {
 		svidTTL = ca.DefaultX509SVIDTTL
 	}
 
-	thresh := ca.KeyActivationThreshold(time.Now(), time.Now().Add(caTTL))
+	if activateThreshold == 0 {
+		activateThreshold = ca.DefaultActivateThreshold
+	}
+
+	thresh := ca.KeyActivationThreshold(time.Now(), time.Now().Add(caTTL), activateThreshold)
 	return caTTL-time.Until(thresh) >= svidTTL
 }
 
@@ -3,7 +3,10 @@ package run
 import (
 	"bytes"
 	"crypto/x509/pkix"
+	"errors"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
@@ -12,11 +15,23 @@ import (
 	"github.com/hashicorp/hcl/hcl/printer"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/endpoints"
+	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/nodeattestation"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/server/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -586,253 +601,1244 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, &logrus.TextFormatter{}, l.Formatter)
 			},
 		},
+		{
+			msg: "log_subsystem_levels are applied",
+			input: func(c *Config) {
+				c.Server.LogLevel = "INFO"
+				c.Server.LogSubsystemLevels = map[string]string{"ca": "DEBUG"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				l := c.Log.(*log.Logger)
+				require.Equal(t, logrus.DebugLevel, l.Level)
+				require.Equal(t, log.Levels{
+					Level:      logrus.InfoLevel,
+					Subsystems: map[string]logrus.Level{"ca": logrus.DebugLevel},
+				}, l.GetLevels())
+				require.Equal(t, l, c.LogLevels)
+			},
+		},
+		{
+			msg:         "invalid log_subsystem_levels returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.LogSubsystemLevels = map[string]string{"ca": "not-a-valid-level"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg:         "invalid log_level returns an error",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.LogLevel = "not-a-valid-level"
+				c.Server.LogLevel = "not-a-valid-level"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "invalid log_format returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.LogFormat = "not-a-valid-format"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "allow_agentless_node_attestors is configured correctly",
+			input: func(c *Config) {
+				c.Server.Experimental.AllowAgentlessNodeAttestors = true
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.Experimental.AllowAgentlessNodeAttestors)
+			},
+		},
+		{
+			msg: "bundle endpoint is parsed and configured correctly",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address: "192.168.1.1",
+						Port:    1337,
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "192.168.1.1", c.Federation.BundleEndpoint.Address.IP.String())
+				require.Equal(t, 1337, c.Federation.BundleEndpoint.Address.Port)
+			},
+		},
+		{
+			msg: "bundle federates with section is parsed and configured correctly",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address:   "192.168.1.1",
+								Port:      1337,
+								SpiffeID:  "spiffe://domain1.test/bundle/endpoint",
+								UseWebPKI: false,
+							},
+						},
+						"domain2.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address:   "192.168.1.1",
+								Port:      1337,
+								UseWebPKI: true,
+							},
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, map[string]bundleClient.TrustDomainConfig{
+					"domain1.test": {
+						EndpointAddress:  "192.168.1.1:1337",
+						EndpointSpiffeID: "spiffe://domain1.test/bundle/endpoint",
+						UseWebPKI:        false,
+					},
+					"domain2.test": {
+						EndpointAddress: "192.168.1.1:1337",
+						UseWebPKI:       true,
+					},
+				}, c.Federation.FederatesWith)
+			},
+		},
+		{
+			msg:         "bundle federates with section uses Web PKI and SpiffeID",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address:   "192.168.1.1",
+								SpiffeID:  "spiffe://domain1.test/bundle/endpoint",
+								Port:      1337,
+								UseWebPKI: true,
+							},
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "bundle federates with section is configured with profiles",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address: "192.168.1.1",
+								Profile: "https_web",
+							},
+						},
+						"domain2.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address:  "192.168.1.2",
+								Port:     8443,
+								SpiffeID: "spiffe://domain2.test/bundle/endpoint",
+								Profile:  "https_spiffe",
+							},
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, map[string]bundleClient.TrustDomainConfig{
+					"domain1.test": {
+						EndpointAddress: "192.168.1.1:443",
+						UseWebPKI:       true,
+					},
+					"domain2.test": {
+						EndpointAddress:  "192.168.1.2:8443",
+						EndpointSpiffeID: "spiffe://domain2.test/bundle/endpoint",
+						UseWebPKI:        false,
+					},
+				}, c.Federation.FederatesWith)
+			},
+		},
+		{
+			msg:         "bundle federates with section uses an unknown profile",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address: "192.168.1.1",
+								Profile: "https_unknown",
+							},
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "bundle federates with section uses the https_spiffe profile and Web PKI",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address:   "192.168.1.1",
+								Profile:   "https_spiffe",
+								UseWebPKI: true,
+							},
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "bundle federates with section uses the https_web profile and SpiffeID",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address:  "192.168.1.1",
+								Profile:  "https_web",
+								SpiffeID: "spiffe://domain1.test/bundle/endpoint",
+							},
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "default_svid_ttl is correctly parsed",
+			input: func(c *Config) {
+				c.Server.DefaultSVIDTTL = "1m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, time.Minute, c.SVIDTTL)
+			},
+		},
+		{
+			msg:         "invalid default_svid_ttl returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.DefaultSVIDTTL = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "rsa-2048 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "rsa-2048"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_2048, c.CAKeyType)
+			},
+		},
+		{
+			msg: "rsa-4096 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "rsa-4096"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_4096, c.CAKeyType)
+			},
+		},
+		{
+			msg: "ec-p256 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p256"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P256, c.CAKeyType)
+			},
+		},
+		{
+			msg: "ec-p384 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p384"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P384, c.CAKeyType)
+			},
+		},
+		{
+			msg:         "unsupported ca_key_type is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CAKeyType = "rsa-1024"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ed25519 jwt_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.JWTKeyType = "ed25519"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_ED25519, c.JWTKeyType)
+				require.Equal(t, keymanager.KeyType_UNSPECIFIED_KEY_TYPE, c.CAKeyType)
+			},
+		},
+		{
+			msg:         "unsupported jwt_key_type is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.JWTKeyType = "rsa-1024"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "jwt_signing_algorithm is correctly parsed",
+			input: func(c *Config) {
+				c.Server.JWTKeyType = "rsa-2048"
+				c.Server.JWTSigningAlgorithm = "ps256"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "PS256", c.JWTSigningAlgorithm)
+			},
+		},
+		{
+			msg: "jwt_signing_algorithm is checked against ca_key_type when jwt_key_type is unset",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p384"
+				c.Server.JWTSigningAlgorithm = "ES384"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "ES384", c.JWTSigningAlgorithm)
+			},
+		},
+		{
+			msg:         "jwt_signing_algorithm incompatible with the key type is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.JWTKeyType = "ec-p256"
+				c.Server.JWTSigningAlgorithm = "EdDSA"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ca_ttl is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CATTL = "1h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, time.Hour, c.CATTL)
+			},
+		},
+		{
+			msg:         "invalid ca_ttl returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CATTL = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "node_resolver_refresh_interval is correctly parsed",
+			input: func(c *Config) {
+				c.Server.NodeResolverRefresh = "5m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 5*time.Minute, c.NodeResolverRefreshInterval)
+			},
+		},
+		{
+			msg:         "invalid node_resolver_refresh_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.NodeResolverRefresh = "-5m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "cache_reload_interval is correctly parsed",
+			input: func(c *Config) {
+				c.Server.Experimental.CacheReloadInterval = "1m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, time.Minute, c.Experimental.CacheReloadInterval)
+			},
+		},
+		{
+			msg:         "invalid cache_reload_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.CacheReloadInterval = "0s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "drain_timeout is correctly parsed",
+			input: func(c *Config) {
+				c.Server.DrainTimeout = "45s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 45*time.Second, c.DrainTimeout)
+			},
+		},
+		{
+			msg:         "invalid drain_timeout returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.DrainTimeout = "-1s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "clock_skew_tolerance is correctly parsed",
+			input: func(c *Config) {
+				c.Server.ClockSkewTolerance = "30s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 30*time.Second, c.ClockSkewTolerance)
+			},
+		},
+		{
+			msg:         "negative clock_skew_tolerance returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.ClockSkewTolerance = "-1s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "svid_backdate is correctly parsed",
+			input: func(c *Config) {
+				c.Server.SVIDBackdate = "1m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, time.Minute, c.SVIDBackdate)
+			},
+		},
+		{
+			msg:         "invalid svid_backdate returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.SVIDBackdate = "0s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "grpc_tuning is correctly parsed",
+			input: func(c *Config) {
+				c.Server.GRPCTuning = &grpcutil.ServerConfig{
+					KeepaliveTime:          "1m",
+					MinClientKeepaliveTime: "30s",
+					MaxConnectionAge:       "10m",
+					InitialWindowSize:      1 << 20,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, grpcutil.ServerTuning{
+					KeepaliveTime:          time.Minute,
+					MinClientKeepaliveTime: 30 * time.Second,
+					MaxConnectionAge:       10 * time.Minute,
+					InitialWindowSize:      1 << 20,
+				}, c.GRPCTuning)
+			},
+		},
+		{
+			msg:         "invalid grpc_tuning returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.GRPCTuning = &grpcutil.ServerConfig{
+					MaxConnectionAge: "-1m",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "node_attestation is correctly parsed",
+			input: func(c *Config) {
+				c.Server.NodeAttestation = &nodeAttestationConfig{
+					ChallengeRoundTimeout:    "10s",
+					MaxChallengeRounds:       4,
+					MaxChallengeSize:         1024,
+					MaxChallengeResponseSize: 2048,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, nodeattestation.Limits{
+					RoundTimeout:     10 * time.Second,
+					MaxRounds:        4,
+					MaxChallengeSize: 1024,
+					MaxResponseSize:  2048,
+				}, c.NodeAttestationLimits)
+			},
+		},
+		{
+			msg:         "node_attestation with an unparseable round timeout returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.NodeAttestation = &nodeAttestationConfig{
+					ChallengeRoundTimeout: "forever",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "node_attestation with a negative limit returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.NodeAttestation = &nodeAttestationConfig{
+					MaxChallengeRounds: -1,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "notifier_reconcile_interval is correctly parsed",
+			input: func(c *Config) {
+				c.Server.NotifierReconcile = "1h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, time.Hour, c.NotifierReconcileInterval)
+			},
+		},
+		{
+			msg:         "invalid notifier_reconcile_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.NotifierReconcile = "0s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "trust_domain_aliases is correctly parsed",
+			input: func(c *Config) {
+				c.Server.TrustDomainAliases = []string{"old.example.org"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, []url.URL{{Scheme: "spiffe", Host: "old.example.org"}}, c.TrustDomainAliases)
+			},
+		},
+		{
+			msg:         "trust_domain_aliases with the server trust domain returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.TrustDomain = "example.org"
+				c.Server.TrustDomainAliases = []string{"example.org"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "trust_domain_aliases with a federated trust domain returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.TrustDomainAliases = []string{"domain1.test"}
+				c.Server.Federation = &federationConfig{
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": {
+							BundleEndpoint: federatesWithBundleEndpointConfig{
+								Address: "192.168.1.1",
+							},
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "svid_rotation is correctly parsed",
+			input: func(c *Config) {
+				c.Server.SVIDRotation = &rotationutil.Config{Before: "10m", Jitter: 0.1}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, rotationutil.Threshold{Before: 10 * time.Minute, Jitter: 0.1}, c.SVIDRotation)
+			},
+		},
+		{
+			msg:         "invalid svid_rotation returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.SVIDRotation = &rotationutil.Config{Before: "moo"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "pruning is correctly parsed",
+			input: func(c *Config) {
+				c.Server.Pruning = &pruningConfig{
+					RetainExpiredEntries: true,
+					ExpiredAgentsAfter:   "168h",
+					OrphanedEntries:      true,
+					DryRun:               true,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, registration.PruningConfig{
+					RetainExpiredEntries: true,
+					ExpiredAgentsAfter:   168 * time.Hour,
+					OrphanedEntries:      true,
+					DryRun:               true,
+				}, c.Pruning)
+			},
+		},
+		{
+			msg: "pruning is disabled by default",
+			input: func(c *Config) {
+				c.Server.Pruning = nil
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, registration.PruningConfig{}, c.Pruning)
+			},
+		},
+		{
+			msg:         "invalid pruning expired_agents_after returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Pruning = &pruningConfig{
+					ExpiredAgentsAfter: "-1h",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ca_max_path_len is unset by default",
+			input: func(c *Config) {
+				c.Server.CAMaxPathLen = nil
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c.CAMaxPathLen)
+			},
+		},
+		{
+			msg: "ca_max_path_len is correctly set",
+			input: func(c *Config) {
+				maxPathLen := 0
+				c.Server.CAMaxPathLen = &maxPathLen
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.NotNil(t, c.CAMaxPathLen)
+				require.Equal(t, 0, *c.CAMaxPathLen)
+			},
+		},
+		{
+			msg:         "negative ca_max_path_len returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				maxPathLen := -1
+				c.Server.CAMaxPathLen = &maxPathLen
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "issuance_metrics_prefixes is correctly set",
+			input: func(c *Config) {
+				c.Server.IssuanceMetrics = []string{"spiffe://example.org/ns/"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, []string{"spiffe://example.org/ns/"}, c.IssuanceMetricsPrefixes)
+			},
+		},
+		{
+			msg:         "issuance_metrics_prefixes outside of the trust domain returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.IssuanceMetrics = []string{"spiffe://other.org/ns/"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ca_full_upstream_chain is correctly set",
+			input: func(c *Config) {
+				c.Server.CAFullUpstreamChain = true
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.CAFullUpstreamChain)
+			},
+		},
+		{
+			msg: "ca thresholds are defaulted when unset",
+			input: func(c *Config) {
+				c.Server.CAPrepareThreshold = 0
+				c.Server.CAActivateThreshold = 0
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, ca.DefaultPrepareThreshold, c.CAPrepareThreshold)
+				require.Equal(t, ca.DefaultActivateThreshold, c.CAActivateThreshold)
+			},
+		},
+		{
+			msg: "ca thresholds are correctly set",
+			input: func(c *Config) {
+				c.Server.CAPrepareThreshold = 0.25
+				c.Server.CAActivateThreshold = 0.75
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 0.25, c.CAPrepareThreshold)
+				require.Equal(t, 0.75, c.CAActivateThreshold)
+			},
+		},
+		{
+			msg:         "ca_prepare_threshold after ca_activate_threshold returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CAPrepareThreshold = 0.9
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "negative ca_prepare_threshold returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CAPrepareThreshold = -0.5
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "ca_activate_threshold not below one returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CAActivateThreshold = 1
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ca_subject is defaulted when unset",
+			input: func(c *Config) {
+				c.Server.CASubject = nil
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, defaultCASubject, c.CASubject)
+			},
+		},
+		{
+			msg: "ca_subject is defaulted when set but empty",
+			input: func(c *Config) {
+				c.Server.CASubject = &caSubjectConfig{}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, defaultCASubject, c.CASubject)
+			},
+		},
+		{
+			msg: "ca_subject is overridable",
+			input: func(c *Config) {
+				c.Server.CASubject = &caSubjectConfig{
+					Organization: []string{"foo"},
+					Country:      []string{"us"},
+					CommonName:   "bar",
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, pkix.Name{
+					Organization: []string{"foo"},
+					Country:      []string{"us"},
+					CommonName:   "bar",
+				}, c.CASubject)
+			},
+		},
+		{
+			msg: "attestation rate limit is on by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.RateLimit.Attestation)
+			},
+		},
+		{
+			msg: "attestation rate limits can be explicitly disabled",
+			input: func(c *Config) {
+				value := false
+				c.Server.RateLimit.Attestation = &value
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.False(t, c.RateLimit.Attestation)
+			},
+		},
+		{
+			msg: "entry_defaults are correctly parsed",
+			input: func(c *Config) {
+				c.Server.EntryDefaults = map[string]entryDefaultsConfig{
+					"spiffe://example.org/node": {
+						TTL:              "1m",
+						FederatesWith:    []string{"spiffe://other.org"},
+						DNSNameTemplates: []string{"{{ .TrustDomain }}"},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.NotNil(t, c.EntryDefaults)
+				entry := &common.RegistrationEntry{
+					ParentId: "spiffe://example.org/node/1",
+					SpiffeId: "spiffe://example.org/workload",
+				}
+				require.NoError(t, c.EntryDefaults.Apply(entry))
+				require.Equal(t, int32(60), entry.Ttl)
+				require.Equal(t, []string{"spiffe://other.org"}, entry.FederatesWith)
+				require.Equal(t, []string{"example.org"}, entry.DnsNames)
+			},
+		},
+		{
+			msg:         "invalid entry_defaults ttl returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.EntryDefaults = map[string]entryDefaultsConfig{
+					"spiffe://example.org/node": {
+						TTL: "abc",
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ca_dns_name_policy is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CADNSNamePolicy = map[string]dnsNamePolicyConfig{
+					"spiffe://example.org/web/": {
+						AllowedSuffixes: []string{"web.example.org"},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.NotNil(t, c.DNSNamePolicy)
+				require.NoError(t, c.DNSNamePolicy.Check("spiffe://example.org/web/frontend", []string{"api.web.example.org"}))
+				require.Error(t, c.DNSNamePolicy.Check("spiffe://example.org/db", []string{"api.web.example.org"}))
+			},
+		},
+		{
+			msg:         "invalid ca_dns_name_policy returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CADNSNamePolicy = map[string]dnsNamePolicyConfig{
+					"example.org/web/": {
+						AllowedSuffixes: []string{"web.example.org"},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "audit log is disabled by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c.AuditLog)
+			},
+		},
+		{
+			msg: "audit log is written to the configured path",
+			input: func(c *Config) {
+				c.Server.AuditLog = &auditLogConfig{
+					Path:      filepath.Join(spiretest.TempDir(t), "audit.log"),
+					MaxSizeMB: 10,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.NotNil(t, c.AuditLog)
+				logger, ok := c.AuditLog.(*log.Logger)
+				require.True(t, ok)
+				require.IsType(t, &logrus.JSONFormatter{}, logger.Formatter)
+				require.NoError(t, logger.Close())
+			},
+		},
+		{
+			msg:         "audit log without a path returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.AuditLog = &auditLogConfig{}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "audit log with a negative max size returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.AuditLog = &auditLogConfig{
+					Path:      filepath.Join(spiretest.TempDir(t), "audit.log"),
+					MaxSizeMB: -1,
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
 			},
 		},
 		{
-			msg:         "invalid log_format returns an error",
+			msg:         "audit log with an unknown format returns an error",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.LogFormat = "not-a-valid-format"
+				c.Server.AuditLog = &auditLogConfig{
+					Path:   filepath.Join(spiretest.TempDir(t), "audit.log"),
+					Format: "xml",
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "allow_agentless_node_attestors is configured correctly",
+			msg: "entry policy is loaded from the configured paths",
 			input: func(c *Config) {
-				c.Server.Experimental.AllowAgentlessNodeAttestors = true
+				path := filepath.Join(spiretest.TempDir(t), "policy.rego")
+				require.NoError(t, ioutil.WriteFile(path, []byte("package spire.entry\n\ndeny[msg] {\n\tinput.entry.admin\n\tmsg := \"no admin entries\"\n}\n"), 0600))
+				c.Server.EntryPolicy = &entryPolicyConfig{
+					PolicyPaths: []string{path},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.True(t, c.Experimental.AllowAgentlessNodeAttestors)
+				require.NotNil(t, c.EntryPolicy)
 			},
 		},
 		{
-			msg: "bundle endpoint is parsed and configured correctly",
+			msg:         "entry policy without policy paths returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.Federation = &federationConfig{
-					BundleEndpoint: &bundleEndpointConfig{
-						Address: "192.168.1.1",
-						Port:    1337,
-					},
-				}
+				c.Server.EntryPolicy = &entryPolicyConfig{}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, "192.168.1.1", c.Federation.BundleEndpoint.Address.IP.String())
-				require.Equal(t, 1337, c.Federation.BundleEndpoint.Address.Port)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "bundle federates with section is parsed and configured correctly",
+			msg:         "entry policy with a malformed policy returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.Federation = &federationConfig{
-					FederatesWith: map[string]federatesWithConfig{
-						"domain1.test": {
-							BundleEndpoint: federatesWithBundleEndpointConfig{
-								Address:   "192.168.1.1",
-								Port:      1337,
-								SpiffeID:  "spiffe://domain1.test/bundle/endpoint",
-								UseWebPKI: false,
-							},
-						},
-						"domain2.test": {
-							BundleEndpoint: federatesWithBundleEndpointConfig{
-								Address:   "192.168.1.1",
-								Port:      1337,
-								UseWebPKI: true,
-							},
-						},
-					},
+				path := filepath.Join(spiretest.TempDir(t), "policy.rego")
+				require.NoError(t, ioutil.WriteFile(path, []byte("package spire.entry\n\ndeny[msg] {"), 0600))
+				c.Server.EntryPolicy = &entryPolicyConfig{
+					PolicyPaths: []string{path},
 				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, map[string]bundleClient.TrustDomainConfig{
-					"domain1.test": {
-						EndpointAddress:  "192.168.1.1:1337",
-						EndpointSpiffeID: "spiffe://domain1.test/bundle/endpoint",
-						UseWebPKI:        false,
-					},
-					"domain2.test": {
-						EndpointAddress: "192.168.1.1:1337",
-						UseWebPKI:       true,
-					},
-				}, c.Federation.FederatesWith)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg:         "bundle federates with section uses Web PKI and SpiffeID",
-			expectError: true,
+			msg:   "grpc health and reflection services are disabled by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.False(t, c.GRPCHealth)
+				require.False(t, c.GRPCReflection)
+			},
+		},
+		{
+			msg: "grpc health and reflection services can be enabled",
 			input: func(c *Config) {
-				c.Server.Federation = &federationConfig{
-					FederatesWith: map[string]federatesWithConfig{
-						"domain1.test": {
-							BundleEndpoint: federatesWithBundleEndpointConfig{
-								Address:   "192.168.1.1",
-								SpiffeID:  "spiffe://domain1.test/bundle/endpoint",
-								Port:      1337,
-								UseWebPKI: true,
-							},
-						},
-					},
+				c.Server.GRPCHealth = true
+				c.Server.GRPCReflection = true
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.GRPCHealth)
+				require.True(t, c.GRPCReflection)
+			},
+		},
+		{
+			msg:   "admin_api is not configured by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c.AdminAPI)
+			},
+		},
+		{
+			msg: "admin_api binds to the server bind address by default",
+			input: func(c *Config) {
+				c.Server.BindAddress = "127.0.0.1"
+				c.Server.AdminAPI = &adminAPIConfig{
+					BindPort:      8082,
+					AuthorizedIDs: []string{"spiffe://example.org/admin"},
 				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Nil(t, c)
+				require.Equal(t, &endpoints.AdminAPIConfig{
+					Address:       &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8082},
+					AuthorizedIDs: []spiffeid.ID{spiffeid.Must("example.org", "admin")},
+				}, c.AdminAPI)
 			},
 		},
 		{
-			msg: "default_svid_ttl is correctly parsed",
+			msg: "admin_api bind address is parsed",
 			input: func(c *Config) {
-				c.Server.DefaultSVIDTTL = "1m"
+				c.Server.AdminAPI = &adminAPIConfig{
+					BindAddress:   "10.0.0.1",
+					BindPort:      8082,
+					AuthorizedIDs: []string{"spiffe://example.org/admin"},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, time.Minute, c.SVIDTTL)
+				require.Equal(t, &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 8082}, c.AdminAPI.Address)
 			},
 		},
 		{
-			msg:         "invalid default_svid_ttl returns an error",
+			msg:         "admin_api without bind_port returns an error",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.DefaultSVIDTTL = "b"
+				c.Server.AdminAPI = &adminAPIConfig{
+					AuthorizedIDs: []string{"spiffe://example.org/admin"},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "rsa-2048 ca_key_type is correctly parsed",
+			msg:         "admin_api without authorized IDs returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "rsa-2048"
+				c.Server.AdminAPI = &adminAPIConfig{
+					BindPort: 8082,
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_RSA_2048, c.CAKeyType)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "rsa-4096 ca_key_type is correctly parsed",
+			msg:         "admin_api with a malformed bind address returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "rsa-4096"
+				c.Server.AdminAPI = &adminAPIConfig{
+					BindAddress:   "nope",
+					BindPort:      8082,
+					AuthorizedIDs: []string{"spiffe://example.org/admin"},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_RSA_4096, c.CAKeyType)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ec-p256 ca_key_type is correctly parsed",
+			msg:         "admin_api with a malformed authorized ID returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "ec-p256"
+				c.Server.AdminAPI = &adminAPIConfig{
+					BindPort:      8082,
+					AuthorizedIDs: []string{"example.org/admin"},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_EC_P256, c.CAKeyType)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ec-p384 ca_key_type is correctly parsed",
+			msg: "role bindings are parsed",
 			input: func(c *Config) {
-				c.Server.CAKeyType = "ec-p384"
+				c.Server.RoleBindings = map[string]roleBindingConfig{
+					"entry-admin": {
+						SPIFFEIDs: []string{"spiffe://example.org/entry-admin"},
+						Selectors: []string{"k8s:ns:ops"},
+					},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_EC_P384, c.CAKeyType)
+				require.Equal(t, []middleware.RoleBinding{
+					{
+						Role:      middleware.RoleEntryAdmin,
+						SPIFFEIDs: []spiffeid.ID{spiffeid.Must("example.org", "entry-admin")},
+						Selectors: []*types.Selector{{Type: "k8s", Value: "ns:ops"}},
+					},
+				}, c.RoleBindings)
 			},
 		},
 		{
-			msg:         "unsupported ca_key_type is rejected",
+			msg:         "role bindings with an unknown role return an error",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "rsa-1024"
+				c.Server.RoleBindings = map[string]roleBindingConfig{
+					"super-admin": {
+						SPIFFEIDs: []string{"spiffe://example.org/super-admin"},
+					},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ca_ttl is correctly parsed",
+			msg:         "role bindings without SPIFFE IDs or selectors return an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CATTL = "1h"
+				c.Server.RoleBindings = map[string]roleBindingConfig{
+					"read-only": {},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, time.Hour, c.CATTL)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg:         "invalid ca_ttl returns an error",
+			msg:         "role bindings with a malformed SPIFFE ID return an error",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.CATTL = "b"
+				c.Server.RoleBindings = map[string]roleBindingConfig{
+					"read-only": {
+						SPIFFEIDs: []string{"example.org/reader"},
+					},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ca_subject is defaulted when unset",
+			msg:         "role bindings with a malformed selector return an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CASubject = nil
+				c.Server.RoleBindings = map[string]roleBindingConfig{
+					"read-only": {
+						Selectors: []string{"k8s"},
+					},
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, defaultCASubject, c.CASubject)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ca_subject is defaulted when set but empty",
+			msg: "attestation rate limits can be explicitly enabled",
 			input: func(c *Config) {
-				c.Server.CASubject = &caSubjectConfig{}
+				value := true
+				c.Server.RateLimit.Attestation = &value
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, defaultCASubject, c.CASubject)
+				require.True(t, c.RateLimit.Attestation)
 			},
 		},
 		{
-			msg: "ca_subject is overridable",
+			msg: "rate limits are configured",
 			input: func(c *Config) {
-				c.Server.CASubject = &caSubjectConfig{
-					Organization: []string{"foo"},
-					Country:      []string{"us"},
-					CommonName:   "bar",
+				c.Server.RateLimit = rateLimitConfig{
+					AttestationPerIP:   2,
+					SigningPerIP:       1000,
+					SigningPerAgent:    100,
+					JWTSigningPerIP:    2000,
+					JWTSigningPerAgent: 200,
 				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, pkix.Name{
-					Organization: []string{"foo"},
-					Country:      []string{"us"},
-					CommonName:   "bar",
-				}, c.CASubject)
+				require.Equal(t, endpoints.RateLimitConfig{
+					Attestation:        true,
+					AttestationPerIP:   2,
+					SigningPerIP:       1000,
+					SigningPerAgent:    100,
+					JWTSigningPerIP:    2000,
+					JWTSigningPerAgent: 200,
+				}, c.RateLimit)
 			},
 		},
 		{
-			msg: "attestation rate limit is on by default",
+			msg:         "negative rate limits are rejected",
+			expectError: true,
 			input: func(c *Config) {
+				c.Server.RateLimit.SigningPerAgent = -1
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.True(t, c.RateLimit.Attestation)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "attestation rate limits can be explicitly disabled",
+			msg: "oidc discovery is disabled by default",
 			input: func(c *Config) {
-				value := false
-				c.Server.RateLimit.Attestation = &value
+				c.Server.OIDCDiscovery = nil
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.False(t, c.RateLimit.Attestation)
+				require.Nil(t, c.OIDCDiscovery)
 			},
 		},
 		{
-			msg: "attestation rate limits can be explicitly enabled",
+			msg: "oidc discovery is parsed and configured correctly",
 			input: func(c *Config) {
-				value := true
-				c.Server.RateLimit.Attestation = &value
+				c.Server.OIDCDiscovery = &oidcDiscoveryConfig{
+					Address: "192.168.1.1",
+					Domain:  "oidc.example.org",
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.True(t, c.RateLimit.Attestation)
+				require.Equal(t, "192.168.1.1", c.OIDCDiscovery.Address.IP.String())
+				require.Equal(t, 443, c.OIDCDiscovery.Address.Port)
+				require.Equal(t, "oidc.example.org", c.OIDCDiscovery.Domain)
+				require.Nil(t, c.OIDCDiscovery.ACME)
+			},
+		},
+		{
+			msg: "oidc discovery ACME uses the oidc discovery domain",
+			input: func(c *Config) {
+				c.Server.DataDir = "/data"
+				c.Server.OIDCDiscovery = &oidcDiscoveryConfig{
+					Port:   8443,
+					Domain: "oidc.example.org",
+					ACME: &bundleEndpointACMEConfig{
+						Email:       "admin@example.org",
+						ToSAccepted: true,
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 8443, c.OIDCDiscovery.Address.Port)
+				require.Equal(t, &bundle.ACMEConfig{
+					DomainName:  "oidc.example.org",
+					CacheDir:    filepath.Join("/data", "oidc-acme"),
+					Email:       "admin@example.org",
+					ToSAccepted: true,
+				}, c.OIDCDiscovery.ACME)
 			},
 		},
 	}
@@ -946,6 +1952,36 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: "federation.federates_with[\"domain.test\"].bundle_endpoint.address must be configured",
 		},
+		{
+			name: "if oidc_discovery is used, oidc_discovery.domain must be configured",
+			applyConf: func(c *Config) {
+				c.Server.OIDCDiscovery = &oidcDiscoveryConfig{}
+			},
+			expectedErr: "oidc_discovery.domain must be configured",
+		},
+		{
+			name: "if ACME is used, oidc_discovery.acme.domain_name must match oidc_discovery.domain",
+			applyConf: func(c *Config) {
+				c.Server.OIDCDiscovery = &oidcDiscoveryConfig{
+					Domain: "oidc.example.org",
+					ACME: &bundleEndpointACMEConfig{
+						DomainName: "other.example.org",
+						Email:      "admin@example.org",
+					},
+				}
+			},
+			expectedErr: "oidc_discovery.acme.domain_name must match oidc_discovery.domain",
+		},
+		{
+			name: "if ACME is used, oidc_discovery.acme.email must be configured",
+			applyConf: func(c *Config) {
+				c.Server.OIDCDiscovery = &oidcDiscoveryConfig{
+					Domain: "oidc.example.org",
+					ACME:   &bundleEndpointACMEConfig{},
+				}
+			},
+			expectedErr: "oidc_discovery.acme.email must be configured",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1007,6 +2043,56 @@ func TestWarnOnUnknownConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			msg:      "in admin_api block",
+			confFile: "server_bad_admin_api_block.conf",
+			expectedLogEntries: []logEntry{
+				{
+					section: "admin_api",
+					keys:    "unknown_option1,unknown_option2",
+				},
+			},
+		},
+		{
+			msg:      "in audit_log block",
+			confFile: "server_bad_audit_log_block.conf",
+			expectedLogEntries: []logEntry{
+				{
+					section: "audit_log",
+					keys:    "unknown_option1,unknown_option2",
+				},
+			},
+		},
+		{
+			msg:      "in entry_policy block",
+			confFile: "server_bad_entry_policy_block.conf",
+			expectedLogEntries: []logEntry{
+				{
+					section: "entry_policy",
+					keys:    "unknown_option1,unknown_option2",
+				},
+			},
+		},
+		{
+			msg:      "in ca_dns_name_policy block",
+			confFile: "server_bad_ca_dns_name_policy_block.conf",
+			expectedLogEntries: []logEntry{
+				{
+					section: `ca_dns_name_policy "spiffe://example.org/web/"`,
+					keys:    "unknown_option1,unknown_option2",
+				},
+			},
+		},
+		{
+			msg:      "in role_bindings block",
+			confFile: "server_bad_role_bindings_block.conf",
+			expectedLogEntries: []logEntry{
+				{
+					section: `role_bindings "entry-admin"`,
+					keys:    "unknown_option1,unknown_option2",
+				},
+			},
+		},
 		{
 			msg:      "in ratelimit block",
 			confFile: "server_bad_ratelimit_block.conf",
@@ -1017,6 +2103,16 @@ func TestWarnOnUnknownConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			msg:      "in oidc_discovery block",
+			confFile: "server_bad_oidc_discovery_block.conf",
+			expectedLogEntries: []logEntry{
+				{
+					section: "oidc_discovery",
+					keys:    "unknown_option1,unknown_option2",
+				},
+			},
+		},
 		// TODO: Re-enable unused key detection for experimental config. See
 		// https://github.com/spiffe/spire/issues/1101 for more information
 		//
@@ -1217,10 +2313,11 @@ func TestLogOptions(t *testing.T) {
 
 func TestHasExpectedTTLs(t *testing.T) {
 	cases := []struct {
-		msg             string
-		caTTL           time.Duration
-		svidTTL         time.Duration
-		hasExpectedTTLs bool
+		msg               string
+		caTTL             time.Duration
+		svidTTL           time.Duration
+		activateThreshold float64
+		hasExpectedTTLs   bool
 	}{
 		// ca_ttl isn't less than default_svid_ttl * 6
 		{
@@ -1266,13 +2363,28 @@ func TestHasExpectedTTLs(t *testing.T) {
 			svidTTL:         time.Hour * 10,
 			hasExpectedTTLs: false,
 		},
+		// custom activation threshold
+		{
+			msg:               "ca_ttl is 40h, default_svid_ttl is 10h and ca_activate_threshold is 0.75",
+			caTTL:             time.Hour * 40,
+			svidTTL:           time.Hour * 10,
+			activateThreshold: 0.75,
+			hasExpectedTTLs:   true,
+		},
+		{
+			msg:               "ca_ttl is 24h, default_svid_ttl is 3h and ca_activate_threshold is 0.9",
+			caTTL:             time.Hour * 24,
+			svidTTL:           time.Hour * 3,
+			activateThreshold: 0.9,
+			hasExpectedTTLs:   false,
+		},
 	}
 
 	for _, testCase := range cases {
 		testCase := testCase
 
 		t.Run(testCase.msg, func(t *testing.T) {
-			require.Equal(t, testCase.hasExpectedTTLs, hasExpectedTTLs(testCase.caTTL, testCase.svidTTL))
+			require.Equal(t, testCase.hasExpectedTTLs, hasExpectedTTLs(testCase.caTTL, testCase.svidTTL, testCase.activateThreshold))
 		})
 	}
 }
@@ -1300,3 +2412,97 @@ func TestExpandEnv(t *testing.T) {
 		assert.Equal(t, testCase.expectedValue, c.Server.TrustDomain)
 	}
 }
+
+func TestReloadConfig(t *testing.T) {
+	logger, err := log.NewLogger(log.WithLevel("INFO"))
+	require.NoError(t, err)
+	logger.SetOutput(ioutil.Discard)
+	hook := test.NewLocal(logger.Logger)
+	c := &server.Config{Log: logger, LogLevels: logger}
+
+	initial := defaultValidConfig()
+	initial.Server.Federation = &federationConfig{}
+
+	reloaded := defaultValidConfig()
+	reloaded.Server.LogLevel = "DEBUG"
+	reloaded.Server.LogSubsystemLevels = map[string]string{"ca": "WARN"}
+	attestation := false
+	reloaded.Server.RateLimit.Attestation = &attestation
+	reloaded.Server.Federation = &federationConfig{
+		FederatesWith: map[string]federatesWithConfig{
+			"domain.test": {
+				BundleEndpoint: federatesWithBundleEndpointConfig{
+					Address:  "192.168.1.1",
+					Port:     1337,
+					SpiffeID: "spiffe://domain.test/bundle-endpoint",
+				},
+			},
+		},
+	}
+	reloaded.Telemetry.InMem = &telemetry.InMem{}
+	reloaded.Server.DataDir = "/other"
+
+	s := new(fakeReloadable)
+	running := reloadConfig(initial, initial, reloaded, c, s)
+	require.Equal(t, reloaded, running)
+
+	levels, err := log.ParseLevels("DEBUG", map[string]string{"ca": "WARN"})
+	require.NoError(t, err)
+	require.Equal(t, levels, logger.GetLevels())
+	rateLimit, err := rateLimitFromConfig(reloaded.Server.RateLimit)
+	require.NoError(t, err)
+	require.False(t, rateLimit.Attestation)
+	require.Equal(t, &rateLimit, s.rateLimit)
+	require.Equal(t, map[string]bundleClient.TrustDomainConfig{
+		"domain.test": {
+			EndpointAddress:  "192.168.1.1:1337",
+			EndpointSpiffeID: "spiffe://domain.test/bundle-endpoint",
+		},
+	}, s.federatesWith)
+	require.Equal(t, &reloaded.Telemetry, s.telemetry)
+
+	entry := hook.LastEntry()
+	require.Equal(t, "Configuration reloaded", entry.Message)
+	require.Equal(t, []string{"server.federation.federates_with", "server.log_level", "server.ratelimit", "telemetry"}, entry.Data[telemetry.AppliedSettings])
+	require.Equal(t, []string{"server.data_dir"}, entry.Data[telemetry.RestartRequiredSettings])
+
+	// Reloading the same configuration applies nothing, but keeps reporting
+	// the settings that require a restart
+	s = new(fakeReloadable)
+	running = reloadConfig(initial, running, reloaded, c, s)
+	require.Equal(t, reloaded, running)
+	require.Equal(t, new(fakeReloadable), s)
+	entry = hook.LastEntry()
+	require.Nil(t, entry.Data[telemetry.AppliedSettings])
+	require.Equal(t, []string{"server.data_dir"}, entry.Data[telemetry.RestartRequiredSettings])
+
+	// Settings that fail to apply are retried on the next reload
+	failing := defaultValidConfig()
+	failing.Server.Federation = &federationConfig{}
+	s = &fakeReloadable{telemetryErr: errors.New("oh no")}
+	require.Equal(t, running, reloadConfig(initial, running, failing, c, s))
+}
+
+type fakeReloadable struct {
+	rateLimit     *endpoints.RateLimitConfig
+	telemetry     *telemetry.FileConfig
+	telemetryErr  error
+	federatesWith map[string]bundleClient.TrustDomainConfig
+}
+
+func (r *fakeReloadable) SetRateLimits(config endpoints.RateLimitConfig) {
+	r.rateLimit = &config
+}
+
+func (r *fakeReloadable) SetTelemetry(config telemetry.FileConfig) error {
+	if r.telemetryErr != nil {
+		return r.telemetryErr
+	}
+	r.telemetry = &config
+	return nil
+}
+
+func (r *fakeReloadable) SetFederatesWith(federatesWith map[string]bundleClient.TrustDomainConfig) error {
+	r.federatesWith = federatesWith
+	return nil
+}
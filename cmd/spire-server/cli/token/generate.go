@@ -27,6 +27,15 @@ type generateCommand struct {
 
 	// Token TTL in seconds
 	TTL int
+
+	// Number of times the token can be used
+	MaxUses int
+
+	// CIDRs of the addresses allowed to use the token
+	AllowedCIDRs common_cli.StringsFlag
+
+	// Selectors assigned to the agents attesting with the token
+	Selectors common_cli.StringsFlag
 }
 
 func (g *generateCommand) Name() string {
@@ -43,10 +52,21 @@ func (g *generateCommand) Run(ctx context.Context, env *common_cli.Env, serverCl
 		return err
 	}
 
+	var selectors []*types.Selector
+	for _, value := range g.Selectors {
+		selectors = append(selectors, &types.Selector{
+			Type:  "join_token",
+			Value: value,
+		})
+	}
+
 	c := serverClient.NewAgentClient()
 	resp, err := c.CreateJoinToken(ctx, &agent.CreateJoinTokenRequest{
-		AgentId: id,
-		Ttl:     int32(g.TTL),
+		AgentId:      id,
+		Ttl:          int32(g.TTL),
+		MaxUses:      int32(g.MaxUses),
+		AllowedCidrs: g.AllowedCIDRs,
+		Selectors:    selectors,
 	})
 	if err != nil {
 		return err
@@ -56,7 +76,9 @@ func (g *generateCommand) Run(ctx context.Context, env *common_cli.Env, serverCl
 		return err
 	}
 
-	if g.SpiffeID == "" {
+	// Agents attesting with a multi-use token are identified by the token
+	// selectors instead of a SPIFFE ID
+	if g.SpiffeID == "" && g.MaxUses <= 1 {
 		env.Printf("Warning: Missing SPIFFE ID.\n")
 		return nil
 	}
@@ -82,4 +104,7 @@ func getID(spiffeID string) (*types.SPIFFEID, error) {
 func (g *generateCommand) AppendFlags(fs *flag.FlagSet) {
 	fs.IntVar(&g.TTL, "ttl", 600, "Token TTL in seconds")
 	fs.StringVar(&g.SpiffeID, "spiffeID", "", "Additional SPIFFE ID to assign the token owner (optional)")
+	fs.IntVar(&g.MaxUses, "maxUses", 0, "Number of agents that can attest with the token. Zero or one means the token can only be used once")
+	fs.Var(&g.AllowedCIDRs, "allowedCIDR", "CIDR of the addresses allowed to use the token. Can be used more than once")
+	fs.Var(&g.Selectors, "selector", "Value of a join_token selector assigned to the agents attesting with the token. Can be used more than once")
 }
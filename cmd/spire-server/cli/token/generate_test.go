@@ -52,6 +52,27 @@ func TestCreateToken(t *testing.T) {
 			},
 			token: "token",
 		},
+		{
+			name: "create multi-use token",
+			args: []string{
+				"-maxUses", "10",
+				"-allowedCIDR", "10.0.0.0/8",
+				"-allowedCIDR", "192.168.0.0/16",
+				"-selector", "asg:web",
+				"-selector", "env:prod:eu",
+			},
+			expectedReq: &agent.CreateJoinTokenRequest{
+				Ttl:          600,
+				MaxUses:      10,
+				AllowedCidrs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+				Selectors: []*types.Selector{
+					{Type: "join_token", Value: "asg:web"},
+					{Type: "join_token", Value: "env:prod:eu"},
+				},
+			},
+			expectedStdout: "Token: token\n",
+			token:          "token",
+		},
 		{
 			name: "malformed spiffe ID",
 			args: []string{
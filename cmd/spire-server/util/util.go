@@ -10,8 +10,12 @@ import (
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
 	"github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
+	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	"github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
 	"github.com/spiffe/spire/proto/spire/api/server/svid/v1"
+	"github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
 	"google.golang.org/grpc"
 )
 
@@ -47,8 +51,12 @@ type ServerClient interface {
 	Release()
 	NewAgentClient() agent.AgentClient
 	NewBundleClient() bundle.BundleClient
+	NewDebugClient() debug.DebugClient
 	NewEntryClient() entry.EntryClient
+	NewEntryTemplateClient() entrytemplate.EntryTemplateClient
+	NewLocalAuthorityClient() localauthority.LocalAuthorityClient
 	NewSVIDClient() svid.SVIDClient
+	NewTrustDomainClient() trustdomain.TrustDomainClient
 }
 
 func NewServerClient(socketPath string) (ServerClient, error) {
@@ -75,14 +83,30 @@ func (c *serverClient) NewBundleClient() bundle.BundleClient {
 	return bundle.NewBundleClient(c.conn)
 }
 
+func (c *serverClient) NewDebugClient() debug.DebugClient {
+	return debug.NewDebugClient(c.conn)
+}
+
 func (c *serverClient) NewEntryClient() entry.EntryClient {
 	return entry.NewEntryClient(c.conn)
 }
 
+func (c *serverClient) NewEntryTemplateClient() entrytemplate.EntryTemplateClient {
+	return entrytemplate.NewEntryTemplateClient(c.conn)
+}
+
+func (c *serverClient) NewLocalAuthorityClient() localauthority.LocalAuthorityClient {
+	return localauthority.NewLocalAuthorityClient(c.conn)
+}
+
 func (c *serverClient) NewSVIDClient() svid.SVIDClient {
 	return svid.NewSVIDClient(c.conn)
 }
 
+func (c *serverClient) NewTrustDomainClient() trustdomain.TrustDomainClient {
+	return trustdomain.NewTrustDomainClient(c.conn)
+}
+
 // Pluralizer concatenates `singular` to `msg` when `val` is one, and
 // `plural` on all other occasions. It is meant to facilitate friendlier
 // CLI output.
@@ -2,6 +2,9 @@ package agent
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" //nolint: gosec // import registers routes on DefaultServeMux
@@ -11,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	admin_api "github.com/spiffe/spire/pkg/agent/api"
 	node_attestor "github.com/spiffe/spire/pkg/agent/attestor/node"
@@ -18,7 +22,11 @@ import (
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/endpoints"
 	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/nodelabels"
+	"github.com/spiffe/spire/pkg/agent/svid/store"
+	"github.com/spiffe/spire/pkg/agent/svidfile"
 	common_catalog "github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
@@ -32,8 +40,33 @@ import (
 	"google.golang.org/grpc"
 )
 
+// attestationHistorySize is the number of workload attestation results kept
+// for the debug API
+const attestationHistorySize = 100
+
 type Agent struct {
 	c *Config
+
+	// mtx protects the metrics of the agent, which can be reconfigured
+	// while the agent runs. They are set once the agent has started them.
+	mtx     sync.Mutex
+	metrics *telemetry.MetricsImpl
+}
+
+// SetTelemetry replaces the metrics sinks of the agent without restarting
+// it.
+func (a *Agent) SetTelemetry(config telemetry.FileConfig) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if a.metrics == nil {
+		return errors.New("telemetry has not been started")
+	}
+	if err := a.metrics.Reload(config); err != nil {
+		return err
+	}
+	a.c.Log.Info("Telemetry updated")
+	return nil
 }
 
 // Run the agent
@@ -48,6 +81,10 @@ func (a *Agent) Run(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	if flags := fflag.Enabled(); len(flags) > 0 {
+		a.c.Log.WithField("feature_flags", flags).Warn("Experimental feature flags are enabled")
+	}
+
 	if a.c.ProfilingEnabled {
 		stopProfiling := a.setupProfiling(ctx)
 		defer stopProfiling()
@@ -61,12 +98,18 @@ func (a *Agent) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	a.mtx.Lock()
+	a.metrics = metrics
+	a.mtx.Unlock()
+
 	metricsService := metricsservice.New(metricsservice.Config{
 		Metrics: metrics,
 	})
 
 	telemetry.EmitVersion(metrics)
 
+	healthChecks := health.NewChecker(a.c.HealthChecks, a.c.Log)
+
 	cat, err := catalog.Load(ctx, catalog.Config{
 		Log: a.c.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
 		GlobalConfig: catalog.GlobalConfig{
@@ -76,31 +119,57 @@ func (a *Agent) Run(ctx context.Context) error {
 		HostServices: []common_catalog.HostServiceServer{
 			common_services.MetricsServiceHostServiceServer(metricsService),
 		},
-		Metrics: metrics,
+		Metrics:      metrics,
+		HealthChecks: healthChecks,
 	})
 	if err != nil {
 		return err
 	}
 	defer cat.Close()
 
-	healthChecks := health.NewChecker(a.c.HealthChecks, a.c.Log)
-
-	as, err := a.attest(ctx, cat, metrics)
+	attestor := a.newAttestor(cat, metrics)
+	as, err := attestor.Attest(ctx)
 	if err != nil {
 		return err
 	}
 
-	manager, err := a.newManager(ctx, cat, metrics, as)
+	manager, err := a.newManager(ctx, cat, metrics, as, attestor)
 	if err != nil {
 		return err
 	}
 
-	endpoints := a.newEndpoints(cat, metrics, manager)
+	// The attestation history is only exposed through the admin API
+	var attestationHistory *workload_attestor.History
+	if a.c.AdminBindAddress != nil {
+		attestationHistory = workload_attestor.NewHistory(attestationHistorySize)
+	}
+
+	var labeler *nodelabels.Labeler
+	if a.c.NodeLabels != nil {
+		labelsConfig := *a.c.NodeLabels
+		labelsConfig.Log = a.c.Log.WithField(telemetry.SubsystemName, telemetry.NodeLabels)
+		labeler = nodelabels.New(labelsConfig)
+		// Workloads must not be attested without the node selectors
+		if err := labeler.Refresh(ctx); err != nil {
+			return err
+		}
+	}
+
+	endpoints := a.newEndpoints(cat, metrics, manager, attestationHistory, labeler)
 
 	if err := healthChecks.AddCheck("agent", a, time.Minute); err != nil {
 		return fmt.Errorf("failed adding healthcheck: %v", err)
 	}
 
+	subsystems := &subsystemChecks{
+		source:      manager,
+		bindAddress: a.c.BindAddress,
+		clk:         clock.New(),
+	}
+	if err := subsystems.addTo(healthChecks, subsystemCheckInterval); err != nil {
+		return fmt.Errorf("failed adding healthcheck: %v", err)
+	}
+
 	tasks := []func(context.Context) error{
 		manager.Run,
 		endpoints.ListenAndServe,
@@ -108,8 +177,26 @@ func (a *Agent) Run(ctx context.Context) error {
 		healthChecks.ListenAndServe,
 	}
 
+	for _, sinkConfig := range a.c.SVIDFileSinks {
+		sinkConfig.Manager = manager
+		sinkConfig.Log = a.c.Log.WithField(telemetry.SubsystemName, telemetry.SVIDFileSink)
+		tasks = append(tasks, svidfile.New(sinkConfig).Run)
+	}
+
+	if labeler != nil {
+		tasks = append(tasks, labeler.Run)
+	}
+
+	if svidStores := cat.GetSVIDStores(); len(svidStores) > 0 {
+		tasks = append(tasks, store.New(store.Config{
+			Manager: manager,
+			Stores:  svidStores,
+			Log:     a.c.Log.WithField(telemetry.SubsystemName, telemetry.SVIDStore),
+		}).Run)
+	}
+
 	if a.c.AdminBindAddress != nil {
-		adminEndpoints, err := a.newAdminEndpoints(manager)
+		adminEndpoints, err := a.newAdminEndpoints(manager, attestationHistory)
 		if err != nil {
 			return fmt.Errorf("failed to create debug endpoints: %v", err)
 		}
@@ -179,7 +266,7 @@ func (a *Agent) setupProfiling(ctx context.Context) (stop func()) {
 	}
 }
 
-func (a *Agent) attest(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics) (*node_attestor.AttestationResult, error) {
+func (a *Agent) newAttestor(cat catalog.Catalog, metrics telemetry.Metrics) node_attestor.Attestor {
 	config := node_attestor.Config{
 		Catalog:               cat,
 		Metrics:               metrics,
@@ -191,13 +278,14 @@ func (a *Agent) attest(ctx context.Context, cat catalog.Catalog, metrics telemet
 		SVIDCachePath:         a.agentSVIDPath(),
 		Log:                   a.c.Log.WithField(telemetry.SubsystemName, telemetry.Attestor),
 		ServerAddress:         a.c.ServerAddress,
+		GRPCTuning:            a.c.ServerGRPCTuning,
 		CreateNewAgentClient:  agent.NewAgentClient,
 		CreateNewBundleClient: bundle.NewBundleClient,
 	}
-	return node_attestor.New(&config).Attest(ctx)
+	return node_attestor.New(&config)
 }
 
-func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, as *node_attestor.AttestationResult) (manager.Manager, error) {
+func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, as *node_attestor.AttestationResult, attestor node_attestor.Attestor) (manager.Manager, error) {
 	config := &manager.Config{
 		SVID:            as.SVID,
 		SVIDKey:         as.Key,
@@ -210,6 +298,26 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 		BundleCachePath: a.bundleCachePath(),
 		SVIDCachePath:   a.agentSVIDPath(),
 		SyncInterval:    a.c.SyncInterval,
+		SyncBackoff:     a.c.SyncBackoff,
+		GRPCTuning:      a.c.ServerGRPCTuning,
+
+		SVIDRotationThreshold:         a.c.AgentSVIDRotation,
+		WorkloadSVIDRotationThreshold: a.c.WorkloadSVIDRotation,
+
+		SVIDCacheMaxSize: a.c.X509SVIDCacheMaxSize,
+		JWTSVIDPrefetch:  a.c.JWTSVIDPrefetch,
+	}
+	if a.c.PersistSVIDCache {
+		config.PersistedCachePath = a.persistedCachePath()
+	}
+	if a.c.JoinToken == "" {
+		config.Reattest = func(ctx context.Context) ([]*x509.Certificate, *ecdsa.PrivateKey, error) {
+			as, err := attestor.Reattest(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return as.SVID, as.Key, nil
+		}
 	}
 
 	mgr := manager.New(config)
@@ -220,33 +328,46 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 	return mgr, nil
 }
 
-func (a *Agent) newEndpoints(cat catalog.Catalog, metrics telemetry.Metrics, mgr manager.Manager) endpoints.Server {
+func (a *Agent) newEndpoints(cat catalog.Catalog, metrics telemetry.Metrics, mgr manager.Manager, history *workload_attestor.History, labeler *nodelabels.Labeler) endpoints.Server {
 	return endpoints.New(endpoints.Config{
-		BindAddr: a.c.BindAddress,
+		BindAddr:            a.c.BindAddress,
+		AdditionalBindAddrs: a.c.AdditionalBindAddresses,
+		TCPBindAddr:         a.c.TCPBindAddress,
+		TCPTLSConfig:        a.c.TCPTLSConfig,
+		HTTPBindAddr:        a.c.HTTPBindAddress,
 		Attestor: workload_attestor.New(&workload_attestor.Config{
-			Catalog: cat,
-			Log:     a.c.Log.WithField(telemetry.SubsystemName, telemetry.WorkloadAttestor),
-			Metrics: metrics,
+			Catalog:           cat,
+			Log:               a.c.Log.WithField(telemetry.SubsystemName, telemetry.WorkloadAttestor),
+			Metrics:           metrics,
+			History:           history,
+			NodeLabels:        labeler,
+			RequiredAttestors: a.c.RequiredWorkloadAttestors,
 		}),
-		Manager:           mgr,
-		Log:               a.c.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
-		Metrics:           metrics,
-		DefaultSVIDName:   a.c.DefaultSVIDName,
-		DefaultBundleName: a.c.DefaultBundleName,
+		Manager:               mgr,
+		JWTSVIDRotation:       a.c.WorkloadSVIDRotation,
+		Log:                   a.c.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
+		Metrics:               metrics,
+		RateLimit:             a.c.WorkloadAPIRateLimit,
+		GRPCHealth:            a.c.GRPCHealth,
+		GRPCReflection:        a.c.GRPCReflection,
+		DefaultSVIDName:       a.c.DefaultSVIDName,
+		DefaultBundleName:     a.c.DefaultBundleName,
+		DefaultAllBundlesName: a.c.DefaultAllBundlesName,
 	})
 }
 
-func (a *Agent) newAdminEndpoints(mgr manager.Manager) (admin_api.Server, error) {
+func (a *Agent) newAdminEndpoints(mgr manager.Manager, history *workload_attestor.History) (admin_api.Server, error) {
 	td, err := spiffeid.TrustDomainFromURI(&a.c.TrustDomain)
 	if err != nil {
 		return nil, err
 	}
 	config := &admin_api.Config{
-		BindAddr:    a.c.AdminBindAddress,
-		Manager:     mgr,
-		Log:         a.c.Log.WithField(telemetry.SubsystemName, telemetry.DebugAPI),
-		TrustDomain: td,
-		Uptime:      uptime.Uptime,
+		BindAddr:           a.c.AdminBindAddress,
+		Manager:            mgr,
+		Log:                a.c.Log.WithField(telemetry.SubsystemName, telemetry.DebugAPI),
+		TrustDomain:        td,
+		Uptime:             uptime.Uptime,
+		AttestationHistory: history,
 	}
 
 	return admin_api.New(config), nil
@@ -259,7 +380,12 @@ func (a *Agent) agentSVIDPath() string {
 	return path.Join(a.c.DataDir, "agent_svid.der")
 }
 
-// Status is used as a top-level health check for the Agent.
+func (a *Agent) persistedCachePath() string {
+	return path.Join(a.c.DataDir, "svid_cache.enc")
+}
+
+// Status is used as a top-level health check for the Agent. The details
+// report the enabled feature flags.
 func (a *Agent) Status() (interface{}, error) {
-	return nil, nil
+	return fflag.GetStatus(), nil
 }
@@ -10,8 +10,10 @@ import (
 	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	debug_pb "github.com/spiffe/spire/proto/spire/api/agent/debug/v1"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/clock"
 	"google.golang.org/grpc"
@@ -35,16 +37,21 @@ type Config struct {
 	Manager     manager.Manager
 	TrustDomain spiffeid.TrustDomain
 	Uptime      func() time.Duration
+
+	// AttestationHistory holds the results of the most recent workload
+	// attestations. It can be nil.
+	AttestationHistory *attestor.History
 }
 
 // New creates a new debug service
 func New(config Config) *Service {
 	return &Service{
-		clock:  config.Clock,
-		log:    config.Log,
-		m:      config.Manager,
-		td:     config.TrustDomain,
-		uptime: config.Uptime,
+		clock:   config.Clock,
+		log:     config.Log,
+		m:       config.Manager,
+		td:      config.TrustDomain,
+		uptime:  config.Uptime,
+		history: config.AttestationHistory,
 	}
 }
 
@@ -56,6 +63,8 @@ type Service struct {
 	td     spiffeid.TrustDomain
 	uptime func() time.Duration
 
+	history *attestor.History
+
 	getInfoResp getInfoResp
 }
 
@@ -103,6 +112,95 @@ func (s *Service) GetInfo(ctx context.Context, req *debug_pb.GetInfoRequest) (*d
 	return s.getInfoResp.resp, nil
 }
 
+// Dump dumps the registration entries cached by SPIRE Agent and the results
+// of the most recent workload attestations
+func (s *Service) Dump(ctx context.Context, req *debug_pb.DumpRequest) (*debug_pb.DumpResponse, error) {
+	resp := &debug_pb.DumpResponse{}
+
+	if svid := s.m.GetCurrentCredentials().SVID; len(svid) > 0 {
+		resp.AgentSvidExpiresAt = svid[0].NotAfter.Unix()
+	}
+
+	svidExpiresAt := make(map[string]int64)
+	for _, identity := range s.m.Identities() {
+		if len(identity.SVID) > 0 {
+			svidExpiresAt[identity.Entry.EntryId] = identity.SVID[0].NotAfter.Unix()
+		}
+	}
+
+	entries := s.m.Entries()
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &debug_pb.DumpResponse_Entry{
+			Id:            entry.EntryId,
+			SpiffeId:      spiffeIDFromString(entry.SpiffeId),
+			ParentId:      spiffeIDFromString(entry.ParentId),
+			Selectors:     selectorsToProto(entry.Selectors),
+			SvidExpiresAt: svidExpiresAt[entry.EntryId],
+		})
+	}
+
+	for _, result := range s.history.Results() {
+		resp.Attestations = append(resp.Attestations, &debug_pb.DumpResponse_Attestation{
+			Pid:        result.PID,
+			AttestedAt: result.AttestedAt.Unix(),
+			Selectors:  selectorsToProto(result.Selectors),
+			Errors:     result.Errors,
+			EntryIds:   matchingEntryIDs(entries, result.Selectors),
+		})
+	}
+
+	return resp, nil
+}
+
+// matchingEntryIDs returns the IDs of the entries whose selectors are a
+// subset of the provided selectors, whether or not they have an X509-SVID.
+func matchingEntryIDs(entries []*common.RegistrationEntry, selectors []*common.Selector) []string {
+	type selectorKey struct{ typ, value string }
+	set := make(map[selectorKey]bool, len(selectors))
+	for _, selector := range selectors {
+		set[selectorKey{typ: selector.Type, value: selector.Value}] = true
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		matches := len(entry.Selectors) > 0
+		for _, selector := range entry.Selectors {
+			if !set[selectorKey{typ: selector.Type, value: selector.Value}] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			ids = append(ids, entry.EntryId)
+		}
+	}
+	return ids
+}
+
+func selectorsToProto(selectors []*common.Selector) []*types.Selector {
+	var out []*types.Selector
+	for _, selector := range selectors {
+		out = append(out, &types.Selector{
+			Type:  selector.Type,
+			Value: selector.Value,
+		})
+	}
+	return out
+}
+
+// spiffeIDFromString gets types SPIFFE ID from a string, it can be nil
+func spiffeIDFromString(s string) *types.SPIFFEID {
+	id, err := spiffeid.FromString(s)
+	if err != nil {
+		return nil
+	}
+
+	return &types.SPIFFEID{
+		TrustDomain: id.TrustDomain().String(),
+		Path:        id.Path(),
+	}
+}
+
 // spiffeIDFromCert gets types SPIFFE ID from certificate, it can be nil
 func spiffeIDFromCert(cert *x509.Certificate) *types.SPIFFEID {
 	id, err := x509svid.IDFromCert(cert)
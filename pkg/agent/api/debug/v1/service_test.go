@@ -11,11 +11,13 @@ import (
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/agent/api/debug/v1"
+	attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	debugpb "github.com/spiffe/spire/proto/spire/api/agent/debug/v1"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/spiretest"
@@ -208,6 +210,88 @@ func TestGetInfo(t *testing.T) {
 	}
 }
 
+func TestDump(t *testing.T) {
+	ca := testca.New(t, td)
+	agentSVID := ca.CreateX509SVID(td.NewID("/spire/agent/foo"))
+	workloadSVID := ca.CreateX509SVID(td.NewID("/workload"))
+
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.m.svidState = svid.State{SVID: agentSVID.Certificates}
+	test.m.entries = []*common.RegistrationEntry{
+		{
+			EntryId:   "ENTRY1",
+			SpiffeId:  "spiffe://example.org/workload",
+			ParentId:  "spiffe://example.org/spire/agent/foo",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		{
+			EntryId:  "ENTRY2",
+			SpiffeId: "spiffe://example.org/other",
+			ParentId: "spiffe://example.org/spire/agent/foo",
+			Selectors: []*common.Selector{
+				{Type: "unix", Value: "uid:1000"},
+				{Type: "unix", Value: "gid:1000"},
+			},
+		},
+	}
+	test.m.identities = []cache.Identity{
+		{Entry: test.m.entries[0], SVID: workloadSVID.Certificates},
+	}
+
+	attestedAt := time.Unix(1000, 0)
+	test.history.Record(attestor.Result{
+		PID:        1,
+		AttestedAt: attestedAt,
+		Selectors:  []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		Errors:     []string{"workload attestor \"k8s\" failed"},
+	})
+	test.history.Record(attestor.Result{
+		PID:        2,
+		AttestedAt: attestedAt.Add(time.Second),
+		Selectors:  []*common.Selector{{Type: "unix", Value: "uid:0"}},
+	})
+
+	resp, err := test.client.Dump(ctx, &debugpb.DumpRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &debugpb.DumpResponse{
+		AgentSvidExpiresAt: agentSVID.Certificates[0].NotAfter.Unix(),
+		Entries: []*debugpb.DumpResponse_Entry{
+			{
+				Id:            "ENTRY1",
+				SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+				ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/foo"},
+				Selectors:     []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				SvidExpiresAt: workloadSVID.Certificates[0].NotAfter.Unix(),
+			},
+			{
+				Id:       "ENTRY2",
+				SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/other"},
+				ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/foo"},
+				Selectors: []*types.Selector{
+					{Type: "unix", Value: "uid:1000"},
+					{Type: "unix", Value: "gid:1000"},
+				},
+			},
+		},
+		Attestations: []*debugpb.DumpResponse_Attestation{
+			{
+				Pid:        2,
+				AttestedAt: attestedAt.Add(time.Second).Unix(),
+				Selectors:  []*types.Selector{{Type: "unix", Value: "uid:0"}},
+			},
+			{
+				Pid:        1,
+				AttestedAt: attestedAt.Unix(),
+				Selectors:  []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				Errors:     []string{"workload attestor \"k8s\" failed"},
+				EntryIds:   []string{"ENTRY1"},
+			},
+		},
+	}, resp)
+}
+
 type serviceTest struct {
 	client debugpb.DebugClient
 	done   func()
@@ -216,6 +300,7 @@ type serviceTest struct {
 	logHook *test.Hook
 	m       *fakeManager
 	uptime  *fakeUptime
+	history *attestor.History
 }
 
 func (s *serviceTest) Cleanup() {
@@ -233,12 +318,15 @@ func setupServiceTest(t *testing.T) *serviceTest {
 		clk:   clk,
 	}
 
+	history := attestor.NewHistory(10)
+
 	service := debug.New(debug.Config{
-		Clock:       clk,
-		Log:         log,
-		Manager:     manager,
-		TrustDomain: td,
-		Uptime:      fakeUptime.uptime,
+		Clock:              clk,
+		Log:                log,
+		Manager:            manager,
+		TrustDomain:        td,
+		Uptime:             fakeUptime.uptime,
+		AttestationHistory: history,
 	})
 
 	test := &serviceTest{
@@ -246,6 +334,7 @@ func setupServiceTest(t *testing.T) *serviceTest {
 		logHook: logHook,
 		m:       manager,
 		uptime:  fakeUptime,
+		history: history,
 	}
 
 	registerFn := func(s *grpc.Server) {
@@ -264,10 +353,12 @@ func setupServiceTest(t *testing.T) *serviceTest {
 type fakeManager struct {
 	manager.Manager
 
-	bundle    *cache.Bundle
-	svidState svid.State
-	svidCount int
-	lastSync  time.Time
+	bundle     *cache.Bundle
+	svidState  svid.State
+	svidCount  int
+	lastSync   time.Time
+	entries    []*common.RegistrationEntry
+	identities []cache.Identity
 }
 
 func (m *fakeManager) GetCurrentCredentials() svid.State {
@@ -286,6 +377,14 @@ func (m *fakeManager) GetBundle() *cache.Bundle {
 	return m.bundle
 }
 
+func (m *fakeManager) Entries() []*common.RegistrationEntry {
+	return m.entries
+}
+
+func (m *fakeManager) Identities() []cache.Identity {
+	return m.identities
+}
+
 type fakeUptime struct {
 	start time.Time
 	clk   *clock.Mock
@@ -53,11 +53,12 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 func (e *Endpoints) registerDebugAPI(server *grpc.Server) {
 	clk := clock.New()
 	service := debug.New(debug.Config{
-		Clock:       clk,
-		Log:         e.c.Log.WithField(telemetry.SubsystemName, telemetry.DebugAPI),
-		Manager:     e.c.Manager,
-		Uptime:      e.c.Uptime,
-		TrustDomain: e.c.TrustDomain,
+		Clock:              clk,
+		Log:                e.c.Log.WithField(telemetry.SubsystemName, telemetry.DebugAPI),
+		Manager:            e.c.Manager,
+		Uptime:             e.c.Uptime,
+		TrustDomain:        e.c.TrustDomain,
+		AttestationHistory: e.c.AttestationHistory,
 	})
 
 	debug.RegisterService(server, service)
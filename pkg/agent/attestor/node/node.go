@@ -17,6 +17,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
@@ -43,6 +44,11 @@ type AttestationResult struct {
 
 type Attestor interface {
 	Attest(ctx context.Context) (*AttestationResult, error)
+
+	// Reattest performs node attestation with a new key, regardless of any
+	// SVID cached on disk. It is not supported with join tokens since they
+	// can only be used once.
+	Reattest(ctx context.Context) (*AttestationResult, error)
 }
 
 type Config struct {
@@ -56,6 +62,7 @@ type Config struct {
 	SVIDCachePath         string
 	Log                   logrus.FieldLogger
 	ServerAddress         string
+	GRPCTuning            grpcutil.ClientTuning
 	CreateNewAgentClient  func(grpc.ClientConnInterface) agent.AgentClient
 	CreateNewBundleClient func(grpc.ClientConnInterface) bundle.BundleClient
 }
@@ -120,6 +127,30 @@ func (a *attestor) Attest(ctx context.Context) (res *AttestationResult, err erro
 	return &AttestationResult{Bundle: bundle, SVID: svid, Key: key}, nil
 }
 
+func (a *attestor) Reattest(ctx context.Context) (*AttestationResult, error) {
+	if a.c.JoinToken != "" {
+		return nil, errors.New("re-attestation is not supported with join tokens")
+	}
+
+	bundle, err := a.loadBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := a.generateKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	svid, bundle, err := a.newSVID(ctx, key, bundle)
+	if err != nil {
+		return nil, err
+	}
+	a.c.Log.WithField(telemetry.SPIFFEID, svid[0].URIs[0].String()).Info("Node re-attestation was successful")
+
+	return &AttestationResult{Bundle: bundle, SVID: svid, Key: key}, nil
+}
+
 // Load the current SVID and key. The returned SVID is nil to indicate a new SVID should be created.
 func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.PrivateKey, error) {
 	km := a.c.Catalog.GetKeyManager()
@@ -150,15 +181,24 @@ func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.Pr
 		// Neither private key nor SVID were found.
 	}
 
+	key, err := a.generateKey(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, key, nil
+}
+
+func (a *attestor) generateKey(ctx context.Context) (*ecdsa.PrivateKey, error) {
+	km := a.c.Catalog.GetKeyManager()
 	generateRes, err := km.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
 	if err != nil {
-		return nil, nil, fmt.Errorf("generate key pair: %s", err)
+		return nil, fmt.Errorf("generate key pair: %s", err)
 	}
 	key, err := x509.ParseECPrivateKey(generateRes.PrivateKey)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse key from keymanager: %v", key)
+		return nil, fmt.Errorf("parse key from keymanager: %v", key)
 	}
-	return nil, key, nil
+	return key, nil
 }
 
 // IsSVIDExpired returns true if the X.509 SVID provided is expired
@@ -296,6 +336,7 @@ func (a *attestor) serverConn(ctx context.Context, bundle *bundleutil.Bundle) (*
 			Address:     a.c.ServerAddress,
 			TrustDomain: a.c.TrustDomain.Host,
 			GetBundle:   bundle.RootCAs,
+			Tuning:      a.c.GRPCTuning,
 		})
 	}
 
@@ -330,9 +371,10 @@ func (a *attestor) serverConn(ctx context.Context, bundle *bundleutil.Bundle) (*
 		},
 	}
 
-	return grpc.DialContext(ctx, a.c.ServerAddress,
+	opts := []grpc.DialOption{
 		grpc.WithBalancerName(roundrobin.Name), //nolint:staticcheck
 		grpc.FailOnNonTempDialError(true),
 		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
-	)
+	}
+	return grpc.DialContext(ctx, a.c.ServerAddress, append(opts, a.c.GRPCTuning.DialOptions()...)...)
 }
@@ -0,0 +1,73 @@
+package attestor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Result is the outcome of attesting a workload.
+type Result struct {
+	PID        int32
+	AttestedAt time.Time
+	Selectors  []*common.Selector
+	// Errors holds the errors returned by the workload attestors that
+	// failed. The selectors from those attestors are not in Selectors.
+	Errors []string
+}
+
+// History keeps the results of the most recent workload attestations so
+// they can be inspected through the debug API.
+type History struct {
+	mtx     sync.Mutex
+	results []Result
+	next    int
+	full    bool
+}
+
+// NewHistory returns a history that keeps up to size results.
+func NewHistory(size int) *History {
+	return &History{
+		results: make([]Result, size),
+	}
+}
+
+// Results returns the recorded results, newest first. It is safe to call on
+// a nil history.
+func (h *History) Results() []Result {
+	if h == nil {
+		return nil
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	count := h.next
+	if h.full {
+		count = len(h.results)
+	}
+	out := make([]Result, 0, count)
+	for i := 1; i <= count; i++ {
+		out = append(out, h.results[(h.next-i+len(h.results))%len(h.results)])
+	}
+	return out
+}
+
+// Record adds a result to the history, evicting the oldest result if the
+// history is full. It is a no-op on a nil history.
+func (h *History) Record(result Result) {
+	if h == nil || len(h.results) == 0 {
+		return
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h.results[h.next] = result
+	h.next++
+	if h.next == len(h.results) {
+		h.next = 0
+		h.full = true
+	}
+}
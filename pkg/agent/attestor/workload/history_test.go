@@ -0,0 +1,36 @@
+package attestor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	pids := func(results []Result) []int32 {
+		var out []int32
+		for _, result := range results {
+			out = append(out, result.PID)
+		}
+		return out
+	}
+
+	history := NewHistory(3)
+	require.Empty(t, history.Results())
+
+	history.Record(Result{PID: 1})
+	history.Record(Result{PID: 2})
+	require.Equal(t, []int32{2, 1}, pids(history.Results()))
+
+	// The oldest results are evicted once the history is full
+	history.Record(Result{PID: 3})
+	history.Record(Result{PID: 4})
+	history.Record(Result{PID: 5})
+	require.Equal(t, []int32{5, 4, 3}, pids(history.Results()))
+}
+
+func TestNilHistory(t *testing.T) {
+	var history *History
+	history.Record(Result{PID: 1})
+	require.Nil(t, history.Results())
+}
@@ -3,9 +3,11 @@ package attestor
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/nodelabels"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_workload "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
@@ -17,7 +19,7 @@ type attestor struct {
 }
 
 type Attestor interface {
-	Attest(ctx context.Context, pid int32) []*common.Selector
+	Attest(ctx context.Context, pid int32) ([]*common.Selector, error)
 }
 
 func New(config *Config) Attestor {
@@ -32,44 +34,92 @@ type Config struct {
 	Catalog catalog.Catalog
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
+
+	// History, if set, records the result of each attestation
+	History *History
+
+	// NodeLabels, if set, provides the selectors of the node, which are
+	// added to the selectors of every workload
+	NodeLabels *nodelabels.Labeler
+
+	// RequiredAttestors lists the names of the workload attestors that must
+	// succeed for the attestation to succeed. The other attestors are
+	// optional.
+	RequiredAttestors []string
 }
 
-// Attest invokes all workload attestor plugins against the provided PID. If an error
-// is encountered, it is logged and selectors from the failing plugin are discarded.
-func (wla *attestor) Attest(ctx context.Context, pid int32) []*common.Selector {
+// Attest invokes all workload attestor plugins against the provided PID. If an
+// optional plugin fails, the error is logged and selectors from the failing
+// plugin are discarded. If a required plugin fails, the attestation fails
+// and no selectors are returned, so that workloads are not granted the
+// identities matched by the selectors of the remaining plugins alone.
+func (wla *attestor) Attest(ctx context.Context, pid int32) (_ []*common.Selector, err error) {
 	counter := telemetry_workload.StartAttestationCall(wla.c.Metrics)
-	defer counter.Done(nil)
+	defer counter.Done(&err)
 
 	log := wla.c.Log.WithField(telemetry.PID, pid)
 
 	plugins := wla.c.Catalog.GetWorkloadAttestors()
 	sChan := make(chan []*common.Selector)
-	errChan := make(chan error)
+	errChan := make(chan attestorError)
 
 	for _, p := range plugins {
 		go func(p catalog.WorkloadAttestor) {
 			if selectors, err := wla.invokeAttestor(ctx, p, pid); err == nil {
 				sChan <- selectors
 			} else {
-				errChan <- err
+				errChan <- attestorError{name: p.Name(), err: err}
 			}
 		}(p)
 	}
 
 	// Collect the results
 	selectors := []*common.Selector{}
+	var errs []string
+	var requiredErr error
 	for i := 0; i < len(plugins); i++ {
 		select {
 		case s := <-sChan:
 			selectors = append(selectors, s...)
-		case err := <-errChan:
-			log.WithError(err).Error("Failed to collect all selectors for PID")
+		case e := <-errChan:
+			if wla.isRequired(e.name) {
+				log.WithError(e.err).Error("Required workload attestor failed")
+				if requiredErr == nil {
+					requiredErr = e.err
+				}
+			} else {
+				log.WithError(e.err).Error("Failed to collect all selectors for PID")
+			}
+			errs = append(errs, e.err.Error())
 		}
 	}
+	if requiredErr != nil {
+		selectors = []*common.Selector{}
+	} else {
+		selectors = append(selectors, wla.c.NodeLabels.Selectors()...)
+	}
+	wla.c.History.Record(Result{
+		PID:        pid,
+		AttestedAt: time.Now(),
+		Selectors:  selectors,
+		Errors:     errs,
+	})
+	if requiredErr != nil {
+		return nil, requiredErr
+	}
 
 	telemetry_workload.AddDiscoveredSelectorsSample(wla.c.Metrics, float32(len(selectors)))
 	log.WithField(telemetry.Selectors, selectors).Debug("PID attested to have selectors")
-	return selectors
+	return selectors, nil
+}
+
+func (wla *attestor) isRequired(name string) bool {
+	for _, required := range wla.c.RequiredAttestors {
+		if required == name {
+			return true
+		}
+	}
+	return false
 }
 
 // invokeAttestor invokes attestation against the supplied plugin. Should be called from a goroutine.
@@ -88,3 +138,8 @@ func (wla *attestor) invokeAttestor(ctx context.Context, a catalog.WorkloadAttes
 
 	return resp.Selectors, nil
 }
+
+type attestorError struct {
+	name string
+	err  error
+}
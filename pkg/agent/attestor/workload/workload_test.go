@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/nodelabels"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_workload "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
 	"github.com/spiffe/spire/pkg/common/util"
@@ -61,24 +62,28 @@ func (s *WorkloadAttestorTestSuite) TestAttestWorkload() {
 	// both attestors succeed but with no selectors
 	s.attestor1.SetSelectors(1, nil)
 	s.attestor2.SetSelectors(1, nil)
-	selectors := s.attestor.Attest(ctx, 1)
+	selectors, err := s.attestor.Attest(ctx, 1)
+	s.Require().NoError(err)
 	s.Empty(selectors)
 
 	// attestor1 has selectors, but not attestor2
 	s.attestor1.SetSelectors(2, selectors1)
 	s.attestor2.SetSelectors(2, nil)
-	selectors = s.attestor.Attest(ctx, 2)
+	selectors, err = s.attestor.Attest(ctx, 2)
+	s.Require().NoError(err)
 	s.Equal(selectors1, selectors)
 
 	// attestor2 has selectors, attestor1 fails
 	s.attestor2.SetSelectors(3, selectors2)
-	selectors = s.attestor.Attest(ctx, 3)
+	selectors, err = s.attestor.Attest(ctx, 3)
+	s.Require().NoError(err)
 	s.Equal(selectors2, selectors)
 
 	// both have selectors
 	s.attestor1.SetSelectors(4, selectors1)
 	s.attestor2.SetSelectors(4, selectors2)
-	selectors = s.attestor.Attest(ctx, 4)
+	selectors, err = s.attestor.Attest(ctx, 4)
+	s.Require().NoError(err)
 	util.SortSelectors(selectors)
 	s.Equal(combined, selectors)
 }
@@ -106,7 +111,8 @@ func (s *WorkloadAttestorTestSuite) TestAttestWorkloadMetrics() {
 	s.attestor1.SetSelectors(2, selectors1)
 
 	// Expect selectors from both attestors
-	selectors := s.attestor.Attest(ctx, 2)
+	selectors, err := s.attestor.Attest(ctx, 2)
+	s.Require().NoError(err)
 
 	// Create expected metrics
 	expected := fakemetrics.New()
@@ -123,12 +129,13 @@ func (s *WorkloadAttestorTestSuite) TestAttestWorkloadMetrics() {
 	s.attestor.c.Metrics = metrics
 
 	// No selectors expected
-	selectors = s.attestor.Attest(ctx, 1)
+	selectors, err = s.attestor.Attest(ctx, 1)
+	s.Require().NoError(err)
 	s.Empty(selectors)
 
 	// Create expected metrics with error key
 	expected = fakemetrics.New()
-	err := errors.New("some error")
+	err = errors.New("some error")
 	attestorCounter = telemetry_workload.StartAttestorCall(expected, "fake1")
 	attestorCounter.Done(&err)
 	telemetry_workload.AddDiscoveredSelectorsSample(expected, float32(0))
@@ -137,3 +144,67 @@ func (s *WorkloadAttestorTestSuite) TestAttestWorkloadMetrics() {
 
 	s.Require().Equal(expected.AllMetrics(), metrics.AllMetrics())
 }
+
+func (s *WorkloadAttestorTestSuite) TestAttestWorkloadHistory() {
+	history := NewHistory(10)
+	s.attestor.c.History = history
+
+	selectors1 := []*common.Selector{{Type: "foo", Value: "bar"}}
+	s.attestor1.SetSelectors(1, selectors1)
+	_, err := s.attestor.Attest(ctx, 1)
+	s.Require().NoError(err)
+
+	results := history.Results()
+	s.Require().Len(results, 1)
+	s.Equal(int32(1), results[0].PID)
+	s.Equal(selectors1, results[0].Selectors)
+	s.Equal([]string{`workload attestor "fake2" failed: cannot attest pid 1`}, results[0].Errors)
+	s.False(results[0].AttestedAt.IsZero())
+}
+
+func (s *WorkloadAttestorTestSuite) TestAttestWorkloadRequiredAttestors() {
+	history := NewHistory(10)
+	s.attestor.c.History = history
+	s.attestor.c.RequiredAttestors = []string{"fake1"}
+
+	selectors1 := []*common.Selector{{Type: "foo", Value: "bar"}}
+	selectors2 := []*common.Selector{{Type: "bat", Value: "baz"}}
+
+	// the optional attestor fails
+	s.attestor1.SetSelectors(1, selectors1)
+	selectors, err := s.attestor.Attest(ctx, 1)
+	s.Require().NoError(err)
+	s.Equal(selectors1, selectors)
+
+	// the required attestor fails, so the selectors of the optional one are
+	// not returned
+	s.attestor2.SetSelectors(2, selectors2)
+	selectors, err = s.attestor.Attest(ctx, 2)
+	s.EqualError(err, `workload attestor "fake1" failed: cannot attest pid 2`)
+	s.Nil(selectors)
+
+	results := history.Results()
+	s.Require().Len(results, 2)
+	s.Empty(results[0].Selectors)
+	s.Equal([]string{`workload attestor "fake1" failed: cannot attest pid 2`}, results[0].Errors)
+}
+
+func (s *WorkloadAttestorTestSuite) TestAttestWorkloadNodeLabels() {
+	s.attestor.c.NodeLabels = nodelabels.New(nodelabels.Config{
+		Labels: map[string]string{"env": "prod"},
+	})
+
+	selectors1 := []*common.Selector{{Type: "foo", Value: "bar"}}
+	s.attestor1.SetSelectors(1, selectors1)
+	selectors, err := s.attestor.Attest(ctx, 1)
+	s.Require().NoError(err)
+	s.Equal([]*common.Selector{
+		{Type: "foo", Value: "bar"},
+		{Type: "label", Value: "env:prod"},
+	}, selectors)
+
+	// the node selectors are not returned when a required attestor fails
+	s.attestor.c.RequiredAttestors = []string{"fake1"}
+	_, err = s.attestor.Attest(ctx, 2)
+	s.Require().Error(err)
+}
@@ -16,11 +16,15 @@ import (
 	na_k8s_sat "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/k8s/sat"
 	na_sshpop "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/sshpop"
 	na_x509pop "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/x509pop"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	ss_aws_secretsmanager "github.com/spiffe/spire/pkg/agent/plugin/svidstore/awssecretsmanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
 	wa_docker "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/docker"
 	wa_k8s "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/k8s"
+	wa_sgx "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/sgx"
 	wa_unix "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/unix"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	keymanager_telemetry "github.com/spiffe/spire/pkg/common/telemetry/agent/keymanager"
 )
@@ -29,6 +33,7 @@ type Catalog interface {
 	GetKeyManager() KeyManager
 	GetNodeAttestor() NodeAttestor
 	GetWorkloadAttestors() []WorkloadAttestor
+	GetSVIDStores() []SVIDStore
 }
 
 type GlobalConfig = catalog.GlobalConfig
@@ -39,6 +44,7 @@ func KnownPlugins() []catalog.PluginClient {
 	return []catalog.PluginClient{
 		keymanager.PluginClient,
 		nodeattestor.PluginClient,
+		svidstore.PluginClient,
 		workloadattestor.PluginClient,
 	}
 }
@@ -59,9 +65,11 @@ func BuiltIns() []catalog.Plugin {
 		na_azure_msi.BuiltIn(),
 		na_k8s_sat.BuiltIn(),
 		na_k8s_psat.BuiltIn(),
+		ss_aws_secretsmanager.BuiltIn(),
 		wa_k8s.BuiltIn(),
 		wa_unix.BuiltIn(),
 		wa_docker.BuiltIn(),
+		wa_sgx.BuiltIn(),
 	}
 }
 
@@ -79,10 +87,16 @@ type WorkloadAttestor struct {
 	workloadattestor.WorkloadAttestor
 }
 
+type SVIDStore struct {
+	catalog.PluginInfo
+	svidstore.SVIDStore
+}
+
 type Plugins struct {
 	KeyManager        KeyManager
 	NodeAttestor      NodeAttestor
 	WorkloadAttestors []WorkloadAttestor `catalog:"min=1"`
+	SVIDStores        []SVIDStore
 }
 
 var _ Catalog = (*Plugins)(nil)
@@ -99,12 +113,20 @@ func (p *Plugins) GetWorkloadAttestors() []WorkloadAttestor {
 	return p.WorkloadAttestors
 }
 
+func (p *Plugins) GetSVIDStores() []SVIDStore {
+	return p.SVIDStores
+}
+
 type Config struct {
 	Log          logrus.FieldLogger
 	GlobalConfig GlobalConfig
 	PluginConfig HCLPluginConfigMap
 	HostServices []catalog.HostServiceServer
 	Metrics      *telemetry.MetricsImpl
+
+	// HealthChecks, if set, receives the health checks contributed by the
+	// built-in plugins.
+	HealthChecks *health.Checker
 }
 
 type Repository struct {
@@ -119,6 +141,7 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 	}
 
 	p := new(Plugins)
+
 	closer, err := catalog.Fill(ctx, catalog.Config{
 		Log:           config.Log,
 		GlobalConfig:  config.GlobalConfig,
@@ -127,6 +150,8 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 		KnownServices: KnownServices(),
 		BuiltIns:      BuiltIns(),
 		HostServices:  config.HostServices,
+		HealthChecks:  config.HealthChecks,
+		Metrics:       config.Metrics,
 	}, p)
 	if err != nil {
 		return nil, err
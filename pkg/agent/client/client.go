@@ -14,6 +14,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	agentpb "github.com/spiffe/spire/proto/spire/api/server/agent/v1"
@@ -45,6 +47,12 @@ type Client interface {
 	NewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[string]*node.X509SVID, error)
 	NewJWTSVID(ctx context.Context, jsr *node.JSR, entryID string) (*JWTSVID, error)
 
+	// WatchEntries watches the authorized entries of the agent. It calls
+	// changed once the watch is established and whenever the server reports
+	// that the entries changed. It blocks until the watch ends, which happens
+	// at the latest when the agent SVID used to establish it expires.
+	WatchEntries(ctx context.Context, changed func()) error
+
 	// Release releases any resources that were held by this Client, if any.
 	Release()
 }
@@ -60,6 +68,10 @@ type Config struct {
 
 	// RotMtx is used to prevent the creation of new connections during SVID rotations
 	RotMtx *sync.RWMutex
+
+	// GRPCTuning holds the keepalive and flow control settings of the
+	// connections to the server
+	GRPCTuning grpcutil.ClientTuning
 }
 
 type client struct {
@@ -67,6 +79,12 @@ type client struct {
 	connections *nodeConn
 	m           sync.Mutex
 
+	// entries holds the authorized entries as of entriesRevision, keyed by
+	// entry ID. It is only used when incremental entry sync is enabled.
+	entriesMtx      sync.Mutex
+	entries         map[string]*types.Entry
+	entriesRevision int64
+
 	// Constructor used for testing purposes.
 	createNewEntryClient  func(grpc.ClientConnInterface) entrypb.EntryClient
 	createNewBundleClient func(grpc.ClientConnInterface) bundlepb.BundleClient
@@ -265,6 +283,45 @@ func (c *client) NewJWTSVID(ctx context.Context, jsr *node.JSR, entryID string)
 	}, nil
 }
 
+func (c *client) WatchEntries(ctx context.Context, changed func()) error {
+	stream, connection, err := c.watchAuthorizedEntries(ctx)
+	if err != nil {
+		return err
+	}
+	defer connection.Release()
+
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.release(connection)
+			return fmt.Errorf("failed to watch authorized entries: %w", err)
+		}
+		changed()
+	}
+}
+
+func (c *client) watchAuthorizedEntries(ctx context.Context) (entrypb.Entry_WatchAuthorizedEntriesClient, *nodeConn, error) {
+	// Only hold the rotation lock while the stream is opened; the stream
+	// keeps using the connection it was opened on after a rotation.
+	c.c.RotMtx.RLock()
+	defer c.c.RotMtx.RUnlock()
+
+	entryClient, connection, err := c.newEntryClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stream, err := entryClient.WatchAuthorizedEntries(ctx, &entrypb.WatchAuthorizedEntriesRequest{})
+	if err != nil {
+		connection.Release()
+		c.release(connection)
+		return nil, nil, fmt.Errorf("failed to watch authorized entries: %w", err)
+	}
+	return stream, connection, nil
+}
+
 // Release the underlying connection.
 func (c *client) Release() {
 	c.release(nil)
@@ -297,25 +354,68 @@ func (c *client) dial(ctx context.Context) (*grpc.ClientConn, error) {
 			}
 			return agentCert
 		},
+		Tuning:      c.c.GRPCTuning,
 		dialContext: c.dialContext,
 	})
 }
 
 func (c *client) fetchEntries(ctx context.Context) ([]*types.Entry, error) {
+	if !fflag.IsSet(fflag.FlagIncrementalEntrySync) {
+		resp, err := c.getAuthorizedEntries(ctx, 0)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Entries, nil
+	}
+
+	c.entriesMtx.Lock()
+	defer c.entriesMtx.Unlock()
+
+	// Only ask for the changes since the last sync. The server returns all of
+	// the entries instead if it does not know that revision.
+	resp, err := c.getAuthorizedEntries(ctx, c.entriesRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !resp.Delta:
+		c.entries = make(map[string]*types.Entry, len(resp.Entries))
+	case c.entries == nil:
+		return nil, errors.New("failed to fetch authorized entries: server returned changes to unknown entries")
+	}
+	for _, id := range resp.DeletedEntryIds {
+		delete(c.entries, id)
+	}
+	for _, entry := range resp.Entries {
+		c.entries[entry.Id] = entry
+	}
+	c.entriesRevision = resp.Revision
+
+	entries := make([]*types.Entry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (c *client) getAuthorizedEntries(ctx context.Context, sinceRevision int64) (*entrypb.GetAuthorizedEntriesResponse, error) {
 	entryClient, connection, err := c.newEntryClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer connection.Release()
 
-	resp, err := entryClient.GetAuthorizedEntries(ctx, &entrypb.GetAuthorizedEntriesRequest{})
+	resp, err := entryClient.GetAuthorizedEntries(ctx, &entrypb.GetAuthorizedEntriesRequest{
+		SinceRevision: sinceRevision,
+	})
 	if err != nil {
 		c.release(connection)
 		c.c.Log.WithError(err).Error("Failed to fetch authorized entries")
 		return nil, fmt.Errorf("failed to fetch authorized entries: %w", err)
 	}
 
-	return resp.Entries, err
+	return resp, nil
 }
 
 func (c *client) fetchBundles(ctx context.Context, federatedBundles []string) ([]*types.Bundle, error) {
@@ -6,11 +6,14 @@ import (
 	"crypto/x509"
 	"errors"
 	"net/url"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	agentpb "github.com/spiffe/spire/proto/spire/api/server/agent/v1"
 	bundlepb "github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
@@ -46,8 +49,11 @@ func TestFetchUpdates(t *testing.T) {
 			Selectors: []*types.Selector{
 				{Type: "S", Value: "1"},
 			},
-			FederatesWith:  []string{"domain1.com"},
-			RevisionNumber: 1234,
+			FederatesWith:      []string{"domain1.com"},
+			RevisionNumber:     1234,
+			X509SvidKeyType:    "rsa-2048",
+			Hint:               "internal",
+			SelectorExpression: "!S:2",
 		},
 		// This entry should be ignored since it is missing an entry ID
 		{
@@ -135,6 +141,107 @@ func TestFetchUpdates(t *testing.T) {
 	assertConnectionIsNotNil(t, client)
 }
 
+func TestFetchUpdatesIncrementalEntrySync(t *testing.T) {
+	require.NoError(t, fflag.Load(fflag.RawConfig{string(fflag.FlagIncrementalEntrySync)}, fflag.ScopeAgent))
+	defer fflag.Unload()
+
+	client, tc := createClient()
+	tc.bundleClient.agentBundle = &types.Bundle{
+		TrustDomain:     "example.org",
+		X509Authorities: []*types.X509Certificate{{Asn1: []byte{10, 20, 30, 40}}},
+	}
+
+	entry1 := &types.Entry{
+		Id:             "ENTRYID1",
+		ParentId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/host"},
+		SpiffeId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/id1"},
+		Selectors:      []*types.Selector{{Type: "S", Value: "1"}},
+		RevisionNumber: 1,
+	}
+	entry2 := &types.Entry{
+		Id:             "ENTRYID2",
+		ParentId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/host"},
+		SpiffeId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/id2"},
+		Selectors:      []*types.Selector{{Type: "S", Value: "2"}},
+		RevisionNumber: 1,
+	}
+	entry3 := &types.Entry{
+		Id:             "ENTRYID3",
+		ParentId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/host"},
+		SpiffeId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/id3"},
+		Selectors:      []*types.Selector{{Type: "S", Value: "3"}},
+		RevisionNumber: 1,
+	}
+
+	fetchEntryIDs := func() []string {
+		update, err := client.FetchUpdates(context.Background())
+		require.NoError(t, err)
+		var ids []string
+		for id := range update.Entries {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	// The first sync gets all of the entries
+	tc.entryClient.resp = &entrypb.GetAuthorizedEntriesResponse{
+		Entries:  []*types.Entry{entry1, entry2},
+		Revision: 10,
+	}
+	require.Equal(t, []string{"ENTRYID1", "ENTRYID2"}, fetchEntryIDs())
+	require.Equal(t, []int64{0}, tc.entryClient.sinceRevisions)
+
+	// Nothing changed
+	tc.entryClient.sinceRevisions = nil
+	tc.entryClient.resp = &entrypb.GetAuthorizedEntriesResponse{
+		Revision: 10,
+		Delta:    true,
+	}
+	require.Equal(t, []string{"ENTRYID1", "ENTRYID2"}, fetchEntryIDs())
+	require.Equal(t, []int64{10}, tc.entryClient.sinceRevisions)
+
+	// An entry was added, one updated and one removed
+	tc.entryClient.sinceRevisions = nil
+	updated := proto.Clone(entry2).(*types.Entry)
+	updated.SpiffeId.Path = "/id2-updated"
+	updated.RevisionNumber = 2
+	tc.entryClient.resp = &entrypb.GetAuthorizedEntriesResponse{
+		Entries:         []*types.Entry{updated, entry3},
+		Revision:        11,
+		Delta:           true,
+		DeletedEntryIds: []string{"ENTRYID1"},
+	}
+	update, err := client.FetchUpdates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, update.Entries, 2)
+	require.Equal(t, "spiffe://example.org/id2-updated", update.Entries["ENTRYID2"].SpiffeId)
+	require.Equal(t, "spiffe://example.org/id3", update.Entries["ENTRYID3"].SpiffeId)
+	require.Equal(t, []int64{10}, tc.entryClient.sinceRevisions)
+
+	// The server did not know the revision and returned all of the entries
+	tc.entryClient.sinceRevisions = nil
+	tc.entryClient.resp = &entrypb.GetAuthorizedEntriesResponse{
+		Entries:  []*types.Entry{entry1},
+		Revision: 20,
+	}
+	require.Equal(t, []string{"ENTRYID1"}, fetchEntryIDs())
+	require.Equal(t, []int64{11}, tc.entryClient.sinceRevisions)
+
+	// Failures are returned and do not change the cached entries
+	tc.entryClient.sinceRevisions = nil
+	tc.entryClient.err = errors.New("an error")
+	_, err = client.FetchUpdates(context.Background())
+	require.EqualError(t, err, "failed to fetch authorized entries: an error")
+	tc.entryClient.err = nil
+	tc.entryClient.resp = &entrypb.GetAuthorizedEntriesResponse{
+		Revision: 20,
+		Delta:    true,
+	}
+	require.Equal(t, []string{"ENTRYID1"}, fetchEntryIDs())
+	require.Equal(t, []int64{20, 20}, tc.entryClient.sinceRevisions)
+}
+
 func TestRenewSVID(t *testing.T) {
 	client, tc := createClient()
 
@@ -218,8 +325,11 @@ func TestNewX509SVIDs(t *testing.T) {
 			Selectors: []*types.Selector{
 				{Type: "S", Value: "1"},
 			},
-			FederatesWith:  []string{"domain1.com"},
-			RevisionNumber: 1234,
+			FederatesWith:      []string{"domain1.com"},
+			RevisionNumber:     1234,
+			X509SvidKeyType:    "rsa-2048",
+			Hint:               "internal",
+			SelectorExpression: "!S:2",
 		},
 		// This entry should be ignored since it is missing an entry ID
 		{
@@ -305,7 +415,10 @@ func newTestFetchX509SVIDResponse() *node.FetchX509SVIDResponse {
 					FederatesWith: []string{
 						"spiffe://domain1.com",
 					},
-					RevisionNumber: 1234,
+					RevisionNumber:     1234,
+					X509SvidKeyType:    "rsa-2048",
+					Hint:               "internal",
+					SelectorExpression: "!S:2",
 				},
 				// This entry should be ignored since it is missing an entry ID
 				{
@@ -369,8 +482,11 @@ func TestFetchReleaseWaitsForFetchUpdatesToFinish(t *testing.T) {
 			Selectors: []*types.Selector{
 				{Type: "S", Value: "1"},
 			},
-			FederatesWith:  []string{"domain1.com"},
-			RevisionNumber: 1234,
+			FederatesWith:      []string{"domain1.com"},
+			RevisionNumber:     1234,
+			X509SvidKeyType:    "rsa-2048",
+			Hint:               "internal",
+			SelectorExpression: "!S:2",
 		},
 		// This entry should be ignored since it is missing an entry ID
 		{
@@ -573,6 +689,60 @@ func TestFetchUpdatesReleaseConnectionIfItFails(t *testing.T) {
 	assertConnectionIsNil(t, client)
 }
 
+func TestWatchEntries(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		setupTest     func(tc *testClient)
+		expectChanged int
+		err           string
+	}{
+		{
+			name: "success",
+			setupTest: func(tc *testClient) {
+				tc.entryClient.watchResponses = []*entrypb.WatchAuthorizedEntriesResponse{
+					{},
+					{UpdatedEntryIds: []string{"ENTRYID1"}},
+				}
+				tc.entryClient.watchErr = errors.New("stream closed")
+			},
+			expectChanged: 2,
+			err:           "failed to watch authorized entries: stream closed",
+		},
+		{
+			name: "fails to open stream",
+			setupTest: func(tc *testClient) {
+				tc.entryClient.err = errors.New("an error")
+			},
+			err: "failed to watch authorized entries: an error",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client, tc := createClient()
+			tt.setupTest(tc)
+
+			changed := 0
+			err := client.WatchEntries(context.Background(), func() {
+				changed++
+			})
+			require.EqualError(t, err, tt.err)
+			require.Equal(t, tt.expectChanged, changed)
+			assertConnectionIsNil(t, client)
+		})
+	}
+}
+
+func TestWatchEntriesStopsWhenContextIsDone(t *testing.T) {
+	client, tc := createClient()
+	tc.entryClient.watchResponses = []*entrypb.WatchAuthorizedEntriesResponse{{}}
+	tc.entryClient.watchErr = errors.New("stream canceled")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := client.WatchEntries(ctx, cancel)
+	require.Equal(t, context.Canceled, err)
+	assertConnectionIsNotNil(t, client)
+}
+
 func TestNewAgentClientFailsDial(t *testing.T) {
 	client := newClient(&Config{
 		KeysAndBundle: keysAndBundle,
@@ -774,19 +944,52 @@ func assertConnectionIsNotNil(t *testing.T, client *client) {
 
 type fakeEntryClient struct {
 	entrypb.EntryClient
-	entries []*types.Entry
-	err     error
+	entries        []*types.Entry
+	err            error
+	sinceRevisions []int64
+	resp           *entrypb.GetAuthorizedEntriesResponse
+	watchResponses []*entrypb.WatchAuthorizedEntriesResponse
+	watchErr       error
 }
 
 func (c *fakeEntryClient) GetAuthorizedEntries(ctx context.Context, in *entrypb.GetAuthorizedEntriesRequest, opts ...grpc.CallOption) (*entrypb.GetAuthorizedEntriesResponse, error) {
+	c.sinceRevisions = append(c.sinceRevisions, in.SinceRevision)
 	if c.err != nil {
 		return nil, c.err
 	}
+	if c.resp != nil {
+		return c.resp, nil
+	}
 	return &entrypb.GetAuthorizedEntriesResponse{
 		Entries: c.entries,
 	}, nil
 }
 
+func (c *fakeEntryClient) WatchAuthorizedEntries(ctx context.Context, in *entrypb.WatchAuthorizedEntriesRequest, opts ...grpc.CallOption) (entrypb.Entry_WatchAuthorizedEntriesClient, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &fakeWatchStream{
+		responses: c.watchResponses,
+		err:       c.watchErr,
+	}, nil
+}
+
+type fakeWatchStream struct {
+	grpc.ClientStream
+	responses []*entrypb.WatchAuthorizedEntriesResponse
+	err       error
+}
+
+func (s *fakeWatchStream) Recv() (*entrypb.WatchAuthorizedEntriesResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, s.err
+	}
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
 type fakeBundleClient struct {
 	bundlepb.BundleClient
 
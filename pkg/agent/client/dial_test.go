@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/grpcutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestDialServerAppliesTuning(t *testing.T) {
+	dialOptions := func(tuning grpcutil.ClientTuning) int {
+		var count int
+		_, err := DialServer(context.Background(), DialServerConfig{
+			Address:     "localhost:8081",
+			TrustDomain: "example.org",
+			GetBundle:   func() []*x509.Certificate { return nil },
+			Tuning:      tuning,
+			dialContext: func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+				count = len(opts)
+				return nil, errors.New("oh no")
+			},
+		})
+		require.EqualError(t, err, "failed to dial localhost:8081: oh no")
+		return count
+	}
+
+	defaults := dialOptions(grpcutil.ClientTuning{})
+	tuned := dialOptions(grpcutil.ClientTuning{
+		KeepaliveTime:     30 * time.Second,
+		InitialWindowSize: 1 << 20,
+	})
+	require.Equal(t, defaults+2, tuned)
+}
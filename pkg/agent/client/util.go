@@ -67,10 +67,13 @@ func slicedEntryFromProto(e *types.Entry) (*common.RegistrationEntry, error) {
 	}
 
 	return &common.RegistrationEntry{
-		EntryId:        e.Id,
-		SpiffeId:       spiffeID,
-		FederatesWith:  federatesWith,
-		RevisionNumber: e.RevisionNumber,
-		Selectors:      selectors,
+		EntryId:            e.Id,
+		SpiffeId:           spiffeID,
+		FederatesWith:      federatesWith,
+		RevisionNumber:     e.RevisionNumber,
+		Selectors:          selectors,
+		X509SvidKeyType:    e.X509SvidKeyType,
+		Hint:               e.Hint,
+		SelectorExpression: e.SelectorExpression,
 	}, nil
 }
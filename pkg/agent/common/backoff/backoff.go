@@ -1,6 +1,7 @@
 package backoff
 
 import (
+	"errors"
 	"time"
 
 	"github.com/andres-erbsen/clock"
@@ -18,16 +19,66 @@ const (
 	_noMaxElapsedTime    = 0
 )
 
+// Policy tunes how a backoff grows from its initial interval. Zero values
+// use the defaults: a multiplier of 1.5, a jitter of 10% and a maximum
+// interval of 24 times the initial interval.
+type Policy struct {
+	// MaxInterval is the upper bound of the intervals between attempts,
+	// before jitter is applied
+	MaxInterval time.Duration
+
+	// Multiplier is the factor the interval grows by after each failed
+	// attempt
+	Multiplier float64
+
+	// Jitter is the fraction of the interval by which each interval is
+	// randomly shortened or lengthened, spreading the attempts of many
+	// clients that failed at the same time
+	Jitter float64
+}
+
+// Validate returns an error if the policy cannot be used with a backoff
+// starting at the given interval
+func (p Policy) Validate(interval time.Duration) error {
+	switch {
+	case p.MaxInterval < 0:
+		return errors.New("max_interval must not be negative")
+	case p.MaxInterval != 0 && p.MaxInterval < interval:
+		return errors.New("max_interval must not be less than the interval")
+	case p.Multiplier != 0 && p.Multiplier < 1:
+		return errors.New("multiplier must be at least 1")
+	case p.Jitter < 0 || p.Jitter >= 1:
+		return errors.New("jitter must be at least 0 and less than 1")
+	}
+	return nil
+}
+
 // NewBackoff returns a new backoff calculator ready for use. Generalizes all backoffs
 // to have the same behavioral pattern, though with different bounds based on given
 // interval.
 func NewBackoff(clk clock.Clock, interval time.Duration) BackOff {
+	return NewBackoffWithPolicy(clk, interval, Policy{})
+}
+
+// NewBackoffWithPolicy returns a new backoff calculator starting at the given
+// interval and growing according to the policy.
+func NewBackoffWithPolicy(clk clock.Clock, interval time.Duration, policy Policy) BackOff {
+	if policy.MaxInterval == 0 {
+		policy.MaxInterval = _maxIntervalMultiple * interval
+	}
+	if policy.Multiplier == 0 {
+		policy.Multiplier = _backoffMultiplier
+	}
+	if policy.Jitter == 0 {
+		policy.Jitter = _jitter
+	}
+
 	b := &backoff.ExponentialBackOff{
 		Clock:               clk,
 		InitialInterval:     interval,
-		RandomizationFactor: _jitter,
-		Multiplier:          _backoffMultiplier,
-		MaxInterval:         _maxIntervalMultiple * interval,
+		RandomizationFactor: policy.Jitter,
+		Multiplier:          policy.Multiplier,
+		MaxInterval:         policy.MaxInterval,
 		MaxElapsedTime:      _noMaxElapsedTime,
 	}
 	b.Reset()
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/require"
 )
 
 // modified from `TestBackoff` in "github.com/cenkalti/backoff/v3", narrowed down to specific usage
@@ -30,11 +31,52 @@ func TestBackOff(t *testing.T) {
 	inRange(t, expectedResults[0], b)
 }
 
+func TestBackOffWithPolicy(t *testing.T) {
+	mockClk := clock.NewMock(t)
+	b := NewBackoffWithPolicy(mockClk, 5*time.Second, Policy{
+		MaxInterval: 30 * time.Second,
+		Multiplier:  2,
+		Jitter:      0.5,
+	})
+
+	var expectedResults = []time.Duration{5, 10, 20, 30, 30}
+	for _, expected := range expectedResults {
+		expected *= time.Second
+		inRangeWithJitter(t, expected, 0.5, b)
+		mockClk.Add(expected)
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	for _, tt := range []struct {
+		policy Policy
+		err    string
+	}{
+		{policy: Policy{}},
+		{policy: Policy{MaxInterval: time.Minute, Multiplier: 2, Jitter: 0.5}},
+		{policy: Policy{MaxInterval: time.Second}, err: "max_interval must not be less than the interval"},
+		{policy: Policy{Multiplier: 0.5}, err: "multiplier must be at least 1"},
+		{policy: Policy{Jitter: 1}, err: "jitter must be at least 0 and less than 1"},
+		{policy: Policy{Jitter: -0.1}, err: "jitter must be at least 0 and less than 1"},
+	} {
+		err := tt.policy.Validate(5 * time.Second)
+		if tt.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, tt.err)
+		}
+	}
+}
+
 func inRange(t *testing.T, expected time.Duration, b BackOff) {
-	var minInterval = expected - time.Duration(_jitter*float64(expected))
-	var maxInterval = expected + time.Duration(_jitter*float64(expected))
+	inRangeWithJitter(t, expected, _jitter, b)
+}
+
+func inRangeWithJitter(t *testing.T, expected time.Duration, jitter float64, b BackOff) {
+	var minInterval = expected - time.Duration(jitter*float64(expected))
+	var maxInterval = expected + time.Duration(jitter*float64(expected))
 	var actualInterval = b.NextBackOff()
 	if !(minInterval <= actualInterval && actualInterval <= maxInterval) {
-		t.Error("error")
+		t.Errorf("expected backoff between %s and %s; got %s", minInterval, maxInterval, actualInterval)
 	}
 }
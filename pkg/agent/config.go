@@ -1,14 +1,24 @@
 package agent
 
 import (
+	"crypto/tls"
 	"crypto/x509"
 	"net"
 	"net/url"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/endpoints"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/nodelabels"
+	"github.com/spiffe/spire/pkg/agent/svidfile"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
@@ -16,6 +26,30 @@ type Config struct {
 	// Address to bind the workload api to
 	BindAddress *net.UnixAddr
 
+	// Additional addresses to bind the workload api to
+	AdditionalBindAddresses []*net.UnixAddr
+
+	// Loopback TCP address to bind the workload api to, secured with
+	// TCPTLSConfig. If nil, the workload api is not served over TCP.
+	TCPBindAddress *net.TCPAddr
+
+	// TLS configuration used to serve the workload api over TCP
+	TCPTLSConfig *tls.Config
+
+	// UNIX domain socket to serve the workload api HTTP gateway on. If nil,
+	// the gateway is disabled.
+	HTTPBindAddress *net.UnixAddr
+
+	// Rate limits imposed on each caller of the workload api
+	WorkloadAPIRateLimit endpoints.RateLimitConfig
+
+	// If true, the gRPC health service is served alongside the workload api
+	GRPCHealth bool
+
+	// If true, the gRPC reflection service is served alongside the workload
+	// api
+	GRPCReflection bool
+
 	// Directory to store runtime data
 	DataDir string
 
@@ -28,6 +62,9 @@ type Config struct {
 	// The TLS Certificate resource name to use for the default X509-SVID with Envoy SDS
 	DefaultSVIDName string
 
+	// The Validation Context resource name to use for all the X.509 bundles with Envoy SDS
+	DefaultAllBundlesName string
+
 	// If true, the agent will bootstrap insecurely with the server
 	InsecureBootstrap bool
 
@@ -37,14 +74,62 @@ type Config struct {
 	// Configurations for agent plugins
 	PluginConfigs catalog.HCLPluginConfigMap
 
+	// Names of the workload attestors that must succeed for a workload to
+	// be attested. The other workload attestors are optional.
+	RequiredWorkloadAttestors []string
+
+	// NodeLabels, if set, configures the selectors of the node added to
+	// every workload
+	NodeLabels *nodelabels.Config
+
 	Log logrus.FieldLogger
 
+	// LogLevels, if set, allows changing the log levels of Log while the
+	// agent runs.
+	LogLevels log.LevelSetter
+
 	// Address of SPIRE server
 	ServerAddress string
 
+	// ServerGRPCTuning holds the keepalive and flow control settings of the
+	// connections to the server
+	ServerGRPCTuning grpcutil.ClientTuning
+
 	// SyncInterval controls how often the agent sync synchronizer waits
 	SyncInterval time.Duration
 
+	// SyncBackoff determines how the interval between synchronizations
+	// grows while they fail
+	SyncBackoff backoff.Policy
+
+	// AgentSVIDRotation determines how long before expiration the agent SVID
+	// is rotated
+	AgentSVIDRotation rotationutil.Threshold
+
+	// WorkloadSVIDRotation determines how long before expiration the SVIDs
+	// of workloads are renewed
+	WorkloadSVIDRotation rotationutil.Threshold
+
+	// X509SVIDCacheMaxSize is the maximum number of X509-SVIDs cached by
+	// the agent. Zero means there is no limit.
+	X509SVIDCacheMaxSize int
+
+	// PersistSVIDCache, if true, persists the entries, bundles and
+	// X509-SVIDs cached by the agent, encrypted, in the data directory so
+	// that workloads can be served after a restart while the server is
+	// unreachable
+	PersistSVIDCache bool
+
+	// JWTSVIDPrefetch lists the JWT-SVIDs the agent fetches and refreshes
+	// ahead of time
+	JWTSVIDPrefetch []manager.JWTSVIDPrefetch
+
+	// SVIDFileSinks write the SVIDs of selected workloads to files
+	SVIDFileSinks []svidfile.Config
+
+	// FeatureFlags are the experimental feature flags enabled for the agent
+	FeatureFlags fflag.RawConfig
+
 	// Trust domain and associated CA bundle
 	TrustDomain url.URL
 	TrustBundle []*x509.Certificate
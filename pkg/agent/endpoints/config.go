@@ -1,40 +1,75 @@
 package endpoints
 
 import (
+	"crypto/tls"
 	"net"
 
 	discovery_v2 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
 	secret_v3 "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
 	"github.com/sirupsen/logrus"
-	workload_pb "github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
 	attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/endpoints/sdsv2"
 	"github.com/spiffe/spire/pkg/agent/endpoints/sdsv3"
 	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
 	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
 type Config struct {
 	BindAddr *net.UnixAddr
 
+	// Additional UNIX domain sockets the Workload and SDS APIs are served on
+	AdditionalBindAddrs []*net.UnixAddr
+
+	// Loopback TCP address the Workload and SDS APIs are served on. If nil,
+	// the APIs are only served over UNIX domain sockets.
+	TCPBindAddr *net.TCPAddr
+
+	// TLS configuration used to secure the TCP listener. It must require
+	// and verify client certificates.
+	TCPTLSConfig *tls.Config
+
+	// UNIX domain socket the Workload API HTTP gateway is served on. If nil,
+	// the gateway is disabled.
+	HTTPBindAddr *net.UnixAddr
+
 	Attestor attestor.Attestor
 
 	Manager manager.Manager
 
+	// JWTSVIDRotation determines when the JWT-SVIDs streamed to workloads
+	// are renewed
+	JWTSVIDRotation rotationutil.Threshold
+
 	Log logrus.FieldLogger
 
 	Metrics telemetry.Metrics
 
+	// RateLimit configures the rate limits imposed on each caller of the
+	// Workload API
+	RateLimit RateLimitConfig
+
+	// GRPCHealth, if true, serves the standard gRPC health service alongside
+	// the Workload and SDS APIs
+	GRPCHealth bool
+
+	// GRPCReflection, if true, serves the gRPC server reflection service
+	// alongside the Workload and SDS APIs
+	GRPCReflection bool
+
 	// The TLS Certificate resource name to use for the default X509-SVID with Envoy SDS
 	DefaultSVIDName string
 
 	// The Validation Context resource name to use for the default X.509 bundle with Envoy SDS
 	DefaultBundleName string
 
+	// The Validation Context resource name to use for all the X.509 bundles with Envoy SDS
+	DefaultAllBundlesName string
+
 	// Hooks used by the unit tests to assert that the configuration provided
 	// to each handler is correct and return fake handlers.
-	newWorkloadAPIHandler func(workload.Config) workload_pb.SpiffeWorkloadAPIServer
+	newWorkloadAPIHandler func(workload.Config) WorkloadAPIServer
 	newSDSv2Handler       func(sdsv2.Config) discovery_v2.SecretDiscoveryServiceServer
 	newSDSv3Handler       func(sdsv3.Config) secret_v3.SecretDiscoveryServiceServer
 }
@@ -2,8 +2,10 @@ package endpoints
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 
 	discovery_v2 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
@@ -16,28 +18,49 @@ import (
 	"github.com/spiffe/spire/pkg/common/api/middleware"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
+	workload_private "github.com/spiffe/spire/proto/private/agent/workload"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 type Server interface {
 	ListenAndServe(ctx context.Context) error
 }
 
+// WorkloadAPIServer is the Workload API server. In addition to the RPCs
+// provided by the go-spiffe bindings it serves FetchX509Bundles and
+// WatchJWTSVID.
+type WorkloadAPIServer interface {
+	workload_pb.SpiffeWorkloadAPIServer
+	workload_private.Server
+}
+
 type Endpoints struct {
 	addr              *net.UnixAddr
+	additionalAddrs   []*net.UnixAddr
+	tcpAddr           *net.TCPAddr
+	tcpTLSConfig      *tls.Config
+	httpAddr          *net.UnixAddr
 	log               logrus.FieldLogger
 	metrics           telemetry.Metrics
-	workloadAPIServer workload_pb.SpiffeWorkloadAPIServer
+	middleware        middleware.Middleware
+	workloadAPIServer WorkloadAPIServer
 	sdsv2Server       discovery_v2.SecretDiscoveryServiceServer
 	sdsv3Server       secret_v3.SecretDiscoveryServiceServer
+	healthServer      *health.Server
+	grpcReflection    bool
 }
 
 func New(c Config) *Endpoints {
 	attestor := peerTrackerAttestor{Attestor: c.Attestor}
 
 	if c.newWorkloadAPIHandler == nil {
-		c.newWorkloadAPIHandler = func(c workload.Config) workload_pb.SpiffeWorkloadAPIServer {
+		c.newWorkloadAPIHandler = func(c workload.Config) WorkloadAPIServer {
 			return workload.New(c)
 		}
 	}
@@ -53,8 +76,9 @@ func New(c Config) *Endpoints {
 	}
 
 	workloadAPIServer := c.newWorkloadAPIHandler(workload.Config{
-		Manager:  c.Manager,
-		Attestor: attestor,
+		Manager:         c.Manager,
+		Attestor:        attestor,
+		JWTSVIDRotation: c.JWTSVIDRotation,
 	})
 
 	sdsv2Server := c.newSDSv2Handler(sdsv2.Config{
@@ -65,75 +89,187 @@ func New(c Config) *Endpoints {
 	})
 
 	sdsv3Server := c.newSDSv3Handler(sdsv3.Config{
-		Attestor:          attestor,
-		Manager:           c.Manager,
-		DefaultSVIDName:   c.DefaultSVIDName,
-		DefaultBundleName: c.DefaultBundleName,
+		Attestor:              attestor,
+		Manager:               c.Manager,
+		DefaultSVIDName:       c.DefaultSVIDName,
+		DefaultBundleName:     c.DefaultBundleName,
+		DefaultAllBundlesName: c.DefaultAllBundlesName,
 	})
 
+	var healthServer *health.Server
+	if c.GRPCHealth {
+		healthServer = health.NewServer()
+	}
+
 	return &Endpoints{
 		addr:              c.BindAddr,
+		additionalAddrs:   c.AdditionalBindAddrs,
+		tcpAddr:           c.TCPBindAddr,
+		tcpTLSConfig:      c.TCPTLSConfig,
+		httpAddr:          c.HTTPBindAddr,
 		log:               c.Log,
 		metrics:           c.Metrics,
+		middleware:        Middleware(c.Log, c.Metrics, c.RateLimit),
 		workloadAPIServer: workloadAPIServer,
 		sdsv2Server:       sdsv2Server,
 		sdsv3Server:       sdsv3Server,
+		healthServer:      healthServer,
+		grpcReflection:    c.GRPCReflection,
 	}
 }
 
 func (e *Endpoints) ListenAndServe(ctx context.Context) error {
-	unaryInterceptor, streamInterceptor := middleware.Interceptors(
-		Middleware(e.log, e.metrics),
-	)
+	var servers []*grpc.Server
+	var listeners []net.Listener
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
 
-	server := grpc.NewServer(
-		grpc.Creds(peertracker.NewCredentials()),
-		grpc.UnaryInterceptor(unaryInterceptor),
-		grpc.StreamInterceptor(streamInterceptor),
-	)
+	udsServer := e.newServer(peertracker.NewCredentials())
+	for _, addr := range append([]*net.UnixAddr{e.addr}, e.additionalAddrs...) {
+		l, err := e.createUDSListener(addr)
+		if err != nil {
+			return err
+		}
+		listeners = append(listeners, l)
+		servers = append(servers, udsServer)
+	}
 
-	workload_pb.RegisterSpiffeWorkloadAPIServer(server, e.workloadAPIServer)
-	discovery_v2.RegisterSecretDiscoveryServiceServer(server, e.sdsv2Server)
-	secret_v3.RegisterSecretDiscoveryServiceServer(server, e.sdsv3Server)
+	if e.tcpAddr != nil {
+		l, err := e.createTCPListener(e.tcpAddr)
+		if err != nil {
+			return err
+		}
+		listeners = append(listeners, l)
+		servers = append(servers, e.newServer(peertracker.NewTLSCredentials(e.tcpTLSConfig)))
+	}
 
-	l, err := e.createUDSListener()
-	if err != nil {
-		return err
+	var httpServer *http.Server
+	var httpListener net.Listener
+	if e.httpAddr != nil {
+		l, err := e.createUDSListener(e.httpAddr)
+		if err != nil {
+			return err
+		}
+		defer l.Close()
+		httpServer, httpListener = e.newHTTPGateway(), l
 	}
-	defer l.Close()
 
 	e.log.Info("Starting Workload and SDS APIs")
-	errChan := make(chan error)
-	go func() { errChan <- server.Serve(l) }()
+	serving := len(listeners)
+	errChan := make(chan error, serving+1)
+	for i := range listeners {
+		server, l := servers[i], listeners[i]
+		go func() { errChan <- server.Serve(l) }()
+	}
+	if httpServer != nil {
+		e.log.WithField(telemetry.Address, httpListener.Addr().String()).Info("Starting Workload API HTTP gateway")
+		serving++
+		go func() { errChan <- httpServer.Serve(httpListener) }()
+	}
 
+	var err error
 	select {
 	case err = <-errChan:
 	case <-ctx.Done():
 		e.log.Info("Stopping Workload and SDS APIs")
+	}
+
+	if e.healthServer != nil {
+		// Ends the health watches before the servers are stopped
+		e.healthServer.Shutdown()
+	}
+
+	// Stopping a server more than once is harmless, so there is no need to
+	// deduplicate the server shared by the UDS listeners.
+	for _, server := range servers {
 		server.Stop()
-		err = <-errChan
-		if err == grpc.ErrServerStopped {
-			err = nil
+	}
+	if httpServer != nil {
+		httpServer.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	// Wait for every server to stop, returning the first unexpected error
+	for i := 0; i < serving; i++ {
+		serveErr := <-errChan
+		if serveErr != grpc.ErrServerStopped && serveErr != http.ErrServerClosed && err == nil {
+			err = serveErr
 		}
 	}
 	return err
 }
 
-func (e *Endpoints) createUDSListener() (net.Listener, error) {
-	// Remove uds if already exists
-	os.Remove(e.addr.String())
+func (e *Endpoints) newServer(creds credentials.TransportCredentials) *grpc.Server {
+	unaryInterceptor, streamInterceptor := middleware.Interceptors(e.middleware)
+
+	server := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+		grpc.UnknownServiceHandler(workload_private.UnknownServiceHandler(e.workloadAPIServer)),
+	)
+
+	workload_pb.RegisterSpiffeWorkloadAPIServer(server, e.workloadAPIServer)
+	discovery_v2.RegisterSecretDiscoveryServiceServer(server, e.sdsv2Server)
+	secret_v3.RegisterSecretDiscoveryServiceServer(server, e.sdsv3Server)
+
+	if e.healthServer != nil {
+		for service := range server.GetServiceInfo() {
+			e.healthServer.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_SERVING)
+		}
+		grpc_health_v1.RegisterHealthServer(server, e.healthServer)
+	}
+	if e.grpcReflection {
+		reflection.Register(server)
+	}
+	return server
+}
+
+func (e *Endpoints) createUDSListener(addr *net.UnixAddr) (net.Listener, error) {
+	abstract := util.IsAbstractUnixSocket(addr)
+	if !abstract {
+		// Remove uds if already exists
+		os.Remove(addr.String())
+	}
 
 	unixListener := &peertracker.ListenerFactory{
 		Log: e.log,
 	}
 
-	l, err := unixListener.ListenUnix(e.addr.Network(), e.addr)
+	l, err := unixListener.ListenUnix(addr.Network(), addr)
 	if err != nil {
 		return nil, fmt.Errorf("create UDS listener: %s", err)
 	}
 
-	if err := os.Chmod(e.addr.String(), os.ModePerm); err != nil {
-		return nil, fmt.Errorf("unable to change UDS permissions: %v", err)
+	// Abstract sockets have no permissions to change: any process in the
+	// network namespace can connect, and callers are attested from the peer
+	// credentials like with any other socket
+	if !abstract {
+		if err := os.Chmod(addr.String(), os.ModePerm); err != nil {
+			l.Close()
+			return nil, fmt.Errorf("unable to change UDS permissions: %v", err)
+		}
+	}
+	return l, nil
+}
+
+func (e *Endpoints) createTCPListener(addr *net.TCPAddr) (net.Listener, error) {
+	if !addr.IP.IsLoopback() {
+		return nil, fmt.Errorf("TCP listener address %s is not a loopback address", addr)
+	}
+
+	tcpListener := &peertracker.ListenerFactory{
+		Log: e.log,
+	}
+
+	l, err := tcpListener.ListenTCP(addr.Network(), addr)
+	if err != nil {
+		return nil, fmt.Errorf("create TCP listener: %s", err)
 	}
 	return l, nil
 }
@@ -2,10 +2,15 @@ package endpoints
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +19,7 @@ import (
 	discovery_v2 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
 	discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	secret_v3 "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	workload_pb "github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
@@ -22,15 +28,22 @@ import (
 	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/common/api/rpccontext"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
+	workload_private "github.com/spiffe/spire/proto/private/agent/workload"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
 )
 
@@ -93,6 +106,85 @@ func TestEndpoints(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "workload api serves FetchX509Bundles",
+			do: func(t *testing.T, conn *grpc.ClientConn) {
+				ctx := metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+				stream, err := workload_private.FetchX509Bundles(ctx, conn, &workload_private.X509BundlesRequest{})
+				require.NoError(t, err)
+				_, err = stream.Recv()
+				require.NoError(t, err)
+			},
+			expectedLogs: []spiretest.LogEntry{
+				logEntryWithPID(logrus.InfoLevel, "Success",
+					"method", "FetchX509Bundles",
+					"service", "WorkloadAPI",
+				),
+			},
+			expectedMetrics: []fakemetrics.MetricItem{
+				// Global connection counter and then the increment/decrement of the connection gauge
+				{Type: fakemetrics.IncrCounterType, Key: []string{"workload_api", "connection"}, Val: 1},
+				{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "connections"}, Val: 1},
+				{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "connections"}, Val: 0},
+				// Call counter
+				{Type: fakemetrics.IncrCounterWithLabelsType, Key: []string{"rpc", "workload_api", "fetch_x509_bundles"}, Val: 1, Labels: []metrics.Label{
+					{Name: "status", Value: "OK"},
+				}},
+				{Type: fakemetrics.MeasureSinceWithLabelsType, Key: []string{"rpc", "workload_api", "fetch_x509_bundles", "elapsed_time"}, Val: 0, Labels: []metrics.Label{
+					{Name: "status", Value: "OK"},
+				}},
+			},
+		},
+		{
+			name: "workload api serves WatchJWTSVID",
+			do: func(t *testing.T, conn *grpc.ClientConn) {
+				ctx := metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+				stream, err := workload_private.WatchJWTSVID(ctx, conn, &workload_private.WatchJWTSVIDRequest{Audience: []string{"AUDIENCE"}})
+				require.NoError(t, err)
+				_, err = stream.Recv()
+				require.NoError(t, err)
+			},
+			expectedLogs: []spiretest.LogEntry{
+				logEntryWithPID(logrus.InfoLevel, "Success",
+					"method", "WatchJWTSVID",
+					"service", "WorkloadAPI",
+				),
+			},
+			expectedMetrics: []fakemetrics.MetricItem{
+				// Global connection counter and then the increment/decrement of the connection gauge
+				{Type: fakemetrics.IncrCounterType, Key: []string{"workload_api", "connection"}, Val: 1},
+				{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "connections"}, Val: 1},
+				{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "connections"}, Val: 0},
+				// Call counter
+				{Type: fakemetrics.IncrCounterWithLabelsType, Key: []string{"rpc", "workload_api", "watch_jwtsvid"}, Val: 1, Labels: []metrics.Label{
+					{Name: "status", Value: "OK"},
+				}},
+				{Type: fakemetrics.MeasureSinceWithLabelsType, Key: []string{"rpc", "workload_api", "watch_jwtsvid", "elapsed_time"}, Val: 0, Labels: []metrics.Label{
+					{Name: "status", Value: "OK"},
+				}},
+			},
+		},
+		{
+			name: "workload api rejects unknown methods",
+			do: func(t *testing.T, conn *grpc.ClientConn) {
+				ctx := metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+				err := conn.Invoke(ctx, "/SpiffeWorkloadAPI/Unknown", &workload_private.X509BundlesRequest{}, &workload_private.X509BundlesResponse{})
+				spiretest.AssertGRPCStatus(t, err, codes.Unimplemented, "unknown method /SpiffeWorkloadAPI/Unknown")
+			},
+			expectedMetrics: []fakemetrics.MetricItem{
+				// Global connection counter and then the increment/decrement of the connection gauge
+				{Type: fakemetrics.IncrCounterType, Key: []string{"workload_api", "connection"}, Val: 1},
+				{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "connections"}, Val: 1},
+				{Type: fakemetrics.SetGaugeType, Key: []string{"workload_api", "connections"}, Val: 0},
+				// Call counter
+				{Type: fakemetrics.IncrCounterWithLabelsType, Key: []string{"rpc", "workload_api", "unknown"}, Val: 1, Labels: []metrics.Label{
+					{Name: "status", Value: "Unimplemented"},
+				}},
+				{Type: fakemetrics.MeasureSinceWithLabelsType, Key: []string{"rpc", "workload_api", "unknown", "elapsed_time"}, Val: 0, Labels: []metrics.Label{
+					{Name: "status", Value: "Unimplemented"},
+				}},
+			},
+		},
 		{
 			name: "sds v2 api has peertracker attestor plumbed",
 			do: func(t *testing.T, conn *grpc.ClientConn) {
@@ -160,18 +252,21 @@ func TestEndpoints(t *testing.T) {
 					Net:  "unix",
 					Name: udsPath,
 				},
-				Log:               log,
-				Metrics:           metrics,
-				Attestor:          FakeAttestor{},
-				Manager:           FakeManager{},
-				DefaultSVIDName:   "DefaultSVIDName",
-				DefaultBundleName: "DefaultBundleName",
+				Log:                   log,
+				Metrics:               metrics,
+				Attestor:              FakeAttestor{},
+				Manager:               FakeManager{},
+				JWTSVIDRotation:       rotationutil.Threshold{Fraction: 0.25},
+				DefaultSVIDName:       "DefaultSVIDName",
+				DefaultBundleName:     "DefaultBundleName",
+				DefaultAllBundlesName: "DefaultAllBundlesName",
 
 				// Assert the provided config and return a fake Workload API handler
-				newWorkloadAPIHandler: func(c workload.Config) workload_pb.SpiffeWorkloadAPIServer {
+				newWorkloadAPIHandler: func(c workload.Config) WorkloadAPIServer {
 					attestor, ok := c.Attestor.(peerTrackerAttestor)
 					require.True(t, ok, "attestor was not a peerTrackerAttestor wrapper")
 					assert.Equal(t, FakeManager{}, c.Manager)
+					assert.Equal(t, rotationutil.Threshold{Fraction: 0.25}, c.JWTSVIDRotation)
 					return FakeWorkloadAPIServer{Attestor: attestor}
 				},
 
@@ -192,6 +287,7 @@ func TestEndpoints(t *testing.T) {
 					assert.Equal(t, FakeManager{}, c.Manager)
 					assert.Equal(t, "DefaultSVIDName", c.DefaultSVIDName)
 					assert.Equal(t, "DefaultBundleName", c.DefaultBundleName)
+					assert.Equal(t, "DefaultAllBundlesName", c.DefaultAllBundlesName)
 					return FakeSDSv3Server{Attestor: attestor}
 				},
 			})
@@ -232,6 +328,408 @@ func TestEndpoints(t *testing.T) {
 	}
 }
 
+func TestEndpointsListeners(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	dir := spiretest.TempDir(t)
+	udsPath := filepath.Join(dir, "agent.sock")
+	additionalUDSPath := filepath.Join(dir, "additional.sock")
+
+	caCert, caKey := testca.CreateCACertificate(t, nil, nil)
+	serverCert, serverKey := testca.CreateX509Certificate(t, caCert, caKey,
+		testca.WithIPAddresses(net.IPv4(127, 0, 0, 1)))
+	clientCert, clientKey := testca.CreateX509Certificate(t, caCert, caKey)
+	caPool := util.NewCertPool(caCert)
+
+	// Reserve a free port for the TCP listener
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	tcpAddr := l.Addr().(*net.TCPAddr)
+	require.NoError(t, l.Close())
+
+	log, _ := test.NewNullLogger()
+	endpoints := New(Config{
+		BindAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: udsPath,
+		},
+		AdditionalBindAddrs: []*net.UnixAddr{
+			{
+				Net:  "unix",
+				Name: additionalUDSPath,
+			},
+		},
+		TCPBindAddr: tcpAddr,
+		TCPTLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{
+				{Certificate: [][]byte{serverCert.Raw}, PrivateKey: serverKey},
+			},
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  caPool,
+			MinVersion: tls.VersionTLS12,
+		},
+		Log:      log,
+		Metrics:  fakemetrics.New(),
+		Attestor: FakeAttestor{},
+		Manager:  FakeManager{},
+		newWorkloadAPIHandler: func(c workload.Config) WorkloadAPIServer {
+			return FakeWorkloadAPIServer{Attestor: c.Attestor.(peerTrackerAttestor)}
+		},
+	})
+
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- endpoints.ListenAndServe(ctx)
+	}()
+	defer func() {
+		cancel()
+		assert.NoError(t, <-errCh)
+	}()
+
+	connectParams := grpc.ConnectParams{
+		Backoff: backoff.DefaultConfig,
+	}
+	connectParams.Backoff.BaseDelay = 5 * time.Millisecond
+
+	fetchJWTSVID := func(t *testing.T, target string, creds grpc.DialOption) error {
+		conn, err := grpc.DialContext(ctx, target,
+			grpc.WithConnectParams(connectParams),
+			creds)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		wlClient := workload_pb.NewSpiffeWorkloadAPIClient(conn)
+		ctx := metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+		_, err = wlClient.FetchJWTSVID(ctx, &workload_pb.JWTSVIDRequest{}, grpc.WaitForReady(true))
+		return err
+	}
+
+	t.Run("socket", func(t *testing.T) {
+		require.NoError(t, fetchJWTSVID(t, "unix:///"+udsPath, grpc.WithInsecure()))
+	})
+
+	t.Run("additional socket", func(t *testing.T) {
+		require.NoError(t, fetchJWTSVID(t, "unix:///"+additionalUDSPath, grpc.WithInsecure()))
+	})
+
+	t.Run("tcp with client certificate", func(t *testing.T) {
+		err := fetchJWTSVID(t, tcpAddr.String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{
+				{Certificate: [][]byte{clientCert.Raw}, PrivateKey: clientKey},
+			},
+			RootCAs:    caPool,
+			MinVersion: tls.VersionTLS12,
+		})))
+		require.NoError(t, err)
+	})
+
+	t.Run("tcp without client certificate", func(t *testing.T) {
+		conn, err := grpc.DialContext(ctx, tcpAddr.String(),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+				RootCAs:    caPool,
+				MinVersion: tls.VersionTLS12,
+			})))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		wlClient := workload_pb.NewSpiffeWorkloadAPIClient(conn)
+		ctx := metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+		_, err = wlClient.FetchJWTSVID(ctx, &workload_pb.JWTSVIDRequest{})
+		require.Error(t, err)
+	})
+}
+
+func TestEndpointsAbstractSocket(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("abstract sockets are only supported on Linux")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	addr := &net.UnixAddr{
+		Net:  "unix",
+		Name: fmt.Sprintf("@spire-agent-test-%d", os.Getpid()),
+	}
+
+	log, _ := test.NewNullLogger()
+	endpoints := New(Config{
+		BindAddr: addr,
+		Log:      log,
+		Metrics:  fakemetrics.New(),
+		Attestor: FakeAttestor{},
+		Manager:  FakeManager{},
+		newWorkloadAPIHandler: func(c workload.Config) WorkloadAPIServer {
+			return FakeWorkloadAPIServer{Attestor: c.Attestor.(peerTrackerAttestor)}
+		},
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- endpoints.ListenAndServe(ctx)
+	}()
+	defer func() {
+		cancel()
+		assert.NoError(t, <-errCh)
+	}()
+
+	// The socket is not backed by a file
+	_, err := os.Stat(addr.Name)
+	require.True(t, os.IsNotExist(err))
+
+	conn, err := grpc.DialContext(ctx, addr.Name,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, name string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", name)
+		}))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Callers are attested from the peer credentials of the connection
+	wlClient := workload_pb.NewSpiffeWorkloadAPIClient(conn)
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+	_, err = wlClient.FetchJWTSVID(ctx, &workload_pb.JWTSVIDRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+}
+
+func TestEndpointsHealthAndReflection(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		enabled := enabled
+		t.Run(fmt.Sprintf("enabled=%t", enabled), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			udsPath := filepath.Join(spiretest.TempDir(t), "agent.sock")
+
+			log, hook := test.NewNullLogger()
+			endpoints := New(Config{
+				BindAddr: &net.UnixAddr{
+					Net:  "unix",
+					Name: udsPath,
+				},
+				Log:            log,
+				Metrics:        fakemetrics.New(),
+				Attestor:       FakeAttestor{},
+				Manager:        FakeManager{},
+				GRPCHealth:     enabled,
+				GRPCReflection: enabled,
+			})
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- endpoints.ListenAndServe(ctx)
+			}()
+			defer func() {
+				cancel()
+				assert.NoError(t, <-errCh)
+			}()
+
+			connectParams := grpc.ConnectParams{
+				Backoff: backoff.DefaultConfig,
+			}
+			connectParams.Backoff.BaseDelay = 5 * time.Millisecond
+			conn, err := grpc.DialContext(ctx, "unix:///"+udsPath,
+				grpc.WithConnectParams(connectParams),
+				grpc.WithInsecure())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			healthClient := grpc_health_v1.NewHealthClient(conn)
+			for _, service := range []string{"", "SpiffeWorkloadAPI", "envoy.service.secret.v3.SecretDiscoveryService"} {
+				resp, err := healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service}, grpc.WaitForReady(true))
+				if !enabled {
+					spiretest.AssertGRPCStatus(t, err, codes.Unimplemented, "unknown method /grpc.health.v1.Health/Check")
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+			}
+
+			stream, err := grpc_reflection_v1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+			require.NoError(t, err)
+			require.NoError(t, stream.Send(&grpc_reflection_v1alpha.ServerReflectionRequest{
+				MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_ListServices{},
+			}))
+			resp, err := stream.Recv()
+			if !enabled {
+				spiretest.AssertGRPCStatus(t, err, codes.Unimplemented, "unknown method /grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo")
+				return
+			}
+			require.NoError(t, err)
+			var services []string
+			for _, service := range resp.GetListServicesResponse().Service {
+				services = append(services, service.Name)
+			}
+			require.ElementsMatch(t, []string{
+				"SpiffeWorkloadAPI",
+				"envoy.service.discovery.v2.SecretDiscoveryService",
+				"envoy.service.secret.v3.SecretDiscoveryService",
+				"grpc.health.v1.Health",
+				"grpc.reflection.v1alpha.ServerReflection",
+			}, services)
+
+			// Calls to the health and reflection services are not reported
+			// as a misconfiguration of the connection metrics
+			for _, entry := range hook.AllEntries() {
+				assert.NotEqual(t, logrus.ErrorLevel, entry.Level, entry.Message)
+			}
+		})
+	}
+}
+
+func TestEndpointsHTTPGateway(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	dir := spiretest.TempDir(t)
+	udsPath := filepath.Join(dir, "agent.sock")
+	httpPath := filepath.Join(dir, "agent-http.sock")
+
+	log, hook := test.NewNullLogger()
+	endpoints := New(Config{
+		BindAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: udsPath,
+		},
+		HTTPBindAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: httpPath,
+		},
+		Log:      log,
+		Metrics:  fakemetrics.New(),
+		Attestor: FakeAttestor{},
+		Manager:  FakeManager{},
+		newWorkloadAPIHandler: func(c workload.Config) WorkloadAPIServer {
+			return FakeWorkloadAPIServer{Attestor: c.Attestor.(peerTrackerAttestor)}
+		},
+	})
+
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- endpoints.ListenAndServe(ctx)
+	}()
+	defer func() {
+		cancel()
+		assert.NoError(t, <-errCh)
+	}()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				for {
+					conn, err := d.DialContext(ctx, "unix", httpPath)
+					if err == nil || ctx.Err() != nil {
+						return conn, err
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+			},
+		},
+	}
+
+	do := func(t *testing.T, method, path, body string, securityHeader bool) (int, string) {
+		req, err := http.NewRequestWithContext(ctx, method, "http://workload"+path, strings.NewReader(body))
+		require.NoError(t, err)
+		if securityHeader {
+			req.Header.Set("workload.spiffe.io", "true")
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		respBody, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(respBody)
+	}
+
+	for _, tt := range []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		securityHeader bool
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			name:           "fetch JWT-SVID",
+			method:         http.MethodPost,
+			path:           "/v1/jwtsvid",
+			body:           `{"audience":["AUDIENCE"]}`,
+			securityHeader: true,
+			expectedCode:   http.StatusOK,
+			expectedBody:   `{"svids":[{"spiffe_id":"spiffe://example.org/workload","svid":"AUDIENCE"}]}`,
+		},
+		{
+			name:           "validate JWT-SVID",
+			method:         http.MethodPost,
+			path:           "/v1/jwtsvid/validate",
+			body:           `{"audience":"AUDIENCE","svid":"TOKEN"}`,
+			securityHeader: true,
+			expectedCode:   http.StatusOK,
+			expectedBody:   `{"spiffe_id":"spiffe://example.org/workload","claims":{"aud":"AUDIENCE"}}`,
+		},
+		{
+			name:         "missing security header",
+			method:       http.MethodPost,
+			path:         "/v1/jwtsvid",
+			body:         `{"audience":["AUDIENCE"]}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"code":"InvalidArgument","message":"security header missing from request"}`,
+		},
+		{
+			name:           "malformed body",
+			method:         http.MethodPost,
+			path:           "/v1/jwtsvid",
+			body:           `{"unknown":true}`,
+			securityHeader: true,
+			expectedCode:   http.StatusBadRequest,
+			expectedBody:   `{"code":"InvalidArgument","message":"malformed request body: json: unknown field \"unknown\""}`,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			path:           "/v1/jwtsvid",
+			securityHeader: true,
+			expectedCode:   http.StatusMethodNotAllowed,
+			expectedBody:   `{"code":"Unimplemented","message":"method GET not allowed"}`,
+		},
+		{
+			name:           "unknown path",
+			method:         http.MethodPost,
+			path:           "/v1/x509svid",
+			securityHeader: true,
+			expectedCode:   http.StatusNotFound,
+			expectedBody:   `{"code":"NotFound","message":"unknown path \"/v1/x509svid\""}`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := do(t, tt.method, tt.path, tt.body, tt.securityHeader)
+			require.Equal(t, tt.expectedCode, code)
+			require.JSONEq(t, tt.expectedBody, body)
+		})
+	}
+
+	// The caller is attested through the peer tracker information of the
+	// HTTP connection
+	var successes int
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "Success" {
+			require.EqualValues(t, os.Getpid(), entry.Data[telemetry.PID])
+			successes++
+		}
+	}
+	require.Equal(t, 2, successes)
+}
+
 type FakeManager struct {
 	manager.Manager
 }
@@ -245,7 +743,39 @@ func (s FakeWorkloadAPIServer) FetchJWTSVID(ctx context.Context, in *workload_pb
 	if err := attest(ctx, s.Attestor); err != nil {
 		return nil, err
 	}
-	return &workload_pb.JWTSVIDResponse{}, nil
+	return &workload_pb.JWTSVIDResponse{
+		Svids: []*workload_pb.JWTSVID{
+			{SpiffeId: "spiffe://example.org/workload", Svid: strings.Join(in.Audience, ",")},
+		},
+	}, nil
+}
+
+func (s FakeWorkloadAPIServer) ValidateJWTSVID(ctx context.Context, in *workload_pb.ValidateJWTSVIDRequest) (*workload_pb.ValidateJWTSVIDResponse, error) {
+	if err := attest(ctx, s.Attestor); err != nil {
+		return nil, err
+	}
+	return &workload_pb.ValidateJWTSVIDResponse{
+		SpiffeId: "spiffe://example.org/workload",
+		Claims: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"aud": {Kind: &structpb.Value_StringValue{StringValue: in.Audience}},
+			},
+		},
+	}, nil
+}
+
+func (s FakeWorkloadAPIServer) FetchX509Bundles(_ *workload_private.X509BundlesRequest, stream workload_private.FetchX509BundlesServer) error {
+	if err := attest(stream.Context(), s.Attestor); err != nil {
+		return err
+	}
+	return stream.Send(&workload_private.X509BundlesResponse{})
+}
+
+func (s FakeWorkloadAPIServer) WatchJWTSVID(_ *workload_private.WatchJWTSVIDRequest, stream workload_private.WatchJWTSVIDServer) error {
+	if err := attest(stream.Context(), s.Attestor); err != nil {
+		return err
+	}
+	return stream.Send(&workload_private.WatchJWTSVIDResponse{})
 }
 
 type FakeSDSv2Server struct {
@@ -0,0 +1,247 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/sirupsen/logrus"
+	workload_pb "github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"github.com/spiffe/spire/pkg/common/api/middleware"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	workload_private "github.com/spiffe/spire/proto/private/agent/workload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	httpFetchJWTSVIDPath    = "/v1/jwtsvid"
+	httpValidateJWTSVIDPath = "/v1/jwtsvid/validate"
+
+	// httpSecurityHeader is the HTTP counterpart of the gRPC metadata that
+	// must be present on every Workload API call.
+	httpSecurityHeader = "workload.spiffe.io"
+
+	// maxHTTPRequestBodySize bounds the size of the JSON request bodies
+	maxHTTPRequestBodySize = 64 * 1024
+)
+
+type httpFetchJWTSVIDRequest struct {
+	Audience []string `json:"audience"`
+	SPIFFEID string   `json:"spiffe_id,omitempty"`
+}
+
+type httpFetchJWTSVIDResponse struct {
+	SVIDs []httpJWTSVID `json:"svids"`
+}
+
+type httpJWTSVID struct {
+	SPIFFEID string `json:"spiffe_id"`
+	SVID     string `json:"svid"`
+	Hint     string `json:"hint,omitempty"`
+}
+
+type httpValidateJWTSVIDRequest struct {
+	Audience string `json:"audience"`
+	SVID     string `json:"svid"`
+}
+
+type httpValidateJWTSVIDResponse struct {
+	SPIFFEID string          `json:"spiffe_id"`
+	Claims   json.RawMessage `json:"claims"`
+}
+
+type httpError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// httpGateway exposes the JWT-SVID operations of the Workload API over
+// HTTP with JSON bodies, for workloads that cannot speak gRPC. Requests go
+// through the same middleware as the gRPC calls, so they are subject to the
+// same security header check, rate limits, logging and metrics, and callers
+// are attested using the peer tracker information of the connection.
+type httpGateway struct {
+	log               logrus.FieldLogger
+	unaryInterceptor  grpc.UnaryServerInterceptor
+	workloadAPIServer WorkloadAPIServer
+}
+
+func (e *Endpoints) newHTTPGateway() *http.Server {
+	unaryInterceptor, _ := middleware.Interceptors(e.middleware)
+	return &http.Server{
+		Handler: &httpGateway{
+			log:               e.log,
+			unaryInterceptor:  unaryInterceptor,
+			workloadAPIServer: e.workloadAPIServer,
+		},
+		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+			// Expose the peer tracker information the same way the gRPC
+			// transport credentials do, so workload attestation works
+			// unchanged.
+			if conn, ok := c.(*peertracker.Conn); ok {
+				return peer.NewContext(ctx, &peer.Peer{
+					Addr:     conn.RemoteAddr(),
+					AuthInfo: conn.Info,
+				})
+			}
+			return ctx
+		},
+	}
+}
+
+func (g *httpGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case httpFetchJWTSVIDPath, httpValidateJWTSVIDPath:
+	default:
+		g.writeError(w, http.StatusNotFound, status.Errorf(codes.NotFound, "unknown path %q", r.URL.Path))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		g.writeError(w, http.StatusMethodNotAllowed, status.Errorf(codes.Unimplemented, "method %s not allowed", r.Method))
+		return
+	}
+
+	switch r.URL.Path {
+	case httpFetchJWTSVIDPath:
+		g.fetchJWTSVID(w, r)
+	case httpValidateJWTSVIDPath:
+		g.validateJWTSVID(w, r)
+	}
+}
+
+func (g *httpGateway) fetchJWTSVID(w http.ResponseWriter, r *http.Request) {
+	var req httpFetchJWTSVIDRequest
+	if !g.readRequest(w, r, &req) {
+		return
+	}
+
+	resp, err := g.invoke(r, "/SpiffeWorkloadAPI/FetchJWTSVID", &workload_pb.JWTSVIDRequest{
+		Audience: req.Audience,
+		SpiffeId: req.SPIFFEID,
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.workloadAPIServer.FetchJWTSVID(ctx, req.(*workload_pb.JWTSVIDRequest))
+	})
+	if err != nil {
+		g.writeError(w, httpStatusFromCode(status.Code(err)), err)
+		return
+	}
+
+	out := httpFetchJWTSVIDResponse{
+		SVIDs: []httpJWTSVID{},
+	}
+	for _, svid := range resp.(*workload_pb.JWTSVIDResponse).Svids {
+		out.SVIDs = append(out.SVIDs, httpJWTSVID{
+			SPIFFEID: svid.SpiffeId,
+			SVID:     svid.Svid,
+			Hint:     workload_private.JWTSVIDHint(svid),
+		})
+	}
+	g.writeResponse(w, out)
+}
+
+func (g *httpGateway) validateJWTSVID(w http.ResponseWriter, r *http.Request) {
+	var req httpValidateJWTSVIDRequest
+	if !g.readRequest(w, r, &req) {
+		return
+	}
+
+	resp, err := g.invoke(r, "/SpiffeWorkloadAPI/ValidateJWTSVID", &workload_pb.ValidateJWTSVIDRequest{
+		Audience: req.Audience,
+		Svid:     req.SVID,
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.workloadAPIServer.ValidateJWTSVID(ctx, req.(*workload_pb.ValidateJWTSVIDRequest))
+	})
+	if err != nil {
+		g.writeError(w, httpStatusFromCode(status.Code(err)), err)
+		return
+	}
+
+	validated := resp.(*workload_pb.ValidateJWTSVIDResponse)
+	out := httpValidateJWTSVIDResponse{
+		SPIFFEID: validated.SpiffeId,
+		Claims:   json.RawMessage("{}"),
+	}
+	if validated.Claims != nil {
+		claims, err := (&jsonpb.Marshaler{}).MarshalToString(validated.Claims)
+		if err != nil {
+			g.writeError(w, http.StatusInternalServerError, status.Errorf(codes.Internal, "unable to marshal claims: %v", err))
+			return
+		}
+		out.Claims = json.RawMessage(claims)
+	}
+	g.writeResponse(w, out)
+}
+
+// invoke calls the handler through the Workload API middleware as if the
+// request had been received over gRPC.
+func (g *httpGateway) invoke(r *http.Request, fullMethod string, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	md := metadata.MD{}
+	if values := r.Header.Values(httpSecurityHeader); len(values) > 0 {
+		md.Set(httpSecurityHeader, values...)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	return g.unaryInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+}
+
+func (g *httpGateway) readRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHTTPRequestBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(req); err != nil {
+		g.writeError(w, http.StatusBadRequest, status.Errorf(codes.InvalidArgument, "malformed request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func (g *httpGateway) writeResponse(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		g.log.WithError(err).Debug("Failed to write HTTP gateway response")
+	}
+}
+
+func (g *httpGateway) writeError(w http.ResponseWriter, code int, err error) {
+	st := status.Convert(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(httpError{
+		Code:    st.Code().String(),
+		Message: st.Message(),
+	}); err != nil {
+		g.log.WithError(err).Debug("Failed to write HTTP gateway error")
+	}
+}
+
+// httpStatusFromCode maps the gRPC status codes returned by the Workload API
+// to HTTP status codes.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -32,6 +32,9 @@ func (m *connectionMetrics) Preprocess(ctx context.Context, fullMethod string) (
 		case middleware.EnvoySDSv2ServiceName, middleware.EnvoySDSv3ServiceName:
 			sdsAPITelemetry.IncrSDSAPIConnectionCounter(m.metrics)
 			sdsAPITelemetry.SetSDSAPIConnectionTotalGauge(m.metrics, atomic.AddInt32(&m.sdsAPIConns, 1))
+		case middleware.HealthServiceName, middleware.ReflectionServiceName:
+			// Connections to the health and reflection services are not
+			// tracked
 		default:
 			middleware.LogMisconfiguration(ctx, "unrecognized service for connection metrics: "+names.Service)
 		}
@@ -46,6 +49,9 @@ func (m *connectionMetrics) Postprocess(ctx context.Context, fullMethod string,
 			workloadAPITelemetry.SetConnectionTotalGauge(m.metrics, atomic.AddInt32(&m.workloadAPIConns, -1))
 		case middleware.EnvoySDSv2ServiceName, middleware.EnvoySDSv3ServiceName:
 			sdsAPITelemetry.SetSDSAPIConnectionTotalGauge(m.metrics, atomic.AddInt32(&m.sdsAPIConns, -1))
+		case middleware.HealthServiceName, middleware.ReflectionServiceName:
+			// Connections to the health and reflection services are not
+			// tracked
 		default:
 			middleware.LogMisconfiguration(ctx, "unrecognized service for connection metrics: "+names.Service)
 		}
@@ -20,7 +20,13 @@ func (a peerTrackerAttestor) Attest(ctx context.Context) ([]*common.Selector, er
 		return nil, status.Error(codes.Internal, "peer tracker watcher missing from context")
 	}
 
-	selectors := a.Attestor.Attest(ctx, watcher.PID())
+	selectors, err := a.Attestor.Attest(ctx, watcher.PID())
+	if err != nil {
+		// A required workload attestor failed, which is usually transient
+		// (e.g. the attestor cannot reach the kubelet), so the caller is
+		// told to retry rather than being handed a narrower identity
+		return nil, status.Errorf(codes.Unavailable, "workload attestation failed: %v", err)
+	}
 
 	// Ensure that the original caller is still alive so that we know we didn't
 	// attest some other process that happened to be assigned the original PID
@@ -33,15 +33,27 @@ func TestPeerTrackerAttestor(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, []*common.Selector{{Type: "Type", Value: "Value"}}, selectors)
 	})
+
+	t.Run("fails if a required attestor fails", func(t *testing.T) {
+		attestor := peerTrackerAttestor{Attestor: FakeAttestor{err: errors.New("oh no")}}
+		selectors, err := attestor.Attest(WithFakeWatcher(true))
+		spiretest.AssertGRPCStatus(t, err, codes.Unavailable, "workload attestation failed: oh no")
+		assert.Empty(t, selectors)
+	})
 }
 
-type FakeAttestor struct{}
+type FakeAttestor struct {
+	err error
+}
 
-func (a FakeAttestor) Attest(ctx context.Context, pid int32) []*common.Selector {
+func (a FakeAttestor) Attest(ctx context.Context, pid int32) ([]*common.Selector, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
 	if int(pid) == os.Getpid() {
-		return []*common.Selector{{Type: "Type", Value: "Value"}}
+		return []*common.Selector{{Type: "Type", Value: "Value"}}, nil
 	}
-	return nil
+	return nil, nil
 }
 
 func WithFakeWatcher(alive bool) context.Context {
@@ -0,0 +1,186 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/api/middleware"
+	"github.com/spiffe/spire/pkg/common/api/rpccontext"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	workloadAPITelemetry "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	fetchJWTSVIDMethod = workloadAPIMethodPrefix + "FetchJWTSVID"
+
+	// rateLimiterGCInterval is the interval at which the limiters of the
+	// callers that stopped calling the Workload API are garbage collected.
+	rateLimiterGCInterval = time.Minute
+
+	// maxDelayedCalls is how many calls of a caller can be delayed at once.
+	// Calls over it fail right away, so a caller cannot pile up blocked calls
+	// and goroutines in the agent.
+	maxDelayedCalls = 8
+)
+
+// RateLimitConfig configures the rate limits imposed on each caller of the
+// Workload API. Limits are expressed in calls per second; zero disables the
+// limit.
+type RateLimitConfig struct {
+	// WorkloadAPI limits the calls to the Workload API RPCs that are not
+	// limited by a more specific limit.
+	WorkloadAPI int
+
+	// FetchJWTSVID limits the calls to FetchJWTSVID, which can cause the
+	// agent to call the server when the JWT-SVID is not cached.
+	FetchJWTSVID int
+}
+
+// withRateLimits returns a middleware that imposes the configured limits on
+// the Workload API calls of each caller process. Calls over the limit are
+// delayed until they are allowed, which applies backpressure on the caller
+// without affecting other workloads. Calls over the limit fail with
+// ResourceExhausted when too many calls of the caller are already delayed.
+func withRateLimits(config RateLimitConfig, metrics telemetry.Metrics, clk clock.Clock) middleware.Middleware {
+	return &rateLimiter{
+		config:  config,
+		metrics: metrics,
+		clk:     clk,
+		current: make(map[limiterKey]*callerLimiter),
+		lastGC:  clk.Now(),
+	}
+}
+
+// limiterKey identifies the limiter of a caller for a group of methods
+type limiterKey struct {
+	method string
+	pid    int32
+}
+
+// callerLimiter limits the calls of a caller and tracks how many of them are
+// being delayed
+type callerLimiter struct {
+	*rate.Limiter
+
+	delayed int32
+}
+
+// startDelay reserves a slot for a delayed call, returning false if all the
+// slots of the caller are taken
+func (l *callerLimiter) startDelay() bool {
+	if atomic.AddInt32(&l.delayed, 1) > maxDelayedCalls {
+		atomic.AddInt32(&l.delayed, -1)
+		return false
+	}
+	return true
+}
+
+func (l *callerLimiter) endDelay() {
+	atomic.AddInt32(&l.delayed, -1)
+}
+
+type rateLimiter struct {
+	config  RateLimitConfig
+	metrics telemetry.Metrics
+	clk     clock.Clock
+
+	mtx sync.Mutex
+
+	// previous holds all of the limiters that were current at the last GC
+	previous map[limiterKey]*callerLimiter
+
+	// current holds all of the limiters that have been created or moved
+	// from the previous limiters since the last GC.
+	current map[limiterKey]*callerLimiter
+
+	lastGC time.Time
+}
+
+func (r *rateLimiter) Preprocess(ctx context.Context, fullMethod string) (context.Context, error) {
+	if !isWorkloadAPIMethod(fullMethod) {
+		return ctx, nil
+	}
+
+	// Methods without a specific limit share the limiter of the caller
+	key := limiterKey{method: workloadAPIMethodPrefix}
+	limit := r.config.WorkloadAPI
+	if fullMethod == fetchJWTSVIDMethod && r.config.FetchJWTSVID > 0 {
+		key.method = fullMethod
+		limit = r.config.FetchJWTSVID
+	}
+	if limit <= 0 {
+		return ctx, nil
+	}
+
+	caller, ok := peertracker.CallerFromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	key.pid = caller.PID
+
+	limiter := r.getLimiter(key, limit)
+	if limiter.Allow() {
+		return ctx, nil
+	}
+
+	log := rpccontext.Logger(ctx).WithField(telemetry.Method, fullMethod)
+	workloadAPITelemetry.IncrRateLimitedCounter(r.metrics, strings.TrimPrefix(fullMethod, workloadAPIMethodPrefix))
+
+	if !limiter.startDelay() {
+		log.Warn("Workload API rate limit exceeded; too many calls delayed")
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded with too many calls delayed")
+	}
+	defer limiter.endDelay()
+
+	log.Warn("Workload API rate limit exceeded; delaying call")
+	err := limiter.Wait(ctx)
+	switch {
+	case err == nil:
+		return ctx, nil
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return nil, status.FromContextError(ctx.Err()).Err()
+	default:
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+}
+
+func (r *rateLimiter) Postprocess(ctx context.Context, fullMethod string, handlerInvoked bool, rpcErr error) {
+}
+
+func (r *rateLimiter) getLimiter(key limiterKey, limit int) *callerLimiter {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if limiter, ok := r.current[key]; ok {
+		return limiter
+	}
+
+	// Reuse the limiter of the caller as of the last GC, if any
+	if limiter, ok := r.previous[key]; ok {
+		r.current[key] = limiter
+		delete(r.previous, key)
+		return limiter
+	}
+
+	now := r.clk.Now()
+	if now.Sub(r.lastGC) >= rateLimiterGCInterval {
+		r.previous = r.current
+		r.current = make(map[limiterKey]*callerLimiter)
+		r.lastGC = now
+	}
+
+	limiter := &callerLimiter{
+		Limiter: rate.NewLimiter(rate.Limit(limit), limit),
+	}
+	r.current[key] = limiter
+	return limiter
+}
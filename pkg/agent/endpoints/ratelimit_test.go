@@ -0,0 +1,212 @@
+package endpoints
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/api/rpccontext"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	fetchX509SVIDMethod = workloadAPIMethodPrefix + "FetchX509SVID"
+	sdsMethod           = "/envoy.service.secret.v3.SecretDiscoveryService/StreamSecrets"
+)
+
+func TestRateLimits(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		config       RateLimitConfig
+		method       string
+		allowedCalls int
+	}{
+		{
+			name:         "workload api limit",
+			config:       RateLimitConfig{WorkloadAPI: 2},
+			method:       fetchX509SVIDMethod,
+			allowedCalls: 2,
+		},
+		{
+			name:         "workload api limit applies to FetchJWTSVID without a specific limit",
+			config:       RateLimitConfig{WorkloadAPI: 2},
+			method:       fetchJWTSVIDMethod,
+			allowedCalls: 2,
+		},
+		{
+			name:         "FetchJWTSVID limit",
+			config:       RateLimitConfig{WorkloadAPI: 5, FetchJWTSVID: 1},
+			method:       fetchJWTSVIDMethod,
+			allowedCalls: 1,
+		},
+		{
+			name:         "FetchJWTSVID limit does not apply to other methods",
+			config:       RateLimitConfig{FetchJWTSVID: 1},
+			method:       fetchX509SVIDMethod,
+			allowedCalls: -1,
+		},
+		{
+			name:         "SDS calls are not limited",
+			config:       RateLimitConfig{WorkloadAPI: 1},
+			method:       sdsMethod,
+			allowedCalls: -1,
+		},
+		{
+			name:         "disabled",
+			method:       fetchJWTSVIDMethod,
+			allowedCalls: -1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := fakemetrics.New()
+			m := withRateLimits(tt.config, metrics, clock.NewMock(t))
+
+			log, hook := test.NewNullLogger()
+			ctx := callerContext(log, 1234)
+
+			// The limiter allows bursts up to the limit and calls over it are
+			// delayed, failing when the delay exceeds the deadline of the call.
+			calls := tt.allowedCalls
+			if calls < 0 {
+				calls = 10
+			}
+			for i := 0; i < calls; i++ {
+				_, err := m.Preprocess(ctx, tt.method)
+				require.NoError(t, err)
+			}
+			if tt.allowedCalls < 0 {
+				require.Empty(t, metrics.AllMetrics())
+				return
+			}
+
+			deadlineCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+			defer cancel()
+			_, err := m.Preprocess(deadlineCtx, tt.method)
+			spiretest.RequireGRPCStatusContains(t, err, codes.ResourceExhausted, "would exceed context deadline")
+
+			spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+				{
+					Level:   logrus.WarnLevel,
+					Message: "Workload API rate limit exceeded; delaying call",
+					Data: logrus.Fields{
+						telemetry.Method: tt.method,
+					},
+				},
+			})
+			assert.Equal(t, []fakemetrics.MetricItem{
+				{
+					Type: fakemetrics.IncrCounterWithLabelsType,
+					Key:  []string{telemetry.WorkloadAPI, telemetry.RateLimited},
+					Val:  1,
+					Labels: []telemetry.Label{
+						{Name: telemetry.Method, Value: strings.TrimPrefix(tt.method, workloadAPIMethodPrefix)},
+					},
+				},
+			}, metrics.AllMetrics())
+		})
+	}
+}
+
+func TestRateLimitsArePerCaller(t *testing.T) {
+	m := withRateLimits(RateLimitConfig{WorkloadAPI: 1}, fakemetrics.New(), clock.NewMock(t))
+	log, _ := test.NewNullLogger()
+
+	_, err := m.Preprocess(callerContext(log, 1), fetchX509SVIDMethod)
+	require.NoError(t, err)
+
+	// Another caller is not affected by the first one exhausting its limit
+	_, err = m.Preprocess(callerContext(log, 2), fetchX509SVIDMethod)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(callerContext(log, 1))
+	cancel()
+	_, err = m.Preprocess(ctx, fetchX509SVIDMethod)
+	spiretest.RequireGRPCStatus(t, err, codes.Canceled, "context canceled")
+}
+
+func TestRateLimitsBoundDelayedCalls(t *testing.T) {
+	m := withRateLimits(RateLimitConfig{WorkloadAPI: 1}, fakemetrics.New(), clock.NewMock(t)).(*rateLimiter)
+	log, hook := test.NewNullLogger()
+
+	_, err := m.Preprocess(callerContext(log, 1), fetchX509SVIDMethod)
+	require.NoError(t, err)
+
+	// Fill up the slots for delayed calls of the caller
+	ctx, cancel := context.WithCancel(callerContext(log, 1))
+	defer cancel()
+	errCh := make(chan error, maxDelayedCalls)
+	for i := 0; i < maxDelayedCalls; i++ {
+		go func() {
+			_, err := m.Preprocess(ctx, fetchX509SVIDMethod)
+			errCh <- err
+		}()
+	}
+	limiter := m.getLimiter(limiterKey{method: workloadAPIMethodPrefix, pid: 1}, 1)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&limiter.delayed) == maxDelayedCalls
+	}, time.Minute, time.Millisecond)
+
+	// Further calls fail right away instead of queueing up
+	_, err = m.Preprocess(callerContext(log, 1), fetchX509SVIDMethod)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate limit exceeded with too many calls delayed")
+	assert.Equal(t, "Workload API rate limit exceeded; too many calls delayed", hook.LastEntry().Message)
+
+	// Other callers are not affected
+	_, err = m.Preprocess(callerContext(log, 2), fetchX509SVIDMethod)
+	require.NoError(t, err)
+
+	// The slots are released when the delayed calls end
+	cancel()
+	for i := 0; i < maxDelayedCalls; i++ {
+		spiretest.RequireGRPCStatus(t, <-errCh, codes.Canceled, "context canceled")
+	}
+	require.Zero(t, atomic.LoadInt32(&limiter.delayed))
+}
+
+func TestRateLimitsGarbageCollection(t *testing.T) {
+	clk := clock.NewMock(t)
+	m := withRateLimits(RateLimitConfig{WorkloadAPI: 1}, fakemetrics.New(), clk).(*rateLimiter)
+	log, _ := test.NewNullLogger()
+
+	_, err := m.Preprocess(callerContext(log, 1), fetchX509SVIDMethod)
+	require.NoError(t, err)
+	require.Len(t, m.current, 1)
+
+	// The limiters are moved to the previous generation when a new limiter
+	// is created after the GC interval, and dropped at the next GC unless
+	// they are used in the meantime.
+	clk.Add(rateLimiterGCInterval)
+	_, err = m.Preprocess(callerContext(log, 2), fetchX509SVIDMethod)
+	require.NoError(t, err)
+	require.Len(t, m.previous, 1)
+	require.Len(t, m.current, 1)
+
+	clk.Add(rateLimiterGCInterval)
+	_, err = m.Preprocess(callerContext(log, 3), fetchX509SVIDMethod)
+	require.NoError(t, err)
+	require.Len(t, m.previous, 1)
+	require.Contains(t, m.previous, limiterKey{method: workloadAPIMethodPrefix, pid: 2})
+	require.Len(t, m.current, 1)
+}
+
+func callerContext(log logrus.FieldLogger, pid int32) context.Context {
+	ctx := rpccontext.WithLogger(context.Background(), log)
+	return peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: peertracker.AuthInfo{
+			Caller: peertracker.CallerInfo{PID: pid},
+		},
+	})
+}
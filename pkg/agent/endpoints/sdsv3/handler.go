@@ -3,8 +3,10 @@ package sdsv3
 import (
 	"context"
 	"crypto/rand"
+	"crypto/x509"
 	"encoding/hex"
 	"io"
+	"sort"
 	"strconv"
 
 	core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -39,6 +41,11 @@ type Config struct {
 	Manager           Manager
 	DefaultBundleName string
 	DefaultSVIDName   string
+
+	// DefaultAllBundlesName is the name of the validation context resource
+	// holding the roots of the trust domain bundle and every federated
+	// bundle the workload is authorized for. It is not served if empty.
+	DefaultAllBundlesName string
 }
 
 type Handler struct {
@@ -271,6 +278,16 @@ func (h *Handler) buildResponse(versionInfo string, req *discovery_v3.DiscoveryR
 		}
 	}
 
+	// The all bundles validation context is only served when explicitly
+	// requested, since its roots are a superset of the other contexts.
+	if h.c.DefaultAllBundlesName != "" && names[h.c.DefaultAllBundlesName] {
+		validationContext, err := buildAllBundlesValidationContext(upd, h.c.DefaultAllBundlesName)
+		if err != nil {
+			return nil, err
+		}
+		resp.Resources = append(resp.Resources, validationContext)
+	}
+
 	for i, identity := range upd.Identities {
 		switch {
 		case len(names) == 0 || names[identity.Entry.SpiffeId]:
@@ -334,7 +351,34 @@ func buildValidationContext(bundle *bundleutil.Bundle, defaultBundleName string)
 	if defaultBundleName != "" {
 		name = defaultBundleName
 	}
-	caBytes := pemutil.EncodeCertificates(bundle.RootCAs())
+	return marshalValidationContext(name, bundle.RootCAs())
+}
+
+// buildAllBundlesValidationContext builds a validation context trusting the
+// roots of the trust domain bundle and of all the federated bundles in the
+// update, allowing Envoy to validate peers from any of those trust domains
+// with a single resource.
+func buildAllBundlesValidationContext(upd *cache.WorkloadUpdate, name string) (*any.Any, error) {
+	var rootCAs []*x509.Certificate
+	if upd.Bundle != nil {
+		rootCAs = append(rootCAs, upd.Bundle.RootCAs()...)
+	}
+
+	// Sort federated trust domains so the resource content is stable
+	trustDomainIDs := make([]string, 0, len(upd.FederatedBundles))
+	for trustDomainID := range upd.FederatedBundles {
+		trustDomainIDs = append(trustDomainIDs, trustDomainID)
+	}
+	sort.Strings(trustDomainIDs)
+	for _, trustDomainID := range trustDomainIDs {
+		rootCAs = append(rootCAs, upd.FederatedBundles[trustDomainID].RootCAs()...)
+	}
+
+	return marshalValidationContext(name, rootCAs)
+}
+
+func marshalValidationContext(name string, rootCAs []*x509.Certificate) (*any.Any, error) {
+	caBytes := pemutil.EncodeCertificates(rootCAs)
 	return ptypes.MarshalAny(&tls_v3.Secret{
 		Name: name,
 		Type: &tls_v3.Secret_ValidationContext{
@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
@@ -18,8 +20,10 @@ import (
 	"github.com/spiffe/spire/pkg/common/api/rpccontext"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	workload_private "github.com/spiffe/spire/proto/private/agent/workload"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc/codes"
@@ -37,10 +41,24 @@ type Attestor interface {
 	Attest(ctx context.Context) ([]*common.Selector, error)
 }
 
+// jwtSVIDRetryInterval is how long WatchJWTSVID waits before trying again
+// to renew a JWT-SVID that is past its rotation time, e.g. because the server
+// is unreachable.
+const jwtSVIDRetryInterval = 5 * time.Second
+
 // Handler implements the Workload API interface
 type Config struct {
 	Manager  Manager
 	Attestor Attestor
+
+	// JWTSVIDRotation determines when the JWT-SVIDs streamed by WatchJWTSVID
+	// are renewed. It should match the threshold used by the manager to
+	// renew cached JWT-SVIDs.
+	JWTSVIDRotation rotationutil.Threshold
+
+	// Clock is used to schedule the renewal of the JWT-SVIDs streamed by
+	// WatchJWTSVID. Defaults to the real clock.
+	Clock clock.Clock
 }
 
 type Handler struct {
@@ -48,6 +66,9 @@ type Handler struct {
 }
 
 func New(c Config) *Handler {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
 	return &Handler{
 		c: c,
 	}
@@ -67,7 +88,7 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest
 		return nil, err
 	}
 
-	var spiffeIDs []string
+	var matched []cache.Identity
 	identities := h.c.Manager.MatchingIdentities(selectors)
 	if len(identities) == 0 {
 		log.WithField(telemetry.Registered, false).Error("No identity issued")
@@ -80,11 +101,12 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest
 		if req.SpiffeId != "" && identity.Entry.SpiffeId != req.SpiffeId {
 			continue
 		}
-		spiffeIDs = append(spiffeIDs, identity.Entry.SpiffeId)
+		matched = append(matched, identity)
 	}
 
 	resp = new(workload.JWTSVIDResponse)
-	for _, spiffeID := range spiffeIDs {
+	for _, identity := range dropDuplicateHints(matched, log) {
+		spiffeID := identity.Entry.SpiffeId
 		loopLog := log.WithField(telemetry.SPIFFEID, spiffeID)
 
 		var svid *client.JWTSVID
@@ -93,10 +115,12 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest
 			log.WithError(err).Error("Could not fetch JWT-SVID")
 			return nil, status.Errorf(codes.Unavailable, "could not fetch JWT-SVID: %v", err)
 		}
-		resp.Svids = append(resp.Svids, &workload.JWTSVID{
+		jwtSVID := &workload.JWTSVID{
 			SpiffeId: spiffeID,
 			Svid:     svid.Token,
-		})
+		}
+		workload_private.SetJWTSVIDHint(jwtSVID, identity.Entry.Hint)
+		resp.Svids = append(resp.Svids, jwtSVID)
 
 		ttl := time.Until(svid.ExpiresAt)
 		loopLog.WithField(telemetry.TTL, ttl.Seconds()).Debug("Fetched JWT SVID")
@@ -131,6 +155,121 @@ func (h *Handler) FetchJWTBundles(req *workload.JWTBundlesRequest, stream worklo
 	}
 }
 
+// WatchJWTSVID streams the JWT-SVIDs of the workload for the requested
+// audience. A response is sent when the stream is opened and whenever the
+// JWT-SVIDs are renewed or the identities of the workload change. JWT-SVIDs
+// are renewed when they reach their rotation threshold, so workloads are
+// handed fresh JWT-SVIDs before the ones they hold expire.
+func (h *Handler) WatchJWTSVID(req *workload_private.WatchJWTSVIDRequest, stream workload_private.WatchJWTSVIDServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+	if len(req.Audience) == 0 {
+		log.Error("Missing required audience parameter")
+		return status.Error(codes.InvalidArgument, "audience must be specified")
+	}
+
+	selectors, err := h.c.Attestor.Attest(ctx)
+	if err != nil {
+		log.WithError(err).Error("Workload attestation failed")
+		return err
+	}
+
+	subscriber := h.c.Manager.SubscribeToCacheChanges(selectors)
+	defer subscriber.Finish()
+
+	var identities []cache.Identity
+	var previous *workload_private.WatchJWTSVIDResponse
+	var renew *clock.Timer
+	defer func() {
+		if renew != nil {
+			renew.Stop()
+		}
+	}()
+	for {
+		var renewC <-chan time.Time
+		if renew != nil {
+			renewC = renew.C
+		}
+		select {
+		case update := <-subscriber.Updates():
+			identities = update.Identities
+		case <-renewC:
+		case <-ctx.Done():
+			return nil
+		}
+
+		resp, renewAt, err := h.composeWatchJWTSVIDResponse(ctx, req, identities, log)
+		if err != nil {
+			return err
+		}
+		if previous == nil || !proto.Equal(resp, previous) {
+			if err := stream.Send(resp); err != nil {
+				log.WithError(err).Error("Failed to send JWT-SVID response")
+				return err
+			}
+			log.WithField(telemetry.Count, len(resp.Svids)).Debug("Sent JWT-SVIDs")
+			previous = resp
+		}
+
+		if renew != nil {
+			renew.Stop()
+			renew = nil
+		}
+		if !renewAt.IsZero() {
+			wait := renewAt.Sub(h.c.Clock.Now())
+			if wait <= 0 {
+				// The manager handed out a cached JWT-SVID past its rotation
+				// time because it could not be renewed
+				wait = jwtSVIDRetryInterval
+			}
+			renew = h.c.Clock.Timer(wait)
+		}
+	}
+}
+
+// composeWatchJWTSVIDResponse fetches the JWT-SVIDs of the identities for the
+// requested audience. It also returns when the first of them should be
+// renewed, or the zero time if there are none.
+func (h *Handler) composeWatchJWTSVIDResponse(ctx context.Context, req *workload_private.WatchJWTSVIDRequest, identities []cache.Identity, log logrus.FieldLogger) (*workload_private.WatchJWTSVIDResponse, time.Time, error) {
+	if len(identities) == 0 {
+		log.WithField(telemetry.Registered, false).Error("No identity issued")
+		return nil, time.Time{}, status.Error(codes.PermissionDenied, "no identity issued")
+	}
+
+	log = log.WithField(telemetry.Registered, true)
+
+	var matched []cache.Identity
+	for _, identity := range identities {
+		if req.SpiffeId != "" && identity.Entry.SpiffeId != req.SpiffeId {
+			continue
+		}
+		matched = append(matched, identity)
+	}
+
+	resp := new(workload_private.WatchJWTSVIDResponse)
+	var renewAt time.Time
+	for _, identity := range dropDuplicateHints(matched, log) {
+		spiffeID := identity.Entry.SpiffeId
+		svid, err := h.c.Manager.FetchJWTSVID(ctx, spiffeID, req.Audience)
+		if err != nil {
+			log.WithError(err).Error("Could not fetch JWT-SVID")
+			return nil, time.Time{}, status.Errorf(codes.Unavailable, "could not fetch JWT-SVID: %v", err)
+		}
+		resp.Svids = append(resp.Svids, &workload_private.WatchedJWTSVID{
+			SpiffeId:  spiffeID,
+			Svid:      svid.Token,
+			Hint:      identity.Entry.Hint,
+			ExpiresAt: svid.ExpiresAt.Unix(),
+		})
+
+		rotationTime := h.c.JWTSVIDRotation.JWTSVIDRotationTime(svid)
+		if renewAt.IsZero() || rotationTime.Before(renewAt) {
+			renewAt = rotationTime
+		}
+	}
+	return resp, renewAt, nil
+}
+
 // ValidateJWTSVID processes request for JWT-SVID validation
 func (h *Handler) ValidateJWTSVID(ctx context.Context, req *workload.ValidateJWTSVIDRequest) (*workload.ValidateJWTSVIDResponse, error) {
 	log := rpccontext.Logger(ctx)
@@ -198,6 +337,42 @@ func (h *Handler) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.Spi
 	}
 }
 
+// FetchX509Bundles processes request for the X.509 bundles. Unlike
+// FetchX509SVID, the workload does not need to be issued an identity, and a
+// response is only sent when the bundles change.
+func (h *Handler) FetchX509Bundles(_ *workload_private.X509BundlesRequest, stream workload_private.FetchX509BundlesServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	selectors, err := h.c.Attestor.Attest(ctx)
+	if err != nil {
+		log.WithError(err).Error("Workload attestation failed")
+		return err
+	}
+
+	subscriber := h.c.Manager.SubscribeToCacheChanges(selectors)
+	defer subscriber.Finish()
+
+	var previous *workload_private.X509BundlesResponse
+	for {
+		select {
+		case update := <-subscriber.Updates():
+			resp := composeX509BundlesResponse(update)
+			if previous != nil && proto.Equal(resp, previous) {
+				continue
+			}
+			if err := stream.Send(resp); err != nil {
+				log.WithError(err).Error("Failed to send X.509 bundles response")
+				return err
+			}
+			log.WithField(telemetry.Count, len(resp.Bundles)).Debug("Fetched X.509 bundles")
+			previous = resp
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
 func sendX509SVIDResponse(update *cache.WorkloadUpdate, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer, log logrus.FieldLogger) (err error) {
 	if len(update.Identities) == 0 {
 		log.WithField(telemetry.Registered, false).Error("No identity issued")
@@ -206,6 +381,12 @@ func sendX509SVIDResponse(update *cache.WorkloadUpdate, stream workload.SpiffeWo
 
 	log = log.WithField(telemetry.Registered, true)
 
+	// Compose the response from a copy of the update, which is shared with
+	// other subscribers
+	filtered := *update
+	filtered.Identities = dropDuplicateHints(update.Identities, log)
+	update = &filtered
+
 	resp, err := composeX509SVIDResponse(update)
 	if err != nil {
 		log.WithError(err).Error("Could not serialize X.509 SVID response")
@@ -258,6 +439,7 @@ func composeX509SVIDResponse(update *cache.WorkloadUpdate) (*workload.X509SVIDRe
 			X509SvidKey: keyData,
 			Bundle:      bundle,
 		}
+		workload_private.SetX509SVIDHint(svid, identity.Entry.Hint)
 
 		resp.Svids = append(resp.Svids, svid)
 	}
@@ -265,6 +447,44 @@ func composeX509SVIDResponse(update *cache.WorkloadUpdate) (*workload.X509SVIDRe
 	return resp, nil
 }
 
+// dropDuplicateHints drops the identities whose hint is the same as the one of
+// a preceding identity, so workloads can pick an SVID by its hint
+// deterministically. Identities are ordered by entry ID.
+func dropDuplicateHints(identities []cache.Identity, log logrus.FieldLogger) []cache.Identity {
+	hints := make(map[string]bool)
+	out := make([]cache.Identity, 0, len(identities))
+	for _, identity := range identities {
+		hint := identity.Entry.Hint
+		if hint != "" {
+			if hints[hint] {
+				log.WithFields(logrus.Fields{
+					telemetry.RegistrationID: identity.Entry.EntryId,
+					telemetry.SPIFFEID:       identity.Entry.SpiffeId,
+					telemetry.Hint:           hint,
+				}).Warn("Ignoring entry with duplicate hint")
+				continue
+			}
+			hints[hint] = true
+		}
+		out = append(out, identity)
+	}
+	return out
+}
+
+func composeX509BundlesResponse(update *cache.WorkloadUpdate) *workload_private.X509BundlesResponse {
+	bundles := make(map[string][]byte)
+	if update.Bundle != nil {
+		bundles[update.Bundle.TrustDomainID()] = marshalBundle(update.Bundle.RootCAs())
+	}
+	for id, federatedBundle := range update.FederatedBundles {
+		bundles[id] = marshalBundle(federatedBundle.RootCAs())
+	}
+
+	return &workload_private.X509BundlesResponse{
+		Bundles: bundles,
+	}
+}
+
 func sendJWTBundlesResponse(update *cache.WorkloadUpdate, stream workload.SpiffeWorkloadAPI_FetchJWTBundlesServer, log logrus.FieldLogger) (err error) {
 	if len(update.Identities) == 0 {
 		log.WithField(telemetry.Registered, false).Error("No identity issued")
@@ -24,7 +24,9 @@ import (
 	"github.com/spiffe/spire/pkg/common/api/middleware"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	workload_private "github.com/spiffe/spire/proto/private/agent/workload"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/testca"
 	"github.com/stretchr/testify/assert"
@@ -43,9 +45,21 @@ func TestFetchX509SVID(t *testing.T) {
 
 	x509SVID1 := ca.CreateX509SVID(td.NewID("/one"))
 	x509SVID2 := ca.CreateX509SVID(td.NewID("/two"))
+	x509SVID3 := ca.CreateX509SVID(td.NewID("/three"))
 	bundle := ca.Bundle()
 	federatedBundle := testca.New(t, td2).Bundle()
 
+	x509SVIDWithHint := func(svid *x509svid.SVID, hint string) *workloadPB.X509SVID {
+		x509SVID := &workloadPB.X509SVID{
+			SpiffeId:    svid.ID.String(),
+			X509Svid:    x509util.DERFromCertificates(svid.Certificates),
+			X509SvidKey: pkcs8FromSigner(t, svid.PrivateKey),
+			Bundle:      x509util.DERFromCertificates(bundle.X509Authorities()),
+		}
+		workload_private.SetX509SVIDHint(x509SVID, hint)
+		return x509SVID
+	}
+
 	for _, tt := range []struct {
 		name       string
 		updates    []*cache.WorkloadUpdate
@@ -144,6 +158,40 @@ func TestFetchX509SVID(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with hints",
+			updates: []*cache.WorkloadUpdate{
+				{
+					Identities: []cache.Identity{
+						identityWithHint(x509SVID1, "internal"),
+						identityWithHint(x509SVID2, "internal"),
+						identityWithHint(x509SVID3, "external"),
+					},
+					Bundle: utilBundleFromBundle(t, bundle),
+				},
+			},
+			expectCode: codes.OK,
+			expectResp: &workloadPB.X509SVIDResponse{
+				Svids: []*workloadPB.X509SVID{
+					x509SVIDWithHint(x509SVID1, "internal"),
+					x509SVIDWithHint(x509SVID3, "external"),
+				},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.WarnLevel,
+					Message: "Ignoring entry with duplicate hint",
+					Data: logrus.Fields{
+						"service":    "WorkloadAPI",
+						"method":     "FetchX509SVID",
+						"registered": "true",
+						"entry_id":   "",
+						"spiffe_id":  x509SVID2.ID.String(),
+						"hint":       "internal",
+					},
+				},
+			},
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -166,6 +214,122 @@ func TestFetchX509SVID(t *testing.T) {
 	}
 }
 
+func TestFetchX509Bundles(t *testing.T) {
+	ca := testca.New(t, td)
+	bundle := ca.Bundle()
+
+	// Reuse the CA for the federated bundle to keep the amount of test keys
+	// low. Only the trust domain matters to the handler.
+	federatedBundle := spiffebundle.FromX509Authorities(td2, ca.X509Authorities())
+
+	for _, tt := range []struct {
+		name        string
+		updates     []*cache.WorkloadUpdate
+		attestErr   error
+		expectCode  codes.Code
+		expectMsg   string
+		expectResps []*workload_private.X509BundlesResponse
+		expectLogs  []spiretest.LogEntry
+	}{
+		{
+			name:       "attest error",
+			attestErr:  errors.New("ohno"),
+			expectCode: codes.Unknown,
+			expectMsg:  "ohno",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Workload attestation failed",
+					Data: logrus.Fields{
+						"service":       "WorkloadAPI",
+						"method":        "FetchX509Bundles",
+						logrus.ErrorKey: "ohno",
+					},
+				},
+			},
+		},
+		{
+			name: "no identity issued",
+			updates: []*cache.WorkloadUpdate{
+				{
+					Bundle: utilBundleFromBundle(t, bundle),
+				},
+			},
+			expectCode: codes.OK,
+			expectResps: []*workload_private.X509BundlesResponse{
+				{
+					Bundles: map[string][]byte{
+						bundle.TrustDomain().IDString(): x509util.DERFromCertificates(bundle.X509Authorities()),
+					},
+				},
+			},
+		},
+		{
+			name: "only bundle changes are sent",
+			updates: []*cache.WorkloadUpdate{
+				{
+					Identities: []cache.Identity{
+						{Entry: &common.RegistrationEntry{SpiffeId: td.NewID("/workload").String()}},
+					},
+					Bundle: utilBundleFromBundle(t, bundle),
+					FederatedBundles: map[string]*bundleutil.Bundle{
+						federatedBundle.TrustDomain().IDString(): utilBundleFromBundle(t, federatedBundle),
+					},
+				},
+				{
+					Bundle: utilBundleFromBundle(t, bundle),
+					FederatedBundles: map[string]*bundleutil.Bundle{
+						federatedBundle.TrustDomain().IDString(): utilBundleFromBundle(t, federatedBundle),
+					},
+				},
+				{
+					Bundle: utilBundleFromBundle(t, bundle),
+				},
+			},
+			expectCode: codes.OK,
+			expectResps: []*workload_private.X509BundlesResponse{
+				{
+					Bundles: map[string][]byte{
+						bundle.TrustDomain().IDString():          x509util.DERFromCertificates(bundle.X509Authorities()),
+						federatedBundle.TrustDomain().IDString(): x509util.DERFromCertificates(federatedBundle.X509Authorities()),
+					},
+				},
+				{
+					Bundles: map[string][]byte{
+						bundle.TrustDomain().IDString(): x509util.DERFromCertificates(bundle.X509Authorities()),
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			params := testParams{
+				CA:         ca,
+				Updates:    tt.updates,
+				AttestErr:  tt.attestErr,
+				ExpectLogs: tt.expectLogs,
+			}
+			runTestWithConn(t, params,
+				func(ctx context.Context, conn *grpc.ClientConn) {
+					stream, err := workload_private.FetchX509Bundles(ctx, conn, &workload_private.X509BundlesRequest{})
+					require.NoError(t, err)
+
+					if tt.expectCode != codes.OK {
+						_, err := stream.Recv()
+						spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+						return
+					}
+					for _, expectResp := range tt.expectResps {
+						resp, err := stream.Recv()
+						require.NoError(t, err)
+						spiretest.RequireProtoEqual(t, expectResp, resp)
+					}
+				})
+		})
+	}
+}
+
 func TestFetchJWTSVID(t *testing.T) {
 	ca := testca.New(t, td)
 
@@ -182,6 +346,7 @@ func TestFetchJWTSVID(t *testing.T) {
 		expectCode     codes.Code
 		expectMsg      string
 		expectTokenIDs []spiffeid.ID
+		expectHints    []string
 		expectLogs     []spiretest.LogEntry
 	}{
 		{
@@ -277,6 +442,31 @@ func TestFetchJWTSVID(t *testing.T) {
 			expectCode:     codes.OK,
 			expectTokenIDs: []spiffeid.ID{x509SVID2.ID},
 		},
+		{
+			name: "success with hints",
+			identities: []cache.Identity{
+				identityWithHint(x509SVID1, "internal"),
+				identityWithHint(x509SVID2, "internal"),
+			},
+			audience:       []string{"AUDIENCE"},
+			expectCode:     codes.OK,
+			expectTokenIDs: []spiffeid.ID{x509SVID1.ID},
+			expectHints:    []string{"internal"},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.WarnLevel,
+					Message: "Ignoring entry with duplicate hint",
+					Data: logrus.Fields{
+						"service":    "WorkloadAPI",
+						"method":     "FetchJWTSVID",
+						"registered": "true",
+						"entry_id":   "",
+						"spiffe_id":  x509SVID2.ID.String(),
+						"hint":       "internal",
+					},
+				},
+			},
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -300,15 +490,220 @@ func TestFetchJWTSVID(t *testing.T) {
 						return
 					}
 					var tokenIDs []spiffeid.ID
+					var hints []string
+					for _, svid := range resp.Svids {
+						parsedSVID, err := jwtsvid.ParseInsecure(svid.Svid, tt.audience)
+						require.NoError(t, err, "JWT-SVID token is malformed")
+						tokenIDs = append(tokenIDs, parsedSVID.ID)
+						if hint := workload_private.JWTSVIDHint(svid); hint != "" {
+							hints = append(hints, hint)
+						}
+					}
+					assert.Equal(t, tt.expectTokenIDs, tokenIDs)
+					assert.Equal(t, tt.expectHints, hints)
+				})
+		})
+	}
+}
+
+func TestWatchJWTSVID(t *testing.T) {
+	ca := testca.New(t, td)
+
+	// Only the SPIFFE IDs and hints of the identities matter to the handler
+	id1 := td.NewID("/one")
+	id2 := td.NewID("/two")
+
+	for _, tt := range []struct {
+		name           string
+		updates        []*cache.WorkloadUpdate
+		spiffeID       string
+		audience       []string
+		attestErr      error
+		managerErr     error
+		expectCode     codes.Code
+		expectMsg      string
+		expectTokenIDs []spiffeid.ID
+		expectHints    []string
+		expectLogs     []spiretest.LogEntry
+	}{
+		{
+			name:       "missing required audience",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "audience must be specified",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Missing required audience parameter",
+					Data: logrus.Fields{
+						"service": "WorkloadAPI",
+						"method":  "WatchJWTSVID",
+					},
+				},
+			},
+		},
+		{
+			name:       "attest error",
+			audience:   []string{"AUDIENCE"},
+			attestErr:  errors.New("ohno"),
+			expectCode: codes.Unknown,
+			expectMsg:  "ohno",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Workload attestation failed",
+					Data: logrus.Fields{
+						"service":       "WorkloadAPI",
+						"method":        "WatchJWTSVID",
+						logrus.ErrorKey: "ohno",
+					},
+				},
+			},
+		},
+		{
+			name:       "no identity issued",
+			updates:    []*cache.WorkloadUpdate{{}},
+			audience:   []string{"AUDIENCE"},
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "no identity issued",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "No identity issued",
+					Data: logrus.Fields{
+						"registered": "false",
+						"service":    "WorkloadAPI",
+						"method":     "WatchJWTSVID",
+					},
+				},
+			},
+		},
+		{
+			name: "fetch error",
+			updates: []*cache.WorkloadUpdate{
+				{Identities: []cache.Identity{identityWithID(id1, "")}},
+			},
+			audience:   []string{"AUDIENCE"},
+			managerErr: errors.New("ohno"),
+			expectCode: codes.Unavailable,
+			expectMsg:  "could not fetch JWT-SVID: ohno",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Could not fetch JWT-SVID",
+					Data: logrus.Fields{
+						"service":       "WorkloadAPI",
+						"method":        "WatchJWTSVID",
+						"registered":    "true",
+						logrus.ErrorKey: "ohno",
+					},
+				},
+			},
+		},
+		{
+			name: "success all",
+			updates: []*cache.WorkloadUpdate{
+				{Identities: []cache.Identity{identityWithID(id1, ""), identityWithID(id2, "")}},
+			},
+			audience:       []string{"AUDIENCE"},
+			expectCode:     codes.OK,
+			expectTokenIDs: []spiffeid.ID{id1, id2},
+		},
+		{
+			name: "success specific",
+			updates: []*cache.WorkloadUpdate{
+				{Identities: []cache.Identity{identityWithID(id1, ""), identityWithID(id2, "")}},
+			},
+			spiffeID:       id2.String(),
+			audience:       []string{"AUDIENCE"},
+			expectCode:     codes.OK,
+			expectTokenIDs: []spiffeid.ID{id2},
+		},
+		{
+			name: "success with hints",
+			updates: []*cache.WorkloadUpdate{
+				{Identities: []cache.Identity{identityWithID(id1, "internal"), identityWithID(id2, "external")}},
+			},
+			audience:       []string{"AUDIENCE"},
+			expectCode:     codes.OK,
+			expectTokenIDs: []spiffeid.ID{id1, id2},
+			expectHints:    []string{"internal", "external"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewMock(t)
+			params := testParams{
+				CA:         ca,
+				Clock:      clk,
+				Updates:    tt.updates,
+				AttestErr:  tt.attestErr,
+				ManagerErr: tt.managerErr,
+				ExpectLogs: tt.expectLogs,
+			}
+			runTestWithConn(t, params,
+				func(ctx context.Context, conn *grpc.ClientConn) {
+					stream, err := workload_private.WatchJWTSVID(ctx, conn, &workload_private.WatchJWTSVIDRequest{
+						SpiffeId: tt.spiffeID,
+						Audience: tt.audience,
+					})
+					require.NoError(t, err)
+
+					resp, err := stream.Recv()
+					spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+					if tt.expectCode != codes.OK {
+						assert.Nil(t, resp)
+						return
+					}
+
+					var tokenIDs []spiffeid.ID
+					var hints []string
 					for _, svid := range resp.Svids {
 						parsedSVID, err := jwtsvid.ParseInsecure(svid.Svid, tt.audience)
 						require.NoError(t, err, "JWT-SVID token is malformed")
+						assert.Equal(t, parsedSVID.ID.String(), svid.SpiffeId)
+						assert.Equal(t, clk.Now().Add(time.Hour).Unix(), svid.ExpiresAt)
 						tokenIDs = append(tokenIDs, parsedSVID.ID)
+						if svid.Hint != "" {
+							hints = append(hints, svid.Hint)
+						}
 					}
 					assert.Equal(t, tt.expectTokenIDs, tokenIDs)
+					assert.Equal(t, tt.expectHints, hints)
 				})
 		})
 	}
+
+	t.Run("renewal", func(t *testing.T) {
+		clk := clock.NewMock(t)
+		params := testParams{
+			CA:    ca,
+			Clock: clk,
+			Updates: []*cache.WorkloadUpdate{
+				{Identities: []cache.Identity{identityWithID(id1, "")}},
+			},
+		}
+		runTestWithConn(t, params,
+			func(ctx context.Context, conn *grpc.ClientConn) {
+				stream, err := workload_private.WatchJWTSVID(ctx, conn, &workload_private.WatchJWTSVIDRequest{
+					Audience: []string{"AUDIENCE"},
+				})
+				require.NoError(t, err)
+
+				first, err := stream.Recv()
+				require.NoError(t, err)
+				require.Len(t, first.Svids, 1)
+
+				// The JWT-SVID is renewed when half of its lifetime is left
+				clk.WaitForTimer(time.Minute, "renewal timer was not created")
+				clk.Add(30 * time.Minute)
+
+				second, err := stream.Recv()
+				require.NoError(t, err)
+				require.Len(t, second.Svids, 1)
+				assert.NotEqual(t, first.Svids[0].Svid, second.Svids[0].Svid)
+				assert.Equal(t, first.Svids[0].ExpiresAt+int64((30*time.Minute).Seconds()), second.Svids[0].ExpiresAt)
+			})
+	})
 }
 
 func TestFetchJWTBundles(t *testing.T) {
@@ -580,6 +975,7 @@ func TestValidateJWTSVID(t *testing.T) {
 
 type testParams struct {
 	CA         *testca.CA
+	Clock      *clock.Mock
 	Identities []cache.Identity
 	Updates    []*cache.WorkloadUpdate
 	AttestErr  error
@@ -588,10 +984,22 @@ type testParams struct {
 }
 
 func runTest(t *testing.T, params testParams, fn func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient)) {
+	runTestWithConn(t, params, func(ctx context.Context, conn *grpc.ClientConn) {
+		fn(ctx, workloadPB.NewSpiffeWorkloadAPIClient(conn))
+	})
+}
+
+func runTestWithConn(t *testing.T, params testParams, fn func(ctx context.Context, conn *grpc.ClientConn)) {
 	log, logHook := test.NewNullLogger()
 
+	clk := params.Clock
+	if clk == nil {
+		clk = clock.NewMock(t)
+	}
+
 	manager := &FakeManager{
 		ca:         params.CA,
+		clk:        clk,
 		identities: params.Identities,
 		updates:    params.Updates,
 		err:        params.ManagerErr,
@@ -600,6 +1008,7 @@ func runTest(t *testing.T, params testParams, fn func(ctx context.Context, clien
 	handler := workload.New(workload.Config{
 		Manager:  manager,
 		Attestor: &FakeAttestor{err: params.AttestErr},
+		Clock:    clk,
 	})
 
 	unaryInterceptor, streamInterceptor := middleware.Interceptors(
@@ -609,6 +1018,7 @@ func runTest(t *testing.T, params testParams, fn func(ctx context.Context, clien
 	server := grpc.NewServer(
 		grpc.UnaryInterceptor(unaryInterceptor),
 		grpc.StreamInterceptor(streamInterceptor),
+		grpc.UnknownServiceHandler(workload_private.UnknownServiceHandler(handler)),
 	)
 	workloadPB.RegisterSpiffeWorkloadAPIServer(server, handler)
 	socketPath := spiretest.ServeGRPCServerOnTempSocket(t, server)
@@ -624,7 +1034,7 @@ func runTest(t *testing.T, params testParams, fn func(ctx context.Context, clien
 	require.NoError(t, err)
 	t.Cleanup(func() { conn.Close() })
 
-	fn(ctx, workloadPB.NewSpiffeWorkloadAPIClient(conn))
+	fn(ctx, conn)
 
 	cancel()
 	server.GracefulStop()
@@ -636,6 +1046,7 @@ func runTest(t *testing.T, params testParams, fn func(ctx context.Context, clien
 
 type FakeManager struct {
 	ca          *testca.CA
+	clk         *clock.Mock
 	identities  []cache.Identity
 	updates     []*cache.WorkloadUpdate
 	subscribers int32
@@ -651,8 +1062,11 @@ func (m *FakeManager) FetchJWTSVID(ctx context.Context, spiffeID string, audienc
 	if m.err != nil {
 		return nil, m.err
 	}
+	now := m.clk.Now()
 	return &client.JWTSVID{
-		Token: svid.Marshal(),
+		Token:     svid.Marshal(),
+		IssuedAt:  now,
+		ExpiresAt: now.Add(time.Hour),
 	}, nil
 }
 
@@ -728,6 +1142,18 @@ func identityFromX509SVID(svid *x509svid.SVID) cache.Identity {
 	}
 }
 
+func identityWithHint(svid *x509svid.SVID, hint string) cache.Identity {
+	identity := identityFromX509SVID(svid)
+	identity.Entry.Hint = hint
+	return identity
+}
+
+func identityWithID(id spiffeid.ID, hint string) cache.Identity {
+	return cache.Identity{
+		Entry: &common.RegistrationEntry{SpiffeId: id.String(), Hint: hint},
+	}
+}
+
 func utilBundleFromBundle(t *testing.T, bundle *spiffebundle.Bundle) *bundleutil.Bundle {
 	b, err := bundleutil.BundleFromProto(commonBundleFromBundle(t, bundle))
 	require.NoError(t, err)
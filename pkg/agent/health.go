@@ -0,0 +1,123 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
+)
+
+const (
+	// subsystemCheckInterval is how often the subsystem health checks run. It
+	// is kept short since the Workload API may not be listening yet when the
+	// checks run for the first time.
+	subsystemCheckInterval = 10 * time.Second
+
+	// maxCacheSyncAge is the amount of time after which the cache is
+	// considered stale if it has not been synchronized with the server.
+	maxCacheSyncAge = 5 * time.Minute
+
+	// workloadAPIDialTimeout is the timeout used to reach the Workload API
+	// when checking its health.
+	workloadAPIDialTimeout = 5 * time.Second
+)
+
+// healthSource provides the agent state needed by the subsystem health checks.
+type healthSource interface {
+	GetCurrentCredentials() svid.State
+	GetLastSync() time.Time
+}
+
+// subsystemChecks reports the health of the individual agent subsystems.
+type subsystemChecks struct {
+	source      healthSource
+	bindAddress net.Addr
+	clk         clock.Clock
+}
+
+// addTo registers the subsystem checks with the health checker. Failures that
+// the agent is not able to recover from without restarting are reported as
+// liveness failures, while a stale cache only affects readiness.
+func (s *subsystemChecks) addTo(checker *health.Checker, interval time.Duration) error {
+	if err := checker.AddLivenessCheck("attestation", health.CheckFunc(s.attestation), interval); err != nil {
+		return err
+	}
+	if err := checker.AddLivenessCheck("svid_rotation", health.CheckFunc(s.svidRotation), interval); err != nil {
+		return err
+	}
+	if err := checker.AddCheck("cache_sync", health.CheckFunc(s.cacheSync), interval); err != nil {
+		return err
+	}
+	return checker.AddLivenessCheck("workload_api", health.CheckFunc(s.workloadAPI), interval)
+}
+
+// attestation checks that the agent holds a valid SVID, obtained through node
+// attestation.
+func (s *subsystemChecks) attestation() (interface{}, error) {
+	state := s.source.GetCurrentCredentials()
+	if len(state.SVID) == 0 {
+		return nil, errors.New("agent is not attested")
+	}
+
+	cert := state.SVID[0]
+	details := map[string]interface{}{}
+	if len(cert.URIs) > 0 {
+		details["agent_id"] = cert.URIs[0].String()
+	}
+	if rotationutil.X509Expired(s.clk.Now(), cert) {
+		return details, errors.New("agent SVID has expired")
+	}
+	return details, nil
+}
+
+// svidRotation checks that the agent SVID is being rotated before it expires.
+func (s *subsystemChecks) svidRotation() (interface{}, error) {
+	state := s.source.GetCurrentCredentials()
+	if len(state.SVID) == 0 {
+		return nil, errors.New("agent has no SVID")
+	}
+
+	cert := state.SVID[0]
+	details := map[string]interface{}{
+		"expires_at": cert.NotAfter,
+	}
+	if rotationutil.X509ExpiresSoon(s.clk.Now(), cert) {
+		return details, fmt.Errorf("agent SVID has not been rotated and expires at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	return details, nil
+}
+
+// cacheSync checks that the cache has been synchronized with the server
+// recently.
+func (s *subsystemChecks) cacheSync() (interface{}, error) {
+	lastSync := s.source.GetLastSync()
+	if lastSync.IsZero() {
+		return nil, errors.New("cache has not been synchronized")
+	}
+
+	details := map[string]interface{}{
+		"last_sync": lastSync,
+	}
+	if s.clk.Now().Sub(lastSync) > maxCacheSyncAge {
+		return details, fmt.Errorf("cache has not been synchronized since %s", lastSync.Format(time.RFC3339))
+	}
+	return details, nil
+}
+
+// workloadAPI checks that the Workload API endpoint accepts connections.
+func (s *subsystemChecks) workloadAPI() (interface{}, error) {
+	details := map[string]interface{}{
+		"address": s.bindAddress.String(),
+	}
+	conn, err := net.DialTimeout(s.bindAddress.Network(), s.bindAddress.String(), workloadAPIDialTimeout)
+	if err != nil {
+		return details, fmt.Errorf("unable to reach the Workload API: %v", err)
+	}
+	conn.Close()
+	return details, nil
+}
@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"crypto/x509"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHealthSource struct {
+	state    svid.State
+	lastSync time.Time
+}
+
+func (s *fakeHealthSource) GetCurrentCredentials() svid.State {
+	return s.state
+}
+
+func (s *fakeHealthSource) GetLastSync() time.Time {
+	return s.lastSync
+}
+
+func TestSubsystemChecks(t *testing.T) {
+	clk := clock.NewMock(t)
+	source := &fakeHealthSource{}
+	checks := &subsystemChecks{
+		source:      source,
+		bindAddress: &net.UnixAddr{Net: "unix", Name: filepath.Join(t.TempDir(), "agent.sock")},
+		clk:         clk,
+	}
+
+	// Nothing is available before the agent is attested and synchronized
+	_, err := checks.attestation()
+	assert.EqualError(t, err, "agent is not attested")
+	_, err = checks.svidRotation()
+	assert.EqualError(t, err, "agent has no SVID")
+	_, err = checks.cacheSync()
+	assert.EqualError(t, err, "cache has not been synchronized")
+	_, err = checks.workloadAPI()
+	assert.Contains(t, err.Error(), "unable to reach the Workload API")
+
+	// Cert that's valid for 1hr
+	temp, err := util.NewSVIDTemplate(clk, "spiffe://example.org/spire/agent/test")
+	require.NoError(t, err)
+	cert, _, err := util.SelfSign(temp)
+	require.NoError(t, err)
+	source.state = svid.State{SVID: []*x509.Certificate{cert}}
+	source.lastSync = clk.Now()
+
+	listener, err := net.Listen("unix", checks.bindAddress.String())
+	require.NoError(t, err)
+	defer listener.Close()
+
+	details, err := checks.attestation()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"agent_id": "spiffe://example.org/spire/agent/test"}, details)
+	_, err = checks.svidRotation()
+	assert.NoError(t, err)
+	_, err = checks.cacheSync()
+	assert.NoError(t, err)
+	_, err = checks.workloadAPI()
+	assert.NoError(t, err)
+
+	// The SVID was not rotated and the cache is stale
+	clk.Add(55 * time.Minute)
+	_, err = checks.attestation()
+	assert.NoError(t, err)
+	_, err = checks.svidRotation()
+	assert.EqualError(t, err, "agent SVID has not been rotated and expires at "+cert.NotAfter.Format(time.RFC3339))
+	_, err = checks.cacheSync()
+	assert.EqualError(t, err, "cache has not been synchronized since "+source.lastSync.Format(time.RFC3339))
+
+	// The SVID has expired
+	clk.Add(10 * time.Minute)
+	_, err = checks.attestation()
+	assert.EqualError(t, err, "agent SVID has expired")
+}
@@ -5,10 +5,12 @@ import (
 	"crypto/x509"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	commonselector "github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/common"
 )
@@ -92,7 +94,19 @@ type X509SVID struct {
 // that could OOM the agent on smaller VMs. For this reason, the cache is
 // presumed to own ALL data passing in and out of the cache. Producers and
 // consumers MUST NOT mutate the data.
+//
+// The number of X509-SVIDs held by the cache can be bounded. When the bound
+// is exceeded, the X509-SVIDs for the least recently used entries that have
+// no active subscribers are evicted. Entries without an X509-SVID are only
+// reported as stale when there is room in the cache or when a subscriber
+// needs them, in which case the cache signals through SVIDsNeeded so the
+// X509-SVIDs can be minted on demand.
 type Cache struct {
+	// accessCounter is incremented each time a record is accessed and is
+	// used to order records for LRU eviction. It is accessed atomically and
+	// kept first in the struct for 64-bit alignment.
+	accessCounter uint64
+
 	*BundleCache
 	*JWTSVIDCache
 
@@ -114,6 +128,14 @@ type Cache struct {
 
 	// bundles holds the trust bundles, keyed by trust domain id (i.e. "spiffe://domain.test")
 	bundles map[string]*bundleutil.Bundle
+
+	// svidCacheMaxSize is the maximum number of X509-SVIDs held by the
+	// cache. Zero means there is no limit.
+	svidCacheMaxSize int
+
+	// svidsNeeded is signaled when a subscriber matches entries that do not
+	// have an X509-SVID.
+	svidsNeeded chan struct{}
 }
 
 // StaleEntry holds stale entries with SVIDs expiration time
@@ -124,7 +146,9 @@ type StaleEntry struct {
 	ExpiresAt time.Time
 }
 
-func New(log logrus.FieldLogger, trustDomainID string, bundle *Bundle, metrics telemetry.Metrics) *Cache {
+// New creates a new cache. If svidCacheMaxSize is greater than zero, it
+// bounds the number of X509-SVIDs held by the cache.
+func New(log logrus.FieldLogger, trustDomainID string, bundle *Bundle, metrics telemetry.Metrics, svidCacheMaxSize int) *Cache {
 	return &Cache{
 		BundleCache:  NewBundleCache(trustDomainID, bundle),
 		JWTSVIDCache: NewJWTSVIDCache(),
@@ -138,11 +162,13 @@ func New(log logrus.FieldLogger, trustDomainID string, bundle *Bundle, metrics t
 		bundles: map[string]*bundleutil.Bundle{
 			trustDomainID: bundle,
 		},
+		svidCacheMaxSize: svidCacheMaxSize,
+		svidsNeeded:      make(chan struct{}, 1),
 	}
 }
 
-// Identities is only used by manager tests
-// TODO: We should remove this and find a better way
+// Identities returns the identities in the cache that have an X509-SVID,
+// sorted by entry ID.
 func (c *Cache) Identities() []Identity {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -160,6 +186,29 @@ func (c *Cache) Identities() []Identity {
 	return out
 }
 
+// Entries returns all the registration entries in the cache, whether or not
+// they have an X509-SVID, sorted by entry ID.
+func (c *Cache) Entries() []*common.RegistrationEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make([]*common.RegistrationEntry, 0, len(c.records))
+	for _, record := range c.records {
+		out = append(out, record.entry)
+	}
+	sort.Slice(out, func(a, b int) bool {
+		return out[a].EntryId < out[b].EntryId
+	})
+	return out
+}
+
+// CountEntries returns the number of registration entries in the cache.
+func (c *Cache) CountEntries() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.records)
+}
+
 func (c *Cache) CountSVIDs() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -204,9 +253,20 @@ func (c *Cache) SubscribeToWorkloadUpdates(selectors []*common.Selector) Subscri
 		c.addSelectorIndexSub(s, sub)
 	}
 	c.notify(sub)
+	if c.svidCacheMaxSize > 0 {
+		c.checkMissingSVIDs(sub.set)
+	}
 	return sub
 }
 
+// SVIDsNeeded returns a channel that is signaled when a subscriber matches
+// entries that do not have an X509-SVID, either because it was evicted or
+// because it was never minted. It is only signaled when the X509-SVID cache
+// size is bounded.
+func (c *Cache) SVIDsNeeded() <-chan struct{} {
+	return c.svidsNeeded
+}
+
 // UpdateEntries updates the cache with the provided registration entries and bundles and
 // notifies impacted subscribers. The checkSVID callback, if provided, is used to determine
 // if the SVID for the entry is stale, or otherwise in need of rotation. Entries marked stale
@@ -220,10 +280,10 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 	// domain should NOT be removed even if not present (which should only be
 	// the case if there is a bug on the server) since it is necessary to
 	// authenticate the server.
-	bundleRemoved := false
+	bundleRemoved := make(map[string]bool)
 	for id := range c.bundles {
 		if _, ok := update.Bundles[id]; !ok && id != c.trustDomainID {
-			bundleRemoved = true
+			bundleRemoved[id] = true
 			// bundle no longer exists.
 			c.log.WithField(telemetry.TrustDomainID, id).Debug("Bundle removed")
 			delete(c.bundles, id)
@@ -305,12 +365,13 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 		notifySet.MergeSet(selRem)
 
 		// Determine if there were changes to FederatesWith declarations or
-		// if any federated bundles related to the entry were updated.
+		// if any federated bundles related to the entry were updated or
+		// removed.
 		c.diffFederatesWith(existingEntry, newEntry, fedAdd, fedRem)
 		federatedBundlesChanged := len(fedAdd) > 0 || len(fedRem) > 0
 		if !federatedBundlesChanged {
 			for _, id := range newEntry.FederatesWith {
-				if bundleChanged[id] {
+				if bundleChanged[id] || bundleRemoved[id] {
 					federatedBundlesChanged = true
 					break
 				}
@@ -358,7 +419,7 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 		}
 	}
 
-	if bundleRemoved || len(bundleChanged) > 0 {
+	if len(bundleRemoved) > 0 || len(bundleChanged) > 0 {
 		c.BundleCache.Update(c.bundles)
 	}
 
@@ -386,6 +447,7 @@ func (c *Cache) UpdateSVIDs(update *UpdateSVIDs) {
 		}
 
 		record.svid = svid
+		c.touchRecord(record)
 		notifySet.Merge(record.entry.Selectors...)
 		log := c.log.WithFields(logrus.Fields{
 			telemetry.Entry:    record.entry.EntryId,
@@ -397,15 +459,33 @@ func (c *Cache) UpdateSVIDs(update *UpdateSVIDs) {
 		delete(c.staleEntries, entryID)
 	}
 
+	if c.svidCacheMaxSize > 0 {
+		c.evictSVIDs()
+	}
+
 	c.notifyBySelectors(notifySet)
 }
 
+// MarkSVIDsStale marks the entries with a cached SVID as stale, so their SVIDs
+// are renewed, with new keys, regardless of their expiration.
+func (c *Cache) MarkSVIDsStale() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for entryID, record := range c.records {
+		if record.svid != nil {
+			c.staleEntries[entryID] = true
+		}
+	}
+}
+
 // GetStaleEntries obtains a list of stale entries
 func (c *Cache) GetStaleEntries() []*StaleEntry {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	var staleEntries []*StaleEntry
+	var missing []*cacheRecord
 	for entryID := range c.staleEntries {
 		cachedEntry, ok := c.records[entryID]
 		if !ok {
@@ -417,6 +497,11 @@ func (c *Cache) GetStaleEntries() []*StaleEntry {
 		var expiresAt time.Time
 		if cachedEntry.svid != nil {
 			expiresAt = cachedEntry.svid.Chain[0].NotAfter
+		} else if c.svidCacheMaxSize > 0 {
+			// Entries without an SVID are subject to the cache size limit
+			// and are handled below.
+			missing = append(missing, cachedEntry)
+			continue
 		}
 
 		staleEntries = append(staleEntries, &StaleEntry{
@@ -425,9 +510,131 @@ func (c *Cache) GetStaleEntries() []*StaleEntry {
 		})
 	}
 
+	if len(missing) > 0 {
+		staleEntries = append(staleEntries, c.missingSVIDEntries(missing)...)
+	}
+
 	return staleEntries
 }
 
+// missingSVIDEntries returns the stale entries, among the given records
+// without an SVID, that should have an SVID minted. Records needed by a
+// subscriber are always returned, before any other record. The rest are
+// returned, most recently used first, while there is room in the cache.
+func (c *Cache) missingSVIDEntries(missing []*cacheRecord) []*StaleEntry {
+	active, activeDone := c.activeRecords()
+	defer activeDone()
+
+	sort.Slice(missing, func(a, b int) bool {
+		_, aActive := active[missing[a]]
+		_, bActive := active[missing[b]]
+		if aActive != bActive {
+			return aActive
+		}
+		return atomic.LoadUint64(&missing[a].lastAccess) > atomic.LoadUint64(&missing[b].lastAccess)
+	})
+
+	room := c.svidCacheMaxSize - c.countSVIDs()
+	var staleEntries []*StaleEntry
+	for _, record := range missing {
+		if _, ok := active[record]; !ok {
+			if room <= 0 {
+				break
+			}
+		}
+		room--
+		staleEntries = append(staleEntries, &StaleEntry{
+			Entry: record.entry,
+		})
+	}
+	return staleEntries
+}
+
+// evictSVIDs drops the SVIDs of the least recently used records that are not
+// needed by any subscriber until the cache is within its size limit. Evicted
+// records stay in the cache so their SVIDs can be minted again on demand.
+func (c *Cache) evictSVIDs() {
+	excess := c.countSVIDs() - c.svidCacheMaxSize
+	if excess <= 0 {
+		return
+	}
+
+	active, activeDone := c.activeRecords()
+	defer activeDone()
+
+	var candidates []*cacheRecord
+	for _, record := range c.records {
+		if record.svid == nil {
+			continue
+		}
+		if _, ok := active[record]; ok {
+			continue
+		}
+		candidates = append(candidates, record)
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		return atomic.LoadUint64(&candidates[a].lastAccess) < atomic.LoadUint64(&candidates[b].lastAccess)
+	})
+
+	if len(candidates) > excess {
+		candidates = candidates[:excess]
+	}
+	for _, record := range candidates {
+		record.svid = nil
+		c.staleEntries[record.entry.EntryId] = true
+	}
+	if len(candidates) > 0 {
+		c.log.WithFields(logrus.Fields{
+			telemetry.Count: len(candidates),
+			telemetry.Limit: c.svidCacheMaxSize,
+		}).Debug("Evicted X509-SVIDs from cache")
+	}
+}
+
+// activeRecords returns the set of records needed by at least one
+// subscriber, whether or not they have an SVID.
+func (c *Cache) activeRecords() (recordSet, func()) {
+	active, activeDone := allocRecordSet()
+
+	subs, subsDone := c.allSubscribers()
+	defer subsDone()
+	for sub := range subs {
+		records, recordsDone := c.getRecordsForSelectors(sub.set, false)
+		for record := range records {
+			active[record] = struct{}{}
+		}
+		recordsDone()
+	}
+	return active, activeDone
+}
+
+// checkMissingSVIDs signals that SVIDs are needed if any of the records
+// matching the selector set does not have an SVID.
+func (c *Cache) checkMissingSVIDs(set selectorSet) {
+	records, recordsDone := c.getRecordsForSelectors(set, false)
+	defer recordsDone()
+
+	for record := range records {
+		if record.svid == nil {
+			select {
+			case c.svidsNeeded <- struct{}{}:
+			default:
+			}
+			return
+		}
+	}
+}
+
+func (c *Cache) countSVIDs() int {
+	var count int
+	for _, record := range c.records {
+		if record.svid != nil {
+			count++
+		}
+	}
+	return count
+}
+
 func (c *Cache) updateOrCreateRecord(newEntry *common.RegistrationEntry) (*cacheRecord, *common.RegistrationEntry) {
 	var existingEntry *common.RegistrationEntry
 	record, recordExists := c.records[newEntry.EntryId]
@@ -437,6 +644,19 @@ func (c *Cache) updateOrCreateRecord(newEntry *common.RegistrationEntry) (*cache
 	} else {
 		existingEntry = record.entry
 	}
+	if existingEntry == nil || existingEntry.SelectorExpression != newEntry.SelectorExpression {
+		record.expr = nil
+		if newEntry.SelectorExpression != "" {
+			expr, err := commonselector.ParseExpression(newEntry.SelectorExpression)
+			if err != nil {
+				// The server validates expressions, so this should not
+				// happen. Entries with a malformed expression match no
+				// workload.
+				c.log.WithError(err).WithField(telemetry.Entry, newEntry.EntryId).Warn("Entry has a malformed selector expression")
+			}
+			record.expr = expr
+		}
+	}
 	record.entry = newEntry
 	return record, existingEntry
 }
@@ -579,7 +799,7 @@ func (c *Cache) getSubscribers(set selectorSet) (subscriberSet, func()) {
 }
 
 func (c *Cache) matchingIdentities(set selectorSet) []Identity {
-	records, recordsDone := c.getRecordsForSelectors(set)
+	records, recordsDone := c.getRecordsForSelectors(set, true)
 	defer recordsDone()
 
 	if len(records) == 0 {
@@ -591,6 +811,7 @@ func (c *Cache) matchingIdentities(set selectorSet) []Identity {
 	// TODO: figure out how to determine the "default" identity
 	out := make([]Identity, 0, len(records))
 	for record := range records {
+		c.touchRecord(record)
 		out = append(out, makeIdentity(record))
 	}
 	sortIdentities(out)
@@ -622,16 +843,19 @@ func (c *Cache) buildWorkloadUpdate(set selectorSet) *WorkloadUpdate {
 	return w
 }
 
-func (c *Cache) getRecordsForSelectors(set selectorSet) (recordSet, func()) {
+func (c *Cache) getRecordsForSelectors(set selectorSet, requireSVID bool) (recordSet, func()) {
 	// Build and dedup a list of candidate entries. Ignore those without an
-	// SVID but otherwise don't check for selector set inclusion yet, since
-	// that is a more expensive operation and we could easily have duplicate
-	// entries to check.
+	// SVID (if required) but otherwise don't check for selector set inclusion
+	// yet, since that is a more expensive operation and we could easily have
+	// duplicate entries to check.
 	records, recordsDone := allocRecordSet()
 	for selector := range set {
-		index := c.getSelectorIndex(selector)
+		index, ok := c.selectors[selector]
+		if !ok {
+			continue
+		}
 		for record := range index.records {
-			if record.svid == nil {
+			if requireSVID && record.svid == nil {
 				continue
 			}
 			records[record] = struct{}{}
@@ -647,9 +871,30 @@ func (c *Cache) getRecordsForSelectors(set selectorSet) (recordSet, func()) {
 			}
 		}
 	}
+
+	// Filter out records whose registration entry selector expression is
+	// not satisfied by the selector set.
+	var raw []*common.Selector
+	for record := range records {
+		if record.entry.SelectorExpression == "" {
+			continue
+		}
+		if raw == nil {
+			raw = set.Raw()
+		}
+		if record.expr == nil || !record.expr.Matches(raw) {
+			delete(records, record)
+		}
+	}
 	return records, recordsDone
 }
 
+// touchRecord marks the record as the most recently used. It is safe to call
+// while holding the read lock.
+func (c *Cache) touchRecord(record *cacheRecord) {
+	atomic.StoreUint64(&record.lastAccess, atomic.AddUint64(&c.accessCounter, 1))
+}
+
 // getSelectorIndex gets the selector index for the selector. If one doesn't
 // exist, it is created.
 func (c *Cache) getSelectorIndex(s selector) *selectorIndex {
@@ -662,9 +907,18 @@ func (c *Cache) getSelectorIndex(s selector) *selectorIndex {
 }
 
 type cacheRecord struct {
+	// lastAccess holds the value of the cache access counter when the
+	// record was last accessed. It is accessed atomically and kept first in
+	// the struct for 64-bit alignment.
+	lastAccess uint64
+
 	entry *common.RegistrationEntry
 	svid  *X509SVID
 	subs  map[*subscriber]struct{}
+
+	// expr is the parsed selector expression of the entry, if any. It is
+	// nil if the entry has no expression or it is malformed.
+	expr *commonselector.Expression
 }
 
 func newCacheRecord() *cacheRecord {
@@ -82,6 +82,42 @@ func TestMatchingIdentities(t *testing.T) {
 	}, identities)
 }
 
+func TestMatchingIdentitiesWithSelectorExpression(t *testing.T) {
+	cache := newTestCache()
+
+	// FOO matches any workload with A unless it has B, BAR matches workloads
+	// with A and either C or some D selector, and BAZ has a malformed
+	// expression
+	foo := makeRegistrationEntry("FOO", "A")
+	foo.SelectorExpression = "!test:B"
+	bar := makeRegistrationEntry("BAR", "A")
+	bar.SelectorExpression = "test:C || test:D*"
+	baz := makeRegistrationEntry("BAZ", "A")
+	baz.SelectorExpression = "test:C ||"
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar, baz),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo, bar, baz),
+	})
+
+	assert.Equal(t, []Identity{{Entry: foo}}, cache.MatchingIdentities(makeSelectors("A")))
+	assert.Empty(t, cache.MatchingIdentities(makeSelectors("A", "B")))
+	assert.Equal(t, []Identity{{Entry: bar}, {Entry: foo}}, cache.MatchingIdentities(makeSelectors("A", "C")))
+	assert.Equal(t, []Identity{{Entry: bar}}, cache.MatchingIdentities(makeSelectors("A", "B", "D1")))
+	assert.Empty(t, cache.MatchingIdentities(makeSelectors("C", "D1")))
+
+	// Updating the expression takes effect on the next match
+	foo = makeRegistrationEntry("FOO", "A")
+	foo.SelectorExpression = "test:B"
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar, baz),
+	}, nil)
+	assert.Equal(t, []Identity{{Entry: foo}}, cache.MatchingIdentities(makeSelectors("A", "B")))
+}
+
 func TestCountSVIDs(t *testing.T) {
 	cache := newTestCache()
 
@@ -106,6 +142,21 @@ func TestCountSVIDs(t *testing.T) {
 	require.Equal(t, 1, cache.CountSVIDs())
 }
 
+func TestCountEntries(t *testing.T) {
+	cache := newTestCache()
+	require.Equal(t, 0, cache.CountEntries())
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar),
+	}, nil)
+
+	// Entries are counted whether or not they have an SVID
+	require.Equal(t, 2, cache.CountEntries())
+}
+
 func TestBundleChanges(t *testing.T) {
 	cache := newTestCache()
 
@@ -210,6 +261,30 @@ func TestSomeSubscribersNotifiedOnFederatedBundleChange(t *testing.T) {
 	})
 	assertNoWorkloadUpdate(t, subB)
 
+	// now remove the federated bundle and make sure subA gets notified
+	// without it, but again, not subB.
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle:     bundleV1,
+		Identities: []Identity{{Entry: foo}},
+	})
+	assertNoWorkloadUpdate(t, subB)
+
+	// bring the federated bundle back and make sure subA gets it again.
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1, otherBundleV2),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle:           bundleV1,
+		FederatedBundles: makeBundles(otherBundleV2),
+		Identities:       []Identity{{Entry: foo}},
+	})
+	assertNoWorkloadUpdate(t, subB)
+
 	// now drop the federation and make sure subA is again notified and no
 	// longer has the federated bundle.
 	foo = makeRegistrationEntry("FOO", "A")
@@ -379,7 +454,12 @@ func TestSubcriberNotificationsOnSelectorChanges(t *testing.T) {
 
 func newTestCache() *Cache {
 	log, _ := test.NewNullLogger()
-	return New(log, "spiffe://domain.test", bundleV1, telemetry.Blackhole{})
+	return New(log, "spiffe://domain.test", bundleV1, telemetry.Blackhole{}, 0)
+}
+
+func newTestCacheWithMaxSVIDs(maxSVIDs int) *Cache {
+	log, _ := test.NewNullLogger()
+	return New(log, "spiffe://domain.test", bundleV1, telemetry.Blackhole{}, maxSVIDs)
 }
 
 func TestSubcriberNotifiedWhenEntryDropped(t *testing.T) {
@@ -591,6 +671,114 @@ func TestGetStaleEntries(t *testing.T) {
 	assert.Empty(t, cache.GetStaleEntries())
 }
 
+func TestMarkSVIDsStale(t *testing.T) {
+	cache := newTestCache()
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV2),
+		RegistrationEntries: makeRegistrationEntries(foo, bar),
+	}, nil)
+
+	// Only FOO has an SVID
+	expiresAt := time.Now()
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: map[string]*X509SVID{
+			foo.EntryId: {Chain: []*x509.Certificate{{NotAfter: expiresAt}}},
+		},
+	})
+	assert.Empty(t, cache.GetStaleEntries())
+
+	cache.MarkSVIDsStale()
+	assert.Equal(t, []*StaleEntry{{
+		Entry:     cache.records[foo.EntryId].entry,
+		ExpiresAt: expiresAt,
+	}}, cache.GetStaleEntries())
+}
+
+func TestSVIDCacheMaxSize(t *testing.T) {
+	cache := newTestCacheWithMaxSVIDs(2)
+	markStale := func(existingEntry, newEntry *common.RegistrationEntry, svid *X509SVID) bool {
+		return svid == nil
+	}
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	baz := makeRegistrationEntry("BAZ", "C")
+
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar),
+	}, markStale)
+	require.Len(t, cache.GetStaleEntries(), 2)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo, bar),
+	})
+
+	// Use FOO so BAR becomes the least recently used entry
+	require.Len(t, cache.MatchingIdentities(makeSelectors("A")), 1)
+
+	// The cache is full, so the new entry is not reported as stale
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar, baz),
+	}, markStale)
+	require.Empty(t, cache.GetStaleEntries())
+
+	// SVIDs minted beyond the limit evict the least recently used SVID
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(baz),
+	})
+	require.Equal(t, 2, cache.CountSVIDs())
+	require.Empty(t, cache.MatchingIdentities(makeSelectors("B")))
+
+	// A subscriber that needs the evicted SVID signals that SVIDs are needed
+	// and the entry is reported as stale even though the cache is full
+	sub := cache.SubscribeToWorkloadUpdates(makeSelectors("B"))
+	defer sub.Finish()
+	select {
+	case <-cache.SVIDsNeeded():
+	default:
+		require.FailNow(t, "expected SVIDs needed signal")
+	}
+	require.Equal(t, []*StaleEntry{{Entry: bar}}, cache.GetStaleEntries())
+
+	// SVIDs needed by subscribers are never evicted
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(bar),
+	})
+	require.Equal(t, 2, cache.CountSVIDs())
+	require.Len(t, cache.MatchingIdentities(makeSelectors("B")), 1)
+}
+
+func TestSVIDCacheMaxSizeKeepsActiveSVIDs(t *testing.T) {
+	cache := newTestCacheWithMaxSVIDs(1)
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+
+	subA := cache.SubscribeToWorkloadUpdates(makeSelectors("A"))
+	defer subA.Finish()
+	subB := cache.SubscribeToWorkloadUpdates(makeSelectors("B"))
+	defer subB.Finish()
+
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar),
+	}, func(existingEntry, newEntry *common.RegistrationEntry, svid *X509SVID) bool {
+		return true
+	})
+
+	// Both entries are needed by subscribers, so both are reported as stale
+	// and kept in the cache, even though that exceeds the limit
+	require.Len(t, cache.GetStaleEntries(), 2)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo, bar),
+	})
+	require.Equal(t, 2, cache.CountSVIDs())
+}
+
 func BenchmarkCacheGlobalNotification(b *testing.B) {
 	cache := newTestCache()
 
@@ -6,8 +6,10 @@ import (
 	"io"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/spiffe/spire/pkg/agent/client"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 type JWTSVIDCache struct {
@@ -38,6 +40,53 @@ func (c *JWTSVIDCache) SetJWTSVID(spiffeID string, audience []string, svid *clie
 	c.svids[key] = svid
 }
 
+// CountJWTSVIDs returns the number of JWT-SVIDs in the cache.
+func (c *JWTSVIDCache) CountJWTSVIDs() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.svids)
+}
+
+// PruneExpiredJWTSVIDs removes the JWT-SVIDs that are expired at the given
+// time and returns how many were removed.
+func (c *JWTSVIDCache) PruneExpiredJWTSVIDs(now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var pruned int
+	for key, svid := range c.svids {
+		if !now.Before(svid.ExpiresAt) {
+			delete(c.svids, key)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// PruneTaintedJWTSVIDs removes the JWT-SVIDs signed by one of the given
+// tainted JWT signing keys and returns how many were removed.
+func (c *JWTSVIDCache) PruneTaintedJWTSVIDs(taintedKeyIDs map[string]bool) int {
+	if len(taintedKeyIDs) == 0 {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var pruned int
+	for key, svid := range c.svids {
+		token, err := jwt.ParseSigned(svid.Token)
+		if err != nil || len(token.Headers) == 0 {
+			continue
+		}
+		if taintedKeyIDs[token.Headers[0].KeyID] {
+			delete(c.svids, key)
+			pruned++
+		}
+	}
+	return pruned
+}
+
 func jwtSVIDKey(spiffeID string, audience []string) string {
 	h := sha256.New()
 
@@ -1,11 +1,17 @@
 package cache
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"testing"
 	"time"
 
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 func TestJWTSVIDCache(t *testing.T) {
@@ -24,4 +30,49 @@ func TestJWTSVIDCache(t *testing.T) {
 	actual, ok = cache.GetJWTSVID("spiffe://example.org/blog", []string{"bar"})
 	assert.True(t, ok)
 	assert.Equal(t, expected, actual)
+	assert.Equal(t, 1, cache.CountJWTSVIDs())
+
+	// JWT is not pruned until it expires
+	assert.Equal(t, 0, cache.PruneExpiredJWTSVIDs(now))
+	assert.Equal(t, 1, cache.PruneExpiredJWTSVIDs(now.Add(time.Second)))
+	assert.Equal(t, 0, cache.CountJWTSVIDs())
+	actual, ok = cache.GetJWTSVID("spiffe://example.org/blog", []string{"bar"})
+	assert.False(t, ok)
+	assert.Nil(t, actual)
+}
+
+func TestJWTSVIDCachePruneTainted(t *testing.T) {
+	now := time.Now()
+	svidA := &client.JWTSVID{Token: signToken(t, "A"), IssuedAt: now, ExpiresAt: now.Add(time.Hour)}
+	svidB := &client.JWTSVID{Token: signToken(t, "B"), IssuedAt: now, ExpiresAt: now.Add(time.Hour)}
+
+	cache := NewJWTSVIDCache()
+	cache.SetJWTSVID("spiffe://example.org/blog", []string{"bar"}, svidA)
+	cache.SetJWTSVID("spiffe://example.org/blog", []string{"baz"}, svidB)
+
+	assert.Equal(t, 0, cache.PruneTaintedJWTSVIDs(nil))
+	assert.Equal(t, 0, cache.PruneTaintedJWTSVIDs(map[string]bool{"C": true}))
+	assert.Equal(t, 1, cache.PruneTaintedJWTSVIDs(map[string]bool{"A": true}))
+	assert.Equal(t, 1, cache.CountJWTSVIDs())
+
+	_, ok := cache.GetJWTSVID("spiffe://example.org/blog", []string{"bar"})
+	assert.False(t, ok)
+	actual, ok := cache.GetJWTSVID("spiffe://example.org/blog", []string{"baz"})
+	assert.True(t, ok)
+	assert.Equal(t, svidB, actual)
+}
+
+func signToken(t *testing.T, kid string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.ES256,
+		Key:       jose.JSONWebKey{Key: key, KeyID: kid},
+	}, nil)
+	require.NoError(t, err)
+
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{Subject: "spiffe://example.org/blog"}).CompactSerialize()
+	require.NoError(t, err)
+	return token
 }
@@ -115,6 +115,17 @@ func (set selectorSet) MergeSet(other selectorSet) {
 	}
 }
 
+func (set selectorSet) Raw() []*common.Selector {
+	out := make([]*common.Selector, 0, len(set))
+	for s := range set {
+		out = append(out, &common.Selector{
+			Type:  s.Type,
+			Value: s.Value,
+		})
+	}
+	return out
+}
+
 func (set selectorSet) In(ss ...*common.Selector) bool {
 	for _, s := range ss {
 		if _, ok := set[makeSelector(s)]; !ok {
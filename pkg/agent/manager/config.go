@@ -1,6 +1,7 @@
 package manager
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
 	"net/url"
@@ -10,11 +11,18 @@ import (
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
+// DefaultSyncInterval is how often the cache is synchronized with the server
+// when SyncInterval is not set
+const DefaultSyncInterval = 5 * time.Second
+
 // Config holds a cache manager configuration
 type Config struct {
 	// Agent SVID and key resulting from successful attestation.
@@ -31,10 +39,51 @@ type Config struct {
 	SyncInterval     time.Duration
 	RotationInterval time.Duration
 
+	// SyncBackoff determines how the interval between synchronizations
+	// grows, from SyncInterval, while they fail.
+	SyncBackoff backoff.Policy
+
+	// PersistedCachePath, if set, is where the entries, bundles and
+	// X509-SVIDs of the cache are persisted, encrypted with a key derived
+	// from the agent key, so that workloads can be served after a restart
+	// while the server is unreachable.
+	PersistedCachePath string
+
+	// SVIDRotationThreshold determines how long before expiration the agent
+	// SVID is rotated.
+	SVIDRotationThreshold rotationutil.Threshold
+
+	// WorkloadSVIDRotationThreshold determines how long before expiration
+	// the SVIDs of workloads are renewed.
+	WorkloadSVIDRotationThreshold rotationutil.Threshold
+
+	// GRPCTuning holds the keepalive and flow control settings of the
+	// connections to the server.
+	GRPCTuning grpcutil.ClientTuning
+
+	// SVIDCacheMaxSize is the maximum number of X509-SVIDs cached by the
+	// manager. Zero means there is no limit.
+	SVIDCacheMaxSize int
+
+	// JWTSVIDPrefetch lists the JWT-SVIDs that are fetched and refreshed
+	// ahead of time on every synchronization.
+	JWTSVIDPrefetch []JWTSVIDPrefetch
+
+	// Reattest, if set, performs node attestation again to obtain a new
+	// agent SVID and key, allowing the agent to recover without restarting
+	// when its SVID cannot be renewed.
+	Reattest func(ctx context.Context) ([]*x509.Certificate, *ecdsa.PrivateKey, error)
+
 	// Clk is the clock the manager will use to get time
 	Clk clock.Clock
 }
 
+// JWTSVIDPrefetch identifies a JWT-SVID that is kept fresh in the cache.
+type JWTSVIDPrefetch struct {
+	SpiffeID string
+	Audience []string
+}
+
 // New creates a cache manager based on c's configuration
 func New(c *Config) Manager {
 	return newManager(c)
@@ -42,7 +91,7 @@ func New(c *Config) Manager {
 
 func newManager(c *Config) *manager {
 	if c.SyncInterval == 0 {
-		c.SyncInterval = 5 * time.Second
+		c.SyncInterval = DefaultSyncInterval
 	}
 
 	if c.RotationInterval == 0 {
@@ -53,7 +102,7 @@ func newManager(c *Config) *manager {
 		c.Clk = clock.New()
 	}
 
-	cache := cache.New(c.Log.WithField(telemetry.SubsystemName, telemetry.CacheManager), c.TrustDomain.String(), c.Bundle, c.Metrics)
+	cache := cache.New(c.Log.WithField(telemetry.SubsystemName, telemetry.CacheManager), c.TrustDomain.String(), c.Bundle, c.Metrics, c.SVIDCacheMaxSize)
 
 	rotCfg := &svid.RotatorConfig{
 		Catalog:      c.Catalog,
@@ -65,7 +114,11 @@ func newManager(c *Config) *manager {
 		ServerAddr:   c.ServerAddr,
 		TrustDomain:  c.TrustDomain,
 		Interval:     c.RotationInterval,
+		Reattest:     c.Reattest,
 		Clk:          c.Clk,
+		GRPCTuning:   c.GRPCTuning,
+
+		RotationThreshold: c.SVIDRotationThreshold,
 	}
 	svidRotator, client := svid.NewRotator(rotCfg)
 
@@ -78,6 +131,9 @@ func newManager(c *Config) *manager {
 		bundleCachePath: c.BundleCachePath,
 		client:          client,
 		clk:             c.Clk,
+
+		entriesChanged:    make(chan struct{}, 1),
+		jwtSVIDFetchLocks: make(map[string]*jwtSVIDFetchLock),
 	}
 
 	return m
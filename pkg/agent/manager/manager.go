@@ -6,6 +6,8 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,12 +19,15 @@ import (
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Cache Manager errors
@@ -74,11 +79,23 @@ type Manager interface {
 	// CountSVIDs returns the amount of X509 SVIDs on memory
 	CountSVIDs() int
 
+	// Entries returns the registration entries cached by the agent, whether
+	// or not they have an X509-SVID.
+	Entries() []*common.RegistrationEntry
+
+	// Identities returns the cached identities that have an X509-SVID,
+	// sorted by entry ID.
+	Identities() []cache.Identity
+
 	// GetLastSync returns the last successful rotation timestamp
 	GetLastSync() time.Time
 
 	// GetBundle get latest cached bundle
 	GetBundle() *cache.Bundle
+
+	// Reattest performs node attestation again to obtain a new agent SVID
+	// without restarting the agent.
+	Reattest(ctx context.Context) error
 }
 
 type manager struct {
@@ -99,10 +116,25 @@ type manager struct {
 
 	client client.Client
 
+	// entriesChanged is signaled when the server reports that the authorized
+	// entries changed. Signals are coalesced.
+	entriesChanged chan struct{}
+
+	// jwtSVIDFetchLocks serializes fetches of the same JWT-SVID, keyed by
+	// SPIFFE ID and audience. Protected by jwtSVIDFetchMtx.
+	jwtSVIDFetchMtx   sync.Mutex
+	jwtSVIDFetchLocks map[string]*jwtSVIDFetchLock
+
 	clk clock.Clock
 
 	// Saves last success sync
 	lastSync time.Time
+
+	// persistMtx serializes writes of the persisted cache. persistedKey and
+	// persistedCache hold the agent key and the plaintext of the last write.
+	persistMtx     sync.Mutex
+	persistedKey   *ecdsa.PrivateKey
+	persistedCache []byte
 }
 
 func (m *manager) Initialize(ctx context.Context) error {
@@ -114,12 +146,20 @@ func (m *manager) Initialize(ctx context.Context) error {
 		return fmt.Errorf("failed to store private key: %v", err)
 	}
 
-	m.backoff = backoff.NewBackoff(m.clk, m.c.SyncInterval)
+	m.backoff = backoff.NewBackoffWithPolicy(m.clk, m.c.SyncInterval, m.c.SyncBackoff)
+
+	restored := m.restorePersistedCache()
 
-	err = m.synchronize(ctx)
-	if nodeutil.ShouldAgentReattest(err) {
+	err = m.synchronizeOrReattest(ctx)
+	switch {
+	case nodeutil.ShouldAgentReattest(err):
 		m.c.Log.WithError(err).Error("Agent needs to re-attest: removing SVID and shutting down")
 		m.deleteSVID()
+	case err != nil && restored:
+		// The synchronizer keeps trying to reach the server while the
+		// restored SVIDs are served
+		m.c.Log.WithError(err).Warn("Unable to synchronize with the server; serving the persisted cache")
+		return nil
 	}
 	return err
 }
@@ -127,11 +167,17 @@ func (m *manager) Initialize(ctx context.Context) error {
 func (m *manager) Run(ctx context.Context) error {
 	defer m.client.Release()
 
-	err := util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		m.runSynchronizer,
 		m.runSVIDObserver,
 		m.runBundleObserver,
-		m.svid.Run)
+		m.svid.Run,
+	}
+	if fflag.IsSet(fflag.FlagEntryChangeFeed) {
+		tasks = append(tasks, m.runEntryWatcher)
+	}
+
+	err := util.RunTasks(ctx, tasks...)
 
 	switch {
 	case err == nil || err == context.Canceled:
@@ -179,6 +225,14 @@ func (m *manager) CountSVIDs() int {
 	return m.cache.CountSVIDs()
 }
 
+func (m *manager) Entries() []*common.RegistrationEntry {
+	return m.cache.Entries()
+}
+
+func (m *manager) Identities() []cache.Identity {
+	return m.cache.Identities()
+}
+
 // FetchWorkloadUpdates gets the latest workload update for the selectors
 func (m *manager) FetchWorkloadUpdate(selectors []*common.Selector) *cache.WorkloadUpdate {
 	return m.cache.FetchWorkloadUpdate(selectors)
@@ -188,7 +242,17 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 	now := m.clk.Now()
 
 	cachedSVID, ok := m.cache.GetJWTSVID(spiffeID, audience)
-	if ok && !rotationutil.JWTSVIDExpiresSoon(cachedSVID, now) {
+	if ok && !m.c.WorkloadSVIDRotationThreshold.JWTSVIDExpiresSoon(cachedSVID, now) {
+		return cachedSVID, nil
+	}
+
+	// Concurrent requests for the same JWT-SVID wait for a single fetch
+	// instead of each making a round trip to the server.
+	unlock := m.lockJWTSVIDFetch(spiffeID, audience)
+	defer unlock()
+
+	cachedSVID, ok = m.cache.GetJWTSVID(spiffeID, audience)
+	if ok && !m.c.WorkloadSVIDRotationThreshold.JWTSVIDExpiresSoon(cachedSVID, now) {
 		return cachedSVID, nil
 	}
 
@@ -217,23 +281,62 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 }
 
 func (m *manager) getEntryID(spiffeID string) string {
-	for _, identity := range m.cache.Identities() {
-		if identity.Entry.SpiffeId == spiffeID {
-			return identity.Entry.EntryId
+	for _, entry := range m.cache.Entries() {
+		if entry.SpiffeId == spiffeID {
+			return entry.EntryId
 		}
 	}
 	return ""
 }
 
+type jwtSVIDFetchLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lockJWTSVIDFetch locks fetches of the JWT-SVID for the SPIFFE ID and
+// audience. The returned function releases the lock.
+func (m *manager) lockJWTSVIDFetch(spiffeID string, audience []string) func() {
+	audience = append([]string(nil), audience...)
+	sort.Strings(audience)
+	key := strings.Join(append([]string{spiffeID}, audience...), "\x00")
+
+	m.jwtSVIDFetchMtx.Lock()
+	lock, ok := m.jwtSVIDFetchLocks[key]
+	if !ok {
+		lock = new(jwtSVIDFetchLock)
+		m.jwtSVIDFetchLocks[key] = lock
+	}
+	lock.refs++
+	m.jwtSVIDFetchMtx.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		m.jwtSVIDFetchMtx.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(m.jwtSVIDFetchLocks, key)
+		}
+		m.jwtSVIDFetchMtx.Unlock()
+	}
+}
+
 func (m *manager) runSynchronizer(ctx context.Context) error {
 	for {
 		select {
 		case <-m.clk.After(m.backoff.NextBackOff()):
+		case <-m.cache.SVIDsNeeded():
+			// A workload needs SVIDs that are not cached; synchronize now
+			// so they are minted on demand.
+		case <-m.entriesChanged:
+			// The server reported that the authorized entries changed
 		case <-ctx.Done():
 			return nil
 		}
 
-		err := m.synchronize(ctx)
+		err := m.synchronizeOrReattest(ctx)
 		switch {
 		case err != nil && nodeutil.ShouldAgentReattest(err):
 			m.c.Log.WithError(err).Error("Synchronize failed")
@@ -247,6 +350,39 @@ func (m *manager) runSynchronizer(ctx context.Context) error {
 	}
 }
 
+// runEntryWatcher watches the authorized entries of the agent and signals the
+// synchronizer whenever the server reports that they changed. The watch is
+// established again, backing off on errors, until the context is done.
+func (m *manager) runEntryWatcher(ctx context.Context) error {
+	retry := backoff.NewBackoff(m.clk, m.c.SyncInterval)
+	changed := func() {
+		retry.Reset()
+		select {
+		case m.entriesChanged <- struct{}{}:
+		default:
+		}
+	}
+
+	for {
+		err := m.client.WatchEntries(ctx, changed)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case status.Code(errors.Unwrap(err)) == codes.Unimplemented:
+			m.c.Log.WithError(err).Warn("Server does not support watching authorized entries; synchronizing on the sync interval only")
+			return nil
+		case err != nil:
+			m.c.Log.WithError(err).Warn("Watching authorized entries failed")
+		}
+
+		select {
+		case <-m.clk.After(retry.NextBackOff()):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
 func (m *manager) setLastSync() {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
@@ -261,6 +397,46 @@ func (m *manager) GetLastSync() time.Time {
 	return m.lastSync
 }
 
+func (m *manager) Reattest(ctx context.Context) error {
+	if err := m.svid.Reattest(ctx); err != nil {
+		return err
+	}
+	// Agents are forced to re-attest when their node may be compromised, so
+	// the workload SVIDs are renewed with new keys as well.
+	m.cache.MarkSVIDsStale()
+	return m.storeCredentials(ctx)
+}
+
+// synchronizeOrReattest synchronizes the cache. If the server requires the
+// agent to re-attest and re-attestation is configured, the agent re-attests
+// and synchronization is retried, instead of shutting down the agent.
+func (m *manager) synchronizeOrReattest(ctx context.Context) error {
+	err := m.synchronize(ctx)
+	if m.c.Reattest == nil || !nodeutil.ShouldAgentReattest(err) {
+		return err
+	}
+
+	m.c.Log.WithError(err).Warn("Agent needs to re-attest; re-attesting")
+	if reattestErr := m.Reattest(ctx); reattestErr != nil {
+		m.c.Log.WithError(reattestErr).Error("Could not re-attest agent")
+		return err
+	}
+	return m.synchronize(ctx)
+}
+
+// storeCredentials persists the current agent SVID and key. The SVID observer
+// does the same on every rotation, but it is not running while the manager is
+// being initialized.
+func (m *manager) storeCredentials(ctx context.Context) error {
+	state := m.svid.State()
+	if err := m.storePrivateKey(ctx, state.Key); err != nil {
+		return fmt.Errorf("failed to store private key: %v", err)
+	}
+	m.storeSVID(state.SVID)
+	m.persistCache()
+	return nil
+}
+
 func (m *manager) GetBundle() *cache.Bundle {
 	m.mtx.RLock()
 	defer m.mtx.RUnlock()
@@ -284,6 +460,10 @@ func (m *manager) runSVIDObserver(ctx context.Context) error {
 			}
 
 			m.storeSVID(s.SVID)
+
+			// The persisted cache is encrypted with the agent key, so it is
+			// written again with the new key
+			m.persistCache()
 		}
 	}
 }
@@ -337,4 +517,7 @@ func (m *manager) deleteSVID() {
 	if err := DeleteSVID(m.svidCachePath); err != nil {
 		m.c.Log.WithError(err).Error("Failed to remove SVID")
 	}
+	// The agent is no longer trusted by the server, so the persisted SVIDs
+	// must not be served after a restart either
+	m.deletePersistedCache()
 }
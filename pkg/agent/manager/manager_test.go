@@ -25,6 +25,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	commonutil "github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
 	agentv1 "github.com/spiffe/spire/proto/spire/api/server/agent/v1"
@@ -586,6 +587,86 @@ func TestSynchronization(t *testing.T) {
 	require.Equal(t, clk.Now(), m.GetLastSync())
 }
 
+func TestPersistedCache(t *testing.T) {
+	dir := spiretest.TempDir(t)
+
+	clk := clock.NewMock(t)
+	api := newMockAPI(t, &mockAPIConfig{
+		getAuthorizedEntries: func(*mockAPI, int32, *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error) {
+			return makeGetAuthorizedEntriesResponse(t, "resp1", "resp2"), nil
+		},
+		batchNewX509SVIDEntries: func(*mockAPI, int32) []*common.RegistrationEntry {
+			return makeBatchNewX509SVIDEntries("resp1", "resp2")
+		},
+		svidTTL: 200,
+		clk:     clk,
+	})
+
+	baseSVID, baseSVIDKey := api.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(memory.New()))
+
+	newConfig := func(serverAddr string, svidKey *ecdsa.PrivateKey) *Config {
+		return &Config{
+			ServerAddr:         serverAddr,
+			SVID:               baseSVID,
+			SVIDKey:            svidKey,
+			Log:                testLogger,
+			TrustDomain:        trustDomainID,
+			SVIDCachePath:      path.Join(dir, "svid.der"),
+			BundleCachePath:    path.Join(dir, "bundle.der"),
+			PersistedCachePath: path.Join(dir, "svid_cache.enc"),
+			Bundle:             api.bundle,
+			Metrics:            &telemetry.Blackhole{},
+			RotationInterval:   time.Hour,
+			SyncInterval:       time.Hour,
+			Clk:                clk,
+			Catalog:            cat,
+		}
+	}
+
+	// The cache is persisted once synchronized
+	m := newManager(newConfig(api.addr, baseSVIDKey))
+	require.NoError(t, m.Initialize(context.Background()))
+	expected := m.cache.Identities()
+	require.Len(t, expected, 3)
+
+	// The persisted cache is served when the server is unreachable
+	listener, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	unreachableAddr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	m = newManager(newConfig(unreachableAddr, baseSVIDKey))
+	require.NoError(t, m.Initialize(context.Background()))
+	actual := m.cache.Identities()
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		spiretest.AssertProtoEqual(t, expected[i].Entry, actual[i].Entry)
+		require.True(t, svidsEqual(expected[i].SVID, actual[i].SVID))
+		require.Equal(t, expected[i].PrivateKey, actual[i].PrivateKey)
+	}
+
+	// Expired X509-SVIDs are not restored
+	clk.Add(200 * time.Second)
+	m = newManager(newConfig(unreachableAddr, baseSVIDKey))
+	require.NoError(t, m.Initialize(context.Background()))
+	require.Empty(t, m.cache.Identities())
+	require.Len(t, m.cache.Entries(), 3)
+
+	// The persisted cache cannot be read with another agent key
+	_, otherKey := api.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+	_, _, err = ReadPersistedCache(path.Join(dir, "svid_cache.enc"), otherKey)
+	require.EqualError(t, err, "unable to decrypt persisted cache at "+path.Join(dir, "svid_cache.enc")+"; it was not written with the current agent key")
+	m = newManager(newConfig(unreachableAddr, otherKey))
+	require.Error(t, m.Initialize(context.Background()))
+
+	// The persisted cache is removed with the agent SVID
+	m.deleteSVID()
+	_, _, err = ReadPersistedCache(path.Join(dir, "svid_cache.enc"), baseSVIDKey)
+	require.Equal(t, ErrNotCached, err)
+}
+
 func TestSynchronizationClearsStaleCacheEntries(t *testing.T) {
 	dir := spiretest.TempDir(t)
 
@@ -739,6 +820,190 @@ func TestSynchronizationUpdatesRegistrationEntries(t *testing.T) {
 		regEntriesFromIdentities(m.cache.Identities()))
 }
 
+func TestEntryWatcherTriggersSynchronization(t *testing.T) {
+	dir := spiretest.TempDir(t)
+
+	clk := clock.NewMock(t)
+	api := newMockAPI(t, &mockAPIConfig{
+		getAuthorizedEntries: func(h *mockAPI, count int32, req *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error) {
+			switch count {
+			case 1:
+				return makeGetAuthorizedEntriesResponse(t, "resp2"), nil
+			default:
+				return makeGetAuthorizedEntriesResponse(t, "resp3"), nil
+			}
+		},
+		batchNewX509SVIDEntries: func(h *mockAPI, count int32) []*common.RegistrationEntry {
+			switch count {
+			case 1:
+				return makeBatchNewX509SVIDEntries("resp2")
+			default:
+				return makeBatchNewX509SVIDEntries("resp3")
+			}
+		},
+		watchAuthorizedEntries: func(h *mockAPI, stream entryv1.Entry_WatchAuthorizedEntriesServer) error {
+			if err := stream.Send(&entryv1.WatchAuthorizedEntriesResponse{}); err != nil {
+				return err
+			}
+			<-stream.Context().Done()
+			return nil
+		},
+		svidTTL: 3,
+		clk:     clk,
+	})
+
+	baseSVID, baseSVIDKey := api.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	km := disk.New()
+	_, err := km.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`directory = %q`, dir),
+	})
+	require.NoError(t, err)
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(km))
+
+	c := &Config{
+		ServerAddr:      api.addr,
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainID,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          api.bundle,
+		Metrics:         &telemetry.Blackhole{},
+		SyncInterval:    time.Hour,
+		Clk:             clk,
+		Catalog:         cat,
+	}
+
+	m := newManager(c)
+	require.NoError(t, m.Initialize(context.Background()))
+	compareRegistrationEntries(t,
+		regEntriesMap["resp2"],
+		regEntriesFromIdentities(m.cache.Identities()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- commonutil.RunTasks(ctx, m.runSynchronizer, m.runEntryWatcher)
+	}()
+
+	// The sync interval does not elapse on the mock clock, so the cache is
+	// only updated if the watch triggers a synchronization.
+	expected := regEntriesAsMap(regEntriesMap["resp3"])
+	require.Eventually(t, func() bool {
+		actual := regEntriesAsMap(regEntriesFromIdentities(m.cache.Identities()))
+		for id := range expected {
+			if _, ok := actual[id]; !ok {
+				return false
+			}
+		}
+		return len(actual) == len(expected)
+	}, time.Minute, 10*time.Millisecond)
+	cancel()
+	err = <-done
+	require.True(t, err == nil || err == context.Canceled, "unexpected error: %v", err)
+
+	compareRegistrationEntries(t,
+		regEntriesMap["resp3"],
+		regEntriesFromIdentities(m.cache.Identities()))
+}
+
+func TestEntryWatcherStopsIfServerDoesNotSupportIt(t *testing.T) {
+	clk := clock.NewMock(t)
+	api := newMockAPI(t, &mockAPIConfig{
+		clk: clk,
+	})
+
+	baseSVID, baseSVIDKey := api.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+	c := &Config{
+		ServerAddr:  api.addr,
+		SVID:        baseSVID,
+		SVIDKey:     baseSVIDKey,
+		Log:         testLogger,
+		TrustDomain: trustDomainID,
+		Bundle:      api.bundle,
+		Metrics:     &telemetry.Blackhole{},
+		Clk:         clk,
+		Catalog:     fakeagentcatalog.New(),
+	}
+
+	m := newManager(c)
+	defer m.client.Release()
+
+	require.NoError(t, m.runEntryWatcher(context.Background()))
+	select {
+	case <-m.entriesChanged:
+		t.Fatal("synchronization should not have been triggered")
+	default:
+	}
+}
+
+func TestSynchronizationRotatesSVIDsSignedByTaintedAuthority(t *testing.T) {
+	dir := spiretest.TempDir(t)
+
+	clk := clock.NewMock(t)
+	api := newMockAPI(t, &mockAPIConfig{
+		getAuthorizedEntries: func(h *mockAPI, count int32, req *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error) {
+			return makeGetAuthorizedEntriesResponse(t, "resp2"), nil
+		},
+		batchNewX509SVIDEntries: func(h *mockAPI, count int32) []*common.RegistrationEntry {
+			return makeBatchNewX509SVIDEntries("resp2")
+		},
+		svidTTL: 200,
+		clk:     clk,
+	})
+
+	baseSVID, baseSVIDKey := api.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(memory.New()))
+
+	c := &Config{
+		ServerAddr:      api.addr,
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainID,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          api.bundle,
+		Metrics:         &telemetry.Blackhole{},
+		Clk:             clk,
+		Catalog:         cat,
+	}
+
+	m := newManager(c)
+	require.NoError(t, m.Initialize(context.Background()))
+	require.Equal(t, int32(1), atomic.LoadInt32(&api.batchNewX509SVIDCount))
+
+	// SVIDs that are not expiring are not rotated
+	require.NoError(t, m.synchronize(context.Background()))
+	require.Equal(t, int32(1), atomic.LoadInt32(&api.batchNewX509SVIDCount))
+
+	// taint the CA and start signing SVIDs with a new one
+	taintedCA := api.ca()
+	newCA, newCAKey := createCA(t, clk)
+	bundle, err := bundleutil.BundleFromProto(&common.Bundle{
+		TrustDomainId: "spiffe://" + trustDomain,
+		RootCas: []*common.Certificate{
+			{DerBytes: taintedCA.Raw, TaintedKey: true},
+			{DerBytes: newCA.Raw},
+		},
+	})
+	require.NoError(t, err)
+	api.bundle = bundle
+	api.cakey = newCAKey
+
+	require.NoError(t, m.synchronize(context.Background()))
+	require.Equal(t, int32(2), atomic.LoadInt32(&api.batchNewX509SVIDCount))
+
+	identities := m.cache.Identities()
+	require.NotEmpty(t, identities)
+	for _, identity := range identities {
+		require.NoError(t, identity.SVID[0].CheckSignatureFrom(newCA))
+	}
+}
+
 func TestSubscribersGetUpToDateBundle(t *testing.T) {
 	dir := spiretest.TempDir(t)
 
@@ -983,6 +1248,91 @@ func TestFetchJWTSVID(t *testing.T) {
 	require.Nil(t, svid)
 }
 
+func TestPrefetchJWTSVID(t *testing.T) {
+	dir := spiretest.TempDir(t)
+
+	spiffeID := "spiffe://example.org/blog"
+	audience := []string{"foo"}
+
+	clk := clock.NewMock(t)
+	var newJWTSVIDCount int32
+	var token string
+	api := newMockAPI(t, &mockAPIConfig{
+		getAuthorizedEntries: func(*mockAPI, int32, *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error) {
+			return makeGetAuthorizedEntriesResponse(t, "resp1", "resp2"), nil
+		},
+		batchNewX509SVIDEntries: func(*mockAPI, int32) []*common.RegistrationEntry {
+			return makeBatchNewX509SVIDEntries("resp1", "resp2")
+		},
+		newJWTSVID: func(_ *mockAPI, req *svidv1.NewJWTSVIDRequest) (*svidv1.NewJWTSVIDResponse, error) {
+			atomic.AddInt32(&newJWTSVIDCount, 1)
+			assert.Equal(t, audience, req.Audience)
+			return &svidv1.NewJWTSVIDResponse{
+				Svid: &types.JWTSVID{
+					Token:     token,
+					IssuedAt:  clk.Now().Unix(),
+					ExpiresAt: clk.Now().Add(time.Minute).Unix(),
+				},
+			}, nil
+		},
+		clk:     clk,
+		svidTTL: 200,
+	})
+
+	cat := fakeagentcatalog.New()
+	diskPlugin := disk.New()
+	_, err := diskPlugin.Configure(context.Background(), &plugin.ConfigureRequest{Configuration: fmt.Sprintf("directory = \"%s\"", dir)})
+	require.NoError(t, err)
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(diskPlugin))
+
+	baseSVID, baseSVIDKey := api.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	c := &Config{
+		ServerAddr:      api.addr,
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainID,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          api.bundle,
+		Metrics:         &telemetry.Blackhole{},
+		Catalog:         cat,
+		Clk:             clk,
+		JWTSVIDPrefetch: []JWTSVIDPrefetch{
+			{SpiffeID: spiffeID, Audience: audience},
+			// Ignored since the agent is not authorized for it
+			{SpiffeID: "spiffe://example.org/unknown", Audience: audience},
+		},
+	}
+
+	// The JWT-SVID is fetched during the initial synchronization
+	token = "A"
+	m := newManager(c)
+	require.NoError(t, m.Initialize(context.Background()))
+	require.Equal(t, int32(1), atomic.LoadInt32(&newJWTSVIDCount))
+
+	// Workloads get the prefetched JWT-SVID without a round trip
+	svid, err := m.FetchJWTSVID(context.Background(), spiffeID, audience)
+	require.NoError(t, err)
+	require.Equal(t, "A", svid.Token)
+	require.Equal(t, int32(1), atomic.LoadInt32(&newJWTSVIDCount))
+
+	// The JWT-SVID is not refreshed until it expires soon
+	require.NoError(t, m.synchronize(context.Background()))
+	require.Equal(t, int32(1), atomic.LoadInt32(&newJWTSVIDCount))
+
+	token = "B"
+	clk.Add(30 * time.Second)
+	require.NoError(t, m.synchronize(context.Background()))
+	require.Equal(t, int32(2), atomic.LoadInt32(&newJWTSVIDCount))
+
+	svid, err = m.FetchJWTSVID(context.Background(), spiffeID, audience)
+	require.NoError(t, err)
+	require.Equal(t, "B", svid.Token)
+	require.Equal(t, int32(2), atomic.LoadInt32(&newJWTSVIDCount))
+}
+
 func makeGetAuthorizedEntriesResponse(t *testing.T, respKeys ...string) *entryv1.GetAuthorizedEntriesResponse {
 	var entries []*types.Entry
 	for _, respKey := range respKeys {
@@ -1061,6 +1411,7 @@ type mockAPIConfig struct {
 	getAuthorizedEntries    func(api *mockAPI, count int32, req *entryv1.GetAuthorizedEntriesRequest) (*entryv1.GetAuthorizedEntriesResponse, error)
 	batchNewX509SVIDEntries func(api *mockAPI, count int32) []*common.RegistrationEntry
 	newJWTSVID              func(api *mockAPI, req *svidv1.NewJWTSVIDRequest) (*svidv1.NewJWTSVIDResponse, error)
+	watchAuthorizedEntries  func(api *mockAPI, stream entryv1.Entry_WatchAuthorizedEntriesServer) error
 
 	svidTTL int
 	clk     clock.Clock
@@ -1153,6 +1504,13 @@ func (h *mockAPI) GetAuthorizedEntries(ctx context.Context, req *entryv1.GetAuth
 	return nil, errors.New("no GetAuthorizedEntries implementation for test")
 }
 
+func (h *mockAPI) WatchAuthorizedEntries(req *entryv1.WatchAuthorizedEntriesRequest, stream entryv1.Entry_WatchAuthorizedEntriesServer) error {
+	if h.c.watchAuthorizedEntries != nil {
+		return h.c.watchAuthorizedEntries(h, stream)
+	}
+	return h.UnimplementedEntryServer.WatchAuthorizedEntries(req, stream)
+}
+
 func (h *mockAPI) BatchNewX509SVID(ctx context.Context, req *svidv1.BatchNewX509SVIDRequest) (*svidv1.BatchNewX509SVIDResponse, error) {
 	count := atomic.AddInt32(&h.batchNewX509SVIDCount, 1)
 
@@ -0,0 +1,298 @@
+package manager
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/common"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	persistedCacheVersion = 1
+
+	// persistedCacheInfo binds the encryption key derived from the agent key
+	// to its use for the persisted cache
+	persistedCacheInfo = "spire-agent persisted cache v1"
+)
+
+// persistedCache is the content of the persisted cache file once decrypted
+type persistedCache struct {
+	Version int `json:"version"`
+
+	// Bundles and Entries hold protobuf encoded common.Bundle and
+	// common.RegistrationEntry messages
+	Bundles [][]byte `json:"bundles"`
+	Entries [][]byte `json:"entries"`
+
+	X509SVIDs []persistedX509SVID `json:"x509_svids"`
+}
+
+type persistedX509SVID struct {
+	EntryID string `json:"entry_id"`
+
+	// Chain holds the DER encoded certificates of the SVID
+	Chain [][]byte `json:"chain"`
+
+	// PrivateKey holds the PKCS#8 encoded private key of the SVID
+	PrivateKey []byte `json:"private_key"`
+}
+
+// ReadPersistedCache decrypts the cache persisted at path with a key derived
+// from the agent key. Returns ErrNotCached if there is no persisted cache.
+func ReadPersistedCache(path string, agentKey *ecdsa.PrivateKey) (*cache.UpdateEntries, *cache.UpdateSVIDs, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, ErrNotCached
+		}
+		return nil, nil, fmt.Errorf("error reading persisted cache at %s: %v", path, err)
+	}
+
+	aead, err := persistedCacheAEAD(agentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, nil, fmt.Errorf("persisted cache at %s is truncated", path)
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(persistedCacheInfo))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decrypt persisted cache at %s; it was not written with the current agent key", path)
+	}
+
+	pc := new(persistedCache)
+	if err := json.Unmarshal(plaintext, pc); err != nil {
+		return nil, nil, fmt.Errorf("error parsing persisted cache at %s: %v", path, err)
+	}
+	if pc.Version != persistedCacheVersion {
+		return nil, nil, fmt.Errorf("unsupported persisted cache version %d", pc.Version)
+	}
+	return pc.toUpdates()
+}
+
+// StorePersistedCache encrypts the cache with a key derived from the agent key
+// and writes it to path.
+func StorePersistedCache(path string, agentKey *ecdsa.PrivateKey, plaintext []byte) error {
+	aead, err := persistedCacheAEAD(agentKey)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	return diskutil.AtomicWriteFile(path, aead.Seal(nonce, nonce, plaintext, []byte(persistedCacheInfo)), 0600)
+}
+
+// DeletePersistedCache removes the cache persisted at path, if any.
+func DeletePersistedCache(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// persistedCacheAEAD returns the AES-256-GCM cipher of the persisted cache.
+// The key is derived from the agent key so that the cache can only be read
+// by the agent that wrote it, and is invalidated when the agent key changes.
+func persistedCacheAEAD(agentKey *ecdsa.PrivateKey) (cipher.AEAD, error) {
+	secret, err := x509.MarshalECPrivateKey(agentKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal agent key: %v", err)
+	}
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, nil, []byte(persistedCacheInfo)), key); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func marshalPersistedCache(bundles map[string]*bundleutil.Bundle, entries []*common.RegistrationEntry, identities []cache.Identity) ([]byte, error) {
+	pc := &persistedCache{
+		Version: persistedCacheVersion,
+	}
+	for _, bundle := range bundles {
+		data, err := proto.Marshal(bundle.Proto())
+		if err != nil {
+			return nil, err
+		}
+		pc.Bundles = append(pc.Bundles, data)
+	}
+	// Bundles are sorted so that an unchanged cache has the same encoding
+	sort.Slice(pc.Bundles, func(a, b int) bool {
+		return bytes.Compare(pc.Bundles[a], pc.Bundles[b]) < 0
+	})
+
+	for _, entry := range entries {
+		data, err := proto.Marshal(entry)
+		if err != nil {
+			return nil, err
+		}
+		pc.Entries = append(pc.Entries, data)
+	}
+
+	for _, identity := range identities {
+		key, err := x509.MarshalPKCS8PrivateKey(identity.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal private key of entry %q: %v", identity.Entry.EntryId, err)
+		}
+		svid := persistedX509SVID{
+			EntryID:    identity.Entry.EntryId,
+			PrivateKey: key,
+		}
+		for _, cert := range identity.SVID {
+			svid.Chain = append(svid.Chain, cert.Raw)
+		}
+		pc.X509SVIDs = append(pc.X509SVIDs, svid)
+	}
+	return json.Marshal(pc)
+}
+
+func (pc *persistedCache) toUpdates() (*cache.UpdateEntries, *cache.UpdateSVIDs, error) {
+	entries := &cache.UpdateEntries{
+		Bundles:             make(map[string]*bundleutil.Bundle),
+		RegistrationEntries: make(map[string]*common.RegistrationEntry),
+	}
+	for _, data := range pc.Bundles {
+		b := new(common.Bundle)
+		if err := proto.Unmarshal(data, b); err != nil {
+			return nil, nil, fmt.Errorf("error parsing persisted bundle: %v", err)
+		}
+		bundle, err := bundleutil.BundleFromProto(b)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing persisted bundle: %v", err)
+		}
+		entries.Bundles[bundle.TrustDomainID()] = bundle
+	}
+	for _, data := range pc.Entries {
+		entry := new(common.RegistrationEntry)
+		if err := proto.Unmarshal(data, entry); err != nil {
+			return nil, nil, fmt.Errorf("error parsing persisted entry: %v", err)
+		}
+		entries.RegistrationEntries[entry.EntryId] = entry
+	}
+
+	svids := &cache.UpdateSVIDs{
+		X509SVIDs: make(map[string]*cache.X509SVID),
+	}
+	for _, svid := range pc.X509SVIDs {
+		chain, err := x509.ParseCertificates(bytes.Join(svid.Chain, nil))
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing persisted X509-SVID of entry %q: %v", svid.EntryID, err)
+		}
+		key, err := x509.ParsePKCS8PrivateKey(svid.PrivateKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing persisted private key of entry %q: %v", svid.EntryID, err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok || len(chain) == 0 {
+			return nil, nil, fmt.Errorf("persisted X509-SVID of entry %q is invalid", svid.EntryID)
+		}
+		svids.X509SVIDs[svid.EntryID] = &cache.X509SVID{
+			Chain:      chain,
+			PrivateKey: signer,
+		}
+	}
+	return entries, svids, nil
+}
+
+// restorePersistedCache loads the persisted cache, if enabled, so that
+// workloads can be served while the server is unreachable. The X509-SVIDs
+// that expired in the meantime are dropped. The bundle of the trust domain
+// loaded during attestation is kept. Returns true if entries were restored.
+func (m *manager) restorePersistedCache() bool {
+	if m.c.PersistedCachePath == "" {
+		return false
+	}
+
+	entries, svids, err := ReadPersistedCache(m.c.PersistedCachePath, m.c.SVIDKey)
+	switch {
+	case err == ErrNotCached:
+		return false
+	case err != nil:
+		m.c.Log.WithError(err).Warn("Ignoring persisted cache")
+		return false
+	}
+
+	trustDomainID := m.c.TrustDomain.String()
+	entries.Bundles[trustDomainID] = m.cache.Bundle()
+
+	now := m.clk.Now()
+	for entryID, svid := range svids.X509SVIDs {
+		if _, ok := entries.RegistrationEntries[entryID]; !ok || !now.Before(svid.Chain[0].NotAfter) {
+			delete(svids.X509SVIDs, entryID)
+		}
+	}
+
+	m.cache.UpdateEntries(entries, func(*common.RegistrationEntry, *common.RegistrationEntry, *cache.X509SVID) bool {
+		return false
+	})
+	m.cache.UpdateSVIDs(svids)
+
+	m.c.Log.WithFields(logrus.Fields{
+		telemetry.Count:     len(entries.RegistrationEntries),
+		telemetry.X509SVIDs: len(svids.X509SVIDs),
+	}).Info("Restored persisted cache")
+	return len(entries.RegistrationEntries) > 0
+}
+
+// persistCache writes the cache to disk, if enabled, encrypted with the
+// current agent key. Nothing is written if neither the cache nor the agent
+// key changed since the last write.
+func (m *manager) persistCache() {
+	if m.c.PersistedCachePath == "" {
+		return
+	}
+
+	m.persistMtx.Lock()
+	defer m.persistMtx.Unlock()
+
+	agentKey := m.svid.State().Key
+	plaintext, err := marshalPersistedCache(m.cache.Bundles(), m.cache.Entries(), m.cache.Identities())
+	if err != nil {
+		m.c.Log.WithError(err).Error("Could not persist cache")
+		return
+	}
+	if agentKey == m.persistedKey && bytes.Equal(plaintext, m.persistedCache) {
+		return
+	}
+
+	if err := StorePersistedCache(m.c.PersistedCachePath, agentKey, plaintext); err != nil {
+		m.c.Log.WithError(err).Error("Could not persist cache")
+		return
+	}
+	m.persistedKey = agentKey
+	m.persistedCache = plaintext
+}
+
+func (m *manager) deletePersistedCache() {
+	if m.c.PersistedCachePath == "" {
+		return
+	}
+	if err := DeletePersistedCache(m.c.PersistedCachePath); err != nil {
+		m.c.Log.WithError(err).Error("Failed to remove persisted cache")
+	}
+}
@@ -2,9 +2,7 @@ package manager
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	"crypto"
 	"crypto/x509"
 	"time"
 
@@ -15,6 +13,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
 )
@@ -22,6 +21,7 @@ import (
 type csrRequest struct {
 	EntryID              string
 	SpiffeID             string
+	KeyType              string
 	CurrentSVIDExpiresAt time.Time
 }
 
@@ -32,6 +32,15 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 		return err
 	}
 
+	// SVIDs signed by authorities tainted in the trust domain bundle are
+	// rotated even if they are not expiring yet.
+	var taintedX509Authorities []*x509.Certificate
+	var taintedJWTKeyIDs map[string]bool
+	if bundle := update.Bundles[m.c.TrustDomain.String()]; bundle != nil {
+		taintedX509Authorities = bundle.TaintedRootCAs()
+		taintedJWTKeyIDs = bundle.TaintedJWTSigningKeyIDs()
+	}
+
 	// update the cache and build a list of CSRs that need to be processed
 	// in this interval.
 	//
@@ -39,6 +48,7 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 	var csrs []csrRequest
 	var expiring int
 	var outdated int
+	var tainted int
 	m.cache.UpdateEntries(update, func(existingEntry, newEntry *common.RegistrationEntry, svid *cache.X509SVID) bool {
 		switch {
 		case svid == nil:
@@ -49,8 +59,10 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 				telemetry.RegistrationID: newEntry.EntryId,
 				telemetry.SPIFFEID:       newEntry.SpiffeId,
 			}).Warn("cached X509 SVID is empty")
-		case rotationutil.ShouldRotateX509(m.c.Clk.Now(), svid.Chain[0]):
+		case m.c.WorkloadSVIDRotationThreshold.ShouldRotateX509(m.c.Clk.Now(), svid.Chain[0]):
 			expiring++
+		case rotationutil.X509SignedByTaintedAuthority(svid.Chain, taintedX509Authorities):
+			tainted++
 		case existingEntry != nil && existingEntry.RevisionNumber != newEntry.RevisionNumber:
 			// Registration entry has been updated
 			outdated++
@@ -71,6 +83,9 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 		telemetry_agent.AddCacheManagerOutdatedSVIDsSample(m.c.Metrics, float32(outdated))
 		m.c.Log.WithField(telemetry.OutdatedSVIDs, outdated).Debug("Updating SVIDs with outdated attributes in cache")
 	}
+	if tainted > 0 {
+		m.c.Log.WithField(telemetry.Count, tainted).Info("Updating SVIDs signed by tainted authorities in cache")
+	}
 
 	staleEntries := m.cache.GetStaleEntries()
 	if len(staleEntries) > 0 {
@@ -87,6 +102,7 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 			csrs = append(csrs, csrRequest{
 				EntryID:              staleEntry.Entry.EntryId,
 				SpiffeID:             staleEntry.Entry.SpiffeId,
+				KeyType:              staleEntry.Entry.X509SvidKeyType,
 				CurrentSVIDExpiresAt: staleEntry.ExpiresAt,
 			})
 		}
@@ -101,9 +117,40 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 
 	// Set last success sync
 	m.setLastSync()
+
+	m.refreshJWTSVIDs(ctx, taintedJWTKeyIDs)
+
+	m.persistCache()
+
+	telemetry_agent.SetCacheManagerEntriesGauge(m.c.Metrics, m.cache.CountEntries())
+	telemetry_agent.SetCacheManagerX509SVIDsGauge(m.c.Metrics, m.cache.CountSVIDs())
+	telemetry_agent.SetCacheManagerJWTSVIDsGauge(m.c.Metrics, m.cache.CountJWTSVIDs())
 	return nil
 }
 
+// refreshJWTSVIDs prunes expired JWT-SVIDs and the ones signed by tainted
+// JWT signing keys from the cache and fetches the prefetch JWT-SVIDs that are
+// missing or expire soon, so workloads do not wait on a round trip to the
+// server.
+func (m *manager) refreshJWTSVIDs(ctx context.Context, taintedJWTKeyIDs map[string]bool) {
+	if pruned := m.cache.PruneExpiredJWTSVIDs(m.clk.Now()); pruned > 0 {
+		m.c.Log.WithField(telemetry.Count, pruned).Debug("Pruned expired JWT-SVIDs from cache")
+	}
+	if pruned := m.cache.PruneTaintedJWTSVIDs(taintedJWTKeyIDs); pruned > 0 {
+		m.c.Log.WithField(telemetry.Count, pruned).Info("Pruned JWT-SVIDs signed by tainted authorities from cache")
+	}
+
+	for _, prefetch := range m.c.JWTSVIDPrefetch {
+		if m.getEntryID(prefetch.SpiffeID) == "" {
+			// The agent is not authorized for the SPIFFE ID (yet)
+			continue
+		}
+		if _, err := m.FetchJWTSVID(ctx, prefetch.SpiffeID, prefetch.Audience); err != nil {
+			m.c.Log.WithError(err).WithField(telemetry.SPIFFEID, prefetch.SpiffeID).Warn("Unable to prefetch JWT-SVID")
+		}
+	}
+}
+
 func (m *manager) fetchSVIDs(ctx context.Context, csrs []csrRequest) (_ *cache.UpdateSVIDs, err error) {
 	// Put all the CSRs in an array to make just one call with all the CSRs.
 	counter := telemetry_agent.StartManagerFetchSVIDsUpdatesCall(m.c.Metrics)
@@ -111,7 +158,7 @@ func (m *manager) fetchSVIDs(ctx context.Context, csrs []csrRequest) (_ *cache.U
 
 	csrsIn := make(map[string][]byte)
 
-	privateKeys := make(map[string]*ecdsa.PrivateKey, len(csrs))
+	privateKeys := make(map[string]crypto.Signer, len(csrs))
 	for _, csr := range csrs {
 		log := m.c.Log.WithField("spiffe_id", csr.SpiffeID)
 		if !csr.CurrentSVIDExpiresAt.IsZero() {
@@ -125,7 +172,7 @@ func (m *manager) fetchSVIDs(ctx context.Context, csrs []csrRequest) (_ *cache.U
 		}
 
 		log.Info("Renewing X509-SVID")
-		privateKey, csrBytes, err := newCSR(csr.SpiffeID)
+		privateKey, csrBytes, err := newCSR(csr.SpiffeID, csr.KeyType)
 		if err != nil {
 			return nil, err
 		}
@@ -180,8 +227,8 @@ func (m *manager) fetchEntries(ctx context.Context) (_ *cache.UpdateEntries, err
 	}, nil
 }
 
-func newCSR(spiffeID string) (pk *ecdsa.PrivateKey, csr []byte, err error) {
-	pk, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+func newCSR(spiffeID, keyType string) (pk crypto.Signer, csr []byte, err error) {
+	pk, err = x509svid.GenerateKey(keyType)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,223 @@
+// Package nodelabels attaches selectors describing the node the agent runs
+// on to every workload it attests. The selectors come from labels set by the
+// operator in the agent configuration and, optionally, from the tags of the
+// instance read from the metadata service of the cloud provider. They allow
+// registration entries to distinguish environments or tiers without a
+// dedicated workload attestor.
+package nodelabels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	// LabelSelectorType is the type of the selectors of the labels set by
+	// the operator, e.g. label:env:prod
+	LabelSelectorType = "label"
+
+	// CloudTagSelectorType is the type of the selectors of the tags read from
+	// the instance metadata, e.g. cloud_tag:env:prod
+	CloudTagSelectorType = "cloud_tag"
+
+	// DefaultRefreshInterval is how often the instance tags are read again
+	// when no interval is configured
+	DefaultRefreshInterval = 5 * time.Minute
+
+	metadataTimeout = 10 * time.Second
+)
+
+// Config is the configuration of the labeler
+type Config struct {
+	Log   logrus.FieldLogger
+	Clock clock.Clock
+
+	// Labels are the labels set by the operator, keyed by name
+	Labels map[string]string
+
+	// CloudProvider, if set, is the cloud provider whose instance metadata
+	// service the tags are read from: aws, azure or gcp
+	CloudProvider string
+
+	// CloudTags are the names of the instance tags to read. On GCP, custom
+	// instance metadata attributes are read instead.
+	CloudTags []string
+
+	// RefreshInterval is how often the instance tags are read again.
+	// Defaults to DefaultRefreshInterval.
+	RefreshInterval time.Duration
+}
+
+// Validate returns an error if the configuration is invalid
+func (c *Config) Validate() error {
+	for name := range c.Labels {
+		if err := validateName(name); err != nil {
+			return fmt.Errorf("invalid label %q: %v", name, err)
+		}
+	}
+	if c.CloudProvider == "" {
+		if len(c.CloudTags) > 0 {
+			return errors.New("cloud tags require a cloud provider")
+		}
+		return nil
+	}
+	if _, ok := metadataEndpoints[c.CloudProvider]; !ok {
+		return fmt.Errorf("unsupported cloud provider %q", c.CloudProvider)
+	}
+	if len(c.CloudTags) == 0 {
+		return errors.New("at least one cloud tag is required")
+	}
+	for _, name := range c.CloudTags {
+		if err := validateName(name); err != nil {
+			return fmt.Errorf("invalid cloud tag %q: %v", name, err)
+		}
+	}
+	if c.RefreshInterval < 0 {
+		return errors.New("refresh interval must not be negative")
+	}
+	return nil
+}
+
+func validateName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("name must not be empty")
+	case strings.Contains(name, ":"):
+		return errors.New("name must not contain a colon")
+	}
+	return nil
+}
+
+// Labeler provides the selectors of the node
+type Labeler struct {
+	c       Config
+	fetcher tagFetcher
+
+	mtx      sync.RWMutex
+	labels   []*common.Selector
+	tags     []*common.Selector
+	fetched  bool
+	lastTags map[string]string
+}
+
+// New returns a labeler for the configuration, which must be valid
+func New(c Config) *Labeler {
+	var fetcher tagFetcher
+	if c.CloudProvider != "" {
+		fetcher = newTagFetcher(c.CloudProvider, metadataEndpoints[c.CloudProvider], &http.Client{Timeout: metadataTimeout})
+	}
+	return newLabeler(c, fetcher)
+}
+
+func newLabeler(c Config, fetcher tagFetcher) *Labeler {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = DefaultRefreshInterval
+	}
+	return &Labeler{
+		c:       c,
+		fetcher: fetcher,
+		labels:  makeSelectors(LabelSelectorType, c.Labels),
+	}
+}
+
+// Selectors returns the selectors of the node. It is safe to call on a nil
+// labeler.
+func (l *Labeler) Selectors() []*common.Selector {
+	if l == nil {
+		return nil
+	}
+
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	selectors := make([]*common.Selector, 0, len(l.labels)+len(l.tags))
+	selectors = append(selectors, l.labels...)
+	return append(selectors, l.tags...)
+}
+
+// Refresh reads the instance tags from the metadata service, if a cloud
+// provider is configured. On failure, the previously read tags are kept.
+func (l *Labeler) Refresh(ctx context.Context) error {
+	if l.fetcher == nil {
+		return nil
+	}
+
+	tags, err := l.fetcher.FetchTags(ctx, l.c.CloudTags)
+	if err != nil {
+		return fmt.Errorf("unable to read instance tags from the %s metadata service: %v", l.c.CloudProvider, err)
+	}
+
+	l.mtx.Lock()
+	changed := l.fetched && !equalTags(tags, l.lastTags)
+	l.tags = makeSelectors(CloudTagSelectorType, tags)
+	l.lastTags = tags
+	l.fetched = true
+	l.mtx.Unlock()
+
+	if changed {
+		l.c.Log.WithField(telemetry.Selectors, l.Selectors()).Info("Instance tags changed")
+	}
+	return nil
+}
+
+// Run reads the instance tags again every refresh interval until the
+// context is canceled. It returns immediately if no cloud provider is
+// configured.
+func (l *Labeler) Run(ctx context.Context) error {
+	if l.fetcher == nil {
+		return nil
+	}
+
+	ticker := l.c.Clock.Ticker(l.c.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.Refresh(ctx); err != nil && ctx.Err() == nil {
+				l.c.Log.WithError(err).Warn("Failed to refresh instance tags; keeping the previous ones")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func makeSelectors(selectorType string, values map[string]string) []*common.Selector {
+	selectors := make([]*common.Selector, 0, len(values))
+	for name, value := range values {
+		selectors = append(selectors, &common.Selector{
+			Type:  selectorType,
+			Value: fmt.Sprintf("%s:%s", name, value),
+		})
+	}
+	sort.Slice(selectors, func(i, j int) bool {
+		return selectors[i].Value < selectors[j].Value
+	})
+	return selectors
+}
+
+func equalTags(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, value := range a {
+		if other, ok := b[name]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
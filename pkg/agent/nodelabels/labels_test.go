@@ -0,0 +1,216 @@
+package nodelabels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name:   "labels only",
+			config: Config{Labels: map[string]string{"env": "prod"}},
+		},
+		{
+			name:   "cloud tags",
+			config: Config{CloudProvider: "aws", CloudTags: []string{"env"}},
+		},
+		{
+			name:   "empty label name",
+			config: Config{Labels: map[string]string{"": "prod"}},
+			err:    `invalid label "": name must not be empty`,
+		},
+		{
+			name:   "label name with colon",
+			config: Config{Labels: map[string]string{"a:b": "prod"}},
+			err:    `invalid label "a:b": name must not contain a colon`,
+		},
+		{
+			name:   "cloud tags without provider",
+			config: Config{CloudTags: []string{"env"}},
+			err:    "cloud tags require a cloud provider",
+		},
+		{
+			name:   "unsupported provider",
+			config: Config{CloudProvider: "foo", CloudTags: []string{"env"}},
+			err:    `unsupported cloud provider "foo"`,
+		},
+		{
+			name:   "provider without tags",
+			config: Config{CloudProvider: "gcp"},
+			err:    "at least one cloud tag is required",
+		},
+		{
+			name:   "invalid cloud tag",
+			config: Config{CloudProvider: "azure", CloudTags: []string{"a:b"}},
+			err:    `invalid cloud tag "a:b": name must not contain a colon`,
+		},
+		{
+			name:   "negative refresh interval",
+			config: Config{CloudProvider: "azure", CloudTags: []string{"env"}, RefreshInterval: -1},
+			err:    "refresh interval must not be negative",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSelectors(t *testing.T) {
+	var nilLabeler *Labeler
+	assert.Nil(t, nilLabeler.Selectors())
+
+	l := New(Config{Labels: map[string]string{"tier": "web", "env": "prod"}})
+	require.NoError(t, l.Refresh(context.Background()))
+	assert.Equal(t, []*common.Selector{
+		{Type: "label", Value: "env:prod"},
+		{Type: "label", Value: "tier:web"},
+	}, l.Selectors())
+}
+
+func TestRefresh(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	fetcher := &fakeTagFetcher{tags: map[string]string{"env": "prod"}}
+	l := newLabeler(Config{
+		Log:           log,
+		Labels:        map[string]string{"tier": "web"},
+		CloudProvider: "aws",
+		CloudTags:     []string{"env", "team"},
+	}, fetcher)
+
+	// tags are not available until read
+	assert.Equal(t, []*common.Selector{{Type: "label", Value: "tier:web"}}, l.Selectors())
+
+	require.NoError(t, l.Refresh(context.Background()))
+	assert.Equal(t, []string{"env", "team"}, fetcher.names)
+	assert.Equal(t, []*common.Selector{
+		{Type: "label", Value: "tier:web"},
+		{Type: "cloud_tag", Value: "env:prod"},
+	}, l.Selectors())
+	assert.Empty(t, hook.AllEntries())
+
+	// previous tags are kept on failure
+	fetcher.err = errors.New("oh no")
+	require.EqualError(t, l.Refresh(context.Background()), "unable to read instance tags from the aws metadata service: oh no")
+	assert.Len(t, l.Selectors(), 2)
+
+	// changes are logged
+	fetcher.err = nil
+	fetcher.tags = map[string]string{"env": "staging"}
+	require.NoError(t, l.Refresh(context.Background()))
+	assert.Equal(t, []*common.Selector{
+		{Type: "label", Value: "tier:web"},
+		{Type: "cloud_tag", Value: "env:staging"},
+	}, l.Selectors())
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, "Instance tags changed", hook.LastEntry().Message)
+}
+
+func TestAWSTagFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+			if r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+				http.Error(w, "missing ttl", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, "TOKEN")
+		case r.Header.Get("X-aws-ec2-metadata-token") != "TOKEN":
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		case r.URL.Path == "/latest/meta-data/tags/instance/env":
+			fmt.Fprint(w, "prod")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := newTagFetcher("aws", server.URL, server.Client())
+	tags, err := fetcher.FetchTags(context.Background(), []string{"env", "team"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, tags)
+}
+
+func TestAzureTagFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Path != "/metadata/instance/compute/tagsList" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `[{"name":"env","value":"prod"},{"name":"owner","value":"alice"}]`)
+	}))
+	defer server.Close()
+
+	fetcher := newTagFetcher("azure", server.URL, server.Client())
+	tags, err := fetcher.FetchTags(context.Background(), []string{"env", "team"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, tags)
+}
+
+func TestGCPTagFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Metadata-Flavor") != "Google":
+			http.Error(w, "forbidden", http.StatusForbidden)
+		case r.URL.Path == "/computeMetadata/v1/instance/attributes/env":
+			fmt.Fprint(w, "prod")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := newTagFetcher("gcp", server.URL, server.Client())
+	tags, err := fetcher.FetchTags(context.Background(), []string{"env", "team"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, tags)
+
+	server.Close()
+	_, err = fetcher.FetchTags(context.Background(), []string{"env"})
+	require.Error(t, err)
+}
+
+func TestMetadataUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "oops", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	fetcher := newTagFetcher("gcp", server.URL, server.Client())
+	_, err := fetcher.FetchTags(context.Background(), []string{"env"})
+	require.EqualError(t, err, "unexpected status 500 from /computeMetadata/v1/instance/attributes/env")
+}
+
+type fakeTagFetcher struct {
+	tags  map[string]string
+	err   error
+	names []string
+}
+
+func (f *fakeTagFetcher) FetchTags(ctx context.Context, names []string) (map[string]string, error) {
+	f.names = names
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.tags, nil
+}
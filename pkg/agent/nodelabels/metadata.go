@@ -0,0 +1,169 @@
+package nodelabels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+const (
+	providerAWS   = "aws"
+	providerAzure = "azure"
+	providerGCP   = "gcp"
+
+	// maxMetadataResponseSize bounds the size of the responses read from
+	// the metadata services
+	maxMetadataResponseSize = 1 << 20
+)
+
+// metadataEndpoints holds the base URL of the instance metadata service of
+// each supported cloud provider
+var metadataEndpoints = map[string]string{
+	providerAWS:   "http://169.254.169.254",
+	providerAzure: "http://169.254.169.254",
+	providerGCP:   "http://metadata.google.internal",
+}
+
+// tagFetcher reads the tags of the instance from the metadata service.
+// Tags that are not set on the instance are omitted from the result.
+type tagFetcher interface {
+	FetchTags(ctx context.Context, names []string) (map[string]string, error)
+}
+
+func newTagFetcher(provider, endpoint string, client *http.Client) tagFetcher {
+	switch provider {
+	case providerAWS:
+		return &awsTagFetcher{endpoint: endpoint, client: client}
+	case providerAzure:
+		return &azureTagFetcher{endpoint: endpoint, client: client}
+	case providerGCP:
+		return &gcpTagFetcher{endpoint: endpoint, client: client}
+	default:
+		return nil
+	}
+}
+
+// awsTagFetcher reads the instance tags through IMDSv2. Access to the tags
+// in the instance metadata must be enabled on the instance.
+type awsTagFetcher struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (f *awsTagFetcher) FetchTags(ctx context.Context, names []string) (map[string]string, error) {
+	token, _, err := doMetadataRequest(ctx, f.client, http.MethodPut, f.endpoint+"/latest/api/token", map[string]string{
+		"X-aws-ec2-metadata-token-ttl-seconds": "60",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get IMDSv2 token: %v", err)
+	}
+
+	tags := make(map[string]string)
+	for _, name := range names {
+		value, found, err := doMetadataRequest(ctx, f.client, http.MethodGet, f.endpoint+"/latest/meta-data/tags/instance/"+url.PathEscape(name), map[string]string{
+			"X-aws-ec2-metadata-token": string(token),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			tags[name] = string(value)
+		}
+	}
+	return tags, nil
+}
+
+// azureTagFetcher reads the tags of the virtual machine
+type azureTagFetcher struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (f *azureTagFetcher) FetchTags(ctx context.Context, names []string) (map[string]string, error) {
+	body, _, err := doMetadataRequest(ctx, f.client, http.MethodGet, f.endpoint+"/metadata/instance/compute/tagsList?api-version=2021-02-01&format=json", map[string]string{
+		"Metadata": "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tagsList []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &tagsList); err != nil {
+		return nil, fmt.Errorf("unable to parse tags: %v", err)
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	tags := make(map[string]string)
+	for _, tag := range tagsList {
+		if wanted[tag.Name] {
+			tags[tag.Name] = tag.Value
+		}
+	}
+	return tags, nil
+}
+
+// gcpTagFetcher reads custom instance metadata attributes, since instance
+// labels are not exposed by the metadata server
+type gcpTagFetcher struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (f *gcpTagFetcher) FetchTags(ctx context.Context, names []string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, name := range names {
+		value, found, err := doMetadataRequest(ctx, f.client, http.MethodGet, f.endpoint+"/computeMetadata/v1/instance/attributes/"+url.PathEscape(name), map[string]string{
+			"Metadata-Flavor": "Google",
+		})
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			tags[name] = string(value)
+		}
+	}
+	return tags, nil
+}
+
+// doMetadataRequest sends a request to a metadata service and returns the
+// body of the response. A 404 response is reported as not found instead of
+// an error.
+func doMetadataRequest(ctx context.Context, client *http.Client, method, url string, header map[string]string) ([]byte, bool, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req = req.WithContext(ctx)
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxMetadataResponseSize))
+	if err != nil {
+		return nil, false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, true, nil
+	case http.StatusNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Path)
+	}
+}
@@ -0,0 +1,307 @@
+package awssecretsmanager
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "aws_secretsmanager"
+
+	// managedTag is set on the secrets created by the plugin. Secrets
+	// without it are never updated or deleted, so the plugin cannot
+	// overwrite secrets that are not managed by SPIRE.
+	managedTag = "spire-svid"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, svidstore.PluginServer(p))
+}
+
+type Config struct {
+	Region          string `hcl:"region"`
+	AccessKeyID     string `hcl:"access_key_id"`
+	SecretAccessKey string `hcl:"secret_access_key"`
+	AssumeRoleARN   string `hcl:"assume_role_arn"`
+	KMSKeyID        string `hcl:"kms_key_id"`
+}
+
+type Plugin struct {
+	svidstore.UnimplementedSVIDStoreServer
+
+	log hclog.Logger
+
+	mtx      sync.RWMutex
+	config   *Config
+	smClient secretsManagerClient
+
+	hooks struct {
+		newClient func(config *Config) (secretsManagerClient, error)
+	}
+}
+
+func New() *Plugin {
+	return newPlugin(newSecretsManagerClient)
+}
+
+func newPlugin(newClient func(config *Config) (secretsManagerClient, error)) *Plugin {
+	p := &Plugin{}
+	p.hooks.newClient = newClient
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(Config)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+	if config.Region == "" {
+		return nil, status.Error(codes.InvalidArgument, "region is required")
+	}
+
+	smClient, err := p.hooks.newClient(config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to create secrets manager client: %v", err)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.config = config
+	p.smClient = smClient
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+// PutX509SVID stores the X509-SVID in the secret identified by the
+// selectors, creating the secret if needed.
+func (p *Plugin) PutX509SVID(ctx context.Context, req *svidstore.PutX509SVIDRequest) (*svidstore.PutX509SVIDResponse, error) {
+	config, smClient, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := parseSelectors(req.Selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	secretString, err := secretValue(req)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := describeManagedSecret(ctx, smClient, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		if secret.name == "" {
+			return nil, status.Errorf(codes.NotFound, "secret %q does not exist", secret.id())
+		}
+		input := &secretsmanager.CreateSecretInput{
+			Name:         aws.String(secret.name),
+			SecretString: aws.String(secretString),
+			Tags: []*secretsmanager.Tag{
+				{Key: aws.String(managedTag), Value: aws.String("true")},
+			},
+		}
+		if config.KMSKeyID != "" {
+			input.KmsKeyId = aws.String(config.KMSKeyID)
+		}
+		if _, err := smClient.CreateSecretWithContext(ctx, input); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create secret %q: %v", secret.id(), err)
+		}
+		return &svidstore.PutX509SVIDResponse{}, nil
+	}
+
+	if _, err := smClient.PutSecretValueWithContext(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(secret.id()),
+		SecretString: aws.String(secretString),
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to put secret value of %q: %v", secret.id(), err)
+	}
+	return &svidstore.PutX509SVIDResponse{}, nil
+}
+
+// DeleteX509SVID deletes the secret identified by the selectors. The secret
+// can be restored during the recovery window configured in AWS.
+func (p *Plugin) DeleteX509SVID(ctx context.Context, req *svidstore.DeleteX509SVIDRequest) (*svidstore.DeleteX509SVIDResponse, error) {
+	_, smClient, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := parseSelectors(req.Selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := describeManagedSecret(ctx, smClient, secret)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &svidstore.DeleteX509SVIDResponse{}, nil
+	}
+
+	if _, err := smClient.DeleteSecretWithContext(ctx, &secretsmanager.DeleteSecretInput{
+		SecretId: aws.String(secret.id()),
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete secret %q: %v", secret.id(), err)
+	}
+	return &svidstore.DeleteX509SVIDResponse{}, nil
+}
+
+func (p *Plugin) getClient() (*Config, secretsManagerClient, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	if p.smClient == nil {
+		return nil, nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.config, p.smClient, nil
+}
+
+// secretRef identifies a secret either by name or by ARN.
+type secretRef struct {
+	name string
+	arn  string
+}
+
+func (s secretRef) id() string {
+	if s.arn != "" {
+		return s.arn
+	}
+	return s.name
+}
+
+// parseSelectors parses the selectors of the plugin, which identify the
+// secret with a "secretname:<name>" or an "arn:<arn>" value.
+func parseSelectors(selectors []*common.Selector) (secretRef, error) {
+	var secret secretRef
+	for _, selector := range selectors {
+		parts := strings.SplitN(selector.Value, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return secretRef{}, status.Errorf(codes.InvalidArgument, "malformed selector %q", selector.Value)
+		}
+		switch parts[0] {
+		case "secretname":
+			secret.name = parts[1]
+		case "arn":
+			secret.arn = parts[1]
+		default:
+			return secretRef{}, status.Errorf(codes.InvalidArgument, "unknown selector %q", selector.Value)
+		}
+	}
+	if secret.id() == "" {
+		return secretRef{}, status.Error(codes.InvalidArgument, "either the secretname or the arn selector is required")
+	}
+	return secret, nil
+}
+
+// describeManagedSecret returns whether the secret exists, failing if it
+// exists but is not managed by SPIRE or is scheduled for deletion.
+func describeManagedSecret(ctx context.Context, smClient secretsManagerClient, secret secretRef) (bool, error) {
+	resp, err := smClient.DescribeSecretWithContext(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(secret.id()),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+		return false, nil
+	}
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to describe secret %q: %v", secret.id(), err)
+	}
+
+	managed := false
+	for _, tag := range resp.Tags {
+		if aws.StringValue(tag.Key) == managedTag && aws.StringValue(tag.Value) == "true" {
+			managed = true
+		}
+	}
+	if !managed {
+		return false, status.Errorf(codes.FailedPrecondition, "secret %q is not managed by SPIRE: the %q tag is missing", secret.id(), managedTag)
+	}
+	if resp.DeletedDate != nil {
+		return false, status.Errorf(codes.FailedPrecondition, "secret %q is scheduled for deletion", secret.id())
+	}
+	return true, nil
+}
+
+type secretDocument struct {
+	SPIFFEID         string            `json:"spiffeId"`
+	X509SVID         string            `json:"x509Svid"`
+	X509SVIDKey      string            `json:"x509SvidKey"`
+	Bundle           string            `json:"bundle"`
+	FederatedBundles map[string]string `json:"federatedBundles,omitempty"`
+}
+
+// secretValue encodes the X509-SVID as the JSON document stored in the
+// secret, with the certificates and key PEM encoded.
+func secretValue(req *svidstore.PutX509SVIDRequest) (string, error) {
+	if req.Svid == nil {
+		return "", status.Error(codes.InvalidArgument, "missing SVID")
+	}
+
+	value := secretDocument{
+		SPIFFEID:    req.Svid.SpiffeId,
+		X509SVID:    encodeCertificates(req.Svid.CertChain),
+		X509SVIDKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: req.Svid.PrivateKey})),
+		Bundle:      encodeCertificates(req.Svid.Bundle),
+	}
+	for trustDomainID, bundleDER := range req.FederatedBundles {
+		bundle, err := x509.ParseCertificates(bundleDER)
+		if err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "malformed federated bundle for %q: %v", trustDomainID, err)
+		}
+		if value.FederatedBundles == nil {
+			value.FederatedBundles = make(map[string]string)
+		}
+		var ders [][]byte
+		for _, cert := range bundle {
+			ders = append(ders, cert.Raw)
+		}
+		value.FederatedBundles[trustDomainID] = encodeCertificates(ders)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to marshal secret: %v", err)
+	}
+	return string(data), nil
+}
+
+func encodeCertificates(ders [][]byte) string {
+	var s strings.Builder
+	for _, der := range ders {
+		s.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+	return s.String()
+}
@@ -0,0 +1,387 @@
+package awssecretsmanager
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx = context.Background()
+
+	td          = spiffeid.RequireTrustDomainFromString("example.org")
+	federatedTD = spiffeid.RequireTrustDomainFromString("federated.test")
+)
+
+func TestConfigure(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		config     string
+		clientErr  error
+		expectCode codes.Code
+		expectMsg  string
+	}{
+		{
+			name:   "success",
+			config: `region = "us-west-2" kms_key_id = "some-key"`,
+		},
+		{
+			name:       "malformed configuration",
+			config:     `region = {`,
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "unable to decode configuration",
+		},
+		{
+			name:       "missing region",
+			config:     `access_key_id = "id"`,
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "region is required",
+		},
+		{
+			name:       "client failure",
+			config:     `region = "us-west-2"`,
+			clientErr:  errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "unable to create secrets manager client: oh no",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var gotConfig *Config
+			p := newPlugin(func(config *Config) (secretsManagerClient, error) {
+				gotConfig = config
+				if tt.clientErr != nil {
+					return nil, tt.clientErr
+				}
+				return newFakeClient(), nil
+			})
+
+			_, err := p.Configure(ctx, &spi.ConfigureRequest{Configuration: tt.config})
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.expectCode, tt.expectMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &Config{Region: "us-west-2", KMSKeyID: "some-key"}, gotConfig)
+		})
+	}
+}
+
+func TestNotConfigured(t *testing.T) {
+	p := New()
+
+	_, err := p.PutX509SVID(ctx, &svidstore.PutX509SVIDRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "not configured")
+
+	_, err = p.DeleteX509SVID(ctx, &svidstore.DeleteX509SVIDRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "not configured")
+}
+
+func TestPutX509SVID(t *testing.T) {
+	ca := testca.New(t, td)
+	federatedCA := testca.New(t, federatedTD)
+	svid := ca.CreateX509SVID(td.NewID("workload"))
+	keyDER, err := x509.MarshalPKCS8PrivateKey(svid.PrivateKey)
+	require.NoError(t, err)
+
+	req := &svidstore.PutX509SVIDRequest{
+		Svid: &svidstore.X509SVID{
+			SpiffeId:   svid.ID.String(),
+			CertChain:  [][]byte{svid.Certificates[0].Raw},
+			PrivateKey: keyDER,
+			Bundle:     [][]byte{ca.X509Authorities()[0].Raw},
+		},
+		FederatedBundles: map[string][]byte{
+			federatedTD.IDString(): federatedCA.X509Authorities()[0].Raw,
+		},
+	}
+	expectValue := secretDocument{
+		SPIFFEID:    svid.ID.String(),
+		X509SVID:    encodeCertificates(req.Svid.CertChain),
+		X509SVIDKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})),
+		Bundle:      encodeCertificates(req.Svid.Bundle),
+		FederatedBundles: map[string]string{
+			federatedTD.IDString(): encodeCertificates([][]byte{federatedCA.X509Authorities()[0].Raw}),
+		},
+	}
+
+	for _, tt := range []struct {
+		name         string
+		selectors    []string
+		secrets      map[string]*fakeSecret
+		expectCode   codes.Code
+		expectMsg    string
+		expectSecret string
+		expectKMSKey string
+	}{
+		{
+			name:         "create secret by name",
+			selectors:    []string{"secretname:my-secret"},
+			expectSecret: "my-secret",
+			expectKMSKey: "some-key",
+		},
+		{
+			name:      "update secret by name",
+			selectors: []string{"secretname:my-secret"},
+			secrets: map[string]*fakeSecret{
+				"my-secret": {managed: true, value: "old"},
+			},
+			expectSecret: "my-secret",
+		},
+		{
+			name:      "update secret by arn",
+			selectors: []string{"arn:arn:aws:secretsmanager:us-west-2:123:secret:my-secret"},
+			secrets: map[string]*fakeSecret{
+				"arn:aws:secretsmanager:us-west-2:123:secret:my-secret": {managed: true, value: "old"},
+			},
+			expectSecret: "arn:aws:secretsmanager:us-west-2:123:secret:my-secret",
+		},
+		{
+			name:       "secret not found by arn",
+			selectors:  []string{"arn:arn:aws:secretsmanager:us-west-2:123:secret:my-secret"},
+			expectCode: codes.NotFound,
+			expectMsg:  `secret "arn:aws:secretsmanager:us-west-2:123:secret:my-secret" does not exist`,
+		},
+		{
+			name:      "secret not managed by SPIRE",
+			selectors: []string{"secretname:my-secret"},
+			secrets: map[string]*fakeSecret{
+				"my-secret": {value: "not mine"},
+			},
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  `secret "my-secret" is not managed by SPIRE`,
+		},
+		{
+			name:      "secret scheduled for deletion",
+			selectors: []string{"secretname:my-secret"},
+			secrets: map[string]*fakeSecret{
+				"my-secret": {managed: true, deleted: true},
+			},
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  `secret "my-secret" is scheduled for deletion`,
+		},
+		{
+			name:       "no selectors",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "either the secretname or the arn selector is required",
+		},
+		{
+			name:       "malformed selector",
+			selectors:  []string{"secretname"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `malformed selector "secretname"`,
+		},
+		{
+			name:       "unknown selector",
+			selectors:  []string{"foo:bar"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `unknown selector "foo:bar"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient()
+			for id, secret := range tt.secrets {
+				client.secrets[id] = secret
+			}
+			p := newConfiguredPlugin(t, client)
+
+			req.Selectors = makeSelectors(tt.selectors)
+			_, err := p.PutX509SVID(ctx, req)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.expectCode, tt.expectMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			secret := client.secrets[tt.expectSecret]
+			require.NotNil(t, secret)
+			require.True(t, secret.managed)
+			require.Equal(t, tt.expectKMSKey, secret.kmsKeyID)
+
+			var value secretDocument
+			require.NoError(t, json.Unmarshal([]byte(secret.value), &value))
+			require.Equal(t, expectValue, value)
+		})
+	}
+}
+
+func TestPutX509SVIDMissingSVID(t *testing.T) {
+	p := newConfiguredPlugin(t, newFakeClient())
+
+	_, err := p.PutX509SVID(ctx, &svidstore.PutX509SVIDRequest{
+		Selectors: makeSelectors([]string{"secretname:my-secret"}),
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "missing SVID")
+}
+
+func TestDeleteX509SVID(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		selectors     []string
+		secrets       map[string]*fakeSecret
+		expectCode    codes.Code
+		expectMsg     string
+		expectDeleted string
+	}{
+		{
+			name:      "delete secret",
+			selectors: []string{"secretname:my-secret"},
+			secrets: map[string]*fakeSecret{
+				"my-secret": {managed: true},
+			},
+			expectDeleted: "my-secret",
+		},
+		{
+			name:      "secret does not exist",
+			selectors: []string{"secretname:my-secret"},
+		},
+		{
+			name:      "secret not managed by SPIRE",
+			selectors: []string{"secretname:my-secret"},
+			secrets: map[string]*fakeSecret{
+				"my-secret": {},
+			},
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  `secret "my-secret" is not managed by SPIRE`,
+		},
+		{
+			name:       "no selectors",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "either the secretname or the arn selector is required",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient()
+			for id, secret := range tt.secrets {
+				client.secrets[id] = secret
+			}
+			p := newConfiguredPlugin(t, client)
+
+			_, err := p.DeleteX509SVID(ctx, &svidstore.DeleteX509SVIDRequest{
+				Selectors: makeSelectors(tt.selectors),
+			})
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.expectCode, tt.expectMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectDeleted != "" {
+				require.True(t, client.secrets[tt.expectDeleted].deleted)
+			}
+		})
+	}
+}
+
+func newConfiguredPlugin(t *testing.T, client *fakeClient) *Plugin {
+	p := newPlugin(func(*Config) (secretsManagerClient, error) {
+		return client, nil
+	})
+	_, err := p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: `region = "us-west-2" kms_key_id = "some-key"`,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func makeSelectors(values []string) []*common.Selector {
+	var selectors []*common.Selector
+	for _, value := range values {
+		selectors = append(selectors, &common.Selector{Type: pluginName, Value: value})
+	}
+	return selectors
+}
+
+type fakeSecret struct {
+	managed  bool
+	deleted  bool
+	kmsKeyID string
+	value    string
+}
+
+type fakeClient struct {
+	secrets map[string]*fakeSecret
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		secrets: make(map[string]*fakeSecret),
+	}
+}
+
+func (c *fakeClient) CreateSecretWithContext(_ aws.Context, input *secretsmanager.CreateSecretInput, _ ...request.Option) (*secretsmanager.CreateSecretOutput, error) {
+	name := aws.StringValue(input.Name)
+	if _, ok := c.secrets[name]; ok {
+		return nil, awserr.New(secretsmanager.ErrCodeResourceExistsException, "secret exists", nil)
+	}
+	secret := &fakeSecret{
+		kmsKeyID: aws.StringValue(input.KmsKeyId),
+		value:    aws.StringValue(input.SecretString),
+	}
+	for _, tag := range input.Tags {
+		if aws.StringValue(tag.Key) == managedTag && aws.StringValue(tag.Value) == "true" {
+			secret.managed = true
+		}
+	}
+	c.secrets[name] = secret
+	return &secretsmanager.CreateSecretOutput{Name: input.Name}, nil
+}
+
+func (c *fakeClient) DeleteSecretWithContext(_ aws.Context, input *secretsmanager.DeleteSecretInput, _ ...request.Option) (*secretsmanager.DeleteSecretOutput, error) {
+	secret, err := c.getSecret(input.SecretId)
+	if err != nil {
+		return nil, err
+	}
+	secret.deleted = true
+	return &secretsmanager.DeleteSecretOutput{}, nil
+}
+
+func (c *fakeClient) DescribeSecretWithContext(_ aws.Context, input *secretsmanager.DescribeSecretInput, _ ...request.Option) (*secretsmanager.DescribeSecretOutput, error) {
+	secret, err := c.getSecret(input.SecretId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &secretsmanager.DescribeSecretOutput{}
+	if secret.managed {
+		resp.Tags = []*secretsmanager.Tag{{Key: aws.String(managedTag), Value: aws.String("true")}}
+	}
+	if secret.deleted {
+		resp.DeletedDate = aws.Time(time.Now())
+	}
+	return resp, nil
+}
+
+func (c *fakeClient) PutSecretValueWithContext(_ aws.Context, input *secretsmanager.PutSecretValueInput, _ ...request.Option) (*secretsmanager.PutSecretValueOutput, error) {
+	secret, err := c.getSecret(input.SecretId)
+	if err != nil {
+		return nil, err
+	}
+	secret.value = aws.StringValue(input.SecretString)
+	return &secretsmanager.PutSecretValueOutput{}, nil
+}
+
+func (c *fakeClient) getSecret(secretID *string) (*fakeSecret, error) {
+	secret, ok := c.secrets[aws.StringValue(secretID)]
+	if !ok {
+		return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "secret not found", nil)
+	}
+	return secret, nil
+}
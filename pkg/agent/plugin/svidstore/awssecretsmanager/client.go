@@ -0,0 +1,43 @@
+package awssecretsmanager
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+type secretsManagerClient interface {
+	CreateSecretWithContext(aws.Context, *secretsmanager.CreateSecretInput, ...request.Option) (*secretsmanager.CreateSecretOutput, error)
+	DeleteSecretWithContext(aws.Context, *secretsmanager.DeleteSecretInput, ...request.Option) (*secretsmanager.DeleteSecretOutput, error)
+	DescribeSecretWithContext(aws.Context, *secretsmanager.DescribeSecretInput, ...request.Option) (*secretsmanager.DescribeSecretOutput, error)
+	PutSecretValueWithContext(aws.Context, *secretsmanager.PutSecretValueInput, ...request.Option) (*secretsmanager.PutSecretValueOutput, error)
+}
+
+func newSecretsManagerClient(config *Config) (secretsManagerClient, error) {
+	awsConfig := &aws.Config{
+		Region: aws.String(config.Region),
+	}
+
+	if config.SecretAccessKey != "" && config.AccessKeyID != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, "")
+	}
+
+	if config.AssumeRoleARN != "" {
+		staticSession, err := session.NewSession(&aws.Config{Credentials: awsConfig.Credentials})
+		if err != nil {
+			return nil, err
+		}
+		awsConfig.Credentials = stscreds.NewCredentialsWithClient(sts.New(staticSession), config.AssumeRoleARN)
+	}
+
+	awsSession, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return secretsmanager.New(awsSession), nil
+}
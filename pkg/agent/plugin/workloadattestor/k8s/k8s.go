@@ -10,6 +10,7 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -51,7 +52,12 @@ const (
 	containerNotInPod
 )
 
-var k8sErr = errs.Class("k8s")
+var (
+	k8sErr = errs.Class("k8s")
+
+	errUnauthorized = errors.New("kubelet rejected the credentials")
+	errUnreachable  = errors.New("kubelet is unreachable")
+)
 
 func BuiltIn() catalog.Plugin {
 	return builtin(New())
@@ -64,12 +70,12 @@ func builtin(p *Plugin) catalog.Plugin {
 // HCLConfig holds the configuration parsed from HCL
 type HCLConfig struct {
 	// KubeletReadOnlyPort defines the read only port for the kubelet
-	// (typically 10255). This option is mutally exclusive with
-	// KubeletSecurePort.
+	// (typically 10255). When used with KubeletSecurePort, the read only
+	// port is only queried when the secure port cannot be.
 	KubeletReadOnlyPort int `hcl:"kubelet_read_only_port"`
 
 	// KubeletSecurePort defines the secure port for the kubelet (typically
-	// 10250). This option is mutually exclusive with KubeletReadOnlyPort.
+	// 10250).
 	KubeletSecurePort int `hcl:"kubelet_secure_port"`
 
 	// MaxPollAttempts is the maximum number of polling attempts for the
@@ -90,7 +96,9 @@ type HCLConfig struct {
 
 	// TokenPath is the path to the bearer token used to authenticate to the
 	// secure port. Defaults to the default service account token path unless
-	// PrivateKeyPath and CertificatePath are specified.
+	// PrivateKeyPath and CertificatePath are specified. The token is loaded
+	// again as soon as the kubelet rejects it, so that projected service
+	// account tokens are picked up when they are rotated.
 	TokenPath string `hcl:"token_path"`
 
 	// CertificatePath is the path to a certificate key used for client
@@ -120,6 +128,7 @@ type HCLConfig struct {
 type k8sConfig struct {
 	Secure                  bool
 	Port                    int
+	ReadOnlyFallbackPort    int
 	MaxPollAttempts         int
 	PollRetryInterval       time.Duration
 	SkipKubeletVerification bool
@@ -132,6 +141,10 @@ type k8sConfig struct {
 
 	Client     *kubeletClient
 	LastReload time.Time
+
+	// ReadOnlyFallbackClient, if set, queries the read-only port when the
+	// secure port cannot be queried
+	ReadOnlyFallbackClient *kubeletClient
 }
 
 type Plugin struct {
@@ -179,7 +192,7 @@ func (p *Plugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest
 	for attempt := 1; ; attempt++ {
 		log = log.With(telemetry.Attempt, attempt)
 
-		list, err := config.Client.GetPodList()
+		list, err := p.getPodList(config)
 		if err != nil {
 			return nil, err
 		}
@@ -253,21 +266,29 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (resp
 	// Determine which kubelet port to hit. Default to the secure port if none
 	// is specified (this is backwards compatible because the read-only-port
 	// config value has always been required, so it should already be set in
-	// existing configurations that rely on it).
-	if config.KubeletSecurePort > 0 && config.KubeletReadOnlyPort > 0 {
-		return nil, k8sErr.New("cannot use both the read-only and secure port")
-	}
+	// existing configurations that rely on it). When both ports are
+	// specified, the secure port is preferred and the read-only port is
+	// only used as a fallback.
 	port := config.KubeletReadOnlyPort
 	secure := false
-	if port <= 0 {
+	readOnlyFallbackPort := 0
+	switch {
+	case config.KubeletSecurePort > 0 && config.KubeletReadOnlyPort > 0:
 		port = config.KubeletSecurePort
 		secure = true
-	}
-	if port <= 0 {
+		readOnlyFallbackPort = config.KubeletReadOnlyPort
+	case port <= 0 && config.KubeletSecurePort > 0:
+		port = config.KubeletSecurePort
+		secure = true
+	case port <= 0:
 		port = defaultSecureKubeletPort
 		secure = true
 	}
 
+	if secure && config.SkipKubeletVerification {
+		p.log.Warn("Kubelet certificate verification is disabled; consider verifying it against the cluster CA with kubelet_ca_path instead")
+	}
+
 	// Determine the node name
 	nodeName := p.getNodeName(config.NodeName, config.NodeNameEnv)
 
@@ -275,6 +296,7 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (resp
 	c := &k8sConfig{
 		Secure:                  secure,
 		Port:                    port,
+		ReadOnlyFallbackPort:    readOnlyFallbackPort,
 		MaxPollAttempts:         maxPollAttempts,
 		PollRetryInterval:       pollRetryInterval,
 		SkipKubeletVerification: config.SkipKubeletVerification,
@@ -326,19 +348,31 @@ func (p *Plugin) getContainerIDFromCGroups(pid int32) (string, error) {
 	return getContainerIDFromCGroups(cgroups)
 }
 
+// getPodList queries the kubelet for the pods running on the node, falling
+// back to the read-only port when configured and nothing listens on the
+// secure port. TLS verification and authentication failures are returned
+// instead, since falling back on them would let anyone able to break or
+// spoof the secure port force attestation onto the unauthenticated port.
+func (p *Plugin) getPodList(config *k8sConfig) (*corev1.PodList, error) {
+	list, err := config.Client.GetPodList()
+	if errors.Is(err, errUnreachable) && config.ReadOnlyFallbackClient != nil {
+		p.log.Warn("Unable to query the kubelet secure port; falling back to the read-only port", "err", err)
+		return config.ReadOnlyFallbackClient.GetPodList()
+	}
+	return list, err
+}
+
 func (p *Plugin) reloadKubeletClient(config *k8sConfig) (err error) {
-	// The insecure client only needs to be loaded once.
+	// The insecure clients only need to be loaded once.
 	if !config.Secure {
 		if config.Client == nil {
-			config.Client = &kubeletClient{
-				URL: url.URL{
-					Scheme: "http",
-					Host:   fmt.Sprintf("127.0.0.1:%d", config.Port),
-				},
-			}
+			config.Client = newReadOnlyKubeletClient(config.Port)
 		}
 		return nil
 	}
+	if config.ReadOnlyFallbackPort > 0 && config.ReadOnlyFallbackClient == nil {
+		config.ReadOnlyFallbackClient = newReadOnlyKubeletClient(config.ReadOnlyFallbackPort)
+	}
 
 	// Is the client still fresh?
 	if config.Client != nil && p.clock.Now().Sub(config.LastReload) < config.ReloadInterval {
@@ -392,6 +426,7 @@ func (p *Plugin) reloadKubeletClient(config *k8sConfig) (err error) {
 	}
 
 	var token string
+	var loadToken func() (string, error)
 	switch {
 	case config.CertificatePath != "" && config.PrivateKeyPath != "":
 		kp, err := p.loadX509KeyPair(config.CertificatePath, config.PrivateKeyPath)
@@ -408,6 +443,10 @@ func (p *Plugin) reloadKubeletClient(config *k8sConfig) (err error) {
 		if err != nil {
 			return err
 		}
+		tokenPath := config.TokenPath
+		loadToken = func() (string, error) {
+			return p.loadToken(tokenPath)
+		}
 	}
 
 	host := config.NodeName
@@ -423,7 +462,8 @@ func (p *Plugin) reloadKubeletClient(config *k8sConfig) (err error) {
 			Scheme: "https",
 			Host:   fmt.Sprintf("%s:%d", host, config.Port),
 		},
-		Token: token,
+		Token:     token,
+		LoadToken: loadToken,
 	}
 	config.LastReload = p.clock.Now()
 	return nil
@@ -493,21 +533,63 @@ func (p *Plugin) getNodeName(name string, env string) string {
 	}
 }
 
+func newReadOnlyKubeletClient(port int) *kubeletClient {
+	return &kubeletClient{
+		URL: url.URL{
+			Scheme: "http",
+			Host:   fmt.Sprintf("127.0.0.1:%d", port),
+		},
+	}
+}
+
 type kubeletClient struct {
 	Transport *http.Transport
 	URL       url.URL
 	Token     string
+
+	// LoadToken, if set, loads the token again when the kubelet rejects
+	// the current one, e.g. because the projected token has been rotated
+	LoadToken func() (string, error)
+
+	tokenMu sync.Mutex
 }
 
 func (c *kubeletClient) GetPodList() (*corev1.PodList, error) {
+	token := c.getToken()
+	list, err := c.getPodList(token)
+	if !errors.Is(err, errUnauthorized) || c.LoadToken == nil {
+		return list, err
+	}
+
+	newToken, loadErr := c.LoadToken()
+	if loadErr != nil || newToken == token {
+		return nil, err
+	}
+	c.setToken(newToken)
+	return c.getPodList(newToken)
+}
+
+func (c *kubeletClient) getToken() string {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return c.Token
+}
+
+func (c *kubeletClient) setToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.Token = token
+}
+
+func (c *kubeletClient) getPodList(token string) (*corev1.PodList, error) {
 	url := c.URL
 	url.Path = "/pods"
 	req, err := http.NewRequest("GET", url.String(), nil)
 	if err != nil {
 		return nil, k8sErr.New("unable to create request: %v", err)
 	}
-	if c.Token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.Token)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
 	client := &http.Client{}
@@ -515,11 +597,17 @@ func (c *kubeletClient) GetPodList() (*corev1.PodList, error) {
 		client.Transport = c.Transport
 	}
 	resp, err := client.Do(req)
-	if err != nil {
+	switch {
+	case isDialError(err):
+		return nil, k8sErr.Wrap(fmt.Errorf("unable to perform request: %w: %v", errUnreachable, err))
+	case err != nil:
 		return nil, k8sErr.New("unable to perform request: %v", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, k8sErr.Wrap(fmt.Errorf("%w: %s", errUnauthorized, tryRead(resp.Body)))
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, k8sErr.New("unexpected status code on pods response: %d %s", resp.StatusCode, tryRead(resp.Body))
 	}
@@ -532,6 +620,14 @@ func (c *kubeletClient) GetPodList() (*corev1.PodList, error) {
 	return out, nil
 }
 
+// isDialError returns true if the connection to the kubelet could not be
+// established, e.g. because nothing is listening on the port. Errors that
+// happen once connected, like TLS handshake failures, are not dial errors.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 func getContainerIDFromCGroups(cgroups []cgroups.Cgroup) (string, error) {
 	var containerID string
 	for _, cgroup := range cgroups {
@@ -142,9 +142,10 @@ type Suite struct {
 	env     map[string]string
 
 	// kubelet stuff
-	server      *httptest.Server
-	kubeletCert *x509.Certificate
-	clientCert  *x509.Certificate
+	server       *httptest.Server
+	kubeletCert  *x509.Certificate
+	clientCert   *x509.Certificate
+	kubeletToken string
 }
 
 func (s *Suite) SetupTest() {
@@ -290,6 +291,68 @@ func (s *Suite) TestAttestOverSecurePortViaTokenAuth() {
 	s.requireAttestFailure(`expected "Bearer default-token", got "Bearer bad-token"`)
 }
 
+func (s *Suite) TestAttestOverSecurePortWithRotatedToken() {
+	s.startSecureKubelet(true, "default-token")
+	s.configureSecure(``)
+	s.requireAttestSuccessWithPod()
+
+	// rotate the token and make sure it is picked up as soon as the kubelet
+	// rejects the previous one, without waiting for the reload interval
+	s.kubeletToken = "rotated-token"
+	s.writeFile(defaultTokenPath, "rotated-token")
+	s.requireAttestSuccessWithPod()
+}
+
+func (s *Suite) TestAttestFallsBackToReadOnlyPort() {
+	// reserve a port the secure kubelet is not listening on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	securePort := l.Addr().(*net.TCPAddr).Port
+	s.Require().NoError(l.Close())
+
+	s.startInsecureKubelet()
+	s.configure(fmt.Sprintf(`
+		kubelet_secure_port = %d
+		kubelet_read_only_port = %d
+		skip_kubelet_verification = true
+		max_poll_attempts = 5
+		poll_retry_interval = "1s"
+	`, securePort, s.kubeletPort()))
+
+	s.requireAttestSuccessWithPod()
+}
+
+func (s *Suite) TestAttestDoesNotFallBackOnKubeletCAMismatch() {
+	readOnlyPort, readOnlyHits := s.startReadOnlyFallbackKubelet()
+	s.startSecureKubelet(true, "default-token")
+
+	// verify the kubelet against a CA that did not sign its certificate
+	s.writeCert(defaultKubeletCAPath, s.createKubeletCert("some-other-ca"))
+	s.configureSecure(fmt.Sprintf(`
+		kubelet_read_only_port = %d
+	`, readOnlyPort))
+
+	s.addPodListResponse(podListFilePath)
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	s.requireAttestFailure("x509: certificate signed by unknown authority")
+	s.Require().Zero(*readOnlyHits, "the read-only port must not be queried")
+}
+
+func (s *Suite) TestAttestDoesNotFallBackOnRejectedCredentials() {
+	readOnlyPort, readOnlyHits := s.startReadOnlyFallbackKubelet()
+	s.startSecureKubelet(true, "default-token")
+
+	s.writeFile(defaultTokenPath, "bad-token")
+	s.configureSecure(fmt.Sprintf(`
+		kubelet_read_only_port = %d
+	`, readOnlyPort))
+
+	s.addPodListResponse(podListFilePath)
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	s.requireAttestFailure(`expected "Bearer default-token", got "Bearer bad-token"`)
+	s.Require().Zero(*readOnlyHits, "the read-only port must not be queried")
+}
+
 func (s *Suite) TestAttestOverSecurePortViaClientAuth() {
 	// start up the secure kubelet with host networking and require client certs
 	s.startSecureKubelet(true, "")
@@ -360,6 +423,7 @@ func (s *Suite) TestConfigure() {
 		HasNodeName       bool
 		Token             string
 		KubeletURL        string
+		FallbackURL       string
 		MaxPollAttempts   int
 		PollRetryInterval time.Duration
 		ReloadInterval    time.Duration
@@ -471,7 +535,15 @@ func (s *Suite) TestConfigure() {
 				kubelet_read_only_port = 10255
 				kubelet_secure_port = 10250
 			`,
-			err: "cannot use both the read-only and secure port",
+			config: &config{
+				VerifyKubelet:     true,
+				Token:             "default-token",
+				KubeletURL:        "https://127.0.0.1:10250",
+				FallbackURL:       "http://127.0.0.1:10255",
+				MaxPollAttempts:   defaultMaxPollAttempts,
+				PollRetryInterval: defaultPollRetryInterval,
+				ReloadInterval:    defaultReloadInterval,
+			},
 		},
 		{
 			name: "non-existent kubelet ca",
@@ -605,6 +677,13 @@ func (s *Suite) TestConfigure() {
 			}
 			assert.Equal(t, testCase.config.Token, c.Client.Token)
 			assert.Equal(t, testCase.config.KubeletURL, c.Client.URL.String())
+			if testCase.config.FallbackURL != "" {
+				if assert.NotNil(t, c.ReadOnlyFallbackClient) {
+					assert.Equal(t, testCase.config.FallbackURL, c.ReadOnlyFallbackClient.URL.String())
+				}
+			} else {
+				assert.Nil(t, c.ReadOnlyFallbackClient)
+			}
 			assert.Equal(t, testCase.config.MaxPollAttempts, c.MaxPollAttempts)
 			assert.Equal(t, testCase.config.PollRetryInterval, c.PollRetryInterval)
 			assert.Equal(t, testCase.config.ReloadInterval, c.ReloadInterval)
@@ -681,6 +760,18 @@ func (s *Suite) startInsecureKubelet() {
 	s.setServer(httptest.NewServer(http.HandlerFunc(s.serveHTTP)))
 }
 
+// startReadOnlyFallbackKubelet starts a read-only kubelet alongside the one
+// under test and returns its port along with the number of requests it got.
+func (s *Suite) startReadOnlyFallbackKubelet() (int, *int) {
+	hits := new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		*hits++
+		s.serveHTTP(w, req)
+	}))
+	s.T().Cleanup(server.Close)
+	return server.Listener.Addr().(*net.TCPAddr).Port, hits
+}
+
 func (s *Suite) generateCerts(nodeName string) {
 	s.kubeletCert = s.createKubeletCert(nodeName)
 	s.writeCert(defaultKubeletCAPath, s.kubeletCert)
@@ -700,13 +791,14 @@ func (s *Suite) startSecureKubelet(hostNetworking bool, token string) {
 		dnsName = "this-name-should-never-be-validated"
 	}
 	s.generateCerts(dnsName)
+	s.kubeletToken = token
 
 	clientCAs := x509.NewCertPool()
 	if s.clientCert != nil {
 		clientCAs.AddCert(s.clientCert)
 	}
 	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		if token == "" {
+		if s.kubeletToken == "" {
 			if len(req.TLS.VerifiedChains) == 0 {
 				http.Error(w, "client auth expected but not used", http.StatusForbidden)
 				return
@@ -716,10 +808,10 @@ func (s *Suite) startSecureKubelet(hostNetworking bool, token string) {
 				http.Error(w, "client auth not expected but used", http.StatusForbidden)
 				return
 			}
-			expectedAuth := "Bearer " + token
+			expectedAuth := "Bearer " + s.kubeletToken
 			auth := req.Header.Get("Authorization")
 			if auth != expectedAuth {
-				http.Error(w, fmt.Sprintf("expected %q, got %q", expectedAuth, auth), http.StatusForbidden)
+				http.Error(w, fmt.Sprintf("expected %q, got %q", expectedAuth, auth), http.StatusUnauthorized)
 				return
 			}
 		}
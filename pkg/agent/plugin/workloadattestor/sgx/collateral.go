@@ -0,0 +1,276 @@
+package sgx
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/pemutil"
+)
+
+const (
+	// tcbInfoVersion is the only supported version of the TCB info
+	// structure, as served by version 3 of the Intel PCS API
+	tcbInfoVersion = 3
+
+	// tcbComponentCount is the number of SGX TCB components of a TCB level
+	tcbComponentCount = 16
+)
+
+var (
+	// oidSGXExtension is the OID of the SGX extension of PCK certificates
+	oidSGXExtension = asn1.ObjectIdentifier{1, 2, 840, 113741, 1, 13, 1}
+	// oidSGXTCB is the OID of the TCB field of the SGX extension. The OIDs
+	// of the TCB components are suffixed with 1 to 16, the one of the PCE
+	// SVN with 17.
+	oidSGXTCB = asn1.ObjectIdentifier{1, 2, 840, 113741, 1, 13, 1, 2}
+	// oidSGXFMSPC is the OID of the FMSPC field of the SGX extension
+	oidSGXFMSPC = asn1.ObjectIdentifier{1, 2, 840, 113741, 1, 13, 1, 4}
+)
+
+// pckTCB holds the TCB level and the family of the platform, as certified by
+// the SGX extension of its PCK certificate
+type pckTCB struct {
+	Components [tcbComponentCount]int
+	PCESVN     int
+	FMSPC      []byte
+}
+
+type sgxExtensionField struct {
+	ID    asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+func parsePCKTCB(cert *x509.Certificate) (*pckTCB, error) {
+	var fields []sgxExtensionField
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidSGXExtension) {
+			continue
+		}
+		if rest, err := asn1.Unmarshal(ext.Value, &fields); err != nil {
+			return nil, fmt.Errorf("malformed SGX extension: %v", err)
+		} else if len(rest) > 0 {
+			return nil, errors.New("malformed SGX extension: trailing data")
+		}
+	}
+
+	tcb := new(pckTCB)
+	var hasTCB bool
+	for _, field := range fields {
+		switch {
+		case field.ID.Equal(oidSGXTCB):
+			if err := parseTCBField(field.Value.FullBytes, tcb); err != nil {
+				return nil, err
+			}
+			hasTCB = true
+		case field.ID.Equal(oidSGXFMSPC):
+			tcb.FMSPC = field.Value.Bytes
+		}
+	}
+	if !hasTCB || tcb.FMSPC == nil {
+		return nil, errors.New("PCK certificate does not hold the TCB level of the platform")
+	}
+	return tcb, nil
+}
+
+func parseTCBField(b []byte, tcb *pckTCB) error {
+	var components []sgxExtensionField
+	if _, err := asn1.Unmarshal(b, &components); err != nil {
+		return fmt.Errorf("malformed SGX TCB extension field: %v", err)
+	}
+	for _, component := range components {
+		if len(component.ID) != len(oidSGXTCB)+1 || !component.ID[:len(oidSGXTCB)].Equal(oidSGXTCB) {
+			continue
+		}
+		n := component.ID[len(oidSGXTCB)]
+		if n < 1 || n > tcbComponentCount+1 {
+			// CPUSVN, which duplicates the components
+			continue
+		}
+		var svn int
+		if _, err := asn1.Unmarshal(component.Value.FullBytes, &svn); err != nil {
+			return fmt.Errorf("malformed SGX TCB component %d: %v", n, err)
+		}
+		if n == tcbComponentCount+1 {
+			tcb.PCESVN = svn
+		} else {
+			tcb.Components[n-1] = svn
+		}
+	}
+	return nil
+}
+
+// tcbInfo is the TCB info of a platform family, as served by the Intel PCS
+type tcbInfo struct {
+	Version    int       `json:"version"`
+	NextUpdate time.Time `json:"nextUpdate"`
+	FMSPC      string    `json:"fmspc"`
+	TCBLevels  []struct {
+		TCB struct {
+			SGXTCBComponents []struct {
+				SVN int `json:"svn"`
+			} `json:"sgxtcbcomponents"`
+			PCESVN int `json:"pcesvn"`
+		} `json:"tcb"`
+		TCBStatus string `json:"tcbStatus"`
+	} `json:"tcbLevels"`
+}
+
+// loadTCBInfo loads the TCB info at path and verifies its signature with the
+// TCB signing certificate of the chain at chainPath, which must chain up to
+// one of the roots.
+func loadTCBInfo(path, chainPath string, roots *x509.CertPool, now time.Time) (*tcbInfo, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := pemutil.LoadCertificates(chainPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load TCB signing certificates: %v", err)
+	}
+
+	var signed struct {
+		TCBInfo   json.RawMessage `json:"tcbInfo"`
+		Signature string          `json:"signature"`
+	}
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, fmt.Errorf("malformed TCB info: %v", err)
+	}
+	sig, err := hex.DecodeString(signed.Signature)
+	if err != nil || len(sig) != signatureSize {
+		return nil, errors.New("malformed TCB info signature")
+	}
+
+	if _, err := verifyChain(chain, roots, now); err != nil {
+		return nil, fmt.Errorf("TCB signing certificate is not trusted: %v", err)
+	}
+	signingKey, ok := chain[0].PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("TCB signing certificate does not hold an ECDSA key")
+	}
+	// The signature is over the tcbInfo field exactly as served
+	if !verifySignature(signingKey, signed.TCBInfo, sig) {
+		return nil, errors.New("TCB info signature is invalid")
+	}
+
+	info := new(tcbInfo)
+	if err := json.Unmarshal(signed.TCBInfo, info); err != nil {
+		return nil, fmt.Errorf("malformed TCB info: %v", err)
+	}
+	if info.Version != tcbInfoVersion {
+		return nil, fmt.Errorf("unsupported TCB info version %d", info.Version)
+	}
+	return info, nil
+}
+
+// Status returns the status of the first TCB level of the info the platform
+// TCB level is at or above. TCB levels are sorted from the most recent one.
+func (info *tcbInfo) Status(tcb *pckTCB, now time.Time) (string, error) {
+	if now.After(info.NextUpdate) {
+		return "", fmt.Errorf("TCB info has expired on %s", info.NextUpdate.Format(time.RFC3339))
+	}
+	if !strings.EqualFold(info.FMSPC, hex.EncodeToString(tcb.FMSPC)) {
+		return "", fmt.Errorf("TCB info is for FMSPC %s, not the FMSPC %x of the platform", info.FMSPC, tcb.FMSPC)
+	}
+
+levels:
+	for _, level := range info.TCBLevels {
+		if len(level.TCB.SGXTCBComponents) != tcbComponentCount {
+			return "", errors.New("malformed TCB info: TCB level does not have 16 components")
+		}
+		for i, component := range level.TCB.SGXTCBComponents {
+			if tcb.Components[i] < component.SVN {
+				continue levels
+			}
+		}
+		if tcb.PCESVN < level.TCB.PCESVN {
+			continue
+		}
+		return level.TCBStatus, nil
+	}
+	return "", errors.New("TCB level of the platform is not supported")
+}
+
+// loadCRLs loads the PEM or DER encoded CRLs at the paths
+func loadCRLs(paths []string) ([]*pkix.CertificateList, error) {
+	var crls []*pkix.CertificateList
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if block, _ := pem.Decode(data); block != nil {
+			data = block.Bytes
+		}
+		crl, err := x509.ParseDERCRL(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse CRL %s: %v", path, err)
+		}
+		crls = append(crls, crl)
+	}
+	return crls, nil
+}
+
+// checkRevocation checks that none of the certificates of the chain, from
+// the leaf to the root, is revoked. Every certificate but the root must be
+// covered by a current CRL of its issuer.
+func checkRevocation(chain []*x509.Certificate, crls []*pkix.CertificateList, now time.Time) error {
+	for i := 0; i < len(chain)-1; i++ {
+		cert, issuer := chain[i], chain[i+1]
+		crl := findCRL(issuer, crls)
+		if crl == nil {
+			return fmt.Errorf("no CRL of %q is configured", issuer.Subject.CommonName)
+		}
+		if now.After(crl.TBSCertList.NextUpdate) {
+			return fmt.Errorf("CRL of %q has expired", issuer.Subject.CommonName)
+		}
+		for _, revoked := range crl.TBSCertList.RevokedCertificates {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return fmt.Errorf("certificate %q is revoked", cert.Subject.CommonName)
+			}
+		}
+	}
+	return nil
+}
+
+// findCRL returns the most recent of the CRLs signed by the issuer
+func findCRL(issuer *x509.Certificate, crls []*pkix.CertificateList) *pkix.CertificateList {
+	var found *pkix.CertificateList
+	for _, crl := range crls {
+		if issuer.CheckCRLSignature(crl) != nil {
+			continue
+		}
+		if found == nil || crl.TBSCertList.ThisUpdate.After(found.TBSCertList.ThisUpdate) {
+			found = crl
+		}
+	}
+	return found
+}
+
+// verifyChain verifies that the first certificate of the chain chains up to
+// one of the roots, and returns the verified chain, from the leaf to the root
+func verifyChain(chain []*x509.Certificate, roots *x509.CertPool, now time.Time) ([]*x509.Certificate, error) {
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	chains, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chains[0], nil
+}
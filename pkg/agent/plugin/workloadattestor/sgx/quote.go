@@ -0,0 +1,270 @@
+package sgx
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Layout of version 3 SGX ECDSA quotes, as produced by the DCAP quoting
+// enclave. All integers are little endian.
+const (
+	quoteVersion = 3
+
+	// attestationKeyTypeECDSAP256 is the only attestation key type of
+	// version 3 quotes
+	attestationKeyTypeECDSAP256 = 2
+
+	quoteHeaderSize = 48
+	reportBodySize  = 384
+
+	// signedQuoteSize is the size of the part of the quote signed by the
+	// attestation key: the header and the report body of the enclave
+	signedQuoteSize = quoteHeaderSize + reportBodySize
+
+	signatureSize = 64
+	publicKeySize = 64
+
+	// pckCertChainType is the certification data type of a PEM encoded PCK
+	// certificate chain
+	pckCertChainType = 5
+
+	// attributeDebug is set in the attributes of debug enclaves, whose
+	// memory can be inspected and which therefore must not be trusted
+	attributeDebug = 0x02
+)
+
+// reportBody holds the fields of an SGX report body the plugin relies on
+type reportBody struct {
+	Attributes uint64
+	MRENCLAVE  [32]byte
+	MRSIGNER   [32]byte
+	ISVProdID  uint16
+	ISVSVN     uint16
+	ReportData [64]byte
+}
+
+func (r *reportBody) Debug() bool {
+	return r.Attributes&attributeDebug != 0
+}
+
+func parseReportBody(b []byte) reportBody {
+	var r reportBody
+	r.Attributes = binary.LittleEndian.Uint64(b[48:56])
+	copy(r.MRENCLAVE[:], b[64:96])
+	copy(r.MRSIGNER[:], b[128:160])
+	r.ISVProdID = binary.LittleEndian.Uint16(b[256:258])
+	r.ISVSVN = binary.LittleEndian.Uint16(b[258:260])
+	copy(r.ReportData[:], b[320:384])
+	return r
+}
+
+// quote is a parsed SGX ECDSA quote
+type quote struct {
+	// Report is the report body of the quoted enclave
+	Report reportBody
+
+	signed         []byte
+	reportSig      []byte
+	attestationKey []byte
+	qeReportRaw    []byte
+	qeReport       reportBody
+	qeReportSig    []byte
+	qeAuthData     []byte
+	pckChain       []*x509.Certificate
+
+	// verifiedChain is the PCK certificate chain, from the PCK certificate
+	// to the root, once verified
+	verifiedChain []*x509.Certificate
+}
+
+func parseQuote(b []byte) (*quote, error) {
+	r := &quoteReader{b: b}
+
+	header := r.next(quoteHeaderSize)
+	rawReport := r.next(reportBodySize)
+	sigDataLen := r.uint32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if version := binary.LittleEndian.Uint16(header[0:2]); version != quoteVersion {
+		return nil, fmt.Errorf("unsupported quote version %d", version)
+	}
+	if keyType := binary.LittleEndian.Uint16(header[2:4]); keyType != attestationKeyTypeECDSAP256 {
+		return nil, fmt.Errorf("unsupported attestation key type %d", keyType)
+	}
+	if int(sigDataLen) != len(b)-signedQuoteSize-4 {
+		return nil, fmt.Errorf("quote signature data length %d does not match the quote size", sigDataLen)
+	}
+
+	q := &quote{
+		Report:         parseReportBody(rawReport),
+		signed:         b[:signedQuoteSize],
+		reportSig:      r.next(signatureSize),
+		attestationKey: r.next(publicKeySize),
+		qeReportRaw:    r.next(reportBodySize),
+		qeReportSig:    r.next(signatureSize),
+	}
+	q.qeAuthData = r.next(int(r.uint16()))
+	certType := r.uint16()
+	certData := r.next(int(r.uint32()))
+	if r.err != nil {
+		return nil, r.err
+	}
+	q.qeReport = parseReportBody(q.qeReportRaw)
+
+	if certType != pckCertChainType {
+		return nil, fmt.Errorf("unsupported certification data type %d", certType)
+	}
+	chain, err := parsePEMCertificates(certData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PCK certificate chain: %v", err)
+	}
+	q.pckChain = chain
+	return q, nil
+}
+
+// qeIdentity is the identity the quoting enclave must have
+type qeIdentity struct {
+	MRSIGNER  [32]byte
+	ISVProdID uint16
+	MinISVSVN uint16
+}
+
+// Verify verifies that the quote was produced by a genuine quoting enclave:
+//
+//   - the PCK certificate chain chains up to one of the roots;
+//   - the report of the quoting enclave is signed by the PCK key;
+//   - the quoting enclave has the expected identity;
+//   - the report of the quoting enclave binds the attestation key;
+//   - the report of the quoted enclave is signed by the attestation key.
+//
+// The revocation status of the PCK certificate chain and the TCB level of
+// the platform are checked separately against the verified chain.
+func (q *quote) Verify(roots *x509.CertPool, qe qeIdentity, now time.Time) error {
+	chain, err := verifyChain(q.pckChain, roots, now)
+	if err != nil {
+		return fmt.Errorf("PCK certificate is not trusted: %v", err)
+	}
+	q.verifiedChain = chain
+
+	pckKey, ok := q.pckChain[0].PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("PCK certificate does not hold an ECDSA key")
+	}
+	if !verifySignature(pckKey, q.qeReportRaw, q.qeReportSig) {
+		return errors.New("quoting enclave report signature is invalid")
+	}
+
+	if q.qeReport.MRSIGNER != qe.MRSIGNER {
+		return fmt.Errorf("quoting enclave MRSIGNER %x is not the expected one", q.qeReport.MRSIGNER)
+	}
+	if q.qeReport.ISVProdID != qe.ISVProdID {
+		return fmt.Errorf("quoting enclave ISVPRODID %d is not the expected one", q.qeReport.ISVProdID)
+	}
+	if q.qeReport.ISVSVN < qe.MinISVSVN {
+		return fmt.Errorf("quoting enclave ISVSVN %d is lower than %d", q.qeReport.ISVSVN, qe.MinISVSVN)
+	}
+
+	binding := sha256.Sum256(append(append([]byte(nil), q.attestationKey...), q.qeAuthData...))
+	if !bytes.Equal(q.qeReport.ReportData[:32], binding[:]) {
+		return errors.New("quoting enclave report does not bind the attestation key")
+	}
+
+	attestationKey, err := parseRawPublicKey(q.attestationKey)
+	if err != nil {
+		return err
+	}
+	if !verifySignature(attestationKey, q.signed, q.reportSig) {
+		return errors.New("enclave report signature is invalid")
+	}
+	return nil
+}
+
+func parseRawPublicKey(b []byte) (*ecdsa.PublicKey, error) {
+	key := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(b[:32]),
+		Y:     new(big.Int).SetBytes(b[32:]),
+	}
+	if !key.Curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.New("attestation key is not a valid P-256 key")
+	}
+	return key, nil
+}
+
+// verifySignature verifies a raw r||s ECDSA signature over the SHA-256 digest
+// of the message
+func verifySignature(key *ecdsa.PublicKey, message, sig []byte) bool {
+	digest := sha256.Sum256(message)
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	return ecdsa.Verify(key, digest[:], r, s)
+}
+
+func parsePEMCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+	return certs, nil
+}
+
+// quoteReader reads the fields of a quote, recording an error if the quote
+// is too short
+type quoteReader struct {
+	b   []byte
+	err error
+}
+
+func (r *quoteReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < n {
+		r.err = errors.New("quote is truncated")
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *quoteReader) uint16() uint16 {
+	b := r.next(2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+func (r *quoteReader) uint32() uint32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
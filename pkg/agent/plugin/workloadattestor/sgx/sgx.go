@@ -0,0 +1,410 @@
+package sgx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/zeebo/errs"
+)
+
+const (
+	pluginName = "sgx"
+
+	// maxQuoteSize bounds the size of the quote files read by the plugin.
+	// ECDSA quotes, PCK certificate chain included, are around 5KiB.
+	maxQuoteSize = 64 * 1024
+
+	// bindingPrefix is the prefix of the string hashed into the report data
+	// of the enclave to bind the quote to the workload process
+	bindingPrefix = "spire-sgx-workload"
+
+	// intelQEMRSIGNER is the MRSIGNER of the Intel quoting enclave
+	intelQEMRSIGNER = "8c4f5775d796503e96137f77c68a829a0056ac8ded70140b081b094490c57bff"
+
+	// intelQEISVProdID is the ISVPRODID of the Intel quoting enclave
+	intelQEISVProdID = 1
+
+	// tcbStatusUpToDate is the TCB status of platforms with no known
+	// vulnerability
+	tcbStatusUpToDate = "UpToDate"
+)
+
+var (
+	sgxErr = errs.Class("sgx")
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, workloadattestor.PluginServer(p))
+}
+
+type Configuration struct {
+	// QuoteDir is the directory where workloads place the quote of their
+	// enclave, in a file named after their PID with the .quote extension
+	QuoteDir string `hcl:"quote_dir"`
+
+	// RootCAPath is the path to the PEM encoded Intel SGX root CA
+	// certificates the PCK certificate chains of quotes must chain up to
+	RootCAPath string `hcl:"root_ca_path"`
+
+	// AllowDebugEnclaves allows quotes of debug enclaves
+	AllowDebugEnclaves bool `hcl:"allow_debug_enclaves"`
+
+	// QEMRSIGNER is the hex encoded MRSIGNER the quoting enclave must have.
+	// Defaults to the one of the Intel quoting enclave.
+	QEMRSIGNER string `hcl:"qe_mrsigner"`
+
+	// QEISVProdID is the ISVPRODID the quoting enclave must have. Defaults
+	// to the one of the Intel quoting enclave.
+	QEISVProdID *int `hcl:"qe_isvprodid"`
+
+	// QEMinISVSVN is the minimum ISVSVN of the quoting enclave
+	QEMinISVSVN int `hcl:"qe_min_isvsvn"`
+
+	// PCKCRLPaths are the paths to the PEM or DER encoded CRLs of the Intel
+	// SGX root CA and PCK CAs
+	PCKCRLPaths []string `hcl:"pck_crl_paths"`
+
+	// InsecureSkipRevocationCheck disables the revocation check of the PCK
+	// certificate chain when no CRL is configured
+	InsecureSkipRevocationCheck bool `hcl:"insecure_skip_revocation_check"`
+
+	// TCBInfoPath is the path to the TCB info of the platforms, as served by
+	// version 3 of the Intel PCS API
+	TCBInfoPath string `hcl:"tcb_info_path"`
+
+	// TCBInfoSigningChainPath is the path to the PEM encoded certificate
+	// chain of the key signing the TCB info
+	TCBInfoSigningChainPath string `hcl:"tcb_info_signing_chain_path"`
+
+	// AllowedTCBStatuses are the TCB statuses of the platform for which
+	// quotes are accepted. Defaults to UpToDate.
+	AllowedTCBStatuses []string `hcl:"allowed_tcb_statuses"`
+
+	// InsecureSkipTCBEvaluation disables the evaluation of the TCB level of
+	// the platform when no TCB info is configured
+	InsecureSkipTCBEvaluation bool `hcl:"insecure_skip_tcb_evaluation"`
+}
+
+type configuration struct {
+	quoteDir                string
+	roots                   *x509.CertPool
+	allowDebugEnclaves      bool
+	qe                      qeIdentity
+	pckCRLPaths             []string
+	tcbInfoPath             string
+	tcbInfoSigningChainPath string
+	allowedTCBStatuses      map[string]bool
+}
+
+type Plugin struct {
+	log    hclog.Logger
+	mu     sync.Mutex
+	config *configuration
+
+	// hooks for tests
+	hooks struct {
+		now          func() time.Time
+		procStatPath func(pid int32) string
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{}
+	p.hooks.now = time.Now
+	p.hooks.procStatPath = getProcStatPath
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest) (*workloadattestor.AttestResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Workloads without an enclave do not place a quote and get no selectors
+	data, err := readQuoteFile(filepath.Join(config.quoteDir, fmt.Sprintf("%d.quote", req.Pid)))
+	switch {
+	case os.IsNotExist(err):
+		return &workloadattestor.AttestResponse{}, nil
+	case err != nil:
+		return nil, sgxErr.New("unable to read quote: %v", err)
+	}
+
+	q, err := parseQuote(data)
+	if err != nil {
+		return nil, sgxErr.New("unable to parse quote: %v", err)
+	}
+	now := p.hooks.now()
+	if err := q.Verify(config.roots, config.qe, now); err != nil {
+		return nil, sgxErr.New("unable to verify quote: %v", err)
+	}
+	if err := checkCollateral(config, q, now); err != nil {
+		return nil, err
+	}
+	if q.Report.Debug() && !config.allowDebugEnclaves {
+		return nil, sgxErr.New("quote is of a debug enclave")
+	}
+
+	// The report data binds the quote to the process, so that a quote
+	// cannot be replayed by another process or after the PID is reused
+	startTime, err := getProcStartTime(p.hooks.procStatPath(req.Pid))
+	if err != nil {
+		return nil, sgxErr.New("unable to get the start time of the process: %v", err)
+	}
+	binding := processBinding(req.Pid, startTime)
+	if !bytes.Equal(q.Report.ReportData[:len(binding)], binding) {
+		return nil, sgxErr.New("quote is not bound to the workload process")
+	}
+
+	selectors := []*common.Selector{
+		makeSelector("mrenclave", hex.EncodeToString(q.Report.MRENCLAVE[:])),
+		makeSelector("mrsigner", hex.EncodeToString(q.Report.MRSIGNER[:])),
+		makeSelector("isvprodid", strconv.Itoa(int(q.Report.ISVProdID))),
+		makeSelector("isvsvn", strconv.Itoa(int(q.Report.ISVSVN))),
+	}
+	if q.Report.Debug() {
+		selectors = append(selectors, makeSelector("debug", "true"))
+	}
+
+	return &workloadattestor.AttestResponse{
+		Selectors: selectors,
+	}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	hclConfig := new(Configuration)
+	if err := hcl.Decode(hclConfig, req.Configuration); err != nil {
+		return nil, sgxErr.New("unable to decode configuration: %v", err)
+	}
+	if hclConfig.QuoteDir == "" {
+		return nil, sgxErr.New("quote_dir is required")
+	}
+	if hclConfig.RootCAPath == "" {
+		return nil, sgxErr.New("root_ca_path is required")
+	}
+
+	rootCAs, err := pemutil.LoadCertificates(hclConfig.RootCAPath)
+	if err != nil {
+		return nil, sgxErr.New("unable to load root CA certificates: %v", err)
+	}
+	roots := x509.NewCertPool()
+	for _, rootCA := range rootCAs {
+		roots.AddCert(rootCA)
+	}
+
+	qe := qeIdentity{
+		ISVProdID: intelQEISVProdID,
+	}
+	qeMRSIGNER := hclConfig.QEMRSIGNER
+	if qeMRSIGNER == "" {
+		qeMRSIGNER = intelQEMRSIGNER
+	}
+	mrsigner, err := hex.DecodeString(qeMRSIGNER)
+	if err != nil || len(mrsigner) != len(qe.MRSIGNER) {
+		return nil, sgxErr.New("qe_mrsigner must be a hex encoded 32 byte value")
+	}
+	copy(qe.MRSIGNER[:], mrsigner)
+	if hclConfig.QEISVProdID != nil {
+		if *hclConfig.QEISVProdID < 0 || *hclConfig.QEISVProdID > math.MaxUint16 {
+			return nil, sgxErr.New("qe_isvprodid is out of range")
+		}
+		qe.ISVProdID = uint16(*hclConfig.QEISVProdID)
+	}
+	if hclConfig.QEMinISVSVN < 0 || hclConfig.QEMinISVSVN > math.MaxUint16 {
+		return nil, sgxErr.New("qe_min_isvsvn is out of range")
+	}
+	qe.MinISVSVN = uint16(hclConfig.QEMinISVSVN)
+
+	config := &configuration{
+		quoteDir:                hclConfig.QuoteDir,
+		roots:                   roots,
+		allowDebugEnclaves:      hclConfig.AllowDebugEnclaves,
+		qe:                      qe,
+		pckCRLPaths:             hclConfig.PCKCRLPaths,
+		tcbInfoPath:             hclConfig.TCBInfoPath,
+		tcbInfoSigningChainPath: hclConfig.TCBInfoSigningChainPath,
+		allowedTCBStatuses:      make(map[string]bool),
+	}
+
+	// The CRLs and the TCB info are loaded on every attestation so that they
+	// can be refreshed without restarting the agent. They are loaded here
+	// so that a misconfiguration is reported early.
+	switch {
+	case len(config.pckCRLPaths) > 0:
+		if _, err := loadCRLs(config.pckCRLPaths); err != nil {
+			return nil, sgxErr.New("unable to load PCK CRLs: %v", err)
+		}
+	case hclConfig.InsecureSkipRevocationCheck:
+		p.log.Warn("Revocation check of PCK certificates is disabled; consider configuring the Intel SGX CRLs with pck_crl_paths instead")
+	default:
+		return nil, sgxErr.New("pck_crl_paths is required unless insecure_skip_revocation_check is set")
+	}
+
+	switch {
+	case config.tcbInfoPath != "":
+		if config.tcbInfoSigningChainPath == "" {
+			return nil, sgxErr.New("tcb_info_signing_chain_path is required with tcb_info_path")
+		}
+		if _, err := loadTCBInfo(config.tcbInfoPath, config.tcbInfoSigningChainPath, roots, p.hooks.now()); err != nil {
+			return nil, sgxErr.New("unable to load TCB info: %v", err)
+		}
+	case hclConfig.InsecureSkipTCBEvaluation:
+		p.log.Warn("TCB evaluation of the platforms is disabled; consider configuring the Intel SGX TCB info with tcb_info_path instead")
+	default:
+		return nil, sgxErr.New("tcb_info_path is required unless insecure_skip_tcb_evaluation is set")
+	}
+
+	allowedTCBStatuses := hclConfig.AllowedTCBStatuses
+	if len(allowedTCBStatuses) == 0 {
+		allowedTCBStatuses = []string{tcbStatusUpToDate}
+	}
+	for _, status := range allowedTCBStatuses {
+		config.allowedTCBStatuses[status] = true
+	}
+
+	p.setConfig(config)
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getConfig() (*configuration, error) {
+	p.mu.Lock()
+	config := p.config
+	p.mu.Unlock()
+	if config == nil {
+		return nil, sgxErr.New("not configured")
+	}
+	return config, nil
+}
+
+func (p *Plugin) setConfig(config *configuration) {
+	p.mu.Lock()
+	p.config = config
+	p.mu.Unlock()
+}
+
+// checkCollateral checks the verified quote against the configured CRLs and
+// TCB info
+func checkCollateral(config *configuration, q *quote, now time.Time) error {
+	if len(config.pckCRLPaths) > 0 {
+		crls, err := loadCRLs(config.pckCRLPaths)
+		if err != nil {
+			return sgxErr.New("unable to load PCK CRLs: %v", err)
+		}
+		if err := checkRevocation(q.verifiedChain, crls, now); err != nil {
+			return sgxErr.New("unable to verify quote: %v", err)
+		}
+	}
+
+	if config.tcbInfoPath != "" {
+		tcb, err := parsePCKTCB(q.verifiedChain[0])
+		if err != nil {
+			return sgxErr.New("unable to verify quote: %v", err)
+		}
+		info, err := loadTCBInfo(config.tcbInfoPath, config.tcbInfoSigningChainPath, config.roots, now)
+		if err != nil {
+			return sgxErr.New("unable to load TCB info: %v", err)
+		}
+		status, err := info.Status(tcb, now)
+		if err != nil {
+			return sgxErr.New("unable to verify quote: %v", err)
+		}
+		if !config.allowedTCBStatuses[status] {
+			return sgxErr.New("TCB status %q of the platform is not allowed", status)
+		}
+	}
+	return nil
+}
+
+func readQuoteFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(f, maxQuoteSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxQuoteSize {
+		return nil, fmt.Errorf("quote exceeds %d bytes", maxQuoteSize)
+	}
+	return data, nil
+}
+
+// processBinding returns the value expected at the start of the report data
+// of the enclave of the process: the SHA-256 digest of
+// "spire-sgx-workload:<pid>:<start time>", where the start time is the one
+// reported in /proc/<pid>/stat, in clock ticks since boot.
+func processBinding(pid int32, startTime uint64) []byte {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%d", bindingPrefix, pid, startTime)))
+	return sum[:]
+}
+
+// getProcStartTime returns the start time of the process, the 22nd field of
+// its stat file
+func getProcStartTime(statPath string) (uint64, error) {
+	data, err := ioutil.ReadFile(statPath)
+	if err != nil {
+		return 0, err
+	}
+	// The command name, in the second field, is in parentheses and may hold
+	// spaces. The fields following it start with the third one.
+	stat := string(data)
+	end := strings.LastIndexByte(stat, ')')
+	if end < 0 {
+		return 0, fmt.Errorf("malformed stat file %s", statPath)
+	}
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("malformed stat file %s", statPath)
+	}
+	return strconv.ParseUint(fields[19], 10, 64)
+}
+
+func getProcStatPath(pid int32) string {
+	procPath := os.Getenv("HOST_PROC")
+	if procPath == "" {
+		procPath = "/proc"
+	}
+	return filepath.Join(procPath, strconv.FormatInt(int64(pid), 10), "stat")
+}
+
+func makeSelector(kind, value string) *common.Selector {
+	return &common.Selector{
+		Type:  pluginName,
+		Value: fmt.Sprintf("%s:%s", kind, value),
+	}
+}
@@ -0,0 +1,849 @@
+package sgx
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	testPID       = 1234
+	testStartTime = 987654
+
+	// testFMSPC, testTCBSVN and testPCESVN are the platform family and TCB
+	// level certified by the PCK certificate of the test PKI
+	testFMSPC  = "00906ed50000"
+	testTCBSVN = 5
+	testPCESVN = 10
+
+	// testQEISVSVN is the security version of the test quoting enclave
+	testQEISVSVN = 6
+
+	// skipCollateral disables the revocation check and the TCB evaluation
+	skipCollateral = `
+		insecure_skip_revocation_check = true
+		insecure_skip_tcb_evaluation = true
+	`
+)
+
+var (
+	ctx = context.Background()
+
+	mrenclave = [32]byte{0x01, 0x02, 0x03}
+	mrsigner  = [32]byte{0x0a, 0x0b, 0x0c}
+)
+
+func TestPlugin(t *testing.T) {
+	spiretest.Run(t, new(Suite))
+}
+
+type Suite struct {
+	spiretest.Suite
+
+	dir      string
+	now      time.Time
+	pki      *testPKI
+	otherPKI *testPKI
+	logHook  *test.Hook
+	p        workloadattestor.Plugin
+}
+
+func (s *Suite) SetupTest() {
+	s.dir = s.TempDir()
+	s.now = time.Now()
+	s.pki = newTestPKI(s.T(), s.now)
+	s.otherPKI = newTestPKI(s.T(), s.now)
+
+	s.writeFile("root.pem", s.pki.rootPEM)
+	s.writeFile("other-root.pem", s.otherPKI.rootPEM)
+	s.writeCollateral()
+	s.writeFile("stat", []byte(fmt.Sprintf("%d (enclave (host) app) S 1 %d 0 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 1 0 %d 0 0", testPID, testPID, testStartTime)))
+
+	p := New()
+	p.hooks.now = func() time.Time { return s.now }
+	p.hooks.procStatPath = func(pid int32) string {
+		return filepath.Join(s.dir, "stat")
+	}
+	log, logHook := test.NewNullLogger()
+	s.logHook = logHook
+	s.LoadPlugin(builtin(p), &s.p, spiretest.Logger(log))
+
+	s.configure(fmt.Sprintf(`
+		quote_dir = %q
+		root_ca_path = %q
+		%s
+	`, s.dir, filepath.Join(s.dir, "root.pem"), s.collateralConfig()))
+}
+
+// writeCollateral writes current CRLs revoking no certificate and the TCB
+// info of the platform of the test PKI, at the UpToDate TCB level
+func (s *Suite) writeCollateral() {
+	s.writeFile("root.crl", s.pki.rootCRL(s.now.Add(time.Hour)))
+	s.writeFile("pck-ca.crl", s.pki.pckCACRL(s.now.Add(time.Hour)))
+	s.writeFile("tcb-info.json", s.pki.tcbInfo(s.now.Add(time.Hour), testFMSPC,
+		tcbLevel{svn: testTCBSVN, pcesvn: testPCESVN, status: "UpToDate"},
+	))
+	s.writeFile("tcb-chain.pem", s.pki.tcbChain)
+	s.writeFile("other-tcb-chain.pem", s.otherPKI.tcbChain)
+}
+
+func (s *Suite) collateralConfig() string {
+	return fmt.Sprintf(`
+		pck_crl_paths = [%q, %q]
+		tcb_info_path = %q
+		tcb_info_signing_chain_path = %q
+	`, filepath.Join(s.dir, "root.crl"), filepath.Join(s.dir, "pck-ca.crl"),
+		filepath.Join(s.dir, "tcb-info.json"), filepath.Join(s.dir, "tcb-chain.pem"))
+}
+
+func (s *Suite) TestAttest() {
+	validBinding := processBinding(testPID, testStartTime)
+
+	for _, tt := range []struct {
+		name       string
+		quote      []byte
+		allowDebug bool
+		rootCA     string
+		// collateral replaces the default CRL and TCB info configuration
+		collateral string
+		// config is appended to the configuration
+		config string
+		// files are written once the plugin is configured
+		files     map[string][]byte
+		err       string
+		selectors []string
+	}{
+		{
+			name: "no quote",
+		},
+		{
+			name:  "valid quote",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			selectors: []string{
+				"mrenclave:" + hex.EncodeToString(mrenclave[:]),
+				"mrsigner:" + hex.EncodeToString(mrsigner[:]),
+				"isvprodid:7",
+				"isvsvn:3",
+			},
+		},
+		{
+			name:  "debug enclave",
+			quote: s.pki.quote(quoteParams{binding: validBinding, debug: true}),
+			err:   "sgx: quote is of a debug enclave",
+		},
+		{
+			name:       "debug enclave allowed",
+			quote:      s.pki.quote(quoteParams{binding: validBinding, debug: true}),
+			allowDebug: true,
+			selectors: []string{
+				"mrenclave:" + hex.EncodeToString(mrenclave[:]),
+				"mrsigner:" + hex.EncodeToString(mrsigner[:]),
+				"isvprodid:7",
+				"isvsvn:3",
+				"debug:true",
+			},
+		},
+		{
+			name:   "untrusted PCK certificate",
+			quote:  s.pki.quote(quoteParams{binding: validBinding}),
+			rootCA: "other-root.pem",
+			// The collateral is signed by the root CA of the test PKI
+			collateral: skipCollateral,
+			err:        "sgx: unable to verify quote: PCK certificate is not trusted: x509: certificate signed by unknown authority",
+		},
+		{
+			name:  "tampered enclave report",
+			quote: s.pki.quote(quoteParams{binding: validBinding, tamperReport: true}),
+			err:   "sgx: unable to verify quote: enclave report signature is invalid",
+		},
+		{
+			name:  "tampered quoting enclave report",
+			quote: s.pki.quote(quoteParams{binding: validBinding, tamperQEReport: true}),
+			err:   "sgx: unable to verify quote: quoting enclave report signature is invalid",
+		},
+		{
+			name:  "attestation key not bound",
+			quote: s.pki.quote(quoteParams{binding: validBinding, unboundKey: true}),
+			err:   "sgx: unable to verify quote: quoting enclave report does not bind the attestation key",
+		},
+		{
+			name:  "quoting enclave of another signer",
+			quote: s.pki.quote(quoteParams{binding: validBinding, otherQE: true}),
+			err:   "sgx: unable to verify quote: quoting enclave MRSIGNER 0a0b0c",
+		},
+		{
+			name:   "quoting enclave of another product",
+			quote:  s.pki.quote(quoteParams{binding: validBinding}),
+			config: "qe_isvprodid = 2",
+			err:    "sgx: unable to verify quote: quoting enclave ISVPRODID 1 is not the expected one",
+		},
+		{
+			name:   "quoting enclave below the minimum security version",
+			quote:  s.pki.quote(quoteParams{binding: validBinding}),
+			config: "qe_min_isvsvn = 7",
+			err:    "sgx: unable to verify quote: quoting enclave ISVSVN 6 is lower than 7",
+		},
+		{
+			name:   "quoting enclave at the minimum security version",
+			quote:  s.pki.quote(quoteParams{binding: validBinding}),
+			config: "qe_min_isvsvn = 6",
+			selectors: []string{
+				"mrenclave:" + hex.EncodeToString(mrenclave[:]),
+				"mrsigner:" + hex.EncodeToString(mrsigner[:]),
+				"isvprodid:7",
+				"isvsvn:3",
+			},
+		},
+		{
+			name:  "revoked PCK certificate",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"pck-ca.crl": s.pki.pckCACRL(s.now.Add(time.Hour), s.pki.pck),
+			},
+			err: `sgx: unable to verify quote: certificate "SGX PCK Certificate" is revoked`,
+		},
+		{
+			name:  "revoked PCK CA certificate",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"root.crl": s.pki.rootCRL(s.now.Add(time.Hour), s.pki.intermediate),
+			},
+			err: `sgx: unable to verify quote: certificate "SGX PCK Platform CA" is revoked`,
+		},
+		{
+			name:  "expired CRL",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"pck-ca.crl": s.pki.pckCACRL(s.now.Add(-time.Minute)),
+			},
+			err: `sgx: unable to verify quote: CRL of "SGX PCK Platform CA" has expired`,
+		},
+		{
+			name:  "CRL of another CA",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"pck-ca.crl": s.otherPKI.pckCACRL(s.now.Add(time.Hour), s.pki.pck),
+			},
+			err: `sgx: unable to verify quote: no CRL of "SGX PCK Platform CA" is configured`,
+		},
+		{
+			name:  "unreadable CRL",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"pck-ca.crl": []byte("not a CRL"),
+			},
+			err: "sgx: unable to load PCK CRLs: unable to parse CRL",
+		},
+		{
+			name:  "out of date TCB level",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"tcb-info.json": s.pki.tcbInfo(s.now.Add(time.Hour), testFMSPC,
+					tcbLevel{svn: testTCBSVN + 1, pcesvn: testPCESVN, status: "UpToDate"},
+					tcbLevel{svn: testTCBSVN, pcesvn: testPCESVN, status: "OutOfDate"},
+				),
+			},
+			err: `sgx: TCB status "OutOfDate" of the platform is not allowed`,
+		},
+		{
+			name:   "out of date TCB level allowed",
+			quote:  s.pki.quote(quoteParams{binding: validBinding}),
+			config: `allowed_tcb_statuses = ["UpToDate", "OutOfDate"]`,
+			files: map[string][]byte{
+				"tcb-info.json": s.pki.tcbInfo(s.now.Add(time.Hour), testFMSPC,
+					tcbLevel{svn: testTCBSVN, pcesvn: testPCESVN + 1, status: "UpToDate"},
+					tcbLevel{svn: testTCBSVN, pcesvn: testPCESVN, status: "OutOfDate"},
+				),
+			},
+			selectors: []string{
+				"mrenclave:" + hex.EncodeToString(mrenclave[:]),
+				"mrsigner:" + hex.EncodeToString(mrsigner[:]),
+				"isvprodid:7",
+				"isvsvn:3",
+			},
+		},
+		{
+			name:  "unsupported TCB level",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"tcb-info.json": s.pki.tcbInfo(s.now.Add(time.Hour), testFMSPC,
+					tcbLevel{svn: testTCBSVN + 1, pcesvn: testPCESVN, status: "UpToDate"},
+				),
+			},
+			err: "sgx: unable to verify quote: TCB level of the platform is not supported",
+		},
+		{
+			name:  "TCB info of another platform family",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"tcb-info.json": s.pki.tcbInfo(s.now.Add(time.Hour), "00606a000000",
+					tcbLevel{svn: testTCBSVN, pcesvn: testPCESVN, status: "UpToDate"},
+				),
+			},
+			err: "sgx: unable to verify quote: TCB info is for FMSPC 00606a000000, not the FMSPC 00906ed50000 of the platform",
+		},
+		{
+			name:  "expired TCB info",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"tcb-info.json": s.pki.tcbInfo(s.now.Add(-time.Minute), testFMSPC,
+					tcbLevel{svn: testTCBSVN, pcesvn: testPCESVN, status: "UpToDate"},
+				),
+			},
+			err: "sgx: unable to verify quote: TCB info has expired",
+		},
+		{
+			name:  "TCB info of another signer",
+			quote: s.pki.quote(quoteParams{binding: validBinding}),
+			files: map[string][]byte{
+				"tcb-info.json": s.otherPKI.tcbInfo(s.now.Add(time.Hour), testFMSPC,
+					tcbLevel{svn: testTCBSVN, pcesvn: testPCESVN, status: "UpToDate"},
+				),
+			},
+			err: "sgx: unable to load TCB info: TCB info signature is invalid",
+		},
+		{
+			name:       "revocation check and TCB evaluation disabled",
+			quote:      s.pki.quote(quoteParams{binding: validBinding}),
+			collateral: skipCollateral,
+			files: map[string][]byte{
+				"pck-ca.crl": s.pki.pckCACRL(s.now.Add(time.Hour), s.pki.pck),
+			},
+			selectors: []string{
+				"mrenclave:" + hex.EncodeToString(mrenclave[:]),
+				"mrsigner:" + hex.EncodeToString(mrsigner[:]),
+				"isvprodid:7",
+				"isvsvn:3",
+			},
+		},
+		{
+			name:  "quote of another process",
+			quote: s.pki.quote(quoteParams{binding: processBinding(testPID, testStartTime+1)}),
+			err:   "sgx: quote is not bound to the workload process",
+		},
+		{
+			name:  "truncated quote",
+			quote: s.pki.quote(quoteParams{binding: validBinding})[:500],
+			err:   "sgx: unable to parse quote: quote signature data length",
+		},
+		{
+			name:  "not a quote",
+			quote: []byte("not a quote"),
+			err:   "sgx: unable to parse quote: quote is truncated",
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			rootCA := tt.rootCA
+			if rootCA == "" {
+				rootCA = "root.pem"
+			}
+			collateral := tt.collateral
+			if collateral == "" {
+				collateral = s.collateralConfig()
+			}
+			s.writeCollateral()
+			s.configure(fmt.Sprintf(`
+				quote_dir = %q
+				root_ca_path = %q
+				allow_debug_enclaves = %t
+				%s
+				%s
+			`, s.dir, filepath.Join(s.dir, rootCA), tt.allowDebug, collateral, tt.config))
+			for name, data := range tt.files {
+				s.writeFile(name, data)
+			}
+
+			quotePath := filepath.Join(s.dir, fmt.Sprintf("%d.quote", testPID))
+			if tt.quote != nil {
+				require.NoError(t, ioutil.WriteFile(quotePath, tt.quote, 0600))
+			} else {
+				require.NoError(t, removeIfExists(quotePath))
+			}
+
+			resp, err := s.p.Attest(ctx, &workloadattestor.AttestRequest{Pid: testPID})
+			if tt.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, codes.Unknown, tt.err)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+
+			var selectors []string
+			for _, selector := range resp.Selectors {
+				require.Equal(t, "sgx", selector.Type)
+				selectors = append(selectors, selector.Value)
+			}
+			require.Equal(t, tt.selectors, selectors)
+		})
+	}
+}
+
+func (s *Suite) TestConfigure() {
+	base := fmt.Sprintf("quote_dir = %q\nroot_ca_path = %q\n", s.dir, filepath.Join(s.dir, "root.pem"))
+	crls := fmt.Sprintf("pck_crl_paths = [%q, %q]\n", filepath.Join(s.dir, "root.crl"), filepath.Join(s.dir, "pck-ca.crl"))
+	tcbInfo := fmt.Sprintf("tcb_info_path = %q\ntcb_info_signing_chain_path = %q\n", filepath.Join(s.dir, "tcb-info.json"), filepath.Join(s.dir, "tcb-chain.pem"))
+	s.writeFile("tampered-tcb-info.json", []byte(strings.Replace(string(s.pki.tcbInfo(s.now.Add(time.Hour), testFMSPC,
+		tcbLevel{svn: testTCBSVN, pcesvn: testPCESVN, status: "OutOfDate"},
+	)), "OutOfDate", "UpToDate", 1)))
+
+	for _, tt := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "malformed configuration",
+			config: "{{",
+			err:    "sgx: unable to decode configuration",
+		},
+		{
+			name:   "missing quote_dir",
+			config: `root_ca_path = "root.pem"`,
+			err:    "sgx: quote_dir is required",
+		},
+		{
+			name:   "missing root_ca_path",
+			config: `quote_dir = "/run/spire/sgx"`,
+			err:    "sgx: root_ca_path is required",
+		},
+		{
+			name:   "unreadable root CA",
+			config: fmt.Sprintf("quote_dir = %q\nroot_ca_path = %q", s.dir, filepath.Join(s.dir, "missing.pem")),
+			err:    "sgx: unable to load root CA certificates",
+		},
+		{
+			name:   "malformed qe_mrsigner",
+			config: base + crls + tcbInfo + `qe_mrsigner = "8c4f57"`,
+			err:    "sgx: qe_mrsigner must be a hex encoded 32 byte value",
+		},
+		{
+			name:   "qe_isvprodid out of range",
+			config: base + crls + tcbInfo + "qe_isvprodid = 65536",
+			err:    "sgx: qe_isvprodid is out of range",
+		},
+		{
+			name:   "qe_min_isvsvn out of range",
+			config: base + crls + tcbInfo + "qe_min_isvsvn = -1",
+			err:    "sgx: qe_min_isvsvn is out of range",
+		},
+		{
+			name:   "missing pck_crl_paths",
+			config: base + tcbInfo,
+			err:    "sgx: pck_crl_paths is required unless insecure_skip_revocation_check is set",
+		},
+		{
+			name:   "unreadable CRL",
+			config: base + tcbInfo + fmt.Sprintf("pck_crl_paths = [%q]", filepath.Join(s.dir, "root.pem")),
+			err:    "sgx: unable to load PCK CRLs: unable to parse CRL",
+		},
+		{
+			name:   "missing tcb_info_path",
+			config: base + crls,
+			err:    "sgx: tcb_info_path is required unless insecure_skip_tcb_evaluation is set",
+		},
+		{
+			name:   "missing tcb_info_signing_chain_path",
+			config: base + crls + fmt.Sprintf("tcb_info_path = %q", filepath.Join(s.dir, "tcb-info.json")),
+			err:    "sgx: tcb_info_signing_chain_path is required with tcb_info_path",
+		},
+		{
+			name:   "untrusted TCB signing certificate",
+			config: base + crls + fmt.Sprintf("tcb_info_path = %q\ntcb_info_signing_chain_path = %q", filepath.Join(s.dir, "tcb-info.json"), filepath.Join(s.dir, "other-tcb-chain.pem")),
+			err:    "sgx: unable to load TCB info: TCB signing certificate is not trusted",
+		},
+		{
+			name:   "tampered TCB info",
+			config: base + crls + fmt.Sprintf("tcb_info_path = %q\ntcb_info_signing_chain_path = %q", filepath.Join(s.dir, "tampered-tcb-info.json"), filepath.Join(s.dir, "tcb-chain.pem")),
+			err:    "sgx: unable to load TCB info: TCB info signature is invalid",
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			_, err := s.p.Configure(ctx, &spi.ConfigureRequest{Configuration: tt.config})
+			spiretest.RequireGRPCStatusContains(t, err, codes.Unknown, tt.err)
+		})
+	}
+}
+
+func (s *Suite) TestConfigureWarnsWhenChecksAreDisabled() {
+	s.logHook.Reset()
+	s.configure(fmt.Sprintf(`
+		quote_dir = %q
+		root_ca_path = %q
+		insecure_skip_revocation_check = true
+		insecure_skip_tcb_evaluation = true
+	`, s.dir, filepath.Join(s.dir, "root.pem")))
+
+	var messages []string
+	for _, entry := range s.logHook.AllEntries() {
+		s.Require().Equal(logrus.WarnLevel, entry.Level)
+		messages = append(messages, entry.Message)
+	}
+	s.Require().Equal([]string{
+		"Revocation check of PCK certificates is disabled; consider configuring the Intel SGX CRLs with pck_crl_paths instead",
+		"TCB evaluation of the platforms is disabled; consider configuring the Intel SGX TCB info with tcb_info_path instead",
+	}, messages)
+}
+
+func (s *Suite) TestAttestNotConfigured() {
+	var p workloadattestor.Plugin
+	s.LoadPlugin(BuiltIn(), &p)
+
+	_, err := p.Attest(ctx, &workloadattestor.AttestRequest{Pid: testPID})
+	s.RequireGRPCStatus(err, codes.Unknown, "sgx: not configured")
+}
+
+func (s *Suite) TestGetProcStartTime() {
+	startTime, err := getProcStartTime(filepath.Join(s.dir, "stat"))
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(testStartTime), startTime)
+
+	s.writeFile("bad-stat", []byte("1234 (truncated) S 1"))
+	_, err = getProcStartTime(filepath.Join(s.dir, "bad-stat"))
+	s.Require().EqualError(err, fmt.Sprintf("malformed stat file %s", filepath.Join(s.dir, "bad-stat")))
+}
+
+func (s *Suite) configure(config string) {
+	_, err := s.p.Configure(ctx, &spi.ConfigureRequest{Configuration: config})
+	s.Require().NoError(err)
+}
+
+func (s *Suite) writeFile(name string, data []byte) {
+	s.Require().NoError(ioutil.WriteFile(filepath.Join(s.dir, name), data, 0600))
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// testPKI is a stand-in for the Intel SGX PKI: a root CA, an intermediate
+// CA, the PCK certificate of the platform and the TCB signing certificate
+type testPKI struct {
+	t               *testing.T
+	now             time.Time
+	rootPEM         []byte
+	root            *x509.Certificate
+	rootKey         *ecdsa.PrivateKey
+	intermediate    *x509.Certificate
+	intermediateKey *ecdsa.PrivateKey
+	pck             *x509.Certificate
+	pckKey          *ecdsa.PrivateKey
+	chain           []byte
+	tcbChain        []byte
+	tcbKey          *ecdsa.PrivateKey
+}
+
+func newTestPKI(t *testing.T, now time.Time) *testPKI {
+	rootKey := newKey(t)
+	root := createCertificate(t, now, "SGX Root CA", rootKey, nil, nil, true)
+	intermediateKey := newKey(t)
+	intermediate := createCertificate(t, now, "SGX PCK Platform CA", intermediateKey, root, rootKey, true)
+	pckKey := newKey(t)
+	pck := createCertificate(t, now, "SGX PCK Certificate", pckKey, intermediate, intermediateKey, false, sgxExtension(t))
+	tcbKey := newKey(t)
+	tcb := createCertificate(t, now, "SGX TCB Signing", tcbKey, root, rootKey, false)
+
+	return &testPKI{
+		t:               t,
+		now:             now,
+		rootPEM:         pemCertificate(root),
+		root:            root,
+		rootKey:         rootKey,
+		intermediate:    intermediate,
+		intermediateKey: intermediateKey,
+		pck:             pck,
+		pckKey:          pckKey,
+		chain:           append(append(pemCertificate(pck), pemCertificate(intermediate)...), pemCertificate(root)...),
+		tcbChain:        append(pemCertificate(tcb), pemCertificate(root)...),
+		tcbKey:          tcbKey,
+	}
+}
+
+// rootCRL returns a PEM encoded CRL of the root CA revoking the certificates
+func (p *testPKI) rootCRL(nextUpdate time.Time, revoked ...*x509.Certificate) []byte {
+	return p.crl(p.root, p.rootKey, nextUpdate, revoked)
+}
+
+// pckCACRL returns a PEM encoded CRL of the intermediate CA revoking the
+// certificates
+func (p *testPKI) pckCACRL(nextUpdate time.Time, revoked ...*x509.Certificate) []byte {
+	return p.crl(p.intermediate, p.intermediateKey, nextUpdate, revoked)
+}
+
+func (p *testPKI) crl(issuer *x509.Certificate, key *ecdsa.PrivateKey, nextUpdate time.Time, revoked []*x509.Certificate) []byte {
+	var revokedCerts []pkix.RevokedCertificate
+	for _, cert := range revoked {
+		revokedCerts = append(revokedCerts, pkix.RevokedCertificate{
+			SerialNumber:   cert.SerialNumber,
+			RevocationTime: p.now.Add(-time.Minute),
+		})
+	}
+	der, err := issuer.CreateCRL(rand.Reader, key, revokedCerts, p.now.Add(-time.Hour), nextUpdate)
+	require.NoError(p.t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+type tcbLevel struct {
+	svn    int
+	pcesvn int
+	status string
+}
+
+// tcbInfo returns the TCB info of the platform family, signed by the TCB
+// signing key
+func (p *testPKI) tcbInfo(nextUpdate time.Time, fmspc string, levels ...tcbLevel) []byte {
+	type svn struct {
+		SVN int `json:"svn"`
+	}
+	type level struct {
+		TCB struct {
+			SGXTCBComponents []svn `json:"sgxtcbcomponents"`
+			PCESVN           int   `json:"pcesvn"`
+		} `json:"tcb"`
+		TCBDate   string `json:"tcbDate"`
+		TCBStatus string `json:"tcbStatus"`
+	}
+	info := struct {
+		ID         string  `json:"id"`
+		Version    int     `json:"version"`
+		IssueDate  string  `json:"issueDate"`
+		NextUpdate string  `json:"nextUpdate"`
+		FMSPC      string  `json:"fmspc"`
+		PCEID      string  `json:"pceId"`
+		TCBLevels  []level `json:"tcbLevels"`
+	}{
+		ID:         "SGX",
+		Version:    tcbInfoVersion,
+		IssueDate:  p.now.UTC().Format(time.RFC3339),
+		NextUpdate: nextUpdate.UTC().Format(time.RFC3339),
+		FMSPC:      fmspc,
+		PCEID:      "0000",
+	}
+	for _, l := range levels {
+		var tl level
+		for i := 0; i < tcbComponentCount; i++ {
+			tl.TCB.SGXTCBComponents = append(tl.TCB.SGXTCBComponents, svn{SVN: l.svn})
+		}
+		tl.TCB.PCESVN = l.pcesvn
+		tl.TCBDate = p.now.UTC().Format(time.RFC3339)
+		tl.TCBStatus = l.status
+		info.TCBLevels = append(info.TCBLevels, tl)
+	}
+
+	rawInfo, err := json.Marshal(info)
+	require.NoError(p.t, err)
+	signed, err := json.Marshal(struct {
+		TCBInfo   json.RawMessage `json:"tcbInfo"`
+		Signature string          `json:"signature"`
+	}{
+		TCBInfo:   rawInfo,
+		Signature: hex.EncodeToString(sign(p.t, p.tcbKey, rawInfo)),
+	})
+	require.NoError(p.t, err)
+	return signed
+}
+
+type quoteParams struct {
+	binding        []byte
+	debug          bool
+	tamperReport   bool
+	tamperQEReport bool
+	unboundKey     bool
+	otherQE        bool
+}
+
+// quote builds a version 3 ECDSA quote of an enclave
+func (p *testPKI) quote(params quoteParams) []byte {
+	attestationKey := newKey(p.t)
+	rawAttestationKey := append(padTo32(attestationKey.X.Bytes()), padTo32(attestationKey.Y.Bytes())...)
+	qeAuthData := []byte("quoting enclave authentication data")
+
+	header := make([]byte, quoteHeaderSize)
+	binary.LittleEndian.PutUint16(header[0:2], quoteVersion)
+	binary.LittleEndian.PutUint16(header[2:4], attestationKeyTypeECDSAP256)
+
+	report := make([]byte, reportBodySize)
+	if params.debug {
+		binary.LittleEndian.PutUint64(report[48:56], attributeDebug)
+	}
+	copy(report[64:96], mrenclave[:])
+	copy(report[128:160], mrsigner[:])
+	binary.LittleEndian.PutUint16(report[256:258], 7)
+	binary.LittleEndian.PutUint16(report[258:260], 3)
+	copy(report[320:384], params.binding)
+
+	qeReport := make([]byte, reportBodySize)
+	qeMRSIGNER, err := hex.DecodeString(intelQEMRSIGNER)
+	require.NoError(p.t, err)
+	if params.otherQE {
+		qeMRSIGNER = mrsigner[:]
+	}
+	copy(qeReport[128:160], qeMRSIGNER)
+	binary.LittleEndian.PutUint16(qeReport[256:258], intelQEISVProdID)
+	binary.LittleEndian.PutUint16(qeReport[258:260], testQEISVSVN)
+	keyBinding := sha256.Sum256(append(append([]byte(nil), rawAttestationKey...), qeAuthData...))
+	if !params.unboundKey {
+		copy(qeReport[320:352], keyBinding[:])
+	}
+
+	reportSig := sign(p.t, attestationKey, append(append([]byte(nil), header...), report...))
+	qeReportSig := sign(p.t, p.pckKey, qeReport)
+	if params.tamperReport {
+		report[64] ^= 0xff
+	}
+	if params.tamperQEReport {
+		qeReport[0] ^= 0xff
+	}
+
+	var sigData []byte
+	sigData = append(sigData, reportSig...)
+	sigData = append(sigData, rawAttestationKey...)
+	sigData = append(sigData, qeReport...)
+	sigData = append(sigData, qeReportSig...)
+	sigData = append(sigData, le16(uint16(len(qeAuthData)))...)
+	sigData = append(sigData, qeAuthData...)
+	sigData = append(sigData, le16(pckCertChainType)...)
+	sigData = append(sigData, le32(uint32(len(p.chain)))...)
+	sigData = append(sigData, p.chain...)
+
+	var quote []byte
+	quote = append(quote, header...)
+	quote = append(quote, report...)
+	quote = append(quote, le32(uint32(len(sigData)))...)
+	quote = append(quote, sigData...)
+	return quote
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func createCertificate(t *testing.T, now time.Time, cn string, key *ecdsa.PrivateKey, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool, extensions ...pkix.Extension) *x509.Certificate {
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		ExtraExtensions:       extensions,
+	}
+	if isCA {
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// sgxExtension returns the SGX extension of the PCK certificate, which
+// certifies the platform family and TCB level of the platform
+func sgxExtension(t *testing.T) pkix.Extension {
+	field := func(id asn1.ObjectIdentifier, value interface{}) asn1.RawValue {
+		rawValue, err := asn1.Marshal(value)
+		require.NoError(t, err)
+		rawField, err := asn1.Marshal(struct {
+			ID    asn1.ObjectIdentifier
+			Value asn1.RawValue
+		}{ID: id, Value: asn1.RawValue{FullBytes: rawValue}})
+		require.NoError(t, err)
+		return asn1.RawValue{FullBytes: rawField}
+	}
+	oid := func(base asn1.ObjectIdentifier, n int) asn1.ObjectIdentifier {
+		return append(append(asn1.ObjectIdentifier(nil), base...), n)
+	}
+
+	var tcb []asn1.RawValue
+	cpusvn := make([]byte, tcbComponentCount)
+	for i := 1; i <= tcbComponentCount; i++ {
+		tcb = append(tcb, field(oid(oidSGXTCB, i), testTCBSVN))
+		cpusvn[i-1] = testTCBSVN
+	}
+	tcb = append(tcb, field(oid(oidSGXTCB, tcbComponentCount+1), testPCESVN))
+	tcb = append(tcb, field(oid(oidSGXTCB, tcbComponentCount+2), cpusvn))
+
+	fmspc, err := hex.DecodeString(testFMSPC)
+	require.NoError(t, err)
+	value, err := asn1.Marshal([]asn1.RawValue{
+		field(oid(oidSGXExtension, 1), make([]byte, 16)),
+		field(oidSGXTCB, tcb),
+		field(oid(oidSGXExtension, 3), []byte{0, 0}),
+		field(oidSGXFMSPC, fmspc),
+	})
+	require.NoError(t, err)
+	return pkix.Extension{Id: oidSGXExtension, Value: value}
+}
+
+func pemCertificate(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+// sign returns the raw r||s ECDSA signature over the SHA-256 digest of the
+// message
+func sign(t *testing.T, key *ecdsa.PrivateKey, message []byte) []byte {
+	digest := sha256.Sum256(message)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	return append(padTo32(r.Bytes()), padTo32(s.Bytes())...)
+}
+
+func padTo32(b []byte) []byte {
+	return append(make([]byte, 32-len(b)), b...)
+}
+
+func le16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, v)
+	return b
+}
+
+func le32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
+
+func TestProcessBinding(t *testing.T) {
+	sum := sha256.Sum256([]byte("spire-sgx-workload:1234:987654"))
+	require.Equal(t, sum[:], processBinding(1234, 987654))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -25,6 +26,10 @@ type Rotator interface {
 	Subscribe() observer.Stream
 	GetRotationMtx() *sync.RWMutex
 	SetRotationFinishedHook(func())
+
+	// Reattest performs node attestation again to obtain a new agent SVID
+	// without restarting the agent.
+	Reattest(ctx context.Context) error
 }
 
 type rotator struct {
@@ -65,6 +70,14 @@ func (r *rotator) Run(ctx context.Context) error {
 func (r *rotator) runRotation(ctx context.Context) error {
 	for {
 		err := r.rotateSVID(ctx)
+		if err != nil && r.c.Reattest != nil && r.shouldReattest(err) {
+			r.c.Log.WithError(err).Warn("Could not rotate agent SVID; re-attesting")
+			if reattestErr := r.Reattest(ctx); reattestErr != nil {
+				r.c.Log.WithError(reattestErr).Error("Could not re-attest agent")
+			} else {
+				err = nil
+			}
+		}
 
 		switch {
 		case err != nil && nodeutil.ShouldAgentReattest(err):
@@ -116,9 +129,14 @@ func (r *rotator) SetRotationFinishedHook(f func()) {
 
 // rotateSVID asks SPIRE's server for a new agent's SVID.
 func (r *rotator) rotateSVID(ctx context.Context) (err error) {
-	if !rotationutil.ShouldRotateX509(r.clk.Now(), r.state.Value().(State).SVID[0]) {
+	chain := r.state.Value().(State).SVID
+	signedByTaintedAuthority := rotationutil.X509SignedByTaintedAuthority(chain, r.taintedAuthorities())
+	if !signedByTaintedAuthority && !r.c.RotationThreshold.ShouldRotateX509(r.clk.Now(), chain[0]) {
 		return nil
 	}
+	if signedByTaintedAuthority {
+		r.c.Log.Info("Agent SVID is signed by a tainted authority, forcing rotation")
+	}
 
 	counter := telemetry_agent.StartRotateAgentSVIDCall(r.c.Metrics)
 	defer counter.Done(&err)
@@ -149,12 +167,52 @@ func (r *rotator) rotateSVID(ctx context.Context) (err error) {
 		return err
 	}
 
-	s := State{
-		SVID: certs,
-		Key:  key,
+	r.setSVID(certs, key)
+	return nil
+}
+
+// Reattest performs node attestation again to obtain a new agent SVID. The
+// agent keeps its identity as long as the node attestor yields the same agent
+// ID, so the registration entries authorized for it are preserved.
+func (r *rotator) Reattest(ctx context.Context) (err error) {
+	if r.c.Reattest == nil {
+		return errors.New("re-attestation is not supported")
+	}
+
+	counter := telemetry_agent.StartReattestAgentCall(r.c.Metrics)
+	defer counter.Done(&err)
+
+	r.rotMtx.Lock()
+	defer r.rotMtx.Unlock()
+	r.c.Log.Info("Re-attesting agent")
+
+	certs, key, err := r.c.Reattest(ctx)
+	if err != nil {
+		return err
 	}
 
-	r.state.Update(s)
+	r.setSVID(certs, key)
+	r.c.Log.Info("Agent re-attested")
+	return nil
+}
+
+// shouldReattest returns true if the agent SVID renewal failed with the given
+// error in a way that re-attestation can recover from: either the server no
+// longer recognizes the agent or the SVID is about to expire.
+func (r *rotator) shouldReattest(err error) bool {
+	if nodeutil.ShouldAgentReattest(err) {
+		return true
+	}
+	return rotationutil.X509ExpiresSoon(r.clk.Now(), r.state.Value().(State).SVID[0])
+}
+
+// setSVID updates the rotator state with the given agent SVID and key. The
+// caller must hold the rotation mutex.
+func (r *rotator) setSVID(svid []*x509.Certificate, key *ecdsa.PrivateKey) {
+	r.state.Update(State{
+		SVID: svid,
+		Key:  key,
+	})
 
 	// We must release the client because its underlaying connection is tied to an
 	// expired SVID, so next time the client is used, it will get a new connection with
@@ -164,7 +222,18 @@ func (r *rotator) rotateSVID(ctx context.Context) (err error) {
 	if r.rotationFinishedHook != nil {
 		r.rotationFinishedHook()
 	}
+}
+
+// taintedAuthorities returns the tainted X509 authorities of the agent trust
+// domain bundle.
+func (r *rotator) taintedAuthorities() []*x509.Certificate {
+	r.bsm.RLock()
+	bundles := r.c.BundleStream.Value()
+	r.bsm.RUnlock()
 
+	if bundle := bundles[r.c.TrustDomain.String()]; bundle != nil {
+		return bundle.TaintedRootCAs()
+	}
 	return nil
 }
 
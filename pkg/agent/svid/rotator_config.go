@@ -1,6 +1,7 @@
 package svid
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
 	"net/url"
@@ -14,6 +15,8 @@ import (
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/common/backoff"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
@@ -31,9 +34,21 @@ type RotatorConfig struct {
 
 	BundleStream *cache.BundleStream
 
+	// Reattest, if set, performs node attestation again to obtain a new
+	// agent SVID and key. It is used when the agent SVID cannot be renewed.
+	Reattest func(ctx context.Context) ([]*x509.Certificate, *ecdsa.PrivateKey, error)
+
 	// How long to wait between expiry checks
 	Interval time.Duration
 
+	// RotationThreshold determines how long before expiration the agent SVID
+	// is rotated
+	RotationThreshold rotationutil.Threshold
+
+	// GRPCTuning holds the keepalive and flow control settings of the
+	// connections to the server
+	GRPCTuning grpcutil.ClientTuning
+
 	// Clk is the clock that the rotator will use to create a ticker
 	Clk clock.Clock
 }
@@ -64,6 +79,7 @@ func newRotator(c *RotatorConfig) (*rotator, client.Client) {
 		Log:         c.Log,
 		Addr:        c.ServerAddr,
 		RotMtx:      rotMtx,
+		GRPCTuning:  c.GRPCTuning,
 		KeysAndBundle: func() ([]*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate) {
 			s := state.Value().(State)
 
@@ -2,7 +2,10 @@ package svid
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/x509"
+	"errors"
+	"fmt"
 	"net/url"
 	"testing"
 	"time"
@@ -12,13 +15,18 @@ import (
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
 	mock_client "github.com/spiffe/spire/test/mock/agent/client"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	tomb "gopkg.in/tomb.v2"
 )
 
@@ -47,7 +55,9 @@ func (s *RotatorTestSuite) SetupTest() {
 
 	b, err := util.LoadBundleFixture()
 	s.Require().NoError(err)
-	s.bundle = observer.NewProperty(b)
+	s.bundle = observer.NewProperty(map[string]*cache.Bundle{
+		"spiffe://example.org": bundleutil.BundleFromRootCAs("spiffe://example.org", b),
+	})
 
 	cat := fakeagentcatalog.New()
 	cat.SetKeyManager(fakeagentcatalog.KeyManager(memory.New()))
@@ -181,6 +191,105 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 	s.Assert().True(goodCert.Equal(state.SVID[0]))
 }
 
+func (s *RotatorTestSuite) TestRotateSVIDSignedByTaintedAuthority() {
+	caTemplate, err := util.NewCATemplate(s.mockClock, "spiffe://example.org")
+	s.Require().NoError(err)
+	caTemplate.SubjectKeyId = []byte("tainted")
+	caCert, _, err := util.SelfSign(caTemplate)
+	s.Require().NoError(err)
+
+	// Cert that's valid for 1hr but was issued by the tainted authority
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	temp.AuthorityKeyId = caCert.SubjectKeyId
+	taintedCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	state := State{
+		SVID: []*x509.Certificate{taintedCert},
+	}
+	s.r.state = observer.NewProperty(state)
+	stream := s.r.Subscribe()
+
+	// The SVID is not rotated while the authority is not tainted
+	s.Require().NoError(s.r.rotateSVID(context.Background()))
+	s.Require().False(stream.HasNext())
+
+	bundle, err := bundleutil.BundleFromProto(&common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: caCert.Raw, TaintedKey: true}},
+	})
+	s.Require().NoError(err)
+	s.bundle.Update(map[string]*cache.Bundle{"spiffe://example.org": bundle})
+	s.r.c.BundleStream.Next()
+
+	temp.AuthorityKeyId = nil
+	goodCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	s.expectSVIDRotation(goodCert)
+	s.Require().NoError(s.r.rotateSVID(context.Background()))
+	s.Require().True(stream.HasNext())
+
+	state = stream.Next().(State)
+	s.Require().Len(state.SVID, 1)
+	s.Assert().True(goodCert.Equal(state.SVID[0]))
+}
+
+func (s *RotatorTestSuite) TestReattest() {
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	oldCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	newCert, newKey, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	s.r.state = observer.NewProperty(State{SVID: []*x509.Certificate{oldCert}})
+	stream := s.r.Subscribe()
+
+	// Re-attestation is not supported unless configured
+	s.Require().EqualError(s.r.Reattest(context.Background()), "re-attestation is not supported")
+
+	s.r.c.Reattest = func(ctx context.Context) ([]*x509.Certificate, *ecdsa.PrivateKey, error) {
+		return nil, nil, errors.New("oh no")
+	}
+	s.Require().EqualError(s.r.Reattest(context.Background()), "oh no")
+	s.Require().False(stream.HasNext())
+
+	s.r.c.Reattest = func(ctx context.Context) ([]*x509.Certificate, *ecdsa.PrivateKey, error) {
+		return []*x509.Certificate{newCert}, newKey, nil
+	}
+	s.client.EXPECT().Release()
+	s.Require().NoError(s.r.Reattest(context.Background()))
+	s.Require().True(stream.HasNext())
+
+	state := stream.Next().(State)
+	s.Require().Len(state.SVID, 1)
+	s.Assert().True(newCert.Equal(state.SVID[0]))
+	s.Assert().Equal(newKey, state.Key)
+}
+
+func (s *RotatorTestSuite) TestShouldReattest() {
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	cert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	s.r.state = observer.NewProperty(State{SVID: []*x509.Certificate{cert}})
+
+	renewErr := errors.New("oh no")
+	s.Assert().False(s.r.shouldReattest(renewErr))
+
+	// The server no longer recognizes the agent
+	st, err := status.New(codes.PermissionDenied, "agent is not attested").WithDetails(&types.PermissionDeniedDetails{
+		Reason: types.PermissionDeniedDetails_AGENT_NOT_ATTESTED,
+	})
+	s.Require().NoError(err)
+	s.Assert().True(s.r.shouldReattest(fmt.Errorf("failed to renew agent SVID: %w", st.Err())))
+
+	// The SVID is about to expire
+	s.mockClock.Add(temp.NotAfter.Sub(s.mockClock.Now()) - time.Minute)
+	s.Assert().True(s.r.shouldReattest(renewErr))
+}
+
 // expectSVIDRotation sets the appropriate expectations for an SVID rotation, and returns
 // the the provided certificate to the client.Client caller.
 func (s *RotatorTestSuite) expectSVIDRotation(cert *x509.Certificate) {
@@ -0,0 +1,235 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	// DefaultInterval is how often the service reconciles the stored SVIDs
+	// with the cached registration entries when none is configured.
+	DefaultInterval = 5 * time.Second
+)
+
+// Config is the configuration of the service.
+type Config struct {
+	Manager manager.Manager
+	Stores  []catalog.SVIDStore
+	Log     logrus.FieldLogger
+	Clock   clock.Clock
+
+	// Interval is how often the stored SVIDs are reconciled. Defaults to
+	// DefaultInterval.
+	Interval time.Duration
+}
+
+// Service pushes the X509-SVIDs of the registration entries that target an
+// SVIDStore plugin to the plugin. An entry targets a plugin when one of its
+// selectors has the plugin name as type; those selectors are passed to the
+// plugin to identify where the SVID is stored.
+type Service struct {
+	c      Config
+	stores map[string]catalog.SVIDStore
+
+	// stored holds the SVIDs pushed to the stores, keyed by entry ID
+	stored map[string]*storedSVID
+}
+
+type storedSVID struct {
+	store     catalog.SVIDStore
+	selectors []*common.Selector
+
+	// state identifies the SVID and bundles last stored
+	state []byte
+}
+
+// New creates a new service.
+func New(c Config) *Service {
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	}
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+
+	stores := make(map[string]catalog.SVIDStore, len(c.Stores))
+	for _, store := range c.Stores {
+		stores[store.Name()] = store
+	}
+
+	return &Service{
+		c:      c,
+		stores: stores,
+		stored: make(map[string]*storedSVID),
+	}
+}
+
+// Run reconciles the stored SVIDs every interval until the context is
+// canceled.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := s.c.Clock.Ticker(s.c.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.reconcile(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile stores the SVIDs that are new or changed since the last run and
+// deletes the ones whose entries were removed. Failed operations are retried
+// on the next run.
+func (s *Service) reconcile(ctx context.Context) {
+	seen := make(map[string]bool)
+	for _, entry := range s.c.Manager.Entries() {
+		store, selectors, ok := s.storeFor(entry)
+		if !ok {
+			continue
+		}
+		seen[entry.EntryId] = true
+
+		log := s.c.Log.WithFields(logrus.Fields{
+			telemetry.RegistrationID: entry.EntryId,
+			telemetry.SPIFFEID:       entry.SpiffeId,
+			telemetry.SVIDStore:      store.Name(),
+		})
+
+		req, state, ok := s.buildRequest(entry, selectors)
+		if !ok {
+			log.Debug("X509-SVID not available yet")
+			continue
+		}
+
+		stored := s.stored[entry.EntryId]
+		if stored != nil && stored.store.Name() == store.Name() && bytes.Equal(stored.state, state) {
+			continue
+		}
+
+		if _, err := store.PutX509SVID(ctx, req); err != nil {
+			log.WithError(err).Error("Failed to put X509-SVID")
+			continue
+		}
+		s.stored[entry.EntryId] = &storedSVID{
+			store:     store,
+			selectors: selectors,
+			state:     state,
+		}
+		log.Debug("X509-SVID stored")
+	}
+
+	for entryID, stored := range s.stored {
+		if seen[entryID] {
+			continue
+		}
+
+		log := s.c.Log.WithFields(logrus.Fields{
+			telemetry.RegistrationID: entryID,
+			telemetry.SVIDStore:      stored.store.Name(),
+		})
+		if _, err := stored.store.DeleteX509SVID(ctx, &svidstore.DeleteX509SVIDRequest{
+			Selectors: stored.selectors,
+		}); err != nil {
+			log.WithError(err).Error("Failed to delete X509-SVID")
+			continue
+		}
+		delete(s.stored, entryID)
+		log.Debug("X509-SVID deleted")
+	}
+}
+
+// storeFor returns the store targeted by the entry along with the selectors
+// of the store.
+func (s *Service) storeFor(entry *common.RegistrationEntry) (catalog.SVIDStore, []*common.Selector, bool) {
+	var store catalog.SVIDStore
+	var selectors []*common.Selector
+	for _, selector := range entry.Selectors {
+		candidate, ok := s.stores[selector.Type]
+		if !ok {
+			continue
+		}
+		if store.SVIDStore != nil && candidate.Name() != store.Name() {
+			s.c.Log.WithField(telemetry.RegistrationID, entry.EntryId).Warn("Entry targets more than one SVIDStore plugin; ignoring it")
+			return catalog.SVIDStore{}, nil, false
+		}
+		store = candidate
+		selectors = append(selectors, selector)
+	}
+	return store, selectors, store.SVIDStore != nil
+}
+
+// buildRequest builds the request storing the X509-SVID of the entry. It
+// also returns the state identifying the SVID and bundles in the request.
+func (s *Service) buildRequest(entry *common.RegistrationEntry, selectors []*common.Selector) (*svidstore.PutX509SVIDRequest, []byte, bool) {
+	update := s.c.Manager.FetchWorkloadUpdate(entry.Selectors)
+
+	var identity *cache.Identity
+	for i := range update.Identities {
+		if update.Identities[i].Entry.EntryId == entry.EntryId {
+			identity = &update.Identities[i]
+			break
+		}
+	}
+	if identity == nil || len(identity.SVID) == 0 {
+		return nil, nil, false
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(identity.PrivateKey)
+	if err != nil {
+		s.c.Log.WithError(err).WithField(telemetry.RegistrationID, entry.EntryId).Error("Failed to marshal private key")
+		return nil, nil, false
+	}
+
+	var state bytes.Buffer
+	svid := &svidstore.X509SVID{
+		SpiffeId:   entry.SpiffeId,
+		PrivateKey: keyDER,
+		ExpiresAt:  identity.SVID[0].NotAfter.Unix(),
+	}
+	for _, cert := range identity.SVID {
+		svid.CertChain = append(svid.CertChain, cert.Raw)
+		state.Write(cert.Raw)
+	}
+	if update.Bundle != nil {
+		for _, cert := range update.Bundle.RootCAs() {
+			svid.Bundle = append(svid.Bundle, cert.Raw)
+			state.Write(cert.Raw)
+		}
+	}
+
+	federatedBundles := make(map[string][]byte)
+	for _, trustDomainID := range entry.FederatesWith {
+		federatedBundle, ok := update.FederatedBundles[trustDomainID]
+		if !ok {
+			continue
+		}
+		var bundleDER []byte
+		for _, cert := range federatedBundle.RootCAs() {
+			bundleDER = append(bundleDER, cert.Raw...)
+		}
+		federatedBundles[trustDomainID] = bundleDER
+		state.WriteString(trustDomainID)
+		state.Write(bundleDER)
+	}
+
+	return &svidstore.PutX509SVIDRequest{
+		Svid:             svid,
+		Selectors:        selectors,
+		FederatedBundles: federatedBundles,
+	}, state.Bytes(), true
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctx = context.Background()
+
+	td          = spiffeid.RequireTrustDomainFromString("example.org")
+	federatedTD = spiffeid.RequireTrustDomainFromString("federated.org")
+
+	storeSelector = &common.Selector{Type: "store", Value: "name:secret"}
+)
+
+func TestReconcile(t *testing.T) {
+	ca := testca.New(t, td)
+	federatedCA := testca.New(t, federatedTD)
+	svid := ca.CreateX509SVID(td.NewID("workload"))
+
+	entry := &common.RegistrationEntry{
+		EntryId:       "entry",
+		SpiffeId:      "spiffe://example.org/workload",
+		Selectors:     []*common.Selector{storeSelector, {Type: "unix", Value: "uid:1000"}},
+		FederatesWith: []string{federatedTD.IDString()},
+	}
+	otherEntry := &common.RegistrationEntry{
+		EntryId:   "other",
+		SpiffeId:  "spiffe://example.org/other",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+
+	m := &fakeManager{
+		entries: []*common.RegistrationEntry{entry, otherEntry},
+		update: &cache.WorkloadUpdate{
+			Identities: []cache.Identity{
+				{Entry: otherEntry, SVID: svid.Certificates, PrivateKey: svid.PrivateKey},
+				{Entry: entry, SVID: svid.Certificates, PrivateKey: svid.PrivateKey},
+			},
+			Bundle: bundleutil.BundleFromRootCAs(td.IDString(), ca.X509Authorities()),
+			FederatedBundles: map[string]*bundleutil.Bundle{
+				federatedTD.IDString(): bundleutil.BundleFromRootCAs(federatedTD.IDString(), federatedCA.X509Authorities()),
+			},
+		},
+	}
+	fakeStore := new(fakeSVIDStore)
+	log, _ := test.NewNullLogger()
+	s := New(Config{
+		Manager: m,
+		Stores:  []catalog.SVIDStore{fakeagentcatalog.SVIDStore("store", fakeStore)},
+		Log:     log,
+	})
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(svid.PrivateKey)
+	require.NoError(t, err)
+	expectPut := &svidstore.PutX509SVIDRequest{
+		Svid: &svidstore.X509SVID{
+			SpiffeId:   entry.SpiffeId,
+			CertChain:  [][]byte{svid.Certificates[0].Raw},
+			PrivateKey: keyDER,
+			Bundle:     [][]byte{ca.X509Authorities()[0].Raw},
+			ExpiresAt:  svid.Certificates[0].NotAfter.Unix(),
+		},
+		Selectors: []*common.Selector{storeSelector},
+		FederatedBundles: map[string][]byte{
+			federatedTD.IDString(): federatedCA.X509Authorities()[0].Raw,
+		},
+	}
+
+	// The SVID of the entry targeting the store is put
+	s.reconcile(ctx)
+	require.Equal(t, []*svidstore.PutX509SVIDRequest{expectPut}, fakeStore.puts)
+
+	// Nothing changed, so the SVID is not put again
+	s.reconcile(ctx)
+	require.Len(t, fakeStore.puts, 1)
+
+	// The SVID is put again when it is rotated
+	rotated := ca.CreateX509SVID(td.NewID("workload"))
+	m.update.Identities[1].SVID = rotated.Certificates
+	s.reconcile(ctx)
+	require.Len(t, fakeStore.puts, 2)
+	require.Equal(t, [][]byte{rotated.Certificates[0].Raw}, fakeStore.puts[1].Svid.CertChain)
+
+	// Failed deletes are retried until they succeed
+	m.entries = []*common.RegistrationEntry{otherEntry}
+	fakeStore.err = errors.New("oh no")
+	s.reconcile(ctx)
+	require.Empty(t, fakeStore.deletes)
+
+	fakeStore.err = nil
+	s.reconcile(ctx)
+	require.Equal(t, []*svidstore.DeleteX509SVIDRequest{
+		{Selectors: []*common.Selector{storeSelector}},
+	}, fakeStore.deletes)
+
+	s.reconcile(ctx)
+	require.Len(t, fakeStore.deletes, 1)
+}
+
+func TestReconcileRetriesFailedPuts(t *testing.T) {
+	ca := testca.New(t, td)
+	svid := ca.CreateX509SVID(td.NewID("workload"))
+	entry := &common.RegistrationEntry{
+		EntryId:   "entry",
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{storeSelector},
+	}
+
+	m := &fakeManager{
+		entries: []*common.RegistrationEntry{entry},
+		update:  &cache.WorkloadUpdate{},
+	}
+	fakeStore := &fakeSVIDStore{err: errors.New("oh no")}
+	log, _ := test.NewNullLogger()
+	s := New(Config{
+		Manager: m,
+		Stores:  []catalog.SVIDStore{fakeagentcatalog.SVIDStore("store", fakeStore)},
+		Log:     log,
+	})
+
+	// Nothing is put until the SVID is available
+	s.reconcile(ctx)
+	require.Empty(t, fakeStore.puts)
+
+	m.update.Identities = []cache.Identity{{Entry: entry, SVID: svid.Certificates, PrivateKey: svid.PrivateKey}}
+	s.reconcile(ctx)
+	require.Empty(t, fakeStore.puts)
+
+	fakeStore.err = nil
+	s.reconcile(ctx)
+	require.Len(t, fakeStore.puts, 1)
+}
+
+type fakeManager struct {
+	manager.Manager
+	entries []*common.RegistrationEntry
+	update  *cache.WorkloadUpdate
+}
+
+func (m *fakeManager) Entries() []*common.RegistrationEntry {
+	return m.entries
+}
+
+func (m *fakeManager) FetchWorkloadUpdate([]*common.Selector) *cache.WorkloadUpdate {
+	return m.update
+}
+
+type fakeSVIDStore struct {
+	err     error
+	puts    []*svidstore.PutX509SVIDRequest
+	deletes []*svidstore.DeleteX509SVIDRequest
+}
+
+func (s *fakeSVIDStore) PutX509SVID(_ context.Context, req *svidstore.PutX509SVIDRequest) (*svidstore.PutX509SVIDResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.puts = append(s.puts, req)
+	return &svidstore.PutX509SVIDResponse{}, nil
+}
+
+func (s *fakeSVIDStore) DeleteX509SVID(_ context.Context, req *svidstore.DeleteX509SVIDRequest) (*svidstore.DeleteX509SVIDResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.deletes = append(s.deletes, req)
+	return &svidstore.DeleteX509SVIDResponse{}, nil
+}
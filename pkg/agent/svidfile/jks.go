@@ -0,0 +1,141 @@
+package svidfile
+
+import (
+	"bytes"
+	"crypto/sha1" // nolint: gosec // SHA-1 is mandated by the JKS format
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// The Java KeyStore (JKS) format is not formally specified. The encoding
+// below follows the one of the JDK sun.security.provider.JavaKeyStore and
+// sun.security.provider.KeyProtector classes.
+const (
+	jksMagic   = 0xfeedfeed
+	jksVersion = 2
+
+	jksPrivateKeyTag  = 1
+	jksTrustedCertTag = 2
+
+	jksCertType = "X.509"
+
+	// jksIntegrityPhrase is mixed into the integrity digest of the keystore
+	jksIntegrityPhrase = "Mighty Aphrodite"
+)
+
+var (
+	oidJKSKeyProtector = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 42, 2, 17, 1, 1}
+)
+
+// encodeJKS encodes a Java KeyStore holding a private key entry for the
+// private key (PKCS#8 DER) and its certificate chain, and a trusted
+// certificate entry for each CA certificate of the bundle. The entries are
+// protected with the keystore password.
+func encodeJKS(keyDER []byte, chain, bundle []*x509.Certificate, password string, now time.Time) ([]byte, error) {
+	encodedPassword := bmpString(password)
+	timestamp := uint64(now.UnixNano() / int64(time.Millisecond))
+
+	protectedKey, err := protectJKSKey(keyDER, encodedPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	w := new(jksWriter)
+	w.uint32(jksMagic)
+	w.uint32(jksVersion)
+	w.uint32(uint32(1 + len(bundle)))
+
+	w.uint32(jksPrivateKeyTag)
+	w.utf(svidAlias)
+	w.uint64(timestamp)
+	w.bytes(protectedKey)
+	w.uint32(uint32(len(chain)))
+	for _, cert := range chain {
+		w.utf(jksCertType)
+		w.bytes(cert.Raw)
+	}
+
+	for i, cert := range bundle {
+		w.uint32(jksTrustedCertTag)
+		w.utf(fmt.Sprintf("bundle-%d", i))
+		w.uint64(timestamp)
+		w.utf(jksCertType)
+		w.bytes(cert.Raw)
+	}
+
+	h := sha1.New() // nolint: gosec // SHA-1 is mandated by the JKS format
+	h.Write(encodedPassword)
+	h.Write([]byte(jksIntegrityPhrase))
+	h.Write(w.buf.Bytes())
+	w.buf.Write(h.Sum(nil))
+
+	return w.buf.Bytes(), nil
+}
+
+// protectJKSKey encrypts a private key with the proprietary algorithm of the
+// JDK KeyProtector: the key is XORed with a keystream made of chained SHA-1
+// digests of the password, seeded with a random salt, and followed by a
+// SHA-1 digest of the password and plaintext key to check its integrity.
+func protectJKSKey(keyDER, encodedPassword []byte) ([]byte, error) {
+	salt, err := randomBytes(sha1.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	protected := make([]byte, 0, len(salt)+len(keyDER)+sha1.Size)
+	protected = append(protected, salt...)
+
+	digest := salt
+	for i := 0; i < len(keyDER); i += sha1.Size {
+		h := sha1.New() // nolint: gosec // SHA-1 is mandated by the JKS format
+		h.Write(encodedPassword)
+		h.Write(digest)
+		digest = h.Sum(nil)
+		for j := 0; j < sha1.Size && i+j < len(keyDER); j++ {
+			protected = append(protected, keyDER[i+j]^digest[j])
+		}
+	}
+
+	h := sha1.New() // nolint: gosec // SHA-1 is mandated by the JKS format
+	h.Write(encodedPassword)
+	h.Write(keyDER)
+	protected = h.Sum(protected)
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm: algorithmIdentifier{
+			Algorithm:  oidJKSKeyProtector,
+			Parameters: asn1.NullRawValue,
+		},
+		EncryptedData: protected,
+	})
+}
+
+// jksWriter writes the big endian primitives the JKS format is made of, as
+// written by java.io.DataOutputStream.
+type jksWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *jksWriter) uint32(v uint32) {
+	_ = binary.Write(&w.buf, binary.BigEndian, v)
+}
+
+func (w *jksWriter) uint64(v uint64) {
+	_ = binary.Write(&w.buf, binary.BigEndian, v)
+}
+
+// utf writes a length prefixed string. Aliases and certificate types are
+// ASCII, for which the modified UTF-8 encoding used by Java matches UTF-8.
+func (w *jksWriter) utf(s string) {
+	_ = binary.Write(&w.buf, binary.BigEndian, uint16(len(s)))
+	w.buf.WriteString(s)
+}
+
+// bytes writes length prefixed data.
+func (w *jksWriter) bytes(b []byte) {
+	w.uint32(uint32(len(b)))
+	w.buf.Write(b)
+}
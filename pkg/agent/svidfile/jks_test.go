@@ -0,0 +1,118 @@
+package svidfile
+
+import (
+	"bytes"
+	"crypto/sha1" // nolint: gosec // SHA-1 is mandated by the JKS format
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeJKS(t *testing.T) {
+	ca := testca.New(t, spiffeid.RequireTrustDomainFromString("example.org"))
+	svid := ca.CreateX509SVID(spiffeid.RequireFromString("spiffe://example.org/workload"))
+	keyDER, err := x509.MarshalPKCS8PrivateKey(svid.PrivateKey)
+	require.NoError(t, err)
+	now := time.Unix(1600000000, 0)
+
+	data, err := encodeJKS(keyDER, svid.Certificates, ca.X509Authorities(), "password", now)
+	require.NoError(t, err)
+
+	// Check the integrity digest
+	body, digest := data[:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+	h := sha1.New() // nolint: gosec // SHA-1 is mandated by the JKS format
+	h.Write(bmpString("password"))
+	h.Write([]byte("Mighty Aphrodite"))
+	h.Write(body)
+	require.Equal(t, h.Sum(nil), digest)
+
+	r := &jksReader{t: t, r: bytes.NewReader(body)}
+	require.Equal(t, uint32(0xfeedfeed), r.uint32())
+	require.Equal(t, uint32(2), r.uint32())
+	require.Equal(t, uint32(2), r.uint32())
+
+	// Private key entry
+	require.Equal(t, uint32(1), r.uint32())
+	require.Equal(t, "svid", r.utf())
+	require.Equal(t, uint64(1600000000000), r.uint64())
+	require.Equal(t, keyDER, recoverJKSKey(t, r.bytes(), bmpString("password")))
+	require.Equal(t, uint32(1), r.uint32())
+	require.Equal(t, "X.509", r.utf())
+	require.Equal(t, svid.Certificates[0].Raw, r.bytes())
+
+	// Trusted certificate entry
+	require.Equal(t, uint32(2), r.uint32())
+	require.Equal(t, "bundle-0", r.utf())
+	require.Equal(t, uint64(1600000000000), r.uint64())
+	require.Equal(t, "X.509", r.utf())
+	require.Equal(t, ca.X509Authorities()[0].Raw, r.bytes())
+
+	require.Zero(t, r.r.Len())
+}
+
+func recoverJKSKey(t *testing.T, der, encodedPassword []byte) []byte {
+	var info encryptedPrivateKeyInfo
+	_, err := asn1.Unmarshal(der, &info)
+	require.NoError(t, err)
+	require.Equal(t, oidJKSKeyProtector, info.Algorithm.Algorithm)
+
+	protected := info.EncryptedData
+	salt := protected[:sha1.Size]
+	encrypted := protected[sha1.Size : len(protected)-sha1.Size]
+	checksum := protected[len(protected)-sha1.Size:]
+
+	key := make([]byte, len(encrypted))
+	digest := salt
+	for i := range encrypted {
+		if i%sha1.Size == 0 {
+			h := sha1.New() // nolint: gosec // SHA-1 is mandated by the JKS format
+			h.Write(encodedPassword)
+			h.Write(digest)
+			digest = h.Sum(nil)
+		}
+		key[i] = encrypted[i] ^ digest[i%sha1.Size]
+	}
+
+	h := sha1.New() // nolint: gosec // SHA-1 is mandated by the JKS format
+	h.Write(encodedPassword)
+	h.Write(key)
+	require.Equal(t, h.Sum(nil), checksum)
+	return key
+}
+
+type jksReader struct {
+	t *testing.T
+	r *bytes.Reader
+}
+
+func (r *jksReader) uint32() (v uint32) {
+	require.NoError(r.t, binary.Read(r.r, binary.BigEndian, &v))
+	return v
+}
+
+func (r *jksReader) uint64() (v uint64) {
+	require.NoError(r.t, binary.Read(r.r, binary.BigEndian, &v))
+	return v
+}
+
+func (r *jksReader) utf() string {
+	var n uint16
+	require.NoError(r.t, binary.Read(r.r, binary.BigEndian, &n))
+	b := make([]byte, n)
+	_, err := r.r.Read(b)
+	require.NoError(r.t, err)
+	return string(b)
+}
+
+func (r *jksReader) bytes() []byte {
+	b := make([]byte, r.uint32())
+	_, err := r.r.Read(b)
+	require.NoError(r.t, err)
+	return b
+}
@@ -0,0 +1,353 @@
+package svidfile
+
+import (
+	"crypto/cipher"
+	"crypto/des" // nolint: gosec // 3DES is the key encryption algorithm supported by most PKCS#12 consumers
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // nolint: gosec // SHA-1 is the MAC algorithm supported by most PKCS#12 consumers
+	"crypto/x509"
+	"encoding/asn1"
+	"hash"
+	"math/big"
+	"unicode/utf16"
+)
+
+// The PKCS#12 files are protected with the algorithms that the widest range
+// of consumers support (e.g. OpenSSL, Java 8+, .NET): the private key is
+// encrypted with pbeWithSHAAnd3-KeyTripleDES-CBC and the file integrity is
+// protected with an HMAC-SHA1, both keyed with the PKCS#12 key derivation
+// function (RFC 7292, appendix B). The certificates are not encrypted.
+const (
+	pkcs12Iterations = 2048
+	pkcs12SaltLen    = 8
+)
+
+var (
+	oidDataContentType               = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidCertBag                       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidPKCS8ShroudedKeyBag           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidCertTypeX509Certificate       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+	oidFriendlyName                  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 20}
+	oidLocalKeyID                    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+	oidPBEWithSHAAnd3KeyTripleDESCBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 3}
+	oidSHA1                          = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+)
+
+type pfxPDU struct {
+	Version  int
+	AuthSafe contentInfo
+	MacData  macData
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue
+}
+
+type macData struct {
+	Mac        digestInfo
+	MacSalt    []byte
+	Iterations int
+}
+
+type digestInfo struct {
+	Algorithm algorithmIdentifier
+	Digest    []byte
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type pbeParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     algorithmIdentifier
+	EncryptedData []byte
+}
+
+type safeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue
+	Attributes []pkcs12Attribute `asn1:"set,optional"`
+}
+
+type pkcs12Attribute struct {
+	ID    asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+type certBag struct {
+	ID   asn1.ObjectIdentifier
+	Data asn1.RawValue
+}
+
+// encodePKCS12 encodes a PKCS#12 file holding the private key (PKCS#8 DER)
+// along with its certificate chain, and the CA certificates of the bundle.
+func encodePKCS12(keyDER []byte, chain, bundle []*x509.Certificate, password string) ([]byte, error) {
+	encodedPassword := append(bmpString(password), 0, 0)
+
+	localKeyID := sha1.Sum(chain[0].Raw) // nolint: gosec // only used to match the key with its certificate
+	keyAttributes := []pkcs12Attribute{
+		attribute(oidLocalKeyID, asn1.RawValue{Tag: asn1.TagOctetString, Bytes: localKeyID[:]}),
+		attribute(oidFriendlyName, asn1.RawValue{Tag: 30 /* BMPString */, Bytes: bmpString(svidAlias)}),
+	}
+
+	var certBags []safeBag
+	for i, cert := range chain {
+		var attributes []pkcs12Attribute
+		if i == 0 {
+			attributes = keyAttributes
+		}
+		bag, err := newCertBag(cert, attributes)
+		if err != nil {
+			return nil, err
+		}
+		certBags = append(certBags, bag)
+	}
+	for _, cert := range bundle {
+		bag, err := newCertBag(cert, nil)
+		if err != nil {
+			return nil, err
+		}
+		certBags = append(certBags, bag)
+	}
+
+	keyBag, err := newShroudedKeyBag(keyDER, encodedPassword, keyAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	certsContent, err := newDataContentInfo(certBags)
+	if err != nil {
+		return nil, err
+	}
+	keyContent, err := newDataContentInfo([]safeBag{keyBag})
+	if err != nil {
+		return nil, err
+	}
+	authSafe, err := asn1.Marshal([]contentInfo{certsContent, keyContent})
+	if err != nil {
+		return nil, err
+	}
+
+	macSalt, err := randomBytes(pkcs12SaltLen)
+	if err != nil {
+		return nil, err
+	}
+	macKey := pkcs12KDF(sha1.New, macSalt, encodedPassword, pkcs12Iterations, 3, sha1.Size)
+	mac := hmac.New(sha1.New, macKey)
+	mac.Write(authSafe)
+
+	authSafeContent, err := explicitOctetString(authSafe)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pfxPDU{
+		Version: 3,
+		AuthSafe: contentInfo{
+			ContentType: oidDataContentType,
+			Content:     authSafeContent,
+		},
+		MacData: macData{
+			Mac: digestInfo{
+				Algorithm: algorithmIdentifier{
+					Algorithm:  oidSHA1,
+					Parameters: asn1.NullRawValue,
+				},
+				Digest: mac.Sum(nil),
+			},
+			MacSalt:    macSalt,
+			Iterations: pkcs12Iterations,
+		},
+	})
+}
+
+func newCertBag(cert *x509.Certificate, attributes []pkcs12Attribute) (safeBag, error) {
+	certData, err := explicitOctetString(cert.Raw)
+	if err != nil {
+		return safeBag{}, err
+	}
+	bagDER, err := asn1.Marshal(certBag{
+		ID:   oidCertTypeX509Certificate,
+		Data: certData,
+	})
+	if err != nil {
+		return safeBag{}, err
+	}
+	return safeBag{
+		ID:         oidCertBag,
+		Value:      explicit(bagDER),
+		Attributes: attributes,
+	}, nil
+}
+
+func newShroudedKeyBag(keyDER, encodedPassword []byte, attributes []pkcs12Attribute) (safeBag, error) {
+	salt, err := randomBytes(pkcs12SaltLen)
+	if err != nil {
+		return safeBag{}, err
+	}
+	params, err := asn1.Marshal(pbeParams{
+		Salt:       salt,
+		Iterations: pkcs12Iterations,
+	})
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	key := pkcs12KDF(sha1.New, salt, encodedPassword, pkcs12Iterations, 1, 24)
+	iv := pkcs12KDF(sha1.New, salt, encodedPassword, pkcs12Iterations, 2, des.BlockSize)
+	block, err := des.NewTripleDESCipher(key)
+	if err != nil {
+		return safeBag{}, err
+	}
+
+	padLen := des.BlockSize - len(keyDER)%des.BlockSize
+	encrypted := make([]byte, len(keyDER)+padLen)
+	copy(encrypted, keyDER)
+	for i := len(keyDER); i < len(encrypted); i++ {
+		encrypted[i] = byte(padLen)
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, encrypted)
+
+	bagDER, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm: algorithmIdentifier{
+			Algorithm:  oidPBEWithSHAAnd3KeyTripleDESCBC,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		EncryptedData: encrypted,
+	})
+	if err != nil {
+		return safeBag{}, err
+	}
+	return safeBag{
+		ID:         oidPKCS8ShroudedKeyBag,
+		Value:      explicit(bagDER),
+		Attributes: attributes,
+	}, nil
+}
+
+func newDataContentInfo(bags []safeBag) (contentInfo, error) {
+	safeContents, err := asn1.Marshal(bags)
+	if err != nil {
+		return contentInfo{}, err
+	}
+	content, err := explicitOctetString(safeContents)
+	if err != nil {
+		return contentInfo{}, err
+	}
+	return contentInfo{
+		ContentType: oidDataContentType,
+		Content:     content,
+	}, nil
+}
+
+func attribute(id asn1.ObjectIdentifier, value asn1.RawValue) pkcs12Attribute {
+	valueDER, _ := asn1.Marshal(value)
+	return pkcs12Attribute{
+		ID:    id,
+		Value: asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: valueDER},
+	}
+}
+
+// explicit wraps DER encoded data in an [0] EXPLICIT tag.
+func explicit(der []byte) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: der}
+}
+
+// explicitOctetString wraps data in an OCTET STRING tagged [0] EXPLICIT.
+func explicitOctetString(data []byte) (asn1.RawValue, error) {
+	der, err := asn1.Marshal(data)
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+	return explicit(der), nil
+}
+
+// bmpString encodes a string as a BMPString (UTF-16BE). Passwords are NULL
+// terminated before being passed to the PKCS#12 key derivation function.
+func bmpString(s string) []byte {
+	encoded := utf16.Encode([]rune(s))
+	out := make([]byte, 0, 2*len(encoded))
+	for _, c := range encoded {
+		out = append(out, byte(c>>8), byte(c))
+	}
+	return out
+}
+
+// pkcs12KDF derives size bytes of key material as described in RFC 7292,
+// appendix B.2. The id selects the purpose of the material: 1 for encryption
+// keys, 2 for IVs and 3 for MAC keys.
+func pkcs12KDF(newHash func() hash.Hash, salt, password []byte, iterations int, id byte, size int) []byte {
+	v := newHash().BlockSize()
+
+	d := make([]byte, v)
+	for i := range d {
+		d[i] = id
+	}
+
+	s := fillBlocks(salt, v)
+	p := fillBlocks(password, v)
+	ii := append(s, p...)
+
+	one := big.NewInt(1)
+	modulus := new(big.Int).Lsh(one, uint(v*8))
+
+	var out []byte
+	for len(out) < size {
+		h := newHash()
+		h.Write(d)
+		h.Write(ii)
+		a := h.Sum(nil)
+		for i := 1; i < iterations; i++ {
+			h = newHash()
+			h.Write(a)
+			a = h.Sum(a[:0])
+		}
+		out = append(out, a...)
+		if len(out) >= size {
+			break
+		}
+
+		b := new(big.Int).SetBytes(fillBlocks(a, v)[:v])
+		b.Add(b, one)
+		for j := 0; j < len(ii); j += v {
+			block := new(big.Int).SetBytes(ii[j : j+v])
+			block.Add(block, b)
+			block.Mod(block, modulus)
+			blockBytes := block.Bytes()
+			for k := j; k < j+v; k++ {
+				ii[k] = 0
+			}
+			copy(ii[j+v-len(blockBytes):j+v], blockBytes)
+		}
+	}
+	return out[:size]
+}
+
+// fillBlocks repeats data to fill the smallest multiple of the block size
+// that can hold it.
+func fillBlocks(data []byte, blockSize int) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	n := blockSize * ((len(data) + blockSize - 1) / blockSize)
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = data[i%len(data)]
+	}
+	return out
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
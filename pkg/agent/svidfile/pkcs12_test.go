@@ -0,0 +1,51 @@
+package svidfile
+
+import (
+	"crypto/sha1" // nolint: gosec // required by the PKCS#12 key derivation function
+	"crypto/x509"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/pkcs12"
+)
+
+func TestEncodePKCS12(t *testing.T) {
+	ca := testca.New(t, spiffeid.RequireTrustDomainFromString("example.org"))
+	svid := ca.CreateX509SVID(spiffeid.RequireFromString("spiffe://example.org/workload"))
+	keyDER, err := x509.MarshalPKCS8PrivateKey(svid.PrivateKey)
+	require.NoError(t, err)
+
+	data, err := encodePKCS12(keyDER, svid.Certificates, ca.X509Authorities(), "password")
+	require.NoError(t, err)
+
+	_, err = pkcs12.ToPEM(data, "wrong")
+	require.Equal(t, pkcs12.ErrIncorrectPassword, err)
+
+	blocks, err := pkcs12.ToPEM(data, "password")
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+
+	require.Equal(t, "CERTIFICATE", blocks[0].Type)
+	require.Equal(t, svid.Certificates[0].Raw, blocks[0].Bytes)
+	require.Equal(t, "svid", blocks[0].Headers["friendlyName"])
+	require.Equal(t, "CERTIFICATE", blocks[1].Type)
+	require.Equal(t, ca.X509Authorities()[0].Raw, blocks[1].Bytes)
+
+	require.Equal(t, "PRIVATE KEY", blocks[2].Type)
+	require.Equal(t, blocks[0].Headers["localKeyId"], blocks[2].Headers["localKeyId"])
+	key, err := x509.ParseECPrivateKey(blocks[2].Bytes)
+	require.NoError(t, err)
+	require.Equal(t, svid.PrivateKey.Public(), key.Public())
+}
+
+func TestPKCS12KDF(t *testing.T) {
+	// Test vectors from the golang.org/x/crypto/pkcs12 tests
+	key := pkcs12KDF(sha1.New, []byte("\xff\xff\xff\xff\xff\xff\xff\xff"), append(bmpString("sesame"), 0, 0), 2048, 1, 24)
+	require.Equal(t, []byte("\x7c\xd9\xfd\x3e\x2b\x3b\xe7\x69\x1a\x44\xe3\xbe\xf0\xf9\xea\x0f\xb9\xb8\x97\xd4\xe3\x25\xd9\xd1"), key)
+
+	// Leading zero bytes in the intermediate blocks must be preserved
+	key = pkcs12KDF(sha1.New, []byte("\xf3\x7e\x05\xb5\x18\x32\x4b\x4b"), []byte("\x00\x00"), 2048, 1, 24)
+	require.Equal(t, []byte("\x00\xf7\x59\xff\x47\xd1\x4d\xd0\x36\x65\xd5\x94\x3c\xb3\xc4\xa3\x9a\x25\x55\xc0\x2a\xed\x66\xe1"), key)
+}
@@ -0,0 +1,252 @@
+package svidfile
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	// retryInterval is how long the sink waits before writing the files
+	// again after a failure.
+	retryInterval = 10 * time.Second
+
+	// DefaultFileMode is the mode of the files written by the sink when
+	// none is configured.
+	DefaultFileMode os.FileMode = 0600
+
+	// svidAlias is the name of the keystore entry holding the SVID and its
+	// private key.
+	svidAlias = "svid"
+)
+
+// Format is the format the sink writes the SVID and bundle in.
+type Format string
+
+const (
+	// FormatPEM writes the SVID, private key and bundle to separate PEM files.
+	FormatPEM Format = "pem"
+
+	// FormatPKCS12 writes the SVID, private key and bundle to a single
+	// password protected PKCS#12 file.
+	FormatPKCS12 Format = "pkcs12"
+
+	// FormatJKS writes the SVID, private key and bundle to a single password
+	// protected Java KeyStore.
+	FormatJKS Format = "jks"
+)
+
+// Config is the configuration of a sink.
+type Config struct {
+	// Selectors the workload identity is matched against. The SVID written
+	// is the first one of the identities the selectors match.
+	Selectors []*common.Selector
+
+	// Format the files are written in.
+	Format Format
+
+	// SVIDPath, KeyPath and BundlePath are the paths the SVID certificate
+	// chain, private key and bundle are written to with FormatPEM.
+	SVIDPath   string
+	KeyPath    string
+	BundlePath string
+
+	// KeystorePath is the path the keystore is written to with
+	// FormatPKCS12 and FormatJKS.
+	KeystorePath string
+
+	// Password protects the keystore.
+	Password string
+
+	// IncludeFederatedBundles adds the bundles of the trust domains the
+	// identity federates with to the written bundle.
+	IncludeFederatedBundles bool
+
+	// FileMode is the mode of the written files. Defaults to DefaultFileMode.
+	FileMode os.FileMode
+
+	Manager manager.Manager
+	Log     logrus.FieldLogger
+	Clock   clock.Clock
+}
+
+// Validate checks that the configuration describes where and how to write
+// the files.
+func (c *Config) Validate() error {
+	if len(c.Selectors) == 0 {
+		return errors.New("at least one selector is required")
+	}
+	switch c.Format {
+	case FormatPEM:
+		if c.SVIDPath == "" || c.KeyPath == "" || c.BundlePath == "" {
+			return errors.New("SVID, key and bundle paths are required with the pem format")
+		}
+	case FormatPKCS12, FormatJKS:
+		if c.KeystorePath == "" {
+			return fmt.Errorf("keystore path is required with the %s format", c.Format)
+		}
+		if c.Password == "" {
+			return fmt.Errorf("password is required with the %s format", c.Format)
+		}
+	default:
+		return fmt.Errorf("unsupported format %q", c.Format)
+	}
+	return nil
+}
+
+// Sink writes the SVID of a workload to files and refreshes them when the
+// SVID is rotated or the bundle changes, for applications that are not able
+// to consume the Workload API.
+type Sink struct {
+	c Config
+
+	// written identifies the SVID and bundle last written
+	written []byte
+}
+
+// New creates a new sink. The configuration is expected to be valid.
+func New(c Config) *Sink {
+	if c.FileMode == 0 {
+		c.FileMode = DefaultFileMode
+	}
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	return &Sink{
+		c: c,
+	}
+}
+
+// Run writes the files every time the workload identity is updated, until
+// the context is canceled.
+func (s *Sink) Run(ctx context.Context) error {
+	sub := s.c.Manager.SubscribeToCacheChanges(s.c.Selectors)
+	defer sub.Finish()
+
+	var update *cache.WorkloadUpdate
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update = <-sub.Updates():
+		case <-retry:
+		}
+
+		retry = nil
+		if err := s.write(update); err != nil {
+			s.c.Log.WithError(err).Error("Failed to write SVID files")
+			retry = s.c.Clock.After(retryInterval)
+		}
+	}
+}
+
+func (s *Sink) write(update *cache.WorkloadUpdate) error {
+	if len(update.Identities) == 0 {
+		s.c.Log.Debug("No identity matches the selectors")
+		return nil
+	}
+	identity := update.Identities[0]
+
+	bundle := s.bundle(identity, update)
+
+	// The keystores are encrypted with random salts, so the certificates are
+	// compared instead of the file contents to avoid rewriting the files
+	// when nothing changed.
+	var state bytes.Buffer
+	for _, cert := range identity.SVID {
+		state.Write(cert.Raw)
+	}
+	for _, cert := range bundle {
+		state.Write(cert.Raw)
+	}
+	if bytes.Equal(s.written, state.Bytes()) {
+		return nil
+	}
+
+	files, err := s.encode(identity, bundle)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+			return err
+		}
+		if err := diskutil.AtomicWriteFile(file.path, file.data, s.c.FileMode); err != nil {
+			return err
+		}
+	}
+	s.written = state.Bytes()
+
+	s.c.Log.WithFields(logrus.Fields{
+		telemetry.SPIFFEID:   identity.Entry.SpiffeId,
+		telemetry.Expiration: identity.SVID[0].NotAfter.Format(time.RFC3339),
+	}).Info("SVID files written")
+	return nil
+}
+
+// bundle returns the CA certificates the identity trusts.
+func (s *Sink) bundle(identity cache.Identity, update *cache.WorkloadUpdate) []*x509.Certificate {
+	var bundle []*x509.Certificate
+	if update.Bundle != nil {
+		bundle = append(bundle, update.Bundle.RootCAs()...)
+	}
+	if s.c.IncludeFederatedBundles {
+		for _, trustDomainID := range identity.Entry.FederatesWith {
+			if federatedBundle, ok := update.FederatedBundles[trustDomainID]; ok {
+				bundle = append(bundle, federatedBundle.RootCAs()...)
+			}
+		}
+	}
+	return bundle
+}
+
+type file struct {
+	path string
+	data []byte
+}
+
+func (s *Sink) encode(identity cache.Identity, bundle []*x509.Certificate) ([]file, error) {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(identity.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal private key: %v", err)
+	}
+
+	switch s.c.Format {
+	case FormatPEM:
+		return []file{
+			{path: s.c.SVIDPath, data: pemutil.EncodeCertificates(identity.SVID)},
+			{path: s.c.KeyPath, data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})},
+			{path: s.c.BundlePath, data: pemutil.EncodeCertificates(bundle)},
+		}, nil
+	case FormatPKCS12:
+		data, err := encodePKCS12(keyDER, identity.SVID, bundle, s.c.Password)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode PKCS#12 keystore: %v", err)
+		}
+		return []file{{path: s.c.KeystorePath, data: data}}, nil
+	case FormatJKS:
+		data, err := encodeJKS(keyDER, identity.SVID, bundle, s.c.Password, s.c.Clock.Now())
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode Java KeyStore: %v", err)
+		}
+		return []file{{path: s.c.KeystorePath, data: data}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q", s.c.Format)
+	}
+}
@@ -0,0 +1,251 @@
+package svidfile
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	td          = spiffeid.RequireTrustDomainFromString("example.org")
+	federatedTD = spiffeid.RequireTrustDomainFromString("federated.org")
+	workloadID  = spiffeid.RequireFromString("spiffe://example.org/workload")
+	selectors   = []*common.Selector{{Type: "unix", Value: "uid:1000"}}
+)
+
+func TestConfigValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name: "pem",
+			config: Config{
+				Selectors:  selectors,
+				Format:     FormatPEM,
+				SVIDPath:   "svid.pem",
+				KeyPath:    "svid_key.pem",
+				BundlePath: "bundle.pem",
+			},
+		},
+		{
+			name: "pkcs12",
+			config: Config{
+				Selectors:    selectors,
+				Format:       FormatPKCS12,
+				KeystorePath: "svid.p12",
+				Password:     "password",
+			},
+		},
+		{
+			name: "no selectors",
+			config: Config{
+				Format:     FormatPEM,
+				SVIDPath:   "svid.pem",
+				KeyPath:    "svid_key.pem",
+				BundlePath: "bundle.pem",
+			},
+			err: "at least one selector is required",
+		},
+		{
+			name: "pem without bundle path",
+			config: Config{
+				Selectors: selectors,
+				Format:    FormatPEM,
+				SVIDPath:  "svid.pem",
+				KeyPath:   "svid_key.pem",
+			},
+			err: "SVID, key and bundle paths are required with the pem format",
+		},
+		{
+			name: "jks without keystore path",
+			config: Config{
+				Selectors: selectors,
+				Format:    FormatJKS,
+				Password:  "password",
+			},
+			err: "keystore path is required with the jks format",
+		},
+		{
+			name: "jks without password",
+			config: Config{
+				Selectors:    selectors,
+				Format:       FormatJKS,
+				KeystorePath: "svid.jks",
+			},
+			err: "password is required with the jks format",
+		},
+		{
+			name: "unsupported format",
+			config: Config{
+				Selectors: selectors,
+				Format:    "der",
+			},
+			err: `unsupported format "der"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSinkWritesPEM(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	ca := testca.New(t, td)
+	federatedCA := testca.New(t, federatedTD)
+
+	log, _ := test.NewNullLogger()
+	sink := New(Config{
+		Selectors:               selectors,
+		Format:                  FormatPEM,
+		SVIDPath:                filepath.Join(dir, "certs", "svid.pem"),
+		KeyPath:                 filepath.Join(dir, "certs", "svid_key.pem"),
+		BundlePath:              filepath.Join(dir, "certs", "bundle.pem"),
+		IncludeFederatedBundles: true,
+		Log:                     log,
+	})
+
+	update := newWorkloadUpdate(ca, federatedCA)
+	require.NoError(t, sink.write(update))
+
+	svid, err := pemutil.LoadCertificates(filepath.Join(dir, "certs", "svid.pem"))
+	require.NoError(t, err)
+	require.Equal(t, update.Identities[0].SVID, svid)
+	key, err := pemutil.LoadPrivateKey(filepath.Join(dir, "certs", "svid_key.pem"))
+	require.NoError(t, err)
+	require.Equal(t, update.Identities[0].PrivateKey, key)
+	bundle, err := pemutil.LoadCertificates(filepath.Join(dir, "certs", "bundle.pem"))
+	require.NoError(t, err)
+	require.Equal(t, append(ca.X509Authorities(), federatedCA.X509Authorities()...), bundle)
+
+	info, err := os.Stat(filepath.Join(dir, "certs", "svid_key.pem"))
+	require.NoError(t, err)
+	require.Equal(t, DefaultFileMode, info.Mode())
+
+	// The files are not written again when nothing changed
+	require.NoError(t, os.Remove(filepath.Join(dir, "certs", "svid.pem")))
+	require.NoError(t, sink.write(update))
+	require.NoFileExists(t, filepath.Join(dir, "certs", "svid.pem"))
+
+	// The files are written again when the SVID is rotated
+	rotated := newWorkloadUpdate(ca, federatedCA)
+	require.NoError(t, sink.write(rotated))
+	svid, err = pemutil.LoadCertificates(filepath.Join(dir, "certs", "svid.pem"))
+	require.NoError(t, err)
+	require.Equal(t, rotated.Identities[0].SVID, svid)
+}
+
+func TestSinkRetriesFailedWrites(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	ca := testca.New(t, td)
+	clk := clock.NewMock(t)
+	keystorePath := filepath.Join(dir, "certs", "svid.p12")
+
+	// Block the creation of the keystore directory
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "certs"), nil, 0600))
+
+	sub := &fakeSubscriber{updates: make(chan *cache.WorkloadUpdate, 1)}
+	log, _ := test.NewNullLogger()
+	sink := New(Config{
+		Selectors:    selectors,
+		Format:       FormatPKCS12,
+		KeystorePath: keystorePath,
+		Password:     "password",
+		FileMode:     0640,
+		Manager:      &fakeManager{t: t, sub: sub},
+		Log:          log,
+		Clock:        clk,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- sink.Run(ctx)
+	}()
+
+	sub.updates <- newWorkloadUpdate(ca, nil)
+	clk.WaitForAfter(time.Minute, "sink did not schedule a retry")
+	require.NoFileExists(t, keystorePath)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "certs")))
+	clk.Add(retryInterval)
+	require.Eventually(t, func() bool {
+		info, err := os.Stat(keystorePath)
+		return err == nil && info.Mode() == 0640
+	}, time.Minute, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.True(t, sub.finished)
+}
+
+func newWorkloadUpdate(ca, federatedCA *testca.CA) *cache.WorkloadUpdate {
+	svid := ca.CreateX509SVID(workloadID)
+	update := &cache.WorkloadUpdate{
+		Identities: []cache.Identity{
+			{
+				Entry: &common.RegistrationEntry{
+					SpiffeId: workloadID.String(),
+				},
+				SVID:       svid.Certificates,
+				PrivateKey: svid.PrivateKey,
+			},
+		},
+		Bundle: bundleutil.BundleFromRootCAs(td.IDString(), ca.X509Authorities()),
+	}
+	if federatedCA != nil {
+		update.Identities[0].Entry.FederatesWith = []string{federatedTD.IDString()}
+		update.FederatedBundles = map[string]*bundleutil.Bundle{
+			federatedTD.IDString(): bundleutil.BundleFromRootCAs(federatedTD.IDString(), federatedCA.X509Authorities()),
+		}
+	}
+	return update
+}
+
+type fakeManager struct {
+	manager.Manager
+	t   *testing.T
+	sub *fakeSubscriber
+}
+
+func (m *fakeManager) SubscribeToCacheChanges(s cache.Selectors) cache.Subscriber {
+	assert.Equal(m.t, cache.Selectors(selectors), s)
+	return m.sub
+}
+
+type fakeSubscriber struct {
+	updates  chan *cache.WorkloadUpdate
+	finished bool
+}
+
+func (s *fakeSubscriber) Updates() <-chan *cache.WorkloadUpdate {
+	return s.updates
+}
+
+func (s *fakeSubscriber) Finish() {
+	s.finished = true
+}
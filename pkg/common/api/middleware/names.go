@@ -19,6 +19,10 @@ const (
 	EnvoySDSv2ServiceShortName  = "SDS.v2"
 	EnvoySDSv3ServiceName       = "envoy.service.secret.v3.SecretDiscoveryService"
 	EnvoySDSv3ServiceShortName  = "SDS.v3"
+	HealthServiceName           = "grpc.health.v1.Health"
+	HealthServiceShortName      = "Health"
+	ReflectionServiceName       = "grpc.reflection.v1alpha.ServerReflection"
+	ReflectionServiceShortName  = "Reflection"
 )
 
 var (
@@ -27,6 +31,8 @@ var (
 		WorkloadAPIServiceName, WorkloadAPIServiceShortName,
 		EnvoySDSv2ServiceName, EnvoySDSv2ServiceShortName,
 		EnvoySDSv3ServiceName, EnvoySDSv3ServiceShortName,
+		HealthServiceName, HealthServiceShortName,
+		ReflectionServiceName, ReflectionServiceShortName,
 	)
 
 	// namesCache caches parsed names
@@ -76,7 +76,8 @@ func CommonBundleFromProto(b *types.Bundle) (*common.Bundle, error) {
 	var rootCAs []*common.Certificate
 	for _, rootCA := range b.X509Authorities {
 		rootCAs = append(rootCAs, &common.Certificate{
-			DerBytes: rootCA.Asn1,
+			DerBytes:   rootCA.Asn1,
+			TaintedKey: rootCA.Tainted,
 		})
 	}
 
@@ -87,9 +88,10 @@ func CommonBundleFromProto(b *types.Bundle) (*common.Bundle, error) {
 		}
 
 		jwtKeys = append(jwtKeys, &common.PublicKey{
-			PkixBytes: key.PublicKey,
-			Kid:       key.KeyId,
-			NotAfter:  key.ExpiresAt,
+			PkixBytes:  key.PublicKey,
+			Kid:        key.KeyId,
+			NotAfter:   key.ExpiresAt,
+			TaintedKey: key.Tainted,
 		})
 	}
 
@@ -129,6 +131,29 @@ func (b *Bundle) JWTSigningKeys() map[string]crypto.PublicKey {
 	return b.jwtSigningKeys
 }
 
+// TaintedRootCAs returns the root CAs whose keys have been tainted.
+func (b *Bundle) TaintedRootCAs() []*x509.Certificate {
+	var tainted []*x509.Certificate
+	for i, rootCA := range b.b.RootCas {
+		if rootCA.TaintedKey {
+			tainted = append(tainted, b.rootCAs[i])
+		}
+	}
+	return tainted
+}
+
+// TaintedJWTSigningKeyIDs returns the key IDs of the JWT signing keys that
+// have been tainted.
+func (b *Bundle) TaintedJWTSigningKeyIDs() map[string]bool {
+	tainted := make(map[string]bool)
+	for _, jwtSigningKey := range b.b.JwtSigningKeys {
+		if jwtSigningKey.TaintedKey {
+			tainted[jwtSigningKey.Kid] = true
+		}
+	}
+	return tainted
+}
+
 // RefreshHint returns the bundle refresh hint.
 func (b *Bundle) RefreshHint() time.Duration {
 	return time.Second * time.Duration(b.b.RefreshHint)
@@ -224,27 +249,31 @@ func JWTSigningKeysFromBundleProto(b *common.Bundle) (map[string]crypto.PublicKe
 	return out, nil
 }
 
+// MergeBundles appends the root CAs and JWT signing keys of b that are not
+// already in a. Whether a root CA or JWT signing key is tainted is not taken
+// into account when comparing them, so a tainted authority in a is not
+// duplicated by an untainted copy in b.
 func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 	c := cloneBundle(a)
 
 	rootCAs := make(map[string]bool)
 	for _, rootCA := range a.RootCas {
-		rootCAs[rootCA.String()] = true
+		rootCAs[rootCAKey(rootCA)] = true
 	}
 	jwtSigningKeys := make(map[string]bool)
 	for _, jwtSigningKey := range a.JwtSigningKeys {
-		jwtSigningKeys[jwtSigningKey.String()] = true
+		jwtSigningKeys[jwtSigningKeyKey(jwtSigningKey)] = true
 	}
 
 	var changed bool
 	for _, rootCA := range b.RootCas {
-		if !rootCAs[rootCA.String()] {
+		if !rootCAs[rootCAKey(rootCA)] {
 			c.RootCas = append(c.RootCas, rootCA)
 			changed = true
 		}
 	}
 	for _, jwtSigningKey := range b.JwtSigningKeys {
-		if !jwtSigningKeys[jwtSigningKey.String()] {
+		if !jwtSigningKeys[jwtSigningKeyKey(jwtSigningKey)] {
 			c.JwtSigningKeys = append(c.JwtSigningKeys, jwtSigningKey)
 			changed = true
 		}
@@ -309,6 +338,14 @@ pruneRootCA:
 	return newBundle, changed, nil
 }
 
+func rootCAKey(rootCA *common.Certificate) string {
+	return string(rootCA.DerBytes)
+}
+
+func jwtSigningKeyKey(jwtSigningKey *common.PublicKey) string {
+	return fmt.Sprintf("%s:%x:%d", jwtSigningKey.Kid, jwtSigningKey.PkixBytes, jwtSigningKey.NotAfter)
+}
+
 func cloneBundle(b *common.Bundle) *common.Bundle {
 	return proto.Clone(b).(*common.Bundle)
 }
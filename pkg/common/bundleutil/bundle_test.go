@@ -147,6 +147,38 @@ func TestCommonBundleFromProto(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "tainted authorities",
+			bundle: &types.Bundle{
+				TrustDomain: td.String(),
+				X509Authorities: []*types.X509Certificate{
+					{
+						Asn1:    rootCA.Raw,
+						Tainted: true,
+					},
+				},
+				JwtAuthorities: []*types.JWTKey{
+					{
+						PublicKey: pkixBytes,
+						KeyId:     "key-id-1",
+						ExpiresAt: 1590514224,
+						Tainted:   true,
+					},
+				},
+			},
+			expectBundle: &common.Bundle{
+				TrustDomainId: td.IDString(),
+				RootCas:       []*common.Certificate{{DerBytes: rootCA.Raw, TaintedKey: true}},
+				JwtSigningKeys: []*common.PublicKey{
+					{
+						PkixBytes:  pkixBytes,
+						Kid:        "key-id-1",
+						NotAfter:   1590514224,
+						TaintedKey: true,
+					},
+				},
+			},
+		},
 		{
 			name: "Empty key ID",
 			bundle: &types.Bundle{
@@ -189,6 +221,59 @@ func TestCommonBundleFromProto(t *testing.T) {
 	}
 }
 
+func TestMergeBundles(t *testing.T) {
+	test := setupTest(t)
+
+	a := createBundle([]*x509.Certificate{test.certExpired}, []*common.PublicKey{
+		{Kid: "KID1", NotAfter: test.jwtKeyExpired.NotAfter, TaintedKey: true},
+	})
+	a.RootCas[0].TaintedKey = true
+
+	// Appending untainted copies of the tainted authorities does not change
+	// the bundle.
+	b := createBundle([]*x509.Certificate{test.certExpired}, []*common.PublicKey{
+		{Kid: "KID1", NotAfter: test.jwtKeyExpired.NotAfter},
+	})
+	merged, changed := MergeBundles(a, b)
+	require.False(t, changed)
+	spiretest.RequireProtoEqual(t, a, merged)
+
+	// New authorities are appended after the existing ones.
+	b = createBundle([]*x509.Certificate{test.certNotExpired}, []*common.PublicKey{
+		{Kid: "KID2", NotAfter: test.jwtKeyNotExpired.NotAfter},
+	})
+	merged, changed = MergeBundles(a, b)
+	require.True(t, changed)
+	expected := createBundle([]*x509.Certificate{test.certExpired, test.certNotExpired}, []*common.PublicKey{
+		{Kid: "KID1", NotAfter: test.jwtKeyExpired.NotAfter, TaintedKey: true},
+		{Kid: "KID2", NotAfter: test.jwtKeyNotExpired.NotAfter},
+	})
+	expected.RootCas[0].TaintedKey = true
+	spiretest.RequireProtoEqual(t, expected, merged)
+}
+
+func TestTaintedAuthorities(t *testing.T) {
+	test := setupTest(t)
+	pkixBytes, err := x509.MarshalPKIXPublicKey(testKey.Public())
+	require.NoError(t, err)
+
+	bundleProto := createBundle([]*x509.Certificate{test.certExpired, test.certNotExpired}, []*common.PublicKey{
+		{Kid: "KID1", PkixBytes: pkixBytes},
+		{Kid: "KID2", PkixBytes: pkixBytes},
+	})
+	bundle, err := BundleFromProto(bundleProto)
+	require.NoError(t, err)
+	require.Empty(t, bundle.TaintedRootCAs())
+	require.Empty(t, bundle.TaintedJWTSigningKeyIDs())
+
+	bundleProto.RootCas[0].TaintedKey = true
+	bundleProto.JwtSigningKeys[1].TaintedKey = true
+	bundle, err = BundleFromProto(bundleProto)
+	require.NoError(t, err)
+	require.Equal(t, []*x509.Certificate{test.certExpired}, bundle.TaintedRootCAs())
+	require.Equal(t, map[string]bool{"KID2": true}, bundle.TaintedJWTSigningKeyIDs())
+}
+
 func createBundle(certs []*x509.Certificate, jwtKeys []*common.PublicKey) *common.Bundle {
 	bundle := BundleProtoFromRootCAs("spiffe://foo", certs)
 	bundle.JwtSigningKeys = jwtKeys
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/zeebo/errs"
@@ -81,7 +82,7 @@ func LoadBuiltInPlugin(ctx context.Context, builtin BuiltInPlugin) (plugin *Load
 		telemetry.PluginBuiltIn,
 	).Named(builtin.Plugin.Name)
 
-	initPluginServer(
+	impls := initPluginServer(
 		builtinServer,
 		&builtinDialer{hostConn: hostConn},
 		logger,
@@ -118,9 +119,28 @@ func LoadBuiltInPlugin(ctx context.Context, builtin BuiltInPlugin) (plugin *Load
 	}
 
 	plugin.closer = closers.Close
+	plugin.checkProviders = checkProvidersFrom(impls)
 	return plugin, nil
 }
 
+// checkProvidersFrom returns the plugin and service implementations that
+// contribute health checks. Only built-in plugins are able to contribute
+// checks since they run in the same process as the health checker.
+func checkProvidersFrom(impls []interface{}) []health.CheckProvider {
+	var providers []health.CheckProvider
+	seen := make(map[interface{}]bool)
+	for _, impl := range impls {
+		if seen[impl] {
+			continue
+		}
+		seen[impl] = true
+		if provider, ok := impl.(health.CheckProvider); ok {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
 func newBuiltInServer() *grpc.Server {
 	return grpc.NewServer(
 		grpc.StreamInterceptor(streamPanicInterceptor),
@@ -2,8 +2,10 @@ package catalog
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
@@ -31,8 +33,24 @@ type Config struct {
 
 	// BuiltIns is the set of builtin plugins available to the host.
 	BuiltIns []Plugin
+
+	// HealthChecks, if set, receives the health checks contributed by the
+	// built-in plugins.
+	HealthChecks *health.Checker
+
+	// Metrics, if set, receives the metrics about the health of external
+	// plugins.
+	Metrics telemetry.Metrics
+
+	// PluginMonitor configures the health monitoring of external plugin
+	// processes, which are restarted when they exit or stop responding.
+	PluginMonitor PluginMonitorConfig
 }
 
+// PluginCheckInterval is how often the health checks contributed by plugins
+// run.
+const PluginCheckInterval = time.Minute
+
 // Catalog provides a method to obtain clients to loaded plugins and services.
 type Catalog interface {
 	// Fill fills up a "catalog" with client interfaces to interface with
@@ -192,9 +210,13 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 				Name:          c.Name,
 				Path:          c.Path,
 				Checksum:      c.Checksum,
+				SignaturePath: c.SignaturePath,
+				PublicKeyPath: c.PublicKeyPath,
 				Plugin:        extPlugin,
 				KnownServices: config.KnownServices,
 				HostServices:  config.HostServices,
+				Metrics:       config.Metrics,
+				Monitor:       config.PluginMonitor,
 			})
 		}
 		if err != nil {
@@ -216,6 +238,14 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 			return nil, errs.New("unable to configure plugin %q: %v", c.Name, err)
 		}
 
+		if config.HealthChecks != nil {
+			for _, provider := range plugin.checkProviders {
+				if err := config.HealthChecks.AddChecksFrom(c.Name, provider, PluginCheckInterval); err != nil {
+					return nil, errs.New("unable to add health checks of plugin %q: %v", c.Name, err)
+				}
+			}
+		}
+
 		pluginLog.WithField(telemetry.PluginServices, plugin.serviceNames).Info("Plugin loaded")
 	}
 
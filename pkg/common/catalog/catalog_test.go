@@ -2,21 +2,34 @@ package catalog_test
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/catalog/test"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/private/test/catalogtest"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -138,6 +151,171 @@ func (s *CatalogSuite) TestOldPlugin() {
 	s.Require().Equal("plugin(OLD)", resp.Out)
 }
 
+func (s *CatalogSuite) TestExternalPluginVerification() {
+	// a binary that does not match the checksum is not launched
+	_, err := catalog.LoadExternalPlugin(context.Background(), catalog.ExternalPlugin{
+		Log:      s.log,
+		Name:     "testext",
+		Path:     s.path,
+		Checksum: strings.Repeat("00", sha256.Size),
+		Plugin:   catalogtest.PluginPluginClient,
+	})
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "plugin binary checksum mismatch")
+
+	// a binary with a valid detached signature is launched
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	binary, err := ioutil.ReadFile(s.path)
+	s.Require().NoError(err)
+	digest := sha256.Sum256(binary)
+	signature, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	s.Require().NoError(err)
+	publicKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	s.Require().NoError(err)
+
+	signaturePath := filepath.Join(s.dir, "pluginbin.sig")
+	publicKeyPath := filepath.Join(s.dir, "pluginbin.pem")
+	s.Require().NoError(ioutil.WriteFile(signaturePath, signature, 0600))
+	s.Require().NoError(ioutil.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0600))
+
+	plugin, err := catalog.LoadExternalPlugin(context.Background(), catalog.ExternalPlugin{
+		Log:           s.log,
+		Name:          "testext",
+		Path:          s.path,
+		SignaturePath: signaturePath,
+		PublicKeyPath: publicKeyPath,
+		Plugin:        catalogtest.PluginPluginClient,
+	})
+	s.Require().NoError(err)
+	plugin.Close()
+
+	// a binary with a signature that does not match is not launched
+	s.Require().NoError(ioutil.WriteFile(signaturePath, []byte("bad signature"), 0600))
+	_, err = catalog.LoadExternalPlugin(context.Background(), catalog.ExternalPlugin{
+		Log:           s.log,
+		Name:          "testext",
+		Path:          s.path,
+		SignaturePath: signaturePath,
+		PublicKeyPath: publicKeyPath,
+		Plugin:        catalogtest.PluginPluginClient,
+	})
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "plugin binary signature verification failed")
+}
+
+func (s *CatalogSuite) TestExternalPluginRestartWhenExited() {
+	s.testExternalPluginRestart("exited", func(process *os.Process) error {
+		return process.Kill()
+	})
+}
+
+func (s *CatalogSuite) TestExternalPluginRestartWhenUnresponsive() {
+	s.testExternalPluginRestart("unresponsive", func(process *os.Process) error {
+		return process.Signal(syscall.SIGSTOP)
+	})
+}
+
+func (s *CatalogSuite) testExternalPluginRestart(reason string, disrupt func(*os.Process) error) {
+	require := s.Require()
+
+	// the PID of the plugin process is logged at debug level
+	s.log.(*logrus.Logger).SetLevel(logrus.DebugLevel)
+	metrics := fakemetrics.New()
+
+	plugin, err := catalog.LoadExternalPlugin(context.Background(), catalog.ExternalPlugin{
+		Log:           s.log,
+		Name:          "testext",
+		Path:          s.path,
+		Checksum:      s.checksum,
+		Plugin:        catalogtest.PluginPluginClient,
+		KnownServices: s.knownServices,
+		HostServices:  s.hostServices,
+		Metrics:       metrics,
+		Monitor: catalog.PluginMonitorConfig{
+			PingInterval: 50 * time.Millisecond,
+			PingTimeout:  100 * time.Millisecond,
+		},
+	})
+	require.NoError(err)
+	defer plugin.Close()
+
+	require.NoError(plugin.Configure(context.Background(), &spi.ConfigureRequest{
+		GlobalConfig:  &catalog.GlobalConfig{TrustDomain: "domain.test"},
+		Configuration: "CONFIG",
+	}))
+
+	var v catalogtest.Plugin
+	require.NoError(plugin.Fill(&v))
+	callPlugin := func() (string, error) {
+		resp, err := v.CallPlugin(context.Background(), &catalogtest.Request{
+			In: "hello-to-plugin",
+		})
+		if err != nil {
+			return "", err
+		}
+		return resp.Out, nil
+	}
+	out, err := callPlugin()
+	require.NoError(err)
+	require.Equal("plugin(hostservice[plugin=testext](hello-to-plugin))", out)
+
+	// disrupt the plugin process out from under the catalog
+	pid := s.lastPluginPID()
+	process, err := os.FindProcess(pid)
+	require.NoError(err)
+	require.NoError(disrupt(process))
+
+	// the clients obtained before the disruption work again once the plugin
+	// is restarted, with host services brokered to the new process
+	require.Eventually(func() bool {
+		out, err := callPlugin()
+		return err == nil && out == "plugin(hostservice[plugin=testext](hello-to-plugin))"
+	}, 10*time.Second, 50*time.Millisecond)
+	require.NotEqual(pid, s.lastPluginPID())
+
+	s.assertHasLogEntries([]testLogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Plugin is unhealthy; restarting",
+			Data: logrus.Fields{
+				telemetry.PluginName: "testext",
+				telemetry.PluginType: catalogtest.PluginType,
+				telemetry.Reason:     reason,
+			},
+		},
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Plugin restarted; any state held by the previous plugin process was reset",
+			Data: logrus.Fields{
+				telemetry.PluginName: "testext",
+				telemetry.PluginType: catalogtest.PluginType,
+				telemetry.Reason:     reason,
+			},
+		},
+	})
+
+	// the new process was configured like the previous one
+	configured := 0
+	for _, entry := range s.logHook.AllEntries() {
+		if entry.Message == "Configure called" {
+			configured++
+		}
+	}
+	s.Equal(2, configured)
+
+	s.Contains(metrics.AllMetrics(), fakemetrics.MetricItem{
+		Type: fakemetrics.IncrCounterWithLabelsType,
+		Key:  []string{telemetry.Catalog, telemetry.Plugin, telemetry.Unhealthy},
+		Val:  1,
+		Labels: []telemetry.Label{
+			{Name: telemetry.PluginName, Value: "testext"},
+			{Name: telemetry.PluginType, Value: catalogtest.PluginType},
+			{Name: telemetry.Reason, Value: reason},
+		},
+	})
+}
+
 func (s *CatalogSuite) TestNoKnownPlugin() {
 	s.knownPlugins = nil
 	s.pluginConfig = s.extPluginConfig()
@@ -205,6 +383,39 @@ func (s *CatalogSuite) TestHostServiceAvailable() {
 	s.assertHasLogEntries(extInitLogs("pluginimpl"))
 }
 
+func (s *CatalogSuite) TestBuiltInHealthChecks() {
+	s.pluginConfig = s.builtinConfig()
+	s.builtins = []catalog.Plugin{catalog.MakePlugin("testbuiltin",
+		catalogtest.PluginPluginServer(checkingPlugin{PluginPlugin: test.NewPlugin()}),
+	)}
+	checker := health.NewChecker(health.Config{}, s.log)
+
+	cat, err := catalog.Load(context.Background(), catalog.Config{
+		Log:           s.log,
+		PluginConfig:  s.pluginConfig,
+		KnownPlugins:  s.knownPlugins,
+		KnownServices: s.knownServices,
+		HostServices:  s.hostServices,
+		BuiltIns:      s.builtins,
+		HealthChecks:  checker,
+	})
+	s.Require().NoError(err)
+	defer cat.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = checker.ListenAndServe(ctx)
+	}()
+
+	s.Require().Eventually(func() bool {
+		return len(checker.ReadyReport().Details) == 1
+	}, time.Second, 10*time.Millisecond)
+	state := checker.ReadyReport().Details["testbuiltin.test"]
+	s.Equal("ok", state.Status)
+	s.Equal("checked", state.Details)
+}
+
 func (s *CatalogSuite) TestDisabledPlugin() {
 	s.pluginConfig = s.extPluginConfig()
 	s.pluginConfig[0].Disabled = true
@@ -756,6 +967,20 @@ func (s *CatalogSuite) builtinConfig() []catalog.PluginConfig {
 	}
 }
 
+// lastPluginPID returns the PID of the plugin process started last
+func (s *CatalogSuite) lastPluginPID() int {
+	entries := s.logHook.AllEntries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Message == "plugin started" {
+			pid, ok := entries[i].Data["pid"].(int)
+			s.Require().True(ok, "plugin PID was not logged")
+			return pid
+		}
+	}
+	s.FailNow("plugin was not started")
+	return 0
+}
+
 func (s *CatalogSuite) assertHasLogEntries(entries []testLogEntry) {
 	for _, e := range entries {
 		s.assertHasLogEntry(e)
@@ -781,6 +1006,19 @@ func testLogEntryFromEntry(entry *logrus.Entry) testLogEntry {
 	}
 }
 
+// checkingPlugin is a test plugin contributing a health check
+type checkingPlugin struct {
+	catalogtest.PluginPlugin
+}
+
+func (checkingPlugin) HealthChecks() map[string]health.CheckFunc {
+	return map[string]health.CheckFunc{
+		"test": func() (interface{}, error) {
+			return "checked", nil
+		},
+	}
+}
+
 func testBuiltIn() catalog.Plugin {
 	builtin := testBuiltInNoService()
 	builtin.Services = append(builtin.Services, catalogtest.ServiceServiceServer(test.NewService()))
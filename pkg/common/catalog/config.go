@@ -10,22 +10,26 @@ import (
 )
 
 type PluginConfig struct {
-	Name     string
-	Type     string
-	Path     string
-	Checksum string
-	Data     string
-	Disabled bool
+	Name          string
+	Type          string
+	Path          string
+	Checksum      string
+	SignaturePath string
+	PublicKeyPath string
+	Data          string
+	Disabled      bool
 }
 
 // HCLPluginConfig serves as an intermediary struct. We pass this to the
 // HCL library for parsing, except the parser won't parse pluginData
 // as a string.
 type HCLPluginConfig struct {
-	PluginCmd      string   `hcl:"plugin_cmd"`
-	PluginChecksum string   `hcl:"plugin_checksum"`
-	PluginData     ast.Node `hcl:"plugin_data"`
-	Enabled        *bool    `hcl:"enabled"`
+	PluginCmd       string   `hcl:"plugin_cmd"`
+	PluginChecksum  string   `hcl:"plugin_checksum"`
+	PluginSignature string   `hcl:"plugin_signature"`
+	PluginPublicKey string   `hcl:"plugin_public_key"`
+	PluginData      ast.Node `hcl:"plugin_data"`
+	Enabled         *bool    `hcl:"enabled"`
 }
 
 func (c HCLPluginConfig) IsEnabled() bool {
@@ -61,17 +65,26 @@ func PluginConfigsFromHCL(hclPlugins HCLPluginConfigMap) ([]PluginConfig, error)
 }
 
 func PluginConfigFromHCL(pluginType, pluginName string, hclPluginConfig HCLPluginConfig) (PluginConfig, error) {
+	if (hclPluginConfig.PluginSignature == "") != (hclPluginConfig.PluginPublicKey == "") {
+		return PluginConfig{}, errs.New("%s plugin %q: plugin_signature and plugin_public_key must be configured together", pluginType, pluginName)
+	}
+	if hclPluginConfig.PluginCmd == "" && (hclPluginConfig.PluginChecksum != "" || hclPluginConfig.PluginSignature != "") {
+		return PluginConfig{}, errs.New("%s plugin %q: plugin_checksum and plugin_signature require plugin_cmd", pluginType, pluginName)
+	}
+
 	var data bytes.Buffer
 	if err := printer.DefaultConfig.Fprint(&data, hclPluginConfig.PluginData); err != nil {
 		return PluginConfig{}, err
 	}
 
 	return PluginConfig{
-		Name:     pluginName,
-		Type:     pluginType,
-		Path:     hclPluginConfig.PluginCmd,
-		Checksum: hclPluginConfig.PluginChecksum,
-		Data:     data.String(),
-		Disabled: !hclPluginConfig.IsEnabled(),
+		Name:          pluginName,
+		Type:          pluginType,
+		Path:          hclPluginConfig.PluginCmd,
+		Checksum:      hclPluginConfig.PluginChecksum,
+		SignaturePath: hclPluginConfig.PluginSignature,
+		PublicKeyPath: hclPluginConfig.PluginPublicKey,
+		Data:          data.String(),
+		Disabled:      !hclPluginConfig.IsEnabled(),
 	}, nil
 }
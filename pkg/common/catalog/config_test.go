@@ -28,6 +28,12 @@ func TestParsePluginConfigsFromHCLSuccess(t *testing.T) {
 		plugin_data = "DATA3"
 		enabled = false
 	}
+	TYPE4 "NAME4" {
+		plugin_cmd = "CMD4"
+		plugin_signature = "SIGNATURE4"
+		plugin_public_key = "PUBLICKEY4"
+		plugin_data = "DATA4"
+	}
 `)
 	require.NoError(t, err)
 
@@ -54,9 +60,34 @@ func TestParsePluginConfigsFromHCLSuccess(t *testing.T) {
 			Data:     `"DATA3"`,
 			Disabled: true,
 		},
+		{
+			Name:          "NAME4",
+			Type:          "TYPE4",
+			Path:          "CMD4",
+			SignaturePath: "SIGNATURE4",
+			PublicKeyPath: "PUBLICKEY4",
+			Data:          `"DATA4"`,
+		},
 	}, config)
 }
 
+func TestParsePluginConfigsFromHCLVerificationFailure(t *testing.T) {
+	_, err := ParsePluginConfigsFromHCL(`
+	TYPE "NAME" {
+		plugin_cmd = "CMD"
+		plugin_signature = "SIGNATURE"
+	}
+`)
+	require.EqualError(t, err, `TYPE plugin "NAME": plugin_signature and plugin_public_key must be configured together`)
+
+	_, err = ParsePluginConfigsFromHCL(`
+	TYPE "NAME" {
+		plugin_checksum = "CHECKSUM"
+	}
+`)
+	require.EqualError(t, err, `TYPE plugin "NAME": plugin_checksum and plugin_signature require plugin_cmd`)
+}
+
 func sortPluginConfig(c []PluginConfig) {
 	sort.Slice(c, func(i, j int) bool {
 		a := c[i]
@@ -2,10 +2,7 @@ package catalog
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"errors"
-	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
@@ -83,10 +80,18 @@ type ExternalPlugin struct {
 	Name          string
 	Path          string
 	Checksum      string
+	SignaturePath string
+	PublicKeyPath string
 	Data          string
 	Plugin        PluginClient
 	KnownServices []ServiceClient
 	HostServices  []HostServiceServer
+
+	// Metrics, if set, receives the metrics about the health of the plugin
+	Metrics telemetry.Metrics
+
+	// Monitor configures the health monitoring of the plugin process
+	Monitor PluginMonitorConfig
 }
 
 func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *LoadedPlugin, err error) {
@@ -96,16 +101,31 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 		return nil, errs.Wrap(err)
 	}
 
+	if ext.Checksum == "" && ext.SignaturePath == "" {
+		ext.Log.Warn("Plugin checksum not configured")
+	}
+
+	plugin, pluginClient, err := startExternalPlugin(ext)
+	if err != nil {
+		return nil, err
+	}
+	if ext.Monitor.Disabled {
+		return plugin, nil
+	}
+	return superviseExternalPlugin(ctx, ext, plugin, pluginClient)
+}
+
+// startExternalPlugin verifies and launches the plugin binary. It returns
+// the plugin, with clients connected to the new process, along with the
+// client that manages the process.
+func startExternalPlugin(ext ExternalPlugin) (plugin *LoadedPlugin, _ *goplugin.Client, err error) {
 	cmd := pluginCmd(ext.Path)
 
-	var secureConfig *goplugin.SecureConfig
-	if ext.Checksum != "" {
-		secureConfig, err = buildSecureConfig(ext.Checksum)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		ext.Log.Warn("Plugin checksum not configured")
+	// go-plugin checks the digest of the verified binary again right before
+	// launching it, so a binary replaced after verification is not launched.
+	secureConfig, err := verifyPluginBinary(ext)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	logger := log.NewHCLogAdapter(
@@ -144,19 +164,19 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 	// create the GRPC client and ensure it is closed on error
 	grpcClient, err := pluginClient.Client()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// the primary interface is dispensed via the plugin name
 	pluginRaw, err := grpcClient.Dispense("external")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	plugin, ok := pluginRaw.(*LoadedPlugin)
 	if !ok {
 		// shouldn't happen.
-		return nil, errs.New("expected %T, got %T", plugin, pluginRaw)
+		return nil, nil, errs.New("expected %T, got %T", plugin, pluginRaw)
 	}
 
 	// Kill also closes the gRPC client
@@ -165,19 +185,7 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 		hcPlugin.WaitUntilBrokerDone()
 	}
 
-	return plugin, nil
-}
-
-func buildSecureConfig(checksum string) (*goplugin.SecureConfig, error) {
-	sum, err := hex.DecodeString(checksum)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode checksum: %v", err)
-	}
-
-	return &goplugin.SecureConfig{
-		Checksum: sum,
-		Hash:     sha256.New(),
-	}, nil
+	return plugin, pluginClient, nil
 }
 
 type hcClientPlugin struct {
@@ -75,7 +75,9 @@ func newCatalogPlugin(ctx context.Context, c *grpc.ClientConn, config catalogPlu
 	}, nil
 }
 
-func initPluginServer(s *grpc.Server, dialer hostDialer, logger hclog.Logger, plugin PluginServer, services []ServiceServer) {
+// initPluginServer registers the plugin and its services with the gRPC server
+// and returns their implementations.
+func initPluginServer(s *grpc.Server, dialer hostDialer, logger hclog.Logger, plugin PluginServer, services []ServiceServer) []interface{} {
 	var impls []interface{}
 	var pluginServices []string
 	impls = append(impls, plugin.RegisterPluginServer(s))
@@ -89,6 +91,7 @@ func initPluginServer(s *grpc.Server, dialer hostDialer, logger hclog.Logger, pl
 		impls:          impls,
 		pluginServices: pluginServices,
 	})
+	return impls
 }
 
 type initServer struct {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/spiffe/spire/pkg/common/health"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
 )
@@ -15,6 +16,14 @@ type LoadedPlugin struct {
 	all          []interface{}
 	serviceNames []string
 
+	// checkProviders are the implementations of a built-in plugin that
+	// contribute health checks
+	checkProviders []health.CheckProvider
+
+	// supervisor, if set, restarts the external plugin process when it
+	// exits or stops responding
+	supervisor *supervisor
+
 	closeOnce sync.Once
 	closer    func()
 }
@@ -39,6 +48,9 @@ func (p *LoadedPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest)
 	if err != nil {
 		return errs.Wrap(err)
 	}
+	if p.supervisor != nil {
+		p.supervisor.setConfigureRequest(req)
+	}
 	return nil
 }
 
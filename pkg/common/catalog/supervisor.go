@@ -0,0 +1,376 @@
+package catalog
+
+import (
+	"context"
+	"math"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/cenkalti/backoff/v3"
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	defaultPluginPingInterval      = 10 * time.Second
+	defaultPluginPingTimeout       = 5 * time.Second
+	defaultPluginMaxPingFailures   = 3
+	defaultPluginMaxRestartBackoff = time.Minute
+	pluginRestartInitialBackoff    = time.Second
+
+	// the reasons an external plugin is restarted
+	pluginExited       = "exited"
+	pluginUnresponsive = "unresponsive"
+)
+
+// PluginMonitorConfig configures the health monitoring of external plugin
+// processes. Zero values select the defaults.
+type PluginMonitorConfig struct {
+	// Disabled disables the monitoring. A plugin process that exits or
+	// stops responding is then not restarted.
+	Disabled bool
+
+	// PingInterval is how often the plugin process is pinged. Defaults to
+	// 10 seconds.
+	PingInterval time.Duration
+
+	// PingTimeout is how long to wait for the plugin process to answer a
+	// ping. Defaults to 5 seconds.
+	PingTimeout time.Duration
+
+	// MaxPingFailures is how many consecutive pings can fail before the
+	// plugin process is considered hung and restarted. Defaults to 3.
+	MaxPingFailures int
+
+	// MaxRestartBackoff is the upper bound of the interval between failed
+	// attempts to restart the plugin process. Defaults to 1 minute.
+	MaxRestartBackoff time.Duration
+}
+
+// supervisor keeps an external plugin process running. The plugin clients
+// handed to the catalog are bound to a connection that dials whichever
+// process is current, so they survive the process being restarted after it
+// exits or stops answering pings.
+type supervisor struct {
+	ext     ExternalPlugin
+	log     logrus.FieldLogger
+	metrics telemetry.Metrics
+	config  PluginMonitorConfig
+	clk     clock.Clock
+	conn    *grpc.ClientConn
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	addr      net.Addr
+	configReq *spi.ConfigureRequest
+
+	// only accessed by the monitoring goroutine, or once it has stopped
+	current      *LoadedPlugin
+	pluginClient *goplugin.Client
+}
+
+func superviseExternalPlugin(ctx context.Context, ext ExternalPlugin, current *LoadedPlugin, pluginClient *goplugin.Client) (_ *LoadedPlugin, err error) {
+	defer func() {
+		if err != nil {
+			current.Close()
+		}
+	}()
+
+	addr, err := pluginClient.Start()
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	servicesMap, err := makeServicesMap(ext.KnownServices)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &supervisor{
+		ext: ext,
+		log: ext.Log.WithFields(logrus.Fields{
+			telemetry.PluginName: ext.Name,
+			telemetry.PluginType: ext.Plugin.PluginType(),
+		}),
+		metrics:      ext.Metrics,
+		config:       withPluginMonitorDefaults(ext.Monitor),
+		clk:          clock.New(),
+		addr:         addr,
+		current:      current,
+		pluginClient: pluginClient,
+	}
+	if s.metrics == nil {
+		s.metrics = telemetry.Blackhole{}
+	}
+
+	// The connection is established lazily, and reestablished against the
+	// new process after a restart.
+	s.conn, err = grpc.DialContext(ctx, ext.Name,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(s.dial),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(math.MaxInt32),
+			grpc.MaxCallSendMsgSize(math.MaxInt32),
+		),
+	)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	pluginImpl := ext.Plugin.NewPluginClient(s.conn)
+	all := []interface{}{pluginImpl}
+	for _, serviceName := range current.serviceNames {
+		all = append(all, servicesMap[serviceName].NewServiceClient(s.conn))
+	}
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run()
+	}()
+
+	return &LoadedPlugin{
+		name:         current.name,
+		builtIn:      false,
+		plugin:       pluginImpl,
+		all:          all,
+		serviceNames: current.serviceNames,
+		supervisor:   s,
+		closer:       s.close,
+	}, nil
+}
+
+func withPluginMonitorDefaults(config PluginMonitorConfig) PluginMonitorConfig {
+	if config.PingInterval <= 0 {
+		config.PingInterval = defaultPluginPingInterval
+	}
+	if config.PingTimeout <= 0 {
+		config.PingTimeout = defaultPluginPingTimeout
+	}
+	if config.MaxPingFailures <= 0 {
+		config.MaxPingFailures = defaultPluginMaxPingFailures
+	}
+	if config.MaxRestartBackoff <= 0 {
+		config.MaxRestartBackoff = defaultPluginMaxRestartBackoff
+	}
+	return config
+}
+
+// setConfigureRequest records the request that successfully configured the
+// plugin, so it can be replayed against a restarted process.
+func (s *supervisor) setConfigureRequest(req *spi.ConfigureRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configReq = req
+}
+
+func (s *supervisor) getConfigureRequest() *spi.ConfigureRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.configReq
+}
+
+func (s *supervisor) setAddr(addr net.Addr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addr = addr
+}
+
+func (s *supervisor) dial(ctx context.Context, _ string) (net.Conn, error) {
+	s.mu.Lock()
+	addr := s.addr
+	s.mu.Unlock()
+	if addr == nil {
+		return nil, errs.New("plugin %q is not running", s.ext.Name)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, addr.Network(), addr.String())
+}
+
+func (s *supervisor) run() {
+	ticker := s.clk.Ticker(s.config.PingInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.ctx.Done():
+			return
+		}
+
+		if s.pluginClient.Exited() {
+			failures = 0
+			s.restart(pluginExited)
+			continue
+		}
+
+		if err := s.ping(); err != nil {
+			failures++
+			s.log.WithError(err).WithField(telemetry.Count, failures).Warn("Plugin failed to answer ping")
+			if failures < s.config.MaxPingFailures {
+				continue
+			}
+			failures = 0
+			s.restart(pluginUnresponsive)
+			continue
+		}
+		failures = 0
+	}
+}
+
+func (s *supervisor) ping() error {
+	ctx, cancel := context.WithTimeout(s.ctx, s.config.PingTimeout)
+	defer cancel()
+
+	// wait for the connection to the plugin to be (re)established, within
+	// the ping timeout, instead of failing fast
+	resp, err := grpc_health_v1.NewHealthClient(s.conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{
+		Service: goplugin.GRPCServiceName,
+	}, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return errs.New("plugin is %s", resp.Status)
+	}
+	return nil
+}
+
+// restart kills the current plugin process and starts a new one, retrying
+// with backoff until it succeeds or the supervisor is closed.
+//
+// s.current is nil from the moment the previous plugin is closed until a new
+// one is fully started and configured. If the supervisor is closed in the
+// meantime, restart returns with s.current still nil, so close, which checks
+// s.current after waiting for the monitoring goroutine, never closes a plugin
+// twice nor leaks one started after it was called.
+func (s *supervisor) restart(reason string) {
+	log := s.log.WithField(telemetry.Reason, reason)
+	log.Warn("Plugin is unhealthy; restarting")
+	s.metrics.IncrCounterWithLabels([]string{telemetry.Catalog, telemetry.Plugin, telemetry.Unhealthy}, 1, []telemetry.Label{
+		{Name: telemetry.PluginName, Value: s.ext.Name},
+		{Name: telemetry.PluginType, Value: s.ext.Plugin.PluginType()},
+		{Name: telemetry.Reason, Value: reason},
+	})
+
+	s.setAddr(nil)
+	s.killProcess()
+	s.current.Close()
+	s.current = nil
+
+	b := &backoff.ExponentialBackOff{
+		Clock:               s.clk,
+		InitialInterval:     pluginRestartInitialBackoff,
+		RandomizationFactor: backoff.DefaultRandomizationFactor,
+		Multiplier:          backoff.DefaultMultiplier,
+		MaxInterval:         s.config.MaxRestartBackoff,
+		MaxElapsedTime:      0,
+	}
+	b.Reset()
+
+	for {
+		if s.ctx.Err() != nil {
+			return
+		}
+		err := s.tryRestart()
+		if err == nil {
+			log.Warn("Plugin restarted; any state held by the previous plugin process was reset")
+			return
+		}
+		if s.ctx.Err() != nil {
+			return
+		}
+		log.WithError(err).Error("Failed to restart plugin")
+
+		select {
+		case <-s.clk.After(b.NextBackOff()):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// killProcess kills the current plugin process outright. Closing the plugin
+// asks the process to shut down gracefully first, which never completes if
+// the process is hung.
+func (s *supervisor) killProcess() {
+	reattach := s.pluginClient.ReattachConfig()
+	if reattach == nil || reattach.Pid == 0 {
+		return
+	}
+	process, err := os.FindProcess(reattach.Pid)
+	if err != nil {
+		return
+	}
+	if err := process.Kill(); err != nil && !s.pluginClient.Exited() {
+		s.log.WithError(err).Warn("Failed to kill plugin process")
+	}
+}
+
+func (s *supervisor) tryRestart() (err error) {
+	counter := telemetry.StartCall(s.metrics, telemetry.Catalog, telemetry.Plugin, telemetry.Restart)
+	counter.AddLabel(telemetry.PluginName, s.ext.Name)
+	counter.AddLabel(telemetry.PluginType, s.ext.Plugin.PluginType())
+	defer counter.Done(&err)
+
+	plugin, pluginClient, err := startExternalPlugin(s.ext)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			plugin.Close()
+		}
+	}()
+
+	addr, err := pluginClient.Start()
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	// Configure the new process the same way the catalog configured the
+	// previous one. The request is sent directly to the new process since the
+	// supervised connection does not dial it until it becomes current.
+	if req := s.getConfigureRequest(); req != nil {
+		if err := plugin.Configure(s.ctx, req); err != nil {
+			return errs.New("unable to configure plugin: %v", err)
+		}
+	}
+
+	// The supervisor was closed while the plugin was starting. The plugin is
+	// closed here rather than installed, since close may already be past its
+	// check of s.current.
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	s.current = plugin
+	s.pluginClient = pluginClient
+	s.setAddr(addr)
+
+	// skip any reconnection backoff accrued while the plugin was down
+	s.conn.ResetConnectBackoff()
+	return nil
+}
+
+func (s *supervisor) close() {
+	s.cancel()
+	s.wg.Wait()
+	if s.current != nil {
+		s.current.Close()
+	}
+	s.conn.Close()
+}
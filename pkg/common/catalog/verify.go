@@ -0,0 +1,112 @@
+package catalog
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/zeebo/errs"
+)
+
+// verifyPluginBinary verifies the plugin binary against the configured
+// checksum and detached signature, if any, so that a binary that has been
+// tampered with is never launched. Plugins run with the privileges of the
+// process that launches them.
+//
+// The returned config pins the digest of the verified binary. It must be
+// used to launch the plugin so the binary cannot be swapped between
+// verification and launch. It is nil if there is nothing to verify.
+func verifyPluginBinary(ext ExternalPlugin) (*goplugin.SecureConfig, error) {
+	if ext.Checksum == "" && ext.SignaturePath == "" {
+		return nil, nil
+	}
+
+	binary, err := ioutil.ReadFile(ext.Path)
+	if err != nil {
+		return nil, errs.New("unable to read plugin binary: %v", err)
+	}
+	digest := sha256.Sum256(binary)
+
+	if ext.Checksum != "" {
+		checksum, err := decodeChecksum(ext.Checksum)
+		if err != nil {
+			return nil, err
+		}
+		if subtle.ConstantTimeCompare(checksum, digest[:]) != 1 {
+			return nil, errs.New("plugin binary checksum mismatch: expected %s, got %x", ext.Checksum, digest)
+		}
+	}
+
+	if ext.SignaturePath != "" {
+		publicKey, err := pemutil.LoadPublicKey(ext.PublicKeyPath)
+		if err != nil {
+			return nil, errs.New("unable to load plugin public key: %v", err)
+		}
+		signature, err := loadSignature(ext.SignaturePath)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifySignature(publicKey, binary, digest[:], signature); err != nil {
+			return nil, errs.New("plugin binary signature verification failed: %v", err)
+		}
+	}
+
+	return &goplugin.SecureConfig{
+		Checksum: digest[:],
+		Hash:     sha256.New(),
+	}, nil
+}
+
+func decodeChecksum(checksum string) ([]byte, error) {
+	sum, err := hex.DecodeString(checksum)
+	if err != nil {
+		return nil, errs.New("unable to decode checksum: %v", err)
+	}
+	if len(sum) != sha256.Size {
+		return nil, errs.New("checksum must be a hex encoded SHA-256 digest")
+	}
+	return sum, nil
+}
+
+// loadSignature loads a detached signature, either raw (e.g. as produced by
+// `openssl dgst -sha256 -sign`) or base64 encoded.
+func loadSignature(path string) ([]byte, error) {
+	signature, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errs.New("unable to read plugin signature: %v", err)
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature))); err == nil {
+		return decoded, nil
+	}
+	return signature, nil
+}
+
+// verifySignature verifies an RSA PKCS #1 v1.5 or ECDSA signature over the
+// SHA-256 digest of the binary, or an Ed25519 signature over the binary.
+func verifySignature(publicKey crypto.PublicKey, binary, digest, signature []byte) error {
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(publicKey, digest, signature) {
+			return errs.New("invalid ECDSA signature")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(publicKey, binary, signature) {
+			return errs.New("invalid Ed25519 signature")
+		}
+		return nil
+	default:
+		return errs.New("unsupported public key type %T", publicKey)
+	}
+}
@@ -0,0 +1,214 @@
+package catalog
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPluginBinary(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catalog-verify-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	binary := []byte("PLUGIN")
+	digest := sha256.Sum256(binary)
+	checksum := hex.EncodeToString(digest[:])
+
+	path := filepath.Join(dir, "plugin")
+	writeFile(t, path, binary)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecSignature, err := ecKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	ecPublicKey := writePublicKey(t, filepath.Join(dir, "ec.pem"), ecKey.Public())
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024) //nolint: gosec // small key for test speed
+	require.NoError(t, err)
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	rsaPublicKey := writePublicKey(t, filepath.Join(dir, "rsa.pem"), rsaKey.Public())
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edSignature := ed25519.Sign(edPrivate, binary)
+	edPublicKey := writePublicKey(t, filepath.Join(dir, "ed25519.pem"), edPublic)
+
+	writeFile(t, filepath.Join(dir, "ec.sig"), ecSignature)
+	writeFile(t, filepath.Join(dir, "ec.sig.b64"), []byte(base64.StdEncoding.EncodeToString(ecSignature)+"\n"))
+	writeFile(t, filepath.Join(dir, "rsa.sig"), rsaSignature)
+	writeFile(t, filepath.Join(dir, "ed25519.sig"), edSignature)
+
+	for _, tt := range []struct {
+		name          string
+		path          string
+		checksum      string
+		signaturePath string
+		publicKeyPath string
+		err           string
+	}{
+		{
+			name: "nothing to verify",
+			path: filepath.Join(dir, "missing"),
+		},
+		{
+			name:     "checksum matches",
+			path:     path,
+			checksum: checksum,
+		},
+		{
+			name:     "checksum mismatch",
+			path:     path,
+			checksum: hex.EncodeToString(make([]byte, sha256.Size)),
+			err:      "plugin binary checksum mismatch: expected " + hex.EncodeToString(make([]byte, sha256.Size)) + ", got " + checksum,
+		},
+		{
+			name:     "malformed checksum",
+			path:     path,
+			checksum: "nothex",
+			err:      "unable to decode checksum: encoding/hex: invalid byte: U+006E 'n'",
+		},
+		{
+			name:     "short checksum",
+			path:     path,
+			checksum: "abcd",
+			err:      "checksum must be a hex encoded SHA-256 digest",
+		},
+		{
+			name:     "missing binary",
+			path:     filepath.Join(dir, "missing"),
+			checksum: checksum,
+			err:      "unable to read plugin binary",
+		},
+		{
+			name:          "ECDSA signature",
+			path:          path,
+			checksum:      checksum,
+			signaturePath: filepath.Join(dir, "ec.sig"),
+			publicKeyPath: ecPublicKey,
+		},
+		{
+			name:          "base64 encoded signature",
+			path:          path,
+			signaturePath: filepath.Join(dir, "ec.sig.b64"),
+			publicKeyPath: ecPublicKey,
+		},
+		{
+			name:          "RSA signature",
+			path:          path,
+			signaturePath: filepath.Join(dir, "rsa.sig"),
+			publicKeyPath: rsaPublicKey,
+		},
+		{
+			name:          "Ed25519 signature",
+			path:          path,
+			signaturePath: filepath.Join(dir, "ed25519.sig"),
+			publicKeyPath: edPublicKey,
+		},
+		{
+			name:          "signature from another key",
+			path:          path,
+			signaturePath: filepath.Join(dir, "rsa.sig"),
+			publicKeyPath: ecPublicKey,
+			err:           "plugin binary signature verification failed: invalid ECDSA signature",
+		},
+		{
+			name:          "missing signature",
+			path:          path,
+			signaturePath: filepath.Join(dir, "missing.sig"),
+			publicKeyPath: ecPublicKey,
+			err:           "unable to read plugin signature",
+		},
+		{
+			name:          "missing public key",
+			path:          path,
+			signaturePath: filepath.Join(dir, "ec.sig"),
+			publicKeyPath: filepath.Join(dir, "missing.pem"),
+			err:           "unable to load plugin public key",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			secureConfig, err := verifyPluginBinary(ExternalPlugin{
+				Path:          tt.path,
+				Checksum:      tt.checksum,
+				SignaturePath: tt.signaturePath,
+				PublicKeyPath: tt.publicKeyPath,
+			})
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				require.Nil(t, secureConfig)
+				return
+			}
+			require.NoError(t, err)
+			if tt.checksum == "" && tt.signaturePath == "" {
+				require.Nil(t, secureConfig)
+				return
+			}
+			require.Equal(t, digest[:], secureConfig.Checksum)
+		})
+	}
+}
+
+func TestVerifyPluginBinaryPinsVerifiedDigest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catalog-verify-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	binary := []byte("PLUGIN")
+	digest := sha256.Sum256(binary)
+	path := filepath.Join(dir, "plugin")
+	writeFile(t, path, binary)
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signaturePath := filepath.Join(dir, "plugin.sig")
+	writeFile(t, signaturePath, ed25519.Sign(edPrivate, binary))
+
+	// only a signature is configured, yet the launch is still pinned to the
+	// digest of the binary that was verified
+	secureConfig, err := verifyPluginBinary(ExternalPlugin{
+		Path:          path,
+		SignaturePath: signaturePath,
+		PublicKeyPath: writePublicKey(t, filepath.Join(dir, "ed25519.pem"), edPublic),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, secureConfig)
+
+	ok, err := secureConfig.Check(path)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// go-plugin refuses to launch a binary swapped after verification
+	writeFile(t, path, []byte("EVIL"))
+	ok, err = secureConfig.Check(path)
+	require.NoError(t, err)
+	require.False(t, ok, "swapped binary %x passed the check for %x", sha256.Sum256([]byte("EVIL")), digest)
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+}
+
+func writePublicKey(t *testing.T, path string, publicKey crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	writeFile(t, path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	return path
+}
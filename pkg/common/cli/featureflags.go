@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spiffe/spire/pkg/common/fflag"
+)
+
+// FeatureFlagsCommand lists the feature flags known to a SPIRE component.
+type FeatureFlagsCommand struct {
+	env   *Env
+	scope fflag.Scope
+	name  string
+}
+
+// NewFeatureFlagsCommand returns a command that lists the feature flags that
+// can be enabled for the given scope.
+func NewFeatureFlagsCommand(env *Env, scope fflag.Scope, name string) *FeatureFlagsCommand {
+	return &FeatureFlagsCommand{
+		env:   env,
+		scope: scope,
+		name:  name,
+	}
+}
+
+func (c *FeatureFlagsCommand) Help() string {
+	return fmt.Sprintf("Usage: %s feature-flags\n\n%s", c.name, c.Synopsis())
+}
+
+func (c *FeatureFlagsCommand) Synopsis() string {
+	return "Lists the experimental feature flags that can be enabled via the feature_flags configurable"
+}
+
+func (c *FeatureFlagsCommand) Run(args []string) int {
+	flags := flag.NewFlagSet("feature-flags", flag.ContinueOnError)
+	flags.SetOutput(c.env.Stderr)
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	known := fflag.Known(c.scope)
+	if len(known) == 0 {
+		_ = c.env.Println("No feature flags available")
+		return 0
+	}
+
+	w := tabwriter.NewWriter(c.env.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FLAG\tDESCRIPTION")
+	for _, info := range known {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", info.Flag, info.Description)
+	}
+	if err := w.Flush(); err != nil {
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+	return 0
+}
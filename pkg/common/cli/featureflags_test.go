@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagsCommand(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := NewFeatureFlagsCommand(&Env{
+		Stdout: stdout,
+		Stderr: stderr,
+	}, fflag.ScopeServer, "spire-server")
+
+	require.Equal(t, 0, cmd.Run(nil))
+	require.Empty(t, stderr.String())
+	require.Contains(t, stdout.String(), "FLAG")
+	for _, info := range fflag.Known(fflag.ScopeServer) {
+		require.Contains(t, stdout.String(), string(info.Flag))
+		require.Contains(t, stdout.String(), info.Description)
+	}
+
+	require.Equal(t, 1, cmd.Run([]string{"-bogus"}))
+}
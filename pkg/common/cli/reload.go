@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/hcl/token"
+)
+
+var posType = reflect.TypeOf(token.Pos{})
+
+// ChangedConfigKeys returns the HCL keys of the fields that differ between
+// two configurations of the same struct type, sorted. The keys of the blocks
+// listed in expand (e.g. "server" or "server.federation") are compared field
+// by field and reported with a dotted path, instead of as a whole. The
+// configurations must have the same type.
+func ChangedConfigKeys(old, new interface{}, expand ...string) []string {
+	expanded := make(map[string]bool, len(expand))
+	for _, key := range expand {
+		expanded[key] = true
+	}
+
+	var keys []string
+	changedConfigKeys(reflect.ValueOf(old), reflect.ValueOf(new), "", expanded, &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+func changedConfigKeys(old, new reflect.Value, prefix string, expanded map[string]bool, keys *[]string) {
+	old, new = indirect(old), indirect(new)
+	if !old.IsValid() || !new.IsValid() {
+		if old.IsValid() != new.IsValid() && prefix != "" {
+			*keys = append(*keys, strings.TrimSuffix(prefix, "."))
+		}
+		return
+	}
+
+	t := old.Type()
+	for i := 0; i < t.NumField(); i++ {
+		oldField, newField := old.Field(i), new.Field(i)
+
+		field := t.Field(i)
+		if field.Anonymous && field.Tag.Get("hcl") == "" && indirect(oldField).Kind() == reflect.Struct {
+			// The keys of embedded structs are decoded at the same level
+			changedConfigKeys(oldField, newField, prefix, expanded, keys)
+			continue
+		}
+
+		name := hclKey(field)
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+
+		if expanded[key] && indirect(oldField).Kind() == reflect.Struct && indirect(newField).Kind() == reflect.Struct {
+			changedConfigKeys(oldField, newField, key+".", expanded, keys)
+			continue
+		}
+		if !configEqual(oldField, newField) {
+			*keys = append(*keys, key)
+		}
+	}
+}
+
+// hclKey returns the HCL key of a struct field, or an empty string if the
+// field is not decoded from a key.
+func hclKey(field reflect.StructField) string {
+	tag := field.Tag.Get("hcl")
+	name := strings.Split(tag, ",")[0]
+	if name == "" || field.PkgPath != "" {
+		return ""
+	}
+	return name
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// configEqual is like reflect.DeepEqual, except that it ignores the
+// positions of HCL nodes (e.g. the plugin data), which change whenever
+// lines are added to or removed from the configuration file.
+func configEqual(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return configEqual(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if a.Type() == posType {
+			return true
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if !configEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !configEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			if !b.MapIndex(key).IsValid() || !configEqual(a.MapIndex(key), b.MapIndex(key)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.String:
+		return a.String() == b.String()
+	default:
+		return a.CanInterface() && b.CanInterface() && reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Server     *testServerConfig `hcl:"server"`
+	Telemetry  map[string]string `hcl:"telemetry"`
+	UnusedKeys []string          `hcl:",unusedKeys"`
+}
+
+type testServerConfig struct {
+	LogLevel   string                `hcl:"log_level"`
+	Federation *testFederationConfig `hcl:"federation"`
+}
+
+type testFederationConfig struct {
+	FederatesWith map[string]string `hcl:"federates_with"`
+}
+
+func TestChangedConfigKeys(t *testing.T) {
+	parse := func(config string) *testConfig {
+		c := new(testConfig)
+		require.NoError(t, hcl.Decode(c, config))
+		return c
+	}
+
+	config := parse(`
+server {
+	log_level = "INFO"
+	federation {
+		federates_with = { "domain.test" = "a" }
+	}
+}
+telemetry = { a = "b" }
+`)
+
+	require.Empty(t, ChangedConfigKeys(config, config, "server"))
+
+	changed := parse(`
+server {
+	log_level = "DEBUG"
+	federation {
+		federates_with = { "domain.test" = "b" }
+	}
+}
+telemetry = { a = "c" }
+`)
+
+	require.Equal(t, []string{"server.federation", "server.log_level", "telemetry"},
+		ChangedConfigKeys(config, changed, "server"))
+	require.Equal(t, []string{"server.federation.federates_with", "server.log_level", "telemetry"},
+		ChangedConfigKeys(config, changed, "server", "server.federation"))
+	require.Equal(t, []string{"server", "telemetry"}, ChangedConfigKeys(config, changed))
+
+	// Adding or removing a block reports the whole block
+	require.Equal(t, []string{"server.federation"},
+		ChangedConfigKeys(config, parse(`
+server {
+	log_level = "INFO"
+}
+telemetry = { a = "b" }
+`), "server", "server.federation"))
+}
+
+func TestChangedConfigKeysIgnoresPositions(t *testing.T) {
+	type pluginConfig struct {
+		Plugins ast.Node `hcl:"plugins"`
+	}
+
+	var config, moved pluginConfig
+	require.NoError(t, hcl.Decode(&config, `plugins { a { b = "c" } }`))
+	require.NoError(t, hcl.Decode(&moved, "\n\n\nplugins {\n\ta {\n\t\tb = \"c\"\n\t}\n}"))
+	require.Empty(t, ChangedConfigKeys(config, moved))
+
+	var changed pluginConfig
+	require.NoError(t, hcl.Decode(&changed, `plugins { a { b = "d" } }`))
+	require.Equal(t, []string{"plugins"}, ChangedConfigKeys(config, changed))
+}
+
+func TestChangedConfigKeysEmbedded(t *testing.T) {
+	type commonConfig struct {
+		LogLevel string `hcl:"log_level"`
+	}
+	type modeConfig struct {
+		commonConfig
+		Addr string `hcl:"addr"`
+	}
+
+	config := modeConfig{commonConfig: commonConfig{LogLevel: "info"}, Addr: ":8443"}
+	changed := modeConfig{commonConfig: commonConfig{LogLevel: "debug"}, Addr: ":8444"}
+	require.Equal(t, []string{"addr", "log_level"}, ChangedConfigKeys(config, changed))
+}
@@ -0,0 +1,184 @@
+// Package fflag provides a global set of feature flags used to gate
+// experimental subsystems in SPIRE Server and SPIRE Agent.
+//
+// Flags are loaded once at startup from the `feature_flags` configurable in
+// the `experimental` section of the configuration file. Every flag must be
+// registered in this package so that misspelled flags are detected at startup
+// and operators can list which experimental behavior is enabled.
+//
+// Flags are expected to be short-lived: once the gated behavior is stable the
+// flag is removed and the behavior becomes the default.
+package fflag
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Flag is the name of a feature flag.
+type Flag string
+
+// Scope identifies which component a flag applies to.
+type Scope int
+
+const (
+	// ScopeServer flags only apply to SPIRE Server.
+	ScopeServer Scope = 1 << iota
+	// ScopeAgent flags only apply to SPIRE Agent.
+	ScopeAgent
+
+	// ScopeAll flags apply to both SPIRE Server and SPIRE Agent.
+	ScopeAll = ScopeServer | ScopeAgent
+)
+
+// RawConfig is the list of flag names as read from the configuration file.
+type RawConfig []string
+
+// Info describes a registered flag.
+type Info struct {
+	Flag        Flag
+	Scope       Scope
+	Description string
+}
+
+var (
+	// known holds the registered flags. Flags are added here as experimental
+	// subsystems are introduced and removed once they graduate.
+	known = map[Flag]Info{}
+
+	singleton = struct {
+		mu     sync.RWMutex
+		loaded bool
+		flags  map[Flag]bool
+	}{
+		flags: map[Flag]bool{},
+	}
+)
+
+// register adds a flag to the set of known flags. It is intended to be
+// called from variable declarations in this package.
+func register(flag Flag, scope Scope, description string) Flag {
+	if _, ok := known[flag]; ok {
+		panic(fmt.Sprintf("feature flag %q registered twice", flag))
+	}
+	known[flag] = Info{
+		Flag:        flag,
+		Scope:       scope,
+		Description: description,
+	}
+	return flag
+}
+
+// Validate returns an error if any flag in the raw configuration is unknown
+// or does not apply to the given scope.
+func Validate(rc RawConfig, scope Scope) error {
+	_, err := parse(rc, scope)
+	return err
+}
+
+// Load enables the flags in the raw configuration for the given scope. It
+// returns an error if a flag is unknown, does not apply to the scope, or if
+// flags have already been loaded. Load is expected to be called once, during
+// startup.
+func Load(rc RawConfig, scope Scope) error {
+	singleton.mu.Lock()
+	defer singleton.mu.Unlock()
+
+	if singleton.loaded {
+		return errors.New("feature flags have already been loaded")
+	}
+
+	flags, err := parse(rc, scope)
+	if err != nil {
+		return err
+	}
+
+	singleton.flags = flags
+	singleton.loaded = true
+	return nil
+}
+
+// Unload resets the loaded flags. It is intended for use in tests.
+func Unload() {
+	singleton.mu.Lock()
+	defer singleton.mu.Unlock()
+
+	singleton.flags = map[Flag]bool{}
+	singleton.loaded = false
+}
+
+// IsSet returns true if the flag is enabled.
+func IsSet(flag Flag) bool {
+	singleton.mu.RLock()
+	defer singleton.mu.RUnlock()
+
+	return singleton.flags[flag]
+}
+
+// Enabled returns the enabled flags, sorted by name.
+func Enabled() []Flag {
+	singleton.mu.RLock()
+	defer singleton.mu.RUnlock()
+
+	flags := make([]Flag, 0, len(singleton.flags))
+	for flag := range singleton.flags {
+		flags = append(flags, flag)
+	}
+	sortFlags(flags)
+	return flags
+}
+
+// Known returns the registered flags applicable to the scope, sorted by name.
+func Known(scope Scope) []Info {
+	var infos []Info
+	for _, info := range known {
+		if info.Scope&scope != 0 {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Flag < infos[j].Flag
+	})
+	return infos
+}
+
+// Status summarizes the feature flag state, suitable for inclusion in health
+// check details.
+type Status struct {
+	Enabled []Flag `json:"enabled"`
+}
+
+// GetStatus returns the current feature flag status.
+func GetStatus() Status {
+	return Status{
+		Enabled: Enabled(),
+	}
+}
+
+func parse(rc RawConfig, scope Scope) (map[Flag]bool, error) {
+	var badFlags []string
+	flags := make(map[Flag]bool)
+	for _, rawFlag := range rc {
+		info, ok := known[Flag(rawFlag)]
+		if !ok || info.Scope&scope == 0 {
+			badFlags = append(badFlags, rawFlag)
+			continue
+		}
+		flags[info.Flag] = true
+	}
+
+	if len(badFlags) > 0 {
+		sort.Strings(badFlags)
+		return nil, fmt.Errorf("unknown feature flag(s): %s", strings.Join(badFlags, ", "))
+	}
+	return flags, nil
+}
+
+func sortFlags(flags []Flag) {
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i] < flags[j]
+	})
+}
@@ -0,0 +1,118 @@
+package fflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testServerFlag = register("test_server_flag", ScopeServer, "Server flag used in tests")
+	testAgentFlag  = register("test_agent_flag", ScopeAgent, "Agent flag used in tests")
+	testAllFlag    = register("test_all_flag", ScopeAll, "Flag used in tests for all components")
+)
+
+func TestLoad(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		rc         RawConfig
+		scope      Scope
+		expectErr  string
+		expectSet  []Flag
+		expectNone []Flag
+	}{
+		{
+			name:       "no flags",
+			scope:      ScopeServer,
+			expectNone: []Flag{testServerFlag, testAgentFlag, testAllFlag},
+		},
+		{
+			name:       "server flags",
+			rc:         RawConfig{"test_server_flag", "test_all_flag"},
+			scope:      ScopeServer,
+			expectSet:  []Flag{testAllFlag, testServerFlag},
+			expectNone: []Flag{testAgentFlag},
+		},
+		{
+			name:       "agent flags",
+			rc:         RawConfig{"test_agent_flag"},
+			scope:      ScopeAgent,
+			expectSet:  []Flag{testAgentFlag},
+			expectNone: []Flag{testServerFlag, testAllFlag},
+		},
+		{
+			name:       "unknown flag",
+			rc:         RawConfig{"bogus", "test_server_flag", "another"},
+			scope:      ScopeServer,
+			expectErr:  "unknown feature flag(s): another, bogus",
+			expectNone: []Flag{testServerFlag},
+		},
+		{
+			name:       "flag for another scope",
+			rc:         RawConfig{"test_agent_flag"},
+			scope:      ScopeServer,
+			expectErr:  "unknown feature flag(s): test_agent_flag",
+			expectNone: []Flag{testAgentFlag},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer Unload()
+
+			err := Load(tt.rc, tt.scope)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, flag := range tt.expectSet {
+				require.True(t, IsSet(flag), "flag %q should be set", flag)
+			}
+			for _, flag := range tt.expectNone {
+				require.False(t, IsSet(flag), "flag %q should not be set", flag)
+			}
+			if len(tt.expectSet) > 0 {
+				require.Equal(t, tt.expectSet, Enabled())
+				require.Equal(t, Status{Enabled: tt.expectSet}, GetStatus())
+			} else {
+				require.Empty(t, Enabled())
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(RawConfig{"test_server_flag", "test_all_flag"}, ScopeServer))
+	require.EqualError(t, Validate(RawConfig{"test_server_flag"}, ScopeAgent), "unknown feature flag(s): test_server_flag")
+
+	// Validation does not enable any flag
+	require.False(t, IsSet(testServerFlag))
+}
+
+func TestLoadTwice(t *testing.T) {
+	defer Unload()
+
+	require.NoError(t, Load(RawConfig{"test_server_flag"}, ScopeServer))
+	require.EqualError(t, Load(RawConfig{"test_all_flag"}, ScopeServer), "feature flags have already been loaded")
+	require.True(t, IsSet(testServerFlag))
+	require.False(t, IsSet(testAllFlag))
+}
+
+func TestKnown(t *testing.T) {
+	var serverFlags []Flag
+	for _, info := range Known(ScopeServer) {
+		require.NotEmpty(t, info.Description)
+		require.NotZero(t, info.Scope&ScopeServer)
+		serverFlags = append(serverFlags, info.Flag)
+	}
+	require.Contains(t, serverFlags, testServerFlag)
+	require.Contains(t, serverFlags, testAllFlag)
+	require.NotContains(t, serverFlags, testAgentFlag)
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	require.Panics(t, func() {
+		register(testServerFlag, ScopeServer, "duplicate")
+	})
+}
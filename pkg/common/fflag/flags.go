@@ -0,0 +1,27 @@
+package fflag
+
+var (
+	// FlagEntryEventCacheRebuild rebuilds the server's in-memory entry cache
+	// as soon as registration entries change, instead of only on the periodic
+	// reload interval.
+	FlagEntryEventCacheRebuild = register("entry_event_cache_rebuild", ScopeServer,
+		"Rebuild the in-memory entry cache as soon as registration entries change")
+
+	// FlagIncrementalEntryCache applies registration entry and agent
+	// selector changes to the server's in-memory entry cache in place,
+	// instead of rebuilding the whole cache.
+	FlagIncrementalEntryCache = register("incremental_entry_cache", ScopeServer,
+		"Update the in-memory entry cache in place as registration entries and agent selectors change")
+
+	// FlagIncrementalEntrySync makes the agent only download the authorized
+	// entries that changed since its last sync, and apply the changes to the
+	// entries it already has.
+	FlagIncrementalEntrySync = register("incremental_entry_sync", ScopeAgent,
+		"Only download the authorized entries that changed since the last sync")
+
+	// FlagEntryChangeFeed makes the agent watch its authorized entries and
+	// synchronize as soon as the server reports that they changed, instead
+	// of only on the sync interval.
+	FlagEntryChangeFeed = register("entry_change_feed", ScopeAgent,
+		"Synchronize as soon as the server reports that the authorized entries changed")
+)
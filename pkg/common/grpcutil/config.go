@@ -0,0 +1,142 @@
+package grpcutil
+
+import (
+	"fmt"
+	"time"
+)
+
+// ServerConfig is the HCL configuration of the connections accepted by a gRPC
+// server
+type ServerConfig struct {
+	// KeepaliveTime is how long a connection may be idle before the server
+	// pings the client (e.g. "1m")
+	KeepaliveTime string `hcl:"keepalive_time"`
+
+	// KeepaliveTimeout is how long the server waits for a ping to be
+	// acknowledged before closing the connection
+	KeepaliveTimeout string `hcl:"keepalive_timeout"`
+
+	// MinClientKeepaliveTime is the shortest interval between client pings
+	// the server tolerates before closing the connection
+	MinClientKeepaliveTime string `hcl:"min_client_keepalive_time"`
+
+	// PermitKeepaliveWithoutStream allows clients to ping while they have no
+	// active stream
+	PermitKeepaliveWithoutStream bool `hcl:"permit_keepalive_without_stream"`
+
+	// MaxConnectionIdle is how long a connection may have no active stream
+	// before it is closed
+	MaxConnectionIdle string `hcl:"max_connection_idle"`
+
+	// MaxConnectionAge is how long a connection may exist before it is
+	// gracefully closed
+	MaxConnectionAge string `hcl:"max_connection_age"`
+
+	// MaxConnectionAgeGrace is how long in-flight RPCs are given to finish
+	// once a connection reaches its maximum age
+	MaxConnectionAgeGrace string `hcl:"max_connection_age_grace"`
+
+	// InitialWindowSize is the flow control window of each stream, in bytes
+	InitialWindowSize int32 `hcl:"initial_window_size"`
+
+	// InitialConnWindowSize is the flow control window of each connection,
+	// in bytes
+	InitialConnWindowSize int32 `hcl:"initial_conn_window_size"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+// Tuning parses and validates the configuration. A nil configuration returns
+// the zero tuning, which keeps the gRPC defaults.
+func (c *ServerConfig) Tuning() (ServerTuning, error) {
+	if c == nil {
+		return ServerTuning{}, nil
+	}
+
+	t := ServerTuning{
+		PermitKeepaliveWithoutStream: c.PermitKeepaliveWithoutStream,
+		InitialWindowSize:            c.InitialWindowSize,
+		InitialConnWindowSize:        c.InitialConnWindowSize,
+	}
+	for _, d := range []struct {
+		name  string
+		value string
+		out   *time.Duration
+	}{
+		{name: "keepalive_time", value: c.KeepaliveTime, out: &t.KeepaliveTime},
+		{name: "keepalive_timeout", value: c.KeepaliveTimeout, out: &t.KeepaliveTimeout},
+		{name: "min_client_keepalive_time", value: c.MinClientKeepaliveTime, out: &t.MinClientKeepaliveTime},
+		{name: "max_connection_idle", value: c.MaxConnectionIdle, out: &t.MaxConnectionIdle},
+		{name: "max_connection_age", value: c.MaxConnectionAge, out: &t.MaxConnectionAge},
+		{name: "max_connection_age_grace", value: c.MaxConnectionAgeGrace, out: &t.MaxConnectionAgeGrace},
+	} {
+		if err := parseDuration(d.name, d.value, d.out); err != nil {
+			return ServerTuning{}, err
+		}
+	}
+	if err := t.Validate(); err != nil {
+		return ServerTuning{}, err
+	}
+	return t, nil
+}
+
+// ClientConfig is the HCL configuration of the connection a gRPC client
+// establishes to a server
+type ClientConfig struct {
+	// KeepaliveTime is how long the connection may be idle before the client
+	// pings the server (e.g. "1m")
+	KeepaliveTime string `hcl:"keepalive_time"`
+
+	// KeepaliveTimeout is how long the client waits for a ping to be
+	// acknowledged before closing the connection
+	KeepaliveTimeout string `hcl:"keepalive_timeout"`
+
+	// PermitKeepaliveWithoutStream makes the client ping while it has no
+	// active stream
+	PermitKeepaliveWithoutStream bool `hcl:"permit_keepalive_without_stream"`
+
+	// InitialWindowSize is the flow control window of each stream, in bytes
+	InitialWindowSize int32 `hcl:"initial_window_size"`
+
+	// InitialConnWindowSize is the flow control window of the connection,
+	// in bytes
+	InitialConnWindowSize int32 `hcl:"initial_conn_window_size"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+// Tuning parses and validates the configuration. A nil configuration returns
+// the zero tuning, which keeps the gRPC defaults.
+func (c *ClientConfig) Tuning() (ClientTuning, error) {
+	if c == nil {
+		return ClientTuning{}, nil
+	}
+
+	t := ClientTuning{
+		PermitKeepaliveWithoutStream: c.PermitKeepaliveWithoutStream,
+		InitialWindowSize:            c.InitialWindowSize,
+		InitialConnWindowSize:        c.InitialConnWindowSize,
+	}
+	if err := parseDuration("keepalive_time", c.KeepaliveTime, &t.KeepaliveTime); err != nil {
+		return ClientTuning{}, err
+	}
+	if err := parseDuration("keepalive_timeout", c.KeepaliveTimeout, &t.KeepaliveTimeout); err != nil {
+		return ClientTuning{}, err
+	}
+	if err := t.Validate(); err != nil {
+		return ClientTuning{}, err
+	}
+	return t, nil
+}
+
+func parseDuration(name, value string, out *time.Duration) error {
+	if value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("could not parse %s %q: %v", name, value, err)
+	}
+	*out = d
+	return nil
+}
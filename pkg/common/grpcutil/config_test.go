@@ -0,0 +1,132 @@
+package grpcutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerConfigTuning(t *testing.T) {
+	var nilConfig *ServerConfig
+	tuning, err := nilConfig.Tuning()
+	require.NoError(t, err)
+	assert.Equal(t, ServerTuning{}, tuning)
+
+	tuning, err = (&ServerConfig{
+		KeepaliveTime:                "1m",
+		KeepaliveTimeout:             "10s",
+		MinClientKeepaliveTime:       "30s",
+		PermitKeepaliveWithoutStream: true,
+		MaxConnectionIdle:            "5m",
+		MaxConnectionAge:             "1h",
+		MaxConnectionAgeGrace:        "30s",
+		InitialWindowSize:            1 << 20,
+		InitialConnWindowSize:        1 << 21,
+	}).Tuning()
+	require.NoError(t, err)
+	assert.Equal(t, ServerTuning{
+		KeepaliveTime:                time.Minute,
+		KeepaliveTimeout:             10 * time.Second,
+		MinClientKeepaliveTime:       30 * time.Second,
+		PermitKeepaliveWithoutStream: true,
+		MaxConnectionIdle:            5 * time.Minute,
+		MaxConnectionAge:             time.Hour,
+		MaxConnectionAgeGrace:        30 * time.Second,
+		InitialWindowSize:            1 << 20,
+		InitialConnWindowSize:        1 << 21,
+	}, tuning)
+	assert.Len(t, tuning.ServerOptions(), 4)
+
+	for _, tt := range []struct {
+		name   string
+		config ServerConfig
+		err    string
+	}{
+		{
+			name:   "unparseable duration",
+			config: ServerConfig{MaxConnectionAge: "forever"},
+			err:    `could not parse max_connection_age "forever": time: invalid duration "forever"`,
+		},
+		{
+			name:   "negative duration",
+			config: ServerConfig{KeepaliveTime: "-1m"},
+			err:    "keepalive_time must not be negative",
+		},
+		{
+			name:   "window too small",
+			config: ServerConfig{InitialWindowSize: 1024},
+			err:    "initial_window_size must be at least 65535 bytes",
+		},
+		{
+			name:   "connection window too small",
+			config: ServerConfig{InitialConnWindowSize: 1024},
+			err:    "initial_conn_window_size must be at least 65535 bytes",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.config.Tuning()
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestClientConfigTuning(t *testing.T) {
+	var nilConfig *ClientConfig
+	tuning, err := nilConfig.Tuning()
+	require.NoError(t, err)
+	assert.Equal(t, ClientTuning{}, tuning)
+	assert.Empty(t, tuning.DialOptions())
+
+	tuning, err = (&ClientConfig{
+		KeepaliveTime:                "30s",
+		KeepaliveTimeout:             "10s",
+		PermitKeepaliveWithoutStream: true,
+		InitialWindowSize:            1 << 20,
+		InitialConnWindowSize:        1 << 21,
+	}).Tuning()
+	require.NoError(t, err)
+	assert.Equal(t, ClientTuning{
+		KeepaliveTime:                30 * time.Second,
+		KeepaliveTimeout:             10 * time.Second,
+		PermitKeepaliveWithoutStream: true,
+		InitialWindowSize:            1 << 20,
+		InitialConnWindowSize:        1 << 21,
+	}, tuning)
+	assert.Len(t, tuning.DialOptions(), 3)
+
+	for _, tt := range []struct {
+		name   string
+		config ClientConfig
+		err    string
+	}{
+		{
+			name:   "unparseable duration",
+			config: ClientConfig{KeepaliveTimeout: "soon"},
+			err:    `could not parse keepalive_timeout "soon": time: invalid duration "soon"`,
+		},
+		{
+			name:   "keepalive below the gRPC minimum",
+			config: ClientConfig{KeepaliveTime: "5s"},
+			err:    "keepalive_time must be at least 10s",
+		},
+		{
+			name:   "negative timeout",
+			config: ClientConfig{KeepaliveTimeout: "-1s"},
+			err:    "keepalive_timeout must not be negative",
+		},
+		{
+			name:   "window too small",
+			config: ClientConfig{InitialWindowSize: 1024},
+			err:    "initial_window_size must be at least 65535 bytes",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.config.Tuning()
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package grpcutil
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// minWindowSize is the smallest flow control window gRPC accepts. Smaller
+	// values are silently ignored by gRPC.
+	minWindowSize = 64*1024 - 1
+
+	// minClientKeepaliveTime is the smallest client keepalive time gRPC
+	// accepts. Smaller values are raised to it by gRPC.
+	minClientKeepaliveTime = 10 * time.Second
+)
+
+// ServerTuning holds the keepalive and flow control settings of the
+// connections accepted by a gRPC server. Zero values keep the gRPC defaults.
+type ServerTuning struct {
+	KeepaliveTime                time.Duration
+	KeepaliveTimeout             time.Duration
+	MinClientKeepaliveTime       time.Duration
+	PermitKeepaliveWithoutStream bool
+	MaxConnectionIdle            time.Duration
+	MaxConnectionAge             time.Duration
+	MaxConnectionAgeGrace        time.Duration
+	InitialWindowSize            int32
+	InitialConnWindowSize        int32
+}
+
+// Validate returns an error if the tuning holds values gRPC cannot honor
+func (t ServerTuning) Validate() error {
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{name: "keepalive_time", value: t.KeepaliveTime},
+		{name: "keepalive_timeout", value: t.KeepaliveTimeout},
+		{name: "min_client_keepalive_time", value: t.MinClientKeepaliveTime},
+		{name: "max_connection_idle", value: t.MaxConnectionIdle},
+		{name: "max_connection_age", value: t.MaxConnectionAge},
+		{name: "max_connection_age_grace", value: t.MaxConnectionAgeGrace},
+	} {
+		if d.value < 0 {
+			return fmt.Errorf("%s must not be negative", d.name)
+		}
+	}
+	return validateWindowSizes(t.InitialWindowSize, t.InitialConnWindowSize)
+}
+
+// ServerOptions returns the gRPC server options applying the tuning
+func (t ServerTuning) ServerOptions() []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     t.MaxConnectionIdle,
+			MaxConnectionAge:      t.MaxConnectionAge,
+			MaxConnectionAgeGrace: t.MaxConnectionAgeGrace,
+			Time:                  t.KeepaliveTime,
+			Timeout:               t.KeepaliveTimeout,
+		}),
+	}
+	if t.MinClientKeepaliveTime > 0 || t.PermitKeepaliveWithoutStream {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             t.MinClientKeepaliveTime,
+			PermitWithoutStream: t.PermitKeepaliveWithoutStream,
+		}))
+	}
+	if t.InitialWindowSize > 0 {
+		opts = append(opts, grpc.InitialWindowSize(t.InitialWindowSize))
+	}
+	if t.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.InitialConnWindowSize(t.InitialConnWindowSize))
+	}
+	return opts
+}
+
+// ClientTuning holds the keepalive and flow control settings of a gRPC client
+// connection. Zero values keep the gRPC defaults.
+type ClientTuning struct {
+	KeepaliveTime                time.Duration
+	KeepaliveTimeout             time.Duration
+	PermitKeepaliveWithoutStream bool
+	InitialWindowSize            int32
+	InitialConnWindowSize        int32
+}
+
+// Validate returns an error if the tuning holds values gRPC cannot honor
+func (t ClientTuning) Validate() error {
+	switch {
+	case t.KeepaliveTime < 0:
+		return errors.New("keepalive_time must not be negative")
+	case t.KeepaliveTime > 0 && t.KeepaliveTime < minClientKeepaliveTime:
+		return fmt.Errorf("keepalive_time must be at least %s", minClientKeepaliveTime)
+	case t.KeepaliveTimeout < 0:
+		return errors.New("keepalive_timeout must not be negative")
+	}
+	return validateWindowSizes(t.InitialWindowSize, t.InitialConnWindowSize)
+}
+
+// DialOptions returns the gRPC dial options applying the tuning
+func (t ClientTuning) DialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if t.KeepaliveTime > 0 || t.KeepaliveTimeout > 0 || t.PermitKeepaliveWithoutStream {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                t.KeepaliveTime,
+			Timeout:             t.KeepaliveTimeout,
+			PermitWithoutStream: t.PermitKeepaliveWithoutStream,
+		}))
+	}
+	if t.InitialWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialWindowSize(t.InitialWindowSize))
+	}
+	if t.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialConnWindowSize(t.InitialConnWindowSize))
+	}
+	return opts
+}
+
+func validateWindowSizes(windowSize, connWindowSize int32) error {
+	switch {
+	case windowSize != 0 && windowSize < minWindowSize:
+		return fmt.Errorf("initial_window_size must be at least %d bytes", minWindowSize)
+	case connWindowSize != 0 && connWindowSize < minWindowSize:
+		return fmt.Errorf("initial_conn_window_size must be at least %d bytes", minWindowSize)
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package health
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -21,16 +23,43 @@ type Checker struct {
 	hc    *health.Health
 	mutex sync.Mutex // Mutex protects non-threadsafe hc
 
+	liveMtx    sync.RWMutex
+	liveChecks map[string]bool
+
 	log logrus.FieldLogger
 }
 
-func live(w http.ResponseWriter, _ *http.Request) {
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(http.StatusOK)
+// CheckFunc adapts a function to the health.ICheckable interface
+type CheckFunc func() (interface{}, error)
+
+// Status returns the result of calling f
+func (f CheckFunc) Status() (interface{}, error) {
+	return f()
+}
+
+// CheckProvider is implemented by components that contribute their own health
+// checks, such as built-in plugins reporting on state only they have access
+// to (e.g. the TTL of the credentials they authenticate with).
+type CheckProvider interface {
+	// HealthChecks returns the checks of the component, keyed by name
+	HealthChecks() map[string]CheckFunc
+}
+
+// Report is the machine-readable health report served on the liveness and
+// readiness paths. It matches the format produced by go-health.
+type Report struct {
+	Status  string                  `json:"status"`
+	Details map[string]health.State `json:"details"`
+}
+
+// Failed returns true if any of the checks in the report failed
+func (r *Report) Failed() bool {
+	return r.Status != "ok"
 }
 
 func NewChecker(config Config, log logrus.FieldLogger) *Checker {
 	hc := health.New()
+	c := &Checker{config: config, hc: hc, liveChecks: make(map[string]bool), log: log}
 
 	var server *http.Server
 	// Start HTTP server if ListenerEnabled is true
@@ -38,7 +67,7 @@ func NewChecker(config Config, log logrus.FieldLogger) *Checker {
 		handler := http.NewServeMux()
 
 		handler.HandleFunc(config.getReadyPath(), handlers.NewJSONHandlerFunc(hc, nil))
-		handler.HandleFunc(config.getLivePath(), live)
+		handler.HandleFunc(config.getLivePath(), c.live)
 
 		server = &http.Server{
 			Addr:    config.getAddress(),
@@ -46,10 +75,11 @@ func NewChecker(config Config, log logrus.FieldLogger) *Checker {
 		}
 	}
 
-	hc.StatusListener = &statusListener{}
+	hc.StatusListener = &statusListener{log: log}
 	hc.Logger = &logadapter{FieldLogger: log.WithField(telemetry.SubsystemName, "health")}
 
-	return &Checker{config: config, server: server, hc: hc, log: log}
+	c.server = server
+	return c
 }
 
 func (c *Checker) AddCheck(name string, checker health.ICheckable, interval time.Duration) error {
@@ -64,6 +94,86 @@ func (c *Checker) AddCheck(name string, checker health.ICheckable, interval time
 	})
 }
 
+// AddChecksFrom adds the checks contributed by a component. Each check is
+// named after the component and the check, e.g. "vault.token_ttl".
+func (c *Checker) AddChecksFrom(component string, provider CheckProvider, interval time.Duration) error {
+	checks := provider.HealthChecks()
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := c.AddCheck(component+"."+name, checks[name], interval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddLivenessCheck adds a check that, in addition to being reported on the
+// readiness path, causes the liveness path to fail when it fails. It should
+// be used for failures that are only recoverable by restarting the process.
+func (c *Checker) AddLivenessCheck(name string, checker health.ICheckable, interval time.Duration) error {
+	if err := c.AddCheck(name, checker, interval); err != nil {
+		return err
+	}
+
+	c.liveMtx.Lock()
+	defer c.liveMtx.Unlock()
+	c.liveChecks[name] = true
+	return nil
+}
+
+// ReadyReport returns the current state of all the checks, as served on the
+// readiness path
+func (c *Checker) ReadyReport() *Report {
+	states, _, _ := c.hc.State()
+
+	report := NewReport()
+	for _, state := range states {
+		report.Add(state)
+	}
+	return report
+}
+
+// LiveReport returns the current state of the liveness checks
+func (c *Checker) LiveReport() *Report {
+	states, _, _ := c.hc.State()
+
+	c.liveMtx.RLock()
+	defer c.liveMtx.RUnlock()
+
+	report := &Report{Status: "ok", Details: make(map[string]health.State)}
+	for name, state := range states {
+		if !c.liveChecks[name] {
+			continue
+		}
+		report.Details[name] = state
+		if state.Status == "failed" {
+			report.Status = "failed"
+		}
+	}
+	return report
+}
+
+func (c *Checker) live(w http.ResponseWriter, _ *http.Request) {
+	report := c.LiveReport()
+
+	statusCode := http.StatusOK
+	if report.Failed() {
+		statusCode = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		c.log.WithError(err).Warn("Error writing liveness report")
+	}
+}
+
 func (c *Checker) ListenAndServe(ctx context.Context) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -1,10 +1,16 @@
 package health
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServerDisabledByDefault(t *testing.T) {
@@ -20,3 +26,66 @@ func TestServerEnabled(t *testing.T) {
 
 	assert.NotNil(t, checker.server)
 }
+
+func TestLiveReport(t *testing.T) {
+	log, _ := logtest.NewNullLogger()
+	checker := NewChecker(Config{ListenerEnabled: true}, log)
+
+	require.NoError(t, checker.AddCheck("ready", CheckFunc(func() (interface{}, error) {
+		return nil, errors.New("not ready")
+	}), time.Minute))
+	require.NoError(t, checker.AddLivenessCheck("live", CheckFunc(func() (interface{}, error) {
+		return "details", nil
+	}), time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = checker.ListenAndServe(ctx)
+	}()
+
+	// The failing readiness check does not affect liveness
+	require.Eventually(t, func() bool {
+		return len(checker.LiveReport().Details) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	report := checker.LiveReport()
+	assert.False(t, report.Failed())
+	assert.Equal(t, "details", report.Details["live"].Details)
+
+	rec := httptest.NewRecorder()
+	checker.live(rec, httptest.NewRequest("GET", "/live", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"status":"ok"`)
+}
+
+type testProvider struct{}
+
+func (testProvider) HealthChecks() map[string]CheckFunc {
+	return map[string]CheckFunc{
+		"b": func() (interface{}, error) { return nil, nil },
+		"a": func() (interface{}, error) { return nil, errors.New("oops") },
+	}
+}
+
+func TestAddChecksFrom(t *testing.T) {
+	log, _ := logtest.NewNullLogger()
+	checker := NewChecker(Config{}, log)
+	require.NoError(t, checker.AddChecksFrom("component", testProvider{}, time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = checker.ListenAndServe(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(checker.ReadyReport().Details) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	report := checker.ReadyReport()
+	assert.True(t, report.Failed())
+	assert.Equal(t, []string{"component.a"}, report.FailedNames())
+	assert.Equal(t, "ok", report.Details["component.b"].Status)
+}
@@ -0,0 +1,135 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/InVisionApp/go-health"
+)
+
+// CheckRegistry holds the checks run by the healthcheck subcommands from
+// outside of the SPIRE process. The state of each check is merged into a
+// Report, alongside the states served on the readiness path.
+type CheckRegistry struct {
+	checks []registeredCheck
+}
+
+type registeredCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Register adds a check to the registry. Checks are run in the order they
+// were registered.
+func (r *CheckRegistry) Register(name string, check CheckFunc) {
+	r.checks = append(r.checks, registeredCheck{name: name, check: check})
+}
+
+// Run runs the registered checks and adds their state to the report.
+func (r *CheckRegistry) Run(report *Report) {
+	for _, c := range r.checks {
+		state := health.State{
+			Name:      c.name,
+			Status:    "ok",
+			CheckTime: time.Now(),
+		}
+		details, err := c.check()
+		state.Details = details
+		if err != nil {
+			state.Status = "failed"
+			state.Err = err.Error()
+		}
+		report.Add(state)
+	}
+}
+
+// NewReport returns an empty report with an "ok" status.
+func NewReport() *Report {
+	return &Report{
+		Status:  "ok",
+		Details: make(map[string]health.State),
+	}
+}
+
+// FetchReport fetches the report served on the readiness path of a SPIRE
+// server or agent.
+func FetchReport(client *http.Client, url string) (*Report, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to contact readiness endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	report := NewReport()
+	if err := json.NewDecoder(resp.Body).Decode(report); err != nil {
+		return nil, fmt.Errorf("unable to parse readiness report: %w", err)
+	}
+	if report.Details == nil {
+		report.Details = make(map[string]health.State)
+	}
+	return report, nil
+}
+
+// Add adds the state of a check to the report, failing the report if the
+// check failed.
+func (r *Report) Add(state health.State) {
+	r.Details[state.Name] = state
+	if state.Status == "failed" {
+		r.Status = "failed"
+	}
+}
+
+// Names returns the sorted names of the checks in the report.
+func (r *Report) Names() []string {
+	names := make([]string, 0, len(r.Details))
+	for name := range r.Details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FailedNames returns the sorted names of the failed checks in the report.
+func (r *Report) FailedNames() []string {
+	var names []string
+	for _, name := range r.Names() {
+		if r.Details[name].Status == "failed" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Summary returns a line per check of the report, sorted by name, giving the
+// status of the check and its error if it failed.
+func (r *Report) Summary() []string {
+	names := r.Names()
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		state := r.Details[name]
+		line := fmt.Sprintf("%s: %s", name, state.Status)
+		if state.Err != "" {
+			line += fmt.Sprintf(" (%s)", state.Err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Filter returns a report holding only the named checks, with a status
+// reflecting those checks alone. It fails if one of the checks is not in
+// the report.
+func (r *Report) Filter(names []string) (*Report, error) {
+	filtered := NewReport()
+	for _, name := range names {
+		state, ok := r.Details[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown check %q", name)
+		}
+		filtered.Add(state)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,72 @@
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/InVisionApp/go-health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRegistry(t *testing.T) {
+	var order []string
+	registry := new(CheckRegistry)
+	registry.Register("b", func() (interface{}, error) {
+		order = append(order, "b")
+		return "details", nil
+	})
+	registry.Register("a", func() (interface{}, error) {
+		order = append(order, "a")
+		return nil, errors.New("oops")
+	})
+
+	report := NewReport()
+	registry.Run(report)
+
+	assert.Equal(t, []string{"b", "a"}, order)
+	assert.True(t, report.Failed())
+	assert.Equal(t, []string{"a", "b"}, report.Names())
+	assert.Equal(t, []string{"a"}, report.FailedNames())
+	assert.Equal(t, "details", report.Details["b"].Details)
+	assert.Equal(t, "oops", report.Details["a"].Err)
+	assert.Equal(t, []string{"a: failed (oops)", "b: ok"}, report.Summary())
+}
+
+func TestReportFilter(t *testing.T) {
+	report := NewReport()
+	report.Add(health.State{Name: "ok", Status: "ok"})
+	report.Add(health.State{Name: "bad", Status: "failed"})
+	require.True(t, report.Failed())
+
+	filtered, err := report.Filter([]string{"ok"})
+	require.NoError(t, err)
+	assert.False(t, filtered.Failed())
+	assert.Equal(t, []string{"ok"}, filtered.Names())
+
+	filtered, err = report.Filter([]string{"ok", "bad"})
+	require.NoError(t, err)
+	assert.True(t, filtered.Failed())
+
+	_, err = report.Filter([]string{"unknown"})
+	assert.EqualError(t, err, `unknown check "unknown"`)
+}
+
+func TestFetchReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"failed","details":{"datastore":{"name":"datastore","status":"failed","error":"oops"}}}`))
+	}))
+	defer server.Close()
+
+	report, err := FetchReport(server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Equal(t, "oops", report.Details["datastore"].Err)
+
+	server.Close()
+	_, err = FetchReport(server.Client(), server.URL)
+	assert.Contains(t, err.Error(), "unable to contact readiness endpoint")
+}
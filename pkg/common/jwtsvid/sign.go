@@ -3,6 +3,7 @@ package jwtsvid
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"errors"
 	"time"
@@ -20,6 +21,11 @@ type SignerConfig struct {
 
 	// Issuer is used as the value of the issuer (iss) claim, if set.
 	Issuer string
+
+	// Algorithm is the preferred signature algorithm. It is used when it is
+	// compatible with the signing key. Otherwise, the signature algorithm is
+	// determined by the signing key type.
+	Algorithm jose.SignatureAlgorithm
 }
 
 type Signer struct {
@@ -35,7 +41,39 @@ func NewSigner(config SignerConfig) *Signer {
 	}
 }
 
+// registeredClaims are the claims set by the signer that cannot be
+// overridden by additional claims.
+var registeredClaims = map[string]bool{
+	"sub": true,
+	"aud": true,
+	"exp": true,
+	"iat": true,
+	"iss": true,
+	"nbf": true,
+	"jti": true,
+}
+
+// ValidateClaims validates that the additional claims do not override any of
+// the registered claims set by the signer.
+func ValidateClaims(claims map[string]string) error {
+	for name := range claims {
+		if name == "" {
+			return errors.New("claim name cannot be empty")
+		}
+		if registeredClaims[name] {
+			return errs.New("claim %q is reserved", name)
+		}
+	}
+	return nil
+}
+
 func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid string) (string, error) {
+	return s.SignTokenWithClaims(spiffeID, audience, expires, signer, kid, nil)
+}
+
+// SignTokenWithClaims signs a token that includes the given claims in
+// addition to the registered claims.
+func (s *Signer) SignTokenWithClaims(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid string, extraClaims map[string]string) (string, error) {
 	if err := idutil.ValidateSpiffeID(spiffeID, idutil.AllowAnyTrustDomainWorkload()); err != nil {
 		return "", err
 	}
@@ -51,6 +89,9 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 	if len(kid) == 0 {
 		return "", errors.New("kid is required")
 	}
+	if err := ValidateClaims(extraClaims); err != nil {
+		return "", err
+	}
 
 	claims := jwt.Claims{
 		Subject:  spiffeID,
@@ -60,26 +101,9 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 		IssuedAt: jwt.NewNumericDate(s.c.Clock.Now()),
 	}
 
-	var alg jose.SignatureAlgorithm
-	switch publicKey := signer.Public().(type) {
-	case *rsa.PublicKey:
-		// Prevent the use of keys smaller than 2048 bits
-		if publicKey.Size() < 256 {
-			return "", errs.New("unsupported RSA key size: %d", publicKey.Size())
-		}
-		alg = jose.RS256
-	case *ecdsa.PublicKey:
-		params := publicKey.Params()
-		switch params.BitSize {
-		case 256:
-			alg = jose.ES256
-		case 384:
-			alg = jose.ES384
-		default:
-			return "", errs.New("unable to determine signature algorithm for EC public key size %d", params.BitSize)
-		}
-	default:
-		return "", errs.New("unable to determine signature algorithm for public key type %T", publicKey)
+	alg, err := s.signatureAlgorithm(signer.Public())
+	if err != nil {
+		return "", err
 	}
 
 	jwtSigner, err := jose.NewSigner(
@@ -96,7 +120,18 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 		return "", errs.Wrap(err)
 	}
 
-	signedToken, err := jwt.Signed(jwtSigner).Claims(claims).CompactSerialize()
+	builder := jwt.Signed(jwtSigner).Claims(claims)
+	if len(extraClaims) > 0 {
+		// The builder only accepts claims that marshal to a JSON object
+		// from a struct or a map of interfaces.
+		m := make(map[string]interface{}, len(extraClaims))
+		for k, v := range extraClaims {
+			m[k] = v
+		}
+		builder = builder.Claims(m)
+	}
+
+	signedToken, err := builder.CompactSerialize()
 	if err != nil {
 		return "", errs.Wrap(err)
 	}
@@ -104,6 +139,48 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 	return signedToken, nil
 }
 
+// signatureAlgorithm returns the signature algorithm used for the given
+// public key.
+func (s *Signer) signatureAlgorithm(publicKey crypto.PublicKey) (jose.SignatureAlgorithm, error) {
+	algs, err := SignatureAlgorithms(publicKey)
+	if err != nil {
+		return "", err
+	}
+	for _, alg := range algs {
+		if alg == s.c.Algorithm {
+			return alg, nil
+		}
+	}
+	return algs[0], nil
+}
+
+// SignatureAlgorithms returns the JWT-SVID signature algorithms that can be
+// used with the given public key. The first algorithm is the default.
+func SignatureAlgorithms(publicKey crypto.PublicKey) ([]jose.SignatureAlgorithm, error) {
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		// Prevent the use of keys smaller than 2048 bits
+		if publicKey.Size() < 256 {
+			return nil, errs.New("unsupported RSA key size: %d", publicKey.Size())
+		}
+		return []jose.SignatureAlgorithm{jose.RS256, jose.PS256}, nil
+	case *ecdsa.PublicKey:
+		params := publicKey.Params()
+		switch params.BitSize {
+		case 256:
+			return []jose.SignatureAlgorithm{jose.ES256}, nil
+		case 384:
+			return []jose.SignatureAlgorithm{jose.ES384}, nil
+		default:
+			return nil, errs.New("unable to determine signature algorithm for EC public key size %d", params.BitSize)
+		}
+	case ed25519.PublicKey:
+		return []jose.SignatureAlgorithm{jose.EdDSA}, nil
+	default:
+		return nil, errs.New("unable to determine signature algorithm for public key type %T", publicKey)
+	}
+}
+
 func pruneEmptyValues(values []string) []string {
 	pruned := make([]string, 0, len(values))
 	for _, value := range values {
@@ -3,6 +3,7 @@ package jwtsvid
 import (
 	"context"
 	"crypto"
+	"crypto/ed25519"
 	"testing"
 	"time"
 
@@ -125,6 +126,8 @@ tB9gAFps/Ka31SEZAg/GJxZuxIUzXRq5M73Y6wijos/xi8AMNpzHSSwsRid5R+Oq
 96A646vR3voz0WAoWGHE5oCYb+uoCYbWG/pnFHVC
 -----END PRIVATE KEY-----
 `))
+
+	ed25519Key = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
 )
 
 func TestToken(t *testing.T) {
@@ -146,6 +149,7 @@ func (s *TokenSuite) SetupTest() {
 			"rsa1024Key": rsa1024Key.Public(),
 			"rsa2048Key": rsa2048Key.Public(),
 			"rsa4096Key": rsa4096Key.Public(),
+			"ed25519Key": ed25519Key.Public(),
 		},
 	})
 	s.signer = NewSigner(SignerConfig{
@@ -180,6 +184,10 @@ func (s *TokenSuite) TestSignAndValidate() {
 			kid: "rsa4096Key",
 			key: rsa4096Key,
 		},
+		{
+			kid: "ed25519Key",
+			key: ed25519Key,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -212,6 +220,51 @@ func (s *TokenSuite) TestSignAndValidateWithAudienceList() {
 	s.Require().NotEmpty(claims)
 }
 
+func (s *TokenSuite) TestSignWithPreferredAlgorithm() {
+	signer := NewSigner(SignerConfig{
+		Clock:     clock.NewMock(s.T()),
+		Algorithm: jose.PS256,
+	})
+
+	// The preferred algorithm is used when compatible with the key
+	token, err := signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), rsa2048Key, "rsa2048Key")
+	s.Require().NoError(err)
+	s.requireTokenAlgorithm(token, jose.PS256)
+
+	spiffeID, _, err := ValidateToken(ctx, token, s.bundle, fakeAudience[0:1])
+	s.Require().NoError(err)
+	s.Require().Equal(fakeSpiffeID, spiffeID)
+
+	// Otherwise the algorithm is determined by the key type
+	token, err = signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key")
+	s.Require().NoError(err)
+	s.requireTokenAlgorithm(token, jose.ES256)
+
+	token, err = signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ed25519Key, "ed25519Key")
+	s.Require().NoError(err)
+	s.requireTokenAlgorithm(token, jose.EdDSA)
+}
+
+func (s *TokenSuite) TestSignAndValidateWithClaims() {
+	token, err := s.signer.SignTokenWithClaims(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key", map[string]string{
+		"role": "admin",
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(token)
+
+	spiffeID, claims, err := ValidateToken(ctx, token, s.bundle, fakeAudience[0:1])
+	s.Require().NoError(err)
+	s.Require().Equal(fakeSpiffeID, spiffeID)
+	s.Require().Equal("admin", claims["role"])
+}
+
+func (s *TokenSuite) TestSignWithReservedClaim() {
+	_, err := s.signer.SignTokenWithClaims(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key", map[string]string{
+		"sub": "spiffe://example.org/other",
+	})
+	s.Require().EqualError(err, `claim "sub" is reserved`)
+}
+
 func (s *TokenSuite) TestSignWithNoExpiration() {
 	_, err := s.signer.SignToken(fakeSpiffeID, fakeAudience, time.Time{}, ec256Key, "ec256Key")
 	s.Require().EqualError(err, "expiration is required")
@@ -345,3 +398,10 @@ func (s *TokenSuite) signToken(alg jose.SignatureAlgorithm, key interface{}, cla
 	s.Require().NoError(err)
 	return token
 }
+
+func (s *TokenSuite) requireTokenAlgorithm(token string, alg jose.SignatureAlgorithm) {
+	tok, err := jwt.ParseSigned(token)
+	s.Require().NoError(err)
+	s.Require().Len(tok.Headers, 1)
+	s.Require().Equal(string(alg), tok.Headers[0].Algorithm)
+}
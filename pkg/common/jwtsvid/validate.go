@@ -53,7 +53,8 @@ func ValidateToken(ctx context.Context, token string, keyStore KeyStore, audienc
 	switch jose.SignatureAlgorithm(alg) {
 	case jose.RS256, jose.RS384, jose.RS512,
 		jose.ES256, jose.ES384, jose.ES512,
-		jose.PS256, jose.PS384, jose.PS512:
+		jose.PS256, jose.PS384, jose.PS512,
+		jose.EdDSA:
 	default:
 		return "", nil, errs.New("unsupported token signature algorithm %q", alg)
 	}
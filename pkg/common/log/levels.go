@@ -0,0 +1,174 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Fields used to match log entries against subsystem level overrides, from
+// the most to the least specific. They mirror the telemetry field names,
+// which can't be imported from here without an import cycle.
+var subsystemFields = []string{"plugin_name", "plugin_type", "subsystem_name"}
+
+// Levels holds the level of the logger and the level overrides of its
+// subsystems.
+type Levels struct {
+	// Level is the level of the entries that don't belong to a subsystem
+	// with an override.
+	Level logrus.Level
+
+	// Subsystems holds level overrides keyed by lowercase subsystem name.
+	// A subsystem is matched against the plugin name, plugin type and
+	// subsystem name fields of an entry, in that order.
+	Subsystems map[string]logrus.Level
+}
+
+// LevelSetter changes the log levels of a logger while it is in use.
+type LevelSetter interface {
+	GetLevels() Levels
+	SetLevels(Levels)
+}
+
+// ParseLevels parses a log level and the level overrides of subsystems.
+func ParseLevels(level string, subsystems map[string]string) (Levels, error) {
+	l, err := logrus.ParseLevel(level)
+	if err != nil {
+		return Levels{}, err
+	}
+
+	levels := Levels{
+		Level:      l,
+		Subsystems: make(map[string]logrus.Level, len(subsystems)),
+	}
+	for subsystem, level := range subsystems {
+		l, err := logrus.ParseLevel(level)
+		if err != nil {
+			return Levels{}, fmt.Errorf("invalid level for subsystem %q: %v", subsystem, err)
+		}
+		levels.Subsystems[strings.ToLower(subsystem)] = l
+	}
+	return levels, nil
+}
+
+// maxLevel returns the most verbose of the configured levels.
+func (l Levels) maxLevel() logrus.Level {
+	level := l.Level
+	for _, subsystemLevel := range l.Subsystems {
+		if subsystemLevel > level {
+			level = subsystemLevel
+		}
+	}
+	return level
+}
+
+// levelFor returns the level that applies to the given entry.
+func (l Levels) levelFor(entry *logrus.Entry) logrus.Level {
+	if len(l.Subsystems) == 0 {
+		return l.Level
+	}
+	for _, field := range subsystemFields {
+		value, ok := entry.Data[field].(string)
+		if !ok {
+			continue
+		}
+		if level, ok := l.Subsystems[strings.ToLower(value)]; ok {
+			return level
+		}
+	}
+	return l.Level
+}
+
+// WithSubsystemLevels overrides the level of the given subsystems. It must
+// come after WithLevel.
+func WithSubsystemLevels(subsystems map[string]string) Option {
+	return func(logger *Logger) error {
+		levels, err := ParseLevels(logger.GetLevels().Level.String(), subsystems)
+		if err != nil {
+			return err
+		}
+		logger.SetLevels(levels)
+		return nil
+	}
+}
+
+// SetLevels changes the level of the logger and the level overrides of its
+// subsystems. It is safe to call while the logger is in use.
+func (logger *Logger) SetLevels(levels Levels) {
+	logger.levelFilter.set(levels)
+	logger.SetLevel(levels.maxLevel())
+	if len(levels.Subsystems) > 0 {
+		logger.installLevelFilter()
+	}
+}
+
+// GetLevels returns the level of the logger and the level overrides of its
+// subsystems.
+func (logger *Logger) GetLevels() Levels {
+	return logger.levelFilter.get()
+}
+
+// installLevelFilter wraps the formatter of the logger so the entries below
+// the level of their subsystem are discarded. The formatter is only wrapped
+// once subsystem overrides are set.
+func (logger *Logger) installLevelFilter() {
+	logger.levelFilter.installMtx.Lock()
+	defer logger.levelFilter.installMtx.Unlock()
+
+	if _, ok := logger.Formatter.(filteringFormatter); ok {
+		return
+	}
+	logger.SetFormatter(filteringFormatter{
+		Formatter: logger.Formatter,
+		filter:    logger.levelFilter,
+	})
+}
+
+// levelFilter drops the entries below the level of their subsystem. Logrus
+// only filters entries by the level of the logger, which is set to the most
+// verbose of the subsystem levels, so the rest of the filtering happens when
+// the entry is formatted.
+type levelFilter struct {
+	mtx    sync.RWMutex
+	levels Levels
+
+	installMtx sync.Mutex
+}
+
+func (f *levelFilter) set(levels Levels) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.levels = levels
+}
+
+func (f *levelFilter) get() Levels {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	return f.levels
+}
+
+func (f *levelFilter) allows(entry *logrus.Entry) bool {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	if len(f.levels.Subsystems) == 0 {
+		// Without overrides, the level of the logger does the filtering
+		return true
+	}
+	return entry.Level <= f.levels.levelFor(entry)
+}
+
+// filteringFormatter formats the entries allowed by the level filter and
+// discards the rest.
+type filteringFormatter struct {
+	logrus.Formatter
+	filter *levelFilter
+}
+
+func (f filteringFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !f.filter.allows(entry) {
+		return nil, nil
+	}
+	return f.Formatter.Format(entry)
+}
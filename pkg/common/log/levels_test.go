@@ -0,0 +1,73 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevels(t *testing.T) {
+	levels, err := ParseLevels("info", map[string]string{"CA": "debug", "datastore": "warn"})
+	require.NoError(t, err)
+	require.Equal(t, Levels{
+		Level: logrus.InfoLevel,
+		Subsystems: map[string]logrus.Level{
+			"ca":        logrus.DebugLevel,
+			"datastore": logrus.WarnLevel,
+		},
+	}, levels)
+
+	_, err = ParseLevels("loud", nil)
+	require.EqualError(t, err, `not a valid logrus Level: "loud"`)
+
+	_, err = ParseLevels("info", map[string]string{"ca": "loud"})
+	require.EqualError(t, err, `invalid level for subsystem "ca": not a valid logrus Level: "loud"`)
+}
+
+func TestSubsystemLevels(t *testing.T) {
+	out := new(bytes.Buffer)
+	logger, err := NewLogger(
+		WithLevel("info"),
+		WithSubsystemLevels(map[string]string{"ca": "debug", "datastore": "warn"}),
+		WithFormat(JSONFormat),
+	)
+	require.NoError(t, err)
+	logger.SetOutput(out)
+
+	logger.Debug("global debug")
+	logger.Info("global info")
+	logger.WithField("subsystem_name", "ca").Debug("ca debug")
+	logger.WithField("subsystem_name", "ca").Trace("ca trace")
+	logger.WithField("subsystem_name", "catalog").WithField("plugin_type", "DataStore").Info("datastore info")
+	logger.WithField("subsystem_name", "catalog").WithField("plugin_type", "DataStore").Warn("datastore warn")
+	require.Equal(t, []string{"global info", "ca debug", "datastore warn"}, readMessages(t, out))
+
+	// Levels can be changed while the logger is in use
+	levels, err := ParseLevels("debug", nil)
+	require.NoError(t, err)
+	logger.SetLevels(levels)
+	require.Equal(t, levels, logger.GetLevels())
+
+	logger.Debug("global debug")
+	logger.WithField("subsystem_name", "catalog").WithField("plugin_type", "DataStore").Info("datastore info")
+	logger.WithField("subsystem_name", "ca").Trace("ca trace")
+	require.Equal(t, []string{"global debug", "datastore info"}, readMessages(t, out))
+}
+
+func readMessages(t *testing.T, out *bytes.Buffer) []string {
+	var msgs []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var data map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &data))
+		assert.Contains(t, data, JSONTimeKey)
+		assert.Contains(t, data, JSONLevelKey)
+		msgs = append(msgs, data[JSONMsgKey].(string))
+	}
+	out.Reset()
+	return msgs
+}
@@ -10,14 +10,18 @@ import (
 type Logger struct {
 	*logrus.Logger
 	io.Closer
+
+	levelFilter *levelFilter
 }
 
 func NewLogger(options ...Option) (*Logger, error) {
 	logger := &Logger{
-		Logger: logrus.New(),
-		Closer: nopCloser{},
+		Logger:      logrus.New(),
+		Closer:      nopCloser{},
+		levelFilter: new(levelFilter),
 	}
 	logger.SetOutput(os.Stdout)
+	logger.levelFilter.set(Levels{Level: logger.GetLevel()})
 
 	for _, option := range options {
 		if err := option(logger); err != nil {
@@ -25,6 +29,12 @@ func NewLogger(options ...Option) (*Logger, error) {
 		}
 	}
 
+	// Options may have replaced the formatter after the subsystem overrides
+	// were set
+	if len(logger.GetLevels().Subsystems) > 0 {
+		logger.installLevelFilter()
+	}
+
 	return logger, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -14,6 +15,27 @@ const (
 	TextFormat    = "TEXT"
 )
 
+// Field names of the JSON log output. They are part of the log format and
+// must not change.
+const (
+	JSONTimeKey  = "time"
+	JSONLevelKey = "level"
+	JSONMsgKey   = "msg"
+)
+
+// newJSONFormatter returns a JSON formatter with stable field names and
+// timestamps in RFC 3339 format.
+func newJSONFormatter() logrus.Formatter {
+	return &logrus.JSONFormatter{
+		TimestampFormat: time.RFC3339,
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyTime:  JSONTimeKey,
+			logrus.FieldKeyLevel: JSONLevelKey,
+			logrus.FieldKeyMsg:   JSONMsgKey,
+		},
+	}
+}
+
 // An Option can change the Logger to apply desired configuration in NewLogger
 type Option func(*Logger) error
 
@@ -47,7 +69,7 @@ func WithFormat(format string) Option {
 		case DefaultFormat:
 			// Logrus has a default formatter set up in logrus.New(), so we don't change it
 		case JSONFormat:
-			logger.Formatter = &logrus.JSONFormatter{}
+			logger.Formatter = newJSONFormatter()
 		case TextFormat:
 			logger.Formatter = &logrus.TextFormatter{}
 		default:
@@ -63,7 +85,9 @@ func WithLevel(logLevel string) Option {
 		if err != nil {
 			return err
 		}
-		logger.SetLevel(level)
+		levels := logger.GetLevels()
+		levels.Level = level
+		logger.SetLevels(levels)
 		return nil
 	}
 }
@@ -0,0 +1,143 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// WithRotatingOutputFile writes the logs to the given file, rotating it when
+// a write would grow it beyond maxSize bytes. Up to maxBackups rotated files
+// are kept, named <file>.1 (the most recent) through <file>.<maxBackups>.
+// A maxSize of zero disables rotation.
+func WithRotatingOutputFile(file string, maxSize int64, maxBackups int) Option {
+	if maxSize <= 0 {
+		return WithOutputFile(file)
+	}
+
+	return func(logger *Logger) error {
+		if file == "" {
+			return nil
+		}
+		if maxBackups < 0 {
+			return fmt.Errorf("invalid number of log file backups: %d", maxBackups)
+		}
+
+		rf, err := openRotatingFile(file, maxSize, maxBackups)
+		if err != nil {
+			return err
+		}
+
+		logger.SetOutput(rf)
+
+		// If, for some reason, there's another closer set, close it first.
+		if logger.Closer != nil {
+			if err := logger.Closer.Close(); err != nil {
+				return err
+			}
+		}
+
+		logger.Closer = rf
+		return nil
+	}
+}
+
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	rf := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.f == nil {
+		// A previous rotation failed to reopen the file; try again.
+		if err := rf.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.f.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.f == nil {
+		return nil
+	}
+	err := rf.f.Close()
+	rf.f = nil
+	return err
+}
+
+func (rf *rotatingFile) open() error {
+	f, err := os.OpenFile(rf.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	rf.f = f
+	rf.size = info.Size()
+	return nil
+}
+
+func (rf *rotatingFile) rotate() error {
+	if err := rf.f.Close(); err != nil {
+		return err
+	}
+	rf.f = nil
+
+	if rf.maxBackups == 0 {
+		if err := os.Remove(rf.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return rf.open()
+	}
+
+	// Shift the existing backups, dropping the oldest one
+	for i := rf.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(rf.backupPath(i), rf.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(rf.path, rf.backupPath(1)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return rf.open()
+}
+
+func (rf *rotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", rf.path, n)
+}
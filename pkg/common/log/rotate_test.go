@@ -0,0 +1,112 @@
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "audit.log")
+
+	rf, err := openRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	write := func(s string) {
+		_, err := rf.Write([]byte(s))
+		require.NoError(t, err)
+	}
+
+	// Writes that fit are appended
+	write("aaaa")
+	write("bbbb")
+	assertFileContent(t, path, "aaaabbbb")
+
+	// Writes that overflow the file rotate it
+	write("cccc")
+	assertFileContent(t, path, "cccc")
+	assertFileContent(t, path+".1", "aaaabbbb")
+
+	// Writes larger than the max size are still written
+	write("dddddddddddd")
+	assertFileContent(t, path, "dddddddddddd")
+	assertFileContent(t, path+".1", "cccc")
+	assertFileContent(t, path+".2", "aaaabbbb")
+
+	// The oldest backup is dropped
+	write("eeee")
+	assertFileContent(t, path, "eeee")
+	assertFileContent(t, path+".1", "dddddddddddd")
+	assertFileContent(t, path+".2", "cccc")
+	assert.NoFileExists(t, path+".3")
+
+	require.NoError(t, rf.Close())
+
+	// Reopening the file keeps track of the existing size
+	rf, err = openRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	write("fffffff")
+	assertFileContent(t, path, "fffffff")
+	assertFileContent(t, path+".1", "eeee")
+	require.NoError(t, rf.Close())
+}
+
+func TestRotatingFileWithoutBackups(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "audit.log")
+
+	rf, err := openRotatingFile(path, 4, 0)
+	require.NoError(t, err)
+	defer rf.Close()
+
+	_, err = rf.Write([]byte("aaaa"))
+	require.NoError(t, err)
+	_, err = rf.Write([]byte("bbbb"))
+	require.NoError(t, err)
+
+	assertFileContent(t, path, "bbbb")
+	assert.NoFileExists(t, path+".1")
+}
+
+func TestWithRotatingOutputFile(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "audit.log")
+
+	logger, err := NewLogger(WithRotatingOutputFile(path, 1, 1), WithFormat(JSONFormat))
+	require.NoError(t, err)
+
+	logger.Warning("first")
+	logger.Warning("second")
+	require.NoError(t, logger.Close())
+
+	current, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), `"msg":"second"`)
+
+	backup, err := ioutil.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Contains(t, string(backup), `"msg":"first"`)
+
+	_, err = NewLogger(WithRotatingOutputFile(path, 1, -1))
+	require.EqualError(t, err, "invalid number of log file backups: -1")
+}
+
+func assertFileContent(t *testing.T, path, expected string) {
+	actual, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "log-rotate-test-")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+	return dir
+}
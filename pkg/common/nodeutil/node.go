@@ -15,6 +15,17 @@ func IsAgentBanned(node *common.AttestedNode) bool {
 	return node.CertSerialNumber == ""
 }
 
+// ReattestSerialNumber is the X509 SVID serial number recorded for agents
+// forced to re-attest. Serial numbers are recorded in decimal, so it does not
+// match the SVID of any agent and the agent has to attest again.
+const ReattestSerialNumber = "reattest"
+
+// IsAgentForcedToReattest determines if a given attested node was forced to
+// re-attest.
+func IsAgentForcedToReattest(node *common.AttestedNode) bool {
+	return node.CertSerialNumber == ReattestSerialNumber
+}
+
 // ShouldAgentReattest returns true if the Server returned an error worth rebooting the Agent
 func ShouldAgentReattest(err error) bool {
 	errStatus := status.Convert(errors.Unwrap(err))
@@ -2,6 +2,7 @@ package peertracker
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 
 	"google.golang.org/grpc/credentials"
@@ -73,3 +74,40 @@ func AuthInfoFromContext(ctx context.Context) (AuthInfo, bool) {
 	ai, ok := peer.AuthInfo.(AuthInfo)
 	return ai, ok
 }
+
+type tlsCredentials struct {
+	grpcCredentials
+	config *tls.Config
+}
+
+// NewTLSCredentials returns credentials that perform a TLS handshake, using
+// the provided configuration, on top of the connections accepted by the peer
+// tracking listener. The peer tracking information remains available to the
+// handlers.
+func NewTLSCredentials(config *tls.Config) credentials.TransportCredentials {
+	return &tlsCredentials{
+		config: config,
+	}
+}
+
+func (c *tlsCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	wrappedCon, ok := conn.(*Conn)
+	if !ok {
+		conn.Close()
+		return conn, AuthInfo{}, ErrInvalidConnection
+	}
+
+	tlsConn := tls.Server(wrappedCon, c.config)
+	if err := tlsConn.Handshake(); err != nil {
+		tlsConn.Close()
+		return conn, AuthInfo{}, err
+	}
+
+	return tlsConn, wrappedCon.Info, nil
+}
+
+func (c *tlsCredentials) Clone() credentials.TransportCredentials {
+	return &tlsCredentials{
+		config: c.config.Clone(),
+	}
+}
@@ -4,6 +4,7 @@ import "errors"
 
 var (
 	ErrInvalidConnection    = errors.New("invalid connection")
+	ErrNonLoopbackCaller    = errors.New("caller is not connected over the loopback interface")
 	ErrUnsupportedPlatform  = errors.New("unsupported platform")
 	ErrUnsupportedTransport = errors.New("unsupported transport")
 )
@@ -13,6 +13,7 @@ type ListenerFactory struct {
 	Log             logrus.FieldLogger
 	NewTracker      func() (PeerTracker, error)
 	NewUnixListener func(network string, laddr *net.UnixAddr) (*net.UnixListener, error)
+	NewTCPListener  func(network string, laddr *net.TCPAddr) (*net.TCPListener, error)
 }
 
 type Listener struct {
@@ -34,6 +35,22 @@ func (lf *ListenerFactory) ListenUnix(network string, laddr *net.UnixAddr) (*Lis
 	return lf.listenUnix(network, laddr)
 }
 
+// ListenTCP creates a listener for TCP connections. Callers are tracked the
+// same way as with UNIX domain sockets, which is only possible for
+// connections established over the loopback interface.
+func (lf *ListenerFactory) ListenTCP(network string, laddr *net.TCPAddr) (*Listener, error) {
+	if lf.NewTCPListener == nil {
+		lf.NewTCPListener = net.ListenTCP
+	}
+	if lf.NewTracker == nil {
+		lf.NewTracker = NewTracker
+	}
+	if lf.Log == nil {
+		lf.Log = newNoopLogger()
+	}
+	return lf.listenTCP(network, laddr)
+}
+
 func newNoopLogger() *logrus.Logger {
 	logger := logrus.New()
 	logger.Out = ioutil.Discard
@@ -59,6 +76,25 @@ func (lf *ListenerFactory) listenUnix(network string, laddr *net.UnixAddr) (*Lis
 	}, nil
 }
 
+func (lf *ListenerFactory) listenTCP(network string, laddr *net.TCPAddr) (*Listener, error) {
+	l, err := lf.NewTCPListener(network, laddr)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker, err := lf.NewTracker()
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	return &Listener{
+		l:       l,
+		Tracker: tracker,
+		log:     lf.Log,
+	}, nil
+}
+
 func (l *Listener) Accept() (net.Conn, error) {
 	for {
 		var caller CallerInfo
@@ -73,6 +109,8 @@ func (l *Listener) Accept() (net.Conn, error) {
 		switch conn.RemoteAddr().Network() {
 		case "unix":
 			caller, err = CallerFromUDSConn(conn)
+		case "tcp":
+			caller, err = CallerFromTCPConn(conn)
 		default:
 			err = ErrUnsupportedTransport
 		}
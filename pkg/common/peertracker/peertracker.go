@@ -2,7 +2,7 @@
 // API. It does so in part by implementing the `net.Listener` interface and
 // the gRPC credential interface, the functions of which are dependent on the
 // underlying platform. Currently, only UNIX domain sockets on Linux, Darwin,
-// and the BSDs are supported.
+// and the BSDs, and loopback TCP connections on Linux, are supported.
 //
 // To accomplish the attestation security required by SPIFFE and SPIRE, this
 // package provides process tracking - namely, exit detection. By using the
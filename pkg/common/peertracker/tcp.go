@@ -0,0 +1,31 @@
+package peertracker
+
+import (
+	"net"
+)
+
+// CallerFromTCPConn returns the information of the process on the other end
+// of a TCP connection. Only connections established over the loopback
+// interface are supported, since the caller must be a process running on the
+// same host.
+func CallerFromTCPConn(conn net.Conn) (CallerInfo, error) {
+	localAddr, ok := conn.LocalAddr().(*net.TCPAddr)
+	if !ok {
+		return CallerInfo{}, ErrInvalidConnection
+	}
+	remoteAddr, ok := conn.RemoteAddr().(*net.TCPAddr)
+	if !ok {
+		return CallerInfo{}, ErrInvalidConnection
+	}
+	if !remoteAddr.IP.IsLoopback() {
+		return CallerInfo{}, ErrNonLoopbackCaller
+	}
+
+	info, err := getTCPCallerInfo(localAddr, remoteAddr)
+	if err != nil {
+		return info, err
+	}
+
+	info.Addr = remoteAddr
+	return info, nil
+}
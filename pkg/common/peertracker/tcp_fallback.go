@@ -0,0 +1,11 @@
+// +build !linux
+
+package peertracker
+
+import (
+	"net"
+)
+
+func getTCPCallerInfo(localAddr, remoteAddr *net.TCPAddr) (CallerInfo, error) {
+	return CallerInfo{}, ErrUnsupportedPlatform
+}
@@ -0,0 +1,170 @@
+// +build linux
+
+package peertracker
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// procRoot is the location of the proc filesystem.
+var procRoot = "/proc"
+
+// getTCPCallerInfo finds the process that owns the client end of a loopback
+// TCP connection. The socket of the caller is looked up in the kernel socket
+// tables to obtain its inode, which is then matched against the file
+// descriptors held by the running processes.
+func getTCPCallerInfo(localAddr, remoteAddr *net.TCPAddr) (CallerInfo, error) {
+	var inode string
+	var uid uint32
+	var err error
+	for _, table := range []string{"tcp", "tcp6"} {
+		inode, uid, err = findTCPSocket(filepath.Join(procRoot, "net", table), remoteAddr, localAddr)
+		if err != nil {
+			return CallerInfo{}, err
+		}
+		if inode != "" {
+			break
+		}
+	}
+	if inode == "" {
+		return CallerInfo{}, errors.New("unable to find the caller socket")
+	}
+
+	pid, err := findSocketOwner(inode)
+	if err != nil {
+		return CallerInfo{}, err
+	}
+
+	var stat syscall.Stat_t
+	if err := syscall.Stat(filepath.Join(procRoot, strconv.Itoa(int(pid))), &stat); err != nil {
+		return CallerInfo{}, fmt.Errorf("unable to stat caller process: %v", err)
+	}
+
+	return CallerInfo{
+		PID: pid,
+		UID: uid,
+		GID: stat.Gid,
+	}, nil
+}
+
+// findTCPSocket looks up the socket with the given local and remote
+// addresses in a kernel socket table (e.g. /proc/net/tcp), returning its
+// inode and owner. An empty inode is returned if the socket is not found.
+func findTCPSocket(path string, localAddr, remoteAddr *net.TCPAddr) (string, uint32, error) {
+	f, err := os.Open(path)
+	switch {
+	case os.IsNotExist(err):
+		// IPv6 might be disabled
+		return "", 0, nil
+	case err != nil:
+		return "", 0, fmt.Errorf("unable to open socket table: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	// Skip the header
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		local, err := parseSocketTableAddr(fields[1])
+		if err != nil {
+			return "", 0, err
+		}
+		remote, err := parseSocketTableAddr(fields[2])
+		if err != nil {
+			return "", 0, err
+		}
+		if !tcpAddrEqual(local, localAddr) || !tcpAddrEqual(remote, remoteAddr) {
+			continue
+		}
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			return "", 0, fmt.Errorf("malformed socket uid %q: %v", fields[7], err)
+		}
+		return fields[9], uint32(uid), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", 0, fmt.Errorf("unable to read socket table: %v", err)
+	}
+	return "", 0, nil
+}
+
+// parseSocketTableAddr parses an address as found in the kernel socket
+// tables, e.g. "0100007F:1F90". The IP address is written as a sequence of
+// 32-bit words in host byte order, which is little endian on the platforms
+// SPIRE supports, while the port is written in big endian.
+func parseSocketTableAddr(s string) (*net.TCPAddr, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed socket address %q", s)
+	}
+	ip, err := hex.DecodeString(parts[0])
+	if err != nil || (len(ip) != net.IPv4len && len(ip) != net.IPv6len) {
+		return nil, fmt.Errorf("malformed socket address %q", s)
+	}
+	for i := 0; i < len(ip); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = ip[i+3], ip[i+2], ip[i+1], ip[i]
+	}
+	port, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("malformed socket address %q", s)
+	}
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
+
+func tcpAddrEqual(a, b *net.TCPAddr) bool {
+	return a.Port == b.Port && a.IP.Equal(b.IP)
+}
+
+// findSocketOwner returns the PID of the process that holds a file
+// descriptor for the socket with the given inode.
+func findSocketOwner(inode string) (int32, error) {
+	procs, err := readDirNames(procRoot)
+	if err != nil {
+		return 0, fmt.Errorf("unable to list processes: %v", err)
+	}
+
+	target := "socket:[" + inode + "]"
+	for _, proc := range procs {
+		pid, err := strconv.ParseInt(proc, 10, 32)
+		if err != nil {
+			// Not a process directory
+			continue
+		}
+		fdDir := filepath.Join(procRoot, proc, "fd")
+		fds, err := readDirNames(fdDir)
+		if err != nil {
+			// The process may have exited or belong to a user whose file
+			// descriptors cannot be inspected
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd))
+			if err == nil && link == target {
+				return int32(pid), nil
+			}
+		}
+	}
+	return 0, errors.New("unable to find the caller process")
+}
+
+func readDirNames(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Readdirnames(-1)
+}
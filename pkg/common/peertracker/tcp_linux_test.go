@@ -0,0 +1,95 @@
+// +build linux
+
+package peertracker
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallerFromTCPConn(t *testing.T) {
+	for _, network := range []string{"tcp4", "tcp6"} {
+		network := network
+		t.Run(network, func(t *testing.T) {
+			addr := "127.0.0.1:0"
+			if network == "tcp6" {
+				addr = "[::1]:0"
+			}
+			l, err := net.Listen(network, addr)
+			if err != nil {
+				t.Skipf("%s loopback not available: %v", network, err)
+			}
+			defer l.Close()
+
+			client, err := net.Dial(network, l.Addr().String())
+			require.NoError(t, err)
+			defer client.Close()
+
+			conn, err := l.Accept()
+			require.NoError(t, err)
+			defer conn.Close()
+
+			info, err := CallerFromTCPConn(conn)
+			require.NoError(t, err)
+			assert.Equal(t, int32(os.Getpid()), info.PID)
+			assert.Equal(t, uint32(os.Getuid()), info.UID)
+			assert.Equal(t, uint32(os.Getgid()), info.GID)
+			assert.Equal(t, client.LocalAddr().String(), info.Addr.String())
+		})
+	}
+}
+
+func TestParseSocketTableAddr(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		in        string
+		expected  string
+		expectErr string
+	}{
+		{
+			name:     "ipv4",
+			in:       "0100007F:1F90",
+			expected: "127.0.0.1:8080",
+		},
+		{
+			name:     "ipv6",
+			in:       "00000000000000000000000001000000:1F90",
+			expected: "[::1]:8080",
+		},
+		{
+			name:     "ipv4 mapped ipv6",
+			in:       "0000000000000000FFFF00000100007F:1F90",
+			expected: "127.0.0.1:8080",
+		},
+		{
+			name:      "missing port",
+			in:        "0100007F",
+			expectErr: `malformed socket address "0100007F"`,
+		},
+		{
+			name:      "bad address",
+			in:        "0100:1F90",
+			expectErr: `malformed socket address "0100:1F90"`,
+		},
+		{
+			name:      "bad port",
+			in:        "0100007F:XYZ",
+			expectErr: `malformed socket address "0100007F:XYZ"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := parseSocketTableAddr(tt.in)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, addr.String())
+		})
+	}
+}
@@ -26,16 +26,24 @@ func TestAllTrueMasks(t *testing.T) {
 	}, protoutil.AllTrueBundleMask)
 
 	assert.Equal(t, &types.EntryMask{
-		SpiffeId:       true,
-		ParentId:       true,
-		Selectors:      true,
-		Ttl:            true,
-		FederatesWith:  true,
-		Admin:          true,
-		Downstream:     true,
-		ExpiresAt:      true,
-		DnsNames:       true,
-		RevisionNumber: true,
+		SpiffeId:           true,
+		ParentId:           true,
+		Selectors:          true,
+		Ttl:                true,
+		FederatesWith:      true,
+		Admin:              true,
+		Downstream:         true,
+		ExpiresAt:          true,
+		DnsNames:           true,
+		RevisionNumber:     true,
+		JwtSvidClaims:      true,
+		JwtSvidAudience:    true,
+		X509SvidKeyType:    true,
+		DnsNameTemplates:   true,
+		X509SvidSubject:    true,
+		Hint:               true,
+		SelectorExpression: true,
+		TemplateId:         true,
 	}, protoutil.AllTrueEntryMask)
 
 	assert.Equal(t, &common.BundleMask{
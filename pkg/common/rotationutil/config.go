@@ -0,0 +1,45 @@
+package rotationutil
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config is the HCL configuration of a rotation threshold
+type Config struct {
+	// Fraction of the SVID lifetime left when the SVID is rotated
+	Fraction float64 `hcl:"fraction"`
+
+	// Before is how long before expiration the SVID is rotated (e.g. "10m")
+	Before string `hcl:"before"`
+
+	// Jitter is the maximum fraction of the SVID lifetime by which the
+	// rotation is brought forward
+	Jitter float64 `hcl:"jitter"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+// Threshold parses and validates the configuration. A nil configuration
+// returns the default threshold.
+func (c *Config) Threshold() (Threshold, error) {
+	if c == nil {
+		return Threshold{}, nil
+	}
+
+	t := Threshold{
+		Fraction: c.Fraction,
+		Jitter:   c.Jitter,
+	}
+	if c.Before != "" {
+		before, err := time.ParseDuration(c.Before)
+		if err != nil {
+			return Threshold{}, fmt.Errorf("could not parse rotation threshold %q: %v", c.Before, err)
+		}
+		t.Before = before
+	}
+	if err := t.Validate(); err != nil {
+		return Threshold{}, err
+	}
+	return t, nil
+}
@@ -1,16 +1,120 @@
 package rotationutil
 
 import (
+	"bytes"
 	"crypto/x509"
+	"errors"
+	"hash/fnv"
+	"math"
 	"time"
 
 	"github.com/spiffe/spire/pkg/agent/client"
 )
 
+// DefaultFraction is the fraction of the lifetime of an SVID left when it is
+// rotated, unless configured otherwise.
+const DefaultFraction = 1.0 / 2
+
+// Threshold determines how long before expiration SVIDs are rotated. The zero
+// value rotates SVIDs when half of their lifetime is left.
+type Threshold struct {
+	// Fraction of the SVID lifetime left when the SVID is rotated. Defaults
+	// to DefaultFraction.
+	Fraction float64
+
+	// Before is how long before expiration the SVID is rotated. When set, it
+	// takes precedence over Fraction, unless it is not shorter than the
+	// lifetime of the SVID.
+	Before time.Duration
+
+	// Jitter is the maximum fraction of the SVID lifetime by which the
+	// rotation is brought forward. The amount is derived from the SVID, so
+	// SVIDs issued at the same time are rotated at different times, while
+	// the rotation time of a given SVID stays the same between checks.
+	Jitter float64
+}
+
+// Validate returns an error if the threshold is out of range, e.g. if SVIDs
+// would be rotated as soon as they are issued.
+func (t Threshold) Validate() error {
+	switch {
+	case t.Fraction < 0 || t.Fraction >= 1:
+		return errors.New("rotation fraction must be between 0 and 1")
+	case t.Before < 0:
+		return errors.New("rotation threshold must not be negative")
+	case t.Jitter < 0 || t.Jitter >= 1:
+		return errors.New("rotation jitter must be between 0 and 1")
+	case t.fraction()+t.Jitter >= 1:
+		return errors.New("rotation fraction plus jitter must be less than 1")
+	}
+	return nil
+}
+
+// ShouldRotateX509 determines if the given X509-SVID should be rotated, based
+// on the presented current time and the certificate's expiration.
+func (t Threshold) ShouldRotateX509(now time.Time, cert *x509.Certificate) bool {
+	return t.shouldRotate(now, cert.NotBefore, cert.NotAfter, cert.Raw)
+}
+
+// JWTSVIDExpiresSoon determines if the given JWT-SVID should be rotated,
+// based on the presented current time and the JWT's expiration. Also returns
+// true if the JWT is already expired.
+func (t Threshold) JWTSVIDExpiresSoon(svid *client.JWTSVID, now time.Time) bool {
+	if JWTSVIDExpired(svid, now) {
+		return true
+	}
+	return t.shouldRotate(now, svid.IssuedAt, svid.ExpiresAt, []byte(svid.Token))
+}
+
+// JWTSVIDRotationTime returns when the given JWT-SVID should be rotated, i.e.
+// the earliest time at which JWTSVIDExpiresSoon returns true.
+func (t Threshold) JWTSVIDRotationTime(svid *client.JWTSVID) time.Time {
+	lifetime := svid.ExpiresAt.Sub(svid.IssuedAt)
+	return svid.ExpiresAt.Add(-t.threshold(lifetime, []byte(svid.Token)))
+}
+
+func (t Threshold) shouldRotate(now, beginTime, expiryTime time.Time, seed []byte) bool {
+	ttl := expiryTime.Sub(now)
+	lifetime := expiryTime.Sub(beginTime)
+	return ttl <= t.threshold(lifetime, seed)
+}
+
+// threshold returns how much of the given lifetime is left when the SVID
+// is rotated.
+func (t Threshold) threshold(lifetime time.Duration, seed []byte) time.Duration {
+	var jitter time.Duration
+	if t.Jitter > 0 {
+		h := fnv.New64a()
+		_, _ = h.Write(seed)
+		jitter = time.Duration(float64(lifetime) * t.Jitter * float64(h.Sum64()) / math.MaxUint64)
+	}
+
+	if t.Before > 0 && t.Before+jitter < lifetime {
+		return t.Before + jitter
+	}
+	return time.Duration(float64(lifetime)*t.fraction()) + jitter
+}
+
+func (t Threshold) fraction() float64 {
+	if t.Fraction == 0 {
+		return DefaultFraction
+	}
+	return t.Fraction
+}
+
 // ShouldRotateX509 determines if a given SVID should be rotated, based
 // on presented current time, and the certificate's expiration.
 func ShouldRotateX509(now time.Time, cert *x509.Certificate) bool {
-	return shouldRotate(now, cert.NotBefore, cert.NotAfter)
+	return Threshold{}.ShouldRotateX509(now, cert)
+}
+
+// X509ExpiresSoon returns true if the given X509 cert has less than a tenth
+// of its lifetime left, or has already expired. It is used to decide when to
+// stop retrying a failing renewal and take a more drastic recovery action.
+func X509ExpiresSoon(now time.Time, cert *x509.Certificate) bool {
+	ttl := cert.NotAfter.Sub(now)
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return ttl <= lifetime/10
 }
 
 // X509Expired returns true if the given X509 cert has expired
@@ -18,26 +122,42 @@ func X509Expired(now time.Time, cert *x509.Certificate) bool {
 	return now.After(cert.NotAfter)
 }
 
+// X509SignedByTaintedAuthority returns true if any certificate in the given
+// X509 chain is, or was issued by, one of the given tainted authorities.
+func X509SignedByTaintedAuthority(chain []*x509.Certificate, taintedAuthorities []*x509.Certificate) bool {
+	for _, cert := range chain {
+		for _, tainted := range taintedAuthorities {
+			if bytes.Equal(cert.Raw, tainted.Raw) {
+				return true
+			}
+			if issuedBy(cert, tainted) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// issuedBy returns true if cert was issued by the given authority. The
+// authority key ID is used when present. Otherwise, since it is omitted when
+// the issuer and subject names match, the signature is checked instead.
+func issuedBy(cert, authority *x509.Certificate) bool {
+	if len(cert.AuthorityKeyId) > 0 {
+		return bytes.Equal(cert.AuthorityKeyId, authority.SubjectKeyId)
+	}
+	return bytes.Equal(cert.RawIssuer, authority.RawSubject) && cert.CheckSignatureFrom(authority) == nil
+}
+
 // JWTSVIDExpiresSoon determines if the given JWT SVID should be rotated
 // based on presented current time, the JWT's expiration.
 // Also returns true if the JWT is already expired.
 func JWTSVIDExpiresSoon(svid *client.JWTSVID, now time.Time) bool {
-	if JWTSVIDExpired(svid, now) {
-		return true
-	}
-
 	// if the SVID has less than half of its lifetime left, consider it
 	// as expiring soon
-	return shouldRotate(now, svid.IssuedAt, svid.ExpiresAt)
+	return Threshold{}.JWTSVIDExpiresSoon(svid, now)
 }
 
 // JWTSVIDExpired returns true if the given SVID is expired.
 func JWTSVIDExpired(svid *client.JWTSVID, now time.Time) bool {
 	return !now.Before(svid.ExpiresAt)
 }
-
-func shouldRotate(now, beginTime, expiryTime time.Time) bool {
-	ttl := expiryTime.Sub(now)
-	lifetime := expiryTime.Sub(beginTime)
-	return ttl <= lifetime/2
-}
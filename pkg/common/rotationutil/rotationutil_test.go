@@ -1,6 +1,7 @@
 package rotationutil
 
 import (
+	"crypto/x509"
 	"testing"
 	"time"
 
@@ -31,6 +32,101 @@ func TestShouldRotateX509(t *testing.T) {
 	assert.True(t, ShouldRotateX509(mockClk.Now(), badCert))
 }
 
+func TestThresholdShouldRotateX509(t *testing.T) {
+	// Cert that's valid for 1hr
+	mockClk := clock.NewMock(t)
+	temp, err := util.NewSVIDTemplate(mockClk, "spiffe://example.org/test")
+	require.NoError(t, err)
+	cert, _, err := util.SelfSign(temp)
+	require.NoError(t, err)
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+
+	// Rotated when a quarter of the lifetime is left
+	quarter := Threshold{Fraction: 0.25}
+	assert.False(t, quarter.ShouldRotateX509(cert.NotAfter.Add(-lifetime/2), cert))
+	assert.True(t, quarter.ShouldRotateX509(cert.NotAfter.Add(-lifetime/4), cert))
+
+	// Rotated ten minutes before expiration
+	before := Threshold{Before: 10 * time.Minute}
+	assert.False(t, before.ShouldRotateX509(cert.NotAfter.Add(-11*time.Minute), cert))
+	assert.True(t, before.ShouldRotateX509(cert.NotAfter.Add(-10*time.Minute), cert))
+
+	// The fraction is used when the cert lives less than the threshold
+	tooLong := Threshold{Before: 2 * time.Hour}
+	assert.False(t, tooLong.ShouldRotateX509(cert.NotAfter.Add(-lifetime/2-time.Second), cert))
+	assert.True(t, tooLong.ShouldRotateX509(cert.NotAfter.Add(-lifetime/2), cert))
+
+	// Jitter brings the rotation forward by up to the given fraction of the
+	// lifetime, always by the same amount for a given cert
+	jitter := Threshold{Jitter: 0.25}
+	offset := jitter.threshold(lifetime, cert.Raw) - lifetime/2
+	assert.True(t, offset >= 0 && offset < lifetime/4, "unexpected jitter %s", offset)
+	assert.Equal(t, offset, jitter.threshold(lifetime, cert.Raw)-lifetime/2)
+	assert.False(t, jitter.ShouldRotateX509(cert.NotAfter.Add(-lifetime/2-offset-time.Second), cert))
+	assert.True(t, jitter.ShouldRotateX509(cert.NotAfter.Add(-lifetime/2-offset), cert))
+}
+
+func TestThresholdValidate(t *testing.T) {
+	assert.NoError(t, Threshold{}.Validate())
+	assert.NoError(t, Threshold{Fraction: 0.8, Jitter: 0.1}.Validate())
+	assert.NoError(t, Threshold{Before: time.Hour, Jitter: 0.1}.Validate())
+	assert.EqualError(t, Threshold{Fraction: 1}.Validate(), "rotation fraction must be between 0 and 1")
+	assert.EqualError(t, Threshold{Fraction: -0.5}.Validate(), "rotation fraction must be between 0 and 1")
+	assert.EqualError(t, Threshold{Before: -time.Minute}.Validate(), "rotation threshold must not be negative")
+	assert.EqualError(t, Threshold{Jitter: 1}.Validate(), "rotation jitter must be between 0 and 1")
+	assert.EqualError(t, Threshold{Fraction: 0.8, Jitter: 0.2}.Validate(), "rotation fraction plus jitter must be less than 1")
+}
+
+func TestX509SignedByTaintedAuthority(t *testing.T) {
+	rootA := &x509.Certificate{Raw: []byte("rootA"), SubjectKeyId: []byte("A")}
+	rootB := &x509.Certificate{Raw: []byte("rootB"), SubjectKeyId: []byte("B")}
+	leafA := &x509.Certificate{Raw: []byte("leafA"), AuthorityKeyId: []byte("A")}
+	leafB := &x509.Certificate{Raw: []byte("leafB"), AuthorityKeyId: []byte("B")}
+	leafNoAKID := &x509.Certificate{Raw: []byte("leaf")}
+
+	assert.False(t, X509SignedByTaintedAuthority([]*x509.Certificate{leafA}, nil))
+	assert.True(t, X509SignedByTaintedAuthority([]*x509.Certificate{leafA}, []*x509.Certificate{rootA}))
+	assert.False(t, X509SignedByTaintedAuthority([]*x509.Certificate{leafB}, []*x509.Certificate{rootA}))
+	assert.False(t, X509SignedByTaintedAuthority([]*x509.Certificate{leafNoAKID}, []*x509.Certificate{{Raw: []byte("root")}}))
+
+	// an intermediate issued by a tainted authority taints the whole chain
+	assert.True(t, X509SignedByTaintedAuthority([]*x509.Certificate{leafB, {Raw: []byte("intermediate"), AuthorityKeyId: []byte("A")}}, []*x509.Certificate{rootA}))
+
+	// the chain includes the tainted authority itself
+	assert.True(t, X509SignedByTaintedAuthority([]*x509.Certificate{leafNoAKID, rootB}, []*x509.Certificate{rootB}))
+
+	// the signature is checked when the authority key ID is omitted
+	mockClk := clock.NewMock(t)
+	caTemplate, err := util.NewCATemplate(mockClk, "example.org")
+	require.NoError(t, err)
+	ca, caKey, err := util.SelfSign(caTemplate)
+	require.NoError(t, err)
+	otherCA, _, err := util.SelfSign(caTemplate)
+	require.NoError(t, err)
+	svidTemplate, err := util.NewSVIDTemplate(mockClk, "spiffe://example.org/test")
+	require.NoError(t, err)
+	svid, _, err := util.Sign(svidTemplate, ca, caKey)
+	require.NoError(t, err)
+	require.Empty(t, svid.AuthorityKeyId)
+
+	assert.True(t, X509SignedByTaintedAuthority([]*x509.Certificate{svid}, []*x509.Certificate{ca}))
+	assert.False(t, X509SignedByTaintedAuthority([]*x509.Certificate{svid}, []*x509.Certificate{otherCA}))
+}
+
+func TestX509ExpiresSoon(t *testing.T) {
+	// Cert that's valid for 1hr
+	mockClk := clock.NewMock(t)
+	temp, err := util.NewSVIDTemplate(mockClk, "spiffe://example.org/test")
+	require.NoError(t, err)
+	cert, _, err := util.SelfSign(temp)
+	require.NoError(t, err)
+
+	assert.False(t, X509ExpiresSoon(mockClk.Now(), cert))
+	assert.False(t, X509ExpiresSoon(cert.NotAfter.Add(-7*time.Minute), cert))
+	assert.True(t, X509ExpiresSoon(cert.NotAfter.Add(-6*time.Minute), cert))
+	assert.True(t, X509ExpiresSoon(cert.NotAfter.Add(time.Minute), cert))
+}
+
 func TestX509Expired(t *testing.T) {
 	// Cert that's valid for 1hr
 	mockClk := clock.NewMock(t)
@@ -85,4 +181,28 @@ func TestJWTSVIDExpiresSoon(t *testing.T) {
 	}
 
 	assert.True(t, JWTSVIDExpiresSoon(expiredJWT, mockClk.Now()))
+
+	// JWT rotated ten minutes before expiration
+	before := Threshold{Before: 10 * time.Minute}
+	assert.False(t, before.JWTSVIDExpiresSoon(goodJWT, goodJWT.ExpiresAt.Add(-11*time.Minute)))
+	assert.True(t, before.JWTSVIDExpiresSoon(goodJWT, goodJWT.ExpiresAt.Add(-10*time.Minute)))
+	assert.True(t, before.JWTSVIDExpiresSoon(expiredJWT, mockClk.Now()))
+}
+
+func TestThresholdJWTSVIDRotationTime(t *testing.T) {
+	mockClk := clock.NewMock(t)
+	jwt := &client.JWTSVID{
+		Token:     "token",
+		IssuedAt:  mockClk.Now(),
+		ExpiresAt: mockClk.Now().Add(time.Hour),
+	}
+
+	assert.Equal(t, jwt.ExpiresAt.Add(-30*time.Minute), Threshold{}.JWTSVIDRotationTime(jwt))
+	assert.Equal(t, jwt.ExpiresAt.Add(-10*time.Minute), Threshold{Before: 10 * time.Minute}.JWTSVIDRotationTime(jwt))
+
+	// The rotation time is the time from which the JWT-SVID expires soon
+	jitter := Threshold{Fraction: 0.25, Jitter: 0.25}
+	rotationTime := jitter.JWTSVIDRotationTime(jwt)
+	assert.False(t, jitter.JWTSVIDExpiresSoon(jwt, rotationTime.Add(-time.Second)))
+	assert.True(t, jitter.JWTSVIDExpiresSoon(jwt, rotationTime))
 }
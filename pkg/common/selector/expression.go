@@ -0,0 +1,356 @@
+package selector
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// MaxExpressionLength is the maximum length of a selector expression.
+const MaxExpressionLength = 4096
+
+// Expression is a boolean expression over a set of selectors, e.g.
+//
+//	k8s:ns:prod && !(k8s:sa:admin || k8s:pod-label:debug:*)
+//
+// Selectors are written as "type:value" and are true when the set contains
+// a selector of that type and value. A "*" in the value matches any
+// sequence of characters. Selectors can be negated with "!", combined with
+// "&&" and "||" ("&&" binds tighter) and grouped with parentheses.
+// Selectors containing whitespace or parentheses must be double quoted.
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// ParseExpression parses a selector expression.
+func ParseExpression(s string) (*Expression, error) {
+	if len(s) > MaxExpressionLength {
+		return nil, fmt.Errorf("expression is longer than %d characters", MaxExpressionLength)
+	}
+	tokens, err := tokenizeExpression(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("expression is empty")
+	}
+
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s at offset %d", p.tokens[p.pos], p.tokens[p.pos].offset)
+	}
+	return &Expression{source: s, root: root}, nil
+}
+
+// ValidateExpression returns an error if the selector expression is
+// malformed. Empty expressions are valid.
+func ValidateExpression(s string) error {
+	if s == "" {
+		return nil
+	}
+	_, err := ParseExpression(s)
+	return err
+}
+
+// MatchesExpression returns whether the selectors satisfy the expression.
+// Empty expressions are satisfied by any set of selectors and malformed
+// expressions are satisfied by none.
+func MatchesExpression(s string, selectors []*common.Selector) bool {
+	if s == "" {
+		return true
+	}
+	expr, err := ParseExpression(s)
+	if err != nil {
+		return false
+	}
+	return expr.Matches(selectors)
+}
+
+// Matches returns whether the selectors satisfy the expression.
+func (e *Expression) Matches(selectors []*common.Selector) bool {
+	return e.root.eval(selectors)
+}
+
+// String returns the expression as it was parsed.
+func (e *Expression) String() string {
+	return e.source
+}
+
+type exprNode interface {
+	eval(selectors []*common.Selector) bool
+}
+
+type notNode struct {
+	operand exprNode
+}
+
+func (n notNode) eval(selectors []*common.Selector) bool {
+	return !n.operand.eval(selectors)
+}
+
+type andNode struct {
+	operands []exprNode
+}
+
+func (n andNode) eval(selectors []*common.Selector) bool {
+	for _, operand := range n.operands {
+		if !operand.eval(selectors) {
+			return false
+		}
+	}
+	return true
+}
+
+type orNode struct {
+	operands []exprNode
+}
+
+func (n orNode) eval(selectors []*common.Selector) bool {
+	for _, operand := range n.operands {
+		if operand.eval(selectors) {
+			return true
+		}
+	}
+	return false
+}
+
+type selectorNode struct {
+	typ   string
+	value string
+}
+
+func (n selectorNode) eval(selectors []*common.Selector) bool {
+	for _, s := range selectors {
+		if s.Type == n.typ && matchWildcard(n.value, s.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcard returns whether the value matches the pattern, where "*"
+// matches any sequence of characters, including the empty one.
+func matchWildcard(pattern, value string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == value
+	}
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, last)
+}
+
+type tokenKind int
+
+const (
+	tokenSelector tokenKind = iota
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenLParen
+	tokenRParen
+)
+
+type exprToken struct {
+	kind   tokenKind
+	value  string
+	offset int
+}
+
+func (t exprToken) String() string {
+	switch t.kind {
+	case tokenNot:
+		return `"!"`
+	case tokenAnd:
+		return `"&&"`
+	case tokenOr:
+		return `"||"`
+	case tokenLParen:
+		return `"("`
+	case tokenRParen:
+		return `")"`
+	default:
+		return fmt.Sprintf("selector %q", t.value)
+	}
+}
+
+func tokenizeExpression(s string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(s); {
+		switch {
+		case unicode.IsSpace(rune(s[i])):
+			i++
+		case s[i] == '(':
+			tokens = append(tokens, exprToken{kind: tokenLParen, offset: i})
+			i++
+		case s[i] == ')':
+			tokens = append(tokens, exprToken{kind: tokenRParen, offset: i})
+			i++
+		case s[i] == '!':
+			tokens = append(tokens, exprToken{kind: tokenNot, offset: i})
+			i++
+		case strings.HasPrefix(s[i:], "&&"):
+			tokens = append(tokens, exprToken{kind: tokenAnd, offset: i})
+			i += 2
+		case strings.HasPrefix(s[i:], "||"):
+			tokens = append(tokens, exprToken{kind: tokenOr, offset: i})
+			i += 2
+		case s[i] == '"':
+			value, n, err := readQuotedSelector(s[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%v at offset %d", err, i)
+			}
+			tokens = append(tokens, exprToken{kind: tokenSelector, value: value, offset: i})
+			i += n
+		default:
+			start := i
+			for i < len(s) && !unicode.IsSpace(rune(s[i])) && s[i] != '(' && s[i] != ')' &&
+				!strings.HasPrefix(s[i:], "&&") && !strings.HasPrefix(s[i:], "||") {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenSelector, value: s[start:i], offset: start})
+		}
+	}
+	return tokens, nil
+}
+
+// readQuotedSelector reads a double quoted selector at the beginning of the
+// string, returning the unquoted selector and the number of bytes consumed.
+// Double quotes and backslashes in the selector are escaped with a
+// backslash.
+func readQuotedSelector(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", 0, errors.New("unterminated quoted selector")
+}
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() (exprToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return exprToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	operand, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	operands := []exprNode{operand}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokenOr {
+			break
+		}
+		p.pos++
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return orNode{operands: operands}, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	operands := []exprNode{operand}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokenAnd {
+			break
+		}
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return andNode{operands: operands}, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, errors.New("unexpected end of expression")
+	}
+	p.pos++
+
+	switch t.kind {
+	case tokenNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	case tokenLParen:
+		operand, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.peek()
+		if !ok || closing.kind != tokenRParen {
+			return nil, fmt.Errorf("missing closing parenthesis for the one at offset %d", t.offset)
+		}
+		p.pos++
+		return operand, nil
+	case tokenSelector:
+		parts := strings.SplitN(t.value, Delimiter, 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("selector %q at offset %d must be formatted as type:value", t.value, t.offset)
+		}
+		if strings.Contains(parts[0], "*") {
+			return nil, fmt.Errorf("selector %q at offset %d has a wildcard in its type", t.value, t.offset)
+		}
+		return selectorNode{typ: parts[0], value: parts[1]}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %s at offset %d", t, t.offset)
+	}
+}
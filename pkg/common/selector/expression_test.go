@@ -0,0 +1,103 @@
+package selector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		err  string
+	}{
+		{name: "selector", expr: "k8s:ns:prod"},
+		{name: "operators", expr: "k8s:ns:prod && !(k8s:sa:admin || k8s:sa:debug)"},
+		{name: "operators without spaces", expr: "!k8s:sa:admin&&(k8s:ns:a||k8s:ns:b)"},
+		{name: "quoted selector", expr: `"docker:label:name:a (b)" && "unix:path:/a\"b"`},
+		{name: "empty", expr: " ", err: "expression is empty"},
+		{name: "too long", expr: strings.Repeat("a", 4097), err: "expression is longer than 4096 characters"},
+		{name: "missing operand", expr: "k8s:ns:prod &&", err: "unexpected end of expression"},
+		{name: "missing operator", expr: "k8s:ns:prod k8s:sa:web", err: `unexpected selector "k8s:sa:web" at offset 12`},
+		{name: "unbalanced parenthesis", expr: "(k8s:ns:prod", err: "missing closing parenthesis for the one at offset 0"},
+		{name: "unexpected parenthesis", expr: "k8s:ns:prod)", err: `unexpected ")" at offset 11`},
+		{name: "unterminated quote", expr: `"k8s:ns:prod`, err: "unterminated quoted selector at offset 0"},
+		{name: "missing value", expr: "k8s", err: `selector "k8s" at offset 0 must be formatted as type:value`},
+		{name: "wildcard type", expr: "k*:ns:prod", err: `selector "k*:ns:prod" at offset 0 has a wildcard in its type`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expr)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expr, expr.String())
+		})
+	}
+}
+
+func TestExpressionMatches(t *testing.T) {
+	selectors := []*common.Selector{
+		{Type: "k8s", Value: "ns:prod"},
+		{Type: "k8s", Value: "sa:web"},
+		{Type: "k8s", Value: "pod-image:registry.example.org/web:1.2"},
+		{Type: "docker", Value: "label:name:a (b)"},
+	}
+
+	tests := []struct {
+		expr    string
+		matches bool
+	}{
+		{expr: "k8s:ns:prod", matches: true},
+		{expr: "k8s:ns:dev", matches: false},
+		{expr: "unix:ns:prod", matches: false},
+		{expr: "!k8s:sa:admin", matches: true},
+		{expr: "!k8s:sa:web", matches: false},
+		{expr: "k8s:ns:prod && !k8s:sa:admin", matches: true},
+		{expr: "k8s:ns:prod && !k8s:sa:web", matches: false},
+		{expr: "k8s:ns:dev || k8s:ns:prod", matches: true},
+		{expr: "k8s:ns:dev || k8s:ns:test", matches: false},
+		{expr: "k8s:ns:dev || k8s:ns:prod && k8s:sa:web", matches: true},
+		{expr: "(k8s:ns:dev || k8s:ns:prod) && k8s:sa:admin", matches: false},
+		{expr: "!!k8s:ns:prod", matches: true},
+		{expr: "k8s:ns:*", matches: true},
+		{expr: "k8s:pod-image:registry.example.org/*", matches: true},
+		{expr: "k8s:pod-image:*/web:*", matches: true},
+		{expr: "k8s:pod-image:*:1.2", matches: true},
+		{expr: "k8s:pod-image:*:1.3", matches: false},
+		{expr: "k8s:pod-image:registry*web*web", matches: false},
+		{expr: "k8s:sa:*admin*", matches: false},
+		{expr: `"docker:label:name:a (b)"`, matches: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := ParseExpression(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, expr.Matches(selectors))
+		})
+	}
+}
+
+func TestMatchesExpression(t *testing.T) {
+	selectors := []*common.Selector{{Type: "unix", Value: "uid:1000"}}
+	assert.True(t, MatchesExpression("", selectors))
+	assert.True(t, MatchesExpression("unix:uid:1000", selectors))
+	assert.False(t, MatchesExpression("unix:uid:0", selectors))
+	assert.False(t, MatchesExpression("unix:uid:1000 &&", selectors))
+}
+
+func TestValidateExpression(t *testing.T) {
+	assert.NoError(t, ValidateExpression(""))
+	assert.NoError(t, ValidateExpression("unix:uid:1000 || unix:uid:0"))
+	assert.EqualError(t, ValidateExpression("unix:uid:1000 ||"), "unexpected end of expression")
+}
@@ -38,3 +38,25 @@ func AddCacheManagerOutdatedSVIDsSample(m telemetry.Metrics, count float32) {
 }
 
 // End Add Samples
+
+// Gauges (metric on the current value of some object, entries, svids...)
+
+// SetCacheManagerEntriesGauge sets the number of registration entries held
+// by the agent cache manager
+func SetCacheManagerEntriesGauge(m telemetry.Metrics, count int) {
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.Entries}, float32(count))
+}
+
+// SetCacheManagerX509SVIDsGauge sets the number of X509-SVIDs held by the
+// agent cache manager
+func SetCacheManagerX509SVIDsGauge(m telemetry.Metrics, count int) {
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.X509SVIDs}, float32(count))
+}
+
+// SetCacheManagerJWTSVIDsGauge sets the number of JWT-SVIDs held by the
+// agent cache manager
+func SetCacheManagerJWTSVIDsGauge(m telemetry.Metrics, count int) {
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.JWTSVIDs}, float32(count))
+}
+
+// End Gauges
@@ -13,4 +13,9 @@ func StartRotateAgentSVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.AgentSVID, telemetry.Rotate)
 }
 
+// StartReattestAgentCall return metric for Agent's re-attestation.
+func StartReattestAgentCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.AgentSVID, telemetry.Reattest)
+}
+
 // End Call Counters
@@ -32,6 +32,14 @@ func IncrConnectionCounter(m telemetry.Metrics) {
 	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.Connection}, 1)
 }
 
+// IncrRateLimitedCounter indicates a Workload API call was delayed
+// because the caller exceeded its rate limit
+func IncrRateLimitedCounter(m telemetry.Metrics, method string) {
+	m.IncrCounterWithLabels([]string{telemetry.WorkloadAPI, telemetry.RateLimited}, 1, []telemetry.Label{
+		{Name: telemetry.Method, Value: method},
+	})
+}
+
 // SetConnectionTotalGauge sets the number of active Workload API connections
 func SetConnectionTotalGauge(m telemetry.Metrics, connections int32) {
 	m.SetGauge([]string{telemetry.WorkloadAPI, telemetry.Connections}, float32(connections))
@@ -8,6 +8,12 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// spanRecorder is implemented by metrics that can export finished calls as
+// trace spans.
+type spanRecorder interface {
+	recordSpan(key []string, start, end time.Time, labels []Label)
+}
+
 // CallCounter is used to track timing and other information about a "call". It
 // is intended to be scoped to a function with a defer and a named error value,
 // if applicable, like so:
@@ -56,6 +62,7 @@ func (c *CallCounter) AddLabel(name, value string) {
 // thread-safe and is intended to be the final call to the CallCounter struct.
 // Emits latency and counter metrics, including adding a Status label according
 // to gRPC code of the given error. If nil error, the code is OK (success).
+// When the metrics support it, the call is also recorded as a trace span.
 func (c *CallCounter) Done(errp *error) {
 	if c.done {
 		return
@@ -71,4 +78,8 @@ func (c *CallCounter) Done(errp *error) {
 
 	c.metrics.IncrCounterWithLabels(key, 1, c.labels)
 	c.metrics.MeasureSinceWithLabels(append(key, ElapsedTime), c.start, c.labels)
+
+	if recorder, ok := c.metrics.(spanRecorder); ok {
+		recorder.recordSpan(key, c.start, time.Now(), c.labels)
+	}
 }
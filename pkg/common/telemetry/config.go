@@ -17,6 +17,13 @@ type FileConfig struct {
 	Statsd     []StatsdConfig    `hcl:"Statsd"`
 	M3         []M3Config        `hcl:"M3"`
 	InMem      *InMem            `hcl:"InMem"`
+	OTLP       *OTLPConfig       `hcl:"OTLP"`
+
+	// AllowedLabels, if set, are the only labels emitted with the metrics
+	AllowedLabels []string `hcl:"AllowedLabels"`
+	// BlockedLabels are never emitted with the metrics, e.g. to avoid high
+	// cardinality labels like the agent ID
+	BlockedLabels []string `hcl:"BlockedLabels"`
 
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
@@ -27,9 +34,12 @@ type DogStatsdConfig struct {
 }
 
 type PrometheusConfig struct {
-	Host       string   `hcl:"host"`
-	Port       int      `hcl:"port"`
-	UnusedKeys []string `hcl:",unusedKeys"`
+	Host string `hcl:"host"`
+	Port int    `hcl:"port"`
+	// HistogramBuckets are the upper bounds, in milliseconds, of the
+	// buckets of the latency histograms
+	HistogramBuckets []float64 `hcl:"histogram_buckets"`
+	UnusedKeys       []string  `hcl:",unusedKeys"`
 }
 
 type StatsdConfig struct {
@@ -43,6 +53,13 @@ type M3Config struct {
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
+type OTLPConfig struct {
+	Endpoint       string            `hcl:"endpoint"`
+	Headers        map[string]string `hcl:"headers"`
+	ExportInterval string            `hcl:"export_interval"`
+	UnusedKeys     []string          `hcl:",unusedKeys"`
+}
+
 type InMem struct {
 	Enabled    *bool    `hcl:"enabled"`
 	UnusedKeys []string `hcl:",unusedKeys"`
@@ -3,6 +3,7 @@ package telemetry
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/armon/go-metrics"
@@ -42,13 +43,22 @@ type Metrics interface {
 type MetricsImpl struct {
 	*metrics.Metrics
 
-	c       *MetricsConfig
+	c *MetricsConfig
+
+	// mtx protects the runners and sinks, which are replaced when the
+	// configuration is reloaded
+	mtx     sync.RWMutex
 	runners []sinkRunner
 	// Each instance of metrics.Metrics in the slice corresponds to one metrics sink type
 	metricsSinks []*metrics.Metrics
+
+	// reloaded is signaled when the runners are replaced so ListenAndServe
+	// restarts them
+	reloaded chan struct{}
 }
 
 var _ Metrics = (*MetricsImpl)(nil)
+var _ spanRecorder = (*MetricsImpl)(nil)
 
 // NewMetrics returns a Metric implementation
 func NewMetrics(c *MetricsConfig) (*MetricsImpl, error) {
@@ -56,12 +66,27 @@ func NewMetrics(c *MetricsConfig) (*MetricsImpl, error) {
 		return nil, errors.New("logger must be configured")
 	}
 
-	impl := &MetricsImpl{c: c}
+	runners, metricsSinks, err := newSinks(c)
+	if err != nil {
+		return nil, err
+	}
 
+	return &MetricsImpl{
+		c:            c,
+		runners:      runners,
+		metricsSinks: metricsSinks,
+		reloaded:     make(chan struct{}, 1),
+	}, nil
+}
+
+func newSinks(c *MetricsConfig) ([]sinkRunner, []*metrics.Metrics, error) {
+	var runners []sinkRunner
+	var metricsSinks []*metrics.Metrics
 	for _, f := range sinkRunnerFactories {
 		runner, err := f(c)
 		if err != nil {
-			return nil, err
+			releaseRunners(runners)
+			return nil, nil, err
 		}
 
 		if !runner.isConfigured() {
@@ -75,30 +100,114 @@ func NewMetrics(c *MetricsConfig) (*MetricsImpl, error) {
 		conf.EnableHostname = false
 		conf.EnableHostnameLabel = true
 		conf.EnableTypePrefix = runner.requiresTypePrefix()
+		conf.AllowedLabels = c.FileConfig.AllowedLabels
+		conf.BlockedLabels = c.FileConfig.BlockedLabels
 
 		metricsSink, err := metrics.New(conf, fanout)
 		if err != nil {
-			return nil, err
+			releaseRunners(append(runners, runner))
+			return nil, nil, err
 		}
 
-		impl.metricsSinks = append(impl.metricsSinks, metricsSink)
-		impl.runners = append(impl.runners, runner)
+		metricsSinks = append(metricsSinks, metricsSink)
+		runners = append(runners, runner)
+	}
+	return runners, metricsSinks, nil
+}
+
+// Reload replaces the metrics sinks with the ones in the given
+// configuration. The sinks are restarted by ListenAndServe. If the new sinks
+// can't be created, the current ones are kept.
+func (m *MetricsImpl) Reload(fileConfig FileConfig) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	// The current sinks are released first since some sinks, like the
+	// Prometheus one, can't be registered twice.
+	releaseRunners(m.runners)
+
+	c := *m.c
+	c.FileConfig = fileConfig
+	runners, metricsSinks, err := newSinks(&c)
+	if err != nil {
+		restoreRunners(m.runners)
+		return err
 	}
 
-	return impl, nil
+	m.c = &c
+	m.runners = runners
+	m.metricsSinks = metricsSinks
+	select {
+	case m.reloaded <- struct{}{}:
+	default:
+	}
+	return nil
 }
 
-// ListenAndServe starts the metrics process
+// ListenAndServe starts the metrics process. The sink runners are restarted
+// every time the configuration is reloaded.
 func (m *MetricsImpl) ListenAndServe(ctx context.Context) error {
-	var tasks []func(context.Context) error
-	for _, runner := range m.runners {
-		tasks = append(tasks, runner.run)
+	for {
+		m.mtx.RLock()
+		var tasks []func(context.Context) error
+		for _, runner := range m.runners {
+			tasks = append(tasks, runner.run)
+		}
+		m.mtx.RUnlock()
+
+		runCtx, cancel := context.WithCancel(ctx)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- util.RunTasks(runCtx, tasks...)
+		}()
+
+		select {
+		case err := <-errCh:
+			if err != nil || ctx.Err() != nil {
+				cancel()
+				return err
+			}
+			// Every runner finished its work; wait for a reload
+			select {
+			case <-m.reloaded:
+				cancel()
+			case <-ctx.Done():
+				cancel()
+				return nil
+			}
+		case <-m.reloaded:
+			cancel()
+			<-errCh
+		}
 	}
+}
 
-	return util.RunTasks(ctx, tasks...)
+// releaser is implemented by the sink runners that hold global resources
+// that must be released before the sinks are replaced.
+type releaser interface {
+	release()
+	restore()
+}
+
+func releaseRunners(runners []sinkRunner) {
+	for _, runner := range runners {
+		if r, ok := runner.(releaser); ok {
+			r.release()
+		}
+	}
+}
+
+func restoreRunners(runners []sinkRunner) {
+	for _, runner := range runners {
+		if r, ok := runner.(releaser); ok {
+			r.restore()
+		}
+	}
 }
 
 func (m *MetricsImpl) SetGauge(key []string, val float32) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	for _, s := range m.metricsSinks {
 		s.SetGauge(key, val)
 	}
@@ -107,18 +216,24 @@ func (m *MetricsImpl) SetGauge(key []string, val float32) {
 // SetGaugeWithLabels delegates to embedded metrics, sanitizing labels
 func (m *MetricsImpl) SetGaugeWithLabels(key []string, val float32, labels []Label) {
 	sanitizedLabels := SanitizeLabels(labels)
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	for _, s := range m.metricsSinks {
 		s.SetGaugeWithLabels(key, val, sanitizedLabels)
 	}
 }
 
 func (m *MetricsImpl) EmitKey(key []string, val float32) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	for _, s := range m.metricsSinks {
 		s.EmitKey(key, val)
 	}
 }
 
 func (m *MetricsImpl) IncrCounter(key []string, val float32) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	for _, s := range m.metricsSinks {
 		s.IncrCounter(key, val)
 	}
@@ -127,12 +242,16 @@ func (m *MetricsImpl) IncrCounter(key []string, val float32) {
 // IncrCounterWithLabels delegates to embedded metrics, sanitizing labels
 func (m *MetricsImpl) IncrCounterWithLabels(key []string, val float32, labels []Label) {
 	sanitizedLabels := SanitizeLabels(labels)
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	for _, s := range m.metricsSinks {
 		s.IncrCounterWithLabels(key, val, sanitizedLabels)
 	}
 }
 
 func (m *MetricsImpl) AddSample(key []string, val float32) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	for _, s := range m.metricsSinks {
 		s.AddSample(key, val)
 	}
@@ -141,12 +260,16 @@ func (m *MetricsImpl) AddSample(key []string, val float32) {
 // AddSampleWithLabels delegates to embedded metrics, sanitizing labels
 func (m *MetricsImpl) AddSampleWithLabels(key []string, val float32, labels []Label) {
 	sanitizedLabels := SanitizeLabels(labels)
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	for _, s := range m.metricsSinks {
 		s.AddSampleWithLabels(key, val, sanitizedLabels)
 	}
 }
 
 func (m *MetricsImpl) MeasureSince(key []string, start time.Time) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	for _, s := range m.metricsSinks {
 		s.MeasureSince(key, start)
 	}
@@ -155,7 +278,21 @@ func (m *MetricsImpl) MeasureSince(key []string, start time.Time) {
 // MeasureSinceWithLabels delegates to embedded metrics, sanitizing labels
 func (m *MetricsImpl) MeasureSinceWithLabels(key []string, start time.Time, labels []Label) {
 	sanitizedLabels := SanitizeLabels(labels)
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
 	for _, s := range m.metricsSinks {
 		s.MeasureSinceWithLabels(key, start, sanitizedLabels)
 	}
 }
+
+// recordSpan delegates to the sink runners that export spans, sanitizing labels
+func (m *MetricsImpl) recordSpan(key []string, start, end time.Time, labels []Label) {
+	sanitizedLabels := SanitizeLabels(labels)
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	for _, runner := range m.runners {
+		if recorder, ok := runner.(spanRecorder); ok {
+			recorder.recordSpan(key, start, end, sanitizedLabels)
+		}
+	}
+}
@@ -22,6 +22,10 @@ const (
 	// to add clarity
 	Attest = "attest"
 
+	// Backup functionality related to backing up some entity; should be used
+	// with other tags to add clarity
+	Backup = "backup"
+
 	// Create functionality related to creating some entity; should be used with other tags
 	// to add clarity
 	Create = "create"
@@ -34,6 +38,10 @@ const (
 	// to add clarity
 	Delete = "delete"
 
+	// Drift functionality related to detecting external copies of some entity
+	// that are out of sync; should be used with other tags to add clarity
+	Drift = "drift"
+
 	// Fetch functionality related to fetching some entity; should be used with other tags
 	// to add clarity
 	Fetch = "fetch"
@@ -58,6 +66,10 @@ const (
 	// (server)
 	GetPublicKeys = "get_public_keys"
 
+	// Issue functionality related to issuing SVIDs to workloads and agents;
+	// should be used with other tags to add clarity
+	Issue = "issue"
+
 	// List functionality related to listing some objects; should be used
 	// with other tags to add clarity
 	List = "list"
@@ -75,9 +87,21 @@ const (
 	// to add clarity
 	Push = "push"
 
+	// RateLimited functionality related to calls delayed by a rate limit;
+	// should be used with other tags to add clarity
+	RateLimited = "rate_limited"
+
+	// Reattest functionality related to performing node attestation again;
+	// should be used with other tags to add clarity
+	Reattest = "reattest"
+
 	// Reload functionality related to reloading of a cache
 	Reload = "reload"
 
+	// Restart functionality related to restarting some entity, such as an
+	// external plugin; should be used with other tags to add clarity
+	Restart = "restart"
+
 	// Rotate functionality related to rotation of SVID; should be used with other tags
 	// to add clarity
 	Rotate = "rotate"
@@ -106,6 +130,10 @@ const (
 	// with other tags to add clarity
 	Update = "update"
 
+	// Use functionality related to using some entity, such as a join token;
+	// should be used with other tags to add clarity
+	Use = "use"
+
 	// Mint functionality related to minting identities
 	Mint = "mint"
 )
@@ -119,12 +147,18 @@ const (
 	// Agent SPIFFE ID
 	AgentID = "agent_id"
 
+	// AppliedSettings tags the configuration settings applied by a reload
+	AppliedSettings = "applied_settings"
+
 	// Attempt tags some count of attempts
 	Attempt = "attempt"
 
 	// Audience tags some audience for a token
 	Audience = "audience"
 
+	// CallerAddr tags the network address of an API caller
+	CallerAddr = "caller_addr"
+
 	// CallerID tags an API caller; should be used with other tags
 	// to add clarity
 	CallerID = "caller_id"
@@ -158,9 +192,16 @@ const (
 	// DNS name is a name which is resolvable with DNS
 	DNSName = "dns_name"
 
+	// DryRun flags whether or not an operation only reports what it would
+	// do, without doing it
+	DryRun = "dry_run"
+
 	// ElapsedTime tags some duration of time.
 	ElapsedTime = "elapsed_time"
 
+	// EntryTemplateID tags some entry template ID
+	EntryTemplateID = "entry_template_id"
+
 	// Error tag for some error that occurred. Limited usage, such as logging errors at
 	// non-error level.
 	Error = "error"
@@ -185,6 +226,9 @@ const (
 	// Generation represents an objection generation (i.e. version)
 	Generation = "generation"
 
+	// Hint tags the hint of a registration entry
+	Hint = "hint"
+
 	// IDType tags some type of ID (eg. registration ID, SPIFFE ID...)
 	IDType = "id_type"
 
@@ -201,6 +245,9 @@ const (
 	// Kid tags some key ID
 	Kid = "kid"
 
+	// LogLevel tags a log level
+	LogLevel = "log_level"
+
 	// NewSerialNumber tags a certificate new serial number
 	NewSerialNumber = "new_serial_num"
 
@@ -210,6 +257,9 @@ const (
 	// Nonce tags some nonce for communication
 	Nonce = "nonce"
 
+	// Orphaned tags something whose parent no longer exists
+	Orphaned = "orphaned"
+
 	// ParentID tags parent ID for an entry
 	ParentID = "parent_id"
 
@@ -245,6 +295,9 @@ const (
 	// Pruned flagging something has been pruned
 	Pruned = "pruned"
 
+	// Reason tags the reason for some action or state
+	Reason = "reason"
+
 	// RegistrationID tags some registration entry ID
 	RegistrationID = "entry_id"
 
@@ -255,9 +308,16 @@ const (
 	// RegistrationEntry tags a registration entry
 	RegistrationEntry = "registration_entry"
 
+	// Request tags the request message of an API call
+	Request = "request"
+
 	// ResourceNames tags some group of resources by name
 	ResourceNames = "resource_names"
 
+	// RestartRequiredSettings tags the changed configuration settings that
+	// are only applied after a restart
+	RestartRequiredSettings = "restart_required_settings"
+
 	// RetryInterval tags some interval for retry logic
 	RetryInterval = "retry_interval"
 
@@ -292,12 +352,21 @@ const (
 	// Slot X509 CA Slot ID
 	Slot = "slot"
 
+	// Setting tags some configuration setting
+	Setting = "setting"
+
 	// SPIFFEID tags a SPIFFE ID
 	SPIFFEID = "spiffe_id"
 
+	// SPIFFEIDPrefix tags a configured prefix of a SPIFFE ID
+	SPIFFEIDPrefix = "spiffe_id_prefix"
+
 	// Status tags status of call (OK, or some error), or status of some process
 	Status = "status"
 
+	// StatusMessage tags the message of the status of a call
+	StatusMessage = "status_message"
+
 	// Subject tags some subject (likely a SPIFFE ID, and likely for a token); should be used
 	// with other tags to add clarity
 	Subject = "subject"
@@ -324,6 +393,10 @@ const (
 	// Unknown tags some unknown caller, entity, or status
 	Unknown = "unknown"
 
+	// Unhealthy tags some entity as unhealthy, such as an external plugin
+	// that exited or stopped responding
+	Unhealthy = "unhealthy"
+
 	// Updated tags some entity as updated; should be used
 	// with other tags to add clarity
 	Updated = "updated"
@@ -390,6 +463,13 @@ const (
 	// to add clarity
 	Entry = "entry"
 
+	// Entries tags registration entries count/list
+	Entries = "entries"
+
+	// EntryTemplate functionality related to an entry template; should be
+	// used with other tags to add clarity
+	EntryTemplate = "entry_template"
+
 	// Event tag some event that has occurred, for a notifier, watcher, listener, etc.
 	Event = "event"
 
@@ -403,6 +483,10 @@ const (
 	// with other tags to add clarity
 	FederatedBundle = "federated_bundle"
 
+	// FederationRelationship functionality related to a federation
+	// relationship; should be used with other tags to add clarity
+	FederationRelationship = "federation_relationship"
+
 	// JoinToken functionality related to a join token; should be used
 	// with other tags to add clarity
 	JoinToken = "join_token"
@@ -415,9 +499,15 @@ const (
 	// to add clarity
 	JWTSVID = "jwt_svid"
 
+	// JWTSVIDs tags JWT-SVIDs count/list
+	JWTSVIDs = "jwt_svids"
+
 	// Limit tags a limit
 	Limit = "limit"
 
+	// LocalAuthorityID tags the ID of a local X509 or JWT authority
+	LocalAuthorityID = "local_authority_id"
+
 	// Manager functionality related to a manager (such as CA manager); should be
 	// used with other tags to add clarity
 	Manager = "manager"
@@ -432,10 +522,25 @@ const (
 	// to add clarity
 	Node = "node"
 
+	// NodeLabels functionality related to the selectors of the node added
+	// to every workload
+	NodeLabels = "node_labels"
+
+	// NodeResolutionManager functionality related to the periodic resolution
+	// of node selectors
+	NodeResolutionManager = "node_resolution_manager"
+
+	// NodeResolver tags a node resolver plugin
+	NodeResolver = "node_resolver"
+
 	// Notifier functionality related to some notifying entity; should be used with other tags
 	// to add clarity
 	Notifier = "notifier"
 
+	// Plugin functionality related to a plugin; should be used with other tags
+	// to add clarity
+	Plugin = "plugin"
+
 	// ServerCA functionality related to a server CA; should be used with other tags
 	// to add clarity
 	ServerCA = "server_ca"
@@ -450,9 +555,15 @@ const (
 	// to add clarity
 	SVID = "svid"
 
+	// SVIDFileSink functionality related to writing SVIDs to files
+	SVIDFileSink = "svid_file_sink"
+
 	// SVIDRotator functionality related to a SVID rotator
 	SVIDRotator = "svid_rotator"
 
+	// SVIDStore functionality related to storing SVIDs in external stores
+	SVIDStore = "svid_store"
+
 	// RegistrationManager functionality related to a registration manager
 	RegistrationManager = "registration_manager"
 
@@ -470,6 +581,9 @@ const (
 	// X509SVID functionality related to an x509 SVID; should be used with other tags
 	// to add clarity
 	X509SVID = "x509_svid"
+
+	// X509SVIDs tags X509-SVIDs count/list
+	X509SVIDs = "x509_svids"
 )
 
 // Operation metric tags or labels that are typically a specific
@@ -590,6 +704,15 @@ const (
 	// ServerKeyManager attached to all operations related to the server KeyManager interface
 	ServerKeyManager = "server_key_manager"
 
+	// SignJWTSVID functionality related to signing a JWT-SVID
+	SignJWTSVID = "sign_jwt_svid"
+
+	// SignX509CASVID functionality related to signing an X509 CA SVID
+	SignX509CASVID = "sign_x509_ca_svid"
+
+	// SignX509SVID functionality related to signing an X509 SVID
+	SignX509SVID = "sign_x509_svid"
+
 	// StreamSecrets functionality related to streaming secrets
 	StreamSecrets = "stream_secrets"
 
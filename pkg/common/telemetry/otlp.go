@@ -0,0 +1,542 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	otlpDefaultExportInterval = 10 * time.Second
+	otlpExportTimeout         = 10 * time.Second
+	otlpMaxPendingSpans       = 4096
+	otlpScopeName             = "github.com/spiffe/spire"
+
+	// OTLP span kind and status codes
+	otlpSpanKindInternal = 1
+	otlpStatusCodeOK     = 1
+	otlpStatusCodeError  = 2
+
+	// OTLP aggregation temporality for sums
+	otlpTemporalityCumulative = 2
+)
+
+// otlpRunner exports metrics and call spans to an OpenTelemetry collector
+// using OTLP over HTTP with JSON encoding.
+type otlpRunner struct {
+	c           *OTLPConfig
+	log         logrus.FieldLogger
+	serviceName string
+	metricsURL  string
+	tracesURL   string
+	interval    time.Duration
+	client      *http.Client
+	sink        *otlpSink
+}
+
+func newOTLPRunner(c *MetricsConfig) (sinkRunner, error) {
+	runner := &otlpRunner{
+		c:           c.FileConfig.OTLP,
+		log:         c.Logger,
+		serviceName: c.ServiceName,
+	}
+
+	if runner.c == nil {
+		return runner, nil
+	}
+
+	if runner.c.Endpoint == "" {
+		return nil, errors.New("OTLP endpoint must be configured")
+	}
+	endpoint, err := url.Parse(runner.c.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTLP endpoint: %v", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: scheme must be http or https", runner.c.Endpoint)
+	}
+	base := strings.TrimSuffix(endpoint.String(), "/")
+	runner.metricsURL = base + "/v1/metrics"
+	runner.tracesURL = base + "/v1/traces"
+
+	runner.interval = otlpDefaultExportInterval
+	if runner.c.ExportInterval != "" {
+		runner.interval, err = time.ParseDuration(runner.c.ExportInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OTLP export_interval: %v", err)
+		}
+		if runner.interval <= 0 {
+			return nil, errors.New("OTLP export_interval must be positive")
+		}
+	}
+
+	runner.client = &http.Client{Timeout: otlpExportTimeout}
+	runner.sink = newOTLPSink(time.Now())
+	return runner, nil
+}
+
+func (o *otlpRunner) isConfigured() bool {
+	return o.c != nil
+}
+
+func (o *otlpRunner) sinks() []Sink {
+	if !o.isConfigured() {
+		return []Sink{}
+	}
+
+	return []Sink{o.sink}
+}
+
+func (o *otlpRunner) run(ctx context.Context) error {
+	if !o.isConfigured() {
+		return nil
+	}
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			o.export(ctx)
+		case <-ctx.Done():
+			// Flush whatever was collected since the last export
+			flushCtx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+			o.export(flushCtx)
+			cancel()
+			return nil
+		}
+	}
+}
+
+func (o *otlpRunner) requiresTypePrefix() bool {
+	return false
+}
+
+func (o *otlpRunner) recordSpan(key []string, start, end time.Time, labels []Label) {
+	if !o.isConfigured() {
+		return
+	}
+	o.sink.recordSpan(key, start, end, labels)
+}
+
+func (o *otlpRunner) export(ctx context.Context) {
+	metricsReq, tracesReq, dropped := o.sink.collect(time.Now(), o.resource())
+	if dropped > 0 {
+		o.log.WithField(Count, dropped).Warn("Dropped spans waiting to be exported to the OTLP collector")
+	}
+
+	if metricsReq != nil {
+		if err := o.post(ctx, o.metricsURL, metricsReq); err != nil {
+			o.log.WithError(err).Warn("Failed to export metrics to the OTLP collector")
+		}
+	}
+	if tracesReq != nil {
+		if err := o.post(ctx, o.tracesURL, tracesReq); err != nil {
+			o.log.WithError(err).Warn("Failed to export spans to the OTLP collector")
+		}
+	}
+}
+
+func (o *otlpRunner) resource() otlpResource {
+	return otlpResource{
+		Attributes: []otlpKeyValue{otlpAttribute("service.name", o.serviceName)},
+	}
+}
+
+func (o *otlpRunner) post(ctx context.Context, endpoint string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range o.c.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+	return nil
+}
+
+// otlpSink aggregates go-metrics calls between exports. Counters and samples
+// are cumulative since the sink was created; gauges hold the last value set.
+type otlpSink struct {
+	mu           sync.Mutex
+	start        time.Time
+	gauges       map[string]*otlpGaugeSeries
+	counters     map[string]*otlpGaugeSeries
+	samples      map[string]*otlpSampleSeries
+	spans        []otlpSpan
+	droppedSpans int
+}
+
+var _ metrics.MetricSink = (*otlpSink)(nil)
+
+type otlpGaugeSeries struct {
+	name   string
+	labels []Label
+	value  float64
+}
+
+type otlpSampleSeries struct {
+	name   string
+	labels []Label
+	count  uint64
+	sum    float64
+	min    float64
+	max    float64
+}
+
+func newOTLPSink(start time.Time) *otlpSink {
+	return &otlpSink{
+		start:    start,
+		gauges:   make(map[string]*otlpGaugeSeries),
+		counters: make(map[string]*otlpGaugeSeries),
+		samples:  make(map[string]*otlpSampleSeries),
+	}
+}
+
+func (s *otlpSink) SetGauge(key []string, val float32) {
+	s.SetGaugeWithLabels(key, val, nil)
+}
+
+func (s *otlpSink) SetGaugeWithLabels(key []string, val float32, labels []Label) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series := otlpGetSeries(s.gauges, key, labels)
+	series.value = float64(val)
+}
+
+func (s *otlpSink) EmitKey(key []string, val float32) {
+	s.SetGaugeWithLabels(key, val, nil)
+}
+
+func (s *otlpSink) IncrCounter(key []string, val float32) {
+	s.IncrCounterWithLabels(key, val, nil)
+}
+
+func (s *otlpSink) IncrCounterWithLabels(key []string, val float32, labels []Label) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series := otlpGetSeries(s.counters, key, labels)
+	series.value += float64(val)
+}
+
+func (s *otlpSink) AddSample(key []string, val float32) {
+	s.AddSampleWithLabels(key, val, nil)
+}
+
+func (s *otlpSink) AddSampleWithLabels(key []string, val float32, labels []Label) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name, id := otlpSeriesID(key, labels)
+	series, ok := s.samples[id]
+	if !ok {
+		series = &otlpSampleSeries{
+			name:   name,
+			labels: append([]Label(nil), labels...),
+			min:    float64(val),
+			max:    float64(val),
+		}
+		s.samples[id] = series
+	}
+	series.count++
+	series.sum += float64(val)
+	if float64(val) < series.min {
+		series.min = float64(val)
+	}
+	if float64(val) > series.max {
+		series.max = float64(val)
+	}
+}
+
+func (s *otlpSink) recordSpan(key []string, start, end time.Time, labels []Label) {
+	span := otlpSpan{
+		TraceID:           otlpRandomID(16),
+		SpanID:            otlpRandomID(8),
+		Name:              strings.Join(key, "."),
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: uint64(start.UnixNano()),
+		EndTimeUnixNano:   uint64(end.UnixNano()),
+		Status:            otlpStatus{Code: otlpStatusCodeOK},
+	}
+	for _, label := range labels {
+		span.Attributes = append(span.Attributes, otlpAttribute(label.Name, label.Value))
+		if label.Name == Status && label.Value != "OK" {
+			span.Status = otlpStatus{Code: otlpStatusCodeError, Message: label.Value}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.spans) >= otlpMaxPendingSpans {
+		s.droppedSpans++
+		return
+	}
+	s.spans = append(s.spans, span)
+}
+
+// collect builds the export requests for the current state of the sink,
+// draining the pending spans. A nil request means there is nothing to export.
+func (s *otlpSink) collect(now time.Time, resource otlpResource) (*otlpMetricsRequest, *otlpTracesRequest, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	startNano := uint64(s.start.UnixNano())
+	nowNano := uint64(now.UnixNano())
+
+	var out []otlpMetric
+	for _, series := range s.gauges {
+		out = append(out, otlpMetric{
+			Name: series.name,
+			Gauge: &otlpGauge{
+				DataPoints: []otlpNumberDataPoint{{
+					Attributes:   otlpAttributes(series.labels),
+					TimeUnixNano: nowNano,
+					AsDouble:     series.value,
+				}},
+			},
+		})
+	}
+	for _, series := range s.counters {
+		out = append(out, otlpMetric{
+			Name: series.name,
+			Sum: &otlpSum{
+				AggregationTemporality: otlpTemporalityCumulative,
+				IsMonotonic:            true,
+				DataPoints: []otlpNumberDataPoint{{
+					Attributes:        otlpAttributes(series.labels),
+					StartTimeUnixNano: startNano,
+					TimeUnixNano:      nowNano,
+					AsDouble:          series.value,
+				}},
+			},
+		})
+	}
+	for _, series := range s.samples {
+		out = append(out, otlpMetric{
+			Name: series.name,
+			Summary: &otlpSummary{
+				DataPoints: []otlpSummaryDataPoint{{
+					Attributes:        otlpAttributes(series.labels),
+					StartTimeUnixNano: startNano,
+					TimeUnixNano:      nowNano,
+					Count:             series.count,
+					Sum:               series.sum,
+					QuantileValues: []otlpQuantileValue{
+						{Quantile: 0, Value: series.min},
+						{Quantile: 1, Value: series.max},
+					},
+				}},
+			},
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	var metricsReq *otlpMetricsRequest
+	if len(out) > 0 {
+		metricsReq = &otlpMetricsRequest{
+			ResourceMetrics: []otlpResourceMetrics{{
+				Resource: resource,
+				ScopeMetrics: []otlpScopeMetrics{{
+					Scope:   otlpScope{Name: otlpScopeName},
+					Metrics: out,
+				}},
+			}},
+		}
+	}
+
+	var tracesReq *otlpTracesRequest
+	if len(s.spans) > 0 {
+		tracesReq = &otlpTracesRequest{
+			ResourceSpans: []otlpResourceSpans{{
+				Resource: resource,
+				ScopeSpans: []otlpScopeSpans{{
+					Scope: otlpScope{Name: otlpScopeName},
+					Spans: s.spans,
+				}},
+			}},
+		}
+		s.spans = nil
+	}
+
+	dropped := s.droppedSpans
+	s.droppedSpans = 0
+	return metricsReq, tracesReq, dropped
+}
+
+func otlpGetSeries(m map[string]*otlpGaugeSeries, key []string, labels []Label) *otlpGaugeSeries {
+	name, id := otlpSeriesID(key, labels)
+	series, ok := m[id]
+	if !ok {
+		series = &otlpGaugeSeries{
+			name:   name,
+			labels: append([]Label(nil), labels...),
+		}
+		m[id] = series
+	}
+	return series
+}
+
+func otlpSeriesID(key []string, labels []Label) (string, string) {
+	name := strings.Join(key, ".")
+	id := name
+	for _, label := range labels {
+		id += "\x00" + label.Name + "=" + label.Value
+	}
+	return name, id
+}
+
+func otlpAttributes(labels []Label) []otlpKeyValue {
+	var attrs []otlpKeyValue
+	for _, label := range labels {
+		attrs = append(attrs, otlpAttribute(label.Name, label.Value))
+	}
+	return attrs
+}
+
+func otlpAttribute(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: value}}
+}
+
+func otlpRandomID(size int) string {
+	b := make([]byte, size)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// The types below mirror the JSON encoding of the OTLP export requests for
+// the subset of the protocol used by the exporter.
+
+type otlpMetricsRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpTracesRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpMetric struct {
+	Name    string       `json:"name"`
+	Gauge   *otlpGauge   `json:"gauge,omitempty"`
+	Sum     *otlpSum     `json:"sum,omitempty"`
+	Summary *otlpSummary `json:"summary,omitempty"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+	IsMonotonic            bool                  `json:"isMonotonic"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano uint64         `json:"startTimeUnixNano,string,omitempty"`
+	TimeUnixNano      uint64         `json:"timeUnixNano,string"`
+	AsDouble          float64        `json:"asDouble"`
+}
+
+type otlpSummary struct {
+	DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+}
+
+type otlpSummaryDataPoint struct {
+	Attributes        []otlpKeyValue      `json:"attributes,omitempty"`
+	StartTimeUnixNano uint64              `json:"startTimeUnixNano,string"`
+	TimeUnixNano      uint64              `json:"timeUnixNano,string"`
+	Count             uint64              `json:"count,string"`
+	Sum               float64             `json:"sum"`
+	QuantileValues    []otlpQuantileValue `json:"quantileValues"`
+}
+
+type otlpQuantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano uint64         `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   uint64         `json:"endTimeUnixNano,string"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
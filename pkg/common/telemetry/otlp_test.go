@@ -0,0 +1,281 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewOTLPRunner(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		config    *OTLPConfig
+		expectErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:   "configured",
+			config: &OTLPConfig{Endpoint: "http://localhost:4318", ExportInterval: "1m"},
+		},
+		{
+			name:      "missing endpoint",
+			config:    &OTLPConfig{},
+			expectErr: "OTLP endpoint must be configured",
+		},
+		{
+			name:      "unsupported endpoint scheme",
+			config:    &OTLPConfig{Endpoint: "grpc://localhost:4317"},
+			expectErr: `invalid OTLP endpoint "grpc://localhost:4317": scheme must be http or https`,
+		},
+		{
+			name:      "invalid export interval",
+			config:    &OTLPConfig{Endpoint: "http://localhost:4318", ExportInterval: "often"},
+			expectErr: "invalid OTLP export_interval",
+		},
+		{
+			name:      "non-positive export interval",
+			config:    &OTLPConfig{Endpoint: "http://localhost:4318", ExportInterval: "0s"},
+			expectErr: "OTLP export_interval must be positive",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config := testOTLPConfig(tt.config)
+			runner, err := newOTLPRunner(config)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.config != nil, runner.isConfigured())
+		})
+	}
+}
+
+func TestOTLPExport(t *testing.T) {
+	collector := newFakeOTLPCollector(t)
+	defer collector.Close()
+
+	config := testOTLPConfig(&OTLPConfig{
+		Endpoint: collector.URL,
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	})
+	metrics, err := NewMetrics(config)
+	require.NoError(t, err)
+
+	var runner *otlpRunner
+	for _, r := range metrics.runners {
+		if o, ok := r.(*otlpRunner); ok {
+			runner = o
+		}
+	}
+	require.NotNil(t, runner)
+
+	metrics.SetGauge([]string{"gauge"}, 2)
+	metrics.IncrCounter([]string{"counter"}, 1)
+	metrics.IncrCounter([]string{"counter"}, 2)
+	metrics.AddSample([]string{"sample"}, 3)
+	metrics.AddSample([]string{"sample"}, 5)
+
+	okCall := StartCall(metrics, "ok", "call")
+	okCall.Done(nil)
+
+	failedErr := status.Error(codes.NotFound, "oh no")
+	failedCall := StartCall(WithLabels(metrics, []Label{{Name: "extra", Value: "label"}}), "failed", "call")
+	failedCall.Done(&failedErr)
+
+	runner.export(context.Background())
+
+	assert.Equal(t, "Bearer token", collector.authorization())
+
+	metricsReq := collector.metrics()
+	require.Len(t, metricsReq.ResourceMetrics, 1)
+	assert.Equal(t, []otlpKeyValue{otlpAttribute("service.name", "foo")}, metricsReq.ResourceMetrics[0].Resource.Attributes)
+	require.Len(t, metricsReq.ResourceMetrics[0].ScopeMetrics, 1)
+	byName := make(map[string]otlpMetric)
+	for _, metric := range metricsReq.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		byName[metric.Name] = metric
+	}
+
+	require.Contains(t, byName, "foo.gauge")
+	require.NotNil(t, byName["foo.gauge"].Gauge)
+	assert.Equal(t, 2.0, byName["foo.gauge"].Gauge.DataPoints[0].AsDouble)
+
+	require.Contains(t, byName, "foo.counter")
+	require.NotNil(t, byName["foo.counter"].Sum)
+	assert.True(t, byName["foo.counter"].Sum.IsMonotonic)
+	assert.Equal(t, 3.0, byName["foo.counter"].Sum.DataPoints[0].AsDouble)
+
+	require.Contains(t, byName, "foo.sample")
+	require.NotNil(t, byName["foo.sample"].Summary)
+	sample := byName["foo.sample"].Summary.DataPoints[0]
+	assert.Equal(t, uint64(2), sample.Count)
+	assert.Equal(t, 8.0, sample.Sum)
+	assert.Equal(t, []otlpQuantileValue{{Quantile: 0, Value: 3}, {Quantile: 1, Value: 5}}, sample.QuantileValues)
+
+	tracesReq := collector.traces()
+	require.Len(t, tracesReq.ResourceSpans, 1)
+	require.Len(t, tracesReq.ResourceSpans[0].ScopeSpans, 1)
+	spans := tracesReq.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "ok.call", spans[0].Name)
+	assert.Equal(t, otlpStatus{Code: otlpStatusCodeOK}, spans[0].Status)
+	assert.Len(t, spans[0].TraceID, 32)
+	assert.Len(t, spans[0].SpanID, 16)
+	assert.True(t, spans[0].EndTimeUnixNano >= spans[0].StartTimeUnixNano)
+
+	assert.Equal(t, "failed.call", spans[1].Name)
+	assert.Equal(t, otlpStatus{Code: otlpStatusCodeError, Message: "NotFound"}, spans[1].Status)
+	assert.Equal(t, []otlpKeyValue{
+		otlpAttribute("extra", "label"),
+		otlpAttribute(Status, "NotFound"),
+	}, spans[1].Attributes)
+
+	// Spans are only exported once
+	collector.reset()
+	runner.export(context.Background())
+	assert.NotNil(t, collector.metrics().ResourceMetrics)
+	assert.Nil(t, collector.traces().ResourceSpans)
+}
+
+func TestOTLPExportFailure(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer collector.Close()
+
+	config := testOTLPConfig(&OTLPConfig{Endpoint: collector.URL})
+	log, hook := test.NewNullLogger()
+	config.Logger = log
+
+	runner, err := newOTLPRunner(config)
+	require.NoError(t, err)
+	o := runner.(*otlpRunner)
+	o.sink.IncrCounter([]string{"counter"}, 1)
+
+	o.export(context.Background())
+
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, "Failed to export metrics to the OTLP collector", hook.LastEntry().Message)
+}
+
+func TestOTLPRunStopsWhenCanceled(t *testing.T) {
+	collector := newFakeOTLPCollector(t)
+	defer collector.Close()
+
+	runner, err := newOTLPRunner(testOTLPConfig(&OTLPConfig{Endpoint: collector.URL, ExportInterval: "1h"}))
+	require.NoError(t, err)
+	runner.(*otlpRunner).sink.IncrCounter([]string{"counter"}, 1)
+
+	errCh := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		errCh <- runner.run(ctx)
+	}()
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(time.Minute):
+		t.Fatal("timeout waiting for shutdown")
+	}
+
+	// Metrics are flushed on shutdown
+	assert.NotNil(t, collector.metrics().ResourceMetrics)
+}
+
+func testOTLPConfig(c *OTLPConfig) *MetricsConfig {
+	l, _ := test.NewNullLogger()
+
+	return &MetricsConfig{
+		Logger:      l,
+		ServiceName: "foo",
+		FileConfig: FileConfig{
+			InMem: &InMem{Enabled: new(bool)},
+			OTLP:  c,
+		},
+	}
+}
+
+type fakeOTLPCollector struct {
+	*httptest.Server
+
+	t          *testing.T
+	mu         sync.Mutex
+	auth       string
+	metricsReq otlpMetricsRequest
+	tracesReq  otlpTracesRequest
+}
+
+func newFakeOTLPCollector(t *testing.T) *fakeOTLPCollector {
+	c := &fakeOTLPCollector{t: t}
+	c.Server = httptest.NewServer(http.HandlerFunc(c.handle))
+	return c
+}
+
+func (c *fakeOTLPCollector) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if !assert.NoError(c.t, err) {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	assert.Equal(c.t, "application/json", r.Header.Get("Content-Type"))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.auth = r.Header.Get("Authorization")
+
+	switch r.URL.Path {
+	case "/v1/metrics":
+		err = json.Unmarshal(body, &c.metricsReq)
+	case "/v1/traces":
+		err = json.Unmarshal(body, &c.tracesReq)
+	default:
+		err = errors.New("unexpected path " + r.URL.Path)
+	}
+	if !assert.NoError(c.t, err) {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (c *fakeOTLPCollector) authorization() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.auth
+}
+
+func (c *fakeOTLPCollector) metrics() otlpMetricsRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.metricsReq
+}
+
+func (c *fakeOTLPCollector) traces() otlpTracesRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tracesReq
+}
+
+func (c *fakeOTLPCollector) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metricsReq = otlpMetricsRequest{}
+	c.tracesReq = otlpTracesRequest{}
+}
@@ -2,16 +2,25 @@ package telemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/armon/go-metrics"
 	prommetrics "github.com/armon/go-metrics/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
+// defaultHistogramBuckets are the upper bounds, in milliseconds, of the
+// latency histogram buckets when none are configured
+var defaultHistogramBuckets = []float64{1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
 type prometheusRunner struct {
 	c      *PrometheusConfig
 	log    logrus.FieldLogger
@@ -29,8 +38,23 @@ func newPrometheusRunner(c *MetricsConfig) (sinkRunner, error) {
 		return runner, nil
 	}
 
+	// Copy the configuration so the defaults don't leak into the file
+	// configuration, which is compared against the reloaded one
+	config := *runner.c
+	runner.c = &config
+
+	buckets := runner.c.HistogramBuckets
+	if len(buckets) == 0 {
+		buckets = defaultHistogramBuckets
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return runner, errors.New("prometheus histogram_buckets must be in increasing order")
+		}
+	}
+
 	var err error
-	runner.sink, err = prommetrics.NewPrometheusSink()
+	runner.sink, err = newPrometheusSink(buckets)
 	if err != nil {
 		return runner, err
 	}
@@ -45,7 +69,7 @@ func newPrometheusRunner(c *MetricsConfig) (sinkRunner, error) {
 	}
 
 	if runner.c.Host != "localhost" {
-		runner.log.Warnf("Agent is now configured to accept remote network connections for Prometheus stats collection. Please ensure access to this port is tightly controlled")
+		runner.log.Warnf("Prometheus endpoint is now configured to accept remote network connections for stats collection. Please ensure access to this port is tightly controlled")
 	}
 
 	runner.server = &http.Server{
@@ -94,6 +118,110 @@ func (p *prometheusRunner) run(ctx context.Context) error {
 	return ctx.Err()
 }
 
+// release unregisters the sink from the Prometheus registry so a new sink
+// can be registered when the configuration is reloaded.
+func (p *prometheusRunner) release() {
+	if collector, ok := p.sink.(prometheus.Collector); ok {
+		prometheus.Unregister(collector)
+	}
+}
+
+// restore registers the sink again if the configuration could not be
+// reloaded.
+func (p *prometheusRunner) restore() {
+	if collector, ok := p.sink.(prometheus.Collector); ok {
+		if err := prometheus.Register(collector); err != nil {
+			p.log.WithError(err).Warn("Failed to register Prometheus sink again")
+		}
+	}
+}
+
 func (p *prometheusRunner) requiresTypePrefix() bool {
 	return false
 }
+
+var forbiddenChars = regexp.MustCompile(`[ .=\-/]`)
+
+// prometheusSink wraps the go-metrics Prometheus sink to record the samples,
+// which are the call latencies, as histograms instead of summaries. Unlike
+// summaries, histograms can be aggregated across servers and agents.
+type prometheusSink struct {
+	*prommetrics.PrometheusSink
+
+	buckets    []float64
+	expiration time.Duration
+
+	mu         sync.Mutex
+	histograms map[string]prometheus.Histogram
+	updates    map[string]time.Time
+}
+
+func newPrometheusSink(buckets []float64) (*prometheusSink, error) {
+	opts := prommetrics.DefaultPrometheusOpts
+	inner, err := prommetrics.NewPrometheusSinkFrom(opts)
+	if err != nil {
+		return nil, err
+	}
+	// The go-metrics sink registers itself. The wrapping sink is registered
+	// instead so the histograms are collected along with the other metrics.
+	prometheus.Unregister(inner)
+
+	sink := &prometheusSink{
+		PrometheusSink: inner,
+		buckets:        buckets,
+		expiration:     opts.Expiration,
+		histograms:     make(map[string]prometheus.Histogram),
+		updates:        make(map[string]time.Time),
+	}
+	return sink, prometheus.Register(sink)
+}
+
+func (p *prometheusSink) AddSample(key []string, val float32) {
+	p.AddSampleWithLabels(key, val, nil)
+}
+
+func (p *prometheusSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	name := forbiddenChars.ReplaceAllString(strings.Join(key, "_"), "_")
+	hash := name
+	for _, label := range labels {
+		hash += fmt.Sprintf(";%s=%s", label.Name, label.Value)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h, ok := p.histograms[hash]
+	if !ok {
+		constLabels := make(prometheus.Labels)
+		for _, label := range labels {
+			constLabels[label.Name] = label.Value
+		}
+		h = prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:        name,
+			Help:        name,
+			ConstLabels: constLabels,
+			Buckets:     p.buckets,
+		})
+		p.histograms[hash] = h
+	}
+	h.Observe(float64(val))
+	p.updates[hash] = time.Now()
+}
+
+// Collect collects the metrics of the go-metrics sink and the histograms,
+// dropping the histograms that have not been updated for longer than the
+// expiration of the go-metrics sink.
+func (p *prometheusSink) Collect(c chan<- prometheus.Metric) {
+	p.PrometheusSink.Collect(c)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	for hash, h := range p.histograms {
+		if p.expiration != 0 && p.updates[hash].Add(p.expiration).Before(now) {
+			delete(p.updates, hash)
+			delete(p.histograms, hash)
+			continue
+		}
+		h.Collect(c)
+	}
+}
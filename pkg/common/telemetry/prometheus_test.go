@@ -5,8 +5,8 @@ import (
 	"testing"
 	"time"
 
-	prommetrics "github.com/armon/go-metrics/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,6 +25,47 @@ func TestNewPrometheusRunner(t *testing.T) {
 	assert.NotNil(t, pr)
 }
 
+func TestNewPrometheusRunnerInvalidBuckets(t *testing.T) {
+	config := testPrometheusConfig()
+	config.FileConfig.Prometheus.HistogramBuckets = []float64{10, 5}
+	_, err := newPrometheusRunner(config)
+	assert.EqualError(t, err, "prometheus histogram_buckets must be in increasing order")
+}
+
+func TestPrometheusHistograms(t *testing.T) {
+	config := testPrometheusConfig()
+	config.FileConfig.Prometheus.HistogramBuckets = []float64{10, 100}
+	config.FileConfig.BlockedLabels = []string{"agent_id"}
+	m, err := NewMetrics(config)
+	require.NoError(t, err)
+	defer releaseRunners(m.runners)
+
+	m.MeasureSinceWithLabels([]string{"rpc", "call", "elapsed_time"}, time.Now(), []Label{
+		{Name: "status", Value: "OK"},
+		{Name: "agent_id", Value: "spiffe://example.org/agent"},
+	})
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	var histogram *dto.Histogram
+	for _, family := range families {
+		if family.GetName() != "foo_rpc_call_elapsed_time" {
+			continue
+		}
+		require.Equal(t, dto.MetricType_HISTOGRAM, family.GetType())
+		require.Len(t, family.Metric, 1)
+		for _, label := range family.Metric[0].Label {
+			assert.NotEqual(t, "agent_id", label.GetName(), "blocked label should not be emitted")
+		}
+		histogram = family.Metric[0].Histogram
+	}
+	require.NotNil(t, histogram, "histogram not found")
+	assert.Equal(t, uint64(1), histogram.GetSampleCount())
+	require.Len(t, histogram.Bucket, 2)
+	assert.Equal(t, 10.0, histogram.Bucket[0].GetUpperBound())
+	assert.Equal(t, 100.0, histogram.Bucket[1].GetUpperBound())
+}
+
 func TestIsConfigured(t *testing.T) {
 	config := testPrometheusConfig()
 
@@ -76,6 +117,46 @@ func TestRun(t *testing.T) {
 	}
 }
 
+func TestReloadPrometheus(t *testing.T) {
+	config := testPrometheusConfig()
+	m, err := NewMetrics(config)
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		errCh <- m.ListenAndServe(ctx)
+	}()
+
+	// The sink can be replaced without duplicate registration errors
+	require.NoError(t, m.Reload(FileConfig{Prometheus: &PrometheusConfig{}}))
+	require.NoError(t, m.Reload(FileConfig{Prometheus: &PrometheusConfig{}}))
+	require.True(t, hasPrometheusRunner(m))
+
+	// And removed
+	require.NoError(t, m.Reload(FileConfig{}))
+	require.False(t, hasPrometheusRunner(m))
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(time.Minute):
+		t.Fatal("timeout waiting for shutdown")
+	}
+}
+
+func hasPrometheusRunner(m *MetricsImpl) bool {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	for _, runner := range m.runners {
+		if _, ok := runner.(*prometheusRunner); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func testPrometheusConfig() *MetricsConfig {
 	l, _ := test.NewNullLogger()
 
@@ -96,7 +177,7 @@ func newTestPrometheusRunner(c *MetricsConfig) (sinkRunner, error) {
 
 	if runner != nil && runner.isConfigured() {
 		pr := runner.(*prometheusRunner)
-		sink := pr.sink.(*prommetrics.PrometheusSink)
+		sink := pr.sink.(*prometheusSink)
 		prometheus.Unregister(sink)
 	}
 
@@ -25,6 +25,24 @@ func StartServerCAManagerPrepareX509CACall(m telemetry.Metrics) *telemetry.CallC
 	return telemetry.StartCall(m, telemetry.CA, telemetry.Manager, telemetry.X509CA, telemetry.Prepare)
 }
 
+// StartServerCASignJWTSVIDCall return metric for
+// Server CA signing a JWT SVID
+func StartServerCASignJWTSVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.ServerCA, telemetry.SignJWTSVID)
+}
+
+// StartServerCASignX509CASVIDCall return metric for
+// Server CA signing an X509 CA SVID
+func StartServerCASignX509CASVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.ServerCA, telemetry.SignX509CASVID)
+}
+
+// StartServerCASignX509SVIDCall return metric for
+// Server CA signing an X509 SVID
+func StartServerCASignX509SVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.ServerCA, telemetry.SignX509SVID)
+}
+
 // End Call Counters
 
 // Gauge (remember previous value set)
@@ -56,12 +74,49 @@ func IncrActivateX509CAManagerCounter(m telemetry.Metrics) {
 	m.IncrCounter([]string{telemetry.CA, telemetry.Manager, telemetry.X509CA, telemetry.Activate}, 1)
 }
 
+// IncrManagerBundleDriftCounter indicate the number of
+// external copies of the bundle a notifier found out of sync
+func IncrManagerBundleDriftCounter(m telemetry.Metrics, notifier string, count int32) {
+	m.IncrCounterWithLabels([]string{telemetry.CA, telemetry.Manager, telemetry.Bundle, telemetry.Drift}, float32(count), []telemetry.Label{
+		{Name: telemetry.Notifier, Value: notifier},
+	})
+}
+
 // IncrManagerPrunedBundleCounter indicate manager
 // having pruned a bundle
 func IncrManagerPrunedBundleCounter(m telemetry.Metrics) {
 	m.IncrCounter([]string{telemetry.CA, telemetry.Manager, telemetry.Bundle, telemetry.Pruned}, 1)
 }
 
+// IncrServerCAIssueJWTSVIDCounter indicate Server CA issued a JWT SVID.
+// Takes the trust domain, the parent agent and the SPIFFE ID prefix of the
+// SVID, the last two being omitted when empty
+func IncrServerCAIssueJWTSVIDCounter(m telemetry.Metrics, trustDomain, parentID, prefix string) {
+	m.IncrCounterWithLabels([]string{telemetry.ServerCA, telemetry.Issue, telemetry.JWTSVID}, 1,
+		issueLabels(trustDomain, parentID, prefix))
+}
+
+// IncrServerCAIssueX509SVIDCounter indicate Server CA issued an X509 SVID.
+// Takes the trust domain, the parent agent and the SPIFFE ID prefix of the
+// SVID, the last two being omitted when empty
+func IncrServerCAIssueX509SVIDCounter(m telemetry.Metrics, trustDomain, parentID, prefix string) {
+	m.IncrCounterWithLabels([]string{telemetry.ServerCA, telemetry.Issue, telemetry.X509SVID}, 1,
+		issueLabels(trustDomain, parentID, prefix))
+}
+
+func issueLabels(trustDomain, parentID, prefix string) []telemetry.Label {
+	labels := []telemetry.Label{
+		{Name: telemetry.TrustDomainID, Value: trustDomain},
+	}
+	if parentID != "" {
+		labels = append(labels, telemetry.Label{Name: telemetry.ParentID, Value: parentID})
+	}
+	if prefix != "" {
+		labels = append(labels, telemetry.Label{Name: telemetry.SPIFFEIDPrefix, Value: prefix})
+	}
+	return labels
+}
+
 // IncrServerCASignJWTSVIDCounter indicate Server CA
 // signed a JWT SVID. Takes SVID's SPIFFE ID
 func IncrServerCASignJWTSVIDCounter(m telemetry.Metrics, id string) {
@@ -0,0 +1,16 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartBackupCall return metric
+// for server's datastore, on backing up the database.
+func StartBackupCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Backup)
+}
+
+// End Call Counters
@@ -31,4 +31,10 @@ func StartPruneJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.JoinToken, telemetry.Prune)
 }
 
+// StartUseJoinTokenCall return metric
+// for server's datastore, on using a join token.
+func StartUseJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.JoinToken, telemetry.Use)
+}
+
 // End Call Counters
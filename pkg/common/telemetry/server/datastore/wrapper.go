@@ -25,6 +25,30 @@ func (w metricsWrapper) AppendBundle(ctx context.Context, req *datastore.AppendB
 	return w.ds.AppendBundle(ctx, req)
 }
 
+func (w metricsWrapper) Backup(ctx context.Context, req *datastore.BackupRequest) (_ *datastore.BackupResponse, err error) {
+	callCounter := StartBackupCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.Backup(ctx, req)
+}
+
+func (w metricsWrapper) CountAttestedNodes(ctx context.Context, req *datastore.CountAttestedNodesRequest) (_ *datastore.CountAttestedNodesResponse, err error) {
+	callCounter := StartCountNodeCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.CountAttestedNodes(ctx, req)
+}
+
+func (w metricsWrapper) CountBundles(ctx context.Context, req *datastore.CountBundlesRequest) (_ *datastore.CountBundlesResponse, err error) {
+	callCounter := StartCountBundleCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.CountBundles(ctx, req)
+}
+
+func (w metricsWrapper) CountRegistrationEntries(ctx context.Context, req *datastore.CountRegistrationEntriesRequest) (_ *datastore.CountRegistrationEntriesResponse, err error) {
+	callCounter := StartCountRegistrationCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.CountRegistrationEntries(ctx, req)
+}
+
 func (w metricsWrapper) CreateAttestedNode(ctx context.Context, req *datastore.CreateAttestedNodeRequest) (_ *datastore.CreateAttestedNodeResponse, err error) {
 	callCounter := StartCreateNodeCall(w.m)
 	defer callCounter.Done(&err)
@@ -37,6 +61,18 @@ func (w metricsWrapper) CreateBundle(ctx context.Context, req *datastore.CreateB
 	return w.ds.CreateBundle(ctx, req)
 }
 
+func (w metricsWrapper) CreateEntryTemplate(ctx context.Context, req *datastore.CreateEntryTemplateRequest) (_ *datastore.CreateEntryTemplateResponse, err error) {
+	callCounter := StartCreateEntryTemplateCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.CreateEntryTemplate(ctx, req)
+}
+
+func (w metricsWrapper) CreateFederationRelationship(ctx context.Context, req *datastore.CreateFederationRelationshipRequest) (_ *datastore.CreateFederationRelationshipResponse, err error) {
+	callCounter := StartCreateFederationRelationshipCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.CreateFederationRelationship(ctx, req)
+}
+
 func (w metricsWrapper) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (_ *datastore.CreateJoinTokenResponse, err error) {
 	callCounter := StartCreateJoinTokenCall(w.m)
 	defer callCounter.Done(&err)
@@ -61,6 +97,18 @@ func (w metricsWrapper) DeleteBundle(ctx context.Context, req *datastore.DeleteB
 	return w.ds.DeleteBundle(ctx, req)
 }
 
+func (w metricsWrapper) DeleteEntryTemplate(ctx context.Context, req *datastore.DeleteEntryTemplateRequest) (_ *datastore.DeleteEntryTemplateResponse, err error) {
+	callCounter := StartDeleteEntryTemplateCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.DeleteEntryTemplate(ctx, req)
+}
+
+func (w metricsWrapper) DeleteFederationRelationship(ctx context.Context, req *datastore.DeleteFederationRelationshipRequest) (_ *datastore.DeleteFederationRelationshipResponse, err error) {
+	callCounter := StartDeleteFederationRelationshipCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.DeleteFederationRelationship(ctx, req)
+}
+
 func (w metricsWrapper) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (_ *datastore.DeleteJoinTokenResponse, err error) {
 	callCounter := StartDeleteJoinTokenCall(w.m)
 	defer callCounter.Done(&err)
@@ -85,6 +133,18 @@ func (w metricsWrapper) FetchBundle(ctx context.Context, req *datastore.FetchBun
 	return w.ds.FetchBundle(ctx, req)
 }
 
+func (w metricsWrapper) FetchEntryTemplate(ctx context.Context, req *datastore.FetchEntryTemplateRequest) (_ *datastore.FetchEntryTemplateResponse, err error) {
+	callCounter := StartFetchEntryTemplateCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.FetchEntryTemplate(ctx, req)
+}
+
+func (w metricsWrapper) FetchFederationRelationship(ctx context.Context, req *datastore.FetchFederationRelationshipRequest) (_ *datastore.FetchFederationRelationshipResponse, err error) {
+	callCounter := StartFetchFederationRelationshipCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.FetchFederationRelationship(ctx, req)
+}
+
 func (w metricsWrapper) FetchJoinToken(ctx context.Context, req *datastore.FetchJoinTokenRequest) (_ *datastore.FetchJoinTokenResponse, err error) {
 	callCounter := StartFetchJoinTokenCall(w.m)
 	defer callCounter.Done(&err)
@@ -115,34 +175,28 @@ func (w metricsWrapper) ListBundles(ctx context.Context, req *datastore.ListBund
 	return w.ds.ListBundles(ctx, req)
 }
 
-func (w metricsWrapper) ListNodeSelectors(ctx context.Context, req *datastore.ListNodeSelectorsRequest) (_ *datastore.ListNodeSelectorsResponse, err error) {
-	callCounter := StartListNodeSelectorsCall(w.m)
-	defer callCounter.Done(&err)
-	return w.ds.ListNodeSelectors(ctx, req)
-}
-
-func (w metricsWrapper) ListRegistrationEntries(ctx context.Context, req *datastore.ListRegistrationEntriesRequest) (_ *datastore.ListRegistrationEntriesResponse, err error) {
-	callCounter := StartListRegistrationCall(w.m)
+func (w metricsWrapper) ListEntryTemplates(ctx context.Context, req *datastore.ListEntryTemplatesRequest) (_ *datastore.ListEntryTemplatesResponse, err error) {
+	callCounter := StartListEntryTemplatesCall(w.m)
 	defer callCounter.Done(&err)
-	return w.ds.ListRegistrationEntries(ctx, req)
+	return w.ds.ListEntryTemplates(ctx, req)
 }
 
-func (w metricsWrapper) CountAttestedNodes(ctx context.Context, req *datastore.CountAttestedNodesRequest) (_ *datastore.CountAttestedNodesResponse, err error) {
-	callCounter := StartCountNodeCall(w.m)
+func (w metricsWrapper) ListFederationRelationships(ctx context.Context, req *datastore.ListFederationRelationshipsRequest) (_ *datastore.ListFederationRelationshipsResponse, err error) {
+	callCounter := StartListFederationRelationshipsCall(w.m)
 	defer callCounter.Done(&err)
-	return w.ds.CountAttestedNodes(ctx, req)
+	return w.ds.ListFederationRelationships(ctx, req)
 }
 
-func (w metricsWrapper) CountBundles(ctx context.Context, req *datastore.CountBundlesRequest) (_ *datastore.CountBundlesResponse, err error) {
-	callCounter := StartCountBundleCall(w.m)
+func (w metricsWrapper) ListNodeSelectors(ctx context.Context, req *datastore.ListNodeSelectorsRequest) (_ *datastore.ListNodeSelectorsResponse, err error) {
+	callCounter := StartListNodeSelectorsCall(w.m)
 	defer callCounter.Done(&err)
-	return w.ds.CountBundles(ctx, req)
+	return w.ds.ListNodeSelectors(ctx, req)
 }
 
-func (w metricsWrapper) CountRegistrationEntries(ctx context.Context, req *datastore.CountRegistrationEntriesRequest) (_ *datastore.CountRegistrationEntriesResponse, err error) {
-	callCounter := StartCountRegistrationCall(w.m)
+func (w metricsWrapper) ListRegistrationEntries(ctx context.Context, req *datastore.ListRegistrationEntriesRequest) (_ *datastore.ListRegistrationEntriesResponse, err error) {
+	callCounter := StartListRegistrationCall(w.m)
 	defer callCounter.Done(&err)
-	return w.ds.CountRegistrationEntries(ctx, req)
+	return w.ds.ListRegistrationEntries(ctx, req)
 }
 
 func (w metricsWrapper) PruneBundle(ctx context.Context, req *datastore.PruneBundleRequest) (_ *datastore.PruneBundleResponse, err error) {
@@ -187,8 +241,26 @@ func (w metricsWrapper) UpdateBundle(ctx context.Context, req *datastore.UpdateB
 	return w.ds.UpdateBundle(ctx, req)
 }
 
+func (w metricsWrapper) UpdateEntryTemplate(ctx context.Context, req *datastore.UpdateEntryTemplateRequest) (_ *datastore.UpdateEntryTemplateResponse, err error) {
+	callCounter := StartUpdateEntryTemplateCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.UpdateEntryTemplate(ctx, req)
+}
+
+func (w metricsWrapper) UpdateFederationRelationship(ctx context.Context, req *datastore.UpdateFederationRelationshipRequest) (_ *datastore.UpdateFederationRelationshipResponse, err error) {
+	callCounter := StartUpdateFederationRelationshipCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.UpdateFederationRelationship(ctx, req)
+}
+
 func (w metricsWrapper) UpdateRegistrationEntry(ctx context.Context, req *datastore.UpdateRegistrationEntryRequest) (_ *datastore.UpdateRegistrationEntryResponse, err error) {
 	callCounter := StartUpdateRegistrationCall(w.m)
 	defer callCounter.Done(&err)
 	return w.ds.UpdateRegistrationEntry(ctx, req)
 }
+
+func (w metricsWrapper) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (_ *datastore.UseJoinTokenResponse, err error) {
+	callCounter := StartUseJoinTokenCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.UseJoinToken(ctx, req)
+}
@@ -37,6 +37,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.append",
 			methodName: "AppendBundle",
 		},
+		{
+			key:        "datastore.backup",
+			methodName: "Backup",
+		},
 		{
 			key:        "datastore.node.count",
 			methodName: "CountAttestedNodes",
@@ -57,6 +61,14 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.create",
 			methodName: "CreateBundle",
 		},
+		{
+			key:        "datastore.entry_template.create",
+			methodName: "CreateEntryTemplate",
+		},
+		{
+			key:        "datastore.federation_relationship.create",
+			methodName: "CreateFederationRelationship",
+		},
 		{
 			key:        "datastore.join_token.create",
 			methodName: "CreateJoinToken",
@@ -73,6 +85,14 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.delete",
 			methodName: "DeleteBundle",
 		},
+		{
+			key:        "datastore.entry_template.delete",
+			methodName: "DeleteEntryTemplate",
+		},
+		{
+			key:        "datastore.federation_relationship.delete",
+			methodName: "DeleteFederationRelationship",
+		},
 		{
 			key:        "datastore.join_token.delete",
 			methodName: "DeleteJoinToken",
@@ -89,6 +109,14 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.fetch",
 			methodName: "FetchBundle",
 		},
+		{
+			key:        "datastore.entry_template.fetch",
+			methodName: "FetchEntryTemplate",
+		},
+		{
+			key:        "datastore.federation_relationship.fetch",
+			methodName: "FetchFederationRelationship",
+		},
 		{
 			key:        "datastore.join_token.fetch",
 			methodName: "FetchJoinToken",
@@ -109,6 +137,14 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.list",
 			methodName: "ListBundles",
 		},
+		{
+			key:        "datastore.entry_template.list",
+			methodName: "ListEntryTemplates",
+		},
+		{
+			key:        "datastore.federation_relationship.list",
+			methodName: "ListFederationRelationships",
+		},
 		{
 			key:        "datastore.node.selectors.list",
 			methodName: "ListNodeSelectors",
@@ -145,10 +181,22 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.update",
 			methodName: "UpdateBundle",
 		},
+		{
+			key:        "datastore.entry_template.update",
+			methodName: "UpdateEntryTemplate",
+		},
+		{
+			key:        "datastore.federation_relationship.update",
+			methodName: "UpdateFederationRelationship",
+		},
 		{
 			key:        "datastore.registration_entry.update",
 			methodName: "UpdateRegistrationEntry",
 		},
+		{
+			key:        "datastore.join_token.use",
+			methodName: "UseJoinToken",
+		},
 	} {
 		tt := tt
 		methodType, ok := wt.MethodByName(tt.methodName)
@@ -224,6 +272,10 @@ func (ds *fakeDataStore) AppendBundle(context.Context, *datastore.AppendBundleRe
 	return &datastore.AppendBundleResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) Backup(context.Context, *datastore.BackupRequest) (*datastore.BackupResponse, error) {
+	return &datastore.BackupResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) CountAttestedNodes(context.Context, *datastore.CountAttestedNodesRequest) (*datastore.CountAttestedNodesResponse, error) {
 	return &datastore.CountAttestedNodesResponse{}, ds.err
 }
@@ -244,6 +296,14 @@ func (ds *fakeDataStore) CreateBundle(context.Context, *datastore.CreateBundleRe
 	return &datastore.CreateBundleResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) CreateEntryTemplate(context.Context, *datastore.CreateEntryTemplateRequest) (*datastore.CreateEntryTemplateResponse, error) {
+	return &datastore.CreateEntryTemplateResponse{}, ds.err
+}
+
+func (ds *fakeDataStore) CreateFederationRelationship(context.Context, *datastore.CreateFederationRelationshipRequest) (*datastore.CreateFederationRelationshipResponse, error) {
+	return &datastore.CreateFederationRelationshipResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) CreateJoinToken(context.Context, *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
 	return &datastore.CreateJoinTokenResponse{}, ds.err
 }
@@ -260,6 +320,14 @@ func (ds *fakeDataStore) DeleteBundle(context.Context, *datastore.DeleteBundleRe
 	return &datastore.DeleteBundleResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) DeleteEntryTemplate(context.Context, *datastore.DeleteEntryTemplateRequest) (*datastore.DeleteEntryTemplateResponse, error) {
+	return &datastore.DeleteEntryTemplateResponse{}, ds.err
+}
+
+func (ds *fakeDataStore) DeleteFederationRelationship(context.Context, *datastore.DeleteFederationRelationshipRequest) (*datastore.DeleteFederationRelationshipResponse, error) {
+	return &datastore.DeleteFederationRelationshipResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) DeleteJoinToken(context.Context, *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
 	return &datastore.DeleteJoinTokenResponse{}, ds.err
 }
@@ -276,6 +344,14 @@ func (ds *fakeDataStore) FetchBundle(context.Context, *datastore.FetchBundleRequ
 	return &datastore.FetchBundleResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) FetchEntryTemplate(context.Context, *datastore.FetchEntryTemplateRequest) (*datastore.FetchEntryTemplateResponse, error) {
+	return &datastore.FetchEntryTemplateResponse{}, ds.err
+}
+
+func (ds *fakeDataStore) FetchFederationRelationship(context.Context, *datastore.FetchFederationRelationshipRequest) (*datastore.FetchFederationRelationshipResponse, error) {
+	return &datastore.FetchFederationRelationshipResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) FetchJoinToken(context.Context, *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
 	return &datastore.FetchJoinTokenResponse{}, ds.err
 }
@@ -296,6 +372,14 @@ func (ds *fakeDataStore) ListBundles(context.Context, *datastore.ListBundlesRequ
 	return &datastore.ListBundlesResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) ListEntryTemplates(context.Context, *datastore.ListEntryTemplatesRequest) (*datastore.ListEntryTemplatesResponse, error) {
+	return &datastore.ListEntryTemplatesResponse{}, ds.err
+}
+
+func (ds *fakeDataStore) ListFederationRelationships(context.Context, *datastore.ListFederationRelationshipsRequest) (*datastore.ListFederationRelationshipsResponse, error) {
+	return &datastore.ListFederationRelationshipsResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) ListNodeSelectors(context.Context, *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
 	return &datastore.ListNodeSelectorsResponse{}, ds.err
 }
@@ -332,6 +416,18 @@ func (ds *fakeDataStore) UpdateBundle(context.Context, *datastore.UpdateBundleRe
 	return &datastore.UpdateBundleResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) UpdateEntryTemplate(context.Context, *datastore.UpdateEntryTemplateRequest) (*datastore.UpdateEntryTemplateResponse, error) {
+	return &datastore.UpdateEntryTemplateResponse{}, ds.err
+}
+
+func (ds *fakeDataStore) UpdateFederationRelationship(context.Context, *datastore.UpdateFederationRelationshipRequest) (*datastore.UpdateFederationRelationshipResponse, error) {
+	return &datastore.UpdateFederationRelationshipResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) UpdateRegistrationEntry(context.Context, *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
 	return &datastore.UpdateRegistrationEntryResponse{}, ds.err
 }
+
+func (ds *fakeDataStore) UseJoinToken(context.Context, *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	return &datastore.UseJoinTokenResponse{}, ds.err
+}
@@ -1,6 +1,10 @@
 package server
 
-import "github.com/spiffe/spire/pkg/common/telemetry"
+import (
+	"strconv"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
 
 // Call Counters (timing and success metrics)
 // Allows adding labels in-code
@@ -11,4 +15,37 @@ func StartRegistrationManagerPruneEntryCall(m telemetry.Metrics) *telemetry.Call
 	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Prune)
 }
 
+// StartRegistrationManagerPruneAgentsCall returns metric for
+// server registration manager expired agent pruning
+func StartRegistrationManagerPruneAgentsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Node, telemetry.Manager, telemetry.Prune)
+}
+
+// StartRegistrationManagerPruneOrphanedEntriesCall returns metric for
+// server registration manager orphaned entry pruning
+func StartRegistrationManagerPruneOrphanedEntriesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Orphaned, telemetry.Prune)
+}
+
 // End Call Counters
+
+// Counters (literal increments, not call counters)
+
+// IncrRegistrationManagerPrunedAgentsCounter indicates the registration
+// manager pruned an expired agent, or would have, if running in dry run mode
+func IncrRegistrationManagerPrunedAgentsCounter(m telemetry.Metrics, dryRun bool) {
+	m.IncrCounterWithLabels([]string{telemetry.Node, telemetry.Manager, telemetry.Pruned}, 1, []telemetry.Label{
+		{Name: telemetry.DryRun, Value: strconv.FormatBool(dryRun)},
+	})
+}
+
+// IncrRegistrationManagerPrunedOrphanedEntriesCounter indicates the
+// registration manager pruned an orphaned entry, or would have, if running in
+// dry run mode
+func IncrRegistrationManagerPrunedOrphanedEntriesCounter(m telemetry.Metrics, dryRun bool) {
+	m.IncrCounterWithLabels([]string{telemetry.RegistrationEntry, telemetry.Manager, telemetry.Orphaned, telemetry.Pruned}, 1, []telemetry.Label{
+		{Name: telemetry.DryRun, Value: strconv.FormatBool(dryRun)},
+	})
+}
+
+// End Counters
@@ -55,6 +55,12 @@ func (w *withLabels) MeasureSinceWithLabels(key []string, start time.Time, label
 	w.metrics.MeasureSinceWithLabels(key, start, w.combineLabels(labels))
 }
 
+func (w *withLabels) recordSpan(key []string, start, end time.Time, labels []Label) {
+	if recorder, ok := w.metrics.(spanRecorder); ok {
+		recorder.recordSpan(key, start, end, w.combineLabels(labels))
+	}
+}
+
 func (w *withLabels) combineLabels(labels []Label) (combined []Label) {
 	combined = append(combined, w.labels...)
 	combined = append(combined, labels...)
@@ -20,8 +20,7 @@ func MakeCSR(privateKey interface{}, spiffeID string) ([]byte, error) {
 			Country:      []string{"US"},
 			Organization: []string{"SPIRE"},
 		},
-		SignatureAlgorithm: x509.ECDSAWithSHA256,
-		URIs:               []*url.URL{uri},
+		URIs: []*url.URL{uri},
 	})
 }
 
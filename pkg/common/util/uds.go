@@ -0,0 +1,15 @@
+package util
+
+import (
+	"net"
+	"strings"
+)
+
+// IsAbstractUnixSocket returns whether the address names a socket in the
+// abstract namespace (Linux only), which is denoted by a leading "@". Such
+// sockets are not backed by a file, so they can be reached from any process
+// sharing the network namespace without mounting the socket, and they have
+// no file permissions.
+func IsAbstractUnixSocket(addr *net.UnixAddr) bool {
+	return strings.HasPrefix(addr.Name, "@")
+}
@@ -0,0 +1,91 @@
+package x509svid
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+)
+
+// Key types that can be requested for X509-SVIDs minted for a registration
+// entry.
+const (
+	KeyTypeECP256  = "ec-p256"
+	KeyTypeECP384  = "ec-p384"
+	KeyTypeRSA2048 = "rsa-2048"
+	KeyTypeRSA4096 = "rsa-4096"
+)
+
+// DefaultKeyType is the key type used when an entry does not request one.
+const DefaultKeyType = KeyTypeECP256
+
+// ValidateKeyType returns an error if the key type is not supported. An empty
+// key type is valid and means the default key type.
+func ValidateKeyType(keyType string) error {
+	switch keyType {
+	case "", KeyTypeECP256, KeyTypeECP384, KeyTypeRSA2048, KeyTypeRSA4096:
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
+
+// GenerateKey generates a private key of the given key type. An empty key
+// type generates a key of the default key type.
+func GenerateKey(keyType string) (crypto.Signer, error) {
+	switch keyType {
+	case "", KeyTypeECP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyTypeECP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case KeyTypeRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case KeyTypeRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
+
+// ValidatePublicKey returns an error if the public key does not match the
+// key type. Any public key matches an empty key type.
+func ValidatePublicKey(keyType string, publicKey crypto.PublicKey) error {
+	if keyType == "" {
+		return nil
+	}
+	actual, err := keyTypeOf(publicKey)
+	if err != nil {
+		return err
+	}
+	if keyType != actual {
+		return fmt.Errorf("expected key type %q but got %q", keyType, actual)
+	}
+	return nil
+}
+
+func keyTypeOf(publicKey crypto.PublicKey) (string, error) {
+	switch publicKey := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve {
+		case elliptic.P256():
+			return KeyTypeECP256, nil
+		case elliptic.P384():
+			return KeyTypeECP384, nil
+		default:
+			return "", fmt.Errorf("unsupported EC curve %q", publicKey.Curve.Params().Name)
+		}
+	case *rsa.PublicKey:
+		switch publicKey.N.BitLen() {
+		case 2048:
+			return KeyTypeRSA2048, nil
+		case 4096:
+			return KeyTypeRSA4096, nil
+		default:
+			return "", fmt.Errorf("unsupported RSA key size %d", publicKey.N.BitLen())
+		}
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
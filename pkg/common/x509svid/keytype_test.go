@@ -0,0 +1,52 @@
+package x509svid
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKeyType(t *testing.T) {
+	for _, keyType := range []string{"", KeyTypeECP256, KeyTypeECP384, KeyTypeRSA2048, KeyTypeRSA4096} {
+		require.NoError(t, ValidateKeyType(keyType))
+	}
+	require.EqualError(t, ValidateKeyType("ed25519"), `unsupported key type "ed25519"`)
+}
+
+func TestGenerateKey(t *testing.T) {
+	for _, keyType := range []string{"", KeyTypeECP256, KeyTypeECP384, KeyTypeRSA2048} {
+		key, err := GenerateKey(keyType)
+		require.NoError(t, err)
+		require.NoError(t, ValidatePublicKey(keyType, key.Public()))
+	}
+
+	key, err := GenerateKey("")
+	require.NoError(t, err)
+	require.NoError(t, ValidatePublicKey(DefaultKeyType, key.Public()))
+
+	_, err = GenerateKey("ed25519")
+	require.EqualError(t, err, `unsupported key type "ed25519"`)
+}
+
+func TestValidatePublicKey(t *testing.T) {
+	ec256 := testkey.NewEC256(t).Public()
+	ec384 := testkey.NewEC384(t).Public()
+	rsa2048 := testkey.NewRSA2048(t).Public()
+
+	require.NoError(t, ValidatePublicKey("", ec256))
+	require.NoError(t, ValidatePublicKey("", rsa2048))
+	require.NoError(t, ValidatePublicKey(KeyTypeECP256, ec256))
+	require.NoError(t, ValidatePublicKey(KeyTypeECP384, ec384))
+	require.NoError(t, ValidatePublicKey(KeyTypeRSA2048, rsa2048))
+	require.EqualError(t, ValidatePublicKey(KeyTypeRSA2048, ec256), `expected key type "rsa-2048" but got "ec-p256"`)
+	require.EqualError(t, ValidatePublicKey(KeyTypeECP256, ec384), `expected key type "ec-p256" but got "ec-p384"`)
+
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, ValidatePublicKey("", p224.Public()))
+	require.EqualError(t, ValidatePublicKey(KeyTypeECP256, p224.Public()), `unsupported EC curve "P-224"`)
+}
@@ -0,0 +1,31 @@
+package x509util
+
+import (
+	"crypto/x509"
+	"time"
+)
+
+// VerifyWithClockSkew verifies the certificate at the given time, tolerating
+// certificates that are not yet valid because the clock of their issuer is
+// ahead by up to the given tolerance. Expired certificates are never accepted.
+// opts.CurrentTime is ignored.
+func VerifyWithClockSkew(cert *x509.Certificate, opts x509.VerifyOptions, now time.Time, tolerance time.Duration) ([][]*x509.Certificate, error) {
+	opts.CurrentTime = now
+	chains, err := cert.Verify(opts)
+	if err == nil || tolerance <= 0 {
+		return chains, err
+	}
+
+	// Go reports certificates outside of their validity period, whether not
+	// yet valid or expired, with the Expired reason. Since verifying at a
+	// later time cannot make an expired certificate valid, retrying at the
+	// edge of the tolerance only lets not yet valid certificates through.
+	if invalid, ok := err.(x509.CertificateInvalidError); !ok || invalid.Reason != x509.Expired {
+		return nil, err
+	}
+	opts.CurrentTime = now.Add(tolerance)
+	if chains, skewErr := cert.Verify(opts); skewErr == nil {
+		return chains, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,53 @@
+package x509util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyWithClockSkew(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	notYetValid := createSelfSignedCertificate(t, now.Add(20*time.Second), now.Add(time.Hour))
+	expired := createSelfSignedCertificate(t, now.Add(-time.Hour), now.Add(-20*time.Second))
+
+	verify := func(cert *x509.Certificate, tolerance time.Duration) error {
+		roots := x509.NewCertPool()
+		roots.AddCert(cert)
+		_, err := VerifyWithClockSkew(cert, x509.VerifyOptions{Roots: roots}, now, tolerance)
+		return err
+	}
+
+	// Certificates that are not yet valid are accepted within the tolerance
+	require.Error(t, verify(notYetValid, 0))
+	require.Error(t, verify(notYetValid, 10*time.Second))
+	require.NoError(t, verify(notYetValid, 30*time.Second))
+
+	// Expired certificates are never accepted
+	require.Error(t, verify(expired, 0))
+	require.Error(t, verify(expired, time.Minute))
+}
+
+func createSelfSignedCertificate(t *testing.T, notBefore, notAfter time.Time) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
@@ -5,25 +5,28 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
-	"io"
+	"net"
 	"path"
 	"time"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/nodeattestation"
+	"github.com/spiffe/spire/pkg/server/noderesolution"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
-	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
 	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
@@ -45,6 +48,15 @@ type Config struct {
 	DataStore   datastore.DataStore
 	ServerCA    ca.ServerCA
 	TrustDomain spiffeid.TrustDomain
+
+	// AttestationLimits bounds the challenge/response exchange of node
+	// attestation.
+	AttestationLimits nodeattestation.Limits
+
+	// RoleBindings are the role bindings of the server API. They are used
+	// to keep callers bound to a role from mapping join tokens to privileged
+	// agent IDs.
+	RoleBindings []middleware.RoleBinding
 }
 
 // New creates a new agent service
@@ -55,6 +67,9 @@ func New(config Config) *Service {
 		ds:  config.DataStore,
 		ca:  config.ServerCA,
 		td:  config.TrustDomain,
+
+		roleBindings: config.RoleBindings,
+		relay:        nodeattestation.NewRelay(config.AttestationLimits, config.Clock),
 	}
 }
 
@@ -65,6 +80,9 @@ type Service struct {
 	ds  datastore.DataStore
 	ca  ca.ServerCA
 	td  spiffeid.TrustDomain
+
+	roleBindings []middleware.RoleBinding
+	relay        *nodeattestation.Relay
 }
 
 func (s *Service) ListAgents(ctx context.Context, req *agent.ListAgentsRequest) (*agent.ListAgentsResponse, error) {
@@ -80,6 +98,12 @@ func (s *Service) ListAgents(ctx context.Context, req *agent.ListAgentsRequest)
 		filter := req.Filter
 		listReq.ByAttestationType = filter.ByAttestationType
 		listReq.ByBanned = filter.ByBanned
+		if filter.ByExpiresBefore != 0 {
+			listReq.ByExpiresBefore = &wrappers.Int64Value{Value: filter.ByExpiresBefore}
+		}
+		if filter.ByExpiresAfter != 0 {
+			listReq.ByExpiresAfter = &wrappers.Int64Value{Value: filter.ByExpiresAfter}
+		}
 
 		if filter.BySelectorMatch != nil {
 			selectors, err := api.SelectorsFromProto(filter.BySelectorMatch.Selectors)
@@ -127,6 +151,32 @@ func (s *Service) ListAgents(ctx context.Context, req *agent.ListAgentsRequest)
 	return resp, nil
 }
 
+func (s *Service) CountAgents(ctx context.Context, req *agent.CountAgentsRequest) (*agent.CountAgentsResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	countReq := &datastore.CountAttestedNodesRequest{}
+	if req.Filter != nil {
+		filter := req.Filter
+		countReq.ByAttestationType = filter.ByAttestationType
+		countReq.ByBanned = filter.ByBanned
+		if filter.ByExpiresBefore != 0 {
+			countReq.ByExpiresBefore = &wrappers.Int64Value{Value: filter.ByExpiresBefore}
+		}
+		if filter.ByExpiresAfter != 0 {
+			countReq.ByExpiresAfter = &wrappers.Int64Value{Value: filter.ByExpiresAfter}
+		}
+	}
+
+	dsResp, err := s.ds.CountAttestedNodes(ctx, countReq)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to count agents", err)
+	}
+
+	return &agent.CountAgentsResponse{
+		Count: dsResp.Nodes,
+	}, nil
+}
+
 func (s *Service) GetAgent(ctx context.Context, req *agent.GetAgentRequest) (*types.Agent, error) {
 	log := rpccontext.Logger(ctx)
 
@@ -216,6 +266,51 @@ func (s *Service) BanAgent(ctx context.Context, req *agent.BanAgentRequest) (*em
 	}
 }
 
+func (s *Service) ReattestAgent(ctx context.Context, req *agent.ReattestAgentRequest) (*empty.Empty, error) {
+	log := rpccontext.Logger(ctx)
+
+	id, err := api.TrustDomainAgentIDFromProto(s.td, req.Id)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
+	}
+
+	log = log.WithField(telemetry.SPIFFEID, id.String())
+
+	resp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: id.String(),
+	})
+	switch {
+	case err != nil:
+		return nil, api.MakeErr(log, codes.Internal, "failed to fetch agent", err)
+	case resp.Node == nil:
+		return nil, api.MakeErr(log, codes.NotFound, "agent not found", nil)
+	case nodeutil.IsAgentBanned(resp.Node):
+		return nil, api.MakeErr(log, codes.FailedPrecondition, "agent is banned", nil)
+	}
+
+	// The agent "forced to re-attest" state is pointed out by setting its
+	// current serial number to one that no SVID has and clearing the new
+	// one, so the agent SVIDs are no longer accepted.
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:         id.String(),
+		CertSerialNumber: nodeutil.ReattestSerialNumber,
+		InputMask: &common.AttestedNodeMask{
+			CertSerialNumber:    true,
+			NewCertSerialNumber: true,
+		},
+	})
+
+	switch status.Code(err) {
+	case codes.OK:
+		log.Info("Agent forced to re-attest")
+		return &empty.Empty{}, nil
+	case codes.NotFound:
+		return nil, api.MakeErr(log, codes.NotFound, "agent not found", err)
+	default:
+		return nil, api.MakeErr(log, codes.Internal, "failed to force agent to re-attest", err)
+	}
+}
+
 func (s *Service) AttestAgent(stream agent.Agent_AttestAgentServer) error {
 	ctx := stream.Context()
 	log := rpccontext.Logger(ctx)
@@ -236,6 +331,7 @@ func (s *Service) AttestAgent(stream agent.Agent_AttestAgentServer) error {
 	}
 
 	log = log.WithField(telemetry.NodeAttestorType, params.Data.Type)
+	rpccontext.AddAuditFields(ctx, logrus.Fields{telemetry.NodeAttestorType: params.Data.Type})
 
 	// attest
 	var attestResp *nodeattestor.AttestResponse
@@ -257,6 +353,7 @@ func (s *Service) AttestAgent(stream agent.Agent_AttestAgentServer) error {
 		return api.MakeErr(log, codes.Internal, "invalid agent id", err)
 	}
 	log = log.WithField(telemetry.AgentID, agentID)
+	rpccontext.AddAuditFields(ctx, logrus.Fields{telemetry.AgentID: agentID})
 
 	// fetch the agent/node to check if it was already attested or banned
 	attestedNode, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
@@ -385,15 +482,26 @@ func (s *Service) CreateJoinToken(ctx context.Context, req *agent.CreateJoinToke
 		return nil, api.MakeErr(log, codes.InvalidArgument, "ttl is required, you must provide one", nil)
 	}
 
-	// If provided, check that the AgentID is valid BEFORE creating the join token so we can fail early
-	var agentID spiffeid.ID
-	var err error
-	if req.AgentId != nil {
-		agentID, err = api.TrustDomainWorkloadIDFromProto(s.td, req.AgentId)
-		if err != nil {
-			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
+	if req.MaxUses < 0 {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "max uses cannot be negative", nil)
+	}
+
+	for _, cidr := range req.AllowedCidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid allowed CIDR", err)
+		}
+	}
+
+	selectors, err := api.SelectorsFromProto(req.Selectors)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid selectors", err)
+	}
+	// The selectors become node selectors of the agents attesting with the
+	// token, so they cannot impersonate those of another attestor
+	for _, selector := range selectors {
+		if selector.Type != "join_token" {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid selectors", fmt.Errorf("selector type must be %q, got %q", "join_token", selector.Type))
 		}
-		log.WithField(telemetry.SPIFFEID, agentID.String())
 	}
 
 	// Generate a token if one wasn't specified
@@ -405,21 +513,46 @@ func (s *Service) CreateJoinToken(ctx context.Context, req *agent.CreateJoinToke
 		req.Token = u.String()
 	}
 
+	// If provided, check that the AgentID is valid BEFORE creating the join token so we can fail early
+	var aliasEntry *common.RegistrationEntry
+	if req.AgentId != nil {
+		// Agents attesting with a multi-use token are assigned unique IDs,
+		// so there is no single agent ID to map to the given one
+		if req.MaxUses > 1 {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "agent ID cannot be set for tokens that can be used more than once", nil)
+		}
+
+		agentID, err := api.TrustDomainWorkloadIDFromProto(s.td, req.AgentId)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
+		}
+		log = log.WithField(telemetry.SPIFFEID, agentID.String())
+
+		aliasEntry = s.joinTokenRegistrationEntry(req.Token, agentID)
+		if err := s.checkPrivileges(ctx, aliasEntry); err != nil {
+			return nil, err
+		}
+	}
+
 	expiry := time.Now().Unix() + int64(req.Ttl)
 
 	result, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
 		JoinToken: &datastore.JoinToken{
-			Token:  req.Token,
-			Expiry: expiry,
+			Token:        req.Token,
+			Expiry:       expiry,
+			MaxUses:      req.MaxUses,
+			AllowedCidrs: req.AllowedCidrs,
+			Selectors:    selectors,
 		},
 	})
 	if err != nil {
 		return nil, api.MakeErr(log, codes.Internal, "failed to create token", err)
 	}
 
-	if req.AgentId != nil {
-		err := s.createJoinTokenRegistrationEntry(ctx, req.Token, agentID.String())
-		if err != nil {
+	if aliasEntry != nil {
+		if _, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+			Entry: aliasEntry,
+		}); err != nil {
 			return nil, api.MakeErr(log, codes.Internal, "failed to create join token registration entry", err)
 		}
 	}
@@ -427,20 +560,34 @@ func (s *Service) CreateJoinToken(ctx context.Context, req *agent.CreateJoinToke
 	return &types.JoinToken{Value: result.JoinToken.Token, ExpiresAt: expiry}, nil
 }
 
-func (s *Service) createJoinTokenRegistrationEntry(ctx context.Context, token string, agentID string) error {
+// joinTokenRegistrationEntry returns the node alias entry mapping the agent
+// attesting with the token to the given agent ID.
+func (s *Service) joinTokenRegistrationEntry(token string, agentID spiffeid.ID) *common.RegistrationEntry {
 	parentID := s.td.NewID(path.Join("spire", "agent", "join_token", token))
-	req := &datastore.CreateRegistrationEntryRequest{
-		Entry: &common.RegistrationEntry{
-			ParentId: parentID.String(),
-			SpiffeId: agentID,
-			Selectors: []*common.Selector{
-				{Type: "spiffe_id", Value: parentID.String()},
-			},
+	return &common.RegistrationEntry{
+		ParentId: parentID.String(),
+		SpiffeId: agentID.String(),
+		Selectors: []*common.Selector{
+			{Type: "spiffe_id", Value: parentID.String()},
 		},
 	}
-	_, err := s.ds.CreateRegistrationEntry(ctx, req)
+}
+
+// checkPrivileges makes sure that callers that are neither local nor admin,
+// i.e. callers bound to a role, cannot map a join token to an agent ID that
+// would receive admin or downstream entries.
+func (s *Service) checkPrivileges(ctx context.Context, entry *common.RegistrationEntry) error {
+	log := rpccontext.Logger(ctx)
+	if rpccontext.CallerIsLocal(ctx) || rpccontext.CallerIsAdmin(ctx) {
+		return nil
+	}
+
+	resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
 	if err != nil {
-		return err
+		return api.MakeErr(log, codes.Internal, "failed to list entries", err)
+	}
+	if err := middleware.CheckEntryPrivileges(ctx, s.roleBindings, entry, resp.Entries); err != nil {
+		return api.MakeErr(log, codes.PermissionDenied, "agent ID rejected", err)
 	}
 	return nil
 }
@@ -499,22 +646,68 @@ func (s *Service) attestJoinToken(ctx context.Context, token string) (*nodeattes
 		return nil, api.MakeErr(log, codes.InvalidArgument, "failed to attest: join token does not exist or has already been used", nil)
 	}
 
-	_, err = s.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+	// The address is checked before using the token so that callers that
+	// are not allowed to use it cannot exhaust it
+	if len(resp.JoinToken.AllowedCidrs) > 0 && !callerInCIDRs(ctx, resp.JoinToken.AllowedCidrs) {
+		return nil, api.MakeErr(log, codes.PermissionDenied, "failed to attest: caller address is not allowed to use the join token", nil)
+	}
+
+	useResp, err := s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{
 		Token: token,
 	})
 	switch {
 	case err != nil:
-		return nil, api.MakeErr(log, codes.Internal, "failed to delete join token", err)
-	case time.Unix(resp.JoinToken.Expiry, 0).Before(s.clk.Now()):
+		return nil, api.MakeErr(log, codes.Internal, "failed to use join token", err)
+	case useResp.JoinToken == nil:
+		return nil, api.MakeErr(log, codes.InvalidArgument, "failed to attest: join token does not exist or has already been used", nil)
+	case time.Unix(useResp.JoinToken.Expiry, 0).Before(s.clk.Now()):
 		return nil, api.MakeErr(log, codes.InvalidArgument, "join token expired", nil)
 	}
 
 	tokenPath := path.Join("spire", "agent", "join_token", token)
+	if useResp.JoinToken.MaxUses > 1 {
+		// Each agent attesting with a multi-use token gets a unique ID
+		u, err := uuid.NewV4()
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to generate agent ID", err)
+		}
+		tokenPath = path.Join(tokenPath, u.String())
+	}
+
+	// Only join_token selectors are accepted when creating tokens; skip any
+	// other type that a token stored before that was enforced may carry
+	var selectors []*common.Selector
+	for _, selector := range useResp.JoinToken.Selectors {
+		if selector.Type == "join_token" {
+			selectors = append(selectors, selector)
+		}
+	}
+
 	return &nodeattestor.AttestResponse{
-		AgentId: s.td.NewID(tokenPath).String(),
+		AgentId:   s.td.NewID(tokenPath).String(),
+		Selectors: selectors,
 	}, nil
 }
 
+// callerInCIDRs returns true if the caller connected from an IP address
+// within one of the given CIDRs.
+func callerInCIDRs(ctx context.Context, cidrs []string) bool {
+	tcpAddr, ok := rpccontext.CallerAddr(ctx).(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) attestChallengeResponse(ctx context.Context, agentStream agent.Agent_AttestAgentServer, params *agent.AttestAgentRequest_Params) (*nodeattestor.AttestResponse, error) {
 	attestorType := params.Data.Type
 	log := rpccontext.Logger(ctx).WithField(telemetry.NodeAttestorType, attestorType)
@@ -524,60 +717,39 @@ func (s *Service) attestChallengeResponse(ctx context.Context, agentStream agent
 		return nil, api.MakeErr(log, codes.FailedPrecondition, "could not find node attestor type", nil)
 	}
 
-	attestorStream, err := nodeAttestor.Attest(ctx)
+	attestResp, err := s.relay.Attest(ctx, nodeAttestor, &common.AttestationData{
+		Type: attestorType,
+		Data: params.Data.Payload,
+	}, attestAgentStream{stream: agentStream})
 	if err != nil {
-		return nil, api.MakeErr(log, codes.Internal, "failed to open stream with attestor", err)
-	}
-
-	attestRequest := &nodeattestor.AttestRequest{
-		AttestationData: &common.AttestationData{
-			Type: attestorType,
-			Data: params.Data.Payload,
-		},
-	}
-	var attestResp *nodeattestor.AttestResponse
-
-	for {
-		attestResp, err = attest(attestorStream, attestRequest)
-		if err != nil {
-			return nil, api.MakeErr(log, codes.Internal, "failed to attest", err)
-		}
-		// Without a challenge we are done. Otherwise we need to continue the challenge/response flow
-		if attestResp.Challenge == nil {
-			break
-		}
-
-		resp := &agent.AttestAgentResponse{
-			Step: &agent.AttestAgentResponse_Challenge{
-				Challenge: attestResp.Challenge,
-			},
-		}
-		if err := agentStream.Send(resp); err != nil {
-			return nil, api.MakeErr(log, codes.Internal, "failed to send challenge to agent", err)
-		}
-
-		req, err := agentStream.Recv()
-		if err != nil {
-			return nil, api.MakeErr(log, codes.Internal, "failed to receive challenge from agent", err)
-		}
-
-		attestRequest = &nodeattestor.AttestRequest{
-			Response: req.GetChallengeResponse(),
+		var relayErr *nodeattestation.Error
+		if errors.As(err, &relayErr) {
+			return nil, api.MakeErr(log, relayErr.Code, relayErr.Msg, relayErr.Err)
 		}
+		return nil, api.MakeErr(log, codes.Internal, "failed to attest", err)
 	}
+	return attestResp, nil
+}
 
-	if attestResp.AgentId == "" {
-		return nil, api.MakeErr(log, codes.Internal, "failed to attest: AgentID response should not be empty", nil)
-	}
+// attestAgentStream is the agent end of the challenge/response exchange
+type attestAgentStream struct {
+	stream agent.Agent_AttestAgentServer
+}
 
-	if err := attestorStream.CloseSend(); err != nil {
-		return nil, api.MakeErr(log, codes.Internal, "failed to close send stream", err)
-	}
-	if _, err := attestorStream.Recv(); err != io.EOF {
-		log.WithError(err).Warn("Expected EOF on attestation stream")
-	}
+func (a attestAgentStream) SendChallenge(challenge []byte) error {
+	return a.stream.Send(&agent.AttestAgentResponse{
+		Step: &agent.AttestAgentResponse_Challenge{
+			Challenge: challenge,
+		},
+	})
+}
 
-	return attestResp, nil
+func (a attestAgentStream) RecvResponse() ([]byte, error) {
+	req, err := a.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return req.GetChallengeResponse(), nil
 }
 
 func (s *Service) augmentSelectors(ctx context.Context, agentID string, selectors []*common.Selector, attestationType string) ([]*common.Selector, error) {
@@ -585,25 +757,12 @@ func (s *Service) augmentSelectors(ctx context.Context, agentID string, selector
 		WithField(telemetry.AgentID, agentID).
 		WithField(telemetry.NodeAttestorType, attestationType)
 
-	// Select node resolver based on request attestation type
-	nodeResolver, ok := s.cat.GetNodeResolverNamed(attestationType)
-	if !ok {
-		log.Debug("Could not find node resolver")
-		return selectors, nil
-	}
-
-	//Call node resolver plugin to get a map of spiffeID=>Selector
-	response, err := nodeResolver.Resolve(ctx, &noderesolver.ResolveRequest{
-		BaseSpiffeIdList: []string{agentID},
-	})
+	resolved, err := noderesolution.Resolve(ctx, log, s.cat, agentID, attestationType)
 	if err != nil {
 		return nil, err
 	}
-	if resolved := response.Map[agentID]; resolved != nil {
-		selectors = append(selectors, resolved.Entries...)
-	}
 
-	return selectors, nil
+	return append(selectors, resolved...), nil
 }
 
 func applyMask(a *types.Agent, mask *types.AgentMask) {
@@ -650,13 +809,6 @@ func validateAttestAgentParams(params *agent.AttestAgentRequest_Params) error {
 	}
 }
 
-func attest(attestorStream nodeattestor.NodeAttestor_AttestClient, attestRequest *nodeattestor.AttestRequest) (*nodeattestor.AttestResponse, error) {
-	if err := attestorStream.Send(attestRequest); err != nil {
-		return nil, err
-	}
-	return attestorStream.Recv()
-}
-
 func getAttestAgentResponse(spiffeID spiffeid.ID, certificates []*x509.Certificate) *agent.AttestAgentResponse {
 	svid := &types.X509SVID{
 		Id:        api.ProtoFromID(spiffeID),
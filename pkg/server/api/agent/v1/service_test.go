@@ -6,6 +6,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"testing"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
@@ -123,6 +125,7 @@ func TestListAgents(t *testing.T) {
 
 	notAfter := time.Now().Add(-time.Minute).Unix()
 	newNoAfter := time.Now().Add(time.Minute).Unix()
+	bannedNotAfter := time.Now().Add(time.Hour).Unix()
 	node1ID := spiffeid.Must("example.org", "node1")
 	node1 := &common.AttestedNode{
 		SpiffeId:            node1ID.String(),
@@ -176,7 +179,7 @@ func TestListAgents(t *testing.T) {
 		SpiffeId:            node3ID.String(),
 		AttestationDataType: "t3",
 		CertSerialNumber:    "",
-		CertNotAfter:        notAfter,
+		CertNotAfter:        bannedNotAfter,
 		NewCertNotAfter:     newNoAfter,
 		NewCertSerialNumber: "",
 	}
@@ -239,7 +242,7 @@ func TestListAgents(t *testing.T) {
 						Id:                   api.ProtoFromID(node3ID),
 						AttestationType:      "t3",
 						Banned:               true,
-						X509SvidExpiresAt:    notAfter,
+						X509SvidExpiresAt:    bannedNotAfter,
 						X509SvidSerialNumber: "",
 					},
 				},
@@ -301,6 +304,35 @@ func TestListAgents(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "by expires before",
+			req: &agentpb.ListAgentsRequest{
+				OutputMask: &types.AgentMask{},
+				Filter: &agentpb.ListAgentsRequest_Filter{
+					ByExpiresBefore: time.Now().Unix(),
+				},
+			},
+			expectResp: &agentpb.ListAgentsResponse{
+				Agents: []*types.Agent{
+					{Id: api.ProtoFromID(node1ID)},
+					{Id: api.ProtoFromID(node2ID)},
+				},
+			},
+		},
+		{
+			name: "by expires after",
+			req: &agentpb.ListAgentsRequest{
+				OutputMask: &types.AgentMask{},
+				Filter: &agentpb.ListAgentsRequest_Filter{
+					ByExpiresAfter: time.Now().Unix(),
+				},
+			},
+			expectResp: &agentpb.ListAgentsResponse{
+				Agents: []*types.Agent{
+					{Id: api.ProtoFromID(node3ID)},
+				},
+			},
+		},
 		{
 			name: "by selectors",
 			req: &agentpb.ListAgentsRequest{
@@ -395,6 +427,126 @@ func TestListAgents(t *testing.T) {
 	}
 }
 
+func TestCountAgents(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	expired := time.Now().Add(-time.Minute).Unix()
+	valid := time.Now().Add(time.Hour).Unix()
+	for _, node := range []*common.AttestedNode{
+		{
+			SpiffeId:            spiffeid.Must("example.org", "node1").String(),
+			AttestationDataType: "t1",
+			CertSerialNumber:    "badcafe",
+			CertNotAfter:        expired,
+		},
+		{
+			SpiffeId:            spiffeid.Must("example.org", "node2").String(),
+			AttestationDataType: "t1",
+			CertSerialNumber:    "deadbeef",
+			CertNotAfter:        valid,
+		},
+		{
+			SpiffeId:            spiffeid.Must("example.org", "node3").String(),
+			AttestationDataType: "t2",
+			CertNotAfter:        valid,
+		},
+	} {
+		_, err := test.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+			Node: node,
+		})
+		require.NoError(t, err)
+	}
+
+	for _, tt := range []struct {
+		name string
+
+		code        codes.Code
+		dsError     error
+		err         string
+		expectLogs  []spiretest.LogEntry
+		expectCount int32
+		req         *agentpb.CountAgentsRequest
+	}{
+		{
+			name:        "no filter",
+			req:         &agentpb.CountAgentsRequest{},
+			expectCount: 3,
+		},
+		{
+			name: "by attestation type",
+			req: &agentpb.CountAgentsRequest{
+				Filter: &agentpb.CountAgentsRequest_Filter{
+					ByAttestationType: "t1",
+				},
+			},
+			expectCount: 2,
+		},
+		{
+			name: "by banned",
+			req: &agentpb.CountAgentsRequest{
+				Filter: &agentpb.CountAgentsRequest_Filter{
+					ByBanned: &wrappers.BoolValue{Value: true},
+				},
+			},
+			expectCount: 1,
+		},
+		{
+			name: "by expires before",
+			req: &agentpb.CountAgentsRequest{
+				Filter: &agentpb.CountAgentsRequest_Filter{
+					ByExpiresBefore: time.Now().Unix(),
+				},
+			},
+			expectCount: 1,
+		},
+		{
+			name: "by attestation type and expires after",
+			req: &agentpb.CountAgentsRequest{
+				Filter: &agentpb.CountAgentsRequest_Filter{
+					ByAttestationType: "t1",
+					ByExpiresAfter:    time.Now().Unix(),
+				},
+			},
+			expectCount: 1,
+		},
+		{
+			name:    "ds fails",
+			req:     &agentpb.CountAgentsRequest{},
+			code:    codes.Internal,
+			dsError: errors.New("some error"),
+			err:     "failed to count agents: some error",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to count agents",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "some error",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.logHook.Reset()
+			test.ds.SetNextError(tt.dsError)
+
+			resp, err := test.client.CountAgents(ctx, tt.req)
+
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectCount, resp.Count)
+		})
+	}
+}
+
 func TestBanAgent(t *testing.T) {
 	agentTrustDomain := "example.org"
 	agentPath := "/spire/agent/agent-1"
@@ -594,6 +746,142 @@ func TestBanAgent(t *testing.T) {
 	}
 }
 
+func TestReattestAgent(t *testing.T) {
+	agentTrustDomain := "example.org"
+	agentPath := "/spire/agent/agent-1"
+	agentID := spiffeid.Must(agentTrustDomain, agentPath).String()
+
+	for _, tt := range []struct {
+		name            string
+		reqID           *types.SPIFFEID
+		banned          bool
+		dsError         error
+		expectedErr     error
+		expectedLogMsgs []spiretest.LogEntry
+	}{
+		{
+			name:  "Reattest agent succeeds",
+			reqID: &types.SPIFFEID{TrustDomain: agentTrustDomain, Path: agentPath},
+			expectedLogMsgs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "Agent forced to re-attest",
+					Data: logrus.Fields{
+						telemetry.SPIFFEID: agentID,
+					},
+				},
+			},
+		},
+		{
+			name:        "Reattest agent fails if ID is nil",
+			expectedErr: status.Error(codes.InvalidArgument, "invalid agent ID: request must specify SPIFFE ID"),
+			expectedLogMsgs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid agent ID",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "request must specify SPIFFE ID",
+					},
+				},
+			},
+		},
+		{
+			name:        "Reattest agent fails if agent does not exists",
+			reqID:       &types.SPIFFEID{TrustDomain: agentTrustDomain, Path: "/spire/agent/agent-2"},
+			expectedErr: status.Error(codes.NotFound, "agent not found"),
+			expectedLogMsgs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Agent not found",
+					Data: logrus.Fields{
+						telemetry.SPIFFEID: spiffeid.Must(agentTrustDomain, "spire/agent/agent-2").String(),
+					},
+				},
+			},
+		},
+		{
+			name:        "Reattest agent fails if agent is banned",
+			reqID:       &types.SPIFFEID{TrustDomain: agentTrustDomain, Path: agentPath},
+			banned:      true,
+			expectedErr: status.Error(codes.FailedPrecondition, "agent is banned"),
+			expectedLogMsgs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Agent is banned",
+					Data: logrus.Fields{
+						telemetry.SPIFFEID: agentID,
+					},
+				},
+			},
+		},
+		{
+			name:        "Reattest agent fails if there is a datastore error",
+			reqID:       &types.SPIFFEID{TrustDomain: agentTrustDomain, Path: agentPath},
+			dsError:     errors.New("unknown datastore error"),
+			expectedErr: status.Error(codes.Internal, "failed to fetch agent: unknown datastore error"),
+			expectedLogMsgs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to fetch agent",
+					Data: logrus.Fields{
+						logrus.ErrorKey:    "unknown datastore error",
+						telemetry.SPIFFEID: agentID,
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+			ctx := context.Background()
+
+			node := &common.AttestedNode{
+				SpiffeId:            agentID,
+				AttestationDataType: "attestation-type",
+				CertNotAfter:        100,
+				NewCertNotAfter:     200,
+				CertSerialNumber:    "1234",
+				NewCertSerialNumber: "1235",
+			}
+			if tt.banned {
+				node.CertSerialNumber = ""
+				node.NewCertSerialNumber = ""
+			}
+
+			_, err := test.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+				Node: node,
+			})
+			require.NoError(t, err)
+			test.ds.SetNextError(tt.dsError)
+
+			resp, err := test.client.ReattestAgent(ctx, &agentpb.ReattestAgentRequest{Id: tt.reqID})
+			test.ds.SetNextError(nil)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectedLogMsgs)
+
+			fetchResp, fetchErr := test.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+				SpiffeId: agentID,
+			})
+			require.NoError(t, fetchErr)
+
+			if tt.expectedErr != nil {
+				require.Equal(t, tt.expectedErr, err)
+				require.Nil(t, resp)
+				spiretest.RequireProtoEqual(t, node, fetchResp.Node)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+
+			node.CertSerialNumber = nodeutil.ReattestSerialNumber
+			node.NewCertSerialNumber = ""
+			spiretest.RequireProtoEqual(t, node, fetchResp.Node)
+		})
+	}
+}
+
 func TestDeleteAgent(t *testing.T) {
 	node1 := &common.AttestedNode{
 		SpiffeId: "spiffe://example.org/spire/agent/node1",
@@ -1180,6 +1468,62 @@ func TestCreateJoinToken(t *testing.T) {
 			err:  "ttl is required, you must provide one",
 			code: codes.InvalidArgument,
 		},
+		{
+			name: "Success Restricted Join Token",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:          1000,
+				MaxUses:      10,
+				AllowedCidrs: []string{"10.0.0.0/8"},
+				Selectors:    []*types.Selector{{Type: "join_token", Value: "asg:web"}},
+			},
+		},
+		{
+			name: "Fail Negative Max Uses",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:     1000,
+				MaxUses: -1,
+			},
+			err:  "max uses cannot be negative",
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Fail Invalid Allowed CIDR",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:          1000,
+				AllowedCidrs: []string{"10.0.0.1"},
+			},
+			err:  "invalid allowed CIDR: invalid CIDR address: 10.0.0.1",
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Fail Invalid Selectors",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:       1000,
+				Selectors: []*types.Selector{{Type: "join_token"}},
+			},
+			err:  "invalid selectors: missing selector value",
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Fail Selectors Of Another Attestor",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:       1000,
+				MaxUses:   10,
+				Selectors: []*types.Selector{{Type: "join_token", Value: "asg:web"}, {Type: "k8s_psat", Value: "cluster:prod"}},
+			},
+			err:  `invalid selectors: selector type must be "join_token", got "k8s_psat"`,
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Fail Agent ID With Multi-Use Token",
+			request: &agentpb.CreateJoinTokenRequest{
+				Ttl:     1000,
+				MaxUses: 2,
+				AgentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "valid"},
+			},
+			err:  "agent ID cannot be set for tokens that can be used more than once",
+			code: codes.InvalidArgument,
+		},
 		{
 			name: "Fail Datastore Error",
 			err:  "failed to create token: datatore broken",
@@ -1204,6 +1548,16 @@ func TestCreateJoinToken(t *testing.T) {
 			require.NotNil(t, result)
 			require.NotEmpty(t, result.Value)
 			require.NotEmpty(t, result.Value)
+
+			resp, err := test.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+				Token: result.Value,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.request.MaxUses, resp.JoinToken.MaxUses)
+			require.Equal(t, tt.request.AllowedCidrs, resp.JoinToken.AllowedCidrs)
+			expectSelectors, err := api.SelectorsFromProto(tt.request.Selectors)
+			require.NoError(t, err)
+			require.Equal(t, expectSelectors, resp.JoinToken.Selectors)
 		})
 	}
 }
@@ -1232,6 +1586,44 @@ func TestCreateJoinTokenWithAgentId(t *testing.T) {
 	require.Equal(t, "spiffe://example.org/spire/agent/join_token/"+token.Value, listEntries.Entries[0].Selectors[0].Value)
 }
 
+func TestCreateJoinTokenWithPrivilegedAgentId(t *testing.T) {
+	test := setupServiceTest(t)
+
+	_, err := test.ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:  "spiffe://example.org/admin-agent",
+			SpiffeId:  "spiffe://example.org/admin",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:0"}},
+			Admin:     true,
+		},
+	})
+	require.NoError(t, err)
+
+	// Callers bound to a role cannot map a token to the agent receiving the
+	// admin entry
+	_, err = test.client.CreateJoinToken(context.Background(), &agentpb.CreateJoinTokenRequest{
+		Ttl:     1000,
+		Token:   "rejected",
+		AgentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/admin-agent"},
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "agent ID rejected: only local or admin callers can manage entries with the SPIFFE ID of the parent of an admin or downstream entry")
+
+	fetchResp, err := test.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{Token: "rejected"})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.JoinToken)
+	listEntries, err := test.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listEntries.Entries, 1)
+
+	// Local callers can
+	test.localCaller = true
+	_, err = test.client.CreateJoinToken(context.Background(), &agentpb.CreateJoinTokenRequest{
+		Ttl:     1000,
+		AgentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/admin-agent"},
+	})
+	require.NoError(t, err)
+}
+
 func TestAttestAgent(t *testing.T) {
 	testCsr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, testkey.MustEC256())
 	require.NoError(t, err)
@@ -1563,8 +1955,8 @@ func TestAttestAgent(t *testing.T) {
 		},
 
 		{
-			name:        "ds: fails to delete join token",
-			expectedErr: "failed to delete join token",
+			name:        "ds: fails to use join token",
+			expectedErr: "failed to use join token",
 			request:     getAttestAgentRequest("join_token", []byte("test_token"), testCsr),
 			code:        codes.Internal,
 			dsError: []error{
@@ -1574,7 +1966,7 @@ func TestAttestAgent(t *testing.T) {
 			expectedLogMsgs: []spiretest.LogEntry{
 				{
 					Level:   logrus.ErrorLevel,
-					Message: "Failed to delete join token",
+					Message: "Failed to use join token",
 					Data: logrus.Fields{
 						telemetry.NodeAttestorType: "join_token",
 						logrus.ErrorKey:            "some error",
@@ -1753,6 +2145,70 @@ func TestAttestAgent(t *testing.T) {
 	}
 }
 
+func TestAttestAgentWithMultiUseJoinToken(t *testing.T) {
+	testCsr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, testkey.MustEC256())
+	require.NoError(t, err)
+
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.rateLimiter.count = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	selectors := []*common.Selector{{Type: "join_token", Value: "asg:web"}}
+	_, err = test.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:        "multi_token",
+			Expiry:       time.Now().Unix() + int64(60*10),
+			MaxUses:      2,
+			AllowedCidrs: []string{"127.0.0.0/8"},
+			// selectors of other types, which can no longer be set through
+			// the API, are not assigned to the agents
+			Selectors: append([]*common.Selector{{Type: "aws_iid", Value: "tag:role:admin"}}, selectors...),
+		},
+	})
+	require.NoError(t, err)
+
+	request := getAttestAgentRequest("join_token", []byte("multi_token"), testCsr)
+	attestWithToken := func() (*agentpb.AttestAgentResponse_Result, error) {
+		stream, err := test.client.AttestAgent(ctx)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, stream.CloseSend())
+		}()
+		return attest(t, stream, request)
+	}
+
+	// Callers outside of the allowed CIDRs cannot use the token
+	test.callerAddr = &net.TCPAddr{IP: net.ParseIP("192.168.1.1"), Port: 12345}
+	_, err = attestWithToken()
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "failed to attest: caller address is not allowed to use the join token")
+	test.callerAddr = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 12345}
+
+	// Each agent is assigned a unique ID along with the token selectors
+	var agentIDs []string
+	for i := 0; i < 2; i++ {
+		result, err := attestWithToken()
+		require.NoError(t, err)
+		agentID := result.Svid.Id
+		require.Equal(t, td.String(), agentID.TrustDomain)
+		require.Regexp(t, "^/spire/agent/join_token/multi_token/[0-9a-f-]+$", agentID.Path)
+		agentIDs = append(agentIDs, agentID.Path)
+
+		resp, err := test.ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+			SpiffeId: td.NewID(agentID.Path).String(),
+		})
+		require.NoError(t, err)
+		spiretest.RequireProtoListEqual(t, selectors, resp.Selectors.Selectors)
+	}
+	require.NotEqual(t, agentIDs[0], agentIDs[1])
+
+	// The token cannot be used more than the maximum number of uses
+	_, err = attestWithToken()
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "failed to attest: join token does not exist or has already been used")
+}
+
 type serviceTest struct {
 	client       agentpb.AgentClient
 	done         func()
@@ -1762,6 +2218,8 @@ type serviceTest struct {
 	logHook      *test.Hook
 	rateLimiter  *fakeRateLimiter
 	withCallerID bool
+	localCaller  bool
+	callerAddr   net.Addr
 	pluginCloser func()
 }
 
@@ -1799,14 +2257,19 @@ func setupServiceTest(t *testing.T) *serviceTest {
 		cat:         cat,
 		logHook:     logHook,
 		rateLimiter: rateLimiter,
+		callerAddr:  &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 12345},
 	}
 
 	contextFn := func(ctx context.Context) context.Context {
 		ctx = rpccontext.WithLogger(ctx, log)
 		ctx = rpccontext.WithRateLimiter(ctx, rateLimiter)
+		ctx = rpccontext.WithCallerAddr(ctx, test.callerAddr)
 		if test.withCallerID {
 			ctx = rpccontext.WithCallerID(ctx, agentID)
 		}
+		if test.localCaller {
+			ctx = rpccontext.WithLocalCaller(ctx)
+		}
 		return ctx
 	}
 
@@ -29,6 +29,15 @@ func (fn AuthorizedEntryFetcherFunc) FetchAuthorizedEntries(ctx context.Context,
 	return fn(ctx, id)
 }
 
+// AuthorizedEntryWatcher is the interface to watch for changes to the entries
+// returned by an AuthorizedEntryFetcher
+type AuthorizedEntryWatcher interface {
+	// WatchAuthorizedEntries returns a channel that is signaled whenever the
+	// authorized entries may have changed. Signals that are not received in
+	// time are coalesced. The watch stops when the context is done.
+	WatchAuthorizedEntries(ctx context.Context) <-chan struct{}
+}
+
 // AttestedNodeToProto converts an agent from the given *common.AttestedNode with
 // the provided selectors to *types.Agent
 func AttestedNodeToProto(node *common.AttestedNode, selectors []*types.Selector) (*types.Agent, error) {
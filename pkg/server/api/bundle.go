@@ -33,7 +33,8 @@ func CertificatesToProto(rootCas []*common.Certificate) []*types.X509Certificate
 	var x509Authorities []*types.X509Certificate
 	for _, rootCA := range rootCas {
 		x509Authorities = append(x509Authorities, &types.X509Certificate{
-			Asn1: rootCA.DerBytes,
+			Asn1:    rootCA.DerBytes,
+			Tainted: rootCA.TaintedKey,
 		})
 	}
 
@@ -46,6 +47,7 @@ func PublicKeysToProto(keys []*common.PublicKey) []*types.JWTKey {
 			PublicKey: key.PkixBytes,
 			KeyId:     key.Kid,
 			ExpiresAt: key.NotAfter,
+			Tainted:   key.TaintedKey,
 		})
 	}
 	return jwtAuthorities
@@ -101,7 +103,8 @@ func ParseX509Authorities(certs []*types.X509Certificate) ([]*common.Certificate
 		}
 
 		rootCAs = append(rootCAs, &common.Certificate{
-			DerBytes: rootCA.Asn1,
+			DerBytes:   rootCA.Asn1,
+			TaintedKey: rootCA.Tainted,
 		})
 	}
 
@@ -120,9 +123,10 @@ func ParseJWTAuthorities(keys []*types.JWTKey) ([]*common.PublicKey, error) {
 		}
 
 		jwtKeys = append(jwtKeys, &common.PublicKey{
-			PkixBytes: key.PublicKey,
-			Kid:       key.KeyId,
-			NotAfter:  key.ExpiresAt,
+			PkixBytes:  key.PublicKey,
+			Kid:        key.KeyId,
+			NotAfter:   key.ExpiresAt,
+			TaintedKey: key.Tainted,
 		})
 	}
 
@@ -10,16 +10,23 @@ import (
 	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	spirelog "github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	commonutil "github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/svid"
+	"github.com/spiffe/spire/pkg/server/util/regentryutil"
 	"github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	debug_pb "github.com/spiffe/spire/proto/spire/api/server/debug/v1"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/clock"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -31,6 +38,11 @@ func RegisterService(s *grpc.Server, service *Service) {
 	debug_pb.RegisterDebugServer(s, service)
 }
 
+// Drainer drains the agent connections of the server
+type Drainer interface {
+	Drain()
+}
+
 // Config configurations for debug service
 type Config struct {
 	Clock        clock.Clock
@@ -38,26 +50,32 @@ type Config struct {
 	SVIDObserver svid.Observer
 	TrustDomain  spiffeid.TrustDomain
 	Uptime       func() time.Duration
+	Drainer      Drainer
+	LogLevels    spirelog.LevelSetter
 }
 
 // New creates a new debug service
 func New(config Config) *Service {
 	return &Service{
-		clock:  config.Clock,
-		ds:     config.DataStore,
-		so:     config.SVIDObserver,
-		td:     config.TrustDomain,
-		uptime: config.Uptime,
+		clock:     config.Clock,
+		ds:        config.DataStore,
+		so:        config.SVIDObserver,
+		td:        config.TrustDomain,
+		uptime:    config.Uptime,
+		drainer:   config.Drainer,
+		logLevels: config.LogLevels,
 	}
 }
 
 // Service implements debug server
 type Service struct {
-	clock  clock.Clock
-	ds     datastore.DataStore
-	so     svid.Observer
-	td     spiffeid.TrustDomain
-	uptime func() time.Duration
+	clock     clock.Clock
+	ds        datastore.DataStore
+	so        svid.Observer
+	td        spiffeid.TrustDomain
+	uptime    func() time.Duration
+	drainer   Drainer
+	logLevels spirelog.LevelSetter
 
 	getInfoResp getInfoResp
 }
@@ -111,6 +129,158 @@ func (s *Service) GetInfo(ctx context.Context, req *debug_pb.GetInfoRequest) (*d
 	return s.getInfoResp.resp, nil
 }
 
+// Drain starts gracefully draining the agent connections of the server
+func (s *Service) Drain(ctx context.Context, req *debug_pb.DrainRequest) (*debug_pb.DrainResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	if s.drainer == nil {
+		return nil, api.MakeErr(log, codes.Unimplemented, "draining is not supported", nil)
+	}
+
+	log.Info("Draining agent connections")
+	s.drainer.Drain()
+	return &debug_pb.DrainResponse{}, nil
+}
+
+// SetLogLevel sets the log level of SPIRE Server and of its subsystems
+func (s *Service) SetLogLevel(ctx context.Context, req *debug_pb.SetLogLevelRequest) (*debug_pb.SetLogLevelResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	if s.logLevels == nil {
+		return nil, api.MakeErr(log, codes.Unimplemented, "setting the log level is not supported", nil)
+	}
+
+	level := req.Level
+	if level == "" {
+		level = s.logLevels.GetLevels().Level.String()
+	}
+	levels, err := spirelog.ParseLevels(level, req.SubsystemLevels)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid log level", err)
+	}
+
+	s.logLevels.SetLevels(levels)
+	log.WithField(telemetry.LogLevel, levels.Level.String()).Info("Log level updated")
+
+	resp := &debug_pb.SetLogLevelResponse{
+		Level: levels.Level.String(),
+	}
+	if len(levels.Subsystems) > 0 {
+		resp.SubsystemLevels = make(map[string]string, len(levels.Subsystems))
+		for subsystem, level := range levels.Subsystems {
+			resp.SubsystemLevels[subsystem] = level.String()
+		}
+	}
+	return resp, nil
+}
+
+// MatchEntries previews the registration entries an agent, or a workload with
+// the given selectors, would receive
+func (s *Service) MatchEntries(ctx context.Context, req *debug_pb.MatchEntriesRequest) (*debug_pb.MatchEntriesResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	if req.AgentId == nil && len(req.Selectors) == 0 {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "an agent ID or selectors must be provided", nil)
+	}
+
+	selectors, err := api.SelectorsFromProto(req.Selectors)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid selectors", err)
+	}
+
+	var entries []*common.RegistrationEntry
+	if req.AgentId != nil {
+		agentID, err := api.TrustDomainAgentIDFromProto(s.td, req.AgentId)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
+		}
+		log = log.WithField(telemetry.AgentID, agentID.String())
+
+		entries, err = regentryutil.FetchRegistrationEntries(ctx, s.ds, agentID.String())
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to fetch agent entries", err)
+		}
+	} else {
+		resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			BySelectors: &datastore.BySelectors{
+				Selectors: selectors,
+				Match:     datastore.BySelectors_MATCH_SUBSET,
+			},
+		})
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to list entries", err)
+		}
+		entries = resp.Entries
+	}
+
+	if len(selectors) > 0 {
+		entries = filterEntriesBySelectorSubset(entries, selectors)
+	}
+	commonutil.SortRegistrationEntries(entries)
+
+	protoEntries, err := api.RegistrationEntriesToProto(entries)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to convert entries", err)
+	}
+
+	return &debug_pb.MatchEntriesResponse{
+		Entries: protoEntries,
+	}, nil
+}
+
+// BackupDatastore writes a consistent copy of the datastore to a file on the
+// server host while the server keeps running
+func (s *Service) BackupDatastore(ctx context.Context, req *debug_pb.BackupDatastoreRequest) (*debug_pb.BackupDatastoreResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	if req.Path == "" {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "backup path is required", nil)
+	}
+	log = log.WithField(telemetry.Path, req.Path)
+
+	_, err := s.ds.Backup(ctx, &datastore.BackupRequest{
+		Path: req.Path,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		log.Info("Datastore backed up")
+		return &debug_pb.BackupDatastoreResponse{}, nil
+	case codes.AlreadyExists:
+		return nil, api.MakeErr(log, codes.AlreadyExists, "backup file already exists", err)
+	case codes.Unimplemented:
+		return nil, api.MakeErr(log, codes.Unimplemented, "datastore does not support backups", err)
+	default:
+		return nil, api.MakeErr(log, codes.Internal, "failed to back up datastore", err)
+	}
+}
+
+// filterEntriesBySelectorSubset returns the entries whose selectors are a
+// subset of the given selectors and whose selector expression, if any, is
+// satisfied by them, i.e. the entries a workload with those selectors would
+// receive.
+func filterEntriesBySelectorSubset(entries []*common.RegistrationEntry, selectors []*common.Selector) []*common.RegistrationEntry {
+	type selectorKey struct{ typ, value string }
+	set := make(map[selectorKey]bool, len(selectors))
+	for _, s := range selectors {
+		set[selectorKey{typ: s.Type, value: s.Value}] = true
+	}
+
+	var out []*common.RegistrationEntry
+	for _, entry := range entries {
+		matches := len(entry.Selectors) > 0
+		for _, s := range entry.Selectors {
+			if !set[selectorKey{typ: s.Type, value: s.Value}] {
+				matches = false
+				break
+			}
+		}
+		if matches && selector.MatchesExpression(entry.SelectorExpression, selectors) {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
 func (s *Service) getCertificateChain(ctx context.Context, log logrus.FieldLogger) ([]*debug.GetInfoResponse_Cert, error) {
 	trustDomainID := s.td.IDString()
 
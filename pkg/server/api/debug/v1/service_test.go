@@ -6,6 +6,9 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -13,7 +16,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	spirelog "github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api/debug/v1"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
@@ -28,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -427,15 +433,410 @@ func TestGetInfo(t *testing.T) {
 	}
 }
 
+func TestDrain(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	resp, err := test.client.Drain(ctx, &debugpb.DrainRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &debugpb.DrainResponse{}, resp)
+	require.True(t, test.drainer.drained)
+	spiretest.AssertLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Draining agent connections",
+		},
+	})
+}
+
+func TestDrainNotSupported(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	service := debug.New(debug.Config{})
+
+	resp, err := service.Drain(rpccontext.WithLogger(ctx, log), &debugpb.DrainRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "draining is not supported")
+	require.Nil(t, resp)
+}
+
+func TestSetLogLevel(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		req          *debugpb.SetLogLevelRequest
+		expectResp   *debugpb.SetLogLevelResponse
+		expectLevels spirelog.Levels
+		expectLogs   []spiretest.LogEntry
+		code         codes.Code
+		err          string
+	}{
+		{
+			name: "set level and subsystem levels",
+			req: &debugpb.SetLogLevelRequest{
+				Level:           "debug",
+				SubsystemLevels: map[string]string{"CA": "warn"},
+			},
+			expectResp: &debugpb.SetLogLevelResponse{
+				Level:           "debug",
+				SubsystemLevels: map[string]string{"ca": "warning"},
+			},
+			expectLevels: spirelog.Levels{
+				Level:      logrus.DebugLevel,
+				Subsystems: map[string]logrus.Level{"ca": logrus.WarnLevel},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "Log level updated",
+					Data: logrus.Fields{
+						telemetry.LogLevel: "debug",
+					},
+				},
+			},
+		},
+		{
+			name: "keep current level",
+			req: &debugpb.SetLogLevelRequest{
+				SubsystemLevels: map[string]string{"datastore": "debug"},
+			},
+			expectResp: &debugpb.SetLogLevelResponse{
+				Level:           "info",
+				SubsystemLevels: map[string]string{"datastore": "debug"},
+			},
+			expectLevels: spirelog.Levels{
+				Level:      logrus.InfoLevel,
+				Subsystems: map[string]logrus.Level{"datastore": logrus.DebugLevel},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "Log level updated",
+					Data: logrus.Fields{
+						telemetry.LogLevel: "info",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid level",
+			req: &debugpb.SetLogLevelRequest{
+				Level: "loud",
+			},
+			expectLevels: spirelog.Levels{
+				Level: logrus.InfoLevel,
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid log level",
+					Data: logrus.Fields{
+						logrus.ErrorKey: `not a valid logrus Level: "loud"`,
+					},
+				},
+			},
+			code: codes.InvalidArgument,
+			err:  `invalid log level: not a valid logrus Level: "loud"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			resp, err := test.client.SetLogLevel(ctx, tt.req)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			require.Equal(t, tt.expectLevels, test.logLevels.levels)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatus(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, tt.expectResp, resp)
+		})
+	}
+}
+
+func TestSetLogLevelNotSupported(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	service := debug.New(debug.Config{})
+
+	resp, err := service.SetLogLevel(rpccontext.WithLogger(ctx, log), &debugpb.SetLogLevelRequest{Level: "debug"})
+	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "setting the log level is not supported")
+	require.Nil(t, resp)
+}
+
+func TestMatchEntries(t *testing.T) {
+	agentID := td.NewID("/spire/agent/a1")
+	otherAgentID := td.NewID("/spire/agent/a2")
+	uid := &types.Selector{Type: "unix", Value: "uid:1000"}
+	gid := &types.Selector{Type: "unix", Value: "gid:1000"}
+
+	for _, tt := range []struct {
+		name        string
+		req         *debugpb.MatchEntriesRequest
+		expectPaths []string
+		expectLogs  []spiretest.LogEntry
+		code        codes.Code
+		err         string
+	}{
+		{
+			name: "agent",
+			req: &debugpb.MatchEntriesRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: td.String(), Path: agentID.Path()},
+			},
+			expectPaths: []string{"/uid", "/uid-gid"},
+		},
+		{
+			name: "agent and selectors",
+			req: &debugpb.MatchEntriesRequest{
+				AgentId:   &types.SPIFFEID{TrustDomain: td.String(), Path: agentID.Path()},
+				Selectors: []*types.Selector{uid},
+			},
+			expectPaths: []string{"/uid"},
+		},
+		{
+			name: "selectors",
+			req: &debugpb.MatchEntriesRequest{
+				Selectors: []*types.Selector{uid},
+			},
+			expectPaths: []string{"/uid", "/uid-no-gid", "/uid-other"},
+		},
+		{
+			name: "selectors not satisfying expression",
+			req: &debugpb.MatchEntriesRequest{
+				Selectors: []*types.Selector{uid, gid},
+			},
+			expectPaths: []string{"/uid", "/uid-gid", "/uid-other"},
+		},
+		{
+			name: "no match",
+			req: &debugpb.MatchEntriesRequest{
+				Selectors: []*types.Selector{gid},
+			},
+		},
+		{
+			name: "missing agent ID and selectors",
+			req:  &debugpb.MatchEntriesRequest{},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: an agent ID or selectors must be provided",
+				},
+			},
+			code: codes.InvalidArgument,
+			err:  "an agent ID or selectors must be provided",
+		},
+		{
+			name: "invalid agent ID",
+			req: &debugpb.MatchEntriesRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: td.String(), Path: "/workload"},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid agent ID",
+					Data: logrus.Fields{
+						logrus.ErrorKey: `"spiffe://example.org/workload" is not an agent in trust domain "example.org"; path is not in the agent namespace`,
+					},
+				},
+			},
+			code: codes.InvalidArgument,
+			err:  `invalid agent ID: "spiffe://example.org/workload" is not an agent in trust domain "example.org"; path is not in the agent namespace`,
+		},
+		{
+			name: "invalid selectors",
+			req: &debugpb.MatchEntriesRequest{
+				Selectors: []*types.Selector{{Value: "uid:1000"}},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid selectors",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "missing selector type",
+					},
+				},
+			},
+			code: codes.InvalidArgument,
+			err:  "invalid selectors: missing selector type",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			for _, entry := range []*common.RegistrationEntry{
+				{
+					ParentId:  agentID.String(),
+					SpiffeId:  td.NewID("/uid").String(),
+					Selectors: []*common.Selector{{Type: uid.Type, Value: uid.Value}},
+				},
+				{
+					ParentId: agentID.String(),
+					SpiffeId: td.NewID("/uid-gid").String(),
+					Selectors: []*common.Selector{
+						{Type: uid.Type, Value: uid.Value},
+						{Type: gid.Type, Value: gid.Value},
+					},
+				},
+				{
+					ParentId:  otherAgentID.String(),
+					SpiffeId:  td.NewID("/uid-other").String(),
+					Selectors: []*common.Selector{{Type: uid.Type, Value: uid.Value}},
+				},
+				{
+					ParentId:           otherAgentID.String(),
+					SpiffeId:           td.NewID("/uid-no-gid").String(),
+					Selectors:          []*common.Selector{{Type: uid.Type, Value: uid.Value}},
+					SelectorExpression: "!unix:gid:*",
+				},
+			} {
+				_, err := test.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+					Entry: entry,
+				})
+				require.NoError(t, err)
+			}
+
+			resp, err := test.client.MatchEntries(ctx, tt.req)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatus(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+
+			var paths []string
+			for _, entry := range resp.Entries {
+				paths = append(paths, entry.SpiffeId.Path)
+			}
+			require.Equal(t, tt.expectPaths, paths)
+		})
+	}
+}
+
+func TestBackupDatastore(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	existingPath := filepath.Join(dir, "existing.sqlite3")
+	require.NoError(t, ioutil.WriteFile(existingPath, nil, 0600))
+
+	for _, tt := range []struct {
+		name       string
+		path       string
+		dsError    error
+		expectLogs []spiretest.LogEntry
+		code       codes.Code
+		err        string
+	}{
+		{
+			name: "success",
+			path: filepath.Join(dir, "backup.sqlite3"),
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "Datastore backed up",
+					Data: logrus.Fields{
+						telemetry.Path: filepath.Join(dir, "backup.sqlite3"),
+					},
+				},
+			},
+		},
+		{
+			name: "missing path",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: backup path is required",
+				},
+			},
+			code: codes.InvalidArgument,
+			err:  "backup path is required",
+		},
+		{
+			name: "file already exists",
+			path: existingPath,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Backup file already exists",
+					Data: logrus.Fields{
+						telemetry.Path:  existingPath,
+						logrus.ErrorKey: fmt.Sprintf("rpc error: code = AlreadyExists desc = backup file %q already exists", existingPath),
+					},
+				},
+			},
+			code: codes.AlreadyExists,
+			err:  fmt.Sprintf("backup file already exists: backup file %q already exists", existingPath),
+		},
+		{
+			name:    "backups not supported",
+			path:    filepath.Join(dir, "unsupported.sqlite3"),
+			dsError: status.Error(codes.Unimplemented, "oh no"),
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Datastore does not support backups",
+					Data: logrus.Fields{
+						telemetry.Path:  filepath.Join(dir, "unsupported.sqlite3"),
+						logrus.ErrorKey: "rpc error: code = Unimplemented desc = oh no",
+					},
+				},
+			},
+			code: codes.Unimplemented,
+			err:  "datastore does not support backups: oh no",
+		},
+		{
+			name:    "datastore failure",
+			path:    filepath.Join(dir, "failure.sqlite3"),
+			dsError: errors.New("oh no"),
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to back up datastore",
+					Data: logrus.Fields{
+						telemetry.Path:  filepath.Join(dir, "failure.sqlite3"),
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+			code: codes.Internal,
+			err:  "failed to back up datastore: oh no",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.ds.SetNextError(tt.dsError)
+
+			resp, err := test.client.BackupDatastore(ctx, &debugpb.BackupDatastoreRequest{
+				Path: tt.path,
+			})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.err != "" {
+				spiretest.RequireGRPCStatus(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, &debugpb.BackupDatastoreResponse{}, resp)
+			require.FileExists(t, tt.path)
+		})
+	}
+}
+
 type serviceTest struct {
 	client debugpb.DebugClient
 	done   func()
 
-	clk     *clock.Mock
-	logHook *test.Hook
-	ds      *fakedatastore.DataStore
-	so      *fakeObserver
-	uptime  *fakeUptime
+	clk       *clock.Mock
+	logHook   *test.Hook
+	ds        *fakedatastore.DataStore
+	so        *fakeObserver
+	uptime    *fakeUptime
+	drainer   *fakeDrainer
+	logLevels *fakeLogLevels
 }
 
 func (s *serviceTest) Cleanup() {
@@ -452,6 +853,8 @@ func setupServiceTest(t *testing.T) *serviceTest {
 		clk:   clk,
 	}
 	observer := &fakeObserver{}
+	drainer := &fakeDrainer{}
+	logLevels := &fakeLogLevels{levels: spirelog.Levels{Level: logrus.InfoLevel}}
 
 	service := debug.New(debug.Config{
 		Clock:        clk,
@@ -459,14 +862,18 @@ func setupServiceTest(t *testing.T) *serviceTest {
 		SVIDObserver: observer,
 		TrustDomain:  td,
 		Uptime:       fakeUptime.uptime,
+		Drainer:      drainer,
+		LogLevels:    logLevels,
 	})
 
 	test := &serviceTest{
-		clk:     clk,
-		ds:      ds,
-		logHook: logHook,
-		so:      observer,
-		uptime:  fakeUptime,
+		clk:       clk,
+		ds:        ds,
+		logHook:   logHook,
+		so:        observer,
+		uptime:    fakeUptime,
+		drainer:   drainer,
+		logLevels: logLevels,
 	}
 
 	registerFn := func(s *grpc.Server) {
@@ -500,3 +907,23 @@ type fakeUptime struct {
 func (f *fakeUptime) uptime() time.Duration {
 	return f.clk.Now().Sub(f.start)
 }
+
+type fakeDrainer struct {
+	drained bool
+}
+
+func (d *fakeDrainer) Drain() {
+	d.drained = true
+}
+
+type fakeLogLevels struct {
+	levels spirelog.Levels
+}
+
+func (l *fakeLogLevels) GetLevels() spirelog.Levels {
+	return l.levels
+}
+
+func (l *fakeLogLevels) SetLevels(levels spirelog.Levels) {
+	l.levels = levels
+}
@@ -3,14 +3,22 @@ package api
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/protoutil"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/entrydefaults"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
 )
 
+// maxHintLength is the maximum length of the hint of an entry.
+const maxHintLength = 1024
+
 // RegistrationEntriesToProto converts RegistrationEntry's into Entry's
 func RegistrationEntriesToProto(es []*common.RegistrationEntry) ([]*types.Entry, error) {
 	if es == nil {
@@ -53,20 +61,42 @@ func RegistrationEntryToProto(e *common.RegistrationEntry) (*types.Entry, error)
 	}
 
 	return &types.Entry{
-		Id:             e.EntryId,
-		SpiffeId:       ProtoFromID(spiffeID),
-		ParentId:       ProtoFromID(parentID),
-		Selectors:      ProtoFromSelectors(e.Selectors),
-		Ttl:            e.Ttl,
-		FederatesWith:  federatesWith,
-		Admin:          e.Admin,
-		Downstream:     e.Downstream,
-		ExpiresAt:      e.EntryExpiry,
-		DnsNames:       append([]string(nil), e.DnsNames...),
-		RevisionNumber: e.RevisionNumber,
+		Id:                 e.EntryId,
+		SpiffeId:           ProtoFromID(spiffeID),
+		ParentId:           ProtoFromID(parentID),
+		Selectors:          ProtoFromSelectors(e.Selectors),
+		Ttl:                e.Ttl,
+		FederatesWith:      federatesWith,
+		Admin:              e.Admin,
+		Downstream:         e.Downstream,
+		ExpiresAt:          e.EntryExpiry,
+		DnsNames:           append([]string(nil), e.DnsNames...),
+		RevisionNumber:     e.RevisionNumber,
+		JwtSvidClaims:      copyClaims(e.JwtSvidClaims),
+		JwtSvidAudience:    append([]string(nil), e.JwtSvidAudience...),
+		X509SvidKeyType:    e.X509SvidKeyType,
+		DnsNameTemplates:   append([]string(nil), e.DnsNameTemplates...),
+		X509SvidSubject:    x509SVIDSubjectToProto(e.X509SvidSubject),
+		Hint:               e.Hint,
+		SelectorExpression: e.SelectorExpression,
+		TemplateId:         e.TemplateId,
 	}, nil
 }
 
+// EntryExpiry returns the time the entry expires at, or the zero time if the
+// entry does not expire.
+func EntryExpiry(e *types.Entry) time.Time {
+	if e.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(e.ExpiresAt, 0)
+}
+
+// IsEntryExpired returns whether the entry has expired as of the given time.
+func IsEntryExpired(e *types.Entry, now time.Time) bool {
+	return e.ExpiresAt != 0 && !now.Before(time.Unix(e.ExpiresAt, 0))
+}
+
 // ProtoToRegistrationEntry converts and validate entry into common registration entry
 func ProtoToRegistrationEntry(td spiffeid.TrustDomain, e *types.Entry) (*common.RegistrationEntry, error) {
 	return ProtoToRegistrationEntryWithMask(td, e, nil)
@@ -164,17 +194,121 @@ func ProtoToRegistrationEntryWithMask(td spiffeid.TrustDomain, e *types.Entry, m
 		revisionNumber = e.RevisionNumber
 	}
 
+	var jwtSVIDClaims map[string]string
+	if mask.JwtSvidClaims {
+		if err := jwtsvid.ValidateClaims(e.JwtSvidClaims); err != nil {
+			return nil, fmt.Errorf("invalid JWT-SVID claims: %v", err)
+		}
+		jwtSVIDClaims = copyClaims(e.JwtSvidClaims)
+	}
+
+	var jwtSVIDAudience []string
+	if mask.JwtSvidAudience {
+		jwtSVIDAudience = make([]string, 0, len(e.JwtSvidAudience))
+		for _, audience := range e.JwtSvidAudience {
+			if audience == "" {
+				return nil, errors.New("invalid JWT-SVID audience: audience cannot be empty")
+			}
+			jwtSVIDAudience = append(jwtSVIDAudience, audience)
+		}
+	}
+
+	var x509SVIDKeyType string
+	if mask.X509SvidKeyType {
+		if err := x509svid.ValidateKeyType(e.X509SvidKeyType); err != nil {
+			return nil, fmt.Errorf("invalid X509-SVID key type: %v", err)
+		}
+		x509SVIDKeyType = e.X509SvidKeyType
+	}
+
+	var dnsNameTemplates []string
+	if mask.DnsNameTemplates {
+		if err := entrydefaults.ValidateDNSNameTemplates(e.DnsNameTemplates); err != nil {
+			return nil, fmt.Errorf("invalid DNS name template: %v", err)
+		}
+		dnsNameTemplates = append([]string(nil), e.DnsNameTemplates...)
+	}
+
+	var x509SVIDSubject *common.X509SVIDSubject
+	if mask.X509SvidSubject {
+		x509SVIDSubject = x509SVIDSubjectFromProto(e.X509SvidSubject)
+	}
+
+	var hint string
+	if mask.Hint {
+		if len(e.Hint) > maxHintLength {
+			return nil, fmt.Errorf("invalid hint: hint is longer than %d characters", maxHintLength)
+		}
+		hint = e.Hint
+	}
+
+	var selectorExpression string
+	if mask.SelectorExpression {
+		if err := selector.ValidateExpression(e.SelectorExpression); err != nil {
+			return nil, fmt.Errorf("invalid selector expression: %v", err)
+		}
+		selectorExpression = e.SelectorExpression
+	}
+
+	var templateID string
+	if mask.TemplateId {
+		templateID = e.TemplateId
+	}
+
 	return &common.RegistrationEntry{
-		EntryId:        e.Id,
-		ParentId:       parentIDString,
-		SpiffeId:       spiffeIDString,
-		Admin:          admin,
-		DnsNames:       dnsNames,
-		Downstream:     downstream,
-		EntryExpiry:    expiresAt,
-		FederatesWith:  federatesWith,
-		Selectors:      selectors,
-		Ttl:            ttl,
-		RevisionNumber: revisionNumber,
+		EntryId:            e.Id,
+		ParentId:           parentIDString,
+		SpiffeId:           spiffeIDString,
+		Admin:              admin,
+		DnsNames:           dnsNames,
+		Downstream:         downstream,
+		EntryExpiry:        expiresAt,
+		FederatesWith:      federatesWith,
+		Selectors:          selectors,
+		Ttl:                ttl,
+		RevisionNumber:     revisionNumber,
+		JwtSvidClaims:      jwtSVIDClaims,
+		JwtSvidAudience:    jwtSVIDAudience,
+		X509SvidKeyType:    x509SVIDKeyType,
+		DnsNameTemplates:   dnsNameTemplates,
+		X509SvidSubject:    x509SVIDSubject,
+		Hint:               hint,
+		SelectorExpression: selectorExpression,
+		TemplateId:         templateID,
 	}, nil
 }
+
+func copyClaims(claims map[string]string) map[string]string {
+	if len(claims) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(claims))
+	for k, v := range claims {
+		out[k] = v
+	}
+	return out
+}
+
+func x509SVIDSubjectToProto(subject *common.X509SVIDSubject) *types.X509SVIDSubject {
+	if subject == nil {
+		return nil
+	}
+	return &types.X509SVIDSubject{
+		CommonName:         subject.CommonName,
+		Organization:       append([]string(nil), subject.Organization...),
+		OrganizationalUnit: append([]string(nil), subject.OrganizationalUnit...),
+		Country:            append([]string(nil), subject.Country...),
+	}
+}
+
+func x509SVIDSubjectFromProto(subject *types.X509SVIDSubject) *common.X509SVIDSubject {
+	if subject == nil {
+		return nil
+	}
+	return &common.X509SVIDSubject{
+		CommonName:         subject.CommonName,
+		Organization:       append([]string(nil), subject.Organization...),
+		OrganizationalUnit: append([]string(nil), subject.OrganizationalUnit...),
+		Country:            append([]string(nil), subject.Country...),
+	}
+}
@@ -0,0 +1,136 @@
+package entry
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/spiffe/spire/proto/spire/types"
+)
+
+const (
+	// Number of agents whose last authorized entries are remembered to
+	// compute the changes returned by GetAuthorizedEntries.
+	revisionsCacheSize = 100_000
+)
+
+// revisions remembers, for each agent, the entry IDs and revision numbers of
+// the authorized entries last returned to the agent, along with the revision
+// the agent was given for them. It is kept in memory, so an agent gets all of
+// its authorized entries after a server restart or when talking to a server
+// that does not know the revision it presents.
+type revisions struct {
+	mu        sync.Mutex
+	cache     *lru.Cache
+	lastIssue int64
+}
+
+type revisionSnapshot struct {
+	revision int64
+	entries  map[string]int64
+}
+
+// entriesDelta describes the authorized entries to return to an agent.
+type entriesDelta struct {
+	revision   int64
+	delta      bool
+	entries    []*types.Entry
+	deletedIDs []string
+}
+
+func newRevisions(size int) *revisions {
+	// lru.New only fails for non-positive sizes
+	cache, _ := lru.New(size)
+	return &revisions{
+		cache: cache,
+		// Start from a random revision so that a revision issued by another
+		// server, or before a restart, is unlikely to be mistaken for one
+		// issued by this server.
+		lastIssue: randomRevision(),
+	}
+}
+
+// update records the authorized entries of the agent and returns what needs
+// to be sent to it. Only the entries that were added or updated since
+// sinceRevision, and the IDs of the removed entries, are returned if the
+// agent presents the revision it was last given. Otherwise, all of the
+// entries are returned. This includes agents whose snapshot was evicted from
+// the cache and agents presenting a revision issued by another server.
+func (r *revisions) update(agentID string, sinceRevision int64, entries []*types.Entry) entriesDelta {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := entryRevisions(entries)
+
+	var previous *revisionSnapshot
+	if value, ok := r.cache.Get(agentID); ok {
+		previous = value.(*revisionSnapshot)
+	}
+
+	if sinceRevision == 0 || previous == nil || previous.revision != sinceRevision {
+		revision := r.issue()
+		r.cache.Add(agentID, &revisionSnapshot{revision: revision, entries: current})
+		return entriesDelta{
+			revision: revision,
+			entries:  entries,
+		}
+	}
+
+	var changed []*types.Entry
+	for _, entry := range entries {
+		revisionNumber, ok := previous.entries[entry.Id]
+		if !ok || revisionNumber != entry.RevisionNumber {
+			changed = append(changed, entry)
+		}
+	}
+	var deletedIDs []string
+	for id := range previous.entries {
+		if _, ok := current[id]; !ok {
+			deletedIDs = append(deletedIDs, id)
+		}
+	}
+	sort.Strings(deletedIDs)
+
+	// The agent is up to date; keep the revision it already has
+	revision := previous.revision
+	if len(changed) > 0 || len(deletedIDs) > 0 {
+		revision = r.issue()
+		r.cache.Add(agentID, &revisionSnapshot{revision: revision, entries: current})
+	}
+
+	return entriesDelta{
+		revision:   revision,
+		delta:      true,
+		entries:    changed,
+		deletedIDs: deletedIDs,
+	}
+}
+
+func (r *revisions) issue() int64 {
+	r.lastIssue++
+	if r.lastIssue <= 0 {
+		r.lastIssue = 1
+	}
+	return r.lastIssue
+}
+
+func randomRevision() int64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 1
+	}
+	// leave plenty of room to issue revisions before wrapping around
+	return int64(binary.BigEndian.Uint64(b[:]) >> 2)
+}
+
+// entryRevisions returns the revision numbers of the entries, keyed by entry
+// ID.
+func entryRevisions(entries []*types.Entry) map[string]int64 {
+	revisions := make(map[string]int64, len(entries))
+	for _, entry := range entries {
+		revisions[entry.Id] = entry.RevisionNumber
+	}
+	return revisions
+}
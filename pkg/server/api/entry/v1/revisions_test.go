@@ -0,0 +1,63 @@
+package entry
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevisionsEvictedSnapshot(t *testing.T) {
+	entry1 := &types.Entry{Id: "entry-1", RevisionNumber: 1}
+	entry2 := &types.Entry{Id: "entry-2", RevisionNumber: 1}
+	entry2Updated := &types.Entry{Id: "entry-2", RevisionNumber: 2}
+
+	r := newRevisions(1)
+
+	first := r.update("agent-1", 0, []*types.Entry{entry1, entry2})
+	require.False(t, first.delta)
+
+	// A delta is returned while the snapshot of the agent is cached
+	delta := r.update("agent-1", first.revision, []*types.Entry{entry1, entry2})
+	require.True(t, delta.delta)
+	require.Equal(t, first.revision, delta.revision)
+	require.Empty(t, delta.entries)
+
+	// Syncing another agent evicts the snapshot of the first one
+	r.update("agent-2", 0, []*types.Entry{entry1})
+
+	// The first agent gets the full entry set even though only entry 2
+	// changed since the revision it presents
+	full := r.update("agent-1", first.revision, []*types.Entry{entry1, entry2Updated})
+	require.False(t, full.delta)
+	require.Equal(t, []*types.Entry{entry1, entry2Updated}, full.entries)
+	require.Empty(t, full.deletedIDs)
+	require.NotEqual(t, first.revision, full.revision)
+
+	// Deltas are computed again from the new snapshot
+	delta = r.update("agent-1", full.revision, []*types.Entry{entry1})
+	require.True(t, delta.delta)
+	require.Empty(t, delta.entries)
+	require.Equal(t, []string{"entry-2"}, delta.deletedIDs)
+}
+
+func TestRevisionsIssuedByAnotherServer(t *testing.T) {
+	entry1 := &types.Entry{Id: "entry-1", RevisionNumber: 1}
+	entry2 := &types.Entry{Id: "entry-2", RevisionNumber: 1}
+
+	serverA := newRevisions(revisionsCacheSize)
+	serverB := newRevisions(revisionsCacheSize)
+
+	fromA := serverA.update("agent", 0, []*types.Entry{entry1})
+
+	// The agent reconnects to server B, which does not know the agent
+	full := serverB.update("agent", fromA.revision, []*types.Entry{entry1, entry2})
+	require.False(t, full.delta)
+	require.Equal(t, []*types.Entry{entry1, entry2}, full.entries)
+
+	// The agent goes back to server A, which remembers an older snapshot
+	// under a revision other than the one issued by server B
+	full = serverA.update("agent", full.revision, []*types.Entry{entry1, entry2})
+	require.False(t, full.delta)
+	require.Equal(t, []*types.Entry{entry1, entry2}, full.entries)
+}
@@ -3,13 +3,20 @@ package entry
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/dnspolicy"
+	"github.com/spiffe/spire/pkg/server/entrydefaults"
+	"github.com/spiffe/spire/pkg/server/entrypolicy"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -26,25 +33,42 @@ func RegisterService(s *grpc.Server, service *Service) {
 
 // Config is the service configuration
 type Config struct {
-	TrustDomain  spiffeid.TrustDomain
-	EntryFetcher api.AuthorizedEntryFetcher
-	DataStore    datastore.DataStore
+	TrustDomain   spiffeid.TrustDomain
+	EntryFetcher  api.AuthorizedEntryFetcher
+	EntryWatcher  api.AuthorizedEntryWatcher
+	DataStore     datastore.DataStore
+	EntryDefaults *entrydefaults.Defaults
+	EntryPolicy   *entrypolicy.Policy
+	DNSNamePolicy *dnspolicy.Policy
+	RoleBindings  []middleware.RoleBinding
 }
 
 // New creates a new entry service
 func New(config Config) *Service {
 	return &Service{
-		td: config.TrustDomain,
-		ds: config.DataStore,
-		ef: config.EntryFetcher,
+		td:           config.TrustDomain,
+		ds:           config.DataStore,
+		ef:           config.EntryFetcher,
+		ew:           config.EntryWatcher,
+		defaults:     config.EntryDefaults,
+		policy:       config.EntryPolicy,
+		dnsPolicy:    config.DNSNamePolicy,
+		roleBindings: config.RoleBindings,
+		revisions:    newRevisions(revisionsCacheSize),
 	}
 }
 
 // Service implements the v1 entry service
 type Service struct {
-	td spiffeid.TrustDomain
-	ds datastore.DataStore
-	ef api.AuthorizedEntryFetcher
+	td           spiffeid.TrustDomain
+	ds           datastore.DataStore
+	ef           api.AuthorizedEntryFetcher
+	ew           api.AuthorizedEntryWatcher
+	defaults     *entrydefaults.Defaults
+	policy       *entrypolicy.Policy
+	dnsPolicy    *dnspolicy.Policy
+	roleBindings []middleware.RoleBinding
+	revisions    *revisions
 }
 
 func (s *Service) ListEntries(ctx context.Context, req *entry.ListEntriesRequest) (*entry.ListEntriesResponse, error) {
@@ -148,7 +172,7 @@ func (s *Service) GetEntry(ctx context.Context, req *entry.GetEntryRequest) (*ty
 func (s *Service) BatchCreateEntry(ctx context.Context, req *entry.BatchCreateEntryRequest) (*entry.BatchCreateEntryResponse, error) {
 	var results []*entry.BatchCreateEntryResponse_Result
 	for _, eachEntry := range req.Entries {
-		results = append(results, s.createEntry(ctx, eachEntry, req.OutputMask))
+		results = append(results, s.createEntry(ctx, eachEntry, req.OutputMask, req.Upsert))
 	}
 
 	return &entry.BatchCreateEntryResponse{
@@ -156,7 +180,7 @@ func (s *Service) BatchCreateEntry(ctx context.Context, req *entry.BatchCreateEn
 	}, nil
 }
 
-func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *types.EntryMask) *entry.BatchCreateEntryResponse_Result {
+func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *types.EntryMask, upsert bool) *entry.BatchCreateEntryResponse_Result {
 	log := rpccontext.Logger(ctx)
 
 	cEntry, err := api.ProtoToRegistrationEntry(s.td, e)
@@ -168,6 +192,24 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 
 	log = log.WithField(telemetry.SPIFFEID, cEntry.SpiffeId)
 
+	if err := s.defaults.Apply(cEntry); err != nil {
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "failed to apply entry defaults", err),
+		}
+	}
+
+	if st := s.checkPrivileges(ctx, log, cEntry); st != nil {
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: st,
+		}
+	}
+
+	if st := s.checkPolicy(ctx, log, entrypolicy.Create, cEntry); st != nil {
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: st,
+		}
+	}
+
 	existingEntry, err := s.getExistingEntry(ctx, cEntry)
 	if err != nil {
 		return &entry.BatchCreateEntryResponse_Result{
@@ -178,18 +220,44 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 	resultStatus := api.OK()
 	regEntry := existingEntry
 
-	if existingEntry == nil {
+	switch {
+	case existingEntry == nil:
 		// Create entry
 		resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
 			Entry: cEntry,
 		})
-		if err != nil {
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.FailedPrecondition:
+			return &entry.BatchCreateEntryResponse_Result{
+				Status: api.MakeStatus(log, codes.FailedPrecondition, "failed to apply entry template", err),
+			}
+		default:
 			return &entry.BatchCreateEntryResponse_Result{
 				Status: api.MakeStatus(log, codes.Internal, "failed to create entry", err),
 			}
 		}
 		regEntry = resp.Entry
-	} else {
+	case upsert && !isEntryUpToDate(existingEntry, cEntry):
+		// Replace the similar entry
+		cEntry.EntryId = existingEntry.EntryId
+		log = log.WithField(telemetry.RegistrationID, cEntry.EntryId)
+		if st := s.checkPolicy(ctx, log, entrypolicy.Update, cEntry); st != nil {
+			return &entry.BatchCreateEntryResponse_Result{
+				Status: st,
+			}
+		}
+
+		resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+			Entry: cEntry,
+		})
+		if err != nil {
+			return &entry.BatchCreateEntryResponse_Result{
+				Status: api.MakeStatus(log, codes.Internal, "failed to update entry", err),
+			}
+		}
+		regEntry = resp.Entry
+	default:
 		resultStatus = api.CreateStatus(codes.AlreadyExists, "similar entry already exists")
 	}
 
@@ -266,6 +334,56 @@ func (s *Service) deleteEntry(ctx context.Context, id string) *entry.BatchDelete
 	}
 }
 
+func (s *Service) BatchRotateEntry(ctx context.Context, req *entry.BatchRotateEntryRequest) (*entry.BatchRotateEntryResponse, error) {
+	var results []*entry.BatchRotateEntryResponse_Result
+	for _, id := range req.Ids {
+		results = append(results, s.rotateEntry(ctx, id))
+	}
+
+	return &entry.BatchRotateEntryResponse{
+		Results: results,
+	}, nil
+}
+
+// rotateEntry bumps the revision number of the entry without changing any
+// other field. Agents renew the X509-SVIDs of entries whose revision number
+// changed, generating new keys.
+func (s *Service) rotateEntry(ctx context.Context, id string) *entry.BatchRotateEntryResponse_Result {
+	log := rpccontext.Logger(ctx)
+
+	if id == "" {
+		return &entry.BatchRotateEntryResponse_Result{
+			Id:     id,
+			Status: api.MakeStatus(log, codes.InvalidArgument, "missing entry ID", nil),
+		}
+	}
+
+	log = log.WithField(telemetry.RegistrationID, id)
+
+	_, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{EntryId: id},
+		Mask:  &common.RegistrationEntryMask{},
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		log.Info("Entry SVIDs rotated")
+		return &entry.BatchRotateEntryResponse_Result{
+			Id:     id,
+			Status: api.OK(),
+		}
+	case codes.NotFound:
+		return &entry.BatchRotateEntryResponse_Result{
+			Id:     id,
+			Status: api.MakeStatus(log, codes.NotFound, "entry not found", nil),
+		}
+	default:
+		return &entry.BatchRotateEntryResponse_Result{
+			Id:     id,
+			Status: api.MakeStatus(log, codes.Internal, "failed to rotate entry", err),
+		}
+	}
+}
+
 func (s *Service) GetAuthorizedEntries(ctx context.Context, req *entry.GetAuthorizedEntriesRequest) (*entry.GetAuthorizedEntriesResponse, error) {
 	log := rpccontext.Logger(ctx)
 
@@ -273,18 +391,99 @@ func (s *Service) GetAuthorizedEntries(ctx context.Context, req *entry.GetAuthor
 	if err != nil {
 		return nil, err
 	}
-	for i, entry := range entries {
-		applyMask(entry, req.OutputMask)
-		entries[i] = entry
+
+	// fetchEntries already made sure the caller ID is set
+	callerID, _ := rpccontext.CallerID(ctx)
+	delta := s.revisions.update(callerID.String(), req.SinceRevision, entries)
+
+	entries = delta.entries
+	if req.OutputMask != nil {
+		// Entries may be shared with the entry cache, so the mask is applied
+		// to copies to avoid modifying the cached entries.
+		masked := make([]*types.Entry, 0, len(entries))
+		for _, entry := range entries {
+			entry = proto.Clone(entry).(*types.Entry)
+			applyMask(entry, req.OutputMask)
+			masked = append(masked, entry)
+		}
+		entries = masked
 	}
 
 	resp := &entry.GetAuthorizedEntriesResponse{
-		Entries: entries,
+		Entries:         entries,
+		Revision:        delta.revision,
+		Delta:           delta.delta,
+		DeletedEntryIds: delta.deletedIDs,
 	}
 
 	return resp, nil
 }
 
+func (s *Service) WatchAuthorizedEntries(req *entry.WatchAuthorizedEntriesRequest, stream entry.Entry_WatchAuthorizedEntriesServer) error {
+	ctx := stream.Context()
+	log := rpccontext.Logger(ctx)
+
+	if s.ew == nil {
+		return api.MakeErr(log, codes.Unimplemented, "watching authorized entries is not supported", nil)
+	}
+
+	// The caller is only authorized when the watch is established, so the
+	// watch ends when the X509-SVID the caller presented expires.
+	if svid, ok := rpccontext.CallerX509SVID(ctx); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, svid.NotAfter)
+		defer cancel()
+	}
+
+	// Start watching before fetching the entries so that no change is missed
+	changed := s.ew.WatchAuthorizedEntries(ctx)
+
+	entries, err := s.fetchEntries(ctx, log)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&entry.WatchAuthorizedEntriesResponse{}); err != nil {
+		return api.MakeErr(log, codes.Internal, "failed to send response over stream", err)
+	}
+
+	revisions := entryRevisions(entries)
+	for {
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil
+		}
+
+		entries, err := s.fetchEntries(ctx, log)
+		if err != nil {
+			return err
+		}
+
+		current := entryRevisions(entries)
+		resp := &entry.WatchAuthorizedEntriesResponse{}
+		for _, e := range entries {
+			if revision, ok := revisions[e.Id]; !ok || revision != e.RevisionNumber {
+				resp.UpdatedEntryIds = append(resp.UpdatedEntryIds, e.Id)
+			}
+		}
+		for id := range revisions {
+			if _, ok := current[id]; !ok {
+				resp.DeletedEntryIds = append(resp.DeletedEntryIds, id)
+			}
+		}
+		revisions = current
+
+		if len(resp.UpdatedEntryIds) == 0 && len(resp.DeletedEntryIds) == 0 {
+			continue
+		}
+		sort.Strings(resp.UpdatedEntryIds)
+		sort.Strings(resp.DeletedEntryIds)
+		if err := stream.Send(resp); err != nil {
+			return api.MakeErr(log, codes.Internal, "failed to send response over stream", err)
+		}
+	}
+}
+
 // fetchEntries fetches authorized entries using caller ID from context
 func (s *Service) fetchEntries(ctx context.Context, log logrus.FieldLogger) ([]*types.Entry, error) {
 	callerID, ok := rpccontext.CallerID(ctx)
@@ -344,6 +543,152 @@ func applyMask(e *types.Entry, mask *types.EntryMask) {
 	if !mask.RevisionNumber {
 		e.RevisionNumber = 0
 	}
+
+	if !mask.JwtSvidClaims {
+		e.JwtSvidClaims = nil
+	}
+
+	if !mask.JwtSvidAudience {
+		e.JwtSvidAudience = nil
+	}
+
+	if !mask.X509SvidKeyType {
+		e.X509SvidKeyType = ""
+	}
+
+	if !mask.DnsNameTemplates {
+		e.DnsNameTemplates = nil
+	}
+
+	if !mask.X509SvidSubject {
+		e.X509SvidSubject = nil
+	}
+
+	if !mask.Hint {
+		e.Hint = ""
+	}
+
+	if !mask.SelectorExpression {
+		e.SelectorExpression = ""
+	}
+
+	if !mask.TemplateId {
+		e.TemplateId = ""
+	}
+}
+
+// checkPolicy evaluates the DNS name and entry policies against the entry.
+// It returns a status if the entry is rejected or the policy cannot be
+// evaluated.
+func (s *Service) checkPolicy(ctx context.Context, log logrus.FieldLogger, op entrypolicy.Operation, e *common.RegistrationEntry) *types.Status {
+	if err := s.dnsPolicy.Check(e.SpiffeId, e.DnsNames); err != nil {
+		return api.MakeStatus(log, codes.PermissionDenied, "entry rejected by DNS name policy", err)
+	}
+
+	input := entrypolicy.Input{
+		Operation: op,
+		Entry:     e,
+	}
+	if callerID, ok := rpccontext.CallerID(ctx); ok {
+		input.CallerID = callerID.String()
+	}
+
+	reasons, err := s.policy.Evaluate(ctx, input)
+	switch {
+	case err != nil:
+		return api.MakeStatus(log, codes.Internal, "failed to evaluate entry policy", err)
+	case len(reasons) > 0:
+		return api.MakeStatus(log, codes.PermissionDenied, "entry rejected by policy", errors.New(strings.Join(reasons, "; ")))
+	}
+	return nil
+}
+
+// checkPrivileges makes sure that callers that are neither local nor admin,
+// i.e. callers bound to a role, cannot grant more privileges than a role does
+// through the entry, including by relating it to the existing admin and
+// downstream entries.
+func (s *Service) checkPrivileges(ctx context.Context, log logrus.FieldLogger, e *common.RegistrationEntry) *types.Status {
+	if rpccontext.CallerIsLocal(ctx) || rpccontext.CallerIsAdmin(ctx) {
+		return nil
+	}
+
+	resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	if err != nil {
+		return api.MakeStatus(log, codes.Internal, "failed to list entries", err)
+	}
+	if err := middleware.CheckEntryPrivileges(ctx, s.roleBindings, e, resp.Entries); err != nil {
+		return api.MakeStatus(log, codes.PermissionDenied, "entry rejected", err)
+	}
+	return nil
+}
+
+// applyInputMask returns a copy of the existing entry with the fields
+// selected by the mask replaced by those of the updated entry.
+func applyInputMask(existing, updated *common.RegistrationEntry, mask *types.EntryMask) *common.RegistrationEntry {
+	e := proto.Clone(existing).(*common.RegistrationEntry)
+	if mask.SpiffeId {
+		e.SpiffeId = updated.SpiffeId
+	}
+	if mask.ParentId {
+		e.ParentId = updated.ParentId
+	}
+	if mask.Ttl {
+		e.Ttl = updated.Ttl
+	}
+	if mask.FederatesWith {
+		e.FederatesWith = updated.FederatesWith
+	}
+	if mask.Admin {
+		e.Admin = updated.Admin
+	}
+	if mask.Downstream {
+		e.Downstream = updated.Downstream
+	}
+	if mask.ExpiresAt {
+		e.EntryExpiry = updated.EntryExpiry
+	}
+	if mask.DnsNames {
+		e.DnsNames = updated.DnsNames
+	}
+	if mask.Selectors {
+		e.Selectors = updated.Selectors
+	}
+	if mask.JwtSvidClaims {
+		e.JwtSvidClaims = updated.JwtSvidClaims
+	}
+	if mask.JwtSvidAudience {
+		e.JwtSvidAudience = updated.JwtSvidAudience
+	}
+	if mask.X509SvidKeyType {
+		e.X509SvidKeyType = updated.X509SvidKeyType
+	}
+	if mask.DnsNameTemplates {
+		e.DnsNameTemplates = updated.DnsNameTemplates
+	}
+	if mask.X509SvidSubject {
+		e.X509SvidSubject = updated.X509SvidSubject
+	}
+	if mask.Hint {
+		e.Hint = updated.Hint
+	}
+	if mask.SelectorExpression {
+		e.SelectorExpression = updated.SelectorExpression
+	}
+	if mask.TemplateId {
+		e.TemplateId = updated.TemplateId
+	}
+	return e
+}
+
+// isEntryUpToDate returns true if the existing entry already matches the
+// desired one, ignoring the fields that are not set by the caller.
+func isEntryUpToDate(existing, desired *common.RegistrationEntry) bool {
+	existing = proto.Clone(existing).(*common.RegistrationEntry)
+	existing.EntryId = desired.EntryId
+	existing.RevisionNumber = desired.RevisionNumber
+	// Similar entries have the same selectors, possibly in a different order
+	existing.Selectors = desired.Selectors
+	return proto.Equal(existing, desired)
 }
 
 func (s *Service) getExistingEntry(ctx context.Context, e *common.RegistrationEntry) (*common.RegistrationEntry, error) {
@@ -381,26 +726,79 @@ func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *ty
 		}
 	}
 
+	// The entry resulting from the update is needed to evaluate the policy
+	// and the privileges of callers that are neither local nor admin
+	if s.policy != nil || !(rpccontext.CallerIsLocal(ctx) || rpccontext.CallerIsAdmin(ctx)) {
+		updatedEntry := convEntry
+		if inputMask != nil {
+			dsResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+				EntryId: convEntry.EntryId,
+			})
+			switch {
+			case err != nil:
+				return &entry.BatchUpdateEntryResponse_Result{
+					Status: api.MakeStatus(log, codes.Internal, "failed to fetch entry", err),
+				}
+			case dsResp.Entry == nil:
+				return &entry.BatchUpdateEntryResponse_Result{
+					Status: api.MakeStatus(log, codes.NotFound, "entry not found", nil),
+				}
+			}
+			updatedEntry = applyInputMask(dsResp.Entry, convEntry, inputMask)
+		}
+
+		if st := s.checkPrivileges(ctx, log, updatedEntry); st != nil {
+			return &entry.BatchUpdateEntryResponse_Result{
+				Status: st,
+			}
+		}
+
+		if st := s.checkPolicy(ctx, log, entrypolicy.Update, updatedEntry); st != nil {
+			return &entry.BatchUpdateEntryResponse_Result{
+				Status: st,
+			}
+		}
+	}
+
 	var resp *datastore.UpdateRegistrationEntryResponse
 	if inputMask != nil {
 		resp, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
 			Entry: convEntry,
 			Mask: &common.RegistrationEntryMask{
-				SpiffeId:      inputMask.SpiffeId,
-				ParentId:      inputMask.ParentId,
-				Ttl:           inputMask.Ttl,
-				FederatesWith: inputMask.FederatesWith,
-				Admin:         inputMask.Admin,
-				Downstream:    inputMask.Downstream,
-				EntryExpiry:   inputMask.ExpiresAt,
-				DnsNames:      inputMask.DnsNames,
-				Selectors:     inputMask.Selectors,
+				SpiffeId:           inputMask.SpiffeId,
+				ParentId:           inputMask.ParentId,
+				Ttl:                inputMask.Ttl,
+				FederatesWith:      inputMask.FederatesWith,
+				Admin:              inputMask.Admin,
+				Downstream:         inputMask.Downstream,
+				EntryExpiry:        inputMask.ExpiresAt,
+				DnsNames:           inputMask.DnsNames,
+				Selectors:          inputMask.Selectors,
+				JwtSvidClaims:      inputMask.JwtSvidClaims,
+				JwtSvidAudience:    inputMask.JwtSvidAudience,
+				X509SvidKeyType:    inputMask.X509SvidKeyType,
+				DnsNameTemplates:   inputMask.DnsNameTemplates,
+				X509SvidSubject:    inputMask.X509SvidSubject,
+				Hint:               inputMask.Hint,
+				SelectorExpression: inputMask.SelectorExpression,
+				TemplateId:         inputMask.TemplateId,
+				RevisionNumber:     inputMask.RevisionNumber,
 			}})
 	} else {
 		resp, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{Entry: convEntry})
 	}
 
-	if err != nil {
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.Aborted:
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.MakeStatus(log, codes.Aborted, "entry was modified since the given revision", err),
+		}
+	case codes.FailedPrecondition:
+		return &entry.BatchUpdateEntryResponse_Result{
+			Status: api.MakeStatus(log, codes.FailedPrecondition, "failed to apply entry template", err),
+		}
+	default:
 		return &entry.BatchUpdateEntryResponse_Result{
 			Status: api.MakeStatus(log, codes.Internal, "failed to update entry", err),
 		}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/entry/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	entrypb "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
@@ -33,6 +35,20 @@ var (
 	td          = spiffeid.RequireTrustDomainFromString("example.org")
 	federatedTd = spiffeid.RequireTrustDomainFromString("domain1.org")
 	agentID     = spiffeid.RequireFromString("spiffe://example.org/agent")
+
+	roleBindings = []middleware.RoleBinding{
+		{
+			Role:      middleware.RoleBundleAdmin,
+			SPIFFEIDs: []spiffeid.ID{td.NewID("bundle-admin")},
+		},
+		{
+			Role: middleware.RoleAgentAdmin,
+			Selectors: []*types.Selector{
+				{Type: "k8s", Value: "ns:spire"},
+				{Type: "k8s", Value: "sa:agent-admin"},
+			},
+		},
+	}
 )
 
 func TestListEntries(t *testing.T) {
@@ -534,6 +550,7 @@ func TestBatchCreateEntry(t *testing.T) {
 		expectStatus  *types.Status
 		outputMask    *types.EntryMask
 		reqEntries    []*types.Entry
+		upsert        bool
 
 		// fake ds configurations
 		dsError         error
@@ -725,6 +742,68 @@ func TestBatchCreateEntry(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "upserts existing similar entry",
+			expectResults: []*entrypb.BatchCreateEntryResponse_Result{
+				{
+					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+					Entry: &types.Entry{
+						Id:  useDefaultEntryID,
+						Ttl: 20,
+					},
+				},
+			},
+			outputMask: &types.EntryMask{
+				Ttl: true,
+			},
+			reqEntries: []*types.Entry{
+				{
+					ParentId: api.ProtoFromID(entryParentID),
+					SpiffeId: api.ProtoFromID(entrySpiffeID),
+					Ttl:      20,
+					Selectors: []*types.Selector{
+						{Type: "unix", Value: "uid:1000"},
+						{Type: "unix", Value: "gid:1000"},
+					},
+				},
+			},
+			upsert: true,
+		},
+		{
+			name: "upsert ignores up to date entry",
+			expectResults: []*entrypb.BatchCreateEntryResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.AlreadyExists),
+						Message: "similar entry already exists",
+					},
+					Entry: &types.Entry{
+						Id:  useDefaultEntryID,
+						Ttl: 60,
+					},
+				},
+			},
+			outputMask: &types.EntryMask{
+				Ttl: true,
+			},
+			reqEntries: []*types.Entry{
+				{
+					ParentId: api.ProtoFromID(entryParentID),
+					SpiffeId: api.ProtoFromID(entrySpiffeID),
+					Ttl:      60,
+					Selectors: []*types.Selector{
+						{Type: "unix", Value: "uid:1000"},
+						{Type: "unix", Value: "gid:1000"},
+					},
+					Admin:         true,
+					DnsNames:      []string{"dns1", "dns2"},
+					Downstream:    true,
+					ExpiresAt:     expiresAt,
+					FederatesWith: []string{federatedTd.String()},
+				},
+			},
+			upsert: true,
+		},
 		{
 			name: "invalid entry",
 			expectResults: []*entrypb.BatchCreateEntryResponse_Result{
@@ -827,6 +906,7 @@ func TestBatchCreateEntry(t *testing.T) {
 			resp, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
 				Entries:    tt.reqEntries,
 				OutputMask: tt.outputMask,
+				Upsert:     tt.upsert,
 			})
 
 			require.NoError(t, err)
@@ -932,18 +1012,18 @@ func TestBatchDeleteEntry(t *testing.T) {
 			expectDs: dsEntries,
 			expectResult: func(m map[string]*common.RegistrationEntry) ([]*entrypb.BatchDeleteEntryResponse_Result, []spiretest.LogEntry) {
 				return []*entrypb.BatchDeleteEntryResponse_Result{
-						{
-							Status: &types.Status{
-								Code:    int32(codes.InvalidArgument),
-								Message: "missing entry ID",
-							},
-						},
-					}, []spiretest.LogEntry{
-						{
-							Level:   logrus.ErrorLevel,
-							Message: "Invalid argument: missing entry ID",
+					{
+						Status: &types.Status{
+							Code:    int32(codes.InvalidArgument),
+							Message: "missing entry ID",
 						},
-					}
+					},
+				}, []spiretest.LogEntry{
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Invalid argument: missing entry ID",
+					},
+				}
 			},
 			ids: func(m map[string]*common.RegistrationEntry) []string {
 				return []string{""}
@@ -955,23 +1035,23 @@ func TestBatchDeleteEntry(t *testing.T) {
 			expectDs: dsEntries,
 			expectResult: func(m map[string]*common.RegistrationEntry) ([]*entrypb.BatchDeleteEntryResponse_Result, []spiretest.LogEntry) {
 				return []*entrypb.BatchDeleteEntryResponse_Result{
-						{
-							Status: &types.Status{
-								Code:    int32(codes.Internal),
-								Message: "failed to delete entry: some error",
-							},
-							Id: m[fooSpiffeID].EntryId,
+					{
+						Status: &types.Status{
+							Code:    int32(codes.Internal),
+							Message: "failed to delete entry: some error",
 						},
-					}, []spiretest.LogEntry{
-						{
-							Level:   logrus.ErrorLevel,
-							Message: "Failed to delete entry",
-							Data: logrus.Fields{
-								telemetry.RegistrationID: m[fooSpiffeID].EntryId,
-								logrus.ErrorKey:          "some error",
-							},
+						Id: m[fooSpiffeID].EntryId,
+					},
+				}, []spiretest.LogEntry{
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Failed to delete entry",
+						Data: logrus.Fields{
+							telemetry.RegistrationID: m[fooSpiffeID].EntryId,
+							logrus.ErrorKey:          "some error",
 						},
-					}
+					},
+				}
 			},
 			ids: func(m map[string]*common.RegistrationEntry) []string {
 				return []string{m[fooSpiffeID].EntryId}
@@ -982,22 +1062,22 @@ func TestBatchDeleteEntry(t *testing.T) {
 			expectDs: dsEntries,
 			expectResult: func(m map[string]*common.RegistrationEntry) ([]*entrypb.BatchDeleteEntryResponse_Result, []spiretest.LogEntry) {
 				return []*entrypb.BatchDeleteEntryResponse_Result{
-						{
-							Status: &types.Status{
-								Code:    int32(codes.NotFound),
-								Message: "entry not found",
-							},
-							Id: "invalid id",
+					{
+						Status: &types.Status{
+							Code:    int32(codes.NotFound),
+							Message: "entry not found",
 						},
-					}, []spiretest.LogEntry{
-						{
-							Level:   logrus.ErrorLevel,
-							Message: "Entry not found",
-							Data: logrus.Fields{
-								telemetry.RegistrationID: "invalid id",
-							},
+						Id: "invalid id",
+					},
+				}, []spiretest.LogEntry{
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Entry not found",
+						Data: logrus.Fields{
+							telemetry.RegistrationID: "invalid id",
 						},
-					}
+					},
+				}
 			},
 			ids: func(m map[string]*common.RegistrationEntry) []string {
 				return []string{"invalid id"}
@@ -1038,6 +1118,137 @@ func TestBatchDeleteEntry(t *testing.T) {
 	}
 }
 
+func TestBatchRotateEntry(t *testing.T) {
+	parentID := td.NewID("host").String()
+	fooEntry := &common.RegistrationEntry{
+		ParentId:  parentID,
+		SpiffeId:  td.NewID("foo").String(),
+		Selectors: []*common.Selector{{Type: "not", Value: "relevant"}},
+		DnsNames:  []string{"foo.example.org"},
+	}
+	barEntry := &common.RegistrationEntry{
+		ParentId:  parentID,
+		SpiffeId:  td.NewID("bar").String(),
+		Selectors: []*common.Selector{{Type: "not", Value: "relevant"}},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		dsError        error
+		ids            func(foo *common.RegistrationEntry) []string
+		expectResults  func(foo *common.RegistrationEntry) []*entrypb.BatchRotateEntryResponse_Result
+		expectLogs     func(foo *common.RegistrationEntry) []spiretest.LogEntry
+		expectRotation bool
+	}{
+		{
+			name: "rotate entries",
+			ids: func(foo *common.RegistrationEntry) []string {
+				return []string{foo.EntryId, "not found", ""}
+			},
+			expectResults: func(foo *common.RegistrationEntry) []*entrypb.BatchRotateEntryResponse_Result {
+				return []*entrypb.BatchRotateEntryResponse_Result{
+					{
+						Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+						Id:     foo.EntryId,
+					},
+					{
+						Status: &types.Status{Code: int32(codes.NotFound), Message: "entry not found"},
+						Id:     "not found",
+					},
+					{
+						Status: &types.Status{Code: int32(codes.InvalidArgument), Message: "missing entry ID"},
+					},
+				}
+			},
+			expectLogs: func(foo *common.RegistrationEntry) []spiretest.LogEntry {
+				return []spiretest.LogEntry{
+					{
+						Level:   logrus.InfoLevel,
+						Message: "Entry SVIDs rotated",
+						Data: logrus.Fields{
+							telemetry.RegistrationID: foo.EntryId,
+						},
+					},
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Entry not found",
+						Data: logrus.Fields{
+							telemetry.RegistrationID: "not found",
+						},
+					},
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Invalid argument: missing entry ID",
+					},
+				}
+			},
+			expectRotation: true,
+		},
+		{
+			name:    "fail to rotate entry",
+			dsError: errors.New("some error"),
+			ids: func(foo *common.RegistrationEntry) []string {
+				return []string{foo.EntryId}
+			},
+			expectResults: func(foo *common.RegistrationEntry) []*entrypb.BatchRotateEntryResponse_Result {
+				return []*entrypb.BatchRotateEntryResponse_Result{
+					{
+						Status: &types.Status{Code: int32(codes.Internal), Message: "failed to rotate entry: some error"},
+						Id:     foo.EntryId,
+					},
+				}
+			},
+			expectLogs: func(foo *common.RegistrationEntry) []spiretest.LogEntry {
+				return []spiretest.LogEntry{
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Failed to rotate entry",
+						Data: logrus.Fields{
+							telemetry.RegistrationID: foo.EntryId,
+							logrus.ErrorKey:          "some error",
+						},
+					},
+				}
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ds := fakedatastore.New(t)
+			test := setupServiceTest(t, ds)
+			defer test.Cleanup()
+
+			entriesMap := createTestEntries(t, ds, fooEntry, barEntry)
+			foo := entriesMap[fooEntry.SpiffeId]
+			bar := entriesMap[barEntry.SpiffeId]
+
+			ds.SetNextError(tt.dsError)
+			resp, err := test.client.BatchRotateEntry(ctx, &entrypb.BatchRotateEntryRequest{
+				Ids: tt.ids(foo),
+			})
+			require.NoError(t, err)
+
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs(foo))
+			spiretest.AssertProtoEqual(t, &entrypb.BatchRotateEntryResponse{
+				Results: tt.expectResults(foo),
+			}, resp)
+
+			// Only the revision number of the rotated entries is bumped
+			expectFoo := proto.Clone(foo).(*common.RegistrationEntry)
+			if tt.expectRotation {
+				expectFoo.RevisionNumber++
+			}
+			for _, expected := range []*common.RegistrationEntry{expectFoo, bar} {
+				fetchResp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+					EntryId: expected.EntryId,
+				})
+				require.NoError(t, err)
+				spiretest.AssertProtoEqual(t, expected, fetchResp.Entry)
+			}
+		})
+	}
+}
+
 func TestGetAuthorizedEntries(t *testing.T) {
 	entry1 := types.Entry{
 		Id:       "entry-1",
@@ -1180,14 +1391,147 @@ func TestGetAuthorizedEntries(t *testing.T) {
 
 			require.NoError(t, err)
 			require.NotNil(t, resp)
+			require.NotZero(t, resp.Revision)
 			expectResponse := &entrypb.GetAuthorizedEntriesResponse{
-				Entries: tt.expectEntries,
+				Entries:  tt.expectEntries,
+				Revision: resp.Revision,
 			}
 			spiretest.AssertProtoEqual(t, expectResponse, resp)
+
+			// Entries returned by the fetcher may be shared with the entry
+			// cache and must not be modified by the output mask.
+			if len(tt.fetcherEntries) > 0 {
+				spiretest.AssertProtoEqual(t, &entry1, tt.fetcherEntries[0])
+				spiretest.AssertProtoEqual(t, &entry2, tt.fetcherEntries[1])
+			}
 		})
 	}
 }
 
+func TestGetAuthorizedEntriesSinceRevision(t *testing.T) {
+	entry1 := &types.Entry{Id: "entry-1", RevisionNumber: 1}
+	entry2 := &types.Entry{Id: "entry-2", RevisionNumber: 1}
+	entry3 := &types.Entry{Id: "entry-3", RevisionNumber: 1}
+	entry2Updated := &types.Entry{Id: "entry-2", RevisionNumber: 2}
+
+	test := setupServiceTest(t, fakedatastore.New(t))
+	defer test.Cleanup()
+	test.withCallerID = true
+
+	// The first call returns all of the entries
+	test.ef.entries = []*types.Entry{entry1, entry2}
+	resp, err := test.client.GetAuthorizedEntries(ctx, &entrypb.GetAuthorizedEntriesRequest{})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &entrypb.GetAuthorizedEntriesResponse{
+		Entries:  []*types.Entry{entry1, entry2},
+		Revision: resp.Revision,
+	}, resp)
+	firstRevision := resp.Revision
+	require.NotZero(t, firstRevision)
+
+	// Nothing changed: the delta is empty and the revision is kept
+	resp, err = test.client.GetAuthorizedEntries(ctx, &entrypb.GetAuthorizedEntriesRequest{
+		SinceRevision: firstRevision,
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &entrypb.GetAuthorizedEntriesResponse{
+		Revision: firstRevision,
+		Delta:    true,
+	}, resp)
+
+	// Entry 1 is removed, entry 2 is updated and entry 3 is added
+	test.ef.entries = []*types.Entry{entry2Updated, entry3}
+	resp, err = test.client.GetAuthorizedEntries(ctx, &entrypb.GetAuthorizedEntriesRequest{
+		SinceRevision: firstRevision,
+	})
+	require.NoError(t, err)
+	secondRevision := resp.Revision
+	require.NotEqual(t, firstRevision, secondRevision)
+	spiretest.AssertProtoEqual(t, &entrypb.GetAuthorizedEntriesResponse{
+		Entries:         []*types.Entry{entry2Updated, entry3},
+		Revision:        secondRevision,
+		Delta:           true,
+		DeletedEntryIds: []string{"entry-1"},
+	}, resp)
+
+	// A revision that is no longer current gets all of the entries
+	resp, err = test.client.GetAuthorizedEntries(ctx, &entrypb.GetAuthorizedEntriesRequest{
+		SinceRevision: firstRevision,
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &entrypb.GetAuthorizedEntriesResponse{
+		Entries:  []*types.Entry{entry2Updated, entry3},
+		Revision: resp.Revision,
+	}, resp)
+	require.NotEqual(t, secondRevision, resp.Revision)
+}
+
+func TestWatchAuthorizedEntries(t *testing.T) {
+	entry1 := &types.Entry{Id: "entry-1", RevisionNumber: 1}
+	entry2 := &types.Entry{Id: "entry-2", RevisionNumber: 1}
+	entry2Updated := &types.Entry{Id: "entry-2", RevisionNumber: 2}
+	entry3 := &types.Entry{Id: "entry-3", RevisionNumber: 1}
+
+	test := setupServiceTest(t, fakedatastore.New(t))
+	defer test.Cleanup()
+	test.withCallerID = true
+	test.ef.setEntries([]*types.Entry{entry1, entry2})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := test.client.WatchAuthorizedEntries(ctx, &entrypb.WatchAuthorizedEntriesRequest{})
+	require.NoError(t, err)
+
+	// The first response tells that the watch is established
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &entrypb.WatchAuthorizedEntriesResponse{}, resp)
+
+	// Nothing is sent when the authorized entries did not change
+	test.ew.changed <- struct{}{}
+
+	// Entry 1 is removed, entry 2 is updated and entry 3 is added
+	test.ef.setEntries([]*types.Entry{entry2Updated, entry3})
+	test.ew.changed <- struct{}{}
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &entrypb.WatchAuthorizedEntriesResponse{
+		UpdatedEntryIds: []string{"entry-2", "entry-3"},
+		DeletedEntryIds: []string{"entry-1"},
+	}, resp)
+
+	// Failures to fetch the entries end the watch
+	test.ef.mu.Lock()
+	test.ef.err = "fetcher fails"
+	test.ef.mu.Unlock()
+	test.ew.changed <- struct{}{}
+	_, err = stream.Recv()
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "failed to fetch entries: fetcher fails")
+}
+
+func TestWatchAuthorizedEntriesNotSupported(t *testing.T) {
+	service := entry.New(entry.Config{
+		TrustDomain:  td,
+		DataStore:    fakedatastore.New(t),
+		EntryFetcher: &entryFetcher{},
+	})
+	registerFn := func(s *grpc.Server) {
+		entry.RegisterService(s, service)
+	}
+	log, _ := test.NewNullLogger()
+	contextFn := func(ctx context.Context) context.Context {
+		ctx = rpccontext.WithLogger(ctx, log)
+		return rpccontext.WithCallerID(ctx, agentID)
+	}
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+	defer done()
+
+	stream, err := entrypb.NewEntryClient(conn).WatchAuthorizedEntries(ctx, &entrypb.WatchAuthorizedEntriesRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "watching authorized entries is not supported")
+}
+
 func createFederatedBundles(t *testing.T, ds datastore.DataStore) {
 	_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
 		Bundle: &common.Bundle{
@@ -1220,10 +1564,14 @@ func createTestEntries(t *testing.T, ds datastore.DataStore, entry ...*common.Re
 type serviceTest struct {
 	client       entrypb.EntryClient
 	ef           *entryFetcher
+	ew           *entryWatcher
 	done         func()
 	ds           datastore.DataStore
 	logHook      *test.Hook
 	withCallerID bool
+	// roleCaller makes the caller neither local nor admin, as callers that
+	// are authorized through a role binding
+	roleCaller bool
 }
 
 func (s *serviceTest) Cleanup() {
@@ -1232,10 +1580,13 @@ func (s *serviceTest) Cleanup() {
 
 func setupServiceTest(t *testing.T, ds datastore.DataStore) *serviceTest {
 	ef := &entryFetcher{}
+	ew := &entryWatcher{changed: make(chan struct{})}
 	service := entry.New(entry.Config{
 		TrustDomain:  td,
 		DataStore:    ds,
 		EntryFetcher: ef,
+		EntryWatcher: ew,
+		RoleBindings: roleBindings,
 	})
 
 	log, logHook := test.NewNullLogger()
@@ -1247,6 +1598,7 @@ func setupServiceTest(t *testing.T, ds datastore.DataStore) *serviceTest {
 		ds:      ds,
 		logHook: logHook,
 		ef:      ef,
+		ew:      ew,
 	}
 
 	contextFn := func(ctx context.Context) context.Context {
@@ -1254,6 +1606,9 @@ func setupServiceTest(t *testing.T, ds datastore.DataStore) *serviceTest {
 		if test.withCallerID {
 			ctx = rpccontext.WithCallerID(ctx, agentID)
 		}
+		if !test.roleCaller {
+			ctx = rpccontext.WithLocalCaller(ctx)
+		}
 		return ctx
 	}
 
@@ -1621,6 +1976,81 @@ func TestBatchUpdateEntry(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "Success Update JWT-SVID Claims and Audience",
+			initialEntries: []*types.Entry{initialEntry},
+			inputMask: &types.EntryMask{
+				JwtSvidClaims:   true,
+				JwtSvidAudience: true,
+			},
+			outputMask: &types.EntryMask{
+				JwtSvidClaims:   true,
+				JwtSvidAudience: true,
+			},
+			updateEntries: []*types.Entry{
+				{
+					JwtSvidClaims:   map[string]string{"role": "admin"},
+					JwtSvidAudience: []string{"audUpdated"},
+				},
+			},
+			expectDsEntries: func(id string) []*types.Entry {
+				modifiedEntry := proto.Clone(initialEntry).(*types.Entry)
+				modifiedEntry.Id = id
+				modifiedEntry.JwtSvidClaims = map[string]string{"role": "admin"}
+				modifiedEntry.JwtSvidAudience = []string{"audUpdated"}
+				modifiedEntry.RevisionNumber = 1
+				return []*types.Entry{modifiedEntry}
+			},
+			expectResults: []*entrypb.BatchUpdateEntryResponse_Result{
+				{
+					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+					Entry: &types.Entry{
+						JwtSvidClaims:   map[string]string{"role": "admin"},
+						JwtSvidAudience: []string{"audUpdated"},
+					},
+				},
+			},
+		},
+		{
+			name:           "Success Update X509-SVID Key Type, DNS Name Templates and Subject",
+			initialEntries: []*types.Entry{initialEntry},
+			inputMask: &types.EntryMask{
+				X509SvidKeyType:  true,
+				DnsNameTemplates: true,
+				X509SvidSubject:  true,
+			},
+			outputMask: &types.EntryMask{
+				X509SvidKeyType:  true,
+				DnsNameTemplates: true,
+				X509SvidSubject:  true,
+			},
+			updateEntries: []*types.Entry{
+				{
+					X509SvidKeyType:  "rsa-2048",
+					DnsNameTemplates: []string{"{{ .TrustDomain }}"},
+					X509SvidSubject:  &types.X509SVIDSubject{CommonName: "cn"},
+				},
+			},
+			expectDsEntries: func(id string) []*types.Entry {
+				modifiedEntry := proto.Clone(initialEntry).(*types.Entry)
+				modifiedEntry.Id = id
+				modifiedEntry.X509SvidKeyType = "rsa-2048"
+				modifiedEntry.DnsNameTemplates = []string{"{{ .TrustDomain }}"}
+				modifiedEntry.X509SvidSubject = &types.X509SVIDSubject{CommonName: "cn"}
+				modifiedEntry.RevisionNumber = 1
+				return []*types.Entry{modifiedEntry}
+			},
+			expectResults: []*entrypb.BatchUpdateEntryResponse_Result{
+				{
+					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+					Entry: &types.Entry{
+						X509SvidKeyType:  "rsa-2048",
+						DnsNameTemplates: []string{"{{ .TrustDomain }}"},
+						X509SvidSubject:  &types.X509SVIDSubject{CommonName: "cn"},
+					},
+				},
+			},
+		},
 		{
 			name:           "Success Don't Update TTL",
 			initialEntries: []*types.Entry{initialEntry},
@@ -1830,6 +2260,79 @@ func TestBatchUpdateEntry(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:           "Success Update With Current Revision Number",
+			initialEntries: []*types.Entry{initialEntry},
+			inputMask: &types.EntryMask{
+				Ttl:            true,
+				RevisionNumber: true,
+			},
+			outputMask: &types.EntryMask{
+				Ttl:            true,
+				RevisionNumber: true,
+			},
+			updateEntries: []*types.Entry{
+				{
+					Ttl:            1000,
+					RevisionNumber: 0,
+				},
+			},
+			expectDsEntries: func(id string) []*types.Entry {
+				modifiedEntry := proto.Clone(initialEntry).(*types.Entry)
+				modifiedEntry.Id = id
+				modifiedEntry.Ttl = 1000
+				modifiedEntry.RevisionNumber = 1
+				return []*types.Entry{modifiedEntry}
+			},
+			expectResults: []*entrypb.BatchUpdateEntryResponse_Result{
+				{
+					Status: &types.Status{Code: int32(codes.OK), Message: "OK"},
+					Entry: &types.Entry{
+						Ttl:            1000,
+						RevisionNumber: 1,
+					},
+				},
+			},
+		},
+		{
+			name:           "Fail Stale Revision Number",
+			initialEntries: []*types.Entry{initialEntry},
+			inputMask: &types.EntryMask{
+				Ttl:            true,
+				RevisionNumber: true,
+			},
+			updateEntries: []*types.Entry{
+				{
+					Ttl:            1000,
+					RevisionNumber: 3,
+				},
+			},
+			expectDsEntries: func(id string) []*types.Entry {
+				unmodifiedEntry := proto.Clone(initialEntry).(*types.Entry)
+				unmodifiedEntry.Id = id
+				return []*types.Entry{unmodifiedEntry}
+			},
+			expectResults: []*entrypb.BatchUpdateEntryResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.Aborted),
+						Message: "entry was modified since the given revision: datastore-sql: entry revision number 3 does not match the current revision number 0",
+					},
+				},
+			},
+			expectLogs: func(m map[string]string) []spiretest.LogEntry {
+				return []spiretest.LogEntry{
+					{
+						Level:   logrus.ErrorLevel,
+						Message: "Entry was modified since the given revision",
+						Data: logrus.Fields{
+							telemetry.RegistrationID: m[entry1SpiffeID.Path],
+							logrus.ErrorKey:          "rpc error: code = Aborted desc = datastore-sql: entry revision number 3 does not match the current revision number 0",
+						},
+					},
+				}
+			},
+		},
 		{
 			name:           "Success Nil Input Mask",
 			initialEntries: []*types.Entry{initialEntry},
@@ -2023,6 +2526,164 @@ func TestBatchUpdateEntry(t *testing.T) {
 	}
 }
 
+func TestRoleCallerCannotEscalatePrivileges(t *testing.T) {
+	parentID := td.NewID("parent")
+	workloadID := td.NewID("workload")
+	adminID := td.NewID("admin")
+	adminAgentID := td.NewID("admin-agent")
+	protoParentID := api.ProtoFromID(parentID)
+	workloadSelectors := []*types.Selector{{Type: "unix", Value: "uid:1000"}}
+	bindingSelectors := []*types.Selector{
+		{Type: "k8s", Value: "ns:spire"},
+		{Type: "k8s", Value: "sa:agent-admin"},
+		{Type: "k8s", Value: "pod-name:escalate"},
+	}
+
+	ds := fakedatastore.New(t)
+	test := setupServiceTest(t, ds)
+	defer test.Cleanup()
+	test.roleCaller = true
+
+	existing := createTestEntries(t, ds,
+		&common.RegistrationEntry{
+			ParentId:  parentID.String(),
+			SpiffeId:  workloadID.String(),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		&common.RegistrationEntry{
+			ParentId:  adminAgentID.String(),
+			SpiffeId:  adminID.String(),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:0"}},
+			Admin:     true,
+		},
+	)
+	workloadEntryID := existing[workloadID.String()].EntryId
+	adminEntryID := existing[adminID.String()].EntryId
+
+	for _, tt := range []struct {
+		name string
+		msg  string
+		// Either create or update is set
+		create    *types.Entry
+		update    *types.Entry
+		inputMask *types.EntryMask
+	}{
+		{
+			name:   "create admin entry",
+			msg:    "entry rejected: only local or admin callers can manage admin entries",
+			create: &types.Entry{ParentId: protoParentID, SpiffeId: api.ProtoFromID(td.NewID("new")), Selectors: workloadSelectors, Admin: true},
+		},
+		{
+			name:   "create downstream entry",
+			msg:    "entry rejected: only local or admin callers can manage downstream entries",
+			create: &types.Entry{ParentId: protoParentID, SpiffeId: api.ProtoFromID(td.NewID("new")), Selectors: workloadSelectors, Downstream: true},
+		},
+		{
+			name:   "create entry with the selectors of a role binding",
+			msg:    `entry rejected: only local or admin callers can manage entries bound to the "agent-admin" role`,
+			create: &types.Entry{ParentId: protoParentID, SpiffeId: api.ProtoFromID(td.NewID("new")), Selectors: bindingSelectors},
+		},
+		{
+			name:   "create entry with the SPIFFE ID of a role binding",
+			msg:    `entry rejected: only local or admin callers can manage entries bound to the "bundle-admin" role`,
+			create: &types.Entry{ParentId: protoParentID, SpiffeId: api.ProtoFromID(td.NewID("bundle-admin")), Selectors: workloadSelectors},
+		},
+		{
+			name:   "create entry with the SPIFFE ID of an admin entry",
+			msg:    "entry rejected: only local or admin callers can manage entries with the SPIFFE ID of an admin or downstream entry",
+			create: &types.Entry{ParentId: protoParentID, SpiffeId: api.ProtoFromID(adminID), Selectors: workloadSelectors},
+		},
+		{
+			name:   "create node alias for the parent of an admin entry",
+			msg:    "entry rejected: only local or admin callers can manage entries with the SPIFFE ID of the parent of an admin or downstream entry",
+			create: &types.Entry{ParentId: api.ProtoFromID(td.NewID("spire/server")), SpiffeId: api.ProtoFromID(adminAgentID), Selectors: workloadSelectors},
+		},
+		{
+			name:   "create entry parented under the parent of an admin entry",
+			msg:    "entry rejected: only local or admin callers can manage entries with the same parent as an admin or downstream entry",
+			create: &types.Entry{ParentId: api.ProtoFromID(adminAgentID), SpiffeId: api.ProtoFromID(td.NewID("new")), Selectors: workloadSelectors},
+		},
+		{
+			name:      "update entry to admin",
+			msg:       "entry rejected: only local or admin callers can manage admin entries",
+			update:    &types.Entry{Id: workloadEntryID, Admin: true},
+			inputMask: &types.EntryMask{Admin: true},
+		},
+		{
+			name:      "update entry to downstream",
+			msg:       "entry rejected: only local or admin callers can manage downstream entries",
+			update:    &types.Entry{Id: workloadEntryID, Downstream: true},
+			inputMask: &types.EntryMask{Downstream: true},
+		},
+		{
+			name:      "update entry with the selectors of a role binding",
+			msg:       `entry rejected: only local or admin callers can manage entries bound to the "agent-admin" role`,
+			update:    &types.Entry{Id: workloadEntryID, Selectors: bindingSelectors},
+			inputMask: &types.EntryMask{Selectors: true},
+		},
+		{
+			name:      "update entry to be parented under the parent of an admin entry",
+			msg:       "entry rejected: only local or admin callers can manage entries with the same parent as an admin or downstream entry",
+			update:    &types.Entry{Id: workloadEntryID, ParentId: api.ProtoFromID(adminAgentID)},
+			inputMask: &types.EntryMask{ParentId: true},
+		},
+		{
+			name:   "update entry without mask",
+			msg:    "entry rejected: only local or admin callers can manage admin entries",
+			update: &types.Entry{Id: workloadEntryID, ParentId: protoParentID, SpiffeId: api.ProtoFromID(workloadID), Selectors: workloadSelectors, Admin: true},
+		},
+		{
+			name:      "update the selectors of an admin entry",
+			msg:       "entry rejected: only local or admin callers can manage admin entries",
+			update:    &types.Entry{Id: adminEntryID, Selectors: workloadSelectors},
+			inputMask: &types.EntryMask{Selectors: true},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var st *types.Status
+			if tt.create != nil {
+				resp, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+					Entries: []*types.Entry{tt.create},
+				})
+				require.NoError(t, err)
+				require.Len(t, resp.Results, 1)
+				st = resp.Results[0].Status
+			} else {
+				resp, err := test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+					Entries:   []*types.Entry{tt.update},
+					InputMask: tt.inputMask,
+				})
+				require.NoError(t, err)
+				require.Len(t, resp.Results, 1)
+				st = resp.Results[0].Status
+			}
+			spiretest.AssertProtoEqual(t, &types.Status{
+				Code:    int32(codes.PermissionDenied),
+				Message: tt.msg,
+			}, st)
+		})
+	}
+
+	// Entries that do not grant more privileges than a role can be managed
+	resp, err := test.client.BatchCreateEntry(ctx, &entrypb.BatchCreateEntryRequest{
+		Entries: []*types.Entry{
+			{ParentId: protoParentID, SpiffeId: api.ProtoFromID(td.NewID("new")), Selectors: bindingSelectors[:1]},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, int32(codes.OK), resp.Results[0].Status.Code)
+
+	updateResp, err := test.client.BatchUpdateEntry(ctx, &entrypb.BatchUpdateEntryRequest{
+		Entries:   []*types.Entry{{Id: workloadEntryID, Ttl: 60}},
+		InputMask: &types.EntryMask{Ttl: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, updateResp.Results, 1)
+	require.Equal(t, int32(codes.OK), updateResp.Results[0].Status.Code)
+}
+
 type fakeDS struct {
 	*fakedatastore.DataStore
 
@@ -2073,11 +2734,21 @@ func (f *fakeDS) CreateRegistrationEntry(ctx context.Context, req *datastore.Cre
 }
 
 type entryFetcher struct {
+	mu      sync.Mutex
 	err     string
 	entries []*types.Entry
 }
 
+func (f *entryFetcher) setEntries(entries []*types.Entry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries = entries
+}
+
 func (f *entryFetcher) FetchAuthorizedEntries(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	if f.err != "" {
 		return nil, status.Error(codes.Internal, f.err)
 	}
@@ -2093,3 +2764,11 @@ func (f *entryFetcher) FetchAuthorizedEntries(ctx context.Context, agentID spiff
 
 	return f.entries, nil
 }
+
+type entryWatcher struct {
+	changed chan struct{}
+}
+
+func (w *entryWatcher) WatchAuthorizedEntries(ctx context.Context) <-chan struct{} {
+	return w.changed
+}
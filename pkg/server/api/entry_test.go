@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -40,11 +41,18 @@ func TestRegistrationEntryToProto(t *testing.T) {
 					// domain name either way.
 					"domain2.com",
 				},
-				Admin:          true,
-				EntryExpiry:    expiresAt,
-				DnsNames:       []string{"dns1", "dns2"},
-				Downstream:     true,
-				RevisionNumber: 99,
+				Admin:              true,
+				EntryExpiry:        expiresAt,
+				DnsNames:           []string{"dns1", "dns2"},
+				Downstream:         true,
+				RevisionNumber:     99,
+				JwtSvidClaims:      map[string]string{"role": "admin"},
+				JwtSvidAudience:    []string{"aud1", "aud2"},
+				X509SvidKeyType:    "rsa-2048",
+				DnsNameTemplates:   []string{"{{ .TrustDomain }}"},
+				X509SvidSubject:    &common.X509SVIDSubject{CommonName: "cn", Organization: []string{"org"}},
+				Hint:               "internal",
+				SelectorExpression: "!unix:uid:0",
 			},
 			expectEntry: &types.Entry{
 				Id:       "entry1",
@@ -59,11 +67,18 @@ func TestRegistrationEntryToProto(t *testing.T) {
 					"domain1.com",
 					"domain2.com",
 				},
-				Admin:          true,
-				ExpiresAt:      expiresAt,
-				DnsNames:       []string{"dns1", "dns2"},
-				Downstream:     true,
-				RevisionNumber: 99,
+				Admin:              true,
+				ExpiresAt:          expiresAt,
+				DnsNames:           []string{"dns1", "dns2"},
+				Downstream:         true,
+				RevisionNumber:     99,
+				JwtSvidClaims:      map[string]string{"role": "admin"},
+				JwtSvidAudience:    []string{"aud1", "aud2"},
+				X509SvidKeyType:    "rsa-2048",
+				DnsNameTemplates:   []string{"{{ .TrustDomain }}"},
+				X509SvidSubject:    &types.X509SVIDSubject{CommonName: "cn", Organization: []string{"org"}},
+				Hint:               "internal",
+				SelectorExpression: "!unix:uid:0",
 			},
 		},
 		{
@@ -223,11 +238,18 @@ func TestProtoToRegistrationEntry(t *testing.T) {
 					// either way.
 					"spiffe://domain2.com",
 				},
-				Admin:          true,
-				ExpiresAt:      expiresAt,
-				DnsNames:       []string{"dns1", "dns2"},
-				Downstream:     true,
-				RevisionNumber: 99,
+				Admin:              true,
+				ExpiresAt:          expiresAt,
+				DnsNames:           []string{"dns1", "dns2"},
+				Downstream:         true,
+				RevisionNumber:     99,
+				JwtSvidClaims:      map[string]string{"role": "admin"},
+				JwtSvidAudience:    []string{"aud1", "aud2"},
+				X509SvidKeyType:    "rsa-2048",
+				DnsNameTemplates:   []string{"{{ .TrustDomain }}"},
+				X509SvidSubject:    &types.X509SVIDSubject{CommonName: "cn", Organization: []string{"org"}},
+				Hint:               "internal",
+				SelectorExpression: "!unix:uid:0",
 			},
 			expectEntry: &common.RegistrationEntry{
 				EntryId:  "entry1",
@@ -242,11 +264,18 @@ func TestProtoToRegistrationEntry(t *testing.T) {
 					"spiffe://domain1.com",
 					"spiffe://domain2.com",
 				},
-				Admin:          true,
-				EntryExpiry:    expiresAt,
-				DnsNames:       []string{"dns1", "dns2"},
-				Downstream:     true,
-				RevisionNumber: 99,
+				Admin:              true,
+				EntryExpiry:        expiresAt,
+				DnsNames:           []string{"dns1", "dns2"},
+				Downstream:         true,
+				RevisionNumber:     99,
+				JwtSvidClaims:      map[string]string{"role": "admin"},
+				JwtSvidAudience:    []string{"aud1", "aud2"},
+				X509SvidKeyType:    "rsa-2048",
+				DnsNameTemplates:   []string{"{{ .TrustDomain }}"},
+				X509SvidSubject:    &common.X509SVIDSubject{CommonName: "cn", Organization: []string{"org"}},
+				Hint:               "internal",
+				SelectorExpression: "!unix:uid:0",
 			},
 		},
 		{
@@ -293,6 +322,66 @@ func TestProtoToRegistrationEntry(t *testing.T) {
 				DnsNames:  []string{"abc-"},
 			},
 		},
+		{
+			name: "reserved JWT-SVID claim",
+			err:  `invalid JWT-SVID claims: claim "aud" is reserved`,
+			entry: &types.Entry{
+				SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors:     []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				JwtSvidClaims: map[string]string{"aud": "foo"},
+			},
+		},
+		{
+			name: "empty JWT-SVID audience",
+			err:  "invalid JWT-SVID audience: audience cannot be empty",
+			entry: &types.Entry{
+				SpiffeId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				ParentId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors:       []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				JwtSvidAudience: []string{""},
+			},
+		},
+		{
+			name: "unsupported X509-SVID key type",
+			err:  `invalid X509-SVID key type: unsupported key type "ed25519"`,
+			entry: &types.Entry{
+				SpiffeId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				ParentId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors:       []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				X509SvidKeyType: "ed25519",
+			},
+		},
+		{
+			name: "malformed selector expression",
+			err:  "invalid selector expression: unexpected end of expression",
+			entry: &types.Entry{
+				SpiffeId:           &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				ParentId:           &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors:          []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				SelectorExpression: "unix:gid:1000 ||",
+			},
+		},
+		{
+			name: "hint too long",
+			err:  "invalid hint: hint is longer than 1024 characters",
+			entry: &types.Entry{
+				SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				Hint:      strings.Repeat("a", 1025),
+			},
+		},
+		{
+			name: "malformed DNS name template",
+			err:  `invalid DNS name template: unable to parse DNS name template "{{ .Path "`,
+			entry: &types.Entry{
+				SpiffeId:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+				ParentId:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/bar"},
+				Selectors:        []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				DnsNameTemplates: []string{"{{ .Path "},
+			},
+		},
 		{
 			name: "malformed federated trust domain",
 			err:  `invalid federated trust domain: spiffeid: unable to parse: parse "spiffe://malformed td":`,
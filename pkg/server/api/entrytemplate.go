@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/entrydefaults"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/types"
+)
+
+// EntryTemplateToProto converts a datastore entry template into its API
+// representation.
+func EntryTemplateToProto(t *datastore.EntryTemplate) (*types.EntryTemplate, error) {
+	if t == nil {
+		return nil, errors.New("no entry template provided")
+	}
+
+	federatesWith := make([]string, 0, len(t.FederatesWith))
+	for _, trustDomainID := range t.FederatesWith {
+		td, err := spiffeid.TrustDomainFromString(trustDomainID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid federated trust domain: %v", err)
+		}
+		federatesWith = append(federatesWith, td.String())
+	}
+
+	return &types.EntryTemplate{
+		Id:               t.TemplateId,
+		SpiffeIdPath:     t.SpiffeIdPath,
+		Ttl:              t.Ttl,
+		FederatesWith:    federatesWith,
+		DnsNameTemplates: append([]string(nil), t.DnsNameTemplates...),
+	}, nil
+}
+
+// ProtoToEntryTemplate validates and converts an API entry template into its
+// datastore representation.
+func ProtoToEntryTemplate(t *types.EntryTemplate) (*datastore.EntryTemplate, error) {
+	return ProtoToEntryTemplateWithMask(t, nil)
+}
+
+// ProtoToEntryTemplateWithMask validates and converts an API entry template
+// into its datastore representation. Only the fields set in the mask are
+// validated and converted. A nil mask includes all fields.
+func ProtoToEntryTemplateWithMask(t *types.EntryTemplate, mask *types.EntryTemplateMask) (*datastore.EntryTemplate, error) {
+	if t == nil {
+		return nil, errors.New("no entry template provided")
+	}
+
+	if mask == nil {
+		mask = &types.EntryTemplateMask{
+			SpiffeIdPath:     true,
+			Ttl:              true,
+			FederatesWith:    true,
+			DnsNameTemplates: true,
+		}
+	}
+
+	if t.Id == "" {
+		return nil, errors.New("missing entry template ID")
+	}
+
+	dsTemplate := &datastore.EntryTemplate{
+		TemplateId: t.Id,
+	}
+
+	if mask.SpiffeIdPath {
+		if err := validateEntryTemplatePath(t.SpiffeIdPath); err != nil {
+			return nil, err
+		}
+		dsTemplate.SpiffeIdPath = t.SpiffeIdPath
+	}
+
+	if mask.Ttl {
+		if t.Ttl < 0 {
+			return nil, errors.New("invalid TTL: TTL cannot be negative")
+		}
+		dsTemplate.Ttl = t.Ttl
+	}
+
+	if mask.FederatesWith {
+		for _, trustDomainName := range t.FederatesWith {
+			td, err := spiffeid.TrustDomainFromString(trustDomainName)
+			if err != nil {
+				return nil, fmt.Errorf("invalid federated trust domain: %v", err)
+			}
+			dsTemplate.FederatesWith = append(dsTemplate.FederatesWith, td.IDString())
+		}
+	}
+
+	if mask.DnsNameTemplates {
+		if err := entrydefaults.ValidateDNSNameTemplates(t.DnsNameTemplates); err != nil {
+			return nil, fmt.Errorf("invalid DNS name template: %v", err)
+		}
+		dsTemplate.DnsNameTemplates = append([]string(nil), t.DnsNameTemplates...)
+	}
+
+	return dsTemplate, nil
+}
+
+// ProtoToEntryTemplateMask converts an API entry template mask into its
+// datastore representation.
+func ProtoToEntryTemplateMask(mask *types.EntryTemplateMask) *datastore.EntryTemplateMask {
+	if mask == nil {
+		return nil
+	}
+
+	return &datastore.EntryTemplateMask{
+		SpiffeIdPath:     mask.SpiffeIdPath,
+		Ttl:              mask.Ttl,
+		FederatesWith:    mask.FederatesWith,
+		DnsNameTemplates: mask.DnsNameTemplates,
+	}
+}
+
+func validateEntryTemplatePath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("invalid SPIFFE ID path %q: path must start with /", path)
+	}
+	for _, segment := range strings.Split(path[1:], "/") {
+		if segment == "" {
+			return fmt.Errorf("invalid SPIFFE ID path %q: path cannot contain empty segments", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package entrytemplate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegisterService registers the entry template service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	entrytemplate.RegisterEntryTemplateServer(s, service)
+}
+
+// Config is the service configuration
+type Config struct {
+	DataStore    datastore.DataStore
+	RoleBindings []middleware.RoleBinding
+}
+
+// New creates a new entry template service
+func New(config Config) *Service {
+	return &Service{
+		ds:           config.DataStore,
+		roleBindings: config.RoleBindings,
+	}
+}
+
+// Service implements the v1 entry template service
+type Service struct {
+	ds           datastore.DataStore
+	roleBindings []middleware.RoleBinding
+}
+
+func (s *Service) ListEntryTemplates(ctx context.Context, req *entrytemplate.ListEntryTemplatesRequest) (*entrytemplate.ListEntryTemplatesResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	listReq := &datastore.ListEntryTemplatesRequest{}
+
+	// Set pagination parameters
+	if req.PageSize > 0 {
+		listReq.Pagination = &datastore.Pagination{
+			PageSize: req.PageSize,
+			Token:    req.PageToken,
+		}
+	}
+
+	dsResp, err := s.ds.ListEntryTemplates(ctx, listReq)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to list entry templates", err)
+	}
+
+	resp := &entrytemplate.ListEntryTemplatesResponse{}
+
+	if dsResp.Pagination != nil {
+		resp.NextPageToken = dsResp.Pagination.Token
+	}
+
+	for _, dsTemplate := range dsResp.EntryTemplates {
+		template, err := api.EntryTemplateToProto(dsTemplate)
+		if err != nil {
+			return nil, api.MakeErr(log.WithField(telemetry.EntryTemplateID, dsTemplate.TemplateId), codes.Internal, "failed to convert entry template", err)
+		}
+		resp.EntryTemplates = append(resp.EntryTemplates, template)
+	}
+
+	return resp, nil
+}
+
+func (s *Service) GetEntryTemplate(ctx context.Context, req *entrytemplate.GetEntryTemplateRequest) (*types.EntryTemplate, error) {
+	log := rpccontext.Logger(ctx).WithField(telemetry.EntryTemplateID, req.Id)
+
+	if req.Id == "" {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "missing entry template ID", nil)
+	}
+
+	dsResp, err := s.ds.FetchEntryTemplate(ctx, &datastore.FetchEntryTemplateRequest{
+		TemplateId: req.Id,
+	})
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to fetch entry template", err)
+	}
+
+	if dsResp.EntryTemplate == nil {
+		return nil, api.MakeErr(log, codes.NotFound, "entry template not found", nil)
+	}
+
+	template, err := api.EntryTemplateToProto(dsResp.EntryTemplate)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to convert entry template", err)
+	}
+
+	return template, nil
+}
+
+func (s *Service) BatchCreateEntryTemplate(ctx context.Context, req *entrytemplate.BatchCreateEntryTemplateRequest) (*entrytemplate.BatchCreateEntryTemplateResponse, error) {
+	var results []*entrytemplate.BatchCreateEntryTemplateResponse_Result
+	for _, template := range req.EntryTemplates {
+		results = append(results, s.createEntryTemplate(ctx, template))
+	}
+
+	return &entrytemplate.BatchCreateEntryTemplateResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *Service) createEntryTemplate(ctx context.Context, template *types.EntryTemplate) *entrytemplate.BatchCreateEntryTemplateResponse_Result {
+	log := rpccontext.Logger(ctx).WithField(telemetry.EntryTemplateID, template.Id)
+
+	dsTemplate, err := api.ProtoToEntryTemplate(template)
+	if err != nil {
+		return &entrytemplate.BatchCreateEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "failed to convert entry template", err),
+		}
+	}
+
+	resp, err := s.ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: dsTemplate,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.AlreadyExists:
+		return &entrytemplate.BatchCreateEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.AlreadyExists, "entry template already exists", nil),
+		}
+	default:
+		return &entrytemplate.BatchCreateEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to create entry template", err),
+		}
+	}
+
+	protoTemplate, err := api.EntryTemplateToProto(resp.EntryTemplate)
+	if err != nil {
+		return &entrytemplate.BatchCreateEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to convert entry template", err),
+		}
+	}
+
+	log.Debug("Entry template created")
+	return &entrytemplate.BatchCreateEntryTemplateResponse_Result{
+		Status:        api.OK(),
+		EntryTemplate: protoTemplate,
+	}
+}
+
+func (s *Service) BatchUpdateEntryTemplate(ctx context.Context, req *entrytemplate.BatchUpdateEntryTemplateRequest) (*entrytemplate.BatchUpdateEntryTemplateResponse, error) {
+	var results []*entrytemplate.BatchUpdateEntryTemplateResponse_Result
+	for _, template := range req.EntryTemplates {
+		results = append(results, s.updateEntryTemplate(ctx, template, req.InputMask))
+	}
+
+	return &entrytemplate.BatchUpdateEntryTemplateResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *Service) updateEntryTemplate(ctx context.Context, template *types.EntryTemplate, inputMask *types.EntryTemplateMask) *entrytemplate.BatchUpdateEntryTemplateResponse_Result {
+	log := rpccontext.Logger(ctx).WithField(telemetry.EntryTemplateID, template.Id)
+
+	dsTemplate, err := api.ProtoToEntryTemplateWithMask(template, inputMask)
+	if err != nil {
+		return &entrytemplate.BatchUpdateEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "failed to convert entry template", err),
+		}
+	}
+
+	if st := s.checkPrivileges(ctx, log, dsTemplate.TemplateId); st != nil {
+		return &entrytemplate.BatchUpdateEntryTemplateResponse_Result{
+			Status: st,
+		}
+	}
+
+	resp, err := s.ds.UpdateEntryTemplate(ctx, &datastore.UpdateEntryTemplateRequest{
+		EntryTemplate: dsTemplate,
+		InputMask:     api.ProtoToEntryTemplateMask(inputMask),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		return &entrytemplate.BatchUpdateEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.NotFound, "entry template not found", err),
+		}
+	case codes.FailedPrecondition:
+		return &entrytemplate.BatchUpdateEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.FailedPrecondition, "failed to apply entry template to its entries", err),
+		}
+	default:
+		return &entrytemplate.BatchUpdateEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to update entry template", err),
+		}
+	}
+
+	protoTemplate, err := api.EntryTemplateToProto(resp.EntryTemplate)
+	if err != nil {
+		return &entrytemplate.BatchUpdateEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to convert entry template", err),
+		}
+	}
+
+	log.WithField(telemetry.Count, resp.EntriesUpdated).Debug("Entry template updated")
+	return &entrytemplate.BatchUpdateEntryTemplateResponse_Result{
+		Status:         api.OK(),
+		EntryTemplate:  protoTemplate,
+		EntriesUpdated: resp.EntriesUpdated,
+	}
+}
+
+func (s *Service) BatchDeleteEntryTemplate(ctx context.Context, req *entrytemplate.BatchDeleteEntryTemplateRequest) (*entrytemplate.BatchDeleteEntryTemplateResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	var results []*entrytemplate.BatchDeleteEntryTemplateResponse_Result
+	for _, id := range req.Ids {
+		results = append(results, s.deleteEntryTemplate(ctx, log, id))
+	}
+
+	return &entrytemplate.BatchDeleteEntryTemplateResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *Service) deleteEntryTemplate(ctx context.Context, log logrus.FieldLogger, id string) *entrytemplate.BatchDeleteEntryTemplateResponse_Result {
+	log = log.WithField(telemetry.EntryTemplateID, id)
+
+	if id == "" {
+		return &entrytemplate.BatchDeleteEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "missing entry template ID", nil),
+			Id:     id,
+		}
+	}
+
+	_, err := s.ds.DeleteEntryTemplate(ctx, &datastore.DeleteEntryTemplateRequest{
+		TemplateId: id,
+	})
+
+	code := status.Code(err)
+	switch code {
+	case codes.OK:
+		log.Debug("Entry template deleted")
+		return &entrytemplate.BatchDeleteEntryTemplateResponse_Result{
+			Status: api.OK(),
+			Id:     id,
+		}
+	case codes.NotFound:
+		return &entrytemplate.BatchDeleteEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.NotFound, "entry template not found", err),
+			Id:     id,
+		}
+	case codes.FailedPrecondition:
+		return &entrytemplate.BatchDeleteEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.FailedPrecondition, "entry template is in use", err),
+			Id:     id,
+		}
+	default:
+		return &entrytemplate.BatchDeleteEntryTemplateResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to delete entry template", err),
+			Id:     id,
+		}
+	}
+}
+
+// checkPrivileges makes sure that callers that are neither local nor admin,
+// i.e. callers bound to a role, cannot change entries that grant more
+// privileges than a role does through the template they use. New templates
+// are not used by any entry, and templates that are used cannot be deleted,
+// so only updates need to be checked.
+func (s *Service) checkPrivileges(ctx context.Context, log logrus.FieldLogger, templateID string) *types.Status {
+	if rpccontext.CallerIsLocal(ctx) || rpccontext.CallerIsAdmin(ctx) {
+		return nil
+	}
+
+	resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	if err != nil {
+		return api.MakeStatus(log, codes.Internal, "failed to list entries", err)
+	}
+	for _, entry := range resp.Entries {
+		if entry.TemplateId != templateID {
+			continue
+		}
+		if err := middleware.CheckEntryPrivileges(ctx, s.roleBindings, entry, resp.Entries); err != nil {
+			return api.MakeStatus(log, codes.PermissionDenied, "entry template rejected", fmt.Errorf("entry %q: %w", entry.EntryId, err))
+		}
+	}
+	return nil
+}
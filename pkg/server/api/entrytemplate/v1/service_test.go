@@ -0,0 +1,683 @@
+package entrytemplate_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/entrytemplate/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	entrytemplatepb "github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ctx = context.Background()
+
+	webTemplate = &types.EntryTemplate{
+		Id:           "web",
+		SpiffeIdPath: "/ns/web",
+		Ttl:          60,
+	}
+	dbTemplate = &types.EntryTemplate{
+		Id:               "db",
+		Ttl:              120,
+		DnsNameTemplates: []string{"{{ index .PathSegments 0 }}.{{ .TrustDomain }}"},
+	}
+)
+
+func TestListEntryTemplates(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createEntryTemplate(t, webTemplate)
+	test.createEntryTemplate(t, dbTemplate)
+
+	for _, tt := range []struct {
+		name            string
+		pageSize        int32
+		expectTemplates []*types.EntryTemplate
+		expectToken     bool
+		dsErr           error
+		expectCode      codes.Code
+		expectMsg       string
+		expectLogs      []spiretest.LogEntry
+	}{
+		{
+			name:            "success",
+			expectTemplates: []*types.EntryTemplate{dbTemplate, webTemplate},
+		},
+		{
+			name:            "success with pagination",
+			pageSize:        1,
+			expectTemplates: []*types.EntryTemplate{webTemplate},
+			expectToken:     true,
+		},
+		{
+			name:       "datastore failure",
+			dsErr:      errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to list entry templates: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to list entry templates",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.logHook.Reset()
+			test.ds.SetNextError(tt.dsErr)
+
+			resp, err := test.client.ListEntryTemplates(ctx, &entrytemplatepb.ListEntryTemplatesRequest{
+				PageSize: tt.pageSize,
+			})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectTemplates, resp.EntryTemplates)
+			require.Equal(t, tt.expectToken, resp.NextPageToken != "")
+		})
+	}
+}
+
+func TestGetEntryTemplate(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createEntryTemplate(t, webTemplate)
+
+	for _, tt := range []struct {
+		name           string
+		id             string
+		dsErr          error
+		expectTemplate *types.EntryTemplate
+		expectCode     codes.Code
+		expectMsg      string
+		expectLogs     []spiretest.LogEntry
+	}{
+		{
+			name:           "success",
+			id:             "web",
+			expectTemplate: webTemplate,
+		},
+		{
+			name:       "missing ID",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "missing entry template ID",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: missing entry template ID",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "",
+					},
+				},
+			},
+		},
+		{
+			name:       "not found",
+			id:         "db",
+			expectCode: codes.NotFound,
+			expectMsg:  "entry template not found",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Entry template not found",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "db",
+					},
+				},
+			},
+		},
+		{
+			name:       "datastore failure",
+			id:         "web",
+			dsErr:      errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to fetch entry template: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to fetch entry template",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "web",
+						logrus.ErrorKey:           "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.logHook.Reset()
+			test.ds.SetNextError(tt.dsErr)
+
+			template, err := test.client.GetEntryTemplate(ctx, &entrytemplatepb.GetEntryTemplateRequest{
+				Id: tt.id,
+			})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, template)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, tt.expectTemplate, template)
+		})
+	}
+}
+
+func TestBatchCreateEntryTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		templates     []*types.EntryTemplate
+		dsErr         error
+		expectResults []*entrytemplatepb.BatchCreateEntryTemplateResponse_Result
+		expectLogs    []spiretest.LogEntry
+	}{
+		{
+			name:      "success",
+			templates: []*types.EntryTemplate{dbTemplate},
+			expectResults: []*entrytemplatepb.BatchCreateEntryTemplateResponse_Result{
+				{Status: api.OK(), EntryTemplate: dbTemplate},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Entry template created",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "db",
+					},
+				},
+			},
+		},
+		{
+			name:      "already exists",
+			templates: []*types.EntryTemplate{webTemplate},
+			expectResults: []*entrytemplatepb.BatchCreateEntryTemplateResponse_Result{
+				{Status: api.CreateStatus(codes.AlreadyExists, "entry template already exists")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Entry template already exists",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "web",
+					},
+				},
+			},
+		},
+		{
+			name:      "invalid template",
+			templates: []*types.EntryTemplate{{Id: "bad", Ttl: -1}},
+			expectResults: []*entrytemplatepb.BatchCreateEntryTemplateResponse_Result{
+				{Status: api.CreateStatus(codes.InvalidArgument, "failed to convert entry template: invalid TTL: TTL cannot be negative")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: failed to convert entry template",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "bad",
+						logrus.ErrorKey:           "invalid TTL: TTL cannot be negative",
+					},
+				},
+			},
+		},
+		{
+			name:      "datastore failure",
+			templates: []*types.EntryTemplate{dbTemplate},
+			dsErr:     errors.New("oh no"),
+			expectResults: []*entrytemplatepb.BatchCreateEntryTemplateResponse_Result{
+				{Status: api.CreateStatus(codes.Internal, "failed to create entry template: oh no")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to create entry template",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "db",
+						logrus.ErrorKey:           "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.createEntryTemplate(t, webTemplate)
+			test.ds.SetNextError(tt.dsErr)
+
+			resp, err := test.client.BatchCreateEntryTemplate(ctx, &entrytemplatepb.BatchCreateEntryTemplateRequest{
+				EntryTemplates: tt.templates,
+			})
+			require.NoError(t, err)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			spiretest.RequireProtoEqual(t, &entrytemplatepb.BatchCreateEntryTemplateResponse{
+				Results: tt.expectResults,
+			}, resp)
+		})
+	}
+}
+
+func TestBatchUpdateEntryTemplate(t *testing.T) {
+	updatedWeb := &types.EntryTemplate{
+		Id:           "web",
+		SpiffeIdPath: "/ns/web",
+		Ttl:          300,
+	}
+
+	for _, tt := range []struct {
+		name          string
+		templates     []*types.EntryTemplate
+		inputMask     *types.EntryTemplateMask
+		dsErr         error
+		expectResults []*entrytemplatepb.BatchUpdateEntryTemplateResponse_Result
+		expectLogs    []spiretest.LogEntry
+		expectEntry   *common.RegistrationEntry
+	}{
+		{
+			name:      "success",
+			templates: []*types.EntryTemplate{{Id: "web", Ttl: 300}},
+			inputMask: &types.EntryTemplateMask{Ttl: true},
+			expectResults: []*entrytemplatepb.BatchUpdateEntryTemplateResponse_Result{
+				{Status: api.OK(), EntryTemplate: updatedWeb, EntriesUpdated: 1},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Entry template updated",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "web",
+						telemetry.Count:           "1",
+					},
+				},
+			},
+			expectEntry: &common.RegistrationEntry{Ttl: 300},
+		},
+		{
+			name:      "not found",
+			templates: []*types.EntryTemplate{{Id: "db", Ttl: 300}},
+			inputMask: &types.EntryTemplateMask{Ttl: true},
+			expectResults: []*entrytemplatepb.BatchUpdateEntryTemplateResponse_Result{
+				{Status: api.CreateStatus(codes.NotFound, "entry template not found")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Entry template not found",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "db",
+					},
+				},
+			},
+			expectEntry: &common.RegistrationEntry{Ttl: 60},
+		},
+		{
+			name:      "path no longer matches entries",
+			templates: []*types.EntryTemplate{{Id: "web", SpiffeIdPath: "/ns/api"}},
+			inputMask: &types.EntryTemplateMask{SpiffeIdPath: true},
+			expectResults: []*entrytemplatepb.BatchUpdateEntryTemplateResponse_Result{
+				{Status: api.CreateStatus(codes.FailedPrecondition, `failed to apply entry template to its entries: datastore-sql: SPIFFE ID "spiffe://example.org/ns/web" of entry does not match path "/ns/api" of entry template "web"`)},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to apply entry template to its entries",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "web",
+						logrus.ErrorKey:           `rpc error: code = FailedPrecondition desc = datastore-sql: SPIFFE ID "spiffe://example.org/ns/web" of entry does not match path "/ns/api" of entry template "web"`,
+					},
+				},
+			},
+			expectEntry: &common.RegistrationEntry{Ttl: 60},
+		},
+		{
+			name:      "datastore failure",
+			templates: []*types.EntryTemplate{{Id: "web", Ttl: 300}},
+			inputMask: &types.EntryTemplateMask{Ttl: true},
+			dsErr:     errors.New("oh no"),
+			expectResults: []*entrytemplatepb.BatchUpdateEntryTemplateResponse_Result{
+				{Status: api.CreateStatus(codes.Internal, "failed to update entry template: oh no")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to update entry template",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "web",
+						logrus.ErrorKey:           "oh no",
+					},
+				},
+			},
+			expectEntry: &common.RegistrationEntry{Ttl: 60},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.createEntryTemplate(t, webTemplate)
+			entry := test.createTemplatedEntry(t, "web", "spiffe://example.org/ns/web")
+			test.ds.SetNextError(tt.dsErr)
+
+			resp, err := test.client.BatchUpdateEntryTemplate(ctx, &entrytemplatepb.BatchUpdateEntryTemplateRequest{
+				EntryTemplates: tt.templates,
+				InputMask:      tt.inputMask,
+			})
+			require.NoError(t, err)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			spiretest.RequireProtoEqual(t, &entrytemplatepb.BatchUpdateEntryTemplateResponse{
+				Results: tt.expectResults,
+			}, resp)
+
+			fetchResp, err := test.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+				EntryId: entry.EntryId,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectEntry.Ttl, fetchResp.Entry.Ttl)
+		})
+	}
+}
+
+func TestBatchUpdateEntryTemplateRoleCaller(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		entry *common.RegistrationEntry
+		// expectErr is formatted with the ID of the entry, empty on success
+		expectErr string
+	}{
+		{
+			name: "entry without privileges",
+			entry: &common.RegistrationEntry{
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+		},
+		{
+			name: "admin entry",
+			entry: &common.RegistrationEntry{
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				Admin:     true,
+			},
+			expectErr: "entry template rejected: entry %q: only local or admin callers can manage admin entries",
+		},
+		{
+			name: "downstream entry",
+			entry: &common.RegistrationEntry{
+				Selectors:  []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				Downstream: true,
+			},
+			expectErr: "entry template rejected: entry %q: only local or admin callers can manage downstream entries",
+		},
+		{
+			name: "entry bound to a role",
+			entry: &common.RegistrationEntry{
+				Selectors: []*common.Selector{{Type: "k8s", Value: "sa:agent-admin"}},
+			},
+			expectErr: "entry template rejected: entry %q: only local or admin callers can manage entries bound to the \"agent-admin\" role",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+			test.roleCaller = true
+			test.createEntryTemplate(t, webTemplate)
+
+			tt.entry.ParentId = "spiffe://example.org/agent"
+			tt.entry.SpiffeId = "spiffe://example.org/ns/web"
+			tt.entry.TemplateId = "web"
+			entryResp, err := test.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+				Entry: tt.entry,
+			})
+			require.NoError(t, err)
+
+			resp, err := test.client.BatchUpdateEntryTemplate(ctx, &entrytemplatepb.BatchUpdateEntryTemplateRequest{
+				EntryTemplates: []*types.EntryTemplate{{Id: "web", Ttl: 300}},
+				InputMask:      &types.EntryTemplateMask{Ttl: true},
+			})
+			require.NoError(t, err)
+			require.Len(t, resp.Results, 1)
+			expectStatus := api.OK()
+			if tt.expectErr != "" {
+				expectStatus = api.CreateStatus(codes.PermissionDenied, fmt.Sprintf(tt.expectErr, entryResp.Entry.EntryId))
+			}
+			spiretest.AssertProtoEqual(t, expectStatus, resp.Results[0].Status)
+		})
+	}
+}
+
+func TestBatchDeleteEntryTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		ids           []string
+		dsErr         error
+		expectResults []*entrytemplatepb.BatchDeleteEntryTemplateResponse_Result
+		expectLogs    []spiretest.LogEntry
+		expectRemain  []*types.EntryTemplate
+	}{
+		{
+			name: "success",
+			ids:  []string{"db"},
+			expectResults: []*entrytemplatepb.BatchDeleteEntryTemplateResponse_Result{
+				{Status: api.OK(), Id: "db"},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Entry template deleted",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "db",
+					},
+				},
+			},
+			expectRemain: []*types.EntryTemplate{webTemplate},
+		},
+		{
+			name: "missing ID",
+			ids:  []string{""},
+			expectResults: []*entrytemplatepb.BatchDeleteEntryTemplateResponse_Result{
+				{Status: api.CreateStatus(codes.InvalidArgument, "missing entry template ID")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: missing entry template ID",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "",
+					},
+				},
+			},
+			expectRemain: []*types.EntryTemplate{dbTemplate, webTemplate},
+		},
+		{
+			name: "not found",
+			ids:  []string{"api"},
+			expectResults: []*entrytemplatepb.BatchDeleteEntryTemplateResponse_Result{
+				{
+					Status: api.CreateStatus(codes.NotFound, "entry template not found"),
+					Id:     "api",
+				},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Entry template not found",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "api",
+					},
+				},
+			},
+			expectRemain: []*types.EntryTemplate{dbTemplate, webTemplate},
+		},
+		{
+			name: "in use",
+			ids:  []string{"web"},
+			expectResults: []*entrytemplatepb.BatchDeleteEntryTemplateResponse_Result{
+				{
+					Status: api.CreateStatus(codes.FailedPrecondition, `entry template is in use: datastore-sql: entry template "web" is referenced by 1 registration entries`),
+					Id:     "web",
+				},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Entry template is in use",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "web",
+						logrus.ErrorKey:           `rpc error: code = FailedPrecondition desc = datastore-sql: entry template "web" is referenced by 1 registration entries`,
+					},
+				},
+			},
+			expectRemain: []*types.EntryTemplate{dbTemplate, webTemplate},
+		},
+		{
+			name:  "datastore failure",
+			ids:   []string{"db"},
+			dsErr: status.Error(codes.Internal, "oh no"),
+			expectResults: []*entrytemplatepb.BatchDeleteEntryTemplateResponse_Result{
+				{
+					Status: api.CreateStatus(codes.Internal, "failed to delete entry template: oh no"),
+					Id:     "db",
+				},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to delete entry template",
+					Data: logrus.Fields{
+						telemetry.EntryTemplateID: "db",
+						logrus.ErrorKey:           "rpc error: code = Internal desc = oh no",
+					},
+				},
+			},
+			expectRemain: []*types.EntryTemplate{dbTemplate, webTemplate},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.createEntryTemplate(t, webTemplate)
+			test.createEntryTemplate(t, dbTemplate)
+			test.createTemplatedEntry(t, "web", "spiffe://example.org/ns/web")
+			test.ds.SetNextError(tt.dsErr)
+
+			resp, err := test.client.BatchDeleteEntryTemplate(ctx, &entrytemplatepb.BatchDeleteEntryTemplateRequest{
+				Ids: tt.ids,
+			})
+			require.NoError(t, err)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			spiretest.RequireProtoEqual(t, &entrytemplatepb.BatchDeleteEntryTemplateResponse{
+				Results: tt.expectResults,
+			}, resp)
+
+			listResp, err := test.client.ListEntryTemplates(ctx, &entrytemplatepb.ListEntryTemplatesRequest{})
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectRemain, listResp.EntryTemplates)
+		})
+	}
+}
+
+type serviceTest struct {
+	client  entrytemplatepb.EntryTemplateClient
+	ds      *fakedatastore.DataStore
+	logHook *test.Hook
+	done    func()
+	// roleCaller makes the caller neither local nor admin, as callers that
+	// are authorized through a role binding
+	roleCaller bool
+}
+
+func (c *serviceTest) Cleanup() {
+	c.done()
+}
+
+func (c *serviceTest) createEntryTemplate(t *testing.T, template *types.EntryTemplate) {
+	dsTemplate, err := api.ProtoToEntryTemplate(template)
+	require.NoError(t, err)
+
+	_, err = c.ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: dsTemplate,
+	})
+	require.NoError(t, err)
+}
+
+func (c *serviceTest) createTemplatedEntry(t *testing.T, templateID, spiffeID string) *common.RegistrationEntry {
+	resp, err := c.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:   "spiffe://example.org/agent",
+			SpiffeId:   spiffeID,
+			Selectors:  []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			TemplateId: templateID,
+		},
+	})
+	require.NoError(t, err)
+	return resp.Entry
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ds := fakedatastore.New(t)
+	service := entrytemplate.New(entrytemplate.Config{
+		DataStore: ds,
+		RoleBindings: []middleware.RoleBinding{
+			{
+				Role:      middleware.RoleAgentAdmin,
+				Selectors: []*types.Selector{{Type: "k8s", Value: "sa:agent-admin"}},
+			},
+		},
+	})
+
+	log, logHook := test.NewNullLogger()
+	log.Level = logrus.DebugLevel
+	registerFn := func(s *grpc.Server) {
+		entrytemplate.RegisterService(s, service)
+	}
+
+	test := &serviceTest{
+		ds:      ds,
+		logHook: logHook,
+	}
+
+	contextFn := func(ctx context.Context) context.Context {
+		ctx = rpccontext.WithLogger(ctx, log)
+		if !test.roleCaller {
+			ctx = rpccontext.WithLocalCaller(ctx)
+		}
+		return ctx
+	}
+
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+	test.client = entrytemplatepb.NewEntryTemplateClient(conn)
+	test.done = done
+	return test
+}
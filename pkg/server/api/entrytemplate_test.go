@@ -0,0 +1,165 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryTemplateToProto(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		template       *datastore.EntryTemplate
+		expectTemplate *types.EntryTemplate
+		expectErr      string
+	}{
+		{
+			name: "success",
+			template: &datastore.EntryTemplate{
+				TemplateId:       "web",
+				SpiffeIdPath:     "/ns/web",
+				Ttl:              60,
+				FederatesWith:    []string{"spiffe://domain1.org"},
+				DnsNameTemplates: []string{"{{ index .PathSegments 1 }}.{{ .TrustDomain }}"},
+			},
+			expectTemplate: &types.EntryTemplate{
+				Id:               "web",
+				SpiffeIdPath:     "/ns/web",
+				Ttl:              60,
+				FederatesWith:    []string{"domain1.org"},
+				DnsNameTemplates: []string{"{{ index .PathSegments 1 }}.{{ .TrustDomain }}"},
+			},
+		},
+		{
+			name:      "no entry template",
+			expectErr: "no entry template provided",
+		},
+		{
+			name: "malformed federated trust domain",
+			template: &datastore.EntryTemplate{
+				TemplateId:    "web",
+				FederatesWith: []string{"spiffe://invalid TD"},
+			},
+			expectErr: `invalid federated trust domain: spiffeid: unable to parse: parse "spiffe://invalid TD": invalid character " " in host name`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := api.EntryTemplateToProto(tt.template)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				require.Nil(t, template)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, tt.expectTemplate, template)
+		})
+	}
+}
+
+func TestProtoToEntryTemplateWithMask(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		template       *types.EntryTemplate
+		mask           *types.EntryTemplateMask
+		expectTemplate *datastore.EntryTemplate
+		expectErr      string
+	}{
+		{
+			name: "success",
+			template: &types.EntryTemplate{
+				Id:               "web",
+				SpiffeIdPath:     "/ns/web",
+				Ttl:              60,
+				FederatesWith:    []string{"domain1.org"},
+				DnsNameTemplates: []string{"{{ index .PathSegments 1 }}.{{ .TrustDomain }}"},
+			},
+			expectTemplate: &datastore.EntryTemplate{
+				TemplateId:       "web",
+				SpiffeIdPath:     "/ns/web",
+				Ttl:              60,
+				FederatesWith:    []string{"spiffe://domain1.org"},
+				DnsNameTemplates: []string{"{{ index .PathSegments 1 }}.{{ .TrustDomain }}"},
+			},
+		},
+		{
+			name: "only masked fields are converted",
+			template: &types.EntryTemplate{
+				Id:           "web",
+				SpiffeIdPath: "invalid",
+				Ttl:          60,
+			},
+			mask: &types.EntryTemplateMask{Ttl: true},
+			expectTemplate: &datastore.EntryTemplate{
+				TemplateId: "web",
+				Ttl:        60,
+			},
+		},
+		{
+			name:      "no entry template",
+			expectErr: "no entry template provided",
+		},
+		{
+			name:      "missing ID",
+			template:  &types.EntryTemplate{},
+			expectErr: "missing entry template ID",
+		},
+		{
+			name: "relative path",
+			template: &types.EntryTemplate{
+				Id:           "web",
+				SpiffeIdPath: "ns/web",
+			},
+			expectErr: `invalid SPIFFE ID path "ns/web": path must start with /`,
+		},
+		{
+			name: "empty path segment",
+			template: &types.EntryTemplate{
+				Id:           "web",
+				SpiffeIdPath: "/ns//web",
+			},
+			expectErr: `invalid SPIFFE ID path "/ns//web": path cannot contain empty segments`,
+		},
+		{
+			name: "negative TTL",
+			template: &types.EntryTemplate{
+				Id:  "web",
+				Ttl: -1,
+			},
+			expectErr: "invalid TTL: TTL cannot be negative",
+		},
+		{
+			name: "malformed federated trust domain",
+			template: &types.EntryTemplate{
+				Id:            "web",
+				FederatesWith: []string{"invalid TD"},
+			},
+			expectErr: `invalid federated trust domain: spiffeid: unable to parse: parse "spiffe://invalid TD": invalid character " " in host name`,
+		},
+		{
+			name: "malformed DNS name template",
+			template: &types.EntryTemplate{
+				Id:               "web",
+				DnsNameTemplates: []string{"{{ .Path "},
+			},
+			expectErr: "invalid DNS name template: unable to parse DNS name template",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := api.ProtoToEntryTemplateWithMask(tt.template, tt.mask)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectErr)
+				require.Nil(t, template)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectTemplate, template)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/types"
+)
+
+const (
+	// BundleEndpointProfileHTTPSWeb is the datastore name of the https_web
+	// bundle endpoint profile.
+	BundleEndpointProfileHTTPSWeb = "https_web"
+
+	// BundleEndpointProfileHTTPSSPIFFE is the datastore name of the
+	// https_spiffe bundle endpoint profile.
+	BundleEndpointProfileHTTPSSPIFFE = "https_spiffe"
+)
+
+// FederationRelationshipToProto converts a datastore federation relationship
+// into its API representation.
+func FederationRelationshipToProto(fr *datastore.FederationRelationship) (*types.FederationRelationship, error) {
+	if fr == nil {
+		return nil, errors.New("no federation relationship provided")
+	}
+
+	td, err := spiffeid.TrustDomainFromString(fr.TrustDomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	protoFR := &types.FederationRelationship{
+		TrustDomain:       td.String(),
+		BundleEndpointUrl: fr.BundleEndpointUrl,
+	}
+
+	switch fr.BundleEndpointProfile {
+	case BundleEndpointProfileHTTPSWeb:
+		protoFR.BundleEndpointProfile = &types.FederationRelationship_HttpsWeb{
+			HttpsWeb: &types.HTTPSWebProfile{},
+		}
+	case BundleEndpointProfileHTTPSSPIFFE:
+		protoFR.BundleEndpointProfile = &types.FederationRelationship_HttpsSpiffe{
+			HttpsSpiffe: &types.HTTPSSPIFFEProfile{
+				EndpointSpiffeId: fr.EndpointSpiffeId,
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unknown bundle endpoint profile %q", fr.BundleEndpointProfile)
+	}
+
+	return protoFR, nil
+}
+
+// ProtoToFederationRelationship validates and converts an API federation
+// relationship into its datastore representation.
+func ProtoToFederationRelationship(fr *types.FederationRelationship) (*datastore.FederationRelationship, error) {
+	return ProtoToFederationRelationshipWithMask(fr, nil)
+}
+
+// ProtoToFederationRelationshipWithMask validates and converts an API
+// federation relationship into its datastore representation. Only the fields
+// set in the mask are validated and converted. A nil mask includes all fields.
+func ProtoToFederationRelationshipWithMask(fr *types.FederationRelationship, mask *types.FederationRelationshipMask) (*datastore.FederationRelationship, error) {
+	if fr == nil {
+		return nil, errors.New("no federation relationship provided")
+	}
+
+	if mask == nil {
+		mask = &types.FederationRelationshipMask{
+			BundleEndpointUrl:     true,
+			BundleEndpointProfile: true,
+		}
+	}
+
+	td, err := spiffeid.TrustDomainFromString(fr.TrustDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	dsFR := &datastore.FederationRelationship{
+		TrustDomainId: td.IDString(),
+	}
+
+	if mask.BundleEndpointUrl {
+		if err := validateBundleEndpointURL(fr.BundleEndpointUrl); err != nil {
+			return nil, err
+		}
+		dsFR.BundleEndpointUrl = fr.BundleEndpointUrl
+	}
+
+	if mask.BundleEndpointProfile {
+		switch profile := fr.BundleEndpointProfile.(type) {
+		case *types.FederationRelationship_HttpsWeb:
+			dsFR.BundleEndpointProfile = BundleEndpointProfileHTTPSWeb
+		case *types.FederationRelationship_HttpsSpiffe:
+			if profile.HttpsSpiffe == nil {
+				return nil, errors.New("missing https_spiffe profile")
+			}
+			endpointID, err := spiffeid.FromString(profile.HttpsSpiffe.EndpointSpiffeId)
+			if err != nil {
+				return nil, fmt.Errorf("endpoint SPIFFE ID is malformed: %v", err)
+			}
+			dsFR.BundleEndpointProfile = BundleEndpointProfileHTTPSSPIFFE
+			dsFR.EndpointSpiffeId = endpointID.String()
+		default:
+			return nil, errors.New("missing bundle endpoint profile")
+		}
+	}
+
+	return dsFR, nil
+}
+
+// ProtoToFederationRelationshipMask converts an API federation relationship
+// mask into its datastore representation.
+func ProtoToFederationRelationshipMask(mask *types.FederationRelationshipMask) *datastore.FederationRelationshipMask {
+	if mask == nil {
+		return nil
+	}
+
+	return &datastore.FederationRelationshipMask{
+		BundleEndpointUrl:     mask.BundleEndpointUrl,
+		BundleEndpointProfile: mask.BundleEndpointProfile,
+	}
+}
+
+func validateBundleEndpointURL(rawURL string) error {
+	if rawURL == "" {
+		return errors.New("missing bundle endpoint URL")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("bundle endpoint URL is malformed: %v", err)
+	}
+	if u.Scheme != "https" {
+		return errors.New("bundle endpoint URL must use the https scheme")
+	}
+	if u.Host == "" {
+		return errors.New("bundle endpoint URL must specify the host")
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederationRelationshipToProto(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		fr        *datastore.FederationRelationship
+		expectFR  *types.FederationRelationship
+		expectErr string
+	}{
+		{
+			name: "https_web",
+			fr: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "https://domain.test/bundle",
+				BundleEndpointProfile: "https_web",
+			},
+			expectFR: &types.FederationRelationship{
+				TrustDomain:           "domain.test",
+				BundleEndpointUrl:     "https://domain.test/bundle",
+				BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+			},
+		},
+		{
+			name: "https_spiffe",
+			fr: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "https://domain.test/bundle",
+				BundleEndpointProfile: "https_spiffe",
+				EndpointSpiffeId:      "spiffe://domain.test/spire/server",
+			},
+			expectFR: &types.FederationRelationship{
+				TrustDomain:       "domain.test",
+				BundleEndpointUrl: "https://domain.test/bundle",
+				BundleEndpointProfile: &types.FederationRelationship_HttpsSpiffe{
+					HttpsSpiffe: &types.HTTPSSPIFFEProfile{EndpointSpiffeId: "spiffe://domain.test/spire/server"},
+				},
+			},
+		},
+		{
+			name:      "no federation relationship",
+			expectErr: "no federation relationship provided",
+		},
+		{
+			name: "malformed trust domain",
+			fr: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://invalid TD",
+				BundleEndpointProfile: "https_web",
+			},
+			expectErr: `spiffeid: unable to parse: parse "spiffe://invalid TD": invalid character " " in host name`,
+		},
+		{
+			name: "unknown profile",
+			fr: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointProfile: "http",
+			},
+			expectErr: `unknown bundle endpoint profile "http"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fr, err := api.FederationRelationshipToProto(tt.fr)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				require.Nil(t, fr)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, tt.expectFR, fr)
+		})
+	}
+}
+
+func TestProtoToFederationRelationshipWithMask(t *testing.T) {
+	spiffeProfile := &types.FederationRelationship_HttpsSpiffe{
+		HttpsSpiffe: &types.HTTPSSPIFFEProfile{EndpointSpiffeId: "spiffe://domain.test/spire/server"},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		fr        *types.FederationRelationship
+		mask      *types.FederationRelationshipMask
+		expectFR  *datastore.FederationRelationship
+		expectErr string
+	}{
+		{
+			name: "https_web",
+			fr: &types.FederationRelationship{
+				TrustDomain:           "domain.test",
+				BundleEndpointUrl:     "https://domain.test/bundle",
+				BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+			},
+			expectFR: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "https://domain.test/bundle",
+				BundleEndpointProfile: "https_web",
+			},
+		},
+		{
+			name: "https_spiffe",
+			fr: &types.FederationRelationship{
+				TrustDomain:           "domain.test",
+				BundleEndpointUrl:     "https://domain.test/bundle",
+				BundleEndpointProfile: spiffeProfile,
+			},
+			expectFR: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "https://domain.test/bundle",
+				BundleEndpointProfile: "https_spiffe",
+				EndpointSpiffeId:      "spiffe://domain.test/spire/server",
+			},
+		},
+		{
+			name: "only the URL in the mask",
+			fr: &types.FederationRelationship{
+				TrustDomain:       "domain.test",
+				BundleEndpointUrl: "https://domain.test/bundle",
+			},
+			mask: &types.FederationRelationshipMask{BundleEndpointUrl: true},
+			expectFR: &datastore.FederationRelationship{
+				TrustDomainId:     "spiffe://domain.test",
+				BundleEndpointUrl: "https://domain.test/bundle",
+			},
+		},
+		{
+			name: "only the profile in the mask",
+			fr: &types.FederationRelationship{
+				TrustDomain:           "domain.test",
+				BundleEndpointProfile: spiffeProfile,
+			},
+			mask: &types.FederationRelationshipMask{BundleEndpointProfile: true},
+			expectFR: &datastore.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointProfile: "https_spiffe",
+				EndpointSpiffeId:      "spiffe://domain.test/spire/server",
+			},
+		},
+		{
+			name:      "no federation relationship",
+			expectErr: "no federation relationship provided",
+		},
+		{
+			name: "malformed trust domain",
+			fr: &types.FederationRelationship{
+				TrustDomain: "invalid TD",
+			},
+			expectErr: `spiffeid: unable to parse: parse "spiffe://invalid TD": invalid character " " in host name`,
+		},
+		{
+			name: "missing URL",
+			fr: &types.FederationRelationship{
+				TrustDomain:           "domain.test",
+				BundleEndpointProfile: spiffeProfile,
+			},
+			expectErr: "missing bundle endpoint URL",
+		},
+		{
+			name: "URL without https",
+			fr: &types.FederationRelationship{
+				TrustDomain:           "domain.test",
+				BundleEndpointUrl:     "http://domain.test/bundle",
+				BundleEndpointProfile: spiffeProfile,
+			},
+			expectErr: "bundle endpoint URL must use the https scheme",
+		},
+		{
+			name: "URL without host",
+			fr: &types.FederationRelationship{
+				TrustDomain:           "domain.test",
+				BundleEndpointUrl:     "https:///bundle",
+				BundleEndpointProfile: spiffeProfile,
+			},
+			expectErr: "bundle endpoint URL must specify the host",
+		},
+		{
+			name: "missing profile",
+			fr: &types.FederationRelationship{
+				TrustDomain:       "domain.test",
+				BundleEndpointUrl: "https://domain.test/bundle",
+			},
+			expectErr: "missing bundle endpoint profile",
+		},
+		{
+			name: "malformed endpoint SPIFFE ID",
+			fr: &types.FederationRelationship{
+				TrustDomain:       "domain.test",
+				BundleEndpointUrl: "https://domain.test/bundle",
+				BundleEndpointProfile: &types.FederationRelationship_HttpsSpiffe{
+					HttpsSpiffe: &types.HTTPSSPIFFEProfile{EndpointSpiffeId: "domain.test"},
+				},
+			},
+			expectErr: "endpoint SPIFFE ID is malformed: spiffeid: invalid scheme",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fr, err := api.ProtoToFederationRelationshipWithMask(tt.fr, tt.mask)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				require.Nil(t, fr)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, tt.expectFR, fr)
+		})
+	}
+}
+
+func TestProtoToFederationRelationshipMask(t *testing.T) {
+	require.Nil(t, api.ProtoToFederationRelationshipMask(nil))
+	spiretest.RequireProtoEqual(t, &datastore.FederationRelationshipMask{
+		BundleEndpointUrl:     true,
+		BundleEndpointProfile: true,
+	}, api.ProtoToFederationRelationshipMask(&types.FederationRelationshipMask{
+		BundleEndpointUrl:     true,
+		BundleEndpointProfile: true,
+	}))
+}
@@ -0,0 +1,200 @@
+package localauthority
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorityManager prepares and activates the next X509 CA and JWT key of
+// the server, and taints and revokes old ones.
+type AuthorityManager interface {
+	PrepareNext(ctx context.Context) (*ca.X509CA, *ca.JWTKey, error)
+	ActivateNext(ctx context.Context) (*ca.X509CA, *ca.JWTKey, error)
+	TaintX509CA(ctx context.Context, authorityID string) (*x509.Certificate, error)
+	RevokeX509CA(ctx context.Context, authorityID string) (*x509.Certificate, error)
+	TaintJWTKey(ctx context.Context, kid string) (*common.PublicKey, error)
+	RevokeJWTKey(ctx context.Context, kid string) (*common.PublicKey, error)
+}
+
+// RegisterService registers the local authority service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	localauthority.RegisterLocalAuthorityServer(s, service)
+}
+
+// Config is the service configuration
+type Config struct {
+	Manager AuthorityManager
+}
+
+// New creates a new local authority service
+func New(config Config) *Service {
+	return &Service{
+		m: config.Manager,
+	}
+}
+
+// Service implements the v1 local authority service
+type Service struct {
+	m AuthorityManager
+}
+
+func (s *Service) PrepareNextAuthority(ctx context.Context, req *localauthority.PrepareNextAuthorityRequest) (*localauthority.PrepareNextAuthorityResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	x509CA, jwtKey, err := s.m.PrepareNext(ctx)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to prepare next authority", err)
+	}
+
+	return &localauthority.PrepareNextAuthorityResponse{
+		X509Authority: x509AuthorityState(x509CA),
+		JwtAuthority:  jwtAuthorityState(jwtKey),
+	}, nil
+}
+
+func (s *Service) RotateAuthority(ctx context.Context, req *localauthority.RotateAuthorityRequest) (*localauthority.RotateAuthorityResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	x509CA, jwtKey, err := s.m.ActivateNext(ctx)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to rotate authority", err)
+	}
+
+	return &localauthority.RotateAuthorityResponse{
+		X509Authority: x509AuthorityState(x509CA),
+		JwtAuthority:  jwtAuthorityState(jwtKey),
+	}, nil
+}
+
+func (s *Service) TaintX509Authority(ctx context.Context, req *localauthority.TaintX509AuthorityRequest) (*localauthority.TaintX509AuthorityResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	authorityID, err := x509AuthorityIDFromRequest(req.AuthorityId)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid authority ID", err)
+	}
+	log = log.WithField(telemetry.LocalAuthorityID, authorityID)
+
+	caCert, err := s.m.TaintX509CA(ctx, authorityID)
+	if err != nil {
+		return nil, authorityErr(log, "failed to taint X509 authority", err)
+	}
+
+	return &localauthority.TaintX509AuthorityResponse{
+		TaintedAuthority: x509CertificateState(caCert),
+	}, nil
+}
+
+func (s *Service) RevokeX509Authority(ctx context.Context, req *localauthority.RevokeX509AuthorityRequest) (*localauthority.RevokeX509AuthorityResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	authorityID, err := x509AuthorityIDFromRequest(req.AuthorityId)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid authority ID", err)
+	}
+	log = log.WithField(telemetry.LocalAuthorityID, authorityID)
+
+	caCert, err := s.m.RevokeX509CA(ctx, authorityID)
+	if err != nil {
+		return nil, authorityErr(log, "failed to revoke X509 authority", err)
+	}
+
+	return &localauthority.RevokeX509AuthorityResponse{
+		RevokedAuthority: x509CertificateState(caCert),
+	}, nil
+}
+
+func (s *Service) TaintJWTAuthority(ctx context.Context, req *localauthority.TaintJWTAuthorityRequest) (*localauthority.TaintJWTAuthorityResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	if req.AuthorityId == "" {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid authority ID", errors.New("missing authority ID"))
+	}
+	log = log.WithField(telemetry.LocalAuthorityID, req.AuthorityId)
+
+	jwtSigningKey, err := s.m.TaintJWTKey(ctx, req.AuthorityId)
+	if err != nil {
+		return nil, authorityErr(log, "failed to taint JWT authority", err)
+	}
+
+	return &localauthority.TaintJWTAuthorityResponse{
+		TaintedAuthority: jwtSigningKeyState(jwtSigningKey),
+	}, nil
+}
+
+func (s *Service) RevokeJWTAuthority(ctx context.Context, req *localauthority.RevokeJWTAuthorityRequest) (*localauthority.RevokeJWTAuthorityResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	if req.AuthorityId == "" {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid authority ID", errors.New("missing authority ID"))
+	}
+	log = log.WithField(telemetry.LocalAuthorityID, req.AuthorityId)
+
+	jwtSigningKey, err := s.m.RevokeJWTKey(ctx, req.AuthorityId)
+	if err != nil {
+		return nil, authorityErr(log, "failed to revoke JWT authority", err)
+	}
+
+	return &localauthority.RevokeJWTAuthorityResponse{
+		RevokedAuthority: jwtSigningKeyState(jwtSigningKey),
+	}, nil
+}
+
+// x509AuthorityIDFromRequest normalizes the X509 authority ID so it can be
+// provided in the colon separated form commonly used to print subject key
+// IDs.
+func x509AuthorityIDFromRequest(authorityID string) (string, error) {
+	if authorityID == "" {
+		return "", errors.New("missing authority ID")
+	}
+	return strings.ToLower(strings.ReplaceAll(authorityID, ":", "")), nil
+}
+
+func authorityErr(log logrus.FieldLogger, msg string, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return api.MakeErr(log, codes.NotFound, "authority not found", err)
+	case codes.FailedPrecondition:
+		return api.MakeErr(log, codes.FailedPrecondition, msg, err)
+	default:
+		return api.MakeErr(log, codes.Internal, msg, err)
+	}
+}
+
+func x509AuthorityState(x509CA *ca.X509CA) *localauthority.AuthorityState {
+	return x509CertificateState(x509CA.Certificate)
+}
+
+func x509CertificateState(caCert *x509.Certificate) *localauthority.AuthorityState {
+	return &localauthority.AuthorityState{
+		AuthorityId: ca.X509AuthorityID(caCert),
+		ExpiresAt:   caCert.NotAfter.Unix(),
+	}
+}
+
+func jwtSigningKeyState(jwtSigningKey *common.PublicKey) *localauthority.AuthorityState {
+	return &localauthority.AuthorityState{
+		AuthorityId: jwtSigningKey.Kid,
+		ExpiresAt:   jwtSigningKey.NotAfter,
+	}
+}
+
+func jwtAuthorityState(jwtKey *ca.JWTKey) *localauthority.AuthorityState {
+	return &localauthority.AuthorityState{
+		AuthorityId: jwtKey.Kid,
+		ExpiresAt:   jwtKey.NotAfter.Unix(),
+	}
+}
@@ -0,0 +1,377 @@
+package localauthority_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/localauthority/v1"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ca"
+	localauthoritypb "github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ctx = context.Background()
+
+	expiresAt = time.Unix(1600000000, 0)
+
+	x509CA = &ca.X509CA{
+		Certificate: &x509.Certificate{
+			SubjectKeyId: []byte{0x01, 0x02, 0x03},
+			NotAfter:     expiresAt,
+		},
+	}
+	jwtKey = &ca.JWTKey{
+		Kid:      "KID",
+		NotAfter: expiresAt.Add(time.Hour),
+	}
+	jwtSigningKey = &common.PublicKey{
+		Kid:      "KID",
+		NotAfter: expiresAt.Add(time.Hour).Unix(),
+	}
+
+	expectedX509Authority = &localauthoritypb.AuthorityState{
+		AuthorityId: "010203",
+		ExpiresAt:   expiresAt.Unix(),
+	}
+	expectedJWTAuthority = &localauthoritypb.AuthorityState{
+		AuthorityId: "KID",
+		ExpiresAt:   expiresAt.Add(time.Hour).Unix(),
+	}
+)
+
+func TestPrepareNextAuthority(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		err        error
+		expectCode codes.Code
+		expectMsg  string
+		expectLogs []spiretest.LogEntry
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:       "manager failure",
+			err:        errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to prepare next authority: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to prepare next authority",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t, &fakeManager{err: tt.err})
+			defer test.Cleanup()
+
+			resp, err := test.client.PrepareNextAuthority(ctx, &localauthoritypb.PrepareNextAuthorityRequest{})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, test.manager.prepared)
+			spiretest.RequireProtoEqual(t, &localauthoritypb.PrepareNextAuthorityResponse{
+				X509Authority: expectedX509Authority,
+				JwtAuthority:  expectedJWTAuthority,
+			}, resp)
+		})
+	}
+}
+
+func TestRotateAuthority(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		err        error
+		expectCode codes.Code
+		expectMsg  string
+		expectLogs []spiretest.LogEntry
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:       "manager failure",
+			err:        errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to rotate authority: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to rotate authority",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t, &fakeManager{err: tt.err})
+			defer test.Cleanup()
+
+			resp, err := test.client.RotateAuthority(ctx, &localauthoritypb.RotateAuthorityRequest{})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, test.manager.activated)
+			spiretest.RequireProtoEqual(t, &localauthoritypb.RotateAuthorityResponse{
+				X509Authority: expectedX509Authority,
+				JwtAuthority:  expectedJWTAuthority,
+			}, resp)
+		})
+	}
+}
+
+func TestTaintX509Authority(t *testing.T) {
+	testX509AuthorityOperation(t, "taint", func(client localauthoritypb.LocalAuthorityClient, authorityID string) (*localauthoritypb.AuthorityState, error) {
+		resp, err := client.TaintX509Authority(ctx, &localauthoritypb.TaintX509AuthorityRequest{AuthorityId: authorityID})
+		return resp.GetTaintedAuthority(), err
+	})
+}
+
+func TestRevokeX509Authority(t *testing.T) {
+	testX509AuthorityOperation(t, "revoke", func(client localauthoritypb.LocalAuthorityClient, authorityID string) (*localauthoritypb.AuthorityState, error) {
+		resp, err := client.RevokeX509Authority(ctx, &localauthoritypb.RevokeX509AuthorityRequest{AuthorityId: authorityID})
+		return resp.GetRevokedAuthority(), err
+	})
+}
+
+func TestTaintJWTAuthority(t *testing.T) {
+	testJWTAuthorityOperation(t, "taint", func(client localauthoritypb.LocalAuthorityClient, authorityID string) (*localauthoritypb.AuthorityState, error) {
+		resp, err := client.TaintJWTAuthority(ctx, &localauthoritypb.TaintJWTAuthorityRequest{AuthorityId: authorityID})
+		return resp.GetTaintedAuthority(), err
+	})
+}
+
+func TestRevokeJWTAuthority(t *testing.T) {
+	testJWTAuthorityOperation(t, "revoke", func(client localauthoritypb.LocalAuthorityClient, authorityID string) (*localauthoritypb.AuthorityState, error) {
+		resp, err := client.RevokeJWTAuthority(ctx, &localauthoritypb.RevokeJWTAuthorityRequest{AuthorityId: authorityID})
+		return resp.GetRevokedAuthority(), err
+	})
+}
+
+type authorityOperationFunc func(client localauthoritypb.LocalAuthorityClient, authorityID string) (*localauthoritypb.AuthorityState, error)
+
+func testX509AuthorityOperation(t *testing.T, operation string, fn authorityOperationFunc) {
+	testAuthorityOperation(t, operation, "X509", "01:02:0A", "01020a", expectedX509Authority, fn)
+}
+
+func testJWTAuthorityOperation(t *testing.T, operation string, fn authorityOperationFunc) {
+	testAuthorityOperation(t, operation, "JWT", "KID", "KID", expectedJWTAuthority, fn)
+}
+
+func testAuthorityOperation(t *testing.T, operation, authorityType, authorityID, expectedID string, expectedAuthority *localauthoritypb.AuthorityState, fn authorityOperationFunc) {
+	failedMsg := "failed to " + operation + " " + authorityType + " authority"
+	for _, tt := range []struct {
+		name        string
+		authorityID string
+		err         error
+		expectCode  codes.Code
+		expectMsg   string
+		expectLogs  []spiretest.LogEntry
+	}{
+		{
+			name:        "success",
+			authorityID: authorityID,
+		},
+		{
+			name:       "missing authority ID",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "invalid authority ID: missing authority ID",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid authority ID",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "missing authority ID",
+					},
+				},
+			},
+		},
+		{
+			name:        "authority not found",
+			authorityID: authorityID,
+			err:         status.Error(codes.NotFound, "not found"),
+			expectCode:  codes.NotFound,
+			expectMsg:   "authority not found",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Authority not found",
+					Data: logrus.Fields{
+						telemetry.LocalAuthorityID: expectedID,
+					},
+				},
+			},
+		},
+		{
+			name:        "authority in use",
+			authorityID: authorityID,
+			err:         status.Error(codes.FailedPrecondition, "in use"),
+			expectCode:  codes.FailedPrecondition,
+			expectMsg:   failedMsg + ": in use",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "F" + failedMsg[1:],
+					Data: logrus.Fields{
+						telemetry.LocalAuthorityID: expectedID,
+						logrus.ErrorKey:            "rpc error: code = FailedPrecondition desc = in use",
+					},
+				},
+			},
+		},
+		{
+			name:        "manager failure",
+			authorityID: authorityID,
+			err:         errors.New("oh no"),
+			expectCode:  codes.Internal,
+			expectMsg:   failedMsg + ": oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "F" + failedMsg[1:],
+					Data: logrus.Fields{
+						telemetry.LocalAuthorityID: expectedID,
+						logrus.ErrorKey:            "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t, &fakeManager{err: tt.err})
+			defer test.Cleanup()
+
+			authority, err := fn(test.client, tt.authorityID)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, authority)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, expectedID, test.manager.authorityID)
+			spiretest.RequireProtoEqual(t, expectedAuthority, authority)
+		})
+	}
+}
+
+type serviceTest struct {
+	client  localauthoritypb.LocalAuthorityClient
+	manager *fakeManager
+	logHook *test.Hook
+	done    func()
+}
+
+func (c *serviceTest) Cleanup() {
+	c.done()
+}
+
+func setupServiceTest(t *testing.T, manager *fakeManager) *serviceTest {
+	service := localauthority.New(localauthority.Config{
+		Manager: manager,
+	})
+
+	log, logHook := test.NewNullLogger()
+	log.Level = logrus.DebugLevel
+	registerFn := func(s *grpc.Server) {
+		localauthority.RegisterService(s, service)
+	}
+
+	contextFn := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+	return &serviceTest{
+		client:  localauthoritypb.NewLocalAuthorityClient(conn),
+		manager: manager,
+		logHook: logHook,
+		done:    done,
+	}
+}
+
+type fakeManager struct {
+	err         error
+	prepared    bool
+	activated   bool
+	authorityID string
+}
+
+func (m *fakeManager) PrepareNext(ctx context.Context) (*ca.X509CA, *ca.JWTKey, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	m.prepared = true
+	return x509CA, jwtKey, nil
+}
+
+func (m *fakeManager) ActivateNext(ctx context.Context) (*ca.X509CA, *ca.JWTKey, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	m.activated = true
+	return x509CA, jwtKey, nil
+}
+
+func (m *fakeManager) TaintX509CA(ctx context.Context, authorityID string) (*x509.Certificate, error) {
+	return m.x509CAOperation(authorityID)
+}
+
+func (m *fakeManager) RevokeX509CA(ctx context.Context, authorityID string) (*x509.Certificate, error) {
+	return m.x509CAOperation(authorityID)
+}
+
+func (m *fakeManager) TaintJWTKey(ctx context.Context, kid string) (*common.PublicKey, error) {
+	return m.jwtKeyOperation(kid)
+}
+
+func (m *fakeManager) RevokeJWTKey(ctx context.Context, kid string) (*common.PublicKey, error) {
+	return m.jwtKeyOperation(kid)
+}
+
+func (m *fakeManager) x509CAOperation(authorityID string) (*x509.Certificate, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.authorityID = authorityID
+	return x509CA.Certificate, nil
+}
+
+func (m *fakeManager) jwtKeyOperation(kid string) (*common.PublicKey, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.authorityID = kid
+	return jwtSigningKey, nil
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/api/middleware"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	auditType    = "audit"
+	redactedText = "[redacted]"
+)
+
+var auditMarshaler = jsonpb.Marshaler{OrigName: true}
+
+// WithAuditLog returns a middleware that writes a record to the audit log
+// for every call to one of the given methods, whether or not the call was
+// authorized or succeeded. The record includes the caller identity, the
+// outcome of the call, the request message of unary calls (see
+// AuditRequestUnaryInterceptor) and any fields added by the handler via
+// rpccontext.AddAuditFields. If the audit log is nil, the middleware does
+// nothing.
+func WithAuditLog(log logrus.FieldLogger, methods map[string]bool) middleware.Middleware {
+	return &auditLogMiddleware{
+		log:     log,
+		methods: methods,
+	}
+}
+
+// AuditRequestUnaryInterceptor wraps a unary interceptor so that the request
+// message is available to the audit log middleware.
+func AuditRequestUnaryInterceptor(next grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return next(rpccontext.WithAuditRequest(ctx, req), req, info, handler)
+	}
+}
+
+type auditLogMiddleware struct {
+	log     logrus.FieldLogger
+	methods map[string]bool
+}
+
+func (m *auditLogMiddleware) Preprocess(ctx context.Context, methodName string) (context.Context, error) {
+	if m.log == nil || !m.methods[methodName] {
+		return ctx, nil
+	}
+
+	// Resolve the caller up front so it can be audited even if the call
+	// fails authorization. Errors are left for the authorization middleware
+	// to report.
+	if callerCtx, err := callerContextFromContext(ctx); err == nil {
+		ctx = callerCtx
+	}
+	return rpccontext.WithAuditFields(ctx), nil
+}
+
+func (m *auditLogMiddleware) Postprocess(ctx context.Context, methodName string, handlerInvoked bool, rpcErr error) {
+	fields, ok := rpccontext.AuditFields(ctx)
+	if !ok {
+		return
+	}
+
+	fields["type"] = auditType
+	if names, ok := rpccontext.Names(ctx); ok {
+		fields["service"] = names.Service
+		fields[telemetry.Method] = names.Method
+	} else {
+		fields[telemetry.Method] = methodName
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		fields[telemetry.CallerAddr] = p.Addr.String()
+	}
+	if id, ok := rpccontext.CallerID(ctx); ok {
+		fields[telemetry.CallerID] = id.String()
+	}
+
+	st := status.Convert(rpcErr)
+	fields[telemetry.Status] = st.Code().String()
+	if st.Message() != "" {
+		fields[telemetry.StatusMessage] = st.Message()
+	}
+
+	if req, ok := rpccontext.AuditRequest(ctx); ok {
+		request, err := auditRequestFields(req)
+		switch {
+		case err != nil:
+			rpccontext.Logger(ctx).WithError(err).Warn("Failed to marshal request for the audit log")
+		case request != nil:
+			fields[telemetry.Request] = request
+		}
+	}
+
+	m.log.WithFields(fields).Info("API call")
+}
+
+// auditRequestFields converts the request message into a map so it is
+// rendered as a nested object by the JSON log formatter. Secrets carried in
+// requests are redacted.
+func auditRequestFields(req interface{}) (map[string]interface{}, error) {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil, nil
+	}
+
+	if r, ok := msg.(*agent.CreateJoinTokenRequest); ok && r.Token != "" {
+		r = proto.Clone(r).(*agent.CreateJoinTokenRequest)
+		r.Token = redactedText
+		msg = r
+	}
+
+	s, err := auditMarshaler.MarshalToString(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var request map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
@@ -0,0 +1,163 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithAuditLog(t *testing.T) {
+	id := spiffeid.Must("example.org", "admin")
+	x509SVID := &x509.Certificate{URIs: []*url.URL{id.URL()}}
+
+	unixPeer := &peer.Peer{
+		Addr: &net.UnixAddr{
+			Net:  "unix",
+			Name: "/not/a/real/path.sock",
+		},
+	}
+
+	mtlsPeer := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("2.2.2.2"),
+			Port: 2,
+		},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				HandshakeComplete: true,
+				PeerCertificates:  []*x509.Certificate{x509SVID},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name        string
+		fullMethod  string
+		peer        *peer.Peer
+		request     interface{}
+		auditFields logrus.Fields
+		rpcErr      error
+		expectLogs  []spiretest.LogEntry
+	}{
+		{
+			name:       "method not audited",
+			fullMethod: "/spire.api.server.foo.v1.Foo/NotAudited",
+			peer:       unixPeer,
+		},
+		{
+			name:       "local caller",
+			fullMethod: fakeFullMethod,
+			peer:       unixPeer,
+			request:    &agent.CreateJoinTokenRequest{Ttl: 60, Token: "secret"},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API call",
+					Data: logrus.Fields{
+						"type":        "audit",
+						"method":      fakeFullMethod,
+						"caller_addr": "/not/a/real/path.sock",
+						"status":      "OK",
+						"request":     "map[token:[redacted] ttl:60]",
+					},
+				},
+			},
+		},
+		{
+			name:        "remote caller with failure",
+			fullMethod:  fakeFullMethod,
+			peer:        mtlsPeer,
+			auditFields: logrus.Fields{"agent_id": "spiffe://example.org/agent"},
+			rpcErr:      status.Error(codes.PermissionDenied, "denied"),
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API call",
+					Data: logrus.Fields{
+						"type":           "audit",
+						"method":         fakeFullMethod,
+						"caller_addr":    "2.2.2.2:2",
+						"caller_id":      "spiffe://example.org/admin",
+						"status":         "PermissionDenied",
+						"status_message": "denied",
+						"agent_id":       "spiffe://example.org/agent",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			auditLog, hook := test.NewNullLogger()
+			m := middleware.WithAuditLog(auditLog, map[string]bool{
+				fakeFullMethod: true,
+			})
+
+			log, _ := test.NewNullLogger()
+			ctxIn := rpccontext.WithLogger(context.Background(), log)
+			ctxIn = peer.NewContext(ctxIn, tt.peer)
+			if tt.request != nil {
+				ctxIn = rpccontext.WithAuditRequest(ctxIn, tt.request)
+			}
+
+			ctxOut, err := m.Preprocess(ctxIn, tt.fullMethod)
+			require.NoError(t, err)
+			rpccontext.AddAuditFields(ctxOut, tt.auditFields)
+			m.Postprocess(ctxOut, tt.fullMethod, true, tt.rpcErr)
+
+			spiretest.AssertLogs(t, hook.AllEntries(), tt.expectLogs)
+		})
+	}
+}
+
+func TestWithAuditLogDisabled(t *testing.T) {
+	m := middleware.WithAuditLog(nil, map[string]bool{
+		fakeFullMethod: true,
+	})
+
+	ctxIn := context.Background()
+	ctxOut, err := m.Preprocess(ctxIn, fakeFullMethod)
+	require.NoError(t, err)
+	assert.Equal(t, ctxIn, ctxOut)
+
+	_, ok := rpccontext.AuditFields(ctxOut)
+	assert.False(t, ok)
+
+	// Make sure it doesn't panic
+	m.Postprocess(ctxOut, fakeFullMethod, true, nil)
+}
+
+func TestAuditRequestUnaryInterceptor(t *testing.T) {
+	req := &agent.CreateJoinTokenRequest{Ttl: 60}
+
+	var got interface{}
+	next := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		got, _ = rpccontext.AuditRequest(ctx)
+		return handler(ctx, req)
+	}
+
+	resp, err := middleware.AuditRequestUnaryInterceptor(next)(context.Background(), req, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "response", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "response", resp)
+	assert.Equal(t, req, got)
+}
@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Role is a set of server API capabilities that can be granted to callers
+// that are not admin workloads.
+type Role string
+
+const (
+	// RoleReadOnly grants read access to entries, agents and federated
+	// bundles.
+	RoleReadOnly Role = "read-only"
+
+	// RoleEntryAdmin grants read access and management of registration
+	// entries.
+	RoleEntryAdmin Role = "entry-admin"
+
+	// RoleBundleAdmin grants read access and management of the trust bundle
+	// and federated bundles.
+	RoleBundleAdmin Role = "bundle-admin"
+
+	// RoleAgentAdmin grants read access and management of agents and join
+	// tokens.
+	RoleAgentAdmin Role = "agent-admin"
+)
+
+// ParseRole parses a role name.
+func ParseRole(s string) (Role, error) {
+	switch role := Role(s); role {
+	case RoleReadOnly, RoleEntryAdmin, RoleBundleAdmin, RoleAgentAdmin:
+		return role, nil
+	default:
+		return "", fmt.Errorf("unknown role %q", s)
+	}
+}
+
+// RoleBinding grants a role to callers. A caller is bound to the role if its
+// SPIFFE ID is one of the binding SPIFFE IDs, or if any of the registration
+// entries of the caller has all of the binding selectors.
+type RoleBinding struct {
+	Role      Role
+	SPIFFEIDs []spiffeid.ID
+	Selectors []*types.Selector
+}
+
+// AuthorizeRole authorizes callers bound to any of the given roles.
+func AuthorizeRole(entryFetcher EntryFetcher, bindings []RoleBinding, roles ...Role) Authorizer {
+	var roleBindings []RoleBinding
+	for _, binding := range bindings {
+		for _, role := range roles {
+			if binding.Role == role {
+				roleBindings = append(roleBindings, binding)
+				break
+			}
+		}
+	}
+
+	return roleAuthorizer{
+		entryFetcher: entryFetcher,
+		roles:        roles,
+		bindings:     roleBindings,
+	}
+}
+
+type roleAuthorizer struct {
+	entryFetcher EntryFetcher
+	roles        []Role
+	bindings     []RoleBinding
+}
+
+func (a roleAuthorizer) Name() string {
+	names := make([]string, 0, len(a.roles))
+	for _, role := range a.roles {
+		names = append(names, string(role))
+	}
+	return fmt.Sprintf("role[%s]", strings.Join(names, ","))
+}
+
+func (a roleAuthorizer) AuthorizeCaller(ctx context.Context) (context.Context, error) {
+	id, ok := rpccontext.CallerID(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "caller does not have a SPIFFE ID")
+	}
+
+	var needEntries bool
+	for _, binding := range a.bindings {
+		for _, bindingID := range binding.SPIFFEIDs {
+			if bindingID == id {
+				return ctx, nil
+			}
+		}
+		if len(binding.Selectors) > 0 {
+			needEntries = true
+		}
+	}
+
+	if needEntries {
+		ctx, entries, err := WithCallerEntries(ctx, a.entryFetcher)
+		if err != nil {
+			return nil, err
+		}
+		for _, binding := range a.bindings {
+			if len(binding.Selectors) > 0 && anyEntryHasSelectors(entries, binding.Selectors) {
+				return ctx, nil
+			}
+		}
+	}
+
+	return nil, status.Error(codes.PermissionDenied, "caller is not bound to a permitted role")
+}
+
+// CheckEntryPrivileges returns an error if the caller is
+// neither local nor an admin and the entry would grant the workloads it
+// describes more privileges than a role does. These are admin and downstream
+// entries, and entries that bind their workloads to a role, either through
+// the SPIFFE ID or all of the selectors of a role binding. Entries that share
+// the SPIFFE ID of an existing admin or downstream entry, whose SPIFFE ID is
+// the parent of one, or that are parented under the parent of one, are
+// rejected as well, since they would let the caller issue the privileged
+// identities to, or alongside, a node it controls. existing holds the
+// registration entries currently in the datastore.
+func CheckEntryPrivileges(ctx context.Context, bindings []RoleBinding, entry *common.RegistrationEntry, existing []*common.RegistrationEntry) error {
+	if rpccontext.CallerIsLocal(ctx) || rpccontext.CallerIsAdmin(ctx) {
+		return nil
+	}
+
+	switch {
+	case entry.Admin:
+		return errors.New("only local or admin callers can manage admin entries")
+	case entry.Downstream:
+		return errors.New("only local or admin callers can manage downstream entries")
+	}
+
+	selectors := api.ProtoFromSelectors(entry.Selectors)
+	for _, binding := range bindings {
+		for _, bindingID := range binding.SPIFFEIDs {
+			if bindingID.String() == entry.SpiffeId {
+				return fmt.Errorf("only local or admin callers can manage entries bound to the %q role", binding.Role)
+			}
+		}
+		if len(binding.Selectors) > 0 && hasSelectors(selectors, binding.Selectors) {
+			return fmt.Errorf("only local or admin callers can manage entries bound to the %q role", binding.Role)
+		}
+	}
+
+	for _, privileged := range existing {
+		if privileged.EntryId == entry.EntryId || !(privileged.Admin || privileged.Downstream) {
+			continue
+		}
+		switch {
+		case entry.SpiffeId == privileged.SpiffeId:
+			return errors.New("only local or admin callers can manage entries with the SPIFFE ID of an admin or downstream entry")
+		case entry.SpiffeId == privileged.ParentId:
+			return errors.New("only local or admin callers can manage entries with the SPIFFE ID of the parent of an admin or downstream entry")
+		case entry.ParentId == privileged.ParentId:
+			return errors.New("only local or admin callers can manage entries with the same parent as an admin or downstream entry")
+		}
+	}
+	return nil
+}
+
+func anyEntryHasSelectors(entries []*types.Entry, selectors []*types.Selector) bool {
+	for _, entry := range entries {
+		if entryHasSelectors(entry, selectors) {
+			return true
+		}
+	}
+	return false
+}
+
+func entryHasSelectors(entry *types.Entry, selectors []*types.Selector) bool {
+	return hasSelectors(entry.Selectors, selectors)
+}
+
+// hasSelectors returns true if all of the selectors are in the set.
+func hasSelectors(set []*types.Selector, selectors []*types.Selector) bool {
+	for _, selector := range selectors {
+		found := false
+		for _, entrySelector := range set {
+			if entrySelector.Type == selector.Type && entrySelector.Value == selector.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,350 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestParseRole(t *testing.T) {
+	for _, role := range []middleware.Role{
+		middleware.RoleReadOnly,
+		middleware.RoleEntryAdmin,
+		middleware.RoleBundleAdmin,
+		middleware.RoleAgentAdmin,
+	} {
+		parsed, err := middleware.ParseRole(string(role))
+		require.NoError(t, err)
+		require.Equal(t, role, parsed)
+	}
+
+	_, err := middleware.ParseRole("super-admin")
+	require.EqualError(t, err, `unknown role "super-admin"`)
+}
+
+func TestRoleAuthorizerName(t *testing.T) {
+	authorizer := middleware.AuthorizeRole(nil, nil, middleware.RoleReadOnly, middleware.RoleEntryAdmin)
+	assert.Equal(t, "role[read-only,entry-admin]", authorizer.Name())
+}
+
+func TestRoleAuthorizer(t *testing.T) {
+	entryAdminID := spiffeid.Must("example.org", "entry-admin")
+	bundleAdminID := spiffeid.Must("example.org", "bundle-admin")
+	opsID := spiffeid.Must("example.org", "ops")
+	workloadID := spiffeid.Must("example.org", "workload")
+	failMeID := spiffeid.Must("example.org", "fail-me")
+
+	bindings := []middleware.RoleBinding{
+		{
+			Role:      middleware.RoleEntryAdmin,
+			SPIFFEIDs: []spiffeid.ID{entryAdminID},
+			Selectors: []*types.Selector{
+				{Type: "k8s", Value: "ns:ops"},
+				{Type: "k8s", Value: "sa:spire-admin"},
+			},
+		},
+		{
+			Role:      middleware.RoleBundleAdmin,
+			SPIFFEIDs: []spiffeid.ID{bundleAdminID},
+		},
+	}
+
+	entryFetcher := middleware.EntryFetcherFunc(
+		func(ctx context.Context, id spiffeid.ID) ([]*types.Entry, error) {
+			switch id {
+			case opsID:
+				return []*types.Entry{
+					{Id: "1", Selectors: []*types.Selector{{Type: "k8s", Value: "ns:ops"}}},
+					{Id: "2", Selectors: []*types.Selector{
+						{Type: "k8s", Value: "ns:ops"},
+						{Type: "k8s", Value: "sa:spire-admin"},
+						{Type: "k8s", Value: "pod-label:app:admin"},
+					}},
+				}, nil
+			case workloadID:
+				return []*types.Entry{
+					{Id: "3", Selectors: []*types.Selector{{Type: "k8s", Value: "ns:ops"}}},
+				}, nil
+			case failMeID:
+				return nil, errors.New("ohno")
+			default:
+				return nil, nil
+			}
+		},
+	)
+
+	denied := "caller is not bound to a permitted role"
+
+	for _, tt := range []struct {
+		name       string
+		roles      []middleware.Role
+		bindings   []middleware.RoleBinding
+		noCallerID bool
+		id         spiffeid.ID
+		expectCode codes.Code
+		expectMsg  string
+		expectLogs []spiretest.LogEntry
+	}{
+		{
+			name:       "bound by SPIFFE ID",
+			roles:      []middleware.Role{middleware.RoleEntryAdmin},
+			bindings:   bindings,
+			id:         entryAdminID,
+			expectCode: codes.OK,
+		},
+		{
+			name:       "bound by selectors",
+			roles:      []middleware.Role{middleware.RoleEntryAdmin},
+			bindings:   bindings,
+			id:         opsID,
+			expectCode: codes.OK,
+		},
+		{
+			name:       "bound to one of the roles",
+			roles:      []middleware.Role{middleware.RoleReadOnly, middleware.RoleBundleAdmin},
+			bindings:   bindings,
+			id:         bundleAdminID,
+			expectCode: codes.OK,
+		},
+		{
+			name:       "bound to another role",
+			roles:      []middleware.Role{middleware.RoleEntryAdmin},
+			bindings:   bindings,
+			id:         bundleAdminID,
+			expectCode: codes.PermissionDenied,
+			expectMsg:  denied,
+		},
+		{
+			name:       "entries with a subset of the selectors",
+			roles:      []middleware.Role{middleware.RoleEntryAdmin},
+			bindings:   bindings,
+			id:         workloadID,
+			expectCode: codes.PermissionDenied,
+			expectMsg:  denied,
+		},
+		{
+			name:       "no bindings",
+			roles:      []middleware.Role{middleware.RoleEntryAdmin},
+			id:         entryAdminID,
+			expectCode: codes.PermissionDenied,
+			expectMsg:  denied,
+		},
+		{
+			name:       "no caller ID",
+			roles:      []middleware.Role{middleware.RoleEntryAdmin},
+			bindings:   bindings,
+			noCallerID: true,
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "caller does not have a SPIFFE ID",
+		},
+		{
+			name:       "fail to fetch entries",
+			roles:      []middleware.Role{middleware.RoleEntryAdmin},
+			bindings:   bindings,
+			id:         failMeID,
+			expectCode: codes.Internal,
+			expectMsg:  "failed to fetch caller entries: ohno",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to fetch caller entries",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "ohno",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, hook := test.NewNullLogger()
+			ctx := rpccontext.WithLogger(context.Background(), log)
+			if !tt.noCallerID {
+				ctx = rpccontext.WithCallerID(ctx, tt.id)
+			}
+
+			authorizer := middleware.AuthorizeRole(entryFetcher, tt.bindings, tt.roles...)
+			ctx, err := authorizer.AuthorizeCaller(ctx)
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			spiretest.AssertLogs(t, hook.AllEntries(), tt.expectLogs)
+			if tt.expectCode == codes.OK {
+				assert.NotNil(t, ctx)
+			} else {
+				assert.Nil(t, ctx)
+			}
+		})
+	}
+}
+
+func TestCheckEntryPrivileges(t *testing.T) {
+	bindings := []middleware.RoleBinding{
+		{
+			Role:      middleware.RoleBundleAdmin,
+			SPIFFEIDs: []spiffeid.ID{spiffeid.Must("example.org", "bundle-admin")},
+		},
+		{
+			Role: middleware.RoleEntryAdmin,
+			Selectors: []*types.Selector{
+				{Type: "k8s", Value: "ns:ops"},
+				{Type: "k8s", Value: "sa:spire-admin"},
+			},
+		},
+	}
+
+	workload := &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "k8s", Value: "ns:ops"}},
+	}
+	admin := &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "k8s", Value: "ns:ops"}},
+		Admin:     true,
+	}
+	downstream := &common.RegistrationEntry{
+		SpiffeId:   "spiffe://example.org/workload",
+		Selectors:  []*common.Selector{{Type: "k8s", Value: "ns:ops"}},
+		Downstream: true,
+	}
+	boundByID := &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/bundle-admin",
+		Selectors: []*common.Selector{{Type: "k8s", Value: "ns:ops"}},
+	}
+	boundBySelectors := &common.RegistrationEntry{
+		SpiffeId: "spiffe://example.org/workload",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:ops"},
+			{Type: "k8s", Value: "sa:spire-admin"},
+			{Type: "k8s", Value: "pod-name:escalate"},
+		},
+	}
+
+	// an admin entry issued to the workloads of an existing agent
+	existing := []*common.RegistrationEntry{
+		workload,
+		{
+			EntryId:   "admin-entry",
+			ParentId:  "spiffe://example.org/agent",
+			SpiffeId:  "spiffe://example.org/admin",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:0"}},
+			Admin:     true,
+		},
+	}
+	sameIDAsAdmin := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/other-agent",
+		SpiffeId:  "spiffe://example.org/admin",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+	aliasOfAdminParent := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/spire/server",
+		SpiffeId:  "spiffe://example.org/agent",
+		Selectors: []*common.Selector{{Type: "k8s_psat", Value: "cluster:mine"}},
+	}
+	sameParentAsAdmin := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/agent",
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		ctx       context.Context
+		entry     *common.RegistrationEntry
+		existing  []*common.RegistrationEntry
+		expectErr string
+	}{
+		{
+			name:  "entry without privileges",
+			ctx:   context.Background(),
+			entry: workload,
+		},
+		{
+			name:      "admin entry",
+			ctx:       context.Background(),
+			entry:     admin,
+			expectErr: "only local or admin callers can manage admin entries",
+		},
+		{
+			name:      "downstream entry",
+			ctx:       context.Background(),
+			entry:     downstream,
+			expectErr: "only local or admin callers can manage downstream entries",
+		},
+		{
+			name:      "entry bound to a role by SPIFFE ID",
+			ctx:       context.Background(),
+			entry:     boundByID,
+			expectErr: `only local or admin callers can manage entries bound to the "bundle-admin" role`,
+		},
+		{
+			name:      "entry bound to a role by selectors",
+			ctx:       context.Background(),
+			entry:     boundBySelectors,
+			expectErr: `only local or admin callers can manage entries bound to the "entry-admin" role`,
+		},
+		{
+			name:     "entry unrelated to existing admin entries",
+			ctx:      context.Background(),
+			entry:    workload,
+			existing: existing,
+		},
+		{
+			name:      "entry with the SPIFFE ID of an admin entry",
+			ctx:       context.Background(),
+			entry:     sameIDAsAdmin,
+			existing:  existing,
+			expectErr: "only local or admin callers can manage entries with the SPIFFE ID of an admin or downstream entry",
+		},
+		{
+			name:      "node alias for the parent of an admin entry",
+			ctx:       context.Background(),
+			entry:     aliasOfAdminParent,
+			existing:  existing,
+			expectErr: "only local or admin callers can manage entries with the SPIFFE ID of the parent of an admin or downstream entry",
+		},
+		{
+			name:      "entry parented under the parent of an admin entry",
+			ctx:       context.Background(),
+			entry:     sameParentAsAdmin,
+			existing:  existing,
+			expectErr: "only local or admin callers can manage entries with the same parent as an admin or downstream entry",
+		},
+		{
+			name:     "admin caller relating to admin entries",
+			ctx:      rpccontext.WithCallerAdminEntries(context.Background(), nil),
+			entry:    aliasOfAdminParent,
+			existing: existing,
+		},
+		{
+			name:  "local caller",
+			ctx:   rpccontext.WithLocalCaller(context.Background()),
+			entry: admin,
+		},
+		{
+			name:  "admin caller",
+			ctx:   rpccontext.WithCallerAdminEntries(context.Background(), nil),
+			entry: boundBySelectors,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := middleware.CheckEntryPrivileges(tt.ctx, bindings, tt.entry, tt.existing)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
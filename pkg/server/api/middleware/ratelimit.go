@@ -17,8 +17,8 @@ import (
 )
 
 const (
-	// gcInterval is the interval at which per-ip limiters are garbage
-	// collected.
+	// gcInterval is the interval at which per-ip and per-agent limiters are
+	// garbage collected.
 	gcInterval = time.Minute
 )
 
@@ -68,6 +68,26 @@ func PerIPLimit(limit int) api.RateLimiter {
 	return newPerIPLimiter(limit)
 }
 
+// PerAgentLimit returns a rate limiter that imposes a per-agent limit on
+// calls to a method. Callers that are not agents are not limited. It can be
+// shared across methods to enforce per-agent limits for a group of methods.
+func PerAgentLimit(limit int) api.RateLimiter {
+	return newPerAgentLimiter(limit)
+}
+
+// AllLimits returns a rate limiter that imposes all of the limits of the
+// given rate limiters, in order.
+func AllLimits(rateLimiters ...api.RateLimiter) api.RateLimiter {
+	return allLimits(rateLimiters)
+}
+
+// ConfigurableLimit returns a rate limiter that imposes the limits of the
+// given rate limiter until it is replaced using SetRateLimiter. It allows
+// limits to be adjusted while the server is running.
+func ConfigurableLimit(rateLimiter api.RateLimiter) *ConfigurableLimiter {
+	return &ConfigurableLimiter{rateLimiter: rateLimiter}
+}
+
 // WithRateLimits returns a middleware that performs rate limiting for the
 // group of methods descripted by the rateLimits map. It provides the
 // configured rate limiter to the method handlers via the request context. If
@@ -101,6 +121,39 @@ func (disabledLimit) RateLimit(ctx context.Context, count int) error {
 	return nil
 }
 
+type allLimits []api.RateLimiter
+
+func (ls allLimits) RateLimit(ctx context.Context, count int) error {
+	for _, l := range ls {
+		if err := l.RateLimit(ctx, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigurableLimiter is a rate limiter whose limits can be replaced while
+// it is in use.
+type ConfigurableLimiter struct {
+	mtx         sync.RWMutex
+	rateLimiter api.RateLimiter
+}
+
+// SetRateLimiter replaces the rate limiter that imposes the limits. Calls
+// already waiting on the previous rate limiter are not affected.
+func (lim *ConfigurableLimiter) SetRateLimiter(rateLimiter api.RateLimiter) {
+	lim.mtx.Lock()
+	defer lim.mtx.Unlock()
+	lim.rateLimiter = rateLimiter
+}
+
+func (lim *ConfigurableLimiter) RateLimit(ctx context.Context, count int) error {
+	lim.mtx.RLock()
+	rateLimiter := lim.rateLimiter
+	lim.mtx.RUnlock()
+	return rateLimiter.RateLimit(ctx, count)
+}
+
 type perCallLimiter struct {
 	limiter rawRateLimiter
 }
@@ -113,9 +166,15 @@ func (lim *perCallLimiter) RateLimit(ctx context.Context, count int) error {
 	return waitN(ctx, lim.limiter, count)
 }
 
-type perIPLimiter struct {
+// perKeyLimiter imposes a limit on calls for each key returned by the
+// key function. It is used to impose per-ip and per-agent limits.
+type perKeyLimiter struct {
 	limit int
 
+	// keyFn returns the key of the caller. Callers without a key aren't
+	// limited.
+	keyFn func(ctx context.Context) (string, bool)
+
 	mtx sync.RWMutex
 
 	// previous holds all of the limiters that were current at the GC
@@ -129,50 +188,58 @@ type perIPLimiter struct {
 	lastGC time.Time
 }
 
-func newPerIPLimiter(limit int) *perIPLimiter {
-	return &perIPLimiter{limit: limit,
+func newPerIPLimiter(limit int) *perKeyLimiter {
+	return newPerKeyLimiter(limit, callerIP)
+}
+
+func newPerAgentLimiter(limit int) *perKeyLimiter {
+	return newPerKeyLimiter(limit, callerAgentID)
+}
+
+func newPerKeyLimiter(limit int, keyFn func(ctx context.Context) (string, bool)) *perKeyLimiter {
+	return &perKeyLimiter{limit: limit,
+		keyFn:   keyFn,
 		current: make(map[string]rawRateLimiter),
 		lastGC:  clk.Now(),
 	}
 }
 
-func (lim *perIPLimiter) RateLimit(ctx context.Context, count int) error {
-	tcpAddr, ok := rpccontext.CallerAddr(ctx).(*net.TCPAddr)
+func (lim *perKeyLimiter) RateLimit(ctx context.Context, count int) error {
+	key, ok := lim.keyFn(ctx)
 	if !ok {
-		// Calls not via TCP/IP aren't limited
 		return nil
 	}
-	limiter := lim.getLimiter(tcpAddr.IP.String())
+	limiter := lim.getLimiter(key)
 	return waitN(ctx, limiter, count)
 }
 
-func (lim *perIPLimiter) getLimiter(ip string) rawRateLimiter {
+func (lim *perKeyLimiter) getLimiter(key string) rawRateLimiter {
 	lim.mtx.RLock()
-	limiter, ok := lim.current[ip]
+	limiter, ok := lim.current[key]
 	if ok {
 		lim.mtx.RUnlock()
 		return limiter
 	}
 	lim.mtx.RUnlock()
 
-	// A limiter does not exist for that address.
+	// A limiter does not exist for that key.
 	lim.mtx.Lock()
 	defer lim.mtx.Unlock()
 
-	// Check the "current" entries in case another goroutine raced on this IP.
-	if limiter, ok = lim.current[ip]; ok {
+	// Check the "current" entries in case another goroutine raced on this key.
+	if limiter, ok = lim.current[key]; ok {
 		return limiter
 	}
 
 	// Then check the "previous" entries to see if a limiter exists for this
-	// IP as of the last GC. If so, move it to current and return it.
-	if limiter, ok = lim.previous[ip]; ok {
-		lim.current[ip] = limiter
-		delete(lim.previous, ip)
+	// key as of the last GC. If so, move it to current and return it.
+	if limiter, ok = lim.previous[key]; ok {
+		lim.current[key] = limiter
+		delete(lim.previous, key)
 		return limiter
 	}
 
-	// There is no limiter for this IP. Before we create one, we should see
+	// There is no limiter for this key. Before we create one, we should see
 	// if we need to do GC.
 	now := clk.Now()
 	if now.Sub(lim.lastGC) >= gcInterval {
@@ -182,10 +249,30 @@ func (lim *perIPLimiter) getLimiter(ip string) rawRateLimiter {
 	}
 
 	limiter = newRawRateLimiter(rate.Limit(lim.limit), lim.limit)
-	lim.current[ip] = limiter
+	lim.current[key] = limiter
 	return limiter
 }
 
+func callerIP(ctx context.Context) (string, bool) {
+	tcpAddr, ok := rpccontext.CallerAddr(ctx).(*net.TCPAddr)
+	if !ok {
+		// Calls not via TCP/IP aren't limited
+		return "", false
+	}
+	return tcpAddr.IP.String(), true
+}
+
+func callerAgentID(ctx context.Context) (string, bool) {
+	if !rpccontext.CallerIsAgent(ctx) {
+		return "", false
+	}
+	id, ok := rpccontext.CallerID(ctx)
+	if !ok {
+		return "", false
+	}
+	return id.String(), true
+}
+
 type rateLimitsMiddleware struct {
 	limiters map[string]api.RateLimiter
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/api/middleware"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
@@ -139,6 +140,75 @@ func TestPerIPLimitGC(t *testing.T) {
 	require.Equal(t, 5, limiters.Count)
 }
 
+func TestPerAgentLimit(t *testing.T) {
+	limiters := NewFakeLimiters()
+
+	m := PerAgentLimit(10)
+
+	// Does not rate limit callers that are not agents
+	err := m.RateLimit(tcpCallerContext("1.1.1.1"), 11)
+	require.NoError(t, err)
+
+	// Once exceeding burst size for agent1
+	err = m.RateLimit(agentCallerContext("1.1.1.1", "agent1"), 11)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (11) exceeds burst size (10)")
+
+	// Once within burst size for agent1 and agent2, calling from the same IP
+	require.NoError(t, m.RateLimit(agentCallerContext("1.1.1.1", "agent1"), 1))
+	require.NoError(t, m.RateLimit(agentCallerContext("1.1.1.1", "agent2"), 2))
+
+	// There should be two rate limiters; agent1 and agent2
+	assert.Equal(t, 2, limiters.Count)
+	assert.Equal(t, []WaitNEvent{
+		{ID: 1, Count: 1},
+		{ID: 2, Count: 2},
+	}, limiters.WaitNEvents)
+}
+
+func TestAllLimits(t *testing.T) {
+	limiters := NewFakeLimiters()
+
+	m := AllLimits(PerIPLimit(10), PerAgentLimit(5))
+
+	// Only the per-ip limit applies to callers that are not agents
+	require.NoError(t, m.RateLimit(tcpCallerContext("1.1.1.1"), 6))
+
+	// Both limits apply to agents
+	err := m.RateLimit(agentCallerContext("1.1.1.1", "agent1"), 6)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (6) exceeds burst size (5)")
+	require.NoError(t, m.RateLimit(agentCallerContext("1.1.1.1", "agent1"), 5))
+
+	// There should be two rate limiters; 1.1.1.1 and agent1
+	assert.Equal(t, 2, limiters.Count)
+	assert.Equal(t, []WaitNEvent{
+		{ID: 1, Count: 6},
+		{ID: 1, Count: 6},
+		{ID: 1, Count: 5},
+		{ID: 2, Count: 5},
+	}, limiters.WaitNEvents)
+}
+
+func TestConfigurableLimit(t *testing.T) {
+	limiters := NewFakeLimiters()
+
+	m := ConfigurableLimit(PerCallLimit(1))
+
+	err := m.RateLimit(context.Background(), 2)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (2) exceeds burst size (1)")
+
+	// The new limits apply once the rate limiter is replaced
+	m.SetRateLimiter(PerCallLimit(2))
+	require.NoError(t, m.RateLimit(context.Background(), 2))
+
+	m.SetRateLimiter(DisabledLimit())
+	require.NoError(t, m.RateLimit(context.Background(), 99))
+
+	assert.Equal(t, 2, limiters.Count)
+	assert.Equal(t, []WaitNEvent{
+		{ID: 2, Count: 2},
+	}, limiters.WaitNEvents)
+}
+
 func TestRateLimits(t *testing.T) {
 	for _, tt := range []struct {
 		name           string
@@ -359,6 +429,12 @@ func tcpCallerContext(ip string) context.Context {
 	})
 }
 
+func agentCallerContext(ip, agentName string) context.Context {
+	ctx := tcpCallerContext(ip)
+	ctx = rpccontext.WithCallerID(ctx, spiffeid.RequireFromString("spiffe://example.org/spire/agent/"+agentName))
+	return rpccontext.WithAgentCaller(ctx)
+}
+
 func setupClock(t *testing.T) (*clock.Mock, func()) {
 	mockClk := clock.NewMock(t)
 	oldClk := clk
@@ -0,0 +1,68 @@
+package rpccontext
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+type auditFieldsKey struct{}
+type auditRequestKey struct{}
+
+type auditFields struct {
+	mu     sync.Mutex
+	fields logrus.Fields
+}
+
+// WithAuditFields returns a context that collects fields for the audit
+// record of the RPC.
+func WithAuditFields(ctx context.Context) context.Context {
+	return context.WithValue(ctx, auditFieldsKey{}, &auditFields{
+		fields: make(logrus.Fields),
+	})
+}
+
+// AuditFields returns a copy of the fields collected for the audit record of
+// the RPC. If the RPC is not audited, it returns false.
+func AuditFields(ctx context.Context) (logrus.Fields, bool) {
+	a, ok := ctx.Value(auditFieldsKey{}).(*auditFields)
+	if !ok {
+		return nil, false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	fields := make(logrus.Fields, len(a.fields))
+	for k, v := range a.fields {
+		fields[k] = v
+	}
+	return fields, true
+}
+
+// AddAuditFields adds fields to the audit record of the RPC. It is a no-op
+// if the RPC is not audited.
+func AddAuditFields(ctx context.Context, fields logrus.Fields) {
+	a, ok := ctx.Value(auditFieldsKey{}).(*auditFields)
+	if !ok {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for k, v := range fields {
+		a.fields[k] = v
+	}
+}
+
+// WithAuditRequest returns a context with the request message of a unary
+// RPC, so it can be included in the audit record.
+func WithAuditRequest(ctx context.Context, req interface{}) context.Context {
+	return context.WithValue(ctx, auditRequestKey{}, req)
+}
+
+// AuditRequest returns the request message of a unary RPC, if available.
+func AuditRequest(ctx context.Context) (interface{}, bool) {
+	req := ctx.Value(auditRequestKey{})
+	return req, req != nil
+}
@@ -1,18 +1,29 @@
 package svid
 
 import (
+	"bytes"
 	"context"
+	"crypto"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/golang/protobuf/jsonpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/entrydefaults"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/api/server/svid/v1"
 	"github.com/spiffe/spire/proto/spire/types"
@@ -112,7 +123,7 @@ func (s *Service) MintX509SVID(ctx context.Context, req *svid.MintX509SVIDReques
 }
 
 func (s *Service) MintJWTSVID(ctx context.Context, req *svid.MintJWTSVIDRequest) (*svid.MintJWTSVIDResponse, error) {
-	jwtsvid, err := s.mintJWTSVID(ctx, req.Id, req.Audience, req.Ttl)
+	jwtsvid, err := s.mintJWTSVID(ctx, req.Id, req.Audience, req.Ttl, time.Time{}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -168,6 +179,17 @@ func (s *Service) fetchEntries(ctx context.Context, log logrus.FieldLogger) (map
 	return entriesMap, nil
 }
 
+// callerAgentID returns the ID of the calling agent, which the issuance
+// metrics are broken down by, or an empty string if the caller is not an
+// agent.
+func callerAgentID(ctx context.Context) string {
+	if !rpccontext.CallerIsAgent(ctx) {
+		return ""
+	}
+	callerID, _ := rpccontext.CallerID(ctx)
+	return callerID.String()
+}
+
 // newX509SVID creates an X509-SVID using data from registration entry and key from CSR
 func (s *Service) newX509SVID(ctx context.Context, param *svid.NewX509SVIDParams, entries map[string]*types.Entry) *svid.BatchNewX509SVIDResponse_Result {
 	log := rpccontext.Logger(ctx)
@@ -214,11 +236,27 @@ func (s *Service) newX509SVID(ctx context.Context, param *svid.NewX509SVIDParams
 	}
 	log = log.WithField(telemetry.SPIFFEID, spiffeID.String())
 
+	if err := x509svid.ValidatePublicKey(entry.X509SvidKeyType, csr.PublicKey); err != nil {
+		return &svid.BatchNewX509SVIDResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "invalid CSR key type", err),
+		}
+	}
+
+	dnsList, err := entryDNSNames(spiffeID, entry)
+	if err != nil {
+		return &svid.BatchNewX509SVIDResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to render DNS name templates", err),
+		}
+	}
+
 	x509Svid, err := s.ca.SignX509SVID(ctx, ca.X509SVIDParams{
 		SpiffeID:  spiffeID.String(),
 		PublicKey: csr.PublicKey,
-		DNSList:   entry.DnsNames,
+		DNSList:   dnsList,
 		TTL:       time.Duration(entry.Ttl) * time.Second,
+		ExpiresAt: api.EntryExpiry(entry),
+		Subject:   x509SVIDSubject(entry.X509SvidSubject),
+		ParentID:  callerAgentID(ctx),
 	})
 	if err != nil {
 		return &svid.BatchNewX509SVIDResponse_Result{
@@ -236,7 +274,7 @@ func (s *Service) newX509SVID(ctx context.Context, param *svid.NewX509SVIDParams
 	}
 }
 
-func (s *Service) mintJWTSVID(ctx context.Context, protoID *types.SPIFFEID, audience []string, ttl int32) (*types.JWTSVID, error) {
+func (s *Service) mintJWTSVID(ctx context.Context, protoID *types.SPIFFEID, audience []string, ttl int32, expiresAt time.Time, claims map[string]string) (*types.JWTSVID, error) {
 	log := rpccontext.Logger(ctx)
 
 	id, err := api.TrustDomainWorkloadIDFromProto(s.td, protoID)
@@ -251,9 +289,12 @@ func (s *Service) mintJWTSVID(ctx context.Context, protoID *types.SPIFFEID, audi
 	}
 
 	token, err := s.ca.SignJWTSVID(ctx, ca.JWTSVIDParams{
-		SpiffeID: id.String(),
-		TTL:      time.Duration(ttl) * time.Second,
-		Audience: audience,
+		SpiffeID:  id.String(),
+		TTL:       time.Duration(ttl) * time.Second,
+		ExpiresAt: expiresAt,
+		Audience:  audience,
+		Claims:    claims,
+		ParentID:  callerAgentID(ctx),
 	})
 	if err != nil {
 		return nil, api.MakeErr(log, codes.Internal, "failed to sign JWT-SVID", err)
@@ -290,7 +331,14 @@ func (s *Service) NewJWTSVID(ctx context.Context, req *svid.NewJWTSVIDRequest) (
 		return nil, api.MakeErr(log, codes.NotFound, "entry not found or not authorized", nil)
 	}
 
-	jwtsvid, err := s.mintJWTSVID(ctx, entry.SpiffeId, req.Audience, entry.Ttl)
+	// Fall back to the default audience of the entry when the request does
+	// not specify one.
+	audience := req.Audience
+	if len(audience) == 0 {
+		audience = entry.JwtSvidAudience
+	}
+
+	jwtsvid, err := s.mintJWTSVID(ctx, entry.SpiffeId, audience, entry.Ttl, api.EntryExpiry(entry), entry.JwtSvidClaims)
 	if err != nil {
 		return nil, err
 	}
@@ -350,6 +398,99 @@ func (s *Service) NewDownstreamX509CA(ctx context.Context, req *svid.NewDownstre
 	}, nil
 }
 
+func (s *Service) ValidateJWTSVID(ctx context.Context, req *svid.ValidateJWTSVIDRequest) (*svid.ValidateJWTSVIDResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	switch {
+	case req.Audience == "":
+		return nil, api.MakeErr(log, codes.InvalidArgument, "missing audience", nil)
+	case req.Token == "":
+		return nil, api.MakeErr(log, codes.InvalidArgument, "missing token", nil)
+	}
+
+	log = log.WithField(telemetry.Audience, req.Audience)
+
+	rawID, claims, err := jwtsvid.ValidateToken(ctx, req.Token, datastoreKeyStore{ds: s.ds}, []string{req.Audience})
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "failed to validate JWT-SVID", err)
+	}
+
+	id, err := spiffeid.FromString(rawID)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "JWT-SVID has a malformed SPIFFE ID", err)
+	}
+	log = log.WithField(telemetry.SPIFFEID, id.String())
+
+	claimsStruct, err := claimsToStruct(claims)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to convert JWT-SVID claims", err)
+	}
+
+	// Only the registration entries of the trust domain are known to the
+	// server
+	var entries []*types.Entry
+	if id.TrustDomain() == s.td {
+		resp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			BySpiffeId: &wrappers.StringValue{
+				Value: id.String(),
+			},
+		})
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to list registration entries", err)
+		}
+		entries, err = api.RegistrationEntriesToProto(resp.Entries)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to convert registration entries", err)
+		}
+	}
+
+	return &svid.ValidateJWTSVIDResponse{
+		Id:      api.ProtoFromID(id),
+		Claims:  claimsStruct,
+		Entries: entries,
+	}, nil
+}
+
+// datastoreKeyStore looks up the JWT authorities used to validate JWT-SVIDs
+// in the bundles of the datastore, so JWT-SVIDs issued by federated trust
+// domains can be validated as well.
+type datastoreKeyStore struct {
+	ds datastore.DataStore
+}
+
+func (k datastoreKeyStore) FindPublicKey(ctx context.Context, trustDomainID, keyID string) (crypto.PublicKey, error) {
+	resp, err := k.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bundle: %v", err)
+	}
+	if resp.Bundle == nil {
+		return nil, fmt.Errorf("no keys found for trust domain %q", trustDomainID)
+	}
+
+	bundle, err := bundleutil.BundleFromProto(resp.Bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %v", err)
+	}
+	return jwtsvid.NewKeyStore(map[string]map[string]crypto.PublicKey{
+		trustDomainID: bundle.JWTSigningKeys(),
+	}).FindPublicKey(ctx, trustDomainID, keyID)
+}
+
+func claimsToStruct(claims map[string]interface{}) (*structpb.Struct, error) {
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(structpb.Struct)
+	if err := jsonpb.Unmarshal(bytes.NewReader(claimsJSON), s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
 func parseAndCheckCSR(ctx context.Context, csrBytes []byte) (*x509.CertificateRequest, error) {
 	log := rpccontext.Logger(ctx)
 
@@ -364,3 +505,38 @@ func parseAndCheckCSR(ctx context.Context, csrBytes []byte) (*x509.CertificateRe
 
 	return csr, nil
 }
+
+// entryDNSNames returns the DNS names of the entry followed by those rendered
+// from its DNS name templates.
+func entryDNSNames(spiffeID spiffeid.ID, entry *types.Entry) ([]string, error) {
+	if len(entry.DnsNameTemplates) == 0 {
+		return entry.DnsNames, nil
+	}
+
+	var parentID string
+	if entry.ParentId != nil {
+		id, err := spiffeid.New(entry.ParentId.TrustDomain, entry.ParentId.Path)
+		if err != nil {
+			return nil, err
+		}
+		parentID = id.String()
+	}
+
+	rendered, err := entrydefaults.RenderDNSNameTemplates(entry.DnsNameTemplates, spiffeID.String(), parentID)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]string(nil), entry.DnsNames...), rendered...), nil
+}
+
+func x509SVIDSubject(subject *types.X509SVIDSubject) pkix.Name {
+	if subject == nil {
+		return pkix.Name{}
+	}
+	return pkix.Name{
+		CommonName:         subject.CommonName,
+		Organization:       subject.Organization,
+		OrganizationalUnit: subject.OrganizationalUnit,
+		Country:            subject.Country,
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/api"
@@ -384,6 +385,137 @@ func TestServiceMintJWTSVID(t *testing.T) {
 	}
 }
 
+func TestServiceValidateJWTSVID(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	ctx := context.Background()
+	now := test.ca.Clock().Now().UTC()
+
+	jwtKey := test.ca.JWTKey()
+	jwtKeyPKIX, err := x509.MarshalPKIXPublicKey(jwtKey.Signer.Public())
+	require.NoError(t, err)
+	federatedKeyPKIX, err := x509.MarshalPKIXPublicKey(testKey.Public())
+	require.NoError(t, err)
+
+	_, err = test.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  td.IDString(),
+			JwtSigningKeys: []*common.PublicKey{{Kid: jwtKey.Kid, PkixBytes: jwtKeyPKIX}},
+		},
+	})
+	require.NoError(t, err)
+	_, err = test.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  "spiffe://federated.test",
+			JwtSigningKeys: []*common.PublicKey{{Kid: "federated", PkixBytes: federatedKeyPKIX}},
+		},
+	})
+	require.NoError(t, err)
+
+	entryResp, err := test.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:  agentID.String(),
+			SpiffeId:  workloadID.String(),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+	})
+	require.NoError(t, err)
+	entry, err := api.RegistrationEntryToProto(entryResp.Entry)
+	require.NoError(t, err)
+
+	mintResp, err := test.client.MintJWTSVID(ctx, &svidpb.MintJWTSVIDRequest{
+		Id:       api.ProtoFromID(workloadID),
+		Audience: []string{"AUDIENCE"},
+	})
+	require.NoError(t, err)
+	token := mintResp.Svid.Token
+
+	federatedID := spiffeid.Must("federated.test", "workload")
+	federatedToken, err := jwtsvid.NewSigner(jwtsvid.SignerConfig{Clock: test.ca.Clock()}).
+		SignToken(federatedID.String(), []string{"AUDIENCE"}, now.Add(time.Hour), testKey, "federated")
+	require.NoError(t, err)
+
+	unknownToken, err := jwtsvid.NewSigner(jwtsvid.SignerConfig{Clock: test.ca.Clock()}).
+		SignToken("spiffe://unknown.test/workload", []string{"AUDIENCE"}, now.Add(time.Hour), testKey, "unknown")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name            string
+		audience        string
+		token           string
+		code            codes.Code
+		err             string
+		logMsg          string
+		expectedID      spiffeid.ID
+		expectedEntries []*types.Entry
+	}{
+		{
+			name:            "success",
+			audience:        "AUDIENCE",
+			token:           token,
+			expectedID:      workloadID,
+			expectedEntries: []*types.Entry{entry},
+		},
+		{
+			name:       "success federated",
+			audience:   "AUDIENCE",
+			token:      federatedToken,
+			expectedID: federatedID,
+		},
+		{
+			name:   "missing audience",
+			token:  token,
+			code:   codes.InvalidArgument,
+			err:    "missing audience",
+			logMsg: "Invalid argument: missing audience",
+		},
+		{
+			name:     "missing token",
+			audience: "AUDIENCE",
+			code:     codes.InvalidArgument,
+			err:      "missing token",
+			logMsg:   "Invalid argument: missing token",
+		},
+		{
+			name:     "wrong audience",
+			audience: "OTHER",
+			token:    token,
+			code:     codes.InvalidArgument,
+			err:      "failed to validate JWT-SVID: expected audience in [\"OTHER\"]",
+			logMsg:   "Invalid argument: failed to validate JWT-SVID",
+		},
+		{
+			name:     "unknown trust domain",
+			audience: "AUDIENCE",
+			token:    unknownToken,
+			code:     codes.InvalidArgument,
+			err:      "failed to validate JWT-SVID: no keys found for trust domain \"spiffe://unknown.test\"",
+			logMsg:   "Invalid argument: failed to validate JWT-SVID",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := test.client.ValidateJWTSVID(ctx, &svidpb.ValidateJWTSVIDRequest{
+				Audience: tt.audience,
+				Token:    tt.token,
+			})
+			if tt.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.err)
+				require.Nil(t, resp)
+				require.Equal(t, tt.logMsg, test.logHook.LastEntry().Message)
+				return
+			}
+			require.NoError(t, err)
+
+			spiretest.RequireProtoEqual(t, api.ProtoFromID(tt.expectedID), resp.Id)
+			require.Equal(t, tt.expectedID.String(), resp.Claims.Fields["sub"].GetStringValue())
+			require.Equal(t, "AUDIENCE", resp.Claims.Fields["aud"].GetListValue().Values[0].GetStringValue())
+			spiretest.AssertProtoListEqual(t, tt.expectedEntries, resp.Entries)
+		})
+	}
+}
+
 func TestServiceNewJWTSVID(t *testing.T) {
 	test := setupServiceTest(t)
 	defer test.Cleanup()
@@ -399,13 +531,20 @@ func TestServiceNewJWTSVID(t *testing.T) {
 		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent-ttl"},
 		Ttl:      10,
 	}
+	entryWithJWTSVIDDefaults := &types.Entry{
+		Id:              "agent-entry-jwt-svid-defaults-id",
+		ParentId:        api.ProtoFromID(agentID),
+		SpiffeId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent-jwt-svid-defaults"},
+		JwtSvidClaims:   map[string]string{"role": "admin"},
+		JwtSvidAudience: []string{"DEFAULT-AUDIENCE"},
+	}
 	invalidEntry := &types.Entry{
 		Id:       "invalid-entry",
 		ParentId: api.ProtoFromID(agentID),
 		SpiffeId: &types.SPIFFEID{},
 	}
 
-	test.ef.entries = []*types.Entry{entry, entryWithTTL, invalidEntry}
+	test.ef.entries = []*types.Entry{entry, entryWithTTL, entryWithJWTSVIDDefaults, invalidEntry}
 	jwtKey := test.ca.JWTKey()
 	now := test.ca.Clock().Now().UTC()
 
@@ -423,6 +562,8 @@ func TestServiceNewJWTSVID(t *testing.T) {
 		failMinting    bool
 		failCallerID   bool
 		audience       []string
+		expectAudience []string
+		expectClaims   map[string]interface{}
 		rateLimiterErr error
 	}{
 		{
@@ -437,6 +578,21 @@ func TestServiceNewJWTSVID(t *testing.T) {
 			entry:     entryWithTTL,
 			expiresAt: now.Add(10 * time.Second),
 		},
+		{
+			name:           "success entry claims",
+			audience:       []string{"AUDIENCE"},
+			entry:          entryWithJWTSVIDDefaults,
+			expiresAt:      expiresAt,
+			expectAudience: []string{"AUDIENCE"},
+			expectClaims:   map[string]interface{}{"role": "admin"},
+		},
+		{
+			name:           "success entry default audience",
+			entry:          entryWithJWTSVIDDefaults,
+			expiresAt:      expiresAt,
+			expectAudience: []string{"DEFAULT-AUDIENCE"},
+			expectClaims:   map[string]interface{}{"role": "admin"},
+		},
 		{
 			name:     "no SPIFFE ID",
 			code:     codes.InvalidArgument,
@@ -516,14 +672,27 @@ func TestServiceNewJWTSVID(t *testing.T) {
 			require.NotNil(t, resp)
 			require.NotNil(t, resp.Svid)
 
+			expectAudience := tt.audience
+			if tt.expectAudience != nil {
+				expectAudience = tt.expectAudience
+			}
+
 			// Verify response
 			verifyJWTSVIDResponse(t, resp.Svid,
 				spiffeid.Must(tt.entry.SpiffeId.TrustDomain, tt.entry.SpiffeId.Path),
-				tt.audience,
+				expectAudience,
 				issuedAt,
 				tt.expiresAt,
 				expiresAt,
 				time.Duration(tt.entry.Ttl)*time.Second)
+
+			token, err := jwt.ParseSigned(resp.Svid.Token)
+			require.NoError(t, err)
+			claims := make(map[string]interface{})
+			require.NoError(t, token.UnsafeClaimsWithoutVerification(&claims))
+			for name, value := range tt.expectClaims {
+				require.Equal(t, value, claims[name])
+			}
 		})
 	}
 }
@@ -553,7 +722,27 @@ func TestServiceBatchNewX509SVID(t *testing.T) {
 		Id:       "invalid",
 		ParentId: api.ProtoFromID(agentID),
 	}
-	test.ef.entries = []*types.Entry{workloadEntry, dnsEntry, ttlEntry, invalidEntry}
+	templateEntry := &types.Entry{
+		Id:               "template",
+		ParentId:         api.ProtoFromID(agentID),
+		SpiffeId:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/ns/foo"},
+		DnsNames:         []string{"entryDNS1"},
+		DnsNameTemplates: []string{"{{ index .PathSegments 1 }}.{{ .TrustDomain }}"},
+		X509SvidSubject:  &types.X509SVIDSubject{Organization: []string{"ACME"}, OrganizationalUnit: []string{"payments"}},
+	}
+	badTemplateEntry := &types.Entry{
+		Id:               "bad-template",
+		ParentId:         api.ProtoFromID(agentID),
+		SpiffeId:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/foo"},
+		DnsNameTemplates: []string{"{{ index .PathSegments 1 }}"},
+	}
+	keyTypeEntry := &types.Entry{
+		Id:              "key-type",
+		ParentId:        api.ProtoFromID(agentID),
+		SpiffeId:        &types.SPIFFEID{TrustDomain: "example.org", Path: "/key-type"},
+		X509SvidKeyType: "rsa-2048",
+	}
+	test.ef.entries = []*types.Entry{workloadEntry, dnsEntry, ttlEntry, invalidEntry, templateEntry, badTemplateEntry, keyTypeEntry}
 
 	x509CA := test.ca.X509CA()
 	now := test.ca.Clock().Now().UTC()
@@ -562,8 +751,10 @@ func TestServiceBatchNewX509SVID(t *testing.T) {
 	require.Error(t, invalidCsrErr)
 
 	type expectResult struct {
-		entry  *types.Entry
-		status *types.Status
+		entry    *types.Entry
+		status   *types.Status
+		dnsNames []string
+		subject  string
 	}
 
 	for _, tt := range []struct {
@@ -603,6 +794,38 @@ func TestServiceBatchNewX509SVID(t *testing.T) {
 					entry: dnsEntry,
 				},
 			},
+		}, {
+			name: "dns name templates and subject",
+			reqs: []string{templateEntry.Id},
+			expectResults: []*expectResult{
+				{
+					entry:    templateEntry,
+					dnsNames: []string{"entryDNS1", "foo.example.org"},
+					subject:  "CN=entryDNS1,OU=payments,O=ACME",
+				},
+			},
+		}, {
+			name: "dns name template fails to render",
+			reqs: []string{badTemplateEntry.Id},
+			expectResults: []*expectResult{
+				{
+					status: &types.Status{
+						Code:    int32(codes.Internal),
+						Message: "failed to render DNS name templates",
+					},
+				},
+			},
+		}, {
+			name: "CSR key type does not match entry",
+			reqs: []string{keyTypeEntry.Id},
+			expectResults: []*expectResult{
+				{
+					status: &types.Status{
+						Code:    int32(codes.InvalidArgument),
+						Message: `invalid CSR key type: expected key type "rsa-2048" but got "ec-p256"`,
+					},
+				},
+			},
 		}, {
 			name: "keep request order",
 			reqs: []string{workloadEntry.Id, invalidEntry.Id, dnsEntry.Id},
@@ -912,6 +1135,12 @@ func TestServiceBatchNewX509SVID(t *testing.T) {
 				require.Equal(t, expiresAt, svid.NotAfter)
 				require.Equal(t, expiresAt.UTC().Unix(), result.Svid.ExpiresAt)
 
+				if expect.dnsNames != nil {
+					require.Equal(t, expect.dnsNames, svid.DNSNames)
+					require.Equal(t, expect.subject, svid.Subject.String())
+					continue
+				}
+
 				require.Equal(t, entry.DnsNames, svid.DNSNames)
 
 				expectedSubject := &pkix.Name{Country: []string{"US"}, Organization: []string{"SPIRE"}}
@@ -0,0 +1,630 @@
+package trustdomain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/api/trustdomain/v1"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	trustdomainpb "github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
+	"github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ctx               = context.Background()
+	serverTrustDomain = spiffeid.RequireTrustDomainFromString("example.org")
+
+	webFR = &types.FederationRelationship{
+		TrustDomain:           "domain1.org",
+		BundleEndpointUrl:     "https://domain1.org/bundle",
+		BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+	}
+	spiffeFR = &types.FederationRelationship{
+		TrustDomain:       "domain2.org",
+		BundleEndpointUrl: "https://domain2.org/bundle",
+		BundleEndpointProfile: &types.FederationRelationship_HttpsSpiffe{
+			HttpsSpiffe: &types.HTTPSSPIFFEProfile{EndpointSpiffeId: "spiffe://domain2.org/spire/server"},
+		},
+	}
+)
+
+func TestListFederationRelationships(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createFederationRelationship(t, webFR)
+	test.createFederationRelationship(t, spiffeFR)
+
+	for _, tt := range []struct {
+		name        string
+		pageSize    int32
+		expectFRs   []*types.FederationRelationship
+		expectToken bool
+		dsErr       error
+		expectCode  codes.Code
+		expectMsg   string
+		expectLogs  []spiretest.LogEntry
+	}{
+		{
+			name:      "success",
+			expectFRs: []*types.FederationRelationship{webFR, spiffeFR},
+		},
+		{
+			name:        "success with pagination",
+			pageSize:    1,
+			expectFRs:   []*types.FederationRelationship{webFR},
+			expectToken: true,
+		},
+		{
+			name:       "datastore failure",
+			dsErr:      errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to list federation relationships: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to list federation relationships",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.logHook.Reset()
+			test.ds.SetNextError(tt.dsErr)
+
+			resp, err := test.client.ListFederationRelationships(ctx, &trustdomainpb.ListFederationRelationshipsRequest{
+				PageSize: tt.pageSize,
+			})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectFRs, resp.FederationRelationships)
+			require.Equal(t, tt.expectToken, resp.NextPageToken != "")
+		})
+	}
+}
+
+func TestGetFederationRelationship(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createFederationRelationship(t, spiffeFR)
+
+	for _, tt := range []struct {
+		name        string
+		trustDomain string
+		dsErr       error
+		expectFR    *types.FederationRelationship
+		expectCode  codes.Code
+		expectMsg   string
+		expectLogs  []spiretest.LogEntry
+	}{
+		{
+			name:        "success",
+			trustDomain: "domain2.org",
+			expectFR:    spiffeFR,
+		},
+		{
+			name:        "malformed trust domain",
+			trustDomain: "malformed id",
+			expectCode:  codes.InvalidArgument,
+			expectMsg:   `trust domain argument is not valid: spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: trust domain argument is not valid",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "malformed id",
+						logrus.ErrorKey:         `spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`,
+					},
+				},
+			},
+		},
+		{
+			name:        "not found",
+			trustDomain: "domain1.org",
+			expectCode:  codes.NotFound,
+			expectMsg:   "federation relationship not found",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Federation relationship not found",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain1.org",
+					},
+				},
+			},
+		},
+		{
+			name:        "datastore failure",
+			trustDomain: "domain2.org",
+			dsErr:       errors.New("oh no"),
+			expectCode:  codes.Internal,
+			expectMsg:   "failed to fetch federation relationship: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to fetch federation relationship",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain2.org",
+						logrus.ErrorKey:         "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.logHook.Reset()
+			test.ds.SetNextError(tt.dsErr)
+
+			fr, err := test.client.GetFederationRelationship(ctx, &trustdomainpb.GetFederationRelationshipRequest{
+				TrustDomain: tt.trustDomain,
+			})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, fr)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, tt.expectFR, fr)
+		})
+	}
+}
+
+func TestBatchCreateFederationRelationship(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		existing      []*types.FederationRelationship
+		toCreate      []*types.FederationRelationship
+		dsErr         error
+		expectResults []*trustdomainpb.BatchCreateFederationRelationshipResponse_Result
+		expectLogs    []spiretest.LogEntry
+	}{
+		{
+			name:     "success",
+			toCreate: []*types.FederationRelationship{webFR, spiffeFR},
+			expectResults: []*trustdomainpb.BatchCreateFederationRelationshipResponse_Result{
+				{Status: api.OK(), FederationRelationship: webFR},
+				{Status: api.OK(), FederationRelationship: spiffeFR},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Federation relationship created",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain1.org",
+					},
+				},
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Federation relationship created",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain2.org",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid relationship",
+			toCreate: []*types.FederationRelationship{
+				{TrustDomain: "domain1.org", BundleEndpointUrl: "http://domain1.org/bundle"},
+			},
+			expectResults: []*trustdomainpb.BatchCreateFederationRelationshipResponse_Result{
+				{Status: api.CreateStatus(codes.InvalidArgument, "failed to convert federation relationship: bundle endpoint URL must use the https scheme")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: failed to convert federation relationship",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain1.org",
+						logrus.ErrorKey:         "bundle endpoint URL must use the https scheme",
+					},
+				},
+			},
+		},
+		{
+			name: "server trust domain",
+			toCreate: []*types.FederationRelationship{
+				{
+					TrustDomain:           "example.org",
+					BundleEndpointUrl:     "https://example.org/bundle",
+					BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+				},
+			},
+			expectResults: []*trustdomainpb.BatchCreateFederationRelationshipResponse_Result{
+				{Status: api.CreateStatus(codes.InvalidArgument, "unable to create federation relationship for the server's own trust domain")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: unable to create federation relationship for the server's own trust domain",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "example.org",
+					},
+				},
+			},
+		},
+		{
+			name:     "already exists",
+			existing: []*types.FederationRelationship{webFR},
+			toCreate: []*types.FederationRelationship{webFR},
+			expectResults: []*trustdomainpb.BatchCreateFederationRelationshipResponse_Result{
+				{Status: api.CreateStatus(codes.AlreadyExists, "federation relationship already exists")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Federation relationship already exists",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain1.org",
+					},
+				},
+			},
+		},
+		{
+			name:     "datastore failure",
+			toCreate: []*types.FederationRelationship{webFR},
+			dsErr:    errors.New("oh no"),
+			expectResults: []*trustdomainpb.BatchCreateFederationRelationshipResponse_Result{
+				{Status: api.CreateStatus(codes.Internal, "failed to create federation relationship: oh no")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to create federation relationship",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain1.org",
+						logrus.ErrorKey:         "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			for _, fr := range tt.existing {
+				test.createFederationRelationship(t, fr)
+			}
+			test.ds.SetNextError(tt.dsErr)
+
+			resp, err := test.client.BatchCreateFederationRelationship(ctx, &trustdomainpb.BatchCreateFederationRelationshipRequest{
+				FederationRelationships: tt.toCreate,
+			})
+			require.NoError(t, err)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			spiretest.RequireProtoEqual(t, &trustdomainpb.BatchCreateFederationRelationshipResponse{
+				Results: tt.expectResults,
+			}, resp)
+		})
+	}
+}
+
+func TestBatchUpdateFederationRelationship(t *testing.T) {
+	updatedFR := &types.FederationRelationship{
+		TrustDomain:           "domain2.org",
+		BundleEndpointUrl:     "https://new.domain2.org/bundle",
+		BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+	}
+
+	for _, tt := range []struct {
+		name          string
+		toUpdate      []*types.FederationRelationship
+		inputMask     *types.FederationRelationshipMask
+		dsErr         error
+		expectResults []*trustdomainpb.BatchUpdateFederationRelationshipResponse_Result
+		expectLogs    []spiretest.LogEntry
+	}{
+		{
+			name:     "success",
+			toUpdate: []*types.FederationRelationship{updatedFR},
+			expectResults: []*trustdomainpb.BatchUpdateFederationRelationshipResponse_Result{
+				{Status: api.OK(), FederationRelationship: updatedFR},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Federation relationship updated",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain2.org",
+					},
+				},
+			},
+		},
+		{
+			name: "success with mask",
+			toUpdate: []*types.FederationRelationship{
+				{
+					TrustDomain:       "domain2.org",
+					BundleEndpointUrl: "https://new.domain2.org/bundle",
+				},
+			},
+			inputMask: &types.FederationRelationshipMask{BundleEndpointUrl: true},
+			expectResults: []*trustdomainpb.BatchUpdateFederationRelationshipResponse_Result{
+				{
+					Status: api.OK(),
+					FederationRelationship: &types.FederationRelationship{
+						TrustDomain:           "domain2.org",
+						BundleEndpointUrl:     "https://new.domain2.org/bundle",
+						BundleEndpointProfile: spiffeFR.BundleEndpointProfile,
+					},
+				},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Federation relationship updated",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain2.org",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid relationship",
+			toUpdate: []*types.FederationRelationship{
+				{TrustDomain: "domain2.org", BundleEndpointUrl: "https://domain2.org/bundle"},
+			},
+			expectResults: []*trustdomainpb.BatchUpdateFederationRelationshipResponse_Result{
+				{Status: api.CreateStatus(codes.InvalidArgument, "failed to convert federation relationship: missing bundle endpoint profile")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: failed to convert federation relationship",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain2.org",
+						logrus.ErrorKey:         "missing bundle endpoint profile",
+					},
+				},
+			},
+		},
+		{
+			name: "not found",
+			toUpdate: []*types.FederationRelationship{
+				{
+					TrustDomain:           "domain3.org",
+					BundleEndpointUrl:     "https://domain3.org/bundle",
+					BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+				},
+			},
+			expectResults: []*trustdomainpb.BatchUpdateFederationRelationshipResponse_Result{
+				{Status: api.CreateStatus(codes.NotFound, "federation relationship not found")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Federation relationship not found",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain3.org",
+					},
+				},
+			},
+		},
+		{
+			name:     "datastore failure",
+			toUpdate: []*types.FederationRelationship{updatedFR},
+			dsErr:    errors.New("oh no"),
+			expectResults: []*trustdomainpb.BatchUpdateFederationRelationshipResponse_Result{
+				{Status: api.CreateStatus(codes.Internal, "failed to update federation relationship: oh no")},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to update federation relationship",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain2.org",
+						logrus.ErrorKey:         "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.createFederationRelationship(t, spiffeFR)
+			test.ds.SetNextError(tt.dsErr)
+
+			resp, err := test.client.BatchUpdateFederationRelationship(ctx, &trustdomainpb.BatchUpdateFederationRelationshipRequest{
+				FederationRelationships: tt.toUpdate,
+				InputMask:               tt.inputMask,
+			})
+			require.NoError(t, err)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			spiretest.RequireProtoEqual(t, &trustdomainpb.BatchUpdateFederationRelationshipResponse{
+				Results: tt.expectResults,
+			}, resp)
+		})
+	}
+}
+
+func TestBatchDeleteFederationRelationship(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		trustDomains  []string
+		dsErr         error
+		expectResults []*trustdomainpb.BatchDeleteFederationRelationshipResponse_Result
+		expectLogs    []spiretest.LogEntry
+		expectRemain  []*types.FederationRelationship
+	}{
+		{
+			name:         "success",
+			trustDomains: []string{"domain1.org"},
+			expectResults: []*trustdomainpb.BatchDeleteFederationRelationshipResponse_Result{
+				{Status: api.OK(), TrustDomain: "domain1.org"},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Federation relationship deleted",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain1.org",
+					},
+				},
+			},
+			expectRemain: []*types.FederationRelationship{spiffeFR},
+		},
+		{
+			name:         "malformed trust domain",
+			trustDomains: []string{"malformed id"},
+			expectResults: []*trustdomainpb.BatchDeleteFederationRelationshipResponse_Result{
+				{
+					Status:      api.CreateStatus(codes.InvalidArgument, `trust domain argument is not valid: spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`),
+					TrustDomain: "malformed id",
+				},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: trust domain argument is not valid",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "malformed id",
+						logrus.ErrorKey:         `spiffeid: unable to parse: parse "spiffe://malformed id": invalid character " " in host name`,
+					},
+				},
+			},
+			expectRemain: []*types.FederationRelationship{webFR, spiffeFR},
+		},
+		{
+			name:         "not found",
+			trustDomains: []string{"domain3.org"},
+			expectResults: []*trustdomainpb.BatchDeleteFederationRelationshipResponse_Result{
+				{
+					Status:      api.CreateStatus(codes.NotFound, "federation relationship not found"),
+					TrustDomain: "domain3.org",
+				},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Federation relationship not found",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain3.org",
+					},
+				},
+			},
+			expectRemain: []*types.FederationRelationship{webFR, spiffeFR},
+		},
+		{
+			name:         "datastore failure",
+			trustDomains: []string{"domain1.org"},
+			dsErr:        status.Error(codes.Internal, "oh no"),
+			expectResults: []*trustdomainpb.BatchDeleteFederationRelationshipResponse_Result{
+				{
+					Status:      api.CreateStatus(codes.Internal, "failed to delete federation relationship: oh no"),
+					TrustDomain: "domain1.org",
+				},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to delete federation relationship",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain1.org",
+						logrus.ErrorKey:         "rpc error: code = Internal desc = oh no",
+					},
+				},
+			},
+			expectRemain: []*types.FederationRelationship{webFR, spiffeFR},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.createFederationRelationship(t, webFR)
+			test.createFederationRelationship(t, spiffeFR)
+			test.ds.SetNextError(tt.dsErr)
+
+			resp, err := test.client.BatchDeleteFederationRelationship(ctx, &trustdomainpb.BatchDeleteFederationRelationshipRequest{
+				TrustDomains: tt.trustDomains,
+			})
+			require.NoError(t, err)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			spiretest.RequireProtoEqual(t, &trustdomainpb.BatchDeleteFederationRelationshipResponse{
+				Results: tt.expectResults,
+			}, resp)
+
+			listResp, err := test.client.ListFederationRelationships(ctx, &trustdomainpb.ListFederationRelationshipsRequest{})
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectRemain, listResp.FederationRelationships)
+		})
+	}
+}
+
+type serviceTest struct {
+	client  trustdomainpb.TrustDomainClient
+	ds      *fakedatastore.DataStore
+	logHook *test.Hook
+	done    func()
+}
+
+func (c *serviceTest) Cleanup() {
+	c.done()
+}
+
+func (c *serviceTest) createFederationRelationship(t *testing.T, fr *types.FederationRelationship) {
+	dsFR, err := api.ProtoToFederationRelationship(fr)
+	require.NoError(t, err)
+
+	_, err = c.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: dsFR,
+	})
+	require.NoError(t, err)
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ds := fakedatastore.New(t)
+	service := trustdomain.New(trustdomain.Config{
+		DataStore:   ds,
+		TrustDomain: serverTrustDomain,
+	})
+
+	log, logHook := test.NewNullLogger()
+	log.Level = logrus.DebugLevel
+	registerFn := func(s *grpc.Server) {
+		trustdomain.RegisterService(s, service)
+	}
+
+	contextFn := func(ctx context.Context) context.Context {
+		return rpccontext.WithLogger(ctx, log)
+	}
+
+	conn, done := spiretest.NewAPIServer(t, registerFn, contextFn)
+	return &serviceTest{
+		client:  trustdomainpb.NewTrustDomainClient(conn),
+		ds:      ds,
+		logHook: logHook,
+		done:    done,
+	}
+}
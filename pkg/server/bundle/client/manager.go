@@ -2,6 +2,8 @@ package client
 
 import (
 	"context"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/andres-erbsen/clock"
@@ -9,7 +11,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
-	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 )
 
@@ -19,6 +21,14 @@ const (
 	// bundle. It is important to try more than once within a refresh hint
 	// period so we can be resilient to temporary downtime or failures.
 	attemptsPerRefreshHint = 4
+
+	// relationshipsRefreshInterval is how often federation relationships are
+	// reloaded from the datastore.
+	relationshipsRefreshInterval = 10 * time.Second
+
+	// relationshipsPageSize is the page size used to list federation
+	// relationships from the datastore.
+	relationshipsPageSize = 100
 )
 
 type TrustDomainConfig struct {
@@ -45,11 +55,32 @@ type ManagerConfig struct {
 	newBundleUpdater func(BundleUpdaterConfig) BundleUpdater
 }
 
+// Manager keeps the federated bundles up to date. It maintains a bundle
+// updater for each trust domain configured statically, plus one for each
+// federation relationship stored in the datastore. Federation relationships
+// are reloaded periodically so that relationships managed through the API
+// take effect without a restart. Static configuration takes precedence over
+// a stored relationship for the same trust domain.
 type Manager struct {
-	log      logrus.FieldLogger
-	metrics  telemetry.Metrics
-	clock    clock.Clock
-	updaters map[string]BundleUpdater
+	log              logrus.FieldLogger
+	metrics          telemetry.Metrics
+	ds               datastore.DataStore
+	clock            clock.Clock
+	newBundleUpdater func(BundleUpdaterConfig) BundleUpdater
+
+	// trustDomainsMtx protects the static trust domain configuration, which
+	// can be replaced while the manager runs
+	trustDomainsMtx     sync.Mutex
+	trustDomains        map[string]TrustDomainConfig
+	trustDomainsChanged chan struct{}
+
+	updaters map[string]*runningUpdater
+}
+
+type runningUpdater struct {
+	config TrustDomainConfig
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 func NewManager(config ManagerConfig) *Manager {
@@ -60,35 +91,143 @@ func NewManager(config ManagerConfig) *Manager {
 		config.newBundleUpdater = NewBundleUpdater
 	}
 
-	updaters := make(map[string]BundleUpdater)
-	for trustDomain, trustDomainConfig := range config.TrustDomains {
-		updaters[trustDomain] = config.newBundleUpdater(BundleUpdaterConfig{
-			TrustDomainConfig: trustDomainConfig,
-			TrustDomain:       trustDomain,
-			DataStore:         config.DataStore,
-		})
+	return &Manager{
+		log:                 config.Log,
+		metrics:             config.Metrics,
+		ds:                  config.DataStore,
+		clock:               config.Clock,
+		trustDomains:        config.TrustDomains,
+		trustDomainsChanged: make(chan struct{}, 1),
+		newBundleUpdater:    config.newBundleUpdater,
+		updaters:            make(map[string]*runningUpdater),
 	}
+}
 
-	return &Manager{
-		log:      config.Log,
-		metrics:  config.Metrics,
-		clock:    config.Clock,
-		updaters: updaters,
+// SetTrustDomains replaces the statically configured trust domains. The
+// bundle updaters are synced right away.
+func (m *Manager) SetTrustDomains(trustDomains map[string]TrustDomainConfig) {
+	m.trustDomainsMtx.Lock()
+	m.trustDomains = trustDomains
+	m.trustDomainsMtx.Unlock()
+
+	select {
+	case m.trustDomainsChanged <- struct{}{}:
+	default:
 	}
 }
 
 func (m *Manager) Run(ctx context.Context) error {
-	var tasks []func(context.Context) error
+	defer m.stopUpdaters(m.updaters)
+
+	ticker := m.clock.Ticker(relationshipsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		m.syncUpdaters(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-m.trustDomainsChanged:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// syncUpdaters starts updaters for new trust domains, restarts the updaters
+// whose configuration changed, and stops the updaters for trust domains that
+// are no longer federated with.
+func (m *Manager) syncUpdaters(ctx context.Context) {
+	trustDomains, err := m.loadTrustDomains(ctx)
+	if err != nil {
+		// Keep the current updaters running until relationships can be
+		// loaded again.
+		m.log.WithError(err).Error("Failed to load federation relationships")
+		return
+	}
+
+	stale := make(map[string]*runningUpdater)
 	for trustDomain, updater := range m.updaters {
-		// alias the loop variables that are used by the closure
-		trustDomain := trustDomain
-		updater := updater
-		tasks = append(tasks, func(ctx context.Context) error {
-			return m.runUpdater(ctx, trustDomain, updater)
-		})
+		if config, ok := trustDomains[trustDomain]; !ok || config != updater.config {
+			stale[trustDomain] = updater
+			delete(m.updaters, trustDomain)
+		}
+	}
+	m.stopUpdaters(stale)
+
+	for trustDomain, config := range trustDomains {
+		if _, ok := m.updaters[trustDomain]; ok {
+			continue
+		}
+		m.log.WithField("trust_domain", trustDomain).Debug("Starting bundle updater")
+		m.updaters[trustDomain] = m.startUpdater(ctx, trustDomain, config)
+	}
+}
+
+func (m *Manager) loadTrustDomains(ctx context.Context) (map[string]TrustDomainConfig, error) {
+	trustDomains := make(map[string]TrustDomainConfig)
+
+	req := &datastore.ListFederationRelationshipsRequest{
+		Pagination: &datastore.Pagination{
+			PageSize: relationshipsPageSize,
+		},
+	}
+	for {
+		resp, err := m.ds.ListFederationRelationships(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, fr := range resp.FederationRelationships {
+			trustDomain := strings.TrimPrefix(fr.TrustDomainId, "spiffe://")
+			trustDomains[trustDomain] = TrustDomainConfig{
+				EndpointAddress:  strings.TrimPrefix(fr.BundleEndpointUrl, "https://"),
+				EndpointSpiffeID: fr.EndpointSpiffeId,
+				UseWebPKI:        fr.BundleEndpointProfile == api.BundleEndpointProfileHTTPSWeb,
+			}
+		}
+		if resp.Pagination == nil || resp.Pagination.Token == "" {
+			break
+		}
+		req.Pagination = resp.Pagination
+	}
+
+	m.trustDomainsMtx.Lock()
+	defer m.trustDomainsMtx.Unlock()
+	for trustDomain, config := range m.trustDomains {
+		trustDomains[trustDomain] = config
+	}
+	return trustDomains, nil
+}
+
+func (m *Manager) startUpdater(ctx context.Context, trustDomain string, config TrustDomainConfig) *runningUpdater {
+	updater := m.newBundleUpdater(BundleUpdaterConfig{
+		TrustDomainConfig: config,
+		TrustDomain:       trustDomain,
+		DataStore:         m.ds,
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = m.runUpdater(ctx, trustDomain, updater)
+	}()
+
+	return &runningUpdater{
+		config: config,
+		cancel: cancel,
+		done:   done,
 	}
+}
 
-	return util.RunTasks(ctx, tasks...)
+func (m *Manager) stopUpdaters(updaters map[string]*runningUpdater) {
+	for trustDomain, updater := range updaters {
+		m.log.WithField("trust_domain", trustDomain).Debug("Stopping bundle updater")
+		updater.cancel()
+	}
+	for _, updater := range updaters {
+		<-updater.done
+	}
 }
 
 func (m *Manager) runUpdater(ctx context.Context, trustDomain string, updater BundleUpdater) error {
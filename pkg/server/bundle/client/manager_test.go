@@ -7,9 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/stretchr/testify/require"
@@ -70,6 +72,144 @@ func TestManager(t *testing.T) {
 	}
 }
 
+func TestManagerFederationRelationships(t *testing.T) {
+	clock := clock.NewMock(t)
+	log, hook := test.NewNullLogger()
+	log.Level = logrus.DebugLevel
+	ds := fakedatastore.New(t)
+
+	fr := &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://domain.test",
+		BundleEndpointUrl:     "https://domain.test/bundle",
+		BundleEndpointProfile: "https_web",
+	}
+	_, err := ds.CreateFederationRelationship(context.Background(), &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: fr,
+	})
+	require.NoError(t, err)
+
+	staticConfig := TrustDomainConfig{
+		EndpointAddress:  "static.test:8443",
+		EndpointSpiffeID: "spiffe://static.test/spire/server",
+	}
+
+	configCh := make(chan BundleUpdaterConfig, 10)
+	done := startManagerWithConfig(t, ManagerConfig{
+		Log:       log,
+		Metrics:   telemetry.Blackhole{},
+		DataStore: ds,
+		Clock:     clock,
+		TrustDomains: map[string]TrustDomainConfig{
+			"static.test": staticConfig,
+		},
+		newBundleUpdater: func(config BundleUpdaterConfig) BundleUpdater {
+			configCh <- config
+			return newFakeBundleUpdater(nil, nil)
+		},
+	})
+	defer done()
+
+	clock.WaitForTicker(time.Minute, "timed out waiting for the relationships ticker")
+
+	// Updaters are started for both the static configuration and the stored
+	// relationship.
+	requireUpdaterConfigs(t, configCh, map[string]TrustDomainConfig{
+		"domain.test": {
+			EndpointAddress: "domain.test/bundle",
+			UseWebPKI:       true,
+		},
+		"static.test": staticConfig,
+	})
+
+	// Changing the relationship restarts its updater with the new
+	// configuration.
+	fr.BundleEndpointProfile = "https_spiffe"
+	fr.EndpointSpiffeId = "spiffe://domain.test/bundle-server"
+	_, err = ds.UpdateFederationRelationship(context.Background(), &datastore.UpdateFederationRelationshipRequest{
+		FederationRelationship: fr,
+	})
+	require.NoError(t, err)
+	clock.Add(relationshipsRefreshInterval)
+
+	requireUpdaterConfigs(t, configCh, map[string]TrustDomainConfig{
+		"domain.test": {
+			EndpointAddress:  "domain.test/bundle",
+			EndpointSpiffeID: "spiffe://domain.test/bundle-server",
+		},
+	})
+
+	// Deleting the relationship stops its updater. The static configuration
+	// is not affected.
+	_, err = ds.DeleteFederationRelationship(context.Background(), &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://domain.test",
+	})
+	require.NoError(t, err)
+	clock.Add(relationshipsRefreshInterval)
+
+	require.Eventually(t, func() bool {
+		return countLogs(hook, "Stopping bundle updater", "domain.test") == 2
+	}, time.Minute, 10*time.Millisecond)
+	require.Zero(t, countLogs(hook, "Stopping bundle updater", "static.test"))
+	require.Len(t, configCh, 0)
+}
+
+func TestManagerSetTrustDomains(t *testing.T) {
+	clock := clock.NewMock(t)
+	log, hook := test.NewNullLogger()
+	log.Level = logrus.DebugLevel
+
+	staticConfig := TrustDomainConfig{
+		EndpointAddress:  "static.test:8443",
+		EndpointSpiffeID: "spiffe://static.test/spire/server",
+	}
+	otherConfig := TrustDomainConfig{
+		EndpointAddress: "other.test:443",
+		UseWebPKI:       true,
+	}
+
+	configCh := make(chan BundleUpdaterConfig, 10)
+	manager := NewManager(ManagerConfig{
+		Log:       log,
+		Metrics:   telemetry.Blackhole{},
+		DataStore: fakedatastore.New(t),
+		Clock:     clock,
+		TrustDomains: map[string]TrustDomainConfig{
+			"static.test": staticConfig,
+		},
+		newBundleUpdater: func(config BundleUpdaterConfig) BundleUpdater {
+			configCh <- config
+			return newFakeBundleUpdater(nil, nil)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- manager.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		require.EqualError(t, <-errCh, "context canceled")
+	}()
+
+	requireUpdaterConfigs(t, configCh, map[string]TrustDomainConfig{
+		"static.test": staticConfig,
+	})
+
+	// Replacing the static configuration syncs the updaters without waiting
+	// for the refresh interval.
+	manager.SetTrustDomains(map[string]TrustDomainConfig{
+		"other.test": otherConfig,
+	})
+
+	requireUpdaterConfigs(t, configCh, map[string]TrustDomainConfig{
+		"other.test": otherConfig,
+	})
+	require.Eventually(t, func() bool {
+		return countLogs(hook, "Stopping bundle updater", "static.test") == 1
+	}, time.Minute, 10*time.Millisecond)
+}
+
 func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) func() {
 	log, _ := test.NewNullLogger()
 	ds := fakedatastore.New(t)
@@ -79,7 +219,7 @@ func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) func()
 		EndpointSpiffeID: "ENDPOINT_SPIFFEID",
 	}
 
-	manager := NewManager(ManagerConfig{
+	return startManagerWithConfig(t, ManagerConfig{
 		Log:       log,
 		Metrics:   telemetry.Blackhole{},
 		DataStore: ds,
@@ -93,6 +233,10 @@ func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) func()
 			return updater
 		},
 	})
+}
+
+func startManagerWithConfig(t *testing.T, config ManagerConfig) func() {
+	manager := NewManager(config)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	errCh := make(chan error, 1)
@@ -116,6 +260,29 @@ func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) func()
 	}
 }
 
+func requireUpdaterConfigs(t *testing.T, configCh chan BundleUpdaterConfig, expected map[string]TrustDomainConfig) {
+	actual := make(map[string]TrustDomainConfig)
+	for len(actual) < len(expected) {
+		select {
+		case config := <-configCh:
+			actual[config.TrustDomain] = config.TrustDomainConfig
+		case <-time.After(time.Second * 10):
+			require.Fail(t, "timed out waiting for updater creation")
+		}
+	}
+	require.Equal(t, expected, actual)
+}
+
+func countLogs(hook *test.Hook, message, trustDomain string) int {
+	count := 0
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == message && entry.Data["trust_domain"] == trustDomain {
+			count++
+		}
+	}
+	return count
+}
+
 func waitForRefresh(t *testing.T, clock *clock.Mock, expectedDuration time.Duration) {
 	select {
 	case d := <-clock.TimerCh():
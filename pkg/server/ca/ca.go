@@ -1,6 +1,7 @@
 package ca
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/rand"
@@ -8,6 +9,7 @@ import (
 	"crypto/x509/pkix"
 	"fmt"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,7 +20,9 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/dnspolicy"
 	"github.com/zeebo/errs"
+	"gopkg.in/square/go-jose.v2"
 )
 
 const (
@@ -50,12 +54,20 @@ type X509SVIDParams struct {
 	// lifetime of the certificate will be capped to that of the signing cert.
 	TTL time.Duration
 
+	// ExpiresAt, if set, caps the lifetime of the SVID, e.g. to the expiry
+	// of the registration entry it is signed for.
+	ExpiresAt time.Time
+
 	// DNSList is used to add DNS SAN's to the X509 SVID. The first entry
 	// is also added as the CN.
 	DNSList []string
 
 	// Subject of the SVID. Default subject is used if it is empty.
 	Subject pkix.Name
+
+	// ParentID is the SPIFFE ID of the agent the SVID is issued to, if any.
+	// It is only used to break down the issuance metrics.
+	ParentID string
 }
 
 // X509CASVIDParams are parameters relevant to X509 CA SVID creation
@@ -80,8 +92,20 @@ type JWTSVIDParams struct {
 	// lifetime of the certificate will be capped to that of the signing cert.
 	TTL time.Duration
 
+	// ExpiresAt, if set, caps the lifetime of the SVID, e.g. to the expiry
+	// of the registration entry it is signed for.
+	ExpiresAt time.Time
+
 	// Audience is used for audience claims
 	Audience []string
+
+	// Claims are additional claims included in the SVID. Registered claims
+	// (e.g. sub, aud, exp) cannot be overridden.
+	Claims map[string]string
+
+	// ParentID is the SPIFFE ID of the agent the SVID is issued to, if any.
+	// It is only used to break down the issuance metrics.
+	ParentID string
 }
 
 type X509CA struct {
@@ -92,8 +116,9 @@ type X509CA struct {
 	Certificate *x509.Certificate
 
 	// UpstreamChain contains the CA certificate and intermediates necessary to
-	// chain back to the upstream trust bundle. It is only set if the CA is
-	// signed by an UpstreamCA.
+	// chain back to the upstream trust bundle. When the full upstream chain is
+	// requested, it also contains the upstream root. It is only set if the CA
+	// is signed by an UpstreamCA.
 	UpstreamChain []*x509.Certificate
 }
 
@@ -117,6 +142,28 @@ type Config struct {
 	JWTIssuer   string
 	Clock       clock.Clock
 	CASubject   pkix.Name
+
+	// JWTSigningAlgorithm is the preferred algorithm used to sign JWT-SVIDs.
+	// If unset or incompatible with the JWT signing key, the algorithm is
+	// determined by the key type.
+	JWTSigningAlgorithm string
+
+	// CAMaxPathLen, if set, is the path length constraint of the X509 CA
+	// certificates signed for downstream servers.
+	CAMaxPathLen *int
+
+	// DNSNamePolicy, if set, restricts the DNS names of X509-SVIDs.
+	DNSNamePolicy *dnspolicy.Policy
+
+	// IssuanceMetricsPrefixes are the SPIFFE ID prefixes the issuance
+	// metrics are broken down by. SVIDs are labeled with the longest
+	// matching prefix.
+	IssuanceMetricsPrefixes []string
+
+	// Backdate is how far the NotBefore of X509-SVIDs is set before the time
+	// they are signed, so that verifiers with a clock behind the one of the
+	// server accept them. Defaults to 10 seconds.
+	Backdate time.Duration
 }
 
 type CA struct {
@@ -139,12 +186,16 @@ func NewCA(config Config) *CA {
 	if config.Clock == nil {
 		config.Clock = clock.New()
 	}
+	if config.Backdate <= 0 {
+		config.Backdate = backdate
+	}
 
 	return &CA{
 		c: config,
 		jwtSigner: jwtsvid.NewSigner(jwtsvid.SignerConfig{
-			Clock:  config.Clock,
-			Issuer: config.JWTIssuer,
+			Clock:     config.Clock,
+			Issuer:    config.JWTIssuer,
+			Algorithm: jose.SignatureAlgorithm(config.JWTSigningAlgorithm),
 		}),
 	}
 }
@@ -173,17 +224,27 @@ func (ca *CA) SetJWTKey(jwtKey *JWTKey) {
 	ca.jwtKey = jwtKey
 }
 
-func (ca *CA) SignX509SVID(ctx context.Context, params X509SVIDParams) ([]*x509.Certificate, error) {
+func (ca *CA) SignX509SVID(ctx context.Context, params X509SVIDParams) (_ []*x509.Certificate, err error) {
+	counter := telemetry_server.StartServerCASignX509SVIDCall(ca.c.Metrics)
+	defer counter.Done(&err)
+
 	x509CA := ca.X509CA()
 	if x509CA == nil {
 		return nil, errs.New("X509 CA is not available for signing")
 	}
 
+	if err := ca.c.DNSNamePolicy.Check(params.SpiffeID, params.DNSList); err != nil {
+		return nil, errs.New("rejected by DNS name policy: %v", err)
+	}
+
 	if params.TTL <= 0 {
 		params.TTL = ca.c.X509SVIDTTL
 	}
 
 	notBefore, notAfter := ca.capLifetime(params.TTL, x509CA.Certificate.NotAfter)
+	if !params.ExpiresAt.IsZero() && notAfter.After(params.ExpiresAt) {
+		notAfter = params.ExpiresAt
+	}
 	serialNumber, err := x509util.NewSerialNumber()
 	if err != nil {
 		return nil, err
@@ -223,11 +284,15 @@ func (ca *CA) SignX509SVID(ctx context.Context, params X509SVIDParams) ([]*x509.
 	}).Debug("Signed X509 SVID")
 
 	telemetry_server.IncrServerCASignX509Counter(ca.c.Metrics, spiffeID)
+	telemetry_server.IncrServerCAIssueX509SVIDCounter(ca.c.Metrics, ca.c.TrustDomain.String(), params.ParentID, ca.issuanceMetricsPrefix(spiffeID))
 
 	return makeSVIDCertChain(x509CA, cert), nil
 }
 
-func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x509.Certificate, error) {
+func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) (_ []*x509.Certificate, err error) {
+	counter := telemetry_server.StartServerCASignX509CASVIDCall(ca.c.Metrics)
+	defer counter.Done(&err)
+
 	x509CA := ca.X509CA()
 	if x509CA == nil {
 		return nil, errs.New("X509 CA is not available for signing")
@@ -247,7 +312,7 @@ func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x
 	// certificate. Additionally, set the OU to a 1-based downstream "level"
 	// for soft debugging support.
 	subject := x509CA.Certificate.Subject
-	subject.OrganizationalUnit = []string{fmt.Sprintf("DOWNSTREAM-%d", 1+len(x509CA.UpstreamChain))}
+	subject.OrganizationalUnit = []string{fmt.Sprintf("DOWNSTREAM-%d", 1+upstreamChainDepth(x509CA.UpstreamChain))}
 
 	template, err := CreateServerCATemplate(params.SpiffeID, params.PublicKey, ca.c.TrustDomain.Host, notBefore, notAfter, serialNumber, subject)
 	if err != nil {
@@ -258,6 +323,11 @@ func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x
 	// OU override below, but just to be safe).
 	template.AuthorityKeyId = x509CA.Certificate.SubjectKeyId
 
+	if maxPathLen := ca.c.CAMaxPathLen; maxPathLen != nil {
+		template.MaxPathLen = *maxPathLen
+		template.MaxPathLenZero = *maxPathLen == 0
+	}
+
 	cert, err := createCertificate(template, x509CA.Certificate, template.PublicKey, x509CA.Signer)
 	if err != nil {
 		return nil, errs.New("unable to create X509 CA SVID: %v", err)
@@ -275,7 +345,10 @@ func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x
 	return makeSVIDCertChain(x509CA, cert), nil
 }
 
-func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, error) {
+func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (_ string, err error) {
+	counter := telemetry_server.StartServerCASignJWTSVIDCall(ca.c.Metrics)
+	defer counter.Done(&err)
+
 	jwtKey := ca.JWTKey()
 	if jwtKey == nil {
 		return "", errs.New("JWT key is not available for signing")
@@ -290,13 +363,17 @@ func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, er
 		ttl = ca.c.JWTSVIDTTL
 	}
 	_, expiresAt := ca.capLifetime(ttl, jwtKey.NotAfter)
+	if !params.ExpiresAt.IsZero() && expiresAt.After(params.ExpiresAt) {
+		expiresAt = params.ExpiresAt
+	}
 
-	token, err := ca.jwtSigner.SignToken(params.SpiffeID, params.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid)
+	token, err := ca.jwtSigner.SignTokenWithClaims(params.SpiffeID, params.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid, params.Claims)
 	if err != nil {
 		return "", errs.New("unable to sign JWT SVID: %v", err)
 	}
 
 	telemetry_server.IncrServerCASignJWTSVIDCounter(ca.c.Metrics, params.SpiffeID)
+	telemetry_server.IncrServerCAIssueJWTSVIDCounter(ca.c.Metrics, ca.c.TrustDomain.String(), params.ParentID, ca.issuanceMetricsPrefix(params.SpiffeID))
 	ca.c.Log.WithFields(logrus.Fields{
 		telemetry.Audience:   params.Audience,
 		telemetry.Expiration: expiresAt.Format(time.RFC3339),
@@ -308,7 +385,7 @@ func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, er
 
 func (ca *CA) capLifetime(ttl time.Duration, expirationCap time.Time) (notBefore, notAfter time.Time) {
 	now := ca.c.Clock.Now()
-	notBefore = now.Add(-backdate)
+	notBefore = now.Add(-ca.c.Backdate)
 	notAfter = now.Add(ttl)
 	if notAfter.After(expirationCap) {
 		notAfter = expirationCap
@@ -316,10 +393,36 @@ func (ca *CA) capLifetime(ttl time.Duration, expirationCap time.Time) (notBefore
 	return notBefore, notAfter
 }
 
+// issuanceMetricsPrefix returns the longest issuance metrics prefix matching
+// the SPIFFE ID, or an empty string if none matches.
+func (ca *CA) issuanceMetricsPrefix(spiffeID string) string {
+	var longest string
+	for _, prefix := range ca.c.IssuanceMetricsPrefixes {
+		if strings.HasPrefix(spiffeID, prefix) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	return longest
+}
+
 func makeSVIDCertChain(x509CA *X509CA, cert *x509.Certificate) []*x509.Certificate {
 	return append([]*x509.Certificate{cert}, x509CA.UpstreamChain...)
 }
 
+// upstreamChainDepth returns the number of certificates in the upstream chain,
+// not counting the upstream root when the full upstream chain is included.
+func upstreamChainDepth(upstreamChain []*x509.Certificate) int {
+	n := len(upstreamChain)
+	if n > 0 && isSelfSigned(upstreamChain[n-1]) {
+		n--
+	}
+	return n
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
 func createCertificate(template, parent *x509.Certificate, pub, priv interface{}) (*x509.Certificate, error) {
 	certDER, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
 	if err != nil {
@@ -15,9 +15,12 @@ import (
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/dnspolicy"
 	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 var (
@@ -123,6 +126,15 @@ func (s *CATestSuite) TestSignX509SVIDUsesDefaultTTLIfTTLUnspecified() {
 	s.Require().Equal(s.clock.Now().Add(time.Minute), svid[0].NotAfter)
 }
 
+func (s *CATestSuite) TestSignX509SVIDWithBackdate() {
+	s.ca.c.Backdate = time.Minute
+	svid, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal(s.clock.Now().Add(-time.Minute), svid[0].NotBefore)
+	s.Require().Equal(s.clock.Now().Add(time.Minute), svid[0].NotAfter)
+}
+
 func (s *CATestSuite) TestSignX509SVIDUsesDefaultTTLAndNoCNDNS() {
 	svid, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
 	s.Require().NoError(err)
@@ -157,6 +169,46 @@ func (s *CATestSuite) TestSignX509SVIDMultipleDNS() {
 	s.Require().Equal("somehost1", svid[0].Subject.CommonName)
 }
 
+func (s *CATestSuite) TestSignX509SVIDWithDNSNamePolicy() {
+	policy, err := dnspolicy.New([]dnspolicy.Rule{
+		{SPIFFEIDPrefix: "spiffe://example.org/workload", AllowedSuffixes: []string{"example.org"}},
+	})
+	s.Require().NoError(err)
+	s.ca.c.DNSNamePolicy = policy
+
+	params := s.createX509SVIDParams()
+	params.DNSList = []string{"web.example.org"}
+	svid, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Equal(params.DNSList, svid[0].DNSNames)
+
+	params.DNSList = []string{"web.example.org", "www.example.com"}
+	_, err = s.ca.SignX509SVID(ctx, params)
+	s.Require().EqualError(err, `rejected by DNS name policy: DNS name "www.example.com" is not allowed for "spiffe://example.org/workload"`)
+}
+
+func (s *CATestSuite) TestSignX509SVIDIssuanceMetrics() {
+	metrics := fakemetrics.New()
+	s.ca.c.Metrics = metrics
+	s.ca.c.IssuanceMetricsPrefixes = []string{"spiffe://example.org/", "spiffe://example.org/work"}
+
+	params := s.createX509SVIDParams()
+	params.ParentID = "spiffe://example.org/spire/agent/test"
+	_, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+
+	s.Require().Contains(metrics.AllMetrics(), fakemetrics.MetricItem{
+		Type: fakemetrics.IncrCounterWithLabelsType,
+		Key:  []string{telemetry.ServerCA, telemetry.Issue, telemetry.X509SVID},
+		Val:  1,
+		Labels: []telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: "spiffe_example_org"},
+			{Name: telemetry.ParentID, Value: "spiffe_example_org_spire_agent_test"},
+			{Name: telemetry.SPIFFEIDPrefix, Value: "spiffe_example_org_work"},
+		},
+	})
+}
+
 func (s *CATestSuite) TestSignX509SVIDWithSubject() {
 	subject := pkix.Name{
 		Organization: []string{"ORG"},
@@ -240,6 +292,17 @@ func (s *CATestSuite) TestSignX509SVIDCapsTTLToCATTL() {
 	s.Require().Equal(s.clock.Now().Add(10*time.Minute), svid[0].NotAfter)
 }
 
+func (s *CATestSuite) TestSignX509SVIDCapsTTLToExpiresAt() {
+	params := s.createX509SVIDParams()
+	params.TTL = 5 * time.Minute
+	params.ExpiresAt = s.clock.Now().Add(time.Minute)
+	svid, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal(s.clock.Now().Add(-backdate), svid[0].NotBefore)
+	s.Require().Equal(s.clock.Now().Add(time.Minute), svid[0].NotAfter)
+}
+
 func (s *CATestSuite) TestSignX509SVIDValidatesTrustDomain() {
 	_, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParamsInDomain("foo.com"))
 	s.Require().EqualError(err, `"spiffe://foo.com/workload" does not belong to trust domain "example.org"`)
@@ -288,6 +351,49 @@ func (s *CATestSuite) TestSignJWTSVIDCapsTTLToKeyExpiry() {
 	s.Require().Equal(s.clock.Now().Add(10*time.Minute), expiresAt)
 }
 
+func (s *CATestSuite) TestSignJWTSVIDCapsTTLToExpiresAt() {
+	params := s.createJWTSVIDParams("example.org", 5*time.Minute)
+	params.ExpiresAt = s.clock.Now().Add(time.Minute)
+	token, err := s.ca.SignJWTSVID(ctx, params)
+	s.Require().NoError(err)
+	_, expiresAt, err := jwtsvid.GetTokenExpiry(token)
+	s.Require().NoError(err)
+	s.Require().Equal(s.clock.Now().Add(time.Minute), expiresAt)
+}
+
+func (s *CATestSuite) TestSignJWTSVIDIncludesClaims() {
+	params := s.createJWTSVIDParams("example.org", 0)
+	params.Claims = map[string]string{"role": "admin"}
+	token, err := s.ca.SignJWTSVID(ctx, params)
+	s.Require().NoError(err)
+
+	tok, err := jwt.ParseSigned(token)
+	s.Require().NoError(err)
+	claims := make(map[string]interface{})
+	s.Require().NoError(tok.UnsafeClaimsWithoutVerification(&claims))
+	s.Require().Equal("admin", claims["role"])
+	s.Require().Equal(makeWorkloadID("example.org"), claims["sub"])
+}
+
+func (s *CATestSuite) TestSignJWTSVIDIssuanceMetrics() {
+	metrics := fakemetrics.New()
+	s.ca.c.Metrics = metrics
+	s.ca.c.IssuanceMetricsPrefixes = []string{"spiffe://example.org/other/"}
+
+	_, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 0))
+	s.Require().NoError(err)
+
+	// Neither the parent nor a prefix are known
+	s.Require().Contains(metrics.AllMetrics(), fakemetrics.MetricItem{
+		Type: fakemetrics.IncrCounterWithLabelsType,
+		Key:  []string{telemetry.ServerCA, telemetry.Issue, telemetry.JWTSVID},
+		Val:  1,
+		Labels: []telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: "spiffe_example_org"},
+		},
+	})
+}
+
 func (s *CATestSuite) TestSignJWTSVIDValidatesJSR() {
 	// spiffe id for wrong trust domain
 	_, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("foo.com", 0))
@@ -298,6 +404,12 @@ func (s *CATestSuite) TestSignJWTSVIDValidatesJSR() {
 	noAudience.Audience = nil
 	_, err = s.ca.SignJWTSVID(ctx, noAudience)
 	s.Require().EqualError(err, "unable to sign JWT SVID: audience is required")
+
+	// registered claims cannot be overridden
+	reservedClaim := s.createJWTSVIDParams("example.org", 0)
+	reservedClaim.Claims = map[string]string{"exp": "0"}
+	_, err = s.ca.SignJWTSVID(ctx, reservedClaim)
+	s.Require().EqualError(err, `unable to sign JWT SVID: claim "exp" is reserved`)
 }
 
 func (s *CATestSuite) TestSignX509CASVIDNoCASet() {
@@ -331,6 +443,33 @@ func (s *CATestSuite) TestSignX509CASVID() {
 	s.Equal("CN=CA,OU=DOWNSTREAM-1", svid.Subject.String())
 }
 
+func (s *CATestSuite) TestSignX509CASVIDWithMaxPathLen() {
+	for _, maxPathLen := range []int{0, 2} {
+		maxPathLen := maxPathLen
+		s.ca.c.CAMaxPathLen = &maxPathLen
+
+		svidChain, err := s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("example.org"))
+		s.Require().NoError(err)
+		s.Require().Len(svidChain, 1)
+		s.Equal(maxPathLen, svidChain[0].MaxPathLen)
+		s.Equal(maxPathLen == 0, svidChain[0].MaxPathLenZero)
+	}
+}
+
+func (s *CATestSuite) TestSignX509CASVIDWithFullUpstreamChain() {
+	s.setX509CA(false)
+
+	svidChain, err := s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("example.org"))
+	s.Require().NoError(err)
+	s.Require().Len(svidChain, 3)
+	s.Equal(s.caCert, svidChain[1])
+	s.Equal(s.upstreamCert, svidChain[2])
+
+	// The upstream root does not count towards the DOWNSTREAM level.
+	s.Equal("CN=CA,OU=DOWNSTREAM-2", svidChain[0].Subject.String())
+	s.Equal(-1, svidChain[0].MaxPathLen)
+}
+
 func (s *CATestSuite) TestSignX509CASVIDUsesDefaultTTLIfTTLUnspecified() {
 	svid, err := s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("example.org"))
 	s.Require().NoError(err)
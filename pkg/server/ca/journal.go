@@ -135,6 +135,48 @@ func (j *Journal) AppendJWTKey(slotID string, issuedAt time.Time, jwtKey *JWTKey
 	return nil
 }
 
+// MarkX509CAActivated marks the most recent X509 CA entry for the slot as
+// activated through a manual rotation.
+func (j *Journal) MarkX509CAActivated(slotID string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for i := len(j.entries.X509CAs) - 1; i >= 0; i-- {
+		entry := j.entries.X509CAs[i]
+		if entry.SlotId != slotID {
+			continue
+		}
+		entry.Activated = true
+		if err := j.save(); err != nil {
+			entry.Activated = false
+			return err
+		}
+		return nil
+	}
+	return errs.New("no X509 CA entry for slot %q", slotID)
+}
+
+// MarkJWTKeyActivated marks the most recent JWT key entry for the slot as
+// activated through a manual rotation.
+func (j *Journal) MarkJWTKeyActivated(slotID string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for i := len(j.entries.JwtKeys) - 1; i >= 0; i-- {
+		entry := j.entries.JwtKeys[i]
+		if entry.SlotId != slotID {
+			continue
+		}
+		entry.Activated = true
+		if err := j.save(); err != nil {
+			entry.Activated = false
+			return err
+		}
+		return nil
+	}
+	return errs.New("no JWT key entry for slot %q", slotID)
+}
+
 func (j *Journal) save() error {
 	return saveJournalEntries(j.path, j.entries)
 }
@@ -141,6 +141,38 @@ func (s *JournalSuite) TestPersistence() {
 	s.requireProtoEqual(journal.Entries(), s.loadJournal().Entries())
 }
 
+func (s *JournalSuite) TestMarkActivated() {
+	now := s.now()
+
+	journal := s.loadJournal()
+
+	s.Require().EqualError(journal.MarkX509CAActivated("A"), `no X509 CA entry for slot "A"`)
+	s.Require().EqualError(journal.MarkJWTKeyActivated("A"), `no JWT key entry for slot "A"`)
+
+	for _, slotID := range []string{"A", "B"} {
+		s.Require().NoError(journal.AppendX509CA(slotID, now, &X509CA{
+			Signer:      testSigner,
+			Certificate: testChain[0],
+		}))
+		s.Require().NoError(journal.AppendJWTKey(slotID, now, &JWTKey{
+			Signer:   testSigner,
+			Kid:      "KID",
+			NotAfter: now.Add(time.Hour),
+		}))
+	}
+
+	s.Require().NoError(journal.MarkX509CAActivated("B"))
+	s.Require().NoError(journal.MarkJWTKeyActivated("B"))
+
+	entries := s.loadJournal().Entries()
+	s.Require().Len(entries.X509CAs, 2)
+	s.False(entries.X509CAs[0].Activated)
+	s.True(entries.X509CAs[1].Activated)
+	s.Require().Len(entries.JwtKeys, 2)
+	s.False(entries.JwtKeys[0].Activated)
+	s.True(entries.JwtKeys[1].Activated)
+}
+
 func (s *JournalSuite) TestX509CAOverflow() {
 	now := s.now()
 
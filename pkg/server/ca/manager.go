@@ -7,8 +7,10 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"net/url"
 	"path/filepath"
@@ -16,6 +18,7 @@ import (
 	"time"
 
 	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -44,7 +47,23 @@ const (
 	sevenDays              = 7 * 24 * time.Hour
 	activationThresholdCap = sevenDays
 
+	// DefaultPrepareThreshold is the fraction of the lifetime of the current
+	// X509 CA and JWT key after which the next ones are prepared.
+	DefaultPrepareThreshold = 1.0 / 2
+
+	// DefaultActivateThreshold is the fraction of the lifetime of the current
+	// X509 CA and JWT key after which the next ones are activated.
+	DefaultActivateThreshold = 5.0 / 6
+
 	publishJWKTimeout = 5 * time.Second
+
+	// caEventQueueSize is the number of CA events queued for the notifiers
+	// before new events are dropped
+	caEventQueueSize = 32
+
+	// mirrorInterval is how often the trust bundle is mirrored to the trust
+	// domain aliases, in addition to every time the bundle is updated.
+	mirrorInterval = time.Minute
 )
 
 type ManagedCA interface {
@@ -64,14 +83,47 @@ type ManagerConfig struct {
 	Log           logrus.FieldLogger
 	Metrics       telemetry.Metrics
 	Clock         clock.Clock
+
+	// FullUpstreamChain, if true, appends the upstream root to the upstream
+	// chain of X509 CAs signed by the UpstreamAuthority.
+	FullUpstreamChain bool
+
+	// PrepareThreshold is the fraction of the lifetime of the current X509 CA
+	// and JWT key after which the next ones are prepared. Defaults to
+	// DefaultPrepareThreshold.
+	PrepareThreshold float64
+
+	// ActivateThreshold is the fraction of the lifetime of the current X509
+	// CA and JWT key after which the next ones are activated. Defaults to
+	// DefaultActivateThreshold.
+	ActivateThreshold float64
+
+	// NotifierReconcileInterval is how often the notifiers are asked to
+	// verify the external copies of the trust bundle they maintain and repair
+	// any drift. Reconciliation is disabled if zero.
+	NotifierReconcileInterval time.Duration
+
+	// TrustDomainAliases are additional trust domains that the trust bundle
+	// is mirrored to, e.g. the previous name of the trust domain while
+	// migrating to a new one.
+	TrustDomainAliases []url.URL
+
+	// Backdate is how far the NotBefore of self-signed X509 CAs is set
+	// before the time they are signed, to tolerate clock skew between the
+	// server and the verifiers. Defaults to 10 seconds.
+	Backdate time.Duration
 }
 
 type Manager struct {
 	c                  ManagerConfig
 	bundleUpdatedCh    chan struct{}
+	caEventCh          chan caEvent
 	upstreamClient     *UpstreamClient
 	upstreamPluginName string
 
+	// rotateMtx serializes the rotation of the X509 CA and JWT key slots
+	// between the rotation task and manual rotations.
+	rotateMtx     sync.Mutex
 	currentX509CA *x509CASlot
 	nextX509CA    *x509CASlot
 	currentJWTKey *jwtKeySlot
@@ -96,10 +148,20 @@ func NewManager(c ManagerConfig) *Manager {
 	if c.JWTKeyType == 0 {
 		c.JWTKeyType = keymanager.KeyType_EC_P256
 	}
+	if c.PrepareThreshold == 0 {
+		c.PrepareThreshold = DefaultPrepareThreshold
+	}
+	if c.ActivateThreshold == 0 {
+		c.ActivateThreshold = DefaultActivateThreshold
+	}
+	if c.Backdate <= 0 {
+		c.Backdate = backdate
+	}
 
 	m := &Manager{
 		c:               c,
 		bundleUpdatedCh: make(chan struct{}, 1),
+		caEventCh:       make(chan caEvent, caEventQueueSize),
 	}
 
 	if upstreamAuthority, ok := c.Catalog.GetUpstreamAuthority(); ok {
@@ -138,7 +200,10 @@ func (m *Manager) Run(ctx context.Context) error {
 	if err := m.notifyBundleLoaded(ctx); err != nil {
 		return err
 	}
-	err := util.RunTasks(ctx,
+	if err := m.mirrorBundle(ctx); err != nil {
+		m.c.Log.WithError(err).Warn("Failed to mirror bundle to trust domain aliases")
+	}
+	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
 			return m.rotateEvery(ctx, rotateInterval)
 		},
@@ -151,13 +216,40 @@ func (m *Manager) Run(ctx context.Context) error {
 			m.notifyOnBundleUpdate(ctx)
 			return nil
 		},
-	)
+		func(ctx context.Context) error {
+			// notifyOnCAEvents does not fail but rather logs any errors
+			// encountered while notifying
+			m.notifyOnCAEvents(ctx)
+			return nil
+		},
+	}
+	if m.c.NotifierReconcileInterval > 0 {
+		tasks = append(tasks, func(ctx context.Context) error {
+			return m.reconcileBundleEvery(ctx, m.c.NotifierReconcileInterval)
+		})
+	}
+	if len(m.c.TrustDomainAliases) > 0 {
+		tasks = append(tasks, func(ctx context.Context) error {
+			return m.mirrorBundleEvery(ctx, mirrorInterval)
+		})
+	}
+	err := util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
 	}
 	return err
 }
 
+// UpstreamAuthorityStatus returns the error of the last call made to the
+// upstream authority, or nil if it succeeded or no upstream authority is
+// configured.
+func (m *Manager) UpstreamAuthorityStatus() error {
+	if m.upstreamClient == nil {
+		return nil
+	}
+	return m.upstreamClient.Status()
+}
+
 func (m *Manager) rotateEvery(ctx context.Context, interval time.Duration) error {
 	ticker := m.c.Clock.Ticker(interval)
 	defer ticker.Stop()
@@ -177,6 +269,9 @@ func (m *Manager) rotateEvery(ctx context.Context, interval time.Duration) error
 }
 
 func (m *Manager) rotate(ctx context.Context) error {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
 	x509CAErr := m.rotateX509CA(ctx)
 	if x509CAErr != nil {
 		m.c.Log.WithError(x509CAErr).Error("Unable to rotate X509 CA")
@@ -190,6 +285,245 @@ func (m *Manager) rotate(ctx context.Context) error {
 	return errs.Combine(x509CAErr, jwtKeyErr)
 }
 
+// PrepareNext prepares the next X509 CA and JWT key without waiting for the
+// preparation threshold, replacing any that were already prepared. The
+// prepared X509 CA and JWT key are added to the trust bundle and activated
+// once the activation threshold of the current ones is reached.
+func (m *Manager) PrepareNext(ctx context.Context) (*X509CA, *JWTKey, error) {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
+	if err := m.prepareX509CA(ctx, m.nextX509CA); err != nil {
+		return nil, nil, errs.New("unable to prepare X509 CA: %v", err)
+	}
+	if err := m.prepareJWTKey(ctx, m.nextJWTKey); err != nil {
+		return nil, nil, errs.New("unable to prepare JWT key: %v", err)
+	}
+	return m.nextX509CA.x509CA, m.nextJWTKey.jwtKey, nil
+}
+
+// ActivateNext activates the next X509 CA and JWT key without waiting for
+// the activation threshold, preparing them first if they have not been
+// prepared. Peers that have not yet received the updated trust bundle will
+// fail to validate SVIDs signed by the activated X509 CA and JWT key.
+func (m *Manager) ActivateNext(ctx context.Context) (*X509CA, *JWTKey, error) {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
+	if m.nextX509CA.IsEmpty() {
+		if err := m.prepareX509CA(ctx, m.nextX509CA); err != nil {
+			return nil, nil, errs.New("unable to prepare X509 CA: %v", err)
+		}
+	}
+	if m.nextJWTKey.IsEmpty() {
+		if err := m.prepareJWTKey(ctx, m.nextJWTKey); err != nil {
+			return nil, nil, errs.New("unable to prepare JWT key: %v", err)
+		}
+	}
+
+	m.currentX509CA, m.nextX509CA = m.nextX509CA, m.currentX509CA
+	m.nextX509CA.Reset()
+	m.activateX509CA()
+	if err := m.journal.MarkX509CAActivated(m.currentX509CA.id); err != nil {
+		m.c.Log.WithError(err).Error("Unable to mark X509 CA as activated in journal")
+	}
+
+	m.currentJWTKey, m.nextJWTKey = m.nextJWTKey, m.currentJWTKey
+	m.nextJWTKey.Reset()
+	m.activateJWTKey()
+	if err := m.journal.MarkJWTKeyActivated(m.currentJWTKey.id); err != nil {
+		m.c.Log.WithError(err).Error("Unable to mark JWT key as activated in journal")
+	}
+
+	return m.currentX509CA.x509CA, m.currentJWTKey.jwtKey, nil
+}
+
+// TaintX509CA marks the X509 CA identified by authorityID as tainted in the
+// trust bundle. Agents rotate the SVIDs that chain back to a tainted X509 CA.
+// The active and prepared X509 CAs cannot be tainted.
+func (m *Manager) TaintX509CA(ctx context.Context, authorityID string) (*x509.Certificate, error) {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
+	if m.upstreamClient != nil {
+		return nil, status.Error(codes.FailedPrecondition, "tainting X509 authorities is not supported when an UpstreamAuthority is configured")
+	}
+	if err := m.checkX509CAUnused(authorityID, "taint"); err != nil {
+		return nil, err
+	}
+
+	var taintedCA *x509.Certificate
+	err := m.updateBundle(ctx, func(bundle *common.Bundle) error {
+		i, caCert, err := findRootCA(bundle, authorityID)
+		if err != nil {
+			return err
+		}
+		if bundle.RootCas[i].TaintedKey {
+			return status.Errorf(codes.FailedPrecondition, "X509 authority %q is already tainted", authorityID)
+		}
+		bundle.RootCas[i].TaintedKey = true
+		taintedCA = caCert
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.c.Log.WithField(telemetry.LocalAuthorityID, authorityID).Info("X509 CA tainted")
+	m.caEvent("X509 CA tainted", &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_CaTainted{
+			CaTainted: &notifier.CATainted{X509Ca: taintedCA.Raw},
+		},
+	})
+	return taintedCA, nil
+}
+
+// RevokeX509CA removes the tainted X509 CA identified by authorityID from the
+// trust bundle. SVIDs that chain back to a revoked X509 CA are no longer
+// trusted, so it should only be revoked once agents have rotated them.
+func (m *Manager) RevokeX509CA(ctx context.Context, authorityID string) (*x509.Certificate, error) {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
+	if err := m.checkX509CAUnused(authorityID, "revoke"); err != nil {
+		return nil, err
+	}
+
+	var revokedCA *x509.Certificate
+	err := m.updateBundle(ctx, func(bundle *common.Bundle) error {
+		i, caCert, err := findRootCA(bundle, authorityID)
+		if err != nil {
+			return err
+		}
+		if !bundle.RootCas[i].TaintedKey {
+			return status.Errorf(codes.FailedPrecondition, "X509 authority %q must be tainted before it can be revoked", authorityID)
+		}
+		bundle.RootCas = append(bundle.RootCas[:i], bundle.RootCas[i+1:]...)
+		revokedCA = caCert
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.c.Log.WithField(telemetry.LocalAuthorityID, authorityID).Info("X509 CA revoked")
+	return revokedCA, nil
+}
+
+// TaintJWTKey marks the JWT key identified by kid as tainted in the trust
+// bundle. Agents drop the cached JWT-SVIDs signed by a tainted JWT key. The
+// active and prepared JWT keys cannot be tainted.
+func (m *Manager) TaintJWTKey(ctx context.Context, kid string) (*common.PublicKey, error) {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
+	if err := m.checkJWTKeyUnused(kid, "taint"); err != nil {
+		return nil, err
+	}
+
+	var taintedKey *common.PublicKey
+	err := m.updateBundle(ctx, func(bundle *common.Bundle) error {
+		i, err := findJWTSigningKey(bundle, kid)
+		if err != nil {
+			return err
+		}
+		if bundle.JwtSigningKeys[i].TaintedKey {
+			return status.Errorf(codes.FailedPrecondition, "JWT authority %q is already tainted", kid)
+		}
+		bundle.JwtSigningKeys[i].TaintedKey = true
+		taintedKey = bundle.JwtSigningKeys[i]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.c.Log.WithField(telemetry.Kid, kid).Info("JWT key tainted")
+	m.caEvent("JWT key tainted", &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_CaTainted{
+			CaTainted: &notifier.CATainted{JwtKey: taintedKey},
+		},
+	})
+	return taintedKey, nil
+}
+
+// RevokeJWTKey removes the tainted JWT key identified by kid from the trust
+// bundle.
+func (m *Manager) RevokeJWTKey(ctx context.Context, kid string) (*common.PublicKey, error) {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
+	if err := m.checkJWTKeyUnused(kid, "revoke"); err != nil {
+		return nil, err
+	}
+
+	var revokedKey *common.PublicKey
+	err := m.updateBundle(ctx, func(bundle *common.Bundle) error {
+		i, err := findJWTSigningKey(bundle, kid)
+		if err != nil {
+			return err
+		}
+		if !bundle.JwtSigningKeys[i].TaintedKey {
+			return status.Errorf(codes.FailedPrecondition, "JWT authority %q must be tainted before it can be revoked", kid)
+		}
+		revokedKey = bundle.JwtSigningKeys[i]
+		bundle.JwtSigningKeys = append(bundle.JwtSigningKeys[:i], bundle.JwtSigningKeys[i+1:]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.c.Log.WithField(telemetry.Kid, kid).Info("JWT key revoked")
+	return revokedKey, nil
+}
+
+func (m *Manager) checkX509CAUnused(authorityID, action string) error {
+	switch {
+	case !m.currentX509CA.IsEmpty() && X509AuthorityID(m.currentX509CA.x509CA.Certificate) == authorityID:
+		return status.Errorf(codes.FailedPrecondition, "unable to %s the active X509 authority; rotate it first", action)
+	case !m.nextX509CA.IsEmpty() && X509AuthorityID(m.nextX509CA.x509CA.Certificate) == authorityID:
+		return status.Errorf(codes.FailedPrecondition, "unable to %s the prepared X509 authority; prepare a new one first", action)
+	}
+	return nil
+}
+
+func (m *Manager) checkJWTKeyUnused(kid, action string) error {
+	switch {
+	case !m.currentJWTKey.IsEmpty() && m.currentJWTKey.jwtKey.Kid == kid:
+		return status.Errorf(codes.FailedPrecondition, "unable to %s the active JWT authority; rotate it first", action)
+	case !m.nextJWTKey.IsEmpty() && m.nextJWTKey.jwtKey.Kid == kid:
+		return status.Errorf(codes.FailedPrecondition, "unable to %s the prepared JWT authority; prepare a new one first", action)
+	}
+	return nil
+}
+
+// updateBundle applies update to the trust domain bundle and stores the
+// resulting root CAs and JWT signing keys.
+func (m *Manager) updateBundle(ctx context.Context, update func(*common.Bundle) error) error {
+	bundle, err := m.fetchRequiredBundle(ctx)
+	if err != nil {
+		return err
+	}
+	if err := update(bundle); err != nil {
+		return err
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	if _, err := ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: bundle,
+		InputMask: &common.BundleMask{
+			RootCas:        true,
+			JwtSigningKeys: true,
+		},
+	}); err != nil {
+		return fmt.Errorf("unable to update bundle: %v", err)
+	}
+
+	m.bundleUpdated()
+	return nil
+}
+
 func (m *Manager) rotateX509CA(ctx context.Context) error {
 	now := m.c.Clock.Now()
 
@@ -203,13 +537,13 @@ func (m *Manager) rotateX509CA(ctx context.Context) error {
 
 	// if there is no next keypair set and the current is within the
 	// preparation threshold, generate one.
-	if m.nextX509CA.IsEmpty() && m.currentX509CA.ShouldPrepareNext(now) {
+	if m.nextX509CA.IsEmpty() && m.currentX509CA.ShouldPrepareNext(now, m.c.PrepareThreshold) {
 		if err := m.prepareX509CA(ctx, m.nextX509CA); err != nil {
 			return err
 		}
 	}
 
-	if m.currentX509CA.ShouldActivateNext(now) {
+	if m.currentX509CA.ShouldActivateNext(now, m.c.ActivateThreshold) {
 		m.currentX509CA, m.nextX509CA = m.nextX509CA, m.currentX509CA
 		m.nextX509CA.Reset()
 		m.activateX509CA()
@@ -236,12 +570,12 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 
 	var x509CA *X509CA
 	if m.upstreamClient != nil {
-		x509CA, err = UpstreamSignX509CA(ctx, signer, m.c.TrustDomain.Host, m.c.CASubject, m.upstreamClient, m.c.CATTL)
+		x509CA, err = UpstreamSignX509CA(ctx, signer, m.c.TrustDomain.Host, m.c.CASubject, m.upstreamClient, m.c.CATTL, m.c.FullUpstreamChain)
 		if err != nil {
 			return err
 		}
 	} else {
-		notBefore := now.Add(-backdate)
+		notBefore := now.Add(-m.c.Backdate)
 		notAfter := now.Add(m.c.CATTL)
 		var trustBundle []*x509.Certificate
 		x509CA, trustBundle, err = SelfSignX509CA(ctx, signer, m.c.TrustDomain.Host, m.c.CASubject, notBefore, notAfter)
@@ -266,6 +600,11 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 		telemetry.Expiration: timeField(slot.x509CA.Certificate.NotAfter),
 		telemetry.SelfSigned: m.upstreamClient == nil,
 	}).Info("X509 CA prepared")
+	m.caEvent("X509 CA prepared", &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_CaPrepared{
+			CaPrepared: &notifier.CAPrepared{X509Ca: slot.x509CA.Certificate.Raw},
+		},
+	})
 	return nil
 }
 
@@ -285,6 +624,11 @@ func (m *Manager) activateX509CA() {
 	}).Debug("Successfully rotated X.509 CA")
 
 	m.c.CA.SetX509CA(m.currentX509CA.x509CA)
+	m.caEvent("X509 CA activated", &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_CaActivated{
+			CaActivated: &notifier.CAActivated{X509Ca: m.currentX509CA.x509CA.Certificate.Raw},
+		},
+	})
 }
 
 func (m *Manager) rotateJWTKey(ctx context.Context) error {
@@ -300,13 +644,13 @@ func (m *Manager) rotateJWTKey(ctx context.Context) error {
 
 	// if there is no next keypair set and the current is within the
 	// preparation threshold, generate one.
-	if m.nextJWTKey.IsEmpty() && m.currentJWTKey.ShouldPrepareNext(now) {
+	if m.nextJWTKey.IsEmpty() && m.currentJWTKey.ShouldPrepareNext(now, m.c.PrepareThreshold) {
 		if err := m.prepareJWTKey(ctx, m.nextJWTKey); err != nil {
 			return err
 		}
 	}
 
-	if m.currentJWTKey.ShouldActivateNext(now) {
+	if m.currentJWTKey.ShouldActivateNext(now, m.c.ActivateThreshold) {
 		m.currentJWTKey, m.nextJWTKey = m.nextJWTKey, m.currentJWTKey
 		m.nextJWTKey.Reset()
 		m.activateJWTKey()
@@ -359,6 +703,11 @@ func (m *Manager) prepareJWTKey(ctx context.Context, slot *jwtKeySlot) (err erro
 		telemetry.IssuedAt:   timeField(slot.issuedAt),
 		telemetry.Expiration: timeField(slot.jwtKey.NotAfter),
 	}).Info("JWT key prepared")
+	m.caEvent("JWT key prepared", &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_CaPrepared{
+			CaPrepared: &notifier.CAPrepared{JwtKey: publicKey},
+		},
+	})
 	return nil
 }
 
@@ -415,6 +764,17 @@ func (m *Manager) activateJWTKey() {
 	}).Info("JWT key activated")
 	telemetry_server.IncrActivateJWTKeyManagerCounter(m.c.Metrics)
 	m.c.CA.SetJWTKey(m.currentJWTKey.jwtKey)
+
+	publicKey, err := publicKeyFromJWTKey(m.currentJWTKey.jwtKey)
+	if err != nil {
+		m.c.Log.WithError(err).Error("Unable to notify JWT key activation")
+		return
+	}
+	m.caEvent("JWT key activated", &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_CaActivated{
+			CaActivated: &notifier.CAActivated{JwtKey: publicKey},
+		},
+	})
 }
 
 func (m *Manager) pruneBundleEvery(ctx context.Context, interval time.Duration) error {
@@ -510,12 +870,14 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 	}).Info("Journal loaded")
 
 	if len(entries.X509CAs) > 0 {
-		m.nextX509CA, err = m.tryLoadX509CASlotFromEntry(ctx, entries.X509CAs[len(entries.X509CAs)-1])
+		lastEntry := entries.X509CAs[len(entries.X509CAs)-1]
+		m.nextX509CA, err = m.tryLoadX509CASlotFromEntry(ctx, lastEntry)
 		if err != nil {
 			return err
 		}
-		// if the last entry is ok, then consider the next entry
-		if m.nextX509CA != nil && len(entries.X509CAs) > 1 {
+		// if the last entry is ok, then consider the next entry, unless the
+		// last entry was manually activated, in which case it is the current.
+		if m.nextX509CA != nil && !lastEntry.Activated && len(entries.X509CAs) > 1 {
 			m.currentX509CA, err = m.tryLoadX509CASlotFromEntry(ctx, entries.X509CAs[len(entries.X509CAs)-2])
 			if err != nil {
 				return err
@@ -534,19 +896,21 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 		m.nextX509CA = newX509CASlot("B")
 	}
 
-	if !m.currentX509CA.IsEmpty() && !m.currentX509CA.ShouldActivateNext(now) {
+	if !m.currentX509CA.IsEmpty() && !m.currentX509CA.ShouldActivateNext(now, m.c.ActivateThreshold) {
 		// activate the X509CA immediately if it is set and not within
 		// activation time of the next X509CA.
 		m.activateX509CA()
 	}
 
 	if len(entries.JwtKeys) > 0 {
-		m.nextJWTKey, err = m.tryLoadJWTKeySlotFromEntry(ctx, entries.JwtKeys[len(entries.JwtKeys)-1])
+		lastEntry := entries.JwtKeys[len(entries.JwtKeys)-1]
+		m.nextJWTKey, err = m.tryLoadJWTKeySlotFromEntry(ctx, lastEntry)
 		if err != nil {
 			return err
 		}
-		// if the last entry is ok, then consider the next entry
-		if m.nextJWTKey != nil && len(entries.JwtKeys) > 1 {
+		// if the last entry is ok, then consider the next entry, unless the
+		// last entry was manually activated, in which case it is the current.
+		if m.nextJWTKey != nil && !lastEntry.Activated && len(entries.JwtKeys) > 1 {
 			m.currentJWTKey, err = m.tryLoadJWTKeySlotFromEntry(ctx, entries.JwtKeys[len(entries.JwtKeys)-2])
 			if err != nil {
 				return err
@@ -565,7 +929,7 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 		m.nextJWTKey = newJWTKeySlot("B")
 	}
 
-	if !m.currentJWTKey.IsEmpty() && !m.currentJWTKey.ShouldActivateNext(now) {
+	if !m.currentJWTKey.IsEmpty() && !m.currentJWTKey.ShouldActivateNext(now, m.c.ActivateThreshold) {
 		// activate the JWT key immediately if it is set and not within
 		// activation time of the next JWT key.
 		m.activateJWTKey()
@@ -575,7 +939,14 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 }
 
 func (m *Manager) journalPath() string {
-	return filepath.Join(m.c.Dir, "journal.pem")
+	return JournalPath(m.c.Dir)
+}
+
+// JournalPath returns the path of the CA journal kept in the given data
+// directory. The journal holds the CA certificates of the server and the IDs
+// of their keys in the key manager, but no private keys.
+func JournalPath(dir string) string {
+	return filepath.Join(dir, "journal.pem")
 }
 
 func (m *Manager) tryLoadX509CASlotFromEntry(ctx context.Context, entry *X509CAEntry) (*x509CASlot, error) {
@@ -726,6 +1097,9 @@ func (m *Manager) notifyOnBundleUpdate(ctx context.Context) {
 	for {
 		select {
 		case <-m.bundleUpdatedCh:
+			if err := m.mirrorBundle(ctx); err != nil {
+				m.c.Log.WithError(err).Warn("Failed to mirror bundle to trust domain aliases")
+			}
 			if err := m.notifyBundleUpdated(ctx); err != nil {
 				m.c.Log.WithError(err).Warn("Failed to notify on bundle update")
 			}
@@ -735,6 +1109,43 @@ func (m *Manager) notifyOnBundleUpdate(ctx context.Context) {
 	}
 }
 
+// caEvent queues a CA event for the notifiers. The event is dropped if the
+// queue is full so that the rotation never waits on the notifiers.
+func (m *Manager) caEvent(event string, req *notifier.NotifyRequest) {
+	if len(m.c.Catalog.GetNotifiers()) == 0 {
+		return
+	}
+	select {
+	case m.caEventCh <- caEvent{name: event, req: req}:
+	default:
+		m.c.Log.WithField(telemetry.Event, event).Warn("Dropping CA event notification; too many pending events")
+	}
+}
+
+type caEvent struct {
+	name string
+	req  *notifier.NotifyRequest
+}
+
+func (m *Manager) notifyOnCAEvents(ctx context.Context) {
+	for {
+		select {
+		case event := <-m.caEventCh:
+			err := m.notify(ctx, event.name, false, nil,
+				func(ctx context.Context, n catalog.Notifier) error {
+					_, err := n.Notify(ctx, event.req)
+					return err
+				},
+			)
+			if err != nil {
+				m.c.Log.WithError(err).Warn("Failed to notify on CA event")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (m *Manager) notifyBundleLoaded(ctx context.Context) error {
 	// if initialization has triggered a "bundle updated" event (e.g. server CA
 	// was rotated), we want to drain it now as we're about to emit the initial
@@ -748,7 +1159,7 @@ func (m *Manager) notifyBundleLoaded(ctx context.Context) error {
 			bundle, err = m.fetchRequiredBundle(ctx)
 			return err
 		},
-		func(ctx context.Context, n notifier.Notifier) error {
+		func(ctx context.Context, n catalog.Notifier) error {
 			_, err := n.NotifyAndAdvise(ctx, &notifier.NotifyAndAdviseRequest{
 				Event: &notifier.NotifyAndAdviseRequest_BundleLoaded{
 					BundleLoaded: &notifier.BundleLoaded{
@@ -768,7 +1179,7 @@ func (m *Manager) notifyBundleUpdated(ctx context.Context) error {
 			bundle, err = m.fetchRequiredBundle(ctx)
 			return err
 		},
-		func(ctx context.Context, n notifier.Notifier) error {
+		func(ctx context.Context, n catalog.Notifier) error {
 			_, err := n.Notify(ctx, &notifier.NotifyRequest{
 				Event: &notifier.NotifyRequest_BundleUpdated{
 					BundleUpdated: &notifier.BundleUpdated{
@@ -781,7 +1192,108 @@ func (m *Manager) notifyBundleUpdated(ctx context.Context) error {
 	)
 }
 
-func (m *Manager) notify(ctx context.Context, event string, advise bool, pre func(context.Context) error, do func(context.Context, notifier.Notifier) error) error {
+func (m *Manager) reconcileBundleEvery(ctx context.Context, interval time.Duration) error {
+	ticker := m.c.Clock.Ticker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.reconcileBundle(ctx); err != nil {
+				m.c.Log.WithError(err).Warn("Failed to reconcile the bundle copies of the notifiers")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reconcileBundle asks the notifiers to verify the external copies of the
+// trust bundle they maintain and repair any drift. Copies found out of sync
+// are logged and counted per notifier.
+func (m *Manager) reconcileBundle(ctx context.Context) error {
+	var bundle *common.Bundle
+	return m.notify(ctx, "reconcile bundle", false,
+		func(ctx context.Context) (err error) {
+			bundle, err = m.fetchRequiredBundle(ctx)
+			return err
+		},
+		func(ctx context.Context, n catalog.Notifier) error {
+			resp, err := n.Notify(ctx, &notifier.NotifyRequest{
+				Event: &notifier.NotifyRequest_ReconcileBundle{
+					ReconcileBundle: &notifier.ReconcileBundle{
+						Bundle: bundle,
+					},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			if resp.DriftedCopies > 0 {
+				m.c.Log.WithFields(logrus.Fields{
+					telemetry.Notifier: n.Name(),
+					telemetry.Count:    resp.DriftedCopies,
+				}).Warn("Notifier repaired bundle copies that were out of sync")
+				telemetry_server.IncrManagerBundleDriftCounter(m.c.Metrics, n.Name(), resp.DriftedCopies)
+			}
+			return nil
+		},
+	)
+}
+
+func (m *Manager) mirrorBundleEvery(ctx context.Context, interval time.Duration) error {
+	ticker := m.c.Clock.Ticker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.mirrorBundle(ctx); err != nil {
+				m.c.Log.WithError(err).Warn("Failed to mirror bundle to trust domain aliases")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// mirrorBundle merges the trust bundle into the bundle of each of the trust
+// domain aliases so that federated peers, the bundle API and agents keep
+// answering for the aliases with the current roots and keys. The roots and
+// keys the alias bundles already hold, e.g. those of the CA that issued SVIDs
+// under the previous name, are kept until they expire so those SVIDs keep
+// validating for the rest of their lifetime.
+func (m *Manager) mirrorBundle(ctx context.Context) error {
+	if len(m.c.TrustDomainAliases) == 0 {
+		return nil
+	}
+
+	bundle, err := m.fetchRequiredBundle(ctx)
+	if err != nil {
+		return err
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	expiresBefore := m.c.Clock.Now().Add(-safetyThreshold)
+	for _, alias := range m.c.TrustDomainAliases {
+		mirror := proto.Clone(bundle).(*common.Bundle)
+		mirror.TrustDomainId = alias.String()
+		if _, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+			Bundle: mirror,
+		}); err != nil {
+			return errs.New("unable to mirror bundle to %q: %v", alias.String(), err)
+		}
+		if _, err := ds.PruneBundle(ctx, &datastore.PruneBundleRequest{
+			TrustDomainId: alias.String(),
+			ExpiresBefore: expiresBefore.Unix(),
+		}); err != nil {
+			return errs.New("unable to prune bundle of %q: %v", alias.String(), err)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) notify(ctx context.Context, event string, advise bool, pre func(context.Context) error, do func(context.Context, catalog.Notifier) error) error {
 	notifiers := m.c.Catalog.GetNotifiers()
 	if len(notifiers) == 0 {
 		return nil
@@ -933,12 +1445,12 @@ func (s *x509CASlot) Reset() {
 	s.x509CA = nil
 }
 
-func (s *x509CASlot) ShouldPrepareNext(now time.Time) bool {
-	return s.x509CA != nil && now.After(preparationThreshold(s.issuedAt, s.x509CA.Certificate.NotAfter))
+func (s *x509CASlot) ShouldPrepareNext(now time.Time, prepareThreshold float64) bool {
+	return s.x509CA != nil && now.After(preparationThreshold(s.issuedAt, s.x509CA.Certificate.NotAfter, prepareThreshold))
 }
 
-func (s *x509CASlot) ShouldActivateNext(now time.Time) bool {
-	return s.x509CA != nil && now.After(KeyActivationThreshold(s.issuedAt, s.x509CA.Certificate.NotAfter))
+func (s *x509CASlot) ShouldActivateNext(now time.Time, activateThreshold float64) bool {
+	return s.x509CA != nil && now.After(KeyActivationThreshold(s.issuedAt, s.x509CA.Certificate.NotAfter, activateThreshold))
 }
 
 type jwtKeySlot struct {
@@ -965,12 +1477,12 @@ func (s *jwtKeySlot) Reset() {
 	s.jwtKey = nil
 }
 
-func (s *jwtKeySlot) ShouldPrepareNext(now time.Time) bool {
-	return s.jwtKey == nil || now.After(preparationThreshold(s.issuedAt, s.jwtKey.NotAfter))
+func (s *jwtKeySlot) ShouldPrepareNext(now time.Time, prepareThreshold float64) bool {
+	return s.jwtKey == nil || now.After(preparationThreshold(s.issuedAt, s.jwtKey.NotAfter, prepareThreshold))
 }
 
-func (s *jwtKeySlot) ShouldActivateNext(now time.Time) bool {
-	return s.jwtKey == nil || now.After(KeyActivationThreshold(s.issuedAt, s.jwtKey.NotAfter))
+func (s *jwtKeySlot) ShouldActivateNext(now time.Time, activateThreshold float64) bool {
+	return s.jwtKey == nil || now.After(KeyActivationThreshold(s.issuedAt, s.jwtKey.NotAfter, activateThreshold))
 }
 
 func otherSlotID(id string) string {
@@ -1038,40 +1550,69 @@ func SelfSignX509CA(ctx context.Context, signer crypto.Signer, trustDomain strin
 	}, trustBundle, nil
 }
 
-func UpstreamSignX509CA(ctx context.Context, signer crypto.Signer, trustDomain string, subject pkix.Name, upstreamClient *UpstreamClient, caTTL time.Duration) (*X509CA, error) {
+func UpstreamSignX509CA(ctx context.Context, signer crypto.Signer, trustDomain string, subject pkix.Name, upstreamClient *UpstreamClient, caTTL time.Duration, fullUpstreamChain bool) (*X509CA, error) {
 	csr, err := GenerateServerCACSR(signer, trustDomain, subject)
 	if err != nil {
 		return nil, err
 	}
 
-	caChain, err := upstreamClient.MintX509CA(ctx, csr, caTTL)
+	caChain, upstreamRoots, err := upstreamClient.MintX509CA(ctx, csr, caTTL)
 	if err != nil {
 		return nil, err
 	}
 
+	upstreamChain := caChain
+	if fullUpstreamChain {
+		upstreamChain, err = appendUpstreamRoot(caChain, upstreamRoots)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &X509CA{
 		Signer:        signer,
 		Certificate:   caChain[0],
-		UpstreamChain: caChain,
+		UpstreamChain: upstreamChain,
 	}, nil
 }
 
-func preparationThreshold(issuedAt, notAfter time.Time) time.Time {
-	lifetime := notAfter.Sub(issuedAt)
-	threshold := lifetime / 2
-	if threshold > preparationThresholdCap {
-		threshold = preparationThresholdCap
+// appendUpstreamRoot appends the upstream root that signed the last
+// certificate of the CA chain. The chain is returned as is if it already ends
+// with a root.
+func appendUpstreamRoot(caChain, upstreamRoots []*x509.Certificate) ([]*x509.Certificate, error) {
+	last := caChain[len(caChain)-1]
+	if isSelfSigned(last) {
+		return caChain, nil
+	}
+	for _, root := range upstreamRoots {
+		if bytes.Equal(last.RawIssuer, root.RawSubject) && last.CheckSignatureFrom(root) == nil {
+			return append(caChain[:len(caChain):len(caChain)], root), nil
+		}
 	}
-	return notAfter.Add(-threshold)
+	return nil, errs.New("unable to find the upstream root of the X509 CA chain")
 }
 
-func KeyActivationThreshold(issuedAt, notAfter time.Time) time.Time {
+// preparationThreshold returns the time after which the next X509 CA or JWT
+// key is prepared, given the fraction of the lifetime of the current one.
+func preparationThreshold(issuedAt, notAfter time.Time, prepareThreshold float64) time.Time {
+	return lifetimeThreshold(issuedAt, notAfter, prepareThreshold, preparationThresholdCap)
+}
+
+// KeyActivationThreshold returns the time after which the next X509 CA or
+// JWT key is activated, given the fraction of the lifetime of the current one.
+func KeyActivationThreshold(issuedAt, notAfter time.Time, activateThreshold float64) time.Time {
+	return lifetimeThreshold(issuedAt, notAfter, activateThreshold, activationThresholdCap)
+}
+
+// lifetimeThreshold returns the time at which the given fraction of the
+// lifetime has elapsed. The remaining lifetime at the threshold is capped.
+func lifetimeThreshold(issuedAt, notAfter time.Time, fraction float64, remainingCap time.Duration) time.Time {
 	lifetime := notAfter.Sub(issuedAt)
-	threshold := lifetime / 6
-	if threshold > activationThresholdCap {
-		threshold = activationThresholdCap
+	remaining := time.Duration(math.Round(float64(lifetime) * (1 - fraction)))
+	if remaining > remainingCap {
+		remaining = remainingCap
 	}
-	return notAfter.Add(-threshold)
+	return notAfter.Add(-remaining)
 }
 
 func newJWTKey(signer crypto.Signer, expiresAt time.Time) (*JWTKey, error) {
@@ -1121,3 +1662,31 @@ func keyIDFromBytes(choices []byte) string {
 func timeField(t time.Time) string {
 	return t.UTC().Format(time.RFC3339)
 }
+
+// X509AuthorityID returns the ID used to identify the given X509 authority,
+// which is the hex encoded subject key ID of the CA certificate.
+func X509AuthorityID(caCert *x509.Certificate) string {
+	return hex.EncodeToString(caCert.SubjectKeyId)
+}
+
+func findRootCA(bundle *common.Bundle, authorityID string) (int, *x509.Certificate, error) {
+	for i, rootCA := range bundle.RootCas {
+		caCert, err := x509.ParseCertificate(rootCA.DerBytes)
+		if err != nil {
+			return 0, nil, errs.New("unable to parse bundle root CA: %v", err)
+		}
+		if X509AuthorityID(caCert) == authorityID {
+			return i, caCert, nil
+		}
+	}
+	return 0, nil, status.Errorf(codes.NotFound, "no X509 authority found with ID %q", authorityID)
+}
+
+func findJWTSigningKey(bundle *common.Bundle, kid string) (int, error) {
+	for i, jwtSigningKey := range bundle.JwtSigningKeys {
+		if jwtSigningKey.Kid == kid {
+			return i, nil
+		}
+	}
+	return 0, status.Errorf(codes.NotFound, "no JWT authority found with ID %q", kid)
+}
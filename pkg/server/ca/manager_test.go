@@ -35,7 +35,9 @@ import (
 	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
 	"github.com/spiffe/spire/test/fakes/fakeupstreamauthority"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 )
 
 const (
@@ -176,6 +178,29 @@ func (s *ManagerSuite) TestUpstreamSigned() {
 	)
 }
 
+func (s *ManagerSuite) TestUpstreamIntermediateSignedWithFullUpstreamChain() {
+	upstreamAuthority, fakeUA := fakeupstreamauthority.Load(s.T(), fakeupstreamauthority.Config{
+		TrustDomain:           testTrustDomain,
+		DisallowPublishJWTKey: true,
+		UseIntermediate:       true,
+	})
+	s.cat.SetUpstreamAuthority(fakeservercatalog.UpstreamAuthority("fakeupstreamauthority", upstreamAuthority))
+
+	c := s.selfSignedConfig()
+	c.FullUpstreamChain = true
+	s.m = NewManager(c)
+	s.NoError(s.m.Initialize(context.Background()))
+
+	// X509 CA chain should contain itself, the upstream intermediate and the
+	// upstream root.
+	x509CA := s.currentX509CA()
+	if s.Len(x509CA.UpstreamChain, 3) {
+		s.Equal(x509CA.Certificate, x509CA.UpstreamChain[0])
+		s.Equal(fakeUA.X509Intermediate(), x509CA.UpstreamChain[1])
+		s.Equal(fakeUA.X509Root(), x509CA.UpstreamChain[2])
+	}
+}
+
 func (s *ManagerSuite) TestUpstreamIntermediateSigned() {
 	upstreamAuthority, fakeUA := fakeupstreamauthority.Load(s.T(), fakeupstreamauthority.Config{
 		TrustDomain:           testTrustDomain,
@@ -511,7 +536,7 @@ func (s *ManagerSuite) TestPreparationThresholdCap() {
 
 	// Expect the preparation threshold to get capped since 1/2 of the lifetime
 	// exceeds the thirty day cap.
-	threshold := preparationThreshold(issuedAt, notAfter)
+	threshold := preparationThreshold(issuedAt, notAfter, DefaultPrepareThreshold)
 	s.Require().Equal(thirtyDays, notAfter.Sub(threshold))
 }
 
@@ -521,10 +546,343 @@ func (s *ManagerSuite) TestActivationThreshholdCap() {
 
 	// Expect the activation threshold to get capped since 1/6 of the lifetime
 	// exceeds the seven day cap.
-	threshold := KeyActivationThreshold(issuedAt, notAfter)
+	threshold := KeyActivationThreshold(issuedAt, notAfter, DefaultActivateThreshold)
 	s.Require().Equal(sevenDays, notAfter.Sub(threshold))
 }
 
+func (s *ManagerSuite) TestConfiguredThresholds() {
+	s.cat.SetUpstreamAuthority(nil)
+	c := s.selfSignedConfig()
+	c.PrepareThreshold = 0.25
+	c.ActivateThreshold = 0.75
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(context.Background()))
+
+	first := s.currentX509CA()
+	initTime := s.clock.Now()
+
+	// move up to a quarter of the lifetime. nothing should change.
+	s.setTimeAndRotate(initTime.Add(testCATTL / 4))
+	s.Nil(s.nextX509CA())
+	s.Nil(s.nextJWTKey())
+
+	// move past a quarter of the lifetime. the next X509 CA and JWT key
+	// should have been prepared.
+	s.addTimeAndRotate(time.Minute)
+	second := s.nextX509CA()
+	s.NotNil(second)
+	s.NotNil(s.nextJWTKey())
+	s.requireX509CAEqual(first, s.currentX509CA())
+
+	// move past three quarters of the lifetime. the next X509 CA should have
+	// been activated.
+	s.setTimeAndRotate(initTime.Add(testCATTL*3/4 + time.Minute))
+	s.requireX509CAEqual(second, s.currentX509CA())
+	s.Nil(s.nextX509CA())
+}
+
+func (s *ManagerSuite) TestPrepareNext() {
+	s.initSelfSignedManager()
+	first := s.currentX509CA()
+	firstJWTKey := s.currentJWTKey()
+
+	x509CA, jwtKey, err := s.m.PrepareNext(context.Background())
+	s.Require().NoError(err)
+	s.requireX509CAEqual(x509CA, s.nextX509CA())
+	s.requireJWTKeyEqual(jwtKey, s.nextJWTKey())
+
+	// the current X509 CA and JWT key are unchanged but the prepared ones
+	// are added to the bundle.
+	s.requireX509CAEqual(first, s.currentX509CA())
+	s.requireJWTKeyEqual(firstJWTKey, s.currentJWTKey())
+	s.requireBundleRootCAs(first.Certificate, x509CA.Certificate)
+	s.requireBundleJWTKeys(firstJWTKey, jwtKey)
+
+	// preparing again replaces the prepared X509 CA and JWT key
+	x509CA2, jwtKey2, err := s.m.PrepareNext(context.Background())
+	s.Require().NoError(err)
+	s.requireX509CANotEqual(x509CA, x509CA2)
+	s.requireJWTKeyNotEqual(jwtKey, jwtKey2)
+	s.requireX509CAEqual(x509CA2, s.nextX509CA())
+	s.requireJWTKeyEqual(jwtKey2, s.nextJWTKey())
+}
+
+func (s *ManagerSuite) TestActivateNext() {
+	s.initSelfSignedManager()
+	first := s.currentX509CA()
+	firstJWTKey := s.currentJWTKey()
+
+	// nothing is prepared so the next X509 CA and JWT key are prepared
+	// before being activated.
+	x509CA, jwtKey, err := s.m.ActivateNext(context.Background())
+	s.Require().NoError(err)
+	s.requireX509CANotEqual(first, x509CA)
+	s.requireJWTKeyNotEqual(firstJWTKey, jwtKey)
+	s.requireX509CAEqual(x509CA, s.currentX509CA())
+	s.requireJWTKeyEqual(jwtKey, s.currentJWTKey())
+	s.Nil(s.nextX509CA())
+	s.Nil(s.nextJWTKey())
+
+	// the manual activation survives a restart
+	s.initSelfSignedManager()
+	s.requireX509CAEqual(x509CA, s.currentX509CA())
+	s.requireJWTKeyEqual(jwtKey, s.currentJWTKey())
+	s.Nil(s.nextX509CA())
+	s.Nil(s.nextJWTKey())
+
+	// activating a prepared X509 CA and JWT key does not prepare new ones
+	preparedX509CA, preparedJWTKey, err := s.m.PrepareNext(context.Background())
+	s.Require().NoError(err)
+	x509CA, jwtKey, err = s.m.ActivateNext(context.Background())
+	s.Require().NoError(err)
+	s.requireX509CAEqual(preparedX509CA, x509CA)
+	s.requireJWTKeyEqual(preparedJWTKey, jwtKey)
+}
+
+func (s *ManagerSuite) TestTaintAndRevokeX509CA() {
+	s.initSelfSignedManager()
+	ctx := context.Background()
+	first := s.currentX509CA()
+	firstID := X509AuthorityID(first.Certificate)
+
+	_, err := s.m.TaintX509CA(ctx, firstID)
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, "unable to taint the active X509 authority; rotate it first")
+
+	prepared, _, err := s.m.PrepareNext(ctx)
+	s.Require().NoError(err)
+	_, err = s.m.TaintX509CA(ctx, X509AuthorityID(prepared.Certificate))
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, "unable to taint the prepared X509 authority; prepare a new one first")
+
+	// revoking requires the X509 CA to be tainted first
+	_, _, err = s.m.ActivateNext(ctx)
+	s.Require().NoError(err)
+	_, err = s.m.RevokeX509CA(ctx, firstID)
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, fmt.Sprintf("X509 authority %q must be tainted before it can be revoked", firstID))
+
+	tainted, err := s.m.TaintX509CA(ctx, firstID)
+	s.Require().NoError(err)
+	s.Require().Equal(first.Certificate.Raw, tainted.Raw)
+	rootCAs := s.fetchBundle().RootCas
+	s.Require().Len(rootCAs, 2)
+	s.Require().True(rootCAs[0].TaintedKey)
+	s.Require().False(rootCAs[1].TaintedKey)
+
+	_, err = s.m.TaintX509CA(ctx, firstID)
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, fmt.Sprintf("X509 authority %q is already tainted", firstID))
+	_, err = s.m.TaintX509CA(ctx, "unknown")
+	spiretest.RequireGRPCStatus(s.T(), err, codes.NotFound, `no X509 authority found with ID "unknown"`)
+
+	revoked, err := s.m.RevokeX509CA(ctx, firstID)
+	s.Require().NoError(err)
+	s.Require().Equal(first.Certificate.Raw, revoked.Raw)
+	s.requireBundleRootCAs(prepared.Certificate)
+
+	_, err = s.m.RevokeX509CA(ctx, firstID)
+	spiretest.RequireGRPCStatus(s.T(), err, codes.NotFound, fmt.Sprintf("no X509 authority found with ID %q", firstID))
+}
+
+func (s *ManagerSuite) TestTaintX509CAFailsWithUpstreamAuthority() {
+	ua, _ := fakeupstreamauthority.Load(s.T(), fakeupstreamauthority.Config{
+		TrustDomain: testTrustDomain,
+	})
+	s.initUpstreamSignedManager(fakeservercatalog.UpstreamAuthority("fakeupstreamauthority", ua))
+
+	_, err := s.m.TaintX509CA(context.Background(), "authority")
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, "tainting X509 authorities is not supported when an UpstreamAuthority is configured")
+}
+
+func (s *ManagerSuite) TestTaintAndRevokeJWTKey() {
+	s.initSelfSignedManager()
+	ctx := context.Background()
+	first := s.currentJWTKey()
+
+	_, err := s.m.TaintJWTKey(ctx, first.Kid)
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, "unable to taint the active JWT authority; rotate it first")
+
+	_, prepared, err := s.m.PrepareNext(ctx)
+	s.Require().NoError(err)
+	_, err = s.m.TaintJWTKey(ctx, prepared.Kid)
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, "unable to taint the prepared JWT authority; prepare a new one first")
+
+	// revoking requires the JWT key to be tainted first
+	_, _, err = s.m.ActivateNext(ctx)
+	s.Require().NoError(err)
+	_, err = s.m.RevokeJWTKey(ctx, first.Kid)
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, fmt.Sprintf("JWT authority %q must be tainted before it can be revoked", first.Kid))
+
+	tainted, err := s.m.TaintJWTKey(ctx, first.Kid)
+	s.Require().NoError(err)
+	s.Require().Equal(first.Kid, tainted.Kid)
+	s.Require().True(tainted.TaintedKey)
+	jwtSigningKeys := s.fetchBundle().JwtSigningKeys
+	s.Require().Len(jwtSigningKeys, 2)
+	s.Require().True(jwtSigningKeys[0].TaintedKey)
+	s.Require().False(jwtSigningKeys[1].TaintedKey)
+
+	_, err = s.m.TaintJWTKey(ctx, first.Kid)
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, fmt.Sprintf("JWT authority %q is already tainted", first.Kid))
+	_, err = s.m.TaintJWTKey(ctx, "unknown")
+	spiretest.RequireGRPCStatus(s.T(), err, codes.NotFound, `no JWT authority found with ID "unknown"`)
+
+	revoked, err := s.m.RevokeJWTKey(ctx, first.Kid)
+	s.Require().NoError(err)
+	s.Require().Equal(first.Kid, revoked.Kid)
+	s.requireBundleJWTKeys(prepared)
+}
+
+func (s *ManagerSuite) TestCAEventNotifications() {
+	notifier, notifyCh := fakenotifier.NotifyWaiter()
+	s.setNotifier(notifier)
+	s.initSelfSignedManager()
+
+	// kick off a goroutine to service CA event notifications. This is
+	// typically handled by Run() but using it would complicate the test.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.m.notifyOnCAEvents(ctx)
+
+	// initialization prepares and activates the first X509 CA and JWT key
+	first := s.currentX509CA()
+	firstJWTKey := s.currentJWTKey()
+	s.Require().Equal(first.Certificate.Raw, s.waitForCAEvent(notifyCh).GetCaPrepared().X509Ca)
+	s.Require().Equal(first.Certificate.Raw, s.waitForCAEvent(notifyCh).GetCaActivated().X509Ca)
+	s.Require().Equal(firstJWTKey.Kid, s.waitForCAEvent(notifyCh).GetCaPrepared().GetJwtKey().Kid)
+	s.Require().Equal(firstJWTKey.Kid, s.waitForCAEvent(notifyCh).GetCaActivated().GetJwtKey().Kid)
+
+	second, secondJWTKey, err := s.m.ActivateNext(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(second.Certificate.Raw, s.waitForCAEvent(notifyCh).GetCaPrepared().X509Ca)
+	s.Require().Equal(secondJWTKey.Kid, s.waitForCAEvent(notifyCh).GetCaPrepared().GetJwtKey().Kid)
+	s.Require().Equal(second.Certificate.Raw, s.waitForCAEvent(notifyCh).GetCaActivated().X509Ca)
+	s.Require().Equal(secondJWTKey.Kid, s.waitForCAEvent(notifyCh).GetCaActivated().GetJwtKey().Kid)
+
+	_, err = s.m.TaintX509CA(ctx, X509AuthorityID(first.Certificate))
+	s.Require().NoError(err)
+	s.Require().Equal(first.Certificate.Raw, s.waitForCAEvent(notifyCh).GetCaTainted().X509Ca)
+
+	_, err = s.m.TaintJWTKey(ctx, firstJWTKey.Kid)
+	s.Require().NoError(err)
+	tainted := s.waitForCAEvent(notifyCh).GetCaTainted().GetJwtKey()
+	s.Require().Equal(firstJWTKey.Kid, tainted.Kid)
+	s.Require().True(tainted.TaintedKey)
+}
+
+func (s *ManagerSuite) TestReconcileBundle() {
+	s.initSelfSignedManager()
+
+	metrics := fakemetrics.New()
+	s.m.c.Metrics = metrics
+
+	var actual *common.Bundle
+	s.setNotifier(fakenotifier.New(fakenotifier.Config{
+		OnNotify: func(req *notifier.NotifyRequest) (*notifier.NotifyResponse, error) {
+			actual = req.GetReconcileBundle().GetBundle()
+			return &notifier.NotifyResponse{DriftedCopies: 2}, nil
+		},
+	}))
+
+	s.Require().NoError(s.m.reconcileBundle(ctx))
+	s.RequireProtoEqual(s.fetchBundle(), actual)
+
+	entry := s.logHook.LastEntry()
+	s.Equal("Notifier repaired bundle copies that were out of sync", entry.Message)
+	s.Equal("fake", entry.Data[telemetry.Notifier])
+	s.Equal(int32(2), entry.Data[telemetry.Count])
+
+	expected := fakemetrics.New()
+	telemetry_server.IncrManagerBundleDriftCounter(expected, "fake", 2)
+	s.Require().Equal(expected.AllMetrics(), metrics.AllMetrics())
+}
+
+func (s *ManagerSuite) TestReconcileBundleWithoutDrift() {
+	s.initSelfSignedManager()
+
+	metrics := fakemetrics.New()
+	s.m.c.Metrics = metrics
+	s.setNotifier(fakenotifier.New(fakenotifier.Config{}))
+	s.logHook.Reset()
+
+	s.Require().NoError(s.m.reconcileBundle(ctx))
+	s.Nil(s.logHook.LastEntry())
+	s.Empty(metrics.AllMetrics())
+}
+
+func (s *ManagerSuite) TestMirrorBundle() {
+	alias := url.URL{Scheme: "spiffe", Host: "old.example.org"}
+
+	c := s.selfSignedConfig()
+	c.TrustDomainAliases = []url.URL{alias}
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(ctx))
+
+	s.Require().NoError(s.m.mirrorBundle(ctx))
+	mirror := s.fetchBundleForTrustDomain(alias.String())
+	expected := s.fetchBundle()
+	expected.TrustDomainId = alias.String()
+	s.RequireProtoEqual(expected, mirror)
+
+	// rotating adds the new roots and keys to the bundle, which are mirrored
+	// again to the alias
+	s.addTimeAndRotate(testCATTL)
+	s.Require().NoError(s.m.mirrorBundle(ctx))
+	mirror = s.fetchBundleForTrustDomain(alias.String())
+	expected = s.fetchBundle()
+	expected.TrustDomainId = alias.String()
+	s.RequireProtoEqual(expected, mirror)
+	s.Len(mirror.RootCas, 2)
+}
+
+func (s *ManagerSuite) TestMirrorBundleKeepsAliasRoots() {
+	alias := url.URL{Scheme: "spiffe", Host: "old.example.org"}
+
+	// the alias bundle holds the CA and JWT key that issued SVIDs under the
+	// previous name
+	now := s.clock.Now()
+	oldCA, oldCAKey := testca.CreateCACertificate(s.T(), nil, nil, testca.WithLifetime(now, now.Add(time.Minute)))
+	oldJWTKeyPKIX, err := x509.MarshalPKIXPublicKey(oldCAKey.Public())
+	s.Require().NoError(err)
+	oldJWTKey := &common.PublicKey{
+		Kid:       "old",
+		PkixBytes: oldJWTKeyPKIX,
+		NotAfter:  now.Add(time.Minute).Unix(),
+	}
+	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  alias.String(),
+			RootCas:        []*common.Certificate{{DerBytes: oldCA.Raw}},
+			JwtSigningKeys: []*common.PublicKey{oldJWTKey},
+		},
+	})
+	s.Require().NoError(err)
+
+	c := s.selfSignedConfig()
+	c.TrustDomainAliases = []url.URL{alias}
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(ctx))
+
+	// mirroring adds the current roots and keys without dropping the old ones
+	s.Require().NoError(s.m.mirrorBundle(ctx))
+	bundle := s.fetchBundle()
+	mirror := s.fetchBundleForTrustDomain(alias.String())
+	s.RequireProtoListEqual(append([]*common.Certificate{{DerBytes: oldCA.Raw}}, bundle.RootCas...), mirror.RootCas)
+	s.RequireProtoListEqual(append([]*common.PublicKey{oldJWTKey}, bundle.JwtSigningKeys...), mirror.JwtSigningKeys)
+
+	// the old CA and JWT key are pruned from the alias bundle once they are
+	// past the safety threshold after expiring
+	s.clock.Add(time.Minute + safetyThreshold + time.Minute)
+	s.Require().NoError(s.m.rotate(ctx))
+	s.Require().NoError(s.m.rotate(ctx))
+	s.Require().NoError(s.m.mirrorBundle(ctx))
+	mirror = s.fetchBundleForTrustDomain(alias.String())
+	for _, rootCA := range mirror.RootCas {
+		s.NotEqual(oldCA.Raw, rootCA.DerBytes)
+	}
+	for _, jwtKey := range mirror.JwtSigningKeys {
+		s.NotEqual(oldJWTKey.Kid, jwtKey.Kid)
+	}
+	s.NotEmpty(mirror.RootCas)
+}
+
 func (s *ManagerSuite) TestAlternateKeyTypes() {
 	ua, _ := fakeupstreamauthority.Load(s.T(), fakeupstreamauthority.Config{
 		TrustDomain: testTrustDomain,
@@ -897,6 +1255,16 @@ func (s *ManagerSuite) waitForBundleUpdatedNotification(ch <-chan *notifier.Noti
 	}
 }
 
+func (s *ManagerSuite) waitForCAEvent(ch <-chan *notifier.NotifyRequest) *notifier.NotifyRequest {
+	select {
+	case <-time.After(time.Minute):
+		s.FailNow("timed out waiting for CA event notification")
+		return nil
+	case req := <-ch:
+		return req
+	}
+}
+
 func (s *ManagerSuite) countLogEntries(level logrus.Level, message string) int { //nolint
 	count := 0
 	for _, e := range s.logHook.AllEntries() {
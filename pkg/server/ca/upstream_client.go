@@ -39,6 +39,9 @@ type UpstreamClient struct {
 	mintX509CAStream    *streamState
 	publishJWTKeyMtx    sync.Mutex
 	publishJWTKeyStream *streamState
+
+	statusMtx sync.RWMutex
+	lastErr   error
 }
 
 // NewUpstreamClient returns a new UpstreamAuthority plugin client.
@@ -66,13 +69,15 @@ func (u *UpstreamClient) Close() error {
 	return nil
 }
 
-// MintX509CA mints an X.509CA using the UpstreamAuthority. It maintains an
-// open stream to the UpstreamAuthority plugin to receive and append X.509 root
+// MintX509CA mints an X.509CA using the UpstreamAuthority. It returns the
+// X.509 CA chain along with the upstream X.509 roots. It maintains an open
+// stream to the UpstreamAuthority plugin to receive and append X.509 root
 // updates to the bundle. The stream remains open until another call to
 // MintX509CA happens or the client is closed.
-func (u *UpstreamClient) MintX509CA(ctx context.Context, csr []byte, ttl time.Duration) (_ []*x509.Certificate, err error) {
+func (u *UpstreamClient) MintX509CA(ctx context.Context, csr []byte, ttl time.Duration) (_ []*x509.Certificate, _ []*x509.Certificate, err error) {
 	u.mintX509CAMtx.Lock()
 	defer u.mintX509CAMtx.Unlock()
+	defer func() { u.setStatus(err) }()
 
 	req := &upstreamauthority.MintX509CARequest{
 		Csr:          csr,
@@ -93,16 +98,16 @@ func (u *UpstreamClient) MintX509CA(ctx context.Context, csr []byte, ttl time.Du
 	case result := <-firstResultCh:
 		switch {
 		case result.err != nil:
-			return nil, result.err
+			return nil, nil, result.err
 		case result.done:
 			// There isn't going to be any more responses on the stream because
 			// we're not participating in the upstream PKI so upstream bundle
 			// updates are inconsequential.
 			u.mintX509CAStream.Stop()
 		}
-		return result.x509CA, nil
+		return result.x509CA, result.x509Roots, nil
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return nil, nil, ctx.Err()
 	}
 }
 
@@ -118,6 +123,7 @@ func (u *UpstreamClient) WaitUntilMintX509CAStreamDone(ctx context.Context) erro
 func (u *UpstreamClient) PublishJWTKey(ctx context.Context, jwtKey *common.PublicKey) (_ []*common.PublicKey, err error) {
 	u.publishJWTKeyMtx.Lock()
 	defer u.publishJWTKeyMtx.Unlock()
+	defer func() { u.setStatus(err) }()
 
 	req := &upstreamauthority.PublishJWTKeyRequest{
 		JwtKey: jwtKey,
@@ -155,6 +161,20 @@ func (u *UpstreamClient) WaitUntilPublishJWTKeyStreamDone(ctx context.Context) e
 	return u.publishJWTKeyStream.WaitUntilStopped(ctx)
 }
 
+// Status returns the error of the last call made to the UpstreamAuthority
+// plugin, or nil if it succeeded.
+func (u *UpstreamClient) Status() error {
+	u.statusMtx.RLock()
+	defer u.statusMtx.RUnlock()
+	return u.lastErr
+}
+
+func (u *UpstreamClient) setStatus(err error) {
+	u.statusMtx.Lock()
+	defer u.statusMtx.Unlock()
+	u.lastErr = err
+}
+
 func (u *UpstreamClient) runMintX509CAStream(ctx context.Context, req *upstreamauthority.MintX509CARequest, firstResultCh chan<- mintX509CAResult) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -182,7 +202,7 @@ func (u *UpstreamClient) runMintX509CAStream(ctx context.Context, req *upstreama
 		return
 	}
 
-	firstResultCh <- mintX509CAResult{x509CA: x509CA}
+	firstResultCh <- mintX509CAResult{x509CA: x509CA, x509Roots: x509Roots}
 
 	for {
 		resp, err := stream.Recv()
@@ -260,9 +280,10 @@ func (u *UpstreamClient) runPublishJWTKeyStream(ctx context.Context, req *upstre
 }
 
 type mintX509CAResult struct {
-	x509CA []*x509.Certificate
-	done   bool
-	err    error
+	x509CA    []*x509.Certificate
+	x509Roots []*x509.Certificate
+	done      bool
+	err       error
 }
 
 func parseMintX509CAFirstResponse(resp *upstreamauthority.MintX509CAResponse) ([]*x509.Certificate, []*x509.Certificate, error) {
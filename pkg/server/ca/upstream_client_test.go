@@ -28,9 +28,11 @@ func TestUpstreamClientMintX509CA_HandlesBundleUpdates(t *testing.T) {
 		UseIntermediate: true,
 	})
 
-	x509CA, err := client.MintX509CA(context.Background(), csr, 0)
+	x509CA, x509Roots, err := client.MintX509CA(context.Background(), csr, 0)
 	require.NoError(t, err)
 	require.Len(t, x509CA, 2)
+	require.Equal(t, ua.X509Roots(), x509Roots)
+	require.NoError(t, client.Status())
 
 	// Assert that the initial bundle update happened.
 	require.Equal(t, ua.X509Roots(), updater.WaitForAppendedX509Roots(t))
@@ -83,9 +85,10 @@ func TestUpstreamClientMintX509CA_FailsOnBadFirstResponse(t *testing.T) {
 				MutateMintX509CAResponse: tt.mutate,
 			})
 
-			_, err := client.MintX509CA(context.Background(), csr, 0)
+			_, _, err := client.MintX509CA(context.Background(), csr, 0)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), tt.err)
+			require.Equal(t, err, client.Status())
 		})
 	}
 }
@@ -133,7 +136,7 @@ func TestUpstreamClientMintX509CA_LogsOnBadSubsequentResponses(t *testing.T) {
 				},
 			})
 
-			x509CA, err := client.MintX509CA(context.Background(), csr, 0)
+			x509CA, _, err := client.MintX509CA(context.Background(), csr, 0)
 			require.NoError(t, err)
 			require.NotNil(t, x509CA)
 
@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
 )
 
@@ -84,9 +86,27 @@ type Agent struct {
 	Selectors []*types.Selector
 }
 
+// FullEntryCache is a Cache of all registration entries and agent selectors.
+// Besides being rebuilt from scratch, it can be updated in place as
+// registration entries and agent selectors change. Updates are not safe for
+// concurrent use with each other or with GetAuthorizedEntries; callers are
+// responsible for synchronization.
 type FullEntryCache struct {
 	aliases map[spiffeID][]aliasEntry
 	entries map[spiffeID][]*types.Entry
+
+	// The following indexes are used to apply updates without scanning the
+	// whole cache.
+	byID           map[string]*types.Entry
+	aliasSelectors map[string]selectorSet
+	aliasesBySel   map[Selector]stringSet
+	agentSelectors map[spiffeID]selectorSet
+	agentsBySel    map[Selector]seenSet
+
+	// aliasExprs holds the parsed selector expressions of the node aliases
+	// that have one. Malformed expressions are held as nil and match no
+	// agent.
+	aliasExprs map[string]*selector.Expression
 }
 
 type selectorSet map[Selector]struct{}
@@ -108,66 +128,201 @@ type aliasEntry struct {
 // Build queries the data source for all registration entries and Agent selectors and builds an in-memory
 // representation of the data that can be used for efficient lookups.
 func Build(ctx context.Context, entryIter EntryIterator, agentIter AgentIterator) (*FullEntryCache, error) {
-	type aliasInfo struct {
-		aliasEntry
-		selectors selectorSet
+	c := &FullEntryCache{
+		aliases:        make(map[spiffeID][]aliasEntry),
+		entries:        make(map[spiffeID][]*types.Entry),
+		byID:           make(map[string]*types.Entry),
+		aliasSelectors: make(map[string]selectorSet),
+		aliasesBySel:   make(map[Selector]stringSet),
+		agentSelectors: make(map[spiffeID]selectorSet),
+		agentsBySel:    make(map[Selector]seenSet),
+		aliasExprs:     make(map[string]*selector.Expression),
 	}
-	bysel := make(map[Selector][]aliasInfo)
 
-	entries := make(map[spiffeID][]*types.Entry)
+	// Agents have not been indexed yet, so adding the entries does not
+	// resolve node aliases. They are resolved as agents are added.
 	for entryIter.Next(ctx) {
-		entry := entryIter.Entry()
-		parentID := spiffeIDFromProto(entry.ParentId)
-		if parentID.Path == "/spire/server" {
-			alias := aliasInfo{
-				aliasEntry: aliasEntry{
-					id:    spiffeIDFromProto(entry.SpiffeId),
-					entry: entry,
-				},
-				selectors: selectorSetFromProto(entry.Selectors),
-			}
-			for selector := range alias.selectors {
-				bysel[selector] = append(bysel[selector], alias)
-			}
-			continue
-		}
-		entries[parentID] = append(entries[parentID], entry)
+		c.addEntry(entryIter.Entry())
 	}
 	if err := entryIter.Err(); err != nil {
 		return nil, err
 	}
 
+	for agentIter.Next(ctx) {
+		agent := agentIter.Agent()
+		c.setAgentSelectors(spiffeIDFromID(agent.ID), selectorSetFromProto(agent.Selectors))
+	}
+	if err := agentIter.Err(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// UpsertEntry adds the registration entry to the cache, replacing the entry
+// with the same ID, if any.
+func (c *FullEntryCache) UpsertEntry(entry *types.Entry) {
+	c.RemoveEntry(entry.Id)
+	c.addEntry(entry)
+}
+
+// RemoveEntry removes the registration entry with the given ID from the
+// cache, if present.
+func (c *FullEntryCache) RemoveEntry(entryID string) {
+	entry, ok := c.byID[entryID]
+	if !ok {
+		return
+	}
+	delete(c.byID, entryID)
+
+	parentID := spiffeIDFromProto(entry.ParentId)
+	if !isNodeAliasParent(parentID) {
+		c.entries[parentID] = removeEntry(c.entries[parentID], entryID)
+		if len(c.entries[parentID]) == 0 {
+			delete(c.entries, parentID)
+		}
+		return
+	}
+
+	selectors := c.aliasSelectors[entryID]
+	delete(c.aliasSelectors, entryID)
+	delete(c.aliasExprs, entryID)
+	for selector := range selectors {
+		delete(c.aliasesBySel[selector], entryID)
+		if len(c.aliasesBySel[selector]) == 0 {
+			delete(c.aliasesBySel, selector)
+		}
+	}
+	for _, agentID := range c.agentsMatching(selectors) {
+		c.aliases[agentID] = removeAlias(c.aliases[agentID], entryID)
+		if len(c.aliases[agentID]) == 0 {
+			delete(c.aliases, agentID)
+		}
+	}
+}
+
+// SetAgentSelectors sets the selectors of the agent, resolving the node
+// aliases it belongs to.
+func (c *FullEntryCache) SetAgentSelectors(agentID spiffeid.ID, selectors []*types.Selector) {
+	c.setAgentSelectors(spiffeIDFromID(agentID), selectorSetFromProto(selectors))
+}
+
+func (c *FullEntryCache) addEntry(entry *types.Entry) {
+	c.byID[entry.Id] = entry
+
+	parentID := spiffeIDFromProto(entry.ParentId)
+	if !isNodeAliasParent(parentID) {
+		c.entries[parentID] = append(c.entries[parentID], entry)
+		return
+	}
+
+	selectors := selectorSetFromProto(entry.Selectors)
+	c.aliasSelectors[entry.Id] = selectors
+	if entry.SelectorExpression != "" {
+		expr, err := selector.ParseExpression(entry.SelectorExpression)
+		if err != nil {
+			expr = nil
+		}
+		c.aliasExprs[entry.Id] = expr
+	}
+	for selector := range selectors {
+		ids, ok := c.aliasesBySel[selector]
+		if !ok {
+			ids = make(stringSet)
+			c.aliasesBySel[selector] = ids
+		}
+		ids[entry.Id] = struct{}{}
+	}
+
+	alias := aliasEntry{
+		id:    spiffeIDFromProto(entry.SpiffeId),
+		entry: entry,
+	}
+	for _, agentID := range c.agentsMatching(selectors) {
+		if c.aliasExprMatches(entry.Id, c.agentSelectors[agentID]) {
+			c.aliases[agentID] = append(c.aliases[agentID], alias)
+		}
+	}
+}
+
+func (c *FullEntryCache) setAgentSelectors(agentID spiffeID, selectors selectorSet) {
+	for selector := range c.agentSelectors[agentID] {
+		delete(c.agentsBySel[selector], agentID)
+		if len(c.agentsBySel[selector]) == 0 {
+			delete(c.agentsBySel, selector)
+		}
+	}
+	delete(c.aliases, agentID)
+
+	if len(selectors) == 0 {
+		delete(c.agentSelectors, agentID)
+		return
+	}
+	c.agentSelectors[agentID] = selectors
+	for selector := range selectors {
+		agents, ok := c.agentsBySel[selector]
+		if !ok {
+			agents = make(seenSet)
+			c.agentsBySel[selector] = agents
+		}
+		agents[agentID] = struct{}{}
+	}
+
+	// track which aliases we've evaluated so far to make sure we don't
+	// add one twice.
 	aliasSeen := allocStringSet()
 	defer freeStringSet(aliasSeen)
 
-	aliases := make(map[spiffeID][]aliasEntry)
-	for agentIter.Next(ctx) {
-		agent := agentIter.Agent()
-		agentID := spiffeIDFromID(agent.ID)
-		agentSelectors := selectorSetFromProto(agent.Selectors)
-		// track which aliases we've evaluated so far to make sure we don't
-		// add one twice.
-		clearStringSet(aliasSeen)
-		for s := range agentSelectors {
-			for _, alias := range bysel[s] {
-				if _, ok := aliasSeen[alias.entry.Id]; ok {
-					continue
-				}
-				aliasSeen[alias.entry.Id] = struct{}{}
-				if isSubset(alias.selectors, agentSelectors) {
-					aliases[agentID] = append(aliases[agentID], alias.aliasEntry)
-				}
+	for selector := range selectors {
+		for entryID := range c.aliasesBySel[selector] {
+			if _, ok := aliasSeen[entryID]; ok {
+				continue
+			}
+			aliasSeen[entryID] = struct{}{}
+			if isSubset(c.aliasSelectors[entryID], selectors) && c.aliasExprMatches(entryID, selectors) {
+				entry := c.byID[entryID]
+				c.aliases[agentID] = append(c.aliases[agentID], aliasEntry{
+					id:    spiffeIDFromProto(entry.SpiffeId),
+					entry: entry,
+				})
 			}
 		}
 	}
-	if err := agentIter.Err(); err != nil {
-		return nil, err
+}
+
+// agentsMatching returns the agents that have all of the given selectors.
+func (c *FullEntryCache) agentsMatching(selectors selectorSet) []spiffeID {
+	// Every matching agent has any one of the selectors, so it is enough to
+	// look at the agents having one of them.
+	for selector := range selectors {
+		var agentIDs []spiffeID
+		for agentID := range c.agentsBySel[selector] {
+			if isSubset(selectors, c.agentSelectors[agentID]) {
+				agentIDs = append(agentIDs, agentID)
+			}
+		}
+		return agentIDs
 	}
+	return nil
+}
 
-	return &FullEntryCache{
-		aliases: aliases,
-		entries: entries,
-	}, nil
+// aliasExprMatches returns whether the agent selectors satisfy the selector
+// expression of the node alias, if any. Expressions let one alias group
+// agents by any of several selectors or by selector prefixes, e.g. all the
+// nodes of the autoscaling groups named "prod-*".
+func (c *FullEntryCache) aliasExprMatches(entryID string, agentSelectors selectorSet) bool {
+	expr, ok := c.aliasExprs[entryID]
+	if !ok {
+		return true
+	}
+	if expr == nil {
+		return false
+	}
+	selectors := make([]*common.Selector, 0, len(agentSelectors))
+	for s := range agentSelectors {
+		selectors = append(selectors, &common.Selector{Type: s.Type, Value: s.Value})
+	}
+	return expr.Matches(selectors)
 }
 
 // GetAuthorizedEntries gets all authorized registration entries for a given Agent SPIFFE ID.
@@ -205,6 +360,33 @@ func (c *FullEntryCache) crawl(parentID spiffeID, seen map[spiffeID]struct{}) []
 	return entries
 }
 
+// isNodeAliasParent returns true if entries with the given parent ID are
+// node aliases, i.e. they group the agents having all of the entry selectors
+// and satisfying the entry selector expression, if any.
+func isNodeAliasParent(parentID spiffeID) bool {
+	return parentID.Path == "/spire/server"
+}
+
+func removeEntry(entries []*types.Entry, entryID string) []*types.Entry {
+	filtered := entries[:0]
+	for _, entry := range entries {
+		if entry.Id != entryID {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+func removeAlias(aliases []aliasEntry, entryID string) []aliasEntry {
+	filtered := aliases[:0]
+	for _, alias := range aliases {
+		if alias.entry.Id != entryID {
+			filtered = append(filtered, alias)
+		}
+	}
+	return filtered
+}
+
 func spiffeIDFromID(id spiffeid.ID) spiffeID {
 	return spiffeID{
 		TrustDomain: id.TrustDomain().String(),
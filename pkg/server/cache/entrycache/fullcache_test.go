@@ -420,6 +420,123 @@ func TestFullCacheExcludesNodeSelectorMappedEntriesForExpiredAgents(t *testing.T
 	assert.Equal(t, expectedEntry, entries[0])
 }
 
+func TestFullCacheIncrementalUpdates(t *testing.T) {
+	agentID := td.NewID("/spire/agent/agent1")
+	serverID := api.ProtoFromID(td.NewID("/spire/server"))
+	s1 := &types.Selector{Type: "s", Value: "1"}
+	s2 := &types.Selector{Type: "s", Value: "2"}
+
+	workload := &types.Entry{
+		Id:       "workload",
+		ParentId: api.ProtoFromID(agentID),
+		SpiffeId: api.ProtoFromID(td.NewID("/workload")),
+	}
+	alias := &types.Entry{
+		Id:        "alias",
+		ParentId:  serverID,
+		SpiffeId:  api.ProtoFromID(td.NewID("/alias")),
+		Selectors: []*types.Selector{s1, s2},
+	}
+	aliasWorkload := &types.Entry{
+		Id:       "alias-workload",
+		ParentId: alias.SpiffeId,
+		SpiffeId: api.ProtoFromID(td.NewID("/alias-workload")),
+	}
+
+	cache, err := Build(context.Background(), makeEntryIterator(nil), makeAgentIterator([]Agent{
+		{ID: agentID, Selectors: []*types.Selector{s1}},
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, cache.GetAuthorizedEntries(agentID))
+
+	cache.UpsertEntry(workload)
+	cache.UpsertEntry(alias)
+	cache.UpsertEntry(aliasWorkload)
+	assert.Equal(t, []*types.Entry{workload}, cache.GetAuthorizedEntries(agentID))
+
+	// The agent joins the node alias once it has all of the alias selectors
+	cache.SetAgentSelectors(agentID, []*types.Selector{s1, s2})
+	assert.ElementsMatch(t, []*types.Entry{workload, alias, aliasWorkload}, cache.GetAuthorizedEntries(agentID))
+
+	// Updating an entry replaces it, even when it moves to another parent
+	movedWorkload := &types.Entry{
+		Id:       "workload",
+		ParentId: alias.SpiffeId,
+		SpiffeId: workload.SpiffeId,
+		Ttl:      60,
+	}
+	cache.UpsertEntry(movedWorkload)
+	assert.ElementsMatch(t, []*types.Entry{alias, aliasWorkload, movedWorkload}, cache.GetAuthorizedEntries(agentID))
+
+	// Node aliases that are updated with selectors the agent does not have
+	// no longer apply to it
+	cache.UpsertEntry(&types.Entry{
+		Id:        "alias",
+		ParentId:  serverID,
+		SpiffeId:  alias.SpiffeId,
+		Selectors: []*types.Selector{s1, {Type: "s", Value: "3"}},
+	})
+	assert.Empty(t, cache.GetAuthorizedEntries(agentID))
+
+	cache.UpsertEntry(alias)
+	cache.RemoveEntry("alias-workload")
+	cache.RemoveEntry("unknown")
+	assert.ElementsMatch(t, []*types.Entry{alias, movedWorkload}, cache.GetAuthorizedEntries(agentID))
+
+	cache.RemoveEntry("alias")
+	assert.Empty(t, cache.GetAuthorizedEntries(agentID))
+}
+
+func TestFullCacheNodeAliasSelectorExpression(t *testing.T) {
+	prodAgentID := td.NewID("/spire/agent/prod")
+	devAgentID := td.NewID("/spire/agent/dev")
+	account := &types.Selector{Type: "aws_iid", Value: "account:123"}
+	prodA := &types.Selector{Type: "aws_iid", Value: "tag:group:prod-a"}
+	prodB := &types.Selector{Type: "aws_iid", Value: "tag:group:prod-b"}
+	dev := &types.Selector{Type: "aws_iid", Value: "tag:group:dev"}
+
+	alias := &types.Entry{
+		Id:                 "alias",
+		ParentId:           api.ProtoFromID(td.NewID("/spire/server")),
+		SpiffeId:           api.ProtoFromID(td.NewID("/prod-nodes")),
+		Selectors:          []*types.Selector{account},
+		SelectorExpression: "aws_iid:tag:group:prod-* || aws_iid:tag:group:staging",
+	}
+
+	cache, err := Build(context.Background(), makeEntryIterator([]*types.Entry{alias}), makeAgentIterator([]Agent{
+		{ID: prodAgentID, Selectors: []*types.Selector{account, prodA}},
+		{ID: devAgentID, Selectors: []*types.Selector{account, dev}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Entry{alias}, cache.GetAuthorizedEntries(prodAgentID))
+	assert.Empty(t, cache.GetAuthorizedEntries(devAgentID))
+
+	// The expression is evaluated again when the agent selectors change
+	cache.SetAgentSelectors(prodAgentID, []*types.Selector{account, dev})
+	cache.SetAgentSelectors(devAgentID, []*types.Selector{account, prodB})
+	assert.Empty(t, cache.GetAuthorizedEntries(prodAgentID))
+	assert.Equal(t, []*types.Entry{alias}, cache.GetAuthorizedEntries(devAgentID))
+
+	// The exact selectors of the alias are still required
+	cache.SetAgentSelectors(devAgentID, []*types.Selector{prodB})
+	assert.Empty(t, cache.GetAuthorizedEntries(devAgentID))
+
+	// Malformed expressions match no agent
+	cache.SetAgentSelectors(devAgentID, []*types.Selector{account, prodB})
+	malformed := *alias
+	malformed.SelectorExpression = "aws_iid:tag:group:prod-* ||"
+	cache.UpsertEntry(&malformed)
+	assert.Empty(t, cache.GetAuthorizedEntries(devAgentID))
+
+	// Dropping the expression applies the alias to every agent with its
+	// selectors
+	unrestricted := *alias
+	unrestricted.SelectorExpression = ""
+	cache.UpsertEntry(&unrestricted)
+	assert.Equal(t, []*types.Entry{&unrestricted}, cache.GetAuthorizedEntries(prodAgentID))
+	assert.Equal(t, []*types.Entry{&unrestricted}, cache.GetAuthorizedEntries(devAgentID))
+}
+
 func TestBuildIteratorError(t *testing.T) {
 	tests := []struct {
 		desc    string
@@ -8,12 +8,14 @@ import (
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/health"
 	common_log "github.com/spiffe/spire/pkg/common/log"
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	datastore_telemetry "github.com/spiffe/spire/pkg/common/telemetry/server/datastore"
 	keymanager_telemetry "github.com/spiffe/spire/pkg/common/telemetry/server/keymanager"
 	"github.com/spiffe/spire/pkg/server/cache/dscache"
+	"github.com/spiffe/spire/pkg/server/entryevents"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	ds_sql "github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
@@ -34,13 +36,17 @@ import (
 	nr_azure_msi "github.com/spiffe/spire/pkg/server/plugin/noderesolver/azure"
 	nr_noop "github.com/spiffe/spire/pkg/server/plugin/noderesolver/noop"
 	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	no_bucket_bundle "github.com/spiffe/spire/pkg/server/plugin/notifier/bucketbundle"
 	no_gcs_bundle "github.com/spiffe/spire/pkg/server/plugin/notifier/gcsbundle"
 	no_k8sbundle "github.com/spiffe/spire/pkg/server/plugin/notifier/k8sbundle"
+	no_webhook "github.com/spiffe/spire/pkg/server/plugin/notifier/webhook"
 	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
 	up_awspca "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/awspca"
 	up_awssecret "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/awssecret"
 	up_disk "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/disk"
+	up_est "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/est"
 	up_spire "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/spire"
+	up_stepca "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/stepca"
 	up_vault "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/vault"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 )
@@ -68,12 +74,16 @@ var (
 		up_spire.BuiltIn(),
 		up_disk.BuiltIn(),
 		up_vault.BuiltIn(),
+		up_stepca.BuiltIn(),
+		up_est.BuiltIn(),
 		// KeyManagers
 		km_disk.BuiltIn(),
 		km_memory.BuiltIn(),
 		// Notifiers
 		no_k8sbundle.BuiltIn(),
 		no_gcs_bundle.BuiltIn(),
+		no_bucket_bundle.BuiltIn(),
+		no_webhook.BuiltIn(),
 	}
 )
 
@@ -81,6 +91,7 @@ type Catalog interface {
 	GetDataStore() datastore.DataStore
 	GetNodeAttestorNamed(name string) (nodeattestor.NodeAttestor, bool)
 	GetNodeResolverNamed(name string) (noderesolver.NodeResolver, bool)
+	GetNodeResolvers() map[string]noderesolver.NodeResolver
 	GetKeyManager() keymanager.KeyManager
 	GetNotifiers() []Notifier
 	GetUpstreamAuthority() (*UpstreamAuthority, bool)
@@ -150,6 +161,10 @@ func (p *Plugins) GetNodeResolverNamed(name string) (noderesolver.NodeResolver,
 	return n, ok
 }
 
+func (p *Plugins) GetNodeResolvers() map[string]noderesolver.NodeResolver {
+	return p.NodeResolvers
+}
+
 func (p *Plugins) GetKeyManager() keymanager.KeyManager {
 	return p.KeyManager
 }
@@ -171,6 +186,14 @@ type Config struct {
 	IdentityProvider hostservices.IdentityProvider
 	AgentStore       hostservices.AgentStore
 	MetricsService   common_services.MetricsService
+
+	// EntryEvents, if set, receives an event for every registration entry
+	// change made through the datastore.
+	EntryEvents *entryevents.Broker
+
+	// HealthChecks, if set, receives the health checks contributed by the
+	// built-in plugins.
+	HealthChecks *health.Checker
 }
 
 type Repository struct {
@@ -187,6 +210,11 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 	if err != nil {
 		return nil, err
 	}
+	if config.HealthChecks != nil {
+		if err := config.HealthChecks.AddChecksFrom(ds_sql.PluginName, ds, catalog.PluginCheckInterval); err != nil {
+			return nil, err
+		}
+	}
 
 	pluginConfigs, err := catalog.PluginConfigsFromHCL(config.PluginConfig)
 	if err != nil {
@@ -206,6 +234,8 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 			hostservices.AgentStoreHostServiceServer(config.AgentStore),
 			common_services.MetricsServiceHostServiceServer(config.MetricsService),
 		},
+		HealthChecks: config.HealthChecks,
+		Metrics:      config.Metrics,
 	}, p)
 	if err != nil {
 		return nil, err
@@ -213,6 +243,9 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 
 	p.DataStore.DataStore = datastore_telemetry.WithMetrics(ds, config.Metrics)
 	p.DataStore.DataStore = dscache.New(p.DataStore.DataStore, clock.New())
+	if config.EntryEvents != nil {
+		p.DataStore.DataStore = entryevents.WithEvents(p.DataStore.DataStore, config.EntryEvents)
+	}
 	p.KeyManager = keymanager_telemetry.WithMetrics(p.KeyManager, config.Metrics)
 
 	return &Repository{
@@ -8,12 +8,23 @@ import (
 
 	"github.com/sirupsen/logrus"
 	common "github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/dnspolicy"
 	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/oidc"
+	"github.com/spiffe/spire/pkg/server/entrydefaults"
+	"github.com/spiffe/spire/pkg/server/entrypolicy"
+	"github.com/spiffe/spire/pkg/server/nodeattestation"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/server/registration"
 )
 
 type Config struct {
@@ -22,18 +33,34 @@ type Config struct {
 
 	Log logrus.FieldLogger
 
+	// AuditLog, if set, receives a record for every audited API call
+	AuditLog logrus.FieldLogger
+
+	// LogLevels, if set, allows changing the log levels of Log while the
+	// server runs.
+	LogLevels log.LevelSetter
+
 	// Address of SPIRE server
 	BindAddress *net.TCPAddr
 
 	// Address of the UDS SPIRE server
 	BindUDSAddress *net.UnixAddr
 
+	// AdminAPI, if set, exposes the admin APIs on an additional TCP listener
+	// that only accepts callers with an authorized X509-SVID
+	AdminAPI *endpoints.AdminAPIConfig
+
 	// Directory to store runtime data
 	DataDir string
 
 	// Trust domain
 	TrustDomain url.URL
 
+	// TrustDomainAliases are the trust domains the server keeps answering
+	// for with its own trust bundle, e.g. the previous name of the trust
+	// domain while migrating to a new one.
+	TrustDomainAliases []url.URL
+
 	Experimental ExperimentalConfig
 
 	// If true enables profiling.
@@ -51,6 +78,10 @@ type Config struct {
 	// SVIDTTL is default time-to-live for SVIDs
 	SVIDTTL time.Duration
 
+	// SVIDRotation determines how long before expiration the server SVID is
+	// rotated
+	SVIDRotation rotationutil.Threshold
+
 	// CATTL is the time-to-live for the server CA. This only applies to
 	// self-signed CA certificates, otherwise it is up to the upstream CA.
 	CATTL time.Duration
@@ -62,26 +93,123 @@ type Config struct {
 	// CASubject is the subject used in the CA certificate
 	CASubject pkix.Name
 
+	// CAMaxPathLen, if set, is the path length constraint of the X509 CA
+	// certificates issued to downstream servers.
+	CAMaxPathLen *int
+
+	// CAFullUpstreamChain, if true, includes the upstream root in the chain
+	// returned with SVIDs, in addition to the upstream intermediates. It only
+	// applies when the server CA is signed by an UpstreamAuthority.
+	CAFullUpstreamChain bool
+
+	// CAPrepareThreshold and CAActivateThreshold are the fractions of the
+	// lifetime of the current X509 CA and JWT key after which the next ones
+	// are prepared and activated, respectively.
+	CAPrepareThreshold  float64
+	CAActivateThreshold float64
+
 	// Telemetry provides the configuration for metrics exporting
 	Telemetry telemetry.FileConfig
 
 	// HealthChecks provides the configuration for health monitoring
 	HealthChecks health.Config
 
-	// CAKeyType is the key type used for the X509 and JWT signing keys
+	// CAKeyType is the key type used for the X509 CA signing keys and, unless
+	// JWTKeyType is set, the JWT signing keys
 	CAKeyType keymanager.KeyType
 
+	// JWTKeyType, if set, is the key type used for the JWT signing keys
+	JWTKeyType keymanager.KeyType
+
+	// JWTSigningAlgorithm, if set, is the algorithm used to sign JWT-SVIDs. It
+	// must be compatible with the JWT signing key type.
+	JWTSigningAlgorithm string
+
 	// Federation holds the configuration needed to federate with other
 	// trust domains.
 	Federation FederationConfig
 
+	// OIDCDiscovery, if set, configures an OIDC discovery endpoint serving
+	// the JWT signing keys of the trust domain.
+	OIDCDiscovery *oidc.EndpointConfig
+
 	// RateLimit holds rate limiting configurations.
 	RateLimit endpoints.RateLimitConfig
+
+	// GRPCHealth, if true, serves the standard gRPC health service on the
+	// server APIs
+	GRPCHealth bool
+
+	// GRPCReflection, if true, serves the gRPC server reflection service on
+	// the server APIs
+	GRPCReflection bool
+
+	// RoleBindings grant server API roles to callers that are not admin
+	// workloads.
+	RoleBindings []middleware.RoleBinding
+
+	// EntryDefaults holds default values applied to newly created
+	// registration entries, keyed by parent ID prefix.
+	EntryDefaults *entrydefaults.Defaults
+
+	// EntryPolicy, if set, is evaluated against created and updated
+	// registration entries before they are written to the datastore.
+	EntryPolicy *entrypolicy.Policy
+
+	// DNSNamePolicy, if set, restricts the DNS names of X509-SVIDs and
+	// registration entries based on their SPIFFE ID.
+	DNSNamePolicy *dnspolicy.Policy
+
+	// IssuanceMetricsPrefixes are the SPIFFE ID prefixes the SVID issuance
+	// metrics are broken down by.
+	IssuanceMetricsPrefixes []string
+
+	// NodeResolverRefreshInterval is how often the selectors of attested
+	// agents are resolved again by the node resolvers that apply to every
+	// agent. If zero, noderesolution.DefaultRefreshInterval is used.
+	NodeResolverRefreshInterval time.Duration
+
+	// Pruning configures the pruning of expired agents and orphaned
+	// registration entries.
+	Pruning registration.PruningConfig
+
+	// DrainTimeout is how long in-flight agent RPCs are given to finish when
+	// the server is drained before the remaining connections are closed. If
+	// zero, a default timeout is used.
+	DrainTimeout time.Duration
+
+	// NotifierReconcileInterval is how often the notifiers are asked to
+	// verify the external copies of the trust bundle they maintain and repair
+	// any drift. Reconciliation is disabled if zero.
+	NotifierReconcileInterval time.Duration
+
+	// ClockSkewTolerance is how far ahead of the clock of the server the
+	// clocks of the issuers of agent SVIDs and attestation data may be.
+	ClockSkewTolerance time.Duration
+
+	// SVIDBackdate is how far the NotBefore of X509-SVIDs and self-signed X509
+	// CAs is set before the time they are signed. If zero, a default is used.
+	SVIDBackdate time.Duration
+
+	// GRPCTuning holds the keepalive and flow control settings of agent
+	// connections to the server
+	GRPCTuning grpcutil.ServerTuning
+
+	// NodeAttestationLimits bounds the challenge/response exchange of node
+	// attestation. Zero values mean the defaults.
+	NodeAttestationLimits nodeattestation.Limits
 }
 
 type ExperimentalConfig struct {
 	// Skip agent id validation in node attestation
 	AllowAgentlessNodeAttestors bool
+
+	// FeatureFlags are the experimental feature flags enabled for the server
+	FeatureFlags fflag.RawConfig
+
+	// CacheReloadInterval is how often the in-memory entry cache is rebuilt
+	// from the datastore. If zero, a default interval is used.
+	CacheReloadInterval time.Duration
 }
 
 type FederationConfig struct {
@@ -94,6 +222,8 @@ type FederationConfig struct {
 
 func New(config Config) *Server {
 	return &Server{
-		config: config,
+		config:       config,
+		rateLimiters: endpoints.NewRateLimiters(config.RateLimit),
+		drainer:      endpoints.NewDrainer(),
 	}
 }
@@ -0,0 +1,100 @@
+// Package dnspolicy restricts the DNS names that may appear on X509-SVIDs
+// based on the SPIFFE ID of the SVID.
+package dnspolicy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Rule describes the DNS names allowed on the SVIDs whose SPIFFE ID starts
+// with SPIFFEIDPrefix.
+type Rule struct {
+	// SPIFFEIDPrefix is matched against the SPIFFE ID of the SVID. The rule
+	// with the longest matching prefix wins.
+	SPIFFEIDPrefix string
+
+	// AllowedSuffixes are the domains the DNS names must be equal to or a
+	// subdomain of, e.g. "web.example.org" allows "web.example.org" and
+	// "api.web.example.org" but not "badweb.example.org".
+	AllowedSuffixes []string
+}
+
+// Policy holds a set of rules. DNS names are denied by default: SVIDs whose
+// SPIFFE ID matches no rule cannot have DNS names. A nil Policy is valid and
+// allows every DNS name.
+type Policy struct {
+	rules []Rule
+}
+
+// New validates the rules and returns a Policy that enforces them.
+func New(rules []Rule) (*Policy, error) {
+	p := &Policy{}
+	seen := make(map[string]bool)
+	for _, r := range rules {
+		if r.SPIFFEIDPrefix == "" {
+			return nil, errors.New("SPIFFE ID prefix is required")
+		}
+		if !strings.HasPrefix(r.SPIFFEIDPrefix, "spiffe://") {
+			return nil, fmt.Errorf("SPIFFE ID prefix %q must start with spiffe://", r.SPIFFEIDPrefix)
+		}
+		if seen[r.SPIFFEIDPrefix] {
+			return nil, fmt.Errorf("duplicate rule for SPIFFE ID prefix %q", r.SPIFFEIDPrefix)
+		}
+		seen[r.SPIFFEIDPrefix] = true
+
+		normalized := Rule{SPIFFEIDPrefix: r.SPIFFEIDPrefix}
+		for _, suffix := range r.AllowedSuffixes {
+			suffix = strings.ToLower(strings.TrimPrefix(suffix, "."))
+			if suffix == "" {
+				return nil, fmt.Errorf("rule %q: allowed suffix cannot be empty", r.SPIFFEIDPrefix)
+			}
+			normalized.AllowedSuffixes = append(normalized.AllowedSuffixes, suffix)
+		}
+		p.rules = append(p.rules, normalized)
+	}
+
+	// Longest prefixes first so the most specific rule is matched.
+	sort.Slice(p.rules, func(i, j int) bool {
+		return len(p.rules[i].SPIFFEIDPrefix) > len(p.rules[j].SPIFFEIDPrefix)
+	})
+	return p, nil
+}
+
+// Check returns an error if any of the DNS names is not allowed on an SVID
+// with the SPIFFE ID.
+func (p *Policy) Check(spiffeID string, dnsNames []string) error {
+	if p == nil || len(dnsNames) == 0 {
+		return nil
+	}
+
+	r := p.match(spiffeID)
+	for _, dnsName := range dnsNames {
+		if r == nil || !r.allows(dnsName) {
+			return fmt.Errorf("DNS name %q is not allowed for %q", dnsName, spiffeID)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) match(spiffeID string) *Rule {
+	for i := range p.rules {
+		if strings.HasPrefix(spiffeID, p.rules[i].SPIFFEIDPrefix) {
+			return &p.rules[i]
+		}
+	}
+	return nil
+}
+
+func (r *Rule) allows(dnsName string) bool {
+	// Wildcard names are allowed if the domain they cover is.
+	name := strings.ToLower(strings.TrimPrefix(dnsName, "*."))
+	for _, suffix := range r.AllowedSuffixes {
+		if name == suffix || strings.HasSuffix(name, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package dnspolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		rules []Rule
+		err   string
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name:  "missing prefix",
+			rules: []Rule{{AllowedSuffixes: []string{"example.org"}}},
+			err:   "SPIFFE ID prefix is required",
+		},
+		{
+			name:  "prefix without scheme",
+			rules: []Rule{{SPIFFEIDPrefix: "example.org/web"}},
+			err:   `SPIFFE ID prefix "example.org/web" must start with spiffe://`,
+		},
+		{
+			name: "duplicate prefix",
+			rules: []Rule{
+				{SPIFFEIDPrefix: "spiffe://example.org/web"},
+				{SPIFFEIDPrefix: "spiffe://example.org/web"},
+			},
+			err: `duplicate rule for SPIFFE ID prefix "spiffe://example.org/web"`,
+		},
+		{
+			name:  "empty suffix",
+			rules: []Rule{{SPIFFEIDPrefix: "spiffe://example.org/web", AllowedSuffixes: []string{"."}}},
+			err:   `rule "spiffe://example.org/web": allowed suffix cannot be empty`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.rules)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				require.Nil(t, p)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, p)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p, err := New([]Rule{
+		{
+			SPIFFEIDPrefix:  "spiffe://example.org/web/",
+			AllowedSuffixes: []string{"web.example.org", ".Internal.example.org"},
+		},
+		{
+			SPIFFEIDPrefix:  "spiffe://example.org/web/admin",
+			AllowedSuffixes: []string{"admin.example.org"},
+		},
+		{
+			SPIFFEIDPrefix: "spiffe://example.org/batch/",
+		},
+	})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		spiffeID string
+		dnsNames []string
+		err      string
+	}{
+		{
+			name:     "no DNS names",
+			spiffeID: "spiffe://example.org/db",
+		},
+		{
+			name:     "suffix itself",
+			spiffeID: "spiffe://example.org/web/frontend",
+			dnsNames: []string{"web.example.org"},
+		},
+		{
+			name:     "subdomains",
+			spiffeID: "spiffe://example.org/web/frontend",
+			dnsNames: []string{"api.web.example.org", "API.internal.example.org"},
+		},
+		{
+			name:     "wildcard",
+			spiffeID: "spiffe://example.org/web/frontend",
+			dnsNames: []string{"*.web.example.org"},
+		},
+		{
+			name:     "suffix without dot boundary",
+			spiffeID: "spiffe://example.org/web/frontend",
+			dnsNames: []string{"badweb.example.org"},
+			err:      `DNS name "badweb.example.org" is not allowed for "spiffe://example.org/web/frontend"`,
+		},
+		{
+			name:     "one of the names not allowed",
+			spiffeID: "spiffe://example.org/web/frontend",
+			dnsNames: []string{"web.example.org", "www.example.com"},
+			err:      `DNS name "www.example.com" is not allowed for "spiffe://example.org/web/frontend"`,
+		},
+		{
+			name:     "most specific rule wins",
+			spiffeID: "spiffe://example.org/web/admin",
+			dnsNames: []string{"web.example.org"},
+			err:      `DNS name "web.example.org" is not allowed for "spiffe://example.org/web/admin"`,
+		},
+		{
+			name:     "most specific rule allows",
+			spiffeID: "spiffe://example.org/web/admin",
+			dnsNames: []string{"ui.admin.example.org"},
+		},
+		{
+			name:     "rule without suffixes",
+			spiffeID: "spiffe://example.org/batch/job",
+			dnsNames: []string{"web.example.org"},
+			err:      `DNS name "web.example.org" is not allowed for "spiffe://example.org/batch/job"`,
+		},
+		{
+			name:     "no matching rule",
+			spiffeID: "spiffe://example.org/db",
+			dnsNames: []string{"web.example.org"},
+			err:      `DNS name "web.example.org" is not allowed for "spiffe://example.org/db"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Check(tt.spiffeID, tt.dnsNames)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNilPolicyAllowsEverything(t *testing.T) {
+	var p *Policy
+	require.NoError(t, p.Check("spiffe://example.org/db", []string{"www.example.com"}))
+}
@@ -0,0 +1,174 @@
+package endpoints
+
+import (
+	"crypto/tls"
+	"net"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	registration_pb "github.com/spiffe/spire/proto/spire/api/registration"
+	agentv1_pb "github.com/spiffe/spire/proto/spire/api/server/agent/v1"
+	bundlev1_pb "github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
+	entryv1_pb "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	entrytemplatev1_pb "github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	localauthorityv1_pb "github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
+	svidv1_pb "github.com/spiffe/spire/proto/spire/api/server/svid/v1"
+	trustdomainv1_pb "github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AdminAPIConfig configures the TCP listener serving the admin APIs to
+// management tooling that does not run on the server host.
+type AdminAPIConfig struct {
+	// Address is the address to bind the admin TCP listener to.
+	Address *net.TCPAddr
+
+	// AuthorizedIDs are the SPIFFE IDs allowed to call the admin listener.
+	// Callers are still subject to the authorization of each API, i.e. they
+	// need an admin registration entry or a role binding. Admin workloads and
+	// role-bound callers that are not in this list are not authorized on the
+	// main TCP listener either.
+	AuthorizedIDs []spiffeid.ID
+}
+
+// authorizedIDs returns the set of SPIFFE IDs allowed to use the admin API.
+func (c *AdminAPIConfig) authorizedIDs() map[spiffeid.ID]bool {
+	authorized := make(map[spiffeid.ID]bool, len(c.AuthorizedIDs))
+	for _, id := range c.AuthorizedIDs {
+		authorized[id] = true
+	}
+	return authorized
+}
+
+// restrict wraps an authorizer granting admin access so that it only
+// authorizes callers allowed to use the admin API. The authorizer is returned
+// as is when the admin API is not configured.
+func (c *AdminAPIConfig) restrict(authorizer middleware.Authorizer) middleware.Authorizer {
+	if c == nil {
+		return authorizer
+	}
+	return adminAPIAuthorizer{
+		authorizer: authorizer,
+		authorized: c.authorizedIDs(),
+	}
+}
+
+type adminAPIAuthorizer struct {
+	authorizer middleware.Authorizer
+	authorized map[spiffeid.ID]bool
+}
+
+func (a adminAPIAuthorizer) Name() string {
+	return a.authorizer.Name()
+}
+
+func (a adminAPIAuthorizer) AuthorizeCaller(ctx context.Context) (context.Context, error) {
+	id, ok := rpccontext.CallerID(ctx)
+	if !ok || !a.authorized[id] {
+		return nil, status.Error(codes.PermissionDenied, "caller is not authorized to use the admin API")
+	}
+	return a.authorizer.AuthorizeCaller(ctx)
+}
+
+// createAdminServer creates the gRPC server for the admin listener. Clients
+// must present an X509-SVID issued by the trust domain and their SPIFFE ID
+// must be authorized before the regular API authorization takes place.
+func (e *Endpoints) createAdminServer(ctx context.Context, unaryInterceptor grpc.UnaryServerInterceptor, streamInterceptor grpc.StreamServerInterceptor) *grpc.Server {
+	tlsConfig := &tls.Config{
+		GetConfigForClient: e.getTLSConfig(ctx, tls.RequireAndVerifyClientCert),
+	}
+
+	authorized := e.AdminAPI.authorizedIDs()
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := e.authorizeAdminCaller(ctx, authorized); err != nil {
+				return nil, err
+			}
+			return unaryInterceptor(ctx, req, info, handler)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := e.authorizeAdminCaller(ss.Context(), authorized); err != nil {
+				return err
+			}
+			return streamInterceptor(srv, ss, info, handler)
+		}),
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+	)
+
+	registration_pb.RegisterRegistrationServer(server, e.OldAPIServers.RegistrationServer)
+	agentv1_pb.RegisterAgentServer(server, e.APIServers.AgentServer)
+	bundlev1_pb.RegisterBundleServer(server, e.APIServers.BundleServer)
+	entryv1_pb.RegisterEntryServer(server, e.APIServers.EntryServer)
+	entrytemplatev1_pb.RegisterEntryTemplateServer(server, e.APIServers.EntryTemplateServer)
+	svidv1_pb.RegisterSVIDServer(server, e.APIServers.SVIDServer)
+	trustdomainv1_pb.RegisterTrustDomainServer(server, e.APIServers.TrustDomainServer)
+	localauthorityv1_pb.RegisterLocalAuthorityServer(server, e.APIServers.LocalAuthorityServer)
+	return server
+}
+
+// authorizeAdminCaller fails unless the caller presented an X509-SVID whose
+// SPIFFE ID is authorized to use the admin listener.
+func (e *Endpoints) authorizeAdminCaller(ctx context.Context, authorized map[spiffeid.ID]bool) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Internal, "no peer information available")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return status.Error(codes.Unauthenticated, "client certificate required")
+	}
+
+	uris := tlsInfo.State.PeerCertificates[0].URIs
+	if len(uris) != 1 {
+		return status.Error(codes.Unauthenticated, "client certificate must have exactly one URI SAN")
+	}
+	id, err := spiffeid.FromURI(uris[0])
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "client certificate has a malformed URI SAN: %v", err)
+	}
+
+	if !authorized[id] {
+		e.Log.WithFields(logrus.Fields{
+			telemetry.CallerID:   id.String(),
+			telemetry.CallerAddr: p.Addr.String(),
+		}).Warn("Caller is not authorized to use the admin API")
+		return status.Errorf(codes.PermissionDenied, "caller %q is not authorized to use the admin API", id)
+	}
+	return nil
+}
+
+// runAdminServer will start the server and block until it exits or we are
+// dying.
+func (e *Endpoints) runAdminServer(ctx context.Context, server *grpc.Server) error {
+	l, err := net.Listen(e.AdminAPI.Address.Network(), e.AdminAPI.Address.String())
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	// Skip use of tomb here so we don't pollute a clean shutdown with errors
+	e.Log.WithField(telemetry.Address, l.Addr().String()).Info("Starting admin TCP server")
+	errChan := make(chan error)
+	go func() { errChan <- server.Serve(l) }()
+
+	select {
+	case err := <-errChan:
+		e.Log.WithError(err).Error("Admin TCP server stopped prematurely")
+		return err
+	case <-ctx.Done():
+		e.Log.Info("Stopping admin TCP server")
+		server.Stop()
+		<-errChan
+		e.Log.Info("Admin TCP server has stopped")
+		return nil
+	}
+}
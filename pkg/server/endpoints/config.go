@@ -11,19 +11,31 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
+	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	agentv1 "github.com/spiffe/spire/pkg/server/api/agent/v1"
 	bundlev1 "github.com/spiffe/spire/pkg/server/api/bundle/v1"
 	debugv1 "github.com/spiffe/spire/pkg/server/api/debug/v1"
 	entryv1 "github.com/spiffe/spire/pkg/server/api/entry/v1"
+	entrytemplatev1 "github.com/spiffe/spire/pkg/server/api/entrytemplate/v1"
+	localauthorityv1 "github.com/spiffe/spire/pkg/server/api/localauthority/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	svidv1 "github.com/spiffe/spire/pkg/server/api/svid/v1"
+	trustdomainv1 "github.com/spiffe/spire/pkg/server/api/trustdomain/v1"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/cache/dscache"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/dnspolicy"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/endpoints/node"
+	"github.com/spiffe/spire/pkg/server/endpoints/oidc"
 	"github.com/spiffe/spire/pkg/server/endpoints/registration"
+	"github.com/spiffe/spire/pkg/server/entrydefaults"
+	"github.com/spiffe/spire/pkg/server/entryevents"
+	"github.com/spiffe/spire/pkg/server/entrypolicy"
+	"github.com/spiffe/spire/pkg/server/nodeattestation"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/svid"
 	"golang.org/x/net/context"
@@ -37,6 +49,10 @@ type Config struct {
 	// UDSAddr is the address to bind the UDS listener to.
 	UDSAddr *net.UnixAddr
 
+	// AdminAPI, if set, configures an additional TCP listener serving the
+	// admin APIs to authorized callers.
+	AdminAPI *AdminAPIConfig
+
 	// The svid rotator used to obtain the latest server credentials
 	SVIDObserver svid.Observer
 
@@ -55,15 +71,78 @@ type Config struct {
 	// Bundle endpoint configuration
 	BundleEndpoint bundle.EndpointConfig
 
+	// OIDC discovery endpoint configuration
+	OIDCDiscovery oidc.EndpointConfig
+
 	// CA Manager
 	Manager *ca.Manager
 
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 
+	// AuditLog, if set, receives a record for every audited API call.
+	AuditLog logrus.FieldLogger
+
 	// RateLimit holds rate limiting configurations.
 	RateLimit RateLimitConfig
 
+	// RateLimiters, if set, holds the rate limiters of the server APIs so
+	// their limits can be adjusted while running. If unset, the rate
+	// limiters are created from RateLimit.
+	RateLimiters *RateLimiters
+
+	// RoleBindings grant server API roles to callers
+	RoleBindings []middleware.RoleBinding
+
+	// EntryDefaults are applied to newly created registration entries
+	EntryDefaults *entrydefaults.Defaults
+
+	// EntryPolicy is evaluated against created and updated registration
+	// entries
+	EntryPolicy *entrypolicy.Policy
+
+	// DNSNamePolicy restricts the DNS names of created and updated
+	// registration entries
+	DNSNamePolicy *dnspolicy.Policy
+
+	// EntryEvents publishes registration entry changes
+	EntryEvents *entryevents.Broker
+
+	// CacheReloadInterval is how often the in-memory entry cache is rebuilt
+	// from the datastore. If zero, a default interval is used.
+	CacheReloadInterval time.Duration
+
+	// Drainer, if set, drains the TCP server when asked to
+	Drainer *Drainer
+
+	// DrainTimeout is how long in-flight RPCs are given to finish while
+	// draining before the remaining connections are closed
+	DrainTimeout time.Duration
+
+	// GRPCHealth, if true, serves the standard gRPC health service on the
+	// TCP and UDS listeners
+	GRPCHealth bool
+
+	// GRPCReflection, if true, serves the gRPC server reflection service on
+	// the TCP and UDS listeners
+	GRPCReflection bool
+
+	// LogLevels, if set, allows changing the log levels through the debug
+	// API
+	LogLevels log.LevelSetter
+
+	// ClockSkewTolerance, if set, is how far ahead of the clock of the
+	// server the clock of the issuer of a client certificate may be
+	ClockSkewTolerance time.Duration
+
+	// GRPCTuning holds the keepalive and flow control settings of agent
+	// connections to the TCP listener
+	GRPCTuning grpcutil.ServerTuning
+
+	// NodeAttestationLimits bounds the challenge/response exchange of node
+	// attestation
+	NodeAttestationLimits nodeattestation.Limits
+
 	Uptime func() time.Duration
 
 	Clock clock.Clock
@@ -76,6 +155,9 @@ func (c *Config) makeOldAPIServers() (OldAPIServers, error) {
 		Catalog:     c.Catalog,
 		TrustDomain: *c.TrustDomain.ID().URL(),
 		ServerCA:    c.ServerCA,
+		Defaults:    c.EntryDefaults,
+		Policy:      c.EntryPolicy,
+		DNSPolicy:   c.DNSNamePolicy,
 	}
 
 	nodeHandler, err := node.NewHandler(node.HandlerConfig{
@@ -87,6 +169,7 @@ func (c *Config) makeOldAPIServers() (OldAPIServers, error) {
 		Manager:                     c.Manager,
 		AllowAgentlessNodeAttestors: c.AllowAgentlessNodeAttestors,
 		RateLimitAttestation:        c.RateLimit.Attestation,
+		AttestationLimits:           c.NodeAttestationLimits,
 	})
 	if err != nil {
 		return OldAPIServers{}, err
@@ -114,30 +197,68 @@ func (c *Config) maybeMakeBundleEndpointServer() Server {
 		})
 	}
 
-	ds := c.Catalog.GetDataStore()
 	return bundle.NewServer(bundle.ServerConfig{
-		Log:     c.Log.WithField(telemetry.SubsystemName, "bundle_endpoint"),
-		Address: c.BundleEndpoint.Address.String(),
-		Getter: bundle.GetterFunc(func(ctx context.Context) (*bundleutil.Bundle, error) {
-			resp, err := ds.FetchBundle(dscache.WithCache(ctx), &datastore.FetchBundleRequest{
-				TrustDomainId: c.TrustDomain.IDString(),
-			})
-			if err != nil {
-				return nil, err
-			}
-			if resp.Bundle == nil {
-				return nil, errors.New("trust domain bundle not found")
-			}
-			return bundleutil.BundleFromProto(resp.Bundle)
-		}),
+		Log:        c.Log.WithField(telemetry.SubsystemName, "bundle_endpoint"),
+		Address:    c.BundleEndpoint.Address.String(),
+		Getter:     c.localBundleGetter(),
 		ServerAuth: serverAuth,
 	})
 }
 
+func (c *Config) maybeMakeOIDCDiscoveryServer() Server {
+	if c.OIDCDiscovery.Address == nil {
+		return nil
+	}
+
+	log := c.Log.WithFields(logrus.Fields{
+		"addr":   c.OIDCDiscovery.Address,
+		"domain": c.OIDCDiscovery.Domain,
+	})
+
+	var serverAuth bundle.ServerAuth
+	if c.OIDCDiscovery.ACME != nil {
+		log.Info("Serving OIDC discovery endpoint")
+		serverAuth = bundle.ACMEAuth(c.Log.WithField(telemetry.SubsystemName, "oidc_acme"), c.Catalog.GetKeyManager(), *c.OIDCDiscovery.ACME)
+	} else {
+		log.Warn("Serving OIDC discovery endpoint over HTTP (insecure)")
+	}
+
+	return oidc.NewServer(oidc.ServerConfig{
+		Log:        c.Log.WithField(telemetry.SubsystemName, "oidc_discovery"),
+		Address:    c.OIDCDiscovery.Address.String(),
+		Domain:     c.OIDCDiscovery.Domain,
+		Getter:     c.localBundleGetter(),
+		ServerAuth: serverAuth,
+	})
+}
+
+// localBundleGetter returns a getter for the bundle of the server trust
+// domain.
+func (c *Config) localBundleGetter() bundle.Getter {
+	ds := c.Catalog.GetDataStore()
+	return bundle.GetterFunc(func(ctx context.Context) (*bundleutil.Bundle, error) {
+		resp, err := ds.FetchBundle(dscache.WithCache(ctx), &datastore.FetchBundleRequest{
+			TrustDomainId: c.TrustDomain.IDString(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Bundle == nil {
+			return nil, errors.New("trust domain bundle not found")
+		}
+		return bundleutil.BundleFromProto(resp.Bundle)
+	})
+}
+
 func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServers {
 	ds := c.Catalog.GetDataStore()
 	upstreamPublisher := UpstreamPublisher(c.Manager)
 
+	// Authorized entries can only be watched if the fetcher supports it.
+	// Otherwise WatchAuthorizedEntries fails with Unimplemented and agents
+	// fall back to synchronizing on their sync interval.
+	entryWatcher, _ := entryFetcher.(api.AuthorizedEntryWatcher)
+
 	return APIServers{
 		AgentServer: agentv1.New(agentv1.Config{
 			DataStore:   ds,
@@ -145,6 +266,9 @@ func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServ
 			TrustDomain: c.TrustDomain,
 			Catalog:     c.Catalog,
 			Clock:       c.Clock,
+
+			AttestationLimits: c.NodeAttestationLimits,
+			RoleBindings:      c.RoleBindings,
 		}),
 		BundleServer: bundlev1.New(bundlev1.Config{
 			TrustDomain:       c.TrustDomain,
@@ -152,9 +276,18 @@ func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServ
 			UpstreamPublisher: upstreamPublisher,
 		}),
 		EntryServer: entryv1.New(entryv1.Config{
-			TrustDomain:  c.TrustDomain,
+			TrustDomain:   c.TrustDomain,
+			DataStore:     ds,
+			EntryFetcher:  entryFetcher,
+			EntryWatcher:  entryWatcher,
+			EntryDefaults: c.EntryDefaults,
+			EntryPolicy:   c.EntryPolicy,
+			DNSNamePolicy: c.DNSNamePolicy,
+			RoleBindings:  c.RoleBindings,
+		}),
+		EntryTemplateServer: entrytemplatev1.New(entrytemplatev1.Config{
 			DataStore:    ds,
-			EntryFetcher: entryFetcher,
+			RoleBindings: c.RoleBindings,
 		}),
 		SVIDServer: svidv1.New(svidv1.Config{
 			TrustDomain:  c.TrustDomain,
@@ -168,6 +301,22 @@ func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServ
 			DataStore:    ds,
 			SVIDObserver: c.SVIDObserver,
 			Uptime:       c.Uptime,
+			Drainer:      c.drainer(),
+			LogLevels:    c.LogLevels,
+		}),
+		TrustDomainServer: trustdomainv1.New(trustdomainv1.Config{
+			TrustDomain: c.TrustDomain,
+			DataStore:   ds,
+		}),
+		LocalAuthorityServer: localauthorityv1.New(localauthorityv1.Config{
+			Manager: c.Manager,
 		}),
 	}
 }
+
+func (c *Config) drainer() debugv1.Drainer {
+	if c.Drainer == nil {
+		return nil
+	}
+	return c.Drainer
+}
@@ -0,0 +1,64 @@
+package endpoints
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// defaultDrainTimeout is how long in-flight RPCs are given to finish
+	// while draining when no timeout is configured
+	defaultDrainTimeout = 30 * time.Second
+)
+
+// Drainer coordinates the graceful draining of the TCP server, which stops
+// accepting new agent connections, tells connected agents to reconnect
+// elsewhere and waits for in-flight RPCs to finish. The UDS server keeps
+// serving while the TCP server drains.
+type Drainer struct {
+	drainOnce   sync.Once
+	draining    chan struct{}
+	drainedOnce sync.Once
+	drained     chan struct{}
+}
+
+// NewDrainer returns a new drainer.
+func NewDrainer() *Drainer {
+	return &Drainer{
+		draining: make(chan struct{}),
+		drained:  make(chan struct{}),
+	}
+}
+
+// Drain starts draining the TCP server. It is safe to call more than once.
+func (d *Drainer) Drain() {
+	d.drainOnce.Do(func() {
+		close(d.draining)
+	})
+}
+
+// Draining returns a channel that is closed once draining starts.
+func (d *Drainer) Draining() <-chan struct{} {
+	return d.draining
+}
+
+// IsDraining returns true if draining has started.
+func (d *Drainer) IsDraining() bool {
+	select {
+	case <-d.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// Drained returns a channel that is closed once the TCP server has stopped.
+func (d *Drainer) Drained() <-chan struct{} {
+	return d.drained
+}
+
+func (d *Drainer) markDrained() {
+	d.drainedOnce.Do(func() {
+		close(d.drained)
+	})
+}
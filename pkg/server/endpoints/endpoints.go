@@ -14,12 +14,16 @@ import (
 	"golang.org/x/net/http2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/common/auth"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/api/middleware"
@@ -33,7 +37,10 @@ import (
 	bundlev1_pb "github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
 	debugv1_pb "github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	entryv1_pb "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	entrytemplatev1_pb "github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	localauthorityv1_pb "github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
 	svidv1_pb "github.com/spiffe/spire/proto/spire/api/server/svid/v1"
+	trustdomainv1_pb "github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
 )
 
 // This is the maximum amount of time an agent connection may exist before
@@ -55,15 +62,26 @@ type Endpoints struct {
 
 	TCPAddr                      *net.TCPAddr
 	UDSAddr                      *net.UnixAddr
+	AdminAPI                     *AdminAPIConfig
 	SVIDObserver                 svid.Observer
 	TrustDomain                  spiffeid.TrustDomain
 	DataStore                    datastore.DataStore
 	APIServers                   APIServers
 	BundleEndpointServer         Server
+	OIDCDiscoveryServer          Server
 	Log                          logrus.FieldLogger
+	AuditLog                     logrus.FieldLogger
 	Metrics                      telemetry.Metrics
-	RateLimit                    RateLimitConfig
+	RateLimiters                 *RateLimiters
+	RoleBindings                 []middleware.RoleBinding
 	EntryFetcherCacheRebuildTask func(context.Context) error
+	Drainer                      *Drainer
+	DrainTimeout                 time.Duration
+	GRPCHealth                   bool
+	GRPCReflection               bool
+	ClockSkewTolerance           time.Duration
+	GRPCTuning                   grpcutil.ServerTuning
+	Clock                        clock.Clock
 }
 
 type OldAPIServers struct {
@@ -72,17 +90,14 @@ type OldAPIServers struct {
 }
 
 type APIServers struct {
-	AgentServer  agentv1_pb.AgentServer
-	BundleServer bundlev1_pb.BundleServer
-	DebugServer  debugv1_pb.DebugServer
-	EntryServer  entryv1_pb.EntryServer
-	SVIDServer   svidv1_pb.SVIDServer
-}
-
-// RateLimitConfig holds rate limiting configurations.
-type RateLimitConfig struct {
-	// Attestation, if true, rate limits attestation
-	Attestation bool
+	AgentServer          agentv1_pb.AgentServer
+	BundleServer         bundlev1_pb.BundleServer
+	DebugServer          debugv1_pb.DebugServer
+	EntryServer          entryv1_pb.EntryServer
+	EntryTemplateServer  entrytemplatev1_pb.EntryTemplateServer
+	LocalAuthorityServer localauthorityv1_pb.LocalAuthorityServer
+	SVIDServer           svidv1_pb.SVIDServer
+	TrustDomainServer    trustdomainv1_pb.TrustDomainServer
 }
 
 // New creates new endpoints struct
@@ -102,20 +117,48 @@ func New(ctx context.Context, c Config) (*Endpoints, error) {
 	if err != nil {
 		return nil, err
 	}
+	if fflag.IsSet(fflag.FlagEntryEventCacheRebuild) || fflag.IsSet(fflag.FlagIncrementalEntryCache) {
+		ef.events = c.EntryEvents
+	}
+	ef.incremental = fflag.IsSet(fflag.FlagIncrementalEntryCache)
+	if c.CacheReloadInterval > 0 {
+		ef.reloadInterval = c.CacheReloadInterval
+	}
+
+	rateLimiters := c.RateLimiters
+	if rateLimiters == nil {
+		rateLimiters = NewRateLimiters(c.RateLimit)
+	}
+
+	drainTimeout := c.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = defaultDrainTimeout
+	}
 
 	return &Endpoints{
 		OldAPIServers:                oldAPIServers,
 		TCPAddr:                      c.TCPAddr,
 		UDSAddr:                      c.UDSAddr,
+		AdminAPI:                     c.AdminAPI,
 		SVIDObserver:                 c.SVIDObserver,
 		TrustDomain:                  c.TrustDomain,
 		DataStore:                    c.Catalog.GetDataStore(),
 		APIServers:                   c.makeAPIServers(ef),
 		BundleEndpointServer:         c.maybeMakeBundleEndpointServer(),
+		OIDCDiscoveryServer:          c.maybeMakeOIDCDiscoveryServer(),
 		Log:                          c.Log,
+		AuditLog:                     c.AuditLog,
 		Metrics:                      c.Metrics,
-		RateLimit:                    c.RateLimit,
+		RateLimiters:                 rateLimiters,
+		RoleBindings:                 c.RoleBindings,
 		EntryFetcherCacheRebuildTask: ef.RunRebuildCacheTask,
+		Drainer:                      c.Drainer,
+		DrainTimeout:                 drainTimeout,
+		GRPCHealth:                   c.GRPCHealth,
+		GRPCReflection:               c.GRPCReflection,
+		ClockSkewTolerance:           c.ClockSkewTolerance,
+		GRPCTuning:                   c.GRPCTuning,
+		Clock:                        c.Clock,
 	}, nil
 }
 
@@ -143,25 +186,47 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	bundlev1_pb.RegisterBundleServer(udsServer, e.APIServers.BundleServer)
 	entryv1_pb.RegisterEntryServer(tcpServer, e.APIServers.EntryServer)
 	entryv1_pb.RegisterEntryServer(udsServer, e.APIServers.EntryServer)
+	entrytemplatev1_pb.RegisterEntryTemplateServer(tcpServer, e.APIServers.EntryTemplateServer)
+	entrytemplatev1_pb.RegisterEntryTemplateServer(udsServer, e.APIServers.EntryTemplateServer)
 	svidv1_pb.RegisterSVIDServer(tcpServer, e.APIServers.SVIDServer)
 	svidv1_pb.RegisterSVIDServer(udsServer, e.APIServers.SVIDServer)
+	trustdomainv1_pb.RegisterTrustDomainServer(tcpServer, e.APIServers.TrustDomainServer)
+	trustdomainv1_pb.RegisterTrustDomainServer(udsServer, e.APIServers.TrustDomainServer)
+	localauthorityv1_pb.RegisterLocalAuthorityServer(tcpServer, e.APIServers.LocalAuthorityServer)
+	localauthorityv1_pb.RegisterLocalAuthorityServer(udsServer, e.APIServers.LocalAuthorityServer)
 	// Register Debug API only on UDS server
 	debugv1_pb.RegisterDebugServer(udsServer, e.APIServers.DebugServer)
 
+	// The TCP and UDS servers have their own health server so the TCP
+	// server can report that it is not serving while it drains
+	tcpHealth := e.registerHealthAndReflection(tcpServer)
+	udsHealth := e.registerHealthAndReflection(udsServer)
+
 	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
-			return e.runTCPServer(ctx, tcpServer)
+			return e.runTCPServer(ctx, tcpServer, tcpHealth)
 		},
 		func(ctx context.Context) error {
-			return e.runUDSServer(ctx, udsServer)
+			return e.runUDSServer(ctx, udsServer, udsHealth)
 		},
 		e.EntryFetcherCacheRebuildTask,
 	}
 
+	if e.AdminAPI != nil {
+		adminServer := e.createAdminServer(ctx, unaryInterceptor, streamInterceptor)
+		tasks = append(tasks, func(ctx context.Context) error {
+			return e.runAdminServer(ctx, adminServer)
+		})
+	}
+
 	if e.BundleEndpointServer != nil {
 		tasks = append(tasks, e.BundleEndpointServer.ListenAndServe)
 	}
 
+	if e.OIDCDiscoveryServer != nil {
+		tasks = append(tasks, e.OIDCDiscoveryServer.ListenAndServe)
+	}
+
 	err := util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
@@ -169,19 +234,45 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	return err
 }
 
+// registerHealthAndReflection registers the gRPC health and reflection
+// services on the server, if enabled. It must be called after the other
+// services are registered so their health is reported. The returned health
+// server is nil if the health service is disabled.
+func (e *Endpoints) registerHealthAndReflection(server *grpc.Server) *health.Server {
+	var healthServer *health.Server
+	if e.GRPCHealth {
+		healthServer = health.NewServer()
+		for service := range server.GetServiceInfo() {
+			healthServer.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_SERVING)
+		}
+		grpc_health_v1.RegisterHealthServer(server, healthServer)
+	}
+	if e.GRPCReflection {
+		reflection.Register(server)
+	}
+	return healthServer
+}
+
 func (e *Endpoints) createTCPServer(ctx context.Context, unaryInterceptor grpc.UnaryServerInterceptor, streamInterceptor grpc.StreamServerInterceptor) *grpc.Server {
 	tlsConfig := &tls.Config{
-		GetConfigForClient: e.getTLSConfig(ctx),
+		// When bootstrapping, the agent does not yet have an SVID. In
+		// order to include the bootstrap endpoint in the same server as the
+		// rest of the Node API, request but don't require a client
+		// certificate
+		GetConfigForClient: e.getTLSConfig(ctx, tls.VerifyClientCertIfGiven),
 	}
 
-	return grpc.NewServer(
+	tuning := e.GRPCTuning
+	if tuning.MaxConnectionAge == 0 {
+		tuning.MaxConnectionAge = defaultMaxConnectionAge
+	}
+
+	opts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(unaryInterceptor),
 		grpc.StreamInterceptor(streamInterceptor),
 		grpc.Creds(credentials.NewTLS(tlsConfig)),
-		grpc.KeepaliveParams(keepalive.ServerParameters{
-			MaxConnectionAge: defaultMaxConnectionAge,
-		}),
-	)
+	}
+	return grpc.NewServer(append(opts, tuning.ServerOptions()...)...)
 }
 
 func (e *Endpoints) createUDSServer(unaryInterceptor grpc.UnaryServerInterceptor, streamInterceptor grpc.StreamServerInterceptor) *grpc.Server {
@@ -192,7 +283,15 @@ func (e *Endpoints) createUDSServer(unaryInterceptor grpc.UnaryServerInterceptor
 }
 
 // runTCPServer will start the server and block until it exits or we are dying.
-func (e *Endpoints) runTCPServer(ctx context.Context, server *grpc.Server) error {
+// If the server is drained, it stops gracefully and returns without waiting
+// for the context to be done, so the other servers keep running.
+func (e *Endpoints) runTCPServer(ctx context.Context, server *grpc.Server, healthServer *health.Server) error {
+	var draining, drained <-chan struct{}
+	if e.Drainer != nil {
+		draining = e.Drainer.Draining()
+		defer e.Drainer.markDrained()
+	}
+
 	l, err := net.Listen(e.TCPAddr.Network(), e.TCPAddr.String())
 	if err != nil {
 		return err
@@ -208,17 +307,63 @@ func (e *Endpoints) runTCPServer(ctx context.Context, server *grpc.Server) error
 	case err = <-errChan:
 		e.Log.WithError(err).Error("TCP server stopped prematurely")
 		return err
+	case <-draining:
+		e.Log.Info("Draining TCP server")
+		if healthServer != nil {
+			healthServer.Shutdown()
+		}
+		drained = e.drainTCPServer(server)
 	case <-ctx.Done():
 		e.Log.Info("Stopping TCP server")
+		if healthServer != nil {
+			healthServer.Shutdown()
+		}
 		server.Stop()
-		<-errChan
-		e.Log.Info("TCP server has stopped")
-		return nil
 	}
+
+	<-errChan
+	if drained != nil {
+		<-drained
+	}
+	e.Log.Info("TCP server has stopped")
+	return nil
+}
+
+// drainTCPServer stops the server from accepting new connections and tells
+// the connected agents to go away, so they reconnect to another server. The
+// in-flight RPCs are given up to the drain timeout to finish before the
+// remaining connections are closed. The returned channel is closed once the
+// server has stopped.
+func (e *Endpoints) drainTCPServer(server *grpc.Server) <-chan struct{} {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		select {
+		case <-stopped:
+		case <-e.clock().After(e.DrainTimeout):
+			e.Log.Warn("Drain timeout elapsed; closing remaining connections")
+			server.Stop()
+			<-stopped
+		}
+	}()
+	return drained
+}
+
+func (e *Endpoints) clock() clock.Clock {
+	if e.Clock == nil {
+		return clock.New()
+	}
+	return e.Clock
 }
 
 // runUDSServer  will start the server and block until it exits or we are dying.
-func (e *Endpoints) runUDSServer(ctx context.Context, server *grpc.Server) error {
+func (e *Endpoints) runUDSServer(ctx context.Context, server *grpc.Server, healthServer *health.Server) error {
 	os.Remove(e.UDSAddr.String())
 	l, err := net.ListenUnix(e.UDSAddr.Network(), e.UDSAddr)
 	if err != nil {
@@ -243,6 +388,9 @@ func (e *Endpoints) runUDSServer(ctx context.Context, server *grpc.Server) error
 		return err
 	case <-ctx.Done():
 		e.Log.Info("Stopping UDS server")
+		if healthServer != nil {
+			healthServer.Shutdown()
+		}
 		server.Stop()
 		<-errChan
 		e.Log.Info("UDS server has stopped")
@@ -251,7 +399,7 @@ func (e *Endpoints) runUDSServer(ctx context.Context, server *grpc.Server) error
 }
 
 // getTLSConfig returns a TLS Config hook for the gRPC server
-func (e *Endpoints) getTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+func (e *Endpoints) getTLSConfig(ctx context.Context, clientAuth tls.ClientAuthType) func(*tls.ClientHelloInfo) (*tls.Config, error) {
 	return func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
 		certs, roots, err := e.getCerts(ctx)
 		if err != nil {
@@ -259,12 +407,13 @@ func (e *Endpoints) getTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo)
 			return nil, err
 		}
 
+		var verificationTime func() time.Time
+		if e.ClockSkewTolerance > 0 {
+			verificationTime = e.verificationTime
+		}
+
 		return &tls.Config{
-			// When bootstrapping, the agent does not yet have
-			// an SVID. In order to include the bootstrap endpoint
-			// in the same server as the rest of the Node API,
-			// request but don't require a client certificate
-			ClientAuth: tls.VerifyClientCertIfGiven,
+			ClientAuth: clientAuth,
 
 			Certificates: certs,
 			ClientCAs:    roots,
@@ -272,10 +421,21 @@ func (e *Endpoints) getTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo)
 			MinVersion: tls.VersionTLS12,
 
 			NextProtos: []string{http2.NextProtoTLS},
+
+			Time: verificationTime,
 		}, nil
 	}
 }
 
+// verificationTime returns the time client certificates are verified at. It
+// is ahead of the current time by the clock skew tolerance, so that SVIDs
+// signed by servers with a clock ahead are accepted. The tradeoff is that
+// SVIDs are considered expired by that much earlier, which agents never get
+// close to since they renew their SVIDs half way through their lifetime.
+func (e *Endpoints) verificationTime() time.Time {
+	return e.clock().Now().Add(e.ClockSkewTolerance)
+}
+
 // getCerts queries the datastore and returns a TLS serving certificate(s) plus
 // the current CA root bundle.
 func (e *Endpoints) getCerts(ctx context.Context) ([]tls.Certificate, *x509.CertPool, error) {
@@ -323,7 +483,8 @@ func (e *Endpoints) makeInterceptors() (grpc.UnaryServerInterceptor, grpc.Stream
 
 	log := e.Log.WithField(telemetry.SubsystemName, "api")
 
-	newUnary, newStream := middleware.Interceptors(Middleware(log, e.Metrics, e.DataStore, clock.New(), e.RateLimit))
+	newUnary, newStream := middleware.Interceptors(Middleware(log, e.AuditLog, e.Metrics, e.DataStore, clock.New(), e.RateLimiters, e.RoleBindings, e.AdminAPI))
+	newUnary = middleware.AuditRequestUnaryInterceptor(newUnary)
 
 	return unaryInterceptorMux(oldUnary, newUnary), streamInterceptorMux(oldStream, newStream)
 }
@@ -16,9 +16,11 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"github.com/spiffe/spire/pkg/common/auth"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/oidc"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/svid"
 	"github.com/spiffe/spire/proto/spire/api/node"
@@ -27,7 +29,10 @@ import (
 	bundlev1 "github.com/spiffe/spire/proto/spire/api/server/bundle/v1"
 	debugv1 "github.com/spiffe/spire/proto/spire/api/server/debug/v1"
 	entryv1 "github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	entrytemplatev1 "github.com/spiffe/spire/proto/spire/api/server/entrytemplate/v1"
+	localauthorityv1 "github.com/spiffe/spire/proto/spire/api/server/localauthority/v1"
 	svidv1 "github.com/spiffe/spire/proto/spire/api/server/svid/v1"
+	trustdomainv1 "github.com/spiffe/spire/proto/spire/api/server/trustdomain/v1"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
@@ -41,7 +46,9 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
 )
 
@@ -51,7 +58,12 @@ var (
 	agentID      = testTD.NewID("/agent")
 	adminID      = testTD.NewID("/admin")
 	downstreamID = testTD.NewID("/downstream")
+	entryAdminID = testTD.NewID("/entry-admin")
+	otherAdminID = testTD.NewID("/other-admin")
 	rateLimit    = RateLimitConfig{Attestation: true}
+	roleBindings = []middleware.RoleBinding{
+		{Role: middleware.RoleEntryAdmin, SPIFFEIDs: []spiffeid.ID{entryAdminID}},
+	}
 )
 
 func TestNew(t *testing.T) {
@@ -62,6 +74,7 @@ func TestNew(t *testing.T) {
 	svidObserver := newSVIDObserver(nil)
 
 	log, _ := test.NewNullLogger()
+	auditLog, _ := test.NewNullLogger()
 	metrics := fakemetrics.New()
 	ds := fakedatastore.New(t)
 
@@ -89,10 +102,13 @@ func TestNew(t *testing.T) {
 		Catalog:        cat,
 		ServerCA:       serverCA,
 		BundleEndpoint: bundle.EndpointConfig{Address: tcpAddr},
+		OIDCDiscovery:  oidc.EndpointConfig{Address: tcpAddr, Domain: "oidc.example.org"},
 		Manager:        manager,
 		Log:            log,
+		AuditLog:       auditLog,
 		Metrics:        metrics,
 		RateLimit:      rateLimit,
+		RoleBindings:   roleBindings,
 		Clock:          clk,
 	})
 	require.NoError(t, err)
@@ -108,9 +124,12 @@ func TestNew(t *testing.T) {
 	assert.NotNil(t, endpoints.APIServers.SVIDServer)
 	assert.NotNil(t, endpoints.APIServers.DebugServer)
 	assert.NotNil(t, endpoints.BundleEndpointServer)
+	assert.NotNil(t, endpoints.OIDCDiscoveryServer)
 	assert.Equal(t, cat.GetDataStore(), endpoints.DataStore)
 	assert.Equal(t, log, endpoints.Log)
+	assert.Equal(t, auditLog, endpoints.AuditLog)
 	assert.Equal(t, metrics, endpoints.Metrics)
+	assert.Equal(t, roleBindings, endpoints.RoleBindings)
 }
 
 func TestNewErrorCreatingAuthorizedEntryFetcher(t *testing.T) {
@@ -160,17 +179,52 @@ func TestNewErrorCreatingAuthorizedEntryFetcher(t *testing.T) {
 	assert.Nil(t, endpoints)
 }
 
+func TestGetTLSConfigClockSkewTolerance(t *testing.T) {
+	ca := testca.New(t, testTD)
+	ds := fakedatastore.New(t)
+	_, err := ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+		Bundle: makeBundle(ca),
+	})
+	require.NoError(t, err)
+
+	clk := clock.NewMock(t)
+	endpoints := Endpoints{
+		SVIDObserver: newSVIDObserver(ca.CreateX509SVID(serverID)),
+		TrustDomain:  testTD,
+		DataStore:    ds,
+		Clock:        clk,
+	}
+
+	// Client certificates are verified at the current time by default
+	tlsConfig, err := endpoints.getTLSConfig(context.Background(), tls.VerifyClientCertIfGiven)(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.Nil(t, tlsConfig.Time)
+
+	// With a tolerance, they are verified ahead of the current time
+	endpoints.ClockSkewTolerance = time.Minute
+	tlsConfig, err = endpoints.getTLSConfig(context.Background(), tls.VerifyClientCertIfGiven)(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.NotNil(t, tlsConfig.Time)
+	require.Equal(t, clk.Now().Add(time.Minute), tlsConfig.Time())
+}
+
 func TestListenAndServe(t *testing.T) {
 	ca := testca.New(t, testTD)
 	serverSVID := ca.CreateX509SVID(serverID)
 	agentSVID := ca.CreateX509SVID(agentID)
 	adminSVID := ca.CreateX509SVID(adminID)
 	downstreamSVID := ca.CreateX509SVID(downstreamID)
+	entryAdminSVID := ca.CreateX509SVID(entryAdminID)
+	otherAdminSVID := ca.CreateX509SVID(otherAdminID)
 
 	listener, err := net.Listen("tcp", "localhost:0")
 	require.NoError(t, err)
 	require.NoError(t, listener.Close())
 
+	adminListener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	require.NoError(t, adminListener.Close())
+
 	dir := spiretest.TempDir(t)
 	udsPath := filepath.Join(dir, "socket")
 
@@ -191,8 +245,12 @@ func TestListenAndServe(t *testing.T) {
 	require.NoError(t, err)
 
 	endpoints := Endpoints{
-		TCPAddr:      listener.Addr().(*net.TCPAddr),
-		UDSAddr:      &net.UnixAddr{Name: udsPath, Net: "unix"},
+		TCPAddr: listener.Addr().(*net.TCPAddr),
+		UDSAddr: &net.UnixAddr{Name: udsPath, Net: "unix"},
+		AdminAPI: &AdminAPIConfig{
+			Address:       adminListener.Addr().(*net.TCPAddr),
+			AuthorizedIDs: []spiffeid.ID{adminID, entryAdminID},
+		},
 		SVIDObserver: newSVIDObserver(serverSVID),
 		TrustDomain:  testTD,
 		DataStore:    ds,
@@ -201,23 +259,31 @@ func TestListenAndServe(t *testing.T) {
 			NodeServer:         nodeServer,
 		},
 		APIServers: APIServers{
-			AgentServer:  &agentv1.UnimplementedAgentServer{},
-			BundleServer: &bundlev1.UnimplementedBundleServer{},
-			EntryServer:  &entryv1.UnimplementedEntryServer{},
-			SVIDServer:   &svidv1.UnimplementedSVIDServer{},
-			DebugServer:  &debugv1.UnimplementedDebugServer{},
+			AgentServer:          &agentv1.UnimplementedAgentServer{},
+			BundleServer:         &bundlev1.UnimplementedBundleServer{},
+			EntryServer:          &entryv1.UnimplementedEntryServer{},
+			EntryTemplateServer:  &entrytemplatev1.UnimplementedEntryTemplateServer{},
+			SVIDServer:           &svidv1.UnimplementedSVIDServer{},
+			DebugServer:          &debugv1.UnimplementedDebugServer{},
+			TrustDomainServer:    &trustdomainv1.UnimplementedTrustDomainServer{},
+			LocalAuthorityServer: &localauthorityv1.UnimplementedLocalAuthorityServer{},
 		},
 		BundleEndpointServer:         bundleEndpointServer,
 		Log:                          log,
 		Metrics:                      metrics,
-		RateLimit:                    rateLimit,
+		RateLimiters:                 NewRateLimiters(rateLimit),
+		RoleBindings:                 roleBindings,
 		EntryFetcherCacheRebuildTask: ef.RunRebuildCacheTask,
+		Drainer:                      NewDrainer(),
+		DrainTimeout:                 time.Minute,
+		GRPCHealth:                   true,
+		GRPCReflection:               true,
 	}
 
 	// Prime the datastore with the:
 	// - bundle used to verify client certificates.
 	// - agent attested node information
-	// - admin registration entry
+	// - admin registration entries
 	// - downstream registration entry
 	prepareDataStore(t, ds, ca, agentSVID)
 
@@ -230,14 +296,17 @@ func TestListenAndServe(t *testing.T) {
 		errCh <- endpoints.ListenAndServe(ctx)
 	}()
 
-	dialTCP := func(tlsConfig *tls.Config) *grpc.ClientConn {
-		conn, err := grpc.DialContext(ctx, endpoints.TCPAddr.String(),
+	dialAddr := func(addr *net.TCPAddr, tlsConfig *tls.Config) *grpc.ClientConn {
+		conn, err := grpc.DialContext(ctx, addr.String(),
 			grpc.WithBlock(), grpc.FailOnNonTempDialError(true),
 			grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
 		)
 		require.NoError(t, err)
 		return conn
 	}
+	dialTCP := func(tlsConfig *tls.Config) *grpc.ClientConn {
+		return dialAddr(endpoints.TCPAddr, tlsConfig)
+	}
 
 	udsConn, err := grpc.DialContext(ctx, "unix://"+endpoints.UDSAddr.String(), grpc.WithBlock(), grpc.WithInsecure())
 	require.NoError(t, err)
@@ -288,13 +357,112 @@ func TestListenAndServe(t *testing.T) {
 	t.Run("Entry", func(t *testing.T) {
 		testEntryAPI(ctx, t, udsConn, noauthConn, agentConn, adminConn, downstreamConn)
 	})
+	t.Run("EntryTemplate", func(t *testing.T) {
+		testEntryTemplateAPI(ctx, t, udsConn, noauthConn, agentConn, adminConn, downstreamConn)
+	})
 	t.Run("SVID", func(t *testing.T) {
 		testSVIDAPI(ctx, t, udsConn, noauthConn, agentConn, adminConn, downstreamConn)
 	})
+	t.Run("TrustDomain", func(t *testing.T) {
+		testTrustDomainAPI(ctx, t, udsConn, noauthConn, agentConn, adminConn, downstreamConn)
+	})
+	t.Run("LocalAuthority", func(t *testing.T) {
+		testLocalAuthorityAPI(ctx, t, udsConn, noauthConn, agentConn, adminConn, downstreamConn)
+	})
+	t.Run("Roles", func(t *testing.T) {
+		entryAdminConn := dialTCP(tlsconfig.MTLSClientConfig(entryAdminSVID, ca.X509Bundle(), tlsconfig.AuthorizeID(serverID)))
+		defer entryAdminConn.Close()
+		testRoles(ctx, t, entryAdminConn)
+	})
+	t.Run("Health", func(t *testing.T) {
+		testHealth(ctx, t, udsConn, noauthConn)
+	})
+	t.Run("Reflection", func(t *testing.T) {
+		testReflection(ctx, t, udsConn, noauthConn)
+	})
+	t.Run("AdminAPI", func(t *testing.T) {
+		dialAdmin := func(svid *x509svid.SVID) *grpc.ClientConn {
+			return dialAddr(endpoints.AdminAPI.Address, tlsconfig.MTLSClientConfig(svid, ca.X509Bundle(), tlsconfig.AuthorizeID(serverID)))
+		}
+		adminConn := dialAdmin(adminSVID)
+		defer adminConn.Close()
+		entryAdminConn := dialAdmin(entryAdminSVID)
+		defer entryAdminConn.Close()
+		agentConn := dialAdmin(agentSVID)
+		defer agentConn.Close()
+		testAdminAPI(ctx, t, adminConn, entryAdminConn, agentConn)
+
+		t.Run("Client certificate required", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+			conn, err := grpc.DialContext(ctx, endpoints.AdminAPI.Address.String(), grpc.WithBlock(), grpc.FailOnNonTempDialError(true),
+				grpc.WithTransportCredentials(credentials.NewTLS(tlsconfig.TLSClientConfig(ca.X509Bundle(), tlsconfig.AuthorizeID(serverID)))),
+			)
+			if err == nil {
+				// With TLS 1.3 the server rejects the handshake after the
+				// client considers it complete, so the first call fails.
+				defer conn.Close()
+				_, err = bundlev1.NewBundleClient(conn).GetBundle(ctx, &bundlev1.GetBundleRequest{})
+			}
+			assert.Error(t, err)
+		})
+
+		t.Run("Admin not authorized on the main listener", func(t *testing.T) {
+			otherAdminConn := dialTCP(tlsconfig.MTLSClientConfig(otherAdminSVID, ca.X509Bundle(), tlsconfig.AuthorizeID(serverID)))
+			defer otherAdminConn.Close()
+			testAuthorization(ctx, t, entryv1.NewEntryClient(otherAdminConn), map[string]bool{
+				"ListEntries":            false,
+				"GetEntry":               false,
+				"BatchCreateEntry":       false,
+				"BatchUpdateEntry":       false,
+				"BatchDeleteEntry":       false,
+				"BatchRotateEntry":       false,
+				"GetAuthorizedEntries":   false,
+				"WatchAuthorizedEntries": false,
+			})
+			testAuthorization(ctx, t, localauthorityv1.NewLocalAuthorityClient(otherAdminConn), map[string]bool{
+				"PrepareNextAuthority": false,
+				"RotateAuthority":      false,
+				"TaintX509Authority":   false,
+				"RevokeX509Authority":  false,
+				"TaintJWTAuthority":    false,
+				"RevokeJWTAuthority":   false,
+			})
+
+			_, err := bundlev1.NewBundleClient(otherAdminConn).GetBundle(ctx, &bundlev1.GetBundleRequest{})
+			spiretest.AssertGRPCStatus(t, err, codes.Unimplemented, "method GetBundle not implemented")
+		})
+	})
 
 	// Assert that the bundle endpoint server was called to listen and serve
 	require.True(t, bundleEndpointServer.Used(), "bundle server was not called to listen and serve")
 
+	t.Run("Drain", func(t *testing.T) {
+		endpoints.Drainer.Drain()
+		select {
+		case <-endpoints.Drainer.Drained():
+		case <-time.After(time.Minute):
+			require.FailNow(t, "timed out waiting for the TCP server to drain")
+		}
+
+		// The TCP server no longer accepts connections
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, endpoints.TCPAddr.String(), grpc.WithBlock(), grpc.FailOnNonTempDialError(true),
+			grpc.WithTransportCredentials(credentials.NewTLS(tlsconfig.MTLSClientConfig(agentSVID, ca.X509Bundle(), tlsconfig.AuthorizeID(serverID)))),
+		)
+		if !assert.Error(t, err, "dialing should have failed") {
+			conn.Close()
+		}
+
+		// The UDS server keeps serving
+		_, err = debugv1.NewDebugClient(udsConn).GetInfo(ctx, &debugv1.GetInfoRequest{})
+		spiretest.AssertGRPCStatus(t, err, codes.Unimplemented, "method GetInfo not implemented")
+		resp, err := grpc_health_v1.NewHealthClient(udsConn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		require.NoError(t, err)
+		require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+	})
+
 	// Cancel the context to bring down the endpoints and ensure they shut
 	// down cleanly.
 	cancel()
@@ -322,16 +490,18 @@ func prepareDataStore(t *testing.T, ds datastore.DataStore, ca *testca.CA, agent
 	})
 	require.NoError(t, err)
 
-	// Create an admin entry
-	_, err = ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
-		Entry: &common.RegistrationEntry{
-			ParentId:  agentID.String(),
-			SpiffeId:  adminID.String(),
-			Selectors: []*common.Selector{{Type: "not", Value: "relevant"}},
-			Admin:     true,
-		},
-	})
-	require.NoError(t, err)
+	// Create the admin entries
+	for _, id := range []spiffeid.ID{adminID, otherAdminID} {
+		_, err = ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+			Entry: &common.RegistrationEntry{
+				ParentId:  agentID.String(),
+				SpiffeId:  id.String(),
+				Selectors: []*common.Selector{{Type: "not", Value: "relevant"}},
+				Admin:     true,
+			},
+		})
+		require.NoError(t, err)
+	}
 
 	// Create a downstream entry
 	_, err = ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
@@ -410,9 +580,11 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 	t.Run("UDS", func(t *testing.T) {
 		testAuthorization(ctx, t, agentv1.NewAgentClient(udsConn), map[string]bool{
 			"ListAgents":      true,
+			"CountAgents":     true,
 			"GetAgent":        true,
 			"DeleteAgent":     true,
 			"BanAgent":        true,
+			"ReattestAgent":   true,
 			"AttestAgent":     true,
 			"RenewAgent":      false,
 			"CreateJoinToken": true,
@@ -422,9 +594,11 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 	t.Run("NoAuth", func(t *testing.T) {
 		testAuthorization(ctx, t, agentv1.NewAgentClient(noauthConn), map[string]bool{
 			"ListAgents":      false,
+			"CountAgents":     false,
 			"GetAgent":        false,
 			"DeleteAgent":     false,
 			"BanAgent":        false,
+			"ReattestAgent":   false,
 			"AttestAgent":     true,
 			"RenewAgent":      false,
 			"CreateJoinToken": false,
@@ -434,9 +608,11 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 	t.Run("Agent", func(t *testing.T) {
 		testAuthorization(ctx, t, agentv1.NewAgentClient(agentConn), map[string]bool{
 			"ListAgents":      false,
+			"CountAgents":     false,
 			"GetAgent":        false,
 			"DeleteAgent":     false,
 			"BanAgent":        false,
+			"ReattestAgent":   false,
 			"AttestAgent":     true,
 			"RenewAgent":      true,
 			"CreateJoinToken": false,
@@ -446,9 +622,11 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 	t.Run("Admin", func(t *testing.T) {
 		testAuthorization(ctx, t, agentv1.NewAgentClient(adminConn), map[string]bool{
 			"ListAgents":      true,
+			"CountAgents":     true,
 			"GetAgent":        true,
 			"DeleteAgent":     true,
 			"BanAgent":        true,
+			"ReattestAgent":   true,
 			"AttestAgent":     true,
 			"RenewAgent":      false,
 			"CreateJoinToken": true,
@@ -458,9 +636,11 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 	t.Run("Downstream", func(t *testing.T) {
 		testAuthorization(ctx, t, agentv1.NewAgentClient(downstreamConn), map[string]bool{
 			"ListAgents":      false,
+			"CountAgents":     false,
 			"GetAgent":        false,
 			"DeleteAgent":     false,
 			"BanAgent":        false,
+			"ReattestAgent":   false,
 			"AttestAgent":     true,
 			"RenewAgent":      false,
 			"CreateJoinToken": false,
@@ -471,31 +651,51 @@ func testAgentAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 func testDebugAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, downstreamConn *grpc.ClientConn) {
 	t.Run("UDS", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(udsConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":         true,
+			"Drain":           true,
+			"SetLogLevel":     true,
+			"MatchEntries":    true,
+			"BackupDatastore": true,
 		})
 	})
 
 	t.Run("NoAuth", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(noauthConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":         true,
+			"Drain":           true,
+			"SetLogLevel":     true,
+			"MatchEntries":    true,
+			"BackupDatastore": true,
 		})
 	})
 
 	t.Run("Agent", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(agentConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":         true,
+			"Drain":           true,
+			"SetLogLevel":     true,
+			"MatchEntries":    true,
+			"BackupDatastore": true,
 		})
 	})
 
 	t.Run("Admin", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(adminConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":         true,
+			"Drain":           true,
+			"SetLogLevel":     true,
+			"MatchEntries":    true,
+			"BackupDatastore": true,
 		})
 	})
 
 	t.Run("Downstream", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(downstreamConn), map[string]bool{
-			"GetInfo": true,
+			"GetInfo":         true,
+			"Drain":           true,
+			"SetLogLevel":     true,
+			"MatchEntries":    true,
+			"BackupDatastore": true,
 		})
 	})
 }
@@ -575,60 +775,247 @@ func testBundleAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agent
 func testEntryAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, downstreamConn *grpc.ClientConn) {
 	t.Run("UDS", func(t *testing.T) {
 		testAuthorization(ctx, t, entryv1.NewEntryClient(udsConn), map[string]bool{
-			"ListEntries":          true,
-			"GetEntry":             true,
-			"BatchCreateEntry":     true,
-			"BatchUpdateEntry":     true,
-			"BatchDeleteEntry":     true,
-			"GetAuthorizedEntries": false,
+			"ListEntries":            true,
+			"GetEntry":               true,
+			"BatchCreateEntry":       true,
+			"BatchUpdateEntry":       true,
+			"BatchDeleteEntry":       true,
+			"BatchRotateEntry":       true,
+			"GetAuthorizedEntries":   false,
+			"WatchAuthorizedEntries": false,
 		})
 	})
 
 	t.Run("NoAuth", func(t *testing.T) {
 		testAuthorization(ctx, t, entryv1.NewEntryClient(noauthConn), map[string]bool{
-			"ListEntries":          false,
-			"GetEntry":             false,
-			"BatchCreateEntry":     false,
-			"BatchUpdateEntry":     false,
-			"BatchDeleteEntry":     false,
-			"GetAuthorizedEntries": false,
+			"ListEntries":            false,
+			"GetEntry":               false,
+			"BatchCreateEntry":       false,
+			"BatchUpdateEntry":       false,
+			"BatchDeleteEntry":       false,
+			"BatchRotateEntry":       false,
+			"GetAuthorizedEntries":   false,
+			"WatchAuthorizedEntries": false,
 		})
 	})
 
 	t.Run("Agent", func(t *testing.T) {
 		testAuthorization(ctx, t, entryv1.NewEntryClient(agentConn), map[string]bool{
-			"ListEntries":          false,
-			"GetEntry":             false,
-			"BatchCreateEntry":     false,
-			"BatchUpdateEntry":     false,
-			"BatchDeleteEntry":     false,
-			"GetAuthorizedEntries": true,
+			"ListEntries":            false,
+			"GetEntry":               false,
+			"BatchCreateEntry":       false,
+			"BatchUpdateEntry":       false,
+			"BatchDeleteEntry":       false,
+			"BatchRotateEntry":       false,
+			"GetAuthorizedEntries":   true,
+			"WatchAuthorizedEntries": true,
 		})
 	})
 
 	t.Run("Admin", func(t *testing.T) {
 		testAuthorization(ctx, t, entryv1.NewEntryClient(adminConn), map[string]bool{
-			"ListEntries":          true,
-			"GetEntry":             true,
-			"BatchCreateEntry":     true,
-			"BatchUpdateEntry":     true,
-			"BatchDeleteEntry":     true,
-			"GetAuthorizedEntries": false,
+			"ListEntries":            true,
+			"GetEntry":               true,
+			"BatchCreateEntry":       true,
+			"BatchUpdateEntry":       true,
+			"BatchDeleteEntry":       true,
+			"BatchRotateEntry":       true,
+			"GetAuthorizedEntries":   false,
+			"WatchAuthorizedEntries": false,
 		})
 	})
 
 	t.Run("Downstream", func(t *testing.T) {
 		testAuthorization(ctx, t, entryv1.NewEntryClient(downstreamConn), map[string]bool{
-			"ListEntries":          false,
-			"GetEntry":             false,
-			"BatchCreateEntry":     false,
-			"BatchUpdateEntry":     false,
-			"BatchDeleteEntry":     false,
-			"GetAuthorizedEntries": false,
+			"ListEntries":            false,
+			"GetEntry":               false,
+			"BatchCreateEntry":       false,
+			"BatchUpdateEntry":       false,
+			"BatchDeleteEntry":       false,
+			"BatchRotateEntry":       false,
+			"GetAuthorizedEntries":   false,
+			"WatchAuthorizedEntries": false,
 		})
 	})
 }
 
+func testRoles(ctx context.Context, t *testing.T, entryAdminConn *grpc.ClientConn) {
+	t.Run("Entry", func(t *testing.T) {
+		testAuthorization(ctx, t, entryv1.NewEntryClient(entryAdminConn), map[string]bool{
+			"ListEntries":            true,
+			"GetEntry":               true,
+			"BatchCreateEntry":       true,
+			"BatchUpdateEntry":       true,
+			"BatchDeleteEntry":       true,
+			"BatchRotateEntry":       true,
+			"GetAuthorizedEntries":   false,
+			"WatchAuthorizedEntries": false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, agentv1.NewAgentClient(entryAdminConn), map[string]bool{
+			"ListAgents":      true,
+			"CountAgents":     true,
+			"GetAgent":        true,
+			"DeleteAgent":     false,
+			"BanAgent":        false,
+			"ReattestAgent":   false,
+			"AttestAgent":     true,
+			"RenewAgent":      false,
+			"CreateJoinToken": false,
+		})
+	})
+
+	t.Run("Bundle", func(t *testing.T) {
+		testAuthorization(ctx, t, bundlev1.NewBundleClient(entryAdminConn), map[string]bool{
+			"GetBundle":                  true,
+			"AppendBundle":               false,
+			"PublishJWTAuthority":        false,
+			"ListFederatedBundles":       true,
+			"GetFederatedBundle":         true,
+			"BatchCreateFederatedBundle": false,
+			"BatchUpdateFederatedBundle": false,
+			"BatchSetFederatedBundle":    false,
+			"BatchDeleteFederatedBundle": false,
+		})
+	})
+
+	t.Run("EntryTemplate", func(t *testing.T) {
+		testAuthorization(ctx, t, entrytemplatev1.NewEntryTemplateClient(entryAdminConn), map[string]bool{
+			"ListEntryTemplates":       true,
+			"GetEntryTemplate":         true,
+			"BatchCreateEntryTemplate": true,
+			"BatchUpdateEntryTemplate": true,
+			"BatchDeleteEntryTemplate": true,
+		})
+	})
+
+	t.Run("TrustDomain", func(t *testing.T) {
+		testAuthorization(ctx, t, trustdomainv1.NewTrustDomainClient(entryAdminConn), map[string]bool{
+			"ListFederationRelationships":       true,
+			"GetFederationRelationship":         true,
+			"BatchCreateFederationRelationship": false,
+			"BatchUpdateFederationRelationship": false,
+			"BatchDeleteFederationRelationship": false,
+		})
+	})
+
+	t.Run("LocalAuthority", func(t *testing.T) {
+		testAuthorization(ctx, t, localauthorityv1.NewLocalAuthorityClient(entryAdminConn), map[string]bool{
+			"PrepareNextAuthority": false,
+			"RotateAuthority":      false,
+			"TaintX509Authority":   false,
+			"RevokeX509Authority":  false,
+			"TaintJWTAuthority":    false,
+			"RevokeJWTAuthority":   false,
+		})
+	})
+
+	t.Run("SVID", func(t *testing.T) {
+		testAuthorization(ctx, t, svidv1.NewSVIDClient(entryAdminConn), map[string]bool{
+			"MintX509SVID":        false,
+			"MintJWTSVID":         false,
+			"BatchNewX509SVID":    false,
+			"NewJWTSVID":          false,
+			"NewDownstreamX509CA": false,
+			"ValidateJWTSVID":     true,
+		})
+	})
+}
+
+func testHealth(ctx context.Context, t *testing.T, udsConn, tcpConn *grpc.ClientConn) {
+	for _, service := range []string{"", "spire.api.server.entry.v1.Entry", "spire.api.registration.Registration"} {
+		for _, conn := range []*grpc.ClientConn{udsConn, tcpConn} {
+			resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+			require.NoError(t, err)
+			require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+		}
+	}
+
+	// The Debug API is only served over UDS
+	resp, err := grpc_health_v1.NewHealthClient(udsConn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "spire.api.server.debug.v1.Debug"})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+	_, err = grpc_health_v1.NewHealthClient(tcpConn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "spire.api.server.debug.v1.Debug"})
+	spiretest.AssertGRPCStatus(t, err, codes.NotFound, "unknown service")
+}
+
+func testReflection(ctx context.Context, t *testing.T, udsConn, tcpConn *grpc.ClientConn) {
+	listServices := func(conn *grpc.ClientConn) []string {
+		stream, err := grpc_reflection_v1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		require.NoError(t, err)
+		defer func() { _ = stream.CloseSend() }()
+		require.NoError(t, stream.Send(&grpc_reflection_v1alpha.ServerReflectionRequest{
+			MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_ListServices{},
+		}))
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		var services []string
+		for _, service := range resp.GetListServicesResponse().Service {
+			services = append(services, service.Name)
+		}
+		return services
+	}
+
+	commonServices := []string{
+		"grpc.health.v1.Health",
+		"grpc.reflection.v1alpha.ServerReflection",
+		"spire.api.registration.Registration",
+		"spire.api.server.agent.v1.Agent",
+		"spire.api.server.bundle.v1.Bundle",
+		"spire.api.server.entry.v1.Entry",
+		"spire.api.server.entrytemplate.v1.EntryTemplate",
+		"spire.api.server.localauthority.v1.LocalAuthority",
+		"spire.api.server.svid.v1.SVID",
+		"spire.api.server.trustdomain.v1.TrustDomain",
+	}
+	require.ElementsMatch(t, append([]string{"spire.api.server.debug.v1.Debug"}, commonServices...), listServices(udsConn))
+	require.ElementsMatch(t, append([]string{"spire.api.node.Node"}, commonServices...), listServices(tcpConn))
+}
+
+func testAdminAPI(ctx context.Context, t *testing.T, adminConn, entryAdminConn, unauthorizedConn *grpc.ClientConn) {
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, entryv1.NewEntryClient(adminConn), map[string]bool{
+			"ListEntries":            true,
+			"GetEntry":               true,
+			"BatchCreateEntry":       true,
+			"BatchUpdateEntry":       true,
+			"BatchDeleteEntry":       true,
+			"BatchRotateEntry":       true,
+			"GetAuthorizedEntries":   false,
+			"WatchAuthorizedEntries": false,
+		})
+	})
+
+	t.Run("Role", func(t *testing.T) {
+		testAuthorization(ctx, t, bundlev1.NewBundleClient(entryAdminConn), map[string]bool{
+			"GetBundle":                  true,
+			"AppendBundle":               false,
+			"PublishJWTAuthority":        false,
+			"ListFederatedBundles":       true,
+			"GetFederatedBundle":         true,
+			"BatchCreateFederatedBundle": false,
+			"BatchUpdateFederatedBundle": false,
+			"BatchSetFederatedBundle":    false,
+			"BatchDeleteFederatedBundle": false,
+		})
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		_, err := bundlev1.NewBundleClient(unauthorizedConn).GetBundle(ctx, &bundlev1.GetBundleRequest{})
+		spiretest.AssertGRPCStatus(t, err, codes.PermissionDenied, `caller "spiffe://domain.test/agent" is not authorized to use the admin API`)
+
+		_, err = entryv1.NewEntryClient(unauthorizedConn).GetAuthorizedEntries(ctx, &entryv1.GetAuthorizedEntriesRequest{})
+		spiretest.AssertGRPCStatus(t, err, codes.PermissionDenied, `caller "spiffe://domain.test/agent" is not authorized to use the admin API`)
+	})
+
+	t.Run("Debug API is not served", func(t *testing.T) {
+		_, err := debugv1.NewDebugClient(adminConn).GetInfo(ctx, &debugv1.GetInfoRequest{})
+		spiretest.AssertGRPCStatus(t, err, codes.Unimplemented, "unknown service spire.api.server.debug.v1.Debug")
+	})
+}
+
 func testSVIDAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, downstreamConn *grpc.ClientConn) {
 	t.Run("UDS", func(t *testing.T) {
 		testAuthorization(ctx, t, svidv1.NewSVIDClient(udsConn), map[string]bool{
@@ -637,6 +1024,7 @@ func testSVIDAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentCo
 			"BatchNewX509SVID":    false,
 			"NewJWTSVID":          false,
 			"NewDownstreamX509CA": false,
+			"ValidateJWTSVID":     true,
 		})
 	})
 
@@ -647,6 +1035,7 @@ func testSVIDAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentCo
 			"BatchNewX509SVID":    false,
 			"NewJWTSVID":          false,
 			"NewDownstreamX509CA": false,
+			"ValidateJWTSVID":     false,
 		})
 	})
 
@@ -657,6 +1046,7 @@ func testSVIDAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentCo
 			"BatchNewX509SVID":    true,
 			"NewJWTSVID":          true,
 			"NewDownstreamX509CA": false,
+			"ValidateJWTSVID":     false,
 		})
 	})
 
@@ -667,6 +1057,7 @@ func testSVIDAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentCo
 			"BatchNewX509SVID":    false,
 			"NewJWTSVID":          false,
 			"NewDownstreamX509CA": false,
+			"ValidateJWTSVID":     true,
 		})
 	})
 
@@ -677,6 +1068,168 @@ func testSVIDAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentCo
 			"BatchNewX509SVID":    false,
 			"NewJWTSVID":          false,
 			"NewDownstreamX509CA": true,
+			"ValidateJWTSVID":     false,
+		})
+	})
+}
+
+func testEntryTemplateAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, entrytemplatev1.NewEntryTemplateClient(udsConn), map[string]bool{
+			"ListEntryTemplates":       true,
+			"GetEntryTemplate":         true,
+			"BatchCreateEntryTemplate": true,
+			"BatchUpdateEntryTemplate": true,
+			"BatchDeleteEntryTemplate": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, entrytemplatev1.NewEntryTemplateClient(noauthConn), map[string]bool{
+			"ListEntryTemplates":       false,
+			"GetEntryTemplate":         false,
+			"BatchCreateEntryTemplate": false,
+			"BatchUpdateEntryTemplate": false,
+			"BatchDeleteEntryTemplate": false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, entrytemplatev1.NewEntryTemplateClient(agentConn), map[string]bool{
+			"ListEntryTemplates":       false,
+			"GetEntryTemplate":         false,
+			"BatchCreateEntryTemplate": false,
+			"BatchUpdateEntryTemplate": false,
+			"BatchDeleteEntryTemplate": false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, entrytemplatev1.NewEntryTemplateClient(adminConn), map[string]bool{
+			"ListEntryTemplates":       true,
+			"GetEntryTemplate":         true,
+			"BatchCreateEntryTemplate": true,
+			"BatchUpdateEntryTemplate": true,
+			"BatchDeleteEntryTemplate": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, entrytemplatev1.NewEntryTemplateClient(downstreamConn), map[string]bool{
+			"ListEntryTemplates":       false,
+			"GetEntryTemplate":         false,
+			"BatchCreateEntryTemplate": false,
+			"BatchUpdateEntryTemplate": false,
+			"BatchDeleteEntryTemplate": false,
+		})
+	})
+}
+
+func testTrustDomainAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, trustdomainv1.NewTrustDomainClient(udsConn), map[string]bool{
+			"ListFederationRelationships":       true,
+			"GetFederationRelationship":         true,
+			"BatchCreateFederationRelationship": true,
+			"BatchUpdateFederationRelationship": true,
+			"BatchDeleteFederationRelationship": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, trustdomainv1.NewTrustDomainClient(noauthConn), map[string]bool{
+			"ListFederationRelationships":       false,
+			"GetFederationRelationship":         false,
+			"BatchCreateFederationRelationship": false,
+			"BatchUpdateFederationRelationship": false,
+			"BatchDeleteFederationRelationship": false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, trustdomainv1.NewTrustDomainClient(agentConn), map[string]bool{
+			"ListFederationRelationships":       false,
+			"GetFederationRelationship":         false,
+			"BatchCreateFederationRelationship": false,
+			"BatchUpdateFederationRelationship": false,
+			"BatchDeleteFederationRelationship": false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, trustdomainv1.NewTrustDomainClient(adminConn), map[string]bool{
+			"ListFederationRelationships":       true,
+			"GetFederationRelationship":         true,
+			"BatchCreateFederationRelationship": true,
+			"BatchUpdateFederationRelationship": true,
+			"BatchDeleteFederationRelationship": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, trustdomainv1.NewTrustDomainClient(downstreamConn), map[string]bool{
+			"ListFederationRelationships":       false,
+			"GetFederationRelationship":         false,
+			"BatchCreateFederationRelationship": false,
+			"BatchUpdateFederationRelationship": false,
+			"BatchDeleteFederationRelationship": false,
+		})
+	})
+}
+
+func testLocalAuthorityAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, localauthorityv1.NewLocalAuthorityClient(udsConn), map[string]bool{
+			"PrepareNextAuthority": true,
+			"RotateAuthority":      true,
+			"TaintX509Authority":   true,
+			"RevokeX509Authority":  true,
+			"TaintJWTAuthority":    true,
+			"RevokeJWTAuthority":   true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, localauthorityv1.NewLocalAuthorityClient(noauthConn), map[string]bool{
+			"PrepareNextAuthority": false,
+			"RotateAuthority":      false,
+			"TaintX509Authority":   false,
+			"RevokeX509Authority":  false,
+			"TaintJWTAuthority":    false,
+			"RevokeJWTAuthority":   false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, localauthorityv1.NewLocalAuthorityClient(agentConn), map[string]bool{
+			"PrepareNextAuthority": false,
+			"RotateAuthority":      false,
+			"TaintX509Authority":   false,
+			"RevokeX509Authority":  false,
+			"TaintJWTAuthority":    false,
+			"RevokeJWTAuthority":   false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, localauthorityv1.NewLocalAuthorityClient(adminConn), map[string]bool{
+			"PrepareNextAuthority": true,
+			"RotateAuthority":      true,
+			"TaintX509Authority":   true,
+			"RevokeX509Authority":  true,
+			"TaintJWTAuthority":    true,
+			"RevokeJWTAuthority":   true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, localauthorityv1.NewLocalAuthorityClient(downstreamConn), map[string]bool{
+			"PrepareNextAuthority": false,
+			"RotateAuthority":      false,
+			"TaintX509Authority":   false,
+			"RevokeX509Authority":  false,
+			"TaintJWTAuthority":    false,
+			"RevokeJWTAuthority":   false,
 		})
 	})
 }
@@ -686,6 +1239,7 @@ func testSVIDAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentCo
 // in the expectedAuthResults, or a method in expectedAuthResults does not
 // belong to the client interface, the test will fail.
 func testAuthorization(ctx context.Context, t *testing.T, client interface{}, expectedAuthResults map[string]bool) {
+	clientStreamType := reflect.TypeOf((*grpc.ClientStream)(nil)).Elem()
 	cv := reflect.ValueOf(client)
 	ct := cv.Type()
 
@@ -696,8 +1250,9 @@ func testAuthorization(ctx context.Context, t *testing.T, client interface{}, ex
 		t.Run(methodName, func(t *testing.T) {
 			var out []reflect.Value
 
-			if mv.Type().NumIn() == 2 {
-				// server-stream method
+			switch {
+			case mt.NumIn() == 2:
+				// bidirectional stream method
 				out = mv.Call([]reflect.Value{reflect.ValueOf(ctx)})
 				require.Len(t, out, 2)
 				// assert there is no failure
@@ -705,7 +1260,16 @@ func testAuthorization(ctx context.Context, t *testing.T, client interface{}, ex
 				// Now call the Recv() method on the stream
 				rv := out[0].MethodByName("Recv")
 				out = rv.Call([]reflect.Value{})
-			} else {
+			case mt.Out(0).Implements(clientStreamType):
+				// server-stream method
+				out = mv.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.New(mt.In(1).Elem())})
+				require.Len(t, out, 2)
+				// assert there is no failure
+				require.Nil(t, out[1].Interface())
+				// Now call the Recv() method on the stream
+				rv := out[0].MethodByName("Recv")
+				out = rv.Call([]reflect.Value{})
+			default:
 				// unary method
 				out = mv.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.New(mt.In(1).Elem())})
 			}
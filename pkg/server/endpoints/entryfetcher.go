@@ -10,6 +10,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
+	"github.com/spiffe/spire/pkg/server/entryevents"
 	"github.com/spiffe/spire/proto/spire/types"
 )
 
@@ -17,16 +18,44 @@ const (
 	cacheReloadInterval = 5 * time.Second
 )
 
-var _ api.AuthorizedEntryFetcher = (*AuthorizedEntryFetcherWithFullCache)(nil)
+var (
+	_ api.AuthorizedEntryFetcher = (*AuthorizedEntryFetcherWithFullCache)(nil)
+	_ api.AuthorizedEntryWatcher = (*AuthorizedEntryFetcherWithFullCache)(nil)
+	_ incrementalCache           = (*entrycache.FullEntryCache)(nil)
+)
 
 type entryCacheBuilderFn func(ctx context.Context) (entrycache.Cache, error)
 
+// incrementalCache is an entry cache that can be updated in place as
+// registration entries and agent selectors change.
+type incrementalCache interface {
+	entrycache.Cache
+	UpsertEntry(entry *types.Entry)
+	RemoveEntry(entryID string)
+	SetAgentSelectors(agentID spiffeid.ID, selectors []*types.Selector)
+}
+
 type AuthorizedEntryFetcherWithFullCache struct {
 	buildCache entryCacheBuilderFn
 	cache      entrycache.Cache
 	clk        clock.Clock
 	log        logrus.FieldLogger
 	mu         sync.RWMutex
+
+	// events, if set, triggers a cache rebuild as soon as registration
+	// entries change instead of waiting for the next reload interval.
+	events *entryevents.Broker
+
+	// incremental, if set, applies the events to the cache in place instead
+	// of rebuilding it. Events that cannot be applied, like pruned entries
+	// or dropped events, still trigger a rebuild.
+	incremental bool
+
+	// reloadInterval is how often the cache is rebuilt from scratch.
+	reloadInterval time.Duration
+
+	watchersMu sync.Mutex
+	watchers   map[chan struct{}]struct{}
 }
 
 func NewAuthorizedEntryFetcherWithFullCache(ctx context.Context, buildCache entryCacheBuilderFn, log logrus.FieldLogger, clk clock.Clock) (*AuthorizedEntryFetcherWithFullCache, error) {
@@ -38,21 +67,77 @@ func NewAuthorizedEntryFetcherWithFullCache(ctx context.Context, buildCache entr
 
 	log.Info("Completed building in-memory entry cache")
 	return &AuthorizedEntryFetcherWithFullCache{
-		buildCache: buildCache,
-		cache:      cache,
-		clk:        clk,
-		log:        log,
+		buildCache:     buildCache,
+		cache:          cache,
+		clk:            clk,
+		log:            log,
+		reloadInterval: cacheReloadInterval,
+		watchers:       make(map[chan struct{}]struct{}),
 	}, nil
 }
 
+// FetchAuthorizedEntries returns the entries the agent is authorized for,
+// leaving out the expired ones, which may still be cached until they are
+// pruned from the datastore.
 func (a *AuthorizedEntryFetcherWithFullCache) FetchAuthorizedEntries(ctx context.Context, agentID spiffeid.ID) ([]*types.Entry, error) {
 	a.mu.RLock()
-	defer a.mu.RUnlock()
-	return a.cache.GetAuthorizedEntries(agentID), nil
+	entries := a.cache.GetAuthorizedEntries(agentID)
+	a.mu.RUnlock()
+
+	now := a.clk.Now()
+	authorized := make([]*types.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if !api.IsEntryExpired(entry, now) {
+			authorized = append(authorized, entry)
+		}
+	}
+	return authorized, nil
+}
+
+// WatchAuthorizedEntries returns a channel that is signaled every time the
+// cache is rebuilt or updated in place. Without an entry event broker, the
+// cache is only rebuilt on the reload interval, so watchers learn about
+// changes within that interval instead of as soon as they happen.
+func (a *AuthorizedEntryFetcherWithFullCache) WatchAuthorizedEntries(ctx context.Context) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	a.watchersMu.Lock()
+	a.watchers[ch] = struct{}{}
+	a.watchersMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		a.watchersMu.Lock()
+		delete(a.watchers, ch)
+		a.watchersMu.Unlock()
+	}()
+
+	return ch
+}
+
+func (a *AuthorizedEntryFetcherWithFullCache) notifyWatchers() {
+	a.watchersMu.Lock()
+	defer a.watchersMu.Unlock()
+	for ch := range a.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
 }
 
 // RunRebuildCacheTask starts a ticker which rebuilds the in-memory entry cache.
+// If an entry event broker is configured, the cache is also rebuilt, or
+// updated in place when incremental updates are enabled, whenever
+// registration entries change. Events do not delay the periodic rebuild, so
+// changes that are only picked up by it, such as those written by other
+// servers, are seen within the reload interval even under steady churn.
 func (a *AuthorizedEntryFetcherWithFullCache) RunRebuildCacheTask(ctx context.Context) error {
+	var events <-chan entryevents.Event
+	if a.events != nil {
+		events = a.events.Subscribe(ctx)
+	}
+
 	rebuild := func() {
 		cache, err := a.buildCache(ctx)
 		if err != nil {
@@ -62,16 +147,87 @@ func (a *AuthorizedEntryFetcherWithFullCache) RunRebuildCacheTask(ctx context.Co
 			a.mu.Lock()
 			a.cache = cache
 			a.mu.Unlock()
+			a.notifyWatchers()
 		}
 	}
 
+	ticker := a.clk.Ticker(a.reloadInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			a.log.Debug("Stopping in-memory entry cache hydrator")
 			return nil
-		case <-a.clk.After(cacheReloadInterval):
+		case <-ticker.C:
 			rebuild()
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if a.incremental && a.applyEvent(event) {
+				a.notifyWatchers()
+				continue
+			}
+			if !a.incremental && event.Type == entryevents.NodeSelectorsSet {
+				// Only registration entry changes trigger a rebuild
+				continue
+			}
+			// Coalesce events that arrived while the last rebuild was
+			// in progress into a single rebuild.
+			drainEvents(events)
+			rebuild()
+		}
+	}
+}
+
+// applyEvent updates the cache in place with the change described by the
+// event. It returns false if the event cannot be applied, in which case the
+// cache needs to be rebuilt.
+func (a *AuthorizedEntryFetcherWithFullCache) applyEvent(event entryevents.Event) bool {
+	var apply func(cache incrementalCache)
+	switch event.Type {
+	case entryevents.EntryCreated, entryevents.EntryUpdated:
+		entry, err := api.RegistrationEntryToProto(event.Entry)
+		if err != nil {
+			a.log.WithError(err).Warn("Failed to convert registration entry from event")
+			return false
+		}
+		apply = func(cache incrementalCache) { cache.UpsertEntry(entry) }
+	case entryevents.EntryDeleted:
+		apply = func(cache incrementalCache) { cache.RemoveEntry(event.Entry.EntryId) }
+	case entryevents.NodeSelectorsSet:
+		agentID, err := spiffeid.FromString(event.NodeSelectors.SpiffeId)
+		if err != nil {
+			a.log.WithError(err).Warn("Failed to parse agent ID from event")
+			return false
+		}
+		selectors := api.ProtoFromSelectors(event.NodeSelectors.Selectors)
+		apply = func(cache incrementalCache) { cache.SetAgentSelectors(agentID, selectors) }
+	default:
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	cache, ok := a.cache.(incrementalCache)
+	if !ok {
+		return false
+	}
+	apply(cache)
+	return true
+}
+
+func drainEvents(events <-chan entryevents.Event) {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		default:
+			return
 		}
 	}
 }
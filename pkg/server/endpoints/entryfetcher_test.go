@@ -11,6 +11,8 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
+	"github.com/spiffe/spire/pkg/server/entryevents"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/clock"
@@ -90,6 +92,44 @@ func TestFetchRegistrationEntries(t *testing.T) {
 	assert.Equal(t, expected, entries)
 }
 
+func TestFetchRegistrationEntriesDropsExpiredEntries(t *testing.T) {
+	ctx := context.Background()
+	log, _ := test.NewNullLogger()
+	clk := clock.NewMock(t)
+	agentID := trustDomain.NewID("/root")
+
+	unexpired := &types.Entry{
+		Id:       "unexpired",
+		SpiffeId: api.ProtoFromID(trustDomain.NewID("/unexpired")),
+		ParentId: api.ProtoFromID(agentID),
+	}
+	expiring := &types.Entry{
+		Id:        "expiring",
+		SpiffeId:  api.ProtoFromID(trustDomain.NewID("/expiring")),
+		ParentId:  api.ProtoFromID(agentID),
+		ExpiresAt: clk.Now().Add(time.Minute).Unix(),
+	}
+	buildCacheFn := func(ctx context.Context) (entrycache.Cache, error) {
+		return newStaticEntryCache(map[spiffeid.ID][]*types.Entry{
+			agentID: {unexpired, expiring},
+		}), nil
+	}
+
+	ef, err := NewAuthorizedEntryFetcherWithFullCache(ctx, buildCacheFn, log, clk)
+	require.NoError(t, err)
+
+	entries, err := ef.FetchAuthorizedEntries(ctx, agentID)
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Entry{unexpired, expiring}, entries)
+
+	// The entry is left out as soon as it expires, before the cache is
+	// rebuilt
+	clk.Add(time.Minute)
+	entries, err = ef.FetchAuthorizedEntries(ctx, agentID)
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Entry{unexpired}, entries)
+}
+
 func TestRunRebuildCacheTask(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	watchErr := make(chan error, 1)
@@ -157,9 +197,9 @@ func TestRunRebuildCacheTask(t *testing.T) {
 	go func() {
 		watchErr <- ef.RunRebuildCacheTask(ctx)
 	}()
+	clk.WaitForTicker(time.Minute, "waiting for reload ticker")
 
 	waitForRequest := func() buildCacheRequest {
-		clk.WaitForAfter(time.Minute, "waiting for watch timer")
 		clk.Add(cacheReloadInterval)
 		select {
 		case request := <-buildCacheCh:
@@ -216,6 +256,261 @@ func TestRunRebuildCacheTask(t *testing.T) {
 	sendResult(req, entryMap, nil)
 }
 
+func TestRunRebuildCacheTaskOnEntryEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	watchErr := make(chan error, 1)
+	defer func() {
+		cancel()
+		select {
+		case err := <-watchErr:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watch to return")
+		}
+	}()
+
+	log, _ := test.NewNullLogger()
+	clk := clock.NewMock(t)
+	agentID := trustDomain.NewID("/root")
+	expectedEntries := setupExpectedEntriesData(t, agentID)
+
+	isFirstCacheBuild := true
+	buildCache := func(ctx context.Context) (entrycache.Cache, error) {
+		if isFirstCacheBuild {
+			isFirstCacheBuild = false
+			return newStaticEntryCache(make(map[spiffeid.ID][]*types.Entry)), nil
+		}
+		return newStaticEntryCache(map[spiffeid.ID][]*types.Entry{
+			agentID: expectedEntries,
+		}), nil
+	}
+
+	ef, err := NewAuthorizedEntryFetcherWithFullCache(ctx, buildCache, log, clk)
+	require.NoError(t, err)
+	broker := entryevents.NewBroker()
+	ef.events = broker
+
+	go func() {
+		watchErr <- ef.RunRebuildCacheTask(ctx)
+	}()
+
+	// Wait for the task to start listening
+	clk.WaitForTicker(time.Minute, "waiting for reload ticker")
+	entries, err := ef.FetchAuthorizedEntries(ctx, agentID)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// Publishing an event rebuilds the cache without waiting for the reload
+	// interval to elapse. Watchers are signaled once the cache is rebuilt.
+	changed := ef.WatchAuthorizedEntries(ctx)
+	broker.Publish(entryevents.Event{Type: entryevents.EntryCreated})
+	select {
+	case <-changed:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for watchers to be signaled")
+	}
+	entries, err = ef.FetchAuthorizedEntries(ctx, agentID)
+	require.NoError(t, err)
+	require.Equal(t, expectedEntries, entries)
+}
+
+func TestRunRebuildCacheTaskAppliesEntryEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	watchErr := make(chan error, 1)
+	defer func() {
+		cancel()
+		select {
+		case err := <-watchErr:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watch to return")
+		}
+	}()
+
+	log, _ := test.NewNullLogger()
+	clk := clock.NewMock(t)
+	agentID := trustDomain.NewID("/spire/agent/agent1")
+
+	builds := 0
+	buildCache := func(ctx context.Context) (entrycache.Cache, error) {
+		builds++
+		return entrycache.Build(ctx, emptyEntryIterator{}, emptyAgentIterator{})
+	}
+
+	ef, err := NewAuthorizedEntryFetcherWithFullCache(ctx, buildCache, log, clk)
+	require.NoError(t, err)
+	broker := entryevents.NewBroker()
+	ef.events = broker
+	ef.incremental = true
+
+	go func() {
+		watchErr <- ef.RunRebuildCacheTask(ctx)
+	}()
+	clk.WaitForTicker(time.Minute, "waiting for reload ticker")
+
+	requireEntries := func(expected ...string) {
+		require.Eventually(t, func() bool {
+			entries, err := ef.FetchAuthorizedEntries(ctx, agentID)
+			require.NoError(t, err)
+			var ids []string
+			for _, entry := range entries {
+				ids = append(ids, entry.Id)
+			}
+			return assert.ObjectsAreEqual(expected, ids)
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	workload := &common.RegistrationEntry{
+		EntryId:   "workload",
+		ParentId:  "spiffe://example.org/alias",
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+	broker.Publish(entryevents.Event{Type: entryevents.EntryCreated, Entry: workload})
+	broker.Publish(entryevents.Event{Type: entryevents.EntryCreated, Entry: &common.RegistrationEntry{
+		EntryId:   "alias",
+		ParentId:  "spiffe://example.org/spire/server",
+		SpiffeId:  "spiffe://example.org/alias",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	}})
+	broker.Publish(entryevents.Event{Type: entryevents.NodeSelectorsSet, NodeSelectors: &datastore.NodeSelectors{
+		SpiffeId:  agentID.String(),
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	}})
+	requireEntries("alias", "workload")
+
+	changed := ef.WatchAuthorizedEntries(ctx)
+	broker.Publish(entryevents.Event{Type: entryevents.EntryDeleted, Entry: workload})
+	select {
+	case <-changed:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for watchers to be signaled")
+	}
+	requireEntries("alias")
+
+	// The cache was updated in place without being rebuilt
+	require.Equal(t, 1, builds)
+
+	// Entry template updates do not carry the updated entries, so the cache
+	// is rebuilt
+	broker.Publish(entryevents.Event{Type: entryevents.EntryTemplateUpdated})
+	select {
+	case <-changed:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for watchers to be signaled")
+	}
+	require.Equal(t, 2, builds)
+}
+
+func TestRunRebuildCacheTaskRebuildsUnderSteadyEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	watchErr := make(chan error, 1)
+	defer func() {
+		cancel()
+		select {
+		case err := <-watchErr:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watch to return")
+		}
+	}()
+
+	log, _ := test.NewNullLogger()
+	clk := clock.NewMock(t)
+
+	builds := make(chan struct{}, 10)
+	buildCache := func(ctx context.Context) (entrycache.Cache, error) {
+		builds <- struct{}{}
+		return entrycache.Build(ctx, emptyEntryIterator{}, emptyAgentIterator{})
+	}
+
+	ef, err := NewAuthorizedEntryFetcherWithFullCache(ctx, buildCache, log, clk)
+	require.NoError(t, err)
+	<-builds // initial build
+	broker := entryevents.NewBroker()
+	ef.events = broker
+	ef.incremental = true
+
+	go func() {
+		watchErr <- ef.RunRebuildCacheTask(ctx)
+	}()
+	clk.WaitForTicker(time.Minute, "waiting for reload ticker")
+
+	// Events applied in place more often than the reload interval do not
+	// postpone the periodic rebuild
+	changed := ef.WatchAuthorizedEntries(ctx)
+	for i := 0; i < 4; i++ {
+		broker.Publish(entryevents.Event{Type: entryevents.EntryCreated, Entry: &common.RegistrationEntry{
+			EntryId:   "entry-" + strconv.Itoa(i),
+			ParentId:  "spiffe://example.org/spire/agent/agent1",
+			SpiffeId:  "spiffe://example.org/workload",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		}})
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the event to be applied")
+		}
+		clk.Add(cacheReloadInterval / 3)
+	}
+
+	select {
+	case <-builds:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the cache to be rebuilt")
+	}
+}
+
+func TestWatchAuthorizedEntriesSignaledOnReload(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	watchErr := make(chan error, 1)
+	defer func() {
+		cancel()
+		select {
+		case err := <-watchErr:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watch to return")
+		}
+	}()
+
+	log, _ := test.NewNullLogger()
+	clk := clock.NewMock(t)
+	buildCache := func(ctx context.Context) (entrycache.Cache, error) {
+		return entrycache.Build(ctx, emptyEntryIterator{}, emptyAgentIterator{})
+	}
+
+	// Without an entry event broker, watchers are signaled every time the
+	// cache is rebuilt on the reload interval
+	ef, err := NewAuthorizedEntryFetcherWithFullCache(ctx, buildCache, log, clk)
+	require.NoError(t, err)
+
+	changed := ef.WatchAuthorizedEntries(ctx)
+	go func() {
+		watchErr <- ef.RunRebuildCacheTask(ctx)
+	}()
+	clk.WaitForTicker(time.Minute, "waiting for reload ticker")
+	clk.Add(cacheReloadInterval)
+
+	select {
+	case <-changed:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for watchers to be signaled")
+	}
+}
+
+type emptyEntryIterator struct{}
+
+func (emptyEntryIterator) Next(context.Context) bool { return false }
+func (emptyEntryIterator) Entry() *types.Entry       { return nil }
+func (emptyEntryIterator) Err() error                { return nil }
+
+type emptyAgentIterator struct{}
+
+func (emptyAgentIterator) Next(context.Context) bool { return false }
+func (emptyAgentIterator) Agent() entrycache.Agent   { return entrycache.Agent{} }
+func (emptyAgentIterator) Err() error                { return nil }
+
 func setupExpectedEntriesData(t *testing.T, agentID spiffeid.ID) []*types.Entry {
 	const numEntries = 2
 	entryIDs := make([]spiffeid.ID, numEntries)
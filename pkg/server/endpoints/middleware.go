@@ -8,6 +8,7 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/bundle/v1"
@@ -16,7 +17,6 @@ import (
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/util/regentryutil"
-	node_pb "github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/types"
 	"github.com/spiffe/spire/test/clock"
 	"golang.org/x/net/context"
@@ -30,16 +30,20 @@ const (
 	entriesCacheSize = 500_000
 )
 
-func Middleware(log logrus.FieldLogger, metrics telemetry.Metrics, ds datastore.DataStore, clk clock.Clock, rlConf RateLimitConfig) middleware.Middleware {
+func Middleware(log logrus.FieldLogger, auditLog logrus.FieldLogger, metrics telemetry.Metrics, ds datastore.DataStore, clk clock.Clock, rateLimiters *RateLimiters, roleBindings []middleware.RoleBinding, adminAPI *AdminAPIConfig) middleware.Middleware {
 	return middleware.Chain(
 		middleware.WithLogger(log),
 		middleware.WithMetrics(metrics),
-		middleware.WithAuthorization(Authorization(log, ds, clk)),
-		middleware.WithRateLimits(RateLimits(rlConf)),
+		middleware.WithAuditLog(auditLog, AuditedMethods()),
+		middleware.WithAuthorization(Authorization(log, ds, clk, roleBindings, adminAPI)),
+		middleware.WithRateLimits(RateLimits(rateLimiters)),
 	)
 }
 
-func Authorization(log logrus.FieldLogger, ds datastore.DataStore, clk clock.Clock) map[string]middleware.Authorizer {
+// Authorization returns the authorizer of each API method. When the admin API
+// is configured, admin workloads and role-bound callers are only authorized
+// if their SPIFFE ID is in the admin API allowlist, whatever the listener.
+func Authorization(log logrus.FieldLogger, ds datastore.DataStore, clk clock.Clock, roleBindings []middleware.RoleBinding, adminAPI *AdminAPIConfig) map[string]middleware.Authorizer {
 	agentAuthorizer := AgentAuthorizer(log, ds, clk)
 	entryFetcher := EntryFetcher(ds)
 
@@ -47,40 +51,117 @@ func Authorization(log logrus.FieldLogger, ds datastore.DataStore, clk clock.Clo
 	local := middleware.AuthorizeLocal()
 	agent := middleware.AuthorizeAgent(agentAuthorizer)
 	downstream := middleware.AuthorizeDownstream(entryFetcher)
-	admin := middleware.AuthorizeAdmin(entryFetcher)
+	admin := adminAPI.restrict(middleware.AuthorizeAdmin(entryFetcher))
+
+	// Every role grants read access
+	reader := adminAPI.restrict(middleware.AuthorizeRole(entryFetcher, roleBindings,
+		middleware.RoleReadOnly, middleware.RoleEntryAdmin, middleware.RoleBundleAdmin, middleware.RoleAgentAdmin))
+	entryAdmin := adminAPI.restrict(middleware.AuthorizeRole(entryFetcher, roleBindings, middleware.RoleEntryAdmin))
+	bundleAdmin := adminAPI.restrict(middleware.AuthorizeRole(entryFetcher, roleBindings, middleware.RoleBundleAdmin))
+	agentAdmin := adminAPI.restrict(middleware.AuthorizeRole(entryFetcher, roleBindings, middleware.RoleAgentAdmin))
 
 	localOrAdmin := middleware.AuthorizeAnyOf(local, admin)
-	localOrAdminOrAgent := middleware.AuthorizeAnyOf(local, admin, agent)
+	localOrAdminOrReader := middleware.AuthorizeAnyOf(local, admin, reader)
+	localOrAdminOrReaderOrAgent := middleware.AuthorizeAnyOf(local, admin, reader, agent)
+	localOrAdminOrEntryAdmin := middleware.AuthorizeAnyOf(local, admin, entryAdmin)
+	localOrAdminOrBundleAdmin := middleware.AuthorizeAnyOf(local, admin, bundleAdmin)
+	localOrAdminOrAgentAdmin := middleware.AuthorizeAnyOf(local, admin, agentAdmin)
 
 	return map[string]middleware.Authorizer{
-		"/spire.api.server.svid.v1.SVID/MintX509SVID":                   localOrAdmin,
-		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                    localOrAdmin,
-		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":               agent,
-		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                     agent,
-		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":            downstream,
-		"/spire.api.server.bundle.v1.Bundle/GetBundle":                  any,
-		"/spire.api.server.bundle.v1.Bundle/AppendBundle":               localOrAdmin,
-		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":        downstream,
-		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":       localOrAdmin,
-		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":         localOrAdminOrAgent,
-		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle": localOrAdmin,
-		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle": localOrAdmin,
-		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":    localOrAdmin,
-		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle": localOrAdmin,
-		"/spire.api.server.debug.v1.Debug/GetInfo":                      local,
-		"/spire.api.server.entry.v1.Entry/ListEntries":                  localOrAdmin,
-		"/spire.api.server.entry.v1.Entry/GetEntry":                     localOrAdmin,
-		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":             localOrAdmin,
-		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":             localOrAdmin,
-		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":             localOrAdmin,
-		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":         agent,
-		"/spire.api.server.agent.v1.Agent/ListAgents":                   localOrAdmin,
-		"/spire.api.server.agent.v1.Agent/GetAgent":                     localOrAdmin,
-		"/spire.api.server.agent.v1.Agent/DeleteAgent":                  localOrAdmin,
-		"/spire.api.server.agent.v1.Agent/BanAgent":                     localOrAdmin,
-		"/spire.api.server.agent.v1.Agent/AttestAgent":                  any,
-		"/spire.api.server.agent.v1.Agent/RenewAgent":                   agent,
-		"/spire.api.server.agent.v1.Agent/CreateJoinToken":              localOrAdmin,
+		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                    localOrAdmin,
+		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                     localOrAdmin,
+		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                                agent,
+		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                      agent,
+		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                             downstream,
+		"/spire.api.server.svid.v1.SVID/ValidateJWTSVID":                                 localOrAdminOrReader,
+		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                   any,
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                                localOrAdminOrBundleAdmin,
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                         downstream,
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                        localOrAdminOrReader,
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                          localOrAdminOrReaderOrAgent,
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                  localOrAdminOrBundleAdmin,
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                  localOrAdminOrBundleAdmin,
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                     localOrAdminOrBundleAdmin,
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                  localOrAdminOrBundleAdmin,
+		"/spire.api.server.debug.v1.Debug/GetInfo":                                       local,
+		"/spire.api.server.debug.v1.Debug/Drain":                                         local,
+		"/spire.api.server.debug.v1.Debug/SetLogLevel":                                   local,
+		"/spire.api.server.debug.v1.Debug/MatchEntries":                                  local,
+		"/spire.api.server.debug.v1.Debug/BackupDatastore":                               local,
+		"/spire.api.server.entry.v1.Entry/ListEntries":                                   localOrAdminOrReader,
+		"/spire.api.server.entry.v1.Entry/GetEntry":                                      localOrAdminOrReader,
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                              localOrAdminOrEntryAdmin,
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                              localOrAdminOrEntryAdmin,
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                              localOrAdminOrEntryAdmin,
+		"/spire.api.server.entry.v1.Entry/BatchRotateEntry":                              localOrAdminOrEntryAdmin,
+		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                          agent,
+		"/spire.api.server.entry.v1.Entry/WatchAuthorizedEntries":                        agent,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/ListEntryTemplates":            localOrAdminOrReader,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/GetEntryTemplate":              localOrAdminOrReader,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/BatchCreateEntryTemplate":      localOrAdminOrEntryAdmin,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/BatchUpdateEntryTemplate":      localOrAdminOrEntryAdmin,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/BatchDeleteEntryTemplate":      localOrAdminOrEntryAdmin,
+		"/spire.api.server.agent.v1.Agent/ListAgents":                                    localOrAdminOrReader,
+		"/spire.api.server.agent.v1.Agent/CountAgents":                                   localOrAdminOrReader,
+		"/spire.api.server.agent.v1.Agent/GetAgent":                                      localOrAdminOrReader,
+		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                   localOrAdminOrAgentAdmin,
+		"/spire.api.server.agent.v1.Agent/BanAgent":                                      localOrAdminOrAgentAdmin,
+		"/spire.api.server.agent.v1.Agent/ReattestAgent":                                 localOrAdminOrAgentAdmin,
+		"/spire.api.server.agent.v1.Agent/AttestAgent":                                   any,
+		"/spire.api.server.agent.v1.Agent/RenewAgent":                                    agent,
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                               localOrAdminOrAgentAdmin,
+		"/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships":       localOrAdminOrReader,
+		"/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship":         localOrAdminOrReader,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship": localOrAdminOrBundleAdmin,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship": localOrAdminOrBundleAdmin,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship": localOrAdminOrBundleAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareNextAuthority":        localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RotateAuthority":             localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":          localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":         localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":           localOrAdmin,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":          localOrAdmin,
+		"/grpc.health.v1.Health/Check":                                                   any,
+		"/grpc.health.v1.Health/Watch":                                                   any,
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo":                 any,
+	}
+}
+
+// AuditedMethods returns the methods that are recorded in the audit log:
+// registration entry, entry template and agent mutations, node attestation,
+// SVID minting, bundle changes and federation relationship changes.
+func AuditedMethods() map[string]bool {
+	return map[string]bool{
+		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                    true,
+		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                     true,
+		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                             true,
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                                true,
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                         true,
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                  true,
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                  true,
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                     true,
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                  true,
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                              true,
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                              true,
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                              true,
+		"/spire.api.server.entry.v1.Entry/BatchRotateEntry":                              true,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/BatchCreateEntryTemplate":      true,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/BatchUpdateEntryTemplate":      true,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/BatchDeleteEntryTemplate":      true,
+		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                   true,
+		"/spire.api.server.agent.v1.Agent/BanAgent":                                      true,
+		"/spire.api.server.agent.v1.Agent/ReattestAgent":                                 true,
+		"/spire.api.server.agent.v1.Agent/AttestAgent":                                   true,
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                               true,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship": true,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship": true,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship": true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareNextAuthority":        true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RotateAuthority":             true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":          true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":         true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":           true,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":          true,
 	}
 }
 
@@ -159,6 +240,9 @@ func AgentAuthorizer(log logrus.FieldLogger, ds datastore.DataStore, clk clock.C
 		case resp.Node.CertSerialNumber == "":
 			log.Error("Agent is banned")
 			return permissionDenied(types.PermissionDeniedDetails_AGENT_BANNED, "agent %q is banned", id)
+		case nodeutil.IsAgentForcedToReattest(resp.Node):
+			log.Warn("Agent was forced to re-attest")
+			return permissionDenied(types.PermissionDeniedDetails_AGENT_NOT_ACTIVE, "agent %q must re-attest", id)
 		case resp.Node.CertSerialNumber == agentSVID.SerialNumber.String():
 			// AgentSVID matches the current serial number, access granted
 			return nil
@@ -189,45 +273,70 @@ func AgentAuthorizer(log logrus.FieldLogger, ds datastore.DataStore, clk clock.C
 	})
 }
 
-func RateLimits(config RateLimitConfig) map[string]api.RateLimiter {
+func RateLimits(rateLimiters *RateLimiters) map[string]api.RateLimiter {
 	noLimit := middleware.NoLimit()
-	attestLimit := middleware.DisabledLimit()
-	if config.Attestation {
-		attestLimit = middleware.PerIPLimit(node_pb.AttestLimit)
-	}
-	csrLimit := middleware.PerIPLimit(node_pb.CSRLimit)
-	jsrLimit := middleware.PerIPLimit(node_pb.JSRLimit)
-	pushJWTKeyLimit := middleware.PerIPLimit(node_pb.PushJWTKeyLimit)
+	attestLimit := rateLimiters.attest
+	csrLimit := rateLimiters.csr
+	jsrLimit := rateLimiters.jsr
+	pushJWTKeyLimit := rateLimiters.pushJWTKey
 
 	return map[string]api.RateLimiter{
-		"/spire.api.server.svid.v1.SVID/MintX509SVID":                   noLimit,
-		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                    noLimit,
-		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":               csrLimit,
-		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                     jsrLimit,
-		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":            csrLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetBundle":                  noLimit,
-		"/spire.api.server.bundle.v1.Bundle/AppendBundle":               noLimit,
-		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":        pushJWTKeyLimit,
-		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":       noLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":         noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle": noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle": noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":    noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle": noLimit,
-		"/spire.api.server.debug.v1.Debug/GetInfo":                      noLimit,
-		"/spire.api.server.entry.v1.Entry/ListEntries":                  noLimit,
-		"/spire.api.server.entry.v1.Entry/GetEntry":                     noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":             noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":             noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":             noLimit,
-		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":         noLimit,
-		"/spire.api.server.agent.v1.Agent/ListAgents":                   noLimit,
-		"/spire.api.server.agent.v1.Agent/GetAgent":                     noLimit,
-		"/spire.api.server.agent.v1.Agent/DeleteAgent":                  noLimit,
-		"/spire.api.server.agent.v1.Agent/BanAgent":                     noLimit,
-		"/spire.api.server.agent.v1.Agent/AttestAgent":                  attestLimit,
-		"/spire.api.server.agent.v1.Agent/RenewAgent":                   csrLimit,
-		"/spire.api.server.agent.v1.Agent/CreateJoinToken":              noLimit,
+		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                    noLimit,
+		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                     noLimit,
+		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                                csrLimit,
+		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                      jsrLimit,
+		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                             csrLimit,
+		"/spire.api.server.svid.v1.SVID/ValidateJWTSVID":                                 noLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                   noLimit,
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                                noLimit,
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                         pushJWTKeyLimit,
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                        noLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                          noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                  noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                  noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                     noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                  noLimit,
+		"/spire.api.server.debug.v1.Debug/GetInfo":                                       noLimit,
+		"/spire.api.server.debug.v1.Debug/Drain":                                         noLimit,
+		"/spire.api.server.debug.v1.Debug/SetLogLevel":                                   noLimit,
+		"/spire.api.server.debug.v1.Debug/MatchEntries":                                  noLimit,
+		"/spire.api.server.debug.v1.Debug/BackupDatastore":                               noLimit,
+		"/spire.api.server.entry.v1.Entry/ListEntries":                                   noLimit,
+		"/spire.api.server.entry.v1.Entry/GetEntry":                                      noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                              noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                              noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                              noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchRotateEntry":                              noLimit,
+		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                          noLimit,
+		"/spire.api.server.entry.v1.Entry/WatchAuthorizedEntries":                        noLimit,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/ListEntryTemplates":            noLimit,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/GetEntryTemplate":              noLimit,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/BatchCreateEntryTemplate":      noLimit,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/BatchUpdateEntryTemplate":      noLimit,
+		"/spire.api.server.entrytemplate.v1.EntryTemplate/BatchDeleteEntryTemplate":      noLimit,
+		"/spire.api.server.agent.v1.Agent/ListAgents":                                    noLimit,
+		"/spire.api.server.agent.v1.Agent/CountAgents":                                   noLimit,
+		"/spire.api.server.agent.v1.Agent/GetAgent":                                      noLimit,
+		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                   noLimit,
+		"/spire.api.server.agent.v1.Agent/BanAgent":                                      noLimit,
+		"/spire.api.server.agent.v1.Agent/ReattestAgent":                                 noLimit,
+		"/spire.api.server.agent.v1.Agent/AttestAgent":                                   attestLimit,
+		"/spire.api.server.agent.v1.Agent/RenewAgent":                                    csrLimit,
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                               noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships":       noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship":         noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship": noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship": noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship": noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareNextAuthority":        noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RotateAuthority":             noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":          noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":         noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":           noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":          noLimit,
+		"/grpc.health.v1.Health/Check":                                                   noLimit,
+		"/grpc.health.v1.Health/Watch":                                                   noLimit,
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo":                 noLimit,
 	}
 }
 
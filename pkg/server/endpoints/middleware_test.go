@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
@@ -178,6 +179,25 @@ func TestAgentAuthorizer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "forced to re-attest",
+			node: &common.AttestedNode{
+				SpiffeId:         agentID.String(),
+				CertSerialNumber: nodeutil.ReattestSerialNumber,
+			},
+			expectedCode:   codes.PermissionDenied,
+			expectedMsg:    `agent "spiffe://domain.test/agent" must re-attest`,
+			expectedReason: types.PermissionDeniedDetails_AGENT_NOT_ACTIVE,
+			expectedLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.WarnLevel,
+					Message: "Agent was forced to re-attest",
+					Data: map[string]interface{}{
+						telemetry.AgentID: agentID.String(),
+					},
+				},
+			},
+		},
 		{
 			name: "inactive SVID",
 			node: &common.AttestedNode{
@@ -3,6 +3,7 @@ package node
 import (
 	"crypto"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
 	"io"
@@ -22,13 +23,16 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/cache/dscache"
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/entrydefaults"
+	"github.com/spiffe/spire/pkg/server/nodeattestation"
+	"github.com/spiffe/spire/pkg/server/noderesolution"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
-	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
 	"github.com/spiffe/spire/pkg/server/util/regentryutil"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -57,11 +61,16 @@ type HandlerConfig struct {
 
 	// Allow agentless SPIFFE IDs when doing node attestation
 	AllowAgentlessNodeAttestors bool
+
+	// AttestationLimits bounds the challenge/response exchange of node
+	// attestation.
+	AttestationLimits nodeattestation.Limits
 }
 
 type Handler struct {
 	c       HandlerConfig
 	limiter Limiter
+	relay   *nodeattestation.Relay
 
 	fetchRegistrationEntriesCache *entrycache.FetchRegistrationEntriesCache
 }
@@ -78,6 +87,7 @@ func NewHandler(config HandlerConfig) (*Handler, error) {
 	return &Handler{
 		c:                             config,
 		limiter:                       NewLimiter(config.Log, config.RateLimitAttestation),
+		relay:                         nodeattestation.NewRelay(config.AttestationLimits, config.Clock),
 		fetchRegistrationEntriesCache: fetchX509SVIDCache,
 	}, nil
 }
@@ -142,24 +152,11 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 			return status.Error(codes.Unimplemented, fmt.Sprintf("could not find node attestor type %q", nodeAttestorType))
 		}
 
-		attestStream, err := nodeAttestor.Attest(ctx)
-		if err != nil {
-			log.WithError(err).Error("Unable to open attest stream")
-			return errorutil.WrapError(err, "unable to open attest stream")
-		}
-
-		attestResponse, err = h.doAttestChallengeResponse(stream, attestStream, request)
+		attestResponse, err = h.relay.Attest(ctx, nodeAttestor, request.AttestationData, attestNodeStream{stream: stream})
 		if err != nil {
 			log.WithError(err).Error("Failed to do node attest challenge response")
 			return err
 		}
-		if err := attestStream.CloseSend(); err != nil {
-			log.WithError(err).Error("Failed to close send stream")
-			return status.Error(codes.Internal, err.Error())
-		}
-		if _, err := attestStream.Recv(); err != io.EOF {
-			log.WithError(err).Warn("Expected EOF on attestation stream")
-		}
 	} else {
 		attestResponse, err = h.attestToken(ctx, request.AttestationData)
 		if err != nil {
@@ -403,9 +400,6 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDReques
 	case req.Jsr.SpiffeId == "":
 		log.Error("Request missing SPIFFE ID")
 		return nil, status.Error(codes.InvalidArgument, "request missing SPIFFE ID")
-	case len(req.Jsr.Audience) == 0:
-		log.Error("Request missing audience")
-		return nil, status.Error(codes.InvalidArgument, "request missing audience")
 	}
 
 	agentID, err := getSpiffeIDFromCert(peerCert)
@@ -426,23 +420,37 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDReques
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	found := false
+	var entry *common.RegistrationEntry
 	for _, candidateEntry := range regEntries {
 		if candidateEntry.SpiffeId == req.Jsr.SpiffeId {
-			found = true
+			entry = candidateEntry
 			break
 		}
 	}
 
-	if !found {
+	if entry == nil {
 		log.Error("Caller is not authorized")
 		return nil, status.Error(codes.PermissionDenied, "caller is not authorized")
 	}
 
+	// Fall back to the default audience of the entry when the request does
+	// not specify one.
+	audience := req.Jsr.Audience
+	if len(audience) == 0 {
+		audience = entry.JwtSvidAudience
+	}
+	if len(audience) == 0 {
+		log.Error("Request missing audience")
+		return nil, status.Error(codes.InvalidArgument, "request missing audience")
+	}
+
 	token, err := h.c.ServerCA.SignJWTSVID(ctx, ca.JWTSVIDParams{
-		SpiffeID: req.Jsr.SpiffeId,
-		TTL:      time.Duration(req.Jsr.Ttl) * time.Second,
-		Audience: req.Jsr.Audience,
+		SpiffeID:  req.Jsr.SpiffeId,
+		TTL:       time.Duration(req.Jsr.Ttl) * time.Second,
+		ExpiresAt: entryExpiry(entry),
+		Audience:  audience,
+		Claims:    entry.JwtSvidClaims,
+		ParentID:  agentID,
 	})
 	if err != nil {
 		log.WithError(err).Error("Failed to sign JWT-SVID")
@@ -650,6 +658,10 @@ func (h *Handler) validateAgentSVID(ctx context.Context, cert *x509.Certificate)
 		return permissionDenied(types.PermissionDeniedDetails_AGENT_BANNED, "agent %q is banned", agentID)
 	}
 
+	if nodeutil.IsAgentForcedToReattest(n) {
+		return permissionDenied(types.PermissionDeniedDetails_AGENT_NOT_ACTIVE, "agent %q must re-attest", agentID)
+	}
+
 	if n.CertSerialNumber != "" && n.CertSerialNumber == cert.SerialNumber.String() {
 		return nil
 	}
@@ -688,45 +700,23 @@ func (h *Handler) validateDownstreamSVID(ctx context.Context, cert *x509.Certifi
 	return h.getDownstreamEntry(ctx, peerID)
 }
 
-func (h *Handler) doAttestChallengeResponse(nodeStream node.Node_AttestServer, attestStream nodeattestor.NodeAttestor_AttestClient, request *node.AttestRequest) (*nodeattestor.AttestResponse, error) {
-	// challenge/response loop
-	for {
-		response, err := h.attest(attestStream, request)
-		if err != nil {
-			h.c.Log.WithError(err).Error("Failed to attest")
-			return nil, errorutil.WrapError(err, "failed to attest")
-		}
-		if response.Challenge == nil {
-			return response, nil
-		}
-
-		challengeResponse := &node.AttestResponse{
-			Challenge: response.Challenge,
-		}
-
-		if err := nodeStream.Send(challengeResponse); err != nil {
-			h.c.Log.WithError(err).Error("Failed to send challenge request")
-			return nil, errorutil.WrapError(err, "failed to send challenge request")
-		}
+// attestNodeStream is the agent end of the challenge/response exchange
+type attestNodeStream struct {
+	stream node.Node_AttestServer
+}
 
-		request, err = nodeStream.Recv()
-		if err != nil {
-			h.c.Log.WithError(err).Error("Failed to receive challenge response")
-			return nil, errorutil.WrapError(err, "failed to receive challenge response")
-		}
-	}
+func (a attestNodeStream) SendChallenge(challenge []byte) error {
+	return a.stream.Send(&node.AttestResponse{
+		Challenge: challenge,
+	})
 }
 
-func (h *Handler) attest(attestStream nodeattestor.NodeAttestor_AttestClient, nodeRequest *node.AttestRequest) (*nodeattestor.AttestResponse, error) {
-	attestRequest := &nodeattestor.AttestRequest{
-		AttestationData: nodeRequest.AttestationData,
-		Response:        nodeRequest.Response,
-	}
-	if err := attestStream.Send(attestRequest); err != nil {
+func (a attestNodeStream) RecvResponse() ([]byte, error) {
+	req, err := a.stream.Recv()
+	if err != nil {
 		return nil, err
 	}
-
-	return attestStream.Recv()
+	return req.Response, nil
 }
 
 func (h *Handler) attestToken(ctx context.Context, attestationData *common.AttestationData) (*nodeattestor.AttestResponse, error) {
@@ -763,6 +753,11 @@ func (h *Handler) attestToken(ctx context.Context, attestationData *common.Attes
 		return nil, errors.New("invalid join token")
 	}
 
+	// Restrictions on the token are only enforced by the agent API
+	if t.MaxUses > 1 || len(t.AllowedCidrs) > 0 || len(t.Selectors) > 0 {
+		return nil, errors.New("join token can only be used through the agent API")
+	}
+
 	_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
 		Token: tokenValue,
 	})
@@ -795,30 +790,15 @@ func (h *Handler) createAttestationEntry(ctx context.Context, cert *x509.Certifi
 }
 
 func (h *Handler) updateNodeSelectors(ctx context.Context, baseSpiffeID string, attestResponse *nodeattestor.AttestResponse, attestationType string) error {
-	var selectors []*common.Selector
-
-	// Select node resolver based on request attestation type
-	nodeResolver, ok := h.c.Catalog.GetNodeResolverNamed(attestationType)
-	if ok {
-		//Call node resolver plugin to get a map of spiffeID=>Selector
-		response, err := nodeResolver.Resolve(ctx, &noderesolver.ResolveRequest{
-			BaseSpiffeIdList: []string{baseSpiffeID},
-		})
-		if err != nil {
-			return err
-		}
-
-		if resolved := response.Map[baseSpiffeID]; resolved != nil {
-			selectors = append(selectors, resolved.Entries...)
-		}
-	} else {
-		h.c.Log.WithField(telemetry.Attestor, attestationType).Debug("Could not find node resolver")
+	selectors, err := noderesolution.Resolve(ctx, h.c.Log.WithField(telemetry.Attestor, attestationType), h.c.Catalog, baseSpiffeID, attestationType)
+	if err != nil {
+		return err
 	}
 
 	selectors = append(selectors, attestResponse.Selectors...)
 
 	ds := h.c.Catalog.GetDataStore()
-	_, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+	_, err = ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
 		Selectors: &datastore.NodeSelectors{
 			SpiffeId:  baseSpiffeID,
 			Selectors: selectors,
@@ -953,7 +933,7 @@ func (h *Handler) signCSRs(ctx context.Context, peerCert *x509.Certificate, csrs
 			}
 		} else {
 			signLog.Debug("Signing SVID")
-			svid, err := h.buildSVID(ctx, entryID, csr, regEntriesMap)
+			svid, err := h.buildSVID(ctx, entryID, csr, regEntriesMap, callerID)
 			if err != nil {
 				return nil, err
 			}
@@ -964,7 +944,7 @@ func (h *Handler) signCSRs(ctx context.Context, peerCert *x509.Certificate, csrs
 	return svids, nil
 }
 
-func (h *Handler) buildSVID(ctx context.Context, id string, csr *CSR, regEntries map[string]*common.RegistrationEntry) (*node.X509SVID, error) {
+func (h *Handler) buildSVID(ctx context.Context, id string, csr *CSR, regEntries map[string]*common.RegistrationEntry, callerID string) (*node.X509SVID, error) {
 	entry, ok := regEntries[id]
 	if !ok {
 		var idType string
@@ -980,11 +960,27 @@ func (h *Handler) buildSVID(ctx context.Context, id string, csr *CSR, regEntries
 		return nil, errors.New("not entitled to sign CSR for given ID type")
 	}
 
+	if err := x509svid.ValidatePublicKey(entry.X509SvidKeyType, csr.PublicKey); err != nil {
+		return nil, fmt.Errorf("invalid CSR key type: %v", err)
+	}
+
+	dnsList := entry.DnsNames
+	if len(entry.DnsNameTemplates) > 0 {
+		rendered, err := entrydefaults.RenderDNSNameTemplates(entry.DnsNameTemplates, entry.SpiffeId, entry.ParentId)
+		if err != nil {
+			return nil, err
+		}
+		dnsList = append(append([]string(nil), entry.DnsNames...), rendered...)
+	}
+
 	svid, err := h.c.ServerCA.SignX509SVID(ctx, ca.X509SVIDParams{
 		SpiffeID:  csr.SpiffeID,
 		PublicKey: csr.PublicKey,
 		TTL:       time.Duration(entry.Ttl) * time.Second,
-		DNSList:   entry.DnsNames,
+		ExpiresAt: entryExpiry(entry),
+		DNSList:   dnsList,
+		Subject:   x509SVIDSubject(entry.X509SvidSubject),
+		ParentID:  callerID,
 	})
 	if err != nil {
 		return nil, err
@@ -1219,3 +1215,24 @@ func getPeerAddress(ctx context.Context) (addr net.Addr, ok bool) {
 	}
 	return nil, false
 }
+
+// entryExpiry returns the time the entry expires at, or the zero time if the
+// entry does not expire.
+func entryExpiry(entry *common.RegistrationEntry) time.Time {
+	if entry.EntryExpiry == 0 {
+		return time.Time{}
+	}
+	return time.Unix(entry.EntryExpiry, 0)
+}
+
+func x509SVIDSubject(subject *common.X509SVIDSubject) pkix.Name {
+	if subject == nil {
+		return pkix.Name{}
+	}
+	return pkix.Name{
+		CommonName:         subject.CommonName,
+		Organization:       subject.Organization,
+		OrganizationalUnit: subject.OrganizationalUnit,
+		Country:            subject.Country,
+	}
+}
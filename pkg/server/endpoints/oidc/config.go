@@ -0,0 +1,21 @@
+package oidc
+
+import (
+	"net"
+
+	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+)
+
+type EndpointConfig struct {
+	// Address is the address on which to serve the OIDC discovery endpoint.
+	Address *net.TCPAddr
+
+	// Domain is the domain name the endpoint is reachable at. It is used to
+	// build the issuer and JWKS URIs in the discovery document.
+	Domain string
+
+	// ACME is the ACME configuration for the OIDC discovery endpoint.
+	// If unset, the endpoint is served over plain HTTP and is expected to be
+	// fronted by a TLS terminating proxy.
+	ACME *bundle.ACMEConfig
+}
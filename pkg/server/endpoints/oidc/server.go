@@ -0,0 +1,168 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/zeebo/errs"
+)
+
+const (
+	wellKnownPath = "/.well-known/openid-configuration"
+	keysPath      = "/keys"
+)
+
+type ServerConfig struct {
+	Log     logrus.FieldLogger
+	Address string
+	Domain  string
+	Getter  bundle.Getter
+
+	// ServerAuth provides the TLS configuration. If nil, the endpoint is
+	// served over plain HTTP.
+	ServerAuth bundle.ServerAuth
+
+	// test hooks
+	listen func(network, address string) (net.Listener, error)
+}
+
+// Server serves an OIDC discovery document and the JWT signing keys of the
+// trust domain bundle, so that JWT-SVIDs can be validated by relying parties
+// that support OIDC federation.
+type Server struct {
+	c ServerConfig
+}
+
+func NewServer(config ServerConfig) *Server {
+	if config.listen == nil {
+		config.listen = net.Listen
+	}
+	return &Server{
+		c: config,
+	}
+}
+
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := s.c.listen("tcp", s.c.Address)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	server := &http.Server{
+		Handler: s.handler(),
+	}
+
+	errCh := make(chan error, 1)
+	if s.c.ServerAuth != nil {
+		server.TLSConfig = s.c.ServerAuth.GetTLSConfig()
+		go func() {
+			errCh <- errs.Wrap(server.ServeTLS(listener, "", ""))
+		}()
+	} else {
+		go func() {
+			errCh <- errs.Wrap(server.Serve(listener))
+		}()
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		server.Close()
+		return nil
+	}
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(wellKnownPath, http.HandlerFunc(s.serveWellKnown))
+	mux.Handle(keysPath, http.HandlerFunc(s.serveKeys))
+	return mux
+}
+
+func (s *Server) serveWellKnown(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	issuerURL := url.URL{
+		Scheme: "https",
+		Host:   s.c.Domain,
+	}
+
+	jwksURI := url.URL{
+		Scheme: "https",
+		Host:   s.c.Domain,
+		Path:   keysPath,
+	}
+
+	doc := struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+
+		// The following are required fields that are hardcoded based on
+		// SPIRE capabilities.
+		AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+		ResponseTypesSupported           []string `json:"response_types_supported"`
+		SubjectTypesSupported            []string `json:"subject_types_supported"`
+		IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	}{
+		Issuer:  issuerURL.String(),
+		JWKSURI: jwksURI.String(),
+
+		AuthorizationEndpoint:            "",
+		ResponseTypesSupported:           []string{"id_token"},
+		SubjectTypesSupported:            []string{},
+		// Every algorithm JWT-SVIDs can be signed with is listed since the
+		// JWT key type, and with it the algorithm, can change between
+		// rotations while tokens signed with the previous keys are valid.
+		IDTokenSigningAlgValuesSupported: []string{"RS256", "PS256", "ES256", "ES384", "EdDSA"},
+	}
+
+	docBytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		s.c.Log.WithError(err).Error("Unable to marshal OIDC discovery document")
+		http.Error(w, "500 unable to marshal document", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(docBytes)
+}
+
+func (s *Server) serveKeys(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	b, err := s.c.Getter.GetBundle(req.Context())
+	if err != nil {
+		s.c.Log.WithError(err).Error("Unable to retrieve local bundle")
+		http.Error(w, "500 unable to retrieve local bundle", http.StatusInternalServerError)
+		return
+	}
+
+	jwksBytes, err := bundleutil.Marshal(b, bundleutil.NoX509SVIDKeys(), bundleutil.StandardJWKS())
+	if err != nil {
+		s.c.Log.WithError(err).Error("Unable to marshal JWKS")
+		http.Error(w, "500 unable to marshal JWKS", http.StatusInternalServerError)
+		return
+	}
+
+	// Relying parties should always get the latest keys since JWT signing
+	// keys are rotated.
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(jwksBytes)
+}
@@ -0,0 +1,172 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestServer(t *testing.T) {
+	jwtKey := testkey.MustEC256()
+
+	b := bundleutil.New("spiffe://domain.test")
+	require.NoError(t, b.AppendJWTSigningKey("KID", jwtKey.Public()))
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		bundle *bundleutil.Bundle
+		status int
+		body   string
+	}{
+		{
+			name:   "discovery document",
+			method: "GET",
+			path:   "/.well-known/openid-configuration",
+			status: http.StatusOK,
+			body: `{
+				"issuer": "https://oidc.domain.test",
+				"jwks_uri": "https://oidc.domain.test/keys",
+				"authorization_endpoint": "",
+				"response_types_supported": ["id_token"],
+				"subject_types_supported": [],
+				"id_token_signing_alg_values_supported": ["RS256", "PS256", "ES256", "ES384", "EdDSA"]
+			}`,
+		},
+		{
+			name:   "discovery document invalid method",
+			method: "POST",
+			path:   "/.well-known/openid-configuration",
+			status: http.StatusMethodNotAllowed,
+			body:   "405 method not allowed\n",
+		},
+		{
+			name:   "keys",
+			method: "GET",
+			path:   "/keys",
+			bundle: b,
+			status: http.StatusOK,
+		},
+		{
+			name:   "keys invalid method",
+			method: "POST",
+			path:   "/keys",
+			bundle: b,
+			status: http.StatusMethodNotAllowed,
+			body:   "405 method not allowed\n",
+		},
+		{
+			name:   "fail to retrieve bundle",
+			method: "GET",
+			path:   "/keys",
+			status: http.StatusInternalServerError,
+			body:   "500 unable to retrieve local bundle\n",
+		},
+		{
+			name:   "invalid path",
+			method: "GET",
+			path:   "/foo",
+			status: http.StatusNotFound,
+			body:   "404 page not found\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			addr, done := newTestServer(t, testGetter(testCase.bundle))
+			defer done()
+
+			req, err := http.NewRequest(testCase.method, fmt.Sprintf("http://%s%s", addr, testCase.path), nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			actual, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.status, resp.StatusCode)
+			switch {
+			case testCase.status != http.StatusOK:
+				require.Equal(t, testCase.body, string(actual))
+			case testCase.path == "/keys":
+				assert.Equal(t, "no-cache, no-store, must-revalidate", resp.Header.Get("Cache-Control"))
+				jwks := new(jose.JSONWebKeySet)
+				require.NoError(t, json.Unmarshal(actual, jwks))
+				require.Len(t, jwks.Keys, 1)
+				assert.Equal(t, "KID", jwks.Keys[0].KeyID)
+				assert.Empty(t, jwks.Keys[0].Use)
+				assert.Empty(t, jwks.Keys[0].Certificates)
+			default:
+				require.JSONEq(t, testCase.body, string(actual))
+			}
+		})
+	}
+}
+
+func newTestServer(t *testing.T, getter bundle.Getter) (net.Addr, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	addrCh := make(chan net.Addr, 1)
+	listen := func(network, address string) (net.Listener, error) {
+		listener, err := net.Listen(network, address)
+		if err != nil {
+			return nil, err
+		}
+		addrCh <- listener.Addr()
+		return listener, nil
+	}
+
+	log, _ := test.NewNullLogger()
+	server := NewServer(ServerConfig{
+		Log:     log,
+		Address: "localhost:0",
+		Domain:  "oidc.domain.test",
+		Getter:  getter,
+		listen:  listen,
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe(ctx)
+	}()
+
+	// wait for the listener to be created and the address to be set
+	var addr net.Addr
+	select {
+	case addr = <-addrCh:
+	case err := <-errCh:
+		cancel()
+		require.NoError(t, err, "unexpected error while waiting for address")
+	case <-time.After(time.Minute):
+		cancel()
+		require.FailNow(t, "timed out waiting for address")
+	}
+
+	return addr, cancel
+}
+
+func testGetter(b *bundleutil.Bundle) bundle.Getter {
+	return bundle.GetterFunc(func(ctx context.Context) (*bundleutil.Bundle, error) {
+		if b == nil {
+			return nil, errors.New("no bundle configured")
+		}
+		return b, nil
+	})
+}
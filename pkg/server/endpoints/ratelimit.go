@@ -0,0 +1,109 @@
+package endpoints
+
+import (
+	"sync"
+
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	node_pb "github.com/spiffe/spire/proto/spire/api/node"
+)
+
+// RateLimitConfig holds rate limiting configurations. Limits are expressed
+// in operations per second (e.g. attestations or signed SVIDs) and also bound
+// the number of operations allowed in a single call.
+type RateLimitConfig struct {
+	// Attestation, if true, rate limits attestation
+	Attestation bool
+
+	// AttestationPerIP is the node attestation limit per caller IP address.
+	// If zero, the default limit is used.
+	AttestationPerIP int
+
+	// SigningPerIP is the X509-SVID signing limit per caller IP address. If
+	// zero, the default limit is used.
+	SigningPerIP int
+
+	// SigningPerAgent is the X509-SVID signing limit per agent. If zero,
+	// signing is not limited per agent.
+	SigningPerAgent int
+
+	// JWTSigningPerIP is the JWT-SVID signing limit per caller IP address. If
+	// zero, the default limit is used.
+	JWTSigningPerIP int
+
+	// JWTSigningPerAgent is the JWT-SVID signing limit per agent. If zero,
+	// JWT-SVID signing is not limited per agent.
+	JWTSigningPerAgent int
+}
+
+// RateLimiters holds the rate limiters of the server APIs. The limits can be
+// adjusted with SetConfig while the server is running.
+type RateLimiters struct {
+	attest     *middleware.ConfigurableLimiter
+	csr        *middleware.ConfigurableLimiter
+	jsr        *middleware.ConfigurableLimiter
+	pushJWTKey api.RateLimiter
+
+	mtx    sync.Mutex
+	config RateLimitConfig
+}
+
+// NewRateLimiters creates the rate limiters of the server APIs.
+func NewRateLimiters(config RateLimitConfig) *RateLimiters {
+	config = config.withDefaults()
+	return &RateLimiters{
+		attest:     middleware.ConfigurableLimit(attestLimit(config)),
+		csr:        middleware.ConfigurableLimit(ipAndAgentLimit(config.SigningPerIP, config.SigningPerAgent)),
+		jsr:        middleware.ConfigurableLimit(ipAndAgentLimit(config.JWTSigningPerIP, config.JWTSigningPerAgent)),
+		pushJWTKey: middleware.PerIPLimit(node_pb.PushJWTKeyLimit),
+		config:     config,
+	}
+}
+
+// SetConfig adjusts the limits to the given configuration. The rate limiters
+// whose limits did not change are kept, so their callers are not granted a
+// new burst.
+func (r *RateLimiters) SetConfig(config RateLimitConfig) {
+	config = config.withDefaults()
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if config.Attestation != r.config.Attestation || config.AttestationPerIP != r.config.AttestationPerIP {
+		r.attest.SetRateLimiter(attestLimit(config))
+	}
+	if config.SigningPerIP != r.config.SigningPerIP || config.SigningPerAgent != r.config.SigningPerAgent {
+		r.csr.SetRateLimiter(ipAndAgentLimit(config.SigningPerIP, config.SigningPerAgent))
+	}
+	if config.JWTSigningPerIP != r.config.JWTSigningPerIP || config.JWTSigningPerAgent != r.config.JWTSigningPerAgent {
+		r.jsr.SetRateLimiter(ipAndAgentLimit(config.JWTSigningPerIP, config.JWTSigningPerAgent))
+	}
+	r.config = config
+}
+
+func (c RateLimitConfig) withDefaults() RateLimitConfig {
+	if c.AttestationPerIP == 0 {
+		c.AttestationPerIP = node_pb.AttestLimit
+	}
+	if c.SigningPerIP == 0 {
+		c.SigningPerIP = node_pb.CSRLimit
+	}
+	if c.JWTSigningPerIP == 0 {
+		c.JWTSigningPerIP = node_pb.JSRLimit
+	}
+	return c
+}
+
+func attestLimit(config RateLimitConfig) api.RateLimiter {
+	if !config.Attestation {
+		return middleware.DisabledLimit()
+	}
+	return middleware.PerIPLimit(config.AttestationPerIP)
+}
+
+func ipAndAgentLimit(perIP, perAgent int) api.RateLimiter {
+	if perAgent == 0 {
+		return middleware.PerIPLimit(perIP)
+	}
+	return middleware.AllLimits(middleware.PerIPLimit(perIP), middleware.PerAgentLimit(perAgent))
+}
@@ -0,0 +1,54 @@
+package endpoints
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRateLimiters(t *testing.T) {
+	tcpCtx := rpccontext.WithCallerAddr(context.Background(), &net.TCPAddr{IP: net.ParseIP("1.1.1.1")})
+	agentCtx := rpccontext.WithAgentCaller(rpccontext.WithCallerID(tcpCtx, spiffeid.RequireFromString("spiffe://example.org/spire/agent/test")))
+
+	// Limits also bound the number of operations of a single call, which
+	// makes them observable without waiting.
+	r := NewRateLimiters(RateLimitConfig{
+		Attestation:     true,
+		SigningPerAgent: 5,
+	})
+
+	// Per-ip limits default to the node API limits
+	require.NoError(t, r.csr.RateLimit(tcpCtx, 500))
+	require.NoError(t, r.jsr.RateLimit(tcpCtx, 500))
+	err := r.attest.RateLimit(tcpCtx, 2)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (2) exceeds burst size (1)")
+
+	// Per-agent limits only apply to agents
+	err = r.csr.RateLimit(agentCtx, 6)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (6) exceeds burst size (5)")
+	require.NoError(t, r.jsr.RateLimit(agentCtx, 6))
+
+	// Limits are adjusted in place
+	r.SetConfig(RateLimitConfig{
+		Attestation:        true,
+		AttestationPerIP:   5,
+		SigningPerIP:       10,
+		JWTSigningPerAgent: 5,
+	})
+	require.NoError(t, r.attest.RateLimit(tcpCtx, 2))
+	err = r.csr.RateLimit(tcpCtx, 11)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (11) exceeds burst size (10)")
+	require.NoError(t, r.csr.RateLimit(agentCtx, 6))
+	err = r.jsr.RateLimit(agentCtx, 6)
+	spiretest.RequireGRPCStatus(t, err, codes.ResourceExhausted, "rate (6) exceeds burst size (5)")
+
+	// Attestation can be disabled
+	r.SetConfig(RateLimitConfig{})
+	require.NoError(t, r.attest.RateLimit(tcpCtx, 99))
+}
@@ -15,12 +15,17 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/auth"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_registrationapi "github.com/spiffe/spire/pkg/common/telemetry/server/registrationapi"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/dnspolicy"
+	"github.com/spiffe/spire/pkg/server/entrydefaults"
+	"github.com/spiffe/spire/pkg/server/entrypolicy"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -43,6 +48,9 @@ type Handler struct {
 	Catalog     catalog.Catalog
 	TrustDomain url.URL
 	ServerCA    ca.ServerCA
+	Defaults    *entrydefaults.Defaults
+	Policy      *entrypolicy.Policy
+	DNSPolicy   *dnspolicy.Policy
 }
 
 //CreateEntry creates an entry in the Registration table,
@@ -177,6 +185,11 @@ func (h *Handler) UpdateEntry(ctx context.Context, request *registration.UpdateE
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := h.checkPolicy(ctx, entrypolicy.Update, request.Entry); err != nil {
+		log.WithError(err).Error("Entry rejected")
+		return nil, err
+	}
+
 	ds := h.getDataStore()
 	resp, err := ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
 		Entry: request.Entry,
@@ -793,6 +806,14 @@ func (h *Handler) createRegistrationEntry(ctx context.Context, requestedEntry *c
 		return nil, false, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := h.Defaults.Apply(requestedEntry); err != nil {
+		return nil, false, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.checkPolicy(ctx, entrypolicy.Create, requestedEntry); err != nil {
+		return nil, false, err
+	}
+
 	ds := h.getDataStore()
 
 	existingEntry, unique, err := h.isEntryUnique(ctx, ds, requestedEntry)
@@ -813,6 +834,24 @@ func (h *Handler) createRegistrationEntry(ctx context.Context, requestedEntry *c
 
 	return createResponse.Entry, false, nil
 }
+func (h *Handler) checkPolicy(ctx context.Context, op entrypolicy.Operation, entry *common.RegistrationEntry) error {
+	if err := h.DNSPolicy.Check(entry.SpiffeId, entry.DnsNames); err != nil {
+		return status.Errorf(codes.PermissionDenied, "entry rejected by DNS name policy: %v", err)
+	}
+	reasons, err := h.Policy.Evaluate(ctx, entrypolicy.Input{
+		Operation: op,
+		CallerID:  getCallerID(ctx),
+		Entry:     entry,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to evaluate entry policy: %v", err)
+	}
+	if len(reasons) > 0 {
+		return status.Errorf(codes.PermissionDenied, "entry rejected by policy: %s", strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
 func (h *Handler) prepareRegistrationEntry(entry *common.RegistrationEntry, forUpdate bool) (*common.RegistrationEntry, error) {
 	entry = cloneRegistrationEntry(entry)
 	if forUpdate && entry.EntryId == "" {
@@ -827,6 +866,22 @@ func (h *Handler) prepareRegistrationEntry(entry *common.RegistrationEntry, forU
 		}
 	}
 
+	if err := jwtsvid.ValidateClaims(entry.JwtSvidClaims); err != nil {
+		return nil, fmt.Errorf("JWT-SVID claims failed validation: %v", err)
+	}
+
+	if err := x509svid.ValidateKeyType(entry.X509SvidKeyType); err != nil {
+		return nil, fmt.Errorf("X509-SVID key type failed validation: %v", err)
+	}
+
+	if err := entrydefaults.ValidateDNSNameTemplates(entry.DnsNameTemplates); err != nil {
+		return nil, fmt.Errorf("DNS name templates failed validation: %v", err)
+	}
+
+	if err := selector.ValidateExpression(entry.SelectorExpression); err != nil {
+		return nil, fmt.Errorf("selector expression failed validation: %v", err)
+	}
+
 	entry.ParentId, err = idutil.NormalizeSpiffeID(entry.ParentId, idutil.AllowAnyInTrustDomain(h.TrustDomain.Host))
 	if err != nil {
 		return nil, err
@@ -0,0 +1,188 @@
+// Package entrydefaults applies operator-configured default values to newly
+// created registration entries based on the parent ID of the entry. It also
+// renders the DNS name templates carried by registration entries.
+package entrydefaults
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Rule describes the defaults applied to entries whose parent ID is
+// ParentIDPrefix or a path under it.
+type Rule struct {
+	// ParentIDPrefix is matched against the parent ID of the entry, on path
+	// segment boundaries, i.e. "spiffe://example.org/k8s" matches
+	// "spiffe://example.org/k8s/node" but not "spiffe://example.org/k8sfoo".
+	// The rule with the longest matching prefix wins.
+	ParentIDPrefix string
+
+	// TTL is applied when the entry does not specify a TTL.
+	TTL int32
+
+	// FederatesWith is applied when the entry does not federate with any
+	// trust domain. Values are trust domain names or IDs.
+	FederatesWith []string
+
+	// DNSNameTemplates are rendered and applied when the entry does not
+	// specify any DNS names. Templates are Go text/template strings evaluated
+	// against TemplateData.
+	DNSNameTemplates []string
+}
+
+// TemplateData is the data available to DNS name templates.
+type TemplateData struct {
+	// SpiffeID is the SPIFFE ID of the entry being created.
+	SpiffeID string
+	// ParentID is the parent ID of the entry being created.
+	ParentID string
+	// TrustDomain is the trust domain name of the entry SPIFFE ID.
+	TrustDomain string
+	// Path is the path component of the entry SPIFFE ID.
+	Path string
+	// PathSegments is the path component split into its non-empty segments.
+	PathSegments []string
+}
+
+type rule struct {
+	Rule
+	dnsNameTemplates []*template.Template
+}
+
+// Defaults holds a set of rules. A nil Defaults is valid and applies nothing.
+type Defaults struct {
+	rules []rule
+}
+
+// New validates the rules and returns a Defaults that applies them.
+func New(rules []Rule) (*Defaults, error) {
+	d := &Defaults{}
+	seen := make(map[string]bool)
+	for _, r := range rules {
+		if r.ParentIDPrefix == "" {
+			return nil, errors.New("parent ID prefix is required")
+		}
+		if seen[r.ParentIDPrefix] {
+			return nil, fmt.Errorf("duplicate rule for parent ID prefix %q", r.ParentIDPrefix)
+		}
+		seen[r.ParentIDPrefix] = true
+
+		if r.TTL < 0 {
+			return nil, fmt.Errorf("rule %q: TTL cannot be negative", r.ParentIDPrefix)
+		}
+
+		compiled := rule{Rule: r}
+		compiled.FederatesWith = nil
+		for _, trustDomain := range r.FederatesWith {
+			td, err := spiffeid.TrustDomainFromString(trustDomain)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: invalid federated trust domain %q: %v", r.ParentIDPrefix, trustDomain, err)
+			}
+			compiled.FederatesWith = append(compiled.FederatesWith, td.IDString())
+		}
+
+		for i, text := range r.DNSNameTemplates {
+			tmpl, err := parseDNSNameTemplate(i, text)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: unable to parse DNS name template %q: %v", r.ParentIDPrefix, text, err)
+			}
+			compiled.dnsNameTemplates = append(compiled.dnsNameTemplates, tmpl)
+		}
+		d.rules = append(d.rules, compiled)
+	}
+
+	// Longest prefixes first so the most specific rule is matched.
+	sort.Slice(d.rules, func(i, j int) bool {
+		return len(d.rules[i].ParentIDPrefix) > len(d.rules[j].ParentIDPrefix)
+	})
+	return d, nil
+}
+
+// Apply fills in unset fields on the entry using the most specific rule that
+// matches the entry parent ID. Fields explicitly set on the entry are never
+// overridden. The entry is modified in place.
+func (d *Defaults) Apply(entry *common.RegistrationEntry) error {
+	r := d.match(entry.ParentId)
+	if r == nil {
+		return nil
+	}
+
+	if entry.Ttl == 0 {
+		entry.Ttl = r.TTL
+	}
+
+	if len(entry.FederatesWith) == 0 && len(r.FederatesWith) > 0 {
+		entry.FederatesWith = append([]string(nil), r.FederatesWith...)
+	}
+
+	if len(entry.DnsNames) == 0 && len(r.dnsNameTemplates) > 0 {
+		data, err := templateDataFromEntry(entry)
+		if err != nil {
+			return err
+		}
+		for _, tmpl := range r.dnsNameTemplates {
+			dnsName, err := renderDNSName(tmpl, data)
+			if err != nil {
+				return fmt.Errorf("unable to render DNS name template for parent ID prefix %q: %v", r.ParentIDPrefix, err)
+			}
+			entry.DnsNames = append(entry.DnsNames, dnsName)
+		}
+	}
+
+	return nil
+}
+
+func (d *Defaults) match(parentID string) *rule {
+	if d == nil || parentID == "" {
+		return nil
+	}
+	for i := range d.rules {
+		if matchesPrefix(parentID, d.rules[i].ParentIDPrefix) {
+			return &d.rules[i]
+		}
+	}
+	return nil
+}
+
+// matchesPrefix returns true if the parent ID is the prefix or continues
+// with a path segment after it.
+func matchesPrefix(parentID, prefix string) bool {
+	if !strings.HasPrefix(parentID, prefix) {
+		return false
+	}
+	rest := parentID[len(prefix):]
+	return rest == "" || strings.HasSuffix(prefix, "/") || strings.HasPrefix(rest, "/")
+}
+
+func templateDataFromEntry(entry *common.RegistrationEntry) (*TemplateData, error) {
+	return newTemplateData(entry.SpiffeId, entry.ParentId)
+}
+
+func newTemplateData(spiffeID, parentID string) (*TemplateData, error) {
+	u, err := url.Parse(spiffeID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse SPIFFE ID %q: %v", spiffeID, err)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return &TemplateData{
+		SpiffeID:     spiffeID,
+		ParentID:     parentID,
+		TrustDomain:  u.Host,
+		Path:         u.Path,
+		PathSegments: segments,
+	}, nil
+}
@@ -0,0 +1,209 @@
+package entrydefaults
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		rules []Rule
+		err   string
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name:  "missing prefix",
+			rules: []Rule{{TTL: 60}},
+			err:   "parent ID prefix is required",
+		},
+		{
+			name: "duplicate prefix",
+			rules: []Rule{
+				{ParentIDPrefix: "spiffe://example.org/node"},
+				{ParentIDPrefix: "spiffe://example.org/node"},
+			},
+			err: `duplicate rule for parent ID prefix "spiffe://example.org/node"`,
+		},
+		{
+			name:  "negative TTL",
+			rules: []Rule{{ParentIDPrefix: "spiffe://example.org/node", TTL: -1}},
+			err:   `rule "spiffe://example.org/node": TTL cannot be negative`,
+		},
+		{
+			name:  "bad template",
+			rules: []Rule{{ParentIDPrefix: "spiffe://example.org/node", DNSNameTemplates: []string{"{{ .Path "}}},
+			err:   `rule "spiffe://example.org/node": unable to parse DNS name template`,
+		},
+		{
+			name:  "invalid federated trust domain",
+			rules: []Rule{{ParentIDPrefix: "spiffe://example.org/node", FederatesWith: []string{"https://other.org"}}},
+			err:   `rule "spiffe://example.org/node": invalid federated trust domain "https://other.org"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := New(tt.rules)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				require.Nil(t, d)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, d)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	d, err := New([]Rule{
+		{
+			ParentIDPrefix: "spiffe://example.org/k8s",
+			TTL:            600,
+			FederatesWith:  []string{"spiffe://other.org"},
+		},
+		{
+			ParentIDPrefix:   "spiffe://example.org/k8s/cluster1",
+			TTL:              300,
+			DNSNameTemplates: []string{"{{ index .PathSegments 1 }}.{{ .TrustDomain }}"},
+		},
+		{
+			ParentIDPrefix: "spiffe://example.org/vm/",
+			FederatesWith:  []string{"Partner.org"},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name   string
+		entry  *common.RegistrationEntry
+		expect *common.RegistrationEntry
+		err    string
+	}{
+		{
+			name: "no matching rule",
+			entry: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/vm",
+				SpiffeId: "spiffe://example.org/workload",
+			},
+			expect: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/vm",
+				SpiffeId: "spiffe://example.org/workload",
+			},
+		},
+		{
+			name: "least specific rule",
+			entry: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/k8s/cluster2",
+				SpiffeId: "spiffe://example.org/ns/foo",
+			},
+			expect: &common.RegistrationEntry{
+				ParentId:      "spiffe://example.org/k8s/cluster2",
+				SpiffeId:      "spiffe://example.org/ns/foo",
+				Ttl:           600,
+				FederatesWith: []string{"spiffe://other.org"},
+			},
+		},
+		{
+			name: "parent ID equal to the prefix",
+			entry: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/k8s",
+				SpiffeId: "spiffe://example.org/ns/foo",
+			},
+			expect: &common.RegistrationEntry{
+				ParentId:      "spiffe://example.org/k8s",
+				SpiffeId:      "spiffe://example.org/ns/foo",
+				Ttl:           600,
+				FederatesWith: []string{"spiffe://other.org"},
+			},
+		},
+		{
+			name: "prefix only matches on path segment boundaries",
+			entry: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/k8sfoo/node",
+				SpiffeId: "spiffe://example.org/ns/foo",
+			},
+			expect: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/k8sfoo/node",
+				SpiffeId: "spiffe://example.org/ns/foo",
+			},
+		},
+		{
+			name: "prefix ending with a slash",
+			entry: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/vm/node",
+				SpiffeId: "spiffe://example.org/workload",
+			},
+			expect: &common.RegistrationEntry{
+				ParentId:      "spiffe://example.org/vm/node",
+				SpiffeId:      "spiffe://example.org/workload",
+				FederatesWith: []string{"spiffe://partner.org"},
+			},
+		},
+		{
+			name: "most specific rule wins",
+			entry: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/k8s/cluster1/node",
+				SpiffeId: "spiffe://example.org/ns/foo",
+			},
+			expect: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/k8s/cluster1/node",
+				SpiffeId: "spiffe://example.org/ns/foo",
+				Ttl:      300,
+				DnsNames: []string{"foo.example.org"},
+			},
+		},
+		{
+			name: "explicit fields are preserved",
+			entry: &common.RegistrationEntry{
+				ParentId:      "spiffe://example.org/k8s/cluster1/node",
+				SpiffeId:      "spiffe://example.org/ns/foo",
+				Ttl:           30,
+				DnsNames:      []string{"bar"},
+				FederatesWith: []string{"spiffe://another.org"},
+			},
+			expect: &common.RegistrationEntry{
+				ParentId:      "spiffe://example.org/k8s/cluster1/node",
+				SpiffeId:      "spiffe://example.org/ns/foo",
+				Ttl:           30,
+				DnsNames:      []string{"bar"},
+				FederatesWith: []string{"spiffe://another.org"},
+			},
+		},
+		{
+			name: "template fails to render",
+			entry: &common.RegistrationEntry{
+				ParentId: "spiffe://example.org/k8s/cluster1/node",
+				SpiffeId: "spiffe://example.org/foo",
+			},
+			err: `unable to render DNS name template for parent ID prefix "spiffe://example.org/k8s/cluster1"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := d.Apply(tt.entry)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, tt.entry)
+		})
+	}
+}
+
+func TestApplyNilDefaults(t *testing.T) {
+	var d *Defaults
+	entry := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/node",
+		SpiffeId: "spiffe://example.org/workload",
+	}
+	require.NoError(t, d.Apply(entry))
+	require.Equal(t, int32(0), entry.Ttl)
+}
@@ -0,0 +1,68 @@
+package entrydefaults
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/spiffe/spire/pkg/common/x509util"
+)
+
+// ValidateDNSNameTemplates checks that the DNS name templates can be parsed.
+func ValidateDNSNameTemplates(templates []string) error {
+	for i, text := range templates {
+		if _, err := parseDNSNameTemplate(i, text); err != nil {
+			return fmt.Errorf("unable to parse DNS name template %q: %v", text, err)
+		}
+	}
+	return nil
+}
+
+// RenderDNSNameTemplates renders the DNS name templates of an entry with the
+// given SPIFFE ID and parent ID. The templates are evaluated against
+// TemplateData and each must render a valid DNS name.
+func RenderDNSNameTemplates(templates []string, spiffeID, parentID string) ([]string, error) {
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	data, err := newTemplateData(spiffeID, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	dnsNames := make([]string, 0, len(templates))
+	for i, text := range templates {
+		tmpl, err := parseDNSNameTemplate(i, text)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse DNS name template %q: %v", text, err)
+		}
+		dnsName, err := renderDNSName(tmpl, data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render DNS name template %q: %v", text, err)
+		}
+		dnsNames = append(dnsNames, dnsName)
+	}
+	return dnsNames, nil
+}
+
+func parseDNSNameTemplate(i int, text string) (*template.Template, error) {
+	return template.New(fmt.Sprintf("dns_name[%d]", i)).Option("missingkey=error").Parse(text)
+}
+
+func renderDNSName(tmpl *template.Template, data *TemplateData) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+	dnsName := strings.TrimSpace(buf.String())
+	if dnsName == "" {
+		return "", errors.New("rendered an empty value")
+	}
+	if err := x509util.ValidateDNS(dnsName); err != nil {
+		return "", fmt.Errorf("rendered an invalid value %q: %v", dnsName, err)
+	}
+	return dnsName, nil
+}
@@ -0,0 +1,67 @@
+package entrydefaults
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDNSNameTemplates(t *testing.T) {
+	require.NoError(t, ValidateDNSNameTemplates(nil))
+	require.NoError(t, ValidateDNSNameTemplates([]string{"{{ .TrustDomain }}"}))
+
+	err := ValidateDNSNameTemplates([]string{"{{ .TrustDomain }}", "{{ .Path "})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unable to parse DNS name template "{{ .Path "`)
+}
+
+func TestRenderDNSNameTemplates(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		templates []string
+		spiffeID  string
+		expect    []string
+		err       string
+	}{
+		{
+			name:     "no templates",
+			spiffeID: "spiffe://example.org/ns/foo",
+		},
+		{
+			name:      "success",
+			templates: []string{"{{ index .PathSegments 1 }}.{{ .TrustDomain }}", "{{ index .PathSegments 1 }}"},
+			spiffeID:  "spiffe://example.org/ns/foo",
+			expect:    []string{"foo.example.org", "foo"},
+		},
+		{
+			name:      "fails to render",
+			templates: []string{"{{ index .PathSegments 1 }}"},
+			spiffeID:  "spiffe://example.org/foo",
+			err:       `unable to render DNS name template "{{ index .PathSegments 1 }}"`,
+		},
+		{
+			name:      "renders empty value",
+			templates: []string{"{{ .Path }}"},
+			spiffeID:  "spiffe://example.org",
+			err:       "rendered an empty value",
+		},
+		{
+			name:      "renders invalid value",
+			templates: []string{"{{ .Path }}"},
+			spiffeID:  "spiffe://example.org/foo",
+			err:       `rendered an invalid value "/foo"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dnsNames, err := RenderDNSNameTemplates(tt.templates, tt.spiffeID, "spiffe://example.org/node")
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, dnsNames)
+		})
+	}
+}
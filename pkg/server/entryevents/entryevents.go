@@ -0,0 +1,212 @@
+// Package entryevents provides a change feed for registration entries and
+// the agent selectors that determine which node aliases agents belong to.
+// The feed is populated by wrapping the datastore and emitting an event for
+// every successful registration entry or node selector mutation.
+package entryevents
+
+import (
+	"context"
+	"sync"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	// subscriberBufferSize is the number of events buffered per subscriber
+	// before the subscriber is considered lagging.
+	subscriberBufferSize = 256
+)
+
+// EventType is the type of change described by an Event.
+type EventType int
+
+const (
+	// EntryCreated is emitted when a registration entry is created.
+	EntryCreated EventType = iota + 1
+	// EntryUpdated is emitted when a registration entry is updated.
+	EntryUpdated
+	// EntryDeleted is emitted when a registration entry is deleted.
+	EntryDeleted
+	// EntriesPruned is emitted when expired registration entries are pruned.
+	// The event does not carry an entry since the datastore does not report
+	// which entries were removed.
+	EntriesPruned
+	// NodeSelectorsSet is emitted when the selectors of an agent are set.
+	NodeSelectorsSet
+	// EventsDropped is delivered to a subscriber that was not keeping up and
+	// missed events. Subscribers receiving it should resynchronize their
+	// state from the datastore.
+	EventsDropped
+	// EntryTemplateUpdated is emitted when an entry template used by
+	// registration entries is updated, which updates those entries as well.
+	// The event does not carry the entries since the datastore does not
+	// report which entries were updated.
+	EntryTemplateUpdated
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EntryCreated:
+		return "created"
+	case EntryUpdated:
+		return "updated"
+	case EntryDeleted:
+		return "deleted"
+	case EntriesPruned:
+		return "pruned"
+	case NodeSelectorsSet:
+		return "node_selectors_set"
+	case EventsDropped:
+		return "dropped"
+	case EntryTemplateUpdated:
+		return "entry_template_updated"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a change to registration entries.
+type Event struct {
+	Type EventType
+
+	// Entry is the entry affected by the change, as stored after the change
+	// for creates and updates, or before the change for deletes. It is only
+	// set for entry creates, updates and deletes.
+	Entry *common.RegistrationEntry
+
+	// NodeSelectors are the selectors of the agent, as set by the change.
+	// It is only set for NodeSelectorsSet events.
+	NodeSelectors *datastore.NodeSelectors
+}
+
+// Broker fans out events to subscribers. The zero value is not usable; use
+// NewBroker.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	ch      chan Event
+	lagging bool
+}
+
+// NewBroker returns a new event broker.
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Subscribe returns a channel that receives events published after the call
+// to Subscribe. The channel is closed when the context is done. Publishing
+// never blocks on a slow subscriber; instead, events are dropped and the
+// subscriber receives an EventsDropped event once it catches up.
+func (b *Broker) Subscribe(ctx context.Context) <-chan Event {
+	sub := &subscriber{
+		ch: make(chan Event, subscriberBufferSize),
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers, sub)
+		close(sub.ch)
+		b.mu.Unlock()
+	}()
+
+	return sub.ch
+}
+
+// Publish delivers the event to all subscribers.
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		if sub.lagging {
+			// Try to deliver the drop notification before anything else so
+			// the subscriber knows its view is incomplete.
+			select {
+			case sub.ch <- Event{Type: EventsDropped}:
+				sub.lagging = false
+			default:
+				continue
+			}
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			sub.lagging = true
+		}
+	}
+}
+
+// DataStore wraps a datastore and publishes an event to the broker for each
+// successful registration entry or node selector mutation.
+type DataStore struct {
+	datastore.DataStore
+	broker *Broker
+}
+
+// WithEvents wraps the datastore so registration entry and node selector
+// mutations are published to the broker.
+func WithEvents(ds datastore.DataStore, broker *Broker) *DataStore {
+	return &DataStore{
+		DataStore: ds,
+		broker:    broker,
+	}
+}
+
+func (ds *DataStore) CreateRegistrationEntry(ctx context.Context, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
+	resp, err := ds.DataStore.CreateRegistrationEntry(ctx, req)
+	if err == nil {
+		ds.broker.Publish(Event{Type: EntryCreated, Entry: resp.Entry})
+	}
+	return resp, err
+}
+
+func (ds *DataStore) UpdateRegistrationEntry(ctx context.Context, req *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
+	resp, err := ds.DataStore.UpdateRegistrationEntry(ctx, req)
+	if err == nil {
+		ds.broker.Publish(Event{Type: EntryUpdated, Entry: resp.Entry})
+	}
+	return resp, err
+}
+
+func (ds *DataStore) DeleteRegistrationEntry(ctx context.Context, req *datastore.DeleteRegistrationEntryRequest) (*datastore.DeleteRegistrationEntryResponse, error) {
+	resp, err := ds.DataStore.DeleteRegistrationEntry(ctx, req)
+	if err == nil {
+		ds.broker.Publish(Event{Type: EntryDeleted, Entry: resp.Entry})
+	}
+	return resp, err
+}
+
+func (ds *DataStore) PruneRegistrationEntries(ctx context.Context, req *datastore.PruneRegistrationEntriesRequest) (*datastore.PruneRegistrationEntriesResponse, error) {
+	resp, err := ds.DataStore.PruneRegistrationEntries(ctx, req)
+	if err == nil {
+		ds.broker.Publish(Event{Type: EntriesPruned})
+	}
+	return resp, err
+}
+
+func (ds *DataStore) UpdateEntryTemplate(ctx context.Context, req *datastore.UpdateEntryTemplateRequest) (*datastore.UpdateEntryTemplateResponse, error) {
+	resp, err := ds.DataStore.UpdateEntryTemplate(ctx, req)
+	if err == nil && resp.EntriesUpdated > 0 {
+		ds.broker.Publish(Event{Type: EntryTemplateUpdated})
+	}
+	return resp, err
+}
+
+func (ds *DataStore) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
+	resp, err := ds.DataStore.SetNodeSelectors(ctx, req)
+	if err == nil {
+		ds.broker.Publish(Event{Type: NodeSelectorsSet, NodeSelectors: req.Selectors})
+	}
+	return resp, err
+}
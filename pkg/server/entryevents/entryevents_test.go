@@ -0,0 +1,171 @@
+package entryevents
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataStoreEmitsEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := NewBroker()
+	fakeDS := fakedatastore.New(t)
+	ds := WithEvents(fakeDS, broker)
+	events := broker.Subscribe(ctx)
+
+	createResp, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:  "spiffe://example.org/node",
+			SpiffeId:  "spiffe://example.org/workload",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+	})
+	require.NoError(t, err)
+	event := recvEvent(t, events)
+	require.Equal(t, EntryCreated, event.Type)
+	require.Equal(t, createResp.Entry.EntryId, event.Entry.EntryId)
+
+	entry := createResp.Entry
+	entry.Ttl = 60
+	_, err = ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	require.NoError(t, err)
+	event = recvEvent(t, events)
+	require.Equal(t, EntryUpdated, event.Type)
+	require.Equal(t, int32(60), event.Entry.Ttl)
+
+	_, err = ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entry.EntryId,
+	})
+	require.NoError(t, err)
+	event = recvEvent(t, events)
+	require.Equal(t, EntryDeleted, event.Type)
+	require.Equal(t, entry.EntryId, event.Entry.EntryId)
+
+	_, err = ds.PruneRegistrationEntries(ctx, &datastore.PruneRegistrationEntriesRequest{
+		ExpiresBefore: time.Now().Unix(),
+	})
+	require.NoError(t, err)
+	event = recvEvent(t, events)
+	require.Equal(t, EntriesPruned, event.Type)
+	require.Nil(t, event.Entry)
+
+	nodeSelectors := &datastore.NodeSelectors{
+		SpiffeId:  "spiffe://example.org/node",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	}
+	_, err = ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: nodeSelectors,
+	})
+	require.NoError(t, err)
+	event = recvEvent(t, events)
+	require.Equal(t, NodeSelectorsSet, event.Type)
+	require.Equal(t, nodeSelectors, event.NodeSelectors)
+
+	// Updating an entry template updates the entries using it. Templates
+	// used by no entry do not emit events.
+	_, err = ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: &datastore.EntryTemplate{TemplateId: "web", Ttl: 60},
+	})
+	require.NoError(t, err)
+	updateTemplate := func() {
+		_, err := ds.UpdateEntryTemplate(ctx, &datastore.UpdateEntryTemplateRequest{
+			EntryTemplate: &datastore.EntryTemplate{TemplateId: "web", Ttl: 120},
+			InputMask:     &datastore.EntryTemplateMask{Ttl: true},
+		})
+		require.NoError(t, err)
+	}
+	updateTemplate()
+	requireNoEvent(t, events)
+
+	_, err = ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:   "spiffe://example.org/node",
+			SpiffeId:   "spiffe://example.org/web",
+			Selectors:  []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			TemplateId: "web",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, EntryCreated, recvEvent(t, events).Type)
+	updateTemplate()
+	event = recvEvent(t, events)
+	require.Equal(t, EntryTemplateUpdated, event.Type)
+	require.Nil(t, event.Entry)
+
+	// Failed mutations do not emit events
+	fakeDS.SetNextError(errors.New("ohno"))
+	_, err = ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	require.Error(t, err)
+	requireNoEvent(t, events)
+}
+
+func TestBrokerDropsEventsForLaggingSubscribers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := NewBroker()
+	events := broker.Subscribe(ctx)
+
+	for i := 0; i < subscriberBufferSize+10; i++ {
+		broker.Publish(Event{Type: EntriesPruned})
+	}
+
+	// Drain the buffer
+	for i := 0; i < subscriberBufferSize; i++ {
+		require.Equal(t, EntriesPruned, recvEvent(t, events).Type)
+	}
+	requireNoEvent(t, events)
+
+	// The next publish first notifies the subscriber that events were dropped
+	broker.Publish(Event{Type: EntryCreated})
+	require.Equal(t, EventsDropped, recvEvent(t, events).Type)
+	require.Equal(t, EntryCreated, recvEvent(t, events).Type)
+}
+
+func TestBrokerClosesChannelOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	broker := NewBroker()
+	events := broker.Subscribe(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		require.False(t, ok)
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for channel to close")
+	}
+
+	// Publishing after the subscriber is gone is a no-op
+	broker.Publish(Event{Type: EntryCreated})
+}
+
+func recvEvent(t *testing.T, events <-chan Event) Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for event")
+	}
+	return Event{}
+}
+
+func requireNoEvent(t *testing.T, events <-chan Event) {
+	select {
+	case event := <-events:
+		require.FailNow(t, "unexpected event", "event=%s", event.Type)
+	default:
+	}
+}
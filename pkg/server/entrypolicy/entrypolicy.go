@@ -0,0 +1,178 @@
+// Package entrypolicy evaluates registration entries against
+// operator-supplied Rego policies before they are written to the datastore.
+package entrypolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// DefaultQuery is the query evaluated when none is configured. It is
+// expected to produce the set of reasons an entry is rejected.
+const DefaultQuery = "data.spire.entry.deny"
+
+// Operation is the registration entry operation being evaluated.
+type Operation string
+
+const (
+	// Create is the operation for newly created entries.
+	Create Operation = "create"
+	// Update is the operation for updated entries.
+	Update Operation = "update"
+)
+
+// Config is the policy configuration.
+type Config struct {
+	// PolicyPaths are the files or directories holding the Rego modules
+	// (and optionally, JSON or YAML data documents) of the policy.
+	PolicyPaths []string
+
+	// Query is evaluated against each entry. It must produce a set (or
+	// array) of rejection reasons, or a boolean. The entry is rejected if
+	// the result is a non-empty set or true. Defaults to DefaultQuery.
+	Query string
+}
+
+// Input is the input of a policy evaluation.
+type Input struct {
+	// Operation is the operation being performed on the entry.
+	Operation Operation
+
+	// CallerID is the SPIFFE ID of the caller. It is empty for callers
+	// without a SPIFFE ID (e.g. local callers over the UDS).
+	CallerID string
+
+	// Entry is the registration entry as it will be written to the
+	// datastore, after entry defaults have been applied.
+	Entry *common.RegistrationEntry
+}
+
+// Policy evaluates registration entries. A nil Policy is valid and allows
+// every entry.
+type Policy struct {
+	query rego.PreparedEvalQuery
+}
+
+// New loads and compiles the policy.
+func New(ctx context.Context, config Config) (*Policy, error) {
+	if len(config.PolicyPaths) == 0 {
+		return nil, errors.New("at least one policy path is required")
+	}
+
+	query := config.Query
+	if query == "" {
+		query = DefaultQuery
+	}
+
+	prepared, err := rego.New(
+		rego.Query(query),
+		rego.Load(config.PolicyPaths, nil),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prepare policy: %v", err)
+	}
+
+	return &Policy{
+		query: prepared,
+	}, nil
+}
+
+// Evaluate evaluates the policy against the input and returns the reasons
+// the entry is rejected. The entry is allowed if no reasons are returned.
+func (p *Policy) Evaluate(ctx context.Context, input Input) ([]string, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	rs, err := p.query.Eval(ctx, rego.EvalInput(inputDocument(input)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to evaluate policy: %v", err)
+	}
+
+	var reasons []string
+	for _, result := range rs {
+		for _, expr := range result.Expressions {
+			exprReasons, err := reasonsFromValue(expr.Value)
+			if err != nil {
+				return nil, err
+			}
+			reasons = append(reasons, exprReasons...)
+		}
+	}
+	return reasons, nil
+}
+
+func reasonsFromValue(value interface{}) ([]string, error) {
+	switch value := value.(type) {
+	case bool:
+		if value {
+			return []string{"denied by policy"}, nil
+		}
+		return nil, nil
+	case string:
+		if value != "" {
+			return []string{value}, nil
+		}
+		return nil, nil
+	case []interface{}:
+		reasons := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				reasons = append(reasons, s)
+			} else {
+				reasons = append(reasons, fmt.Sprint(v))
+			}
+		}
+		return reasons, nil
+	default:
+		return nil, fmt.Errorf("unexpected policy result type %T", value)
+	}
+}
+
+// inputDocument converts the input into the document exposed to the policy
+// as `input`. Field names follow the registration API.
+func inputDocument(input Input) map[string]interface{} {
+	doc := map[string]interface{}{
+		"operation": string(input.Operation),
+		"caller_id": input.CallerID,
+	}
+
+	e := input.Entry
+	if e == nil {
+		return doc
+	}
+
+	selectors := make([]interface{}, 0, len(e.Selectors))
+	for _, s := range e.Selectors {
+		selectors = append(selectors, map[string]interface{}{
+			"type":  s.Type,
+			"value": s.Value,
+		})
+	}
+
+	doc["entry"] = map[string]interface{}{
+		"id":             e.EntryId,
+		"spiffe_id":      e.SpiffeId,
+		"parent_id":      e.ParentId,
+		"selectors":      selectors,
+		"ttl":            e.Ttl,
+		"federates_with": stringsDocument(e.FederatesWith),
+		"admin":          e.Admin,
+		"downstream":     e.Downstream,
+		"expires_at":     e.EntryExpiry,
+		"dns_names":      stringsDocument(e.DnsNames),
+	}
+	return doc
+}
+
+func stringsDocument(ss []string) []interface{} {
+	out := make([]interface{}, 0, len(ss))
+	for _, s := range ss {
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,244 @@
+package entrypolicy
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+package spire.entry
+
+deny[msg] {
+	s := input.entry.selectors[_]
+	s.type == "unix"
+	s.value == "uid:0"
+	msg := "unix:uid:0 selectors are not allowed"
+}
+
+deny[msg] {
+	input.caller_id == "spiffe://example.org/admin/team-a"
+	not startswith(input.entry.spiffe_id, "spiffe://example.org/team-a/")
+	msg := sprintf("%s cannot be registered by team-a admins", [input.entry.spiffe_id])
+}
+
+deny[msg] {
+	input.operation == "update"
+	input.entry.admin
+	msg := "entries cannot be made admin through an update"
+}
+`
+
+func TestNew(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	goodPath := writePolicy(t, dir, "good.rego", testPolicy)
+	badPath := writePolicy(t, dir, "bad.rego", "package spire.entry\n\ndeny[msg] {")
+
+	for _, tt := range []struct {
+		name   string
+		config Config
+		err    string
+	}{
+		{
+			name:   "success",
+			config: Config{PolicyPaths: []string{goodPath}},
+		},
+		{
+			name:   "success with custom query",
+			config: Config{PolicyPaths: []string{goodPath}, Query: "data.spire.entry.deny"},
+		},
+		{
+			name: "no policy paths",
+			err:  "at least one policy path is required",
+		},
+		{
+			name:   "missing policy file",
+			config: Config{PolicyPaths: []string{filepath.Join(dir, "missing.rego")}},
+			err:    "unable to prepare policy",
+		},
+		{
+			name:   "malformed policy",
+			config: Config{PolicyPaths: []string{badPath}},
+			err:    "unable to prepare policy",
+		},
+		{
+			name:   "malformed query",
+			config: Config{PolicyPaths: []string{goodPath}, Query: "data.spire["},
+			err:    "unable to prepare policy",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(context.Background(), tt.config)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				require.Nil(t, p)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, p)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	p, err := New(context.Background(), Config{
+		PolicyPaths: []string{writePolicy(t, dir, "policy.rego", testPolicy)},
+	})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name    string
+		input   Input
+		reasons []string
+	}{
+		{
+			name: "allowed",
+			input: Input{
+				Operation: Create,
+				CallerID:  "spiffe://example.org/admin/team-a",
+				Entry: &common.RegistrationEntry{
+					SpiffeId:  "spiffe://example.org/team-a/workload",
+					ParentId:  "spiffe://example.org/node",
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				},
+			},
+		},
+		{
+			name: "root selector",
+			input: Input{
+				Operation: Create,
+				Entry: &common.RegistrationEntry{
+					SpiffeId:  "spiffe://example.org/workload",
+					ParentId:  "spiffe://example.org/node",
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:0"}},
+				},
+			},
+			reasons: []string{"unix:uid:0 selectors are not allowed"},
+		},
+		{
+			name: "SPIFFE ID outside of the caller prefix",
+			input: Input{
+				Operation: Create,
+				CallerID:  "spiffe://example.org/admin/team-a",
+				Entry: &common.RegistrationEntry{
+					SpiffeId:  "spiffe://example.org/team-b/workload",
+					ParentId:  "spiffe://example.org/node",
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:0"}},
+				},
+			},
+			reasons: []string{
+				"spiffe://example.org/team-b/workload cannot be registered by team-a admins",
+				"unix:uid:0 selectors are not allowed",
+			},
+		},
+		{
+			name: "admin allowed on create",
+			input: Input{
+				Operation: Create,
+				Entry: &common.RegistrationEntry{
+					SpiffeId:  "spiffe://example.org/workload",
+					ParentId:  "spiffe://example.org/node",
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+					Admin:     true,
+				},
+			},
+		},
+		{
+			name: "admin denied on update",
+			input: Input{
+				Operation: Update,
+				Entry: &common.RegistrationEntry{
+					EntryId:   "ENTRYID",
+					SpiffeId:  "spiffe://example.org/workload",
+					ParentId:  "spiffe://example.org/node",
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+					Admin:     true,
+				},
+			},
+			reasons: []string{"entries cannot be made admin through an update"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			reasons, err := p.Evaluate(context.Background(), tt.input)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.reasons, reasons)
+		})
+	}
+}
+
+func TestEvaluateBooleanQuery(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	p, err := New(context.Background(), Config{
+		PolicyPaths: []string{writePolicy(t, dir, "policy.rego", `
+package spire.entry
+
+default reject = false
+
+reject {
+	input.entry.downstream
+}
+`)},
+		Query: "data.spire.entry.reject",
+	})
+	require.NoError(t, err)
+
+	reasons, err := p.Evaluate(context.Background(), Input{
+		Operation: Create,
+		Entry:     &common.RegistrationEntry{Downstream: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"denied by policy"}, reasons)
+
+	reasons, err = p.Evaluate(context.Background(), Input{
+		Operation: Create,
+		Entry:     &common.RegistrationEntry{},
+	})
+	require.NoError(t, err)
+	require.Empty(t, reasons)
+}
+
+func TestEvaluateUnexpectedResult(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	p, err := New(context.Background(), Config{
+		PolicyPaths: []string{writePolicy(t, dir, "policy.rego", `
+package spire.entry
+
+ttl = input.entry.ttl
+`)},
+		Query: "data.spire.entry.ttl",
+	})
+	require.NoError(t, err)
+
+	_, err = p.Evaluate(context.Background(), Input{
+		Operation: Create,
+		Entry:     &common.RegistrationEntry{Ttl: 60},
+	})
+	require.EqualError(t, err, "unexpected policy result type json.Number")
+}
+
+func TestNilPolicy(t *testing.T) {
+	var p *Policy
+	reasons, err := p.Evaluate(context.Background(), Input{
+		Operation: Create,
+		Entry: &common.RegistrationEntry{
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:0"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, reasons)
+}
+
+func writePolicy(t *testing.T, dir, name, policy string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(policy), 0600))
+	return path
+}
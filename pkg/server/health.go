@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+const (
+	// dependencyCheckInterval is how often the dependency health checks run.
+	dependencyCheckInterval = 10 * time.Second
+
+	// datastoreCheckTimeout is the timeout used to reach the datastore when
+	// checking its health.
+	datastoreCheckTimeout = 5 * time.Second
+)
+
+// upstreamAuthorityStatus reports the outcome of the last call made to the
+// upstream authority.
+type upstreamAuthorityStatus interface {
+	UpstreamAuthorityStatus() error
+}
+
+// dependencyChecks reports the health of the dependencies the server needs in
+// order to serve requests. They only affect readiness, so load balancers stop
+// routing requests to the server while they fail.
+type dependencyChecks struct {
+	trustDomainID string
+	dataStore     datastore.DataStore
+	ca            *ca.CA
+	upstream      upstreamAuthorityStatus
+	clk           clock.Clock
+}
+
+// addTo registers the dependency checks with the health checker.
+func (d *dependencyChecks) addTo(checker *health.Checker, interval time.Duration) error {
+	if err := checker.AddCheck("datastore", health.CheckFunc(d.datastore), interval); err != nil {
+		return err
+	}
+	if err := checker.AddCheck("ca", health.CheckFunc(d.caStatus), interval); err != nil {
+		return err
+	}
+	return checker.AddCheck("upstream_authority", health.CheckFunc(d.upstreamAuthority), interval)
+}
+
+// datastore checks that the datastore can be queried.
+func (d *dependencyChecks) datastore() (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), datastoreCheckTimeout)
+	defer cancel()
+
+	if _, err := d.dataStore.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: d.trustDomainID,
+	}); err != nil {
+		return nil, fmt.Errorf("unable to query the datastore: %v", err)
+	}
+	return nil, nil
+}
+
+// caStatus checks that the server has a valid X509 CA and JWT key to sign
+// SVIDs with.
+func (d *dependencyChecks) caStatus() (interface{}, error) {
+	now := d.clk.Now()
+
+	x509CA := d.ca.X509CA()
+	if x509CA == nil {
+		return nil, errors.New("no X509 CA is active")
+	}
+	details := map[string]interface{}{
+		"x509_ca_expires_at": x509CA.Certificate.NotAfter,
+	}
+	if now.After(x509CA.Certificate.NotAfter) {
+		return details, errors.New("the active X509 CA has expired")
+	}
+
+	jwtKey := d.ca.JWTKey()
+	if jwtKey == nil {
+		return details, errors.New("no JWT key is active")
+	}
+	details["jwt_key_expires_at"] = jwtKey.NotAfter
+	if now.After(jwtKey.NotAfter) {
+		return details, errors.New("the active JWT key has expired")
+	}
+	return details, nil
+}
+
+// upstreamAuthority checks that the last call made to the upstream authority
+// succeeded.
+func (d *dependencyChecks) upstreamAuthority() (interface{}, error) {
+	if err := d.upstream.UpstreamAuthorityStatus(); err != nil {
+		return nil, fmt.Errorf("unable to reach the upstream authority: %v", err)
+	}
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUpstreamAuthorityStatus struct {
+	err error
+}
+
+func (f *fakeUpstreamAuthorityStatus) UpstreamAuthorityStatus() error {
+	return f.err
+}
+
+func TestDependencyChecks(t *testing.T) {
+	clk := clock.NewMock(t)
+	ds := fakedatastore.New(t)
+	serverCA := ca.NewCA(ca.Config{Clock: clk})
+	upstream := &fakeUpstreamAuthorityStatus{}
+	checks := &dependencyChecks{
+		trustDomainID: "spiffe://example.org",
+		dataStore:     ds,
+		ca:            serverCA,
+		upstream:      upstream,
+		clk:           clk,
+	}
+
+	t.Run("datastore", func(t *testing.T) {
+		_, err := checks.datastore()
+		assert.NoError(t, err)
+
+		ds.SetNextError(errors.New("oh no"))
+		_, err = checks.datastore()
+		assert.EqualError(t, err, "unable to query the datastore: oh no")
+	})
+
+	t.Run("ca", func(t *testing.T) {
+		_, err := checks.caStatus()
+		assert.EqualError(t, err, "no X509 CA is active")
+
+		caTemplate, err := util.NewCATemplate(clk, "spiffe://example.org")
+		require.NoError(t, err)
+		caCert, caKey, err := util.SelfSign(caTemplate)
+		require.NoError(t, err)
+		serverCA.SetX509CA(&ca.X509CA{Signer: caKey, Certificate: caCert})
+
+		_, err = checks.caStatus()
+		assert.EqualError(t, err, "no JWT key is active")
+
+		serverCA.SetJWTKey(&ca.JWTKey{Signer: caKey, Kid: "kid", NotAfter: caCert.NotAfter})
+		details, err := checks.caStatus()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"x509_ca_expires_at": caCert.NotAfter,
+			"jwt_key_expires_at": caCert.NotAfter,
+		}, details)
+
+		clk.Add(caCert.NotAfter.Sub(clk.Now()) + time.Second)
+		_, err = checks.caStatus()
+		assert.EqualError(t, err, "the active X509 CA has expired")
+	})
+
+	t.Run("upstream authority", func(t *testing.T) {
+		_, err := checks.upstreamAuthority()
+		assert.NoError(t, err)
+
+		upstream.err = errors.New("oh no")
+		_, err = checks.upstreamAuthority()
+		assert.EqualError(t, err, "unable to reach the upstream authority: oh no")
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -105,11 +106,37 @@ func (s *IdentityProvider) FetchX509Identity(ctx context.Context, req *hostservi
 		return nil, errs.Wrap(err)
 	}
 
+	var federatedBundles []*common.Bundle
+	if req.IncludeFederatedBundles {
+		federatedBundles, err = s.fetchFederatedBundles(ctx, deps.DataStore)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &hostservices.FetchX509IdentityResponse{
 		Identity: &hostservices.X509Identity{
 			CertChain:  certChain,
 			PrivateKey: privateKey,
 		},
-		Bundle: resp.Bundle,
+		Bundle:           resp.Bundle,
+		FederatedBundles: federatedBundles,
 	}, nil
 }
+
+// fetchFederatedBundles returns the bundles of every trust domain other
+// than the one of the server.
+func (s *IdentityProvider) fetchFederatedBundles(ctx context.Context, ds datastore.DataStore) ([]*common.Bundle, error) {
+	resp, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var federatedBundles []*common.Bundle
+	for _, bundle := range resp.Bundles {
+		if bundle.TrustDomainId != s.config.TrustDomainID {
+			federatedBundles = append(federatedBundles, bundle)
+		}
+	}
+	return federatedBundles, nil
+}
@@ -79,3 +79,45 @@ func TestFetchX509IdentitySuccess(t *testing.T) {
 	require.Equal(t, privateKeyBytes, resp.Identity.PrivateKey)
 	spiretest.RequireProtoEqual(t, bundle, resp.Bundle)
 }
+
+func TestFetchX509IdentityWithFederatedBundles(t *testing.T) {
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://domain.test",
+	}
+	federatedBundle := &common.Bundle{
+		TrustDomainId: "spiffe://otherdomain.test",
+	}
+
+	ds := fakedatastore.New(t)
+	for _, b := range []*common.Bundle{bundle, federatedBundle} {
+		_, err := ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+			Bundle: b,
+		})
+		require.NoError(t, err)
+	}
+
+	hs := New(Config{
+		TrustDomainID: "spiffe://domain.test",
+	})
+	err := hs.SetDeps(Deps{
+		DataStore: ds,
+		X509IdentityFetcher: X509IdentityFetcherFunc(func(context.Context) (*X509Identity, error) {
+			return &X509Identity{
+				PrivateKey: privateKey,
+			}, nil
+		}),
+	})
+	require.NoError(t, err)
+
+	// Federated bundles are only returned when requested
+	resp, err := hs.FetchX509Identity(context.Background(), &hostservices.FetchX509IdentityRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.FederatedBundles)
+
+	resp, err = hs.FetchX509Identity(context.Background(), &hostservices.FetchX509IdentityRequest{
+		IncludeFederatedBundles: true,
+	})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, bundle, resp.Bundle)
+	spiretest.RequireProtoListEqual(t, []*common.Bundle{federatedBundle}, resp.FederatedBundles)
+}
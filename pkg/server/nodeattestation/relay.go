@@ -0,0 +1,253 @@
+// Package nodeattestation relays the challenge/response exchange of node
+// attestation between an attesting agent and a node attestor plugin.
+//
+// The exchange happens over the Attest stream of the plugin:
+//
+//  1. The attestation data sent by the agent is forwarded to the plugin in
+//     the attestation_data field of the first AttestRequest.
+//  2. The plugin answers every request with an AttestResponse. A response
+//     with a challenge starts a new round: the challenge is relayed to the
+//     agent and the answer of the agent is forwarded to the plugin in the
+//     response field of the next AttestRequest.
+//  3. A response without a challenge completes the attestation. It must
+//     hold the agent ID and may hold selectors.
+//
+// Plugins may issue as many challenges as their protocol needs (e.g. a TPM
+// attestor activating a credential and then requesting a quote), within the
+// limits enforced here, so that plugins do not have to defend against
+// stalled or abusive agents themselves:
+//
+//   - every round, from the request to the plugin up to the answer of the
+//     agent, must complete within the round timeout;
+//   - the number of challenges is capped;
+//   - challenges and challenge responses are size bounded.
+package nodeattestation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultRoundTimeout is how long a round of the exchange may take when
+	// no timeout is configured.
+	DefaultRoundTimeout = 30 * time.Second
+
+	// DefaultMaxRounds is how many challenges an attestor may issue when no
+	// maximum is configured.
+	DefaultMaxRounds = 10
+
+	// DefaultMaxChallengeSize is the maximum size of a challenge, in bytes,
+	// when no maximum is configured.
+	DefaultMaxChallengeSize = 64 * 1024
+
+	// DefaultMaxResponseSize is the maximum size of a challenge response, in
+	// bytes, when no maximum is configured.
+	DefaultMaxResponseSize = 64 * 1024
+)
+
+// Limits bounds the challenge/response exchange. Zero values mean the
+// defaults.
+type Limits struct {
+	// RoundTimeout is how long a round may take, from the request sent to
+	// the plugin up to the challenge response received from the agent.
+	RoundTimeout time.Duration
+
+	// MaxRounds is the maximum number of challenges an attestor may issue
+	// during a single attestation.
+	MaxRounds int
+
+	// MaxChallengeSize is the maximum size of a challenge, in bytes.
+	MaxChallengeSize int
+
+	// MaxResponseSize is the maximum size of a challenge response, in bytes.
+	MaxResponseSize int
+}
+
+// Validate returns an error if the limits hold negative values
+func (l Limits) Validate() error {
+	switch {
+	case l.RoundTimeout < 0:
+		return errors.New("challenge_round_timeout must not be negative")
+	case l.MaxRounds < 0:
+		return errors.New("max_challenge_rounds must not be negative")
+	case l.MaxChallengeSize < 0:
+		return errors.New("max_challenge_size must not be negative")
+	case l.MaxResponseSize < 0:
+		return errors.New("max_challenge_response_size must not be negative")
+	}
+	return nil
+}
+
+func (l Limits) withDefaults() Limits {
+	if l.RoundTimeout == 0 {
+		l.RoundTimeout = DefaultRoundTimeout
+	}
+	if l.MaxRounds == 0 {
+		l.MaxRounds = DefaultMaxRounds
+	}
+	if l.MaxChallengeSize == 0 {
+		l.MaxChallengeSize = DefaultMaxChallengeSize
+	}
+	if l.MaxResponseSize == 0 {
+		l.MaxResponseSize = DefaultMaxResponseSize
+	}
+	return l
+}
+
+// Agent is the agent end of the exchange, usually backed by the attestation
+// stream of the agent.
+type Agent interface {
+	// SendChallenge sends a challenge issued by the attestor to the agent.
+	SendChallenge(challenge []byte) error
+
+	// RecvResponse receives the response of the agent to the last challenge.
+	RecvResponse() ([]byte, error)
+}
+
+// Error is returned when the exchange fails. Msg describes the step that
+// failed and Code is the gRPC status code to report to the agent.
+type Error struct {
+	Code codes.Code
+	Msg  string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Msg
+	}
+	return e.Msg + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns the status reported to the agent
+func (e *Error) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Error())
+}
+
+// Relay relays the challenge/response exchange between agents and node
+// attestor plugins.
+type Relay struct {
+	limits Limits
+	clk    clock.Clock
+}
+
+// NewRelay returns a relay enforcing the given limits
+func NewRelay(limits Limits, clk clock.Clock) *Relay {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &Relay{
+		limits: limits.withDefaults(),
+		clk:    clk,
+	}
+}
+
+// Attest attests the agent with the attestor, relaying challenges until the
+// attestor completes the attestation. Failures are returned as *Error.
+func (r *Relay) Attest(ctx context.Context, attestor nodeattestor.NodeAttestor, data *common.AttestationData, agent Agent) (*nodeattestor.AttestResponse, error) {
+	// The plugin stream is canceled on failure so the plugin is not left
+	// waiting on a request that will never come
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := attestor.Attest(ctx)
+	if err != nil {
+		return nil, &Error{Code: codes.Internal, Msg: "failed to open stream with attestor", Err: err}
+	}
+
+	req := &nodeattestor.AttestRequest{
+		AttestationData: data,
+	}
+	for round := 1; ; round++ {
+		resp, response, err := r.round(ctx, stream, agent, req, round)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Challenge != nil {
+			req = &nodeattestor.AttestRequest{
+				Response: response,
+			}
+			continue
+		}
+
+		if resp.AgentId == "" {
+			return nil, &Error{Code: codes.Internal, Msg: "failed to attest", Err: errors.New("AgentID response should not be empty")}
+		}
+		if err := stream.CloseSend(); err != nil {
+			return nil, &Error{Code: codes.Internal, Msg: "failed to close send stream", Err: err}
+		}
+		return resp, nil
+	}
+}
+
+// round sends the request to the attestor and, if the attestor answers with
+// a challenge, relays it to the agent and returns the response of the agent.
+func (r *Relay) round(ctx context.Context, stream nodeattestor.NodeAttestor_AttestClient, agent Agent, req *nodeattestor.AttestRequest, round int) (*nodeattestor.AttestResponse, []byte, error) {
+	type result struct {
+		resp     *nodeattestor.AttestResponse
+		response []byte
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, response, err := r.exchange(stream, agent, req, round)
+		done <- result{resp: resp, response: response, err: err}
+	}()
+
+	timer := r.clk.Timer(r.limits.RoundTimeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-done:
+		return res.resp, res.response, res.err
+	case <-timer.C:
+		return nil, nil, &Error{Code: codes.DeadlineExceeded, Msg: "failed to attest", Err: fmt.Errorf("challenge round %d did not complete within %s", round, r.limits.RoundTimeout)}
+	case <-ctx.Done():
+		return nil, nil, &Error{Code: codes.Canceled, Msg: "failed to attest", Err: ctx.Err()}
+	}
+}
+
+func (r *Relay) exchange(stream nodeattestor.NodeAttestor_AttestClient, agent Agent, req *nodeattestor.AttestRequest, round int) (*nodeattestor.AttestResponse, []byte, error) {
+	if err := stream.Send(req); err != nil {
+		return nil, nil, &Error{Code: codes.Internal, Msg: "failed to attest", Err: err}
+	}
+	resp, err := stream.Recv()
+	switch {
+	case err == io.EOF:
+		return nil, nil, &Error{Code: codes.Internal, Msg: "failed to attest", Err: errors.New("attestor closed the stream without a response")}
+	case err != nil:
+		return nil, nil, &Error{Code: codes.Internal, Msg: "failed to attest", Err: err}
+	case resp.Challenge == nil:
+		return resp, nil, nil
+	case round > r.limits.MaxRounds:
+		return nil, nil, &Error{Code: codes.ResourceExhausted, Msg: "failed to attest", Err: fmt.Errorf("attestor exceeded the maximum of %d challenge rounds", r.limits.MaxRounds)}
+	case len(resp.Challenge) > r.limits.MaxChallengeSize:
+		return nil, nil, &Error{Code: codes.Internal, Msg: "failed to attest", Err: fmt.Errorf("challenge of %d bytes exceeds the maximum of %d bytes", len(resp.Challenge), r.limits.MaxChallengeSize)}
+	}
+
+	if err := agent.SendChallenge(resp.Challenge); err != nil {
+		return nil, nil, &Error{Code: codes.Internal, Msg: "failed to send challenge to agent", Err: err}
+	}
+	response, err := agent.RecvResponse()
+	if err != nil {
+		return nil, nil, &Error{Code: codes.Internal, Msg: "failed to receive challenge response from agent", Err: err}
+	}
+	if len(response) > r.limits.MaxResponseSize {
+		return nil, nil, &Error{Code: codes.InvalidArgument, Msg: "failed to attest", Err: fmt.Errorf("challenge response of %d bytes exceeds the maximum of %d bytes", len(response), r.limits.MaxResponseSize)}
+	}
+	return resp, response, nil
+}
@@ -0,0 +1,207 @@
+package nodeattestation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakeservernodeattestor"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRelayAttest(t *testing.T) {
+	attestor := prepareAttestor(t, fakeservernodeattestor.Config{
+		Data: map[string]string{
+			"no_challenge":        "id_no_challenge",
+			"one_challenge":       "id_one_challenge",
+			"three_challenges":    "id_three_challenges",
+			"oversized_challenge": "id_oversized_challenge",
+		},
+		Challenges: map[string][]string{
+			"id_one_challenge":       {"one"},
+			"id_three_challenges":    {"one", "two", "three"},
+			"id_oversized_challenge": {strings.Repeat("x", DefaultMaxChallengeSize+1)},
+		},
+		Selectors: map[string][]string{
+			"id_three_challenges": {"selector"},
+		},
+	})
+
+	for _, tt := range []struct {
+		name               string
+		data               string
+		limits             Limits
+		agent              *fakeAgent
+		expectCode         codes.Code
+		expectMsg          string
+		expectAgentID      string
+		expectSelectors    []*common.Selector
+		expectedChallenges []string
+	}{
+		{
+			name:          "without challenge",
+			data:          "no_challenge",
+			expectAgentID: "spiffe://example.org/spire/agent/test/id_no_challenge",
+		},
+		{
+			name:               "single round",
+			data:               "one_challenge",
+			expectAgentID:      "spiffe://example.org/spire/agent/test/id_one_challenge",
+			expectedChallenges: []string{"one"},
+		},
+		{
+			name:               "multiple rounds",
+			data:               "three_challenges",
+			expectAgentID:      "spiffe://example.org/spire/agent/test/id_three_challenges",
+			expectSelectors:    []*common.Selector{{Type: "test", Value: "selector"}},
+			expectedChallenges: []string{"one", "two", "three"},
+		},
+		{
+			name:               "too many rounds",
+			data:               "three_challenges",
+			limits:             Limits{MaxRounds: 2},
+			expectCode:         codes.ResourceExhausted,
+			expectMsg:          "failed to attest: attestor exceeded the maximum of 2 challenge rounds",
+			expectedChallenges: []string{"one", "two"},
+		},
+		{
+			name:       "challenge too large",
+			data:       "oversized_challenge",
+			expectCode: codes.Internal,
+			expectMsg:  "failed to attest: challenge of 65537 bytes exceeds the maximum of 65536 bytes",
+		},
+		{
+			name:   "challenge response too large",
+			data:   "one_challenge",
+			limits: Limits{MaxResponseSize: 2},
+			agent: &fakeAgent{
+				respond: func(challenge []byte) ([]byte, error) {
+					return []byte("too large"), nil
+				},
+			},
+			expectCode:         codes.InvalidArgument,
+			expectMsg:          "failed to attest: challenge response of 9 bytes exceeds the maximum of 2 bytes",
+			expectedChallenges: []string{"one"},
+		},
+		{
+			name: "agent fails to respond",
+			data: "one_challenge",
+			agent: &fakeAgent{
+				respond: func(challenge []byte) ([]byte, error) {
+					return nil, errors.New("oh no")
+				},
+			},
+			expectCode:         codes.Internal,
+			expectMsg:          "failed to receive challenge response from agent: oh no",
+			expectedChallenges: []string{"one"},
+		},
+		{
+			name:   "round timeout",
+			data:   "one_challenge",
+			limits: Limits{RoundTimeout: 10 * time.Millisecond},
+			agent: &fakeAgent{
+				respond: func(challenge []byte) ([]byte, error) {
+					time.Sleep(time.Second)
+					return challenge, nil
+				},
+			},
+			expectCode:         codes.DeadlineExceeded,
+			expectMsg:          "failed to attest: challenge round 1 did not complete within 10ms",
+			expectedChallenges: []string{"one"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			agent := tt.agent
+			if agent == nil {
+				agent = &fakeAgent{}
+			}
+
+			resp, err := NewRelay(tt.limits, nil).Attest(context.Background(), attestor, &common.AttestationData{
+				Type: "test",
+				Data: []byte(tt.data),
+			}, agent)
+			assert.Equal(t, tt.expectedChallenges, agent.challenges())
+			if tt.expectMsg != "" {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				var relayErr *Error
+				require.True(t, errors.As(err, &relayErr))
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectAgentID, resp.AgentId)
+			spiretest.AssertProtoListEqual(t, tt.expectSelectors, resp.Selectors)
+		})
+	}
+}
+
+func TestRelayAttestFailsOnAttestorError(t *testing.T) {
+	attestor := prepareAttestor(t, fakeservernodeattestor.Config{})
+
+	_, err := NewRelay(Limits{}, nil).Attest(context.Background(), attestor, &common.AttestationData{
+		Type: "test",
+		Data: []byte("unknown"),
+	}, &fakeAgent{})
+	spiretest.RequireGRPCStatusContains(t, err, codes.Internal, `failed to attest: `)
+	spiretest.RequireGRPCStatusContains(t, err, codes.Internal, `no ID configured for attestation data "unknown"`)
+}
+
+func TestLimitsValidate(t *testing.T) {
+	require.NoError(t, Limits{}.Validate())
+	require.NoError(t, Limits{RoundTimeout: time.Minute, MaxRounds: 3, MaxChallengeSize: 1, MaxResponseSize: 1}.Validate())
+	require.EqualError(t, Limits{RoundTimeout: -time.Second}.Validate(), "challenge_round_timeout must not be negative")
+	require.EqualError(t, Limits{MaxRounds: -1}.Validate(), "max_challenge_rounds must not be negative")
+	require.EqualError(t, Limits{MaxChallengeSize: -1}.Validate(), "max_challenge_size must not be negative")
+	require.EqualError(t, Limits{MaxResponseSize: -1}.Validate(), "max_challenge_response_size must not be negative")
+}
+
+func prepareAttestor(t *testing.T, config fakeservernodeattestor.Config) nodeattestor.NodeAttestor {
+	var attestor nodeattestor.NodeAttestor
+	spiretest.LoadPlugin(t, catalog.MakePlugin("test",
+		nodeattestor.PluginServer(fakeservernodeattestor.New("test", config)),
+	), &attestor)
+	return attestor
+}
+
+// fakeAgent echoes challenges back unless a respond function is set
+type fakeAgent struct {
+	respond func(challenge []byte) ([]byte, error)
+
+	mu   sync.Mutex
+	seen []string
+	last []byte
+}
+
+func (a *fakeAgent) SendChallenge(challenge []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.seen = append(a.seen, string(challenge))
+	a.last = challenge
+	return nil
+}
+
+func (a *fakeAgent) RecvResponse() ([]byte, error) {
+	a.mu.Lock()
+	challenge := a.last
+	a.mu.Unlock()
+	if a.respond != nil {
+		return a.respond(challenge)
+	}
+	return challenge, nil
+}
+
+func (a *fakeAgent) challenges() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.seen
+}
@@ -0,0 +1,149 @@
+package noderesolution
+
+import (
+	"context"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+const (
+	// DefaultRefreshInterval is how often the selectors of the agents are
+	// resolved again by the general node resolvers when no interval is
+	// configured.
+	DefaultRefreshInterval = 10 * time.Minute
+
+	listAttestedNodesPageSize = 500
+)
+
+// ManagerConfig is the configuration of the manager.
+type ManagerConfig struct {
+	Catalog catalog.Catalog
+	Log     logrus.FieldLogger
+	Clock   clock.Clock
+
+	// RefreshInterval is how often the agent selectors are resolved again.
+	// Defaults to DefaultRefreshInterval.
+	RefreshInterval time.Duration
+}
+
+// Manager periodically resolves the selectors of every attested agent
+// through the general node resolvers, so that selectors sourced from
+// external systems (e.g. inventory APIs) stay up to date after attestation.
+// The selectors stored for an agent act as the cache of the resolved
+// selectors; resolvers are only called at attestation and on refresh.
+type Manager struct {
+	c ManagerConfig
+}
+
+// NewManager creates a new manager.
+func NewManager(c ManagerConfig) *Manager {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = DefaultRefreshInterval
+	}
+	return &Manager{c: c}
+}
+
+// Run refreshes the agent selectors every refresh interval until the context
+// is canceled. It returns immediately when there are no general node
+// resolvers.
+func (m *Manager) Run(ctx context.Context) error {
+	if len(GeneralResolverNames(m.c.Catalog)) == 0 {
+		return nil
+	}
+
+	ticker := m.c.Clock.Ticker(m.c.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// Log an error on failure unless we're shutting down
+			if err := m.refresh(ctx); err != nil && ctx.Err() == nil {
+				m.c.Log.WithError(err).Error("Failed to refresh agent selectors")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// refresh resolves the selectors of every attested agent again. The
+// selectors whose type is the name of a general resolver are replaced with
+// the resolved ones; the rest, which come from attestation or from the
+// resolver of the attestor, are kept. Agents whose selectors cannot be
+// resolved keep their current selectors.
+func (m *Manager) refresh(ctx context.Context) error {
+	names := GeneralResolverNames(m.c.Catalog)
+	resolvedTypes := make(map[string]bool, len(names))
+	for _, name := range names {
+		resolvedTypes[name] = true
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	req := &datastore.ListAttestedNodesRequest{
+		FetchSelectors: true,
+		Pagination: &datastore.Pagination{
+			PageSize: listAttestedNodesPageSize,
+		},
+	}
+	for {
+		resp, err := ds.ListAttestedNodes(ctx, req)
+		if err != nil {
+			return err
+		}
+		if len(resp.Nodes) == 0 {
+			return nil
+		}
+
+		for _, node := range resp.Nodes {
+			if err := m.refreshNode(ctx, ds, node, resolvedTypes); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				m.c.Log.WithError(err).WithField(telemetry.AgentID, node.SpiffeId).Warn("Failed to refresh agent selectors")
+			}
+		}
+
+		if resp.Pagination == nil || resp.Pagination.Token == "" {
+			return nil
+		}
+		req.Pagination.Token = resp.Pagination.Token
+	}
+}
+
+func (m *Manager) refreshNode(ctx context.Context, ds datastore.DataStore, node *common.AttestedNode, resolvedTypes map[string]bool) error {
+	resolved, err := ResolveGeneral(ctx, m.c.Log, m.c.Catalog, node.SpiffeId)
+	if err != nil {
+		return err
+	}
+
+	var selectors []*common.Selector
+	for _, s := range node.Selectors {
+		if !resolvedTypes[s.Type] {
+			selectors = append(selectors, s)
+		}
+	}
+	selectors = append(selectors, resolved...)
+
+	if selector.NewSetFromRaw(node.Selectors).Equal(selector.NewSetFromRaw(selectors)) {
+		return nil
+	}
+
+	_, err = ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  node.SpiffeId,
+			Selectors: selectors,
+		},
+	})
+	return err
+}
@@ -0,0 +1,138 @@
+package noderesolution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+const otherAgentID = "spiffe://example.org/spire/agent/test/other"
+
+func TestManagerRunWithoutGeneralResolvers(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	cat := fakeservercatalog.New()
+	cat.AddNodeAttestorNamed("test", nil)
+	cat.AddNodeResolverNamed("test", fakeResolver{})
+
+	// Run returns right away since there is nothing to refresh
+	m := NewManager(ManagerConfig{Catalog: cat, Log: log})
+	require.NoError(t, m.Run(context.Background()))
+}
+
+func TestManagerRefresh(t *testing.T) {
+	ctx := context.Background()
+	log, hook := test.NewNullLogger()
+	ds := fakedatastore.New(t)
+	cat := fakeservercatalog.New()
+	cat.SetDataStore(ds)
+	cat.AddNodeAttestorNamed("test", nil)
+
+	cmdb := fakeResolver{
+		agentID: {{Type: "cmdb", Value: "rack:12"}},
+	}
+	cat.AddNodeResolverNamed("test", fakeResolver{})
+	cat.AddNodeResolverNamed("cmdb", cmdb)
+	cat.AddNodeResolverNamed("inventory", failingResolverFor(otherAgentID, fakeResolver{
+		agentID: {{Type: "inventory", Value: "owner:team"}},
+	}))
+
+	createNode(t, ds, agentID, []*common.Selector{
+		{Type: "test", Value: "attested"},
+		{Type: "cmdb", Value: "rack:1"},
+	})
+	createNode(t, ds, otherAgentID, []*common.Selector{
+		{Type: "test", Value: "attested"},
+		{Type: "cmdb", Value: "rack:1"},
+	})
+
+	m := NewManager(ManagerConfig{Catalog: cat, Log: log})
+	require.NoError(t, m.refresh(ctx))
+
+	// Selectors of the general resolvers are replaced, while the other
+	// selectors are kept
+	requireNodeSelectors(t, ds, agentID, []*common.Selector{
+		{Type: "cmdb", Value: "rack:12"},
+		{Type: "inventory", Value: "owner:team"},
+		{Type: "test", Value: "attested"},
+	})
+
+	// Agents whose selectors cannot be resolved keep their selectors
+	requireNodeSelectors(t, ds, otherAgentID, []*common.Selector{
+		{Type: "cmdb", Value: "rack:1"},
+		{Type: "test", Value: "attested"},
+	})
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Failed to refresh agent selectors",
+			Data: logrus.Fields{
+				telemetry.AgentID: otherAgentID,
+				logrus.ErrorKey:   `node resolver "inventory" failed: oh no`,
+			},
+		},
+	})
+
+	// Selectors no longer resolved are removed
+	delete(cmdb, agentID)
+	require.NoError(t, m.refresh(ctx))
+	requireNodeSelectors(t, ds, agentID, []*common.Selector{
+		{Type: "inventory", Value: "owner:team"},
+		{Type: "test", Value: "attested"},
+	})
+}
+
+func createNode(t *testing.T, ds datastore.DataStore, id string, selectors []*common.Selector) {
+	_, err := ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            id,
+			AttestationDataType: "test",
+			CertSerialNumber:    "1234",
+		},
+	})
+	require.NoError(t, err)
+	_, err = ds.SetNodeSelectors(context.Background(), &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  id,
+			Selectors: selectors,
+		},
+	})
+	require.NoError(t, err)
+}
+
+func requireNodeSelectors(t *testing.T, ds datastore.DataStore, id string, expected []*common.Selector) {
+	resp, err := ds.GetNodeSelectors(context.Background(), &datastore.GetNodeSelectorsRequest{
+		SpiffeId: id,
+	})
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, expected, resp.Selectors.Selectors)
+}
+
+// failingResolverFor fails to resolve the given agent and delegates the
+// resolution of the other agents
+func failingResolverFor(id string, resolver fakeResolver) failingFor {
+	return failingFor{id: id, fakeResolver: resolver}
+}
+
+type failingFor struct {
+	fakeResolver
+	id string
+}
+
+func (r failingFor) Resolve(ctx context.Context, req *noderesolver.ResolveRequest) (*noderesolver.ResolveResponse, error) {
+	for _, id := range req.BaseSpiffeIdList {
+		if id == r.id {
+			return failingResolver{}.Resolve(ctx, req)
+		}
+	}
+	return r.fakeResolver.Resolve(ctx, req)
+}
@@ -0,0 +1,87 @@
+package noderesolution
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Resolve returns the selectors resolved for an agent by the node resolvers
+// that apply to its attestation type: the resolver named after the
+// attestation type, if any, and every general resolver.
+func Resolve(ctx context.Context, log logrus.FieldLogger, cat catalog.Catalog, agentID, attestationType string) ([]*common.Selector, error) {
+	var selectors []*common.Selector
+	if nodeResolver, ok := cat.GetNodeResolverNamed(attestationType); ok {
+		resolved, err := resolve(ctx, nodeResolver, agentID)
+		if err != nil {
+			return nil, fmt.Errorf("node resolver %q failed: %w", attestationType, err)
+		}
+		selectors = append(selectors, resolved...)
+	} else {
+		log.Debug("Could not find node resolver")
+	}
+
+	resolved, err := ResolveGeneral(ctx, log, cat, agentID)
+	if err != nil {
+		return nil, err
+	}
+	return append(selectors, resolved...), nil
+}
+
+// ResolveGeneral returns the selectors resolved for an agent by the general
+// node resolvers, i.e. the resolvers that are not named after a node
+// attestor. A general resolver can only resolve selectors whose type is the
+// resolver name; other selectors are dropped.
+func ResolveGeneral(ctx context.Context, log logrus.FieldLogger, cat catalog.Catalog, agentID string) ([]*common.Selector, error) {
+	var selectors []*common.Selector
+	for _, name := range GeneralResolverNames(cat) {
+		resolved, err := resolve(ctx, cat.GetNodeResolvers()[name], agentID)
+		if err != nil {
+			return nil, fmt.Errorf("node resolver %q failed: %w", name, err)
+		}
+		for _, selector := range resolved {
+			if selector.Type != name {
+				log.WithFields(logrus.Fields{
+					telemetry.NodeResolver: name,
+					telemetry.Selector:     fmt.Sprintf("%s:%s", selector.Type, selector.Value),
+				}).Warn("Dropping selector resolved with a type other than the node resolver name")
+				continue
+			}
+			selectors = append(selectors, selector)
+		}
+	}
+	return selectors, nil
+}
+
+// GeneralResolverNames returns the sorted names of the node resolvers that
+// are not named after a node attestor. These resolvers apply to every agent.
+func GeneralResolverNames(cat catalog.Catalog) []string {
+	var names []string
+	for name := range cat.GetNodeResolvers() {
+		if _, ok := cat.GetNodeAttestorNamed(name); ok {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func resolve(ctx context.Context, nodeResolver noderesolver.NodeResolver, agentID string) ([]*common.Selector, error) {
+	resp, err := nodeResolver.Resolve(ctx, &noderesolver.ResolveRequest{
+		BaseSpiffeIdList: []string{agentID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resolved := resp.Map[agentID]; resolved != nil {
+		return resolved.Entries, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,118 @@
+package noderesolution
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+const agentID = "spiffe://example.org/spire/agent/test/id"
+
+func TestResolve(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	cat := fakeservercatalog.New()
+	cat.AddNodeAttestorNamed("test", nil)
+	cat.AddNodeAttestorNamed("other", nil)
+	cat.AddNodeResolverNamed("test", fakeResolver{
+		agentID: {{Type: "test", Value: "test-value"}},
+	})
+	cat.AddNodeResolverNamed("other", fakeResolver{
+		agentID: {{Type: "other", Value: "other-value"}},
+	})
+	cat.AddNodeResolverNamed("cmdb", fakeResolver{
+		agentID: {
+			{Type: "cmdb", Value: "rack:12"},
+			{Type: "test", Value: "spoofed"},
+		},
+	})
+	cat.AddNodeResolverNamed("inventory", fakeResolver{
+		agentID: {{Type: "inventory", Value: "owner:team"}},
+	})
+
+	// The resolver of the attestation type and the general resolvers are
+	// used, while the resolvers of other attestors are not
+	selectors, err := Resolve(context.Background(), log, cat, agentID, "test")
+	require.NoError(t, err)
+	require.Equal(t, []*common.Selector{
+		{Type: "test", Value: "test-value"},
+		{Type: "cmdb", Value: "rack:12"},
+		{Type: "inventory", Value: "owner:team"},
+	}, selectors)
+
+	// General resolvers cannot resolve selectors of other types
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Dropping selector resolved with a type other than the node resolver name",
+			Data: logrus.Fields{
+				telemetry.NodeResolver: "cmdb",
+				telemetry.Selector:     "test:spoofed",
+			},
+		},
+	})
+}
+
+func TestResolveWithoutAttestorResolver(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.DebugLevel)
+	cat := fakeservercatalog.New()
+	cat.AddNodeResolverNamed("cmdb", fakeResolver{
+		agentID: {{Type: "cmdb", Value: "rack:12"}},
+	})
+
+	selectors, err := Resolve(context.Background(), log, cat, agentID, "test")
+	require.NoError(t, err)
+	require.Equal(t, []*common.Selector{
+		{Type: "cmdb", Value: "rack:12"},
+	}, selectors)
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.DebugLevel,
+			Message: "Could not find node resolver",
+		},
+	})
+}
+
+func TestResolveFailure(t *testing.T) {
+	log, _ := test.NewNullLogger()
+
+	cat := fakeservercatalog.New()
+	cat.AddNodeResolverNamed("test", failingResolver{})
+	_, err := Resolve(context.Background(), log, cat, agentID, "test")
+	require.EqualError(t, err, `node resolver "test" failed: oh no`)
+
+	cat = fakeservercatalog.New()
+	cat.AddNodeResolverNamed("cmdb", failingResolver{})
+	_, err = Resolve(context.Background(), log, cat, agentID, "test")
+	require.EqualError(t, err, `node resolver "cmdb" failed: oh no`)
+}
+
+// fakeResolver resolves the selectors of the agents it holds
+type fakeResolver map[string][]*common.Selector
+
+func (r fakeResolver) Resolve(ctx context.Context, req *noderesolver.ResolveRequest) (*noderesolver.ResolveResponse, error) {
+	resp := &noderesolver.ResolveResponse{
+		Map: make(map[string]*common.Selectors),
+	}
+	for _, id := range req.BaseSpiffeIdList {
+		if selectors, ok := r[id]; ok {
+			resp.Map[id] = &common.Selectors{Entries: selectors}
+		}
+	}
+	return resp, nil
+}
+
+type failingResolver struct{}
+
+func (failingResolver) Resolve(context.Context, *noderesolver.ResolveRequest) (*noderesolver.ResolveResponse, error) {
+	return nil, errors.New("oh no")
+}
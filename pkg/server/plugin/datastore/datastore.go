@@ -12,73 +12,101 @@ import (
 	"google.golang.org/grpc"
 )
 
-type AppendBundleRequest = datastore.AppendBundleRequest                           //nolint: golint
-type AppendBundleResponse = datastore.AppendBundleResponse                         //nolint: golint
-type BySelectors = datastore.BySelectors                                           //nolint: golint
-type BySelectors_MatchBehavior = datastore.BySelectors_MatchBehavior               //nolint: golint
-type CountAttestedNodesRequest = datastore.CountAttestedNodesRequest               //nolint: golint
-type CountAttestedNodesResponse = datastore.CountAttestedNodesResponse             //nolint: golint
-type CountBundlesRequest = datastore.CountBundlesRequest                           //nolint: golint
-type CountBundlesResponse = datastore.CountBundlesResponse                         //nolint: golint
-type CountRegistrationEntriesRequest = datastore.CountRegistrationEntriesRequest   //nolint: golint
-type CountRegistrationEntriesResponse = datastore.CountRegistrationEntriesResponse //nolint: golint
-type CreateAttestedNodeRequest = datastore.CreateAttestedNodeRequest               //nolint: golint
-type CreateAttestedNodeResponse = datastore.CreateAttestedNodeResponse             //nolint: golint
-type CreateBundleRequest = datastore.CreateBundleRequest                           //nolint: golint
-type CreateBundleResponse = datastore.CreateBundleResponse                         //nolint: golint
-type CreateJoinTokenRequest = datastore.CreateJoinTokenRequest                     //nolint: golint
-type CreateJoinTokenResponse = datastore.CreateJoinTokenResponse                   //nolint: golint
-type CreateRegistrationEntryRequest = datastore.CreateRegistrationEntryRequest     //nolint: golint
-type CreateRegistrationEntryResponse = datastore.CreateRegistrationEntryResponse   //nolint: golint
-type DataStoreClient = datastore.DataStoreClient                                   //nolint: golint
-type DataStoreServer = datastore.DataStoreServer                                   //nolint: golint
-type DeleteAttestedNodeRequest = datastore.DeleteAttestedNodeRequest               //nolint: golint
-type DeleteAttestedNodeResponse = datastore.DeleteAttestedNodeResponse             //nolint: golint
-type DeleteBundleRequest = datastore.DeleteBundleRequest                           //nolint: golint
-type DeleteBundleRequest_Mode = datastore.DeleteBundleRequest_Mode                 //nolint: golint
-type DeleteBundleResponse = datastore.DeleteBundleResponse                         //nolint: golint
-type DeleteJoinTokenRequest = datastore.DeleteJoinTokenRequest                     //nolint: golint
-type DeleteJoinTokenResponse = datastore.DeleteJoinTokenResponse                   //nolint: golint
-type DeleteRegistrationEntryRequest = datastore.DeleteRegistrationEntryRequest     //nolint: golint
-type DeleteRegistrationEntryResponse = datastore.DeleteRegistrationEntryResponse   //nolint: golint
-type FetchAttestedNodeRequest = datastore.FetchAttestedNodeRequest                 //nolint: golint
-type FetchAttestedNodeResponse = datastore.FetchAttestedNodeResponse               //nolint: golint
-type FetchBundleRequest = datastore.FetchBundleRequest                             //nolint: golint
-type FetchBundleResponse = datastore.FetchBundleResponse                           //nolint: golint
-type FetchJoinTokenRequest = datastore.FetchJoinTokenRequest                       //nolint: golint
-type FetchJoinTokenResponse = datastore.FetchJoinTokenResponse                     //nolint: golint
-type FetchRegistrationEntryRequest = datastore.FetchRegistrationEntryRequest       //nolint: golint
-type FetchRegistrationEntryResponse = datastore.FetchRegistrationEntryResponse     //nolint: golint
-type GetNodeSelectorsRequest = datastore.GetNodeSelectorsRequest                   //nolint: golint
-type GetNodeSelectorsResponse = datastore.GetNodeSelectorsResponse                 //nolint: golint
-type JoinToken = datastore.JoinToken                                               //nolint: golint
-type ListAttestedNodesRequest = datastore.ListAttestedNodesRequest                 //nolint: golint
-type ListAttestedNodesResponse = datastore.ListAttestedNodesResponse               //nolint: golint
-type ListBundlesRequest = datastore.ListBundlesRequest                             //nolint: golint
-type ListBundlesResponse = datastore.ListBundlesResponse                           //nolint: golint
-type ListNodeSelectorsRequest = datastore.ListNodeSelectorsRequest                 //nolint: golint
-type ListNodeSelectorsResponse = datastore.ListNodeSelectorsResponse               //nolint: golint
-type ListRegistrationEntriesRequest = datastore.ListRegistrationEntriesRequest     //nolint: golint
-type ListRegistrationEntriesResponse = datastore.ListRegistrationEntriesResponse   //nolint: golint
-type NodeSelectors = datastore.NodeSelectors                                       //nolint: golint
-type Pagination = datastore.Pagination                                             //nolint: golint
-type PruneBundleRequest = datastore.PruneBundleRequest                             //nolint: golint
-type PruneBundleResponse = datastore.PruneBundleResponse                           //nolint: golint
-type PruneJoinTokensRequest = datastore.PruneJoinTokensRequest                     //nolint: golint
-type PruneJoinTokensResponse = datastore.PruneJoinTokensResponse                   //nolint: golint
-type PruneRegistrationEntriesRequest = datastore.PruneRegistrationEntriesRequest   //nolint: golint
-type PruneRegistrationEntriesResponse = datastore.PruneRegistrationEntriesResponse //nolint: golint
-type SetBundleRequest = datastore.SetBundleRequest                                 //nolint: golint
-type SetBundleResponse = datastore.SetBundleResponse                               //nolint: golint
-type SetNodeSelectorsRequest = datastore.SetNodeSelectorsRequest                   //nolint: golint
-type SetNodeSelectorsResponse = datastore.SetNodeSelectorsResponse                 //nolint: golint
-type UnimplementedDataStoreServer = datastore.UnimplementedDataStoreServer         //nolint: golint
-type UpdateAttestedNodeRequest = datastore.UpdateAttestedNodeRequest               //nolint: golint
-type UpdateAttestedNodeResponse = datastore.UpdateAttestedNodeResponse             //nolint: golint
-type UpdateBundleRequest = datastore.UpdateBundleRequest                           //nolint: golint
-type UpdateBundleResponse = datastore.UpdateBundleResponse                         //nolint: golint
-type UpdateRegistrationEntryRequest = datastore.UpdateRegistrationEntryRequest     //nolint: golint
-type UpdateRegistrationEntryResponse = datastore.UpdateRegistrationEntryResponse   //nolint: golint
+type AppendBundleRequest = datastore.AppendBundleRequest                                   //nolint: golint
+type AppendBundleResponse = datastore.AppendBundleResponse                                 //nolint: golint
+type BackupRequest = datastore.BackupRequest                                               //nolint: golint
+type BackupResponse = datastore.BackupResponse                                             //nolint: golint
+type BySelectors = datastore.BySelectors                                                   //nolint: golint
+type BySelectors_MatchBehavior = datastore.BySelectors_MatchBehavior                       //nolint: golint
+type CountAttestedNodesRequest = datastore.CountAttestedNodesRequest                       //nolint: golint
+type CountAttestedNodesResponse = datastore.CountAttestedNodesResponse                     //nolint: golint
+type CountBundlesRequest = datastore.CountBundlesRequest                                   //nolint: golint
+type CountBundlesResponse = datastore.CountBundlesResponse                                 //nolint: golint
+type CountRegistrationEntriesRequest = datastore.CountRegistrationEntriesRequest           //nolint: golint
+type CountRegistrationEntriesResponse = datastore.CountRegistrationEntriesResponse         //nolint: golint
+type CreateAttestedNodeRequest = datastore.CreateAttestedNodeRequest                       //nolint: golint
+type CreateAttestedNodeResponse = datastore.CreateAttestedNodeResponse                     //nolint: golint
+type CreateBundleRequest = datastore.CreateBundleRequest                                   //nolint: golint
+type CreateBundleResponse = datastore.CreateBundleResponse                                 //nolint: golint
+type CreateEntryTemplateRequest = datastore.CreateEntryTemplateRequest                     //nolint: golint
+type CreateEntryTemplateResponse = datastore.CreateEntryTemplateResponse                   //nolint: golint
+type CreateFederationRelationshipRequest = datastore.CreateFederationRelationshipRequest   //nolint: golint
+type CreateFederationRelationshipResponse = datastore.CreateFederationRelationshipResponse //nolint: golint
+type CreateJoinTokenRequest = datastore.CreateJoinTokenRequest                             //nolint: golint
+type CreateJoinTokenResponse = datastore.CreateJoinTokenResponse                           //nolint: golint
+type CreateRegistrationEntryRequest = datastore.CreateRegistrationEntryRequest             //nolint: golint
+type CreateRegistrationEntryResponse = datastore.CreateRegistrationEntryResponse           //nolint: golint
+type DataStoreClient = datastore.DataStoreClient                                           //nolint: golint
+type DataStoreServer = datastore.DataStoreServer                                           //nolint: golint
+type DeleteAttestedNodeRequest = datastore.DeleteAttestedNodeRequest                       //nolint: golint
+type DeleteAttestedNodeResponse = datastore.DeleteAttestedNodeResponse                     //nolint: golint
+type DeleteBundleRequest = datastore.DeleteBundleRequest                                   //nolint: golint
+type DeleteBundleRequest_Mode = datastore.DeleteBundleRequest_Mode                         //nolint: golint
+type DeleteBundleResponse = datastore.DeleteBundleResponse                                 //nolint: golint
+type DeleteEntryTemplateRequest = datastore.DeleteEntryTemplateRequest                     //nolint: golint
+type DeleteEntryTemplateResponse = datastore.DeleteEntryTemplateResponse                   //nolint: golint
+type DeleteFederationRelationshipRequest = datastore.DeleteFederationRelationshipRequest   //nolint: golint
+type DeleteFederationRelationshipResponse = datastore.DeleteFederationRelationshipResponse //nolint: golint
+type DeleteJoinTokenRequest = datastore.DeleteJoinTokenRequest                             //nolint: golint
+type DeleteJoinTokenResponse = datastore.DeleteJoinTokenResponse                           //nolint: golint
+type DeleteRegistrationEntryRequest = datastore.DeleteRegistrationEntryRequest             //nolint: golint
+type DeleteRegistrationEntryResponse = datastore.DeleteRegistrationEntryResponse           //nolint: golint
+type EntryTemplate = datastore.EntryTemplate                                               //nolint: golint
+type EntryTemplateMask = datastore.EntryTemplateMask                                       //nolint: golint
+type FederationRelationship = datastore.FederationRelationship                             //nolint: golint
+type FederationRelationshipMask = datastore.FederationRelationshipMask                     //nolint: golint
+type FetchAttestedNodeRequest = datastore.FetchAttestedNodeRequest                         //nolint: golint
+type FetchAttestedNodeResponse = datastore.FetchAttestedNodeResponse                       //nolint: golint
+type FetchBundleRequest = datastore.FetchBundleRequest                                     //nolint: golint
+type FetchBundleResponse = datastore.FetchBundleResponse                                   //nolint: golint
+type FetchEntryTemplateRequest = datastore.FetchEntryTemplateRequest                       //nolint: golint
+type FetchEntryTemplateResponse = datastore.FetchEntryTemplateResponse                     //nolint: golint
+type FetchFederationRelationshipRequest = datastore.FetchFederationRelationshipRequest     //nolint: golint
+type FetchFederationRelationshipResponse = datastore.FetchFederationRelationshipResponse   //nolint: golint
+type FetchJoinTokenRequest = datastore.FetchJoinTokenRequest                               //nolint: golint
+type FetchJoinTokenResponse = datastore.FetchJoinTokenResponse                             //nolint: golint
+type FetchRegistrationEntryRequest = datastore.FetchRegistrationEntryRequest               //nolint: golint
+type FetchRegistrationEntryResponse = datastore.FetchRegistrationEntryResponse             //nolint: golint
+type GetNodeSelectorsRequest = datastore.GetNodeSelectorsRequest                           //nolint: golint
+type GetNodeSelectorsResponse = datastore.GetNodeSelectorsResponse                         //nolint: golint
+type JoinToken = datastore.JoinToken                                                       //nolint: golint
+type ListAttestedNodesRequest = datastore.ListAttestedNodesRequest                         //nolint: golint
+type ListAttestedNodesResponse = datastore.ListAttestedNodesResponse                       //nolint: golint
+type ListBundlesRequest = datastore.ListBundlesRequest                                     //nolint: golint
+type ListBundlesResponse = datastore.ListBundlesResponse                                   //nolint: golint
+type ListEntryTemplatesRequest = datastore.ListEntryTemplatesRequest                       //nolint: golint
+type ListEntryTemplatesResponse = datastore.ListEntryTemplatesResponse                     //nolint: golint
+type ListFederationRelationshipsRequest = datastore.ListFederationRelationshipsRequest     //nolint: golint
+type ListFederationRelationshipsResponse = datastore.ListFederationRelationshipsResponse   //nolint: golint
+type ListNodeSelectorsRequest = datastore.ListNodeSelectorsRequest                         //nolint: golint
+type ListNodeSelectorsResponse = datastore.ListNodeSelectorsResponse                       //nolint: golint
+type ListRegistrationEntriesRequest = datastore.ListRegistrationEntriesRequest             //nolint: golint
+type ListRegistrationEntriesResponse = datastore.ListRegistrationEntriesResponse           //nolint: golint
+type NodeSelectors = datastore.NodeSelectors                                               //nolint: golint
+type Pagination = datastore.Pagination                                                     //nolint: golint
+type PruneBundleRequest = datastore.PruneBundleRequest                                     //nolint: golint
+type PruneBundleResponse = datastore.PruneBundleResponse                                   //nolint: golint
+type PruneJoinTokensRequest = datastore.PruneJoinTokensRequest                             //nolint: golint
+type PruneJoinTokensResponse = datastore.PruneJoinTokensResponse                           //nolint: golint
+type PruneRegistrationEntriesRequest = datastore.PruneRegistrationEntriesRequest           //nolint: golint
+type PruneRegistrationEntriesResponse = datastore.PruneRegistrationEntriesResponse         //nolint: golint
+type SetBundleRequest = datastore.SetBundleRequest                                         //nolint: golint
+type SetBundleResponse = datastore.SetBundleResponse                                       //nolint: golint
+type SetNodeSelectorsRequest = datastore.SetNodeSelectorsRequest                           //nolint: golint
+type SetNodeSelectorsResponse = datastore.SetNodeSelectorsResponse                         //nolint: golint
+type UnimplementedDataStoreServer = datastore.UnimplementedDataStoreServer                 //nolint: golint
+type UpdateAttestedNodeRequest = datastore.UpdateAttestedNodeRequest                       //nolint: golint
+type UpdateAttestedNodeResponse = datastore.UpdateAttestedNodeResponse                     //nolint: golint
+type UpdateBundleRequest = datastore.UpdateBundleRequest                                   //nolint: golint
+type UpdateBundleResponse = datastore.UpdateBundleResponse                                 //nolint: golint
+type UpdateEntryTemplateRequest = datastore.UpdateEntryTemplateRequest                     //nolint: golint
+type UpdateEntryTemplateResponse = datastore.UpdateEntryTemplateResponse                   //nolint: golint
+type UpdateFederationRelationshipRequest = datastore.UpdateFederationRelationshipRequest   //nolint: golint
+type UpdateFederationRelationshipResponse = datastore.UpdateFederationRelationshipResponse //nolint: golint
+type UpdateRegistrationEntryRequest = datastore.UpdateRegistrationEntryRequest             //nolint: golint
+type UpdateRegistrationEntryResponse = datastore.UpdateRegistrationEntryResponse           //nolint: golint
+type UseJoinTokenRequest = datastore.UseJoinTokenRequest                                   //nolint: golint
+type UseJoinTokenResponse = datastore.UseJoinTokenResponse                                 //nolint: golint
 
 const (
 	Type                           = "DataStore"
@@ -92,24 +120,33 @@ const (
 // DataStore is the client interface for the service type DataStore interface.
 type DataStore interface {
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
 	CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error)
 	CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error)
 	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateEntryTemplate(context.Context, *CreateEntryTemplateRequest) (*CreateEntryTemplateResponse, error)
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
+	DeleteEntryTemplate(context.Context, *DeleteEntryTemplateRequest) (*DeleteEntryTemplateResponse, error)
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	FetchEntryTemplate(context.Context, *FetchEntryTemplateRequest) (*FetchEntryTemplateResponse, error)
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListEntryTemplates(context.Context, *ListEntryTemplatesRequest) (*ListEntryTemplatesResponse, error)
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
 	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
@@ -119,32 +156,44 @@ type DataStore interface {
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
+	UpdateEntryTemplate(context.Context, *UpdateEntryTemplateRequest) (*UpdateEntryTemplateResponse, error)
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 }
 
 // Plugin is the client interface for the service with the plugin related methods used by the catalog to initialize the plugin.
 type Plugin interface {
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
 	Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error)
 	CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error)
 	CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error)
 	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateEntryTemplate(context.Context, *CreateEntryTemplateRequest) (*CreateEntryTemplateResponse, error)
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
+	DeleteEntryTemplate(context.Context, *DeleteEntryTemplateRequest) (*DeleteEntryTemplateResponse, error)
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	FetchEntryTemplate(context.Context, *FetchEntryTemplateRequest) (*FetchEntryTemplateResponse, error)
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListEntryTemplates(context.Context, *ListEntryTemplatesRequest) (*ListEntryTemplatesResponse, error)
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
 	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
@@ -154,7 +203,10 @@ type Plugin interface {
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
+	UpdateEntryTemplate(context.Context, *UpdateEntryTemplateRequest) (*UpdateEntryTemplateResponse, error)
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 }
 
 // PluginServer returns a catalog PluginServer implementation for the DataStore plugin.
@@ -206,6 +258,10 @@ func (a pluginClientAdapter) AppendBundle(ctx context.Context, in *AppendBundleR
 	return a.client.AppendBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) Backup(ctx context.Context, in *BackupRequest) (*BackupResponse, error) {
+	return a.client.Backup(ctx, in)
+}
+
 func (a pluginClientAdapter) Configure(ctx context.Context, in *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	return a.client.Configure(ctx, in)
 }
@@ -230,6 +286,14 @@ func (a pluginClientAdapter) CreateBundle(ctx context.Context, in *CreateBundleR
 	return a.client.CreateBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) CreateEntryTemplate(ctx context.Context, in *CreateEntryTemplateRequest) (*CreateEntryTemplateResponse, error) {
+	return a.client.CreateEntryTemplate(ctx, in)
+}
+
+func (a pluginClientAdapter) CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error) {
+	return a.client.CreateFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error) {
 	return a.client.CreateJoinToken(ctx, in)
 }
@@ -246,6 +310,14 @@ func (a pluginClientAdapter) DeleteBundle(ctx context.Context, in *DeleteBundleR
 	return a.client.DeleteBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) DeleteEntryTemplate(ctx context.Context, in *DeleteEntryTemplateRequest) (*DeleteEntryTemplateResponse, error) {
+	return a.client.DeleteEntryTemplate(ctx, in)
+}
+
+func (a pluginClientAdapter) DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error) {
+	return a.client.DeleteFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return a.client.DeleteJoinToken(ctx, in)
 }
@@ -262,6 +334,14 @@ func (a pluginClientAdapter) FetchBundle(ctx context.Context, in *FetchBundleReq
 	return a.client.FetchBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) FetchEntryTemplate(ctx context.Context, in *FetchEntryTemplateRequest) (*FetchEntryTemplateResponse, error) {
+	return a.client.FetchEntryTemplate(ctx, in)
+}
+
+func (a pluginClientAdapter) FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error) {
+	return a.client.FetchFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error) {
 	return a.client.FetchJoinToken(ctx, in)
 }
@@ -286,6 +366,14 @@ func (a pluginClientAdapter) ListBundles(ctx context.Context, in *ListBundlesReq
 	return a.client.ListBundles(ctx, in)
 }
 
+func (a pluginClientAdapter) ListEntryTemplates(ctx context.Context, in *ListEntryTemplatesRequest) (*ListEntryTemplatesResponse, error) {
+	return a.client.ListEntryTemplates(ctx, in)
+}
+
+func (a pluginClientAdapter) ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	return a.client.ListFederationRelationships(ctx, in)
+}
+
 func (a pluginClientAdapter) ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error) {
 	return a.client.ListNodeSelectors(ctx, in)
 }
@@ -322,6 +410,18 @@ func (a pluginClientAdapter) UpdateBundle(ctx context.Context, in *UpdateBundleR
 	return a.client.UpdateBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) UpdateEntryTemplate(ctx context.Context, in *UpdateEntryTemplateRequest) (*UpdateEntryTemplateResponse, error) {
+	return a.client.UpdateEntryTemplate(ctx, in)
+}
+
+func (a pluginClientAdapter) UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error) {
+	return a.client.UpdateFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error) {
 	return a.client.UpdateRegistrationEntry(ctx, in)
 }
+
+func (a pluginClientAdapter) UseJoinToken(ctx context.Context, in *UseJoinTokenRequest) (*UseJoinTokenResponse, error) {
+	return a.client.UseJoinToken(ctx, in)
+}
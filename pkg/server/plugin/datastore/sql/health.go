@@ -0,0 +1,73 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/health"
+)
+
+const (
+	// latencyCheckTimeout is the timeout of the query run by the latency
+	// health check.
+	latencyCheckTimeout = 5 * time.Second
+
+	// maxHealthyLatency is the query latency above which the datastore is
+	// reported as unhealthy.
+	maxHealthyLatency = time.Second
+)
+
+// HealthChecks returns the health checks contributed by the datastore.
+func (ds *Plugin) HealthChecks() map[string]health.CheckFunc {
+	return map[string]health.CheckFunc{
+		"latency": ds.checkLatency,
+	}
+}
+
+// checkLatency measures the round trip of a trivial query to the database
+// and, if configured, to the read-only replica.
+func (ds *Plugin) checkLatency() (interface{}, error) {
+	ds.mu.Lock()
+	db, roDb := ds.db, ds.roDb
+	ds.mu.Unlock()
+
+	if db == nil {
+		return nil, errors.New("datastore is not configured")
+	}
+
+	details := make(map[string]interface{})
+	latency, err := queryLatency(db)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query the database: %v", err)
+	}
+	details["latency"] = latency.String()
+	if latency > maxHealthyLatency {
+		return details, fmt.Errorf("database latency of %s exceeds %s", latency, maxHealthyLatency)
+	}
+
+	if roDb != nil && roDb != db {
+		latency, err := queryLatency(roDb)
+		if err != nil {
+			return details, fmt.Errorf("unable to query the read-only database: %v", err)
+		}
+		details["read_only_latency"] = latency.String()
+		if latency > maxHealthyLatency {
+			return details, fmt.Errorf("read-only database latency of %s exceeds %s", latency, maxHealthyLatency)
+		}
+	}
+	return details, nil
+}
+
+func queryLatency(db *sqlDB) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), latencyCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var one int
+	if err := db.raw.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
@@ -13,7 +13,7 @@ import (
 
 var (
 	pagedNames    = []string{"", "with-token", "no-token"}
-	filterByNames = []string{"", "expires-before", "selector-subset-one", "selector-subset-many", "selector-exact-one", "selector-exact-many", "attestation-type", "banned", "no-banned", "fetch-selectors"}
+	filterByNames = []string{"", "expires-before", "selector-subset-one", "selector-subset-many", "selector-exact-one", "selector-exact-many", "attestation-type", "banned", "no-banned", "fetch-selectors", "expires-after"}
 )
 
 type filterBy int
@@ -29,6 +29,7 @@ const (
 	byBanned
 	byNoBanned
 	byFetchSelectors
+	byExpiresAfter
 )
 
 func (f filterBy) String() string {
@@ -146,6 +147,31 @@ WHERE id IN (
 		SELECT id 
 		FROM filtered_nodes
 )
+`},
+		{
+			dialect: "sqlite3",
+			by:      []filterBy{byExpiresBefore, byExpiresAfter},
+			query: `
+WITH filtered_nodes AS (
+	SELECT * FROM attested_node_entries WHERE true
+		AND expires_at < ?
+		AND expires_at >= ?
+)
+SELECT 
+	id as e_id,
+	spiffe_id,
+	data_type,
+	serial_number,
+	expires_at,
+	new_serial_number,
+	new_expires_at,
+	NULL AS selector_type,
+	NULL AS selector_value
+FROM filtered_nodes
+WHERE id IN (
+		SELECT id 
+		FROM filtered_nodes
+)
 `},
 		{
 			dialect: "sqlite3",
@@ -1361,6 +1387,23 @@ SELECT
 	NULL AS selector_value
 FROM attested_node_entries N
 WHERE true AND N.expires_at < ?
+`},
+		{
+			dialect: "mysql",
+			by:      []filterBy{byExpiresBefore, byExpiresAfter},
+			query: `
+SELECT 
+	N.id as e_id,
+	N.spiffe_id,
+	N.data_type,
+	N.serial_number,
+	N.expires_at,
+	N.new_serial_number,
+	N.new_expires_at,
+	NULL AS selector_type,
+	NULL AS selector_value
+FROM attested_node_entries N
+WHERE true AND N.expires_at < ? AND N.expires_at >= ?
 `},
 		{
 			dialect: "mysql",
@@ -2525,6 +2568,10 @@ WHERE id IN (
 					}
 				case byFetchSelectors:
 					req.FetchSelectors = true
+				case byExpiresAfter:
+					req.ByExpiresAfter = &wrappers.Int64Value{
+						Value: expiresBefore,
+					}
 				}
 			}
 
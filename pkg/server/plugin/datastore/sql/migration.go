@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/blang/semver"
@@ -19,7 +20,11 @@ import (
 
 const (
 	// the latest schema version of the database in the code
-	latestSchemaVersion = 15
+	latestSchemaVersion = 22
+
+	// the earliest schema version the database can be rolled back to.
+	// Migrations to this version and earlier cannot be reverted.
+	earliestRollbackVersion = 15
 )
 
 var (
@@ -46,14 +51,9 @@ func migrateDB(db *gorm.DB, dbType string, disableMigration bool, log hclog.Logg
 		return sqlError.New("current migration code not compatible with current release version")
 	}
 
-	// ensure migrations table exists so we can check versioning in all cases
-	if err := db.AutoMigrate(&Migration{}).Error; err != nil {
-		return sqlError.Wrap(err)
-	}
-
-	migration := new(Migration)
-	if err := db.Assign(Migration{}).FirstOrCreate(migration).Error; err != nil {
-		return sqlError.Wrap(err)
+	migration, err := ensureMigration(db)
+	if err != nil {
+		return err
 	}
 
 	schemaVersion := migration.Version
@@ -95,7 +95,8 @@ func migrateDB(db *gorm.DB, dbType string, disableMigration bool, log hclog.Logg
 
 	// The DB schema version can get ahead of us if the cluster is in the middle of
 	// an upgrade. So long as the version is compatible, log a warning and continue.
-	// Otherwise, we should bail out. Migration rollbacks are not supported.
+	// Otherwise, we should bail out. The server never rolls back migrations;
+	// see Migrator.Rollback.
 	if schemaVersion > latestSchemaVersion {
 		if !isCompatibleCodeVersion(dbCodeVersion) {
 			log.Error("Incompatible DB schema is too new for code version, upgrade SPIRE Server")
@@ -110,23 +111,88 @@ func migrateDB(db *gorm.DB, dbType string, disableMigration bool, log hclog.Logg
 	// - schema version of DB is behind
 
 	log.Info("Running migrations...")
-	for schemaVersion < latestSchemaVersion {
+	if _, err := runMigrations(db, schemaVersion, false, log); err != nil {
+		return err
+	}
+
+	log.Info("Done running migrations")
+	return nil
+}
+
+// ensureMigration makes sure the migrations table and its single row exist,
+// so versioning can be checked in all cases, and returns the row.
+func ensureMigration(db *gorm.DB) (*Migration, error) {
+	if err := db.AutoMigrate(&Migration{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	migration := new(Migration)
+	if err := db.Assign(Migration{}).FirstOrCreate(migration).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return migration, nil
+}
+
+// runMigrations brings the schema from the given version up to the latest
+// version. Each migration normally runs in its own transaction. A dry run
+// runs all of them in a single transaction that is rolled back at the end, so
+// later migrations see the changes made by earlier ones without anything
+// being persisted.
+func runMigrations(db *gorm.DB, schemaVersion int, dryRun bool, log hclog.Logger) ([]MigrationResult, error) {
+	return runSteps(db, schemaVersion, latestSchemaVersion, dryRun, func(tx *gorm.DB, version int) (MigrationResult, error) {
+		return migrateVersion(tx, version, log)
+	})
+}
+
+// runRollbacks brings the schema from the given version down to the target
+// version, in the same way runMigrations brings it up.
+func runRollbacks(db *gorm.DB, schemaVersion, targetVersion int, codeVersions map[int]string, dryRun bool, log hclog.Logger) ([]MigrationResult, error) {
+	return runSteps(db, schemaVersion, targetVersion, dryRun, func(tx *gorm.DB, version int) (MigrationResult, error) {
+		return rollbackVersion(tx, version, codeVersions, log)
+	})
+}
+
+// runSteps runs step from the given schema version until the schema reaches
+// the target version.
+func runSteps(db *gorm.DB, schemaVersion, targetVersion int, dryRun bool, step func(tx *gorm.DB, version int) (MigrationResult, error)) (results []MigrationResult, err error) {
+	if dryRun {
 		tx := db.Begin()
 		if err := tx.Error; err != nil {
-			return sqlError.Wrap(err)
+			return nil, sqlError.Wrap(err)
+		}
+		defer tx.Rollback()
+
+		if _, err := ensureMigration(tx); err != nil {
+			return nil, err
 		}
-		schemaVersion, err = migrateVersion(tx, schemaVersion, log)
+		for schemaVersion != targetVersion {
+			result, err := step(tx, schemaVersion)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+			schemaVersion = result.Version
+		}
+		return results, nil
+	}
+
+	for schemaVersion != targetVersion {
+		tx := db.Begin()
+		if err := tx.Error; err != nil {
+			return results, sqlError.Wrap(err)
+		}
+		result, err := step(tx, schemaVersion)
 		if err != nil {
 			tx.Rollback()
-			return err
+			return results, err
 		}
 		if err := tx.Commit().Error; err != nil {
-			return sqlError.Wrap(err)
+			return results, sqlError.Wrap(err)
 		}
+		results = append(results, result)
+		schemaVersion = result.Version
 	}
-
-	log.Info("Done running migrations")
-	return nil
+	return results, nil
 }
 
 func isDisabledMigrationAllowed(dbCodeVersion semver.Version) error {
@@ -169,6 +235,23 @@ func initDB(db *gorm.DB, dbType string, log hclog.Logger) (err error) {
 		return sqlError.Wrap(err)
 	}
 
+	if _, err := initSchema(tx, dbType); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	return nil
+}
+
+// initSchema creates the latest schema in a new database and records it in
+// the migration history.
+func initSchema(tx *gorm.DB, dbType string) (MigrationResult, error) {
+	rec, recorder := recordStatements(tx)
+
 	tables := []interface{}{
 		&Bundle{},
 		&AttestedNode{},
@@ -178,30 +261,26 @@ func initDB(db *gorm.DB, dbType string, log hclog.Logger) (err error) {
 		&Selector{},
 		&Migration{},
 		&DNSName{},
+		&FederatedTrustDomain{},
+		&EntryTemplate{},
 	}
 
-	if err := tableOptionsForDialect(tx, dbType).AutoMigrate(tables...).Error; err != nil {
-		tx.Rollback()
-		return sqlError.Wrap(err)
+	if err := tableOptionsForDialect(rec, dbType).AutoMigrate(tables...).Error; err != nil {
+		return MigrationResult{}, sqlError.Wrap(err)
+	}
+
+	if err := addFederatedRegistrationEntriesRegisteredEntryIDIndex(rec); err != nil {
+		return MigrationResult{}, err
 	}
 
 	if err := tx.Assign(Migration{
 		Version:     latestSchemaVersion,
 		CodeVersion: codeVersion.String(),
 	}).FirstOrCreate(&Migration{}).Error; err != nil {
-		tx.Rollback()
-		return sqlError.Wrap(err)
-	}
-
-	if err := addFederatedRegistrationEntriesRegisteredEntryIDIndex(tx); err != nil {
-		return err
-	}
-
-	if err := tx.Commit().Error; err != nil {
-		return sqlError.Wrap(err)
+		return MigrationResult{}, sqlError.Wrap(err)
 	}
 
-	return nil
+	return recordMigration(tx, dbType, latestSchemaVersion, false, recorder)
 }
 
 func tableOptionsForDialect(tx *gorm.DB, dbType string) *gorm.DB {
@@ -214,7 +293,7 @@ func tableOptionsForDialect(tx *gorm.DB, dbType string) *gorm.DB {
 	return tx
 }
 
-func migrateVersion(tx *gorm.DB, currVersion int, log hclog.Logger) (versionOut int, err error) {
+func migrateVersion(tx *gorm.DB, currVersion int, log hclog.Logger) (MigrationResult, error) {
 	log.Info("Migrating version", telemetry.VersionInfo, currVersion)
 
 	// When a new version is added an entry must be included here that knows
@@ -237,14 +316,22 @@ func migrateVersion(tx *gorm.DB, currVersion int, log hclog.Logger) (versionOut
 		migrateToV13,
 		migrateToV14,
 		migrateToV15,
+		migrateToV16,
+		migrateToV17,
+		migrateToV18,
+		migrateToV19,
+		migrateToV20,
+		migrateToV21,
+		migrateToV22,
 	}
 
 	if currVersion >= len(migrations) {
-		return currVersion, sqlError.New("no migration support for version %d", currVersion)
+		return MigrationResult{}, sqlError.New("no migration support for version %d", currVersion)
 	}
 
-	if err := migrations[currVersion](tx); err != nil {
-		return currVersion, err
+	rec, recorder := recordStatements(tx)
+	if err := migrations[currVersion](rec); err != nil {
+		return MigrationResult{}, err
 	}
 
 	nextVersion := currVersion + 1
@@ -252,10 +339,74 @@ func migrateVersion(tx *gorm.DB, currVersion int, log hclog.Logger) (versionOut
 		Version:     nextVersion,
 		CodeVersion: version.Version(),
 	}).Error; err != nil {
-		return currVersion, sqlError.Wrap(err)
+		return MigrationResult{}, sqlError.Wrap(err)
+	}
+
+	return recordMigration(tx, tx.Dialect().GetName(), nextVersion, false, recorder)
+}
+
+// rollbackVersion reverts the migration that brought the schema to the
+// current version. The code version recorded for the previous schema version
+// is restored, if known, so that the SPIRE Server that last ran against that
+// schema accepts it.
+func rollbackVersion(tx *gorm.DB, currVersion int, codeVersions map[int]string, log hclog.Logger) (MigrationResult, error) {
+	log.Info("Rolling back version", telemetry.VersionInfo, currVersion)
+
+	// When a new version is added an entry should be included here that
+	// knows how to bring it back to the previous version.
+	rollbacks := map[int]func(tx *gorm.DB, dbType string) error{
+		16: rollbackToV15,
+		17: rollbackToV16,
+		18: rollbackToV17,
+		19: rollbackToV18,
+		20: rollbackToV19,
+		21: rollbackToV20,
+		22: rollbackToV21,
+	}
+
+	rollback, ok := rollbacks[currVersion]
+	if !ok {
+		return MigrationResult{}, sqlError.New("no rollback support for version %d", currVersion)
+	}
+
+	dbType := tx.Dialect().GetName()
+	rec, recorder := recordStatements(tx)
+	if err := rollback(rec, dbType); err != nil {
+		return MigrationResult{}, err
+	}
+
+	prevVersion := currVersion - 1
+	if err := tx.Model(&Migration{}).Updates(Migration{
+		Version:     prevVersion,
+		CodeVersion: codeVersions[prevVersion],
+	}).Error; err != nil {
+		return MigrationResult{}, sqlError.Wrap(err)
+	}
+
+	return recordMigration(tx, dbType, prevVersion, true, recorder)
+}
+
+// recordMigration adds the statements captured by the recorder to the
+// migration history under the given schema version.
+func recordMigration(tx *gorm.DB, dbType string, schemaVersion int, rollback bool, recorder *statementRecorder) (MigrationResult, error) {
+	if err := tableOptionsForDialect(tx, dbType).AutoMigrate(&MigrationHistory{}).Error; err != nil {
+		return MigrationResult{}, sqlError.Wrap(err)
 	}
 
-	return nextVersion, nil
+	result := MigrationResult{
+		Version:    schemaVersion,
+		Statements: recorder.statements,
+		Checksum:   recorder.checksum(),
+	}
+	if err := tx.Create(&MigrationHistory{
+		Version:     result.Version,
+		CodeVersion: version.Version(),
+		Checksum:    result.Checksum,
+		Rollback:    rollback,
+	}).Error; err != nil {
+		return MigrationResult{}, sqlError.Wrap(err)
+	}
+	return result, nil
 }
 
 func migrateToV1(tx *gorm.DB) error {
@@ -478,6 +629,157 @@ func migrateToV15(tx *gorm.DB) error {
 	return addAttestedNodeEntriesExpiresAtIndex(tx)
 }
 
+func migrateToV16(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V16FederatedTrustDomain{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV17(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V17RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV18(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V18RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV19(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V19JoinToken{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV20(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V20RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV21(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V21RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV22(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V22EntryTemplate{}, &V22RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func rollbackToV15(tx *gorm.DB, dbType string) error {
+	if err := tx.DropTableIfExists(&V16FederatedTrustDomain{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func rollbackToV16(tx *gorm.DB, dbType string) error {
+	return dropColumns(tx, dbType, &V16RegisteredEntry{}, "jwt_svid_claims", "jwt_svid_audience")
+}
+
+func rollbackToV17(tx *gorm.DB, dbType string) error {
+	return dropColumns(tx, dbType, &V17RegisteredEntry{}, "x509_svid_key_type", "dns_name_templates", "x509_svid_subject")
+}
+
+func rollbackToV18(tx *gorm.DB, dbType string) error {
+	return dropColumns(tx, dbType, &V18JoinToken{}, "max_uses", "uses", "allowed_cidrs", "selectors")
+}
+
+func rollbackToV19(tx *gorm.DB, dbType string) error {
+	// The registered entries of version 19 are those of version 18
+	return dropColumns(tx, dbType, &V18RegisteredEntry{}, "hint")
+}
+
+func rollbackToV20(tx *gorm.DB, dbType string) error {
+	return dropColumns(tx, dbType, &V20RegisteredEntry{}, "selector_expression")
+}
+
+func rollbackToV21(tx *gorm.DB, dbType string) error {
+	if err := dropColumns(tx, dbType, &V21RegisteredEntry{}, "template_id"); err != nil {
+		return err
+	}
+	if err := tx.DropTableIfExists(&V22EntryTemplate{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+// dropColumns drops columns from the table of the given model, which holds
+// the table as it is once the columns are dropped. The indexes on the
+// columns are dropped along with them.
+func dropColumns(tx *gorm.DB, dbType string, model interface{}, columns ...string) error {
+	if dbType == SQLite {
+		// The SQLite version used does not support dropping columns
+		return rebuildSQLiteTable(tx, model)
+	}
+
+	table := tx.NewScope(model).TableName()
+	for _, column := range columns {
+		if err := tx.Table(table).DropColumn(column).Error; err != nil {
+			return sqlError.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// rebuildSQLiteTable replaces the table of the given model by a table with the
+// columns and indexes of the model, keeping the values of those columns.
+func rebuildSQLiteTable(tx *gorm.DB, model interface{}) error {
+	scope := tx.NewScope(model)
+	table := scope.TableName()
+	rebuilt := table + "_rollback"
+
+	var columns []string
+	for _, field := range scope.GetModelStruct().StructFields {
+		if field.IsNormal && !field.IsIgnored {
+			columns = append(columns, scope.Quote(field.DBName))
+		}
+	}
+	columnList := strings.Join(columns, ",")
+
+	if err := tx.Table(rebuilt).CreateTable(model).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	if err := tx.Exec(fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s",
+		scope.Quote(rebuilt), columnList, columnList, scope.Quote(table))).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	if err := tx.DropTable(table).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s RENAME TO %s", scope.Quote(rebuilt), scope.Quote(table))).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	// The indexes keep the names they were created with for the rebuilt
+	// table. Drop them and let gorm create them under their usual names.
+	var indexes []string
+	if err := tx.Raw("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", table).Pluck("name", &indexes).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	for _, index := range indexes {
+		if err := tx.Exec(fmt.Sprintf("DROP INDEX %s", scope.Quote(index))).Error; err != nil {
+			return sqlError.Wrap(err)
+		}
+	}
+	if err := tx.AutoMigrate(model).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
 func addFederatedRegistrationEntriesRegisteredEntryIDIndex(tx *gorm.DB) error {
 	// GORM creates the federated_registration_entries implicitly with a primary
 	// key tuple (bundle_id, registered_entry_id). Unfortunately, MySQL5 does
@@ -699,3 +1001,320 @@ type V11Migration struct {
 func (V11Migration) TableName() string {
 	return "migrations"
 }
+
+// V16FederatedTrustDomain holds a version 16 federation relationship
+type V16FederatedTrustDomain struct {
+	Model
+
+	// TrustDomain is the trust domain ID of the federated trust domain
+	TrustDomain string `gorm:"not null;unique_index"`
+
+	BundleEndpointURL     string
+	BundleEndpointProfile string
+	EndpointSPIFFEID      string
+}
+
+// TableName gets table name for v16 federated trust domain
+func (V16FederatedTrustDomain) TableName() string {
+	return "federated_trust_domains"
+}
+
+// V16RegisteredEntry holds a version 16 registered entry
+type V16RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64 `gorm:"index"`
+	// (optional) DNS entries
+	DNSList []DNSName
+
+	// RevisionNumber is a counter that is incremented when the entry is
+	// updated.
+	RevisionNumber int64
+}
+
+// TableName gets table name for v16 registered entry
+func (V16RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V17RegisteredEntry holds a version 17 registered entry
+type V17RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64 `gorm:"index"`
+	// (optional) DNS entries
+	DNSList []DNSName
+
+	// RevisionNumber is a counter that is incremented when the entry is
+	// updated.
+	RevisionNumber int64
+
+	// (optional) additional JWT-SVID claims, encoded as a JSON object
+	JWTSVIDClaims string `gorm:"column:jwt_svid_claims;type:text"`
+	// (optional) default JWT-SVID audience, encoded as a JSON array
+	JWTSVIDAudience string `gorm:"column:jwt_svid_audience;type:text"`
+}
+
+// TableName gets table name for v17 registered entry
+func (V17RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V18RegisteredEntry holds a version 18 registered entry
+type V18RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64 `gorm:"index"`
+	// (optional) DNS entries
+	DNSList []DNSName
+
+	// RevisionNumber is a counter that is incremented when the entry is
+	// updated.
+	RevisionNumber int64
+
+	// (optional) additional JWT-SVID claims, encoded as a JSON object
+	JWTSVIDClaims string `gorm:"column:jwt_svid_claims;type:text"`
+	// (optional) default JWT-SVID audience, encoded as a JSON array
+	JWTSVIDAudience string `gorm:"column:jwt_svid_audience;type:text"`
+
+	// (optional) key type of X509-SVIDs minted for the entry
+	X509SVIDKeyType string `gorm:"column:x509_svid_key_type"`
+	// (optional) DNS name templates, encoded as a JSON array
+	DNSNameTemplates string `gorm:"column:dns_name_templates;type:text"`
+	// (optional) X509-SVID subject, encoded as a JSON object
+	X509SVIDSubject string `gorm:"column:x509_svid_subject;type:text"`
+}
+
+// TableName gets table name for v18 registered entry
+func (V18RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V18JoinToken holds a version 18 join token
+type V18JoinToken struct {
+	Model
+
+	Token  string `gorm:"unique_index"`
+	Expiry int64
+}
+
+// TableName gets table name for v18 join token
+func (V18JoinToken) TableName() string {
+	return "join_tokens"
+}
+
+// V19JoinToken holds a version 19 join token
+type V19JoinToken struct {
+	Model
+
+	Token  string `gorm:"unique_index"`
+	Expiry int64
+
+	// MaxUses is the number of times the token can be used. Zero means the
+	// token can be used once.
+	MaxUses int32
+	Uses    int32
+
+	// AllowedCIDRs and Selectors are JSON encoded lists
+	AllowedCIDRs string `gorm:"column:allowed_cidrs;type:text"`
+	Selectors    string `gorm:"type:text"`
+}
+
+// TableName gets table name for v19 join token
+func (V19JoinToken) TableName() string {
+	return "join_tokens"
+}
+
+// V20RegisteredEntry holds a version 20 registered entry
+type V20RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64 `gorm:"index"`
+	// (optional) DNS entries
+	DNSList []DNSName
+
+	// RevisionNumber is a counter that is incremented when the entry is
+	// updated.
+	RevisionNumber int64
+
+	// (optional) additional JWT-SVID claims, encoded as a JSON object
+	JWTSVIDClaims string `gorm:"column:jwt_svid_claims;type:text"`
+	// (optional) default JWT-SVID audience, encoded as a JSON array
+	JWTSVIDAudience string `gorm:"column:jwt_svid_audience;type:text"`
+
+	// (optional) key type of X509-SVIDs minted for the entry
+	X509SVIDKeyType string `gorm:"column:x509_svid_key_type"`
+	// (optional) DNS name templates, encoded as a JSON array
+	DNSNameTemplates string `gorm:"column:dns_name_templates;type:text"`
+	// (optional) X509-SVID subject, encoded as a JSON object
+	X509SVIDSubject string `gorm:"column:x509_svid_subject;type:text"`
+
+	// (optional) hint returned to workloads along with the SVIDs minted for
+	// the entry
+	Hint string
+}
+
+// TableName gets table name for v20 registered entry
+func (V20RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V21RegisteredEntry holds a version 21 registered entry
+type V21RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64 `gorm:"index"`
+	// (optional) DNS entries
+	DNSList []DNSName
+
+	// RevisionNumber is a counter that is incremented when the entry is
+	// updated.
+	RevisionNumber int64
+
+	// (optional) additional JWT-SVID claims, encoded as a JSON object
+	JWTSVIDClaims string `gorm:"column:jwt_svid_claims;type:text"`
+	// (optional) default JWT-SVID audience, encoded as a JSON array
+	JWTSVIDAudience string `gorm:"column:jwt_svid_audience;type:text"`
+
+	// (optional) key type of X509-SVIDs minted for the entry
+	X509SVIDKeyType string `gorm:"column:x509_svid_key_type"`
+	// (optional) DNS name templates, encoded as a JSON array
+	DNSNameTemplates string `gorm:"column:dns_name_templates;type:text"`
+	// (optional) X509-SVID subject, encoded as a JSON object
+	X509SVIDSubject string `gorm:"column:x509_svid_subject;type:text"`
+
+	// (optional) hint returned to workloads along with the SVIDs minted for
+	// the entry
+	Hint string
+
+	// (optional) expression over the workload selectors that must be
+	// satisfied for the entry to match a workload
+	SelectorExpression string `gorm:"column:selector_expression;type:text"`
+}
+
+// TableName gets table name for v21 registered entry
+func (V21RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V22RegisteredEntry holds a version 22 registered entry
+type V22RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64 `gorm:"index"`
+	// (optional) DNS entries
+	DNSList []DNSName
+
+	// RevisionNumber is a counter that is incremented when the entry is
+	// updated.
+	RevisionNumber int64
+
+	// (optional) additional JWT-SVID claims, encoded as a JSON object
+	JWTSVIDClaims string `gorm:"column:jwt_svid_claims;type:text"`
+	// (optional) default JWT-SVID audience, encoded as a JSON array
+	JWTSVIDAudience string `gorm:"column:jwt_svid_audience;type:text"`
+
+	// (optional) key type of X509-SVIDs minted for the entry
+	X509SVIDKeyType string `gorm:"column:x509_svid_key_type"`
+	// (optional) DNS name templates, encoded as a JSON array
+	DNSNameTemplates string `gorm:"column:dns_name_templates;type:text"`
+	// (optional) X509-SVID subject, encoded as a JSON object
+	X509SVIDSubject string `gorm:"column:x509_svid_subject;type:text"`
+
+	// (optional) hint returned to workloads along with the SVIDs minted for
+	// the entry
+	Hint string
+
+	// (optional) expression over the workload selectors that must be
+	// satisfied for the entry to match a workload
+	SelectorExpression string `gorm:"column:selector_expression;type:text"`
+
+	// (optional) ID of the entry template the TTL, federated trust domains
+	// and DNS name templates of the entry are managed by
+	TemplateID string `gorm:"column:template_id;index"`
+}
+
+// TableName gets table name for v22 registered entry
+func (V22RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V22EntryTemplate holds a version 22 entry template
+type V22EntryTemplate struct {
+	Model
+
+	TemplateID string `gorm:"column:template_id;not null;unique_index"`
+
+	// (optional) shape the SPIFFE ID path of the entries must match
+	SPIFFEIDPath string `gorm:"column:spiffe_id_path"`
+	TTL          int32
+	// (optional) federated trust domain IDs, encoded as a JSON array
+	FederatesWith string `gorm:"type:text"`
+	// (optional) DNS name templates, encoded as a JSON array
+	DNSNameTemplates string `gorm:"column:dns_name_templates;type:text"`
+}
+
+// TableName gets table name for v22 entry template
+func (V22EntryTemplate) TableName() string {
+	return "entry_templates"
+}
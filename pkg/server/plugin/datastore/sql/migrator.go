@@ -0,0 +1,296 @@
+package sql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/jinzhu/gorm"
+)
+
+// MigrationResult describes a schema migration that was applied, or that
+// would be applied by a dry run
+type MigrationResult struct {
+	// Version is the schema version the migration brings the database to
+	Version int
+
+	// Statements are the SQL statements run by the migration. Queries that
+	// only read from the database are left out.
+	Statements []string
+
+	// Checksum is the hex encoded SHA-256 digest of the statements, which
+	// does not depend on the order they ran in
+	Checksum string
+}
+
+// AppliedMigration is an entry of the migration history of a database
+type AppliedMigration struct {
+	Version     int
+	Rollback    bool
+	CodeVersion string
+	Checksum    string
+	AppliedAt   time.Time
+}
+
+// MigrationStatus describes the schema of a database relative to the schema
+// expected by this version of SPIRE Server
+type MigrationStatus struct {
+	// Initialized is false when the database does not hold a SPIRE schema yet
+	Initialized bool
+
+	// SchemaVersion is the current schema version of the database
+	SchemaVersion int
+
+	// LatestSchemaVersion is the schema version expected by this code
+	LatestSchemaVersion int
+
+	// EarliestRollbackVersion is the oldest schema version the database can
+	// be rolled back to
+	EarliestRollbackVersion int
+
+	// CodeVersion is the SPIRE version that last migrated the database
+	CodeVersion string
+
+	// Pending are the schema versions that running the migrations applies
+	Pending []int
+
+	// Applied is the recorded migration history, oldest first. Migrations
+	// applied by SPIRE versions that predate the history are not listed.
+	Applied []AppliedMigration
+}
+
+// Migrator inspects and applies the schema migrations of a SQL datastore
+// outside of a running server, for deployments that disable auto-migration
+// or want to review the changes first.
+type Migrator struct {
+	db     *gorm.DB
+	dbType string
+	log    hclog.Logger
+}
+
+// NewMigrator connects to the database described by the given plugin_data of
+// the sql DataStore plugin. Unlike the plugin, it does not run migrations
+// when connecting.
+func NewMigrator(pluginData string, log hclog.Logger) (*Migrator, error) {
+	config := &configuration{}
+	if err := hcl.Decode(config, pluginData); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	dialect, err := newDialect(config.DatabaseType, log)
+	if err != nil {
+		return nil, err
+	}
+
+	db, _, _, err := dialect.connect(config, false)
+	if err != nil {
+		return nil, err
+	}
+	setGormLogger(db, log)
+
+	return &Migrator{
+		db:     db,
+		dbType: config.DatabaseType,
+		log:    log,
+	}, nil
+}
+
+// Close closes the database connection
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
+
+// Status returns the migration status of the database
+func (m *Migrator) Status() (*MigrationStatus, error) {
+	status := &MigrationStatus{
+		LatestSchemaVersion:     latestSchemaVersion,
+		EarliestRollbackVersion: earliestRollbackVersion,
+	}
+
+	if !m.db.HasTable(&Bundle{}) {
+		return status, sqlError.Wrap(m.db.Error)
+	}
+	status.Initialized = true
+
+	if m.db.HasTable(&Migration{}) {
+		migration := new(Migration)
+		if err := m.db.FirstOrInit(migration).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		status.SchemaVersion = migration.Version
+		status.CodeVersion = migration.CodeVersion
+	}
+
+	for v := status.SchemaVersion + 1; v <= latestSchemaVersion; v++ {
+		status.Pending = append(status.Pending, v)
+	}
+
+	if m.db.HasTable(&MigrationHistory{}) {
+		var history []MigrationHistory
+		if err := m.db.Order("id").Find(&history).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		for _, h := range history {
+			status.Applied = append(status.Applied, AppliedMigration{
+				Version:     h.Version,
+				Rollback:    h.Rollback,
+				CodeVersion: h.CodeVersion,
+				Checksum:    h.Checksum,
+				AppliedAt:   h.CreatedAt,
+			})
+		}
+	}
+
+	return status, nil
+}
+
+// Migrate applies the pending migrations, or creates the schema of a new
+// database, and returns the migrations that were run. Each migration runs in
+// its own transaction and a failed migration is rolled back, leaving the
+// database at the last successfully applied version.
+//
+// When dryRun is true the migrations are run in a transaction that is always
+// rolled back, so the statements can be reviewed without changing the
+// database. MySQL commits schema changes implicitly, so dry runs are not
+// supported for it.
+func (m *Migrator) Migrate(dryRun bool) ([]MigrationResult, error) {
+	if dryRun && m.dbType == MySQL {
+		return nil, sqlError.New("dry run is not supported for mysql since schema changes cannot be rolled back")
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.Initialized {
+		tx := m.db.Begin()
+		if err := tx.Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		result, err := initSchema(tx, m.dbType)
+		if err != nil || dryRun {
+			tx.Rollback()
+			if err != nil {
+				return nil, err
+			}
+			return []MigrationResult{result}, nil
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		return []MigrationResult{result}, nil
+	}
+
+	// See the corresponding check in migrateDB
+	if codeVersion.Major != 0 {
+		return nil, sqlError.New("current migration code not compatible with current release version")
+	}
+
+	if status.SchemaVersion > latestSchemaVersion {
+		return nil, sqlError.New("database schema version %d is newer than the latest version %d supported by this SPIRE Server", status.SchemaVersion, latestSchemaVersion)
+	}
+
+	if !dryRun {
+		if _, err := ensureMigration(m.db); err != nil {
+			return nil, err
+		}
+	}
+
+	return runMigrations(m.db, status.SchemaVersion, dryRun, m.log)
+}
+
+// Rollback reverts the migrations applied to the database down to the given
+// schema version, and returns the rollbacks that were run. Like migrations,
+// each rollback runs in its own transaction, is recorded in the migration
+// history, and can be run as a dry run. The data held by the tables and
+// columns a rollback drops is lost.
+//
+// The code version of the database is set back to the SPIRE version that
+// the migration history records as having applied the target version, so
+// that version of SPIRE Server accepts the database again.
+func (m *Migrator) Rollback(toVersion int, dryRun bool) ([]MigrationResult, error) {
+	if dryRun && m.dbType == MySQL {
+		return nil, sqlError.New("dry run is not supported for mysql since schema changes cannot be rolled back")
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !status.Initialized:
+		return nil, sqlError.New("database is not initialized")
+	case status.SchemaVersion > latestSchemaVersion:
+		return nil, sqlError.New("database schema version %d is newer than the latest version %d supported by this SPIRE Server", status.SchemaVersion, latestSchemaVersion)
+	case toVersion > status.SchemaVersion:
+		return nil, sqlError.New("cannot roll back to version %d since the database schema is at version %d", toVersion, status.SchemaVersion)
+	case toVersion < earliestRollbackVersion:
+		return nil, sqlError.New("cannot roll back to version %d since migrations to version %d and earlier cannot be rolled back", toVersion, earliestRollbackVersion)
+	}
+
+	codeVersions := make(map[int]string)
+	for _, applied := range status.Applied {
+		if !applied.Rollback {
+			codeVersions[applied.Version] = applied.CodeVersion
+		}
+	}
+
+	return runRollbacks(m.db, status.SchemaVersion, toVersion, codeVersions, dryRun, m.log)
+}
+
+// statementRecorder is a gorm logger that records the statements executed
+// through a database handle in detailed log mode
+type statementRecorder struct {
+	statements []string
+}
+
+// recordStatements returns a handle that shares the transaction of tx and
+// records every statement executed through it
+func recordStatements(tx *gorm.DB) (*gorm.DB, *statementRecorder) {
+	recorder := new(statementRecorder)
+	rec := tx.New()
+	rec.LogMode(true)
+	rec.SetLogger(recorder)
+	return rec, recorder
+}
+
+// Print implements the gorm logger interface. SQL log entries are of the form
+// ("sql", source, duration, statement, values, rowsAffected).
+func (r *statementRecorder) Print(v ...interface{}) {
+	if len(v) < 4 || v[0] != "sql" {
+		return
+	}
+	statement, ok := v[3].(string)
+	if !ok {
+		return
+	}
+	statement = strings.TrimSpace(statement)
+	if strings.HasPrefix(strings.ToUpper(statement), "SELECT") {
+		return
+	}
+	r.statements = append(r.statements, statement)
+}
+
+// checksum digests the recorded statements in sorted order, since gorm does
+// not create the indexes of a table in a stable order
+func (r *statementRecorder) checksum() string {
+	statements := append([]string(nil), r.statements...)
+	sort.Strings(statements)
+
+	h := sha256.New()
+	for _, statement := range statements {
+		h.Write([]byte(statement))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
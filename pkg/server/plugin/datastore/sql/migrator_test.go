@@ -0,0 +1,261 @@
+package sql
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/jinzhu/gorm"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigratorInitializesNewDatabase(t *testing.T) {
+	m := newTestMigrator(t, filepath.Join(spiretest.TempDir(t), "new.sqlite3"))
+
+	status, err := m.Status()
+	require.NoError(t, err)
+	require.Equal(t, &MigrationStatus{
+		LatestSchemaVersion:     latestSchemaVersion,
+		EarliestRollbackVersion: earliestRollbackVersion,
+	}, status)
+
+	// A dry run reports the statements creating the schema without
+	// creating it
+	dryRun, err := m.Migrate(true)
+	require.NoError(t, err)
+	require.Len(t, dryRun, 1)
+	require.Equal(t, latestSchemaVersion, dryRun[0].Version)
+	require.NotEmpty(t, dryRun[0].Checksum)
+	requireStatementPrefix(t, dryRun[0].Statements, `CREATE TABLE "bundles"`)
+
+	status, err = m.Status()
+	require.NoError(t, err)
+	require.False(t, status.Initialized)
+
+	applied, err := m.Migrate(false)
+	require.NoError(t, err)
+	requireSameMigrations(t, dryRun, applied)
+
+	status, err = m.Status()
+	require.NoError(t, err)
+	require.True(t, status.Initialized)
+	require.Equal(t, latestSchemaVersion, status.SchemaVersion)
+	require.Equal(t, codeVersion.String(), status.CodeVersion)
+	require.Empty(t, status.Pending)
+	require.Len(t, status.Applied, 1)
+	require.Equal(t, latestSchemaVersion, status.Applied[0].Version)
+	require.Equal(t, applied[0].Checksum, status.Applied[0].Checksum)
+	require.Equal(t, codeVersion.String(), status.Applied[0].CodeVersion)
+
+	// Nothing is left to migrate
+	applied, err = m.Migrate(false)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+}
+
+func TestMigratorAppliesPendingMigrations(t *testing.T) {
+	const fromVersion = latestSchemaVersion - 2
+
+	dbPath := filepath.Join(spiretest.TempDir(t), "old.sqlite3")
+	require.NoError(t, dumpDB(dbPath, migrationDump(fromVersion)))
+	m := newTestMigrator(t, dbPath)
+
+	status, err := m.Status()
+	require.NoError(t, err)
+	require.True(t, status.Initialized)
+	require.Equal(t, fromVersion, status.SchemaVersion)
+	require.Equal(t, []int{fromVersion + 1, fromVersion + 2}, status.Pending)
+	require.Empty(t, status.Applied)
+
+	// A dry run rolls back every migration
+	dryRun, err := m.Migrate(true)
+	require.NoError(t, err)
+	require.Len(t, dryRun, 2)
+	require.Equal(t, fromVersion+1, dryRun[0].Version)
+	require.Equal(t, fromVersion+2, dryRun[1].Version)
+	requireStatementPrefix(t, dryRun[1].Statements, `CREATE TABLE "entry_templates"`)
+
+	afterDryRun, err := m.Status()
+	require.NoError(t, err)
+	require.Equal(t, status, afterDryRun)
+
+	applied, err := m.Migrate(false)
+	require.NoError(t, err)
+	requireSameMigrations(t, dryRun, applied)
+
+	status, err = m.Status()
+	require.NoError(t, err)
+	require.Equal(t, latestSchemaVersion, status.SchemaVersion)
+	require.Empty(t, status.Pending)
+	require.Len(t, status.Applied, 2)
+	for i, migration := range status.Applied {
+		require.Equal(t, applied[i].Version, migration.Version)
+		require.Equal(t, applied[i].Checksum, migration.Checksum)
+	}
+}
+
+func TestMigratorRejectsDryRunOnMySQL(t *testing.T) {
+	m := &Migrator{dbType: MySQL}
+	_, err := m.Migrate(true)
+	require.EqualError(t, err, "datastore-sql: dry run is not supported for mysql since schema changes cannot be rolled back")
+}
+
+func TestMigratorRollsBackMigrations(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	dbPath := filepath.Join(dir, "rollback.sqlite3")
+	require.NoError(t, dumpDB(dbPath, migrationDump(earliestRollbackVersion)))
+	m := newTestMigrator(t, dbPath)
+
+	applied, err := m.Migrate(false)
+	require.NoError(t, err)
+	require.Len(t, applied, latestSchemaVersion-earliestRollbackVersion)
+
+	// Data in the columns that predate the rollback version is kept
+	require.NoError(t, m.db.Exec(`INSERT INTO registered_entries (entry_id, spiffe_id, parent_id, ttl, hint, template_id) VALUES ('entry', 'spiffe://example.org/workload', 'spiffe://example.org/agent', 60, 'hint', 'template')`).Error)
+	require.NoError(t, m.db.Exec(`INSERT INTO join_tokens (token, expiry, max_uses) VALUES ('token', 1000, 5)`).Error)
+
+	// A dry run reports the statements without changing the database
+	before, err := m.Status()
+	require.NoError(t, err)
+	dryRun, err := m.Rollback(earliestRollbackVersion, true)
+	require.NoError(t, err)
+	require.Len(t, dryRun, latestSchemaVersion-earliestRollbackVersion)
+	require.Equal(t, latestSchemaVersion-1, dryRun[0].Version)
+	require.Equal(t, earliestRollbackVersion, dryRun[len(dryRun)-1].Version)
+	requireStatementPrefix(t, dryRun[0].Statements, `DROP TABLE "entry_templates"`)
+	after, err := m.Status()
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+
+	// The code version the history records for the target version is
+	// restored
+	require.NoError(t, m.db.Exec("UPDATE migration_histories SET code_version = '0.11.0' WHERE version = ?", earliestRollbackVersion+1).Error)
+	rolledBack, err := m.Rollback(earliestRollbackVersion+1, false)
+	require.NoError(t, err)
+	requireSameMigrations(t, dryRun[:len(dryRun)-1], rolledBack)
+	status, err := m.Status()
+	require.NoError(t, err)
+	require.Equal(t, earliestRollbackVersion+1, status.SchemaVersion)
+	require.Equal(t, "0.11.0", status.CodeVersion)
+
+	// The history has no record of the migration to the earliest version,
+	// which predates the history, so the code version is left as is
+	rolledBack, err = m.Rollback(earliestRollbackVersion, false)
+	require.NoError(t, err)
+	requireSameMigrations(t, dryRun[len(dryRun)-1:], rolledBack)
+	status, err = m.Status()
+	require.NoError(t, err)
+	require.Equal(t, earliestRollbackVersion, status.SchemaVersion)
+	require.Equal(t, "0.11.0", status.CodeVersion)
+	require.Len(t, status.Applied, 2*(latestSchemaVersion-earliestRollbackVersion))
+	last := status.Applied[len(status.Applied)-1]
+	require.True(t, last.Rollback)
+	require.Equal(t, earliestRollbackVersion, last.Version)
+	require.Equal(t, rolledBack[0].Checksum, last.Checksum)
+
+	// The schema is the one of the rollback version
+	expectedPath := filepath.Join(dir, "expected.sqlite3")
+	require.NoError(t, dumpDB(expectedPath, migrationDump(earliestRollbackVersion)))
+	expected := newTestMigrator(t, expectedPath)
+	require.Equal(t, sqliteSchema(t, expected.db), sqliteSchema(t, m.db))
+
+	var entryIDs, spiffeIDs []string
+	require.NoError(t, m.db.Table("registered_entries").Pluck("entry_id", &entryIDs).Error)
+	require.Equal(t, []string{"entry"}, entryIDs)
+	require.NoError(t, m.db.Table("registered_entries").Pluck("spiffe_id", &spiffeIDs).Error)
+	require.Equal(t, []string{"spiffe://example.org/workload"}, spiffeIDs)
+	var tokens []string
+	require.NoError(t, m.db.Table("join_tokens").Pluck("token", &tokens).Error)
+	require.Equal(t, []string{"token"}, tokens)
+
+	// The migrations can be applied again
+	applied, err = m.Migrate(false)
+	require.NoError(t, err)
+	require.Len(t, applied, latestSchemaVersion-earliestRollbackVersion)
+}
+
+func TestMigratorRejectsInvalidRollbacks(t *testing.T) {
+	m := newTestMigrator(t, filepath.Join(spiretest.TempDir(t), "rollback.sqlite3"))
+
+	_, err := m.Rollback(earliestRollbackVersion, false)
+	require.EqualError(t, err, "datastore-sql: database is not initialized")
+
+	_, err = m.Migrate(false)
+	require.NoError(t, err)
+
+	_, err = m.Rollback(latestSchemaVersion+1, false)
+	require.EqualError(t, err, fmt.Sprintf("datastore-sql: cannot roll back to version %d since the database schema is at version %d", latestSchemaVersion+1, latestSchemaVersion))
+
+	_, err = m.Rollback(earliestRollbackVersion-1, false)
+	require.EqualError(t, err, fmt.Sprintf("datastore-sql: cannot roll back to version %d since migrations to version %d and earlier cannot be rolled back", earliestRollbackVersion-1, earliestRollbackVersion))
+
+	// Rolling back to the current version does nothing
+	rolledBack, err := m.Rollback(latestSchemaVersion, false)
+	require.NoError(t, err)
+	require.Empty(t, rolledBack)
+}
+
+// sqliteSchema returns the columns and indexes of the SPIRE tables of a
+// SQLite database
+func sqliteSchema(t *testing.T, db *gorm.DB) map[string][]string {
+	var tables []string
+	require.NoError(t, db.Raw("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT IN ('sqlite_sequence', 'migration_histories')").Pluck("name", &tables).Error)
+
+	schema := make(map[string][]string)
+	for _, table := range tables {
+		rows, err := db.Raw(fmt.Sprintf("PRAGMA table_info(%q)", table)).Rows()
+		require.NoError(t, err)
+		for rows.Next() {
+			var (
+				cid, notNull, pk int
+				name, typ        string
+				dflt             interface{}
+			)
+			require.NoError(t, rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk))
+			schema[table] = append(schema[table], fmt.Sprintf("column %s %s", name, typ))
+		}
+		require.NoError(t, rows.Close())
+
+		var indexes []string
+		require.NoError(t, db.Raw("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ?", table).Pluck("name", &indexes).Error)
+		for _, index := range indexes {
+			schema[table] = append(schema[table], "index "+index)
+		}
+		sort.Strings(schema[table])
+	}
+	return schema
+}
+
+func requireSameMigrations(t *testing.T, expected, actual []MigrationResult) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].Version, actual[i].Version)
+		require.Equal(t, expected[i].Checksum, actual[i].Checksum)
+		require.ElementsMatch(t, expected[i].Statements, actual[i].Statements)
+	}
+}
+
+func newTestMigrator(t *testing.T, dbPath string) *Migrator {
+	m, err := NewMigrator(fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = "%s"
+	`, dbPath), hclog.NewNullLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, m.Close())
+	})
+	return m
+}
+
+func requireStatementPrefix(t *testing.T, statements []string, prefix string) {
+	for _, statement := range statements {
+		if strings.HasPrefix(statement, prefix) {
+			return
+		}
+	}
+	require.Fail(t, "no statement starts with "+prefix, "statements: %q", statements)
+}
@@ -89,6 +89,30 @@ type RegisteredEntry struct {
 	// RevisionNumber is a counter that is incremented when the entry is
 	// updated.
 	RevisionNumber int64
+
+	// (optional) additional JWT-SVID claims, encoded as a JSON object
+	JWTSVIDClaims string `gorm:"column:jwt_svid_claims;type:text"`
+	// (optional) default JWT-SVID audience, encoded as a JSON array
+	JWTSVIDAudience string `gorm:"column:jwt_svid_audience;type:text"`
+
+	// (optional) key type of X509-SVIDs minted for the entry
+	X509SVIDKeyType string `gorm:"column:x509_svid_key_type"`
+	// (optional) DNS name templates, encoded as a JSON array
+	DNSNameTemplates string `gorm:"column:dns_name_templates;type:text"`
+	// (optional) X509-SVID subject, encoded as a JSON object
+	X509SVIDSubject string `gorm:"column:x509_svid_subject;type:text"`
+
+	// (optional) hint returned to workloads along with the SVIDs minted for
+	// the entry
+	Hint string
+
+	// (optional) expression over the workload selectors that must be
+	// satisfied for the entry to match a workload
+	SelectorExpression string `gorm:"column:selector_expression;type:text"`
+
+	// (optional) ID of the entry template the TTL, federated trust domains
+	// and DNS name templates of the entry are managed by
+	TemplateID string `gorm:"column:template_id;index"`
 }
 
 // JoinToken holds a join token
@@ -97,6 +121,15 @@ type JoinToken struct {
 
 	Token  string `gorm:"unique_index"`
 	Expiry int64
+
+	// MaxUses is the number of times the token can be used. Zero means the
+	// token can be used once.
+	MaxUses int32
+	Uses    int32
+
+	// AllowedCIDRs and Selectors are JSON encoded lists
+	AllowedCIDRs string `gorm:"column:allowed_cidrs;type:text"`
+	Selectors    string `gorm:"type:text"`
 }
 
 type Selector struct {
@@ -120,6 +153,35 @@ func (DNSName) TableName() string {
 	return "dns_names"
 }
 
+// FederatedTrustDomain holds a federation relationship with another trust
+// domain
+type FederatedTrustDomain struct {
+	Model
+
+	// TrustDomain is the trust domain ID of the federated trust domain
+	TrustDomain string `gorm:"not null;unique_index"`
+
+	BundleEndpointURL     string
+	BundleEndpointProfile string
+	EndpointSPIFFEID      string
+}
+
+// EntryTemplate holds a template managing the TTL, federated trust domains
+// and DNS name templates of the registration entries referencing it
+type EntryTemplate struct {
+	Model
+
+	TemplateID string `gorm:"column:template_id;not null;unique_index"`
+
+	// (optional) shape the SPIFFE ID path of the entries must match
+	SPIFFEIDPath string `gorm:"column:spiffe_id_path"`
+	TTL          int32
+	// (optional) federated trust domain IDs, encoded as a JSON array
+	FederatesWith string `gorm:"type:text"`
+	// (optional) DNS name templates, encoded as a JSON array
+	DNSNameTemplates string `gorm:"column:dns_name_templates;type:text"`
+}
+
 // Migration holds database schema version number, and
 // the SPIRE Code version number
 type Migration struct {
@@ -131,3 +193,22 @@ type Migration struct {
 	// SPIRE Code versioning
 	CodeVersion string
 }
+
+// MigrationHistory records a schema version applied to the database together
+// with a checksum of the SQL statements that produced it
+type MigrationHistory struct {
+	Model
+
+	// Database version the migration brought the schema to
+	Version int
+
+	// Whether the schema was brought to the version by rolling back a
+	// migration
+	Rollback bool
+
+	// SPIRE Code version that applied the migration
+	CodeVersion string
+
+	// Hex encoded SHA-256 digest of the statements run by the migration
+	Checksum string
+}
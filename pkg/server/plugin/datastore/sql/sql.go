@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
@@ -78,6 +80,10 @@ type configuration struct {
 	MaxIdleConns       *int    `hcl:"max_idle_conns" json:"max_idle_conns"`
 	DisableMigration   bool    `hcl:"disable_migration" json:"disable_migration"`
 
+	// SQLite specific settings
+	SQLiteJournalMode string `hcl:"sqlite_journal_mode" json:"sqlite_journal_mode"`
+	SQLiteBusyTimeout string `hcl:"sqlite_busy_timeout" json:"sqlite_busy_timeout"`
+
 	// Undocumented flags
 	LogSQL bool `hcl:"log_sql" json:"log_sql"`
 }
@@ -256,7 +262,7 @@ func (ds *Plugin) FetchAttestedNode(ctx context.Context,
 func (ds *Plugin) CountAttestedNodes(ctx context.Context,
 	req *datastore.CountAttestedNodesRequest) (resp *datastore.CountAttestedNodesResponse, err error) {
 	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
-		resp, err = countAttestedNodes(tx)
+		resp, err = countAttestedNodes(tx, req)
 		return err
 	}); err != nil {
 		return nil, err
@@ -454,6 +460,18 @@ func (ds *Plugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoin
 	return resp, nil
 }
 
+// UseJoinToken uses the given join token once, deleting it when it reaches its
+// maximum number of uses
+func (ds *Plugin) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (resp *datastore.UseJoinTokenResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = useJoinToken(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // PruneJoinTokens takes a Token message, and deletes all tokens which have expired
 // before the date in the message
 func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (resp *datastore.PruneJoinTokensResponse, err error) {
@@ -466,6 +484,144 @@ func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinT
 	return resp, nil
 }
 
+// CreateFederationRelationship creates a federation relationship with
+// another trust domain
+func (ds *Plugin) CreateFederationRelationship(ctx context.Context, req *datastore.CreateFederationRelationshipRequest) (resp *datastore.CreateFederationRelationshipResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = createFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchFederationRelationship fetches the federation relationship with the
+// given trust domain
+func (ds *Plugin) FetchFederationRelationship(ctx context.Context, req *datastore.FetchFederationRelationshipRequest) (resp *datastore.FetchFederationRelationshipResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = fetchFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListFederationRelationships lists federation relationships
+func (ds *Plugin) ListFederationRelationships(ctx context.Context, req *datastore.ListFederationRelationshipsRequest) (resp *datastore.ListFederationRelationshipsResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listFederationRelationships(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateFederationRelationship updates the federation relationship with the
+// given trust domain
+func (ds *Plugin) UpdateFederationRelationship(ctx context.Context, req *datastore.UpdateFederationRelationshipRequest) (resp *datastore.UpdateFederationRelationshipResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = updateFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteFederationRelationship deletes the federation relationship with the
+// given trust domain. The federated bundle, if any, is left untouched.
+func (ds *Plugin) DeleteFederationRelationship(ctx context.Context, req *datastore.DeleteFederationRelationshipRequest) (resp *datastore.DeleteFederationRelationshipResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = deleteFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateEntryTemplate creates an entry template
+func (ds *Plugin) CreateEntryTemplate(ctx context.Context, req *datastore.CreateEntryTemplateRequest) (resp *datastore.CreateEntryTemplateResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = createEntryTemplate(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchEntryTemplate fetches the entry template with the given ID
+func (ds *Plugin) FetchEntryTemplate(ctx context.Context, req *datastore.FetchEntryTemplateRequest) (resp *datastore.FetchEntryTemplateResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = fetchEntryTemplate(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListEntryTemplates lists entry templates
+func (ds *Plugin) ListEntryTemplates(ctx context.Context, req *datastore.ListEntryTemplatesRequest) (resp *datastore.ListEntryTemplatesResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listEntryTemplates(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateEntryTemplate updates the entry template with the given ID, along
+// with all of the registration entries referencing it, in a single
+// transaction.
+func (ds *Plugin) UpdateEntryTemplate(ctx context.Context, req *datastore.UpdateEntryTemplateRequest) (resp *datastore.UpdateEntryTemplateResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = updateEntryTemplate(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteEntryTemplate deletes the entry template with the given ID. Templates
+// still referenced by registration entries cannot be deleted.
+func (ds *Plugin) DeleteEntryTemplate(ctx context.Context, req *datastore.DeleteEntryTemplateRequest) (resp *datastore.DeleteEntryTemplateResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = deleteEntryTemplate(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Backup writes a consistent copy of the database to the requested path
+// without blocking readers or writers. Only sqlite3 databases are supported.
+func (ds *Plugin) Backup(ctx context.Context, req *datastore.BackupRequest) (*datastore.BackupResponse, error) {
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "backup path is required")
+	}
+
+	ds.mu.Lock()
+	db := ds.db
+	ds.mu.Unlock()
+
+	if db.databaseType != SQLite {
+		return nil, status.Errorf(codes.Unimplemented, "backup is not supported for database type %q", db.databaseType)
+	}
+
+	if err := backupSQLite3(ctx, db.raw, req.Path); err != nil {
+		return nil, err
+	}
+	return &datastore.BackupResponse{}, nil
+}
+
 // Configure parses HCL config payload into config struct, and opens new DB based on the result
 func (ds *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	config := &configuration{}
@@ -626,18 +782,10 @@ func (ds *Plugin) gormToGRPCStatus(err error) error {
 }
 
 func (ds *Plugin) openDB(cfg *configuration, isReadOnly bool) (*gorm.DB, string, bool, dialect, error) {
-	var dialect dialect
-
 	ds.log.Info("Opening SQL database", telemetry.DatabaseType, cfg.DatabaseType)
-	switch cfg.DatabaseType {
-	case SQLite:
-		dialect = sqliteDB{log: ds.log}
-	case PostgreSQL:
-		dialect = postgresDB{}
-	case MySQL:
-		dialect = mysqlDB{}
-	default:
-		return nil, "", false, nil, sqlError.New("unsupported database_type: %v", cfg.DatabaseType)
+	dialect, err := newDialect(cfg.DatabaseType, ds.log)
+	if err != nil {
+		return nil, "", false, nil, err
 	}
 
 	db, version, supportsCTE, err := dialect.connect(cfg, isReadOnly)
@@ -645,11 +793,7 @@ func (ds *Plugin) openDB(cfg *configuration, isReadOnly bool) (*gorm.DB, string,
 		return nil, "", false, nil, err
 	}
 
-	gormLogger := ds.log.Named("gorm")
-	gormLogger.SetLevel(hclog.Debug)
-	db.SetLogger(gormLogger.StandardLogger(&hclog.StandardLoggerOptions{
-		InferLevels: true,
-	}))
+	setGormLogger(db, ds.log)
 	if cfg.MaxOpenConns != nil {
 		db.DB().SetMaxOpenConns(*cfg.MaxOpenConns)
 	}
@@ -674,6 +818,27 @@ func (ds *Plugin) openDB(cfg *configuration, isReadOnly bool) (*gorm.DB, string,
 	return db, version, supportsCTE, dialect, nil
 }
 
+func newDialect(databaseType string, log hclog.Logger) (dialect, error) {
+	switch databaseType {
+	case SQLite:
+		return sqliteDB{log: log}, nil
+	case PostgreSQL:
+		return postgresDB{}, nil
+	case MySQL:
+		return mysqlDB{}, nil
+	default:
+		return nil, sqlError.New("unsupported database_type: %v", databaseType)
+	}
+}
+
+func setGormLogger(db *gorm.DB, log hclog.Logger) {
+	gormLogger := log.Named("gorm")
+	gormLogger.SetLevel(hclog.Debug)
+	db.SetLogger(gormLogger.StandardLogger(&hclog.StandardLoggerOptions{
+		InferLevels: true,
+	}))
+}
+
 func createBundle(tx *gorm.DB, req *datastore.CreateBundleRequest) (*datastore.CreateBundleResponse, error) {
 	model, err := bundleToModel(req.Bundle)
 	if err != nil {
@@ -1023,9 +1188,27 @@ func fetchAttestedNode(tx *gorm.DB, req *datastore.FetchAttestedNodeRequest) (*d
 	}, nil
 }
 
-func countAttestedNodes(tx *gorm.DB) (*datastore.CountAttestedNodesResponse, error) {
+func countAttestedNodes(tx *gorm.DB, req *datastore.CountAttestedNodesRequest) (*datastore.CountAttestedNodesResponse, error) {
+	tx = tx.Model(&AttestedNode{})
+	if req.ByAttestationType != "" {
+		tx = tx.Where("data_type = ?", req.ByAttestationType)
+	}
+	if req.ByBanned != nil {
+		if req.ByBanned.Value {
+			tx = tx.Where("serial_number = ''")
+		} else {
+			tx = tx.Where("serial_number <> ''")
+		}
+	}
+	if req.ByExpiresBefore != nil {
+		tx = tx.Where("expires_at < ?", time.Unix(req.ByExpiresBefore.Value, 0))
+	}
+	if req.ByExpiresAfter != nil {
+		tx = tx.Where("expires_at >= ?", time.Unix(req.ByExpiresAfter.Value, 0))
+	}
+
 	var count int
-	if err := tx.Model(&AttestedNode{}).Count(&count).Error; err != nil {
+	if err := tx.Count(&count).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
@@ -1206,6 +1389,10 @@ func buildListAttestedNodesQueryCTE(req *datastore.ListAttestedNodesRequest, dbT
 		builder.WriteString("\t\tAND expires_at < ?\n")
 		args = append(args, time.Unix(req.ByExpiresBefore.Value, 0))
 	}
+	if req.ByExpiresAfter != nil {
+		builder.WriteString("\t\tAND expires_at >= ?\n")
+		args = append(args, time.Unix(req.ByExpiresAfter.Value, 0))
+	}
 
 	// Filter by Attestation type
 	if req.ByAttestationType != "" {
@@ -1417,6 +1604,10 @@ FROM attested_node_entries N
 			builder.WriteString(" AND N.expires_at < ?")
 			args = append(args, time.Unix(req.ByExpiresBefore.Value, 0))
 		}
+		if req.ByExpiresAfter != nil {
+			builder.WriteString(" AND N.expires_at >= ?")
+			args = append(args, time.Unix(req.ByExpiresAfter.Value, 0))
+		}
 
 		// Filter by Attestation type
 		if req.ByAttestationType != "" {
@@ -1698,25 +1889,62 @@ func createRegistrationEntry(tx *gorm.DB, req *datastore.CreateRegistrationEntry
 		return nil, err
 	}
 
+	jwtSVIDClaims, err := marshalJWTSVIDClaims(req.Entry.JwtSvidClaims)
+	if err != nil {
+		return nil, err
+	}
+	jwtSVIDAudience, err := marshalJWTSVIDAudience(req.Entry.JwtSvidAudience)
+	if err != nil {
+		return nil, err
+	}
+	dnsNameTemplates, err := marshalDNSNameTemplates(req.Entry.DnsNameTemplates)
+	if err != nil {
+		return nil, err
+	}
+	x509SVIDSubject, err := marshalX509SVIDSubject(req.Entry.X509SvidSubject)
+	if err != nil {
+		return nil, err
+	}
+
 	newRegisteredEntry := RegisteredEntry{
-		EntryID:    entryID,
-		SpiffeID:   req.Entry.SpiffeId,
-		ParentID:   req.Entry.ParentId,
-		TTL:        req.Entry.Ttl,
-		Admin:      req.Entry.Admin,
-		Downstream: req.Entry.Downstream,
-		Expiry:     req.Entry.EntryExpiry,
+		EntryID:            entryID,
+		SpiffeID:           req.Entry.SpiffeId,
+		ParentID:           req.Entry.ParentId,
+		TTL:                req.Entry.Ttl,
+		Admin:              req.Entry.Admin,
+		Downstream:         req.Entry.Downstream,
+		Expiry:             req.Entry.EntryExpiry,
+		JWTSVIDClaims:      jwtSVIDClaims,
+		JWTSVIDAudience:    jwtSVIDAudience,
+		X509SVIDKeyType:    req.Entry.X509SvidKeyType,
+		DNSNameTemplates:   dnsNameTemplates,
+		X509SVIDSubject:    x509SVIDSubject,
+		Hint:               req.Entry.Hint,
+		SelectorExpression: req.Entry.SelectorExpression,
+		TemplateID:         req.Entry.TemplateId,
+	}
+
+	var federatesWith []*Bundle
+	if newRegisteredEntry.TemplateID != "" {
+		template, err := fetchEntryTemplateForEntry(tx, newRegisteredEntry.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		federatesWith, err = applyEntryTemplate(tx, &newRegisteredEntry, template)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		federatesWith, err = makeFederatesWith(tx, req.Entry.FederatesWith)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if err := tx.Create(&newRegisteredEntry).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
-	federatesWith, err := makeFederatesWith(tx, req.Entry.FederatesWith)
-	if err != nil {
-		return nil, err
-	}
-
 	if err := tx.Model(&newRegisteredEntry).Association("FederatesWith").Append(federatesWith).Error; err != nil {
 		return nil, err
 	}
@@ -1829,7 +2057,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -1837,7 +2073,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1850,7 +2086,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1858,7 +2094,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1888,7 +2124,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -1896,7 +2140,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1909,7 +2153,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1917,7 +2161,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1944,7 +2188,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -1981,7 +2233,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -1989,7 +2249,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2002,7 +2262,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2010,7 +2270,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2211,7 +2471,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 `)
@@ -2222,7 +2490,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2237,7 +2505,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 `)
@@ -2248,7 +2516,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -2289,7 +2557,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 `)
@@ -2300,7 +2576,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2315,7 +2591,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 `)
@@ -2326,7 +2602,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -2371,7 +2647,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -2425,7 +2709,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 `)
@@ -2436,7 +2728,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2451,7 +2743,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 `)
@@ -2462,7 +2754,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -2782,21 +3074,29 @@ func fillNodeSelectorFromRow(nodeSelector *common.Selector, r *nodeSelectorRow)
 }
 
 type entryRow struct {
-	EId            uint64
-	EntryID        sql.NullString
-	SpiffeID       sql.NullString
-	ParentID       sql.NullString
-	RegTTL         sql.NullInt64
-	Admin          sql.NullBool
-	Downstream     sql.NullBool
-	Expiry         sql.NullInt64
-	SelectorID     sql.NullInt64
-	SelectorType   sql.NullString
-	SelectorValue  sql.NullString
-	TrustDomain    sql.NullString
-	DNSNameID      sql.NullInt64
-	DNSName        sql.NullString
-	RevisionNumber sql.NullInt64
+	EId                uint64
+	EntryID            sql.NullString
+	SpiffeID           sql.NullString
+	ParentID           sql.NullString
+	RegTTL             sql.NullInt64
+	Admin              sql.NullBool
+	Downstream         sql.NullBool
+	Expiry             sql.NullInt64
+	SelectorID         sql.NullInt64
+	SelectorType       sql.NullString
+	SelectorValue      sql.NullString
+	TrustDomain        sql.NullString
+	DNSNameID          sql.NullInt64
+	DNSName            sql.NullString
+	RevisionNumber     sql.NullInt64
+	JWTSVIDClaims      sql.NullString
+	JWTSVIDAudience    sql.NullString
+	X509SVIDKeyType    sql.NullString
+	DNSNameTemplates   sql.NullString
+	X509SVIDSubject    sql.NullString
+	Hint               sql.NullString
+	SelectorExpression sql.NullString
+	TemplateID         sql.NullString
 }
 
 func scanEntryRow(rs *sql.Rows, r *entryRow) error {
@@ -2816,6 +3116,14 @@ func scanEntryRow(rs *sql.Rows, r *entryRow) error {
 		&r.DNSNameID,
 		&r.DNSName,
 		&r.RevisionNumber,
+		&r.JWTSVIDClaims,
+		&r.JWTSVIDAudience,
+		&r.X509SVIDKeyType,
+		&r.DNSNameTemplates,
+		&r.X509SVIDSubject,
+		&r.Hint,
+		&r.SelectorExpression,
+		&r.TemplateID,
 	))
 }
 
@@ -2844,6 +3152,46 @@ func fillEntryFromRow(entry *common.RegistrationEntry, r *entryRow) error {
 	if r.RevisionNumber.Valid {
 		entry.RevisionNumber = r.RevisionNumber.Int64
 	}
+	if r.JWTSVIDClaims.Valid {
+		claims, err := unmarshalJWTSVIDClaims(r.JWTSVIDClaims.String)
+		if err != nil {
+			return err
+		}
+		entry.JwtSvidClaims = claims
+	}
+	if r.JWTSVIDAudience.Valid {
+		audience, err := unmarshalJWTSVIDAudience(r.JWTSVIDAudience.String)
+		if err != nil {
+			return err
+		}
+		entry.JwtSvidAudience = audience
+	}
+	if r.X509SVIDKeyType.Valid {
+		entry.X509SvidKeyType = r.X509SVIDKeyType.String
+	}
+	if r.DNSNameTemplates.Valid {
+		templates, err := unmarshalDNSNameTemplates(r.DNSNameTemplates.String)
+		if err != nil {
+			return err
+		}
+		entry.DnsNameTemplates = templates
+	}
+	if r.X509SVIDSubject.Valid {
+		subject, err := unmarshalX509SVIDSubject(r.X509SVIDSubject.String)
+		if err != nil {
+			return err
+		}
+		entry.X509SvidSubject = subject
+	}
+	if r.Hint.Valid {
+		entry.Hint = r.Hint.String
+	}
+	if r.SelectorExpression.Valid {
+		entry.SelectorExpression = r.SelectorExpression.String
+	}
+	if r.TemplateID.Valid {
+		entry.TemplateId = r.TemplateID.String
+	}
 
 	if r.SelectorType.Valid {
 		if !r.SelectorValue.Valid {
@@ -2893,6 +3241,9 @@ func updateRegistrationEntry(tx *gorm.DB,
 	if err := tx.Find(&entry, "entry_id = ?", req.Entry.EntryId).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
+	if req.Mask != nil && req.Mask.RevisionNumber && entry.RevisionNumber != req.Entry.RevisionNumber {
+		return nil, status.Newf(codes.Aborted, "datastore-sql: entry revision number %d does not match the current revision number %d", req.Entry.RevisionNumber, entry.RevisionNumber).Err()
+	}
 	if req.Mask == nil || req.Mask.Selectors {
 		// Delete existing selectors - we will write new ones
 		if err := tx.Exec("DELETE FROM selectors WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
@@ -2946,6 +3297,60 @@ func updateRegistrationEntry(tx *gorm.DB,
 	if req.Mask == nil || req.Mask.EntryExpiry {
 		entry.Expiry = req.Entry.EntryExpiry
 	}
+	if req.Mask == nil || req.Mask.JwtSvidClaims {
+		jwtSVIDClaims, err := marshalJWTSVIDClaims(req.Entry.JwtSvidClaims)
+		if err != nil {
+			return nil, err
+		}
+		entry.JWTSVIDClaims = jwtSVIDClaims
+	}
+	if req.Mask == nil || req.Mask.JwtSvidAudience {
+		jwtSVIDAudience, err := marshalJWTSVIDAudience(req.Entry.JwtSvidAudience)
+		if err != nil {
+			return nil, err
+		}
+		entry.JWTSVIDAudience = jwtSVIDAudience
+	}
+	if req.Mask == nil || req.Mask.X509SvidKeyType {
+		entry.X509SVIDKeyType = req.Entry.X509SvidKeyType
+	}
+	if req.Mask == nil || req.Mask.DnsNameTemplates {
+		dnsNameTemplates, err := marshalDNSNameTemplates(req.Entry.DnsNameTemplates)
+		if err != nil {
+			return nil, err
+		}
+		entry.DNSNameTemplates = dnsNameTemplates
+	}
+	if req.Mask == nil || req.Mask.X509SvidSubject {
+		x509SVIDSubject, err := marshalX509SVIDSubject(req.Entry.X509SvidSubject)
+		if err != nil {
+			return nil, err
+		}
+		entry.X509SVIDSubject = x509SVIDSubject
+	}
+	if req.Mask == nil || req.Mask.Hint {
+		entry.Hint = req.Entry.Hint
+	}
+	if req.Mask == nil || req.Mask.SelectorExpression {
+		entry.SelectorExpression = req.Entry.SelectorExpression
+	}
+	if req.Mask == nil || req.Mask.TemplateId {
+		entry.TemplateID = req.Entry.TemplateId
+	}
+
+	// The TTL, DNS name templates and federated trust domains of entries
+	// referencing a template are managed by the template, whatever the mask
+	var templateFederatesWith []*Bundle
+	if entry.TemplateID != "" {
+		template, err := fetchEntryTemplateForEntry(tx, entry.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		templateFederatesWith, err = applyEntryTemplate(tx, &entry, template)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Revision number is increased by 1 on every update call
 	entry.RevisionNumber++
@@ -2954,7 +3359,12 @@ func updateRegistrationEntry(tx *gorm.DB,
 		return nil, sqlError.Wrap(err)
 	}
 
-	if req.Mask == nil || req.Mask.FederatesWith {
+	switch {
+	case entry.TemplateID != "":
+		if err := tx.Model(&entry).Association("FederatesWith").Replace(templateFederatesWith).Error; err != nil {
+			return nil, err
+		}
+	case req.Mask == nil || req.Mask.FederatesWith:
 		federatesWith, err := makeFederatesWith(tx, req.Entry.FederatesWith)
 		if err != nil {
 			return nil, err
@@ -3030,12 +3440,12 @@ func pruneRegistrationEntries(tx *gorm.DB, req *datastore.PruneRegistrationEntri
 }
 
 func createJoinToken(tx *gorm.DB, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
-	t := JoinToken{
-		Token:  req.JoinToken.Token,
-		Expiry: req.JoinToken.Expiry,
+	t, err := joinTokenToModel(req.JoinToken)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := tx.Create(&t).Error; err != nil {
+	if err := tx.Create(t).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
@@ -3053,8 +3463,13 @@ func fetchJoinToken(tx *gorm.DB, req *datastore.FetchJoinTokenRequest) (*datasto
 		return nil, sqlError.Wrap(err)
 	}
 
+	joinToken, err := modelToJoinToken(model)
+	if err != nil {
+		return nil, err
+	}
+
 	return &datastore.FetchJoinTokenResponse{
-		JoinToken: modelToJoinToken(model),
+		JoinToken: joinToken,
 	}, nil
 }
 
@@ -3068,42 +3483,444 @@ func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datas
 		return nil, sqlError.Wrap(err)
 	}
 
+	joinToken, err := modelToJoinToken(model)
+	if err != nil {
+		return nil, err
+	}
+
 	return &datastore.DeleteJoinTokenResponse{
-		JoinToken: modelToJoinToken(model),
+		JoinToken: joinToken,
 	}, nil
 }
 
-func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
-	if err := tx.Where("expiry < ?", req.ExpiresBefore).Delete(&JoinToken{}).Error; err != nil {
+func useJoinToken(tx *gorm.DB, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	// Increment the uses with a conditional update so concurrent attestations
+	// cannot use the token more times than allowed. Tokens with no maximum
+	// number of uses can only be used once.
+	result := tx.Model(&JoinToken{}).
+		Where("token = ? AND (uses < max_uses OR (max_uses = 0 AND uses = 0))", req.Token).
+		UpdateColumn("uses", gorm.Expr("uses + ?", 1))
+	if err := result.Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
+	if result.RowsAffected == 0 {
+		return &datastore.UseJoinTokenResponse{}, nil
+	}
 
-	return &datastore.PruneJoinTokensResponse{}, nil
-}
-
-// modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
-// include any embedded CACert models.
-func modelToBundle(model *Bundle) (*common.Bundle, error) {
-	bundle := new(common.Bundle)
-	if err := proto.Unmarshal(model.Data, bundle); err != nil {
+	var model JoinToken
+	if err := tx.Find(&model, "token = ?", req.Token).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
-	return bundle, nil
-}
-
-func validateRegistrationEntry(entry *common.RegistrationEntry) error {
-	if entry == nil {
-		return sqlError.New("invalid request: missing registered entry")
+	if model.Uses >= model.MaxUses {
+		if err := tx.Delete(&model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
 	}
 
-	if entry.Selectors == nil || len(entry.Selectors) == 0 {
-		return sqlError.New("invalid registration entry: missing selector list")
+	joinToken, err := modelToJoinToken(model)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(entry.SpiffeId) == 0 {
-		return sqlError.New("invalid registration entry: missing SPIFFE ID")
-	}
+	return &datastore.UseJoinTokenResponse{
+		JoinToken: joinToken,
+	}, nil
+}
+
+func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
+	if err := tx.Where("expiry < ?", req.ExpiresBefore).Delete(&JoinToken{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.PruneJoinTokensResponse{}, nil
+}
+
+func createFederationRelationship(tx *gorm.DB, req *datastore.CreateFederationRelationshipRequest) (*datastore.CreateFederationRelationshipResponse, error) {
+	model, err := federationRelationshipToModel(req.FederationRelationship)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.BundleEndpointURL == "" {
+		return nil, sqlError.New("missing bundle endpoint URL")
+	}
+
+	if err := tx.Create(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.CreateFederationRelationshipResponse{
+		FederationRelationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func fetchFederationRelationship(tx *gorm.DB, req *datastore.FetchFederationRelationshipRequest) (*datastore.FetchFederationRelationshipResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model := new(FederatedTrustDomain)
+	err = tx.Find(model, "trust_domain = ?", trustDomainID).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return &datastore.FetchFederationRelationshipResponse{}, nil
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.FetchFederationRelationshipResponse{
+		FederationRelationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func listFederationRelationships(tx *gorm.DB, req *datastore.ListFederationRelationshipsRequest) (*datastore.ListFederationRelationshipsResponse, error) {
+	p := req.Pagination
+	var err error
+	if p != nil {
+		tx, err = applyPagination(p, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var models []FederatedTrustDomain
+	if err := tx.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if p != nil {
+		p.Token = ""
+		if len(models) > 0 {
+			p.Token = fmt.Sprint(models[len(models)-1].ID)
+		}
+	}
+
+	resp := &datastore.ListFederationRelationshipsResponse{
+		Pagination: p,
+	}
+	for _, model := range models {
+		model := model // alias the loop variable since we pass it by reference below
+		resp.FederationRelationships = append(resp.FederationRelationships, modelToFederationRelationship(&model))
+	}
+	return resp, nil
+}
+
+func updateFederationRelationship(tx *gorm.DB, req *datastore.UpdateFederationRelationshipRequest) (*datastore.UpdateFederationRelationshipResponse, error) {
+	newModel, err := federationRelationshipToModel(req.FederationRelationship)
+	if err != nil {
+		return nil, err
+	}
+
+	model := new(FederatedTrustDomain)
+	if err := tx.Find(model, "trust_domain = ?", newModel.TrustDomain).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	mask := req.InputMask
+	if mask == nil {
+		mask = &datastore.FederationRelationshipMask{
+			BundleEndpointUrl:     true,
+			BundleEndpointProfile: true,
+		}
+	}
+	if mask.BundleEndpointUrl {
+		model.BundleEndpointURL = newModel.BundleEndpointURL
+	}
+	if mask.BundleEndpointProfile {
+		model.BundleEndpointProfile = newModel.BundleEndpointProfile
+		model.EndpointSPIFFEID = newModel.EndpointSPIFFEID
+	}
+
+	if err := tx.Save(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.UpdateFederationRelationshipResponse{
+		FederationRelationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func deleteFederationRelationship(tx *gorm.DB, req *datastore.DeleteFederationRelationshipRequest) (*datastore.DeleteFederationRelationshipResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model := new(FederatedTrustDomain)
+	if err := tx.Find(model, "trust_domain = ?", trustDomainID).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if err := tx.Delete(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.DeleteFederationRelationshipResponse{
+		FederationRelationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func createEntryTemplate(tx *gorm.DB, req *datastore.CreateEntryTemplateRequest) (*datastore.CreateEntryTemplateResponse, error) {
+	model, err := entryTemplateToModel(tx, req.EntryTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	template, err := modelToEntryTemplate(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.CreateEntryTemplateResponse{
+		EntryTemplate: template,
+	}, nil
+}
+
+func fetchEntryTemplate(tx *gorm.DB, req *datastore.FetchEntryTemplateRequest) (*datastore.FetchEntryTemplateResponse, error) {
+	model := new(EntryTemplate)
+	err := tx.Find(model, "template_id = ?", req.TemplateId).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return &datastore.FetchEntryTemplateResponse{}, nil
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+
+	template, err := modelToEntryTemplate(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.FetchEntryTemplateResponse{
+		EntryTemplate: template,
+	}, nil
+}
+
+func listEntryTemplates(tx *gorm.DB, req *datastore.ListEntryTemplatesRequest) (*datastore.ListEntryTemplatesResponse, error) {
+	p := req.Pagination
+	var err error
+	if p != nil {
+		tx, err = applyPagination(p, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var models []EntryTemplate
+	if err := tx.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if p != nil {
+		p.Token = ""
+		if len(models) > 0 {
+			p.Token = fmt.Sprint(models[len(models)-1].ID)
+		}
+	}
+
+	resp := &datastore.ListEntryTemplatesResponse{
+		Pagination: p,
+	}
+	for _, model := range models {
+		model := model // alias the loop variable since we pass it by reference below
+		template, err := modelToEntryTemplate(&model)
+		if err != nil {
+			return nil, err
+		}
+		resp.EntryTemplates = append(resp.EntryTemplates, template)
+	}
+	return resp, nil
+}
+
+func updateEntryTemplate(tx *gorm.DB, req *datastore.UpdateEntryTemplateRequest) (*datastore.UpdateEntryTemplateResponse, error) {
+	newModel, err := entryTemplateToModel(tx, req.EntryTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	model := new(EntryTemplate)
+	if err := tx.Find(model, "template_id = ?", newModel.TemplateID).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	mask := req.InputMask
+	if mask == nil {
+		mask = &datastore.EntryTemplateMask{
+			SpiffeIdPath:     true,
+			Ttl:              true,
+			FederatesWith:    true,
+			DnsNameTemplates: true,
+		}
+	}
+	if mask.SpiffeIdPath {
+		model.SPIFFEIDPath = newModel.SPIFFEIDPath
+	}
+	if mask.Ttl {
+		model.TTL = newModel.TTL
+	}
+	if mask.FederatesWith {
+		model.FederatesWith = newModel.FederatesWith
+	}
+	if mask.DnsNameTemplates {
+		model.DNSNameTemplates = newModel.DNSNameTemplates
+	}
+
+	if err := tx.Save(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	// Propagate the template to every entry referencing it. This happens in
+	// the same transaction so the entries never observe a partially applied
+	// template.
+	var entries []RegisteredEntry
+	if err := tx.Find(&entries, "template_id = ?", model.TemplateID).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	for i := range entries {
+		entry := &entries[i]
+		federatesWith, err := applyEntryTemplate(tx, entry, model)
+		if err != nil {
+			return nil, err
+		}
+		entry.RevisionNumber++
+		if err := tx.Save(entry).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if err := tx.Model(entry).Association("FederatesWith").Replace(federatesWith).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	template, err := modelToEntryTemplate(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.UpdateEntryTemplateResponse{
+		EntryTemplate:  template,
+		EntriesUpdated: int32(len(entries)),
+	}, nil
+}
+
+func deleteEntryTemplate(tx *gorm.DB, req *datastore.DeleteEntryTemplateRequest) (*datastore.DeleteEntryTemplateResponse, error) {
+	model := new(EntryTemplate)
+	if err := tx.Find(model, "template_id = ?", req.TemplateId).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	var count int
+	if err := tx.Model(&RegisteredEntry{}).Where("template_id = ?", model.TemplateID).Count(&count).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if count > 0 {
+		return nil, status.Newf(codes.FailedPrecondition, "datastore-sql: entry template %q is referenced by %d registration entries", model.TemplateID, count).Err()
+	}
+
+	if err := tx.Delete(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	template, err := modelToEntryTemplate(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.DeleteEntryTemplateResponse{
+		EntryTemplate: template,
+	}, nil
+}
+
+// fetchEntryTemplateForEntry fetches the template a registration entry
+// references. It fails with FailedPrecondition if the template does not
+// exist.
+func fetchEntryTemplateForEntry(tx *gorm.DB, templateID string) (*EntryTemplate, error) {
+	model := new(EntryTemplate)
+	err := tx.Find(model, "template_id = ?", templateID).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return nil, status.Newf(codes.FailedPrecondition, "datastore-sql: entry template %q does not exist", templateID).Err()
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+	return model, nil
+}
+
+// applyEntryTemplate overrides the TTL and DNS name templates of the entry
+// with those of the template, and returns the federated bundles the entry
+// must be associated with. It fails with FailedPrecondition if the SPIFFE ID
+// of the entry does not have the shape the template requires.
+func applyEntryTemplate(tx *gorm.DB, entry *RegisteredEntry, template *EntryTemplate) ([]*Bundle, error) {
+	if !matchesEntryTemplatePath(entry.SpiffeID, template.SPIFFEIDPath) {
+		return nil, status.Newf(codes.FailedPrecondition, "datastore-sql: SPIFFE ID %q of entry does not match path %q of entry template %q", entry.SpiffeID, template.SPIFFEIDPath, template.TemplateID).Err()
+	}
+
+	entry.TTL = template.TTL
+	entry.DNSNameTemplates = template.DNSNameTemplates
+
+	federatesWith, err := unmarshalEntryTemplateFederatesWith(template.FederatesWith)
+	if err != nil {
+		return nil, err
+	}
+	bundles, err := makeFederatesWith(tx, federatesWith)
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return bundles, nil
+}
+
+// matchesEntryTemplatePath returns whether the path of the SPIFFE ID has the
+// shape of the template path, where a "*" segment matches any single
+// segment. An empty template path matches any SPIFFE ID.
+func matchesEntryTemplatePath(spiffeID, templatePath string) bool {
+	if templatePath == "" {
+		return true
+	}
+	u, err := url.Parse(spiffeID)
+	if err != nil {
+		return false
+	}
+	segments := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	patterns := strings.Split(strings.TrimPrefix(templatePath, "/"), "/")
+	if len(segments) != len(patterns) {
+		return false
+	}
+	for i, pattern := range patterns {
+		if pattern != "*" && pattern != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
+// include any embedded CACert models.
+func modelToBundle(model *Bundle) (*common.Bundle, error) {
+	bundle := new(common.Bundle)
+	if err := proto.Unmarshal(model.Data, bundle); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return bundle, nil
+}
+
+func validateRegistrationEntry(entry *common.RegistrationEntry) error {
+	if entry == nil {
+		return sqlError.New("invalid request: missing registered entry")
+	}
+
+	if entry.Selectors == nil || len(entry.Selectors) == 0 {
+		return sqlError.New("invalid registration entry: missing selector list")
+	}
+
+	if len(entry.SpiffeId) == 0 {
+		return sqlError.New("invalid registration entry: missing SPIFFE ID")
+	}
 
 	if entry.Ttl < 0 {
 		return sqlError.New("invalid registration entry: TTL is not set")
@@ -3194,21 +4011,142 @@ func modelToEntry(tx *gorm.DB, model RegisteredEntry) (*common.RegistrationEntry
 		federatesWith = append(federatesWith, bundle.TrustDomain)
 	}
 
+	jwtSVIDClaims, err := unmarshalJWTSVIDClaims(model.JWTSVIDClaims)
+	if err != nil {
+		return nil, err
+	}
+	jwtSVIDAudience, err := unmarshalJWTSVIDAudience(model.JWTSVIDAudience)
+	if err != nil {
+		return nil, err
+	}
+	dnsNameTemplates, err := unmarshalDNSNameTemplates(model.DNSNameTemplates)
+	if err != nil {
+		return nil, err
+	}
+	x509SVIDSubject, err := unmarshalX509SVIDSubject(model.X509SVIDSubject)
+	if err != nil {
+		return nil, err
+	}
+
 	return &common.RegistrationEntry{
-		EntryId:        model.EntryID,
-		Selectors:      selectors,
-		SpiffeId:       model.SpiffeID,
-		ParentId:       model.ParentID,
-		Ttl:            model.TTL,
-		FederatesWith:  federatesWith,
-		Admin:          model.Admin,
-		Downstream:     model.Downstream,
-		EntryExpiry:    model.Expiry,
-		DnsNames:       dnsList,
-		RevisionNumber: model.RevisionNumber,
+		EntryId:            model.EntryID,
+		Selectors:          selectors,
+		SpiffeId:           model.SpiffeID,
+		ParentId:           model.ParentID,
+		Ttl:                model.TTL,
+		FederatesWith:      federatesWith,
+		Admin:              model.Admin,
+		Downstream:         model.Downstream,
+		EntryExpiry:        model.Expiry,
+		DnsNames:           dnsList,
+		RevisionNumber:     model.RevisionNumber,
+		JwtSvidClaims:      jwtSVIDClaims,
+		JwtSvidAudience:    jwtSVIDAudience,
+		X509SvidKeyType:    model.X509SVIDKeyType,
+		DnsNameTemplates:   dnsNameTemplates,
+		X509SvidSubject:    x509SVIDSubject,
+		Hint:               model.Hint,
+		SelectorExpression: model.SelectorExpression,
+		TemplateId:         model.TemplateID,
 	}, nil
 }
 
+// marshalJWTSVIDClaims encodes the JWT-SVID claims of an entry as a JSON
+// object. Entries without claims are stored as an empty string.
+func marshalJWTSVIDClaims(claims map[string]string) (string, error) {
+	if len(claims) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return "", sqlError.Wrap(err)
+	}
+	return string(data), nil
+}
+
+func unmarshalJWTSVIDClaims(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var claims map[string]string
+	if err := json.Unmarshal([]byte(s), &claims); err != nil {
+		return nil, sqlError.New("unable to unmarshal JWT-SVID claims: %v", err)
+	}
+	return claims, nil
+}
+
+// marshalJWTSVIDAudience encodes the JWT-SVID audience of an entry as a JSON
+// array. Entries without audience are stored as an empty string.
+func marshalJWTSVIDAudience(audience []string) (string, error) {
+	if len(audience) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(audience)
+	if err != nil {
+		return "", sqlError.Wrap(err)
+	}
+	return string(data), nil
+}
+
+func unmarshalJWTSVIDAudience(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var audience []string
+	if err := json.Unmarshal([]byte(s), &audience); err != nil {
+		return nil, sqlError.New("unable to unmarshal JWT-SVID audience: %v", err)
+	}
+	return audience, nil
+}
+
+// marshalDNSNameTemplates encodes the DNS name templates of an entry as a
+// JSON array. Entries without templates are stored as an empty string.
+func marshalDNSNameTemplates(templates []string) (string, error) {
+	if len(templates) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(templates)
+	if err != nil {
+		return "", sqlError.Wrap(err)
+	}
+	return string(data), nil
+}
+
+func unmarshalDNSNameTemplates(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var templates []string
+	if err := json.Unmarshal([]byte(s), &templates); err != nil {
+		return nil, sqlError.New("unable to unmarshal DNS name templates: %v", err)
+	}
+	return templates, nil
+}
+
+// marshalX509SVIDSubject encodes the X509-SVID subject of an entry as a JSON
+// object. Entries without a subject are stored as an empty string.
+func marshalX509SVIDSubject(subject *common.X509SVIDSubject) (string, error) {
+	if subject == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(subject)
+	if err != nil {
+		return "", sqlError.Wrap(err)
+	}
+	return string(data), nil
+}
+
+func unmarshalX509SVIDSubject(s string) (*common.X509SVIDSubject, error) {
+	if s == "" {
+		return nil, nil
+	}
+	subject := new(common.X509SVIDSubject)
+	if err := json.Unmarshal([]byte(s), subject); err != nil {
+		return nil, sqlError.New("unable to unmarshal X509-SVID subject: %v", err)
+	}
+	return subject, nil
+}
+
 func newRegistrationEntryID() (string, error) {
 	u, err := uuid.NewV4()
 	if err != nil {
@@ -3228,11 +4166,198 @@ func modelToAttestedNode(model AttestedNode) *common.AttestedNode {
 	}
 }
 
-func modelToJoinToken(model JoinToken) *datastore.JoinToken {
+func joinTokenToModel(joinToken *datastore.JoinToken) (*JoinToken, error) {
+	allowedCIDRs, err := marshalJoinTokenAllowedCIDRs(joinToken.AllowedCidrs)
+	if err != nil {
+		return nil, err
+	}
+	selectors, err := marshalJoinTokenSelectors(joinToken.Selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JoinToken{
+		Token:        joinToken.Token,
+		Expiry:       joinToken.Expiry,
+		MaxUses:      joinToken.MaxUses,
+		Uses:         joinToken.Uses,
+		AllowedCIDRs: allowedCIDRs,
+		Selectors:    selectors,
+	}, nil
+}
+
+func modelToJoinToken(model JoinToken) (*datastore.JoinToken, error) {
+	allowedCIDRs, err := unmarshalJoinTokenAllowedCIDRs(model.AllowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	selectors, err := unmarshalJoinTokenSelectors(model.Selectors)
+	if err != nil {
+		return nil, err
+	}
+
 	return &datastore.JoinToken{
-		Token:  model.Token,
-		Expiry: model.Expiry,
+		Token:        model.Token,
+		Expiry:       model.Expiry,
+		MaxUses:      model.MaxUses,
+		Uses:         model.Uses,
+		AllowedCidrs: allowedCIDRs,
+		Selectors:    selectors,
+	}, nil
+}
+
+func marshalJoinTokenAllowedCIDRs(cidrs []string) (string, error) {
+	if len(cidrs) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(cidrs)
+	if err != nil {
+		return "", sqlError.Wrap(err)
+	}
+	return string(data), nil
+}
+
+func unmarshalJoinTokenAllowedCIDRs(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var cidrs []string
+	if err := json.Unmarshal([]byte(s), &cidrs); err != nil {
+		return nil, sqlError.New("unable to unmarshal join token allowed CIDRs: %v", err)
+	}
+	return cidrs, nil
+}
+
+func marshalJoinTokenSelectors(selectors []*common.Selector) (string, error) {
+	if len(selectors) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(selectors)
+	if err != nil {
+		return "", sqlError.Wrap(err)
+	}
+	return string(data), nil
+}
+
+func unmarshalJoinTokenSelectors(s string) ([]*common.Selector, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var selectors []*common.Selector
+	if err := json.Unmarshal([]byte(s), &selectors); err != nil {
+		return nil, sqlError.New("unable to unmarshal join token selectors: %v", err)
+	}
+	return selectors, nil
+}
+
+func federationRelationshipToModel(fr *datastore.FederationRelationship) (*FederatedTrustDomain, error) {
+	if fr == nil {
+		return nil, sqlError.New("missing federation relationship")
+	}
+
+	trustDomainID, err := idutil.NormalizeSpiffeID(fr.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
 	}
+
+	return &FederatedTrustDomain{
+		TrustDomain:           trustDomainID,
+		BundleEndpointURL:     fr.BundleEndpointUrl,
+		BundleEndpointProfile: fr.BundleEndpointProfile,
+		EndpointSPIFFEID:      fr.EndpointSpiffeId,
+	}, nil
+}
+
+func modelToFederationRelationship(model *FederatedTrustDomain) *datastore.FederationRelationship {
+	return &datastore.FederationRelationship{
+		TrustDomainId:         model.TrustDomain,
+		BundleEndpointUrl:     model.BundleEndpointURL,
+		BundleEndpointProfile: model.BundleEndpointProfile,
+		EndpointSpiffeId:      model.EndpointSPIFFEID,
+	}
+}
+
+func entryTemplateToModel(tx *gorm.DB, template *datastore.EntryTemplate) (*EntryTemplate, error) {
+	if template == nil {
+		return nil, sqlError.New("missing entry template")
+	}
+	if template.TemplateId == "" {
+		return nil, sqlError.New("missing entry template ID")
+	}
+	if template.SpiffeIdPath != "" && !strings.HasPrefix(template.SpiffeIdPath, "/") {
+		return nil, sqlError.New("entry template SPIFFE ID path %q must start with /", template.SpiffeIdPath)
+	}
+
+	federatesWith := make([]string, 0, len(template.FederatesWith))
+	for _, id := range template.FederatesWith {
+		trustDomainID, err := idutil.NormalizeSpiffeID(id, idutil.AllowAnyTrustDomain())
+		if err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		federatesWith = append(federatesWith, trustDomainID)
+	}
+	// Fail early on unknown trust domains rather than when the template is
+	// applied to an entry
+	if _, err := makeFederatesWith(tx, federatesWith); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	federatesWithJSON, err := marshalEntryTemplateFederatesWith(federatesWith)
+	if err != nil {
+		return nil, err
+	}
+
+	dnsNameTemplates, err := marshalDNSNameTemplates(template.DnsNameTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EntryTemplate{
+		TemplateID:       template.TemplateId,
+		SPIFFEIDPath:     template.SpiffeIdPath,
+		TTL:              template.Ttl,
+		FederatesWith:    federatesWithJSON,
+		DNSNameTemplates: dnsNameTemplates,
+	}, nil
+}
+
+func modelToEntryTemplate(model *EntryTemplate) (*datastore.EntryTemplate, error) {
+	federatesWith, err := unmarshalEntryTemplateFederatesWith(model.FederatesWith)
+	if err != nil {
+		return nil, err
+	}
+	dnsNameTemplates, err := unmarshalDNSNameTemplates(model.DNSNameTemplates)
+	if err != nil {
+		return nil, err
+	}
+	return &datastore.EntryTemplate{
+		TemplateId:       model.TemplateID,
+		SpiffeIdPath:     model.SPIFFEIDPath,
+		Ttl:              model.TTL,
+		FederatesWith:    federatesWith,
+		DnsNameTemplates: dnsNameTemplates,
+	}, nil
+}
+
+func marshalEntryTemplateFederatesWith(ids []string) (string, error) {
+	if len(ids) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return "", sqlError.Wrap(err)
+	}
+	return string(data), nil
+}
+
+func unmarshalEntryTemplateFederatesWith(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var ids []string
+	if err := json.Unmarshal([]byte(s), &ids); err != nil {
+		return nil, sqlError.New("unable to unmarshal entry template federated trust domains: %v", err)
+	}
+	return ids, nil
 }
 
 func makeFederatesWith(tx *gorm.DB, ids []string) ([]*Bundle, error) {
@@ -3287,6 +4412,16 @@ func (cfg *configuration) Validate() error {
 		return errors.New("connection_string must be set")
 	}
 
+	if cfg.DatabaseType != SQLite && (cfg.SQLiteJournalMode != "" || cfg.SQLiteBusyTimeout != "") {
+		return errors.New("sqlite_journal_mode and sqlite_busy_timeout are only supported for sqlite3")
+	}
+
+	if cfg.DatabaseType == SQLite {
+		if _, err := sqliteOptionsFromConfig(cfg); err != nil {
+			return err
+		}
+	}
+
 	if cfg.DatabaseType == MySQL {
 		if err := validateMySQLConfig(cfg, false); err != nil {
 			return err
@@ -183,6 +183,19 @@ func (s *PluginSuite) newPlugin() datastore.Plugin {
 	return ds
 }
 
+func (s *PluginSuite) TestLatencyHealthCheck() {
+	checks := s.sqlPlugin.HealthChecks()
+	s.Require().Contains(checks, "latency")
+
+	details, err := checks["latency"]()
+	s.Require().NoError(err)
+	s.Require().Contains(details, "latency")
+
+	s.Require().NoError(s.sqlPlugin.db.raw.Close())
+	_, err = checks["latency"]()
+	s.Require().EqualError(err, "unable to query the database: sql: database is closed")
+}
+
 func (s *PluginSuite) TestInvalidPluginConfiguration() {
 	_, err := s.ds.Configure(context.Background(), &spi.ConfigureRequest{
 		Configuration: `
@@ -551,10 +564,69 @@ func (s *PluginSuite) TestCountAttestedNodes() {
 	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node2})
 	s.Require().NoError(err)
 
+	node3 := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/baz",
+		AttestationDataType: "t1",
+		CertNotAfter:        time.Now().Add(3 * time.Hour).Unix(),
+	}
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node3})
+	s.Require().NoError(err)
+
 	// Count all
 	resp, err = s.ds.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{})
 	s.Require().NoError(err)
-	spiretest.RequireProtoEqual(s.T(), &datastore.CountAttestedNodesResponse{Nodes: 2}, resp)
+	spiretest.RequireProtoEqual(s.T(), &datastore.CountAttestedNodesResponse{Nodes: 3}, resp)
+
+	for _, tt := range []struct {
+		name   string
+		req    *datastore.CountAttestedNodesRequest
+		expect int32
+	}{
+		{
+			name:   "by attestation type",
+			req:    &datastore.CountAttestedNodesRequest{ByAttestationType: "t1"},
+			expect: 2,
+		},
+		{
+			name:   "by banned",
+			req:    &datastore.CountAttestedNodesRequest{ByBanned: &wrappers.BoolValue{Value: true}},
+			expect: 1,
+		},
+		{
+			name:   "by not banned",
+			req:    &datastore.CountAttestedNodesRequest{ByBanned: &wrappers.BoolValue{Value: false}},
+			expect: 2,
+		},
+		{
+			name: "by expires before",
+			req: &datastore.CountAttestedNodesRequest{
+				ByExpiresBefore: &wrappers.Int64Value{Value: time.Now().Add(2 * time.Hour).Unix()},
+			},
+			expect: 2,
+		},
+		{
+			name: "by expires after",
+			req: &datastore.CountAttestedNodesRequest{
+				ByExpiresAfter: &wrappers.Int64Value{Value: time.Now().Add(2 * time.Hour).Unix()},
+			},
+			expect: 1,
+		},
+		{
+			name: "by attestation type and expires before",
+			req: &datastore.CountAttestedNodesRequest{
+				ByAttestationType: "t1",
+				ByExpiresBefore:   &wrappers.Int64Value{Value: time.Now().Add(2 * time.Hour).Unix()},
+			},
+			expect: 1,
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.CountAttestedNodes(ctx, tt.req)
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, &datastore.CountAttestedNodesResponse{Nodes: tt.expect}, resp)
+		})
+	}
 }
 
 func (s *PluginSuite) TestCountRegistrationEntries() {
@@ -938,6 +1010,15 @@ func (s *PluginSuite) TestFetchAttestedNodesWithPagination() {
 			},
 			expectedList: []*common.AttestedNode{aNode1, aNode3, aNode4, aNode5},
 		},
+		{
+			name: "get nodes by expire after no pagination",
+			req: &datastore.ListAttestedNodesRequest{
+				ByExpiresAfter: &wrappers.Int64Value{
+					Value: time.Now().Unix(),
+				},
+			},
+			expectedList: []*common.AttestedNode{aNode2},
+		},
 		{
 			name: "get nodes by expire before get only page first page",
 			req: &datastore.ListAttestedNodesRequest{
@@ -2010,6 +2091,44 @@ func (s *PluginSuite) TestUpdateRegistrationEntry() {
 	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
 }
 
+func (s *PluginSuite) TestUpdateRegistrationEntryRevisionNumber() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+		Ttl:       1,
+	})
+	mask := &common.RegistrationEntryMask{Ttl: true, RevisionNumber: true}
+
+	// The update succeeds when the revision number is current
+	entry.Ttl = 2
+	resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry,
+		Mask:  mask,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), resp.Entry.RevisionNumber)
+
+	// A stale revision number is rejected and the entry is left unchanged
+	entry.Ttl = 3
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry,
+		Mask:  mask,
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, "datastore-sql: entry revision number 0 does not match the current revision number 1")
+
+	fetchResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), fetchResp.Entry.Ttl)
+
+	// The revision number is not checked unless the mask says so
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry,
+		Mask:  &common.RegistrationEntryMask{Ttl: true},
+	})
+	s.Require().NoError(err)
+}
+
 func (s *PluginSuite) TestUpdateRegistrationEntryWithMask() {
 	// There are 9 fields in a registration entry. Of these, 3 have some validation in the SQL
 	// layer. In this test, we update each of the 9 fields and make sure update works, and also check
@@ -2018,26 +2137,40 @@ func (s *PluginSuite) TestUpdateRegistrationEntryWithMask() {
 
 	// Note that most of the input validation is done in the API layer and has more extensive tests there.
 	oldEntry := common.RegistrationEntry{
-		ParentId:      "spiffe://example.org/oldParentId",
-		SpiffeId:      "spiffe://example.org/oldSpiffeId",
-		Ttl:           1000,
-		Selectors:     []*common.Selector{{Type: "Type1", Value: "Value1"}},
-		FederatesWith: []string{"spiffe://dom1.org"},
-		Admin:         false,
-		EntryExpiry:   1000,
-		DnsNames:      []string{"dns1"},
-		Downstream:    false,
+		ParentId:           "spiffe://example.org/oldParentId",
+		SpiffeId:           "spiffe://example.org/oldSpiffeId",
+		Ttl:                1000,
+		Selectors:          []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		FederatesWith:      []string{"spiffe://dom1.org"},
+		Admin:              false,
+		EntryExpiry:        1000,
+		DnsNames:           []string{"dns1"},
+		Downstream:         false,
+		JwtSvidClaims:      map[string]string{"role": "reader"},
+		JwtSvidAudience:    []string{"aud1"},
+		X509SvidKeyType:    "ec-p256",
+		DnsNameTemplates:   []string{"{{ index .PathSegments 0 }}.old"},
+		X509SvidSubject:    &common.X509SVIDSubject{CommonName: "old"},
+		Hint:               "internal",
+		SelectorExpression: "!unix:uid:0",
 	}
 	newEntry := common.RegistrationEntry{
-		ParentId:      "spiffe://example.org/oldParentId",
-		SpiffeId:      "spiffe://example.org/newSpiffeId",
-		Ttl:           1000,
-		Selectors:     []*common.Selector{{Type: "Type2", Value: "Value2"}},
-		FederatesWith: []string{"spiffe://dom2.org"},
-		Admin:         false,
-		EntryExpiry:   1000,
-		DnsNames:      []string{"dns2"},
-		Downstream:    false,
+		ParentId:           "spiffe://example.org/oldParentId",
+		SpiffeId:           "spiffe://example.org/newSpiffeId",
+		Ttl:                1000,
+		Selectors:          []*common.Selector{{Type: "Type2", Value: "Value2"}},
+		FederatesWith:      []string{"spiffe://dom2.org"},
+		Admin:              false,
+		EntryExpiry:        1000,
+		DnsNames:           []string{"dns2"},
+		Downstream:         false,
+		JwtSvidClaims:      map[string]string{"role": "writer", "tenant": "acme"},
+		JwtSvidAudience:    []string{"aud2", "aud3"},
+		X509SvidKeyType:    "rsa-2048",
+		DnsNameTemplates:   []string{"{{ index .PathSegments 0 }}.new"},
+		X509SvidSubject:    &common.X509SVIDSubject{CommonName: "new", Organization: []string{"ACME"}},
+		Hint:               "external",
+		SelectorExpression: "unix:gid:1000 || unix:gid:1001",
 	}
 	badEntry := common.RegistrationEntry{
 		ParentId:      "not a good parent id",
@@ -2165,6 +2298,77 @@ func (s *PluginSuite) TestUpdateRegistrationEntryWithMask() {
 			mask:   &common.RegistrationEntryMask{Downstream: false},
 			update: func(e *common.RegistrationEntry) { e.Downstream = newEntry.Downstream },
 			result: func(e *common.RegistrationEntry) {}},
+		/// JWTSVIDCLAIMS FIELD -- This field isn't validated so we just check with good data
+		{name: "Update JwtSvidClaims, Good Data, Mask True",
+			mask:   &common.RegistrationEntryMask{JwtSvidClaims: true},
+			update: func(e *common.RegistrationEntry) { e.JwtSvidClaims = newEntry.JwtSvidClaims },
+			result: func(e *common.RegistrationEntry) { e.JwtSvidClaims = newEntry.JwtSvidClaims }},
+		{name: "Update JwtSvidClaims, Good Data, Mask False",
+			mask:   &common.RegistrationEntryMask{JwtSvidClaims: false},
+			update: func(e *common.RegistrationEntry) { e.JwtSvidClaims = newEntry.JwtSvidClaims },
+			result: func(e *common.RegistrationEntry) {}},
+		{name: "Clear JwtSvidClaims, Mask True",
+			mask:   &common.RegistrationEntryMask{JwtSvidClaims: true},
+			update: func(e *common.RegistrationEntry) { e.JwtSvidClaims = nil },
+			result: func(e *common.RegistrationEntry) { e.JwtSvidClaims = nil }},
+		/// JWTSVIDAUDIENCE FIELD -- This field isn't validated so we just check with good data
+		{name: "Update JwtSvidAudience, Good Data, Mask True",
+			mask:   &common.RegistrationEntryMask{JwtSvidAudience: true},
+			update: func(e *common.RegistrationEntry) { e.JwtSvidAudience = newEntry.JwtSvidAudience },
+			result: func(e *common.RegistrationEntry) { e.JwtSvidAudience = newEntry.JwtSvidAudience }},
+		{name: "Update JwtSvidAudience, Good Data, Mask False",
+			mask:   &common.RegistrationEntryMask{JwtSvidAudience: false},
+			update: func(e *common.RegistrationEntry) { e.JwtSvidAudience = newEntry.JwtSvidAudience },
+			result: func(e *common.RegistrationEntry) {}},
+		/// X509SVIDKEYTYPE FIELD -- This field is validated in the API layer so we just check with good data
+		{name: "Update X509SvidKeyType, Good Data, Mask True",
+			mask:   &common.RegistrationEntryMask{X509SvidKeyType: true},
+			update: func(e *common.RegistrationEntry) { e.X509SvidKeyType = newEntry.X509SvidKeyType },
+			result: func(e *common.RegistrationEntry) { e.X509SvidKeyType = newEntry.X509SvidKeyType }},
+		{name: "Update X509SvidKeyType, Good Data, Mask False",
+			mask:   &common.RegistrationEntryMask{X509SvidKeyType: false},
+			update: func(e *common.RegistrationEntry) { e.X509SvidKeyType = newEntry.X509SvidKeyType },
+			result: func(e *common.RegistrationEntry) {}},
+		/// DNSNAMETEMPLATES FIELD -- This field is validated in the API layer so we just check with good data
+		{name: "Update DnsNameTemplates, Good Data, Mask True",
+			mask:   &common.RegistrationEntryMask{DnsNameTemplates: true},
+			update: func(e *common.RegistrationEntry) { e.DnsNameTemplates = newEntry.DnsNameTemplates },
+			result: func(e *common.RegistrationEntry) { e.DnsNameTemplates = newEntry.DnsNameTemplates }},
+		{name: "Update DnsNameTemplates, Good Data, Mask False",
+			mask:   &common.RegistrationEntryMask{DnsNameTemplates: false},
+			update: func(e *common.RegistrationEntry) { e.DnsNameTemplates = newEntry.DnsNameTemplates },
+			result: func(e *common.RegistrationEntry) {}},
+		/// X509SVIDSUBJECT FIELD -- This field isn't validated so we just check with good data
+		{name: "Update X509SvidSubject, Good Data, Mask True",
+			mask:   &common.RegistrationEntryMask{X509SvidSubject: true},
+			update: func(e *common.RegistrationEntry) { e.X509SvidSubject = newEntry.X509SvidSubject },
+			result: func(e *common.RegistrationEntry) { e.X509SvidSubject = newEntry.X509SvidSubject }},
+		{name: "Update X509SvidSubject, Good Data, Mask False",
+			mask:   &common.RegistrationEntryMask{X509SvidSubject: false},
+			update: func(e *common.RegistrationEntry) { e.X509SvidSubject = newEntry.X509SvidSubject },
+			result: func(e *common.RegistrationEntry) {}},
+		{name: "Clear X509SvidSubject, Mask True",
+			mask:   &common.RegistrationEntryMask{X509SvidSubject: true},
+			update: func(e *common.RegistrationEntry) { e.X509SvidSubject = nil },
+			result: func(e *common.RegistrationEntry) { e.X509SvidSubject = nil }},
+		/// HINT FIELD -- This field isn't validated so we just check with good data
+		{name: "Update Hint, Good Data, Mask True",
+			mask:   &common.RegistrationEntryMask{Hint: true},
+			update: func(e *common.RegistrationEntry) { e.Hint = newEntry.Hint },
+			result: func(e *common.RegistrationEntry) { e.Hint = newEntry.Hint }},
+		{name: "Update Hint, Good Data, Mask False",
+			mask:   &common.RegistrationEntryMask{Hint: false},
+			update: func(e *common.RegistrationEntry) { e.Hint = newEntry.Hint },
+			result: func(e *common.RegistrationEntry) {}},
+		/// SELECTOR EXPRESSION FIELD -- This field isn't validated so we just check with good data
+		{name: "Update Selector Expression, Good Data, Mask True",
+			mask:   &common.RegistrationEntryMask{SelectorExpression: true},
+			update: func(e *common.RegistrationEntry) { e.SelectorExpression = newEntry.SelectorExpression },
+			result: func(e *common.RegistrationEntry) { e.SelectorExpression = newEntry.SelectorExpression }},
+		{name: "Update Selector Expression, Good Data, Mask False",
+			mask:   &common.RegistrationEntryMask{SelectorExpression: false},
+			update: func(e *common.RegistrationEntry) { e.SelectorExpression = newEntry.SelectorExpression },
+			result: func(e *common.RegistrationEntry) {}},
 		// This should update all fields
 		{name: "Test With Nil Mask",
 			mask:   nil,
@@ -2525,6 +2729,77 @@ func (s *PluginSuite) TestCreateAndFetchJoinToken() {
 	s.Equal(now, res.JoinToken.Expiry)
 }
 
+func (s *PluginSuite) TestCreateAndFetchJoinTokenWithRestrictions() {
+	joinToken := &datastore.JoinToken{
+		Token:        "foobar",
+		Expiry:       time.Now().Unix(),
+		MaxUses:      3,
+		AllowedCidrs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+		Selectors: []*common.Selector{
+			{Type: "join_token", Value: "asg:web"},
+		},
+	}
+
+	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: joinToken,
+	})
+	s.Require().NoError(err)
+
+	res, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: joinToken.Token,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(joinToken, res.JoinToken)
+}
+
+func (s *PluginSuite) TestUseJoinToken() {
+	now := time.Now().Unix()
+	for _, joinToken := range []*datastore.JoinToken{
+		{Token: "single", Expiry: now},
+		{Token: "multi", Expiry: now, MaxUses: 2},
+	} {
+		_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+			JoinToken: joinToken,
+		})
+		s.Require().NoError(err)
+	}
+
+	// A token without a maximum number of uses is deleted when used
+	resp, err := s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: "single"})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(&datastore.JoinToken{Token: "single", Expiry: now, Uses: 1}, resp.JoinToken)
+
+	resp, err = s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: "single"})
+	s.Require().NoError(err)
+	s.Nil(resp.JoinToken)
+
+	// A token is kept until it reaches its maximum number of uses
+	resp, err = s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: "multi"})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(&datastore.JoinToken{Token: "multi", Expiry: now, MaxUses: 2, Uses: 1}, resp.JoinToken)
+
+	fetchResp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: "multi"})
+	s.Require().NoError(err)
+	s.Equal(int32(1), fetchResp.JoinToken.Uses)
+
+	resp, err = s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: "multi"})
+	s.Require().NoError(err)
+	s.Equal(int32(2), resp.JoinToken.Uses)
+
+	fetchResp, err = s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{Token: "multi"})
+	s.Require().NoError(err)
+	s.Nil(fetchResp.JoinToken)
+
+	resp, err = s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: "multi"})
+	s.Require().NoError(err)
+	s.Nil(resp.JoinToken)
+
+	// Tokens that do not exist cannot be used
+	resp, err = s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{Token: "unknown"})
+	s.Require().NoError(err)
+	s.Nil(resp.JoinToken)
+}
+
 func (s *PluginSuite) TestDeleteJoinToken() {
 	now := time.Now().Unix()
 	joinToken1 := &datastore.JoinToken{
@@ -2617,6 +2892,445 @@ func (s *PluginSuite) TestPruneJoinTokens() {
 	s.Nil(resp.JoinToken)
 }
 
+func (s *PluginSuite) TestCreateAndFetchFederationRelationship() {
+	fr := &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://otherdomain.org",
+		BundleEndpointUrl:     "https://otherdomain.org/bundle",
+		BundleEndpointProfile: "https_spiffe",
+		EndpointSpiffeId:      "spiffe://otherdomain.org/bundle-server",
+	}
+
+	resp, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: fr,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(fr, resp.FederationRelationship)
+
+	// Make sure we can't create it twice
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: fr,
+	})
+	s.Equal(codes.AlreadyExists, status.Code(err))
+
+	fetchResp, err := s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: "spiffe://OTHERDOMAIN.org",
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(fr, fetchResp.FederationRelationship)
+
+	fetchResp, err = s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: "spiffe://unknown.org",
+	})
+	s.Require().NoError(err)
+	s.Nil(fetchResp.FederationRelationship)
+}
+
+func (s *PluginSuite) TestCreateInvalidFederationRelationship() {
+	_, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{})
+	s.EqualError(err, "rpc error: code = Unknown desc = datastore-sql: missing federation relationship")
+
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: &datastore.FederationRelationship{
+			TrustDomainId:     "spiffe://otherdomain.org/path",
+			BundleEndpointUrl: "https://otherdomain.org/bundle",
+		},
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "path is not empty")
+
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: &datastore.FederationRelationship{
+			TrustDomainId: "spiffe://otherdomain.org",
+		},
+	})
+	s.EqualError(err, "rpc error: code = Unknown desc = datastore-sql: missing bundle endpoint URL")
+}
+
+func (s *PluginSuite) TestListFederationRelationships() {
+	fr1 := s.createFederationRelationship("spiffe://a.org")
+	fr2 := s.createFederationRelationship("spiffe://b.org")
+	fr3 := s.createFederationRelationship("spiffe://c.org")
+
+	resp, err := s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.FederationRelationship{fr1, fr2, fr3}, resp.FederationRelationships)
+	s.Nil(resp.Pagination)
+
+	pagination := &datastore.Pagination{PageSize: 2}
+	resp, err = s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{
+		Pagination: pagination,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.FederationRelationship{fr1, fr2}, resp.FederationRelationships)
+	s.Require().NotEmpty(resp.Pagination.Token)
+
+	resp, err = s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{
+		Pagination: resp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.FederationRelationship{fr3}, resp.FederationRelationships)
+
+	resp, err = s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{
+		Pagination: resp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.Empty(resp.FederationRelationships)
+	s.Empty(resp.Pagination.Token)
+
+	_, err = s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{
+		Pagination: &datastore.Pagination{},
+	})
+	s.RequireGRPCStatus(err, codes.InvalidArgument, "cannot paginate with pagesize = 0")
+}
+
+func (s *PluginSuite) TestUpdateFederationRelationship() {
+	s.createFederationRelationship("spiffe://otherdomain.org")
+
+	update := &datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://otherdomain.org",
+		BundleEndpointUrl:     "https://new.otherdomain.org/bundle",
+		BundleEndpointProfile: "https_web",
+	}
+
+	// Only the URL is updated
+	resp, err := s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+		FederationRelationship: update,
+		InputMask:              &datastore.FederationRelationshipMask{BundleEndpointUrl: true},
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(&datastore.FederationRelationship{
+		TrustDomainId:         "spiffe://otherdomain.org",
+		BundleEndpointUrl:     "https://new.otherdomain.org/bundle",
+		BundleEndpointProfile: "https_spiffe",
+		EndpointSpiffeId:      "spiffe://otherdomain.org/spire/server",
+	}, resp.FederationRelationship)
+
+	// Everything is updated without a mask
+	resp, err = s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+		FederationRelationship: update,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(update, resp.FederationRelationship)
+
+	fetchResp, err := s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(update, fetchResp.FederationRelationship)
+
+	// Relationship does not exist
+	update.TrustDomainId = "spiffe://unknown.org"
+	_, err = s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+		FederationRelationship: update,
+	})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+}
+
+func (s *PluginSuite) TestDeleteFederationRelationship() {
+	fr := s.createFederationRelationship("spiffe://otherdomain.org")
+
+	resp, err := s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(fr, resp.FederationRelationship)
+
+	fetchResp, err := s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.Nil(fetchResp.FederationRelationship)
+
+	_, err = s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+}
+
+func (s *PluginSuite) TestCreateAndFetchEntryTemplate() {
+	s.createBundle("spiffe://otherdomain.org")
+
+	template := &datastore.EntryTemplate{
+		TemplateId:       "web",
+		SpiffeIdPath:     "/ns/*/sa/*",
+		Ttl:              60,
+		FederatesWith:    []string{"spiffe://otherdomain.org"},
+		DnsNameTemplates: []string{"{{ .SPIFFEID.Path }}.example.org"},
+	}
+
+	resp, err := s.ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: template,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(template, resp.EntryTemplate)
+
+	// Make sure we can't create it twice
+	_, err = s.ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: template,
+	})
+	s.Equal(codes.AlreadyExists, status.Code(err))
+
+	fetchResp, err := s.ds.FetchEntryTemplate(ctx, &datastore.FetchEntryTemplateRequest{
+		TemplateId: "web",
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(template, fetchResp.EntryTemplate)
+
+	fetchResp, err = s.ds.FetchEntryTemplate(ctx, &datastore.FetchEntryTemplateRequest{
+		TemplateId: "unknown",
+	})
+	s.Require().NoError(err)
+	s.Nil(fetchResp.EntryTemplate)
+}
+
+func (s *PluginSuite) TestCreateInvalidEntryTemplate() {
+	_, err := s.ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{})
+	s.EqualError(err, "rpc error: code = Unknown desc = datastore-sql: missing entry template")
+
+	_, err = s.ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: &datastore.EntryTemplate{Ttl: 60},
+	})
+	s.EqualError(err, "rpc error: code = Unknown desc = datastore-sql: missing entry template ID")
+
+	_, err = s.ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: &datastore.EntryTemplate{TemplateId: "web", SpiffeIdPath: "ns/*"},
+	})
+	s.EqualError(err, `rpc error: code = Unknown desc = datastore-sql: entry template SPIFFE ID path "ns/*" must start with /`)
+
+	_, err = s.ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: &datastore.EntryTemplate{TemplateId: "web", FederatesWith: []string{"spiffe://unknown.org"}},
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), `unable to find federated bundle "spiffe://unknown.org"`)
+}
+
+func (s *PluginSuite) TestListEntryTemplates() {
+	t1 := s.createEntryTemplate(&datastore.EntryTemplate{TemplateId: "a", Ttl: 1})
+	t2 := s.createEntryTemplate(&datastore.EntryTemplate{TemplateId: "b", Ttl: 2})
+	t3 := s.createEntryTemplate(&datastore.EntryTemplate{TemplateId: "c", Ttl: 3})
+
+	resp, err := s.ds.ListEntryTemplates(ctx, &datastore.ListEntryTemplatesRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.EntryTemplate{t1, t2, t3}, resp.EntryTemplates)
+	s.Nil(resp.Pagination)
+
+	resp, err = s.ds.ListEntryTemplates(ctx, &datastore.ListEntryTemplatesRequest{
+		Pagination: &datastore.Pagination{PageSize: 2},
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.EntryTemplate{t1, t2}, resp.EntryTemplates)
+	s.Require().NotEmpty(resp.Pagination.Token)
+
+	resp, err = s.ds.ListEntryTemplates(ctx, &datastore.ListEntryTemplatesRequest{
+		Pagination: resp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.EntryTemplate{t3}, resp.EntryTemplates)
+
+	resp, err = s.ds.ListEntryTemplates(ctx, &datastore.ListEntryTemplatesRequest{
+		Pagination: resp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.Empty(resp.EntryTemplates)
+	s.Empty(resp.Pagination.Token)
+}
+
+func (s *PluginSuite) TestCreateRegistrationEntryWithEntryTemplate() {
+	s.createBundle("spiffe://otherdomain.org")
+	s.createEntryTemplate(&datastore.EntryTemplate{
+		TemplateId:       "web",
+		SpiffeIdPath:     "/ns/*/sa/*",
+		Ttl:              60,
+		FederatesWith:    []string{"spiffe://otherdomain.org"},
+		DnsNameTemplates: []string{"web.example.org"},
+	})
+
+	// The template overrides the TTL, federated trust domains and DNS name
+	// templates of the entry
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:   "spiffe://example.org/ns/prod/sa/web",
+		ParentId:   "spiffe://example.org/node",
+		Selectors:  []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		Ttl:        3600,
+		TemplateId: "web",
+	})
+	s.Equal("web", entry.TemplateId)
+	s.Equal(int32(60), entry.Ttl)
+	s.Equal([]string{"spiffe://otherdomain.org"}, entry.FederatesWith)
+	s.Equal([]string{"web.example.org"}, entry.DnsNameTemplates)
+	s.AssertProtoEqual(entry, s.fetchRegistrationEntry(entry.EntryId))
+
+	// The SPIFFE ID must have the shape of the template
+	_, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			SpiffeId:   "spiffe://example.org/ns/prod/web",
+			ParentId:   "spiffe://example.org/node",
+			Selectors:  []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			TemplateId: "web",
+		},
+	})
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, `datastore-sql: SPIFFE ID "spiffe://example.org/ns/prod/web" of entry does not match path "/ns/*/sa/*" of entry template "web"`)
+
+	// The template must exist
+	_, err = s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			SpiffeId:   "spiffe://example.org/ns/prod/sa/web",
+			ParentId:   "spiffe://example.org/node",
+			Selectors:  []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			TemplateId: "unknown",
+		},
+	})
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, `datastore-sql: entry template "unknown" does not exist`)
+}
+
+func (s *PluginSuite) TestUpdateEntryTemplate() {
+	s.createBundle("spiffe://otherdomain.org")
+	s.createEntryTemplate(&datastore.EntryTemplate{
+		TemplateId:   "web",
+		SpiffeIdPath: "/ns/*/sa/*",
+		Ttl:          60,
+	})
+
+	var entries []*common.RegistrationEntry
+	for _, ns := range []string{"dev", "prod"} {
+		entries = append(entries, s.createRegistrationEntry(&common.RegistrationEntry{
+			SpiffeId:   "spiffe://example.org/ns/" + ns + "/sa/web",
+			ParentId:   "spiffe://example.org/node",
+			Selectors:  []*common.Selector{{Type: "k8s", Value: "ns:" + ns}},
+			TemplateId: "web",
+		}))
+	}
+	untemplated := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/ns/prod/sa/db",
+		ParentId:  "spiffe://example.org/node",
+		Selectors: []*common.Selector{{Type: "k8s", Value: "ns:prod"}},
+		Ttl:       60,
+	})
+
+	// Only the TTL and federated trust domains are updated
+	resp, err := s.ds.UpdateEntryTemplate(ctx, &datastore.UpdateEntryTemplateRequest{
+		EntryTemplate: &datastore.EntryTemplate{
+			TemplateId:    "web",
+			SpiffeIdPath:  "/ignored",
+			Ttl:           120,
+			FederatesWith: []string{"spiffe://otherdomain.org"},
+		},
+		InputMask: &datastore.EntryTemplateMask{Ttl: true, FederatesWith: true},
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(&datastore.EntryTemplate{
+		TemplateId:    "web",
+		SpiffeIdPath:  "/ns/*/sa/*",
+		Ttl:           120,
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+	}, resp.EntryTemplate)
+	s.Equal(int32(2), resp.EntriesUpdated)
+
+	// The entries using the template are updated along with it
+	for _, entry := range entries {
+		updated := s.fetchRegistrationEntry(entry.EntryId)
+		s.Equal(int32(120), updated.Ttl)
+		s.Equal([]string{"spiffe://otherdomain.org"}, updated.FederatesWith)
+		s.Equal(entry.RevisionNumber+1, updated.RevisionNumber)
+	}
+	s.AssertProtoEqual(untemplated, s.fetchRegistrationEntry(untemplated.EntryId))
+
+	// Entries that no longer match the template path fail the whole update
+	_, err = s.ds.UpdateEntryTemplate(ctx, &datastore.UpdateEntryTemplateRequest{
+		EntryTemplate: &datastore.EntryTemplate{
+			TemplateId:   "web",
+			SpiffeIdPath: "/ns/prod/sa/*",
+			Ttl:          30,
+		},
+	})
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, `datastore-sql: SPIFFE ID "spiffe://example.org/ns/dev/sa/web" of entry does not match path "/ns/prod/sa/*" of entry template "web"`)
+	s.Equal(int32(120), s.fetchRegistrationEntry(entries[1].EntryId).Ttl)
+
+	// Template does not exist
+	_, err = s.ds.UpdateEntryTemplate(ctx, &datastore.UpdateEntryTemplateRequest{
+		EntryTemplate: &datastore.EntryTemplate{TemplateId: "unknown"},
+	})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+}
+
+func (s *PluginSuite) TestDeleteEntryTemplate() {
+	template := s.createEntryTemplate(&datastore.EntryTemplate{TemplateId: "web", Ttl: 60})
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:   "spiffe://example.org/web",
+		ParentId:   "spiffe://example.org/node",
+		Selectors:  []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		TemplateId: "web",
+	})
+
+	// Templates in use cannot be deleted
+	_, err := s.ds.DeleteEntryTemplate(ctx, &datastore.DeleteEntryTemplateRequest{
+		TemplateId: "web",
+	})
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, `datastore-sql: entry template "web" is referenced by 1 registration entries`)
+
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entry.EntryId,
+	})
+	s.Require().NoError(err)
+
+	resp, err := s.ds.DeleteEntryTemplate(ctx, &datastore.DeleteEntryTemplateRequest{
+		TemplateId: "web",
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(template, resp.EntryTemplate)
+
+	fetchResp, err := s.ds.FetchEntryTemplate(ctx, &datastore.FetchEntryTemplateRequest{
+		TemplateId: "web",
+	})
+	s.Require().NoError(err)
+	s.Nil(fetchResp.EntryTemplate)
+
+	_, err = s.ds.DeleteEntryTemplate(ctx, &datastore.DeleteEntryTemplateRequest{
+		TemplateId: "web",
+	})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+}
+
+func (s *PluginSuite) TestBackup() {
+	backupPath := filepath.Join(s.dir, "backup.sqlite3")
+
+	_, err := s.ds.Backup(ctx, &datastore.BackupRequest{})
+	s.RequireGRPCStatus(err, codes.InvalidArgument, "backup path is required")
+
+	if TestDialect != "" {
+		_, err = s.ds.Backup(ctx, &datastore.BackupRequest{Path: backupPath})
+		s.RequireGRPCStatus(err, codes.Unimplemented, fmt.Sprintf("backup is not supported for database type %q", TestDialect))
+		return
+	}
+
+	bundle := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cert)
+	s.createBundle("spiffe://foo")
+
+	_, err = s.ds.Backup(ctx, &datastore.BackupRequest{Path: backupPath})
+	s.Require().NoError(err)
+
+	// The backup must be a complete database that can be opened on its own
+	p := New()
+	var backupDS datastore.Plugin
+	s.LoadPlugin(builtin(p), &backupDS)
+	_, err = backupDS.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			database_type = "sqlite3"
+			connection_string = "%s"
+			`, backupPath),
+	})
+	s.Require().NoError(err)
+	defer p.closeDB()
+
+	fetchResp, err := backupDS.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: "spiffe://foo"})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(bundle, fetchResp.Bundle)
+
+	// Existing files are never overwritten
+	_, err = s.ds.Backup(ctx, &datastore.BackupRequest{Path: backupPath})
+	s.RequireGRPCStatus(err, codes.AlreadyExists, fmt.Sprintf("backup file %q already exists", backupPath))
+}
+
 func (s *PluginSuite) TestGetPluginInfo() {
 	resp, err := s.ds.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
 	s.Require().NoError(err)
@@ -2793,26 +3507,26 @@ func (s *PluginSuite) TestMigration() {
 			s.Require().Len(resp.Entries[0].DnsNames, 1)
 			s.Require().Equal("abcd.efg", resp.Entries[0].DnsNames[0])
 		case 8:
-			db, err := openSQLite3(dbURI)
+			db, err := openSQLite3(dbURI, sqliteOptions{})
 			s.Require().NoError(err)
 			s.Require().True(db.Dialect().HasIndex("registered_entries", "idx_registered_entries_parent_id"))
 			s.Require().True(db.Dialect().HasIndex("registered_entries", "idx_registered_entries_spiffe_id"))
 			s.Require().True(db.Dialect().HasIndex("selectors", "idx_selectors_type_value"))
 		case 9:
-			db, err := openSQLite3(dbURI)
+			db, err := openSQLite3(dbURI, sqliteOptions{})
 			s.Require().NoError(err)
 			s.Require().True(db.Dialect().HasIndex("registered_entries", "idx_registered_entries_expiry"))
 		case 10:
-			db, err := openSQLite3(dbURI)
+			db, err := openSQLite3(dbURI, sqliteOptions{})
 			s.Require().NoError(err)
 			s.Require().True(db.Dialect().HasIndex("federated_registration_entries", "idx_federated_registration_entries_registered_entry_id"))
 		case 11:
-			db, err := openSQLite3(dbURI)
+			db, err := openSQLite3(dbURI, sqliteOptions{})
 			s.Require().NoError(err)
 			s.Require().True(db.Dialect().HasColumn("migrations", "code_version"))
 		case 12:
 			// Ensure attested_nodes_entries gained two new columns
-			db, err := openSQLite3(dbURI)
+			db, err := openSQLite3(dbURI, sqliteOptions{})
 			s.Require().NoError(err)
 
 			// Assert attested_node_entries tables gained the new columns
@@ -2836,9 +3550,30 @@ func (s *PluginSuite) TestMigration() {
 		case 13:
 			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "revision_number"))
 		case 14:
-			db, err := openSQLite3(dbURI)
+			db, err := openSQLite3(dbURI, sqliteOptions{})
 			s.Require().NoError(err)
 			s.Require().True(db.Dialect().HasIndex("attested_node_entries", "idx_attested_node_entries_expires_at"))
+		case 15:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("federated_trust_domains"))
+		case 16:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "jwt_svid_claims"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "jwt_svid_audience"))
+		case 17:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "x509_svid_key_type"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "dns_name_templates"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "x509_svid_subject"))
+		case 18:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("join_tokens", "max_uses"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("join_tokens", "uses"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("join_tokens", "allowed_cidrs"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("join_tokens", "selectors"))
+		case 19:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "hint"))
+		case 20:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "selector_expression"))
+		case 21:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("entry_templates"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "template_id"))
 		default:
 			s.T().Fatalf("no migration test added for version %d", i)
 		}
@@ -2902,6 +3637,28 @@ func (s *PluginSuite) createBundle(trustDomainID string) {
 	s.Require().NoError(err)
 }
 
+func (s *PluginSuite) createFederationRelationship(trustDomainID string) *datastore.FederationRelationship {
+	fr := &datastore.FederationRelationship{
+		TrustDomainId:         trustDomainID,
+		BundleEndpointUrl:     "https://" + strings.TrimPrefix(trustDomainID, "spiffe://") + "/bundle",
+		BundleEndpointProfile: "https_spiffe",
+		EndpointSpiffeId:      trustDomainID + "/spire/server",
+	}
+	resp, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		FederationRelationship: fr,
+	})
+	s.Require().NoError(err)
+	return resp.FederationRelationship
+}
+
+func (s *PluginSuite) createEntryTemplate(template *datastore.EntryTemplate) *datastore.EntryTemplate {
+	resp, err := s.ds.CreateEntryTemplate(ctx, &datastore.CreateEntryTemplateRequest{
+		EntryTemplate: template,
+	})
+	s.Require().NoError(err)
+	return resp.EntryTemplate
+}
+
 func (s *PluginSuite) createRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
 	resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
 		Entry: entry,
@@ -3037,6 +3794,95 @@ func (s *PluginSuite) TestConfigure() {
 	}
 }
 
+func (s *PluginSuite) TestConfigureSQLiteSettings() {
+	tests := []struct {
+		desc              string
+		giveDBConfig      string
+		expectJournalMode string
+		expectBusyTimeout string
+		expectErr         string
+	}{
+		{
+			desc:              "defaults",
+			expectJournalMode: "wal",
+			expectBusyTimeout: "5000",
+		},
+		{
+			desc: "custom values",
+			giveDBConfig: `
+			sqlite_journal_mode = "truncate"
+			sqlite_busy_timeout = "30s"
+			`,
+			expectJournalMode: "truncate",
+			expectBusyTimeout: "30000",
+		},
+		{
+			desc:         "invalid journal mode",
+			giveDBConfig: `sqlite_journal_mode = "bogus"`,
+			expectErr:    `invalid sqlite_journal_mode "bogus": must be one of DELETE, TRUNCATE, PERSIST, MEMORY, WAL, OFF`,
+		},
+		{
+			desc:         "invalid busy timeout",
+			giveDBConfig: `sqlite_busy_timeout = "forever"`,
+			expectErr:    `failed to parse sqlite_busy_timeout "forever"`,
+		},
+		{
+			desc:         "negative busy timeout",
+			giveDBConfig: `sqlite_busy_timeout = "-1s"`,
+			expectErr:    "sqlite_busy_timeout must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		s.T().Run(tt.desc, func(t *testing.T) {
+			p := New()
+
+			var ds datastore.Plugin
+			spiretest.LoadPlugin(t, builtin(p), &ds)
+
+			dbPath := filepath.Join(s.dir, "test-datastore-sqlite-settings.sqlite3")
+
+			_, err := ds.Configure(context.Background(), &spi.ConfigureRequest{
+				Configuration: fmt.Sprintf(`
+				database_type = "sqlite3"
+				connection_string = "%s"
+				%s
+			`, dbPath, tt.giveDBConfig),
+			})
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			defer p.closeDB()
+
+			var journalMode, busyTimeout string
+			require.NoError(t, p.db.DB.DB().QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+			require.NoError(t, p.db.DB.DB().QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout))
+			require.Equal(t, tt.expectJournalMode, journalMode)
+			require.Equal(t, tt.expectBusyTimeout, busyTimeout)
+		})
+	}
+}
+
+func (s *PluginSuite) TestConfigureSQLiteSettingsRequireSQLite() {
+	p := New()
+
+	var ds datastore.Plugin
+	s.LoadPlugin(builtin(p), &ds)
+
+	_, err := ds.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+			database_type = "postgres"
+			connection_string = "dbname=spire"
+			sqlite_journal_mode = "WAL"
+			`,
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "sqlite_journal_mode and sqlite_busy_timeout are only supported for sqlite3")
+}
+
 func TestListRegistrationEntriesQuery(t *testing.T) {
 	testCases := []struct {
 		dialect     string
@@ -3063,14 +3909,22 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3081,14 +3935,14 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 
@@ -3117,7 +3971,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3125,7 +3987,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3138,7 +4000,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3146,7 +4008,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3176,7 +4038,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3184,7 +4054,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3197,7 +4067,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3205,7 +4075,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3235,7 +4105,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3243,7 +4121,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3256,7 +4134,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3264,7 +4142,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3294,7 +4172,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3302,7 +4188,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3315,7 +4201,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3323,7 +4209,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3357,7 +4243,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3365,7 +4259,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3378,7 +4272,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3386,7 +4280,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3416,7 +4310,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3424,7 +4326,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3437,7 +4339,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3445,7 +4347,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3479,7 +4381,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3487,7 +4397,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3500,7 +4410,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3508,7 +4418,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3542,7 +4452,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3550,7 +4468,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3563,7 +4481,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3571,7 +4489,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3609,7 +4527,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3617,7 +4543,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3630,7 +4556,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3638,7 +4564,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3672,7 +4598,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3680,7 +4614,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3693,7 +4627,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3701,7 +4635,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3737,7 +4671,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3745,7 +4687,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3758,7 +4700,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3766,7 +4708,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3796,7 +4738,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3804,7 +4754,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3817,7 +4767,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3825,7 +4775,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3855,7 +4805,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3863,7 +4821,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3876,7 +4834,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3884,7 +4842,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3915,7 +4873,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3923,7 +4889,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3936,7 +4902,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3944,7 +4910,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3979,7 +4945,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -3987,7 +4961,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4000,7 +4974,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4008,7 +4982,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4034,14 +5008,22 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4052,14 +5034,14 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 
@@ -4088,7 +5070,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4096,7 +5086,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4109,7 +5099,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4117,7 +5107,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4147,7 +5137,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4155,7 +5153,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4168,7 +5166,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4176,7 +5174,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4206,7 +5204,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4214,7 +5220,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4227,7 +5233,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4235,7 +5241,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4265,7 +5271,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4273,7 +5287,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4286,7 +5300,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4294,7 +5308,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4328,7 +5342,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4336,7 +5358,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4349,7 +5371,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4357,7 +5379,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4387,7 +5409,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4395,7 +5425,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4408,7 +5438,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4416,7 +5446,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4450,7 +5480,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4458,7 +5496,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4471,7 +5509,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4479,7 +5517,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4513,7 +5551,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4521,7 +5567,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4534,7 +5580,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4542,7 +5588,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4580,7 +5626,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4588,7 +5642,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4601,7 +5655,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4609,7 +5663,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4643,7 +5697,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4651,7 +5713,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4664,7 +5726,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4672,7 +5734,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4708,7 +5770,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4716,7 +5786,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4729,7 +5799,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4737,7 +5807,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4767,7 +5837,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4775,7 +5853,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4788,7 +5866,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4796,7 +5874,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4826,7 +5904,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4834,7 +5920,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4847,7 +5933,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4855,7 +5941,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4886,7 +5972,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4894,7 +5988,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4907,7 +6001,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4915,7 +6009,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4950,7 +6044,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL ::integer AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -4958,7 +6060,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4971,7 +6073,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4979,7 +6081,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5005,7 +6107,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5039,7 +6149,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5075,7 +6193,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5111,7 +6237,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5147,7 +6281,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5183,7 +6325,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5223,7 +6373,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5259,7 +6417,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5300,7 +6466,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5341,7 +6515,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5386,7 +6568,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5427,7 +6617,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5471,7 +6669,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5509,7 +6715,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5548,7 +6762,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5587,7 +6809,15 @@ SELECT
 	B.trust_domain,
 	D.id AS dns_name_id,
 	D.value AS dns_name,
-	E.revision_number
+	E.revision_number,
+	E.jwt_svid_claims,
+	E.jwt_svid_audience,
+	E.x509_svid_key_type,
+	E.dns_name_templates,
+	E.x509_svid_subject,
+	E.hint,
+	E.selector_expression,
+	E.template_id
 FROM
 	registered_entries E
 LEFT JOIN
@@ -5630,14 +6860,22 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5648,14 +6886,14 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 
@@ -5685,7 +6923,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -5693,7 +6939,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5706,7 +6952,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5714,7 +6960,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5745,7 +6991,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -5753,7 +7007,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5766,7 +7020,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5774,7 +7028,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5805,7 +7059,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -5813,7 +7075,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5826,7 +7088,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5834,7 +7096,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5865,7 +7127,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -5873,7 +7143,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5886,7 +7156,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5894,7 +7164,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5929,7 +7199,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -5937,7 +7215,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5950,7 +7228,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5958,7 +7236,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5989,7 +7267,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -5997,7 +7283,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6010,7 +7296,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6018,7 +7304,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6054,7 +7340,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -6062,7 +7356,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6075,7 +7369,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6083,7 +7377,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6119,7 +7413,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -6127,7 +7429,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6140,7 +7442,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6148,7 +7450,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6188,7 +7490,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -6196,7 +7506,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6209,7 +7519,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6217,7 +7527,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6253,7 +7563,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -6261,7 +7579,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6274,7 +7592,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6282,7 +7600,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6321,7 +7639,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -6329,7 +7655,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6342,7 +7668,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6350,7 +7676,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6383,7 +7709,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -6391,7 +7725,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6404,7 +7738,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6412,7 +7746,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6445,7 +7779,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -6453,7 +7795,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6466,7 +7808,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6474,7 +7816,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6508,7 +7850,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -6516,7 +7866,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6529,7 +7879,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6537,7 +7887,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6576,7 +7926,15 @@ SELECT
 	NULL AS trust_domain,
 	NULL AS dns_name_id,
 	NULL AS dns_name,
-	revision_number
+	revision_number,
+	jwt_svid_claims,
+	jwt_svid_audience,
+	x509_svid_key_type,
+	dns_name_templates,
+	x509_svid_subject,
+	hint,
+	selector_expression,
+	template_id
 FROM
 	registered_entries
 WHERE id IN (SELECT id FROM listing)
@@ -6584,7 +7942,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6597,7 +7955,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6605,7 +7963,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1,16 +1,70 @@
 package sql
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/jinzhu/gorm"
 	"github.com/mattn/go-sqlite3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	// gorm sqlite dialect init registration
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 )
 
+const defaultSQLiteJournalMode = "WAL"
+
+// sqliteJournalModes are the journal modes accepted by sqlite_journal_mode.
+var sqliteJournalModes = []string{"DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF"}
+
+// sqliteOptions holds the per-connection settings applied to each sqlite3
+// connection. Zero values select the defaults.
+type sqliteOptions struct {
+	journalMode string
+	busyTimeout time.Duration
+}
+
+func sqliteOptionsFromConfig(cfg *configuration) (sqliteOptions, error) {
+	var opts sqliteOptions
+
+	if cfg.SQLiteJournalMode != "" {
+		journalMode := strings.ToUpper(cfg.SQLiteJournalMode)
+		valid := false
+		for _, mode := range sqliteJournalModes {
+			if journalMode == mode {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return sqliteOptions{}, fmt.Errorf("invalid sqlite_journal_mode %q: must be one of %s", cfg.SQLiteJournalMode, strings.Join(sqliteJournalModes, ", "))
+		}
+		opts.journalMode = journalMode
+	}
+
+	if cfg.SQLiteBusyTimeout != "" {
+		busyTimeout, err := time.ParseDuration(cfg.SQLiteBusyTimeout)
+		if err != nil {
+			return sqliteOptions{}, fmt.Errorf("failed to parse sqlite_busy_timeout %q: %v", cfg.SQLiteBusyTimeout, err)
+		}
+		if busyTimeout < 0 {
+			return sqliteOptions{}, errors.New("sqlite_busy_timeout must not be negative")
+		}
+		opts.busyTimeout = busyTimeout
+	}
+
+	return opts, nil
+}
+
 type sqliteDB struct {
 	log hclog.Logger
 }
@@ -20,7 +74,12 @@ func (s sqliteDB) connect(cfg *configuration, isReadOnly bool) (db *gorm.DB, ver
 		s.log.Warn("Read-only connection is not applicable for sqlite3. Falling back to primary connection")
 	}
 
-	db, err = openSQLite3(cfg.ConnectionString)
+	opts, err := sqliteOptionsFromConfig(cfg)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	db, err = openSQLite3(cfg.ConnectionString, opts)
 	if err != nil {
 		return nil, "", false, err
 	}
@@ -42,8 +101,8 @@ func (s sqliteDB) isConstraintViolation(err error) bool {
 	return ok && e.Code == sqlite3.ErrConstraint
 }
 
-func openSQLite3(connString string) (*gorm.DB, error) {
-	embellished, err := embellishSQLite3ConnString(connString)
+func openSQLite3(connString string, opts sqliteOptions) (*gorm.DB, error) {
+	embellished, err := embellishSQLite3ConnString(connString, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -55,11 +114,12 @@ func openSQLite3(connString string) (*gorm.DB, error) {
 }
 
 // embellishSQLite3ConnString adds query values supported by
-// github.com/mattn/go-sqlite3 to enable journal mode and foreign key support.
-// These query values MUST be part of the connection string in order to be
-// enabled for *each* connection opened by db/sql. If the connection string is
-// not already a file: URI, it is converted first.
-func embellishSQLite3ConnString(connectionString string) (string, error) {
+// github.com/mattn/go-sqlite3 to enable journal mode and foreign key support,
+// and to set the busy timeout when one is configured. The journal mode
+// defaults to WAL. These query values MUST be part of the connection string in
+// order to be enabled for *each* connection opened by db/sql. If the
+// connection string is not already a file: URI, it is converted first.
+func embellishSQLite3ConnString(connectionString string, opts sqliteOptions) (string, error) {
 	u, err := url.Parse(connectionString)
 	if err != nil {
 		return "", sqlError.Wrap(err)
@@ -81,7 +141,69 @@ func embellishSQLite3ConnString(connectionString string) (string, error) {
 
 	q := u.Query()
 	q.Set("_foreign_keys", "ON")
-	q.Set("_journal_mode", "WAL")
+	journalMode := opts.journalMode
+	if journalMode == "" {
+		journalMode = defaultSQLiteJournalMode
+	}
+	q.Set("_journal_mode", journalMode)
+	if opts.busyTimeout > 0 {
+		q.Set("_busy_timeout", strconv.FormatInt(opts.busyTimeout.Milliseconds(), 10))
+	}
 	u.RawQuery = q.Encode()
 	return u.String(), nil
 }
+
+// backupSQLite3 copies the database behind db into a new database file at
+// path using the SQLite online backup API. The copy is a consistent snapshot
+// and the source database remains available for reads and writes while the
+// backup runs.
+func backupSQLite3(ctx context.Context, db *sql.DB, path string) (err error) {
+	switch _, statErr := os.Stat(path); {
+	case statErr == nil:
+		return status.Errorf(codes.AlreadyExists, "backup file %q already exists", path)
+	case !os.IsNotExist(statErr):
+		return sqlError.Wrap(statErr)
+	}
+
+	driverConn, err := (&sqlite3.SQLiteDriver{}).Open(path)
+	if err != nil {
+		return sqlError.Wrap(err)
+	}
+	dest := driverConn.(*sqlite3.SQLiteConn)
+	defer func() {
+		dest.Close()
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return sqlError.Wrap(err)
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		src, ok := driverConn.(*sqlite3.SQLiteConn)
+		if !ok {
+			return sqlError.New("unexpected sqlite3 driver connection type %T", driverConn)
+		}
+
+		backup, err := dest.Backup("main", src, "main")
+		if err != nil {
+			return sqlError.Wrap(err)
+		}
+
+		for {
+			done, err := backup.Step(-1)
+			if err != nil {
+				backup.Close()
+				return sqlError.Wrap(err)
+			}
+			if done {
+				break
+			}
+		}
+		return sqlError.Wrap(backup.Finish())
+	})
+}
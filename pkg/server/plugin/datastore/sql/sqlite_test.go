@@ -2,6 +2,7 @@ package sql
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -10,6 +11,7 @@ func TestEmbellishSQLite3ConnString(t *testing.T) {
 	testCases := []struct {
 		name     string
 		in       string
+		opts     sqliteOptions
 		expected string
 	}{
 		{
@@ -62,12 +64,24 @@ func TestEmbellishSQLite3ConnString(t *testing.T) {
 			in:       "file:/home/fred/data.db?vfs=unix-dotfile",
 			expected: "file:///home/fred/data.db?_foreign_keys=ON&_journal_mode=WAL&vfs=unix-dotfile",
 		},
+		{
+			name:     "custom journal mode",
+			in:       "data.db",
+			opts:     sqliteOptions{journalMode: "DELETE"},
+			expected: "file:data.db?_foreign_keys=ON&_journal_mode=DELETE",
+		},
+		{
+			name:     "busy timeout",
+			in:       "data.db",
+			opts:     sqliteOptions{busyTimeout: 10 * time.Second},
+			expected: "file:data.db?_busy_timeout=10000&_foreign_keys=ON&_journal_mode=WAL",
+		},
 	}
 
 	for _, testCase := range testCases {
 		testCase := testCase
 		t.Run(testCase.name, func(t *testing.T) {
-			actual, err := embellishSQLite3ConnString(testCase.in)
+			actual, err := embellishSQLite3ConnString(testCase.in, testCase.opts)
 			require.NoError(t, err)
 			require.Equal(t, testCase.expected, actual)
 		})
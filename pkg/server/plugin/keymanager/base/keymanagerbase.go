@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -125,7 +126,9 @@ func (m *Base) SignData(ctx context.Context, req *keymanager.SignDataRequest) (*
 	var signerOpts crypto.SignerOpts
 	switch opts := req.SignerOpts.(type) {
 	case *keymanager.SignDataRequest_HashAlgorithm:
-		if opts.HashAlgorithm == keymanager.HashAlgorithm_UNSPECIFIED_HASH_ALGORITHM {
+		// Ed25519 signs the message directly and therefore does not use a
+		// hash algorithm.
+		if opts.HashAlgorithm == keymanager.HashAlgorithm_UNSPECIFIED_HASH_ALGORITHM && m.getKeyType(req.KeyId) != keymanager.KeyType_ED25519 {
 			return nil, m.newError("hash algorithm is required")
 		}
 		signerOpts = crypto.Hash(opts.HashAlgorithm)
@@ -173,6 +176,15 @@ func (m *Base) getPrivateKey(id string) crypto.PrivateKey {
 	return nil
 }
 
+func (m *Base) getKeyType(id string) keymanager.KeyType {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if entry := m.entries[id]; entry != nil {
+		return entry.Type
+	}
+	return keymanager.KeyType_UNSPECIFIED_KEY_TYPE
+}
+
 func (m *Base) generateKeyEntry(keyID string, keyType keymanager.KeyType) (e *KeyEntry, err error) {
 	var privateKey crypto.PrivateKey
 	var publicKey crypto.PublicKey
@@ -187,6 +199,8 @@ func (m *Base) generateKeyEntry(keyID string, keyType keymanager.KeyType) (e *Ke
 		privateKey, publicKey, err = generateRSAKey(2048)
 	case keymanager.KeyType_RSA_4096:
 		privateKey, publicKey, err = generateRSAKey(4096)
+	case keymanager.KeyType_ED25519:
+		privateKey, publicKey, err = generateEd25519Key()
 	default:
 		return nil, m.newError("unknown key type %q", keyType)
 	}
@@ -236,6 +250,8 @@ func MakeKeyEntryFromKey(id string, privateKey crypto.PrivateKey) (*KeyEntry, er
 			return nil, err
 		}
 		return makeKeyEntry(id, keyType, privateKey, privateKey.Public())
+	case ed25519.PrivateKey:
+		return makeKeyEntry(id, keymanager.KeyType_ED25519, privateKey, privateKey.Public())
 	default:
 		return nil, fmt.Errorf("unexpected private key type %T", privateKey)
 	}
@@ -283,6 +299,14 @@ func generateECKey(curve elliptic.Curve) (*ecdsa.PrivateKey, *ecdsa.PublicKey, e
 	return privateKey, &privateKey.PublicKey, nil
 }
 
+func generateEd25519Key() (ed25519.PrivateKey, ed25519.PublicKey, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privateKey, publicKey, nil
+}
+
 func entriesSliceFromMap(entriesMap map[string]*KeyEntry) (entriesSlice []*KeyEntry) {
 	for _, entry := range entriesMap {
 		entriesSlice = append(entriesSlice, entry)
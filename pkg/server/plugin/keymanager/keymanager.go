@@ -45,6 +45,7 @@ const (
 	HashAlgorithm_UNSPECIFIED_HASH_ALGORITHM = keymanager.HashAlgorithm_UNSPECIFIED_HASH_ALGORITHM //nolint: golint
 	KeyType_EC_P256                          = keymanager.KeyType_EC_P256                          //nolint: golint
 	KeyType_EC_P384                          = keymanager.KeyType_EC_P384                          //nolint: golint
+	KeyType_ED25519                          = keymanager.KeyType_ED25519                          //nolint: golint
 	KeyType_RSA_1024                         = keymanager.KeyType_RSA_1024                         //nolint: golint
 	KeyType_RSA_2048                         = keymanager.KeyType_RSA_2048                         //nolint: golint
 	KeyType_RSA_4096                         = keymanager.KeyType_RSA_4096                         //nolint: golint
@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/x509"
@@ -142,6 +143,22 @@ func (s *baseSuite) TestGenerateKeyRSA4096() {
 	s.Require().Equal(4096, rsaPublicKey.N.BitLen())
 }
 
+func (s *baseSuite) TestGenerateKeyEd25519() {
+	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_ED25519,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().NotNil(resp.PublicKey)
+	s.Require().Equal(resp.PublicKey.Id, "KEY")
+	s.Require().Equal(resp.PublicKey.Type, keymanager.KeyType_ED25519)
+	publicKey, err := x509.ParsePKIXPublicKey(resp.PublicKey.PkixData)
+	s.Require().NoError(err)
+	_, ok := publicKey.(ed25519.PublicKey)
+	s.Require().True(ok)
+}
+
 func (s *baseSuite) TestGetPublicKeyMissingKeyID() {
 	resp, err := s.m.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{})
 	s.Require().Error(err)
@@ -210,6 +227,26 @@ func (s *baseSuite) TestSignDataRSAPSS() {
 	s.testSignData(keymanager.KeyType_RSA_1024, x509.SHA256WithRSAPSS)
 }
 
+func (s *baseSuite) TestSignDataEd25519() {
+	generateResp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_ED25519,
+	})
+	s.Require().NoError(err)
+
+	publicKey, err := x509.ParsePKIXPublicKey(generateResp.PublicKey.PkixData)
+	s.Require().NoError(err)
+
+	// Ed25519 signs the message directly, without a hash algorithm
+	signResp, err := s.m.SignData(ctx, &keymanager.SignDataRequest{
+		KeyId:      "KEY",
+		Data:       []byte("DATA"),
+		SignerOpts: &keymanager.SignDataRequest_HashAlgorithm{},
+	})
+	s.Require().NoError(err)
+	s.Require().True(ed25519.Verify(publicKey.(ed25519.PublicKey), []byte("DATA"), signResp.Signature))
+}
+
 func (s *baseSuite) testSignData(keyType keymanager.KeyType, signatureAlgorithm x509.SignatureAlgorithm) {
 	// create a new key
 	generateResp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
@@ -18,6 +18,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
@@ -54,6 +55,9 @@ const (
 	accessKeyIDVarName = "AWS_ACCESS_KEY_ID"
 	// secretAccessKeyVarName env car name for AWS secret access key
 	secretAccessKeyVarName = "AWS_SECRET_ACCESS_KEY" //nolint: gosec // false positive
+	// autoscalingGroupNameTag is the tag EC2 Auto Scaling adds to instances
+	// with the name of their autoscaling group
+	autoscalingGroupNameTag = "aws:autoscaling:groupName"
 )
 
 const awsCaCertPEM = `-----BEGIN CERTIFICATE-----
@@ -93,6 +97,7 @@ type IIDAttestorPlugin struct {
 	config  *IIDAttestorConfig
 	mtx     sync.RWMutex
 	clients *clientsCache
+	lookups *nodeattestorbase.LookupCache
 
 	hooks struct {
 		// in test, this can be overridden to mock OS env
@@ -107,7 +112,9 @@ type IIDAttestorConfig struct {
 	SkipBlockDevice    bool     `hcl:"skip_block_device"`
 	LocalValidAcctIDs  []string `hcl:"account_ids_for_local_validation"`
 	AgentPathTemplate  string   `hcl:"agent_path_template"`
+	LookupCacheTTL     string   `hcl:"lookup_cache_ttl"`
 	pathTemplate       *template.Template
+	lookupCacheTTL     time.Duration
 	trustDomain        string
 	awsCaCertPublicKey *rsa.PublicKey
 }
@@ -116,6 +123,7 @@ type IIDAttestorConfig struct {
 func New() *IIDAttestorPlugin {
 	p := &IIDAttestorPlugin{}
 	p.clients = newClientsCache(defaultNewClientCallback)
+	p.lookups = nodeattestorbase.NewLookupCache(clock.New())
 	p.hooks.getenv = os.Getenv
 	return p
 }
@@ -162,10 +170,7 @@ func (p *IIDAttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer
 	ctx, cancel := context.WithTimeout(stream.Context(), _awsTimeout)
 	defer cancel()
 
-	instancesDesc, err := awsClient.DescribeInstancesWithContext(ctx, &ec2.DescribeInstancesInput{
-		InstanceIds: []*string{aws.String(validDoc.InstanceID)},
-		Filters:     instanceFilters,
-	})
+	instancesDesc, err := p.describeInstance(ctx, awsClient, validDoc.Region, validDoc.InstanceID)
 	if err != nil {
 		return caws.AttestationStepError("querying AWS via describe-instances", err)
 	}
@@ -274,11 +279,23 @@ func (p *IIDAttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReq
 		config.pathTemplate = tmpl
 	}
 
+	if config.LookupCacheTTL != "" {
+		ttl, err := time.ParseDuration(config.LookupCacheTTL)
+		if err != nil {
+			return nil, iidError.New("invalid lookup_cache_ttl: %w", err)
+		}
+		if ttl < 0 {
+			return nil, iidError.New("lookup_cache_ttl must not be negative")
+		}
+		config.lookupCacheTTL = ttl
+	}
+
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
 	p.config = config
 	p.clients.configure(config.SessionConfig)
+	p.lookups.Configure(config.lookupCacheTTL)
 
 	return &spi.ConfigureResponse{}, nil
 }
@@ -344,6 +361,36 @@ func (p *IIDAttestorPlugin) getConfig() (*IIDAttestorConfig, error) {
 	return p.config, nil
 }
 
+// describeInstance describes the instance, reusing the description of a
+// recent attestation of the same instance when the lookup cache is enabled.
+func (p *IIDAttestorPlugin) describeInstance(ctx context.Context, client Client, region, instanceID string) (*ec2.DescribeInstancesOutput, error) {
+	value, err := p.lookups.Lookup("describe-instances:"+region+"/"+instanceID, func() (interface{}, error) {
+		return client.DescribeInstancesWithContext(ctx, &ec2.DescribeInstancesInput{
+			InstanceIds: []*string{aws.String(instanceID)},
+			Filters:     instanceFilters,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*ec2.DescribeInstancesOutput), nil
+}
+
+// getInstanceProfile gets the instance profile, reusing the result of a
+// recent attestation when the lookup cache is enabled. Results are keyed by
+// ARN since instance profile names are only unique within an account.
+func (p *IIDAttestorPlugin) getInstanceProfile(ctx context.Context, client Client, profileArn, profileName string) (*iam.GetInstanceProfileOutput, error) {
+	value, err := p.lookups.Lookup("instance-profile:"+profileArn, func() (interface{}, error) {
+		return client.GetInstanceProfileWithContext(ctx, &iam.GetInstanceProfileInput{
+			InstanceProfileName: aws.String(profileName),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*iam.GetInstanceProfileOutput), nil
+}
+
 func (p *IIDAttestorPlugin) getEC2Instance(instancesDesc *ec2.DescribeInstancesOutput) (*ec2.Instance, error) {
 	if len(instancesDesc.Reservations) < 1 {
 		return nil, caws.AttestationStepError("querying AWS via describe-instances", iidError.New("returned no reservations"))
@@ -402,17 +449,17 @@ func (p *IIDAttestorPlugin) resolveSelectors(parent context.Context, instancesDe
 	for _, reservation := range instancesDesc.Reservations {
 		for _, instance := range reservation.Instances {
 			addSelectors(resolveTags(instance.Tags))
+			addSelectors(resolveAutoscalingGroup(instance.Tags))
 			addSelectors(resolveSecurityGroups(instance.SecurityGroups))
 			if instance.IamInstanceProfile != nil && instance.IamInstanceProfile.Arn != nil {
+				addSelectors([]string{fmt.Sprintf("iamprofile:%s", aws.StringValue(instance.IamInstanceProfile.Arn))})
 				instanceProfileName, err := instanceProfileNameFromArn(*instance.IamInstanceProfile.Arn)
 				if err != nil {
 					return nil, err
 				}
 				ctx, cancel := context.WithTimeout(parent, _awsTimeout)
 				defer cancel()
-				output, err := client.GetInstanceProfileWithContext(ctx, &iam.GetInstanceProfileInput{
-					InstanceProfileName: aws.String(instanceProfileName),
-				})
+				output, err := p.getInstanceProfile(ctx, client, *instance.IamInstanceProfile.Arn, instanceProfileName)
 				if err != nil {
 					return nil, iidError.Wrap(err)
 				}
@@ -444,6 +491,17 @@ func resolveTags(tags []*ec2.Tag) []string {
 	return values
 }
 
+// resolveAutoscalingGroup resolves the autoscaling group of the instance from
+// the tag that EC2 Auto Scaling adds to the instances it launches.
+func resolveAutoscalingGroup(tags []*ec2.Tag) []string {
+	for _, tag := range tags {
+		if tag != nil && aws.StringValue(tag.Key) == autoscalingGroupNameTag {
+			return []string{fmt.Sprintf("asg:name:%s", aws.StringValue(tag.Value))}
+		}
+	}
+	return nil
+}
+
 func resolveSecurityGroups(sgs []*ec2.GroupIdentifier) []string {
 	values := make([]string, 0, len(sgs)*2)
 	for _, sg := range sgs {
@@ -389,6 +389,7 @@ func (s *IIDAttestorSuite) TestClientAndIDReturns() {
 			},
 			replacementTemplate: "{{ .PluginName}}/zone1/{{ .Tags.Hostname }}",
 			expectSelectors: []*common.Selector{
+				{Type: caws.PluginName, Value: "iamprofile:arn:aws::::instance-profile/" + testProfile},
 				{Type: caws.PluginName, Value: "iamrole:role1"},
 				{Type: caws.PluginName, Value: "iamrole:role2"},
 				{Type: caws.PluginName, Value: "sg:id:TestGroup"},
@@ -397,6 +398,39 @@ func (s *IIDAttestorSuite) TestClientAndIDReturns() {
 			},
 			expectID: "spiffe://example.org/spire/agent/aws_iid/zone1/host1",
 		},
+		{
+			desc: "success, autoscaling group and instance profile selectors",
+			mockExpect: func(mock *mock_aws.MockClient) {
+				output := getDefaultDescribeInstancesOutput()
+				output.Reservations[0].Instances[0].Tags = []*ec2.Tag{
+					{
+						Key:   aws.String("aws:autoscaling:groupName"),
+						Value: aws.String("web-asg"),
+					},
+				}
+				output.Reservations[0].Instances[0].IamInstanceProfile = &ec2.IamInstanceProfile{
+					Arn: aws.String("arn:aws::::instance-profile/" + testProfile),
+				}
+				output.Reservations[0].Instances[0].RootDeviceType = &instanceStoreType
+				output.Reservations[0].Instances[0].NetworkInterfaces[0].Attachment.DeviceIndex = &zeroDeviceIndex
+				setAttestExpectations(mock, output, nil)
+				gipo := &iam.GetInstanceProfileOutput{
+					InstanceProfile: &iam.InstanceProfile{
+						Roles: []*iam.Role{
+							{Arn: aws.String("role1")},
+						},
+					},
+				}
+				setResolveSelectorsExpectations(mock, gipo)
+			},
+			expectSelectors: []*common.Selector{
+				{Type: caws.PluginName, Value: "asg:name:web-asg"},
+				{Type: caws.PluginName, Value: "iamprofile:arn:aws::::instance-profile/" + testProfile},
+				{Type: caws.PluginName, Value: "iamrole:role1"},
+				{Type: caws.PluginName, Value: "tag:aws:autoscaling:groupName:web-asg"},
+			},
+			expectID: "spiffe://example.org/spire/agent/aws_iid/test-account/test-region/test-instance",
+		},
 		{
 			desc: "missing tags do not panic",
 			mockExpect: func(mock *mock_aws.MockClient) {
@@ -485,6 +519,39 @@ func (s *IIDAttestorSuite) TestClientAndIDReturns() {
 	}
 }
 
+func (s *IIDAttestorSuite) TestLookupCache() {
+	mockCtl := gomock.NewController(s.T())
+	defer mockCtl.Finish()
+
+	client := mock_aws.NewMockClient(mockCtl)
+	s.plugin.clients = newClientsCache(func(config *SessionConfig, region string) (Client, error) {
+		return client, nil
+	})
+
+	// the instance is described once for both attestations
+	setAttestExpectations(client, getDefaultDescribeInstancesOutput(), nil)
+
+	_, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+skip_block_device = true
+lookup_cache_ttl = "1m"
+`,
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+	s.plugin.config.awsCaCertPublicKey = &s.rsaKey.PublicKey
+
+	data := &common.AttestationData{
+		Type: caws.PluginName,
+		Data: s.iidAttestationDataToBytes(*s.buildDefaultIIDAttestationData()),
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := s.attest(&nodeattestor.AttestRequest{AttestationData: data})
+		s.Require().NoError(err)
+		s.Require().Equal("spiffe://example.org/spire/agent/aws_iid/test-account/test-region/test-instance", resp.AgentId)
+	}
+}
+
 func (s *IIDAttestorSuite) TestErrorOnBadSVIDTemplate() {
 	_, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
 		Configuration: `
@@ -548,6 +615,19 @@ func (s *IIDAttestorSuite) TestConfigure() {
 	delete(s.env, accessKeyIDVarName)
 	delete(s.env, secretAccessKeyVarName)
 
+	// invalid lookup cache TTL
+	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `lookup_cache_ttl = "soon"`,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"}})
+	s.RequireErrorContains(err, "invalid lookup_cache_ttl")
+	require.Nil(resp)
+
+	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `lookup_cache_ttl = "-1m"`,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"}})
+	s.RequireErrorContains(err, "lookup_cache_ttl must not be negative")
+	require.Nil(resp)
+
 	// success, no AWS keys
 	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{
 		Configuration: ``,
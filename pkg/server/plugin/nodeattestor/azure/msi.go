@@ -50,6 +50,7 @@ type TenantConfig struct {
 
 type MSIAttestorConfig struct {
 	trustDomain string
+	leeway      time.Duration
 	Tenants     map[string]*TenantConfig `hcl:"tenants"`
 }
 
@@ -152,7 +153,7 @@ func (p *MSIAttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer
 	if err := claims.ValidateWithLeeway(jwt.Expected{
 		Audience: []string{tenant.ResourceID},
 		Time:     p.hooks.now(),
-	}, tokenLeeway); err != nil {
+	}, config.leeway); err != nil {
 		return msiError.New("unable to validate token claims: %v", err)
 	}
 
@@ -179,6 +180,12 @@ func (p *MSIAttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReq
 	}
 	config.trustDomain = req.GlobalConfig.TrustDomain
 
+	// The configured clock skew tolerance only extends the default leeway
+	config.leeway = tokenLeeway
+	if clockSkewTolerance := time.Duration(req.GlobalConfig.ClockSkewTolerance) * time.Second; clockSkewTolerance > config.leeway {
+		config.leeway = clockSkewTolerance
+	}
+
 	if len(config.Tenants) == 0 {
 		return nil, msiError.New("configuration must have at least one tenant")
 	}
@@ -0,0 +1,99 @@
+package base
+
+import (
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+)
+
+// LookupCache caches the results of successful cloud provider API lookups
+// made during attestation (e.g. to describe the attesting instance) for a
+// configurable TTL. This limits the API quota consumed, and the attestation
+// latency, when many agents attest or re-attest at the same time. Failed
+// lookups are never cached.
+type LookupCache struct {
+	clock clock.Clock
+
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]lookupCacheEntry
+	lastPrune time.Time
+}
+
+type lookupCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewLookupCache returns a new cache. Caching is disabled until a positive
+// TTL is configured.
+func NewLookupCache(clk clock.Clock) *LookupCache {
+	return &LookupCache{
+		clock:   clk,
+		entries: make(map[string]lookupCacheEntry),
+	}
+}
+
+// Configure sets the TTL of the cached results and drops the results cached
+// so far, since they may have been looked up with a different configuration.
+// A zero TTL disables caching.
+func (c *LookupCache) Configure(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+	c.entries = make(map[string]lookupCacheEntry)
+}
+
+// Lookup returns the cached result for the key, if any and not expired.
+// Otherwise, it calls the lookup function and caches its result if it
+// succeeds.
+func (c *LookupCache) Lookup(key string, lookup func() (interface{}, error)) (interface{}, error) {
+	if value, ok := c.get(key); ok {
+		return value, nil
+	}
+
+	value, err := lookup()
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, value)
+	return value, nil
+}
+
+func (c *LookupCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.clock.Now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *LookupCache) set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+
+	now := c.clock.Now()
+	c.entries[key] = lookupCacheEntry{
+		value:     value,
+		expiresAt: now.Add(c.ttl),
+	}
+
+	// Drop expired entries once per TTL so results for instances that do
+	// not attest again do not pile up
+	if now.Sub(c.lastPrune) >= c.ttl {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastPrune = now
+	}
+}
@@ -0,0 +1,70 @@
+package base
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupCache(t *testing.T) {
+	clk := clock.NewMock(t)
+	cache := NewLookupCache(clk)
+
+	calls := 0
+	lookup := func(value string) func() (interface{}, error) {
+		return func() (interface{}, error) {
+			calls++
+			return value, nil
+		}
+	}
+
+	// disabled until configured
+	value, err := cache.Lookup("key", lookup("a"))
+	require.NoError(t, err)
+	assert.Equal(t, "a", value)
+	value, err = cache.Lookup("key", lookup("b"))
+	require.NoError(t, err)
+	assert.Equal(t, "b", value)
+	assert.Equal(t, 2, calls)
+
+	cache.Configure(time.Minute)
+
+	// failures are not cached
+	_, err = cache.Lookup("key", func() (interface{}, error) {
+		return nil, errors.New("oh no")
+	})
+	require.EqualError(t, err, "oh no")
+
+	value, err = cache.Lookup("key", lookup("c"))
+	require.NoError(t, err)
+	assert.Equal(t, "c", value)
+
+	// cached until the TTL elapses
+	clk.Add(time.Minute - time.Second)
+	value, err = cache.Lookup("key", lookup("d"))
+	require.NoError(t, err)
+	assert.Equal(t, "c", value)
+	assert.Equal(t, 3, calls)
+
+	clk.Add(time.Second)
+	value, err = cache.Lookup("key", lookup("e"))
+	require.NoError(t, err)
+	assert.Equal(t, "e", value)
+	assert.Equal(t, 4, calls)
+
+	// expired entries are pruned
+	clk.Add(time.Minute)
+	_, err = cache.Lookup("other", lookup("f"))
+	require.NoError(t, err)
+	assert.Len(t, cache.entries, 1)
+
+	// reconfiguring drops the cached results
+	cache.Configure(time.Minute)
+	value, err = cache.Lookup("other", lookup("g"))
+	require.NoError(t, err)
+	assert.Equal(t, "g", value)
+}
@@ -6,7 +6,9 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/hashicorp/hcl"
 	"github.com/zeebo/errs"
 
@@ -26,6 +28,13 @@ const (
 	tokenAudience               = "spire-gcp-node-attestor" //nolint: gosec // false positive
 	googleCertURL               = "https://www.googleapis.com/oauth2/v1/certs"
 	defaultMaxMetadataValueSize = 128
+
+	// Labels and metadata GKE sets on the instances of its node pools
+	gkeClusterNameLabel       = "goog-k8s-cluster-name"
+	gkeNodePoolNameLabel      = "goog-k8s-node-pool-name"
+	gkeClusterNameMetadataKey = "cluster-name"
+	gkeKubeLabelsMetadataKey  = "kube-labels"
+	gkeNodePoolKubeLabel      = "cloud.google.com/gke-nodepool"
 )
 
 var (
@@ -57,6 +66,7 @@ type IITAttestorPlugin struct {
 	mtx               sync.Mutex
 	tokenKeyRetriever tokenKeyRetriever
 	client            computeEngineClient
+	lookups           *nodeattestorbase.LookupCache
 }
 
 // IITAttestorConfig is the config for IITAttestorPlugin.
@@ -65,6 +75,8 @@ type IITAttestorConfig struct {
 	trustDomain         string
 	allowedLabelKeys    map[string]bool
 	allowedMetadataKeys map[string]bool
+	clockSkewTolerance  time.Duration
+	lookupCacheTTL      time.Duration
 
 	ProjectIDWhitelist   []string `hcl:"projectid_whitelist"`
 	AgentPathTemplate    string   `hcl:"agent_path_template"`
@@ -73,6 +85,7 @@ type IITAttestorConfig struct {
 	AllowedMetadataKeys  []string `hcl:"allowed_metadata_keys"`
 	MaxMetadataValueSize int      `hcl:"max_metadata_value_size"`
 	ServiceAccountFile   string   `hcl:"service_account_file"`
+	LookupCacheTTL       string   `hcl:"lookup_cache_ttl"`
 }
 
 // New creates a new IITAttestorPlugin.
@@ -80,6 +93,7 @@ func New() *IITAttestorPlugin {
 	return &IITAttestorPlugin{
 		tokenKeyRetriever: newGooglePublicKeyRetriever(googleCertURL),
 		client:            googleComputeEngineClient{},
+		lookups:           nodeattestorbase.NewLookupCache(clock.New()),
 	}
 }
 
@@ -90,7 +104,7 @@ func (p *IITAttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer
 		return err
 	}
 
-	identityMetadata, err := validateAttestationAndExtractIdentityMetadata(stream, gcp.PluginName, p.tokenKeyRetriever)
+	identityMetadata, err := validateAttestationAndExtractIdentityMetadata(stream, gcp.PluginName, p.tokenKeyRetriever, c.clockSkewTolerance)
 	if err != nil {
 		return err
 	}
@@ -121,7 +135,7 @@ func (p *IITAttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer
 
 	var instance *compute.Instance
 	if c.UseInstanceMetadata {
-		instance, err = p.client.fetchInstanceMetadata(stream.Context(), identityMetadata.ProjectID, identityMetadata.Zone, identityMetadata.InstanceName, c.ServiceAccountFile)
+		instance, err = p.fetchInstanceMetadata(stream.Context(), c, identityMetadata)
 		if err != nil {
 			return pluginErr.New("failed to fetch instance metadata: %v", err)
 		}
@@ -161,6 +175,7 @@ func (p *IITAttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReq
 		return nil, pluginErr.New("trust_domain is required")
 	}
 	config.trustDomain = req.GlobalConfig.TrustDomain
+	config.clockSkewTolerance = time.Duration(req.GlobalConfig.ClockSkewTolerance) * time.Second
 
 	if len(config.ProjectIDWhitelist) == 0 {
 		return nil, pluginErr.New("projectid_whitelist is required")
@@ -193,12 +208,24 @@ func (p *IITAttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReq
 		config.MaxMetadataValueSize = defaultMaxMetadataValueSize
 	}
 
+	if config.LookupCacheTTL != "" {
+		ttl, err := time.ParseDuration(config.LookupCacheTTL)
+		if err != nil {
+			return nil, pluginErr.New("invalid lookup_cache_ttl: %v", err)
+		}
+		if ttl < 0 {
+			return nil, pluginErr.New("lookup_cache_ttl must not be negative")
+		}
+		config.lookupCacheTTL = ttl
+	}
+
 	config.idPathTemplate = tmpl
 
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
 	p.config = config
+	p.lookups.Configure(config.lookupCacheTTL)
 
 	return &spi.ConfigureResponse{}, nil
 }
@@ -218,6 +245,19 @@ func (p *IITAttestorPlugin) getConfig() (*IITAttestorConfig, error) {
 	return p.config, nil
 }
 
+// fetchInstanceMetadata fetches the instance, reusing the result of a recent
+// attestation of the same instance when the lookup cache is enabled.
+func (p *IITAttestorPlugin) fetchInstanceMetadata(ctx context.Context, c *IITAttestorConfig, identityMetadata gcp.ComputeEngine) (*compute.Instance, error) {
+	key := fmt.Sprintf("instance:%s/%s/%s", identityMetadata.ProjectID, identityMetadata.Zone, identityMetadata.InstanceName)
+	value, err := p.lookups.Lookup(key, func() (interface{}, error) {
+		return p.client.fetchInstanceMetadata(ctx, identityMetadata.ProjectID, identityMetadata.Zone, identityMetadata.InstanceName, c.ServiceAccountFile)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*compute.Instance), nil
+}
+
 func getInstanceSelectors(config *IITAttestorConfig, instance *compute.Instance) ([]*common.Selector, error) {
 	metadata, err := getInstanceMetadata(instance, config.allowedMetadataKeys, config.MaxMetadataValueSize)
 	if err != nil {
@@ -237,6 +277,12 @@ func getInstanceSelectors(config *IITAttestorConfig, instance *compute.Instance)
 	for _, md := range metadata {
 		selectors = append(selectors, makeSelector("metadata", md.key, md.value))
 	}
+	if clusterName, nodePool := getInstanceGKENodePool(instance); clusterName != "" {
+		selectors = append(selectors, makeSelector("gke-cluster", clusterName))
+		if nodePool != "" {
+			selectors = append(selectors, makeSelector("gke-node-pool", clusterName, nodePool))
+		}
+	}
 	return selectors, nil
 }
 
@@ -245,7 +291,7 @@ type keyValue struct {
 	value string
 }
 
-func validateAttestationAndExtractIdentityMetadata(stream nodeattestor.NodeAttestor_AttestServer, pluginName string, tokenRetriever tokenKeyRetriever) (gcp.ComputeEngine, error) {
+func validateAttestationAndExtractIdentityMetadata(stream nodeattestor.NodeAttestor_AttestServer, pluginName string, tokenRetriever tokenKeyRetriever, clockSkewTolerance time.Duration) (gcp.ComputeEngine, error) {
 	req, err := stream.Recv()
 	if err != nil {
 		return gcp.ComputeEngine{}, err
@@ -261,10 +307,15 @@ func validateAttestationAndExtractIdentityMetadata(stream nodeattestor.NodeAttes
 	}
 
 	identityToken := &gcp.IdentityToken{}
-	_, err = jwt.ParseWithClaims(string(req.GetAttestationData().Data), identityToken, tokenRetriever.retrieveKey)
+	// The time claims are validated separately to tolerate clock skew
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	_, err = parser.ParseWithClaims(string(req.GetAttestationData().Data), identityToken, tokenRetriever.retrieveKey)
 	if err != nil {
 		return gcp.ComputeEngine{}, pluginErr.New("unable to parse/validate the identity token: %v", err)
 	}
+	if err := validateTokenTimes(&identityToken.StandardClaims, time.Now(), clockSkewTolerance); err != nil {
+		return gcp.ComputeEngine{}, pluginErr.New("unable to parse/validate the identity token: %v", err)
+	}
 
 	if identityToken.Audience != tokenAudience {
 		return gcp.ComputeEngine{}, pluginErr.New("unexpected identity token audience %q", identityToken.Audience)
@@ -273,6 +324,22 @@ func validateAttestationAndExtractIdentityMetadata(stream nodeattestor.NodeAttes
 	return identityToken.Google.ComputeEngine, nil
 }
 
+// validateTokenTimes validates the time claims of the identity token. Tokens
+// issued by a clock that is ahead by up to the tolerance are accepted, but
+// expired tokens never are.
+func validateTokenTimes(claims *jwt.StandardClaims, now time.Time, clockSkewTolerance time.Duration) error {
+	skewed := now.Add(clockSkewTolerance).Unix()
+	switch {
+	case !claims.VerifyExpiresAt(now.Unix(), false):
+		return errs.New("token is expired")
+	case !claims.VerifyIssuedAt(skewed, false):
+		return errs.New("token used before issued")
+	case !claims.VerifyNotBefore(skewed, false):
+		return errs.New("token is not valid yet")
+	}
+	return nil
+}
+
 func getInstanceTags(instance *compute.Instance) []string {
 	if instance.Tags != nil {
 		return instance.Tags.Items
@@ -302,6 +369,45 @@ func getInstanceLabels(instance *compute.Instance, allowedKeys map[string]bool)
 	return labels
 }
 
+// getInstanceGKENodePool returns the names of the GKE cluster and node pool
+// the instance belongs to, if any. They are read from the labels GKE puts on
+// its nodes, falling back to the instance metadata set by older GKE versions.
+func getInstanceGKENodePool(instance *compute.Instance) (string, string) {
+	clusterName := instance.Labels[gkeClusterNameLabel]
+	nodePool := instance.Labels[gkeNodePoolNameLabel]
+	if instance.Metadata == nil {
+		return clusterName, nodePool
+	}
+	for _, item := range instance.Metadata.Items {
+		if item.Value == nil {
+			continue
+		}
+		switch item.Key {
+		case gkeClusterNameMetadataKey:
+			if clusterName == "" {
+				clusterName = *item.Value
+			}
+		case gkeKubeLabelsMetadataKey:
+			if nodePool == "" {
+				nodePool = getKubeLabel(*item.Value, gkeNodePoolKubeLabel)
+			}
+		}
+	}
+	return clusterName, nodePool
+}
+
+// getKubeLabel returns the value of a label in the comma separated list of
+// key=value pairs GKE stores in the kube-labels metadata.
+func getKubeLabel(kubeLabels, key string) string {
+	for _, label := range strings.Split(kubeLabels, ",") {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) == 2 && parts[0] == key {
+			return parts[1]
+		}
+	}
+	return ""
+}
+
 func getInstanceMetadata(instance *compute.Instance, allowedKeys map[string]bool, maxValueSize int) ([]keyValue, error) {
 	if instance.Metadata == nil {
 		return nil, nil
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/spiffe/spire/pkg/common/pemutil"
@@ -118,6 +119,37 @@ func (s *IITAttestorSuite) TestErrorOnInvalidClaims() {
 	s.RequireErrorContains(err, "gcp-iit: unable to parse/validate the identity token: token is expired")
 }
 
+func (s *IITAttestorSuite) TestErrorOnTokenIssuedInTheFuture() {
+	claims := buildDefaultClaims()
+	claims["iat"] = time.Now().Add(time.Minute).Unix()
+	token := buildTokenWithClaims(claims)
+
+	data := &common.AttestationData{
+		Type: gcp.PluginName,
+		Data: s.signToken(token),
+	}
+
+	_, err := s.attest(&nodeattestor.AttestRequest{AttestationData: data})
+	s.RequireErrorContains(err, "gcp-iit: unable to parse/validate the identity token: token used before issued")
+}
+
+func (s *IITAttestorSuite) TestValidateTokenTimesWithClockSkewTolerance() {
+	now := time.Now()
+	claims := &jwt.StandardClaims{
+		IssuedAt:  now.Add(20 * time.Second).Unix(),
+		NotBefore: now.Add(20 * time.Second).Unix(),
+		ExpiresAt: now.Add(-time.Second).Unix(),
+	}
+
+	// Tokens from a clock that is ahead are tolerated
+	s.Require().EqualError(validateTokenTimes(&jwt.StandardClaims{IssuedAt: claims.IssuedAt}, now, 0), "token used before issued")
+	s.Require().EqualError(validateTokenTimes(&jwt.StandardClaims{NotBefore: claims.NotBefore}, now, 0), "token is not valid yet")
+	s.Require().NoError(validateTokenTimes(&jwt.StandardClaims{IssuedAt: claims.IssuedAt, NotBefore: claims.NotBefore}, now, 30*time.Second))
+
+	// Expired tokens are not
+	s.Require().EqualError(validateTokenTimes(claims, now, 30*time.Second), "token is expired")
+}
+
 func (s *IITAttestorSuite) TestErrorOnInvalidAudience() {
 	claims := buildClaims(testProject, "invalid")
 	token := buildTokenWithClaims(claims)
@@ -284,6 +316,96 @@ func (s *IITAttestorSuite) TestAttestSuccessWithInstanceMetadata() {
 	s.RequireProtoEqual(expected, actual)
 }
 
+func (s *IITAttestorSuite) TestAttestWithLookupCache() {
+	s.client.setInstance(&compute.Instance{
+		Tags: &compute.Tags{Items: []string{"tag-1"}},
+	})
+	_, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+projectid_whitelist = ["test-project"]
+use_instance_metadata = true
+service_account_file = "test_sa.json"
+lookup_cache_ttl = "1m"
+`,
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+
+	req := &nodeattestor.AttestRequest{
+		AttestationData: &common.AttestationData{
+			Type: gcp.PluginName,
+			Data: s.signToken(buildToken()),
+		},
+	}
+	resp, err := s.attest(req)
+	s.Require().NoError(err)
+	s.Require().Contains(resp.Selectors, &common.Selector{Type: "gcp_iit", Value: "tag:tag-1"})
+
+	// the instance fetched by the first attestation is reused
+	s.client.setInstance(&compute.Instance{
+		Tags: &compute.Tags{Items: []string{"tag-2"}},
+	})
+	resp, err = s.attest(req)
+	s.Require().NoError(err)
+	s.Require().Contains(resp.Selectors, &common.Selector{Type: "gcp_iit", Value: "tag:tag-1"})
+}
+
+func (s *IITAttestorSuite) TestAttestSuccessWithGKEInstanceMetadata() {
+	for _, tt := range []struct {
+		name     string
+		instance *compute.Instance
+	}{
+		{
+			name: "from labels",
+			instance: &compute.Instance{
+				Labels: map[string]string{
+					"goog-k8s-cluster-name":   "cluster",
+					"goog-k8s-node-pool-name": "pool",
+				},
+			},
+		},
+		{
+			name: "from metadata",
+			instance: &compute.Instance{
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "cluster-name",
+							Value: stringPtr("cluster"),
+						},
+						{
+							Key:   "kube-labels",
+							Value: stringPtr("cloud.google.com/gke-boot-disk=pd-standard,cloud.google.com/gke-nodepool=pool"),
+						},
+					},
+				},
+			},
+		},
+	} {
+		s.Run(tt.name, func() {
+			s.configureForInstanceMetadata(tt.instance)
+
+			actual, err := s.attest(&nodeattestor.AttestRequest{
+				AttestationData: &common.AttestationData{
+					Type: gcp.PluginName,
+					Data: s.signToken(buildToken()),
+				},
+			})
+			s.Require().NoError(err)
+			s.RequireProtoEqual(&nodeattestor.AttestResponse{
+				AgentId: testAgentID,
+				Selectors: []*common.Selector{
+					{Type: "gcp_iit", Value: "project-id:" + testProject},
+					{Type: "gcp_iit", Value: "zone:" + testZone},
+					{Type: "gcp_iit", Value: "instance-name:" + testInstanceName},
+					{Type: "gcp_iit", Value: "gke-cluster:cluster"},
+					{Type: "gcp_iit", Value: "gke-node-pool:cluster:pool"},
+				},
+			}, actual)
+		})
+	}
+}
+
 func (s *IITAttestorSuite) TestAttestFailsIfInstanceMetadataValueExceedsLimit() {
 	s.configureForInstanceMetadata(&compute.Instance{
 		Metadata: &compute.Metadata{
@@ -396,6 +518,26 @@ projectid_whitelist = ["bar"]
 	})
 	s.RequireErrorContains(err, "gcp-iit: projectid_whitelist is required")
 	require.Nil(resp)
+
+	// invalid lookup cache TTL
+	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+projectid_whitelist = ["bar"]
+lookup_cache_ttl = "soon"
+`,
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"}})
+	s.RequireErrorContains(err, "gcp-iit: invalid lookup_cache_ttl")
+	require.Nil(resp)
+
+	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+projectid_whitelist = ["bar"]
+lookup_cache_ttl = "-1m"
+`,
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"}})
+	s.RequireErrorContains(err, "gcp-iit: lookup_cache_ttl must not be negative")
+	require.Nil(resp)
+
 	// success
 	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{
 		Configuration: `
@@ -416,7 +558,7 @@ func (s *IITAttestorSuite) TestGetPluginInfo() {
 }
 
 func (s *IITAttestorSuite) TestFailToRecvStream() {
-	_, err := validateAttestationAndExtractIdentityMetadata(&recvFailStream{}, gcp.PluginName, testKeyRetriever{})
+	_, err := validateAttestationAndExtractIdentityMetadata(&recvFailStream{}, gcp.PluginName, testKeyRetriever{}, 0)
 	s.Require().EqualError(err, "failed to recv from stream")
 }
 
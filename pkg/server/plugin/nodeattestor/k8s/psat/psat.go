@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"sync"
+	"time"
 
+	"github.com/andres-erbsen/clock"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/plugin/k8s"
@@ -18,6 +22,8 @@ import (
 
 const (
 	pluginName = "k8s_psat"
+
+	defaultClustersFileReloadInterval = time.Minute
 )
 
 var (
@@ -38,6 +44,19 @@ func builtin(p *AttestorPlugin) catalog.Plugin {
 // AttestorConfig contains a map of clusters that uses cluster name as key
 type AttestorConfig struct {
 	Clusters map[string]*ClusterConfig `hcl:"clusters"`
+
+	// Path to a file holding additional clusters, using the same format as
+	// the clusters configuration. The file is reloaded periodically, which
+	// allows clusters to be added or removed without restarting the server.
+	ClustersFile string `hcl:"clusters_file"`
+
+	// How often the clusters file is reloaded. Defaults to one minute.
+	ClustersFileReloadInterval string `hcl:"clusters_file_reload_interval"`
+}
+
+// clustersFileConfig is the content of the clusters file
+type clustersFileConfig struct {
+	Clusters map[string]*ClusterConfig `hcl:"clusters"`
 }
 
 // ClusterConfig holds a single cluster configuration
@@ -65,6 +84,11 @@ type ClusterConfig struct {
 type attestorConfig struct {
 	trustDomain string
 	clusters    map[string]*clusterConfig
+
+	clustersFile               string
+	clustersFileReloadInterval time.Duration
+	fileClusters               map[string]*clusterConfig
+	lastReload                 time.Time
 }
 
 type clusterConfig struct {
@@ -77,13 +101,21 @@ type clusterConfig struct {
 
 //AttestorPlugin is a PSAT (Projected SAT) node attestor plugin
 type AttestorPlugin struct {
-	mu     sync.RWMutex
+	mu     sync.Mutex
 	config *attestorConfig
+	log    hclog.Logger
+	clock  clock.Clock
+
+	// newClient creates the clients of the clusters. It is overridden in tests.
+	newClient func(kubeConfigFile string) apiserver.Client
 }
 
 // New creates a new PSAT node attestor plugin
 func New() *AttestorPlugin {
-	return &AttestorPlugin{}
+	return &AttestorPlugin{
+		clock:     clock.New(),
+		newClient: apiserver.New,
+	}
 }
 
 var _ nodeattestor.NodeAttestorServer = (*AttestorPlugin)(nil)
@@ -94,7 +126,7 @@ func (p *AttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) e
 		return psatError.Wrap(err)
 	}
 
-	config, err := p.getConfig()
+	trustDomain, err := p.getTrustDomain()
 	if err != nil {
 		return psatError.Wrap(err)
 	}
@@ -124,7 +156,7 @@ func (p *AttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) e
 		return psatError.New("missing token in attestation data")
 	}
 
-	cluster := config.clusters[attestationData.Cluster]
+	cluster := p.getCluster(attestationData.Cluster)
 	if cluster == nil {
 		return psatError.New("not configured for cluster %q", attestationData.Cluster)
 	}
@@ -196,7 +228,7 @@ func (p *AttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) e
 	}
 
 	return stream.Send(&nodeattestor.AttestResponse{
-		AgentId:   k8s.AgentID(pluginName, config.trustDomain, attestationData.Cluster, nodeUID),
+		AgentId:   k8s.AgentID(pluginName, trustDomain, attestationData.Cluster, nodeUID),
 		Selectors: selectors,
 	})
 }
@@ -213,18 +245,124 @@ func (p *AttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReques
 		return nil, psatError.New("global configuration missing trust domain")
 	}
 
-	if len(hclConfig.Clusters) == 0 {
-		return nil, psatError.New("configuration must have at least one cluster")
+	clustersFileReloadInterval := defaultClustersFileReloadInterval
+	if hclConfig.ClustersFileReloadInterval != "" {
+		var err error
+		clustersFileReloadInterval, err = time.ParseDuration(hclConfig.ClustersFileReloadInterval)
+		if err != nil {
+			return nil, psatError.New("unable to parse clusters file reload interval: %v", err)
+		}
+		if clustersFileReloadInterval <= 0 {
+			return nil, psatError.New("clusters file reload interval must be positive")
+		}
+	}
+
+	clusters, err := p.makeClusters(hclConfig.Clusters)
+	if err != nil {
+		return nil, psatError.Wrap(err)
 	}
 
 	config := &attestorConfig{
-		trustDomain: req.GlobalConfig.TrustDomain,
-		clusters:    make(map[string]*clusterConfig),
+		trustDomain:                req.GlobalConfig.TrustDomain,
+		clusters:                   clusters,
+		clustersFile:               hclConfig.ClustersFile,
+		clustersFileReloadInterval: clustersFileReloadInterval,
+	}
+
+	if config.clustersFile != "" {
+		config.fileClusters, err = p.loadClustersFile(config)
+		if err != nil {
+			return nil, psatError.Wrap(err)
+		}
+		config.lastReload = p.clock.Now()
+	}
+
+	if len(config.clusters) == 0 && len(config.fileClusters) == 0 {
+		return nil, psatError.New("configuration must have at least one cluster")
+	}
+
+	p.setConfig(config)
+	return &spi.ConfigureResponse{}, nil
+}
+
+// SetLogger sets this plugin's logger
+func (p *AttestorPlugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *AttestorPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *AttestorPlugin) getTrustDomain() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.config == nil {
+		return "", errs.New("not configured")
+	}
+	return p.config.trustDomain, nil
+}
+
+// getCluster returns the configuration of the named cluster, or nil if the
+// cluster is not configured. The clusters file is reloaded first when the
+// reload interval has elapsed. When the file cannot be reloaded, the
+// clusters that were last loaded from it are kept.
+func (p *AttestorPlugin) getCluster(name string) *clusterConfig {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	config := p.config
+	if config.clustersFile != "" && p.clock.Now().Sub(config.lastReload) >= config.clustersFileReloadInterval {
+		fileClusters, err := p.loadClustersFile(config)
+		if err != nil {
+			if p.log != nil {
+				p.log.Warn("Failed to reload clusters file", "path", config.clustersFile, "error", err)
+			}
+		} else {
+			config.fileClusters = fileClusters
+		}
+		config.lastReload = p.clock.Now()
+	}
+
+	if cluster, ok := config.clusters[name]; ok {
+		return cluster
+	}
+	return config.fileClusters[name]
+}
+
+func (p *AttestorPlugin) setConfig(config *attestorConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+}
+
+// loadClustersFile loads the clusters in the clusters file. Clusters in the
+// file cannot override the ones in the plugin configuration.
+func (p *AttestorPlugin) loadClustersFile(config *attestorConfig) (map[string]*clusterConfig, error) {
+	data, err := ioutil.ReadFile(config.clustersFile)
+	if err != nil {
+		return nil, errs.New("unable to read clusters file: %v", err)
+	}
+
+	fileConfig := new(clustersFileConfig)
+	if err := hcl.Decode(fileConfig, string(data)); err != nil {
+		return nil, errs.New("unable to decode clusters file: %v", err)
+	}
+
+	for name := range fileConfig.Clusters {
+		if _, ok := config.clusters[name]; ok {
+			return nil, errs.New("cluster %q in clusters file is already configured", name)
+		}
 	}
 
-	for name, cluster := range hclConfig.Clusters {
+	return p.makeClusters(fileConfig.Clusters)
+}
+
+func (p *AttestorPlugin) makeClusters(hclClusters map[string]*ClusterConfig) (map[string]*clusterConfig, error) {
+	clusters := make(map[string]*clusterConfig)
+	for name, cluster := range hclClusters {
 		if len(cluster.ServiceAccountWhitelist) == 0 {
-			return nil, psatError.New("cluster %q configuration must have at least one service account whitelisted", name)
+			return nil, errs.New("cluster %q configuration must have at least one service account whitelisted", name)
 		}
 
 		serviceAccounts := make(map[string]bool)
@@ -249,34 +387,13 @@ func (p *AttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReques
 			allowedPodLabelKeys[label] = true
 		}
 
-		config.clusters[name] = &clusterConfig{
+		clusters[name] = &clusterConfig{
 			serviceAccounts:      serviceAccounts,
 			audience:             audience,
-			client:               apiserver.New(cluster.KubeConfigFile),
+			client:               p.newClient(cluster.KubeConfigFile),
 			allowedNodeLabelKeys: allowedNodeLabelKeys,
 			allowedPodLabelKeys:  allowedPodLabelKeys,
 		}
 	}
-
-	p.setConfig(config)
-	return &spi.ConfigureResponse{}, nil
-}
-
-func (p *AttestorPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
-	return &spi.GetPluginInfoResponse{}, nil
-}
-
-func (p *AttestorPlugin) getConfig() (*attestorConfig, error) {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
-	if p.config == nil {
-		return nil, psatError.New("not configured")
-	}
-	return p.config, nil
-}
-
-func (p *AttestorPlugin) setConfig(config *attestorConfig) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-	p.config = config
+	return clusters, nil
 }
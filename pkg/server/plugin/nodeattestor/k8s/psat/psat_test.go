@@ -20,9 +20,11 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	sat_common "github.com/spiffe/spire/pkg/common/plugin/k8s"
+	"github.com/spiffe/spire/pkg/common/plugin/k8s/apiserver"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/clock"
 	k8s_apiserver_mock "github.com/spiffe/spire/test/mock/common/plugin/k8s/apiserver"
 	"github.com/spiffe/spire/test/spiretest"
 	"google.golang.org/grpc/codes"
@@ -363,6 +365,77 @@ func (s *AttestorSuite) TestAttestSuccess() {
 	}, resp.Selectors)
 }
 
+func (s *AttestorSuite) TestAttestWithClustersFile() {
+	clustersFile := filepath.Join(s.dir, "clusters.conf")
+	s.Require().NoError(ioutil.WriteFile(clustersFile, []byte(`
+		clusters = {
+			"BAZ" = {
+				service_account_whitelist = ["NS3:SA3"]
+			}
+		}
+	`), 0600))
+
+	clk := clock.NewMock(s.T())
+	attestor := New()
+	attestor.clock = clk
+	attestor.newClient = func(string) apiserver.Client { return s.mockClient }
+	_, err := attestor.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+		clusters_file = %q
+		clusters_file_reload_interval = "10s"
+		`, clustersFile),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+
+	var p nodeattestor.Plugin
+	s.LoadPlugin(builtin(attestor), &p)
+
+	attest := func(cluster string) (*nodeattestor.AttestResponse, error) {
+		tokenData := &TokenData{
+			namespace:          "NS3",
+			serviceAccountName: "SA3",
+			podName:            "PODNAME-3",
+			podUID:             "PODUID-3",
+		}
+		token := s.signToken(s.fooSigner, tokenData)
+		s.mockClient.EXPECT().ValidateToken(notNil, token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
+		s.mockClient.EXPECT().GetPod(notNil, "NS3", "PODNAME-3").Return(createPod("NODENAME-3"), nil)
+		s.mockClient.EXPECT().GetNode(notNil, "NODENAME-3").Return(createNode("NODEUID-3"), nil)
+		return s.doAttestOnAttestor(p, makeAttestRequest(cluster, token))
+	}
+
+	resp, err := attest("BAZ")
+	s.Require().NoError(err)
+	s.Require().Equal("spiffe://example.org/spire/agent/k8s_psat/BAZ/NODEUID-3", resp.AgentId)
+	s.Require().Contains(resp.Selectors, &common.Selector{Type: "k8s_psat", Value: "cluster:BAZ"})
+
+	// The cluster is replaced in the file, which is not reloaded until the
+	// reload interval elapses
+	s.Require().NoError(ioutil.WriteFile(clustersFile, []byte(`
+		clusters = {
+			"QUX" = {
+				service_account_whitelist = ["NS3:SA3"]
+			}
+		}
+	`), 0600))
+	_, err = s.doAttestOnAttestor(p, makeAttestRequest("QUX", "blah"))
+	s.RequireErrorContains(err, `k8s-psat: not configured for cluster "QUX"`)
+
+	clk.Add(10 * time.Second)
+	resp, err = attest("QUX")
+	s.Require().NoError(err)
+	s.Require().Equal("spiffe://example.org/spire/agent/k8s_psat/QUX/NODEUID-3", resp.AgentId)
+	_, err = s.doAttestOnAttestor(p, makeAttestRequest("BAZ", "blah"))
+	s.RequireErrorContains(err, `k8s-psat: not configured for cluster "BAZ"`)
+
+	// Clusters last loaded are kept when the file fails to reload
+	s.Require().NoError(ioutil.WriteFile(clustersFile, []byte("blah"), 0600))
+	clk.Add(10 * time.Second)
+	_, err = attest("QUX")
+	s.Require().NoError(err)
+}
+
 func (s *AttestorSuite) TestConfigure() {
 	// malformed configuration
 	resp, err := s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
@@ -399,6 +472,42 @@ func (s *AttestorSuite) TestConfigure() {
 	s.RequireGRPCStatus(err, codes.Unknown, `k8s-psat: cluster "FOO" configuration must have at least one service account whitelisted`)
 	s.Require().Nil(resp)
 
+	// invalid clusters file reload interval
+	resp, err = s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `clusters_file_reload_interval = "blah"`,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.RequireErrorContains(err, "k8s-psat: unable to parse clusters file reload interval")
+	s.Require().Nil(resp)
+
+	// missing clusters file
+	resp, err = s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`clusters_file = %q`, filepath.Join(s.dir, "missing.conf")),
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.RequireErrorContains(err, "k8s-psat: unable to read clusters file")
+	s.Require().Nil(resp)
+
+	// cluster configured in both the configuration and the clusters file
+	clustersFile := filepath.Join(s.dir, "duplicate.conf")
+	s.Require().NoError(ioutil.WriteFile(clustersFile, []byte(`clusters = {
+			"FOO" = {
+				service_account_whitelist = ["NS1:SA1"]
+			}
+		}`), 0600))
+	resp, err = s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+		clusters_file = %q
+		clusters = {
+			"FOO" = {
+				service_account_whitelist = ["NS1:SA1"]
+			}
+		}`, clustersFile),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, `k8s-psat: cluster "FOO" in clusters file is already configured`)
+	s.Require().Nil(resp)
+
 	// success with two CERT based key files
 	s.configureAttestor()
 }
@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/plugin/x509pop"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
 	"github.com/spiffe/spire/proto/spire/common"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
@@ -35,6 +37,8 @@ type configuration struct {
 	trustDomain  string
 	trustBundle  *x509.CertPool
 	pathTemplate *template.Template
+
+	clockSkewTolerance time.Duration
 }
 
 type Config struct {
@@ -90,11 +94,11 @@ func (p *Plugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) error {
 	}
 
 	// verify the chain of trust
-	chains, err := leaf.Verify(x509.VerifyOptions{
+	chains, err := x509util.VerifyWithClockSkew(leaf, x509.VerifyOptions{
 		Intermediates: intermediates,
 		Roots:         c.trustBundle,
 		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
-	})
+	}, time.Now(), c.clockSkewTolerance)
 	if err != nil {
 		return newError("certificate verification failed: %v", err)
 	}
@@ -175,6 +179,8 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi
 		trustDomain:  req.GlobalConfig.TrustDomain,
 		trustBundle:  util.NewCertPool(bundles...),
 		pathTemplate: pathTemplate,
+
+		clockSkewTolerance: time.Duration(req.GlobalConfig.ClockSkewTolerance) * time.Second,
 	})
 
 	return &spi.ConfigureResponse{}, nil
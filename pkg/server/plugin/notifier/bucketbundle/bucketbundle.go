@@ -0,0 +1,365 @@
+package bucketbundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	providerS3        = "s3"
+	providerGCS       = "gcs"
+	providerAzureBlob = "azure_blob"
+
+	// formatPEM uploads the X.509 authorities as PEM encoded certificates
+	formatPEM = "pem"
+	// formatSPIFFE uploads the bundle in the SPIFFE bundle format
+	formatSPIFFE = "spiffe"
+	// formatJWKS uploads the JWT authorities as a standard JWKS document
+	formatJWKS = "jwks"
+
+	defaultObjectKey = "{{ .TrustDomain }}.{{ .Extension }}"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtIn(New())
+}
+
+func builtIn(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin("bucket_bundle",
+		notifier.PluginServer(p),
+	)
+}
+
+// bucketClient uploads objects to a bucket of an object storage provider.
+type bucketClient interface {
+	// GetObject returns the content of the object, or nil if it does not
+	// exist.
+	GetObject(ctx context.Context, bucket, key string) ([]byte, error)
+	PutObject(ctx context.Context, bucket, key string, data []byte, contentType string) error
+	Close() error
+}
+
+type pluginConfig struct {
+	Provider                string `hcl:"provider"`
+	Bucket                  string `hcl:"bucket"`
+	ObjectKey               string `hcl:"object_key"`
+	Format                  string `hcl:"format"`
+	IncludeFederatedBundles bool   `hcl:"include_federated_bundles"`
+
+	// S3
+	Region          string `hcl:"region"`
+	Endpoint        string `hcl:"endpoint"`
+	AccessKeyID     string `hcl:"access_key_id"`
+	SecretAccessKey string `hcl:"secret_access_key"`
+
+	// GCS
+	ServiceAccountFile string `hcl:"service_account_file"`
+
+	// Azure Blob
+	StorageAccount    string `hcl:"storage_account"`
+	StorageAccountKey string `hcl:"storage_account_key"`
+
+	objectKey *template.Template
+}
+
+// objectKeyData holds the values the object key template is rendered with.
+type objectKeyData struct {
+	// TrustDomain is the name of the trust domain of the bundle, e.g.
+	// example.org
+	TrustDomain string
+
+	// Extension is the file extension of the format, i.e. pem or json
+	Extension string
+}
+
+type Plugin struct {
+	mu               sync.RWMutex
+	log              hclog.Logger
+	config           *pluginConfig
+	identityProvider hostservices.IdentityProvider
+
+	hooks struct {
+		newBucketClient func(ctx context.Context, c *pluginConfig) (bucketClient, error)
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{}
+	p.hooks.newBucketClient = newBucketClient
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) BrokerHostServices(broker catalog.HostServiceBroker) error {
+	has, err := broker.GetHostService(hostservices.IdentityProviderHostServiceClient(&p.identityProvider))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return status.Errorf(codes.FailedPrecondition, "IdentityProvider host service is required")
+	}
+	return nil
+}
+
+func (p *Plugin) Notify(ctx context.Context, req *notifier.NotifyRequest) (*notifier.NotifyResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.Event.(type) {
+	case *notifier.NotifyRequest_BundleUpdated:
+		// ignore the bundle presented in the request. see uploadBundles for details on why.
+		if _, err := p.uploadBundles(ctx, config, false); err != nil {
+			return nil, err
+		}
+	case *notifier.NotifyRequest_ReconcileBundle:
+		uploaded, err := p.uploadBundles(ctx, config, true)
+		if err != nil {
+			return nil, err
+		}
+		return &notifier.NotifyResponse{DriftedCopies: int32(uploaded)}, nil
+	}
+	return &notifier.NotifyResponse{}, nil
+}
+
+func (p *Plugin) NotifyAndAdvise(ctx context.Context, req *notifier.NotifyAndAdviseRequest) (*notifier.NotifyAndAdviseResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := req.Event.(*notifier.NotifyAndAdviseRequest_BundleLoaded); ok {
+		// ignore the bundle presented in the request. see uploadBundles for details on why.
+		if _, err := p.uploadBundles(ctx, config, false); err != nil {
+			return nil, err
+		}
+	}
+	return &notifier.NotifyAndAdviseResponse{}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (resp *spi.ConfigureResponse, err error) {
+	if p.identityProvider == nil {
+		return nil, status.Error(codes.FailedPrecondition, "IdentityProvider host service is required but not brokered")
+	}
+
+	config := new(pluginConfig)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	switch config.Provider {
+	case providerS3:
+		if config.Region == "" {
+			return nil, status.Error(codes.InvalidArgument, "region must be set with the s3 provider")
+		}
+	case providerGCS:
+	case providerAzureBlob:
+		if config.StorageAccount == "" || config.StorageAccountKey == "" {
+			return nil, status.Error(codes.InvalidArgument, "storage_account and storage_account_key must be set with the azure_blob provider")
+		}
+	case "":
+		return nil, status.Error(codes.InvalidArgument, "provider must be set")
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported provider %q", config.Provider)
+	}
+
+	if config.Bucket == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket must be set")
+	}
+
+	if config.Format == "" {
+		config.Format = formatPEM
+	}
+	if _, err := extension(config.Format); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if config.ObjectKey == "" {
+		config.ObjectKey = defaultObjectKey
+	}
+	config.objectKey, err = template.New("object_key").Option("missingkey=error").Parse(config.ObjectKey)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to parse object_key: %v", err)
+	}
+
+	// The bundles of the federated trust domains are uploaded with the same
+	// template, so it must produce a key per trust domain.
+	key1, err := config.renderObjectKey("domain1.test")
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to render object_key: %v", err)
+	}
+	key2, _ := config.renderObjectKey("domain2.test")
+	if config.IncludeFederatedBundles && key1 == key2 {
+		return nil, status.Error(codes.InvalidArgument, "object_key must include the trust domain when include_federated_bundles is set")
+	}
+
+	p.setConfig(config)
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(ctx context.Context, req *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getConfig() (*pluginConfig, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.config == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.config, nil
+}
+
+func (p *Plugin) setConfig(config *pluginConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+}
+
+// uploadBundles uploads the bundle of the trust domain and, if configured,
+// the bundles of the federated trust domains. The bundles are loaded from
+// the identity provider instead of the notification so that the latest
+// bundles are uploaded even if notifications are processed out of order.
+// When reconciling, only the objects whose content does not match their
+// bundle are uploaded. It returns the number of objects uploaded.
+func (p *Plugin) uploadBundles(ctx context.Context, c *pluginConfig, reconcile bool) (int, error) {
+	resp, err := p.identityProvider.FetchX509Identity(ctx, &hostservices.FetchX509IdentityRequest{
+		IncludeFederatedBundles: c.IncludeFederatedBundles,
+	})
+	if err != nil {
+		st := status.Convert(err)
+		return 0, status.Errorf(st.Code(), "unable to fetch bundle from SPIRE server: %v", st.Message())
+	}
+
+	client, err := p.hooks.newBucketClient(ctx, c)
+	if err != nil {
+		return 0, status.Errorf(codes.Unknown, "unable to instantiate bucket client: %v", err)
+	}
+	defer client.Close()
+
+	uploaded := 0
+	bundles := append([]*common.Bundle{resp.Bundle}, resp.FederatedBundles...)
+	for _, bundle := range bundles {
+		ok, err := p.uploadBundle(ctx, c, client, bundle, reconcile)
+		if err != nil {
+			return uploaded, err
+		}
+		if ok {
+			uploaded++
+		}
+	}
+	return uploaded, nil
+}
+
+func (p *Plugin) uploadBundle(ctx context.Context, c *pluginConfig, client bucketClient, bundle *common.Bundle, reconcile bool) (bool, error) {
+	td, err := spiffeid.TrustDomainFromString(bundle.TrustDomainId)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "invalid trust domain %q: %v", bundle.TrustDomainId, err)
+	}
+
+	key, err := c.renderObjectKey(td.String())
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "unable to render object key: %v", err)
+	}
+
+	data, contentType, err := bundleData(bundle, c.Format)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "unable to format bundle of %q: %v", td, err)
+	}
+
+	if reconcile {
+		current, err := client.GetObject(ctx, c.Bucket, key)
+		if err != nil {
+			return false, status.Errorf(codes.Unknown, "unable to get bundle object %s/%s: %v", c.Bucket, key, err)
+		}
+		if bytes.Equal(current, data) {
+			return false, nil
+		}
+	}
+
+	if err := client.PutObject(ctx, c.Bucket, key, data, contentType); err != nil {
+		return false, status.Errorf(codes.Unknown, "unable to upload bundle object %s/%s: %v", c.Bucket, key, err)
+	}
+	p.log.Debug("Bundle object uploaded", telemetry.TrustDomainID, td.IDString(), "object", key)
+	return true, nil
+}
+
+func (c *pluginConfig) renderObjectKey(trustDomain string) (string, error) {
+	ext, err := extension(c.Format)
+	if err != nil {
+		return "", err
+	}
+
+	key := new(bytes.Buffer)
+	if err := c.objectKey.Execute(key, objectKeyData{
+		TrustDomain: trustDomain,
+		Extension:   ext,
+	}); err != nil {
+		return "", err
+	}
+	return key.String(), nil
+}
+
+// extension returns the file extension of the objects in the given format.
+func extension(format string) (string, error) {
+	switch format {
+	case formatPEM:
+		return "pem", nil
+	case formatSPIFFE, formatJWKS:
+		return "json", nil
+	default:
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// bundleData formats the bundle data for storage in the bucket, returning
+// the data and its content type.
+func bundleData(bundle *common.Bundle, format string) ([]byte, string, error) {
+	switch format {
+	case formatSPIFFE, formatJWKS:
+		b, err := bundleutil.BundleFromProto(bundle)
+		if err != nil {
+			return nil, "", err
+		}
+		var opts []bundleutil.MarshalOption
+		if format == formatJWKS {
+			opts = append(opts, bundleutil.NoX509SVIDKeys(), bundleutil.StandardJWKS())
+		}
+		data, err := bundleutil.Marshal(b, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, "application/json", nil
+	default:
+		bundleData := new(bytes.Buffer)
+		for _, rootCA := range bundle.RootCas {
+			// no need to check the error since we're encoding into a memory buffer
+			_ = pem.Encode(bundleData, &pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: rootCA.DerBytes,
+			})
+		}
+		return bundleData.Bytes(), "application/x-pem-file", nil
+	}
+}
@@ -0,0 +1,486 @@
+package bucketbundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/fakes/fakeidentityprovider"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRequiresIdentityProvider(t *testing.T) {
+	_, err := catalog.LoadBuiltInPlugin(context.Background(), catalog.BuiltInPlugin{
+		Plugin: BuiltIn(),
+	})
+	spiretest.RequireGRPCStatusContains(t, err, codes.Unknown, "IdentityProvider host service is required")
+}
+
+func TestConfigure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		code   codes.Code
+		desc   string
+	}{
+		{
+			name: "malformed",
+			config: `
+				MALFORMED
+			`,
+			code: codes.InvalidArgument,
+			desc: "unable to decode configuration",
+		},
+		{
+			name: "missing provider",
+			config: `
+				bucket = "the-bucket"
+			`,
+			code: codes.InvalidArgument,
+			desc: "provider must be set",
+		},
+		{
+			name: "unsupported provider",
+			config: `
+				provider = "floppy"
+				bucket = "the-bucket"
+			`,
+			code: codes.InvalidArgument,
+			desc: `unsupported provider "floppy"`,
+		},
+		{
+			name: "missing bucket",
+			config: `
+				provider = "gcs"
+			`,
+			code: codes.InvalidArgument,
+			desc: "bucket must be set",
+		},
+		{
+			name: "missing s3 region",
+			config: `
+				provider = "s3"
+				bucket = "the-bucket"
+			`,
+			code: codes.InvalidArgument,
+			desc: "region must be set with the s3 provider",
+		},
+		{
+			name: "missing azure storage account",
+			config: `
+				provider = "azure_blob"
+				bucket = "the-container"
+			`,
+			code: codes.InvalidArgument,
+			desc: "storage_account and storage_account_key must be set with the azure_blob provider",
+		},
+		{
+			name: "unsupported format",
+			config: `
+				provider = "gcs"
+				bucket = "the-bucket"
+				format = "der"
+			`,
+			code: codes.InvalidArgument,
+			desc: `unsupported format "der"`,
+		},
+		{
+			name: "malformed object key",
+			config: `
+				provider = "gcs"
+				bucket = "the-bucket"
+				object_key = "{{ .TrustDomain"
+			`,
+			code: codes.InvalidArgument,
+			desc: "unable to parse object_key",
+		},
+		{
+			name: "unknown object key field",
+			config: `
+				provider = "gcs"
+				bucket = "the-bucket"
+				object_key = "{{ .Bundle }}"
+			`,
+			code: codes.InvalidArgument,
+			desc: "unable to render object_key",
+		},
+		{
+			name: "object key without trust domain with federated bundles",
+			config: `
+				provider = "gcs"
+				bucket = "the-bucket"
+				object_key = "bundle.pem"
+				include_federated_bundles = true
+			`,
+			code: codes.InvalidArgument,
+			desc: "object_key must include the trust domain when include_federated_bundles is set",
+		},
+		{
+			name: "success with s3",
+			config: `
+				provider = "s3"
+				bucket = "the-bucket"
+				region = "us-east-1"
+				access_key_id = "the-access-key-id"
+				secret_access_key = "the-secret-access-key"
+			`,
+			code: codes.OK,
+		},
+		{
+			name: "success with gcs",
+			config: `
+				provider = "gcs"
+				bucket = "the-bucket"
+				object_key = "bundle.pem"
+				service_account_file = "the-service-account-file"
+			`,
+			code: codes.OK,
+		},
+		{
+			name: "success with azure blob",
+			config: `
+				provider = "azure_blob"
+				bucket = "the-container"
+				storage_account = "the-storage-account"
+				storage_account_key = "the-storage-account-key"
+				format = "spiffe"
+				object_key = "bundles/{{ .TrustDomain }}.{{ .Extension }}"
+				include_federated_bundles = true
+			`,
+			code: codes.OK,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			idp := fakeidentityprovider.New()
+
+			raw := New()
+			var plugin notifier.Plugin
+			spiretest.LoadPlugin(t, builtIn(raw), &plugin,
+				spiretest.HostService(hostservices.IdentityProviderHostServiceServer(idp)))
+
+			resp, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{Configuration: tt.config})
+			if tt.code != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.desc)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	resp, err := New().GetPluginInfo(context.Background(), &spi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &spi.GetPluginInfoResponse{}, resp)
+}
+
+func TestNotify(t *testing.T) {
+	testUploadBundles(t, func(plugin notifier.Plugin) error {
+		_, err := plugin.Notify(context.Background(), &notifier.NotifyRequest{
+			Event: &notifier.NotifyRequest_BundleUpdated{
+				BundleUpdated: &notifier.BundleUpdated{},
+			},
+		})
+		return err
+	})
+}
+
+func TestNotifyAndAdvise(t *testing.T) {
+	testUploadBundles(t, func(plugin notifier.Plugin) error {
+		_, err := plugin.NotifyAndAdvise(context.Background(), &notifier.NotifyAndAdviseRequest{
+			Event: &notifier.NotifyAndAdviseRequest_BundleLoaded{
+				BundleLoaded: &notifier.BundleLoaded{},
+			},
+		})
+		return err
+	})
+}
+
+func TestReconcileBundle(t *testing.T) {
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("1")}},
+	}
+	federatedBundle := &common.Bundle{
+		TrustDomainId: "spiffe://domain.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("2")}},
+	}
+
+	client := newFakeBucketClient()
+	client.objects["example.org.pem"] = pemData(t, bundle)
+	client.objects["domain.test.pem"] = pemData(t, bundle)
+
+	raw := New()
+	raw.hooks.newBucketClient = func(ctx context.Context, c *pluginConfig) (bucketClient, error) {
+		return client, nil
+	}
+
+	idp := fakeidentityprovider.New()
+	idp.AppendBundle(bundle)
+	idp.SetFederatedBundles(federatedBundle)
+
+	var plugin notifier.Plugin
+	spiretest.LoadPlugin(t, builtIn(raw), &plugin,
+		spiretest.HostService(hostservices.IdentityProviderHostServiceServer(idp)))
+
+	_, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+			provider = "gcs"
+			bucket = "the-bucket"
+			include_federated_bundles = true
+		`,
+	})
+	require.NoError(t, err)
+
+	resp, err := plugin.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_ReconcileBundle{
+			ReconcileBundle: &notifier.ReconcileBundle{},
+		},
+	})
+	require.NoError(t, err)
+
+	// Only the object holding the wrong bundle is uploaded again
+	require.Equal(t, &notifier.NotifyResponse{DriftedCopies: 1}, resp)
+	require.Equal(t, []string{"domain.test.pem"}, client.Puts())
+	require.Equal(t, map[string]string{
+		"example.org.pem": pemData(t, bundle),
+		"domain.test.pem": pemData(t, federatedBundle),
+	}, client.GetObjects())
+}
+
+func testUploadBundles(t *testing.T, notify func(plugin notifier.Plugin) error) {
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("1")}},
+	}
+	federatedBundle := &common.Bundle{
+		TrustDomainId: "spiffe://domain.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("2")}},
+	}
+
+	ca := testca.New(t, spiffeid.RequireTrustDomainFromString("example.org"))
+	caBundle := bundleutil.BundleFromRootCAs("spiffe://example.org", ca.X509Authorities())
+	spiffeData, err := bundleutil.Marshal(caBundle)
+	require.NoError(t, err)
+
+	const baseConfig = `
+		provider = "gcs"
+		bucket = "the-bucket"
+		object_key = "bundles/{{ .TrustDomain }}.{{ .Extension }}"
+	`
+
+	for _, tt := range []struct {
+		name                  string
+		config                string
+		bundles               []*common.Bundle
+		skipConfigure         bool
+		configureBucketClient func(client *fakeBucketClient) error
+		code                  codes.Code
+		desc                  string
+		expectedObjects       map[string]string
+	}{
+		{
+			name:          "not configured",
+			skipConfigure: true,
+			code:          codes.FailedPrecondition,
+			desc:          "not configured",
+		},
+		{
+			name: "failed to fetch bundle from identity provider",
+			code: codes.Unknown,
+			desc: "unable to fetch bundle from SPIRE server: no bundle",
+		},
+		{
+			name:    "failed to create bucket client",
+			bundles: []*common.Bundle{bundle},
+			configureBucketClient: func(*fakeBucketClient) error {
+				return errors.New("ohno")
+			},
+			code: codes.Unknown,
+			desc: "unable to instantiate bucket client: ohno",
+		},
+		{
+			name:    "failed to put object",
+			bundles: []*common.Bundle{bundle},
+			configureBucketClient: func(client *fakeBucketClient) error {
+				client.SetPutObjectError(errors.New("ohno"))
+				return nil
+			},
+			code: codes.Unknown,
+			desc: "unable to upload bundle object the-bucket/bundles/example.org.pem: ohno",
+		},
+		{
+			name:    "success",
+			bundles: []*common.Bundle{bundle},
+			code:    codes.OK,
+			expectedObjects: map[string]string{
+				"bundles/example.org.pem": pemData(t, bundle),
+			},
+		},
+		{
+			name:    "success with federated bundles",
+			config:  `include_federated_bundles = true`,
+			bundles: []*common.Bundle{bundle},
+			code:    codes.OK,
+			expectedObjects: map[string]string{
+				"bundles/example.org.pem": pemData(t, bundle),
+				"bundles/domain.test.pem": pemData(t, federatedBundle),
+			},
+		},
+		{
+			name:    "success with spiffe format",
+			config:  `format = "spiffe"`,
+			bundles: []*common.Bundle{caBundle.Proto()},
+			code:    codes.OK,
+			expectedObjects: map[string]string{
+				"bundles/example.org.json": string(spiffeData),
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a raw instance so we can hook the bucket client creation,
+			// possibly overriding with a test specific hook.
+			client := newFakeBucketClient()
+			raw := New()
+			raw.hooks.newBucketClient = func(ctx context.Context, c *pluginConfig) (bucketClient, error) {
+				if c.Provider != providerGCS {
+					return nil, fmt.Errorf("unexpected provider %q", c.Provider)
+				}
+				if tt.configureBucketClient != nil {
+					if err := tt.configureBucketClient(client); err != nil {
+						return nil, err
+					}
+				}
+				return client, nil
+			}
+
+			idp := fakeidentityprovider.New()
+			for _, bundle := range tt.bundles {
+				idp.AppendBundle(bundle)
+			}
+			idp.SetFederatedBundles(federatedBundle)
+
+			// Load the instance as a plugin
+			var plugin notifier.Plugin
+			spiretest.LoadPlugin(t, builtIn(raw), &plugin,
+				spiretest.HostService(hostservices.IdentityProviderHostServiceServer(idp)))
+
+			if !tt.skipConfigure {
+				_, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{
+					Configuration: baseConfig + tt.config,
+				})
+				require.NoError(t, err)
+			}
+
+			err := notify(plugin)
+			if tt.code != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.code, tt.desc)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedObjects, client.GetObjects())
+			require.True(t, client.Closed())
+		})
+	}
+}
+
+func pemData(t *testing.T, bundle *common.Bundle) string {
+	data, contentType, err := bundleData(bundle, formatPEM)
+	require.NoError(t, err)
+	require.Equal(t, "application/x-pem-file", contentType)
+	return string(data)
+}
+
+type fakeBucketClient struct {
+	mu           sync.Mutex
+	objects      map[string]string
+	putObjectErr error
+	puts         []string
+	closed       bool
+}
+
+func newFakeBucketClient() *fakeBucketClient {
+	return &fakeBucketClient{
+		objects: make(map[string]string),
+	}
+}
+
+func (c *fakeBucketClient) GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.objects[key]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(data), nil
+}
+
+func (c *fakeBucketClient) PutObject(ctx context.Context, bucket, key string, data []byte, contentType string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if bucket != "the-bucket" {
+		return fmt.Errorf("expected bucket %q; got %q", "the-bucket", bucket)
+	}
+	if c.putObjectErr != nil {
+		return c.putObjectErr
+	}
+
+	c.objects[key] = string(data)
+	c.puts = append(c.puts, key)
+	return nil
+}
+
+func (c *fakeBucketClient) SetPutObjectError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.putObjectErr = err
+}
+
+func (c *fakeBucketClient) GetObjects() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	objects := make(map[string]string, len(c.objects))
+	for key, data := range c.objects {
+		objects[key] = data
+	}
+	return objects
+}
+
+func (c *fakeBucketClient) Puts() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.puts...)
+}
+
+func (c *fakeBucketClient) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *fakeBucketClient) Closed() bool {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+	return closed
+}
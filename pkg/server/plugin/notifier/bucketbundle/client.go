@@ -0,0 +1,169 @@
+package bucketbundle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	azblob "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/zeebo/errs"
+	"google.golang.org/api/option"
+)
+
+func newBucketClient(ctx context.Context, c *pluginConfig) (bucketClient, error) {
+	switch c.Provider {
+	case providerS3:
+		return newS3BucketClient(c)
+	case providerGCS:
+		return newGCSBucketClient(ctx, c)
+	case providerAzureBlob:
+		return newAzureBlobBucketClient(c)
+	default:
+		return nil, fmt.Errorf("unsupported provider %q", c.Provider)
+	}
+}
+
+type s3BucketClient struct {
+	client *s3.S3
+}
+
+func newS3BucketClient(c *pluginConfig) (bucketClient, error) {
+	awsConfig := &aws.Config{
+		Region: aws.String(c.Region),
+	}
+	if c.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(c.Endpoint)
+		// Custom endpoints (e.g. S3 compatible storage) rarely support
+		// virtual hosted-style addressing
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	if c.SecretAccessKey != "" && c.AccessKeyID != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(c.AccessKeyID, c.SecretAccessKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &s3BucketClient{client: s3.New(sess)}, nil
+}
+
+func (c *s3BucketClient) GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	out, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	defer out.Body.Close()
+
+	data, err := ioutil.ReadAll(out.Body)
+	return data, errs.Wrap(err)
+}
+
+func (c *s3BucketClient) PutObject(ctx context.Context, bucket, key string, data []byte, contentType string) error {
+	_, err := c.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	return errs.Wrap(err)
+}
+
+func (c *s3BucketClient) Close() error {
+	return nil
+}
+
+type gcsBucketClient struct {
+	client *storage.Client
+}
+
+func newGCSBucketClient(ctx context.Context, c *pluginConfig) (bucketClient, error) {
+	var opts []option.ClientOption
+	if c.ServiceAccountFile != "" {
+		opts = append(opts, option.WithCredentialsFile(c.ServiceAccountFile))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &gcsBucketClient{client: client}, nil
+}
+
+func (c *gcsBucketClient) GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	r, err := c.client.Bucket(bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	return data, errs.Wrap(err)
+}
+
+func (c *gcsBucketClient) PutObject(ctx context.Context, bucket, key string, data []byte, contentType string) error {
+	w := c.client.Bucket(bucket).Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := w.Write(data); err != nil {
+		// the error is sticky and returned by Close as well
+		_ = w.Close()
+		return errs.Wrap(err)
+	}
+	return errs.Wrap(w.Close())
+}
+
+func (c *gcsBucketClient) Close() error {
+	return errs.Wrap(c.client.Close())
+}
+
+type azureBlobBucketClient struct {
+	client azblob.BlobStorageClient
+}
+
+func newAzureBlobBucketClient(c *pluginConfig) (bucketClient, error) {
+	client, err := azblob.NewBasicClient(c.StorageAccount, c.StorageAccountKey)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &azureBlobBucketClient{client: client.GetBlobService()}, nil
+}
+
+func (c *azureBlobBucketClient) GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	r, err := c.client.GetContainerReference(bucket).GetBlobReference(key).Get(nil)
+	if err != nil {
+		if serr, ok := err.(azblob.AzureStorageServiceError); ok && serr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	return data, errs.Wrap(err)
+}
+
+func (c *azureBlobBucketClient) PutObject(ctx context.Context, bucket, key string, data []byte, contentType string) error {
+	blob := c.client.GetContainerReference(bucket).GetBlobReference(key)
+	blob.Properties.ContentType = contentType
+	return errs.Wrap(blob.CreateBlockBlobFromReader(bytes.NewReader(data), nil))
+}
+
+func (c *azureBlobBucketClient) Close() error {
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/pem"
+	"io/ioutil"
 	"net/http"
 	"sync"
 
@@ -35,6 +36,7 @@ func builtIn(p *Plugin) catalog.Plugin {
 
 type bucketClient interface {
 	GetObjectGeneration(ctx context.Context, bucket, object string) (int64, error)
+	GetObject(ctx context.Context, bucket, object string) ([]byte, int64, error)
 	PutObject(ctx context.Context, bucket, object string, data []byte, generation int64) error
 	Close() error
 }
@@ -83,11 +85,20 @@ func (p *Plugin) Notify(ctx context.Context, req *notifier.NotifyRequest) (*noti
 		return nil, err
 	}
 
-	if _, ok := req.Event.(*notifier.NotifyRequest_BundleUpdated); ok {
+	switch req.Event.(type) {
+	case *notifier.NotifyRequest_BundleUpdated:
 		// ignore the bundle presented in the request. see updateBundleObject for details on why.
-		if err := p.updateBundleObject(ctx, config); err != nil {
+		if _, err := p.updateBundleObject(ctx, config, false); err != nil {
 			return nil, err
 		}
+	case *notifier.NotifyRequest_ReconcileBundle:
+		updated, err := p.updateBundleObject(ctx, config, true)
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			return &notifier.NotifyResponse{DriftedCopies: 1}, nil
+		}
 	}
 	return &notifier.NotifyResponse{}, nil
 }
@@ -100,7 +111,7 @@ func (p *Plugin) NotifyAndAdvise(ctx context.Context, req *notifier.NotifyAndAdv
 
 	if _, ok := req.Event.(*notifier.NotifyAndAdviseRequest_BundleLoaded); ok {
 		// ignore the bundle presented in the request. see updateBundleObject for details on why.
-		if err := p.updateBundleObject(ctx, config); err != nil {
+		if _, err := p.updateBundleObject(ctx, config, false); err != nil {
 			return nil, err
 		}
 	}
@@ -147,19 +158,29 @@ func (p *Plugin) setConfig(config *pluginConfig) {
 	p.config = config
 }
 
-func (p *Plugin) updateBundleObject(ctx context.Context, c *pluginConfig) (err error) {
+// updateBundleObject uploads the bundle to the bucket. When reconciling, the
+// object is only uploaded if its content does not match the bundle. It
+// returns whether the object was uploaded.
+func (p *Plugin) updateBundleObject(ctx context.Context, c *pluginConfig, reconcile bool) (updated bool, err error) {
 	client, err := p.hooks.newBucketClient(ctx, c.ServiceAccountFile)
 	if err != nil {
-		return status.Errorf(codes.Unknown, "unable to instantiate bucket client: %v", err)
+		return false, status.Errorf(codes.Unknown, "unable to instantiate bucket client: %v", err)
 	}
 	defer client.Close()
 
 	for {
 		// Get the bundle object generation that we can use to resolve
-		// conflicts racing on updates from other servers.
-		generation, err := client.GetObjectGeneration(ctx, c.Bucket, c.ObjectPath)
+		// conflicts racing on updates from other servers. When reconciling,
+		// the current content is retrieved as well to detect drift.
+		var generation int64
+		var currentData []byte
+		if reconcile {
+			currentData, generation, err = client.GetObject(ctx, c.Bucket, c.ObjectPath)
+		} else {
+			generation, err = client.GetObjectGeneration(ctx, c.Bucket, c.ObjectPath)
+		}
 		if err != nil {
-			return status.Errorf(codes.Unknown, "unable to get bundle object %s/%s: %v", c.Bucket, c.ObjectPath, err)
+			return false, status.Errorf(codes.Unknown, "unable to get bundle object %s/%s: %v", c.Bucket, c.ObjectPath, err)
 		}
 		p.log.Debug("Bundle object retrieved", telemetry.Generation, generation)
 
@@ -170,21 +191,26 @@ func (p *Plugin) updateBundleObject(ctx context.Context, c *pluginConfig) (err e
 		resp, err := p.identityProvider.FetchX509Identity(ctx, &hostservices.FetchX509IdentityRequest{})
 		if err != nil {
 			st := status.Convert(err)
-			return status.Errorf(st.Code(), "unable to fetch bundle from SPIRE server: %v", st.Message())
+			return false, status.Errorf(st.Code(), "unable to fetch bundle from SPIRE server: %v", st.Message())
+		}
+
+		data := bundleData(resp.Bundle)
+		if reconcile && bytes.Equal(currentData, data) {
+			return false, nil
 		}
 
 		// Upload the bundle, handling version conflicts
-		if err := client.PutObject(ctx, c.Bucket, c.ObjectPath, bundleData(resp.Bundle), generation); err != nil {
+		if err := client.PutObject(ctx, c.Bucket, c.ObjectPath, data, generation); err != nil {
 			// If there is a conflict then some other server won the race updating
 			// the object. We need to retrieve the latest bundle and try again.
 			if isConditionNotMetError(err) {
 				p.log.Debug("Conflict detected setting bundle object", telemetry.Generation, generation)
 				continue
 			}
-			return status.Errorf(codes.Unknown, "unable to update bundle object %s/%s: %v", c.Bucket, c.ObjectPath, err)
+			return false, status.Errorf(codes.Unknown, "unable to update bundle object %s/%s: %v", c.Bucket, c.ObjectPath, err)
 		}
 		p.log.Debug("Bundle object updated", telemetry.Generation, generation)
-		return nil
+		return true, nil
 	}
 }
 
@@ -218,6 +244,23 @@ func (c *gcsBucketClient) GetObjectGeneration(ctx context.Context, bucket, objec
 	return attrs.Generation, nil
 }
 
+func (c *gcsBucketClient) GetObject(ctx context.Context, bucket, object string) ([]byte, int64, error) {
+	r, err := c.client.Bucket(bucket).Object(object).NewReader(ctx)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return nil, 0, nil
+		}
+		return nil, 0, errs.Wrap(err)
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, 0, errs.Wrap(err)
+	}
+	return data, r.Attrs.Generation, nil
+}
+
 func (c *gcsBucketClient) PutObject(ctx context.Context, bucket, object string, data []byte, generation int64) error {
 	// If for whatever reason we don't make it to w.Close(), canceling the
 	// context will cleanly release resources held by the writer.
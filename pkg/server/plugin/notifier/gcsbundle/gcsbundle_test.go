@@ -126,6 +126,71 @@ func TestNotifyAndAdvise(t *testing.T) {
 	})
 }
 
+func TestReconcileBundle(t *testing.T) {
+	bundle := &common.Bundle{RootCas: []*common.Certificate{{DerBytes: []byte("1")}}}
+	staleBundle := &common.Bundle{RootCas: []*common.Certificate{{DerBytes: []byte("2")}}}
+
+	for _, tt := range []struct {
+		name            string
+		currentBundle   *common.Bundle
+		expectedDrifted int32
+		expectedPuts    int
+	}{
+		{
+			name:          "up to date",
+			currentBundle: bundle,
+		},
+		{
+			name:            "stale",
+			currentBundle:   staleBundle,
+			expectedDrifted: 1,
+			expectedPuts:    1,
+		},
+		{
+			name:            "missing",
+			expectedDrifted: 1,
+			expectedPuts:    1,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeBucketClient()
+			if tt.currentBundle != nil {
+				client.data = bundleData(tt.currentBundle)
+			}
+			raw := New()
+			raw.hooks.newBucketClient = func(ctx context.Context, serviceAccountFile string) (bucketClient, error) {
+				return client, nil
+			}
+
+			idp := fakeidentityprovider.New()
+			idp.AppendBundle(bundle)
+
+			var plugin notifier.Plugin
+			spiretest.LoadPlugin(t, builtIn(raw), &plugin,
+				spiretest.HostService(hostservices.IdentityProviderHostServiceServer(idp)))
+
+			_, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{
+				Configuration: `
+				bucket = "the-bucket"
+				object_path = "bundle.pem"
+			`,
+			})
+			require.NoError(t, err)
+
+			resp, err := plugin.Notify(context.Background(), &notifier.NotifyRequest{
+				Event: &notifier.NotifyRequest_ReconcileBundle{
+					ReconcileBundle: &notifier.ReconcileBundle{},
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedDrifted, resp.DriftedCopies)
+			require.Equal(t, tt.expectedPuts, client.Puts())
+			require.Equal(t, bundleData(bundle), client.GetBundleData())
+		})
+	}
+}
+
 func testUpdateBundleObject(t *testing.T, notify func(plugin notifier.Plugin) error) {
 	bundle1 := &common.Bundle{RootCas: []*common.Certificate{{DerBytes: []byte("1")}}}
 	bundle2 := &common.Bundle{RootCas: []*common.Certificate{{DerBytes: []byte("2")}}}
@@ -267,6 +332,7 @@ type fakeBucketClient struct {
 	data                   []byte
 	getObjectGenerationErr error
 	putObjectErrs          []error
+	puts                   int
 	closed                 bool
 }
 
@@ -280,6 +346,12 @@ func (c *fakeBucketClient) GetObjectGeneration(ctx context.Context, bucket, obje
 	return 99, c.getObjectGenerationErr
 }
 
+func (c *fakeBucketClient) GetObject(ctx context.Context, bucket, object string) ([]byte, int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]byte(nil), c.data...), 99, c.getObjectGenerationErr
+}
+
 func (c *fakeBucketClient) PutObject(ctx context.Context, bucket, object string, data []byte, generation int64) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -301,6 +373,7 @@ func (c *fakeBucketClient) PutObject(ctx context.Context, bucket, object string,
 	}
 
 	c.data = append([]byte(nil), data...)
+	c.puts++
 	return nil
 }
 
@@ -323,6 +396,12 @@ func (c *fakeBucketClient) GetBundleData() []byte {
 	return data
 }
 
+func (c *fakeBucketClient) Puts() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.puts
+}
+
 func (c *fakeBucketClient) Close() error {
 	c.mu.Lock()
 	c.closed = true
@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
 	"sync"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
@@ -35,6 +38,14 @@ const (
 	defaultNamespace    = "spire"
 	defaultConfigMap    = "spire-bundle"
 	defaultConfigMapKey = "bundle.crt"
+	defaultSecretKey    = "bundle.crt"
+
+	// formatPEM writes the X.509 authorities as PEM encoded certificates
+	formatPEM = "pem"
+	// formatSPIFFE writes the bundle in the SPIFFE bundle format
+	formatSPIFFE = "spiffe"
+	// formatJWKS writes the JWT authorities as a standard JWKS document
+	formatJWKS = "jwks"
 )
 
 func BuiltIn() catalog.Plugin {
@@ -47,11 +58,89 @@ func builtIn(p *Plugin) catalog.Plugin {
 	)
 }
 
-type pluginConfig struct {
-	Namespace          string `hcl:"namespace"`
-	ConfigMap          string `hcl:"config_map"`
-	ConfigMapKey       string `hcl:"config_map_key"`
+// ClusterConfig configures how to reach a cluster and where the bundle is
+// written in it.
+type ClusterConfig struct {
+	// KubeConfigFilePath is the path to the kubeconfig used to reach the
+	// cluster.
 	KubeConfigFilePath string `hcl:"kube_config_file_path"`
+
+	// APIServer is the address of the API server of the cluster, used
+	// instead of a kubeconfig along with TokenFilePath and CAFilePath.
+	APIServer     string `hcl:"api_server"`
+	TokenFilePath string `hcl:"token_file_path"`
+	CAFilePath    string `hcl:"ca_file_path"`
+
+	Namespace    string `hcl:"namespace"`
+	ConfigMap    string `hcl:"config_map"`
+	ConfigMapKey string `hcl:"config_map_key"`
+
+	// Secret, if set, is the name of the Secret the bundle is written to
+	// instead of the ConfigMap.
+	Secret    string `hcl:"secret"`
+	SecretKey string `hcl:"secret_key"`
+
+	Format string `hcl:"format"`
+
+	// name is the name of the cluster block, if any
+	name string
+}
+
+// wrapErr prefixes the error with the name of the cluster, if any.
+func (c *ClusterConfig) wrapErr(err error) error {
+	if c.name == "" {
+		return err
+	}
+	return fmt.Errorf("cluster %q: %w", c.name, err)
+}
+
+// object returns the kind and name of the object the bundle is written to.
+func (c *ClusterConfig) object() (kind, name string) {
+	if c.Secret != "" {
+		return "secret", c.Secret
+	}
+	return "config map", c.ConfigMap
+}
+
+// setDefaults sets the unset fields to the ones of the given configuration.
+func (c *ClusterConfig) setDefaults(d ClusterConfig) {
+	setDefault := func(value *string, defaultValue string) {
+		if *value == "" {
+			*value = defaultValue
+		}
+	}
+	setDefault(&c.Namespace, d.Namespace)
+	setDefault(&c.ConfigMap, d.ConfigMap)
+	setDefault(&c.ConfigMapKey, d.ConfigMapKey)
+	setDefault(&c.Secret, d.Secret)
+	setDefault(&c.SecretKey, d.SecretKey)
+	setDefault(&c.Format, d.Format)
+}
+
+func (c *ClusterConfig) validate() error {
+	if c.KubeConfigFilePath != "" && c.APIServer != "" {
+		return c.wrapErr(errors.New("kube_config_file_path and api_server are mutually exclusive"))
+	}
+	if c.APIServer == "" && (c.TokenFilePath != "" || c.CAFilePath != "") {
+		return c.wrapErr(errors.New("token_file_path and ca_file_path require api_server"))
+	}
+	switch c.Format {
+	case formatPEM, formatSPIFFE, formatJWKS:
+	default:
+		return c.wrapErr(fmt.Errorf("unsupported format %q", c.Format))
+	}
+	return nil
+}
+
+type pluginConfig struct {
+	// The top-level settings configure a single cluster. When clusters are
+	// configured, they provide the defaults of the clusters instead.
+	ClusterConfig `hcl:",squash"`
+
+	Clusters map[string]ClusterConfig `hcl:"cluster"`
+
+	// clusters holds the clusters the bundle is written to, sorted by name
+	clusters []ClusterConfig
 }
 
 type Plugin struct {
@@ -61,7 +150,7 @@ type Plugin struct {
 	identityProvider hostservices.IdentityProvider
 
 	hooks struct {
-		newKubeClient func(configPath string) (kubeClient, error)
+		newKubeClient func(c *ClusterConfig) (kubeClient, error)
 	}
 }
 
@@ -92,11 +181,18 @@ func (p *Plugin) Notify(ctx context.Context, req *notifier.NotifyRequest) (*noti
 		return nil, err
 	}
 
-	if _, ok := req.Event.(*notifier.NotifyRequest_BundleUpdated); ok {
-		// ignore the bundle presented in the request. see updateBundleConfigMap for details on why.
-		if err := p.updateBundleConfigMap(ctx, config); err != nil {
+	switch req.Event.(type) {
+	case *notifier.NotifyRequest_BundleUpdated:
+		// ignore the bundle presented in the request. see updateBundle for details on why.
+		if _, err := p.updateBundles(ctx, config, false); err != nil {
 			return nil, err
 		}
+	case *notifier.NotifyRequest_ReconcileBundle:
+		drifted, err := p.updateBundles(ctx, config, true)
+		if err != nil {
+			return nil, err
+		}
+		return &notifier.NotifyResponse{DriftedCopies: int32(drifted)}, nil
 	}
 	return &notifier.NotifyResponse{}, nil
 }
@@ -108,8 +204,8 @@ func (p *Plugin) NotifyAndAdvise(ctx context.Context, req *notifier.NotifyAndAdv
 	}
 
 	if _, ok := req.Event.(*notifier.NotifyAndAdviseRequest_BundleLoaded); ok {
-		// ignore the bundle presented in the request. see updateBundleConfigMap for details on why.
-		if err := p.updateBundleConfigMap(ctx, config); err != nil {
+		// ignore the bundle presented in the request. see updateBundle for details on why.
+		if _, err := p.updateBundles(ctx, config, false); err != nil {
 			return nil, err
 		}
 	}
@@ -126,14 +222,32 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (resp
 		return nil, k8sErr.New("unable to decode configuration: %v", err)
 	}
 
-	if config.Namespace == "" {
-		config.Namespace = defaultNamespace
+	if len(config.Clusters) == 0 {
+		config.clusters = []ClusterConfig{config.ClusterConfig}
+	} else if config.KubeConfigFilePath != "" || config.APIServer != "" || config.TokenFilePath != "" || config.CAFilePath != "" {
+		return nil, k8sErr.New("the connection settings of each cluster must be set within its cluster block")
 	}
-	if config.ConfigMap == "" {
-		config.ConfigMap = defaultConfigMap
+	for name, cluster := range config.Clusters {
+		cluster.name = name
+		config.clusters = append(config.clusters, cluster)
 	}
-	if config.ConfigMapKey == "" {
-		config.ConfigMapKey = defaultConfigMapKey
+	sort.Slice(config.clusters, func(i, j int) bool {
+		return config.clusters[i].name < config.clusters[j].name
+	})
+
+	for i := range config.clusters {
+		cluster := &config.clusters[i]
+		cluster.setDefaults(config.ClusterConfig)
+		cluster.setDefaults(ClusterConfig{
+			Namespace:    defaultNamespace,
+			ConfigMap:    defaultConfigMap,
+			ConfigMapKey: defaultConfigMapKey,
+			SecretKey:    defaultSecretKey,
+			Format:       formatPEM,
+		})
+		if err := cluster.validate(); err != nil {
+			return nil, k8sErr.Wrap(err)
+		}
 	}
 
 	p.setConfig(config)
@@ -159,60 +273,129 @@ func (p *Plugin) setConfig(config *pluginConfig) {
 	p.config = config
 }
 
-func (p *Plugin) updateBundleConfigMap(ctx context.Context, c *pluginConfig) (err error) {
-	client, err := p.hooks.newKubeClient(c.KubeConfigFilePath)
+// updateBundles writes the bundle to every configured cluster. A failure
+// to update a cluster does not prevent the others from being updated. When
+// reconciling, only the clusters holding stale bundle data are updated. It
+// returns the number of clusters that were updated.
+func (p *Plugin) updateBundles(ctx context.Context, c *pluginConfig, reconcile bool) (int, error) {
+	var errGroup errs.Group
+	updated := 0
+	for i := range c.clusters {
+		cluster := &c.clusters[i]
+		patched, err := p.updateBundle(ctx, cluster, reconcile)
+		switch {
+		case err != nil:
+			errGroup.Add(cluster.wrapErr(err))
+		case patched:
+			updated++
+		}
+	}
+	return updated, errGroup.Err()
+}
+
+func (p *Plugin) updateBundle(ctx context.Context, c *ClusterConfig, reconcile bool) (patched bool, err error) {
+	client, err := p.hooks.newKubeClient(c)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	for {
-		// Get the config map so we can use the version to resolve conflicts racing
+		// Get the object so we can use the version to resolve conflicts racing
 		// on updates from other servers.
-		configMap, err := client.GetConfigMap(ctx, c.Namespace, c.ConfigMap)
+		resourceVersion, currentData, err := getObject(ctx, client, c)
 		if err != nil {
-			return k8sErr.New("unable to get config map %s/%s: %v", c.Namespace, c.ConfigMap, err)
+			return false, err
 		}
 
 		// Load bundle data from the registration api. The bundle has to be
-		// loaded after fetching the config map so we can properly detect and
+		// loaded after fetching the object so we can properly detect and
 		// correct a race updating the bundle (i.e.  read-modify-write
 		// semantics).
 		resp, err := p.identityProvider.FetchX509Identity(ctx, &hostservices.FetchX509IdentityRequest{})
 		if err != nil {
-			return err
+			return false, err
+		}
+
+		data, err := bundleData(resp.Bundle, c.Format)
+		if err != nil {
+			return false, err
+		}
+
+		if reconcile && bytes.Equal(currentData, data) {
+			return false, nil
 		}
 
 		// Build patch with the new bundle data. The resource version MUST be set
 		// to support conflict resolution.
-		patchBytes, err := json.Marshal(corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				ResourceVersion: configMap.ResourceVersion,
-			},
-			Data: map[string]string{
-				c.ConfigMapKey: bundleData(resp.Bundle),
-			},
-		})
+		patchBytes, err := bundlePatch(c, resourceVersion, data)
 		if err != nil {
-			return k8sErr.New("unable to marshal patch: %v", err)
+			return false, k8sErr.New("unable to marshal patch: %v", err)
 		}
 
 		// Patch the bundle, handling version conflicts
-		if err := client.PatchConfigMap(ctx, c.Namespace, c.ConfigMap, patchBytes); err != nil {
+		if c.Secret != "" {
+			err = client.PatchSecret(ctx, c.Namespace, c.Secret, patchBytes)
+		} else {
+			err = client.PatchConfigMap(ctx, c.Namespace, c.ConfigMap, patchBytes)
+		}
+		if err != nil {
 			// If there is a conflict then some other server won the race updating
-			// the ConfigMap. We need to retrieve the latest bundle and try again.
+			// the object. We need to retrieve the latest bundle and try again.
+			kind, name := c.object()
 			if s, ok := err.(k8serrors.APIStatus); ok && s.Status().Code == http.StatusConflict {
-				p.log.Debug("Conflict detected patching configmap; will retry", telemetry.VersionInfo, configMap.ResourceVersion)
+				p.log.Debug("Conflict detected patching "+kind+"; will retry", telemetry.VersionInfo, resourceVersion)
 				continue
 			}
-			return k8sErr.New("unable to update config map %s/%s: %v", c.Namespace, c.ConfigMap, err)
+			return false, k8sErr.New("unable to update %s %s/%s: %v", kind, c.Namespace, name, err)
 		}
 
-		return nil
+		return true, nil
+	}
+}
+
+// getObject returns the resource version of the ConfigMap or Secret the
+// bundle is written to, along with the bundle data it currently holds.
+func getObject(ctx context.Context, client kubeClient, c *ClusterConfig) (string, []byte, error) {
+	if c.Secret != "" {
+		secret, err := client.GetSecret(ctx, c.Namespace, c.Secret)
+		if err != nil {
+			return "", nil, k8sErr.New("unable to get secret %s/%s: %v", c.Namespace, c.Secret, err)
+		}
+		return secret.ResourceVersion, secret.Data[c.SecretKey], nil
+	}
+
+	configMap, err := client.GetConfigMap(ctx, c.Namespace, c.ConfigMap)
+	if err != nil {
+		return "", nil, k8sErr.New("unable to get config map %s/%s: %v", c.Namespace, c.ConfigMap, err)
+	}
+	return configMap.ResourceVersion, []byte(configMap.Data[c.ConfigMapKey]), nil
+}
+
+// bundlePatch returns the patch of the ConfigMap or Secret with the new
+// bundle data.
+func bundlePatch(c *ClusterConfig, resourceVersion string, data []byte) ([]byte, error) {
+	meta := metav1.ObjectMeta{
+		ResourceVersion: resourceVersion,
+	}
+
+	if c.Secret != "" {
+		return json.Marshal(corev1.Secret{
+			ObjectMeta: meta,
+			Data: map[string][]byte{
+				c.SecretKey: data,
+			},
+		})
 	}
+	return json.Marshal(corev1.ConfigMap{
+		ObjectMeta: meta,
+		Data: map[string]string{
+			c.ConfigMapKey: string(data),
+		},
+	})
 }
 
-func newKubeClient(configPath string) (kubeClient, error) {
-	config, err := getKubeConfig(configPath)
+func newKubeClient(c *ClusterConfig) (kubeClient, error) {
+	config, err := getKubeConfig(c)
 	if err != nil {
 		return nil, k8sErr.Wrap(err)
 	}
@@ -224,16 +407,28 @@ func newKubeClient(configPath string) (kubeClient, error) {
 	return kubeClientset{Clientset: client}, nil
 }
 
-func getKubeConfig(configPath string) (*rest.Config, error) {
-	if configPath != "" {
-		return clientcmd.BuildConfigFromFlags("", configPath)
+func getKubeConfig(c *ClusterConfig) (*rest.Config, error) {
+	switch {
+	case c.KubeConfigFilePath != "":
+		return clientcmd.BuildConfigFromFlags("", c.KubeConfigFilePath)
+	case c.APIServer != "":
+		return &rest.Config{
+			Host:            c.APIServer,
+			BearerTokenFile: c.TokenFilePath,
+			TLSClientConfig: rest.TLSClientConfig{
+				CAFile: c.CAFilePath,
+			},
+		}, nil
+	default:
+		return rest.InClusterConfig()
 	}
-	return rest.InClusterConfig()
 }
 
 type kubeClient interface {
 	GetConfigMap(ctx context.Context, namespace, configMap string) (*corev1.ConfigMap, error)
 	PatchConfigMap(ctx context.Context, namespace string, configMap string, patchBytes []byte) error
+	GetSecret(ctx context.Context, namespace, secret string) (*corev1.Secret, error)
+	PatchSecret(ctx context.Context, namespace string, secret string, patchBytes []byte) error
 }
 
 type kubeClientset struct {
@@ -249,14 +444,41 @@ func (c kubeClientset) PatchConfigMap(ctx context.Context, namespace, configMap
 	return err
 }
 
-// bundleData formats the bundle data for inclusion in the config map
-func bundleData(bundle *common.Bundle) string {
-	bundleData := new(bytes.Buffer)
-	for _, rootCA := range bundle.RootCas {
-		_ = pem.Encode(bundleData, &pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: rootCA.DerBytes,
-		})
+func (c kubeClientset) GetSecret(ctx context.Context, namespace, secret string) (*corev1.Secret, error) {
+	return c.CoreV1().Secrets(namespace).Get(ctx, secret, metav1.GetOptions{})
+}
+
+func (c kubeClientset) PatchSecret(ctx context.Context, namespace, secret string, patchBytes []byte) error {
+	_, err := c.CoreV1().Secrets(namespace).Patch(ctx, secret, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
+
+// bundleData formats the bundle data for inclusion in the config map or
+// secret
+func bundleData(bundle *common.Bundle, format string) ([]byte, error) {
+	switch format {
+	case formatSPIFFE, formatJWKS:
+		b, err := bundleutil.BundleFromProto(bundle)
+		if err != nil {
+			return nil, k8sErr.New("unable to parse bundle: %v", err)
+		}
+		var opts []bundleutil.MarshalOption
+		if format == formatJWKS {
+			opts = append(opts, bundleutil.NoX509SVIDKeys(), bundleutil.StandardJWKS())
+		}
+		data, err := bundleutil.Marshal(b, opts...)
+		if err != nil {
+			return nil, k8sErr.New("unable to marshal bundle: %v", err)
+		}
+		return data, nil
+	default:
+		bundleData := new(bytes.Buffer)
+		for _, rootCA := range bundle.RootCas {
+			_ = pem.Encode(bundleData, &pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: rootCA.DerBytes,
+			})
+		}
+		return bundleData.Bytes(), nil
 	}
-	return bundleData.String()
 }
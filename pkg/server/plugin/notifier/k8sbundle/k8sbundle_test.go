@@ -12,6 +12,8 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/server/plugin/notifier"
@@ -19,7 +21,9 @@ import (
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/spiffe/spire/test/fakes/fakeidentityprovider"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
 	"google.golang.org/grpc/codes"
+	"gopkg.in/square/go-jose.v2"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -406,6 +410,235 @@ kube_config_file_path = "/some/file/path"
 	}, s.k.getConfigMap("NAMESPACE", "CONFIGMAP"))
 }
 
+func (s *Suite) TestBundleUpdatedToSecret() {
+	s.k.setSecret(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "spire",
+			Name:            "SECRET",
+			ResourceVersion: "1",
+		},
+	})
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`secret = "SECRET"`)
+
+	s.notifyBundleUpdated()
+
+	s.Equal(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "spire",
+			Name:            "SECRET",
+			ResourceVersion: "2",
+		},
+		Data: map[string][]byte{
+			"bundle.crt": []byte(testBundleData),
+		},
+	}, s.k.getSecret("spire", "SECRET"))
+}
+
+func (s *Suite) TestBundleUpdatedWithFormats() {
+	ca := testca.New(s.T(), spiffeid.RequireTrustDomainFromString("example.org"))
+	bundle := bundleutil.BundleFromRootCAs("spiffe://example.org", ca.X509Authorities())
+	for kid, key := range ca.JWTAuthorities() {
+		s.Require().NoError(bundle.AppendJWTSigningKey(kid, key))
+	}
+	spiffeData, err := bundleutil.Marshal(bundle)
+	s.Require().NoError(err)
+	jwksData, err := bundleutil.Marshal(bundle, bundleutil.NoX509SVIDKeys(), bundleutil.StandardJWKS())
+	s.Require().NoError(err)
+
+	for _, tt := range []struct {
+		format string
+		data   string
+	}{
+		{format: "spiffe", data: string(spiffeData)},
+		{format: "jwks", data: string(jwksData)},
+	} {
+		s.k.setConfigMap(newConfigMap())
+		s.r.AppendBundle(bundle.Proto())
+
+		s.configure(fmt.Sprintf("format = %q", tt.format))
+
+		s.notifyBundleUpdated()
+		s.Equal(tt.data, s.k.getConfigMap("spire", "spire-bundle").Data["bundle.crt"], tt.format)
+	}
+
+	var jwks jose.JSONWebKeySet
+	s.Require().NoError(json.Unmarshal(jwksData, &jwks))
+	s.Len(jwks.Keys, 1)
+}
+
+func (s *Suite) TestBundleUpdatedToMultipleClusters() {
+	east := newFakeKubeClient(newConfigMap())
+	west := newFakeKubeClient()
+	west.setSecret(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "infra",
+			Name:            "SECRET",
+			ResourceVersion: "1",
+		},
+	})
+	s.withKubeClients(map[string]kubeClient{
+		"east": east,
+		"west": west,
+	})
+	s.r.AppendBundle(testBundle)
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`
+cluster "east" {
+	kube_config_file_path = "/east/kubeconfig"
+}
+cluster "west" {
+	api_server = "https://west.example.org"
+	token_file_path = "/west/token"
+	namespace = "infra"
+	secret = "SECRET"
+}
+`)
+
+	s.notifyBundleUpdated()
+	s.Equal(testBundleData, east.getConfigMap("spire", "spire-bundle").Data["bundle.crt"])
+	s.Equal([]byte(testBundleData), west.getSecret("infra", "SECRET").Data["bundle.crt"])
+}
+
+func (s *Suite) TestBundleUpdatedToMultipleClustersWithFailure() {
+	west := newFakeKubeClient(newConfigMap())
+	s.withKubeClients(map[string]kubeClient{
+		"west": west,
+	})
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`
+config_map_key = "CONFIGMAPKEY"
+cluster "east" {
+	kube_config_file_path = "/east/kubeconfig"
+}
+cluster "west" {
+	kube_config_file_path = "/west/kubeconfig"
+}
+`)
+
+	_, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, `cluster "east": kube client not configured`)
+
+	// The failure to update a cluster does not prevent updating the others,
+	// and the top-level settings are used as defaults
+	s.Equal(testBundleData, west.getConfigMap("spire", "spire-bundle").Data["CONFIGMAPKEY"])
+}
+
+func (s *Suite) TestReconcileBundle() {
+	upToDate := newConfigMap()
+	upToDate.Data = map[string]string{"bundle.crt": testBundleData}
+	east := newFakeKubeClient(upToDate)
+	west := newFakeKubeClient()
+	west.setSecret(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "infra",
+			Name:            "SECRET",
+			ResourceVersion: "1",
+		},
+		Data: map[string][]byte{
+			"bundle.crt": []byte(testBundle2Data),
+		},
+	})
+	s.withKubeClients(map[string]kubeClient{
+		"east": east,
+		"west": west,
+	})
+	s.r.AppendBundle(testBundle)
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`
+cluster "east" {
+	kube_config_file_path = "/east/kubeconfig"
+}
+cluster "west" {
+	kube_config_file_path = "/west/kubeconfig"
+	namespace = "infra"
+	secret = "SECRET"
+}
+`)
+
+	resp, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_ReconcileBundle{
+			ReconcileBundle: &notifier.ReconcileBundle{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&notifier.NotifyResponse{DriftedCopies: 1}, resp)
+
+	// The up to date config map is left untouched while the stale secret is
+	// repaired
+	s.Equal("1", east.getConfigMap("spire", "spire-bundle").ResourceVersion)
+	s.Equal("2", west.getSecret("infra", "SECRET").ResourceVersion)
+	s.Equal([]byte(testBundleData), west.getSecret("infra", "SECRET").Data["bundle.crt"])
+}
+
+func (s *Suite) TestReconcileBundleFailure() {
+	s.configure("")
+	s.r.AppendBundle(testBundle)
+
+	_, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_ReconcileBundle{
+			ReconcileBundle: &notifier.ReconcileBundle{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "k8s-bundle: unable to get config map spire/spire-bundle: not found")
+}
+
+func (s *Suite) TestConfigureWithInvalidConfiguration() {
+	for _, tt := range []struct {
+		config string
+		err    string
+	}{
+		{
+			config: `format = "der"`,
+			err:    `k8s-bundle: unsupported format "der"`,
+		},
+		{
+			config: `
+kube_config_file_path = "/some/file/path"
+api_server = "https://example.org"`,
+			err: "k8s-bundle: kube_config_file_path and api_server are mutually exclusive",
+		},
+		{
+			config: `token_file_path = "/some/token"`,
+			err:    "k8s-bundle: token_file_path and ca_file_path require api_server",
+		},
+		{
+			config: `
+kube_config_file_path = "/some/file/path"
+cluster "other" {
+	kube_config_file_path = "/other/file/path"
+}`,
+			err: "k8s-bundle: the connection settings of each cluster must be set within its cluster block",
+		},
+		{
+			config: `
+cluster "other" {
+	format = "der"
+}`,
+			err: `k8s-bundle: cluster "other": unsupported format "der"`,
+		},
+	} {
+		_, err := s.p.Configure(context.Background(), &spi.ConfigureRequest{
+			Configuration: tt.config,
+		})
+		s.RequireGRPCStatus(err, codes.Unknown, tt.err)
+	}
+}
+
 func (s *Suite) TestConfigureWithMalformedConfiguration() {
 	_, err := s.p.Configure(context.Background(), &spi.ConfigureRequest{
 		Configuration: "blah",
@@ -429,8 +662,8 @@ func (s *Suite) TestBundleFailsToLoadIfHostServicesUnavailabler() {
 }
 
 func (s *Suite) withKubeClient(client kubeClient, expectedConfigPath string) {
-	s.raw.hooks.newKubeClient = func(configPath string) (kubeClient, error) {
-		s.Equal(expectedConfigPath, configPath)
+	s.raw.hooks.newKubeClient = func(c *ClusterConfig) (kubeClient, error) {
+		s.Equal(expectedConfigPath, c.KubeConfigFilePath)
 		if client == nil {
 			return nil, errors.New("kube client not configured")
 		}
@@ -438,6 +671,30 @@ func (s *Suite) withKubeClient(client kubeClient, expectedConfigPath string) {
 	}
 }
 
+// withKubeClients sets up a client per cluster, keyed by the name of the
+// cluster.
+func (s *Suite) withKubeClients(clients map[string]kubeClient) {
+	s.raw.hooks.newKubeClient = func(c *ClusterConfig) (kubeClient, error) {
+		client, ok := clients[c.name]
+		if !ok {
+			return nil, errors.New("kube client not configured")
+		}
+		return client, nil
+	}
+}
+
+func (s *Suite) notifyBundleUpdated() {
+	resp, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&notifier.NotifyResponse{}, resp)
+}
+
 func (s *Suite) configure(configuration string) {
 	_, err := s.p.Configure(context.Background(), &spi.ConfigureRequest{
 		Configuration: configuration,
@@ -448,12 +705,14 @@ func (s *Suite) configure(configuration string) {
 type fakeKubeClient struct {
 	mu         sync.RWMutex
 	configMaps map[string]*corev1.ConfigMap
+	secrets    map[string]*corev1.Secret
 	patchErr   error
 }
 
 func newFakeKubeClient(configMaps ...*corev1.ConfigMap) *fakeKubeClient {
 	c := &fakeKubeClient{
 		configMaps: make(map[string]*corev1.ConfigMap),
+		secrets:    make(map[string]*corev1.Secret),
 	}
 	for _, configMap := range configMaps {
 		c.setConfigMap(configMap)
@@ -503,6 +762,53 @@ func (c *fakeKubeClient) PatchConfigMap(ctx context.Context, namespace, configMa
 	return nil
 }
 
+func (c *fakeKubeClient) GetSecret(ctx context.Context, namespace, secret string) (*corev1.Secret, error) {
+	entry := c.getSecret(namespace, secret)
+	if entry == nil {
+		return nil, errors.New("not found")
+	}
+	return entry, nil
+}
+
+func (c *fakeKubeClient) PatchSecret(ctx context.Context, namespace, secret string, patchBytes []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.secrets[configMapKey(namespace, secret)]
+	if !ok {
+		return errors.New("not found")
+	}
+
+	patchedSecret := new(corev1.Secret)
+	if err := json.Unmarshal(patchBytes, patchedSecret); err != nil {
+		return err
+	}
+	resourceVersion, err := strconv.Atoi(patchedSecret.ResourceVersion)
+	if err != nil {
+		return errors.New("patch does not have resource version")
+	}
+	entry.ResourceVersion = fmt.Sprint(resourceVersion + 1)
+	if entry.Data == nil {
+		entry.Data = map[string][]byte{}
+	}
+	for key, data := range patchedSecret.Data {
+		entry.Data[key] = data
+	}
+	return nil
+}
+
+func (c *fakeKubeClient) getSecret(namespace, secret string) *corev1.Secret {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.secrets[configMapKey(namespace, secret)]
+}
+
+func (c *fakeKubeClient) setSecret(secret *corev1.Secret) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.secrets[configMapKey(secret.Namespace, secret.Name)] = secret
+}
+
 func (c *fakeKubeClient) getConfigMap(namespace, configMap string) *corev1.ConfigMap {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
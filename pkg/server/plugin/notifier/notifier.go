@@ -14,6 +14,9 @@ import (
 
 type BundleLoaded = notifier.BundleLoaded                                               //nolint: golint
 type BundleUpdated = notifier.BundleUpdated                                             //nolint: golint
+type CAActivated = notifier.CAActivated                                                 //nolint: golint
+type CAPrepared = notifier.CAPrepared                                                   //nolint: golint
+type CATainted = notifier.CATainted                                                     //nolint: golint
 type NotifierClient = notifier.NotifierClient                                           //nolint: golint
 type NotifierServer = notifier.NotifierServer                                           //nolint: golint
 type NotifyAndAdviseRequest = notifier.NotifyAndAdviseRequest                           //nolint: golint
@@ -21,7 +24,12 @@ type NotifyAndAdviseRequest_BundleLoaded = notifier.NotifyAndAdviseRequest_Bundl
 type NotifyAndAdviseResponse = notifier.NotifyAndAdviseResponse                         //nolint: golint
 type NotifyRequest = notifier.NotifyRequest                                             //nolint: golint
 type NotifyRequest_BundleUpdated = notifier.NotifyRequest_BundleUpdated                 //nolint: golint
+type NotifyRequest_CaActivated = notifier.NotifyRequest_CaActivated                     //nolint: golint
+type NotifyRequest_CaPrepared = notifier.NotifyRequest_CaPrepared                       //nolint: golint
+type NotifyRequest_CaTainted = notifier.NotifyRequest_CaTainted                         //nolint: golint
+type NotifyRequest_ReconcileBundle = notifier.NotifyRequest_ReconcileBundle             //nolint: golint
 type NotifyResponse = notifier.NotifyResponse                                           //nolint: golint
+type ReconcileBundle = notifier.ReconcileBundle                                         //nolint: golint
 type UnimplementedNotifierServer = notifier.UnimplementedNotifierServer                 //nolint: golint
 
 const (
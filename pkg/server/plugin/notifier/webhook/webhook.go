@@ -0,0 +1,468 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	EventBundleLoaded  = "bundle_loaded"
+	EventBundleUpdated = "bundle_updated"
+	EventCAPrepared    = "ca_prepared"
+	EventCAActivated   = "ca_activated"
+	EventCATainted     = "ca_tainted"
+
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the request body,
+	// keyed with the signing key and prefixed with "sha256="
+	SignatureHeader = "X-Spire-Signature-256"
+	// EventHeader holds the type of the event
+	EventHeader = "X-Spire-Event"
+	// DeliveryHeader holds the ID of the event, which is the same for every
+	// attempt to deliver it
+	DeliveryHeader = "X-Spire-Delivery"
+
+	defaultTimeout       = 10 * time.Second
+	defaultMaxRetries    = 3
+	defaultRetryInterval = time.Second
+)
+
+var allEvents = []string{
+	EventBundleLoaded,
+	EventBundleUpdated,
+	EventCAPrepared,
+	EventCAActivated,
+	EventCATainted,
+}
+
+func BuiltIn() catalog.Plugin {
+	return builtIn(New())
+}
+
+func builtIn(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin("webhook",
+		notifier.PluginServer(p),
+	)
+}
+
+// Event is the JSON document posted to the endpoints.
+type Event struct {
+	// ID identifies the event. It is the same for every attempt to deliver
+	// the event.
+	ID string `json:"id"`
+
+	// Type is the type of the event, e.g. ca_activated.
+	Type string `json:"type"`
+
+	// TrustDomain is the trust domain of the server, e.g. example.org.
+	TrustDomain string `json:"trust_domain"`
+
+	// Timestamp is the time the event was delivered for the first time.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Bundle is the trust bundle in the SPIFFE bundle format. Set for the
+	// bundle events.
+	Bundle json.RawMessage `json:"bundle,omitempty"`
+
+	// X509Authority is the X509 CA the event is about. Set for the CA events
+	// about X509 CAs.
+	X509Authority *X509Authority `json:"x509_authority,omitempty"`
+
+	// JWTAuthority is the JWT key the event is about. Set for the CA events
+	// about JWT keys.
+	JWTAuthority *JWTAuthority `json:"jwt_authority,omitempty"`
+}
+
+type X509Authority struct {
+	// AuthorityID is the hex encoded subject key ID of the CA certificate,
+	// which identifies the authority in the local authority API.
+	AuthorityID string `json:"authority_id"`
+
+	// Certificate is the ASN.1 DER encoded CA certificate.
+	Certificate []byte `json:"certificate"`
+
+	// ExpiresAt is the expiration time of the CA certificate.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type JWTAuthority struct {
+	// KeyID is the key ID of the JWT key.
+	KeyID string `json:"key_id"`
+
+	// PublicKey is the PKIX encoded public key.
+	PublicKey []byte `json:"public_key"`
+
+	// ExpiresAt is the expiration time of the JWT key.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Tainted is true if the JWT key is tainted.
+	Tainted bool `json:"tainted"`
+}
+
+type endpointConfig struct {
+	URL     string            `hcl:"url"`
+	Headers map[string]string `hcl:"headers"`
+}
+
+type pluginConfig struct {
+	Endpoints     map[string]endpointConfig `hcl:"endpoint"`
+	SigningKey    string                    `hcl:"signing_key"`
+	Events        []string                  `hcl:"events"`
+	Timeout       string                    `hcl:"timeout"`
+	MaxRetries    *int                      `hcl:"max_retries"`
+	RetryInterval string                    `hcl:"retry_interval"`
+
+	trustDomain   string
+	endpoints     []namedEndpoint
+	events        map[string]bool
+	client        *http.Client
+	maxRetries    int
+	retryInterval time.Duration
+}
+
+type namedEndpoint struct {
+	name string
+	endpointConfig
+}
+
+type Plugin struct {
+	mu     sync.RWMutex
+	log    hclog.Logger
+	config *pluginConfig
+
+	hooks struct {
+		now func() time.Time
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{}
+	p.hooks.now = time.Now
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) Notify(ctx context.Context, req *notifier.NotifyRequest) (*notifier.NotifyResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := p.eventFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if event != nil {
+		if err := p.deliver(ctx, config, event); err != nil {
+			return nil, err
+		}
+	}
+	return &notifier.NotifyResponse{}, nil
+}
+
+func (p *Plugin) NotifyAndAdvise(ctx context.Context, req *notifier.NotifyAndAdviseRequest) (*notifier.NotifyAndAdviseResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if x, ok := req.Event.(*notifier.NotifyAndAdviseRequest_BundleLoaded); ok {
+		event, err := p.bundleEvent(EventBundleLoaded, x.BundleLoaded.Bundle)
+		if err != nil {
+			return nil, err
+		}
+		// An unreachable endpoint must not keep SPIRE server from starting,
+		// so delivery failures are only logged.
+		if err := p.deliver(ctx, config, event); err != nil {
+			p.log.Warn("Failed to deliver event", telemetry.Event, event.Type, telemetry.Error, err)
+		}
+	}
+	return &notifier.NotifyAndAdviseResponse{}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(pluginConfig)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	if req.GlobalConfig == nil || req.GlobalConfig.TrustDomain == "" {
+		return nil, status.Error(codes.InvalidArgument, "trust domain is required")
+	}
+	config.trustDomain = req.GlobalConfig.TrustDomain
+
+	if len(config.Endpoints) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one endpoint must be configured")
+	}
+	for name, endpoint := range config.Endpoints {
+		if endpoint.URL == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "url must be set for endpoint %q", name)
+		}
+		config.endpoints = append(config.endpoints, namedEndpoint{name: name, endpointConfig: endpoint})
+	}
+	sort.Slice(config.endpoints, func(i, j int) bool {
+		return config.endpoints[i].name < config.endpoints[j].name
+	})
+
+	if config.SigningKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "signing_key must be set")
+	}
+
+	events := config.Events
+	if len(events) == 0 {
+		events = allEvents
+	}
+	config.events = make(map[string]bool, len(events))
+	for _, event := range events {
+		if !isEvent(event) {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported event %q", event)
+		}
+		config.events[event] = true
+	}
+
+	timeout, err := parseDuration(config.Timeout, defaultTimeout)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid timeout: %v", err)
+	}
+	config.client = &http.Client{Timeout: timeout}
+
+	config.maxRetries = defaultMaxRetries
+	if config.MaxRetries != nil {
+		if *config.MaxRetries < 0 {
+			return nil, status.Error(codes.InvalidArgument, "max_retries must not be negative")
+		}
+		config.maxRetries = *config.MaxRetries
+	}
+	config.retryInterval, err = parseDuration(config.RetryInterval, defaultRetryInterval)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid retry_interval: %v", err)
+	}
+
+	p.setConfig(config)
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(ctx context.Context, req *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getConfig() (*pluginConfig, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.config == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.config, nil
+}
+
+func (p *Plugin) setConfig(config *pluginConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+}
+
+// eventFromRequest returns the event of the request, or nil if the request
+// holds an event the plugin does not know about.
+func (p *Plugin) eventFromRequest(req *notifier.NotifyRequest) (*Event, error) {
+	switch x := req.Event.(type) {
+	case *notifier.NotifyRequest_BundleUpdated:
+		return p.bundleEvent(EventBundleUpdated, x.BundleUpdated.Bundle)
+	case *notifier.NotifyRequest_CaPrepared:
+		return p.caEvent(EventCAPrepared, x.CaPrepared.X509Ca, x.CaPrepared.JwtKey)
+	case *notifier.NotifyRequest_CaActivated:
+		return p.caEvent(EventCAActivated, x.CaActivated.X509Ca, x.CaActivated.JwtKey)
+	case *notifier.NotifyRequest_CaTainted:
+		return p.caEvent(EventCATainted, x.CaTainted.X509Ca, x.CaTainted.JwtKey)
+	default:
+		return nil, nil
+	}
+}
+
+func (p *Plugin) bundleEvent(eventType string, bundle *common.Bundle) (*Event, error) {
+	b, err := bundleutil.BundleFromProto(bundle)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bundle: %v", err)
+	}
+	bundleData, err := bundleutil.Marshal(b)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to marshal bundle: %v", err)
+	}
+	return &Event{
+		Type:   eventType,
+		Bundle: bundleData,
+	}, nil
+}
+
+func (p *Plugin) caEvent(eventType string, x509CA []byte, jwtKey *common.PublicKey) (*Event, error) {
+	event := &Event{
+		Type: eventType,
+	}
+	switch {
+	case len(x509CA) > 0:
+		cert, err := x509.ParseCertificate(x509CA)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid X509 CA: %v", err)
+		}
+		event.X509Authority = &X509Authority{
+			AuthorityID: hex.EncodeToString(cert.SubjectKeyId),
+			Certificate: x509CA,
+			ExpiresAt:   cert.NotAfter.UTC(),
+		}
+	case jwtKey != nil:
+		event.JWTAuthority = &JWTAuthority{
+			KeyID:     jwtKey.Kid,
+			PublicKey: jwtKey.PkixBytes,
+			ExpiresAt: time.Unix(jwtKey.NotAfter, 0).UTC(),
+			Tainted:   jwtKey.TaintedKey,
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "event has neither an X509 CA nor a JWT key")
+	}
+	return event, nil
+}
+
+// deliver posts the event to every endpoint, if the event is enabled.
+func (p *Plugin) deliver(ctx context.Context, c *pluginConfig, event *Event) error {
+	if !c.events[event.Type] {
+		return nil
+	}
+
+	id, err := newEventID()
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to generate event ID: %v", err)
+	}
+	event.ID = id
+	event.TrustDomain = c.trustDomain
+	event.Timestamp = p.hooks.now().UTC()
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to marshal event: %v", err)
+	}
+	signature := sign(c.SigningKey, body)
+
+	var group errs.Group
+	for _, endpoint := range c.endpoints {
+		if err := p.deliverWithRetries(ctx, c, endpoint, event, body, signature); err != nil {
+			group.Add(fmt.Errorf("endpoint %q: %v", endpoint.name, err))
+		}
+	}
+	if err := group.Err(); err != nil {
+		return status.Errorf(codes.Unavailable, "unable to deliver %s event: %v", event.Type, err)
+	}
+	return nil
+}
+
+func (p *Plugin) deliverWithRetries(ctx context.Context, c *pluginConfig, endpoint namedEndpoint, event *Event, body []byte, signature string) error {
+	interval := c.retryInterval
+	for attempt := 0; ; attempt++ {
+		retry, err := post(ctx, c.client, endpoint, event, body, signature)
+		if err == nil {
+			p.log.Debug("Event delivered", telemetry.Event, event.Type, "endpoint", endpoint.name)
+			return nil
+		}
+		if !retry || attempt >= c.maxRetries {
+			return err
+		}
+
+		p.log.Debug("Retrying event delivery", telemetry.Event, event.Type, "endpoint", endpoint.name, telemetry.Error, err)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		interval *= 2
+	}
+}
+
+// post posts the event to the endpoint. It returns whether a failed delivery
+// can be retried.
+func post(ctx context.Context, client *http.Client, endpoint namedEndpoint, event *Event, body []byte, signature string) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	for name, value := range endpoint.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(DeliveryHeader, event.ID)
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+}
+
+// sign returns the value of the signature header for the body.
+func sign(key string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func isEvent(event string) bool {
+	for _, e := range allEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func parseDuration(s string, defaultValue time.Duration) (time.Duration, error) {
+	if s == "" {
+		return defaultValue, nil
+	}
+	return time.ParseDuration(s)
+}
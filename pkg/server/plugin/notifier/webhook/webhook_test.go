@@ -0,0 +1,433 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var now = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestConfigure(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        string
+		noTrustDomain bool
+		code          codes.Code
+		desc          string
+	}{
+		{
+			name: "malformed",
+			config: `
+				MALFORMED
+			`,
+			code: codes.InvalidArgument,
+			desc: "unable to decode configuration",
+		},
+		{
+			name: "missing trust domain",
+			config: `
+				endpoint "inventory" { url = "https://inventory.test" }
+				signing_key = "key"
+			`,
+			noTrustDomain: true,
+			code:          codes.InvalidArgument,
+			desc:          "trust domain is required",
+		},
+		{
+			name: "no endpoints",
+			config: `
+				signing_key = "key"
+			`,
+			code: codes.InvalidArgument,
+			desc: "at least one endpoint must be configured",
+		},
+		{
+			name: "missing url",
+			config: `
+				endpoint "inventory" {}
+				signing_key = "key"
+			`,
+			code: codes.InvalidArgument,
+			desc: `url must be set for endpoint "inventory"`,
+		},
+		{
+			name: "missing signing key",
+			config: `
+				endpoint "inventory" { url = "https://inventory.test" }
+			`,
+			code: codes.InvalidArgument,
+			desc: "signing_key must be set",
+		},
+		{
+			name: "unsupported event",
+			config: `
+				endpoint "inventory" { url = "https://inventory.test" }
+				signing_key = "key"
+				events = ["ca_revoked"]
+			`,
+			code: codes.InvalidArgument,
+			desc: `unsupported event "ca_revoked"`,
+		},
+		{
+			name: "invalid timeout",
+			config: `
+				endpoint "inventory" { url = "https://inventory.test" }
+				signing_key = "key"
+				timeout = "soon"
+			`,
+			code: codes.InvalidArgument,
+			desc: "invalid timeout",
+		},
+		{
+			name: "negative max retries",
+			config: `
+				endpoint "inventory" { url = "https://inventory.test" }
+				signing_key = "key"
+				max_retries = -1
+			`,
+			code: codes.InvalidArgument,
+			desc: "max_retries must not be negative",
+		},
+		{
+			name: "invalid retry interval",
+			config: `
+				endpoint "inventory" { url = "https://inventory.test" }
+				signing_key = "key"
+				retry_interval = "later"
+			`,
+			code: codes.InvalidArgument,
+			desc: "invalid retry_interval",
+		},
+		{
+			name: "success",
+			config: `
+				endpoint "inventory" {
+					url = "https://inventory.test"
+					headers { Authorization = "Bearer token" }
+				}
+				endpoint "alerting" { url = "https://alerting.test" }
+				signing_key = "key"
+				events = ["ca_activated", "ca_tainted"]
+				timeout = "5s"
+				max_retries = 0
+				retry_interval = "2s"
+			`,
+			code: codes.OK,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var plugin notifier.Plugin
+			spiretest.LoadPlugin(t, BuiltIn(), &plugin)
+
+			req := &spi.ConfigureRequest{Configuration: tt.config}
+			if !tt.noTrustDomain {
+				req.GlobalConfig = &spi.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"}
+			}
+			resp, err := plugin.Configure(context.Background(), req)
+			if tt.code != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.desc)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	resp, err := New().GetPluginInfo(context.Background(), &spi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &spi.GetPluginInfoResponse{}, resp)
+}
+
+func TestNotifyNotConfigured(t *testing.T) {
+	var plugin notifier.Plugin
+	spiretest.LoadPlugin(t, BuiltIn(), &plugin)
+
+	_, err := plugin.Notify(context.Background(), &notifier.NotifyRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "not configured")
+	_, err = plugin.NotifyAndAdvise(context.Background(), &notifier.NotifyAndAdviseRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "not configured")
+}
+
+func TestNotify(t *testing.T) {
+	ca := testca.New(t, spiffeid.RequireTrustDomainFromString("example.org"))
+	caCert := ca.X509Authorities()[0]
+	bundle := bundleutil.BundleFromRootCAs("spiffe://example.org", ca.X509Authorities())
+	bundleData, err := bundleutil.Marshal(bundle)
+	require.NoError(t, err)
+	// the bundle is embedded in the event, which is encoded compactly
+	compactBundleData := new(bytes.Buffer)
+	require.NoError(t, json.Compact(compactBundleData, bundleData))
+	jwtKey := &common.PublicKey{
+		Kid:        "kid",
+		PkixBytes:  []byte("pkix"),
+		NotAfter:   now.Add(time.Hour).Unix(),
+		TaintedKey: true,
+	}
+
+	for _, tt := range []struct {
+		name     string
+		events   string
+		req      *notifier.NotifyRequest
+		expected *Event
+	}{
+		{
+			name: "bundle updated",
+			req: &notifier.NotifyRequest{
+				Event: &notifier.NotifyRequest_BundleUpdated{
+					BundleUpdated: &notifier.BundleUpdated{Bundle: bundle.Proto()},
+				},
+			},
+			expected: &Event{
+				Type:   EventBundleUpdated,
+				Bundle: compactBundleData.Bytes(),
+			},
+		},
+		{
+			name: "X509 CA prepared",
+			req: &notifier.NotifyRequest{
+				Event: &notifier.NotifyRequest_CaPrepared{
+					CaPrepared: &notifier.CAPrepared{X509Ca: caCert.Raw},
+				},
+			},
+			expected: &Event{
+				Type: EventCAPrepared,
+				X509Authority: &X509Authority{
+					AuthorityID: fmt.Sprintf("%x", caCert.SubjectKeyId),
+					Certificate: caCert.Raw,
+					ExpiresAt:   caCert.NotAfter.UTC(),
+				},
+			},
+		},
+		{
+			name: "X509 CA activated",
+			req: &notifier.NotifyRequest{
+				Event: &notifier.NotifyRequest_CaActivated{
+					CaActivated: &notifier.CAActivated{X509Ca: caCert.Raw},
+				},
+			},
+			expected: &Event{
+				Type: EventCAActivated,
+				X509Authority: &X509Authority{
+					AuthorityID: fmt.Sprintf("%x", caCert.SubjectKeyId),
+					Certificate: caCert.Raw,
+					ExpiresAt:   caCert.NotAfter.UTC(),
+				},
+			},
+		},
+		{
+			name: "JWT key tainted",
+			req: &notifier.NotifyRequest{
+				Event: &notifier.NotifyRequest_CaTainted{
+					CaTainted: &notifier.CATainted{JwtKey: jwtKey},
+				},
+			},
+			expected: &Event{
+				Type: EventCATainted,
+				JWTAuthority: &JWTAuthority{
+					KeyID:     "kid",
+					PublicKey: []byte("pkix"),
+					ExpiresAt: now.Add(time.Hour),
+					Tainted:   true,
+				},
+			},
+		},
+		{
+			name:   "event not enabled",
+			events: `events = ["ca_tainted"]`,
+			req: &notifier.NotifyRequest{
+				Event: &notifier.NotifyRequest_CaActivated{
+					CaActivated: &notifier.CAActivated{X509Ca: caCert.Raw},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t)
+			plugin := loadPlugin(t, tt.events, server.URL)
+
+			_, err := plugin.Notify(context.Background(), tt.req)
+			require.NoError(t, err)
+
+			deliveries := server.Deliveries()
+			if tt.expected == nil {
+				require.Empty(t, deliveries)
+				return
+			}
+			require.Len(t, deliveries, 1)
+			event := deliveries[0]
+			assert.NotEmpty(t, event.ID)
+			tt.expected.ID = event.ID
+			tt.expected.TrustDomain = "example.org"
+			tt.expected.Timestamp = now
+			require.Equal(t, tt.expected, event)
+		})
+	}
+}
+
+func TestNotifyRetries(t *testing.T) {
+	req := &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_CaTainted{
+			CaTainted: &notifier.CATainted{JwtKey: &common.PublicKey{Kid: "kid"}},
+		},
+	}
+
+	t.Run("retried until delivered", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.AppendStatusCodes(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+		plugin := loadPlugin(t, "", server.URL)
+
+		_, err := plugin.Notify(context.Background(), req)
+		require.NoError(t, err)
+		deliveries := server.Deliveries()
+		require.Len(t, deliveries, 3)
+		// every attempt carries the same event
+		require.Equal(t, deliveries[0], deliveries[2])
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.AppendStatusCodes(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+		plugin := loadPlugin(t, "max_retries = 2", server.URL)
+
+		_, err := plugin.Notify(context.Background(), req)
+		spiretest.RequireGRPCStatus(t, err, codes.Unavailable, `unable to deliver ca_tainted event: endpoint "test": unexpected status code 500`)
+		require.Len(t, server.Deliveries(), 3)
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		server := newFakeServer(t)
+		server.AppendStatusCodes(http.StatusBadRequest)
+		plugin := loadPlugin(t, "", server.URL)
+
+		_, err := plugin.Notify(context.Background(), req)
+		spiretest.RequireGRPCStatus(t, err, codes.Unavailable, `unable to deliver ca_tainted event: endpoint "test": unexpected status code 400`)
+		require.Len(t, server.Deliveries(), 1)
+	})
+}
+
+func TestNotifyAndAdvise(t *testing.T) {
+	ca := testca.New(t, spiffeid.RequireTrustDomainFromString("example.org"))
+	bundle := bundleutil.BundleFromRootCAs("spiffe://example.org", ca.X509Authorities())
+	req := &notifier.NotifyAndAdviseRequest{
+		Event: &notifier.NotifyAndAdviseRequest_BundleLoaded{
+			BundleLoaded: &notifier.BundleLoaded{Bundle: bundle.Proto()},
+		},
+	}
+
+	server := newFakeServer(t)
+	plugin := loadPlugin(t, "", server.URL)
+	_, err := plugin.NotifyAndAdvise(context.Background(), req)
+	require.NoError(t, err)
+	deliveries := server.Deliveries()
+	require.Len(t, deliveries, 1)
+	require.Equal(t, EventBundleLoaded, deliveries[0].Type)
+
+	// delivery failures do not keep the server from starting
+	server = newFakeServer(t)
+	server.AppendStatusCodes(http.StatusBadRequest)
+	plugin = loadPlugin(t, "", server.URL)
+	_, err = plugin.NotifyAndAdvise(context.Background(), req)
+	require.NoError(t, err)
+}
+
+func loadPlugin(t *testing.T, config, url string) notifier.Plugin {
+	raw := New()
+	raw.hooks.now = func() time.Time { return now }
+
+	var plugin notifier.Plugin
+	spiretest.LoadPlugin(t, builtIn(raw), &plugin)
+
+	_, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			endpoint "test" {
+				url = %q
+				headers { Authorization = "Bearer token" }
+			}
+			signing_key = "key"
+			retry_interval = "1ms"
+			%s
+		`, url, config),
+		GlobalConfig: &spi.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.NoError(t, err)
+	return plugin
+}
+
+type fakeServer struct {
+	*httptest.Server
+	t *testing.T
+
+	mu          sync.Mutex
+	statusCodes []int
+	deliveries  []*Event
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	s := &fakeServer{t: t}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(req.Body)
+	if !assert.NoError(s.t, err) {
+		return
+	}
+	assert.Equal(s.t, http.MethodPost, req.Method)
+	assert.Equal(s.t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(s.t, "Bearer token", req.Header.Get("Authorization"))
+	assert.Equal(s.t, sign("key", body), req.Header.Get(SignatureHeader))
+
+	event := new(Event)
+	if !assert.NoError(s.t, json.Unmarshal(body, event)) {
+		return
+	}
+	assert.Equal(s.t, event.Type, req.Header.Get(EventHeader))
+	assert.Equal(s.t, event.ID, req.Header.Get(DeliveryHeader))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, event)
+	if len(s.statusCodes) > 0 {
+		w.WriteHeader(s.statusCodes[0])
+		s.statusCodes = s.statusCodes[1:]
+	}
+}
+
+func (s *fakeServer) AppendStatusCodes(codes ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statusCodes = append(s.statusCodes, codes...)
+}
+
+func (s *fakeServer) Deliveries() []*Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deliveries
+}
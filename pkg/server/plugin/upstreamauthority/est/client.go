@@ -0,0 +1,190 @@
+package est
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	wellKnownPath = "/.well-known/est"
+
+	cacertsOperation      = "cacerts"
+	simpleEnrollOperation = "simpleenroll"
+
+	pkcs10ContentType = "application/pkcs10"
+
+	// maxResponseSize bounds the size of the responses read from the EST
+	// server.
+	maxResponseSize = 1 << 20
+)
+
+// clientConfig configures the EST client.
+type clientConfig struct {
+	// ServerURL is the URL of the EST server, without the well-known path
+	ServerURL string
+	// Label is the optional CA label, for servers hosting several CAs
+	Label string
+	// Username and Password are used for HTTP basic authentication
+	Username string
+	Password string
+	// RootCAs authenticate the EST server. System roots are used if nil.
+	RootCAs *x509.CertPool
+	// Certificate is the TLS client certificate, if any
+	Certificate *tls.Certificate
+}
+
+// client implements the client side of the EST protocol (RFC 7030).
+type client struct {
+	config     clientConfig
+	httpClient *http.Client
+}
+
+func newClient(config clientConfig) *client {
+	tlsConfig := &tls.Config{
+		RootCAs:    config.RootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	if config.Certificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*config.Certificate}
+	}
+	return &client{
+		config: config,
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}
+}
+
+// errRetryLater is returned when the EST server accepted the enrollment
+// request but did not issue the certificate yet, e.g. because it requires
+// manual approval.
+type errRetryLater struct {
+	after time.Duration
+}
+
+func (e errRetryLater) Error() string {
+	return fmt.Sprintf("enrollment pending, retry after %s", e.after)
+}
+
+// CACerts returns the current CA certificates of the EST server.
+func (c *client) CACerts(ctx context.Context) ([]*x509.Certificate, error) {
+	req, err := http.NewRequest(http.MethodGet, c.operationURL(cacertsOperation), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	body, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCertsOnly(body)
+}
+
+// SimpleEnroll requests the EST server to issue a certificate for the
+// DER-encoded CSR. It returns the certificates of the response, starting
+// with the issued certificate.
+func (c *client) SimpleEnroll(ctx context.Context, csr []byte) ([]*x509.Certificate, error) {
+	body := base64.StdEncoding.EncodeToString(csr)
+	req, err := http.NewRequest(http.MethodPost, c.operationURL(simpleEnrollOperation), strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", pkcs10ContentType)
+	req.Header.Set("Content-Transfer-Encoding", "base64")
+
+	respBody, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCertsOnly(respBody)
+}
+
+func (c *client) operationURL(operation string) string {
+	u := strings.TrimSuffix(c.config.ServerURL, "/") + wellKnownPath
+	if c.config.Label != "" {
+		u += "/" + c.config.Label
+	}
+	return u + "/" + operation
+}
+
+func (c *client) do(ctx context.Context, req *http.Request) ([]byte, error) {
+	req = req.WithContext(ctx)
+	if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("EST request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read EST response: %v", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusAccepted:
+		return nil, errRetryLater{after: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	default:
+		msg := strings.TrimSpace(string(body))
+		if msg == "" {
+			msg = http.StatusText(resp.StatusCode)
+		}
+		return nil, fmt.Errorf("EST server returned status %d: %s", resp.StatusCode, msg)
+	}
+}
+
+// decodeCertsOnly decodes the base64-encoded certs-only PKCS#7 structure EST
+// servers respond with.
+func decodeCertsOnly(body []byte) ([]*x509.Certificate, error) {
+	der, err := base64.StdEncoding.DecodeString(removeWhitespace(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode EST response: %v", err)
+	}
+	return parseCertsOnly(der)
+}
+
+// parseRetryAfter parses the delay of a Retry-After header, given either in
+// seconds or as an HTTP date. A default of one minute is used if the header
+// is missing or malformed.
+func parseRetryAfter(value string) time.Duration {
+	const defaultRetryAfter = time.Minute
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return defaultRetryAfter
+}
+
+func removeWhitespace(s string) string {
+	var b bytes.Buffer
+	for _, r := range s {
+		switch r {
+		case ' ', '\t', '\r', '\n':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
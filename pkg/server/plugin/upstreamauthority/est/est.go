@@ -0,0 +1,253 @@
+package est
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "est"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, upstreamauthority.PluginServer(p))
+}
+
+// Configuration is the configuration of the EST plugin
+type Configuration struct {
+	// URL of the EST server, without the /.well-known/est path
+	// (e.g. https://est.example.org)
+	ServerURL string `hcl:"server_url" json:"server_url"`
+	// Optional CA label, for EST servers hosting several CAs
+	Label string `hcl:"label" json:"label"`
+	// Path to the CA certificates used to authenticate the EST server.
+	// System roots are used if not set.
+	ServerCACertPath string `hcl:"server_ca_cert_path" json:"server_ca_cert_path"`
+	// Username and password for HTTP basic authentication
+	Username string `hcl:"username" json:"username"`
+	Password string `hcl:"password" json:"password"`
+	// Paths to a client certificate and key for TLS client authentication
+	ClientCertPath string `hcl:"client_cert_path" json:"client_cert_path"`
+	ClientKeyPath  string `hcl:"client_key_path" json:"client_key_path"`
+}
+
+// Plugin requests intermediate certificates from an EST server (RFC 7030)
+type Plugin struct {
+	log hclog.Logger
+
+	mtx    sync.RWMutex
+	client *client
+
+	hooks struct {
+		clock clock.Clock
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{}
+	p.hooks.clock = clock.New()
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(Configuration)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	if config.ServerURL == "" {
+		return nil, errors.New("server_url is required")
+	}
+	serverURL, err := url.Parse(config.ServerURL)
+	if err != nil || serverURL.Scheme != "https" || serverURL.Host == "" {
+		return nil, fmt.Errorf("server_url %q must be an https URL", config.ServerURL)
+	}
+	if config.Password != "" && config.Username == "" {
+		return nil, errors.New("username is required when password is set")
+	}
+
+	clientConfig := clientConfig{
+		ServerURL: config.ServerURL,
+		Label:     config.Label,
+		Username:  config.Username,
+		Password:  config.Password,
+	}
+
+	if config.ServerCACertPath != "" {
+		certs, err := pemutil.LoadCertificates(config.ServerCACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load server CA certificates: %v", err)
+		}
+		clientConfig.RootCAs = x509.NewCertPool()
+		for _, cert := range certs {
+			clientConfig.RootCAs.AddCert(cert)
+		}
+	}
+
+	switch {
+	case config.ClientCertPath != "" && config.ClientKeyPath != "":
+		cert, err := tls.LoadX509KeyPair(config.ClientCertPath, config.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		clientConfig.Certificate = &cert
+	case config.ClientCertPath != "" || config.ClientKeyPath != "":
+		return nil, errors.New("both client_cert_path and client_key_path are required")
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.client = newClient(clientConfig)
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (*Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+// MintX509CA enrolls the CSR with the EST server. If the server defers the
+// enrollment, e.g. pending manual approval, the request is retried after the
+// delay the server asks for, until the stream is canceled.
+func (p *Plugin) MintX509CA(req *upstreamauthority.MintX509CARequest, stream upstreamauthority.UpstreamAuthority_MintX509CAServer) error {
+	client, err := p.getClient()
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+
+	var issued []*x509.Certificate
+	for {
+		issued, err = client.SimpleEnroll(ctx, req.Csr)
+		var retry errRetryLater
+		if !errors.As(err, &retry) {
+			break
+		}
+		p.log.Info("Enrollment pending on EST server", "retry_after", retry.after)
+		select {
+		case <-p.hooks.clock.After(retry.after):
+		case <-ctx.Done():
+			return makeError(codes.DeadlineExceeded, "enrollment still pending: %v", ctx.Err())
+		}
+	}
+	if err != nil {
+		return makeError(codes.Internal, "failed to enroll CSR: %v", err)
+	}
+
+	caCerts, err := client.CACerts(ctx)
+	if err != nil {
+		return makeError(codes.Internal, "failed to fetch CA certificates: %v", err)
+	}
+
+	chain, roots, err := buildChain(issued, caCerts)
+	if err != nil {
+		return makeError(codes.Internal, "%v", err)
+	}
+
+	return stream.Send(&upstreamauthority.MintX509CAResponse{
+		X509CaChain:       x509util.RawCertsFromCertificates(chain),
+		UpstreamX509Roots: x509util.RawCertsFromCertificates(roots),
+	})
+}
+
+// PublishJWTKey is not implemented by the wrapper and returns a codes.Unimplemented status
+func (*Plugin) PublishJWTKey(*upstreamauthority.PublishJWTKeyRequest, upstreamauthority.UpstreamAuthority_PublishJWTKeyServer) error {
+	return makeError(codes.Unimplemented, "publishing upstream is unsupported")
+}
+
+func (p *Plugin) getClient() (*client, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	if p.client == nil {
+		return nil, makeError(codes.FailedPrecondition, "not configured")
+	}
+	return p.client, nil
+}
+
+// buildChain orders the certificates returned by the EST server into the
+// chain of the issued certificate, which is the first enrolled certificate,
+// up to but excluding the root. The roots are the self-signed CA
+// certificates.
+func buildChain(enrolled, caCerts []*x509.Certificate) (chain, roots []*x509.Certificate, err error) {
+	for _, cert := range caCerts {
+		if isSelfSigned(cert) {
+			roots = append(roots, cert)
+		}
+	}
+	if len(roots) == 0 {
+		return nil, nil, errors.New("no root certificate in the EST server CA certificates")
+	}
+
+	var intermediates []*x509.Certificate
+	for _, certs := range [][]*x509.Certificate{enrolled[1:], caCerts} {
+		for _, cert := range certs {
+			if !isSelfSigned(cert) {
+				intermediates = append(intermediates, cert)
+			}
+		}
+	}
+
+	chain = []*x509.Certificate{enrolled[0]}
+	for current := enrolled[0]; ; {
+		issuer := findIssuer(current, intermediates)
+		if issuer == nil {
+			break
+		}
+		chain = append(chain, issuer)
+		current = issuer
+		if len(chain) > len(intermediates)+1 {
+			return nil, nil, errors.New("loop in the EST server certificate chain")
+		}
+	}
+
+	// Make sure the chain actually leads to one of the roots
+	if findIssuer(chain[len(chain)-1], roots) == nil {
+		return nil, nil, errors.New("issued certificate does not chain to the EST server CA certificates")
+	}
+	return chain, roots, nil
+}
+
+func findIssuer(cert *x509.Certificate, candidates []*x509.Certificate) *x509.Certificate {
+	for _, candidate := range candidates {
+		if candidate.Equal(cert) || !bytes.Equal(cert.RawIssuer, candidate.RawSubject) {
+			continue
+		}
+		if cert.CheckSignatureFrom(candidate) == nil {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+func makeError(code codes.Code, format string, args ...interface{}) error {
+	return status.Errorf(code, "est: "+format, args...)
+}
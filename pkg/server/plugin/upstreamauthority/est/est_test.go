@@ -0,0 +1,372 @@
+package est
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx       = context.Background()
+	spiffeURI = &url.URL{Scheme: "spiffe", Host: "example.org"}
+)
+
+func TestEST(t *testing.T) {
+	spiretest.Run(t, new(ESTSuite))
+}
+
+type ESTSuite struct {
+	spiretest.Suite
+
+	clock  *clock.Mock
+	server *fakeESTServer
+	csrKey *ecdsa.PrivateKey
+	dir    string
+
+	plugin upstreamauthority.Plugin
+}
+
+func (s *ESTSuite) SetupSuite() {
+	s.server = newFakeESTServer(s.T())
+	s.csrKey = testkey.NewEC256(s.T())
+}
+
+func (s *ESTSuite) SetupTest() {
+	s.clock = clock.NewMock(s.T())
+	s.server.reset(s.T())
+	s.dir = s.TempDir()
+	s.Require().NoError(pemutil.SaveCertificate(s.path("server-ca.pem"), s.server.root, 0600))
+
+	p := New()
+	p.hooks.clock = s.clock
+	p.SetLogger(hclog.NewNullLogger())
+	s.LoadPlugin(builtin(p), &s.plugin)
+}
+
+func (s *ESTSuite) TestConfigure() {
+	for _, tt := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "malformed",
+			config: "{{{",
+			err:    "failed to decode configuration",
+		},
+		{
+			name:   "missing server URL",
+			config: `label = "spire"`,
+			err:    "server_url is required",
+		},
+		{
+			name:   "server URL not https",
+			config: `server_url = "http://est.example.org"`,
+			err:    `server_url "http://est.example.org" must be an https URL`,
+		},
+		{
+			name: "password without username",
+			config: `
+				server_url = "https://est.example.org"
+				password = "secret"`,
+			err: "username is required when password is set",
+		},
+		{
+			name: "client certificate without key",
+			config: `
+				server_url = "https://est.example.org"
+				client_cert_path = "cert.pem"`,
+			err: "both client_cert_path and client_key_path are required",
+		},
+		{
+			name: "missing server CA",
+			config: fmt.Sprintf(`
+				server_url = "https://est.example.org"
+				server_ca_cert_path = %q`, s.path("missing.pem")),
+			err: "failed to load server CA certificates",
+		},
+	} {
+		tt := tt
+		s.Run(tt.name, func() {
+			_, err := s.plugin.Configure(ctx, &spi.ConfigureRequest{Configuration: tt.config})
+			s.RequireErrorContains(err, tt.err)
+		})
+	}
+}
+
+func (s *ESTSuite) TestMintX509CANotConfigured() {
+	_, err := s.mintX509CA()
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "est: not configured")
+}
+
+func (s *ESTSuite) TestMintX509CA() {
+	s.configure("secret")
+
+	resp, err := s.mintX509CA()
+	s.Require().NoError(err)
+	s.requireMintResponse(resp)
+}
+
+func (s *ESTSuite) TestMintX509CAWaitsForPendingEnrollment() {
+	s.configure("secret")
+	s.server.setPending(1)
+
+	type result struct {
+		resp *upstreamauthority.MintX509CAResponse
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		resp, err := s.mintX509CA()
+		results <- result{resp: resp, err: err}
+	}()
+
+	s.clock.WaitForAfter(5*time.Second, "enrollment was not retried")
+	s.clock.Add(30 * time.Second)
+
+	r := <-results
+	s.Require().NoError(r.err)
+	s.requireMintResponse(r.resp)
+}
+
+func (s *ESTSuite) TestMintX509CAUnauthorized() {
+	s.configure("wrong")
+
+	_, err := s.mintX509CA()
+	s.RequireGRPCStatus(err, codes.Internal, "est: failed to enroll CSR: EST server returned status 401: unauthorized")
+}
+
+func (s *ESTSuite) TestPublishJWTKey() {
+	stream, err := s.plugin.PublishJWTKey(ctx, &upstreamauthority.PublishJWTKeyRequest{})
+	s.Require().NoError(err)
+	_, err = stream.Recv()
+	s.RequireGRPCStatus(err, codes.Unimplemented, "est: publishing upstream is unsupported")
+}
+
+func (s *ESTSuite) configure(password string) {
+	_, err := s.plugin.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			server_url = %q
+			label = "spire"
+			server_ca_cert_path = %q
+			username = "spire"
+			password = %q`, s.server.server.URL, s.path("server-ca.pem"), password),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ESTSuite) mintX509CA() (*upstreamauthority.MintX509CAResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		URIs: []*url.URL{spiffeURI},
+	}, s.csrKey)
+	s.Require().NoError(err)
+
+	stream, err := s.plugin.MintX509CA(ctx, &upstreamauthority.MintX509CARequest{Csr: csr})
+	s.Require().NoError(err)
+
+	resp, err := stream.Recv()
+	if err == nil {
+		_, eofErr := stream.Recv()
+		s.Require().Equal(io.EOF, eofErr)
+	}
+	return resp, err
+}
+
+func (s *ESTSuite) requireMintResponse(resp *upstreamauthority.MintX509CAResponse) {
+	s.Require().Len(resp.X509CaChain, 2)
+	cert, err := x509.ParseCertificate(resp.X509CaChain[0])
+	s.Require().NoError(err)
+	s.Require().Equal([]*url.URL{spiffeURI}, cert.URIs)
+	s.Require().Equal(s.server.intermediate.Raw, resp.X509CaChain[1])
+	s.Require().Equal([][]byte{s.server.root.Raw}, resp.UpstreamX509Roots)
+}
+
+func (s *ESTSuite) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+func TestParseCertsOnly(t *testing.T) {
+	root, rootKey := testca.CreateCACertificate(t, nil, nil)
+	intermediate, _ := testca.CreateCACertificate(t, root, rootKey)
+
+	certs, err := parseCertsOnly(encodeCertsOnly(t, intermediate, root))
+	require.NoError(t, err)
+	require.Equal(t, []*x509.Certificate{intermediate, root}, certs)
+
+	_, err = parseCertsOnly(encodeCertsOnly(t))
+	require.EqualError(t, err, "no certificates in PKCS#7 signed data")
+
+	data, err := asn1.Marshal(contentInfo{ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}})
+	require.NoError(t, err)
+	_, err = parseCertsOnly(data)
+	require.EqualError(t, err, "unexpected PKCS#7 content type 1.2.840.113549.1.7.1")
+
+	_, err = parseCertsOnly([]byte("not DER"))
+	require.Error(t, err)
+}
+
+// fakeESTServer implements the cacerts and simpleenroll EST operations for
+// the "spire" label, authenticating clients with HTTP basic authentication.
+type fakeESTServer struct {
+	server *httptest.Server
+
+	root            *x509.Certificate
+	intermediate    *x509.Certificate
+	intermediateKey crypto.Signer
+
+	mtx     sync.Mutex
+	t       *testing.T
+	pending int
+}
+
+func newFakeESTServer(t *testing.T) *fakeESTServer {
+	root, rootKey := testca.CreateCACertificate(t, nil, nil)
+	intermediate, intermediateKey := testca.CreateCACertificate(t, root, rootKey)
+	webCert, webKey := testca.CreateX509Certificate(t, root, rootKey, testca.WithIPAddresses(net.IPv4(127, 0, 0, 1)))
+
+	f := &fakeESTServer{
+		t:               t,
+		root:            root,
+		intermediate:    intermediate,
+		intermediateKey: intermediateKey,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/est/spire/cacerts", f.handleCACerts)
+	mux.HandleFunc("/.well-known/est/spire/simpleenroll", f.handleSimpleEnroll)
+
+	f.server = httptest.NewUnstartedServer(mux)
+	f.server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{webCert.Raw},
+			PrivateKey:  webKey,
+		}},
+	}
+	f.server.StartTLS()
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeESTServer) reset(t *testing.T) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.t = t
+	f.pending = 0
+}
+
+func (f *fakeESTServer) setPending(pending int) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.pending = pending
+}
+
+func (f *fakeESTServer) handleCACerts(w http.ResponseWriter, r *http.Request) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.writeCertsOnly(w, f.intermediate, f.root)
+}
+
+func (f *fakeESTServer) handleSimpleEnroll(w http.ResponseWriter, r *http.Request) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if username, password, ok := r.BasicAuth(); !ok || username != "spire" || password != "secret" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if f.pending > 0 {
+		f.pending--
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	require.Equal(f.t, "application/pkcs10", r.Header.Get("Content-Type"))
+	body, err := ioutil.ReadAll(r.Body)
+	require.NoError(f.t, err)
+	der, err := base64.StdEncoding.DecodeString(string(body))
+	require.NoError(f.t, err)
+	csr, err := x509.ParseCertificateRequest(der)
+	require.NoError(f.t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(f.t, err)
+	cert := testca.CreateCertificate(f.t, &x509.Certificate{
+		SerialNumber:          serial,
+		URIs:                  csr.URIs,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, f.intermediate, csr.PublicKey, f.intermediateKey)
+
+	f.writeCertsOnly(w, cert)
+}
+
+func (f *fakeESTServer) writeCertsOnly(w http.ResponseWriter, certs ...*x509.Certificate) {
+	w.Header().Set("Content-Type", "application/pkcs7-mime; smime-type=certs-only")
+	w.Header().Set("Content-Transfer-Encoding", "base64")
+	_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(encodeCertsOnly(f.t, certs...))))
+}
+
+// encodeCertsOnly encodes the certificates into a DER-encoded certs-only
+// PKCS#7 SignedData structure.
+func encodeCertsOnly(t *testing.T, certs ...*x509.Certificate) []byte {
+	var raw []byte
+	for _, cert := range certs {
+		raw = append(raw, cert.Raw...)
+	}
+
+	emptySet := asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true}
+	dataContentInfo, err := asn1.Marshal(struct {
+		ContentType asn1.ObjectIdentifier
+	}{ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}})
+	require.NoError(t, err)
+
+	sd, err := asn1.Marshal(signedData{
+		Version:          1,
+		DigestAlgorithms: emptySet,
+		ContentInfo:      asn1.RawValue{FullBytes: dataContentInfo},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: raw},
+		SignerInfos:      emptySet,
+	})
+	require.NoError(t, err)
+
+	der, err := asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd},
+	})
+	require.NoError(t, err)
+	return der
+}
@@ -0,0 +1,63 @@
+package est
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+var oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+// contentInfo is the PKCS#7 ContentInfo structure (RFC 2315, section 7).
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	// Content is the [0] EXPLICIT tagged content
+	Content asn1.RawValue `asn1:"optional"`
+}
+
+// signedData is the PKCS#7 SignedData structure (RFC 2315, section 9.1).
+// EST responses are "certs-only" SignedData structures, which carry no
+// content or signatures, so only the certificates are decoded.
+type signedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      asn1.RawValue
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      asn1.RawValue
+}
+
+// parseCertsOnly returns the certificates of a DER-encoded certs-only PKCS#7
+// SignedData structure, as returned by EST servers.
+func parseCertsOnly(der []byte) ([]*x509.Certificate, error) {
+	var ci contentInfo
+	rest, err := asn1.Unmarshal(der, &ci)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS#7 content info: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data after PKCS#7 content info")
+	}
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("unexpected PKCS#7 content type %s", ci.ContentType)
+	}
+
+	if ci.Content.Class != asn1.ClassContextSpecific || ci.Content.Tag != 0 {
+		return nil, errors.New("missing PKCS#7 signed data")
+	}
+
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS#7 signed data: %v", err)
+	}
+
+	certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS#7 certificates: %v", err)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates in PKCS#7 signed data")
+	}
+	return certs, nil
+}
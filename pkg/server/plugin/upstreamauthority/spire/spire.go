@@ -25,6 +25,10 @@ const (
 	pluginName       = "spire"
 	upstreamPollFreq = 5 * time.Second
 	internalPollFreq = time.Second
+
+	// defaultWorkloadAPISocket is the default Workload API socket of the
+	// SPIRE agent co-located with the downstream server.
+	defaultWorkloadAPISocket = "/tmp/agent.sock"
 )
 
 var clk clock.Clock = clock.New()
@@ -78,6 +82,18 @@ func (m *Plugin) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*
 		return nil, errors.New("trust_domain is required")
 	}
 
+	if config.ServerAddr == "" {
+		return nil, errors.New("server_address is required")
+	}
+
+	if config.ServerPort == "" {
+		return nil, errors.New("server_port is required")
+	}
+
+	if config.WorkloadAPISocket == "" {
+		config.WorkloadAPISocket = defaultWorkloadAPISocket
+	}
+
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
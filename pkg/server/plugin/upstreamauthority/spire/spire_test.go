@@ -294,6 +294,22 @@ func TestSpirePlugin_Configure(t *testing.T) {
 			},
 			err: "malformed trustdomain: spiffeid: unable to parse: parse \"spiffe://malformed td\": invalid character \" \" in host name",
 		},
+		{
+			name: "no server address",
+			req: &spi.ConfigureRequest{
+				Configuration: `server_port = "8081"`,
+				GlobalConfig:  &spi.ConfigureRequest_GlobalConfig{TrustDomain: trustDomain.String()},
+			},
+			err: "server_address is required",
+		},
+		{
+			name: "no server port",
+			req: &spi.ConfigureRequest{
+				Configuration: `server_address = "localhost"`,
+				GlobalConfig:  &spi.ConfigureRequest_GlobalConfig{TrustDomain: trustDomain.String()},
+			},
+			err: "server_port is required",
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -312,8 +328,19 @@ func TestSpirePlugin_Configure(t *testing.T) {
 	}
 }
 
+func TestSpirePlugin_ConfigureDefaultWorkloadAPISocket(t *testing.T) {
+	m := New()
+	_, err := m.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: `server_address = "localhost" server_port = "8081"`,
+		GlobalConfig:  &spi.ConfigureRequest_GlobalConfig{TrustDomain: trustDomain.String()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/agent.sock", m.config.WorkloadAPISocket)
+	require.Equal(t, "unix:///tmp/agent.sock", m.serverClient.workloadAPISocket)
+}
+
 func TestSpirePlugin_GetPluginInfo(t *testing.T) {
-	m, _ := newWithDefault(t, "", "")
+	m, _ := newWithDefault(t, "localhost:8081", "")
 
 	res, err := m.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
 	require.NoError(t, err)
@@ -362,8 +389,8 @@ func TestSpirePlugin_MintX509CA(t *testing.T) {
 			getCSR: func() ([]byte, crypto.PublicKey) {
 				return csr, pubKey
 			},
-			customServerAddr: "localhost",
-			expectedErr:      `rpc error: code = Unavailable desc = connection error: desc = "transport: Error while dialing dial tcp :0`,
+			customServerAddr: "127.0.0.1:0",
+			expectedErr:      `rpc error: code = Unavailable desc = connection error: desc = "transport: Error while dialing dial tcp 127.0.0.1:0`,
 		},
 		{
 			name: "invalid scheme",
@@ -0,0 +1,199 @@
+package stepca
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/pemutil"
+)
+
+const (
+	signPath  = "/1.0/sign"
+	renewPath = "/renew"
+	rootsPath = "/roots"
+
+	csrType = "CERTIFICATE REQUEST"
+)
+
+// signRequest is the body of a step-ca sign request.
+type signRequest struct {
+	CSR      string `json:"csr"`
+	OTT      string `json:"ott"`
+	NotAfter string `json:"notAfter,omitempty"`
+}
+
+// signResponse is the body of the step-ca sign and renew responses.
+type signResponse struct {
+	Certificate string   `json:"crt"`
+	CA          string   `json:"ca"`
+	CertChain   []string `json:"certChain"`
+}
+
+// rootsResponse is the body of the step-ca roots response.
+type rootsResponse struct {
+	Certificates []string `json:"crts"`
+}
+
+// errorResponse is the body step-ca returns on failed requests.
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// client talks to the step-ca HTTP API.
+type client struct {
+	caURL   string
+	rootCAs *x509.CertPool
+}
+
+func newClient(caURL string, rootCAs *x509.CertPool) *client {
+	return &client{
+		caURL:   strings.TrimSuffix(caURL, "/"),
+		rootCAs: rootCAs,
+	}
+}
+
+// signAudience returns the audience of the tokens authorizing sign requests.
+func (c *client) signAudience() string {
+	return c.caURL + signPath
+}
+
+// Sign requests step-ca to sign the CSR, authorized by the one-time token.
+// The returned chain starts with the signed certificate.
+func (c *client) Sign(ctx context.Context, csr []byte, ott string, ttl time.Duration) ([]*x509.Certificate, error) {
+	req := signRequest{
+		CSR: string(pem.EncodeToMemory(&pem.Block{Type: csrType, Bytes: csr})),
+		OTT: ott,
+	}
+	if ttl > 0 {
+		req.NotAfter = ttl.String()
+	}
+
+	resp := new(signResponse)
+	if err := c.do(ctx, c.httpClient(nil), http.MethodPost, signPath, req, resp); err != nil {
+		return nil, err
+	}
+	return resp.chain()
+}
+
+// Renew requests step-ca to renew the certificate at the head of the chain,
+// authenticating with it over mutual TLS. The returned chain starts with the
+// renewed certificate.
+func (c *client) Renew(ctx context.Context, cert *tls.Certificate) ([]*x509.Certificate, error) {
+	resp := new(signResponse)
+	if err := c.do(ctx, c.httpClient(cert), http.MethodPost, renewPath, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.chain()
+}
+
+// Roots returns the root certificates of step-ca.
+func (c *client) Roots(ctx context.Context) ([]*x509.Certificate, error) {
+	resp := new(rootsResponse)
+	if err := c.do(ctx, c.httpClient(nil), http.MethodGet, rootsPath, nil, resp); err != nil {
+		return nil, err
+	}
+
+	var roots []*x509.Certificate
+	for _, rootPEM := range resp.Certificates {
+		root, err := pemutil.ParseCertificate([]byte(rootPEM))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse root certificate: %v", err)
+		}
+		roots = append(roots, root)
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no root certificates returned")
+	}
+	return roots, nil
+}
+
+func (c *client) httpClient(cert *tls.Certificate) *http.Client {
+	tlsConfig := &tls.Config{
+		RootCAs:    c.rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	if cert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*cert}
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+}
+
+func (c *client) do(ctx context.Context, httpClient *http.Client, method, path string, in, out interface{}) error {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return fmt.Errorf("failed to encode request: %v", err)
+		}
+	}
+
+	req, err := http.NewRequest(method, c.caURL+path, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req = req.WithContext(ctx)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response from %s: %v", path, err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		errResp := new(errorResponse)
+		if err := json.Unmarshal(respBody, errResp); err == nil && errResp.Message != "" {
+			return fmt.Errorf("request to %s failed with status %d: %s", path, resp.StatusCode, errResp.Message)
+		}
+		return fmt.Errorf("request to %s failed with status %d", path, resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %v", path, err)
+	}
+	return nil
+}
+
+func (r *signResponse) chain() ([]*x509.Certificate, error) {
+	chainPEM := r.CertChain
+	if len(chainPEM) == 0 {
+		// Older step-ca releases only return the certificate and its issuer
+		chainPEM = []string{r.Certificate, r.CA}
+	}
+
+	var chain []*x509.Certificate
+	for _, certPEM := range chainPEM {
+		if certPEM == "" {
+			continue
+		}
+		cert, err := pemutil.ParseCertificate([]byte(certPEM))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %v", err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no certificate returned")
+	}
+	return chain, nil
+}
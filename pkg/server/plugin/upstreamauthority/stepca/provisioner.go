@@ -0,0 +1,272 @@
+package stepca
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
+	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	// tokenLifetime is how long the one-time tokens are valid for. Tokens are
+	// generated right before they are used, so they can be short-lived.
+	tokenLifetime = 5 * time.Minute
+)
+
+// provisioner generates the one-time tokens step-ca requires to sign
+// certificates.
+type provisioner interface {
+	// Token returns a token authorizing the signing of a certificate with the
+	// subject and SANs.
+	Token(audience, subject string, sans []string) (string, error)
+}
+
+// tokenClaims are the claims step-ca expects in the one-time tokens.
+type tokenClaims struct {
+	jwt.Claims
+	SANs []string `json:"sans,omitempty"`
+}
+
+func newTokenClaims(clk clock.Clock, issuer, audience, subject string, sans []string) (*tokenClaims, error) {
+	jti := make([]byte, 32)
+	if _, err := rand.Read(jti); err != nil {
+		return nil, fmt.Errorf("failed to generate token ID: %v", err)
+	}
+
+	now := clk.Now()
+	return &tokenClaims{
+		Claims: jwt.Claims{
+			ID:        hex.EncodeToString(jti),
+			Issuer:    issuer,
+			Subject:   subject,
+			Audience:  jwt.Audience{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(now.Add(tokenLifetime)),
+		},
+		SANs: sans,
+	}, nil
+}
+
+func signToken(key jose.JSONWebKey, opts *jose.SignerOptions, claims *tokenClaims) (string, error) {
+	alg, err := signatureAlgorithm(key.Key)
+	if err != nil {
+		return "", err
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, opts.WithType("JWT"))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token signer: %v", err)
+	}
+
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %v", err)
+	}
+	return token, nil
+}
+
+// jwkProvisioner signs tokens with the private key of a JWK provisioner.
+type jwkProvisioner struct {
+	name  string
+	key   jose.JSONWebKey
+	clock clock.Clock
+}
+
+// newJWKProvisioner loads the private JWK of the provisioner. The key can be
+// encrypted with a password, as done by `step crypto jwk create`.
+func newJWKProvisioner(name, keyPath, passwordPath string, clk clock.Clock) (*jwkProvisioner, error) {
+	keyBytes, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWK provisioner key: %v", err)
+	}
+
+	if passwordPath != "" {
+		password, err := ioutil.ReadFile(passwordPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWK provisioner key password: %v", err)
+		}
+		encrypted, err := jose.ParseEncrypted(string(keyBytes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse encrypted JWK provisioner key: %v", err)
+		}
+		keyBytes, err = encrypted.Decrypt([]byte(strings.TrimRight(string(password), "\r\n")))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt JWK provisioner key: %v", err)
+		}
+	}
+
+	var key jose.JSONWebKey
+	if err := key.UnmarshalJSON(keyBytes); err != nil {
+		return nil, fmt.Errorf("failed to parse JWK provisioner key: %v", err)
+	}
+	if key.IsPublic() {
+		return nil, errors.New("JWK provisioner key is not a private key")
+	}
+	if key.KeyID == "" {
+		// step-ca identifies JWK provisioner keys by their thumbprint
+		thumbprint, err := key.Thumbprint(crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute JWK provisioner key ID: %v", err)
+		}
+		key.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+	}
+
+	return &jwkProvisioner{
+		name:  name,
+		key:   key,
+		clock: clk,
+	}, nil
+}
+
+func (p *jwkProvisioner) Token(audience, subject string, sans []string) (string, error) {
+	claims, err := newTokenClaims(p.clock, p.name, audience, subject, sans)
+	if err != nil {
+		return "", err
+	}
+	return signToken(p.key, &jose.SignerOptions{}, claims)
+}
+
+// x5cProvisioner signs tokens with the private key of an X5C provisioner
+// certificate, including the certificate chain in the token header.
+type x5cProvisioner struct {
+	name     string
+	certPath string
+	key      crypto.Signer
+	clock    clock.Clock
+
+	mtx   sync.RWMutex
+	chain []*x509.Certificate
+}
+
+func newX5CProvisioner(name, certPath, keyPath string, clk clock.Clock) (*x5cProvisioner, error) {
+	chain, err := pemutil.LoadCertificates(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load X5C provisioner certificate: %v", err)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no X5C provisioner certificate found")
+	}
+
+	key, err := pemutil.LoadSigner(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load X5C provisioner key: %v", err)
+	}
+
+	matches, err := cryptoutil.PublicKeyEqual(chain[0].PublicKey, key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare X5C provisioner certificate and key: %v", err)
+	}
+	if !matches {
+		return nil, errors.New("X5C provisioner key does not match the certificate")
+	}
+
+	return &x5cProvisioner{
+		name:     name,
+		certPath: certPath,
+		key:      key,
+		clock:    clk,
+		chain:    chain,
+	}, nil
+}
+
+func (p *x5cProvisioner) Token(audience, subject string, sans []string) (string, error) {
+	claims, err := newTokenClaims(p.clock, p.name, audience, subject, sans)
+	if err != nil {
+		return "", err
+	}
+
+	chain := p.getChain()
+	x5c := make([]string, 0, len(chain))
+	for _, cert := range chain {
+		x5c = append(x5c, base64.StdEncoding.EncodeToString(cert.Raw))
+	}
+
+	opts := new(jose.SignerOptions).WithHeader("x5c", x5c)
+	return signToken(jose.JSONWebKey{Key: p.key}, opts, claims)
+}
+
+// RenewAt returns when the certificate should be renewed, once two thirds of
+// its lifetime have elapsed.
+func (p *x5cProvisioner) RenewAt() time.Time {
+	cert := p.getChain()[0]
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotBefore.Add(lifetime * 2 / 3)
+}
+
+// TLSCertificate returns the certificate and key to authenticate to step-ca
+// with when renewing the certificate.
+func (p *x5cProvisioner) TLSCertificate() *tls.Certificate {
+	chain := p.getChain()
+	cert := &tls.Certificate{
+		PrivateKey: p.key,
+		Leaf:       chain[0],
+	}
+	for _, c := range chain {
+		cert.Certificate = append(cert.Certificate, c.Raw)
+	}
+	return cert
+}
+
+// SetChain replaces the certificate chain with a renewed one, persisting it
+// to disk so it survives restarts.
+func (p *x5cProvisioner) SetChain(chain []*x509.Certificate) error {
+	matches, err := cryptoutil.PublicKeyEqual(chain[0].PublicKey, p.key.Public())
+	if err != nil {
+		return fmt.Errorf("failed to compare renewed certificate and key: %v", err)
+	}
+	if !matches {
+		return errors.New("renewed certificate does not match the X5C provisioner key")
+	}
+
+	if err := diskutil.AtomicWriteFile(p.certPath, pemutil.EncodeCertificates(chain), 0644); err != nil {
+		return fmt.Errorf("failed to write renewed certificate: %v", err)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.chain = chain
+	return nil
+}
+
+func (p *x5cProvisioner) getChain() []*x509.Certificate {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return p.chain
+}
+
+func signatureAlgorithm(key interface{}) (jose.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		case elliptic.P521():
+			return jose.ES512, nil
+		}
+		return "", fmt.Errorf("unsupported elliptic curve %q", k.Curve.Params().Name)
+	case *rsa.PrivateKey:
+		return jose.RS256, nil
+	}
+	return "", fmt.Errorf("unsupported key type %T", key)
+}
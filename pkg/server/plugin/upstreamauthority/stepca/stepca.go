@@ -0,0 +1,295 @@
+package stepca
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "step_ca"
+
+	// renewRetryInterval is how long to wait before retrying a failed
+	// renewal of the X5C provisioner certificate.
+	renewRetryInterval = time.Minute
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, upstreamauthority.PluginServer(p))
+}
+
+// Configuration is the configuration of the step-ca plugin
+type Configuration struct {
+	// URL of step-ca (e.g. https://ca.example.org:9000)
+	CAURL string `hcl:"ca_url" json:"ca_url"`
+	// Path to the root certificate of step-ca, used to authenticate it
+	CACertPath string `hcl:"ca_cert_path" json:"ca_cert_path"`
+	// Name of the provisioner used to sign the intermediate certificates
+	ProvisionerName string `hcl:"provisioner_name" json:"provisioner_name"`
+	// Configuration of a JWK provisioner
+	JWKProvisioner *JWKProvisionerConfig `hcl:"jwk_provisioner" json:"jwk_provisioner"`
+	// Configuration of an X5C provisioner
+	X5CProvisioner *X5CProvisionerConfig `hcl:"x5c_provisioner" json:"x5c_provisioner"`
+}
+
+// JWKProvisionerConfig holds the private key of a JWK provisioner
+type JWKProvisionerConfig struct {
+	// Path to the private JWK of the provisioner
+	KeyPath string `hcl:"key_path" json:"key_path"`
+	// Path to a file holding the password the private JWK is encrypted with
+	PasswordPath string `hcl:"password_path" json:"password_path"`
+}
+
+// X5CProvisionerConfig holds the certificate and key of an X5C provisioner
+type X5CProvisionerConfig struct {
+	// Path to the certificate chain trusted by the provisioner
+	CertPath string `hcl:"cert_path" json:"cert_path"`
+	// Path to the private key of the certificate
+	KeyPath string `hcl:"key_path" json:"key_path"`
+}
+
+// Plugin requests intermediate certificates from step-ca
+type Plugin struct {
+	log hclog.Logger
+
+	mtx         sync.RWMutex
+	client      *client
+	provisioner provisioner
+	stopRenewal context.CancelFunc
+
+	hooks struct {
+		clock clock.Clock
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{}
+	p.hooks.clock = clock.New()
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(Configuration)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %v", err)
+	}
+
+	if config.CAURL == "" {
+		return nil, errors.New("ca_url is required")
+	}
+	caURL, err := url.Parse(config.CAURL)
+	if err != nil || caURL.Scheme != "https" || caURL.Host == "" {
+		return nil, fmt.Errorf("ca_url %q must be an https URL", config.CAURL)
+	}
+	if config.CACertPath == "" {
+		return nil, errors.New("ca_cert_path is required")
+	}
+	if config.ProvisionerName == "" {
+		return nil, errors.New("provisioner_name is required")
+	}
+
+	roots, err := pemutil.LoadCertificates(config.CACertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CA certificate: %v", err)
+	}
+	rootCAs := x509.NewCertPool()
+	for _, root := range roots {
+		rootCAs.AddCert(root)
+	}
+
+	var prov provisioner
+	var x5c *x5cProvisioner
+	switch {
+	case config.JWKProvisioner != nil && config.X5CProvisioner != nil:
+		return nil, errors.New("only one of jwk_provisioner or x5c_provisioner can be configured")
+	case config.JWKProvisioner != nil:
+		if config.JWKProvisioner.KeyPath == "" {
+			return nil, errors.New("jwk_provisioner key_path is required")
+		}
+		prov, err = newJWKProvisioner(config.ProvisionerName, config.JWKProvisioner.KeyPath, config.JWKProvisioner.PasswordPath, p.hooks.clock)
+	case config.X5CProvisioner != nil:
+		if config.X5CProvisioner.CertPath == "" || config.X5CProvisioner.KeyPath == "" {
+			return nil, errors.New("x5c_provisioner cert_path and key_path are required")
+		}
+		x5c, err = newX5CProvisioner(config.ProvisionerName, config.X5CProvisioner.CertPath, config.X5CProvisioner.KeyPath, p.hooks.clock)
+		prov = x5c
+	default:
+		return nil, errors.New("one of jwk_provisioner or x5c_provisioner is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client := newClient(config.CAURL, rootCAs)
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.stopRenewal != nil {
+		p.stopRenewal()
+		p.stopRenewal = nil
+	}
+	if x5c != nil {
+		var renewalCtx context.Context
+		renewalCtx, p.stopRenewal = context.WithCancel(context.Background())
+		go p.runX5CRenewal(renewalCtx, client, x5c)
+	}
+
+	p.client = client
+	p.provisioner = prov
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (*Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+// MintX509CA submits the CSR to step-ca, authorized by a one-time token
+// generated with the provisioner credentials.
+func (p *Plugin) MintX509CA(req *upstreamauthority.MintX509CARequest, stream upstreamauthority.UpstreamAuthority_MintX509CAServer) error {
+	client, prov, err := p.getClient()
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+
+	csr, err := x509.ParseCertificateRequest(req.Csr)
+	if err != nil {
+		return makeError(codes.InvalidArgument, "failed to parse CSR: %v", err)
+	}
+
+	token, err := prov.Token(client.signAudience(), csr.Subject.CommonName, csrSANs(csr))
+	if err != nil {
+		return makeError(codes.Internal, "failed to generate provisioner token: %v", err)
+	}
+
+	chain, err := client.Sign(ctx, req.Csr, token, time.Duration(req.PreferredTtl)*time.Second)
+	if err != nil {
+		return makeError(codes.Internal, "failed to sign CSR: %v", err)
+	}
+
+	roots, err := client.Roots(ctx)
+	if err != nil {
+		return makeError(codes.Internal, "failed to fetch root certificates: %v", err)
+	}
+
+	// step-ca may include its root in the chain; SPIRE expects the chain to
+	// end with the certificate signed by one of the roots.
+	return stream.Send(&upstreamauthority.MintX509CAResponse{
+		X509CaChain:       x509util.RawCertsFromCertificates(trimRoots(chain, roots)),
+		UpstreamX509Roots: x509util.RawCertsFromCertificates(roots),
+	})
+}
+
+// PublishJWTKey is not implemented by the wrapper and returns a codes.Unimplemented status
+func (*Plugin) PublishJWTKey(*upstreamauthority.PublishJWTKeyRequest, upstreamauthority.UpstreamAuthority_PublishJWTKeyServer) error {
+	return makeError(codes.Unimplemented, "publishing upstream is unsupported")
+}
+
+// runX5CRenewal renews the X5C provisioner certificate through step-ca once
+// two thirds of its lifetime have elapsed, until the context is canceled.
+func (p *Plugin) runX5CRenewal(ctx context.Context, client *client, x5c *x5cProvisioner) {
+	retry := false
+	for {
+		wait := x5c.RenewAt().Sub(p.hooks.clock.Now())
+		if retry {
+			wait = renewRetryInterval
+		}
+		if wait < 0 {
+			wait = 0
+		}
+
+		select {
+		case <-p.hooks.clock.After(wait):
+		case <-ctx.Done():
+			return
+		}
+
+		retry = false
+		if err := p.renewX5CCertificate(ctx, client, x5c); err != nil {
+			p.log.Warn("Failed to renew X5C provisioner certificate", "error", err)
+			retry = true
+		}
+	}
+}
+
+func (p *Plugin) renewX5CCertificate(ctx context.Context, client *client, x5c *x5cProvisioner) error {
+	chain, err := client.Renew(ctx, x5c.TLSCertificate())
+	if err != nil {
+		return err
+	}
+	if err := x5c.SetChain(chain); err != nil {
+		return err
+	}
+	p.log.Info("Renewed X5C provisioner certificate", "expiration", chain[0].NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+func (p *Plugin) getClient() (*client, provisioner, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	if p.client == nil {
+		return nil, nil, makeError(codes.FailedPrecondition, "not configured")
+	}
+	return p.client, p.provisioner, nil
+}
+
+// csrSANs returns the SANs of the CSR in the form step-ca expects them in
+// the token.
+func csrSANs(csr *x509.CertificateRequest) []string {
+	var sans []string
+	sans = append(sans, csr.DNSNames...)
+	for _, ip := range csr.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, csr.EmailAddresses...)
+	for _, uri := range csr.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
+// trimRoots removes the trailing certificates of the chain that are roots.
+func trimRoots(chain, roots []*x509.Certificate) []*x509.Certificate {
+	for len(chain) > 1 && isRoot(chain[len(chain)-1], roots) {
+		chain = chain[:len(chain)-1]
+	}
+	return chain
+}
+
+func isRoot(cert *x509.Certificate, roots []*x509.Certificate) bool {
+	for _, root := range roots {
+		if cert.Equal(root) {
+			return true
+		}
+	}
+	return false
+}
+
+func makeError(code codes.Code, format string, args ...interface{}) error {
+	return status.Errorf(code, "step-ca: "+format, args...)
+}
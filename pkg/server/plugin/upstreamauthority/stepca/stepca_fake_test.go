@@ -0,0 +1,228 @@
+package stepca
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// fakeStepCA implements the parts of the step-ca HTTP API used by the plugin.
+type fakeStepCA struct {
+	t      *testing.T
+	clock  clock.Clock
+	server *httptest.Server
+
+	root            *x509.Certificate
+	intermediate    *x509.Certificate
+	intermediateKey crypto.Signer
+
+	// jwkKey is the public key of the JWK provisioner
+	jwkKey jose.JSONWebKey
+
+	mtx          sync.Mutex
+	signErr      string
+	lastSignReq  *signRequest
+	lastHeader   jose.Header
+	lastClaims   *tokenClaims
+	renewedCerts []*x509.Certificate
+}
+
+// newFakeStepCA creates the certificates of step-ca and starts serving the
+// API. It is meant to be shared by the tests, which must call reset first.
+func newFakeStepCA(t *testing.T) *fakeStepCA {
+	now := time.Now()
+	lifetime := testca.WithLifetime(now.Add(-time.Minute), now.Add(24*time.Hour))
+	root, rootKey := testca.CreateCACertificate(t, nil, nil, lifetime)
+	intermediate, intermediateKey := testca.CreateCACertificate(t, root, rootKey, lifetime)
+	webCert, webKey := testca.CreateX509Certificate(t, root, rootKey, testca.WithIPAddresses(net.IPv4(127, 0, 0, 1)))
+
+	f := &fakeStepCA{
+		t:               t,
+		root:            root,
+		intermediate:    intermediate,
+		intermediateKey: intermediateKey,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(signPath, f.handleSign)
+	mux.HandleFunc(renewPath, f.handleRenew)
+	mux.HandleFunc(rootsPath, f.handleRoots)
+
+	f.server = httptest.NewUnstartedServer(mux)
+	f.server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{webCert.Raw},
+			PrivateKey:  webKey,
+		}},
+		ClientAuth: tls.RequestClientCert,
+	}
+	f.server.StartTLS()
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// reset clears the state of the previous test.
+func (f *fakeStepCA) reset(t *testing.T, clk clock.Clock) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.t = t
+	f.clock = clk
+	f.signErr = ""
+	f.lastSignReq = nil
+	f.lastHeader = jose.Header{}
+	f.lastClaims = nil
+	f.renewedCerts = nil
+}
+
+func (f *fakeStepCA) URL() string {
+	return f.server.URL
+}
+
+func (f *fakeStepCA) setSignErr(msg string) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.signErr = msg
+}
+
+func (f *fakeStepCA) handleSign(w http.ResponseWriter, r *http.Request) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.signErr != "" {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Message: f.signErr})
+		return
+	}
+
+	req := new(signRequest)
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(req))
+	f.lastSignReq = req
+
+	token, err := jwt.ParseSigned(req.OTT)
+	require.NoError(f.t, err)
+	require.Len(f.t, token.Headers, 1)
+	f.lastHeader = token.Headers[0]
+
+	// JWK tokens are verified with the key of the provisioner, X5C tokens
+	// with the certificate in their header.
+	var verificationKey interface{} = f.jwkKey.Key
+	if token.Headers[0].KeyID == "" {
+		chains, err := token.Headers[0].Certificates(x509.VerifyOptions{
+			Roots:       util.NewCertPool(f.root),
+			CurrentTime: f.clock.Now(),
+			KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		require.NoError(f.t, err)
+		verificationKey = chains[0][0].PublicKey
+	}
+	claims := new(tokenClaims)
+	require.NoError(f.t, token.Claims(verificationKey, claims))
+	f.lastClaims = claims
+
+	csr, err := pemutil.ParseCertificateRequest([]byte(req.CSR))
+	require.NoError(f.t, err)
+
+	notAfter := f.clock.Now().Add(time.Hour)
+	if req.NotAfter != "" {
+		ttl, err := time.ParseDuration(req.NotAfter)
+		require.NoError(f.t, err)
+		notAfter = f.clock.Now().Add(ttl)
+	}
+
+	cert := testca.CreateCertificate(f.t, &x509.Certificate{
+		SerialNumber:          newSerial(f.t),
+		URIs:                  csr.URIs,
+		NotBefore:             f.clock.Now(),
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, f.intermediate, csr.PublicKey, f.intermediateKey)
+
+	f.writeChain(w, cert)
+}
+
+func (f *fakeStepCA) handleRenew(w http.ResponseWriter, r *http.Request) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Message: "missing client certificate"})
+		return
+	}
+	peer := r.TLS.PeerCertificates[0]
+
+	lifetime := peer.NotAfter.Sub(peer.NotBefore)
+	cert := testca.CreateCertificate(f.t, &x509.Certificate{
+		SerialNumber: newSerial(f.t),
+		Subject:      peer.Subject,
+		NotBefore:    f.clock.Now(),
+		NotAfter:     f.clock.Now().Add(lifetime),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, f.intermediate, peer.PublicKey, f.intermediateKey)
+	f.renewedCerts = append(f.renewedCerts, cert)
+
+	f.writeChain(w, cert)
+}
+
+func (f *fakeStepCA) handleRoots(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, rootsResponse{
+		Certificates: []string{string(pemutil.EncodeCertificate(f.root))},
+	})
+}
+
+func (f *fakeStepCA) writeChain(w http.ResponseWriter, cert *x509.Certificate) {
+	certPEM := string(pemutil.EncodeCertificate(cert))
+	intermediatePEM := string(pemutil.EncodeCertificate(f.intermediate))
+	writeJSON(w, http.StatusCreated, signResponse{
+		Certificate: certPEM,
+		CA:          intermediatePEM,
+		CertChain:   []string{certPEM, intermediatePEM},
+	})
+}
+
+// issueX5CCertificate issues a provisioner certificate from the
+// intermediate, as done by `step ca certificate`.
+func (f *fakeStepCA) issueX5CCertificate(notBefore, notAfter time.Time) ([]*x509.Certificate, crypto.Signer) {
+	cert, key := testca.CreateX509Certificate(f.t, f.intermediate, f.intermediateKey, testca.WithLifetime(notBefore, notAfter))
+	return []*x509.Certificate{cert, f.intermediate}, key
+}
+
+func (f *fakeStepCA) getLastSign() (*signRequest, jose.Header, *tokenClaims) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.lastSignReq, f.lastHeader, f.lastClaims
+}
+
+func (f *fakeStepCA) getRenewedCerts() []*x509.Certificate {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.renewedCerts
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newSerial(t *testing.T) *big.Int {
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	return serial
+}
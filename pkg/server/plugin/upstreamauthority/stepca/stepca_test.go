@@ -0,0 +1,348 @@
+package stepca
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testkey"
+	"google.golang.org/grpc/codes"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+var (
+	ctx = context.Background()
+	td  = spiffeid.RequireTrustDomainFromString("example.org")
+)
+
+func TestStepCA(t *testing.T) {
+	spiretest.Run(t, new(StepCASuite))
+}
+
+type StepCASuite struct {
+	spiretest.Suite
+
+	clock  *clock.Mock
+	stepCA *fakeStepCA
+	csrKey *ecdsa.PrivateKey
+	jwkKey jose.JSONWebKey
+	dir    string
+
+	rawPlugin *Plugin
+	plugin    upstreamauthority.Plugin
+}
+
+func (s *StepCASuite) SetupSuite() {
+	s.stepCA = newFakeStepCA(s.T())
+	s.csrKey = testkey.NewEC256(s.T())
+	s.jwkKey = jose.JSONWebKey{
+		Key:       testkey.NewEC256(s.T()),
+		KeyID:     "jwk-key-id",
+		Algorithm: string(jose.ES256),
+	}
+	s.stepCA.jwkKey = s.jwkKey.Public()
+}
+
+func (s *StepCASuite) SetupTest() {
+	s.clock = clock.NewMock(s.T())
+	s.stepCA.reset(s.T(), s.clock)
+	s.dir = s.TempDir()
+
+	s.Require().NoError(pemutil.SaveCertificate(s.path("root.pem"), s.stepCA.root, 0600))
+
+	// Encrypt the JWK provisioner key with a password, as `step crypto jwk
+	// create` does
+	jwkJSON, err := s.jwkKey.MarshalJSON()
+	s.Require().NoError(err)
+	encrypter, err := jose.NewEncrypter(jose.A128GCM, jose.Recipient{
+		Algorithm:  jose.PBES2_HS256_A128KW,
+		Key:        []byte("secret"),
+		PBES2Count: 1000,
+	}, nil)
+	s.Require().NoError(err)
+	encrypted, err := encrypter.Encrypt(jwkJSON)
+	s.Require().NoError(err)
+	s.writeFile("jwk.json", encrypted.FullSerialize())
+	s.writeFile("password.txt", "secret\n")
+
+	p := New()
+	p.hooks.clock = s.clock
+	p.SetLogger(hclog.NewNullLogger())
+	s.rawPlugin = p
+	s.LoadPlugin(builtin(p), &s.plugin)
+}
+
+func (s *StepCASuite) TestConfigure() {
+	for _, tt := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "malformed",
+			config: "{{{",
+			err:    "failed to decode configuration",
+		},
+		{
+			name:   "missing CA URL",
+			config: `provisioner_name = "spire"`,
+			err:    "ca_url is required",
+		},
+		{
+			name:   "CA URL not https",
+			config: `ca_url = "http://ca.example.org"`,
+			err:    `ca_url "http://ca.example.org" must be an https URL`,
+		},
+		{
+			name:   "missing CA certificate",
+			config: `ca_url = "https://ca.example.org"`,
+			err:    "ca_cert_path is required",
+		},
+		{
+			name: "missing provisioner name",
+			config: fmt.Sprintf(`
+				ca_url = "https://ca.example.org"
+				ca_cert_path = %q`, s.path("root.pem")),
+			err: "provisioner_name is required",
+		},
+		{
+			name: "missing provisioner",
+			config: fmt.Sprintf(`
+				ca_url = "https://ca.example.org"
+				ca_cert_path = %q
+				provisioner_name = "spire"`, s.path("root.pem")),
+			err: "one of jwk_provisioner or x5c_provisioner is required",
+		},
+		{
+			name: "both provisioners",
+			config: fmt.Sprintf(`
+				ca_url = "https://ca.example.org"
+				ca_cert_path = %q
+				provisioner_name = "spire"
+				jwk_provisioner { key_path = "jwk.json" }
+				x5c_provisioner {
+					cert_path = "cert.pem"
+					key_path = "key.pem"
+				}`, s.path("root.pem")),
+			err: "only one of jwk_provisioner or x5c_provisioner can be configured",
+		},
+		{
+			name: "wrong JWK password",
+			config: fmt.Sprintf(`
+				ca_url = "https://ca.example.org"
+				ca_cert_path = %q
+				provisioner_name = "spire"
+				jwk_provisioner {
+					key_path = %q
+					password_path = %q
+				}`, s.path("root.pem"), s.path("jwk.json"), s.writeFile("wrong.txt", "wrong")),
+			err: "failed to decrypt JWK provisioner key",
+		},
+		{
+			name: "X5C key mismatch",
+			config: s.x5cConfig(func() {
+				_, otherKey := s.stepCA.issueX5CCertificate(s.clock.Now(), s.clock.Now().Add(time.Hour))
+				keyPEM, err := pemutil.EncodePKCS8PrivateKey(otherKey)
+				s.Require().NoError(err)
+				s.writeFile("x5c-key.pem", string(keyPEM))
+			}),
+			err: "X5C provisioner key does not match the certificate",
+		},
+	} {
+		tt := tt
+		s.Run(tt.name, func() {
+			_, err := s.plugin.Configure(ctx, &spi.ConfigureRequest{Configuration: tt.config})
+			s.RequireErrorContains(err, tt.err)
+		})
+	}
+}
+
+func (s *StepCASuite) TestMintX509CANotConfigured() {
+	_, err := s.mintX509CA(s.generateCSR(), 0)
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "step-ca: not configured")
+}
+
+func (s *StepCASuite) TestMintX509CAWithJWKProvisioner() {
+	s.configure(s.jwkConfig())
+
+	resp, err := s.mintX509CA(s.generateCSR(), 3600)
+	s.Require().NoError(err)
+	s.requireMintResponse(resp)
+
+	req, header, claims := s.stepCA.getLastSign()
+	s.Require().Equal("1h0m0s", req.NotAfter)
+	s.Require().Equal("jwk-key-id", header.KeyID)
+	s.requireClaims(claims)
+}
+
+func (s *StepCASuite) TestMintX509CAWithX5CProvisioner() {
+	s.configure(s.x5cConfig(nil))
+
+	resp, err := s.mintX509CA(s.generateCSR(), 0)
+	s.Require().NoError(err)
+	s.requireMintResponse(resp)
+
+	req, header, claims := s.stepCA.getLastSign()
+	s.Require().Empty(req.NotAfter)
+	s.Require().Empty(header.KeyID)
+	s.requireClaims(claims)
+}
+
+func (s *StepCASuite) TestMintX509CASignFails() {
+	s.configure(s.jwkConfig())
+	s.stepCA.setSignErr("token already used")
+
+	_, err := s.mintX509CA(s.generateCSR(), 0)
+	s.RequireGRPCStatus(err, codes.Internal, "step-ca: failed to sign CSR: request to /1.0/sign failed with status 401: token already used")
+}
+
+func (s *StepCASuite) TestX5CCertificateRenewal() {
+	s.configure(s.x5cConfig(nil))
+
+	// The certificate is renewed once two thirds of its lifetime of three
+	// hours have elapsed
+	s.clock.WaitForAfter(time.Minute, "renewal was not scheduled")
+	s.Require().Empty(s.stepCA.getRenewedCerts())
+	s.clock.Add(2 * time.Hour)
+	s.clock.WaitForAfter(time.Minute, "next renewal was not scheduled")
+
+	renewed := s.stepCA.getRenewedCerts()
+	s.Require().Len(renewed, 1)
+
+	// The renewed certificate is persisted and used in the tokens
+	chain, err := pemutil.LoadCertificates(s.path("x5c-cert.pem"))
+	s.Require().NoError(err)
+	s.Require().Equal(renewed[0].Raw, chain[0].Raw)
+
+	_, err = s.mintX509CA(s.generateCSR(), 0)
+	s.Require().NoError(err)
+	_, header, _ := s.stepCA.getLastSign()
+	verified, err := header.Certificates(x509.VerifyOptions{
+		Roots:       util.NewCertPool(s.stepCA.root),
+		CurrentTime: renewed[0].NotBefore,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(renewed[0].Raw, verified[0][0].Raw)
+}
+
+func (s *StepCASuite) TestPublishJWTKey() {
+	stream, err := s.plugin.PublishJWTKey(ctx, &upstreamauthority.PublishJWTKeyRequest{})
+	s.Require().NoError(err)
+	_, err = stream.Recv()
+	s.RequireGRPCStatus(err, codes.Unimplemented, "step-ca: publishing upstream is unsupported")
+}
+
+func (s *StepCASuite) configure(config string) {
+	_, err := s.plugin.Configure(ctx, &spi.ConfigureRequest{Configuration: config})
+	s.Require().NoError(err)
+}
+
+func (s *StepCASuite) jwkConfig() string {
+	return fmt.Sprintf(`
+		ca_url = %q
+		ca_cert_path = %q
+		provisioner_name = "spire"
+		jwk_provisioner {
+			key_path = %q
+			password_path = %q
+		}`, s.stepCA.URL(), s.path("root.pem"), s.path("jwk.json"), s.path("password.txt"))
+}
+
+// x5cConfig issues a provisioner certificate with a lifetime of three hours
+// and returns the configuration using it. The modify function, if set, can
+// replace the files before the configuration is returned.
+func (s *StepCASuite) x5cConfig(modify func()) string {
+	now := s.clock.Now()
+	chain, key := s.stepCA.issueX5CCertificate(now, now.Add(3*time.Hour))
+	keyPEM, err := pemutil.EncodePKCS8PrivateKey(key)
+	s.Require().NoError(err)
+	s.writeFile("x5c-cert.pem", string(pemutil.EncodeCertificates(chain)))
+	s.writeFile("x5c-key.pem", string(keyPEM))
+	if modify != nil {
+		modify()
+	}
+
+	return fmt.Sprintf(`
+		ca_url = %q
+		ca_cert_path = %q
+		provisioner_name = "spire"
+		x5c_provisioner {
+			cert_path = %q
+			key_path = %q
+		}`, s.stepCA.URL(), s.path("root.pem"), s.path("x5c-cert.pem"), s.path("x5c-key.pem"))
+}
+
+func (s *StepCASuite) mintX509CA(csr []byte, ttl int32) (*upstreamauthority.MintX509CAResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	stream, err := s.plugin.MintX509CA(ctx, &upstreamauthority.MintX509CARequest{
+		Csr:          csr,
+		PreferredTtl: ttl,
+	})
+	s.Require().NoError(err)
+
+	resp, err := stream.Recv()
+	if err == nil {
+		_, eofErr := stream.Recv()
+		s.Require().Equal(io.EOF, eofErr)
+	}
+	return resp, err
+}
+
+func (s *StepCASuite) requireMintResponse(resp *upstreamauthority.MintX509CAResponse) {
+	// The chain ends with the step-ca intermediate, signed by the root
+	s.Require().Len(resp.X509CaChain, 2)
+	cert, err := x509.ParseCertificate(resp.X509CaChain[0])
+	s.Require().NoError(err)
+	s.Require().Equal([]*url.URL{td.ID().URL()}, cert.URIs)
+	s.Require().Equal(s.stepCA.intermediate.Raw, resp.X509CaChain[1])
+	s.Require().Equal([][]byte{s.stepCA.root.Raw}, resp.UpstreamX509Roots)
+}
+
+func (s *StepCASuite) requireClaims(claims *tokenClaims) {
+	s.Require().Equal("spire", claims.Issuer)
+	s.Require().Equal(jwt.Audience{s.stepCA.URL() + "/1.0/sign"}, claims.Audience)
+	s.Require().Equal("SPIRE", claims.Subject)
+	s.Require().Equal([]string{"spiffe://example.org"}, claims.SANs)
+	s.Require().Equal(s.clock.Now().Add(5*time.Minute).Unix(), int64(*claims.Expiry))
+	s.Require().NotEmpty(claims.ID)
+}
+
+func (s *StepCASuite) generateCSR() []byte {
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "SPIRE"},
+		URIs:    []*url.URL{td.ID().URL()},
+	}, s.csrKey)
+	s.Require().NoError(err)
+	return csr
+}
+
+func (s *StepCASuite) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+func (s *StepCASuite) writeFile(name, content string) string {
+	path := s.path(name)
+	s.Require().NoError(ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
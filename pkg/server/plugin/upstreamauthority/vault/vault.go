@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
@@ -15,6 +16,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
@@ -35,6 +37,7 @@ func builtin(p *Plugin) catalog.Plugin {
 
 type PluginConfig struct {
 	// A URL of Vault server. (e.g., https://vault.example.com:8443/)
+	// Addresses of the form unix:///path/to/socket connect over a Unix domain socket.
 	VaultAddr string `hcl:"vault_addr"`
 	// Name of the mount point where PKI secret engine is mounted. (e.g., /<mount_point>/ca/pem)
 	PKIMountPoint string `hcl:"pki_mount_point"`
@@ -50,6 +53,19 @@ type PluginConfig struct {
 	// If true, vault client accepts any server certificates.
 	// It should be used only test environment so on.
 	InsecureSkipVerify bool `hcl:"insecure_skip_verify"`
+	// Server name used to verify the Vault server certificate, if different
+	// from the host of VaultAddr.
+	TLSServerName string `hcl:"tls_server_name"`
+	// Minimum TLS version used to connect to Vault ("1.2" or "1.3").
+	TLSMinVersion string `hcl:"tls_min_version"`
+	// Names of the TLS 1.2 cipher suites offered to Vault.
+	TLSCipherSuites []string `hcl:"tls_cipher_suites"`
+	// If true, the CA certificates of CACertPath are added to the system
+	// cert pool instead of replacing it.
+	UseSystemCertPool bool `hcl:"use_system_cert_pool"`
+	// URL of an HTTP CONNECT (http:// or https://) or SOCKS5 (socks5://)
+	// proxy to reach the Vault server through.
+	ProxyAddr string `hcl:"proxy_addr"`
 }
 
 // TokenAuth represents parameters for token auth method
@@ -111,9 +127,6 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi
 		return nil, fmt.Errorf("failed to decode configuration file: %v", err)
 	}
 
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
-
 	am, err := parseAuthMethod(config)
 	if err != nil {
 		return nil, err
@@ -124,26 +137,51 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi
 		return nil, err
 	}
 
+	p.mtx.RLock()
+	reconfigure := p.cc != nil
+	p.mtx.RUnlock()
+
+	// On reconfiguration the new client is authenticated and validated
+	// before it replaces the current one, so a bad configuration (e.g. an
+	// unreachable address or invalid credentials) leaves the plugin working
+	// with its previous configuration. Signing requests in flight keep using
+	// the client they started with.
+	var (
+		vc         *Client
+		reuseToken bool
+	)
+	if reconfigure {
+		vc, reuseToken, err = vcConfig.NewAuthenticatedClient(am)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate with the new configuration: %v", err)
+		}
+		// The token auth method already looked up the token
+		if am != TOKEN {
+			if _, err := vc.LookupSelf(""); err != nil {
+				return nil, fmt.Errorf("failed to validate the new configuration: %v", err)
+			}
+		}
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
 	p.authMethod = am
 	p.cc = vcConfig
+	p.vc = vc
+	p.reuseToken = reuseToken
+
+	if reconfigure {
+		p.logger.Info("Vault client reconfigured", "vault_addr", cp.VaultAddr)
+	}
 
 	return &spi.ConfigureResponse{}, nil
 }
 
 func (p *Plugin) MintX509CA(req *upstreamauthority.MintX509CARequest, stream upstreamauthority.UpstreamAuthority_MintX509CAServer) error {
-	if p.cc == nil {
-		return errors.New("plugin not configured")
-	}
-
-	// reuseToken=false means that the token cannot be renewed and may expire,
-	// authenticates to the Vault at each signing request.
-	if p.vc == nil || !p.reuseToken {
-		vc, reusable, err := p.cc.NewAuthenticatedClient(p.authMethod)
-		if err != nil {
-			return fmt.Errorf("failed to prepare authenticated client: %v", err)
-		}
-		p.vc = vc
-		p.reuseToken = reusable
+	vc, err := p.getClient()
+	if err != nil {
+		return err
 	}
 
 	var ttl string
@@ -158,7 +196,7 @@ func (p *Plugin) MintX509CA(req *upstreamauthority.MintX509CARequest, stream ups
 		return fmt.Errorf("failed to parse CSR data: %v", err)
 	}
 
-	signResp, err := p.vc.SignIntermediate(ttl, csr)
+	signResp, err := vc.SignIntermediate(ttl, csr)
 	if err != nil {
 		return fmt.Errorf("failed to request signing the intermediate certificate: %v", err)
 	}
@@ -201,6 +239,74 @@ func (*Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.G
 	return &spi.GetPluginInfoResponse{}, nil
 }
 
+// getClient returns the authenticated client signing requests are sent
+// with. A new client is authenticated if there is none yet or if its token
+// cannot be reused. The client is only stored if the plugin was not
+// reconfigured in the meantime.
+func (p *Plugin) getClient() (*Client, error) {
+	p.mtx.RLock()
+	cc, authMethod, vc, reuseToken := p.cc, p.authMethod, p.vc, p.reuseToken
+	p.mtx.RUnlock()
+
+	if cc == nil {
+		return nil, errors.New("plugin not configured")
+	}
+
+	// reuseToken=false means that the token cannot be renewed and may expire,
+	// authenticates to the Vault at each signing request.
+	if vc != nil && reuseToken {
+		return vc, nil
+	}
+
+	vc, reusable, err := cc.NewAuthenticatedClient(authMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare authenticated client: %v", err)
+	}
+
+	p.mtx.Lock()
+	if p.cc == cc {
+		p.vc = vc
+		p.reuseToken = reusable
+	}
+	p.mtx.Unlock()
+
+	return vc, nil
+}
+
+// HealthChecks returns the health checks contributed by the plugin.
+func (p *Plugin) HealthChecks() map[string]health.CheckFunc {
+	return map[string]health.CheckFunc{
+		"token_ttl": p.checkTokenTTL,
+	}
+}
+
+// checkTokenTTL reports the remaining TTL of the token the plugin signs
+// with. It fails if Vault no longer accepts the token, which happens when a
+// reusable token could not be renewed and expired.
+func (p *Plugin) checkTokenTTL() (interface{}, error) {
+	p.mtx.RLock()
+	vc, reuseToken := p.vc, p.reuseToken
+	p.mtx.RUnlock()
+
+	// Tokens that are not reusable are obtained on each signing request
+	if vc == nil || !reuseToken {
+		return map[string]interface{}{"authenticated": false}, nil
+	}
+
+	secret, err := vc.LookupSelf("")
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up the token: %v", err)
+	}
+	details := map[string]interface{}{
+		"authenticated": true,
+		"renewable":     secret.Auth.Renewable,
+	}
+	if secret.Auth.LeaseDuration > 0 {
+		details["ttl"] = (time.Duration(secret.Auth.LeaseDuration) * time.Second).String()
+	}
+	return details, nil
+}
+
 // PublishJWTKey is not implemented by the wrapper and returns a codes.Unimplemented status
 func (*Plugin) PublishJWTKey(*upstreamauthority.PublishJWTKeyRequest, upstreamauthority.UpstreamAuthority_PublishJWTKeyServer) error {
 	return makeError(codes.Unimplemented, "publishing upstream is unsupported")
@@ -244,10 +350,15 @@ func checkForAuthMethodConfigured(authMethod AuthMethod) error {
 
 func genClientParams(method AuthMethod, config *PluginConfig) *ClientParams {
 	cp := &ClientParams{
-		VaultAddr:     getEnvOrDefault(envVaultAddr, config.VaultAddr),
-		CACertPath:    getEnvOrDefault(envVaultCACert, config.CACertPath),
-		PKIMountPoint: config.PKIMountPoint,
-		TLSSKipVerify: config.InsecureSkipVerify,
+		VaultAddr:         getEnvOrDefault(envVaultAddr, config.VaultAddr),
+		CACertPath:        getEnvOrDefault(envVaultCACert, config.CACertPath),
+		PKIMountPoint:     config.PKIMountPoint,
+		TLSSKipVerify:     config.InsecureSkipVerify,
+		ProxyAddr:         config.ProxyAddr,
+		TLSServerName:     config.TLSServerName,
+		TLSMinVersion:     config.TLSMinVersion,
+		TLSCipherSuites:   config.TLSCipherSuites,
+		UseSystemCertPool: config.UseSystemCertPool,
 	}
 
 	switch method {
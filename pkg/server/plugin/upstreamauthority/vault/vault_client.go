@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/hashicorp/go-hclog"
@@ -25,6 +26,8 @@ const (
 	envVaultAppRoleID       = "VAULT_APPROLE_ID"
 	envVaultAppRoleSecretID = "VAULT_APPROLE_SECRET_ID" //// #nosec G101
 
+	unixSocketScheme = "unix://"
+
 	defaultCertMountPoint    = "cert"
 	defaultPKIMountPoint     = "pki"
 	defaultAppRoleMountPoint = "approle"
@@ -57,6 +60,7 @@ type ClientConfig struct {
 
 type ClientParams struct {
 	// A URL of Vault server. (e.g., https://vault.example.com:8443/)
+	// Addresses of the form unix:///path/to/socket connect over a Unix domain socket.
 	VaultAddr string
 	// Name of mount point where PKI secret engine is mounted. (e.e., /<mount_point>/ca/pem )
 	PKIMountPoint string
@@ -82,6 +86,22 @@ type ClientParams struct {
 	// If true, client accepts any certificates.
 	// It should be used only test environment so on.
 	TLSSKipVerify bool
+	// Server name used to verify the Vault server certificate and sent as
+	// SNI. If empty, the host of VaultAddr is used.
+	TLSServerName string
+	// Minimum TLS version ("1.2" or "1.3"). Defaults to TLS 1.2.
+	TLSMinVersion string
+	// Names of the TLS 1.2 cipher suites to offer (e.g.,
+	// TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256). Go defaults are used if
+	// empty.
+	TLSCipherSuites []string
+	// If true, the CA certificates of CACertPath are added to the system
+	// cert pool instead of replacing it.
+	UseSystemCertPool bool
+	// URL of a proxy to reach Vault through. Both HTTP CONNECT proxies
+	// (http:// or https://) and SOCKS5 proxies (socks5://) are supported.
+	// If empty, the proxy is taken from the environment (e.g. HTTPS_PROXY).
+	ProxyAddr string
 	// MaxRetries controls the number of times to retry to connect
 	// Set to 0 to disable retrying.
 	// If the value is nil, to use the default in hashicorp/vault/api.
@@ -116,6 +136,15 @@ func NewClientConfig(cp *ClientParams, logger hclog.Logger) (*ClientConfig, erro
 	if err := mergo.Merge(cp, defaultParams); err != nil {
 		return nil, err
 	}
+	if _, err := parseProxyAddr(cp); err != nil {
+		return nil, err
+	}
+	if _, err := parseTLSVersion(cp.TLSMinVersion); err != nil {
+		return nil, err
+	}
+	if _, err := parseCipherSuites(cp.TLSCipherSuites); err != nil {
+		return nil, err
+	}
 	cc.clientParams = cp
 	return cc, nil
 }
@@ -132,6 +161,9 @@ func (c *ClientConfig) NewAuthenticatedClient(method AuthMethod) (client *Client
 	if err := c.configureTLS(config); err != nil {
 		return nil, false, err
 	}
+	if err := c.configureProxy(config); err != nil {
+		return nil, false, err
+	}
 	vc, err := vapi.NewClient(config)
 	if err != nil {
 		return nil, false, err
@@ -258,6 +290,12 @@ func (c *ClientConfig) configureTLS(vc *vapi.Config) error {
 			return fmt.Errorf("failed to load CA certificate: %v", err)
 		}
 		pool := x509.NewCertPool()
+		if c.clientParams.UseSystemCertPool {
+			pool, err = x509.SystemCertPool()
+			if err != nil {
+				return fmt.Errorf("failed to load system cert pool: %v", err)
+			}
+		}
 		for _, cert := range certs {
 			pool.AddCert(cert)
 		}
@@ -268,6 +306,24 @@ func (c *ClientConfig) configureTLS(vc *vapi.Config) error {
 		clientTLSConfig.InsecureSkipVerify = true
 	}
 
+	if c.clientParams.TLSServerName != "" {
+		clientTLSConfig.ServerName = c.clientParams.TLSServerName
+	}
+
+	minVersion, err := parseTLSVersion(c.clientParams.TLSMinVersion)
+	if err != nil {
+		return err
+	}
+	if minVersion != 0 {
+		clientTLSConfig.MinVersion = minVersion
+	}
+
+	cipherSuites, err := parseCipherSuites(c.clientParams.TLSCipherSuites)
+	if err != nil {
+		return err
+	}
+	clientTLSConfig.CipherSuites = cipherSuites
+
 	if foundClientCert {
 		clientTLSConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
 			return &clientCert, nil
@@ -277,6 +333,87 @@ func (c *ClientConfig) configureTLS(vc *vapi.Config) error {
 	return nil
 }
 
+// parseTLSVersion parses a TLS version of the form "1.2". It returns zero if
+// the version is empty.
+func parseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "":
+		return 0, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q: must be one of 1.2 or 1.3", version)
+	}
+}
+
+// parseCipherSuites returns the IDs of the named cipher suites. Only the
+// secure cipher suites of crypto/tls are accepted.
+func parseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	supported := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		supported[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := supported[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// configureProxy configures the proxy the Vault client connects through.
+// Addresses of the form unix:///path/to/socket connect to Vault (or a local
+// Vault agent) over a Unix domain socket, which is handled by the Vault API
+// client itself.
+func (c *ClientConfig) configureProxy(vc *vapi.Config) error {
+	proxyURL, err := parseProxyAddr(c.clientParams)
+	if err != nil {
+		return err
+	}
+	if proxyURL == nil {
+		return nil
+	}
+
+	if vc.HttpClient == nil {
+		vc.HttpClient = vapi.DefaultConfig().HttpClient
+	}
+	vc.HttpClient.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
+	return nil
+}
+
+func parseProxyAddr(cp *ClientParams) (*url.URL, error) {
+	if cp.ProxyAddr == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(cp.VaultAddr, unixSocketScheme) {
+		return nil, errors.New("a proxy cannot be used to reach Vault over a unix socket")
+	}
+
+	u, err := url.Parse(cp.ProxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proxy address: %v", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q: must be one of http, https or socks5", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy address %q is missing a host", cp.ProxyAddr)
+	}
+	return u, nil
+}
+
 // SetToken wraps vapi.Client.SetToken()
 func (c *Client) SetToken(v string) {
 	c.vaultClient.SetToken(v)
@@ -319,7 +456,7 @@ func (c *Client) LookupSelf(token string) (*vapi.Secret, error) {
 	secret.Auth = &vapi.SecretAuth{
 		ClientToken:   id,
 		Renewable:     renewable,
-		LeaseDuration: int(ttl),
+		LeaseDuration: int(ttl.Seconds()),
 		// don't care any parameters
 	}
 	return secret, nil
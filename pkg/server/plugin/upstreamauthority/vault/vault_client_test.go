@@ -5,7 +5,10 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"github.com/spiffe/spire/pkg/common/pemutil"
@@ -357,6 +360,82 @@ func (vcs *VaultClientSuite) Test_ConfigureTLS_WithAppRoleAuth() {
 	vcs.Require().Equal(testPool, tcc.RootCAs)
 }
 
+func (vcs *VaultClientSuite) Test_ConfigureTLS_ConnectionParams() {
+	cp := &ClientParams{
+		VaultAddr:       "https://127.0.0.1:8200",
+		CACertPath:      testRootCert,
+		Token:           "test-token",
+		TLSServerName:   "vault.example.org",
+		TLSMinVersion:   "1.3",
+		TLSCipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256"},
+	}
+	cc, err := NewClientConfig(cp, hclog.Default())
+	vcs.Require().NoError(err)
+
+	vc := vapi.DefaultConfig()
+	err = cc.configureTLS(vc)
+	vcs.Require().NoError(err)
+
+	tcc := vc.HttpClient.Transport.(*http.Transport).TLSClientConfig
+	vcs.Require().Equal("vault.example.org", tcc.ServerName)
+	vcs.Require().Equal(uint16(tls.VersionTLS13), tcc.MinVersion)
+	vcs.Require().Equal([]uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+	}, tcc.CipherSuites)
+}
+
+func (vcs *VaultClientSuite) Test_ConfigureTLS_DefaultConnectionParams() {
+	cp := &ClientParams{
+		VaultAddr: "https://127.0.0.1:8200",
+		Token:     "test-token",
+	}
+	cc, err := NewClientConfig(cp, hclog.Default())
+	vcs.Require().NoError(err)
+
+	vc := vapi.DefaultConfig()
+	err = cc.configureTLS(vc)
+	vcs.Require().NoError(err)
+
+	tcc := vc.HttpClient.Transport.(*http.Transport).TLSClientConfig
+	vcs.Require().Empty(tcc.ServerName)
+	vcs.Require().Equal(uint16(tls.VersionTLS12), tcc.MinVersion)
+	vcs.Require().Nil(tcc.CipherSuites)
+	vcs.Require().Nil(tcc.RootCAs)
+}
+
+func (vcs *VaultClientSuite) Test_ConfigureTLS_UseSystemCertPool() {
+	cp := &ClientParams{
+		VaultAddr:         "https://127.0.0.1:8200",
+		CACertPath:        testRootCert,
+		Token:             "test-token",
+		UseSystemCertPool: true,
+	}
+	cc, err := NewClientConfig(cp, hclog.Default())
+	vcs.Require().NoError(err)
+
+	vc := vapi.DefaultConfig()
+	err = cc.configureTLS(vc)
+	vcs.Require().NoError(err)
+
+	expected, err := x509.SystemCertPool()
+	vcs.Require().NoError(err)
+	rootCert, err := pemutil.LoadCertificate(testRootCert)
+	vcs.Require().NoError(err)
+	expected.AddCert(rootCert)
+
+	tcc := vc.HttpClient.Transport.(*http.Transport).TLSClientConfig
+	vcs.Require().True(expected.Equal(tcc.RootCAs))
+}
+
+func (vcs *VaultClientSuite) Test_NewClientConfig_InvalidConnectionParams() {
+	_, err := NewClientConfig(&ClientParams{TLSMinVersion: "1.1"}, hclog.Default())
+	vcs.Require().EqualError(err, `unsupported TLS version "1.1": must be one of 1.2 or 1.3`)
+
+	_, err = NewClientConfig(&ClientParams{TLSCipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}, hclog.Default())
+	vcs.Require().EqualError(err, `unsupported TLS cipher suite "TLS_RSA_WITH_RC4_128_SHA"`)
+}
+
 func (vcs *VaultClientSuite) Test_ConfigureTLS_InvalidCACert() {
 	cp := &ClientParams{
 		VaultAddr:      "http://example.org:8200",
@@ -416,6 +495,94 @@ func (vcs *VaultClientSuite) Test_ConfigureTLS_Require_ClientCertAndKey() {
 	vcs.Require().EqualError(err, "both client cert and client key are required")
 }
 
+func (vcs *VaultClientSuite) Test_ConfigureProxy() {
+	for _, c := range []struct {
+		name      string
+		vaultAddr string
+		proxyAddr string
+		err       string
+	}{
+		{
+			name:      "HTTP CONNECT proxy",
+			vaultAddr: "https://example.org:8200",
+			proxyAddr: "http://proxy.example.org:3128",
+		},
+		{
+			name:      "SOCKS5 proxy",
+			vaultAddr: "https://example.org:8200",
+			proxyAddr: "socks5://127.0.0.1:1080",
+		},
+		{
+			name:      "Unsupported proxy scheme",
+			vaultAddr: "https://example.org:8200",
+			proxyAddr: "ftp://proxy.example.org",
+			err:       `unsupported proxy scheme "ftp": must be one of http, https or socks5`,
+		},
+		{
+			name:      "Proxy without host",
+			vaultAddr: "https://example.org:8200",
+			proxyAddr: "http://",
+			err:       `proxy address "http://" is missing a host`,
+		},
+		{
+			name:      "Proxy with unix socket",
+			vaultAddr: "unix:///tmp/vault.sock",
+			proxyAddr: "http://proxy.example.org:3128",
+			err:       "a proxy cannot be used to reach Vault over a unix socket",
+		},
+	} {
+		c := c
+		vcs.Run(c.name, func() {
+			cp := &ClientParams{
+				VaultAddr: c.vaultAddr,
+				ProxyAddr: c.proxyAddr,
+			}
+			cc, err := NewClientConfig(cp, hclog.Default())
+			if c.err != "" {
+				vcs.Require().EqualError(err, c.err)
+				return
+			}
+			vcs.Require().NoError(err)
+
+			vc := vapi.DefaultConfig()
+			err = cc.configureProxy(vc)
+			vcs.Require().NoError(err)
+
+			req, err := http.NewRequest(http.MethodGet, c.vaultAddr, nil)
+			vcs.Require().NoError(err)
+			proxyURL, err := vc.HttpClient.Transport.(*http.Transport).Proxy(req)
+			vcs.Require().NoError(err)
+			vcs.Require().Equal(c.proxyAddr, proxyURL.String())
+		})
+	}
+}
+
+func (vcs *VaultClientSuite) Test_NewAuthenticatedClient_UnixSocket() {
+	mux := http.NewServeMux()
+	mux.HandleFunc(defaultLookupSelfEndpoint, defaultReqHandler(200, []byte(testLookupSelfResponseNeverExpire)))
+
+	socketPath := filepath.Join(spiretest.TempDir(vcs.T()), "vault.sock")
+	l, err := net.Listen("unix", socketPath)
+	vcs.Require().NoError(err)
+
+	s := httptest.NewUnstartedServer(mux)
+	s.Listener = l
+	s.Start()
+	defer s.Close()
+
+	cp := &ClientParams{
+		VaultAddr: "unix://" + socketPath,
+		Token:     "test-token",
+	}
+	cc, err := NewClientConfig(cp, hclog.Default())
+	vcs.Require().NoError(err)
+
+	client, reusable, err := cc.NewAuthenticatedClient(TOKEN)
+	vcs.Require().NoError(err)
+	vcs.Require().True(reusable)
+	vcs.Require().Equal("test-token", client.vaultClient.Token())
+}
+
 func (vcs *VaultClientSuite) Test_SignIntermediate() {
 	vcs.fakeVaultServer.CertAuthResponseCode = 200
 	vcs.fakeVaultServer.CertAuthResponse = []byte(testCertAuthResponse)
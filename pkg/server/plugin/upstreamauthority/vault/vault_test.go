@@ -176,6 +176,57 @@ func (vps *VaultPluginSuite) Test_Configure_Error_InvalidConfig() {
 	vps.Require().Contains(err.Error(), "failed to decode configuration file")
 }
 
+func (vps *VaultPluginSuite) Test_Reconfigure() {
+	vps.fakeVaultServer.LookupSelfResponse = []byte(testLookupSelfResponse)
+	vps.fakeVaultServer.LookupSelfResponseCode = 200
+	vps.fakeVaultServer.SignIntermediateReqEndpoint = "/v1/test-pki/root/sign-intermediate"
+	vps.fakeVaultServer.SignIntermediateResponseCode = 200
+	vps.fakeVaultServer.SignIntermediateResponse = []byte(testSignIntermediateResponse)
+
+	s, addr, err := vps.fakeVaultServer.NewTLSServer()
+	vps.Require().NoError(err)
+	s.Start()
+	defer s.Close()
+
+	// The second server rejects the token
+	vps.fakeVaultServer.LookupSelfResponse = []byte(`{"errors":["permission denied"]}`)
+	vps.fakeVaultServer.LookupSelfResponseCode = 403
+	badServer, badAddr, err := vps.fakeVaultServer.NewTLSServer()
+	vps.Require().NoError(err)
+	badServer.Start()
+	defer badServer.Close()
+
+	p := vps.newPlugin()
+	vps.LoadPlugin(builtin(p), &vps.plugin)
+	ctx := context.Background()
+
+	// The initial configuration does not reach Vault
+	_, err = p.Configure(ctx, vps.getTestConfigureRequest(fmt.Sprintf("https://%v/", badAddr), testTokenAuthConfigTpl))
+	vps.Require().NoError(err)
+	vps.Require().Nil(p.vc)
+
+	// Reconfiguring validates the new configuration before using it
+	_, err = p.Configure(ctx, vps.getTestConfigureRequest(fmt.Sprintf("https://%v/", addr), testTokenAuthConfigTpl))
+	vps.Require().NoError(err)
+	vps.Require().NotNil(p.vc)
+	cc, vc := p.cc, p.vc
+
+	res, err := vps.mintX509CA(vps.loadMintX509CARequestFromTestFile())
+	vps.Require().NoError(err)
+	vps.Require().NotNil(res)
+
+	// A configuration failing validation is rolled back
+	_, err = p.Configure(ctx, vps.getTestConfigureRequest(fmt.Sprintf("https://%v/", badAddr), testTokenAuthConfigTpl))
+	vps.Require().Error(err)
+	vps.Require().Contains(err.Error(), "failed to authenticate with the new configuration")
+	vps.Require().Equal(cc, p.cc)
+	vps.Require().Equal(vc, p.vc)
+
+	res, err = vps.mintX509CA(vps.loadMintX509CARequestFromTestFile())
+	vps.Require().NoError(err)
+	vps.Require().NotNil(res)
+}
+
 func (vps *VaultPluginSuite) Test_MintX509CA() {
 	for _, c := range []struct {
 		name            string
@@ -407,6 +458,44 @@ func (vps *VaultPluginSuite) Test_MintX509CA_InvalidCSR() {
 	vps.Require().Contains(err.Error(), "failed to parse CSR data")
 }
 
+func (vps *VaultPluginSuite) Test_TokenTTLHealthCheck() {
+	vps.fakeVaultServer.LookupSelfResponse = []byte(testLookupSelfResponse)
+	vps.fakeVaultServer.LookupSelfResponseCode = 200
+
+	s, addr, err := vps.fakeVaultServer.NewTLSServer()
+	vps.Require().NoError(err)
+	s.Start()
+	defer s.Close()
+
+	p := vps.newPlugin()
+	check := p.HealthChecks()["token_ttl"]
+	vps.Require().NotNil(check)
+
+	// Not authenticated yet
+	details, err := check()
+	vps.Require().NoError(err)
+	vps.Require().Equal(map[string]interface{}{"authenticated": false}, details)
+
+	p.cc = vps.getFakeClientConfig(addr)
+	p.authMethod = TOKEN
+	_, err = p.getClient()
+	vps.Require().NoError(err)
+
+	details, err = check()
+	vps.Require().NoError(err)
+	vps.Require().Equal(map[string]interface{}{
+		"authenticated": true,
+		"renewable":     true,
+		"ttl":           "767h37m57s",
+	}, details)
+
+	// The token is no longer accepted
+	s.Close()
+	_, err = check()
+	vps.Require().Error(err)
+	vps.Require().Contains(err.Error(), "unable to look up the token")
+}
+
 func (vps *VaultPluginSuite) mintX509CA(req *upstreamauthority.MintX509CARequest) (*upstreamauthority.MintX509CAResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
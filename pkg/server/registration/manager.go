@@ -5,14 +5,22 @@ import (
 	"time"
 
 	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 const (
 	_pruningCandence = 5 * time.Minute
+
+	// pruningPageSize is the number of agents and entries listed at a time
+	// when looking for the ones to prune.
+	pruningPageSize = 500
 )
 
 // ManagerConfig is the config for the registration manager
@@ -23,6 +31,37 @@ type ManagerConfig struct {
 	Metrics telemetry.Metrics
 
 	Clock clock.Clock
+
+	// TrustDomain is the trust domain of the server. It is required when
+	// pruning orphaned entries, to recognize the entries parented to the
+	// server.
+	TrustDomain spiffeid.TrustDomain
+
+	// Pruning configures the optional pruning of stale agents and entries.
+	Pruning PruningConfig
+}
+
+// PruningConfig configures the pruning of the agents and registration entries
+// that are no longer of use.
+type PruningConfig struct {
+	// RetainExpiredEntries keeps the expired registration entries in the
+	// datastore instead of pruning them. SVIDs are not issued for expired
+	// entries either way.
+	RetainExpiredEntries bool
+
+	// ExpiredAgentsAfter, if non-zero, evicts the agents whose SVID has been
+	// expired for longer than this. Banned agents are never evicted, since
+	// evicting them would lift the ban.
+	ExpiredAgentsAfter time.Duration
+
+	// OrphanedEntries deletes the registration entries whose parent is
+	// neither the server, an attested agent, nor the SPIFFE ID of another
+	// entry.
+	OrphanedEntries bool
+
+	// DryRun logs the agents and entries that would be pruned, without
+	// pruning them.
+	DryRun bool
 }
 
 // Manager is the manager of registrations
@@ -38,9 +77,14 @@ func NewManager(c ManagerConfig) *Manager {
 		c.Clock = clock.New()
 	}
 
+	log := c.Log.WithField(telemetry.RetryInterval, _pruningCandence)
+	if c.Pruning.DryRun {
+		log = log.WithField(telemetry.DryRun, true)
+	}
+
 	return &Manager{
 		c:       c,
-		log:     c.Log.WithField(telemetry.RetryInterval, _pruningCandence),
+		log:     log,
 		metrics: c.Metrics,
 	}
 }
@@ -61,6 +105,12 @@ func (m *Manager) pruneEvery(ctx context.Context) error {
 			if err := m.prune(ctx); err != nil && ctx.Err() == nil {
 				m.log.WithError(err).Error("Failed pruning registration entries")
 			}
+			if err := m.pruneExpiredAgents(ctx); err != nil && ctx.Err() == nil {
+				m.log.WithError(err).Error("Failed pruning expired agents")
+			}
+			if err := m.pruneOrphanedEntries(ctx); err != nil && ctx.Err() == nil {
+				m.log.WithError(err).Error("Failed pruning orphaned registration entries")
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -68,6 +118,10 @@ func (m *Manager) pruneEvery(ctx context.Context) error {
 }
 
 func (m *Manager) prune(ctx context.Context) (err error) {
+	if m.c.Pruning.RetainExpiredEntries {
+		return nil
+	}
+
 	counter := telemetry_server.StartRegistrationManagerPruneEntryCall(m.c.Metrics)
 	defer counter.Done(&err)
 
@@ -76,3 +130,137 @@ func (m *Manager) prune(ctx context.Context) (err error) {
 	})
 	return err
 }
+
+// pruneExpiredAgents evicts the agents whose SVID has been expired for longer
+// than the configured threshold.
+func (m *Manager) pruneExpiredAgents(ctx context.Context) (err error) {
+	if m.c.Pruning.ExpiredAgentsAfter <= 0 {
+		return nil
+	}
+
+	counter := telemetry_server.StartRegistrationManagerPruneAgentsCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	// Collect the agents before deleting any, so deletions don't shift the
+	// pages being listed.
+	var expired []*common.AttestedNode
+	req := &datastore.ListAttestedNodesRequest{
+		ByExpiresBefore: &wrappers.Int64Value{
+			Value: m.c.Clock.Now().Add(-m.c.Pruning.ExpiredAgentsAfter).Unix(),
+		},
+		ByBanned: &wrappers.BoolValue{Value: false},
+		Pagination: &datastore.Pagination{
+			PageSize: pruningPageSize,
+		},
+	}
+	for {
+		resp, err := m.c.DataStore.ListAttestedNodes(ctx, req)
+		if err != nil {
+			return err
+		}
+		expired = append(expired, resp.Nodes...)
+		if len(resp.Nodes) == 0 || resp.Pagination == nil || resp.Pagination.Token == "" {
+			break
+		}
+		req.Pagination.Token = resp.Pagination.Token
+	}
+
+	for _, node := range expired {
+		log := m.log.WithFields(logrus.Fields{
+			telemetry.AgentID:    node.SpiffeId,
+			telemetry.Expiration: time.Unix(node.CertNotAfter, 0).UTC().Format(time.RFC3339),
+		})
+		if m.c.Pruning.DryRun {
+			log.Info("Expired agent would be pruned")
+			telemetry_server.IncrRegistrationManagerPrunedAgentsCounter(m.c.Metrics, true)
+			continue
+		}
+		if _, err := m.c.DataStore.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{
+			SpiffeId: node.SpiffeId,
+		}); err != nil {
+			return err
+		}
+		log.Info("Pruned expired agent")
+		telemetry_server.IncrRegistrationManagerPrunedAgentsCounter(m.c.Metrics, false)
+	}
+	return nil
+}
+
+// pruneOrphanedEntries deletes the registration entries whose parent does not
+// exist.
+func (m *Manager) pruneOrphanedEntries(ctx context.Context) (err error) {
+	if !m.c.Pruning.OrphanedEntries {
+		return nil
+	}
+
+	counter := telemetry_server.StartRegistrationManagerPruneOrphanedEntriesCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	parents := map[string]bool{
+		idutil.ServerID(m.c.TrustDomain.String()): true,
+	}
+
+	nodesReq := &datastore.ListAttestedNodesRequest{
+		Pagination: &datastore.Pagination{
+			PageSize: pruningPageSize,
+		},
+	}
+	for {
+		resp, err := m.c.DataStore.ListAttestedNodes(ctx, nodesReq)
+		if err != nil {
+			return err
+		}
+		for _, node := range resp.Nodes {
+			parents[node.SpiffeId] = true
+		}
+		if len(resp.Nodes) == 0 || resp.Pagination == nil || resp.Pagination.Token == "" {
+			break
+		}
+		nodesReq.Pagination.Token = resp.Pagination.Token
+	}
+
+	var entries []*common.RegistrationEntry
+	entriesReq := &datastore.ListRegistrationEntriesRequest{
+		Pagination: &datastore.Pagination{
+			PageSize: pruningPageSize,
+		},
+	}
+	for {
+		resp, err := m.c.DataStore.ListRegistrationEntries(ctx, entriesReq)
+		if err != nil {
+			return err
+		}
+		for _, entry := range resp.Entries {
+			parents[entry.SpiffeId] = true
+		}
+		entries = append(entries, resp.Entries...)
+		if len(resp.Entries) == 0 || resp.Pagination == nil || resp.Pagination.Token == "" {
+			break
+		}
+		entriesReq.Pagination.Token = resp.Pagination.Token
+	}
+
+	for _, entry := range entries {
+		if parents[entry.ParentId] {
+			continue
+		}
+		log := m.log.WithFields(logrus.Fields{
+			telemetry.RegistrationID: entry.EntryId,
+			telemetry.SPIFFEID:       entry.SpiffeId,
+			telemetry.ParentID:       entry.ParentId,
+		})
+		if m.c.Pruning.DryRun {
+			log.Info("Orphaned registration entry would be pruned")
+			telemetry_server.IncrRegistrationManagerPrunedOrphanedEntriesCounter(m.c.Metrics, true)
+			continue
+		}
+		if _, err := m.c.DataStore.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+			EntryId: entry.EntryId,
+		}); err != nil {
+			return err
+		}
+		log.Info("Pruned orphaned registration entry")
+		telemetry_server.IncrRegistrationManagerPrunedOrphanedEntriesCounter(m.c.Metrics, false)
+	}
+	return nil
+}
@@ -2,11 +2,15 @@ package registration
 
 import (
 	"context"
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
@@ -132,13 +136,206 @@ func (s *ManagerSuite) TestPruning() {
 	s.Empty(listResp.Entries)
 }
 
-func (s *ManagerSuite) setupAndRunManager() func() {
+func (s *ManagerSuite) TestRetainExpiredEntries() {
+	s.newManager(PruningConfig{RetainExpiredEntries: true})
+
+	entry := &common.RegistrationEntry{
+		ParentId:    "spiffe://example.org/spire/server",
+		SpiffeId:    "spiffe://example.org/expired",
+		Selectors:   []*common.Selector{{Type: "type", Value: "value"}},
+		EntryExpiry: s.clock.Now().Add(-time.Minute).Unix(),
+	}
+	createResp, err := s.ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.m.prune(context.Background()))
+	s.Equal([]string{createResp.Entry.EntryId}, s.listEntryIDs())
+	s.Empty(s.metrics.AllMetrics())
+}
+
+func (s *ManagerSuite) TestPruneExpiredAgents() {
+	s.newManager(PruningConfig{ExpiredAgentsAfter: time.Hour})
+
+	s.createAttestedNode("spiffe://example.org/spire/agent/valid", "1", s.clock.Now().Add(time.Hour))
+	s.createAttestedNode("spiffe://example.org/spire/agent/recently-expired", "2", s.clock.Now().Add(-time.Minute))
+	s.createAttestedNode("spiffe://example.org/spire/agent/expired", "3", s.clock.Now().Add(-2*time.Hour))
+	s.createAttestedNode("spiffe://example.org/spire/agent/banned", "", s.clock.Now().Add(-2*time.Hour))
+
+	s.Require().NoError(s.m.pruneExpiredAgents(context.Background()))
+	s.Equal([]string{
+		"spiffe://example.org/spire/agent/banned",
+		"spiffe://example.org/spire/agent/recently-expired",
+		"spiffe://example.org/spire/agent/valid",
+	}, s.listAttestedNodeIDs())
+	spiretest.AssertLogs(s.T(), s.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Pruned expired agent",
+			Data: logrus.Fields{
+				telemetry.RetryInterval: _pruningCandence.String(),
+				telemetry.AgentID:       "spiffe://example.org/spire/agent/expired",
+				telemetry.Expiration:    s.clock.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	s.Equal(float32(1), s.prunedCount([]string{telemetry.Node, telemetry.Manager, telemetry.Pruned}, "false"))
+}
+
+func (s *ManagerSuite) TestPruneExpiredAgentsDryRun() {
+	s.newManager(PruningConfig{ExpiredAgentsAfter: time.Hour, DryRun: true})
+
+	s.createAttestedNode("spiffe://example.org/spire/agent/expired", "1", s.clock.Now().Add(-2*time.Hour))
+
+	s.Require().NoError(s.m.pruneExpiredAgents(context.Background()))
+	s.Equal([]string{"spiffe://example.org/spire/agent/expired"}, s.listAttestedNodeIDs())
+	spiretest.AssertLogs(s.T(), s.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Expired agent would be pruned",
+			Data: logrus.Fields{
+				telemetry.RetryInterval: _pruningCandence.String(),
+				telemetry.DryRun:        "true",
+				telemetry.AgentID:       "spiffe://example.org/spire/agent/expired",
+				telemetry.Expiration:    s.clock.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	s.Equal(float32(1), s.prunedCount([]string{telemetry.Node, telemetry.Manager, telemetry.Pruned}, "true"))
+}
+
+func (s *ManagerSuite) TestPruneExpiredAgentsDisabled() {
+	s.newManager(PruningConfig{})
+
+	s.createAttestedNode("spiffe://example.org/spire/agent/expired", "1", s.clock.Now().Add(-2*time.Hour))
+
+	s.Require().NoError(s.m.pruneExpiredAgents(context.Background()))
+	s.Equal([]string{"spiffe://example.org/spire/agent/expired"}, s.listAttestedNodeIDs())
+	s.Empty(s.metrics.AllMetrics())
+}
+
+func (s *ManagerSuite) TestPruneOrphanedEntries() {
+	s.newManager(PruningConfig{OrphanedEntries: true})
+
+	s.createAttestedNode("spiffe://example.org/spire/agent/agent", "1", s.clock.Now().Add(time.Hour))
+	alias := s.createEntry("spiffe://example.org/spire/server", "spiffe://example.org/alias")
+	aliasChild := s.createEntry("spiffe://example.org/alias", "spiffe://example.org/alias-workload")
+	agentChild := s.createEntry("spiffe://example.org/spire/agent/agent", "spiffe://example.org/workload")
+	orphan := s.createEntry("spiffe://example.org/spire/agent/evicted", "spiffe://example.org/orphan")
+
+	s.Require().NoError(s.m.pruneOrphanedEntries(context.Background()))
+	s.Equal([]string{alias.EntryId, aliasChild.EntryId, agentChild.EntryId}, s.listEntryIDs())
+	spiretest.AssertLogs(s.T(), s.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Pruned orphaned registration entry",
+			Data: logrus.Fields{
+				telemetry.RetryInterval:  _pruningCandence.String(),
+				telemetry.RegistrationID: orphan.EntryId,
+				telemetry.SPIFFEID:       "spiffe://example.org/orphan",
+				telemetry.ParentID:       "spiffe://example.org/spire/agent/evicted",
+			},
+		},
+	})
+	s.Equal(float32(1), s.prunedCount([]string{telemetry.RegistrationEntry, telemetry.Manager, telemetry.Orphaned, telemetry.Pruned}, "false"))
+}
+
+func (s *ManagerSuite) TestPruneOrphanedEntriesDryRun() {
+	s.newManager(PruningConfig{OrphanedEntries: true, DryRun: true})
+
+	orphan := s.createEntry("spiffe://example.org/spire/agent/evicted", "spiffe://example.org/orphan")
+
+	s.Require().NoError(s.m.pruneOrphanedEntries(context.Background()))
+	s.Equal([]string{orphan.EntryId}, s.listEntryIDs())
+	spiretest.AssertLogs(s.T(), s.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Orphaned registration entry would be pruned",
+			Data: logrus.Fields{
+				telemetry.RetryInterval:  _pruningCandence.String(),
+				telemetry.DryRun:         "true",
+				telemetry.RegistrationID: orphan.EntryId,
+				telemetry.SPIFFEID:       "spiffe://example.org/orphan",
+				telemetry.ParentID:       "spiffe://example.org/spire/agent/evicted",
+			},
+		},
+	})
+	s.Equal(float32(1), s.prunedCount([]string{telemetry.RegistrationEntry, telemetry.Manager, telemetry.Orphaned, telemetry.Pruned}, "true"))
+}
+
+func (s *ManagerSuite) newManager(pruning PruningConfig) {
 	s.m = NewManager(ManagerConfig{
-		Clock:     s.clock,
-		DataStore: s.ds,
-		Log:       s.log,
-		Metrics:   s.metrics,
+		Clock:       s.clock,
+		DataStore:   s.ds,
+		Log:         s.log,
+		Metrics:     s.metrics,
+		TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+		Pruning:     pruning,
 	})
+}
+
+func (s *ManagerSuite) createAttestedNode(spiffeID, serial string, notAfter time.Time) {
+	_, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            spiffeID,
+			AttestationDataType: "test",
+			CertSerialNumber:    serial,
+			CertNotAfter:        notAfter.Unix(),
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ManagerSuite) createEntry(parentID, spiffeID string) *common.RegistrationEntry {
+	resp, err := s.ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:  parentID,
+			SpiffeId:  spiffeID,
+			Selectors: []*common.Selector{{Type: "type", Value: "value"}},
+		},
+	})
+	s.Require().NoError(err)
+	return resp.Entry
+}
+
+func (s *ManagerSuite) listAttestedNodeIDs() []string {
+	resp, err := s.ds.ListAttestedNodes(context.Background(), &datastore.ListAttestedNodesRequest{})
+	s.Require().NoError(err)
+	var ids []string
+	for _, node := range resp.Nodes {
+		ids = append(ids, node.SpiffeId)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (s *ManagerSuite) listEntryIDs() []string {
+	resp, err := s.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	var ids []string
+	for _, entry := range resp.Entries {
+		ids = append(ids, entry.EntryId)
+	}
+	return ids
+}
+
+// prunedCount returns the number of pruned items counted by the metric with
+// the given key and dry run label
+func (s *ManagerSuite) prunedCount(key []string, dryRun string) float32 {
+	var count float32
+	for _, metric := range s.metrics.AllMetrics() {
+		if metric.Type != fakemetrics.IncrCounterWithLabelsType || !reflect.DeepEqual(key, metric.Key) {
+			continue
+		}
+		s.Equal([]telemetry.Label{{Name: telemetry.DryRun, Value: dryRun}}, metric.Labels)
+		count += metric.Val
+	}
+	return count
+}
+
+func (s *ManagerSuite) setupAndRunManager() func() {
+	s.newManager(PruningConfig{})
 
 	ctx, cancel := context.WithCancel(context.Background())
 	errCh := make(chan error, 1)
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" //nolint: gosec // import registers routes on DefaultServeMux
@@ -13,6 +14,7 @@ import (
 
 	"github.com/andres-erbsen/clock"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
@@ -24,10 +26,13 @@ import (
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/endpoints"
+	"github.com/spiffe/spire/pkg/server/entryevents"
 	"github.com/spiffe/spire/pkg/server/hostservices/agentstore"
 	"github.com/spiffe/spire/pkg/server/hostservices/identityprovider"
+	"github.com/spiffe/spire/pkg/server/noderesolution"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/server/registration"
 	"github.com/spiffe/spire/pkg/server/svid"
 	"google.golang.org/grpc"
@@ -47,6 +52,70 @@ const (
 
 type Server struct {
 	config Config
+
+	// rateLimiters holds the rate limiters of the server APIs, which can be
+	// adjusted while the server runs.
+	rateLimiters *endpoints.RateLimiters
+
+	// drainer drains the agent connections of the server, either when asked
+	// through the debug API or before shutting down.
+	drainer *endpoints.Drainer
+
+	// mtx protects the subsystems that can be reconfigured while the server
+	// runs. They are set once the server has started them.
+	mtx           sync.Mutex
+	metrics       *telemetry.MetricsImpl
+	bundleManager *bundle_client.Manager
+}
+
+// SetRateLimits adjusts the rate limits of the server APIs without
+// restarting the server.
+func (s *Server) SetRateLimits(config endpoints.RateLimitConfig) {
+	s.rateLimiters.SetConfig(config)
+	s.config.Log.Info("Rate limits updated")
+}
+
+// SetTelemetry replaces the metrics sinks of the server without restarting
+// it.
+func (s *Server) SetTelemetry(config telemetry.FileConfig) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.metrics == nil {
+		return errors.New("telemetry has not been started")
+	}
+	if err := s.metrics.Reload(config); err != nil {
+		return err
+	}
+	s.config.Log.Info("Telemetry updated")
+	return nil
+}
+
+// SetFederatesWith replaces the statically configured trust domains the
+// server federates with, without restarting it.
+func (s *Server) SetFederatesWith(federatesWith map[string]bundle_client.TrustDomainConfig) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.bundleManager == nil {
+		return errors.New("federation has not been started")
+	}
+	s.bundleManager.SetTrustDomains(federatesWith)
+	s.config.Log.Info("Federation relationships updated")
+	return nil
+}
+
+// Drain stops the server from accepting new agent connections, tells the
+// connected agents to reconnect elsewhere and lets in-flight RPCs finish.
+// The local APIs keep being served until the server is shut down.
+func (s *Server) Drain() {
+	s.drainer.Drain()
+}
+
+// Drained returns a channel that is closed once the agent connections have
+// been drained.
+func (s *Server) Drained() <-chan struct{} {
+	return s.drainer.Drained()
 }
 
 // Run the server
@@ -67,6 +136,10 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return err
 	}
 
+	if flags := fflag.Enabled(); len(flags) > 0 {
+		s.config.Log.WithField("feature_flags", flags).Warn("Experimental feature flags are enabled")
+	}
+
 	if s.config.ProfilingEnabled {
 		stopProfiling := s.setupProfiling(ctx)
 		defer stopProfiling()
@@ -80,6 +153,10 @@ func (s *Server) run(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	s.mtx.Lock()
+	s.metrics = metrics
+	s.mtx.Unlock()
+
 	metricsService := metricsservice.New(metricsservice.Config{
 		Metrics: metrics,
 	})
@@ -99,14 +176,18 @@ func (s *Server) run(ctx context.Context) (err error) {
 	// until the call to SetDeps() below.
 	agentStore := agentstore.New()
 
-	cat, err := s.loadCatalog(ctx, metrics, identityProvider, agentStore, metricsService)
+	// Registration entry changes made through the datastore are published
+	// here so interested subsystems can react without polling.
+	entryEvents := entryevents.NewBroker()
+
+	healthChecks := health.NewChecker(s.config.HealthChecks, s.config.Log)
+
+	cat, err := s.loadCatalog(ctx, metrics, identityProvider, agentStore, metricsService, entryEvents, healthChecks)
 	if err != nil {
 		return err
 	}
 	defer cat.Close()
 
-	healthChecks := health.NewChecker(s.config.HealthChecks, s.config.Log)
-
 	s.config.Log.Info("Plugins started")
 
 	err = s.validateTrustDomain(ctx, cat.GetDataStore())
@@ -128,7 +209,7 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	endpointsServer, err := s.newEndpointsServer(ctx, cat, svidRotator, serverCA, metrics, caManager)
+	endpointsServer, err := s.newEndpointsServer(ctx, cat, svidRotator, serverCA, metrics, caManager, entryEvents)
 	if err != nil {
 		return err
 	}
@@ -156,13 +237,29 @@ func (s *Server) run(ctx context.Context) (err error) {
 	}
 
 	bundleManager := s.newBundleManager(cat, metrics)
+	s.mtx.Lock()
+	s.bundleManager = bundleManager
+	s.mtx.Unlock()
 
 	registrationManager := s.newRegistrationManager(cat, metrics)
 
+	nodeResolutionManager := s.newNodeResolutionManager(cat)
+
 	if err := healthChecks.AddCheck("server", s, time.Minute); err != nil {
 		return fmt.Errorf("failed adding healthcheck: %v", err)
 	}
 
+	dependencies := &dependencyChecks{
+		trustDomainID: s.config.TrustDomain.String(),
+		dataStore:     cat.GetDataStore(),
+		ca:            serverCA,
+		upstream:      caManager,
+		clk:           clock.New(),
+	}
+	if err := dependencies.addTo(healthChecks, dependencyCheckInterval); err != nil {
+		return fmt.Errorf("failed adding healthcheck: %v", err)
+	}
+
 	err = util.RunTasks(ctx,
 		caManager.Run,
 		svidRotator.Run,
@@ -170,6 +267,7 @@ func (s *Server) run(ctx context.Context) (err error) {
 		metrics.ListenAndServe,
 		bundleManager.Run,
 		registrationManager.Run,
+		nodeResolutionManager.Run,
 		healthChecks.ListenAndServe,
 	)
 	if err == context.Canceled {
@@ -235,17 +333,20 @@ func (s *Server) setupProfiling(ctx context.Context) (stop func()) {
 }
 
 func (s *Server) loadCatalog(ctx context.Context, metrics telemetry.Metrics, identityProvider hostservices.IdentityProvider, agentStore hostservices.AgentStore,
-	metricsService common_services.MetricsService) (*catalog.Repository, error) {
+	metricsService common_services.MetricsService, entryEvents *entryevents.Broker, healthChecks *health.Checker) (*catalog.Repository, error) {
 	return catalog.Load(ctx, catalog.Config{
 		Log: s.config.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
 		GlobalConfig: catalog.GlobalConfig{
-			TrustDomain: s.config.TrustDomain.Host,
+			TrustDomain:        s.config.TrustDomain.Host,
+			ClockSkewTolerance: int64(s.config.ClockSkewTolerance / time.Second),
 		},
 		PluginConfig:     s.config.PluginConfigs,
 		Metrics:          metrics,
 		IdentityProvider: identityProvider,
 		AgentStore:       agentStore,
 		MetricsService:   metricsService,
+		EntryEvents:      entryEvents,
+		HealthChecks:     healthChecks,
 	})
 }
 
@@ -257,10 +358,22 @@ func (s *Server) newCA(metrics telemetry.Metrics) *ca.CA {
 		JWTIssuer:   s.config.JWTIssuer,
 		TrustDomain: s.config.TrustDomain,
 		CASubject:   s.config.CASubject,
+
+		JWTSigningAlgorithm: s.config.JWTSigningAlgorithm,
+		CAMaxPathLen:        s.config.CAMaxPathLen,
+		DNSNamePolicy:       s.config.DNSNamePolicy,
+
+		IssuanceMetricsPrefixes: s.config.IssuanceMetricsPrefixes,
+		Backdate:                s.config.SVIDBackdate,
 	})
 }
 
 func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, serverCA *ca.CA) (*ca.Manager, error) {
+	jwtKeyType := s.config.JWTKeyType
+	if jwtKeyType == keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+		jwtKeyType = s.config.CAKeyType
+	}
+
 	caManager := ca.NewManager(ca.ManagerConfig{
 		CA:            serverCA,
 		Catalog:       cat,
@@ -271,7 +384,15 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 		CASubject:     s.config.CASubject,
 		Dir:           s.config.DataDir,
 		X509CAKeyType: s.config.CAKeyType,
-		JWTKeyType:    s.config.CAKeyType,
+		JWTKeyType:    jwtKeyType,
+
+		FullUpstreamChain: s.config.CAFullUpstreamChain,
+		PrepareThreshold:  s.config.CAPrepareThreshold,
+		ActivateThreshold: s.config.CAActivateThreshold,
+
+		NotifierReconcileInterval: s.config.NotifierReconcileInterval,
+		TrustDomainAliases:        s.config.TrustDomainAliases,
+		Backdate:                  s.config.SVIDBackdate,
 	})
 	if err := caManager.Initialize(ctx); err != nil {
 		return nil, err
@@ -281,19 +402,31 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 
 func (s *Server) newRegistrationManager(cat catalog.Catalog, metrics telemetry.Metrics) *registration.Manager {
 	registrationManager := registration.NewManager(registration.ManagerConfig{
-		DataStore: cat.GetDataStore(),
-		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
-		Metrics:   metrics,
+		DataStore:   cat.GetDataStore(),
+		Log:         s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
+		Metrics:     metrics,
+		TrustDomain: spiffeid.RequireTrustDomainFromURI(&s.config.TrustDomain),
+		Pruning:     s.config.Pruning,
 	})
 	return registrationManager
 }
 
+func (s *Server) newNodeResolutionManager(cat catalog.Catalog) *noderesolution.Manager {
+	return noderesolution.NewManager(noderesolution.ManagerConfig{
+		Catalog:         cat,
+		Log:             s.config.Log.WithField(telemetry.SubsystemName, telemetry.NodeResolutionManager),
+		RefreshInterval: s.config.NodeResolverRefreshInterval,
+	})
+}
+
 func (s *Server) newSVIDRotator(ctx context.Context, serverCA ca.ServerCA, metrics telemetry.Metrics) (*svid.Rotator, error) {
 	svidRotator := svid.NewRotator(&svid.RotatorConfig{
 		ServerCA:    serverCA,
 		Log:         s.config.Log.WithField(telemetry.SubsystemName, telemetry.SVIDRotator),
 		Metrics:     metrics,
 		TrustDomain: s.config.TrustDomain,
+
+		RotationThreshold: s.config.SVIDRotation,
 	})
 	if err := svidRotator.Initialize(ctx); err != nil {
 		return nil, err
@@ -301,19 +434,36 @@ func (s *Server) newSVIDRotator(ctx context.Context, serverCA ca.ServerCA, metri
 	return svidRotator, nil
 }
 
-func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, metrics telemetry.Metrics, caManager *ca.Manager) (endpoints.Server, error) {
+func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, metrics telemetry.Metrics, caManager *ca.Manager, entryEvents *entryevents.Broker) (endpoints.Server, error) {
 	config := endpoints.Config{
 		TCPAddr:                     s.config.BindAddress,
 		UDSAddr:                     s.config.BindUDSAddress,
+		AdminAPI:                    s.config.AdminAPI,
 		SVIDObserver:                svidObserver,
 		TrustDomain:                 spiffeid.RequireTrustDomainFromURI(&s.config.TrustDomain),
 		Catalog:                     catalog,
 		ServerCA:                    serverCA,
 		Log:                         s.config.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
+		AuditLog:                    s.config.AuditLog,
 		Metrics:                     metrics,
 		Manager:                     caManager,
 		AllowAgentlessNodeAttestors: s.config.Experimental.AllowAgentlessNodeAttestors,
 		RateLimit:                   s.config.RateLimit,
+		RateLimiters:                s.rateLimiters,
+		RoleBindings:                s.config.RoleBindings,
+		EntryDefaults:               s.config.EntryDefaults,
+		EntryPolicy:                 s.config.EntryPolicy,
+		DNSNamePolicy:               s.config.DNSNamePolicy,
+		EntryEvents:                 entryEvents,
+		CacheReloadInterval:         s.config.Experimental.CacheReloadInterval,
+		Drainer:                     s.drainer,
+		DrainTimeout:                s.config.DrainTimeout,
+		GRPCHealth:                  s.config.GRPCHealth,
+		GRPCReflection:              s.config.GRPCReflection,
+		LogLevels:                   s.config.LogLevels,
+		ClockSkewTolerance:          s.config.ClockSkewTolerance,
+		GRPCTuning:                  s.config.GRPCTuning,
+		NodeAttestationLimits:       s.config.NodeAttestationLimits,
 		Uptime:                      uptime.Uptime,
 		Clock:                       clock.New(),
 	}
@@ -321,6 +471,9 @@ func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog
 		config.BundleEndpoint.Address = s.config.Federation.BundleEndpoint.Address
 		config.BundleEndpoint.ACME = s.config.Federation.BundleEndpoint.ACME
 	}
+	if s.config.OIDCDiscovery != nil {
+		config.OIDCDiscovery = *s.config.OIDCDiscovery
+	}
 	return endpoints.New(ctx, config)
 }
 
@@ -383,7 +536,12 @@ func (s *Server) validateTrustDomain(ctx context.Context, ds datastore.DataStore
 	return nil
 }
 
-// Status is used as a top-level health check for the Server.
+// Status is used as a top-level health check for the Server. The details
+// report the enabled feature flags. The server is reported unhealthy while
+// draining so that load balancers stop sending it new agents.
 func (s *Server) Status() (interface{}, error) {
-	return nil, nil
+	if s.drainer.IsDraining() {
+		return fflag.GetStatus(), errors.New("server is draining")
+	}
+	return fflag.GetStatus(), nil
 }
@@ -74,7 +74,7 @@ func (r *Rotator) shouldRotate() bool {
 		return true
 	}
 
-	return r.c.Clock.Now().After(certHalfLife(s.SVID[0]))
+	return r.c.RotationThreshold.ShouldRotateX509(r.c.Clock.Now(), s.SVID[0])
 }
 
 // rotateSVID cuts a new server SVID from the CA plugin and installs
@@ -104,7 +104,3 @@ func (r *Rotator) rotateSVID(ctx context.Context) (err error) {
 
 	return nil
 }
-
-func certHalfLife(cert *x509.Certificate) time.Time {
-	return cert.NotBefore.Add(cert.NotAfter.Sub(cert.NotBefore) / 2)
-}
@@ -7,6 +7,7 @@ import (
 	"github.com/andres-erbsen/clock"
 	"github.com/imkira/go-observer"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/ca"
 )
@@ -24,6 +25,10 @@ type RotatorConfig struct {
 
 	// How long to wait between expiry checks
 	Interval time.Duration
+
+	// RotationThreshold determines how long before expiration the SVID is
+	// rotated
+	RotationThreshold rotationutil.Threshold
 }
 
 func NewRotator(c *RotatorConfig) *Rotator {
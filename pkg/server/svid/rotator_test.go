@@ -12,6 +12,7 @@ import (
 	observer "github.com/imkira/go-observer"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakeserverca"
@@ -106,6 +107,42 @@ func (s *RotatorTestSuite) TestRotationSucceeds() {
 	s.Require().NoError(<-errCh)
 }
 
+func (s *RotatorTestSuite) TestRotationThreshold() {
+	s.r.c.RotationThreshold = rotationutil.Threshold{Before: 2 * time.Minute}
+	stream := s.r.Subscribe()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Require().NoError(s.r.Initialize(ctx))
+	cert := s.requireNewCert(stream, big.NewInt(-1))
+
+	wg.Add(1)
+	errCh := make(chan error, 1)
+	go func() {
+		defer wg.Done()
+		errCh <- s.r.Run(ctx)
+	}()
+
+	s.clock.WaitForTicker(time.Minute, "waiting for the Run() ticker")
+
+	// half of the lifetime is left, so it shouldn't rotate yet
+	s.clock.Set(certHalfLife(cert))
+	s.clock.Add(DefaultRotatorInterval)
+	s.requireStateChangeTimeout(stream)
+
+	// two minutes before expiration it rotates
+	s.clock.Set(cert.NotAfter.Add(-2*time.Minute - DefaultRotatorInterval))
+	s.clock.Add(DefaultRotatorInterval)
+	s.requireNewCert(stream, cert.SerialNumber)
+
+	cancel()
+	s.Require().NoError(<-errCh)
+}
+
 func (s *RotatorTestSuite) TestRotationFails() {
 	var wg sync.WaitGroup
 	defer wg.Wait()
@@ -173,3 +210,7 @@ func (s *RotatorTestSuite) requireStateChangeTimeout(stream observer.Stream) {
 	case <-timer.C:
 	}
 }
+
+func certHalfLife(cert *x509.Certificate) time.Time {
+	return cert.NotBefore.Add(cert.NotAfter.Sub(cert.NotBefore) / 2)
+}
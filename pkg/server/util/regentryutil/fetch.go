@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
@@ -44,7 +45,19 @@ func (f *registrationEntryFetcher) Fetch(ctx context.Context, id string) ([]*com
 	if err != nil {
 		return nil, err
 	}
-	return util.DedupRegistrationEntries(entries), nil
+	return dropExpiredEntries(util.DedupRegistrationEntries(entries), time.Now()), nil
+}
+
+// dropExpiredEntries filters out the expired entries, which remain in the
+// datastore until they are pruned.
+func dropExpiredEntries(entries []*common.RegistrationEntry, now time.Time) []*common.RegistrationEntry {
+	unexpired := entries[:0]
+	for _, entry := range entries {
+		if entry.EntryExpiry == 0 || now.Before(time.Unix(entry.EntryExpiry, 0)) {
+			unexpired = append(unexpired, entry)
+		}
+	}
+	return unexpired
 }
 
 func (f *registrationEntryFetcher) fetch(ctx context.Context, id string, visited map[string]bool, shouldCache bool) ([]*common.RegistrationEntry, error) {
@@ -145,7 +158,15 @@ func (f *registrationEntryFetcher) mappedEntries(ctx context.Context, clientID s
 		return nil, err
 	}
 
-	return listResp.Entries, nil
+	// node aliases with a selector expression only map the agents whose
+	// selectors satisfy it
+	entries := listResp.Entries[:0]
+	for _, entry := range listResp.Entries {
+		if selector.MatchesExpression(entry.SelectorExpression, selectors) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
 }
 
 type nullCache struct {
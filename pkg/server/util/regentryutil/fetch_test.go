@@ -3,6 +3,7 @@ package regentryutil
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/spiffe/spire/pkg/server/cache/entrycache"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
@@ -105,3 +106,76 @@ func TestFetchRegistrationEntries(t *testing.T) {
 
 	assert.Equal(expected, actual)
 }
+
+func TestFetchRegistrationEntriesDropsExpiredEntries(t *testing.T) {
+	dataStore := fakedatastore.New(t)
+
+	createRegistrationEntry := func(entry *common.RegistrationEntry) *common.RegistrationEntry {
+		resp, err := dataStore.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+			Entry: entry,
+		})
+		require.NoError(t, err)
+		return resp.Entry
+	}
+
+	rootID := "spiffe://example.org/root"
+	unexpiredEntry := createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:    rootID,
+		SpiffeId:    "spiffe://example.org/unexpired",
+		Selectors:   []*common.Selector{{Type: "not", Value: "relevant"}},
+		EntryExpiry: time.Now().Add(time.Hour).Unix(),
+	})
+	createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:    rootID,
+		SpiffeId:    "spiffe://example.org/expired",
+		Selectors:   []*common.Selector{{Type: "not", Value: "relevant"}},
+		EntryExpiry: time.Now().Add(-time.Hour).Unix(),
+	})
+
+	actual, err := FetchRegistrationEntries(ctx, dataStore, rootID)
+	require.NoError(t, err)
+	assert.Equal(t, []*common.RegistrationEntry{unexpiredEntry}, actual)
+}
+
+func TestFetchRegistrationEntriesHonorsNodeAliasSelectorExpression(t *testing.T) {
+	dataStore := fakedatastore.New(t)
+
+	createRegistrationEntry := func(entry *common.RegistrationEntry) *common.RegistrationEntry {
+		resp, err := dataStore.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+			Entry: entry,
+		})
+		require.NoError(t, err)
+		return resp.Entry
+	}
+
+	setNodeSelectors := func(spiffeID string, selectors ...*common.Selector) {
+		_, err := dataStore.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{
+				SpiffeId:  spiffeID,
+				Selectors: selectors,
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	account := &common.Selector{Type: "aws_iid", Value: "account:123"}
+	prodID := "spiffe://example.org/spire/agent/prod"
+	devID := "spiffe://example.org/spire/agent/dev"
+	setNodeSelectors(prodID, account, &common.Selector{Type: "aws_iid", Value: "tag:group:prod-a"})
+	setNodeSelectors(devID, account, &common.Selector{Type: "aws_iid", Value: "tag:group:dev"})
+
+	aliasEntry := createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:           "spiffe://example.org/spire/server",
+		SpiffeId:           "spiffe://example.org/prod-nodes",
+		Selectors:          []*common.Selector{account},
+		SelectorExpression: "aws_iid:tag:group:prod-* || aws_iid:tag:group:staging",
+	})
+
+	actual, err := FetchRegistrationEntries(ctx, dataStore, prodID)
+	require.NoError(t, err)
+	assert.Equal(t, []*common.RegistrationEntry{aliasEntry}, actual)
+
+	actual, err = FetchRegistrationEntries(ctx, dataStore, devID)
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+}
@@ -0,0 +1,67 @@
+package workload
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// FetchX509BundlesMethod is the full method name of the FetchX509Bundles RPC
+// of the SPIFFE Workload API. The go-spiffe Workload API bindings do not
+// provide the RPC yet, and the SpiffeWorkloadAPI service cannot be registered
+// twice on a gRPC server, so the RPC is served through an unknown service
+// handler (see UnknownServiceHandler) and called through FetchX509Bundles.
+const FetchX509BundlesMethod = "/SpiffeWorkloadAPI/FetchX509Bundles"
+
+var fetchX509BundlesStreamDesc = &grpc.StreamDesc{
+	StreamName:    "FetchX509Bundles",
+	ServerStreams: true,
+}
+
+// FetchX509BundlesClient is the client side of a FetchX509Bundles stream.
+type FetchX509BundlesClient interface {
+	Recv() (*X509BundlesResponse, error)
+	grpc.ClientStream
+}
+
+// FetchX509Bundles calls the FetchX509Bundles RPC on the given connection.
+func FetchX509Bundles(ctx context.Context, cc grpc.ClientConnInterface, in *X509BundlesRequest, opts ...grpc.CallOption) (FetchX509BundlesClient, error) {
+	stream, err := cc.NewStream(ctx, fetchX509BundlesStreamDesc, FetchX509BundlesMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fetchX509BundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type fetchX509BundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *fetchX509BundlesClient) Recv() (*X509BundlesResponse, error) {
+	m := new(X509BundlesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FetchX509BundlesServer is the server side of a FetchX509Bundles stream.
+type FetchX509BundlesServer interface {
+	Send(*X509BundlesResponse) error
+	grpc.ServerStream
+}
+
+type fetchX509BundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *fetchX509BundlesServer) Send(m *X509BundlesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
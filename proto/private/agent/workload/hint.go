@@ -0,0 +1,105 @@
+package workload
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+)
+
+// The go-spiffe Workload API bindings do not provide the hint fields of the
+// X509SVID and JWTSVID messages yet. The hints are carried as unrecognized
+// fields of the messages, which are marshaled along with the known fields,
+// and are therefore wire compatible with the messages defined by the
+// specification.
+const (
+	x509SVIDHintField = 5
+	jwtSVIDHintField  = 3
+)
+
+// SetX509SVIDHint sets the hint of the X509-SVID.
+func SetX509SVIDHint(svid *workload.X509SVID, hint string) {
+	svid.XXX_unrecognized = setStringField(svid.XXX_unrecognized, x509SVIDHintField, hint)
+}
+
+// X509SVIDHint returns the hint of the X509-SVID, if any.
+func X509SVIDHint(svid *workload.X509SVID) string {
+	return getStringField(svid.XXX_unrecognized, x509SVIDHintField)
+}
+
+// SetJWTSVIDHint sets the hint of the JWT-SVID.
+func SetJWTSVIDHint(svid *workload.JWTSVID, hint string) {
+	svid.XXX_unrecognized = setStringField(svid.XXX_unrecognized, jwtSVIDHintField, hint)
+}
+
+// JWTSVIDHint returns the hint of the JWT-SVID, if any.
+func JWTSVIDHint(svid *workload.JWTSVID) string {
+	return getStringField(svid.XXX_unrecognized, jwtSVIDHintField)
+}
+
+// setStringField replaces the string field with the given number in the
+// unrecognized fields. Empty strings are not encoded, as with proto3 scalar
+// fields.
+func setStringField(unrecognized []byte, number uint64, value string) []byte {
+	var out []byte
+	forEachField(unrecognized, func(n uint64, field, _ []byte) {
+		if n != number {
+			out = append(out, field...)
+		}
+	})
+	if value != "" {
+		out = append(out, proto.EncodeVarint(number<<3|proto.WireBytes)...)
+		out = append(out, proto.EncodeVarint(uint64(len(value)))...)
+		out = append(out, value...)
+	}
+	return out
+}
+
+// getStringField returns the last value of the string field with the given
+// number in the unrecognized fields.
+func getStringField(unrecognized []byte, number uint64) (value string) {
+	forEachField(unrecognized, func(n uint64, _, payload []byte) {
+		if n == number && payload != nil {
+			value = string(payload)
+		}
+	})
+	return value
+}
+
+// forEachField calls fn with the number and the encoding, key included, of
+// each field in the unrecognized fields. The payload is only set for
+// length-delimited fields. Decoding stops at the first malformed field.
+func forEachField(unrecognized []byte, fn func(number uint64, field, payload []byte)) {
+	for b := unrecognized; len(b) > 0; {
+		key, n := proto.DecodeVarint(b)
+		if n == 0 {
+			return
+		}
+		size := n
+		var payload []byte
+		switch key & 7 {
+		case proto.WireVarint:
+			_, m := proto.DecodeVarint(b[size:])
+			if m == 0 {
+				return
+			}
+			size += m
+		case proto.WireFixed64:
+			size += 8
+		case proto.WireBytes:
+			l, m := proto.DecodeVarint(b[size:])
+			if m == 0 || l > uint64(len(b)-size-m) {
+				return
+			}
+			payload = b[size+m : size+m+int(l)]
+			size += m + int(l)
+		case proto.WireFixed32:
+			size += 4
+		default:
+			return
+		}
+		if size > len(b) {
+			return
+		}
+		fn(key>>3, b[:size], payload)
+		b = b[size:]
+	}
+}
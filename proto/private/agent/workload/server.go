@@ -0,0 +1,39 @@
+package workload
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server is the server side of the Workload API RPCs that are not provided by
+// the go-spiffe Workload API bindings.
+type Server interface {
+	FetchX509Bundles(*X509BundlesRequest, FetchX509BundlesServer) error
+	WatchJWTSVID(*WatchJWTSVIDRequest, WatchJWTSVIDServer) error
+}
+
+// UnknownServiceHandler returns a stream handler, suitable for use with
+// grpc.UnknownServiceHandler, that serves the RPCs of the given server. Calls
+// to any other unknown method fail as unimplemented.
+func UnknownServiceHandler(srv Server) grpc.StreamHandler {
+	return func(_ interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		switch method {
+		case FetchX509BundlesMethod:
+			m := new(X509BundlesRequest)
+			if err := stream.RecvMsg(m); err != nil {
+				return err
+			}
+			return srv.FetchX509Bundles(m, &fetchX509BundlesServer{stream})
+		case WatchJWTSVIDMethod:
+			m := new(WatchJWTSVIDRequest)
+			if err := stream.RecvMsg(m); err != nil {
+				return err
+			}
+			return srv.WatchJWTSVID(m, &watchJWTSVIDServer{stream})
+		default:
+			return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package workload
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// WatchJWTSVIDMethod is the full method name of the WatchJWTSVID RPC. The RPC
+// is an extension to the SPIFFE Workload API that streams the JWT-SVIDs of the
+// workload, renewing them before they expire, so that workloads do not need
+// to call FetchJWTSVID each time they use a JWT-SVID. Like FetchX509Bundles,
+// it is served through an unknown service handler (see UnknownServiceHandler)
+// and called through WatchJWTSVID.
+const WatchJWTSVIDMethod = "/SpiffeWorkloadAPI/WatchJWTSVID"
+
+var watchJWTSVIDStreamDesc = &grpc.StreamDesc{
+	StreamName:    "WatchJWTSVID",
+	ServerStreams: true,
+}
+
+// WatchJWTSVIDClient is the client side of a WatchJWTSVID stream.
+type WatchJWTSVIDClient interface {
+	Recv() (*WatchJWTSVIDResponse, error)
+	grpc.ClientStream
+}
+
+// WatchJWTSVID calls the WatchJWTSVID RPC on the given connection.
+func WatchJWTSVID(ctx context.Context, cc grpc.ClientConnInterface, in *WatchJWTSVIDRequest, opts ...grpc.CallOption) (WatchJWTSVIDClient, error) {
+	stream, err := cc.NewStream(ctx, watchJWTSVIDStreamDesc, WatchJWTSVIDMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &watchJWTSVIDClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type watchJWTSVIDClient struct {
+	grpc.ClientStream
+}
+
+func (x *watchJWTSVIDClient) Recv() (*WatchJWTSVIDResponse, error) {
+	m := new(WatchJWTSVIDResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WatchJWTSVIDServer is the server side of a WatchJWTSVID stream.
+type WatchJWTSVIDServer interface {
+	Send(*WatchJWTSVIDResponse) error
+	grpc.ServerStream
+}
+
+type watchJWTSVIDServer struct {
+	grpc.ServerStream
+}
+
+func (x *watchJWTSVIDServer) Send(m *WatchJWTSVIDResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,313 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: private/agent/workload/workload.proto
+
+package workload
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// The X509BundlesRequest message conveys parameters for requesting X.509
+// bundles. There are currently no such parameters.
+type X509BundlesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509BundlesRequest) Reset()         { *m = X509BundlesRequest{} }
+func (m *X509BundlesRequest) String() string { return proto.CompactTextString(m) }
+func (*X509BundlesRequest) ProtoMessage()    {}
+func (*X509BundlesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bbd611cf353ebabc, []int{0}
+}
+
+func (m *X509BundlesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509BundlesRequest.Unmarshal(m, b)
+}
+func (m *X509BundlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509BundlesRequest.Marshal(b, m, deterministic)
+}
+func (m *X509BundlesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509BundlesRequest.Merge(m, src)
+}
+func (m *X509BundlesRequest) XXX_Size() int {
+	return xxx_messageInfo_X509BundlesRequest.Size(m)
+}
+func (m *X509BundlesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509BundlesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509BundlesRequest proto.InternalMessageInfo
+
+// The X509BundlesResponse message carries a set of global CRLs and a map of
+// trust bundles the workload should trust.
+type X509BundlesResponse struct {
+	// Optional. ASN.1 DER encoded certificate revocation lists.
+	Crl [][]byte `protobuf:"bytes,1,rep,name=crl,proto3" json:"crl,omitempty"`
+	// Required. CA certificate bundles belonging to trust domains that the
+	// workload should trust, keyed by the SPIFFE ID of the trust domain.
+	// Bundles are ASN.1 DER encoded.
+	Bundles              map[string][]byte `protobuf:"bytes,2,rep,name=bundles,proto3" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *X509BundlesResponse) Reset()         { *m = X509BundlesResponse{} }
+func (m *X509BundlesResponse) String() string { return proto.CompactTextString(m) }
+func (*X509BundlesResponse) ProtoMessage()    {}
+func (*X509BundlesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bbd611cf353ebabc, []int{1}
+}
+
+func (m *X509BundlesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509BundlesResponse.Unmarshal(m, b)
+}
+func (m *X509BundlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509BundlesResponse.Marshal(b, m, deterministic)
+}
+func (m *X509BundlesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509BundlesResponse.Merge(m, src)
+}
+func (m *X509BundlesResponse) XXX_Size() int {
+	return xxx_messageInfo_X509BundlesResponse.Size(m)
+}
+func (m *X509BundlesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509BundlesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509BundlesResponse proto.InternalMessageInfo
+
+func (m *X509BundlesResponse) GetCrl() [][]byte {
+	if m != nil {
+		return m.Crl
+	}
+	return nil
+}
+
+func (m *X509BundlesResponse) GetBundles() map[string][]byte {
+	if m != nil {
+		return m.Bundles
+	}
+	return nil
+}
+
+// The WatchJWTSVIDRequest message conveys parameters for watching JWT-SVIDs.
+// It is an extension to the SPIFFE Workload API.
+type WatchJWTSVIDRequest struct {
+	// Required. The audience(s) the workload intends to authenticate against.
+	Audience []string `protobuf:"bytes,1,rep,name=audience,proto3" json:"audience,omitempty"`
+	// Optional. The requested SPIFFE ID for the JWT-SVID. If unset, JWT-SVIDs
+	// are returned for all identities the workload is entitled to.
+	SpiffeId             string   `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchJWTSVIDRequest) Reset()         { *m = WatchJWTSVIDRequest{} }
+func (m *WatchJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchJWTSVIDRequest) ProtoMessage()    {}
+func (*WatchJWTSVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bbd611cf353ebabc, []int{2}
+}
+
+func (m *WatchJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchJWTSVIDRequest.Unmarshal(m, b)
+}
+func (m *WatchJWTSVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchJWTSVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchJWTSVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchJWTSVIDRequest.Merge(m, src)
+}
+func (m *WatchJWTSVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchJWTSVIDRequest.Size(m)
+}
+func (m *WatchJWTSVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchJWTSVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchJWTSVIDRequest proto.InternalMessageInfo
+
+func (m *WatchJWTSVIDRequest) GetAudience() []string {
+	if m != nil {
+		return m.Audience
+	}
+	return nil
+}
+
+func (m *WatchJWTSVIDRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+// The WatchJWTSVIDResponse message carries the current JWT-SVIDs of the
+// workload. A new response is sent whenever a JWT-SVID is renewed or the
+// identities of the workload change.
+type WatchJWTSVIDResponse struct {
+	// Required. The list of returned JWT-SVIDs.
+	Svids                []*WatchedJWTSVID `protobuf:"bytes,1,rep,name=svids,proto3" json:"svids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *WatchJWTSVIDResponse) Reset()         { *m = WatchJWTSVIDResponse{} }
+func (m *WatchJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchJWTSVIDResponse) ProtoMessage()    {}
+func (*WatchJWTSVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bbd611cf353ebabc, []int{3}
+}
+
+func (m *WatchJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchJWTSVIDResponse.Unmarshal(m, b)
+}
+func (m *WatchJWTSVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchJWTSVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchJWTSVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchJWTSVIDResponse.Merge(m, src)
+}
+func (m *WatchJWTSVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchJWTSVIDResponse.Size(m)
+}
+func (m *WatchJWTSVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchJWTSVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchJWTSVIDResponse proto.InternalMessageInfo
+
+func (m *WatchJWTSVIDResponse) GetSvids() []*WatchedJWTSVID {
+	if m != nil {
+		return m.Svids
+	}
+	return nil
+}
+
+// The WatchedJWTSVID message carries a JWT-SVID. Its first fields are wire
+// compatible with the JWTSVID message of the SPIFFE Workload API.
+type WatchedJWTSVID struct {
+	// Required. The SPIFFE ID of the JWT-SVID.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Required. Encoded JWT using JWS Compact Serialization.
+	Svid string `protobuf:"bytes,2,opt,name=svid,proto3" json:"svid,omitempty"`
+	// Optional. An operator-specified string used to provide guidance on how
+	// this identity should be used by a workload when more than one SVID is
+	// returned.
+	Hint string `protobuf:"bytes,3,opt,name=hint,proto3" json:"hint,omitempty"`
+	// Required. The expiration time of the JWT-SVID, in seconds since the
+	// Unix epoch. A renewed JWT-SVID is sent before it expires.
+	ExpiresAt            int64    `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchedJWTSVID) Reset()         { *m = WatchedJWTSVID{} }
+func (m *WatchedJWTSVID) String() string { return proto.CompactTextString(m) }
+func (*WatchedJWTSVID) ProtoMessage()    {}
+func (*WatchedJWTSVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bbd611cf353ebabc, []int{4}
+}
+
+func (m *WatchedJWTSVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchedJWTSVID.Unmarshal(m, b)
+}
+func (m *WatchedJWTSVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchedJWTSVID.Marshal(b, m, deterministic)
+}
+func (m *WatchedJWTSVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchedJWTSVID.Merge(m, src)
+}
+func (m *WatchedJWTSVID) XXX_Size() int {
+	return xxx_messageInfo_WatchedJWTSVID.Size(m)
+}
+func (m *WatchedJWTSVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchedJWTSVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchedJWTSVID proto.InternalMessageInfo
+
+func (m *WatchedJWTSVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *WatchedJWTSVID) GetSvid() string {
+	if m != nil {
+		return m.Svid
+	}
+	return ""
+}
+
+func (m *WatchedJWTSVID) GetHint() string {
+	if m != nil {
+		return m.Hint
+	}
+	return ""
+}
+
+func (m *WatchedJWTSVID) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*X509BundlesRequest)(nil), "X509BundlesRequest")
+	proto.RegisterType((*X509BundlesResponse)(nil), "X509BundlesResponse")
+	proto.RegisterType((*WatchJWTSVIDRequest)(nil), "WatchJWTSVIDRequest")
+	proto.RegisterType((*WatchJWTSVIDResponse)(nil), "WatchJWTSVIDResponse")
+	proto.RegisterType((*WatchedJWTSVID)(nil), "WatchedJWTSVID")
+	proto.RegisterMapType((map[string][]byte)(nil), "X509BundlesResponse.BundlesEntry")
+}
+
+func init() {
+	proto.RegisterFile("private/agent/workload/workload.proto", fileDescriptor_bbd611cf353ebabc)
+}
+
+var fileDescriptor_bbd611cf353ebabc = []byte{
+	// 343 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x4f, 0x4f, 0xfa, 0x40,
+	0x10, 0xfd, 0x2d, 0x85, 0x9f, 0x74, 0x20, 0x6a, 0x16, 0x0e, 0x0d, 0xc6, 0xa4, 0x36, 0x21, 0xe9,
+	0xa9, 0x35, 0xf8, 0x27, 0x8a, 0xf1, 0x20, 0xd1, 0x03, 0x1e, 0x3c, 0xac, 0x46, 0x8c, 0x17, 0x52,
+	0xda, 0x01, 0x36, 0xd4, 0xb6, 0x76, 0xb7, 0x55, 0xbe, 0x8d, 0x1f, 0xd5, 0xf4, 0x0f, 0xc4, 0x26,
+	0x9c, 0xf6, 0xcd, 0x7b, 0x33, 0x6f, 0xde, 0x66, 0x17, 0xfa, 0x51, 0xcc, 0x53, 0x47, 0xa2, 0xed,
+	0x2c, 0x30, 0x90, 0xf6, 0x57, 0x18, 0xaf, 0xfc, 0xd0, 0xf1, 0xb6, 0xc0, 0x8a, 0xe2, 0x50, 0x86,
+	0xf4, 0x9f, 0xd1, 0x05, 0xfa, 0x76, 0x71, 0x7a, 0x3d, 0x4a, 0x02, 0xcf, 0x47, 0xc1, 0xf0, 0x33,
+	0x41, 0x21, 0x8d, 0x1f, 0x02, 0x9d, 0x0a, 0x2d, 0xa2, 0x30, 0x10, 0x48, 0x0f, 0x41, 0x71, 0x63,
+	0x5f, 0x23, 0xba, 0x62, 0xb6, 0x59, 0x06, 0xe9, 0x0d, 0xec, 0xcd, 0x8a, 0x26, 0xad, 0xa6, 0x2b,
+	0x66, 0x6b, 0x70, 0x62, 0xed, 0x18, 0xb4, 0xca, 0xfa, 0x21, 0x90, 0xf1, 0x9a, 0x6d, 0x26, 0x7a,
+	0x43, 0x68, 0xff, 0x15, 0x32, 0xfb, 0x15, 0xae, 0x35, 0xa2, 0x13, 0x53, 0x65, 0x19, 0xa4, 0x5d,
+	0x68, 0xa4, 0x8e, 0x9f, 0xa0, 0x56, 0xd3, 0x89, 0xd9, 0x66, 0x45, 0x31, 0xac, 0x5d, 0x11, 0xe3,
+	0x09, 0x3a, 0x13, 0x47, 0xba, 0xcb, 0xc7, 0xc9, 0xcb, 0xf3, 0xeb, 0xf8, 0xbe, 0x4c, 0x4e, 0x7b,
+	0xd0, 0x74, 0x12, 0x8f, 0x63, 0xe0, 0x62, 0x1e, 0x53, 0x65, 0xdb, 0x9a, 0x1e, 0x81, 0x2a, 0x22,
+	0x3e, 0x9f, 0xe3, 0x94, 0x7b, 0xb9, 0xa1, 0xca, 0x9a, 0x05, 0x31, 0xf6, 0x8c, 0x5b, 0xe8, 0x56,
+	0xfd, 0xca, 0x2b, 0xf7, 0xa1, 0x21, 0x52, 0xee, 0x89, 0xdc, 0xad, 0x35, 0x38, 0xb0, 0xf2, 0x2e,
+	0xf4, 0x36, 0x7d, 0x85, 0x6a, 0x48, 0xd8, 0xaf, 0x0a, 0xd5, 0x6d, 0xa4, 0xba, 0x8d, 0x52, 0xa8,
+	0x8b, 0x74, 0x9b, 0x22, 0xc7, 0x19, 0xb7, 0xe4, 0x81, 0xd4, 0x94, 0x82, 0xcb, 0x30, 0x3d, 0x06,
+	0xc0, 0xef, 0x88, 0xc7, 0x28, 0xa6, 0x8e, 0xd4, 0xea, 0x3a, 0x31, 0x15, 0xa6, 0x96, 0xcc, 0x9d,
+	0x1c, 0x5d, 0xbe, 0x9f, 0x2f, 0xb8, 0x5c, 0x26, 0x33, 0xcb, 0x0d, 0x3f, 0xec, 0xc2, 0x3d, 0x3b,
+	0x62, 0xb4, 0xf3, 0x07, 0xb6, 0x77, 0x7f, 0x83, 0xd9, 0xff, 0x5c, 0x3d, 0xfb, 0x1d, 0x00, 0x41,
+	0x70, 0x55, 0x68, 0x27, 0x02, 0x00, 0x00,
+}
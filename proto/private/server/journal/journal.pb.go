@@ -28,7 +28,10 @@ type X509CAEntry struct {
 	// DER encoded CA certificate
 	Certificate []byte `protobuf:"bytes,3,opt,name=certificate,proto3" json:"certificate,omitempty"`
 	// DER encoded upstream CA chain. See the X509CA struct for details.
-	UpstreamChain        [][]byte `protobuf:"bytes,4,rep,name=upstream_chain,json=upstreamChain,proto3" json:"upstream_chain,omitempty"`
+	UpstreamChain [][]byte `protobuf:"bytes,4,rep,name=upstream_chain,json=upstreamChain,proto3" json:"upstream_chain,omitempty"`
+	// Whether the CA was activated through a manual rotation, ahead of the
+	// activation threshold of the CA it replaced.
+	Activated            bool     `protobuf:"varint,5,opt,name=activated,proto3" json:"activated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -87,6 +90,13 @@ func (m *X509CAEntry) GetUpstreamChain() [][]byte {
 	return nil
 }
 
+func (m *X509CAEntry) GetActivated() bool {
+	if m != nil {
+		return m.Activated
+	}
+	return false
+}
+
 type JWTKeyEntry struct {
 	// Which JWT Key slot this entry occupied.
 	SlotId string `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
@@ -97,7 +107,10 @@ type JWTKeyEntry struct {
 	// JWT key id (i.e. "kid" claim)
 	Kid string `protobuf:"bytes,4,opt,name=kid,proto3" json:"kid,omitempty"`
 	// PKIX encoded public key
-	PublicKey            []byte   `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	PublicKey []byte `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Whether the key was activated through a manual rotation, ahead of the
+	// activation threshold of the key it replaced.
+	Activated            bool     `protobuf:"varint,6,opt,name=activated,proto3" json:"activated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -163,6 +176,13 @@ func (m *JWTKeyEntry) GetPublicKey() []byte {
 	return nil
 }
 
+func (m *JWTKeyEntry) GetActivated() bool {
+	if m != nil {
+		return m.Activated
+	}
+	return false
+}
+
 type Entries struct {
 	X509CAs              []*X509CAEntry `protobuf:"bytes,1,rep,name=x509CAs,proto3" json:"x509CAs,omitempty"`
 	JwtKeys              []*JWTKeyEntry `protobuf:"bytes,2,rep,name=jwtKeys,proto3" json:"jwtKeys,omitempty"`
@@ -221,25 +241,27 @@ func init() {
 }
 
 var fileDescriptor_63c6786ba201045d = []byte{
-	// 319 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0xcb, 0x4b, 0xc3, 0x40,
-	0x10, 0xc6, 0x49, 0x53, 0xdb, 0x66, 0x53, 0x45, 0xf6, 0xe2, 0x42, 0x11, 0x42, 0x51, 0xc9, 0xa9,
-	0x11, 0x5f, 0xe0, 0xb1, 0x16, 0x0f, 0xda, 0xdb, 0x22, 0xf8, 0xb8, 0x84, 0x6d, 0x32, 0xb1, 0xdb,
-	0x47, 0x36, 0xec, 0x4e, 0xaa, 0xf9, 0x1f, 0xbc, 0xf8, 0x1f, 0xcb, 0xa6, 0x06, 0x7a, 0xf0, 0xe6,
-	0x69, 0x76, 0x7e, 0xdf, 0xb0, 0x7c, 0x33, 0x1f, 0x39, 0x29, 0xb4, 0xdc, 0x08, 0x84, 0xc8, 0x80,
-	0xde, 0x80, 0x8e, 0x16, 0xaa, 0xd4, 0xb9, 0x58, 0x35, 0x75, 0x54, 0x68, 0x85, 0x6a, 0xf8, 0xe5,
-	0x10, 0xff, 0xe5, 0xfa, 0xfc, 0x76, 0x32, 0xbe, 0xcf, 0x51, 0x57, 0xf4, 0x88, 0x74, 0xcd, 0x4a,
-	0x61, 0x2c, 0x53, 0xe6, 0x04, 0x4e, 0xe8, 0xf1, 0x8e, 0x6d, 0x1f, 0x52, 0x3a, 0x20, 0x9e, 0x34,
-	0xa6, 0x84, 0x34, 0x16, 0xc8, 0x5a, 0x81, 0x13, 0xba, 0xbc, 0xb7, 0x05, 0x63, 0xa4, 0x01, 0xf1,
-	0x13, 0xd0, 0x28, 0x33, 0x99, 0x08, 0x04, 0xe6, 0x06, 0x4e, 0xd8, 0xe7, 0xbb, 0x88, 0x9e, 0x92,
-	0x83, 0xb2, 0x30, 0xa8, 0x41, 0xac, 0xe3, 0x64, 0x2e, 0x64, 0xce, 0xda, 0x81, 0x1b, 0xf6, 0xf9,
-	0x7e, 0x43, 0x27, 0x16, 0x0e, 0xbf, 0x1d, 0xe2, 0x3f, 0x3e, 0x3f, 0x4d, 0xa1, 0xfa, 0x8f, 0x9d,
-	0x01, 0xf1, 0x72, 0x85, 0xb1, 0xc8, 0x10, 0x74, 0x6d, 0xc6, 0xe5, 0xbd, 0x5c, 0xe1, 0xd8, 0xf6,
-	0xf4, 0x90, 0xb8, 0x4b, 0x99, 0xb2, 0x76, 0xfd, 0x9d, 0x7d, 0xd2, 0x63, 0x42, 0x8a, 0x72, 0xb6,
-	0x92, 0x49, 0xbc, 0x84, 0x8a, 0xed, 0xd5, 0xe6, 0xbd, 0x2d, 0x99, 0x42, 0x35, 0x7c, 0x25, 0x5d,
-	0x6b, 0x46, 0x82, 0xa1, 0x67, 0xa4, 0xfb, 0x59, 0x1f, 0xcb, 0x30, 0x27, 0x70, 0x43, 0xff, 0xa2,
-	0x3f, 0xda, 0x39, 0x1e, 0x6f, 0x44, 0x3b, 0xb7, 0xf8, 0xc0, 0x29, 0x54, 0x86, 0xb5, 0x7e, 0xe7,
-	0x76, 0xb6, 0xe2, 0x8d, 0x78, 0x77, 0xf3, 0x76, 0xf5, 0x2e, 0x71, 0x5e, 0xce, 0x46, 0x89, 0x5a,
-	0x47, 0xa6, 0x90, 0x59, 0x06, 0xb6, 0x68, 0x88, 0xea, 0x78, 0xa2, 0xbf, 0x33, 0x9c, 0x75, 0x6a,
-	0xf5, 0xf2, 0x27, 0x00, 0x00, 0xff, 0xff, 0xd9, 0x9c, 0xff, 0x3d, 0xe4, 0x01, 0x00, 0x00,
+	// 337 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0x4d, 0x4b, 0xf3, 0x40,
+	0x10, 0xc7, 0xd9, 0xa6, 0x4f, 0xdb, 0x6c, 0xf2, 0x3c, 0x3c, 0xec, 0xc5, 0x85, 0x2a, 0x84, 0xa2,
+	0x92, 0x53, 0x23, 0xbe, 0x81, 0xc7, 0x5a, 0x3c, 0x68, 0x6f, 0x8b, 0xe0, 0xcb, 0x25, 0x6c, 0x93,
+	0x89, 0xdd, 0xbe, 0x24, 0x61, 0x77, 0x52, 0xcd, 0x57, 0xf2, 0xe2, 0x57, 0x94, 0xa4, 0x06, 0xab,
+	0x78, 0xf3, 0x34, 0x99, 0xdf, 0x7f, 0x08, 0xbf, 0x9d, 0xa1, 0xfb, 0xb9, 0x56, 0x6b, 0x89, 0x10,
+	0x18, 0xd0, 0x6b, 0xd0, 0xc1, 0x3c, 0x2b, 0x74, 0x2a, 0x97, 0x4d, 0x1d, 0xe6, 0x3a, 0xc3, 0x6c,
+	0xf0, 0x4a, 0xa8, 0x73, 0x7f, 0x76, 0x74, 0x31, 0x1e, 0x5d, 0xa5, 0xa8, 0x4b, 0xb6, 0x43, 0xbb,
+	0x66, 0x99, 0x61, 0xa8, 0x62, 0x4e, 0x3c, 0xe2, 0xdb, 0xa2, 0x53, 0xb5, 0xd7, 0x31, 0xeb, 0x53,
+	0x5b, 0x19, 0x53, 0x40, 0x1c, 0x4a, 0xe4, 0x2d, 0x8f, 0xf8, 0x96, 0xe8, 0x6d, 0xc0, 0x08, 0x99,
+	0x47, 0x9d, 0x08, 0x34, 0xaa, 0x44, 0x45, 0x12, 0x81, 0x5b, 0x1e, 0xf1, 0x5d, 0xb1, 0x8d, 0xd8,
+	0x01, 0xfd, 0x57, 0xe4, 0x06, 0x35, 0xc8, 0x55, 0x18, 0xcd, 0xa4, 0x4a, 0x79, 0xdb, 0xb3, 0x7c,
+	0x57, 0xfc, 0x6d, 0xe8, 0xb8, 0x82, 0x6c, 0x97, 0xda, 0x32, 0xc2, 0xda, 0x3b, 0xe6, 0x7f, 0x3c,
+	0xe2, 0xf7, 0xc4, 0x27, 0x18, 0xbc, 0x11, 0xea, 0xdc, 0xdc, 0xdd, 0x4e, 0xa0, 0xfc, 0x8d, 0x6c,
+	0x9f, 0xda, 0x69, 0x86, 0xa1, 0x4c, 0x10, 0x74, 0xad, 0x6a, 0x89, 0x5e, 0x9a, 0xe1, 0xa8, 0xea,
+	0xd9, 0x7f, 0x6a, 0x2d, 0x54, 0xcc, 0xdb, 0xf5, 0xef, 0xaa, 0x4f, 0xb6, 0x47, 0x69, 0x5e, 0x4c,
+	0x97, 0x2a, 0x0a, 0x17, 0x50, 0xd6, 0x4e, 0xae, 0xb0, 0x37, 0x64, 0x02, 0xe5, 0x57, 0xe3, 0xce,
+	0x77, 0xe3, 0x07, 0xda, 0xad, 0x54, 0x15, 0x18, 0x76, 0x48, 0xbb, 0x2f, 0xf5, 0xa2, 0x0d, 0x27,
+	0x9e, 0xe5, 0x3b, 0xc7, 0xee, 0x70, 0x6b, 0xf1, 0xa2, 0x09, 0xab, 0xb9, 0xf9, 0x33, 0x4e, 0xa0,
+	0x34, 0xbc, 0xf5, 0x31, 0xb7, 0xf5, 0x66, 0xd1, 0x84, 0x97, 0xe7, 0x8f, 0xa7, 0x4f, 0x0a, 0x67,
+	0xc5, 0x74, 0x18, 0x65, 0xab, 0xc0, 0xe4, 0x2a, 0x49, 0xa0, 0x2a, 0x1a, 0x82, 0xfa, 0xb4, 0xc1,
+	0xcf, 0xf7, 0x9f, 0x76, 0xea, 0xf4, 0xe4, 0x7d, 0x00, 0x73, 0xdf, 0xba, 0x29, 0x20, 0x02, 0x00,
+	0x00,
 }
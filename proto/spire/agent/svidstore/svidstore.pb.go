@@ -0,0 +1,520 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: spire/agent/svidstore/svidstore.proto
+
+package svidstore
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/spiffe/spire/proto/spire/common"
+	plugin "github.com/spiffe/spire/proto/spire/common/plugin"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// * Represents an X509-SVID along with the bundles needed to validate it and
+// the SVIDs of other workloads
+type X509SVID struct {
+	//* SPIFFE ID of the SVID
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	//* ASN.1 DER encoded certificate chain, leaf first
+	CertChain [][]byte `protobuf:"bytes,2,rep,name=cert_chain,json=certChain,proto3" json:"cert_chain,omitempty"`
+	//* PKCS#8 ASN.1 DER encoded private key
+	PrivateKey []byte `protobuf:"bytes,3,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	//* ASN.1 DER encoded CA certificates of the trust domain bundle
+	Bundle [][]byte `protobuf:"bytes,4,rep,name=bundle,proto3" json:"bundle,omitempty"`
+	//* Expiration of the SVID, in seconds since the Unix epoch
+	ExpiresAt            int64    `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVID) Reset()         { *m = X509SVID{} }
+func (m *X509SVID) String() string { return proto.CompactTextString(m) }
+func (*X509SVID) ProtoMessage()    {}
+func (*X509SVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60840e5758815d27, []int{0}
+}
+
+func (m *X509SVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509SVID.Unmarshal(m, b)
+}
+func (m *X509SVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509SVID.Marshal(b, m, deterministic)
+}
+func (m *X509SVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVID.Merge(m, src)
+}
+func (m *X509SVID) XXX_Size() int {
+	return xxx_messageInfo_X509SVID.Size(m)
+}
+func (m *X509SVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVID proto.InternalMessageInfo
+
+func (m *X509SVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *X509SVID) GetCertChain() [][]byte {
+	if m != nil {
+		return m.CertChain
+	}
+	return nil
+}
+
+func (m *X509SVID) GetPrivateKey() []byte {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return nil
+}
+
+func (m *X509SVID) GetBundle() [][]byte {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+func (m *X509SVID) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// * Represents a request to store an X509-SVID
+type PutX509SVIDRequest struct {
+	//* The X509-SVID to store
+	Svid *X509SVID `protobuf:"bytes,1,opt,name=svid,proto3" json:"svid,omitempty"`
+	//* Selectors of the registration entry whose type is the name of the
+	//plugin
+	Selectors []*common.Selector `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	//* Concatenated ASN.1 DER encoded CA certificates of the bundles of the
+	//trust domains the entry federates with, keyed by trust domain ID
+	FederatedBundles     map[string][]byte `protobuf:"bytes,3,rep,name=federated_bundles,json=federatedBundles,proto3" json:"federated_bundles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PutX509SVIDRequest) Reset()         { *m = PutX509SVIDRequest{} }
+func (m *PutX509SVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*PutX509SVIDRequest) ProtoMessage()    {}
+func (*PutX509SVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60840e5758815d27, []int{1}
+}
+
+func (m *PutX509SVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutX509SVIDRequest.Unmarshal(m, b)
+}
+func (m *PutX509SVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutX509SVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *PutX509SVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutX509SVIDRequest.Merge(m, src)
+}
+func (m *PutX509SVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_PutX509SVIDRequest.Size(m)
+}
+func (m *PutX509SVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutX509SVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutX509SVIDRequest proto.InternalMessageInfo
+
+func (m *PutX509SVIDRequest) GetSvid() *X509SVID {
+	if m != nil {
+		return m.Svid
+	}
+	return nil
+}
+
+func (m *PutX509SVIDRequest) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *PutX509SVIDRequest) GetFederatedBundles() map[string][]byte {
+	if m != nil {
+		return m.FederatedBundles
+	}
+	return nil
+}
+
+// * Represents an empty response
+type PutX509SVIDResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutX509SVIDResponse) Reset()         { *m = PutX509SVIDResponse{} }
+func (m *PutX509SVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*PutX509SVIDResponse) ProtoMessage()    {}
+func (*PutX509SVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60840e5758815d27, []int{2}
+}
+
+func (m *PutX509SVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutX509SVIDResponse.Unmarshal(m, b)
+}
+func (m *PutX509SVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutX509SVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *PutX509SVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutX509SVIDResponse.Merge(m, src)
+}
+func (m *PutX509SVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_PutX509SVIDResponse.Size(m)
+}
+func (m *PutX509SVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutX509SVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutX509SVIDResponse proto.InternalMessageInfo
+
+// * Represents a request to remove a stored X509-SVID, sent when its
+// registration entry is deleted or no longer stored by the plugin
+type DeleteX509SVIDRequest struct {
+	//* Selectors of the registration entry whose type is the name of the
+	//plugin
+	Selectors            []*common.Selector `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DeleteX509SVIDRequest) Reset()         { *m = DeleteX509SVIDRequest{} }
+func (m *DeleteX509SVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteX509SVIDRequest) ProtoMessage()    {}
+func (*DeleteX509SVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60840e5758815d27, []int{3}
+}
+
+func (m *DeleteX509SVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteX509SVIDRequest.Unmarshal(m, b)
+}
+func (m *DeleteX509SVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteX509SVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteX509SVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteX509SVIDRequest.Merge(m, src)
+}
+func (m *DeleteX509SVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteX509SVIDRequest.Size(m)
+}
+func (m *DeleteX509SVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteX509SVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteX509SVIDRequest proto.InternalMessageInfo
+
+func (m *DeleteX509SVIDRequest) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+// * Represents an empty response
+type DeleteX509SVIDResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteX509SVIDResponse) Reset()         { *m = DeleteX509SVIDResponse{} }
+func (m *DeleteX509SVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteX509SVIDResponse) ProtoMessage()    {}
+func (*DeleteX509SVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60840e5758815d27, []int{4}
+}
+
+func (m *DeleteX509SVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteX509SVIDResponse.Unmarshal(m, b)
+}
+func (m *DeleteX509SVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteX509SVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteX509SVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteX509SVIDResponse.Merge(m, src)
+}
+func (m *DeleteX509SVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteX509SVIDResponse.Size(m)
+}
+func (m *DeleteX509SVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteX509SVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteX509SVIDResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*X509SVID)(nil), "spire.agent.svidstore.X509SVID")
+	proto.RegisterType((*PutX509SVIDRequest)(nil), "spire.agent.svidstore.PutX509SVIDRequest")
+	proto.RegisterMapType((map[string][]byte)(nil), "spire.agent.svidstore.PutX509SVIDRequest.FederatedBundlesEntry")
+	proto.RegisterType((*PutX509SVIDResponse)(nil), "spire.agent.svidstore.PutX509SVIDResponse")
+	proto.RegisterType((*DeleteX509SVIDRequest)(nil), "spire.agent.svidstore.DeleteX509SVIDRequest")
+	proto.RegisterType((*DeleteX509SVIDResponse)(nil), "spire.agent.svidstore.DeleteX509SVIDResponse")
+}
+
+func init() {
+	proto.RegisterFile("spire/agent/svidstore/svidstore.proto", fileDescriptor_60840e5758815d27)
+}
+
+var fileDescriptor_60840e5758815d27 = []byte{
+	// 504 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xef, 0x6b, 0xd3, 0x50,
+	0x14, 0x25, 0xcd, 0x36, 0x96, 0xdb, 0x29, 0xf3, 0x69, 0x4b, 0x8c, 0xc8, 0x42, 0x60, 0xd2, 0x89,
+	0x26, 0xd2, 0x3a, 0x50, 0xbf, 0x88, 0xeb, 0x54, 0x8a, 0x08, 0x23, 0x05, 0x91, 0x7d, 0x09, 0x69,
+	0x73, 0xd3, 0x05, 0xd3, 0x24, 0xe6, 0xbd, 0x14, 0xfb, 0xcf, 0xf8, 0xc9, 0xbf, 0xc0, 0xbf, 0x50,
+	0xde, 0x8f, 0x76, 0xa6, 0x66, 0xae, 0x9f, 0xde, 0x7b, 0xe7, 0x9e, 0x73, 0xef, 0xc9, 0x09, 0xef,
+	0xc1, 0x31, 0x2d, 0x92, 0x12, 0xbd, 0x70, 0x86, 0x19, 0xf3, 0xe8, 0x22, 0x89, 0x28, 0xcb, 0x4b,
+	0xbc, 0xde, 0xb9, 0x45, 0x99, 0xb3, 0x9c, 0x74, 0x04, 0xcd, 0x15, 0x34, 0x77, 0x5d, 0xb4, 0x1e,
+	0x4a, 0xf5, 0x34, 0x9f, 0xcf, 0xf3, 0x4c, 0x2d, 0x52, 0x61, 0xd9, 0xb5, 0x52, 0x91, 0x56, 0xb3,
+	0x64, 0xb5, 0x48, 0x86, 0xf3, 0x53, 0x83, 0xfd, 0xaf, 0xa7, 0x2f, 0x5e, 0x8f, 0xbf, 0x8c, 0xce,
+	0xc9, 0x23, 0x30, 0x68, 0x91, 0xc4, 0x31, 0x06, 0x49, 0x64, 0x6a, 0xb6, 0xd6, 0x33, 0xfc, 0x7d,
+	0x09, 0x8c, 0x22, 0xf2, 0x18, 0x60, 0x8a, 0x25, 0x0b, 0xa6, 0x57, 0x61, 0x92, 0x99, 0x2d, 0x5b,
+	0xef, 0x1d, 0xf8, 0x06, 0x47, 0x86, 0x1c, 0x20, 0x47, 0xd0, 0x2e, 0xca, 0x64, 0x11, 0x32, 0x0c,
+	0xbe, 0xe1, 0xd2, 0xd4, 0x6d, 0xad, 0x77, 0xe0, 0x83, 0x82, 0x3e, 0xe1, 0x92, 0x74, 0x61, 0x6f,
+	0x52, 0x65, 0x51, 0x8a, 0xe6, 0x8e, 0xd0, 0xaa, 0x13, 0xef, 0x8b, 0x3f, 0xb8, 0x4d, 0x1a, 0x84,
+	0xcc, 0xdc, 0xb5, 0xb5, 0x9e, 0xee, 0x1b, 0x0a, 0x79, 0xc7, 0x9c, 0xdf, 0x2d, 0x20, 0x17, 0x15,
+	0x5b, 0x79, 0xf4, 0xf1, 0x7b, 0x85, 0x94, 0x91, 0x01, 0xec, 0xd0, 0x85, 0x72, 0xd9, 0xee, 0x1f,
+	0xb9, 0x8d, 0xd1, 0xb8, 0x6b, 0x95, 0x20, 0x93, 0x97, 0x60, 0x50, 0x4c, 0x71, 0xca, 0xf2, 0x92,
+	0x8a, 0x2f, 0x68, 0xf7, 0xbb, 0x4a, 0xa9, 0x62, 0x1b, 0xab, 0xb2, 0x7f, 0x4d, 0x24, 0x29, 0xdc,
+	0x8b, 0x31, 0xc2, 0x32, 0x64, 0x18, 0x05, 0xd2, 0x34, 0x35, 0x75, 0xa1, 0x7e, 0x7b, 0xc3, 0xdc,
+	0x7f, 0x0d, 0xbb, 0x1f, 0x56, 0x2d, 0xce, 0x64, 0x87, 0xf7, 0x19, 0x2b, 0x97, 0xfe, 0x61, 0xbc,
+	0x01, 0x5b, 0x43, 0xe8, 0x34, 0x52, 0xc9, 0x21, 0xe8, 0x3c, 0x58, 0xf9, 0x5b, 0xf8, 0x96, 0x3c,
+	0x80, 0xdd, 0x45, 0x98, 0x56, 0x68, 0xb6, 0x44, 0xd8, 0xf2, 0xf0, 0xa6, 0xf5, 0x4a, 0x73, 0x3a,
+	0x70, 0xbf, 0x66, 0x81, 0x16, 0x79, 0x46, 0xd1, 0xf9, 0x0c, 0x9d, 0x73, 0x4c, 0x91, 0xe1, 0x66,
+	0x9a, 0xb5, 0x60, 0xb4, 0x2d, 0x83, 0x71, 0x4c, 0xe8, 0x6e, 0xb6, 0x93, 0x83, 0xfa, 0xbf, 0x74,
+	0x30, 0x38, 0x30, 0xe6, 0x69, 0x90, 0x08, 0xda, 0x7f, 0xb9, 0x21, 0x27, 0x5b, 0x87, 0x66, 0x3d,
+	0xdd, 0x86, 0x2a, 0x67, 0x92, 0x39, 0xdc, 0xad, 0xbb, 0x21, 0xcf, 0x6e, 0x50, 0x37, 0x66, 0x60,
+	0x3d, 0xdf, 0x92, 0xad, 0xc6, 0x5d, 0x82, 0x31, 0xcc, 0xb3, 0x38, 0x99, 0x55, 0x25, 0x92, 0xe3,
+	0x7a, 0x58, 0xea, 0x86, 0xad, 0xeb, 0xab, 0x11, 0x4f, 0x6e, 0xa3, 0xa9, 0xde, 0x31, 0xdc, 0xf9,
+	0x88, 0xec, 0x42, 0x94, 0x47, 0x59, 0x9c, 0x93, 0x93, 0x46, 0x61, 0x8d, 0xb3, 0x19, 0xd9, 0x7f,
+	0xa9, 0x72, 0xce, 0xd9, 0xe9, 0xe5, 0x60, 0x96, 0xb0, 0xab, 0x6a, 0xc2, 0xd9, 0x9e, 0xbc, 0xe9,
+	0x9e, 0x7c, 0x32, 0xc4, 0xeb, 0xe0, 0x35, 0xbe, 0x4b, 0x93, 0x3d, 0x51, 0x1c, 0xfc, 0x19, 0x00,
+	0xa2, 0xdd, 0x38, 0x60, 0xb7, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// SVIDStoreClient is the client API for SVIDStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SVIDStoreClient interface {
+	//* Stores an X509-SVID, replacing the one previously stored for the
+	//same selectors
+	PutX509SVID(ctx context.Context, in *PutX509SVIDRequest, opts ...grpc.CallOption) (*PutX509SVIDResponse, error)
+	//* Removes a stored X509-SVID
+	DeleteX509SVID(ctx context.Context, in *DeleteX509SVIDRequest, opts ...grpc.CallOption) (*DeleteX509SVIDResponse, error)
+	//* Applies the plugin configuration and returns configuration errors
+	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
+	//* Returns the version and related metadata of the plugin
+	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+}
+
+type sVIDStoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSVIDStoreClient(cc grpc.ClientConnInterface) SVIDStoreClient {
+	return &sVIDStoreClient{cc}
+}
+
+func (c *sVIDStoreClient) PutX509SVID(ctx context.Context, in *PutX509SVIDRequest, opts ...grpc.CallOption) (*PutX509SVIDResponse, error) {
+	out := new(PutX509SVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.agent.svidstore.SVIDStore/PutX509SVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sVIDStoreClient) DeleteX509SVID(ctx context.Context, in *DeleteX509SVIDRequest, opts ...grpc.CallOption) (*DeleteX509SVIDResponse, error) {
+	out := new(DeleteX509SVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.agent.svidstore.SVIDStore/DeleteX509SVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sVIDStoreClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
+	out := new(plugin.ConfigureResponse)
+	err := c.cc.Invoke(ctx, "/spire.agent.svidstore.SVIDStore/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sVIDStoreClient) GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error) {
+	out := new(plugin.GetPluginInfoResponse)
+	err := c.cc.Invoke(ctx, "/spire.agent.svidstore.SVIDStore/GetPluginInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SVIDStoreServer is the server API for SVIDStore service.
+type SVIDStoreServer interface {
+	//* Stores an X509-SVID, replacing the one previously stored for the
+	//same selectors
+	PutX509SVID(context.Context, *PutX509SVIDRequest) (*PutX509SVIDResponse, error)
+	//* Removes a stored X509-SVID
+	DeleteX509SVID(context.Context, *DeleteX509SVIDRequest) (*DeleteX509SVIDResponse, error)
+	//* Applies the plugin configuration and returns configuration errors
+	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
+	//* Returns the version and related metadata of the plugin
+	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+}
+
+// UnimplementedSVIDStoreServer can be embedded to have forward compatible implementations.
+type UnimplementedSVIDStoreServer struct {
+}
+
+func (*UnimplementedSVIDStoreServer) PutX509SVID(ctx context.Context, req *PutX509SVIDRequest) (*PutX509SVIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutX509SVID not implemented")
+}
+func (*UnimplementedSVIDStoreServer) DeleteX509SVID(ctx context.Context, req *DeleteX509SVIDRequest) (*DeleteX509SVIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteX509SVID not implemented")
+}
+func (*UnimplementedSVIDStoreServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (*UnimplementedSVIDStoreServer) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPluginInfo not implemented")
+}
+
+func RegisterSVIDStoreServer(s *grpc.Server, srv SVIDStoreServer) {
+	s.RegisterService(&_SVIDStore_serviceDesc, srv)
+}
+
+func _SVIDStore_PutX509SVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutX509SVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SVIDStoreServer).PutX509SVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.agent.svidstore.SVIDStore/PutX509SVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SVIDStoreServer).PutX509SVID(ctx, req.(*PutX509SVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SVIDStore_DeleteX509SVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteX509SVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SVIDStoreServer).DeleteX509SVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.agent.svidstore.SVIDStore/DeleteX509SVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SVIDStoreServer).DeleteX509SVID(ctx, req.(*DeleteX509SVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SVIDStore_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SVIDStoreServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.agent.svidstore.SVIDStore/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SVIDStoreServer).Configure(ctx, req.(*plugin.ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SVIDStore_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.GetPluginInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SVIDStoreServer).GetPluginInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.agent.svidstore.SVIDStore/GetPluginInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SVIDStoreServer).GetPluginInfo(ctx, req.(*plugin.GetPluginInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SVIDStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.agent.svidstore.SVIDStore",
+	HandlerType: (*SVIDStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PutX509SVID",
+			Handler:    _SVIDStore_PutX509SVID_Handler,
+		},
+		{
+			MethodName: "DeleteX509SVID",
+			Handler:    _SVIDStore_DeleteX509SVID_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _SVIDStore_Configure_Handler,
+		},
+		{
+			MethodName: "GetPluginInfo",
+			Handler:    _SVIDStore_GetPluginInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/agent/svidstore/svidstore.proto",
+}
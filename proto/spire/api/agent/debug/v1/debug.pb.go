@@ -181,10 +181,256 @@ func (m *GetInfoResponse_Cert) GetSubject() string {
 	return ""
 }
 
+type DumpRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DumpRequest) Reset()         { *m = DumpRequest{} }
+func (m *DumpRequest) String() string { return proto.CompactTextString(m) }
+func (*DumpRequest) ProtoMessage()    {}
+func (*DumpRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e5721b49b138bf5, []int{2}
+}
+
+func (m *DumpRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpRequest.Unmarshal(m, b)
+}
+func (m *DumpRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpRequest.Marshal(b, m, deterministic)
+}
+func (m *DumpRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpRequest.Merge(m, src)
+}
+func (m *DumpRequest) XXX_Size() int {
+	return xxx_messageInfo_DumpRequest.Size(m)
+}
+func (m *DumpRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpRequest proto.InternalMessageInfo
+
+type DumpResponse struct {
+	// Registration entries cached by the agent
+	Entries []*DumpResponse_Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Most recent workload attestations, newest first
+	Attestations []*DumpResponse_Attestation `protobuf:"bytes,2,rep,name=attestations,proto3" json:"attestations,omitempty"`
+	// Expiration time of the agent SVID (in seconds since unix epoch)
+	AgentSvidExpiresAt   int64    `protobuf:"varint,3,opt,name=agent_svid_expires_at,json=agentSvidExpiresAt,proto3" json:"agent_svid_expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DumpResponse) Reset()         { *m = DumpResponse{} }
+func (m *DumpResponse) String() string { return proto.CompactTextString(m) }
+func (*DumpResponse) ProtoMessage()    {}
+func (*DumpResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e5721b49b138bf5, []int{3}
+}
+
+func (m *DumpResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpResponse.Unmarshal(m, b)
+}
+func (m *DumpResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpResponse.Marshal(b, m, deterministic)
+}
+func (m *DumpResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpResponse.Merge(m, src)
+}
+func (m *DumpResponse) XXX_Size() int {
+	return xxx_messageInfo_DumpResponse.Size(m)
+}
+func (m *DumpResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpResponse proto.InternalMessageInfo
+
+func (m *DumpResponse) GetEntries() []*DumpResponse_Entry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *DumpResponse) GetAttestations() []*DumpResponse_Attestation {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
+func (m *DumpResponse) GetAgentSvidExpiresAt() int64 {
+	if m != nil {
+		return m.AgentSvidExpiresAt
+	}
+	return 0
+}
+
+type DumpResponse_Entry struct {
+	// Registration entry ID
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// SPIFFE ID of the entry
+	SpiffeId *types.SPIFFEID `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Parent ID of the entry
+	ParentId *types.SPIFFEID `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Selectors of the entry
+	Selectors []*types.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Expiration time of the cached X509-SVID (in seconds since unix
+	// epoch), or 0 if the agent has no X509-SVID for the entry
+	SvidExpiresAt        int64    `protobuf:"varint,5,opt,name=svid_expires_at,json=svidExpiresAt,proto3" json:"svid_expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DumpResponse_Entry) Reset()         { *m = DumpResponse_Entry{} }
+func (m *DumpResponse_Entry) String() string { return proto.CompactTextString(m) }
+func (*DumpResponse_Entry) ProtoMessage()    {}
+func (*DumpResponse_Entry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e5721b49b138bf5, []int{3, 0}
+}
+
+func (m *DumpResponse_Entry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpResponse_Entry.Unmarshal(m, b)
+}
+func (m *DumpResponse_Entry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpResponse_Entry.Marshal(b, m, deterministic)
+}
+func (m *DumpResponse_Entry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpResponse_Entry.Merge(m, src)
+}
+func (m *DumpResponse_Entry) XXX_Size() int {
+	return xxx_messageInfo_DumpResponse_Entry.Size(m)
+}
+func (m *DumpResponse_Entry) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpResponse_Entry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpResponse_Entry proto.InternalMessageInfo
+
+func (m *DumpResponse_Entry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DumpResponse_Entry) GetSpiffeId() *types.SPIFFEID {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return nil
+}
+
+func (m *DumpResponse_Entry) GetParentId() *types.SPIFFEID {
+	if m != nil {
+		return m.ParentId
+	}
+	return nil
+}
+
+func (m *DumpResponse_Entry) GetSelectors() []*types.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *DumpResponse_Entry) GetSvidExpiresAt() int64 {
+	if m != nil {
+		return m.SvidExpiresAt
+	}
+	return 0
+}
+
+type DumpResponse_Attestation struct {
+	// PID of the attested workload
+	Pid int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Attestation time (in seconds since unix epoch)
+	AttestedAt int64 `protobuf:"varint,2,opt,name=attested_at,json=attestedAt,proto3" json:"attested_at,omitempty"`
+	// Selectors discovered for the workload
+	Selectors []*types.Selector `protobuf:"bytes,3,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Errors returned by the workload attestors
+	Errors []string `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	// IDs of the cached registration entries matching the selectors
+	EntryIds             []string `protobuf:"bytes,5,rep,name=entry_ids,json=entryIds,proto3" json:"entry_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DumpResponse_Attestation) Reset()         { *m = DumpResponse_Attestation{} }
+func (m *DumpResponse_Attestation) String() string { return proto.CompactTextString(m) }
+func (*DumpResponse_Attestation) ProtoMessage()    {}
+func (*DumpResponse_Attestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4e5721b49b138bf5, []int{3, 1}
+}
+
+func (m *DumpResponse_Attestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpResponse_Attestation.Unmarshal(m, b)
+}
+func (m *DumpResponse_Attestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpResponse_Attestation.Marshal(b, m, deterministic)
+}
+func (m *DumpResponse_Attestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpResponse_Attestation.Merge(m, src)
+}
+func (m *DumpResponse_Attestation) XXX_Size() int {
+	return xxx_messageInfo_DumpResponse_Attestation.Size(m)
+}
+func (m *DumpResponse_Attestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpResponse_Attestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpResponse_Attestation proto.InternalMessageInfo
+
+func (m *DumpResponse_Attestation) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *DumpResponse_Attestation) GetAttestedAt() int64 {
+	if m != nil {
+		return m.AttestedAt
+	}
+	return 0
+}
+
+func (m *DumpResponse_Attestation) GetSelectors() []*types.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *DumpResponse_Attestation) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+func (m *DumpResponse_Attestation) GetEntryIds() []string {
+	if m != nil {
+		return m.EntryIds
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*GetInfoRequest)(nil), "spire.agent.debug.v1.GetInfoRequest")
 	proto.RegisterType((*GetInfoResponse)(nil), "spire.agent.debug.v1.GetInfoResponse")
 	proto.RegisterType((*GetInfoResponse_Cert)(nil), "spire.agent.debug.v1.GetInfoResponse.Cert")
+	proto.RegisterType((*DumpRequest)(nil), "spire.agent.debug.v1.DumpRequest")
+	proto.RegisterType((*DumpResponse)(nil), "spire.agent.debug.v1.DumpResponse")
+	proto.RegisterType((*DumpResponse_Entry)(nil), "spire.agent.debug.v1.DumpResponse.Entry")
+	proto.RegisterType((*DumpResponse_Attestation)(nil), "spire.agent.debug.v1.DumpResponse.Attestation")
 }
 
 func init() {
@@ -192,30 +438,45 @@ func init() {
 }
 
 var fileDescriptor_4e5721b49b138bf5 = []byte{
-	// 353 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x4f, 0x4b, 0xeb, 0x40,
-	0x10, 0x27, 0x49, 0xff, 0xd0, 0x29, 0xbc, 0xbe, 0xb7, 0x3c, 0x25, 0x04, 0xc4, 0x50, 0x5a, 0x08,
-	0x3d, 0x6c, 0x68, 0x3d, 0x2a, 0x82, 0xb6, 0x56, 0x72, 0x93, 0x14, 0x3c, 0x78, 0x09, 0xc9, 0x66,
-	0xd3, 0xae, 0xd8, 0x24, 0x76, 0x36, 0xc5, 0x7e, 0x24, 0xbf, 0xa5, 0xec, 0x26, 0x7a, 0x10, 0x45,
-	0x6f, 0x3b, 0xbf, 0x3f, 0xcc, 0xcc, 0x6f, 0x16, 0x46, 0x58, 0x8a, 0x1d, 0xf7, 0xe3, 0x52, 0xf8,
-	0xf1, 0x9a, 0xe7, 0xd2, 0x4f, 0x79, 0x52, 0xad, 0xfd, 0xfd, 0xb4, 0x7e, 0xd0, 0x72, 0x57, 0xc8,
-	0x82, 0xfc, 0xd7, 0x2a, 0xaa, 0x15, 0xb4, 0x26, 0xf6, 0x53, 0xc7, 0xa9, 0xbd, 0xf2, 0x50, 0x72,
-	0xf4, 0xb1, 0x14, 0x59, 0xc6, 0x45, 0x5a, 0x3b, 0x86, 0x7f, 0xe1, 0xcf, 0x2d, 0x97, 0x41, 0x9e,
-	0x15, 0x21, 0x7f, 0xae, 0x38, 0xca, 0xe1, 0xab, 0x09, 0x83, 0x0f, 0x08, 0xcb, 0x22, 0x47, 0x4e,
-	0x02, 0x00, 0xdc, 0x8b, 0x34, 0x62, 0x9b, 0x58, 0xe4, 0xb6, 0xe1, 0x5a, 0x5e, 0x7f, 0x36, 0xa1,
-	0x5f, 0x35, 0xa3, 0x9f, 0xac, 0x74, 0xce, 0x77, 0x32, 0xec, 0x29, 0xf7, 0x5c, 0x99, 0xc9, 0x31,
-	0x74, 0xaa, 0x52, 0x8a, 0x2d, 0xb7, 0x4d, 0xd7, 0xf0, 0xda, 0x61, 0x53, 0x91, 0x53, 0xe8, 0x2b,
-	0x11, 0x46, 0xac, 0xa8, 0x72, 0x69, 0x5b, 0x9a, 0xd4, 0x5d, 0x71, 0xae, 0x10, 0x32, 0x81, 0x7f,
-	0x4f, 0x31, 0xca, 0x08, 0x0f, 0x39, 0x8b, 0xb0, 0x62, 0x8c, 0x23, 0xda, 0x2d, 0xd7, 0xf0, 0xac,
-	0x70, 0xa0, 0x88, 0xd5, 0x21, 0x67, 0xab, 0x1a, 0x76, 0x32, 0x68, 0xa9, 0xbe, 0x64, 0x0c, 0xa6,
-	0x48, 0x6d, 0xc3, 0x35, 0xbc, 0xfe, 0xec, 0xa8, 0x99, 0x57, 0xc7, 0x40, 0x57, 0x77, 0xc1, 0x72,
-	0x79, 0x13, 0x2c, 0x42, 0x53, 0xa4, 0xe4, 0x04, 0x80, 0xbf, 0x28, 0x12, 0xa3, 0x58, 0xea, 0xb9,
-	0xac, 0xb0, 0xd7, 0x20, 0x57, 0x92, 0xd8, 0xd0, 0xc5, 0x2a, 0x79, 0xe4, 0xac, 0x1e, 0xab, 0x17,
-	0xbe, 0x97, 0xb3, 0x08, 0xda, 0x0b, 0xb5, 0x38, 0xb9, 0x87, 0x6e, 0xb3, 0x38, 0x19, 0xfd, 0x90,
-	0x8b, 0x4e, 0xd9, 0x19, 0xff, 0x2a, 0xbd, 0xeb, 0xcb, 0x87, 0x8b, 0xb5, 0x90, 0x9b, 0x2a, 0xa1,
-	0xac, 0xd8, 0x36, 0xb7, 0xf3, 0xeb, 0x73, 0xea, 0xfb, 0xf9, 0xdf, 0x7d, 0x8b, 0x73, 0xfd, 0x48,
-	0x3a, 0x5a, 0x75, 0xf6, 0x16, 0x00, 0x00, 0xff, 0xff, 0x2a, 0x1c, 0xda, 0xd6, 0x3f, 0x02, 0x00,
-	0x00,
+	// 595 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0x55, 0x92, 0x66, 0x5b, 0x6e, 0xf7, 0x85, 0xc5, 0xa6, 0xa8, 0x08, 0x51, 0xaa, 0x0d, 0x55,
+	0x7b, 0x48, 0xd4, 0xee, 0x11, 0x84, 0xd4, 0xb5, 0x1d, 0xca, 0x03, 0x12, 0x72, 0x25, 0x1e, 0x78,
+	0x89, 0xd2, 0xd8, 0xed, 0x8c, 0xd6, 0x24, 0xc4, 0x4e, 0x45, 0x7f, 0x12, 0x3c, 0xf0, 0x8b, 0x78,
+	0xe5, 0x7f, 0x20, 0xdb, 0xc9, 0xfa, 0xc1, 0xe8, 0xfa, 0x66, 0xdf, 0x73, 0x8f, 0x7d, 0xef, 0xf1,
+	0xf1, 0x85, 0x0b, 0x9e, 0xb1, 0x9c, 0xfa, 0x51, 0xc6, 0xfc, 0x68, 0x4a, 0x13, 0xe1, 0x13, 0x3a,
+	0x2e, 0xa6, 0xfe, 0xbc, 0xa3, 0x17, 0x5e, 0x96, 0xa7, 0x22, 0x45, 0xcf, 0x55, 0x96, 0xa7, 0x32,
+	0x3c, 0x0d, 0xcc, 0x3b, 0x8d, 0x86, 0xe6, 0x8a, 0x45, 0x46, 0xb9, 0xcf, 0x33, 0x36, 0x99, 0x50,
+	0x46, 0x34, 0x63, 0x03, 0xa3, 0xf7, 0x34, 0x16, 0x69, 0xae, 0xb1, 0xd6, 0x29, 0x1c, 0x7f, 0xa0,
+	0x22, 0x48, 0x26, 0x29, 0xa6, 0xdf, 0x0a, 0xca, 0x45, 0xeb, 0x87, 0x09, 0x27, 0x0f, 0x21, 0x9e,
+	0xa5, 0x09, 0xa7, 0x28, 0x00, 0xe0, 0x73, 0x46, 0xc2, 0xf8, 0x2e, 0x62, 0x89, 0x6b, 0x34, 0xad,
+	0x76, 0xbd, 0x7b, 0xe5, 0x3d, 0x56, 0x88, 0xb7, 0x41, 0xf5, 0xfa, 0x34, 0x17, 0xd8, 0x91, 0xec,
+	0xbe, 0x24, 0xa3, 0x73, 0xd8, 0x2b, 0x32, 0xc1, 0x66, 0xd4, 0x35, 0x9b, 0x46, 0xdb, 0xc6, 0xe5,
+	0x0e, 0xbd, 0x82, 0xba, 0x4c, 0xe2, 0x61, 0x9c, 0x16, 0x89, 0x70, 0x2d, 0x05, 0xaa, 0x5b, 0x79,
+	0x5f, 0x46, 0xd0, 0x15, 0x3c, 0xbb, 0x8f, 0xb8, 0x08, 0xf9, 0x22, 0x89, 0x43, 0x5e, 0xc4, 0x31,
+	0xe5, 0xdc, 0xad, 0x35, 0x8d, 0xb6, 0x85, 0x4f, 0x24, 0x30, 0x5a, 0x24, 0xf1, 0x48, 0x87, 0x1b,
+	0x13, 0xa8, 0xc9, 0x7b, 0xd1, 0x25, 0x98, 0x8c, 0xb8, 0x46, 0xd3, 0x68, 0xd7, 0xbb, 0x67, 0x65,
+	0xbd, 0x4a, 0x06, 0x6f, 0xf4, 0x29, 0xb8, 0xbd, 0x1d, 0x06, 0x03, 0x6c, 0x32, 0x82, 0x5e, 0x02,
+	0xd0, 0xef, 0x12, 0xe4, 0x61, 0x24, 0x54, 0x5d, 0x16, 0x76, 0xca, 0x48, 0x4f, 0x20, 0x17, 0xf6,
+	0x79, 0x31, 0xfe, 0x4a, 0x63, 0x5d, 0x96, 0x83, 0xab, 0x6d, 0xeb, 0x08, 0xea, 0x83, 0x62, 0x96,
+	0x55, 0xd2, 0xfd, 0xa9, 0xc1, 0xa1, 0xde, 0x97, 0xba, 0xdd, 0xc0, 0x3e, 0x4d, 0x44, 0xce, 0x28,
+	0x2f, 0x45, 0x6b, 0x3f, 0x2e, 0xda, 0x2a, 0xc9, 0x1b, 0x26, 0x22, 0x5f, 0xe0, 0x8a, 0x88, 0x30,
+	0x1c, 0x46, 0x42, 0x50, 0x2e, 0x22, 0xc1, 0xd2, 0x84, 0xbb, 0xa6, 0x3a, 0xc8, 0xdb, 0xe1, 0xa0,
+	0xde, 0x92, 0x86, 0xd7, 0xce, 0x40, 0x1d, 0x38, 0x53, 0xc4, 0x50, 0xbd, 0xea, 0x4a, 0xef, 0x96,
+	0xea, 0x1d, 0x29, 0x70, 0x34, 0x67, 0x64, 0x58, 0x89, 0xd0, 0xf8, 0x6d, 0x80, 0xad, 0x2a, 0x43,
+	0xc7, 0x0f, 0xa2, 0x3a, 0x4a, 0xbd, 0x2e, 0x38, 0xda, 0x70, 0x21, 0x23, 0xae, 0xb9, 0x4d, 0xeb,
+	0x03, 0x9d, 0x17, 0x28, 0x4e, 0x16, 0xe5, 0xb2, 0x02, 0x46, 0x5c, 0x6b, 0x2b, 0x47, 0xe7, 0x05,
+	0x04, 0x5d, 0x83, 0x53, 0x99, 0x57, 0x3e, 0xbc, 0xf5, 0x2f, 0xa7, 0x44, 0xf1, 0x32, 0x0f, 0xbd,
+	0x81, 0x93, 0xcd, 0x1e, 0x6d, 0xd5, 0xe3, 0x11, 0x5f, 0x6b, 0xef, 0xa7, 0x01, 0xf5, 0x15, 0xbd,
+	0xd0, 0x29, 0x58, 0x59, 0xd9, 0xa5, 0x8d, 0xe5, 0x52, 0x1a, 0x54, 0x6b, 0x48, 0xc9, 0xd2, 0x25,
+	0x50, 0x85, 0x7a, 0x62, 0xbd, 0x3e, 0x6b, 0xc7, 0xfa, 0xce, 0x61, 0x8f, 0xe6, 0x79, 0xd5, 0x91,
+	0x83, 0xcb, 0x1d, 0x7a, 0x01, 0x8e, 0x34, 0xc0, 0x22, 0x64, 0x84, 0xbb, 0xb6, 0x82, 0x0e, 0x54,
+	0x20, 0x20, 0xbc, 0xfb, 0xcb, 0x00, 0x7b, 0x20, 0x9f, 0x1c, 0x7d, 0x86, 0xfd, 0xf2, 0xc3, 0xa1,
+	0x8b, 0x27, 0xfe, 0xa3, 0xb2, 0x68, 0xe3, 0x72, 0xa7, 0x5f, 0x8b, 0x3e, 0x42, 0x4d, 0x5a, 0x09,
+	0xbd, 0xde, 0x66, 0x33, 0x7d, 0x62, 0xeb, 0x69, 0x27, 0xde, 0xbc, 0xff, 0xf2, 0x6e, 0xca, 0xc4,
+	0x5d, 0x31, 0xf6, 0xe2, 0x74, 0x56, 0x8e, 0x27, 0x5f, 0x4f, 0x25, 0x35, 0x86, 0xfc, 0xff, 0x4d,
+	0xbe, 0xb7, 0x6a, 0x31, 0xde, 0x53, 0x59, 0xd7, 0x7f, 0x07, 0x00, 0x33, 0xaa, 0xde, 0x64, 0x22,
+	0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -232,6 +493,9 @@ const _ = grpc.SupportPackageIsVersion6
 type DebugClient interface {
 	// Get information about SPIRE agent
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// Dump the registration entries cached by the agent and the results of
+	// the most recent workload attestations
+	Dump(ctx context.Context, in *DumpRequest, opts ...grpc.CallOption) (*DumpResponse, error)
 }
 
 type debugClient struct {
@@ -251,10 +515,22 @@ func (c *debugClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...g
 	return out, nil
 }
 
+func (c *debugClient) Dump(ctx context.Context, in *DumpRequest, opts ...grpc.CallOption) (*DumpResponse, error) {
+	out := new(DumpResponse)
+	err := c.cc.Invoke(ctx, "/spire.agent.debug.v1.Debug/Dump", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DebugServer is the server API for Debug service.
 type DebugServer interface {
 	// Get information about SPIRE agent
 	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// Dump the registration entries cached by the agent and the results of
+	// the most recent workload attestations
+	Dump(context.Context, *DumpRequest) (*DumpResponse, error)
 }
 
 // UnimplementedDebugServer can be embedded to have forward compatible implementations.
@@ -264,6 +540,9 @@ type UnimplementedDebugServer struct {
 func (*UnimplementedDebugServer) GetInfo(ctx context.Context, req *GetInfoRequest) (*GetInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
 }
+func (*UnimplementedDebugServer) Dump(ctx context.Context, req *DumpRequest) (*DumpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Dump not implemented")
+}
 
 func RegisterDebugServer(s *grpc.Server, srv DebugServer) {
 	s.RegisterService(&_Debug_serviceDesc, srv)
@@ -287,6 +566,24 @@ func _Debug_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Debug_Dump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).Dump(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.agent.debug.v1.Debug/Dump",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).Dump(ctx, req.(*DumpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Debug_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.agent.debug.v1.Debug",
 	HandlerType: (*DebugServer)(nil),
@@ -295,6 +592,10 @@ var _Debug_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetInfo",
 			Handler:    _Debug_GetInfo_Handler,
 		},
+		{
+			MethodName: "Dump",
+			Handler:    _Debug_Dump_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "spire/api/agent/debug/v1/debug.proto",
@@ -101,10 +101,16 @@ type ListAgentsRequest_Filter struct {
 	// Filters agents to those satisfying the selector match.
 	BySelectorMatch *types.SelectorMatch `protobuf:"bytes,2,opt,name=by_selector_match,json=bySelectorMatch,proto3" json:"by_selector_match,omitempty"`
 	// Filters agents to those that are banned.
-	ByBanned             *wrappers.BoolValue `protobuf:"bytes,3,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	ByBanned *wrappers.BoolValue `protobuf:"bytes,3,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
+	// Filters agents to those whose X509-SVID expires before the given
+	// time, in seconds since the Unix epoch. Ignored if zero.
+	ByExpiresBefore int64 `protobuf:"varint,4,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	// Filters agents to those whose X509-SVID expires at or after the
+	// given time, in seconds since the Unix epoch. Ignored if zero.
+	ByExpiresAfter       int64    `protobuf:"varint,5,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListAgentsRequest_Filter) Reset()         { *m = ListAgentsRequest_Filter{} }
@@ -153,6 +159,20 @@ func (m *ListAgentsRequest_Filter) GetByBanned() *wrappers.BoolValue {
 	return nil
 }
 
+func (m *ListAgentsRequest_Filter) GetByExpiresBefore() int64 {
+	if m != nil {
+		return m.ByExpiresBefore
+	}
+	return 0
+}
+
+func (m *ListAgentsRequest_Filter) GetByExpiresAfter() int64 {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return 0
+}
+
 type ListAgentsResponse struct {
 	// The agents.
 	Agents []*types.Agent `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
@@ -333,6 +353,46 @@ func (m *BanAgentRequest) GetId() *types.SPIFFEID {
 	return nil
 }
 
+type ReattestAgentRequest struct {
+	// Required. The SPIFFE ID of the agent.
+	Id                   *types.SPIFFEID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ReattestAgentRequest) Reset()         { *m = ReattestAgentRequest{} }
+func (m *ReattestAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*ReattestAgentRequest) ProtoMessage()    {}
+func (*ReattestAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_938d8685c088801c, []int{5}
+}
+
+func (m *ReattestAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReattestAgentRequest.Unmarshal(m, b)
+}
+func (m *ReattestAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReattestAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *ReattestAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReattestAgentRequest.Merge(m, src)
+}
+func (m *ReattestAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_ReattestAgentRequest.Size(m)
+}
+func (m *ReattestAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReattestAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReattestAgentRequest proto.InternalMessageInfo
+
+func (m *ReattestAgentRequest) GetId() *types.SPIFFEID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
 type AttestAgentRequest struct {
 	// Required. The data for the step in the attestation flow.
 	//
@@ -349,7 +409,7 @@ func (m *AttestAgentRequest) Reset()         { *m = AttestAgentRequest{} }
 func (m *AttestAgentRequest) String() string { return proto.CompactTextString(m) }
 func (*AttestAgentRequest) ProtoMessage()    {}
 func (*AttestAgentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_938d8685c088801c, []int{5}
+	return fileDescriptor_938d8685c088801c, []int{6}
 }
 
 func (m *AttestAgentRequest) XXX_Unmarshal(b []byte) error {
@@ -429,7 +489,7 @@ func (m *AttestAgentRequest_Params) Reset()         { *m = AttestAgentRequest_Pa
 func (m *AttestAgentRequest_Params) String() string { return proto.CompactTextString(m) }
 func (*AttestAgentRequest_Params) ProtoMessage()    {}
 func (*AttestAgentRequest_Params) Descriptor() ([]byte, []int) {
-	return fileDescriptor_938d8685c088801c, []int{5, 0}
+	return fileDescriptor_938d8685c088801c, []int{6, 0}
 }
 
 func (m *AttestAgentRequest_Params) XXX_Unmarshal(b []byte) error {
@@ -478,7 +538,7 @@ func (m *AttestAgentResponse) Reset()         { *m = AttestAgentResponse{} }
 func (m *AttestAgentResponse) String() string { return proto.CompactTextString(m) }
 func (*AttestAgentResponse) ProtoMessage()    {}
 func (*AttestAgentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_938d8685c088801c, []int{6}
+	return fileDescriptor_938d8685c088801c, []int{7}
 }
 
 func (m *AttestAgentResponse) XXX_Unmarshal(b []byte) error {
@@ -556,7 +616,7 @@ func (m *AttestAgentResponse_Result) Reset()         { *m = AttestAgentResponse_
 func (m *AttestAgentResponse_Result) String() string { return proto.CompactTextString(m) }
 func (*AttestAgentResponse_Result) ProtoMessage()    {}
 func (*AttestAgentResponse_Result) Descriptor() ([]byte, []int) {
-	return fileDescriptor_938d8685c088801c, []int{6, 0}
+	return fileDescriptor_938d8685c088801c, []int{7, 0}
 }
 
 func (m *AttestAgentResponse_Result) XXX_Unmarshal(b []byte) error {
@@ -596,7 +656,7 @@ func (m *RenewAgentRequest) Reset()         { *m = RenewAgentRequest{} }
 func (m *RenewAgentRequest) String() string { return proto.CompactTextString(m) }
 func (*RenewAgentRequest) ProtoMessage()    {}
 func (*RenewAgentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_938d8685c088801c, []int{7}
+	return fileDescriptor_938d8685c088801c, []int{8}
 }
 
 func (m *RenewAgentRequest) XXX_Unmarshal(b []byte) error {
@@ -636,7 +696,7 @@ func (m *RenewAgentResponse) Reset()         { *m = RenewAgentResponse{} }
 func (m *RenewAgentResponse) String() string { return proto.CompactTextString(m) }
 func (*RenewAgentResponse) ProtoMessage()    {}
 func (*RenewAgentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_938d8685c088801c, []int{8}
+	return fileDescriptor_938d8685c088801c, []int{9}
 }
 
 func (m *RenewAgentResponse) XXX_Unmarshal(b []byte) error {
@@ -672,18 +732,29 @@ type CreateJoinTokenRequest struct {
 	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
 	// An optional SPIFFE ID to assign to the agent beyond that given by
 	// join token attestation. If set, this results in an entry being created
-	// that maps the attestation assigned agent ID to this ID.
-	AgentId              *types.SPIFFEID `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	// that maps the attestation assigned agent ID to this ID. Cannot be set
+	// for tokens that can be used more than once.
+	AgentId *types.SPIFFEID `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// An optional number of times the token can be used to attest an agent.
+	// Each agent attesting with a token that can be used more than once is
+	// assigned a unique agent ID. Zero or one means the token can only be
+	// used once.
+	MaxUses int32 `protobuf:"varint,4,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// An optional list of CIDRs. When set, the token can only be used by
+	// agents connecting from an IP address within one of them.
+	AllowedCidrs []string `protobuf:"bytes,5,rep,name=allowed_cidrs,json=allowedCidrs,proto3" json:"allowed_cidrs,omitempty"`
+	// Optional selectors assigned to the agents attesting with the token.
+	Selectors            []*types.Selector `protobuf:"bytes,6,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *CreateJoinTokenRequest) Reset()         { *m = CreateJoinTokenRequest{} }
 func (m *CreateJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateJoinTokenRequest) ProtoMessage()    {}
 func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_938d8685c088801c, []int{9}
+	return fileDescriptor_938d8685c088801c, []int{10}
 }
 
 func (m *CreateJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -725,6 +796,27 @@ func (m *CreateJoinTokenRequest) GetAgentId() *types.SPIFFEID {
 	return nil
 }
 
+func (m *CreateJoinTokenRequest) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *CreateJoinTokenRequest) GetAllowedCidrs() []string {
+	if m != nil {
+		return m.AllowedCidrs
+	}
+	return nil
+}
+
+func (m *CreateJoinTokenRequest) GetSelectors() []*types.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
 type AgentX509SVIDParams struct {
 	// Required. The ASN.1 DER encoded Certificate Signing Request (CSR). The
 	// CSR is only used to convey the public key; other fields in the CSR are
@@ -739,7 +831,7 @@ func (m *AgentX509SVIDParams) Reset()         { *m = AgentX509SVIDParams{} }
 func (m *AgentX509SVIDParams) String() string { return proto.CompactTextString(m) }
 func (*AgentX509SVIDParams) ProtoMessage()    {}
 func (*AgentX509SVIDParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_938d8685c088801c, []int{10}
+	return fileDescriptor_938d8685c088801c, []int{11}
 }
 
 func (m *AgentX509SVIDParams) XXX_Unmarshal(b []byte) error {
@@ -767,6 +859,155 @@ func (m *AgentX509SVIDParams) GetCsr() []byte {
 	return nil
 }
 
+type CountAgentsRequest struct {
+	// Filters the agents counted.
+	Filter               *CountAgentsRequest_Filter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *CountAgentsRequest) Reset()         { *m = CountAgentsRequest{} }
+func (m *CountAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*CountAgentsRequest) ProtoMessage()    {}
+func (*CountAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_938d8685c088801c, []int{12}
+}
+
+func (m *CountAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountAgentsRequest.Unmarshal(m, b)
+}
+func (m *CountAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountAgentsRequest.Marshal(b, m, deterministic)
+}
+func (m *CountAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountAgentsRequest.Merge(m, src)
+}
+func (m *CountAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_CountAgentsRequest.Size(m)
+}
+func (m *CountAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountAgentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountAgentsRequest proto.InternalMessageInfo
+
+func (m *CountAgentsRequest) GetFilter() *CountAgentsRequest_Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type CountAgentsRequest_Filter struct {
+	// Filters agents to those matching the attestation type.
+	ByAttestationType string `protobuf:"bytes,1,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	// Filters agents to those that are banned.
+	ByBanned *wrappers.BoolValue `protobuf:"bytes,2,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
+	// Filters agents to those whose X509-SVID expires before the given
+	// time, in seconds since the Unix epoch. Ignored if zero.
+	ByExpiresBefore int64 `protobuf:"varint,3,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	// Filters agents to those whose X509-SVID expires at or after the
+	// given time, in seconds since the Unix epoch. Ignored if zero.
+	ByExpiresAfter       int64    `protobuf:"varint,4,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountAgentsRequest_Filter) Reset()         { *m = CountAgentsRequest_Filter{} }
+func (m *CountAgentsRequest_Filter) String() string { return proto.CompactTextString(m) }
+func (*CountAgentsRequest_Filter) ProtoMessage()    {}
+func (*CountAgentsRequest_Filter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_938d8685c088801c, []int{12, 0}
+}
+
+func (m *CountAgentsRequest_Filter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountAgentsRequest_Filter.Unmarshal(m, b)
+}
+func (m *CountAgentsRequest_Filter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountAgentsRequest_Filter.Marshal(b, m, deterministic)
+}
+func (m *CountAgentsRequest_Filter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountAgentsRequest_Filter.Merge(m, src)
+}
+func (m *CountAgentsRequest_Filter) XXX_Size() int {
+	return xxx_messageInfo_CountAgentsRequest_Filter.Size(m)
+}
+func (m *CountAgentsRequest_Filter) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountAgentsRequest_Filter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountAgentsRequest_Filter proto.InternalMessageInfo
+
+func (m *CountAgentsRequest_Filter) GetByAttestationType() string {
+	if m != nil {
+		return m.ByAttestationType
+	}
+	return ""
+}
+
+func (m *CountAgentsRequest_Filter) GetByBanned() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByBanned
+	}
+	return nil
+}
+
+func (m *CountAgentsRequest_Filter) GetByExpiresBefore() int64 {
+	if m != nil {
+		return m.ByExpiresBefore
+	}
+	return 0
+}
+
+func (m *CountAgentsRequest_Filter) GetByExpiresAfter() int64 {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return 0
+}
+
+type CountAgentsResponse struct {
+	// The number of agents matching the filter.
+	Count                int32    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountAgentsResponse) Reset()         { *m = CountAgentsResponse{} }
+func (m *CountAgentsResponse) String() string { return proto.CompactTextString(m) }
+func (*CountAgentsResponse) ProtoMessage()    {}
+func (*CountAgentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_938d8685c088801c, []int{13}
+}
+
+func (m *CountAgentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountAgentsResponse.Unmarshal(m, b)
+}
+func (m *CountAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountAgentsResponse.Marshal(b, m, deterministic)
+}
+func (m *CountAgentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountAgentsResponse.Merge(m, src)
+}
+func (m *CountAgentsResponse) XXX_Size() int {
+	return xxx_messageInfo_CountAgentsResponse.Size(m)
+}
+func (m *CountAgentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountAgentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountAgentsResponse proto.InternalMessageInfo
+
+func (m *CountAgentsResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ListAgentsRequest)(nil), "spire.api.server.agent.v1.ListAgentsRequest")
 	proto.RegisterType((*ListAgentsRequest_Filter)(nil), "spire.api.server.agent.v1.ListAgentsRequest.Filter")
@@ -774,6 +1015,7 @@ func init() {
 	proto.RegisterType((*GetAgentRequest)(nil), "spire.api.server.agent.v1.GetAgentRequest")
 	proto.RegisterType((*DeleteAgentRequest)(nil), "spire.api.server.agent.v1.DeleteAgentRequest")
 	proto.RegisterType((*BanAgentRequest)(nil), "spire.api.server.agent.v1.BanAgentRequest")
+	proto.RegisterType((*ReattestAgentRequest)(nil), "spire.api.server.agent.v1.ReattestAgentRequest")
 	proto.RegisterType((*AttestAgentRequest)(nil), "spire.api.server.agent.v1.AttestAgentRequest")
 	proto.RegisterType((*AttestAgentRequest_Params)(nil), "spire.api.server.agent.v1.AttestAgentRequest.Params")
 	proto.RegisterType((*AttestAgentResponse)(nil), "spire.api.server.agent.v1.AttestAgentResponse")
@@ -782,6 +1024,9 @@ func init() {
 	proto.RegisterType((*RenewAgentResponse)(nil), "spire.api.server.agent.v1.RenewAgentResponse")
 	proto.RegisterType((*CreateJoinTokenRequest)(nil), "spire.api.server.agent.v1.CreateJoinTokenRequest")
 	proto.RegisterType((*AgentX509SVIDParams)(nil), "spire.api.server.agent.v1.AgentX509SVIDParams")
+	proto.RegisterType((*CountAgentsRequest)(nil), "spire.api.server.agent.v1.CountAgentsRequest")
+	proto.RegisterType((*CountAgentsRequest_Filter)(nil), "spire.api.server.agent.v1.CountAgentsRequest.Filter")
+	proto.RegisterType((*CountAgentsResponse)(nil), "spire.api.server.agent.v1.CountAgentsResponse")
 }
 
 func init() {
@@ -789,65 +1034,77 @@ func init() {
 }
 
 var fileDescriptor_938d8685c088801c = []byte{
-	// 914 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x71, 0x6f, 0xdb, 0x44,
-	0x14, 0x9f, 0xd3, 0x26, 0x24, 0x2f, 0x43, 0x21, 0x57, 0x28, 0xc1, 0x65, 0x53, 0x65, 0x69, 0x10,
-	0x26, 0xb0, 0xd3, 0x96, 0x6a, 0x4c, 0x13, 0x9a, 0x16, 0xba, 0xb0, 0x0e, 0x36, 0xaa, 0x6b, 0x35,
-	0x21, 0x40, 0xb2, 0xce, 0xc9, 0x6b, 0x6a, 0xea, 0xd8, 0x9e, 0xef, 0xd2, 0xcd, 0xfb, 0x93, 0x6f,
-	0xc0, 0x27, 0x40, 0xe2, 0x5b, 0xf0, 0x37, 0x5f, 0x6c, 0xf2, 0x9d, 0x9d, 0xd8, 0x49, 0x6a, 0xb5,
-	0xfd, 0xef, 0x72, 0xbf, 0xdf, 0x7b, 0xf7, 0xbb, 0xdf, 0x7b, 0xf7, 0x62, 0xb8, 0xc7, 0x43, 0x37,
-	0x42, 0x8b, 0x85, 0xae, 0xc5, 0x31, 0xba, 0xc0, 0xc8, 0x62, 0x63, 0xf4, 0x85, 0x75, 0xb1, 0xa3,
-	0x16, 0x66, 0x18, 0x05, 0x22, 0x20, 0x9f, 0x49, 0x9a, 0xc9, 0x42, 0xd7, 0x54, 0x34, 0x53, 0xa1,
-	0x17, 0x3b, 0xfa, 0xd6, 0x38, 0x08, 0xc6, 0x1e, 0x5a, 0x92, 0xe8, 0x4c, 0x4f, 0x2d, 0x9c, 0x84,
-	0x22, 0x56, 0x71, 0xfa, 0xdd, 0x45, 0xf0, 0x4d, 0xc4, 0xc2, 0x10, 0x23, 0x9e, 0xe2, 0x9f, 0xaa,
-	0xe3, 0x45, 0x1c, 0x22, 0xcf, 0x1f, 0xa8, 0xdf, 0x29, 0x00, 0x42, 0x20, 0x17, 0x4c, 0xb8, 0x81,
-	0x9f, 0xc2, 0x5b, 0x79, 0xf8, 0xcf, 0xc0, 0xf5, 0x45, 0x70, 0x8e, 0x19, 0xa8, 0xe7, 0x41, 0x8e,
-	0x1e, 0x0e, 0x45, 0x10, 0xad, 0xc4, 0x42, 0xf7, 0xf4, 0x14, 0xdd, 0xd1, 0x2a, 0xec, 0xed, 0x7e,
-	0xef, 0x21, 0xbf, 0xc8, 0x30, 0xe3, 0xef, 0x35, 0x68, 0xff, 0xec, 0x72, 0xf1, 0x24, 0xd1, 0xc8,
-	0x29, 0xbe, 0x9e, 0x22, 0x17, 0xe4, 0x27, 0xa8, 0x9d, 0xba, 0x9e, 0xc0, 0xa8, 0xa3, 0x6d, 0x6b,
-	0xdd, 0xe6, 0xee, 0x9e, 0x79, 0xa9, 0x4f, 0xe6, 0x52, 0xb4, 0x39, 0x90, 0xa1, 0x34, 0x4d, 0x41,
-	0x1e, 0x40, 0x33, 0x98, 0x8a, 0x70, 0x2a, 0xec, 0x09, 0xe3, 0xe7, 0x9d, 0x8a, 0xcc, 0xb8, 0x99,
-	0x66, 0x94, 0xa2, 0x4c, 0x19, 0xff, 0x82, 0xf1, 0x73, 0x0a, 0x8a, 0x9a, 0xac, 0xc9, 0x16, 0x34,
-	0x42, 0x36, 0x46, 0x9b, 0xbb, 0xef, 0xb0, 0xb3, 0xb6, 0xad, 0x75, 0xab, 0xb4, 0x9e, 0x6c, 0x1c,
-	0xbb, 0xef, 0x90, 0xdc, 0x01, 0x90, 0xa0, 0x34, 0xa8, 0xb3, 0xbe, 0xad, 0x75, 0x1b, 0x54, 0xd2,
-	0x4f, 0x92, 0x0d, 0xfd, 0x3f, 0x0d, 0x6a, 0x4a, 0x07, 0x31, 0x61, 0xc3, 0x89, 0xed, 0x9c, 0xd7,
-	0x76, 0x72, 0xa8, 0xbc, 0x59, 0x83, 0xb6, 0x9d, 0xf8, 0xc9, 0x1c, 0x39, 0x89, 0x43, 0x24, 0x03,
-	0x68, 0x3b, 0xb1, 0x9d, 0xf9, 0x6b, 0x4f, 0x98, 0x18, 0x9e, 0xa5, 0xaa, 0xf5, 0x82, 0xea, 0xe3,
-	0x94, 0xf2, 0x22, 0x61, 0xd0, 0x96, 0x13, 0x17, 0x36, 0xc8, 0x03, 0x68, 0x38, 0xb1, 0xed, 0x30,
-	0xdf, 0xc7, 0x91, 0x94, 0x9f, 0xc4, 0xab, 0xbe, 0x31, 0xb3, 0xbe, 0x31, 0xfb, 0x41, 0xe0, 0xbd,
-	0x62, 0xde, 0x14, 0x69, 0xdd, 0x89, 0xfb, 0x92, 0x6b, 0x9c, 0x01, 0xc9, 0x9b, 0xca, 0xc3, 0xc0,
-	0xe7, 0x48, 0xee, 0x43, 0x4d, 0x7a, 0xce, 0x3b, 0xda, 0xf6, 0x5a, 0xb7, 0xb9, 0x4b, 0x96, 0x1d,
-	0xa4, 0x29, 0x83, 0x7c, 0x01, 0x2d, 0x1f, 0xdf, 0x0a, 0x3b, 0xe7, 0x50, 0x45, 0x5e, 0xf7, 0xc3,
-	0x64, 0xfb, 0x28, 0x73, 0xc9, 0x78, 0x0d, 0xad, 0x1f, 0x51, 0x1d, 0x94, 0x95, 0xfe, 0x1e, 0x54,
-	0xdc, 0x51, 0x5a, 0xf6, 0x4f, 0x8a, 0xd7, 0x3d, 0x3a, 0x1c, 0x0c, 0x9e, 0x1e, 0x1e, 0xd0, 0x8a,
-	0x3b, 0xba, 0x71, 0x51, 0x8d, 0x47, 0x40, 0x0e, 0xd0, 0x43, 0x81, 0x37, 0x38, 0xd5, 0xf8, 0x0e,
-	0x5a, 0x7d, 0xe6, 0xdf, 0x24, 0xf2, 0xdf, 0x0a, 0x10, 0x55, 0xe8, 0x42, 0xf4, 0x4b, 0xa8, 0x85,
-	0x2c, 0x62, 0x13, 0x9e, 0x66, 0xf8, 0xb6, 0xa4, 0xd1, 0x97, 0xc3, 0xcd, 0x23, 0x19, 0xfb, 0xec,
-	0x16, 0x4d, 0xb3, 0x10, 0x0b, 0xc8, 0xf0, 0x8c, 0x79, 0x1e, 0xfa, 0x63, 0xb4, 0xa3, 0xb4, 0x74,
-	0xd2, 0x9d, 0xdb, 0xcf, 0x6e, 0xd1, 0xf6, 0x0c, 0xcb, 0xaa, 0xaa, 0xff, 0xa5, 0x41, 0x4d, 0x65,
-	0x21, 0x3d, 0x58, 0x1f, 0x31, 0xc1, 0x52, 0x25, 0x9f, 0x17, 0xbd, 0x9c, 0xf7, 0xe8, 0x01, 0x13,
-	0x8c, 0x4a, 0x26, 0x19, 0xcc, 0xd4, 0x2b, 0xff, 0xcd, 0x32, 0xf5, 0xc9, 0xe2, 0xd7, 0xfd, 0xde,
-	0xc3, 0xe3, 0x57, 0x87, 0x07, 0xea, 0xc4, 0x4c, 0x75, 0xbf, 0x06, 0xeb, 0x5c, 0x60, 0x68, 0xfc,
-	0xaf, 0xc1, 0x46, 0xe1, 0x96, 0x69, 0xeb, 0xfd, 0x02, 0xb5, 0x08, 0xf9, 0xd4, 0x13, 0xa9, 0xb6,
-	0xfd, 0xab, 0xba, 0xa4, 0xe2, 0x4d, 0x2a, 0x83, 0x13, 0x9b, 0x54, 0x1a, 0x72, 0x17, 0x1a, 0x33,
-	0x2b, 0x66, 0xee, 0xcc, 0xb7, 0xf4, 0x3d, 0xa8, 0xa9, 0x18, 0xf2, 0x15, 0xac, 0x27, 0xd3, 0x6a,
-	0x65, 0x81, 0xb3, 0xdb, 0x50, 0x49, 0x99, 0xdd, 0xe2, 0x77, 0x68, 0x53, 0xf4, 0xf1, 0x4d, 0xa1,
-	0xd0, 0x83, 0x85, 0x42, 0xdf, 0xd0, 0x2a, 0xe3, 0x31, 0x90, 0x7c, 0xf2, 0xd4, 0xa0, 0xab, 0xab,
-	0x34, 0x22, 0xd8, 0xfc, 0x21, 0x42, 0x26, 0xf0, 0x79, 0xe0, 0xfa, 0xf2, 0x15, 0x66, 0x12, 0x3f,
-	0x82, 0x35, 0x21, 0x3c, 0x99, 0xa3, 0x4a, 0x93, 0x25, 0xf9, 0x18, 0xaa, 0xf9, 0xc7, 0xab, 0x7e,
-	0x90, 0x1e, 0xd4, 0xa5, 0x54, 0xdb, 0xcd, 0xc6, 0xca, 0x25, 0x7d, 0xff, 0x81, 0xa4, 0x1d, 0x8e,
-	0x8c, 0x2f, 0x61, 0x63, 0xc5, 0x9d, 0x92, 0x03, 0x87, 0x5c, 0x8d, 0xf8, 0xdb, 0x34, 0x59, 0xee,
-	0xfe, 0x53, 0x85, 0xaa, 0x64, 0x12, 0x17, 0x60, 0x3e, 0x83, 0xc8, 0xd7, 0xd7, 0x99, 0xff, 0xfa,
-	0x37, 0x57, 0x64, 0xa7, 0xe6, 0x3d, 0x87, 0x7a, 0x36, 0x84, 0xc8, 0xfd, 0x92, 0xd0, 0x85, 0x49,
-	0xa5, 0xaf, 0x18, 0x80, 0xe4, 0x04, 0x9a, 0xb9, 0xe9, 0x42, 0xca, 0x94, 0x2c, 0x4f, 0x21, 0x7d,
-	0x73, 0x69, 0x3c, 0x3f, 0x4d, 0xfe, 0xf3, 0xc9, 0x4b, 0xa8, 0x67, 0x63, 0xa7, 0x54, 0xe1, 0xc2,
-	0x6c, 0xba, 0x34, 0x5f, 0x08, 0xcd, 0xdc, 0x33, 0x29, 0x55, 0xb9, 0x3c, 0x74, 0x74, 0xf3, 0x7a,
-	0xaf, 0xaf, 0xab, 0xf5, 0xb4, 0xa4, 0x9c, 0xf3, 0xb6, 0x2d, 0x2d, 0xe7, 0xd2, 0xd3, 0x29, 0x2d,
-	0xe7, 0x8a, 0xb7, 0xf0, 0x07, 0xb4, 0x16, 0x1a, 0x9c, 0xec, 0x94, 0x64, 0x58, 0xfd, 0x18, 0xf4,
-	0xe2, 0x5f, 0xc9, 0x0c, 0xee, 0x3f, 0xfe, 0xed, 0xfb, 0xb1, 0x2b, 0xce, 0xa6, 0x8e, 0x39, 0x0c,
-	0x26, 0xe9, 0x87, 0x8e, 0xa5, 0xbe, 0x6f, 0xa4, 0xc9, 0xd6, 0xa5, 0xdf, 0x7d, 0x8f, 0xe4, 0xc2,
-	0xa9, 0x49, 0xda, 0xde, 0xfb, 0x00, 0x00, 0x00, 0xff, 0xff, 0x20, 0x85, 0x57, 0x74, 0x21, 0x0a,
-	0x00, 0x00,
+	// 1113 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xef, 0x72, 0xda, 0x46,
+	0x10, 0x8f, 0xc0, 0x28, 0xb0, 0x24, 0x75, 0x7c, 0x76, 0x5d, 0x22, 0x37, 0x19, 0x86, 0x4e, 0x5a,
+	0xea, 0xd6, 0xc2, 0x7f, 0xea, 0x49, 0x33, 0x99, 0x4c, 0xc6, 0xd8, 0xa6, 0x71, 0x9a, 0xa4, 0x9e,
+	0xb3, 0x9b, 0xc9, 0xb4, 0x9d, 0xd1, 0x9c, 0xe0, 0xc0, 0xaa, 0x85, 0xa4, 0xe8, 0x0e, 0xdb, 0xe4,
+	0x63, 0x5f, 0xa0, 0xef, 0xd0, 0xcf, 0x7d, 0x8b, 0x7e, 0xe8, 0xa3, 0xf8, 0x35, 0x3a, 0xba, 0x3b,
+	0x81, 0x04, 0x98, 0x21, 0xf4, 0xdb, 0xb1, 0xfb, 0xdb, 0x3f, 0xb7, 0xbb, 0xb7, 0x3f, 0x04, 0x8f,
+	0x58, 0xe0, 0x84, 0xb4, 0x46, 0x02, 0xa7, 0xc6, 0x68, 0x78, 0x41, 0xc3, 0x1a, 0xe9, 0x50, 0x8f,
+	0xd7, 0x2e, 0xb6, 0xe4, 0xc1, 0x0c, 0x42, 0x9f, 0xfb, 0xe8, 0xbe, 0x80, 0x99, 0x24, 0x70, 0x4c,
+	0x09, 0x33, 0xa5, 0xf6, 0x62, 0xcb, 0x58, 0xeb, 0xf8, 0x7e, 0xc7, 0xa5, 0x35, 0x01, 0xb4, 0x7b,
+	0xed, 0x1a, 0xed, 0x06, 0xbc, 0x2f, 0xed, 0x8c, 0x87, 0xa3, 0xca, 0xcb, 0x90, 0x04, 0x01, 0x0d,
+	0x99, 0xd2, 0x7f, 0x26, 0xc3, 0xf3, 0x7e, 0x40, 0x59, 0x32, 0xa0, 0xf1, 0x20, 0xa5, 0xe0, 0x9c,
+	0x32, 0x4e, 0xb8, 0xe3, 0x7b, 0x4a, 0xbd, 0x96, 0x54, 0xff, 0xee, 0x3b, 0x1e, 0xf7, 0xcf, 0x69,
+	0xac, 0x34, 0x92, 0x4a, 0x46, 0x5d, 0xda, 0xe4, 0x7e, 0x38, 0x51, 0x17, 0x38, 0xed, 0x36, 0x75,
+	0x5a, 0x93, 0x74, 0x57, 0xbb, 0x9b, 0x4f, 0xd8, 0x45, 0xac, 0xab, 0x5c, 0x67, 0x61, 0xe9, 0x95,
+	0xc3, 0xf8, 0x5e, 0x94, 0x23, 0xc3, 0xf4, 0x7d, 0x8f, 0x32, 0x8e, 0x7e, 0x04, 0xbd, 0xed, 0xb8,
+	0x9c, 0x86, 0x25, 0xad, 0xac, 0x55, 0x8b, 0xdb, 0x3b, 0xe6, 0x8d, 0x75, 0x32, 0xc7, 0xac, 0xcd,
+	0x86, 0x30, 0xc5, 0xca, 0x05, 0x7a, 0x0c, 0x45, 0xbf, 0xc7, 0x83, 0x1e, 0xb7, 0xba, 0x84, 0x9d,
+	0x97, 0x32, 0xc2, 0xe3, 0xaa, 0xf2, 0x28, 0x92, 0x32, 0x85, 0xfd, 0x6b, 0xc2, 0xce, 0x31, 0x48,
+	0x68, 0x74, 0x46, 0x6b, 0x50, 0x08, 0x48, 0x87, 0x5a, 0xcc, 0xf9, 0x40, 0x4b, 0xd9, 0xb2, 0x56,
+	0xcd, 0xe1, 0x7c, 0x24, 0x38, 0x71, 0x3e, 0x50, 0xf4, 0x00, 0x40, 0x28, 0x45, 0x81, 0x4a, 0x0b,
+	0x65, 0xad, 0x5a, 0xc0, 0x02, 0x7e, 0x1a, 0x09, 0x8c, 0x3f, 0x33, 0xa0, 0xcb, 0x3c, 0x90, 0x09,
+	0xcb, 0x76, 0xdf, 0x4a, 0xd4, 0xda, 0x8a, 0x82, 0x8a, 0x9b, 0x15, 0xf0, 0x92, 0xdd, 0xdf, 0x1b,
+	0x6a, 0x4e, 0xfb, 0x01, 0x45, 0x0d, 0x58, 0xb2, 0xfb, 0x56, 0x5c, 0x5f, 0xab, 0x4b, 0x78, 0xf3,
+	0x4c, 0x65, 0x6d, 0xa4, 0xb2, 0x3e, 0x51, 0x90, 0xd7, 0x11, 0x02, 0x2f, 0xda, 0xfd, 0x94, 0x00,
+	0x3d, 0x86, 0x82, 0xdd, 0xb7, 0x6c, 0xe2, 0x79, 0xb4, 0x55, 0xca, 0x2a, 0x7b, 0x39, 0x37, 0x66,
+	0x3c, 0x37, 0x66, 0xdd, 0xf7, 0xdd, 0xb7, 0xc4, 0xed, 0x51, 0x9c, 0xb7, 0xfb, 0x75, 0x81, 0x45,
+	0xeb, 0x22, 0x01, 0x7a, 0x15, 0x85, 0x62, 0x96, 0x4d, 0xdb, 0x7e, 0x48, 0xc5, 0x0d, 0xb3, 0x51,
+	0x90, 0x43, 0x29, 0xaf, 0x0b, 0x31, 0xaa, 0xc2, 0xbd, 0x04, 0x96, 0xb4, 0xa3, 0x9e, 0xe5, 0x04,
+	0xf4, 0x93, 0x01, 0x74, 0x2f, 0x92, 0x56, 0xce, 0x00, 0x25, 0x5b, 0xc5, 0x02, 0xdf, 0x63, 0x14,
+	0xad, 0x83, 0x2e, 0x3a, 0xc9, 0x4a, 0x5a, 0x39, 0x5b, 0x2d, 0x6e, 0xa3, 0xf1, 0xbe, 0x60, 0x85,
+	0x40, 0x5f, 0xc2, 0xa2, 0x47, 0xaf, 0xb8, 0x95, 0xa8, 0x7b, 0x46, 0x14, 0xf1, 0x6e, 0x24, 0x3e,
+	0x8e, 0x6b, 0x5f, 0x79, 0x0f, 0x8b, 0x3f, 0x50, 0x19, 0x28, 0x1e, 0xa8, 0x47, 0x90, 0x71, 0x5a,
+	0x6a, 0x98, 0x3e, 0x4d, 0x17, 0xf1, 0xf8, 0xa8, 0xd1, 0x38, 0x3c, 0x3a, 0xc0, 0x19, 0xa7, 0x35,
+	0xf7, 0xa8, 0x54, 0x9e, 0x02, 0x3a, 0xa0, 0x2e, 0xe5, 0x74, 0x8e, 0xa8, 0x95, 0xef, 0x61, 0xb1,
+	0x4e, 0xbc, 0x79, 0x2c, 0x9f, 0xc1, 0x0a, 0xa6, 0x72, 0xb2, 0xe6, 0x31, 0xff, 0x2b, 0x03, 0x68,
+	0x6f, 0xdc, 0xfa, 0x0d, 0xe8, 0x01, 0x09, 0x49, 0x97, 0x29, 0x0f, 0xdf, 0x4d, 0x79, 0x7d, 0xe3,
+	0xe6, 0xe6, 0xb1, 0xb0, 0x7d, 0x71, 0x0b, 0x2b, 0x2f, 0xa8, 0x06, 0xa8, 0x79, 0x46, 0x5c, 0x97,
+	0x7a, 0x1d, 0x6a, 0x85, 0xaa, 0xf3, 0xa2, 0xb8, 0x77, 0x5e, 0xdc, 0xc2, 0x4b, 0x03, 0x5d, 0x3c,
+	0x14, 0xc6, 0x1f, 0x1a, 0xe8, 0xd2, 0x0b, 0xda, 0x84, 0x85, 0x16, 0xe1, 0x44, 0x65, 0xf2, 0x79,
+	0xba, 0x15, 0xc3, 0x87, 0x73, 0x40, 0x38, 0xc1, 0x02, 0x89, 0x1a, 0x83, 0xec, 0x65, 0xfb, 0xcc,
+	0x69, 0xd9, 0x47, 0x87, 0x77, 0xbb, 0x9b, 0x4f, 0x4e, 0xde, 0x1e, 0x1d, 0xc8, 0x88, 0x71, 0xd6,
+	0x75, 0x1d, 0x16, 0x18, 0xa7, 0x41, 0xe5, 0x1f, 0x0d, 0x96, 0x53, 0xb7, 0x54, 0x93, 0xfb, 0x13,
+	0xe8, 0x21, 0x65, 0x3d, 0x97, 0xab, 0xdc, 0x76, 0x67, 0xad, 0x92, 0xb4, 0x37, 0xb1, 0x30, 0x8e,
+	0xca, 0x24, 0xdd, 0xa0, 0x87, 0x50, 0x18, 0x94, 0x62, 0x50, 0x9d, 0xa1, 0xc8, 0xd8, 0x01, 0x5d,
+	0xda, 0xa0, 0xaf, 0x61, 0x21, 0x5a, 0xa1, 0x13, 0x1b, 0x1c, 0xdf, 0x06, 0x0b, 0xc8, 0xe0, 0x16,
+	0xbf, 0xc2, 0x12, 0xa6, 0x1e, 0xbd, 0x4c, 0x35, 0xba, 0x31, 0xd2, 0xe8, 0x39, 0x4b, 0x55, 0x79,
+	0x0e, 0x28, 0xe9, 0x5c, 0x15, 0x68, 0xf6, 0x2c, 0x2b, 0xd7, 0x1a, 0xac, 0xee, 0x87, 0x94, 0x70,
+	0xfa, 0xd2, 0x77, 0x3c, 0xf1, 0x8a, 0xe3, 0x1c, 0xef, 0x41, 0x96, 0x73, 0x57, 0x38, 0xc9, 0xe1,
+	0xe8, 0x88, 0x56, 0x20, 0x97, 0x7c, 0xfc, 0xf2, 0x07, 0xda, 0x84, 0xbc, 0xc8, 0xd5, 0x72, 0xe2,
+	0x65, 0x77, 0xc3, 0xe0, 0xdf, 0x16, 0xb0, 0xa3, 0x16, 0xba, 0x0f, 0xf9, 0x2e, 0xb9, 0xb2, 0x7a,
+	0x8c, 0x32, 0xb1, 0xdd, 0x72, 0xf8, 0x76, 0x97, 0x5c, 0xfd, 0xcc, 0x28, 0x43, 0x5f, 0xc0, 0x5d,
+	0xe2, 0xba, 0xfe, 0x25, 0x6d, 0x59, 0x4d, 0xa7, 0x15, 0xb2, 0x52, 0xae, 0x9c, 0xad, 0x16, 0xf0,
+	0x1d, 0x25, 0xdc, 0x8f, 0x64, 0x68, 0x07, 0x0a, 0xf1, 0x92, 0x66, 0x25, 0xbd, 0x9c, 0x1d, 0x0f,
+	0xa9, 0xb4, 0x78, 0x88, 0xab, 0x7c, 0x05, 0xcb, 0x13, 0x2a, 0x19, 0xdd, 0xb2, 0xc9, 0x24, 0xdb,
+	0xdd, 0xc1, 0xd1, 0xb1, 0xf2, 0x77, 0x06, 0xd0, 0xbe, 0xdf, 0xf3, 0x46, 0x98, 0xf1, 0xd5, 0x08,
+	0x33, 0x4e, 0x7b, 0x9b, 0xe3, 0xe6, 0x23, 0xd4, 0x68, 0xfc, 0xab, 0xcd, 0xcd, 0x52, 0x29, 0x76,
+	0xc9, 0xfc, 0x5f, 0x76, 0xc9, 0xce, 0xce, 0x2e, 0x0b, 0x13, 0xd9, 0xe5, 0x1b, 0x58, 0x4e, 0x5d,
+	0x57, 0xcd, 0xe0, 0x0a, 0xe4, 0x9a, 0x91, 0x58, 0xcd, 0x8f, 0xfc, 0xb1, 0x7d, 0xad, 0x43, 0x4e,
+	0x00, 0x91, 0x03, 0x30, 0x24, 0x25, 0xf4, 0xed, 0xc7, 0xfc, 0xcd, 0x30, 0x36, 0x66, 0x44, 0xab,
+	0x54, 0x5c, 0x28, 0x26, 0x32, 0x44, 0x1b, 0x1f, 0xd5, 0x38, 0xc3, 0x9c, 0x15, 0xae, 0xa2, 0xbd,
+	0x84, 0x7c, 0xcc, 0x81, 0x68, 0x7d, 0x8a, 0xed, 0x08, 0x51, 0x1a, 0x13, 0xf8, 0x17, 0x9d, 0x42,
+	0x31, 0x41, 0x6e, 0x53, 0x33, 0x1f, 0x27, 0x41, 0x63, 0x75, 0x6c, 0x2a, 0x0e, 0xa3, 0x3f, 0xb2,
+	0xe8, 0x0d, 0xe4, 0x63, 0xd6, 0x9b, 0x9a, 0xe1, 0x08, 0x35, 0xde, 0xe8, 0xef, 0x1d, 0xdc, 0x4d,
+	0x71, 0x21, 0xaa, 0x4d, 0x71, 0x3a, 0x89, 0x35, 0x6f, 0xf4, 0x1c, 0x40, 0x31, 0xb1, 0xc0, 0xa7,
+	0xde, 0x7f, 0x9c, 0x0e, 0x0d, 0x73, 0x56, 0xb8, 0xec, 0x5c, 0x55, 0xdb, 0xd4, 0xa2, 0xb1, 0x1c,
+	0x2e, 0xd4, 0xa9, 0x63, 0x39, 0xb6, 0xd4, 0x8d, 0x8d, 0x19, 0xd1, 0x6a, 0x50, 0x7e, 0x83, 0xc5,
+	0x91, 0xcd, 0x8b, 0xb6, 0xa6, 0xcd, 0xda, 0xc4, 0x2d, 0x6d, 0xa4, 0xff, 0x23, 0x0d, 0xd4, 0xf5,
+	0xe7, 0xbf, 0x3c, 0xeb, 0x38, 0xfc, 0xac, 0x67, 0x9b, 0x4d, 0xbf, 0xab, 0xbe, 0x0b, 0x6a, 0xf2,
+	0x73, 0x40, 0x14, 0xb9, 0x76, 0xe3, 0x67, 0xd2, 0x53, 0x71, 0xb0, 0x75, 0x01, 0xdb, 0xf9, 0x6f,
+	0x00, 0xc3, 0xcb, 0xc6, 0x4b, 0x50, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -864,23 +1121,41 @@ const _ = grpc.SupportPackageIsVersion6
 type AgentClient interface {
 	// Lists agents.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// Counts agents.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	CountAgents(ctx context.Context, in *CountAgentsRequest, opts ...grpc.CallOption) (*CountAgentsResponse, error)
 	// Gets an agent.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	GetAgent(ctx context.Context, in *GetAgentRequest, opts ...grpc.CallOption) (*types.Agent, error)
 	// Deletes an agent. The agent can come back into the trust domain through
 	// the Issuer AttestAgent RPC.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// agent-admin role.
 	DeleteAgent(ctx context.Context, in *DeleteAgentRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// Bans an agent. This evicts the agent and prevents it from rejoining the
 	// trust domain through attestation until the ban is lifted via a call to
 	// DeleteAgent.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// agent-admin role.
 	BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Forces an agent to re-attest. The current X509-SVID of the agent is no
+	// longer accepted, so the agent attests again, obtaining an X509-SVID for
+	// a new key, and renews the X509-SVIDs of its workloads. Agents that
+	// cannot re-attest, e.g. those attested with a join token, must be
+	// attested again by other means.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// agent-admin role.
+	ReattestAgent(ctx context.Context, in *ReattestAgentRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// Attests the agent via node attestation, using a bidirectional stream to
 	// faciliate attestation methods that require challenge/response.
 	//
@@ -895,7 +1170,8 @@ type AgentClient interface {
 	// Creates an agent join token. The token can be used with `join_token`
 	// attestation to join the trust domain.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// agent-admin role.
 	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*types.JoinToken, error)
 }
 
@@ -916,6 +1192,15 @@ func (c *agentClient) ListAgents(ctx context.Context, in *ListAgentsRequest, opt
 	return out, nil
 }
 
+func (c *agentClient) CountAgents(ctx context.Context, in *CountAgentsRequest, opts ...grpc.CallOption) (*CountAgentsResponse, error) {
+	out := new(CountAgentsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agent.v1.Agent/CountAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentClient) GetAgent(ctx context.Context, in *GetAgentRequest, opts ...grpc.CallOption) (*types.Agent, error) {
 	out := new(types.Agent)
 	err := c.cc.Invoke(ctx, "/spire.api.server.agent.v1.Agent/GetAgent", in, out, opts...)
@@ -943,6 +1228,15 @@ func (c *agentClient) BanAgent(ctx context.Context, in *BanAgentRequest, opts ..
 	return out, nil
 }
 
+func (c *agentClient) ReattestAgent(ctx context.Context, in *ReattestAgentRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agent.v1.Agent/ReattestAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentClient) AttestAgent(ctx context.Context, opts ...grpc.CallOption) (Agent_AttestAgentClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[0], "/spire.api.server.agent.v1.Agent/AttestAgent", opts...)
 	if err != nil {
@@ -996,23 +1290,41 @@ func (c *agentClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRe
 type AgentServer interface {
 	// Lists agents.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	// Counts agents.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	CountAgents(context.Context, *CountAgentsRequest) (*CountAgentsResponse, error)
 	// Gets an agent.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	GetAgent(context.Context, *GetAgentRequest) (*types.Agent, error)
 	// Deletes an agent. The agent can come back into the trust domain through
 	// the Issuer AttestAgent RPC.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// agent-admin role.
 	DeleteAgent(context.Context, *DeleteAgentRequest) (*empty.Empty, error)
 	// Bans an agent. This evicts the agent and prevents it from rejoining the
 	// trust domain through attestation until the ban is lifted via a call to
 	// DeleteAgent.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// agent-admin role.
 	BanAgent(context.Context, *BanAgentRequest) (*empty.Empty, error)
+	// Forces an agent to re-attest. The current X509-SVID of the agent is no
+	// longer accepted, so the agent attests again, obtaining an X509-SVID for
+	// a new key, and renews the X509-SVIDs of its workloads. Agents that
+	// cannot re-attest, e.g. those attested with a join token, must be
+	// attested again by other means.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// agent-admin role.
+	ReattestAgent(context.Context, *ReattestAgentRequest) (*empty.Empty, error)
 	// Attests the agent via node attestation, using a bidirectional stream to
 	// faciliate attestation methods that require challenge/response.
 	//
@@ -1027,7 +1339,8 @@ type AgentServer interface {
 	// Creates an agent join token. The token can be used with `join_token`
 	// attestation to join the trust domain.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// agent-admin role.
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*types.JoinToken, error)
 }
 
@@ -1038,6 +1351,9 @@ type UnimplementedAgentServer struct {
 func (*UnimplementedAgentServer) ListAgents(ctx context.Context, req *ListAgentsRequest) (*ListAgentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
 }
+func (*UnimplementedAgentServer) CountAgents(ctx context.Context, req *CountAgentsRequest) (*CountAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountAgents not implemented")
+}
 func (*UnimplementedAgentServer) GetAgent(ctx context.Context, req *GetAgentRequest) (*types.Agent, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAgent not implemented")
 }
@@ -1047,6 +1363,9 @@ func (*UnimplementedAgentServer) DeleteAgent(ctx context.Context, req *DeleteAge
 func (*UnimplementedAgentServer) BanAgent(ctx context.Context, req *BanAgentRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BanAgent not implemented")
 }
+func (*UnimplementedAgentServer) ReattestAgent(ctx context.Context, req *ReattestAgentRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReattestAgent not implemented")
+}
 func (*UnimplementedAgentServer) AttestAgent(srv Agent_AttestAgentServer) error {
 	return status.Errorf(codes.Unimplemented, "method AttestAgent not implemented")
 }
@@ -1079,6 +1398,24 @@ func _Agent_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_CountAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).CountAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agent.v1.Agent/CountAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).CountAgents(ctx, req.(*CountAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Agent_GetAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAgentRequest)
 	if err := dec(in); err != nil {
@@ -1133,6 +1470,24 @@ func _Agent_BanAgent_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_ReattestAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReattestAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ReattestAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agent.v1.Agent/ReattestAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ReattestAgent(ctx, req.(*ReattestAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Agent_AttestAgent_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(AgentServer).AttestAgent(&agentAttestAgentServer{stream})
 }
@@ -1203,6 +1558,10 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListAgents",
 			Handler:    _Agent_ListAgents_Handler,
 		},
+		{
+			MethodName: "CountAgents",
+			Handler:    _Agent_CountAgents_Handler,
+		},
 		{
 			MethodName: "GetAgent",
 			Handler:    _Agent_GetAgent_Handler,
@@ -1215,6 +1574,10 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "BanAgent",
 			Handler:    _Agent_BanAgent_Handler,
 		},
+		{
+			MethodName: "ReattestAgent",
+			Handler:    _Agent_ReattestAgent_Handler,
+		},
 		{
 			MethodName: "RenewAgent",
 			Handler:    _Agent_RenewAgent_Handler,
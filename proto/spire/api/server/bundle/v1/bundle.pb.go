@@ -1080,7 +1080,8 @@ type BundleClient interface {
 	// is returned. This is the only RPC that can be used to update the
 	// bundle for the trust domain of the SPIRE server.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*types.Bundle, error)
 	// Publishes a downstream JWT authority to the SPIRE server. If the server
 	// is itself a downstream server (i.e. configured with an UpstreamAuthority
@@ -1093,27 +1094,34 @@ type BundleClient interface {
 	PublishJWTAuthority(ctx context.Context, in *PublishJWTAuthorityRequest, opts ...grpc.CallOption) (*PublishJWTAuthorityResponse, error)
 	// Lists federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	ListFederatedBundles(ctx context.Context, in *ListFederatedBundlesRequest, opts ...grpc.CallOption) (*ListFederatedBundlesResponse, error)
 	// Gets a federated bundle. If the bundle does not exist, NOT_FOUND is returned.
 	//
-	// The caller must be local or present an admin or an active agent X509-SVID.
+	// The caller must be local, present an admin or an active agent X509-SVID,
+	// or be bound to the read-only, entry-admin, bundle-admin or agent-admin
+	// role.
 	GetFederatedBundle(ctx context.Context, in *GetFederatedBundleRequest, opts ...grpc.CallOption) (*types.Bundle, error)
 	// Batch creates one or more federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	BatchCreateFederatedBundle(ctx context.Context, in *BatchCreateFederatedBundleRequest, opts ...grpc.CallOption) (*BatchCreateFederatedBundleResponse, error)
 	// Batch updates one or more federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	BatchUpdateFederatedBundle(ctx context.Context, in *BatchUpdateFederatedBundleRequest, opts ...grpc.CallOption) (*BatchUpdateFederatedBundleResponse, error)
 	// Batch upserts one or more federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	BatchSetFederatedBundle(ctx context.Context, in *BatchSetFederatedBundleRequest, opts ...grpc.CallOption) (*BatchSetFederatedBundleResponse, error)
 	// Batch deletes one or more federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	BatchDeleteFederatedBundle(ctx context.Context, in *BatchDeleteFederatedBundleRequest, opts ...grpc.CallOption) (*BatchDeleteFederatedBundleResponse, error)
 }
 
@@ -1217,7 +1225,8 @@ type BundleServer interface {
 	// is returned. This is the only RPC that can be used to update the
 	// bundle for the trust domain of the SPIRE server.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	AppendBundle(context.Context, *AppendBundleRequest) (*types.Bundle, error)
 	// Publishes a downstream JWT authority to the SPIRE server. If the server
 	// is itself a downstream server (i.e. configured with an UpstreamAuthority
@@ -1230,27 +1239,34 @@ type BundleServer interface {
 	PublishJWTAuthority(context.Context, *PublishJWTAuthorityRequest) (*PublishJWTAuthorityResponse, error)
 	// Lists federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	ListFederatedBundles(context.Context, *ListFederatedBundlesRequest) (*ListFederatedBundlesResponse, error)
 	// Gets a federated bundle. If the bundle does not exist, NOT_FOUND is returned.
 	//
-	// The caller must be local or present an admin or an active agent X509-SVID.
+	// The caller must be local, present an admin or an active agent X509-SVID,
+	// or be bound to the read-only, entry-admin, bundle-admin or agent-admin
+	// role.
 	GetFederatedBundle(context.Context, *GetFederatedBundleRequest) (*types.Bundle, error)
 	// Batch creates one or more federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	BatchCreateFederatedBundle(context.Context, *BatchCreateFederatedBundleRequest) (*BatchCreateFederatedBundleResponse, error)
 	// Batch updates one or more federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	BatchUpdateFederatedBundle(context.Context, *BatchUpdateFederatedBundleRequest) (*BatchUpdateFederatedBundleResponse, error)
 	// Batch upserts one or more federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	BatchSetFederatedBundle(context.Context, *BatchSetFederatedBundleRequest) (*BatchSetFederatedBundleResponse, error)
 	// Batch deletes one or more federated bundles.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
 	BatchDeleteFederatedBundle(context.Context, *BatchDeleteFederatedBundleRequest) (*BatchDeleteFederatedBundleResponse, error)
 }
 
@@ -190,10 +190,343 @@ func (m *GetInfoResponse_Cert) GetSubject() string {
 	return ""
 }
 
+type DrainRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainRequest) Reset()         { *m = DrainRequest{} }
+func (m *DrainRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainRequest) ProtoMessage()    {}
+func (*DrainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82b2f92dd8d9caf5, []int{2}
+}
+
+func (m *DrainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainRequest.Unmarshal(m, b)
+}
+func (m *DrainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainRequest.Marshal(b, m, deterministic)
+}
+func (m *DrainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainRequest.Merge(m, src)
+}
+func (m *DrainRequest) XXX_Size() int {
+	return xxx_messageInfo_DrainRequest.Size(m)
+}
+func (m *DrainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainRequest proto.InternalMessageInfo
+
+type DrainResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainResponse) Reset()         { *m = DrainResponse{} }
+func (m *DrainResponse) String() string { return proto.CompactTextString(m) }
+func (*DrainResponse) ProtoMessage()    {}
+func (*DrainResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82b2f92dd8d9caf5, []int{3}
+}
+
+func (m *DrainResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainResponse.Unmarshal(m, b)
+}
+func (m *DrainResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainResponse.Marshal(b, m, deterministic)
+}
+func (m *DrainResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainResponse.Merge(m, src)
+}
+func (m *DrainResponse) XXX_Size() int {
+	return xxx_messageInfo_DrainResponse.Size(m)
+}
+func (m *DrainResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainResponse proto.InternalMessageInfo
+
+type SetLogLevelRequest struct {
+	// Log level of the server. If empty, the current level is kept.
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// Log level overrides keyed by subsystem, replacing the current ones
+	SubsystemLevels      map[string]string `protobuf:"bytes,2,rep,name=subsystem_levels,json=subsystemLevels,proto3" json:"subsystem_levels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetLogLevelRequest) Reset()         { *m = SetLogLevelRequest{} }
+func (m *SetLogLevelRequest) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelRequest) ProtoMessage()    {}
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82b2f92dd8d9caf5, []int{4}
+}
+
+func (m *SetLogLevelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelRequest.Unmarshal(m, b)
+}
+func (m *SetLogLevelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelRequest.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelRequest.Merge(m, src)
+}
+func (m *SetLogLevelRequest) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelRequest.Size(m)
+}
+func (m *SetLogLevelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelRequest proto.InternalMessageInfo
+
+func (m *SetLogLevelRequest) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *SetLogLevelRequest) GetSubsystemLevels() map[string]string {
+	if m != nil {
+		return m.SubsystemLevels
+	}
+	return nil
+}
+
+type SetLogLevelResponse struct {
+	// Log level of the server
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// Log level overrides keyed by subsystem
+	SubsystemLevels      map[string]string `protobuf:"bytes,2,rep,name=subsystem_levels,json=subsystemLevels,proto3" json:"subsystem_levels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SetLogLevelResponse) Reset()         { *m = SetLogLevelResponse{} }
+func (m *SetLogLevelResponse) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelResponse) ProtoMessage()    {}
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82b2f92dd8d9caf5, []int{5}
+}
+
+func (m *SetLogLevelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelResponse.Unmarshal(m, b)
+}
+func (m *SetLogLevelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelResponse.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelResponse.Merge(m, src)
+}
+func (m *SetLogLevelResponse) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelResponse.Size(m)
+}
+func (m *SetLogLevelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelResponse proto.InternalMessageInfo
+
+func (m *SetLogLevelResponse) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *SetLogLevelResponse) GetSubsystemLevels() map[string]string {
+	if m != nil {
+		return m.SubsystemLevels
+	}
+	return nil
+}
+
+type MatchEntriesRequest struct {
+	// SPIFFE ID of the agent. If set, only the entries the agent is
+	// authorized for are matched.
+	AgentId *types.SPIFFEID `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Selectors of the workload. If set, only the entries whose selectors
+	// are a subset of them are matched.
+	Selectors            []*types.Selector `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MatchEntriesRequest) Reset()         { *m = MatchEntriesRequest{} }
+func (m *MatchEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*MatchEntriesRequest) ProtoMessage()    {}
+func (*MatchEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82b2f92dd8d9caf5, []int{6}
+}
+
+func (m *MatchEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchEntriesRequest.Unmarshal(m, b)
+}
+func (m *MatchEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchEntriesRequest.Marshal(b, m, deterministic)
+}
+func (m *MatchEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchEntriesRequest.Merge(m, src)
+}
+func (m *MatchEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_MatchEntriesRequest.Size(m)
+}
+func (m *MatchEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchEntriesRequest proto.InternalMessageInfo
+
+func (m *MatchEntriesRequest) GetAgentId() *types.SPIFFEID {
+	if m != nil {
+		return m.AgentId
+	}
+	return nil
+}
+
+func (m *MatchEntriesRequest) GetSelectors() []*types.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+type MatchEntriesResponse struct {
+	// The matching registration entries
+	Entries              []*types.Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *MatchEntriesResponse) Reset()         { *m = MatchEntriesResponse{} }
+func (m *MatchEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*MatchEntriesResponse) ProtoMessage()    {}
+func (*MatchEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82b2f92dd8d9caf5, []int{7}
+}
+
+func (m *MatchEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchEntriesResponse.Unmarshal(m, b)
+}
+func (m *MatchEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchEntriesResponse.Marshal(b, m, deterministic)
+}
+func (m *MatchEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchEntriesResponse.Merge(m, src)
+}
+func (m *MatchEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_MatchEntriesResponse.Size(m)
+}
+func (m *MatchEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchEntriesResponse proto.InternalMessageInfo
+
+func (m *MatchEntriesResponse) GetEntries() []*types.Entry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type BackupDatastoreRequest struct {
+	// Path of the file on the server host the backup is written to. The file
+	// must not exist.
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupDatastoreRequest) Reset()         { *m = BackupDatastoreRequest{} }
+func (m *BackupDatastoreRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupDatastoreRequest) ProtoMessage()    {}
+func (*BackupDatastoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82b2f92dd8d9caf5, []int{8}
+}
+
+func (m *BackupDatastoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupDatastoreRequest.Unmarshal(m, b)
+}
+func (m *BackupDatastoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupDatastoreRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupDatastoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupDatastoreRequest.Merge(m, src)
+}
+func (m *BackupDatastoreRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupDatastoreRequest.Size(m)
+}
+func (m *BackupDatastoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupDatastoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupDatastoreRequest proto.InternalMessageInfo
+
+func (m *BackupDatastoreRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type BackupDatastoreResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupDatastoreResponse) Reset()         { *m = BackupDatastoreResponse{} }
+func (m *BackupDatastoreResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupDatastoreResponse) ProtoMessage()    {}
+func (*BackupDatastoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_82b2f92dd8d9caf5, []int{9}
+}
+
+func (m *BackupDatastoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupDatastoreResponse.Unmarshal(m, b)
+}
+func (m *BackupDatastoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupDatastoreResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupDatastoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupDatastoreResponse.Merge(m, src)
+}
+func (m *BackupDatastoreResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupDatastoreResponse.Size(m)
+}
+func (m *BackupDatastoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupDatastoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupDatastoreResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*GetInfoRequest)(nil), "spire.api.server.debug.v1.GetInfoRequest")
 	proto.RegisterType((*GetInfoResponse)(nil), "spire.api.server.debug.v1.GetInfoResponse")
 	proto.RegisterType((*GetInfoResponse_Cert)(nil), "spire.api.server.debug.v1.GetInfoResponse.Cert")
+	proto.RegisterType((*DrainRequest)(nil), "spire.api.server.debug.v1.DrainRequest")
+	proto.RegisterType((*DrainResponse)(nil), "spire.api.server.debug.v1.DrainResponse")
+	proto.RegisterType((*SetLogLevelRequest)(nil), "spire.api.server.debug.v1.SetLogLevelRequest")
+	proto.RegisterMapType((map[string]string)(nil), "spire.api.server.debug.v1.SetLogLevelRequest.SubsystemLevelsEntry")
+	proto.RegisterType((*SetLogLevelResponse)(nil), "spire.api.server.debug.v1.SetLogLevelResponse")
+	proto.RegisterMapType((map[string]string)(nil), "spire.api.server.debug.v1.SetLogLevelResponse.SubsystemLevelsEntry")
+	proto.RegisterType((*MatchEntriesRequest)(nil), "spire.api.server.debug.v1.MatchEntriesRequest")
+	proto.RegisterType((*MatchEntriesResponse)(nil), "spire.api.server.debug.v1.MatchEntriesResponse")
+	proto.RegisterType((*BackupDatastoreRequest)(nil), "spire.api.server.debug.v1.BackupDatastoreRequest")
+	proto.RegisterType((*BackupDatastoreResponse)(nil), "spire.api.server.debug.v1.BackupDatastoreResponse")
 }
 
 func init() {
@@ -201,31 +534,50 @@ func init() {
 }
 
 var fileDescriptor_82b2f92dd8d9caf5 = []byte{
-	// 375 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x4f, 0x8b, 0xdb, 0x30,
-	0x10, 0xc5, 0xb1, 0xf3, 0x8f, 0x4c, 0xd2, 0x3f, 0x08, 0xd2, 0xba, 0x86, 0x42, 0x9a, 0x12, 0x48,
-	0x7b, 0x90, 0x48, 0x0a, 0xbd, 0x94, 0x52, 0x9a, 0xa4, 0x29, 0xb9, 0x94, 0xe2, 0xbd, 0xed, 0xc5,
-	0x6b, 0x5b, 0xe3, 0x44, 0xcb, 0xc6, 0xd6, 0x5a, 0xb2, 0xd9, 0xfd, 0xb8, 0xfb, 0x4d, 0x16, 0x4b,
-	0x4e, 0x0e, 0x0b, 0x81, 0xdc, 0xa4, 0xf7, 0x7e, 0x33, 0xf6, 0x3c, 0x0d, 0x4c, 0x95, 0x14, 0x05,
-	0xb2, 0x48, 0x0a, 0xa6, 0xb0, 0xa8, 0xb0, 0x60, 0x1c, 0xe3, 0x72, 0xc7, 0xaa, 0xb9, 0x3d, 0x50,
-	0x59, 0xe4, 0x3a, 0x27, 0x1f, 0x0c, 0x46, 0x23, 0x29, 0xa8, 0xc5, 0xa8, 0x75, 0xab, 0xb9, 0xef,
-	0xdb, 0x0e, 0xfa, 0x51, 0xa2, 0x62, 0x4a, 0x8a, 0x34, 0x45, 0xc1, 0x6d, 0xd9, 0xe4, 0x2d, 0xbc,
-	0xfe, 0x8b, 0x7a, 0x9b, 0xa5, 0x79, 0x80, 0xf7, 0x25, 0x2a, 0x3d, 0x79, 0x72, 0xe1, 0xcd, 0x49,
-	0x52, 0x32, 0xcf, 0x14, 0x92, 0x7f, 0x00, 0xaa, 0x12, 0x3c, 0x4c, 0xf6, 0x91, 0xc8, 0x3c, 0x67,
-	0xdc, 0x9a, 0x0d, 0x16, 0x8c, 0x9e, 0xfd, 0x22, 0x7d, 0x51, 0x4f, 0x57, 0x58, 0xe8, 0xa0, 0x5f,
-	0xb7, 0x58, 0xd5, 0x1d, 0xc8, 0x3b, 0xe8, 0x96, 0x52, 0x8b, 0x03, 0x7a, 0xee, 0xd8, 0x99, 0x75,
-	0x82, 0xe6, 0x46, 0x3e, 0xc1, 0x30, 0xda, 0x61, 0xa6, 0x55, 0x98, 0xe4, 0x65, 0xa6, 0xbd, 0x96,
-	0x71, 0x07, 0x56, 0x5b, 0xd5, 0x12, 0xf9, 0x0e, 0xef, 0x53, 0xe4, 0x58, 0x44, 0x1a, 0x79, 0x18,
-	0x97, 0x19, 0xbf, 0xc3, 0x23, 0xdd, 0x36, 0xf4, 0xe8, 0x64, 0x2f, 0xad, 0x6b, 0xeb, 0x3e, 0xc3,
-	0x2b, 0xcc, 0x74, 0x21, 0x4e, 0x74, 0xc7, 0xd0, 0xc3, 0x46, 0x34, 0x90, 0x9f, 0x42, 0xbb, 0xfe,
-	0x55, 0x32, 0x05, 0x57, 0x70, 0xcf, 0x19, 0x3b, 0xb3, 0xc1, 0x62, 0xd4, 0xcc, 0x69, 0xe2, 0xa3,
-	0x57, 0xff, 0xb7, 0x9b, 0xcd, 0x9f, 0xed, 0x3a, 0x70, 0x05, 0x27, 0x1f, 0x01, 0xf0, 0xa1, 0x36,
-	0x55, 0x18, 0x69, 0x33, 0x4a, 0x2b, 0xe8, 0x37, 0xca, 0x6f, 0x4d, 0x3c, 0xe8, 0xa9, 0x32, 0xbe,
-	0xc5, 0xc4, 0x0e, 0xd2, 0x0f, 0x8e, 0xd7, 0x85, 0x80, 0xce, 0xba, 0xce, 0x8a, 0xdc, 0x40, 0xaf,
-	0xc9, 0x8a, 0x7c, 0xb9, 0x24, 0x4f, 0xf3, 0x44, 0xfe, 0xd7, 0xcb, 0xa3, 0x5f, 0xfe, 0xba, 0xfe,
-	0xb9, 0x13, 0x7a, 0x5f, 0xc6, 0x34, 0xc9, 0x0f, 0xcd, 0xeb, 0x33, 0xbb, 0x10, 0x66, 0x03, 0xd8,
-	0xd9, 0xf5, 0xfa, 0x61, 0x0e, 0x71, 0xd7, 0x60, 0xdf, 0x9e, 0x03, 0x00, 0x00, 0xff, 0xff, 0x8e,
-	0x79, 0x6e, 0x4a, 0x88, 0x02, 0x00, 0x00,
+	// 687 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xdb, 0x6e, 0xd3, 0x4c,
+	0x10, 0x96, 0x73, 0x68, 0xfe, 0x4c, 0xd2, 0xa6, 0xda, 0x9e, 0x52, 0x4b, 0xbf, 0x54, 0x8c, 0x2a,
+	0x02, 0x2a, 0x36, 0x4d, 0x25, 0x84, 0x40, 0x08, 0x91, 0xa4, 0x45, 0x91, 0x0a, 0x42, 0xee, 0x5d,
+	0x6f, 0x82, 0x63, 0x4f, 0x92, 0xa5, 0x89, 0x6d, 0xbc, 0xeb, 0xa8, 0x91, 0x78, 0x16, 0xde, 0x0c,
+	0x21, 0xde, 0x04, 0x79, 0x77, 0x5d, 0x92, 0x36, 0x4d, 0x93, 0x1b, 0xee, 0x76, 0x66, 0xbe, 0x99,
+	0x9d, 0xc3, 0x37, 0xbb, 0x70, 0xc8, 0x42, 0x1a, 0xa1, 0xe5, 0x84, 0xd4, 0x62, 0x18, 0x8d, 0x31,
+	0xb2, 0x3c, 0xec, 0xc6, 0x7d, 0x6b, 0x7c, 0x2c, 0x0f, 0x66, 0x18, 0x05, 0x3c, 0x20, 0xfb, 0x02,
+	0x66, 0x3a, 0x21, 0x35, 0x25, 0xcc, 0x94, 0xd6, 0xf1, 0xb1, 0xae, 0xcb, 0x08, 0x7c, 0x12, 0x22,
+	0xb3, 0x58, 0x48, 0x7b, 0x3d, 0xa4, 0x9e, 0x74, 0xd3, 0xf7, 0xa6, 0x6d, 0xe8, 0xf3, 0x68, 0xa2,
+	0x0c, 0xb3, 0x4e, 0x38, 0x44, 0x97, 0x07, 0x91, 0xb4, 0x19, 0x9b, 0xb0, 0xf1, 0x01, 0x79, 0xdb,
+	0xef, 0x05, 0x36, 0x7e, 0x8b, 0x91, 0x71, 0xe3, 0x77, 0x06, 0x2a, 0x37, 0x2a, 0x16, 0x06, 0x3e,
+	0x43, 0xf2, 0x09, 0x80, 0x8d, 0xa9, 0xd7, 0x71, 0x07, 0x0e, 0xf5, 0xab, 0xda, 0x41, 0xb6, 0x56,
+	0xaa, 0x5b, 0xe6, 0xbd, 0x69, 0x9a, 0xb7, 0xfc, 0xcd, 0x26, 0x46, 0xdc, 0x2e, 0x26, 0x21, 0x9a,
+	0x49, 0x04, 0xb2, 0x0b, 0x6b, 0x71, 0xc8, 0xe9, 0x08, 0xab, 0x99, 0x03, 0xad, 0x96, 0xb7, 0x95,
+	0x44, 0x1e, 0x41, 0xd9, 0xe9, 0xa3, 0xcf, 0x59, 0xc7, 0x0d, 0x62, 0x9f, 0x57, 0xb3, 0xc2, 0x5a,
+	0x92, 0xba, 0x66, 0xa2, 0x22, 0x2f, 0x61, 0xaf, 0x87, 0x1e, 0x46, 0x0e, 0x47, 0xaf, 0xd3, 0x8d,
+	0x7d, 0x6f, 0x88, 0x29, 0x3a, 0x27, 0xd0, 0x3b, 0x37, 0xe6, 0x86, 0xb4, 0x4a, 0xbf, 0xc7, 0xb0,
+	0x9e, 0xf4, 0x84, 0xde, 0xa0, 0xf3, 0x02, 0x5d, 0x56, 0x4a, 0x01, 0xd2, 0x7b, 0x90, 0x4b, 0x52,
+	0x25, 0x87, 0x90, 0xa1, 0x5e, 0x55, 0x3b, 0xd0, 0x6a, 0xa5, 0xfa, 0x8e, 0xaa, 0x53, 0xb4, 0xcf,
+	0xbc, 0xf8, 0xdc, 0x3e, 0x3b, 0x3b, 0x6d, 0xb7, 0xec, 0x0c, 0xf5, 0xc8, 0xff, 0x00, 0x78, 0x9d,
+	0x18, 0x59, 0xc7, 0xe1, 0xa2, 0x94, 0xac, 0x5d, 0x54, 0x9a, 0xf7, 0x9c, 0x54, 0xa1, 0xc0, 0xe2,
+	0xee, 0x57, 0x74, 0x65, 0x21, 0x45, 0x3b, 0x15, 0x8d, 0x0d, 0x28, 0xb7, 0x22, 0x87, 0xfa, 0x69,
+	0xcf, 0x2b, 0xb0, 0xae, 0x64, 0xd9, 0x30, 0xe3, 0xa7, 0x06, 0xe4, 0x02, 0xf9, 0x79, 0xd0, 0x3f,
+	0xc7, 0x31, 0x0e, 0x15, 0x8e, 0x6c, 0x43, 0x7e, 0x98, 0xc8, 0x22, 0xb5, 0xa2, 0x2d, 0x05, 0x32,
+	0x82, 0x4d, 0x16, 0x77, 0xd9, 0x84, 0x71, 0x1c, 0x75, 0x84, 0x8a, 0x55, 0x33, 0x62, 0x46, 0x8d,
+	0x05, 0x33, 0xba, 0x1b, 0xde, 0xbc, 0x48, 0xa3, 0x08, 0x2d, 0x3b, 0x4d, 0x38, 0x64, 0x57, 0xd8,
+	0xac, 0x56, 0x6f, 0xc0, 0xf6, 0x3c, 0x20, 0xd9, 0x84, 0xec, 0x15, 0x4e, 0x54, 0x6a, 0xc9, 0x31,
+	0x49, 0x77, 0xec, 0x0c, 0x63, 0x39, 0xe5, 0xa2, 0x2d, 0x85, 0xd7, 0x99, 0x57, 0x9a, 0xf1, 0x4b,
+	0x83, 0xad, 0x99, 0x04, 0x14, 0xd1, 0xe6, 0x17, 0xe8, 0xdf, 0x5b, 0x60, 0x73, 0xd9, 0x02, 0x15,
+	0x11, 0xff, 0x5d, 0x85, 0xdf, 0x61, 0xeb, 0xa3, 0xc3, 0xdd, 0xc1, 0xa9, 0xe4, 0x57, 0x3a, 0xc1,
+	0x17, 0xf0, 0x9f, 0x60, 0x73, 0xe7, 0x21, 0x7e, 0x15, 0x04, 0xac, 0xed, 0x91, 0x13, 0x28, 0xa6,
+	0x3b, 0x9b, 0x56, 0x7d, 0xcb, 0x45, 0x59, 0xed, 0xbf, 0x38, 0xa3, 0x05, 0xdb, 0xb3, 0xb7, 0xab,
+	0xfe, 0x1e, 0x41, 0x41, 0x11, 0x5e, 0x6d, 0x31, 0x99, 0x09, 0x25, 0xfb, 0x91, 0x42, 0x8c, 0x23,
+	0xd8, 0x6d, 0x38, 0xee, 0x55, 0x1c, 0xb6, 0x1c, 0xee, 0x30, 0x1e, 0x44, 0x98, 0x96, 0x41, 0x20,
+	0x17, 0x3a, 0x7c, 0xa0, 0x5a, 0x21, 0xce, 0xc6, 0x3e, 0xec, 0xdd, 0x41, 0xcb, 0x6b, 0xeb, 0x3f,
+	0x72, 0x90, 0x6f, 0x25, 0x73, 0x21, 0x5f, 0xa0, 0xa0, 0x1e, 0x07, 0xf2, 0x74, 0x99, 0x07, 0x44,
+	0x5c, 0xa7, 0x3f, 0x5b, 0xfe, 0xad, 0x21, 0x97, 0x90, 0x17, 0xbb, 0x44, 0x9e, 0x2c, 0x70, 0x9a,
+	0xde, 0x3e, 0xbd, 0xf6, 0x30, 0x50, 0xc5, 0x1e, 0x42, 0x69, 0x8a, 0x55, 0xe4, 0xf9, 0x4a, 0xeb,
+	0xa5, 0x9b, 0xab, 0x91, 0x95, 0x04, 0x50, 0x9e, 0x1e, 0x22, 0x59, 0xe4, 0x3f, 0x87, 0x6b, 0xba,
+	0xb5, 0x34, 0x5e, 0x5d, 0x78, 0x0d, 0x95, 0x5b, 0x13, 0x24, 0xc7, 0x0b, 0x62, 0xcc, 0xe7, 0x86,
+	0x5e, 0x5f, 0xc5, 0x45, 0xde, 0xdc, 0x78, 0x77, 0xf9, 0xb6, 0x4f, 0xf9, 0x20, 0xee, 0x9a, 0x6e,
+	0x30, 0x52, 0x1f, 0x9b, 0x25, 0xbf, 0x2d, 0xf1, 0x4f, 0x59, 0xf7, 0xfe, 0x9c, 0x6f, 0xc4, 0xa1,
+	0xbb, 0x26, 0x60, 0x27, 0x7f, 0x06, 0x00, 0x17, 0x87, 0x54, 0xca, 0x63, 0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -242,6 +594,16 @@ const _ = grpc.SupportPackageIsVersion6
 type DebugClient interface {
 	// Get information about SPIRE server
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// Gracefully drain the agent connections of the SPIRE server
+	Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error)
+	// Set the log level of SPIRE server and of its subsystems
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// Preview the registration entries an agent, or a workload with the
+	// given selectors, would receive
+	MatchEntries(ctx context.Context, in *MatchEntriesRequest, opts ...grpc.CallOption) (*MatchEntriesResponse, error)
+	// Write a consistent copy of the datastore to a file on the server host
+	// while the server keeps running
+	BackupDatastore(ctx context.Context, in *BackupDatastoreRequest, opts ...grpc.CallOption) (*BackupDatastoreResponse, error)
 }
 
 type debugClient struct {
@@ -261,10 +623,56 @@ func (c *debugClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...g
 	return out, nil
 }
 
+func (c *debugClient) Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error) {
+	out := new(DrainResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.debug.v1.Debug/Drain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.debug.v1.Debug/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) MatchEntries(ctx context.Context, in *MatchEntriesRequest, opts ...grpc.CallOption) (*MatchEntriesResponse, error) {
+	out := new(MatchEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.debug.v1.Debug/MatchEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *debugClient) BackupDatastore(ctx context.Context, in *BackupDatastoreRequest, opts ...grpc.CallOption) (*BackupDatastoreResponse, error) {
+	out := new(BackupDatastoreResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.debug.v1.Debug/BackupDatastore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DebugServer is the server API for Debug service.
 type DebugServer interface {
 	// Get information about SPIRE server
 	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// Gracefully drain the agent connections of the SPIRE server
+	Drain(context.Context, *DrainRequest) (*DrainResponse, error)
+	// Set the log level of SPIRE server and of its subsystems
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// Preview the registration entries an agent, or a workload with the
+	// given selectors, would receive
+	MatchEntries(context.Context, *MatchEntriesRequest) (*MatchEntriesResponse, error)
+	// Write a consistent copy of the datastore to a file on the server host
+	// while the server keeps running
+	BackupDatastore(context.Context, *BackupDatastoreRequest) (*BackupDatastoreResponse, error)
 }
 
 // UnimplementedDebugServer can be embedded to have forward compatible implementations.
@@ -274,6 +682,18 @@ type UnimplementedDebugServer struct {
 func (*UnimplementedDebugServer) GetInfo(ctx context.Context, req *GetInfoRequest) (*GetInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
 }
+func (*UnimplementedDebugServer) Drain(ctx context.Context, req *DrainRequest) (*DrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drain not implemented")
+}
+func (*UnimplementedDebugServer) SetLogLevel(ctx context.Context, req *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (*UnimplementedDebugServer) MatchEntries(ctx context.Context, req *MatchEntriesRequest) (*MatchEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatchEntries not implemented")
+}
+func (*UnimplementedDebugServer) BackupDatastore(ctx context.Context, req *BackupDatastoreRequest) (*BackupDatastoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupDatastore not implemented")
+}
 
 func RegisterDebugServer(s *grpc.Server, srv DebugServer) {
 	s.RegisterService(&_Debug_serviceDesc, srv)
@@ -297,6 +717,78 @@ func _Debug_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Debug_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.debug.v1.Debug/Drain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).Drain(ctx, req.(*DrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.debug.v1.Debug/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_MatchEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).MatchEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.debug.v1.Debug/MatchEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).MatchEntries(ctx, req.(*MatchEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Debug_BackupDatastore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupDatastoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebugServer).BackupDatastore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.debug.v1.Debug/BackupDatastore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebugServer).BackupDatastore(ctx, req.(*BackupDatastoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Debug_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.server.debug.v1.Debug",
 	HandlerType: (*DebugServer)(nil),
@@ -305,6 +797,22 @@ var _Debug_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetInfo",
 			Handler:    _Debug_GetInfo_Handler,
 		},
+		{
+			MethodName: "Drain",
+			Handler:    _Debug_Drain_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _Debug_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "MatchEntries",
+			Handler:    _Debug_MatchEntries_Handler,
+		},
+		{
+			MethodName: "BackupDatastore",
+			Handler:    _Debug_BackupDatastore_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "spire/api/server/debug/v1/debug.proto",
@@ -253,10 +253,14 @@ type BatchCreateEntryRequest struct {
 	// be ignored here.
 	Entries []*types.Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	// An output mask indicating the entry fields set in the response.
-	OutputMask           *types.EntryMask `protobuf:"bytes,2,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	OutputMask *types.EntryMask `protobuf:"bytes,2,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
+	// If true, an entry that is similar to an existing entry (see
+	// BatchCreateEntryResponse) replaces the existing entry instead of being
+	// ignored. This allows a set of entries to be applied idempotently.
+	Upsert               bool     `protobuf:"varint,3,opt,name=upsert,proto3" json:"upsert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *BatchCreateEntryRequest) Reset()         { *m = BatchCreateEntryRequest{} }
@@ -298,6 +302,13 @@ func (m *BatchCreateEntryRequest) GetOutputMask() *types.EntryMask {
 	return nil
 }
 
+func (m *BatchCreateEntryRequest) GetUpsert() bool {
+	if m != nil {
+		return m.Upsert
+	}
+	return false
+}
+
 type BatchCreateEntryResponse struct {
 	// Result for each entry in the request (order is maintained).
 	Results              []*BatchCreateEntryResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
@@ -342,6 +353,8 @@ type BatchCreateEntryResponse_Result struct {
 	// The status of creating the entry. If status code will be
 	// ALREADY_EXISTS if a similar entry already exists. An entry is
 	// similar if it has the same spiffe_id, parent_id, and selectors.
+	// When upserting, the status code is OK if the similar entry was
+	// updated, and ALREADY_EXISTS if it was already up to date.
 	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	// The entry that was created (.e.g status code is OK) or that already
 	// exists (i.e. status code is ALREADY_EXISTS).
@@ -395,7 +408,10 @@ func (m *BatchCreateEntryResponse_Result) GetEntry() *types.Entry {
 type BatchUpdateEntryRequest struct {
 	// The entries to be updated.
 	Entries []*types.Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
-	// An input mask indicating what entry fields should be updated.
+	// An input mask indicating what entry fields should be updated. If
+	// revision_number is set, the update of an entry is rejected with ABORTED
+	// when the given revision number does not match the current one, which
+	// means the entry was updated since it was read.
 	InputMask *types.EntryMask `protobuf:"bytes,2,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
 	// An output mask indicating what entry fields are set in the response.
 	OutputMask           *types.EntryMask `protobuf:"bytes,3,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
@@ -670,19 +686,153 @@ func (m *BatchDeleteEntryResponse_Result) GetId() string {
 	return ""
 }
 
+type BatchRotateEntryRequest struct {
+	// IDs of the entries to rotate the SVIDs of.
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchRotateEntryRequest) Reset()         { *m = BatchRotateEntryRequest{} }
+func (m *BatchRotateEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchRotateEntryRequest) ProtoMessage()    {}
+func (*BatchRotateEntryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1dcc80f67f3b6103, []int{9}
+}
+
+func (m *BatchRotateEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchRotateEntryRequest.Unmarshal(m, b)
+}
+func (m *BatchRotateEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchRotateEntryRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchRotateEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchRotateEntryRequest.Merge(m, src)
+}
+func (m *BatchRotateEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchRotateEntryRequest.Size(m)
+}
+func (m *BatchRotateEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchRotateEntryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchRotateEntryRequest proto.InternalMessageInfo
+
+func (m *BatchRotateEntryRequest) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type BatchRotateEntryResponse struct {
+	// Result for each entry ID in the request (order is maintained).
+	Results              []*BatchRotateEntryResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *BatchRotateEntryResponse) Reset()         { *m = BatchRotateEntryResponse{} }
+func (m *BatchRotateEntryResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchRotateEntryResponse) ProtoMessage()    {}
+func (*BatchRotateEntryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1dcc80f67f3b6103, []int{10}
+}
+
+func (m *BatchRotateEntryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchRotateEntryResponse.Unmarshal(m, b)
+}
+func (m *BatchRotateEntryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchRotateEntryResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchRotateEntryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchRotateEntryResponse.Merge(m, src)
+}
+func (m *BatchRotateEntryResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchRotateEntryResponse.Size(m)
+}
+func (m *BatchRotateEntryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchRotateEntryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchRotateEntryResponse proto.InternalMessageInfo
+
+func (m *BatchRotateEntryResponse) GetResults() []*BatchRotateEntryResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchRotateEntryResponse_Result struct {
+	// The status of rotating the SVIDs of the entry.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The ID of the entry whose SVIDs were rotated.
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchRotateEntryResponse_Result) Reset()         { *m = BatchRotateEntryResponse_Result{} }
+func (m *BatchRotateEntryResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*BatchRotateEntryResponse_Result) ProtoMessage()    {}
+func (*BatchRotateEntryResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1dcc80f67f3b6103, []int{10, 0}
+}
+
+func (m *BatchRotateEntryResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchRotateEntryResponse_Result.Unmarshal(m, b)
+}
+func (m *BatchRotateEntryResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchRotateEntryResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *BatchRotateEntryResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchRotateEntryResponse_Result.Merge(m, src)
+}
+func (m *BatchRotateEntryResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_BatchRotateEntryResponse_Result.Size(m)
+}
+func (m *BatchRotateEntryResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchRotateEntryResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchRotateEntryResponse_Result proto.InternalMessageInfo
+
+func (m *BatchRotateEntryResponse_Result) GetStatus() *types.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *BatchRotateEntryResponse_Result) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
 type GetAuthorizedEntriesRequest struct {
 	// An output mask indicating which fields are set in the response.
-	OutputMask           *types.EntryMask `protobuf:"bytes,1,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	OutputMask *types.EntryMask `protobuf:"bytes,1,opt,name=output_mask,json=outputMask,proto3" json:"output_mask,omitempty"`
+	// Optional. The revision returned by a previous call. If the server still
+	// knows the authorized entries of the caller at that revision, the
+	// response only carries the changes since then. Otherwise, or if unset,
+	// all of the authorized entries are returned.
+	SinceRevision        int64    `protobuf:"varint,2,opt,name=since_revision,json=sinceRevision,proto3" json:"since_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetAuthorizedEntriesRequest) Reset()         { *m = GetAuthorizedEntriesRequest{} }
 func (m *GetAuthorizedEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAuthorizedEntriesRequest) ProtoMessage()    {}
 func (*GetAuthorizedEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1dcc80f67f3b6103, []int{9}
+	return fileDescriptor_1dcc80f67f3b6103, []int{11}
 }
 
 func (m *GetAuthorizedEntriesRequest) XXX_Unmarshal(b []byte) error {
@@ -710,19 +860,36 @@ func (m *GetAuthorizedEntriesRequest) GetOutputMask() *types.EntryMask {
 	return nil
 }
 
+func (m *GetAuthorizedEntriesRequest) GetSinceRevision() int64 {
+	if m != nil {
+		return m.SinceRevision
+	}
+	return 0
+}
+
 type GetAuthorizedEntriesResponse struct {
-	// The authorized entries.
-	Entries              []*types.Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	// The authorized entries. If delta is set, only the entries that were
+	// added or updated since the requested revision.
+	Entries []*types.Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// The revision of the authorized entries of the caller, to be passed as
+	// since_revision in the next call.
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// Whether the response only carries the changes since the requested
+	// revision.
+	Delta bool `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	// The IDs of the entries that are no longer authorized since the
+	// requested revision. Only set if delta is set.
+	DeletedEntryIds      []string `protobuf:"bytes,4,rep,name=deleted_entry_ids,json=deletedEntryIds,proto3" json:"deleted_entry_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetAuthorizedEntriesResponse) Reset()         { *m = GetAuthorizedEntriesResponse{} }
 func (m *GetAuthorizedEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAuthorizedEntriesResponse) ProtoMessage()    {}
 func (*GetAuthorizedEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1dcc80f67f3b6103, []int{10}
+	return fileDescriptor_1dcc80f67f3b6103, []int{12}
 }
 
 func (m *GetAuthorizedEntriesResponse) XXX_Unmarshal(b []byte) error {
@@ -750,6 +917,107 @@ func (m *GetAuthorizedEntriesResponse) GetEntries() []*types.Entry {
 	return nil
 }
 
+func (m *GetAuthorizedEntriesResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *GetAuthorizedEntriesResponse) GetDelta() bool {
+	if m != nil {
+		return m.Delta
+	}
+	return false
+}
+
+func (m *GetAuthorizedEntriesResponse) GetDeletedEntryIds() []string {
+	if m != nil {
+		return m.DeletedEntryIds
+	}
+	return nil
+}
+
+type WatchAuthorizedEntriesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchAuthorizedEntriesRequest) Reset()         { *m = WatchAuthorizedEntriesRequest{} }
+func (m *WatchAuthorizedEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchAuthorizedEntriesRequest) ProtoMessage()    {}
+func (*WatchAuthorizedEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1dcc80f67f3b6103, []int{13}
+}
+
+func (m *WatchAuthorizedEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchAuthorizedEntriesRequest.Unmarshal(m, b)
+}
+func (m *WatchAuthorizedEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchAuthorizedEntriesRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchAuthorizedEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchAuthorizedEntriesRequest.Merge(m, src)
+}
+func (m *WatchAuthorizedEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchAuthorizedEntriesRequest.Size(m)
+}
+func (m *WatchAuthorizedEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchAuthorizedEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchAuthorizedEntriesRequest proto.InternalMessageInfo
+
+type WatchAuthorizedEntriesResponse struct {
+	// The IDs of the entries that were added or updated.
+	UpdatedEntryIds []string `protobuf:"bytes,1,rep,name=updated_entry_ids,json=updatedEntryIds,proto3" json:"updated_entry_ids,omitempty"`
+	// The IDs of the entries that are no longer authorized.
+	DeletedEntryIds      []string `protobuf:"bytes,2,rep,name=deleted_entry_ids,json=deletedEntryIds,proto3" json:"deleted_entry_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchAuthorizedEntriesResponse) Reset()         { *m = WatchAuthorizedEntriesResponse{} }
+func (m *WatchAuthorizedEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchAuthorizedEntriesResponse) ProtoMessage()    {}
+func (*WatchAuthorizedEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1dcc80f67f3b6103, []int{14}
+}
+
+func (m *WatchAuthorizedEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchAuthorizedEntriesResponse.Unmarshal(m, b)
+}
+func (m *WatchAuthorizedEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchAuthorizedEntriesResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchAuthorizedEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchAuthorizedEntriesResponse.Merge(m, src)
+}
+func (m *WatchAuthorizedEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchAuthorizedEntriesResponse.Size(m)
+}
+func (m *WatchAuthorizedEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchAuthorizedEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchAuthorizedEntriesResponse proto.InternalMessageInfo
+
+func (m *WatchAuthorizedEntriesResponse) GetUpdatedEntryIds() []string {
+	if m != nil {
+		return m.UpdatedEntryIds
+	}
+	return nil
+}
+
+func (m *WatchAuthorizedEntriesResponse) GetDeletedEntryIds() []string {
+	if m != nil {
+		return m.DeletedEntryIds
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ListEntriesRequest)(nil), "spire.api.server.entry.v1.ListEntriesRequest")
 	proto.RegisterType((*ListEntriesRequest_Filter)(nil), "spire.api.server.entry.v1.ListEntriesRequest.Filter")
@@ -764,8 +1032,13 @@ func init() {
 	proto.RegisterType((*BatchDeleteEntryRequest)(nil), "spire.api.server.entry.v1.BatchDeleteEntryRequest")
 	proto.RegisterType((*BatchDeleteEntryResponse)(nil), "spire.api.server.entry.v1.BatchDeleteEntryResponse")
 	proto.RegisterType((*BatchDeleteEntryResponse_Result)(nil), "spire.api.server.entry.v1.BatchDeleteEntryResponse.Result")
+	proto.RegisterType((*BatchRotateEntryRequest)(nil), "spire.api.server.entry.v1.BatchRotateEntryRequest")
+	proto.RegisterType((*BatchRotateEntryResponse)(nil), "spire.api.server.entry.v1.BatchRotateEntryResponse")
+	proto.RegisterType((*BatchRotateEntryResponse_Result)(nil), "spire.api.server.entry.v1.BatchRotateEntryResponse.Result")
 	proto.RegisterType((*GetAuthorizedEntriesRequest)(nil), "spire.api.server.entry.v1.GetAuthorizedEntriesRequest")
 	proto.RegisterType((*GetAuthorizedEntriesResponse)(nil), "spire.api.server.entry.v1.GetAuthorizedEntriesResponse")
+	proto.RegisterType((*WatchAuthorizedEntriesRequest)(nil), "spire.api.server.entry.v1.WatchAuthorizedEntriesRequest")
+	proto.RegisterType((*WatchAuthorizedEntriesResponse)(nil), "spire.api.server.entry.v1.WatchAuthorizedEntriesResponse")
 }
 
 func init() {
@@ -773,53 +1046,64 @@ func init() {
 }
 
 var fileDescriptor_1dcc80f67f3b6103 = []byte{
-	// 736 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xd1, 0x6e, 0xd3, 0x4a,
-	0x10, 0x95, 0x93, 0x9b, 0xb4, 0x99, 0xdc, 0x7b, 0x5b, 0x6d, 0x81, 0x1a, 0x17, 0xa4, 0x28, 0x12,
-	0x28, 0xa2, 0xe0, 0xa8, 0x29, 0x50, 0x09, 0x54, 0x21, 0x4a, 0x9b, 0x2a, 0xa8, 0x95, 0x2a, 0xb7,
-	0xf0, 0xd0, 0x97, 0xc8, 0xa9, 0xa7, 0xed, 0x2a, 0xa9, 0x6d, 0xbc, 0xeb, 0x8a, 0x94, 0x17, 0xde,
-	0xf8, 0x00, 0x3e, 0x06, 0xf1, 0xca, 0x1f, 0xf0, 0x47, 0xc8, 0xbb, 0x6b, 0x14, 0xc7, 0x4e, 0xd3,
-	0x18, 0x89, 0x37, 0x6b, 0xe6, 0xcc, 0x99, 0xd9, 0x33, 0xb3, 0xe3, 0x85, 0x07, 0xcc, 0xa7, 0x01,
-	0x36, 0x6d, 0x9f, 0x36, 0x19, 0x06, 0x97, 0x18, 0x34, 0xd1, 0xe5, 0xc1, 0xb0, 0x79, 0xb9, 0x26,
-	0x3f, 0x4c, 0x3f, 0xf0, 0xb8, 0x47, 0xee, 0x0a, 0x98, 0x69, 0xfb, 0xd4, 0x94, 0x30, 0x53, 0x7a,
-	0x2f, 0xd7, 0x8c, 0x65, 0xc9, 0xc0, 0x87, 0x3e, 0xb2, 0xd1, 0x18, 0xc3, 0x18, 0x75, 0x30, 0x1c,
-	0xe0, 0x09, 0xf7, 0x82, 0x4c, 0x9f, 0x4f, 0x4f, 0x4f, 0x91, 0x3a, 0xca, 0xa7, 0x27, 0x7c, 0xdc,
-	0xe6, 0x21, 0x93, 0x9e, 0xfa, 0xd7, 0x22, 0x90, 0x3d, 0xca, 0xf8, 0x8e, 0xcb, 0x03, 0x8a, 0xcc,
-	0xc2, 0x0f, 0x21, 0x32, 0x4e, 0xf6, 0xa0, 0x7c, 0x4a, 0x07, 0x1c, 0x03, 0x5d, 0xab, 0x69, 0x8d,
-	0x6a, 0xeb, 0xa9, 0x39, 0xb1, 0x5a, 0x33, 0x1d, 0x6e, 0xb6, 0x45, 0xac, 0xa5, 0x38, 0xc8, 0x06,
-	0x54, 0xbd, 0x90, 0xfb, 0x21, 0xef, 0x5e, 0xd8, 0xac, 0xaf, 0x17, 0x04, 0xe5, 0x1d, 0x45, 0x29,
-	0x8a, 0x32, 0x23, 0x82, 0xe1, 0xbe, 0xcd, 0xfa, 0x16, 0x48, 0x68, 0xf4, 0x4d, 0x56, 0xa0, 0xe2,
-	0xdb, 0x67, 0xd8, 0x65, 0xf4, 0x0a, 0xf5, 0x62, 0x4d, 0x6b, 0x94, 0xac, 0xf9, 0xc8, 0x70, 0x48,
-	0xaf, 0x90, 0xdc, 0x07, 0x10, 0x4e, 0xee, 0xf5, 0xd1, 0xd5, 0xff, 0xa9, 0x69, 0x8d, 0x8a, 0x25,
-	0xe0, 0x47, 0x91, 0xc1, 0xf8, 0xae, 0x41, 0xb9, 0x1d, 0xe7, 0xff, 0xb7, 0x37, 0xec, 0x4a, 0x4d,
-	0xba, 0xd4, 0x51, 0x67, 0xba, 0x9d, 0x28, 0xe0, 0xf0, 0xa0, 0xd3, 0x6e, 0xef, 0x74, 0xb6, 0x2d,
-	0xe8, 0x0d, 0x0f, 0x05, 0xb2, 0xe3, 0xa8, 0x40, 0xdf, 0x0e, 0xd0, 0xe5, 0x51, 0x60, 0x61, 0x4a,
-	0xe0, 0x81, 0x40, 0x76, 0x1c, 0xb2, 0x29, 0x33, 0xaa, 0x0e, 0x31, 0x51, 0x7b, 0xb5, 0x65, 0x24,
-	0x03, 0x95, 0x77, 0xdf, 0xe6, 0x27, 0xe7, 0x56, 0xb5, 0x37, 0x8c, 0x0d, 0xac, 0xde, 0x87, 0xa5,
-	0x84, 0xaa, 0xcc, 0xf7, 0x5c, 0x86, 0xe4, 0x31, 0xcc, 0xa1, 0x34, 0xe9, 0x5a, 0xad, 0xd8, 0xa8,
-	0xb6, 0x48, 0x5a, 0x43, 0x2b, 0x86, 0x90, 0x87, 0xb0, 0xe0, 0xe2, 0x47, 0xde, 0x1d, 0x11, 0xa9,
-	0x20, 0x44, 0xfa, 0x2f, 0x32, 0x1f, 0xc4, 0x42, 0xd5, 0x8f, 0x61, 0x61, 0x17, 0xb9, 0x0c, 0x56,
-	0xed, 0xff, 0x1f, 0x0a, 0x4a, 0xa6, 0x8a, 0x55, 0xa0, 0x4e, 0xee, 0x06, 0xd6, 0x3f, 0x6b, 0xb0,
-	0xbc, 0x15, 0x9d, 0xef, 0x4d, 0x80, 0x36, 0xc7, 0x44, 0x92, 0xd9, 0x4e, 0x93, 0xbb, 0x84, 0x9f,
-	0x1a, 0xe8, 0xe9, 0x12, 0x94, 0xa2, 0x47, 0x30, 0x17, 0x20, 0x0b, 0x07, 0x3c, 0xae, 0xe1, 0xc5,
-	0x35, 0x83, 0x3e, 0x89, 0xc5, 0xb4, 0x04, 0x85, 0x15, 0x53, 0x19, 0x5d, 0x28, 0x4b, 0x13, 0x59,
-	0x85, 0xb2, 0xbc, 0x6e, 0x6a, 0xe6, 0x96, 0x92, 0x13, 0x20, 0x5c, 0x96, 0x82, 0x90, 0x06, 0x94,
-	0x44, 0x2e, 0x75, 0xb8, 0x2c, 0x39, 0x24, 0xa0, 0xfe, 0x2d, 0x96, 0xf5, 0x9d, 0xef, 0xfc, 0x99,
-	0xac, 0xcf, 0x00, 0xa8, 0x7b, 0x43, 0x55, 0x2b, 0x02, 0x29, 0x2e, 0xe6, 0x58, 0x37, 0x8a, 0xb3,
-	0x77, 0x23, 0x51, 0x79, 0xee, 0x6e, 0x64, 0xb0, 0xfc, 0xfd, 0x6e, 0xac, 0xaa, 0x66, 0x6c, 0xe3,
-	0x00, 0xc7, 0x9a, 0xb1, 0x08, 0x45, 0xea, 0xc8, 0xd3, 0x54, 0xac, 0xe8, 0x33, 0x6a, 0x9d, 0x9e,
-	0x46, 0xe7, 0x16, 0x20, 0x83, 0x25, 0x25, 0xc0, 0x4e, 0x3e, 0x01, 0xe4, 0x12, 0x28, 0xc4, 0x4b,
-	0xa0, 0xfe, 0x1e, 0x56, 0x76, 0x91, 0xbf, 0x0e, 0xf9, 0xb9, 0x17, 0xd0, 0x2b, 0x74, 0xc6, 0x7e,
-	0x19, 0x63, 0x23, 0xa1, 0xdd, 0x78, 0x24, 0xf6, 0xe0, 0x5e, 0x36, 0x6f, 0x9e, 0xad, 0xd7, 0xfa,
-	0x51, 0x82, 0x92, 0x30, 0x91, 0x01, 0x54, 0x47, 0x96, 0x28, 0x79, 0x32, 0xd3, 0x2f, 0xcc, 0x30,
-	0x6f, 0x0a, 0x57, 0x55, 0xbe, 0x85, 0xf9, 0x78, 0x8b, 0x92, 0x47, 0xd7, 0xc4, 0x8e, 0xad, 0x5a,
-	0x23, 0xe3, 0x30, 0xe4, 0x13, 0x2c, 0x8e, 0xef, 0x1a, 0xd2, 0x9a, 0x69, 0x31, 0x49, 0xee, 0xf5,
-	0x1c, 0xcb, 0xec, 0x77, 0xf2, 0x91, 0xab, 0x35, 0x3d, 0x79, 0x7a, 0x0f, 0x4d, 0x4f, 0x9e, 0xb5,
-	0x01, 0xe2, 0xe4, 0x23, 0x63, 0x3d, 0x3d, 0x79, 0xfa, 0xde, 0x4d, 0x4f, 0x9e, 0x75, 0xfb, 0xbe,
-	0x68, 0x70, 0x2b, 0x6b, 0x12, 0xc9, 0xf3, 0xeb, 0xfb, 0x39, 0xe9, 0x4a, 0x18, 0x1b, 0x33, 0xc7,
-	0xc9, 0x4a, 0xb6, 0x5e, 0x1d, 0x6f, 0x9e, 0x51, 0x7e, 0x1e, 0xf6, 0xcc, 0x13, 0xef, 0x42, 0x3d,
-	0xe6, 0x9a, 0xf2, 0x0d, 0x27, 0x9e, 0x6d, 0xcd, 0x89, 0x4f, 0xcc, 0x97, 0xe2, 0xa3, 0x57, 0x16,
-	0xb0, 0xf5, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x3a, 0x6b, 0x38, 0x66, 0x8c, 0x0a, 0x00, 0x00,
+	// 906 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x5f, 0x8f, 0xdb, 0x44,
+	0x10, 0x97, 0x93, 0x5e, 0xee, 0x32, 0xa1, 0xbd, 0x76, 0x5b, 0xae, 0xc1, 0xa5, 0x70, 0xb2, 0x54,
+	0x14, 0x5d, 0xc1, 0xa1, 0x09, 0x50, 0xfe, 0xa8, 0x42, 0x94, 0xde, 0x55, 0x41, 0xad, 0x74, 0xda,
+	0x2b, 0x42, 0xea, 0x8b, 0xe5, 0x9c, 0xe7, 0x7a, 0xab, 0xa4, 0xb6, 0xf1, 0xae, 0xa3, 0xe6, 0x10,
+	0xcf, 0x3c, 0xf2, 0xc0, 0x0b, 0x9f, 0x81, 0x2f, 0x50, 0xf1, 0x31, 0xee, 0x1b, 0x21, 0xef, 0xae,
+	0x23, 0xdb, 0x71, 0xfe, 0x19, 0xc1, 0x9b, 0xbd, 0xf3, 0xe7, 0x37, 0xf3, 0x9b, 0xd9, 0x19, 0x1b,
+	0xee, 0xf1, 0x90, 0x45, 0xd8, 0x75, 0x43, 0xd6, 0xe5, 0x18, 0x4d, 0x30, 0xea, 0xa2, 0x2f, 0xa2,
+	0x69, 0x77, 0xf2, 0x40, 0x3d, 0xd8, 0x61, 0x14, 0x88, 0x80, 0xbc, 0x27, 0xd5, 0x6c, 0x37, 0x64,
+	0xb6, 0x52, 0xb3, 0x95, 0x74, 0xf2, 0xc0, 0xbc, 0xad, 0x3c, 0x88, 0x69, 0x88, 0x3c, 0x6b, 0x63,
+	0x9a, 0x59, 0x01, 0xc7, 0x31, 0x9e, 0x8a, 0x20, 0x2a, 0x95, 0x85, 0xec, 0xec, 0x0c, 0x99, 0xa7,
+	0x65, 0xed, 0x9c, 0x4c, 0xb8, 0x22, 0xe6, 0x4a, 0x62, 0xfd, 0x51, 0x07, 0xf2, 0x8c, 0x71, 0x71,
+	0xe8, 0x8b, 0x88, 0x21, 0xa7, 0xf8, 0x73, 0x8c, 0x5c, 0x90, 0x67, 0xd0, 0x38, 0x63, 0x63, 0x81,
+	0x51, 0xdb, 0xd8, 0x37, 0x3a, 0xad, 0xde, 0x67, 0xf6, 0xc2, 0x68, 0xed, 0x79, 0x73, 0xfb, 0x48,
+	0xda, 0x52, 0xed, 0x83, 0x3c, 0x84, 0x56, 0x10, 0x8b, 0x30, 0x16, 0xce, 0x6b, 0x97, 0x8f, 0xda,
+	0x35, 0xe9, 0x72, 0x4f, 0xbb, 0x94, 0x41, 0xd9, 0x89, 0x83, 0xe9, 0x73, 0x97, 0x8f, 0x28, 0x28,
+	0xd5, 0xe4, 0x99, 0xdc, 0x81, 0x66, 0xe8, 0xbe, 0x42, 0x87, 0xb3, 0x0b, 0x6c, 0xd7, 0xf7, 0x8d,
+	0xce, 0x16, 0xdd, 0x49, 0x0e, 0x4e, 0xd8, 0x05, 0x92, 0xbb, 0x00, 0x52, 0x28, 0x82, 0x11, 0xfa,
+	0xed, 0x2b, 0xfb, 0x46, 0xa7, 0x49, 0xa5, 0xfa, 0x8b, 0xe4, 0xc0, 0xfc, 0xdb, 0x80, 0xc6, 0x51,
+	0x8a, 0xff, 0xce, 0x70, 0xea, 0x28, 0x4e, 0x1c, 0xe6, 0xe9, 0x9c, 0xde, 0xcd, 0x05, 0x70, 0x72,
+	0x3c, 0x38, 0x3a, 0x3a, 0x1c, 0x3c, 0xa1, 0x30, 0x9c, 0x9e, 0x48, 0xcd, 0x81, 0xa7, 0x0d, 0x43,
+	0x37, 0x42, 0x5f, 0x24, 0x86, 0xb5, 0x15, 0x86, 0xc7, 0x52, 0x73, 0xe0, 0x91, 0x47, 0x0a, 0x51,
+	0x57, 0x88, 0xcb, 0xd8, 0x5b, 0x3d, 0x33, 0x6f, 0xa8, 0xa5, 0xcf, 0x5d, 0x71, 0x7a, 0x4e, 0x5b,
+	0xc3, 0x69, 0x7a, 0xc0, 0xad, 0x11, 0xdc, 0xcc, 0xb1, 0xca, 0xc3, 0xc0, 0xe7, 0x48, 0x3e, 0x86,
+	0x6d, 0x54, 0x47, 0x6d, 0x63, 0xbf, 0xde, 0x69, 0xf5, 0xc8, 0x3c, 0x87, 0x34, 0x55, 0x21, 0x1f,
+	0xc1, 0xae, 0x8f, 0x6f, 0x84, 0x93, 0x21, 0xa9, 0x26, 0x49, 0xba, 0x9a, 0x1c, 0x1f, 0xa7, 0x44,
+	0x59, 0x2f, 0x61, 0xf7, 0x29, 0x0a, 0x65, 0xac, 0xcb, 0x7f, 0x0d, 0x6a, 0x9a, 0xa6, 0x26, 0xad,
+	0x31, 0xaf, 0x72, 0x01, 0xad, 0x3f, 0x0d, 0xb8, 0xfd, 0x38, 0xc9, 0xef, 0xfb, 0x08, 0x5d, 0x81,
+	0x39, 0x90, 0xcd, 0xb2, 0xa9, 0xdc, 0x43, 0x7b, 0xd0, 0x88, 0x43, 0x8e, 0x91, 0x90, 0x45, 0xd8,
+	0xa1, 0xfa, 0xcd, 0xba, 0x34, 0xa0, 0x3d, 0x1f, 0x9a, 0x66, 0xfa, 0x05, 0x6c, 0x47, 0xc8, 0xe3,
+	0xb1, 0x48, 0x63, 0xfb, 0x7a, 0xc9, 0x05, 0x58, 0xe4, 0xc5, 0xa6, 0xd2, 0x05, 0x4d, 0x5d, 0x99,
+	0x0e, 0x34, 0xd4, 0x11, 0xb9, 0x0f, 0x0d, 0x75, 0x0d, 0x75, 0x2f, 0xde, 0xcc, 0x77, 0x86, 0x14,
+	0x51, 0xad, 0x42, 0x3a, 0xb0, 0x25, 0xb1, 0x74, 0xd2, 0x65, 0x34, 0x29, 0x05, 0xeb, 0x6d, 0x4a,
+	0xf7, 0x8f, 0xa1, 0xf7, 0xef, 0xe8, 0xfe, 0x1c, 0x80, 0xf9, 0x6b, 0xb2, 0xdd, 0x94, 0x9a, 0x92,
+	0xec, 0x42, 0x95, 0xea, 0x6b, 0x37, 0xca, 0xac, 0x1a, 0xb9, 0xc8, 0x2b, 0x57, 0xa3, 0xc4, 0xcb,
+	0xff, 0x5f, 0x8d, 0xfb, 0xba, 0x18, 0x4f, 0x70, 0x8c, 0x85, 0x62, 0x5c, 0x87, 0x3a, 0xf3, 0x54,
+	0x36, 0x4d, 0x9a, 0x3c, 0x5a, 0x6f, 0x53, 0x02, 0x72, 0xda, 0x95, 0x09, 0x28, 0xf1, 0x32, 0x47,
+	0xc0, 0x61, 0x35, 0x02, 0xd4, 0x70, 0xa8, 0xa5, 0xc3, 0x61, 0x96, 0x26, 0x0d, 0x84, 0xbb, 0x7e,
+	0x9a, 0x39, 0xed, 0xca, 0x69, 0x96, 0x78, 0xf9, 0xaf, 0xd2, 0xfc, 0x15, 0xee, 0x3c, 0x45, 0xf1,
+	0x5d, 0x2c, 0xce, 0x83, 0x88, 0x5d, 0xa0, 0x57, 0xd8, 0x98, 0x85, 0xce, 0x37, 0xd6, 0x9e, 0x4f,
+	0xf7, 0xe0, 0x1a, 0x67, 0xfe, 0x29, 0x3a, 0x11, 0x4e, 0x18, 0x67, 0x81, 0x9a, 0xd2, 0x75, 0x7a,
+	0x55, 0x9e, 0x52, 0x7d, 0x68, 0xfd, 0x65, 0xc0, 0xfb, 0xe5, 0xf8, 0x95, 0x96, 0x83, 0x09, 0x3b,
+	0x05, 0xbc, 0xd9, 0x3b, 0xb9, 0x05, 0x5b, 0x1e, 0x8e, 0x85, 0xab, 0x07, 0xa6, 0x7a, 0x21, 0x07,
+	0x70, 0xc3, 0x93, 0x4d, 0xe5, 0x39, 0xb2, 0x06, 0x4e, 0x52, 0xd9, 0x2b, 0xb2, 0xb2, 0xbb, 0x5a,
+	0x20, 0x31, 0x06, 0x1e, 0xb7, 0x3e, 0x84, 0xbb, 0x3f, 0x25, 0xe5, 0x59, 0xc4, 0x96, 0xf5, 0x06,
+	0x3e, 0x58, 0xa4, 0xa0, 0xd3, 0x39, 0x80, 0x1b, 0xb1, 0xbc, 0xc4, 0x59, 0x38, 0xd5, 0x48, 0xbb,
+	0x5a, 0x90, 0xc2, 0x95, 0x87, 0x56, 0x2b, 0x0d, 0xad, 0x77, 0xb9, 0x0d, 0x5b, 0xf2, 0x85, 0x8c,
+	0xa1, 0x95, 0x59, 0xb2, 0xe4, 0x93, 0x8d, 0x3e, 0x71, 0x4c, 0x7b, 0x5d, 0x75, 0x9d, 0xcf, 0x0f,
+	0xb0, 0x93, 0x6e, 0x59, 0x72, 0xb0, 0xc4, 0xb6, 0xb0, 0x8a, 0xcd, 0x92, 0x2a, 0x92, 0x5f, 0xe0,
+	0x7a, 0x71, 0xe7, 0x90, 0xde, 0x46, 0x0b, 0x4a, 0xf9, 0xee, 0x57, 0x58, 0x6a, 0x33, 0xf0, 0xcc,
+	0x88, 0x5d, 0x0d, 0x3e, 0xbf, 0x8f, 0xcc, 0xfe, 0x46, 0x36, 0x05, 0xf0, 0xcc, 0x78, 0x5b, 0x0d,
+	0x3e, 0x3f, 0x7f, 0xcd, 0xfe, 0x46, 0x36, 0x05, 0xf0, 0xcc, 0xd0, 0x59, 0x0d, 0x3e, 0x3f, 0x15,
+	0xcd, 0xfe, 0x46, 0x36, 0x1a, 0xfc, 0x37, 0x03, 0x6e, 0x95, 0xdd, 0x7f, 0xf2, 0xc5, 0xf2, 0x66,
+	0x5a, 0x74, 0x05, 0xcd, 0x87, 0x1b, 0xdb, 0xe9, 0x48, 0x7e, 0x37, 0x60, 0xaf, 0xfc, 0xf2, 0x92,
+	0x2f, 0x97, 0xf8, 0x5c, 0x3a, 0x10, 0xcc, 0xaf, 0x2a, 0x58, 0xaa, 0x78, 0x3e, 0x35, 0x1e, 0x7f,
+	0xfb, 0xf2, 0xd1, 0x2b, 0x26, 0xce, 0xe3, 0xa1, 0x7d, 0x1a, 0xbc, 0xd6, 0xff, 0x3e, 0x5d, 0xf5,
+	0xcb, 0x23, 0xff, 0x72, 0xba, 0x0b, 0xff, 0xc8, 0xbe, 0x91, 0x0f, 0xc3, 0x86, 0x54, 0xeb, 0xff,
+	0x33, 0x00, 0xb0, 0x51, 0x09, 0x1a, 0xbb, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -836,29 +1120,50 @@ const _ = grpc.SupportPackageIsVersion6
 type EntryClient interface {
 	// Lists entries.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
 	// Gets an entry. If the entry does not exist, NOT_FOUND is returned.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*types.Entry, error)
 	// Batch creates one or more entries.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
 	BatchCreateEntry(ctx context.Context, in *BatchCreateEntryRequest, opts ...grpc.CallOption) (*BatchCreateEntryResponse, error)
 	// Batch updates one or more entries.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
 	BatchUpdateEntry(ctx context.Context, in *BatchUpdateEntryRequest, opts ...grpc.CallOption) (*BatchUpdateEntryResponse, error)
 	// Batch deletes one or more entries.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
 	BatchDeleteEntry(ctx context.Context, in *BatchDeleteEntryRequest, opts ...grpc.CallOption) (*BatchDeleteEntryResponse, error)
+	// Batch rotates the SVIDs of one or more entries. Agents renew the
+	// X509-SVIDs issued based on the entries, using new keys, as soon as they
+	// synchronize with the server, regardless of their expiration.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
+	BatchRotateEntry(ctx context.Context, in *BatchRotateEntryRequest, opts ...grpc.CallOption) (*BatchRotateEntryResponse, error)
 	// Gets the entries the caller is authorized for.
 	//
 	// The caller must present an active agent X509-SVID. See the Agent
 	// AttestAgent/RenewAgent RPCs.
 	GetAuthorizedEntries(ctx context.Context, in *GetAuthorizedEntriesRequest, opts ...grpc.CallOption) (*GetAuthorizedEntriesResponse, error)
+	// Watches changes to the entries the caller is authorized for. The first
+	// response is sent as soon as the watch is established. Subsequent
+	// responses are sent whenever entries are added to, updated in or removed
+	// from the entries the caller is authorized for. The caller is expected
+	// to get the changes with GetAuthorizedEntries.
+	//
+	// The caller must present an active agent X509-SVID. See the Agent
+	// AttestAgent/RenewAgent RPCs.
+	WatchAuthorizedEntries(ctx context.Context, in *WatchAuthorizedEntriesRequest, opts ...grpc.CallOption) (Entry_WatchAuthorizedEntriesClient, error)
 }
 
 type entryClient struct {
@@ -914,6 +1219,15 @@ func (c *entryClient) BatchDeleteEntry(ctx context.Context, in *BatchDeleteEntry
 	return out, nil
 }
 
+func (c *entryClient) BatchRotateEntry(ctx context.Context, in *BatchRotateEntryRequest, opts ...grpc.CallOption) (*BatchRotateEntryResponse, error) {
+	out := new(BatchRotateEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.entry.v1.Entry/BatchRotateEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *entryClient) GetAuthorizedEntries(ctx context.Context, in *GetAuthorizedEntriesRequest, opts ...grpc.CallOption) (*GetAuthorizedEntriesResponse, error) {
 	out := new(GetAuthorizedEntriesResponse)
 	err := c.cc.Invoke(ctx, "/spire.api.server.entry.v1.Entry/GetAuthorizedEntries", in, out, opts...)
@@ -923,33 +1237,86 @@ func (c *entryClient) GetAuthorizedEntries(ctx context.Context, in *GetAuthorize
 	return out, nil
 }
 
+func (c *entryClient) WatchAuthorizedEntries(ctx context.Context, in *WatchAuthorizedEntriesRequest, opts ...grpc.CallOption) (Entry_WatchAuthorizedEntriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Entry_serviceDesc.Streams[0], "/spire.api.server.entry.v1.Entry/WatchAuthorizedEntries", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &entryWatchAuthorizedEntriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Entry_WatchAuthorizedEntriesClient interface {
+	Recv() (*WatchAuthorizedEntriesResponse, error)
+	grpc.ClientStream
+}
+
+type entryWatchAuthorizedEntriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *entryWatchAuthorizedEntriesClient) Recv() (*WatchAuthorizedEntriesResponse, error) {
+	m := new(WatchAuthorizedEntriesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EntryServer is the server API for Entry service.
 type EntryServer interface {
 	// Lists entries.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error)
 	// Gets an entry. If the entry does not exist, NOT_FOUND is returned.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
 	GetEntry(context.Context, *GetEntryRequest) (*types.Entry, error)
 	// Batch creates one or more entries.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
 	BatchCreateEntry(context.Context, *BatchCreateEntryRequest) (*BatchCreateEntryResponse, error)
 	// Batch updates one or more entries.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
 	BatchUpdateEntry(context.Context, *BatchUpdateEntryRequest) (*BatchUpdateEntryResponse, error)
 	// Batch deletes one or more entries.
 	//
-	// The caller must be local or present an admin X509-SVID.
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
 	BatchDeleteEntry(context.Context, *BatchDeleteEntryRequest) (*BatchDeleteEntryResponse, error)
+	// Batch rotates the SVIDs of one or more entries. Agents renew the
+	// X509-SVIDs issued based on the entries, using new keys, as soon as they
+	// synchronize with the server, regardless of their expiration.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
+	BatchRotateEntry(context.Context, *BatchRotateEntryRequest) (*BatchRotateEntryResponse, error)
 	// Gets the entries the caller is authorized for.
 	//
 	// The caller must present an active agent X509-SVID. See the Agent
 	// AttestAgent/RenewAgent RPCs.
 	GetAuthorizedEntries(context.Context, *GetAuthorizedEntriesRequest) (*GetAuthorizedEntriesResponse, error)
+	// Watches changes to the entries the caller is authorized for. The first
+	// response is sent as soon as the watch is established. Subsequent
+	// responses are sent whenever entries are added to, updated in or removed
+	// from the entries the caller is authorized for. The caller is expected
+	// to get the changes with GetAuthorizedEntries.
+	//
+	// The caller must present an active agent X509-SVID. See the Agent
+	// AttestAgent/RenewAgent RPCs.
+	WatchAuthorizedEntries(*WatchAuthorizedEntriesRequest, Entry_WatchAuthorizedEntriesServer) error
 }
 
 // UnimplementedEntryServer can be embedded to have forward compatible implementations.
@@ -971,9 +1338,15 @@ func (*UnimplementedEntryServer) BatchUpdateEntry(ctx context.Context, req *Batc
 func (*UnimplementedEntryServer) BatchDeleteEntry(ctx context.Context, req *BatchDeleteEntryRequest) (*BatchDeleteEntryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteEntry not implemented")
 }
+func (*UnimplementedEntryServer) BatchRotateEntry(ctx context.Context, req *BatchRotateEntryRequest) (*BatchRotateEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchRotateEntry not implemented")
+}
 func (*UnimplementedEntryServer) GetAuthorizedEntries(ctx context.Context, req *GetAuthorizedEntriesRequest) (*GetAuthorizedEntriesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAuthorizedEntries not implemented")
 }
+func (*UnimplementedEntryServer) WatchAuthorizedEntries(req *WatchAuthorizedEntriesRequest, srv Entry_WatchAuthorizedEntriesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAuthorizedEntries not implemented")
+}
 
 func RegisterEntryServer(s *grpc.Server, srv EntryServer) {
 	s.RegisterService(&_Entry_serviceDesc, srv)
@@ -1069,6 +1442,24 @@ func _Entry_BatchDeleteEntry_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Entry_BatchRotateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRotateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).BatchRotateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.entry.v1.Entry/BatchRotateEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).BatchRotateEntry(ctx, req.(*BatchRotateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Entry_GetAuthorizedEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAuthorizedEntriesRequest)
 	if err := dec(in); err != nil {
@@ -1087,6 +1478,27 @@ func _Entry_GetAuthorizedEntries_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Entry_WatchAuthorizedEntries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAuthorizedEntriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EntryServer).WatchAuthorizedEntries(m, &entryWatchAuthorizedEntriesServer{stream})
+}
+
+type Entry_WatchAuthorizedEntriesServer interface {
+	Send(*WatchAuthorizedEntriesResponse) error
+	grpc.ServerStream
+}
+
+type entryWatchAuthorizedEntriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *entryWatchAuthorizedEntriesServer) Send(m *WatchAuthorizedEntriesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Entry_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.server.entry.v1.Entry",
 	HandlerType: (*EntryServer)(nil),
@@ -1111,11 +1523,21 @@ var _Entry_serviceDesc = grpc.ServiceDesc{
 			MethodName: "BatchDeleteEntry",
 			Handler:    _Entry_BatchDeleteEntry_Handler,
 		},
+		{
+			MethodName: "BatchRotateEntry",
+			Handler:    _Entry_BatchRotateEntry_Handler,
+		},
 		{
 			MethodName: "GetAuthorizedEntries",
 			Handler:    _Entry_GetAuthorizedEntries_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAuthorizedEntries",
+			Handler:       _Entry_WatchAuthorizedEntries_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "spire/api/server/entry/v1/entry.proto",
 }
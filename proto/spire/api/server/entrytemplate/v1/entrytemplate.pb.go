@@ -0,0 +1,912 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: spire/api/server/entrytemplate/v1/entrytemplate.proto
+
+package entrytemplate
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	types "github.com/spiffe/spire/proto/spire/types"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ListEntryTemplatesRequest struct {
+	// The maximum number of results to return. The server may further
+	// constrain this value, or if zero, choose its own.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token value returned from a previous request, if any.
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEntryTemplatesRequest) Reset()         { *m = ListEntryTemplatesRequest{} }
+func (m *ListEntryTemplatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListEntryTemplatesRequest) ProtoMessage()    {}
+func (*ListEntryTemplatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{0}
+}
+
+func (m *ListEntryTemplatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntryTemplatesRequest.Unmarshal(m, b)
+}
+func (m *ListEntryTemplatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntryTemplatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListEntryTemplatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntryTemplatesRequest.Merge(m, src)
+}
+func (m *ListEntryTemplatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListEntryTemplatesRequest.Size(m)
+}
+func (m *ListEntryTemplatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntryTemplatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEntryTemplatesRequest proto.InternalMessageInfo
+
+func (m *ListEntryTemplatesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListEntryTemplatesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListEntryTemplatesResponse struct {
+	// The entry templates.
+	EntryTemplates []*types.EntryTemplate `protobuf:"bytes,1,rep,name=entry_templates,json=entryTemplates,proto3" json:"entry_templates,omitempty"`
+	// The page token for the next request. Empty if there are no more results.
+	// This field should be checked by clients even when a page_size was not
+	// requested, since the server may choose its own (see page_size).
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEntryTemplatesResponse) Reset()         { *m = ListEntryTemplatesResponse{} }
+func (m *ListEntryTemplatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListEntryTemplatesResponse) ProtoMessage()    {}
+func (*ListEntryTemplatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{1}
+}
+
+func (m *ListEntryTemplatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntryTemplatesResponse.Unmarshal(m, b)
+}
+func (m *ListEntryTemplatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntryTemplatesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListEntryTemplatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntryTemplatesResponse.Merge(m, src)
+}
+func (m *ListEntryTemplatesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListEntryTemplatesResponse.Size(m)
+}
+func (m *ListEntryTemplatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntryTemplatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEntryTemplatesResponse proto.InternalMessageInfo
+
+func (m *ListEntryTemplatesResponse) GetEntryTemplates() []*types.EntryTemplate {
+	if m != nil {
+		return m.EntryTemplates
+	}
+	return nil
+}
+
+func (m *ListEntryTemplatesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetEntryTemplateRequest struct {
+	// Required. ID of the entry template to get.
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEntryTemplateRequest) Reset()         { *m = GetEntryTemplateRequest{} }
+func (m *GetEntryTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEntryTemplateRequest) ProtoMessage()    {}
+func (*GetEntryTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{2}
+}
+
+func (m *GetEntryTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEntryTemplateRequest.Unmarshal(m, b)
+}
+func (m *GetEntryTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEntryTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEntryTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEntryTemplateRequest.Merge(m, src)
+}
+func (m *GetEntryTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEntryTemplateRequest.Size(m)
+}
+func (m *GetEntryTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEntryTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEntryTemplateRequest proto.InternalMessageInfo
+
+func (m *GetEntryTemplateRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type BatchCreateEntryTemplateRequest struct {
+	// The entry templates to be created.
+	EntryTemplates       []*types.EntryTemplate `protobuf:"bytes,1,rep,name=entry_templates,json=entryTemplates,proto3" json:"entry_templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *BatchCreateEntryTemplateRequest) Reset()         { *m = BatchCreateEntryTemplateRequest{} }
+func (m *BatchCreateEntryTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateEntryTemplateRequest) ProtoMessage()    {}
+func (*BatchCreateEntryTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{3}
+}
+
+func (m *BatchCreateEntryTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateEntryTemplateRequest.Unmarshal(m, b)
+}
+func (m *BatchCreateEntryTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateEntryTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateEntryTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateEntryTemplateRequest.Merge(m, src)
+}
+func (m *BatchCreateEntryTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateEntryTemplateRequest.Size(m)
+}
+func (m *BatchCreateEntryTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateEntryTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateEntryTemplateRequest proto.InternalMessageInfo
+
+func (m *BatchCreateEntryTemplateRequest) GetEntryTemplates() []*types.EntryTemplate {
+	if m != nil {
+		return m.EntryTemplates
+	}
+	return nil
+}
+
+type BatchCreateEntryTemplateResponse struct {
+	// Result for each entry template in the request (order is maintained).
+	Results              []*BatchCreateEntryTemplateResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                   `json:"-"`
+	XXX_unrecognized     []byte                                     `json:"-"`
+	XXX_sizecache        int32                                      `json:"-"`
+}
+
+func (m *BatchCreateEntryTemplateResponse) Reset()         { *m = BatchCreateEntryTemplateResponse{} }
+func (m *BatchCreateEntryTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateEntryTemplateResponse) ProtoMessage()    {}
+func (*BatchCreateEntryTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{4}
+}
+
+func (m *BatchCreateEntryTemplateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateEntryTemplateResponse.Unmarshal(m, b)
+}
+func (m *BatchCreateEntryTemplateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateEntryTemplateResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateEntryTemplateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateEntryTemplateResponse.Merge(m, src)
+}
+func (m *BatchCreateEntryTemplateResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateEntryTemplateResponse.Size(m)
+}
+func (m *BatchCreateEntryTemplateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateEntryTemplateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateEntryTemplateResponse proto.InternalMessageInfo
+
+func (m *BatchCreateEntryTemplateResponse) GetResults() []*BatchCreateEntryTemplateResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchCreateEntryTemplateResponse_Result struct {
+	// The status of creating the entry template.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The entry template that was created. This will be set if the
+	// status is OK.
+	EntryTemplate        *types.EntryTemplate `protobuf:"bytes,2,opt,name=entry_template,json=entryTemplate,proto3" json:"entry_template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BatchCreateEntryTemplateResponse_Result) Reset() {
+	*m = BatchCreateEntryTemplateResponse_Result{}
+}
+func (m *BatchCreateEntryTemplateResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateEntryTemplateResponse_Result) ProtoMessage()    {}
+func (*BatchCreateEntryTemplateResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{4, 0}
+}
+
+func (m *BatchCreateEntryTemplateResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateEntryTemplateResponse_Result.Unmarshal(m, b)
+}
+func (m *BatchCreateEntryTemplateResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateEntryTemplateResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateEntryTemplateResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateEntryTemplateResponse_Result.Merge(m, src)
+}
+func (m *BatchCreateEntryTemplateResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateEntryTemplateResponse_Result.Size(m)
+}
+func (m *BatchCreateEntryTemplateResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateEntryTemplateResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateEntryTemplateResponse_Result proto.InternalMessageInfo
+
+func (m *BatchCreateEntryTemplateResponse_Result) GetStatus() *types.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *BatchCreateEntryTemplateResponse_Result) GetEntryTemplate() *types.EntryTemplate {
+	if m != nil {
+		return m.EntryTemplate
+	}
+	return nil
+}
+
+type BatchUpdateEntryTemplateRequest struct {
+	// The entry templates to be updated.
+	EntryTemplates []*types.EntryTemplate `protobuf:"bytes,1,rep,name=entry_templates,json=entryTemplates,proto3" json:"entry_templates,omitempty"`
+	// An input mask indicating which entry template fields should be
+	// updated.
+	InputMask            *types.EntryTemplateMask `protobuf:"bytes,2,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *BatchUpdateEntryTemplateRequest) Reset()         { *m = BatchUpdateEntryTemplateRequest{} }
+func (m *BatchUpdateEntryTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateEntryTemplateRequest) ProtoMessage()    {}
+func (*BatchUpdateEntryTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{5}
+}
+
+func (m *BatchUpdateEntryTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateEntryTemplateRequest.Unmarshal(m, b)
+}
+func (m *BatchUpdateEntryTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateEntryTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateEntryTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateEntryTemplateRequest.Merge(m, src)
+}
+func (m *BatchUpdateEntryTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateEntryTemplateRequest.Size(m)
+}
+func (m *BatchUpdateEntryTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateEntryTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchUpdateEntryTemplateRequest proto.InternalMessageInfo
+
+func (m *BatchUpdateEntryTemplateRequest) GetEntryTemplates() []*types.EntryTemplate {
+	if m != nil {
+		return m.EntryTemplates
+	}
+	return nil
+}
+
+func (m *BatchUpdateEntryTemplateRequest) GetInputMask() *types.EntryTemplateMask {
+	if m != nil {
+		return m.InputMask
+	}
+	return nil
+}
+
+type BatchUpdateEntryTemplateResponse struct {
+	// Result for each entry template in the request (order is maintained).
+	Results              []*BatchUpdateEntryTemplateResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                   `json:"-"`
+	XXX_unrecognized     []byte                                     `json:"-"`
+	XXX_sizecache        int32                                      `json:"-"`
+}
+
+func (m *BatchUpdateEntryTemplateResponse) Reset()         { *m = BatchUpdateEntryTemplateResponse{} }
+func (m *BatchUpdateEntryTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateEntryTemplateResponse) ProtoMessage()    {}
+func (*BatchUpdateEntryTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{6}
+}
+
+func (m *BatchUpdateEntryTemplateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateEntryTemplateResponse.Unmarshal(m, b)
+}
+func (m *BatchUpdateEntryTemplateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateEntryTemplateResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateEntryTemplateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateEntryTemplateResponse.Merge(m, src)
+}
+func (m *BatchUpdateEntryTemplateResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateEntryTemplateResponse.Size(m)
+}
+func (m *BatchUpdateEntryTemplateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateEntryTemplateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchUpdateEntryTemplateResponse proto.InternalMessageInfo
+
+func (m *BatchUpdateEntryTemplateResponse) GetResults() []*BatchUpdateEntryTemplateResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchUpdateEntryTemplateResponse_Result struct {
+	// The status of updating the entry template.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The entry template that was updated. This will be set if the
+	// status is OK.
+	EntryTemplate *types.EntryTemplate `protobuf:"bytes,2,opt,name=entry_template,json=entryTemplate,proto3" json:"entry_template,omitempty"`
+	// The number of entries updated along with the template.
+	EntriesUpdated       int32    `protobuf:"varint,3,opt,name=entries_updated,json=entriesUpdated,proto3" json:"entries_updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchUpdateEntryTemplateResponse_Result) Reset() {
+	*m = BatchUpdateEntryTemplateResponse_Result{}
+}
+func (m *BatchUpdateEntryTemplateResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateEntryTemplateResponse_Result) ProtoMessage()    {}
+func (*BatchUpdateEntryTemplateResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{6, 0}
+}
+
+func (m *BatchUpdateEntryTemplateResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateEntryTemplateResponse_Result.Unmarshal(m, b)
+}
+func (m *BatchUpdateEntryTemplateResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateEntryTemplateResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateEntryTemplateResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateEntryTemplateResponse_Result.Merge(m, src)
+}
+func (m *BatchUpdateEntryTemplateResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateEntryTemplateResponse_Result.Size(m)
+}
+func (m *BatchUpdateEntryTemplateResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateEntryTemplateResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchUpdateEntryTemplateResponse_Result proto.InternalMessageInfo
+
+func (m *BatchUpdateEntryTemplateResponse_Result) GetStatus() *types.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *BatchUpdateEntryTemplateResponse_Result) GetEntryTemplate() *types.EntryTemplate {
+	if m != nil {
+		return m.EntryTemplate
+	}
+	return nil
+}
+
+func (m *BatchUpdateEntryTemplateResponse_Result) GetEntriesUpdated() int32 {
+	if m != nil {
+		return m.EntriesUpdated
+	}
+	return 0
+}
+
+type BatchDeleteEntryTemplateRequest struct {
+	// IDs of the entry templates to delete.
+	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchDeleteEntryTemplateRequest) Reset()         { *m = BatchDeleteEntryTemplateRequest{} }
+func (m *BatchDeleteEntryTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteEntryTemplateRequest) ProtoMessage()    {}
+func (*BatchDeleteEntryTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{7}
+}
+
+func (m *BatchDeleteEntryTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteEntryTemplateRequest.Unmarshal(m, b)
+}
+func (m *BatchDeleteEntryTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteEntryTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteEntryTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteEntryTemplateRequest.Merge(m, src)
+}
+func (m *BatchDeleteEntryTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteEntryTemplateRequest.Size(m)
+}
+func (m *BatchDeleteEntryTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteEntryTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteEntryTemplateRequest proto.InternalMessageInfo
+
+func (m *BatchDeleteEntryTemplateRequest) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type BatchDeleteEntryTemplateResponse struct {
+	// Result for each ID in the request (order is maintained).
+	Results              []*BatchDeleteEntryTemplateResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                   `json:"-"`
+	XXX_unrecognized     []byte                                     `json:"-"`
+	XXX_sizecache        int32                                      `json:"-"`
+}
+
+func (m *BatchDeleteEntryTemplateResponse) Reset()         { *m = BatchDeleteEntryTemplateResponse{} }
+func (m *BatchDeleteEntryTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteEntryTemplateResponse) ProtoMessage()    {}
+func (*BatchDeleteEntryTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{8}
+}
+
+func (m *BatchDeleteEntryTemplateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteEntryTemplateResponse.Unmarshal(m, b)
+}
+func (m *BatchDeleteEntryTemplateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteEntryTemplateResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteEntryTemplateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteEntryTemplateResponse.Merge(m, src)
+}
+func (m *BatchDeleteEntryTemplateResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteEntryTemplateResponse.Size(m)
+}
+func (m *BatchDeleteEntryTemplateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteEntryTemplateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteEntryTemplateResponse proto.InternalMessageInfo
+
+func (m *BatchDeleteEntryTemplateResponse) GetResults() []*BatchDeleteEntryTemplateResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchDeleteEntryTemplateResponse_Result struct {
+	// The status of deleting the entry template.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The ID of the entry template that was deleted.
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchDeleteEntryTemplateResponse_Result) Reset() {
+	*m = BatchDeleteEntryTemplateResponse_Result{}
+}
+func (m *BatchDeleteEntryTemplateResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteEntryTemplateResponse_Result) ProtoMessage()    {}
+func (*BatchDeleteEntryTemplateResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98b04e3acf6d20f3, []int{8, 0}
+}
+
+func (m *BatchDeleteEntryTemplateResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteEntryTemplateResponse_Result.Unmarshal(m, b)
+}
+func (m *BatchDeleteEntryTemplateResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteEntryTemplateResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteEntryTemplateResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteEntryTemplateResponse_Result.Merge(m, src)
+}
+func (m *BatchDeleteEntryTemplateResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteEntryTemplateResponse_Result.Size(m)
+}
+func (m *BatchDeleteEntryTemplateResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteEntryTemplateResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteEntryTemplateResponse_Result proto.InternalMessageInfo
+
+func (m *BatchDeleteEntryTemplateResponse_Result) GetStatus() *types.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *BatchDeleteEntryTemplateResponse_Result) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ListEntryTemplatesRequest)(nil), "spire.api.server.entrytemplate.v1.ListEntryTemplatesRequest")
+	proto.RegisterType((*ListEntryTemplatesResponse)(nil), "spire.api.server.entrytemplate.v1.ListEntryTemplatesResponse")
+	proto.RegisterType((*GetEntryTemplateRequest)(nil), "spire.api.server.entrytemplate.v1.GetEntryTemplateRequest")
+	proto.RegisterType((*BatchCreateEntryTemplateRequest)(nil), "spire.api.server.entrytemplate.v1.BatchCreateEntryTemplateRequest")
+	proto.RegisterType((*BatchCreateEntryTemplateResponse)(nil), "spire.api.server.entrytemplate.v1.BatchCreateEntryTemplateResponse")
+	proto.RegisterType((*BatchCreateEntryTemplateResponse_Result)(nil), "spire.api.server.entrytemplate.v1.BatchCreateEntryTemplateResponse.Result")
+	proto.RegisterType((*BatchUpdateEntryTemplateRequest)(nil), "spire.api.server.entrytemplate.v1.BatchUpdateEntryTemplateRequest")
+	proto.RegisterType((*BatchUpdateEntryTemplateResponse)(nil), "spire.api.server.entrytemplate.v1.BatchUpdateEntryTemplateResponse")
+	proto.RegisterType((*BatchUpdateEntryTemplateResponse_Result)(nil), "spire.api.server.entrytemplate.v1.BatchUpdateEntryTemplateResponse.Result")
+	proto.RegisterType((*BatchDeleteEntryTemplateRequest)(nil), "spire.api.server.entrytemplate.v1.BatchDeleteEntryTemplateRequest")
+	proto.RegisterType((*BatchDeleteEntryTemplateResponse)(nil), "spire.api.server.entrytemplate.v1.BatchDeleteEntryTemplateResponse")
+	proto.RegisterType((*BatchDeleteEntryTemplateResponse_Result)(nil), "spire.api.server.entrytemplate.v1.BatchDeleteEntryTemplateResponse.Result")
+}
+
+func init() {
+	proto.RegisterFile("spire/api/server/entrytemplate/v1/entrytemplate.proto", fileDescriptor_98b04e3acf6d20f3)
+}
+
+var fileDescriptor_98b04e3acf6d20f3 = []byte{
+	// 595 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xcf, 0x6f, 0xd3, 0x30,
+	0x14, 0x56, 0x5a, 0x51, 0xe8, 0x9b, 0xda, 0x4d, 0xe6, 0x40, 0x08, 0x82, 0x95, 0x1c, 0xa0, 0x08,
+	0x29, 0xd1, 0x3a, 0x71, 0x01, 0x76, 0xa0, 0x65, 0x42, 0x42, 0x4c, 0x20, 0x6f, 0x08, 0x89, 0x4b,
+	0x95, 0x2d, 0xaf, 0x9b, 0xe9, 0x2f, 0x13, 0x3b, 0xd5, 0xb6, 0x1b, 0xdc, 0x38, 0xf2, 0x27, 0x20,
+	0xc4, 0x8d, 0xff, 0x85, 0x7f, 0x09, 0xc5, 0x4e, 0x00, 0xaf, 0x49, 0x5b, 0xad, 0x43, 0xbb, 0x45,
+	0xcf, 0xfe, 0x3e, 0x7f, 0xef, 0x7b, 0xce, 0x27, 0xc3, 0x23, 0xc1, 0x59, 0x84, 0x7e, 0xc0, 0x99,
+	0x2f, 0x30, 0x9a, 0x60, 0xe4, 0xe3, 0x48, 0x46, 0x27, 0x12, 0x87, 0x7c, 0x10, 0x48, 0xf4, 0x27,
+	0x1b, 0x66, 0xc1, 0xe3, 0xd1, 0x58, 0x8e, 0xc9, 0x5d, 0x05, 0xf3, 0x02, 0xce, 0x3c, 0x0d, 0xf3,
+	0xcc, 0x5d, 0x93, 0x0d, 0x67, 0x5d, 0x33, 0xcb, 0x13, 0x8e, 0x22, 0x8f, 0xc3, 0xb1, 0xff, 0xdd,
+	0x20, 0x64, 0x20, 0x63, 0xa1, 0x57, 0xdc, 0x77, 0x70, 0xf3, 0x15, 0x13, 0x72, 0x3b, 0x01, 0xed,
+	0xa5, 0x20, 0x41, 0xf1, 0x63, 0x8c, 0x42, 0x92, 0x5b, 0x50, 0xe5, 0xc1, 0x21, 0x76, 0x05, 0x3b,
+	0x45, 0xdb, 0x6a, 0x58, 0xcd, 0x2b, 0xf4, 0x5a, 0x52, 0xd8, 0x65, 0xa7, 0x48, 0x6e, 0x03, 0xa8,
+	0x45, 0x39, 0xee, 0xe3, 0xc8, 0x2e, 0x35, 0xac, 0x66, 0x95, 0xaa, 0xed, 0x7b, 0x49, 0xc1, 0xfd,
+	0x62, 0x81, 0x93, 0xc7, 0x2c, 0xf8, 0x78, 0x24, 0x90, 0x74, 0x60, 0x55, 0x09, 0xed, 0x66, 0x4a,
+	0x85, 0x6d, 0x35, 0xca, 0xcd, 0x95, 0x96, 0xe3, 0xe9, 0x7e, 0x95, 0x56, 0xcf, 0x40, 0xd3, 0x3a,
+	0x1a, 0x64, 0xe4, 0x1e, 0xac, 0x8e, 0xf0, 0x58, 0x76, 0xa7, 0x74, 0xd4, 0x92, 0xf2, 0x9b, 0x3f,
+	0x5a, 0x1e, 0xc0, 0x8d, 0x17, 0x68, 0x2a, 0xc9, 0x5a, 0xac, 0x43, 0x89, 0x85, 0xaa, 0xb7, 0x2a,
+	0x2d, 0xb1, 0xd0, 0xed, 0xc1, 0x7a, 0x3b, 0x90, 0x07, 0x47, 0x9d, 0x08, 0x03, 0x89, 0xb9, 0x90,
+	0x8b, 0x90, 0xee, 0x7e, 0x2e, 0x41, 0xa3, 0xf8, 0xa0, 0xd4, 0xa4, 0x10, 0xae, 0x46, 0x28, 0xe2,
+	0x81, 0xcc, 0x4e, 0x78, 0xe9, 0xcd, 0xbd, 0x0c, 0xde, 0x3c, 0x56, 0x8f, 0x2a, 0x4a, 0x9a, 0x51,
+	0x3b, 0xc7, 0x50, 0xd1, 0x25, 0xf2, 0x10, 0x2a, 0xfa, 0x72, 0x28, 0x43, 0x56, 0x5a, 0xd7, 0x8d,
+	0x86, 0x76, 0xd5, 0x12, 0x4d, 0xb7, 0x90, 0x67, 0x50, 0x37, 0x6d, 0x50, 0xde, 0xcf, 0x76, 0xa1,
+	0x66, 0xb8, 0xe0, 0xfe, 0xb0, 0x52, 0xb7, 0xdf, 0xf2, 0xf0, 0x7f, 0xba, 0x4d, 0xb6, 0x00, 0xd8,
+	0x88, 0xc7, 0xb2, 0x3b, 0x0c, 0x44, 0x3f, 0xd5, 0x79, 0xa7, 0x18, 0xbf, 0x13, 0x88, 0x3e, 0xad,
+	0x2a, 0x44, 0xf2, 0xe9, 0xfe, 0xcc, 0x86, 0x95, 0xab, 0x73, 0xe9, 0x61, 0xcd, 0x60, 0x9d, 0x1a,
+	0xd6, 0x37, 0xeb, 0xb2, 0xa6, 0x45, 0xee, 0xeb, 0x49, 0x30, 0x14, 0xdd, 0x58, 0x29, 0x0e, 0xed,
+	0xb2, 0xca, 0x84, 0x7a, 0x5a, 0xd6, 0x7d, 0x84, 0xee, 0x66, 0x3a, 0xd5, 0xe7, 0x38, 0xc0, 0x82,
+	0xa9, 0xae, 0x41, 0x99, 0x85, 0xda, 0xa8, 0x2a, 0x4d, 0x3e, 0xdd, 0x5f, 0x16, 0x34, 0x8a, 0x51,
+	0x4b, 0x7b, 0x3c, 0x83, 0x75, 0xca, 0xe3, 0xed, 0xf3, 0x59, 0xac, 0xa3, 0xa4, 0x94, 0x45, 0x49,
+	0xeb, 0x53, 0x05, 0x6a, 0xc6, 0x81, 0xe4, 0xab, 0x05, 0x64, 0x3a, 0x13, 0xc9, 0xd3, 0x05, 0x9a,
+	0x28, 0x0c, 0x69, 0x67, 0xeb, 0x9c, 0xe8, 0xd4, 0xd2, 0x0f, 0xb0, 0x76, 0x36, 0x1b, 0xc9, 0xe3,
+	0x05, 0x28, 0x0b, 0x02, 0xd5, 0x99, 0x71, 0xa1, 0xc8, 0x77, 0x0b, 0xec, 0xa2, 0x78, 0x22, 0xed,
+	0xa5, 0xb2, 0x4d, 0x1f, 0xde, 0xb9, 0x80, 0x7c, 0xfc, 0xab, 0x32, 0xe7, 0xbf, 0x5c, 0x5c, 0x65,
+	0x71, 0xa4, 0x39, 0x9d, 0xa5, 0x38, 0xce, 0xaa, 0xcc, 0xb9, 0xd9, 0x8b, 0xab, 0x2c, 0xfe, 0x45,
+	0x9d, 0xce, 0x52, 0x1c, 0x5a, 0x65, 0xfb, 0xf5, 0xfb, 0x9d, 0x43, 0x26, 0x8f, 0xe2, 0x7d, 0xef,
+	0x60, 0x3c, 0xf4, 0x05, 0x67, 0xbd, 0x1e, 0xfa, 0xfa, 0x31, 0xa2, 0xde, 0x1f, 0xfe, 0xdc, 0x37,
+	0xd1, 0x13, 0xa3, 0xb0, 0x5f, 0x51, 0xb0, 0xcd, 0xdf, 0x03, 0x00, 0x83, 0xa8, 0x3b, 0xf6, 0x4d,
+	0x09, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// EntryTemplateClient is the client API for EntryTemplate service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EntryTemplateClient interface {
+	// Lists entry templates.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	ListEntryTemplates(ctx context.Context, in *ListEntryTemplatesRequest, opts ...grpc.CallOption) (*ListEntryTemplatesResponse, error)
+	// Gets an entry template. If there is no template with the ID, NOT_FOUND
+	// is returned.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	GetEntryTemplate(ctx context.Context, in *GetEntryTemplateRequest, opts ...grpc.CallOption) (*types.EntryTemplate, error)
+	// Batch creates one or more entry templates.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
+	BatchCreateEntryTemplate(ctx context.Context, in *BatchCreateEntryTemplateRequest, opts ...grpc.CallOption) (*BatchCreateEntryTemplateResponse, error)
+	// Batch updates one or more entry templates. The entries using a
+	// template are updated along with it, atomically.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
+	BatchUpdateEntryTemplate(ctx context.Context, in *BatchUpdateEntryTemplateRequest, opts ...grpc.CallOption) (*BatchUpdateEntryTemplateResponse, error)
+	// Batch deletes one or more entry templates. Templates still used by
+	// entries cannot be deleted.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
+	BatchDeleteEntryTemplate(ctx context.Context, in *BatchDeleteEntryTemplateRequest, opts ...grpc.CallOption) (*BatchDeleteEntryTemplateResponse, error)
+}
+
+type entryTemplateClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEntryTemplateClient(cc grpc.ClientConnInterface) EntryTemplateClient {
+	return &entryTemplateClient{cc}
+}
+
+func (c *entryTemplateClient) ListEntryTemplates(ctx context.Context, in *ListEntryTemplatesRequest, opts ...grpc.CallOption) (*ListEntryTemplatesResponse, error) {
+	out := new(ListEntryTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.entrytemplate.v1.EntryTemplate/ListEntryTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryTemplateClient) GetEntryTemplate(ctx context.Context, in *GetEntryTemplateRequest, opts ...grpc.CallOption) (*types.EntryTemplate, error) {
+	out := new(types.EntryTemplate)
+	err := c.cc.Invoke(ctx, "/spire.api.server.entrytemplate.v1.EntryTemplate/GetEntryTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryTemplateClient) BatchCreateEntryTemplate(ctx context.Context, in *BatchCreateEntryTemplateRequest, opts ...grpc.CallOption) (*BatchCreateEntryTemplateResponse, error) {
+	out := new(BatchCreateEntryTemplateResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.entrytemplate.v1.EntryTemplate/BatchCreateEntryTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryTemplateClient) BatchUpdateEntryTemplate(ctx context.Context, in *BatchUpdateEntryTemplateRequest, opts ...grpc.CallOption) (*BatchUpdateEntryTemplateResponse, error) {
+	out := new(BatchUpdateEntryTemplateResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.entrytemplate.v1.EntryTemplate/BatchUpdateEntryTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryTemplateClient) BatchDeleteEntryTemplate(ctx context.Context, in *BatchDeleteEntryTemplateRequest, opts ...grpc.CallOption) (*BatchDeleteEntryTemplateResponse, error) {
+	out := new(BatchDeleteEntryTemplateResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.entrytemplate.v1.EntryTemplate/BatchDeleteEntryTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EntryTemplateServer is the server API for EntryTemplate service.
+type EntryTemplateServer interface {
+	// Lists entry templates.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	ListEntryTemplates(context.Context, *ListEntryTemplatesRequest) (*ListEntryTemplatesResponse, error)
+	// Gets an entry template. If there is no template with the ID, NOT_FOUND
+	// is returned.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	GetEntryTemplate(context.Context, *GetEntryTemplateRequest) (*types.EntryTemplate, error)
+	// Batch creates one or more entry templates.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
+	BatchCreateEntryTemplate(context.Context, *BatchCreateEntryTemplateRequest) (*BatchCreateEntryTemplateResponse, error)
+	// Batch updates one or more entry templates. The entries using a
+	// template are updated along with it, atomically.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
+	BatchUpdateEntryTemplate(context.Context, *BatchUpdateEntryTemplateRequest) (*BatchUpdateEntryTemplateResponse, error)
+	// Batch deletes one or more entry templates. Templates still used by
+	// entries cannot be deleted.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// entry-admin role.
+	BatchDeleteEntryTemplate(context.Context, *BatchDeleteEntryTemplateRequest) (*BatchDeleteEntryTemplateResponse, error)
+}
+
+// UnimplementedEntryTemplateServer can be embedded to have forward compatible implementations.
+type UnimplementedEntryTemplateServer struct {
+}
+
+func (*UnimplementedEntryTemplateServer) ListEntryTemplates(ctx context.Context, req *ListEntryTemplatesRequest) (*ListEntryTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntryTemplates not implemented")
+}
+func (*UnimplementedEntryTemplateServer) GetEntryTemplate(ctx context.Context, req *GetEntryTemplateRequest) (*types.EntryTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntryTemplate not implemented")
+}
+func (*UnimplementedEntryTemplateServer) BatchCreateEntryTemplate(ctx context.Context, req *BatchCreateEntryTemplateRequest) (*BatchCreateEntryTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateEntryTemplate not implemented")
+}
+func (*UnimplementedEntryTemplateServer) BatchUpdateEntryTemplate(ctx context.Context, req *BatchUpdateEntryTemplateRequest) (*BatchUpdateEntryTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateEntryTemplate not implemented")
+}
+func (*UnimplementedEntryTemplateServer) BatchDeleteEntryTemplate(ctx context.Context, req *BatchDeleteEntryTemplateRequest) (*BatchDeleteEntryTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteEntryTemplate not implemented")
+}
+
+func RegisterEntryTemplateServer(s *grpc.Server, srv EntryTemplateServer) {
+	s.RegisterService(&_EntryTemplate_serviceDesc, srv)
+}
+
+func _EntryTemplate_ListEntryTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntryTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryTemplateServer).ListEntryTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.entrytemplate.v1.EntryTemplate/ListEntryTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryTemplateServer).ListEntryTemplates(ctx, req.(*ListEntryTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryTemplate_GetEntryTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntryTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryTemplateServer).GetEntryTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.entrytemplate.v1.EntryTemplate/GetEntryTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryTemplateServer).GetEntryTemplate(ctx, req.(*GetEntryTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryTemplate_BatchCreateEntryTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateEntryTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryTemplateServer).BatchCreateEntryTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.entrytemplate.v1.EntryTemplate/BatchCreateEntryTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryTemplateServer).BatchCreateEntryTemplate(ctx, req.(*BatchCreateEntryTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryTemplate_BatchUpdateEntryTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateEntryTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryTemplateServer).BatchUpdateEntryTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.entrytemplate.v1.EntryTemplate/BatchUpdateEntryTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryTemplateServer).BatchUpdateEntryTemplate(ctx, req.(*BatchUpdateEntryTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryTemplate_BatchDeleteEntryTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteEntryTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryTemplateServer).BatchDeleteEntryTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.entrytemplate.v1.EntryTemplate/BatchDeleteEntryTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryTemplateServer).BatchDeleteEntryTemplate(ctx, req.(*BatchDeleteEntryTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _EntryTemplate_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.entrytemplate.v1.EntryTemplate",
+	HandlerType: (*EntryTemplateServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListEntryTemplates",
+			Handler:    _EntryTemplate_ListEntryTemplates_Handler,
+		},
+		{
+			MethodName: "GetEntryTemplate",
+			Handler:    _EntryTemplate_GetEntryTemplate_Handler,
+		},
+		{
+			MethodName: "BatchCreateEntryTemplate",
+			Handler:    _EntryTemplate_BatchCreateEntryTemplate_Handler,
+		},
+		{
+			MethodName: "BatchUpdateEntryTemplate",
+			Handler:    _EntryTemplate_BatchUpdateEntryTemplate_Handler,
+		},
+		{
+			MethodName: "BatchDeleteEntryTemplate",
+			Handler:    _EntryTemplate_BatchDeleteEntryTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/entrytemplate/v1/entrytemplate.proto",
+}
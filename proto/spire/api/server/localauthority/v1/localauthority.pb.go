@@ -0,0 +1,935 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: spire/api/server/localauthority/v1/localauthority.proto
+
+package localauthority
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type AuthorityState struct {
+	// The authority ID. For X509 authorities, it is the hex encoded subject
+	// key ID of the CA certificate. For JWT authorities, it is the key ID.
+	AuthorityId string `protobuf:"bytes,1,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	// When the authority expires (seconds since Unix epoch).
+	ExpiresAt            int64    `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthorityState) Reset()         { *m = AuthorityState{} }
+func (m *AuthorityState) String() string { return proto.CompactTextString(m) }
+func (*AuthorityState) ProtoMessage()    {}
+func (*AuthorityState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{0}
+}
+
+func (m *AuthorityState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthorityState.Unmarshal(m, b)
+}
+func (m *AuthorityState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthorityState.Marshal(b, m, deterministic)
+}
+func (m *AuthorityState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthorityState.Merge(m, src)
+}
+func (m *AuthorityState) XXX_Size() int {
+	return xxx_messageInfo_AuthorityState.Size(m)
+}
+func (m *AuthorityState) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthorityState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthorityState proto.InternalMessageInfo
+
+func (m *AuthorityState) GetAuthorityId() string {
+	if m != nil {
+		return m.AuthorityId
+	}
+	return ""
+}
+
+func (m *AuthorityState) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type PrepareNextAuthorityRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrepareNextAuthorityRequest) Reset()         { *m = PrepareNextAuthorityRequest{} }
+func (m *PrepareNextAuthorityRequest) String() string { return proto.CompactTextString(m) }
+func (*PrepareNextAuthorityRequest) ProtoMessage()    {}
+func (*PrepareNextAuthorityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{1}
+}
+
+func (m *PrepareNextAuthorityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrepareNextAuthorityRequest.Unmarshal(m, b)
+}
+func (m *PrepareNextAuthorityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrepareNextAuthorityRequest.Marshal(b, m, deterministic)
+}
+func (m *PrepareNextAuthorityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareNextAuthorityRequest.Merge(m, src)
+}
+func (m *PrepareNextAuthorityRequest) XXX_Size() int {
+	return xxx_messageInfo_PrepareNextAuthorityRequest.Size(m)
+}
+func (m *PrepareNextAuthorityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareNextAuthorityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrepareNextAuthorityRequest proto.InternalMessageInfo
+
+type PrepareNextAuthorityResponse struct {
+	// The prepared X509 authority.
+	X509Authority *AuthorityState `protobuf:"bytes,1,opt,name=x509_authority,json=x509Authority,proto3" json:"x509_authority,omitempty"`
+	// The prepared JWT authority.
+	JwtAuthority         *AuthorityState `protobuf:"bytes,2,opt,name=jwt_authority,json=jwtAuthority,proto3" json:"jwt_authority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PrepareNextAuthorityResponse) Reset()         { *m = PrepareNextAuthorityResponse{} }
+func (m *PrepareNextAuthorityResponse) String() string { return proto.CompactTextString(m) }
+func (*PrepareNextAuthorityResponse) ProtoMessage()    {}
+func (*PrepareNextAuthorityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{2}
+}
+
+func (m *PrepareNextAuthorityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrepareNextAuthorityResponse.Unmarshal(m, b)
+}
+func (m *PrepareNextAuthorityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrepareNextAuthorityResponse.Marshal(b, m, deterministic)
+}
+func (m *PrepareNextAuthorityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareNextAuthorityResponse.Merge(m, src)
+}
+func (m *PrepareNextAuthorityResponse) XXX_Size() int {
+	return xxx_messageInfo_PrepareNextAuthorityResponse.Size(m)
+}
+func (m *PrepareNextAuthorityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareNextAuthorityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrepareNextAuthorityResponse proto.InternalMessageInfo
+
+func (m *PrepareNextAuthorityResponse) GetX509Authority() *AuthorityState {
+	if m != nil {
+		return m.X509Authority
+	}
+	return nil
+}
+
+func (m *PrepareNextAuthorityResponse) GetJwtAuthority() *AuthorityState {
+	if m != nil {
+		return m.JwtAuthority
+	}
+	return nil
+}
+
+type RotateAuthorityRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateAuthorityRequest) Reset()         { *m = RotateAuthorityRequest{} }
+func (m *RotateAuthorityRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateAuthorityRequest) ProtoMessage()    {}
+func (*RotateAuthorityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{3}
+}
+
+func (m *RotateAuthorityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateAuthorityRequest.Unmarshal(m, b)
+}
+func (m *RotateAuthorityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateAuthorityRequest.Marshal(b, m, deterministic)
+}
+func (m *RotateAuthorityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateAuthorityRequest.Merge(m, src)
+}
+func (m *RotateAuthorityRequest) XXX_Size() int {
+	return xxx_messageInfo_RotateAuthorityRequest.Size(m)
+}
+func (m *RotateAuthorityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateAuthorityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateAuthorityRequest proto.InternalMessageInfo
+
+type RotateAuthorityResponse struct {
+	// The activated X509 authority.
+	X509Authority *AuthorityState `protobuf:"bytes,1,opt,name=x509_authority,json=x509Authority,proto3" json:"x509_authority,omitempty"`
+	// The activated JWT authority.
+	JwtAuthority         *AuthorityState `protobuf:"bytes,2,opt,name=jwt_authority,json=jwtAuthority,proto3" json:"jwt_authority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RotateAuthorityResponse) Reset()         { *m = RotateAuthorityResponse{} }
+func (m *RotateAuthorityResponse) String() string { return proto.CompactTextString(m) }
+func (*RotateAuthorityResponse) ProtoMessage()    {}
+func (*RotateAuthorityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{4}
+}
+
+func (m *RotateAuthorityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateAuthorityResponse.Unmarshal(m, b)
+}
+func (m *RotateAuthorityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateAuthorityResponse.Marshal(b, m, deterministic)
+}
+func (m *RotateAuthorityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateAuthorityResponse.Merge(m, src)
+}
+func (m *RotateAuthorityResponse) XXX_Size() int {
+	return xxx_messageInfo_RotateAuthorityResponse.Size(m)
+}
+func (m *RotateAuthorityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateAuthorityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateAuthorityResponse proto.InternalMessageInfo
+
+func (m *RotateAuthorityResponse) GetX509Authority() *AuthorityState {
+	if m != nil {
+		return m.X509Authority
+	}
+	return nil
+}
+
+func (m *RotateAuthorityResponse) GetJwtAuthority() *AuthorityState {
+	if m != nil {
+		return m.JwtAuthority
+	}
+	return nil
+}
+
+type TaintX509AuthorityRequest struct {
+	// Required. The ID of the X509 authority to taint.
+	AuthorityId          string   `protobuf:"bytes,1,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintX509AuthorityRequest) Reset()         { *m = TaintX509AuthorityRequest{} }
+func (m *TaintX509AuthorityRequest) String() string { return proto.CompactTextString(m) }
+func (*TaintX509AuthorityRequest) ProtoMessage()    {}
+func (*TaintX509AuthorityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{5}
+}
+
+func (m *TaintX509AuthorityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintX509AuthorityRequest.Unmarshal(m, b)
+}
+func (m *TaintX509AuthorityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintX509AuthorityRequest.Marshal(b, m, deterministic)
+}
+func (m *TaintX509AuthorityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintX509AuthorityRequest.Merge(m, src)
+}
+func (m *TaintX509AuthorityRequest) XXX_Size() int {
+	return xxx_messageInfo_TaintX509AuthorityRequest.Size(m)
+}
+func (m *TaintX509AuthorityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintX509AuthorityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintX509AuthorityRequest proto.InternalMessageInfo
+
+func (m *TaintX509AuthorityRequest) GetAuthorityId() string {
+	if m != nil {
+		return m.AuthorityId
+	}
+	return ""
+}
+
+type TaintX509AuthorityResponse struct {
+	// The tainted X509 authority.
+	TaintedAuthority     *AuthorityState `protobuf:"bytes,1,opt,name=tainted_authority,json=taintedAuthority,proto3" json:"tainted_authority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *TaintX509AuthorityResponse) Reset()         { *m = TaintX509AuthorityResponse{} }
+func (m *TaintX509AuthorityResponse) String() string { return proto.CompactTextString(m) }
+func (*TaintX509AuthorityResponse) ProtoMessage()    {}
+func (*TaintX509AuthorityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{6}
+}
+
+func (m *TaintX509AuthorityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintX509AuthorityResponse.Unmarshal(m, b)
+}
+func (m *TaintX509AuthorityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintX509AuthorityResponse.Marshal(b, m, deterministic)
+}
+func (m *TaintX509AuthorityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintX509AuthorityResponse.Merge(m, src)
+}
+func (m *TaintX509AuthorityResponse) XXX_Size() int {
+	return xxx_messageInfo_TaintX509AuthorityResponse.Size(m)
+}
+func (m *TaintX509AuthorityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintX509AuthorityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintX509AuthorityResponse proto.InternalMessageInfo
+
+func (m *TaintX509AuthorityResponse) GetTaintedAuthority() *AuthorityState {
+	if m != nil {
+		return m.TaintedAuthority
+	}
+	return nil
+}
+
+type RevokeX509AuthorityRequest struct {
+	// Required. The ID of the X509 authority to revoke.
+	AuthorityId          string   `protobuf:"bytes,1,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeX509AuthorityRequest) Reset()         { *m = RevokeX509AuthorityRequest{} }
+func (m *RevokeX509AuthorityRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeX509AuthorityRequest) ProtoMessage()    {}
+func (*RevokeX509AuthorityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{7}
+}
+
+func (m *RevokeX509AuthorityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeX509AuthorityRequest.Unmarshal(m, b)
+}
+func (m *RevokeX509AuthorityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeX509AuthorityRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeX509AuthorityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeX509AuthorityRequest.Merge(m, src)
+}
+func (m *RevokeX509AuthorityRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeX509AuthorityRequest.Size(m)
+}
+func (m *RevokeX509AuthorityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeX509AuthorityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeX509AuthorityRequest proto.InternalMessageInfo
+
+func (m *RevokeX509AuthorityRequest) GetAuthorityId() string {
+	if m != nil {
+		return m.AuthorityId
+	}
+	return ""
+}
+
+type RevokeX509AuthorityResponse struct {
+	// The revoked X509 authority.
+	RevokedAuthority     *AuthorityState `protobuf:"bytes,1,opt,name=revoked_authority,json=revokedAuthority,proto3" json:"revoked_authority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RevokeX509AuthorityResponse) Reset()         { *m = RevokeX509AuthorityResponse{} }
+func (m *RevokeX509AuthorityResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeX509AuthorityResponse) ProtoMessage()    {}
+func (*RevokeX509AuthorityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{8}
+}
+
+func (m *RevokeX509AuthorityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeX509AuthorityResponse.Unmarshal(m, b)
+}
+func (m *RevokeX509AuthorityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeX509AuthorityResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeX509AuthorityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeX509AuthorityResponse.Merge(m, src)
+}
+func (m *RevokeX509AuthorityResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeX509AuthorityResponse.Size(m)
+}
+func (m *RevokeX509AuthorityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeX509AuthorityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeX509AuthorityResponse proto.InternalMessageInfo
+
+func (m *RevokeX509AuthorityResponse) GetRevokedAuthority() *AuthorityState {
+	if m != nil {
+		return m.RevokedAuthority
+	}
+	return nil
+}
+
+type TaintJWTAuthorityRequest struct {
+	// Required. The ID of the JWT authority to taint.
+	AuthorityId          string   `protobuf:"bytes,1,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintJWTAuthorityRequest) Reset()         { *m = TaintJWTAuthorityRequest{} }
+func (m *TaintJWTAuthorityRequest) String() string { return proto.CompactTextString(m) }
+func (*TaintJWTAuthorityRequest) ProtoMessage()    {}
+func (*TaintJWTAuthorityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{9}
+}
+
+func (m *TaintJWTAuthorityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintJWTAuthorityRequest.Unmarshal(m, b)
+}
+func (m *TaintJWTAuthorityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintJWTAuthorityRequest.Marshal(b, m, deterministic)
+}
+func (m *TaintJWTAuthorityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintJWTAuthorityRequest.Merge(m, src)
+}
+func (m *TaintJWTAuthorityRequest) XXX_Size() int {
+	return xxx_messageInfo_TaintJWTAuthorityRequest.Size(m)
+}
+func (m *TaintJWTAuthorityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintJWTAuthorityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintJWTAuthorityRequest proto.InternalMessageInfo
+
+func (m *TaintJWTAuthorityRequest) GetAuthorityId() string {
+	if m != nil {
+		return m.AuthorityId
+	}
+	return ""
+}
+
+type TaintJWTAuthorityResponse struct {
+	// The tainted JWT authority.
+	TaintedAuthority     *AuthorityState `protobuf:"bytes,1,opt,name=tainted_authority,json=taintedAuthority,proto3" json:"tainted_authority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *TaintJWTAuthorityResponse) Reset()         { *m = TaintJWTAuthorityResponse{} }
+func (m *TaintJWTAuthorityResponse) String() string { return proto.CompactTextString(m) }
+func (*TaintJWTAuthorityResponse) ProtoMessage()    {}
+func (*TaintJWTAuthorityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{10}
+}
+
+func (m *TaintJWTAuthorityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintJWTAuthorityResponse.Unmarshal(m, b)
+}
+func (m *TaintJWTAuthorityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintJWTAuthorityResponse.Marshal(b, m, deterministic)
+}
+func (m *TaintJWTAuthorityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintJWTAuthorityResponse.Merge(m, src)
+}
+func (m *TaintJWTAuthorityResponse) XXX_Size() int {
+	return xxx_messageInfo_TaintJWTAuthorityResponse.Size(m)
+}
+func (m *TaintJWTAuthorityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintJWTAuthorityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintJWTAuthorityResponse proto.InternalMessageInfo
+
+func (m *TaintJWTAuthorityResponse) GetTaintedAuthority() *AuthorityState {
+	if m != nil {
+		return m.TaintedAuthority
+	}
+	return nil
+}
+
+type RevokeJWTAuthorityRequest struct {
+	// Required. The ID of the JWT authority to revoke.
+	AuthorityId          string   `protobuf:"bytes,1,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeJWTAuthorityRequest) Reset()         { *m = RevokeJWTAuthorityRequest{} }
+func (m *RevokeJWTAuthorityRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeJWTAuthorityRequest) ProtoMessage()    {}
+func (*RevokeJWTAuthorityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{11}
+}
+
+func (m *RevokeJWTAuthorityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeJWTAuthorityRequest.Unmarshal(m, b)
+}
+func (m *RevokeJWTAuthorityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeJWTAuthorityRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeJWTAuthorityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeJWTAuthorityRequest.Merge(m, src)
+}
+func (m *RevokeJWTAuthorityRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeJWTAuthorityRequest.Size(m)
+}
+func (m *RevokeJWTAuthorityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeJWTAuthorityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeJWTAuthorityRequest proto.InternalMessageInfo
+
+func (m *RevokeJWTAuthorityRequest) GetAuthorityId() string {
+	if m != nil {
+		return m.AuthorityId
+	}
+	return ""
+}
+
+type RevokeJWTAuthorityResponse struct {
+	// The revoked JWT authority.
+	RevokedAuthority     *AuthorityState `protobuf:"bytes,1,opt,name=revoked_authority,json=revokedAuthority,proto3" json:"revoked_authority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RevokeJWTAuthorityResponse) Reset()         { *m = RevokeJWTAuthorityResponse{} }
+func (m *RevokeJWTAuthorityResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeJWTAuthorityResponse) ProtoMessage()    {}
+func (*RevokeJWTAuthorityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f38ced9b48ccd901, []int{12}
+}
+
+func (m *RevokeJWTAuthorityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeJWTAuthorityResponse.Unmarshal(m, b)
+}
+func (m *RevokeJWTAuthorityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeJWTAuthorityResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeJWTAuthorityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeJWTAuthorityResponse.Merge(m, src)
+}
+func (m *RevokeJWTAuthorityResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeJWTAuthorityResponse.Size(m)
+}
+func (m *RevokeJWTAuthorityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeJWTAuthorityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeJWTAuthorityResponse proto.InternalMessageInfo
+
+func (m *RevokeJWTAuthorityResponse) GetRevokedAuthority() *AuthorityState {
+	if m != nil {
+		return m.RevokedAuthority
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*AuthorityState)(nil), "spire.api.server.localauthority.v1.AuthorityState")
+	proto.RegisterType((*PrepareNextAuthorityRequest)(nil), "spire.api.server.localauthority.v1.PrepareNextAuthorityRequest")
+	proto.RegisterType((*PrepareNextAuthorityResponse)(nil), "spire.api.server.localauthority.v1.PrepareNextAuthorityResponse")
+	proto.RegisterType((*RotateAuthorityRequest)(nil), "spire.api.server.localauthority.v1.RotateAuthorityRequest")
+	proto.RegisterType((*RotateAuthorityResponse)(nil), "spire.api.server.localauthority.v1.RotateAuthorityResponse")
+	proto.RegisterType((*TaintX509AuthorityRequest)(nil), "spire.api.server.localauthority.v1.TaintX509AuthorityRequest")
+	proto.RegisterType((*TaintX509AuthorityResponse)(nil), "spire.api.server.localauthority.v1.TaintX509AuthorityResponse")
+	proto.RegisterType((*RevokeX509AuthorityRequest)(nil), "spire.api.server.localauthority.v1.RevokeX509AuthorityRequest")
+	proto.RegisterType((*RevokeX509AuthorityResponse)(nil), "spire.api.server.localauthority.v1.RevokeX509AuthorityResponse")
+	proto.RegisterType((*TaintJWTAuthorityRequest)(nil), "spire.api.server.localauthority.v1.TaintJWTAuthorityRequest")
+	proto.RegisterType((*TaintJWTAuthorityResponse)(nil), "spire.api.server.localauthority.v1.TaintJWTAuthorityResponse")
+	proto.RegisterType((*RevokeJWTAuthorityRequest)(nil), "spire.api.server.localauthority.v1.RevokeJWTAuthorityRequest")
+	proto.RegisterType((*RevokeJWTAuthorityResponse)(nil), "spire.api.server.localauthority.v1.RevokeJWTAuthorityResponse")
+}
+
+func init() {
+	proto.RegisterFile("spire/api/server/localauthority/v1/localauthority.proto", fileDescriptor_f38ced9b48ccd901)
+}
+
+var fileDescriptor_f38ced9b48ccd901 = []byte{
+	// 509 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0x5d, 0x6b, 0xd4, 0x40,
+	0x14, 0x65, 0x2a, 0x28, 0xbd, 0x6d, 0x57, 0x3b, 0x8a, 0x66, 0xa7, 0x16, 0x6a, 0x9e, 0xfa, 0x94,
+	0xd8, 0x15, 0x11, 0xad, 0x9b, 0xb5, 0xbe, 0x29, 0xe2, 0x47, 0x2c, 0x54, 0x7d, 0x09, 0xd3, 0xee,
+	0xd4, 0x4e, 0xad, 0x9d, 0x38, 0x99, 0x4d, 0x57, 0xb0, 0xfe, 0x00, 0x9f, 0x14, 0x41, 0xf0, 0x47,
+	0xf9, 0xe2, 0x2f, 0x92, 0x24, 0x63, 0xb2, 0xdd, 0xcc, 0xda, 0x30, 0x94, 0x05, 0x1f, 0xf7, 0xde,
+	0x9c, 0x33, 0xe7, 0xdc, 0xd9, 0x73, 0x19, 0xb8, 0x93, 0xc4, 0x5c, 0x32, 0x9f, 0xc6, 0xdc, 0x4f,
+	0x98, 0x4c, 0x99, 0xf4, 0x0f, 0xc4, 0x0e, 0x3d, 0xa0, 0x03, 0xb5, 0x27, 0x24, 0x57, 0x1f, 0xfd,
+	0x74, 0x6d, 0xac, 0xe2, 0xc5, 0x52, 0x28, 0x81, 0xdd, 0x1c, 0xe8, 0xd1, 0x98, 0x7b, 0x05, 0xd0,
+	0x1b, 0xfb, 0x2c, 0x5d, 0x73, 0x43, 0x68, 0x6d, 0xfc, 0xfd, 0xfd, 0x52, 0x51, 0xc5, 0xf0, 0x0d,
+	0x98, 0x2f, 0xbf, 0x88, 0x78, 0xdf, 0x41, 0x2b, 0x68, 0x75, 0x36, 0x9c, 0x2b, 0x6b, 0x8f, 0xfa,
+	0x78, 0x19, 0x80, 0x0d, 0x33, 0xee, 0x24, 0xa2, 0xca, 0x99, 0x59, 0x41, 0xab, 0xe7, 0xc2, 0x59,
+	0x5d, 0xd9, 0x50, 0xee, 0x32, 0x2c, 0x3d, 0x97, 0x2c, 0xa6, 0x92, 0x3d, 0x65, 0x43, 0x55, 0xd2,
+	0x87, 0xec, 0xc3, 0x80, 0x25, 0xca, 0xfd, 0x8d, 0xe0, 0xba, 0xb9, 0x9f, 0xc4, 0xe2, 0x30, 0x61,
+	0xf8, 0x35, 0xb4, 0x86, 0xb7, 0x6f, 0xde, 0x8d, 0xca, 0x23, 0x73, 0x0d, 0x73, 0x9d, 0x8e, 0x77,
+	0xba, 0x21, 0xef, 0xa4, 0x9b, 0x70, 0x21, 0x63, 0x2a, 0x6b, 0x78, 0x0b, 0x16, 0xf6, 0x8f, 0xd4,
+	0x08, 0xf3, 0x8c, 0x35, 0xf3, 0xfc, 0xfe, 0x51, 0xa5, 0xdd, 0x75, 0xe0, 0x6a, 0x28, 0xb2, 0x7a,
+	0xcd, 0xee, 0x2f, 0x04, 0xd7, 0x6a, 0xad, 0xff, 0xd8, 0x69, 0x00, 0xed, 0x4d, 0xca, 0x0f, 0xd5,
+	0xab, 0xd1, 0xe3, 0xb4, 0xd9, 0x06, 0x7f, 0x1e, 0xf7, 0x18, 0x88, 0x09, 0xaf, 0x27, 0x12, 0xc1,
+	0xa2, 0xca, 0xba, 0xac, 0x7f, 0x26, 0x43, 0xb9, 0xa4, 0xc9, 0x2a, 0xf9, 0x3d, 0x20, 0x21, 0x4b,
+	0xc5, 0x3b, 0x66, 0xab, 0xff, 0x33, 0x2c, 0x19, 0x09, 0x2a, 0x03, 0x32, 0x6f, 0x9f, 0x91, 0x01,
+	0x4d, 0x56, 0x19, 0xe8, 0x82, 0x93, 0xcf, 0xef, 0xf1, 0xd6, 0xa6, 0x8d, 0xfc, 0x4f, 0xd0, 0x36,
+	0xc0, 0xa7, 0x35, 0xfd, 0x00, 0xda, 0xc5, 0xf0, 0x2c, 0xd5, 0x1f, 0x03, 0x31, 0xe1, 0xa7, 0x34,
+	0xfb, 0xce, 0xb7, 0x0b, 0xd0, 0x7a, 0x92, 0xc1, 0xaa, 0x9c, 0xfd, 0x44, 0x70, 0xc5, 0xb4, 0xcd,
+	0x70, 0xaf, 0xc9, 0x89, 0xff, 0xd8, 0x93, 0xe4, 0x81, 0x3d, 0x81, 0x9e, 0xc7, 0x17, 0x04, 0x17,
+	0xc7, 0x56, 0x0f, 0xbe, 0xd7, 0x84, 0xd5, 0xbc, 0xca, 0xc8, 0xba, 0x15, 0x56, 0x8b, 0xf9, 0x8e,
+	0x00, 0xd7, 0x83, 0x8f, 0xbb, 0x4d, 0x38, 0x27, 0x2e, 0x1c, 0x12, 0xd8, 0xc2, 0xb5, 0xaa, 0x1f,
+	0x08, 0x2e, 0x1b, 0xe2, 0x8c, 0x1b, 0xf1, 0x4e, 0x5e, 0x24, 0xa4, 0x67, 0x8d, 0xd7, 0xc2, 0xbe,
+	0x22, 0x58, 0xac, 0x05, 0x15, 0xdf, 0x6f, 0x6c, 0xd7, 0x10, 0x30, 0xd2, 0xb5, 0x44, 0x8f, 0xdc,
+	0x60, 0x3d, 0x7d, 0xcd, 0x6e, 0x70, 0x62, 0xea, 0x49, 0x60, 0x0b, 0x2f, 0x54, 0x3d, 0x7c, 0xf1,
+	0xe6, 0xd9, 0x5b, 0xae, 0xf6, 0x06, 0xdb, 0xde, 0x8e, 0x78, 0xef, 0x27, 0x31, 0xdf, 0xdd, 0x65,
+	0x7e, 0xf1, 0x64, 0xca, 0x9f, 0x41, 0xfe, 0xe9, 0xcf, 0xa7, 0xf5, 0x93, 0x95, 0xed, 0xf3, 0x39,
+	0xf0, 0xd6, 0x9f, 0x01, 0x00, 0xb5, 0x0d, 0xdd, 0x82, 0x7a, 0x09, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// LocalAuthorityClient is the client API for LocalAuthority service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type LocalAuthorityClient interface {
+	// Prepares the next X509 CA and JWT signing key of the server without
+	// waiting for the preparation threshold, replacing any that were already
+	// prepared. The prepared authorities are added to the trust bundle and
+	// activated once the activation threshold of the current ones is
+	// reached.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	PrepareNextAuthority(ctx context.Context, in *PrepareNextAuthorityRequest, opts ...grpc.CallOption) (*PrepareNextAuthorityResponse, error)
+	// Activates the next X509 CA and JWT signing key of the server without
+	// waiting for the activation threshold, preparing them first if they
+	// have not been prepared. Peers that have not received the updated trust
+	// bundle will fail to validate SVIDs signed by the activated authorities.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	RotateAuthority(ctx context.Context, in *RotateAuthorityRequest, opts ...grpc.CallOption) (*RotateAuthorityResponse, error)
+	// Marks an old X509 authority as tainted. Agents rotate the SVIDs that
+	// chain back to a tainted X509 authority. The active and prepared X509
+	// authorities cannot be tainted. Not supported when the server is
+	// configured with an UpstreamAuthority.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	TaintX509Authority(ctx context.Context, in *TaintX509AuthorityRequest, opts ...grpc.CallOption) (*TaintX509AuthorityResponse, error)
+	// Removes a tainted X509 authority from the trust bundle. SVIDs that
+	// chain back to it are no longer trusted.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	RevokeX509Authority(ctx context.Context, in *RevokeX509AuthorityRequest, opts ...grpc.CallOption) (*RevokeX509AuthorityResponse, error)
+	// Marks an old JWT authority as tainted. Agents drop the JWT-SVIDs signed
+	// by a tainted JWT authority. The active and prepared JWT authorities
+	// cannot be tainted.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	TaintJWTAuthority(ctx context.Context, in *TaintJWTAuthorityRequest, opts ...grpc.CallOption) (*TaintJWTAuthorityResponse, error)
+	// Removes a tainted JWT authority from the trust bundle. JWT-SVIDs signed
+	// by it are no longer trusted.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	RevokeJWTAuthority(ctx context.Context, in *RevokeJWTAuthorityRequest, opts ...grpc.CallOption) (*RevokeJWTAuthorityResponse, error)
+}
+
+type localAuthorityClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLocalAuthorityClient(cc grpc.ClientConnInterface) LocalAuthorityClient {
+	return &localAuthorityClient{cc}
+}
+
+func (c *localAuthorityClient) PrepareNextAuthority(ctx context.Context, in *PrepareNextAuthorityRequest, opts ...grpc.CallOption) (*PrepareNextAuthorityResponse, error) {
+	out := new(PrepareNextAuthorityResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.localauthority.v1.LocalAuthority/PrepareNextAuthority", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localAuthorityClient) RotateAuthority(ctx context.Context, in *RotateAuthorityRequest, opts ...grpc.CallOption) (*RotateAuthorityResponse, error) {
+	out := new(RotateAuthorityResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.localauthority.v1.LocalAuthority/RotateAuthority", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localAuthorityClient) TaintX509Authority(ctx context.Context, in *TaintX509AuthorityRequest, opts ...grpc.CallOption) (*TaintX509AuthorityResponse, error) {
+	out := new(TaintX509AuthorityResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localAuthorityClient) RevokeX509Authority(ctx context.Context, in *RevokeX509AuthorityRequest, opts ...grpc.CallOption) (*RevokeX509AuthorityResponse, error) {
+	out := new(RevokeX509AuthorityResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localAuthorityClient) TaintJWTAuthority(ctx context.Context, in *TaintJWTAuthorityRequest, opts ...grpc.CallOption) (*TaintJWTAuthorityResponse, error) {
+	out := new(TaintJWTAuthorityResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localAuthorityClient) RevokeJWTAuthority(ctx context.Context, in *RevokeJWTAuthorityRequest, opts ...grpc.CallOption) (*RevokeJWTAuthorityResponse, error) {
+	out := new(RevokeJWTAuthorityResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LocalAuthorityServer is the server API for LocalAuthority service.
+type LocalAuthorityServer interface {
+	// Prepares the next X509 CA and JWT signing key of the server without
+	// waiting for the preparation threshold, replacing any that were already
+	// prepared. The prepared authorities are added to the trust bundle and
+	// activated once the activation threshold of the current ones is
+	// reached.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	PrepareNextAuthority(context.Context, *PrepareNextAuthorityRequest) (*PrepareNextAuthorityResponse, error)
+	// Activates the next X509 CA and JWT signing key of the server without
+	// waiting for the activation threshold, preparing them first if they
+	// have not been prepared. Peers that have not received the updated trust
+	// bundle will fail to validate SVIDs signed by the activated authorities.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	RotateAuthority(context.Context, *RotateAuthorityRequest) (*RotateAuthorityResponse, error)
+	// Marks an old X509 authority as tainted. Agents rotate the SVIDs that
+	// chain back to a tainted X509 authority. The active and prepared X509
+	// authorities cannot be tainted. Not supported when the server is
+	// configured with an UpstreamAuthority.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	TaintX509Authority(context.Context, *TaintX509AuthorityRequest) (*TaintX509AuthorityResponse, error)
+	// Removes a tainted X509 authority from the trust bundle. SVIDs that
+	// chain back to it are no longer trusted.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	RevokeX509Authority(context.Context, *RevokeX509AuthorityRequest) (*RevokeX509AuthorityResponse, error)
+	// Marks an old JWT authority as tainted. Agents drop the JWT-SVIDs signed
+	// by a tainted JWT authority. The active and prepared JWT authorities
+	// cannot be tainted.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	TaintJWTAuthority(context.Context, *TaintJWTAuthorityRequest) (*TaintJWTAuthorityResponse, error)
+	// Removes a tainted JWT authority from the trust bundle. JWT-SVIDs signed
+	// by it are no longer trusted.
+	//
+	// The caller must be local or present an admin X509-SVID.
+	RevokeJWTAuthority(context.Context, *RevokeJWTAuthorityRequest) (*RevokeJWTAuthorityResponse, error)
+}
+
+// UnimplementedLocalAuthorityServer can be embedded to have forward compatible implementations.
+type UnimplementedLocalAuthorityServer struct {
+}
+
+func (*UnimplementedLocalAuthorityServer) PrepareNextAuthority(ctx context.Context, req *PrepareNextAuthorityRequest) (*PrepareNextAuthorityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareNextAuthority not implemented")
+}
+func (*UnimplementedLocalAuthorityServer) RotateAuthority(ctx context.Context, req *RotateAuthorityRequest) (*RotateAuthorityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateAuthority not implemented")
+}
+func (*UnimplementedLocalAuthorityServer) TaintX509Authority(ctx context.Context, req *TaintX509AuthorityRequest) (*TaintX509AuthorityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TaintX509Authority not implemented")
+}
+func (*UnimplementedLocalAuthorityServer) RevokeX509Authority(ctx context.Context, req *RevokeX509AuthorityRequest) (*RevokeX509AuthorityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeX509Authority not implemented")
+}
+func (*UnimplementedLocalAuthorityServer) TaintJWTAuthority(ctx context.Context, req *TaintJWTAuthorityRequest) (*TaintJWTAuthorityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TaintJWTAuthority not implemented")
+}
+func (*UnimplementedLocalAuthorityServer) RevokeJWTAuthority(ctx context.Context, req *RevokeJWTAuthorityRequest) (*RevokeJWTAuthorityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeJWTAuthority not implemented")
+}
+
+func RegisterLocalAuthorityServer(s *grpc.Server, srv LocalAuthorityServer) {
+	s.RegisterService(&_LocalAuthority_serviceDesc, srv)
+}
+
+func _LocalAuthority_PrepareNextAuthority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareNextAuthorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).PrepareNextAuthority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.localauthority.v1.LocalAuthority/PrepareNextAuthority",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).PrepareNextAuthority(ctx, req.(*PrepareNextAuthorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalAuthority_RotateAuthority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateAuthorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).RotateAuthority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.localauthority.v1.LocalAuthority/RotateAuthority",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).RotateAuthority(ctx, req.(*RotateAuthorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalAuthority_TaintX509Authority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaintX509AuthorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).TaintX509Authority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).TaintX509Authority(ctx, req.(*TaintX509AuthorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalAuthority_RevokeX509Authority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeX509AuthorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).RevokeX509Authority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).RevokeX509Authority(ctx, req.(*RevokeX509AuthorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalAuthority_TaintJWTAuthority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaintJWTAuthorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).TaintJWTAuthority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).TaintJWTAuthority(ctx, req.(*TaintJWTAuthorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalAuthority_RevokeJWTAuthority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeJWTAuthorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).RevokeJWTAuthority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).RevokeJWTAuthority(ctx, req.(*RevokeJWTAuthorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _LocalAuthority_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.localauthority.v1.LocalAuthority",
+	HandlerType: (*LocalAuthorityServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PrepareNextAuthority",
+			Handler:    _LocalAuthority_PrepareNextAuthority_Handler,
+		},
+		{
+			MethodName: "RotateAuthority",
+			Handler:    _LocalAuthority_RotateAuthority_Handler,
+		},
+		{
+			MethodName: "TaintX509Authority",
+			Handler:    _LocalAuthority_TaintX509Authority_Handler,
+		},
+		{
+			MethodName: "RevokeX509Authority",
+			Handler:    _LocalAuthority_RevokeX509Authority_Handler,
+		},
+		{
+			MethodName: "TaintJWTAuthority",
+			Handler:    _LocalAuthority_TaintJWTAuthority_Handler,
+		},
+		{
+			MethodName: "RevokeJWTAuthority",
+			Handler:    _LocalAuthority_RevokeJWTAuthority_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/localauthority/v1/localauthority.proto",
+}
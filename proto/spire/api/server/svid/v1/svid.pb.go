@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	_struct "github.com/golang/protobuf/ptypes/struct"
 	types "github.com/spiffe/spire/proto/spire/types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -585,6 +586,114 @@ func (m *NewX509SVIDParams) GetCsr() []byte {
 	return nil
 }
 
+type ValidateJWTSVIDRequest struct {
+	// Required. The audience the JWT-SVID must have been issued for.
+	Audience string `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
+	// Required. The JWT-SVID to validate.
+	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidateJWTSVIDRequest) Reset()         { *m = ValidateJWTSVIDRequest{} }
+func (m *ValidateJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateJWTSVIDRequest) ProtoMessage()    {}
+func (*ValidateJWTSVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e88f76a4f58fcdb9, []int{11}
+}
+
+func (m *ValidateJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateJWTSVIDRequest.Unmarshal(m, b)
+}
+func (m *ValidateJWTSVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateJWTSVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *ValidateJWTSVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateJWTSVIDRequest.Merge(m, src)
+}
+func (m *ValidateJWTSVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_ValidateJWTSVIDRequest.Size(m)
+}
+func (m *ValidateJWTSVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateJWTSVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateJWTSVIDRequest proto.InternalMessageInfo
+
+func (m *ValidateJWTSVIDRequest) GetAudience() string {
+	if m != nil {
+		return m.Audience
+	}
+	return ""
+}
+
+func (m *ValidateJWTSVIDRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type ValidateJWTSVIDResponse struct {
+	// The SPIFFE ID of the validated JWT-SVID.
+	Id *types.SPIFFEID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The claims of the validated JWT-SVID.
+	Claims *_struct.Struct `protobuf:"bytes,2,opt,name=claims,proto3" json:"claims,omitempty"`
+	// The registration entries for the SPIFFE ID of the validated JWT-SVID.
+	// Empty for JWT-SVIDs issued by federated trust domains.
+	Entries              []*types.Entry `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ValidateJWTSVIDResponse) Reset()         { *m = ValidateJWTSVIDResponse{} }
+func (m *ValidateJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateJWTSVIDResponse) ProtoMessage()    {}
+func (*ValidateJWTSVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e88f76a4f58fcdb9, []int{12}
+}
+
+func (m *ValidateJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateJWTSVIDResponse.Unmarshal(m, b)
+}
+func (m *ValidateJWTSVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateJWTSVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *ValidateJWTSVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateJWTSVIDResponse.Merge(m, src)
+}
+func (m *ValidateJWTSVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidateJWTSVIDResponse.Size(m)
+}
+func (m *ValidateJWTSVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateJWTSVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateJWTSVIDResponse proto.InternalMessageInfo
+
+func (m *ValidateJWTSVIDResponse) GetId() *types.SPIFFEID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ValidateJWTSVIDResponse) GetClaims() *_struct.Struct {
+	if m != nil {
+		return m.Claims
+	}
+	return nil
+}
+
+func (m *ValidateJWTSVIDResponse) GetEntries() []*types.Entry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*MintX509SVIDRequest)(nil), "spire.api.server.svid.v1.MintX509SVIDRequest")
 	proto.RegisterType((*MintX509SVIDResponse)(nil), "spire.api.server.svid.v1.MintX509SVIDResponse")
@@ -598,6 +707,8 @@ func init() {
 	proto.RegisterType((*NewDownstreamX509CARequest)(nil), "spire.api.server.svid.v1.NewDownstreamX509CARequest")
 	proto.RegisterType((*NewDownstreamX509CAResponse)(nil), "spire.api.server.svid.v1.NewDownstreamX509CAResponse")
 	proto.RegisterType((*NewX509SVIDParams)(nil), "spire.api.server.svid.v1.NewX509SVIDParams")
+	proto.RegisterType((*ValidateJWTSVIDRequest)(nil), "spire.api.server.svid.v1.ValidateJWTSVIDRequest")
+	proto.RegisterType((*ValidateJWTSVIDResponse)(nil), "spire.api.server.svid.v1.ValidateJWTSVIDResponse")
 }
 
 func init() {
@@ -605,46 +716,54 @@ func init() {
 }
 
 var fileDescriptor_e88f76a4f58fcdb9 = []byte{
-	// 622 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x5d, 0x4f, 0xdb, 0x30,
-	0x14, 0x55, 0x5b, 0x56, 0xe0, 0xb6, 0xd3, 0x98, 0x61, 0x5a, 0xc8, 0x5e, 0xaa, 0x4c, 0x93, 0x8a,
-	0x00, 0x07, 0xba, 0xf1, 0x50, 0xa1, 0x7d, 0x40, 0x19, 0x52, 0x91, 0x86, 0x90, 0x99, 0xb6, 0x69,
-	0x0f, 0xeb, 0x4c, 0x30, 0xd4, 0x88, 0x36, 0x99, 0xed, 0xb4, 0xe3, 0x75, 0x3f, 0x6f, 0x6f, 0xfb,
-	0x47, 0x53, 0x1c, 0x37, 0x4d, 0xba, 0xa6, 0xa3, 0x4f, 0x89, 0xed, 0x73, 0xee, 0x39, 0xbe, 0x1f,
-	0x32, 0x3c, 0x97, 0x01, 0x17, 0xcc, 0xa5, 0x01, 0x77, 0x25, 0x13, 0x03, 0x26, 0x5c, 0x39, 0xe0,
-	0x97, 0xee, 0x60, 0x57, 0x7f, 0x71, 0x20, 0x7c, 0xe5, 0x23, 0x4b, 0x83, 0x30, 0x0d, 0x38, 0x8e,
-	0x41, 0x58, 0x1f, 0x0e, 0x76, 0xed, 0xf5, 0x98, 0xae, 0xee, 0x02, 0x26, 0xdd, 0x9b, 0xa1, 0x1a,
-	0x93, 0x6c, 0x3b, 0x7d, 0x24, 0x03, 0x7e, 0x75, 0xc5, 0x92, 0x33, 0x2b, 0x73, 0xa6, 0xa8, 0x0a,
-	0xe5, 0x34, 0xd6, 0xcf, 0xbd, 0x9d, 0xe6, 0x38, 0xa2, 0xd3, 0x84, 0xd5, 0x0f, 0xbc, 0xaf, 0xbe,
-	0xec, 0xed, 0x34, 0xcf, 0x3f, 0xb5, 0x8f, 0x08, 0xfb, 0x11, 0x32, 0xa9, 0xd0, 0x0a, 0x94, 0x3c,
-	0x29, 0xac, 0x42, 0xad, 0x50, 0xaf, 0x92, 0xe8, 0x37, 0xda, 0x51, 0xea, 0xd6, 0x2a, 0xd6, 0x0a,
-	0xf5, 0x07, 0x24, 0xfa, 0x75, 0x0e, 0x60, 0x2d, 0x4b, 0x95, 0x81, 0xdf, 0x97, 0x0c, 0x6d, 0xc0,
-	0x42, 0x24, 0xa0, 0xc9, 0x95, 0xc6, 0x13, 0x1c, 0x5f, 0x54, 0xab, 0xe3, 0x04, 0xac, 0x21, 0x0e,
-	0x07, 0x14, 0x85, 0x38, 0xf9, 0xfc, 0x31, 0x2d, 0xfe, 0x02, 0x8a, 0x39, 0xf4, 0xf3, 0xb3, 0xf6,
-	0xf1, 0xf1, 0xfb, 0xf6, 0x11, 0x29, 0xf2, 0x4b, 0x64, 0xc3, 0x12, 0x0d, 0x2f, 0x39, 0xeb, 0x7b,
-	0xcc, 0x2a, 0xd6, 0x4a, 0xf5, 0x65, 0x92, 0xac, 0x47, 0x6e, 0x4b, 0x63, 0xb7, 0x6f, 0xe3, 0x8b,
-	0x26, 0x52, 0xc6, 0x6c, 0x3d, 0x63, 0x76, 0x2d, 0xa3, 0x36, 0xc2, 0xc6, 0x5e, 0xbf, 0xc1, 0xd3,
-	0x43, 0xaa, 0xbc, 0xee, 0x29, 0x1b, 0x4e, 0x66, 0xab, 0x05, 0xe5, 0x80, 0x0a, 0xda, 0x93, 0x56,
-	0xa1, 0x56, 0xaa, 0x57, 0x1a, 0x9b, 0x38, 0xaf, 0xb8, 0x38, 0xc5, 0x3e, 0xd3, 0x14, 0x62, 0xa8,
-	0xce, 0x9f, 0x02, 0x58, 0xff, 0x0a, 0x18, 0x9b, 0xe7, 0xb0, 0x28, 0x98, 0x0c, 0x6f, 0xd5, 0x48,
-	0xa2, 0x99, 0x2f, 0x91, 0x17, 0x04, 0x13, 0x1d, 0x81, 0x8c, 0x22, 0xd9, 0xdf, 0xa1, 0x1c, 0x6f,
-	0xa1, 0x4d, 0x28, 0xc7, 0x1d, 0x63, 0xf2, 0xb0, 0x9a, 0xcd, 0xba, 0x3e, 0x22, 0x06, 0x92, 0xd4,
-	0xb7, 0xf8, 0xff, 0xfa, 0x9e, 0xc0, 0xe3, 0x53, 0x36, 0x9c, 0x28, 0xef, 0x3a, 0x2c, 0xb1, 0xbe,
-	0x12, 0x77, 0x1d, 0x93, 0xf6, 0x65, 0xb2, 0xa8, 0xd7, 0xed, 0x99, 0x25, 0x75, 0xde, 0x00, 0x4a,
-	0xc7, 0x9a, 0xbb, 0x7e, 0x18, 0xec, 0x53, 0x36, 0x3c, 0xf2, 0x87, 0x7d, 0xa9, 0x04, 0xa3, 0xbd,
-	0xc8, 0x6a, 0xeb, 0x20, 0xb7, 0xe1, 0x9d, 0x5b, 0x78, 0x36, 0x15, 0x6f, 0x84, 0x1d, 0x78, 0xe8,
-	0xd1, 0x8e, 0xc7, 0x84, 0xea, 0x78, 0x5d, 0xca, 0xfb, 0xba, 0x2e, 0x55, 0x52, 0xf1, 0x68, 0x8b,
-	0x09, 0xd5, 0x8a, 0xb6, 0xd0, 0x06, 0xac, 0x44, 0xe3, 0xd6, 0xa1, 0xa1, 0xea, 0xfa, 0x82, 0x2b,
-	0xce, 0xa4, 0xbe, 0x56, 0x95, 0x3c, 0x8a, 0xf6, 0x0f, 0xc6, 0xdb, 0xce, 0x3b, 0x9d, 0xa9, 0x6c,
-	0x6b, 0xcc, 0xca, 0x94, 0xf1, 0x5b, 0x4c, 0xfc, 0x36, 0x7e, 0x2f, 0xc0, 0x42, 0xc4, 0x45, 0x3d,
-	0xa8, 0xa6, 0xe7, 0x12, 0x6d, 0xe7, 0xb7, 0xca, 0x94, 0xd1, 0xb7, 0xf1, 0x7d, 0xe1, 0x26, 0x11,
-	0x37, 0x50, 0x49, 0x0d, 0x16, 0xda, 0x9a, 0x4d, 0xcf, 0xf6, 0x82, 0xbd, 0x7d, 0x4f, 0xb4, 0xd1,
-	0xba, 0x83, 0x95, 0xc9, 0xee, 0x46, 0xbb, 0xf3, 0x4c, 0x42, 0xac, 0xda, 0x98, 0x7f, 0x78, 0xd0,
-	0x35, 0xc0, 0xb8, 0xfd, 0xd0, 0xec, 0x09, 0x9f, 0xb8, 0xe4, 0xd6, 0xfd, 0xc0, 0x46, 0xe8, 0x57,
-	0x01, 0x56, 0xa7, 0x34, 0x1e, 0x7a, 0x35, 0x33, 0x4a, 0x4e, 0x5f, 0xdb, 0x7b, 0x73, 0xb2, 0x62,
-	0x13, 0x87, 0xaf, 0xbf, 0xee, 0x5f, 0x73, 0xd5, 0x0d, 0x2f, 0xb0, 0xe7, 0xf7, 0xcc, 0x4b, 0xe3,
-	0xc6, 0xcf, 0x88, 0x7e, 0x37, 0xdc, 0xbc, 0x27, 0x6e, 0x3f, 0xfa, 0x5e, 0x94, 0x35, 0xe8, 0xe5,
-	0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x41, 0xf8, 0x80, 0x22, 0x0a, 0x07, 0x00, 0x00,
+	// 746 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcd, 0x6e, 0xda, 0x4a,
+	0x14, 0x16, 0x90, 0x90, 0xe4, 0xc0, 0x55, 0x72, 0x87, 0xdc, 0x0b, 0x71, 0xbb, 0x40, 0xae, 0x2a,
+	0x11, 0x25, 0xb1, 0x03, 0x6d, 0x16, 0x28, 0xea, 0x4f, 0x42, 0x12, 0x89, 0x48, 0x8d, 0xa2, 0xa1,
+	0x4a, 0xab, 0x2e, 0x4a, 0x27, 0x66, 0x02, 0x93, 0x02, 0x76, 0x3d, 0x63, 0x68, 0xb6, 0x7d, 0x91,
+	0xbe, 0x4b, 0xdf, 0xa0, 0x6f, 0x54, 0x79, 0x3c, 0x80, 0x4d, 0x30, 0x85, 0x95, 0x3d, 0x73, 0xbe,
+	0xef, 0xfc, 0x9f, 0x63, 0xc3, 0x33, 0xee, 0x30, 0x97, 0x9a, 0xc4, 0x61, 0x26, 0xa7, 0xee, 0x80,
+	0xba, 0x26, 0x1f, 0xb0, 0x96, 0x39, 0x28, 0xcb, 0xa7, 0xe1, 0xb8, 0xb6, 0xb0, 0x51, 0x41, 0x82,
+	0x0c, 0xe2, 0x30, 0x23, 0x00, 0x19, 0x52, 0x38, 0x28, 0x6b, 0x3b, 0x01, 0x5d, 0x3c, 0x38, 0x94,
+	0x9b, 0xf7, 0x43, 0x31, 0x21, 0x69, 0x5a, 0x58, 0xc4, 0x1d, 0x76, 0x77, 0x47, 0xc7, 0xb2, 0x42,
+	0x44, 0x26, 0x88, 0xf0, 0xf8, 0x2c, 0xd6, 0xf7, 0xa3, 0xc3, 0x6a, 0x48, 0xe3, 0xd3, 0xb6, 0x6d,
+	0xb7, 0xbb, 0xd4, 0x94, 0xa7, 0x5b, 0xef, 0xce, 0xe4, 0xc2, 0xf5, 0x2c, 0xa1, 0xa4, 0xf9, 0x30,
+	0x93, 0xf6, 0x85, 0xfb, 0x10, 0x08, 0xf4, 0x2a, 0xe4, 0xde, 0xb1, 0xbe, 0xf8, 0x78, 0x74, 0x58,
+	0x6d, 0xdc, 0xd4, 0xcf, 0x30, 0xfd, 0xe6, 0x51, 0x2e, 0xd0, 0x16, 0xa4, 0x2c, 0xee, 0x16, 0x12,
+	0xc5, 0x44, 0x29, 0x8b, 0xfd, 0x57, 0xff, 0x46, 0x88, 0x6e, 0x21, 0x59, 0x4c, 0x94, 0x56, 0xb1,
+	0xff, 0xaa, 0x9f, 0xc0, 0x76, 0x94, 0xca, 0x1d, 0xbb, 0xcf, 0x29, 0xda, 0x85, 0x15, 0xdf, 0x2f,
+	0x49, 0xce, 0x54, 0xfe, 0x33, 0x82, 0xfc, 0x48, 0xd3, 0xc6, 0x18, 0x2c, 0x21, 0x3a, 0x03, 0xe4,
+	0xab, 0xb8, 0xfc, 0xf0, 0x3e, 0x6c, 0xfc, 0x39, 0x24, 0x63, 0xe8, 0x8d, 0xeb, 0xfa, 0xc5, 0xc5,
+	0x79, 0xfd, 0x0c, 0x27, 0x59, 0x0b, 0x69, 0xb0, 0x4e, 0xbc, 0x16, 0xa3, 0x7d, 0x8b, 0x16, 0x92,
+	0xc5, 0x54, 0x69, 0x03, 0x8f, 0xcf, 0x23, 0x6f, 0x53, 0x13, 0x6f, 0xdf, 0x40, 0x2e, 0x62, 0x4a,
+	0x39, 0x5b, 0x8a, 0x38, 0xbb, 0x1d, 0xb1, 0x36, 0xc2, 0x06, 0xbe, 0x7e, 0x86, 0xfc, 0x29, 0x11,
+	0x56, 0xe7, 0x8a, 0x0e, 0xa7, 0xb3, 0x55, 0x83, 0xb4, 0x43, 0x5c, 0xd2, 0xe3, 0x85, 0x44, 0x31,
+	0x55, 0xca, 0x54, 0xf6, 0x8c, 0xb8, 0x9e, 0x30, 0x42, 0xec, 0x6b, 0x49, 0xc1, 0x8a, 0xaa, 0xff,
+	0x4e, 0x40, 0xe1, 0xb1, 0x01, 0xe5, 0x66, 0x03, 0xd6, 0x5c, 0xca, 0xbd, 0xae, 0x18, 0x99, 0xa8,
+	0xc6, 0x9b, 0x88, 0x53, 0x62, 0x60, 0xa9, 0x01, 0x8f, 0x34, 0x69, 0x5f, 0x20, 0x1d, 0x5c, 0xa1,
+	0x3d, 0x48, 0x07, 0x8d, 0xa6, 0xf2, 0x90, 0x8b, 0x66, 0x5d, 0x8a, 0xb0, 0x82, 0x8c, 0xeb, 0x9b,
+	0xfc, 0x7b, 0x7d, 0x2f, 0xe1, 0xdf, 0x2b, 0x3a, 0x9c, 0x2a, 0xef, 0x0e, 0xac, 0xcb, 0x0e, 0x6c,
+	0xaa, 0xb4, 0x6f, 0xe0, 0x35, 0x79, 0xae, 0xcf, 0x2d, 0xa9, 0xfe, 0x1a, 0x50, 0x58, 0xd7, 0xd2,
+	0xf5, 0x33, 0x40, 0xbb, 0xa2, 0xc3, 0x33, 0x7b, 0xd8, 0xe7, 0xc2, 0xa5, 0xa4, 0xe7, 0xbb, 0x5a,
+	0x3b, 0x89, 0x6d, 0x78, 0xbd, 0x0b, 0x4f, 0x66, 0xe2, 0x95, 0x61, 0x1d, 0xfe, 0xb1, 0x48, 0xd3,
+	0xa2, 0xae, 0x68, 0x5a, 0x1d, 0xc2, 0xfa, 0xb2, 0x2e, 0x59, 0x9c, 0xb1, 0x48, 0x8d, 0xba, 0xa2,
+	0xe6, 0x5f, 0xa1, 0x5d, 0xd8, 0xf2, 0xa7, 0xb4, 0x49, 0x3c, 0xd1, 0xb1, 0x5d, 0x26, 0x18, 0xe5,
+	0x32, 0xac, 0x2c, 0xde, 0xf4, 0xef, 0x4f, 0x26, 0xd7, 0xfa, 0x5b, 0x99, 0xa9, 0x68, 0x6b, 0xcc,
+	0xcb, 0x94, 0xf2, 0x37, 0x39, 0xf1, 0xf7, 0x12, 0xfe, 0xbf, 0x21, 0x5d, 0xd6, 0x22, 0x82, 0x4e,
+	0x25, 0x3c, 0x9c, 0xd5, 0x40, 0xcd, 0x64, 0x50, 0xb6, 0x61, 0x55, 0xd8, 0x5f, 0x69, 0x5f, 0x6a,
+	0xda, 0xc0, 0xc1, 0x41, 0xff, 0x99, 0x80, 0xfc, 0x23, 0x65, 0x2a, 0xf0, 0x05, 0xa7, 0xd3, 0x84,
+	0xb4, 0xd5, 0x25, 0xac, 0xc7, 0x55, 0x9f, 0xe4, 0x8d, 0x60, 0x41, 0x19, 0xa3, 0x05, 0x65, 0x34,
+	0xe4, 0x82, 0xc2, 0x0a, 0x86, 0xf6, 0x41, 0x06, 0xe7, 0xe7, 0x28, 0x25, 0x5b, 0x1c, 0x45, 0x94,
+	0x9f, 0xfb, 0x81, 0xe3, 0x11, 0xa4, 0xf2, 0x6b, 0x15, 0x56, 0x7c, 0xb7, 0x50, 0x0f, 0xb2, 0xe1,
+	0x2d, 0x84, 0x0e, 0xe2, 0x07, 0x63, 0xc6, 0xa2, 0xd3, 0x8c, 0x45, 0xe1, 0x2a, 0xfa, 0x7b, 0xc8,
+	0x84, 0xd6, 0x08, 0xda, 0x9f, 0x4f, 0x8f, 0x16, 0x42, 0x3b, 0x58, 0x10, 0xad, 0x6c, 0x3d, 0xc0,
+	0xd6, 0xf4, 0x2c, 0xa3, 0xf2, 0x32, 0x73, 0x1f, 0x58, 0xad, 0x2c, 0xbf, 0x2a, 0x50, 0x1b, 0x60,
+	0x32, 0x6c, 0x68, 0xfe, 0x3e, 0x9b, 0x0a, 0x72, 0x7f, 0x31, 0xb0, 0x32, 0xf4, 0x23, 0x01, 0xb9,
+	0x19, 0x63, 0x86, 0x5e, 0xce, 0xd5, 0x12, 0x33, 0xc5, 0xda, 0xd1, 0x92, 0x2c, 0xe5, 0xc4, 0x00,
+	0x36, 0xa7, 0xba, 0x1d, 0x1d, 0xc6, 0x6b, 0x9a, 0x3d, 0x65, 0x5a, 0x79, 0x09, 0x46, 0x60, 0xf7,
+	0xf4, 0xd5, 0xa7, 0xe3, 0x36, 0x13, 0x1d, 0xef, 0xd6, 0xb0, 0xec, 0x9e, 0xfa, 0x0d, 0x30, 0x83,
+	0x2f, 0xb5, 0x9c, 0x12, 0x33, 0xee, 0xff, 0xe3, 0xd8, 0x7f, 0xde, 0xa6, 0x25, 0xe8, 0xc5, 0x9f,
+	0x01, 0x00, 0x46, 0x12, 0x8f, 0x89, 0xa7, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -684,6 +803,13 @@ type SVIDClient interface {
 	//
 	// The caller must present a downstream X509-SVID.
 	NewDownstreamX509CA(ctx context.Context, in *NewDownstreamX509CARequest, opts ...grpc.CallOption) (*NewDownstreamX509CAResponse, error)
+	// Validates a JWT-SVID against the JWT authorities of the trust domain
+	// and of the federated trust domains. Returns the SPIFFE ID and claims
+	// of the JWT-SVID along with the registration entries for the SPIFFE ID.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	ValidateJWTSVID(ctx context.Context, in *ValidateJWTSVIDRequest, opts ...grpc.CallOption) (*ValidateJWTSVIDResponse, error)
 }
 
 type sVIDClient struct {
@@ -739,6 +865,15 @@ func (c *sVIDClient) NewDownstreamX509CA(ctx context.Context, in *NewDownstreamX
 	return out, nil
 }
 
+func (c *sVIDClient) ValidateJWTSVID(ctx context.Context, in *ValidateJWTSVIDRequest, opts ...grpc.CallOption) (*ValidateJWTSVIDResponse, error) {
+	out := new(ValidateJWTSVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.svid.v1.SVID/ValidateJWTSVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SVIDServer is the server API for SVID service.
 type SVIDServer interface {
 	// Mints a one-off X509-SVID outside of the normal node/workload
@@ -766,6 +901,13 @@ type SVIDServer interface {
 	//
 	// The caller must present a downstream X509-SVID.
 	NewDownstreamX509CA(context.Context, *NewDownstreamX509CARequest) (*NewDownstreamX509CAResponse, error)
+	// Validates a JWT-SVID against the JWT authorities of the trust domain
+	// and of the federated trust domains. Returns the SPIFFE ID and claims
+	// of the JWT-SVID along with the registration entries for the SPIFFE ID.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	ValidateJWTSVID(context.Context, *ValidateJWTSVIDRequest) (*ValidateJWTSVIDResponse, error)
 }
 
 // UnimplementedSVIDServer can be embedded to have forward compatible implementations.
@@ -787,6 +929,9 @@ func (*UnimplementedSVIDServer) NewJWTSVID(ctx context.Context, req *NewJWTSVIDR
 func (*UnimplementedSVIDServer) NewDownstreamX509CA(ctx context.Context, req *NewDownstreamX509CARequest) (*NewDownstreamX509CAResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewDownstreamX509CA not implemented")
 }
+func (*UnimplementedSVIDServer) ValidateJWTSVID(ctx context.Context, req *ValidateJWTSVIDRequest) (*ValidateJWTSVIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateJWTSVID not implemented")
+}
 
 func RegisterSVIDServer(s *grpc.Server, srv SVIDServer) {
 	s.RegisterService(&_SVID_serviceDesc, srv)
@@ -882,6 +1027,24 @@ func _SVID_NewDownstreamX509CA_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SVID_ValidateJWTSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateJWTSVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SVIDServer).ValidateJWTSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.svid.v1.SVID/ValidateJWTSVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SVIDServer).ValidateJWTSVID(ctx, req.(*ValidateJWTSVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SVID_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.server.svid.v1.SVID",
 	HandlerType: (*SVIDServer)(nil),
@@ -906,6 +1069,10 @@ var _SVID_serviceDesc = grpc.ServiceDesc{
 			MethodName: "NewDownstreamX509CA",
 			Handler:    _SVID_NewDownstreamX509CA_Handler,
 		},
+		{
+			MethodName: "ValidateJWTSVID",
+			Handler:    _SVID_ValidateJWTSVID_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "spire/api/server/svid/v1/svid.proto",
@@ -0,0 +1,934 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: spire/api/server/trustdomain/v1/trustdomain.proto
+
+package trustdomain
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	types "github.com/spiffe/spire/proto/spire/types"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ListFederationRelationshipsRequest struct {
+	// The maximum number of results to return. The server may further
+	// constrain this value, or if zero, choose its own.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token value returned from a previous request, if any.
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFederationRelationshipsRequest) Reset()         { *m = ListFederationRelationshipsRequest{} }
+func (m *ListFederationRelationshipsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsRequest) ProtoMessage()    {}
+func (*ListFederationRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{0}
+}
+
+func (m *ListFederationRelationshipsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsRequest.Merge(m, src)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Size(m)
+}
+func (m *ListFederationRelationshipsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsRequest proto.InternalMessageInfo
+
+func (m *ListFederationRelationshipsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListFederationRelationshipsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListFederationRelationshipsResponse struct {
+	// The federation relationships.
+	FederationRelationships []*types.FederationRelationship `protobuf:"bytes,1,rep,name=federation_relationships,json=federationRelationships,proto3" json:"federation_relationships,omitempty"`
+	// The page token for the next request. Empty if there are no more results.
+	// This field should be checked by clients even when a page_size was not
+	// requested, since the server may choose its own (see page_size).
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFederationRelationshipsResponse) Reset()         { *m = ListFederationRelationshipsResponse{} }
+func (m *ListFederationRelationshipsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsResponse) ProtoMessage()    {}
+func (*ListFederationRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{1}
+}
+
+func (m *ListFederationRelationshipsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsResponse.Merge(m, src)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Size(m)
+}
+func (m *ListFederationRelationshipsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsResponse proto.InternalMessageInfo
+
+func (m *ListFederationRelationshipsResponse) GetFederationRelationships() []*types.FederationRelationship {
+	if m != nil {
+		return m.FederationRelationships
+	}
+	return nil
+}
+
+func (m *ListFederationRelationshipsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetFederationRelationshipRequest struct {
+	// Required. The trust domain name of the federation relationship (e.g.,
+	// "example.org").
+	TrustDomain          string   `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain,proto3" json:"trust_domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFederationRelationshipRequest) Reset()         { *m = GetFederationRelationshipRequest{} }
+func (m *GetFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFederationRelationshipRequest) ProtoMessage()    {}
+func (*GetFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{2}
+}
+
+func (m *GetFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *GetFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFederationRelationshipRequest.Merge(m, src)
+}
+func (m *GetFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFederationRelationshipRequest.Size(m)
+}
+func (m *GetFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *GetFederationRelationshipRequest) GetTrustDomain() string {
+	if m != nil {
+		return m.TrustDomain
+	}
+	return ""
+}
+
+type BatchCreateFederationRelationshipRequest struct {
+	// The federation relationships to be created.
+	FederationRelationships []*types.FederationRelationship `protobuf:"bytes,1,rep,name=federation_relationships,json=federationRelationships,proto3" json:"federation_relationships,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}                        `json:"-"`
+	XXX_unrecognized        []byte                          `json:"-"`
+	XXX_sizecache           int32                           `json:"-"`
+}
+
+func (m *BatchCreateFederationRelationshipRequest) Reset() {
+	*m = BatchCreateFederationRelationshipRequest{}
+}
+func (m *BatchCreateFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateFederationRelationshipRequest) ProtoMessage()    {}
+func (*BatchCreateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{3}
+}
+
+func (m *BatchCreateFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *BatchCreateFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateFederationRelationshipRequest.Merge(m, src)
+}
+func (m *BatchCreateFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateFederationRelationshipRequest.Size(m)
+}
+func (m *BatchCreateFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *BatchCreateFederationRelationshipRequest) GetFederationRelationships() []*types.FederationRelationship {
+	if m != nil {
+		return m.FederationRelationships
+	}
+	return nil
+}
+
+type BatchCreateFederationRelationshipResponse struct {
+	// Result for each federation relationship in the request (order is
+	// maintained).
+	Results              []*BatchCreateFederationRelationshipResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                            `json:"-"`
+	XXX_unrecognized     []byte                                              `json:"-"`
+	XXX_sizecache        int32                                               `json:"-"`
+}
+
+func (m *BatchCreateFederationRelationshipResponse) Reset() {
+	*m = BatchCreateFederationRelationshipResponse{}
+}
+func (m *BatchCreateFederationRelationshipResponse) String() string {
+	return proto.CompactTextString(m)
+}
+func (*BatchCreateFederationRelationshipResponse) ProtoMessage() {}
+func (*BatchCreateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{4}
+}
+
+func (m *BatchCreateFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *BatchCreateFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateFederationRelationshipResponse.Merge(m, src)
+}
+func (m *BatchCreateFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateFederationRelationshipResponse.Size(m)
+}
+func (m *BatchCreateFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *BatchCreateFederationRelationshipResponse) GetResults() []*BatchCreateFederationRelationshipResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchCreateFederationRelationshipResponse_Result struct {
+	// The status of creating the federation relationship.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The federation relationship that was created. This will be set if
+	// the status is OK.
+	FederationRelationship *types.FederationRelationship `protobuf:"bytes,2,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                      `json:"-"`
+	XXX_unrecognized       []byte                        `json:"-"`
+	XXX_sizecache          int32                         `json:"-"`
+}
+
+func (m *BatchCreateFederationRelationshipResponse_Result) Reset() {
+	*m = BatchCreateFederationRelationshipResponse_Result{}
+}
+func (m *BatchCreateFederationRelationshipResponse_Result) String() string {
+	return proto.CompactTextString(m)
+}
+func (*BatchCreateFederationRelationshipResponse_Result) ProtoMessage() {}
+func (*BatchCreateFederationRelationshipResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{4, 0}
+}
+
+func (m *BatchCreateFederationRelationshipResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateFederationRelationshipResponse_Result.Unmarshal(m, b)
+}
+func (m *BatchCreateFederationRelationshipResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateFederationRelationshipResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateFederationRelationshipResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateFederationRelationshipResponse_Result.Merge(m, src)
+}
+func (m *BatchCreateFederationRelationshipResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateFederationRelationshipResponse_Result.Size(m)
+}
+func (m *BatchCreateFederationRelationshipResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateFederationRelationshipResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateFederationRelationshipResponse_Result proto.InternalMessageInfo
+
+func (m *BatchCreateFederationRelationshipResponse_Result) GetStatus() *types.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *BatchCreateFederationRelationshipResponse_Result) GetFederationRelationship() *types.FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type BatchUpdateFederationRelationshipRequest struct {
+	// The federation relationships to be updated.
+	FederationRelationships []*types.FederationRelationship `protobuf:"bytes,1,rep,name=federation_relationships,json=federationRelationships,proto3" json:"federation_relationships,omitempty"`
+	// An input mask indicating which federation relationship fields should be
+	// updated.
+	InputMask            *types.FederationRelationshipMask `protobuf:"bytes,2,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *BatchUpdateFederationRelationshipRequest) Reset() {
+	*m = BatchUpdateFederationRelationshipRequest{}
+}
+func (m *BatchUpdateFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateFederationRelationshipRequest) ProtoMessage()    {}
+func (*BatchUpdateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{5}
+}
+
+func (m *BatchUpdateFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *BatchUpdateFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateFederationRelationshipRequest.Merge(m, src)
+}
+func (m *BatchUpdateFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateFederationRelationshipRequest.Size(m)
+}
+func (m *BatchUpdateFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchUpdateFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *BatchUpdateFederationRelationshipRequest) GetFederationRelationships() []*types.FederationRelationship {
+	if m != nil {
+		return m.FederationRelationships
+	}
+	return nil
+}
+
+func (m *BatchUpdateFederationRelationshipRequest) GetInputMask() *types.FederationRelationshipMask {
+	if m != nil {
+		return m.InputMask
+	}
+	return nil
+}
+
+type BatchUpdateFederationRelationshipResponse struct {
+	// Result for each federation relationship in the request (order is
+	// maintained).
+	Results              []*BatchUpdateFederationRelationshipResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                            `json:"-"`
+	XXX_unrecognized     []byte                                              `json:"-"`
+	XXX_sizecache        int32                                               `json:"-"`
+}
+
+func (m *BatchUpdateFederationRelationshipResponse) Reset() {
+	*m = BatchUpdateFederationRelationshipResponse{}
+}
+func (m *BatchUpdateFederationRelationshipResponse) String() string {
+	return proto.CompactTextString(m)
+}
+func (*BatchUpdateFederationRelationshipResponse) ProtoMessage() {}
+func (*BatchUpdateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{6}
+}
+
+func (m *BatchUpdateFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *BatchUpdateFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateFederationRelationshipResponse.Merge(m, src)
+}
+func (m *BatchUpdateFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateFederationRelationshipResponse.Size(m)
+}
+func (m *BatchUpdateFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchUpdateFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *BatchUpdateFederationRelationshipResponse) GetResults() []*BatchUpdateFederationRelationshipResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchUpdateFederationRelationshipResponse_Result struct {
+	// The status of updating the federation relationship.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The federation relationship that was updated. This will be set if
+	// the status is OK.
+	FederationRelationship *types.FederationRelationship `protobuf:"bytes,2,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                      `json:"-"`
+	XXX_unrecognized       []byte                        `json:"-"`
+	XXX_sizecache          int32                         `json:"-"`
+}
+
+func (m *BatchUpdateFederationRelationshipResponse_Result) Reset() {
+	*m = BatchUpdateFederationRelationshipResponse_Result{}
+}
+func (m *BatchUpdateFederationRelationshipResponse_Result) String() string {
+	return proto.CompactTextString(m)
+}
+func (*BatchUpdateFederationRelationshipResponse_Result) ProtoMessage() {}
+func (*BatchUpdateFederationRelationshipResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{6, 0}
+}
+
+func (m *BatchUpdateFederationRelationshipResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateFederationRelationshipResponse_Result.Unmarshal(m, b)
+}
+func (m *BatchUpdateFederationRelationshipResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateFederationRelationshipResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *BatchUpdateFederationRelationshipResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateFederationRelationshipResponse_Result.Merge(m, src)
+}
+func (m *BatchUpdateFederationRelationshipResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateFederationRelationshipResponse_Result.Size(m)
+}
+func (m *BatchUpdateFederationRelationshipResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateFederationRelationshipResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchUpdateFederationRelationshipResponse_Result proto.InternalMessageInfo
+
+func (m *BatchUpdateFederationRelationshipResponse_Result) GetStatus() *types.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *BatchUpdateFederationRelationshipResponse_Result) GetFederationRelationship() *types.FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type BatchDeleteFederationRelationshipRequest struct {
+	// The trust domain names (e.g., "example.org") of the federation
+	// relationships to delete.
+	TrustDomains         []string `protobuf:"bytes,1,rep,name=trust_domains,json=trustDomains,proto3" json:"trust_domains,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchDeleteFederationRelationshipRequest) Reset() {
+	*m = BatchDeleteFederationRelationshipRequest{}
+}
+func (m *BatchDeleteFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteFederationRelationshipRequest) ProtoMessage()    {}
+func (*BatchDeleteFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{7}
+}
+
+func (m *BatchDeleteFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *BatchDeleteFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteFederationRelationshipRequest.Merge(m, src)
+}
+func (m *BatchDeleteFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteFederationRelationshipRequest.Size(m)
+}
+func (m *BatchDeleteFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *BatchDeleteFederationRelationshipRequest) GetTrustDomains() []string {
+	if m != nil {
+		return m.TrustDomains
+	}
+	return nil
+}
+
+type BatchDeleteFederationRelationshipResponse struct {
+	// Result for each trust domain name in the request (order is maintained).
+	Results              []*BatchDeleteFederationRelationshipResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                            `json:"-"`
+	XXX_unrecognized     []byte                                              `json:"-"`
+	XXX_sizecache        int32                                               `json:"-"`
+}
+
+func (m *BatchDeleteFederationRelationshipResponse) Reset() {
+	*m = BatchDeleteFederationRelationshipResponse{}
+}
+func (m *BatchDeleteFederationRelationshipResponse) String() string {
+	return proto.CompactTextString(m)
+}
+func (*BatchDeleteFederationRelationshipResponse) ProtoMessage() {}
+func (*BatchDeleteFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{8}
+}
+
+func (m *BatchDeleteFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *BatchDeleteFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteFederationRelationshipResponse.Merge(m, src)
+}
+func (m *BatchDeleteFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteFederationRelationshipResponse.Size(m)
+}
+func (m *BatchDeleteFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *BatchDeleteFederationRelationshipResponse) GetResults() []*BatchDeleteFederationRelationshipResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchDeleteFederationRelationshipResponse_Result struct {
+	// The status of deleting the federation relationship.
+	Status *types.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The trust domain name (e.g., "example.org") of the federation
+	// relationship that was deleted.
+	TrustDomain          string   `protobuf:"bytes,2,opt,name=trust_domain,json=trustDomain,proto3" json:"trust_domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchDeleteFederationRelationshipResponse_Result) Reset() {
+	*m = BatchDeleteFederationRelationshipResponse_Result{}
+}
+func (m *BatchDeleteFederationRelationshipResponse_Result) String() string {
+	return proto.CompactTextString(m)
+}
+func (*BatchDeleteFederationRelationshipResponse_Result) ProtoMessage() {}
+func (*BatchDeleteFederationRelationshipResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_39aab112a51319c9, []int{8, 0}
+}
+
+func (m *BatchDeleteFederationRelationshipResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteFederationRelationshipResponse_Result.Unmarshal(m, b)
+}
+func (m *BatchDeleteFederationRelationshipResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteFederationRelationshipResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteFederationRelationshipResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteFederationRelationshipResponse_Result.Merge(m, src)
+}
+func (m *BatchDeleteFederationRelationshipResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteFederationRelationshipResponse_Result.Size(m)
+}
+func (m *BatchDeleteFederationRelationshipResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteFederationRelationshipResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteFederationRelationshipResponse_Result proto.InternalMessageInfo
+
+func (m *BatchDeleteFederationRelationshipResponse_Result) GetStatus() *types.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *BatchDeleteFederationRelationshipResponse_Result) GetTrustDomain() string {
+	if m != nil {
+		return m.TrustDomain
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ListFederationRelationshipsRequest)(nil), "spire.api.server.trustdomain.v1.ListFederationRelationshipsRequest")
+	proto.RegisterType((*ListFederationRelationshipsResponse)(nil), "spire.api.server.trustdomain.v1.ListFederationRelationshipsResponse")
+	proto.RegisterType((*GetFederationRelationshipRequest)(nil), "spire.api.server.trustdomain.v1.GetFederationRelationshipRequest")
+	proto.RegisterType((*BatchCreateFederationRelationshipRequest)(nil), "spire.api.server.trustdomain.v1.BatchCreateFederationRelationshipRequest")
+	proto.RegisterType((*BatchCreateFederationRelationshipResponse)(nil), "spire.api.server.trustdomain.v1.BatchCreateFederationRelationshipResponse")
+	proto.RegisterType((*BatchCreateFederationRelationshipResponse_Result)(nil), "spire.api.server.trustdomain.v1.BatchCreateFederationRelationshipResponse.Result")
+	proto.RegisterType((*BatchUpdateFederationRelationshipRequest)(nil), "spire.api.server.trustdomain.v1.BatchUpdateFederationRelationshipRequest")
+	proto.RegisterType((*BatchUpdateFederationRelationshipResponse)(nil), "spire.api.server.trustdomain.v1.BatchUpdateFederationRelationshipResponse")
+	proto.RegisterType((*BatchUpdateFederationRelationshipResponse_Result)(nil), "spire.api.server.trustdomain.v1.BatchUpdateFederationRelationshipResponse.Result")
+	proto.RegisterType((*BatchDeleteFederationRelationshipRequest)(nil), "spire.api.server.trustdomain.v1.BatchDeleteFederationRelationshipRequest")
+	proto.RegisterType((*BatchDeleteFederationRelationshipResponse)(nil), "spire.api.server.trustdomain.v1.BatchDeleteFederationRelationshipResponse")
+	proto.RegisterType((*BatchDeleteFederationRelationshipResponse_Result)(nil), "spire.api.server.trustdomain.v1.BatchDeleteFederationRelationshipResponse.Result")
+}
+
+func init() {
+	proto.RegisterFile("spire/api/server/trustdomain/v1/trustdomain.proto", fileDescriptor_39aab112a51319c9)
+}
+
+var fileDescriptor_39aab112a51319c9 = []byte{
+	// 608 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xdf, 0x6e, 0x12, 0x4f,
+	0x14, 0xce, 0xf2, 0xcb, 0x0f, 0xe5, 0xd0, 0xc6, 0x64, 0x4c, 0x2c, 0x6e, 0x63, 0xa4, 0x4b, 0xa2,
+	0x34, 0x26, 0xbb, 0x01, 0x2f, 0xbd, 0xb2, 0xc5, 0x9a, 0xa2, 0x46, 0x9d, 0xd6, 0xc4, 0x18, 0x23,
+	0x6e, 0xcb, 0xa1, 0x4c, 0x80, 0xdd, 0x71, 0x67, 0x96, 0x68, 0x6f, 0xbd, 0xf3, 0xd6, 0x97, 0xf0,
+	0x46, 0x1f, 0xc0, 0xc7, 0xf0, 0x51, 0x4c, 0xbc, 0x37, 0xcc, 0x40, 0x59, 0x0b, 0xec, 0x4e, 0x04,
+	0x63, 0xbc, 0x82, 0x39, 0x33, 0xe7, 0x3b, 0x7f, 0xbe, 0xb3, 0xdf, 0x0c, 0xd4, 0x04, 0x67, 0x11,
+	0x7a, 0x3e, 0x67, 0x9e, 0xc0, 0x68, 0x88, 0x91, 0x27, 0xa3, 0x58, 0xc8, 0x76, 0x38, 0xf0, 0x59,
+	0xe0, 0x0d, 0x6b, 0xc9, 0xa5, 0xcb, 0xa3, 0x50, 0x86, 0xe4, 0xba, 0x72, 0x71, 0x7d, 0xce, 0x5c,
+	0xed, 0xe2, 0x26, 0xcf, 0x0c, 0x6b, 0x76, 0x55, 0x63, 0xca, 0x77, 0x1c, 0x85, 0xd7, 0xc1, 0x36,
+	0x46, 0xbe, 0x64, 0x61, 0x10, 0x61, 0x5f, 0xfd, 0x8a, 0x2e, 0xe3, 0x1a, 0xca, 0x2e, 0x25, 0x4f,
+	0x0a, 0xe9, 0xcb, 0x58, 0xe8, 0x1d, 0xe7, 0x35, 0x38, 0x0f, 0x99, 0x90, 0x7b, 0x67, 0xde, 0x34,
+	0xe1, 0x2d, 0x28, 0xbe, 0x89, 0x51, 0x48, 0xb2, 0x09, 0x05, 0xee, 0x9f, 0x60, 0x4b, 0xb0, 0x53,
+	0x2c, 0x59, 0x65, 0xab, 0xfa, 0x3f, 0xbd, 0x38, 0x32, 0x1c, 0xb0, 0x53, 0x24, 0xd7, 0x00, 0xd4,
+	0xa6, 0x0c, 0x7b, 0x18, 0x94, 0x72, 0x65, 0xab, 0x5a, 0xa0, 0xea, 0xf8, 0xe1, 0xc8, 0xe0, 0x7c,
+	0xb6, 0xa0, 0x92, 0x1a, 0x42, 0xf0, 0x30, 0x10, 0x48, 0x5e, 0x41, 0x69, 0x5a, 0x43, 0x2b, 0x59,
+	0x84, 0x28, 0x59, 0xe5, 0xff, 0xaa, 0xc5, 0x7a, 0xc5, 0xd5, 0x1d, 0x51, 0x65, 0xb8, 0xf3, 0xf1,
+	0xe8, 0x46, 0x67, 0x7e, 0x1c, 0x72, 0x03, 0x2e, 0x05, 0xf8, 0x56, 0xb6, 0x66, 0x72, 0x5d, 0x1f,
+	0x99, 0x9f, 0x9c, 0xe5, 0x7b, 0x0f, 0xca, 0xf7, 0x71, 0x41, 0xb6, 0x93, 0x7e, 0x6c, 0xc1, 0x9a,
+	0xe2, 0xa2, 0xa5, 0xc9, 0x50, 0x2d, 0x29, 0xd0, 0xa2, 0xb2, 0x35, 0x94, 0xc9, 0xf9, 0x60, 0x41,
+	0x75, 0xc7, 0x97, 0xc7, 0xdd, 0xdd, 0x08, 0x7d, 0x89, 0xe9, 0x78, 0x7f, 0xb8, 0x76, 0xe7, 0x4b,
+	0x0e, 0xb6, 0x0d, 0x92, 0x19, 0x33, 0xd1, 0x83, 0x0b, 0x11, 0x8a, 0xb8, 0x2f, 0x27, 0xc1, 0x9f,
+	0xba, 0x19, 0xa3, 0xe8, 0x1a, 0x83, 0xbb, 0x54, 0x21, 0xd3, 0x49, 0x04, 0xfb, 0xa3, 0x05, 0x79,
+	0x6d, 0x23, 0xb7, 0x20, 0xaf, 0x67, 0x53, 0xf5, 0xb3, 0x58, 0xbf, 0xfc, 0x4b, 0xcd, 0x07, 0x6a,
+	0x8b, 0x8e, 0x8f, 0x90, 0x97, 0xb0, 0xb1, 0xa0, 0x65, 0x8a, 0x56, 0xc3, 0x8e, 0x5d, 0x99, 0xdf,
+	0x31, 0xe7, 0xdb, 0x84, 0xbd, 0x67, 0xbc, 0xfd, 0xb7, 0xd9, 0x23, 0x7b, 0x00, 0x2c, 0xe0, 0xb1,
+	0x6c, 0x0d, 0x7c, 0xd1, 0x1b, 0x57, 0x77, 0xd3, 0x00, 0xf1, 0x91, 0x2f, 0x7a, 0xb4, 0xa0, 0x5c,
+	0x47, 0x7f, 0xa7, 0x53, 0x90, 0x5e, 0xd4, 0x92, 0x53, 0x60, 0x02, 0xfe, 0x8f, 0x4c, 0xc1, 0xe3,
+	0xf1, 0x10, 0x34, 0xb0, 0x8f, 0x59, 0x43, 0x50, 0x81, 0xf5, 0xa4, 0x24, 0xe8, 0xa6, 0x15, 0xe8,
+	0x5a, 0x42, 0x13, 0x84, 0xf3, 0xdd, 0x82, 0x6d, 0x03, 0xc4, 0x25, 0x19, 0x30, 0x01, 0x9f, 0x61,
+	0xe0, 0xf9, 0xef, 0x11, 0x70, 0x5e, 0x09, 0x73, 0x33, 0x4a, 0x58, 0xff, 0x91, 0x87, 0xe2, 0xe1,
+	0x74, 0x4d, 0x3e, 0x59, 0xb0, 0x99, 0x72, 0x21, 0x90, 0xdd, 0xcc, 0x2a, 0xb3, 0x6f, 0x2c, 0xbb,
+	0xb1, 0x1c, 0xc8, 0x98, 0x81, 0xf7, 0x16, 0x5c, 0x5d, 0x78, 0x19, 0x90, 0xbb, 0x99, 0x31, 0xb2,
+	0x2e, 0x12, 0xdb, 0x64, 0x3c, 0xc9, 0x57, 0x0b, 0xb6, 0x32, 0x05, 0x96, 0xec, 0xaf, 0x42, 0xa4,
+	0x75, 0x56, 0xcd, 0xd5, 0xe9, 0xfd, 0x34, 0xf9, 0x34, 0x5d, 0x30, 0x4d, 0xde, 0x40, 0x8d, 0xed,
+	0xe6, 0x2a, 0xa0, 0xce, 0x27, 0x9f, 0xf6, 0x49, 0x99, 0x26, 0x6f, 0xa0, 0x22, 0x76, 0x73, 0x15,
+	0x50, 0x3a, 0xf9, 0x9d, 0x07, 0x2f, 0xf6, 0x4f, 0x98, 0xec, 0xc6, 0x47, 0xee, 0x71, 0x38, 0xf0,
+	0x04, 0x67, 0x9d, 0x0e, 0x7a, 0xfa, 0x21, 0xa8, 0xde, 0x7e, 0x5e, 0xc6, 0x93, 0xf4, 0x4e, 0x62,
+	0x79, 0x94, 0x57, 0x2e, 0xb7, 0x7f, 0x0e, 0x00, 0xf6, 0xb9, 0xc0, 0xb4, 0xc8, 0x0a, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// TrustDomainClient is the client API for TrustDomain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TrustDomainClient interface {
+	// Lists federation relationships with foreign trust domains.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error)
+	// Gets a federation relationship with a foreign trust domain. If there is
+	// no relationship with the trust domain, NOT_FOUND is returned.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	GetFederationRelationship(ctx context.Context, in *GetFederationRelationshipRequest, opts ...grpc.CallOption) (*types.FederationRelationship, error)
+	// Batch creates one or more federation relationships with foreign trust
+	// domains.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
+	BatchCreateFederationRelationship(ctx context.Context, in *BatchCreateFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchCreateFederationRelationshipResponse, error)
+	// Batch updates one or more federation relationships with foreign trust
+	// domains.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
+	BatchUpdateFederationRelationship(ctx context.Context, in *BatchUpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchUpdateFederationRelationshipResponse, error)
+	// Batch deletes federation relationships with foreign trust domains. The
+	// federated bundles of the trust domains are not deleted.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
+	BatchDeleteFederationRelationship(ctx context.Context, in *BatchDeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchDeleteFederationRelationshipResponse, error)
+}
+
+type trustDomainClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTrustDomainClient(cc grpc.ClientConnInterface) TrustDomainClient {
+	return &trustDomainClient{cc}
+}
+
+func (c *trustDomainClient) ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error) {
+	out := new(ListFederationRelationshipsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trustDomainClient) GetFederationRelationship(ctx context.Context, in *GetFederationRelationshipRequest, opts ...grpc.CallOption) (*types.FederationRelationship, error) {
+	out := new(types.FederationRelationship)
+	err := c.cc.Invoke(ctx, "/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trustDomainClient) BatchCreateFederationRelationship(ctx context.Context, in *BatchCreateFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchCreateFederationRelationshipResponse, error) {
+	out := new(BatchCreateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trustDomainClient) BatchUpdateFederationRelationship(ctx context.Context, in *BatchUpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchUpdateFederationRelationshipResponse, error) {
+	out := new(BatchUpdateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trustDomainClient) BatchDeleteFederationRelationship(ctx context.Context, in *BatchDeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchDeleteFederationRelationshipResponse, error) {
+	out := new(BatchDeleteFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TrustDomainServer is the server API for TrustDomain service.
+type TrustDomainServer interface {
+	// Lists federation relationships with foreign trust domains.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
+	// Gets a federation relationship with a foreign trust domain. If there is
+	// no relationship with the trust domain, NOT_FOUND is returned.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// read-only, entry-admin, bundle-admin or agent-admin role.
+	GetFederationRelationship(context.Context, *GetFederationRelationshipRequest) (*types.FederationRelationship, error)
+	// Batch creates one or more federation relationships with foreign trust
+	// domains.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
+	BatchCreateFederationRelationship(context.Context, *BatchCreateFederationRelationshipRequest) (*BatchCreateFederationRelationshipResponse, error)
+	// Batch updates one or more federation relationships with foreign trust
+	// domains.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
+	BatchUpdateFederationRelationship(context.Context, *BatchUpdateFederationRelationshipRequest) (*BatchUpdateFederationRelationshipResponse, error)
+	// Batch deletes federation relationships with foreign trust domains. The
+	// federated bundles of the trust domains are not deleted.
+	//
+	// The caller must be local, present an admin X509-SVID, or be bound to the
+	// bundle-admin role.
+	BatchDeleteFederationRelationship(context.Context, *BatchDeleteFederationRelationshipRequest) (*BatchDeleteFederationRelationshipResponse, error)
+}
+
+// UnimplementedTrustDomainServer can be embedded to have forward compatible implementations.
+type UnimplementedTrustDomainServer struct {
+}
+
+func (*UnimplementedTrustDomainServer) ListFederationRelationships(ctx context.Context, req *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederationRelationships not implemented")
+}
+func (*UnimplementedTrustDomainServer) GetFederationRelationship(ctx context.Context, req *GetFederationRelationshipRequest) (*types.FederationRelationship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFederationRelationship not implemented")
+}
+func (*UnimplementedTrustDomainServer) BatchCreateFederationRelationship(ctx context.Context, req *BatchCreateFederationRelationshipRequest) (*BatchCreateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateFederationRelationship not implemented")
+}
+func (*UnimplementedTrustDomainServer) BatchUpdateFederationRelationship(ctx context.Context, req *BatchUpdateFederationRelationshipRequest) (*BatchUpdateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateFederationRelationship not implemented")
+}
+func (*UnimplementedTrustDomainServer) BatchDeleteFederationRelationship(ctx context.Context, req *BatchDeleteFederationRelationshipRequest) (*BatchDeleteFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteFederationRelationship not implemented")
+}
+
+func RegisterTrustDomainServer(s *grpc.Server, srv TrustDomainServer) {
+	s.RegisterService(&_TrustDomain_serviceDesc, srv)
+}
+
+func _TrustDomain_ListFederationRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederationRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrustDomainServer).ListFederationRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrustDomainServer).ListFederationRelationships(ctx, req.(*ListFederationRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrustDomain_GetFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrustDomainServer).GetFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrustDomainServer).GetFederationRelationship(ctx, req.(*GetFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrustDomain_BatchCreateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrustDomainServer).BatchCreateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrustDomainServer).BatchCreateFederationRelationship(ctx, req.(*BatchCreateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrustDomain_BatchUpdateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrustDomainServer).BatchUpdateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrustDomainServer).BatchUpdateFederationRelationship(ctx, req.(*BatchUpdateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrustDomain_BatchDeleteFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrustDomainServer).BatchDeleteFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrustDomainServer).BatchDeleteFederationRelationship(ctx, req.(*BatchDeleteFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TrustDomain_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.trustdomain.v1.TrustDomain",
+	HandlerType: (*TrustDomainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFederationRelationships",
+			Handler:    _TrustDomain_ListFederationRelationships_Handler,
+		},
+		{
+			MethodName: "GetFederationRelationship",
+			Handler:    _TrustDomain_GetFederationRelationship_Handler,
+		},
+		{
+			MethodName: "BatchCreateFederationRelationship",
+			Handler:    _TrustDomain_BatchCreateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "BatchUpdateFederationRelationship",
+			Handler:    _TrustDomain_BatchUpdateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "BatchDeleteFederationRelationship",
+			Handler:    _TrustDomain_BatchDeleteFederationRelationship_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/trustdomain/v1/trustdomain.proto",
+}
@@ -20,7 +20,7 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-//* Represents an empty message
+// * Represents an empty message
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -52,7 +52,7 @@ func (m *Empty) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Empty proto.InternalMessageInfo
 
-//* A type which contains attestation data for specific platform.
+// * A type which contains attestation data for specific platform.
 type AttestationData struct {
 	//* Type of attestation to perform.
 	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
@@ -102,8 +102,8 @@ func (m *AttestationData) GetData() []byte {
 	return nil
 }
 
-//* A type which describes the conditions under which a registration
-//entry is matched.
+// * A type which describes the conditions under which a registration
+// entry is matched.
 type Selector struct {
 	//* A selector type represents the type of attestation used in attesting
 	//the entity (Eg: AWS, K8).
@@ -154,7 +154,7 @@ func (m *Selector) GetValue() string {
 	return ""
 }
 
-//* Represents a type with a list of Selector.
+// * Represents a type with a list of Selector.
 type Selectors struct {
 	//* A list of Selector.
 	Entries              []*Selector `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
@@ -290,8 +290,8 @@ func (m *AttestedNode) GetSelectors() []*Selector {
 	return nil
 }
 
-//* This is a curated record that the Server uses to set up and
-//manage the various registered nodes and workloads that are controlled by it.
+// * This is a curated record that the Server uses to set up and
+// manage the various registered nodes and workloads that are controlled by it.
 type RegistrationEntry struct {
 	//* A list of selectors.
 	Selectors []*Selector `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
@@ -319,7 +319,25 @@ type RegistrationEntry struct {
 	//* DNS entries
 	DnsNames []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
 	//* Revision number is bumped every time the entry is updated
-	RevisionNumber       int64    `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	RevisionNumber int64 `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	//* Additional static claims included in JWT-SVIDs minted for this entry
+	JwtSvidClaims map[string]string `protobuf:"bytes,12,rep,name=jwt_svid_claims,json=jwtSvidClaims,proto3" json:"jwt_svid_claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	//* Audience used for JWT-SVIDs minted for this entry when none is requested
+	JwtSvidAudience []string `protobuf:"bytes,13,rep,name=jwt_svid_audience,json=jwtSvidAudience,proto3" json:"jwt_svid_audience,omitempty"`
+	//* Key type of X509-SVIDs minted for this entry (e.g. "ec-p256", "rsa-2048")
+	X509SvidKeyType string `protobuf:"bytes,14,opt,name=x509_svid_key_type,json=x509SvidKeyType,proto3" json:"x509_svid_key_type,omitempty"`
+	//* Templates rendered into additional DNS SANs of X509-SVIDs minted for this entry
+	DnsNameTemplates []string `protobuf:"bytes,15,rep,name=dns_name_templates,json=dnsNameTemplates,proto3" json:"dns_name_templates,omitempty"`
+	//* Subject of X509-SVIDs minted for this entry
+	X509SvidSubject *X509SVIDSubject `protobuf:"bytes,16,opt,name=x509_svid_subject,json=x509SvidSubject,proto3" json:"x509_svid_subject,omitempty"`
+	//* Hint returned to workloads to tell apart the SVIDs minted for this and other entries
+	Hint string `protobuf:"bytes,17,opt,name=hint,proto3" json:"hint,omitempty"`
+	//* Expression over the workload selectors that must also be satisfied,
+	//in addition to the selectors, for the entry to match a workload
+	SelectorExpression string `protobuf:"bytes,18,opt,name=selector_expression,json=selectorExpression,proto3" json:"selector_expression,omitempty"`
+	//* ID of the entry template managing the ttl, federates_with and
+	//dns_name_templates of the entry
+	TemplateId           string   `protobuf:"bytes,19,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -427,18 +445,153 @@ func (m *RegistrationEntry) GetRevisionNumber() int64 {
 	return 0
 }
 
-//* The RegistrationEntryMask is used to update only selected fields of the RegistrationEntry
+func (m *RegistrationEntry) GetJwtSvidClaims() map[string]string {
+	if m != nil {
+		return m.JwtSvidClaims
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetJwtSvidAudience() []string {
+	if m != nil {
+		return m.JwtSvidAudience
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetX509SvidKeyType() string {
+	if m != nil {
+		return m.X509SvidKeyType
+	}
+	return ""
+}
+
+func (m *RegistrationEntry) GetDnsNameTemplates() []string {
+	if m != nil {
+		return m.DnsNameTemplates
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetX509SvidSubject() *X509SVIDSubject {
+	if m != nil {
+		return m.X509SvidSubject
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetHint() string {
+	if m != nil {
+		return m.Hint
+	}
+	return ""
+}
+
+func (m *RegistrationEntry) GetSelectorExpression() string {
+	if m != nil {
+		return m.SelectorExpression
+	}
+	return ""
+}
+
+func (m *RegistrationEntry) GetTemplateId() string {
+	if m != nil {
+		return m.TemplateId
+	}
+	return ""
+}
+
+// * Subject fields included in X509-SVIDs minted for a registration entry
+type X509SVIDSubject struct {
+	//* Common name
+	CommonName string `protobuf:"bytes,1,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	//* Organizations
+	Organization []string `protobuf:"bytes,2,rep,name=organization,proto3" json:"organization,omitempty"`
+	//* Organizational units
+	OrganizationalUnit []string `protobuf:"bytes,3,rep,name=organizational_unit,json=organizationalUnit,proto3" json:"organizational_unit,omitempty"`
+	//* Countries
+	Country              []string `protobuf:"bytes,4,rep,name=country,proto3" json:"country,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVIDSubject) Reset()         { *m = X509SVIDSubject{} }
+func (m *X509SVIDSubject) String() string { return proto.CompactTextString(m) }
+func (*X509SVIDSubject) ProtoMessage()    {}
+func (*X509SVIDSubject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c11412a53cc81147, []int{6}
+}
+
+func (m *X509SVIDSubject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509SVIDSubject.Unmarshal(m, b)
+}
+func (m *X509SVIDSubject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509SVIDSubject.Marshal(b, m, deterministic)
+}
+func (m *X509SVIDSubject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVIDSubject.Merge(m, src)
+}
+func (m *X509SVIDSubject) XXX_Size() int {
+	return xxx_messageInfo_X509SVIDSubject.Size(m)
+}
+func (m *X509SVIDSubject) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVIDSubject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVIDSubject proto.InternalMessageInfo
+
+func (m *X509SVIDSubject) GetCommonName() string {
+	if m != nil {
+		return m.CommonName
+	}
+	return ""
+}
+
+func (m *X509SVIDSubject) GetOrganization() []string {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+func (m *X509SVIDSubject) GetOrganizationalUnit() []string {
+	if m != nil {
+		return m.OrganizationalUnit
+	}
+	return nil
+}
+
+func (m *X509SVIDSubject) GetCountry() []string {
+	if m != nil {
+		return m.Country
+	}
+	return nil
+}
+
+// * The RegistrationEntryMask is used to update only selected fields of the RegistrationEntry
 type RegistrationEntryMask struct {
-	Selectors            bool     `protobuf:"varint,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
-	ParentId             bool     `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	SpiffeId             bool     `protobuf:"varint,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
-	Ttl                  bool     `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	FederatesWith        bool     `protobuf:"varint,5,opt,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
-	EntryId              bool     `protobuf:"varint,6,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
-	Admin                bool     `protobuf:"varint,7,opt,name=admin,proto3" json:"admin,omitempty"`
-	Downstream           bool     `protobuf:"varint,8,opt,name=downstream,proto3" json:"downstream,omitempty"`
-	EntryExpiry          bool     `protobuf:"varint,9,opt,name=entryExpiry,proto3" json:"entryExpiry,omitempty"`
-	DnsNames             bool     `protobuf:"varint,10,opt,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	Selectors     bool `protobuf:"varint,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
+	ParentId      bool `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	SpiffeId      bool `protobuf:"varint,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	Ttl           bool `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	FederatesWith bool `protobuf:"varint,5,opt,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
+	EntryId       bool `protobuf:"varint,6,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Admin         bool `protobuf:"varint,7,opt,name=admin,proto3" json:"admin,omitempty"`
+	Downstream    bool `protobuf:"varint,8,opt,name=downstream,proto3" json:"downstream,omitempty"`
+	EntryExpiry   bool `protobuf:"varint,9,opt,name=entryExpiry,proto3" json:"entryExpiry,omitempty"`
+	DnsNames      bool `protobuf:"varint,10,opt,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	//* When set, the update is rejected if the revision number of the entry
+	//does not match the revision number of the stored entry
+	RevisionNumber       bool     `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	JwtSvidClaims        bool     `protobuf:"varint,12,opt,name=jwt_svid_claims,json=jwtSvidClaims,proto3" json:"jwt_svid_claims,omitempty"`
+	JwtSvidAudience      bool     `protobuf:"varint,13,opt,name=jwt_svid_audience,json=jwtSvidAudience,proto3" json:"jwt_svid_audience,omitempty"`
+	X509SvidKeyType      bool     `protobuf:"varint,14,opt,name=x509_svid_key_type,json=x509SvidKeyType,proto3" json:"x509_svid_key_type,omitempty"`
+	DnsNameTemplates     bool     `protobuf:"varint,15,opt,name=dns_name_templates,json=dnsNameTemplates,proto3" json:"dns_name_templates,omitempty"`
+	X509SvidSubject      bool     `protobuf:"varint,16,opt,name=x509_svid_subject,json=x509SvidSubject,proto3" json:"x509_svid_subject,omitempty"`
+	Hint                 bool     `protobuf:"varint,17,opt,name=hint,proto3" json:"hint,omitempty"`
+	SelectorExpression   bool     `protobuf:"varint,18,opt,name=selector_expression,json=selectorExpression,proto3" json:"selector_expression,omitempty"`
+	TemplateId           bool     `protobuf:"varint,19,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -448,7 +601,7 @@ func (m *RegistrationEntryMask) Reset()         { *m = RegistrationEntryMask{} }
 func (m *RegistrationEntryMask) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntryMask) ProtoMessage()    {}
 func (*RegistrationEntryMask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c11412a53cc81147, []int{6}
+	return fileDescriptor_c11412a53cc81147, []int{7}
 }
 
 func (m *RegistrationEntryMask) XXX_Unmarshal(b []byte) error {
@@ -539,7 +692,70 @@ func (m *RegistrationEntryMask) GetDnsNames() bool {
 	return false
 }
 
-//* A list of registration entries.
+func (m *RegistrationEntryMask) GetRevisionNumber() bool {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetJwtSvidClaims() bool {
+	if m != nil {
+		return m.JwtSvidClaims
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetJwtSvidAudience() bool {
+	if m != nil {
+		return m.JwtSvidAudience
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetX509SvidKeyType() bool {
+	if m != nil {
+		return m.X509SvidKeyType
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetDnsNameTemplates() bool {
+	if m != nil {
+		return m.DnsNameTemplates
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetX509SvidSubject() bool {
+	if m != nil {
+		return m.X509SvidSubject
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetHint() bool {
+	if m != nil {
+		return m.Hint
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetSelectorExpression() bool {
+	if m != nil {
+		return m.SelectorExpression
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetTemplateId() bool {
+	if m != nil {
+		return m.TemplateId
+	}
+	return false
+}
+
+// * A list of registration entries.
 type RegistrationEntries struct {
 	//* A list of RegistrationEntry.
 	Entries              []*RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
@@ -552,7 +768,7 @@ func (m *RegistrationEntries) Reset()         { *m = RegistrationEntries{} }
 func (m *RegistrationEntries) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntries) ProtoMessage()    {}
 func (*RegistrationEntries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c11412a53cc81147, []int{7}
+	return fileDescriptor_c11412a53cc81147, []int{8}
 }
 
 func (m *RegistrationEntries) XXX_Unmarshal(b []byte) error {
@@ -580,9 +796,11 @@ func (m *RegistrationEntries) GetEntries() []*RegistrationEntry {
 	return nil
 }
 
-//* Certificate represents a ASN.1/DER encoded X509 certificate
+// * Certificate represents a ASN.1/DER encoded X509 certificate
 type Certificate struct {
-	DerBytes             []byte   `protobuf:"bytes,1,opt,name=der_bytes,json=derBytes,proto3" json:"der_bytes,omitempty"`
+	DerBytes []byte `protobuf:"bytes,1,opt,name=der_bytes,json=derBytes,proto3" json:"der_bytes,omitempty"`
+	//* whether the key of the certificate has been tainted
+	TaintedKey           bool     `protobuf:"varint,2,opt,name=tainted_key,json=taintedKey,proto3" json:"tainted_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -592,7 +810,7 @@ func (m *Certificate) Reset()         { *m = Certificate{} }
 func (m *Certificate) String() string { return proto.CompactTextString(m) }
 func (*Certificate) ProtoMessage()    {}
 func (*Certificate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c11412a53cc81147, []int{8}
+	return fileDescriptor_c11412a53cc81147, []int{9}
 }
 
 func (m *Certificate) XXX_Unmarshal(b []byte) error {
@@ -620,14 +838,23 @@ func (m *Certificate) GetDerBytes() []byte {
 	return nil
 }
 
-//* PublicKey represents a PKIX encoded public key
+func (m *Certificate) GetTaintedKey() bool {
+	if m != nil {
+		return m.TaintedKey
+	}
+	return false
+}
+
+// * PublicKey represents a PKIX encoded public key
 type PublicKey struct {
 	//* PKIX encoded key data
 	PkixBytes []byte `protobuf:"bytes,1,opt,name=pkix_bytes,json=pkixBytes,proto3" json:"pkix_bytes,omitempty"`
 	//* key identifier
 	Kid string `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
 	//* not after (seconds since unix epoch, 0 means "never expires")
-	NotAfter             int64    `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	NotAfter int64 `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	//* whether the key has been tainted
+	TaintedKey           bool     `protobuf:"varint,4,opt,name=tainted_key,json=taintedKey,proto3" json:"tainted_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -637,7 +864,7 @@ func (m *PublicKey) Reset()         { *m = PublicKey{} }
 func (m *PublicKey) String() string { return proto.CompactTextString(m) }
 func (*PublicKey) ProtoMessage()    {}
 func (*PublicKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c11412a53cc81147, []int{9}
+	return fileDescriptor_c11412a53cc81147, []int{10}
 }
 
 func (m *PublicKey) XXX_Unmarshal(b []byte) error {
@@ -679,6 +906,13 @@ func (m *PublicKey) GetNotAfter() int64 {
 	return 0
 }
 
+func (m *PublicKey) GetTaintedKey() bool {
+	if m != nil {
+		return m.TaintedKey
+	}
+	return false
+}
+
 type Bundle struct {
 	//* the SPIFFE ID of the trust domain the bundle belongs to
 	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
@@ -698,7 +932,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c11412a53cc81147, []int{10}
+	return fileDescriptor_c11412a53cc81147, []int{11}
 }
 
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
@@ -760,7 +994,7 @@ func (m *BundleMask) Reset()         { *m = BundleMask{} }
 func (m *BundleMask) String() string { return proto.CompactTextString(m) }
 func (*BundleMask) ProtoMessage()    {}
 func (*BundleMask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c11412a53cc81147, []int{11}
+	return fileDescriptor_c11412a53cc81147, []int{12}
 }
 
 func (m *BundleMask) XXX_Unmarshal(b []byte) error {
@@ -817,7 +1051,7 @@ func (m *AttestedNodeMask) Reset()         { *m = AttestedNodeMask{} }
 func (m *AttestedNodeMask) String() string { return proto.CompactTextString(m) }
 func (*AttestedNodeMask) ProtoMessage()    {}
 func (*AttestedNodeMask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c11412a53cc81147, []int{12}
+	return fileDescriptor_c11412a53cc81147, []int{13}
 }
 
 func (m *AttestedNodeMask) XXX_Unmarshal(b []byte) error {
@@ -880,6 +1114,8 @@ func init() {
 	proto.RegisterType((*Selectors)(nil), "spire.common.Selectors")
 	proto.RegisterType((*AttestedNode)(nil), "spire.common.AttestedNode")
 	proto.RegisterType((*RegistrationEntry)(nil), "spire.common.RegistrationEntry")
+	proto.RegisterMapType((map[string]string)(nil), "spire.common.RegistrationEntry.JwtSvidClaimsEntry")
+	proto.RegisterType((*X509SVIDSubject)(nil), "spire.common.X509SVIDSubject")
 	proto.RegisterType((*RegistrationEntryMask)(nil), "spire.common.RegistrationEntryMask")
 	proto.RegisterType((*RegistrationEntries)(nil), "spire.common.RegistrationEntries")
 	proto.RegisterType((*Certificate)(nil), "spire.common.Certificate")
@@ -894,58 +1130,78 @@ func init() {
 }
 
 var fileDescriptor_c11412a53cc81147 = []byte{
-	// 842 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x6e, 0x1b, 0x37,
-	0x10, 0xc6, 0x4a, 0x91, 0xb5, 0x3b, 0x52, 0x6c, 0x97, 0x69, 0xd2, 0x35, 0xfa, 0xa7, 0x2e, 0xfa,
-	0x23, 0xa4, 0x81, 0x5d, 0x24, 0xbe, 0xe4, 0xd0, 0x83, 0x9d, 0x18, 0xa8, 0x11, 0xd4, 0x08, 0xd6,
-	0x05, 0x8a, 0xf6, 0xb2, 0xa0, 0xc4, 0x91, 0xc5, 0x58, 0xcb, 0x5d, 0x90, 0xa3, 0x28, 0xfb, 0x66,
-	0x7d, 0x87, 0xf6, 0x75, 0x7a, 0xeb, 0xa1, 0x20, 0xa9, 0xbf, 0x55, 0x1c, 0xc5, 0x39, 0xe4, 0xb4,
-	0xe4, 0xc7, 0x99, 0xe1, 0x37, 0xdf, 0x0c, 0xc9, 0x85, 0x03, 0x53, 0x4a, 0x8d, 0x47, 0xc3, 0x22,
-	0xcf, 0x0b, 0x35, 0xff, 0x1c, 0x96, 0xba, 0xa0, 0x82, 0x75, 0xdd, 0xd2, 0xa1, 0xc7, 0x92, 0x36,
-	0xb4, 0xce, 0xf2, 0x92, 0xaa, 0xe4, 0x29, 0xec, 0x9d, 0x10, 0xa1, 0x21, 0x4e, 0xb2, 0x50, 0xcf,
-	0x39, 0x71, 0xc6, 0xe0, 0x0e, 0x55, 0x25, 0xc6, 0x41, 0x2f, 0xe8, 0x47, 0xa9, 0x1b, 0x5b, 0x4c,
-	0x70, 0xe2, 0x71, 0xa3, 0x17, 0xf4, 0xbb, 0xa9, 0x1b, 0x27, 0xc7, 0x10, 0x5e, 0xe2, 0x04, 0x87,
-	0x54, 0xe8, 0x1b, 0x7d, 0x3e, 0x85, 0xd6, 0x6b, 0x3e, 0x99, 0xa2, 0x73, 0x8a, 0x52, 0x3f, 0x49,
-	0x7e, 0x86, 0x68, 0xe1, 0x65, 0xd8, 0x4f, 0xd0, 0x46, 0x45, 0x5a, 0xa2, 0x89, 0x83, 0x5e, 0xb3,
-	0xdf, 0x79, 0xfc, 0xe0, 0x70, 0x9d, 0xe6, 0xe1, 0xc2, 0x32, 0x5d, 0x98, 0x25, 0x7f, 0x37, 0xa0,
-	0xeb, 0x09, 0xa3, 0xb8, 0x28, 0x04, 0xb2, 0xcf, 0x21, 0x32, 0xa5, 0x1c, 0x8d, 0x30, 0x93, 0x62,
-	0xbe, 0x7d, 0xe8, 0x81, 0x73, 0xc1, 0x1e, 0xc3, 0x7d, 0xbe, 0xca, 0x2e, 0xb3, 0xb4, 0x33, 0xc7,
-	0xd3, 0x53, 0xba, 0xc7, 0xeb, 0xa9, 0xff, 0x66, 0x69, 0x3f, 0x02, 0x36, 0x44, 0x4d, 0x99, 0x41,
-	0x2d, 0xf9, 0x24, 0x53, 0xd3, 0x7c, 0x80, 0x3a, 0x6e, 0x3a, 0x87, 0x7d, 0xbb, 0x72, 0xe9, 0x16,
-	0x2e, 0x1c, 0xce, 0xbe, 0x85, 0x5d, 0x67, 0xad, 0x0a, 0xca, 0xf8, 0x88, 0x50, 0xc7, 0x77, 0x7a,
-	0x41, 0xbf, 0x99, 0x76, 0x2d, 0x7a, 0x51, 0xd0, 0x89, 0xc5, 0xd8, 0x13, 0x78, 0xa0, 0x70, 0x96,
-	0xdd, 0x10, 0xb7, 0xe5, 0x89, 0x28, 0x9c, 0x3d, 0xdb, 0x0c, 0xfd, 0x23, 0xb0, 0xa5, 0xd3, 0x2a,
-	0xfc, 0x8e, 0x0b, 0xbf, 0x37, 0x77, 0x58, 0xee, 0x70, 0x0c, 0x91, 0x59, 0xc8, 0x1a, 0xb7, 0xb7,
-	0x6a, 0xb9, 0x32, 0x4c, 0xfe, 0x6d, 0xc0, 0x27, 0x29, 0x5e, 0x49, 0x43, 0xda, 0x89, 0x70, 0xa6,
-	0x48, 0x57, 0xf5, 0x58, 0xc1, 0x2d, 0x63, 0xd9, 0x42, 0x94, 0x5c, 0xa3, 0x22, 0x5b, 0x08, 0xaf,
-	0x6f, 0xe8, 0x81, 0x73, 0x51, 0xaf, 0x52, 0x73, 0xa3, 0x4a, 0xfb, 0xd0, 0x24, 0x9a, 0x38, 0xe1,
-	0x5a, 0xa9, 0x1d, 0xb2, 0xef, 0x60, 0x77, 0x84, 0x02, 0x35, 0x27, 0x34, 0xd9, 0x4c, 0xd2, 0x38,
-	0x6e, 0xf5, 0x9a, 0xfd, 0x28, 0xbd, 0xbb, 0x44, 0x7f, 0x97, 0x34, 0x66, 0x07, 0x10, 0xda, 0xbe,
-	0xa8, 0x6c, 0xd0, 0x1d, 0x17, 0xd4, 0xf5, 0x49, 0x75, 0x2e, 0x6c, 0xf3, 0x71, 0x91, 0x4b, 0x15,
-	0xb7, 0x7b, 0x41, 0x3f, 0x4c, 0xfd, 0x84, 0x7d, 0x05, 0x20, 0x8a, 0x99, 0x32, 0xa4, 0x91, 0xe7,
-	0x71, 0xe8, 0x96, 0xd6, 0x10, 0xd6, 0x83, 0x8e, 0x0b, 0x70, 0xf6, 0xa6, 0x94, 0xba, 0x8a, 0x23,
-	0xa7, 0xf5, 0x3a, 0x64, 0x13, 0x11, 0xca, 0x64, 0x8a, 0xe7, 0x68, 0x62, 0x70, 0xa4, 0x42, 0xa1,
-	0xcc, 0x85, 0x9d, 0xb3, 0x1f, 0x60, 0x4f, 0xe3, 0x6b, 0x69, 0x6c, 0xaf, 0xcd, 0xeb, 0xdb, 0x71,
-	0x21, 0x76, 0x17, 0xb0, 0x2f, 0x6d, 0xf2, 0x57, 0x03, 0xee, 0xbf, 0xa5, 0xfb, 0xaf, 0xdc, 0x5c,
-	0xb3, 0x2f, 0xea, 0xda, 0x5b, 0x82, 0xdb, 0x34, 0x0e, 0xb7, 0x69, 0x1c, 0xde, 0xac, 0x71, 0xf8,
-	0x6e, 0x8d, 0xed, 0xe2, 0x7b, 0x34, 0x0e, 0x3f, 0x82, 0xc6, 0xe1, 0x56, 0x8d, 0x5d, 0x22, 0x0b,
-	0x8d, 0x93, 0x97, 0x70, 0x6f, 0x53, 0x39, 0x89, 0x86, 0x3d, 0xdd, 0xbc, 0x49, 0xbe, 0xae, 0x77,
-	0xec, 0x5b, 0x6a, 0xaf, 0xae, 0x94, 0x87, 0xd0, 0xb1, 0x47, 0x49, 0x8e, 0xe4, 0x90, 0x93, 0xbb,
-	0x50, 0x04, 0xea, 0x6c, 0x50, 0x11, 0xfa, 0x0a, 0x74, 0xd3, 0x50, 0xa0, 0x3e, 0xb5, 0xf3, 0xe4,
-	0x0f, 0x88, 0x5e, 0x4e, 0x07, 0x13, 0x39, 0x7c, 0x81, 0x15, 0xfb, 0x12, 0xa0, 0xbc, 0x96, 0x6f,
-	0x6a, 0xa6, 0x91, 0x45, 0x9c, 0xad, 0x95, 0xfc, 0x7a, 0x79, 0x14, 0xec, 0xd0, 0x86, 0x5e, 0x1d,
-	0xe4, 0xa6, 0xeb, 0x8c, 0x50, 0xcd, 0x4f, 0x70, 0xf2, 0x4f, 0x00, 0x3b, 0xa7, 0x53, 0x25, 0x26,
-	0xc8, 0xbe, 0x87, 0x3d, 0xd2, 0x53, 0x43, 0x99, 0x28, 0x72, 0x2e, 0xd5, 0xea, 0x66, 0xbb, 0xeb,
-	0xe0, 0xe7, 0x0e, 0x3d, 0x17, 0xec, 0x18, 0x42, 0x5d, 0x14, 0x94, 0x0d, 0xb9, 0x89, 0x1b, 0x2e,
-	0xeb, 0x83, 0x7a, 0xd6, 0x6b, 0x79, 0xa5, 0x6d, 0x6b, 0xfa, 0x8c, 0x1b, 0x76, 0x02, 0xfb, 0xaf,
-	0x66, 0x94, 0x19, 0x79, 0xa5, 0xa4, 0xba, 0xca, 0xae, 0xb1, 0x32, 0x71, 0xd3, 0x79, 0x7f, 0x56,
-	0xf7, 0x5e, 0x66, 0x9a, 0xee, 0xbe, 0x9a, 0xd1, 0xa5, 0xb7, 0x7f, 0x81, 0x95, 0x61, 0xdf, 0x40,
-	0x57, 0xe3, 0x48, 0xa3, 0x19, 0x67, 0x63, 0xa9, 0x68, 0x7e, 0xe7, 0x75, 0xe6, 0xd8, 0x2f, 0x52,
-	0x51, 0x42, 0x00, 0x3e, 0x1b, 0xd7, 0xd6, 0x07, 0x6b, 0x4c, 0x7d, 0x57, 0x2f, 0xe9, 0xf4, 0x6f,
-	0xa0, 0xe3, 0x5b, 0xfb, 0x7d, 0xbb, 0xfa, 0x1e, 0xaf, 0xed, 0xfa, 0x5f, 0x00, 0xfb, 0xeb, 0xcf,
-	0x83, 0xdb, 0xfc, 0x9d, 0xaf, 0x80, 0x67, 0xf2, 0x01, 0xaf, 0x80, 0xe7, 0x75, 0x9b, 0x57, 0xc0,
-	0x73, 0xbb, 0xed, 0x2b, 0xe0, 0x8f, 0xe5, 0x07, 0xbc, 0x02, 0xfe, 0xa8, 0x6e, 0xbe, 0x02, 0xa7,
-	0x8f, 0xfe, 0x7c, 0x78, 0x25, 0x69, 0x3c, 0x1d, 0xd8, 0x12, 0x1e, 0xf9, 0xc3, 0x7f, 0xe4, 0xff,
-	0x09, 0xdc, 0x5f, 0xc0, 0xd1, 0xfa, 0xff, 0xc1, 0x60, 0xc7, 0x61, 0x4f, 0xfe, 0x0f, 0x00, 0x00,
-	0xff, 0xff, 0xa6, 0x57, 0x2c, 0xa4, 0x36, 0x08, 0x00, 0x00,
+	// 1162 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x6d, 0x6f, 0x1a, 0x47,
+	0x10, 0xd6, 0x19, 0x63, 0x8e, 0x01, 0x1b, 0xbc, 0x6e, 0xd2, 0x73, 0xdb, 0x34, 0xf4, 0xd4, 0x17,
+	0x94, 0x46, 0x76, 0x44, 0x52, 0xa9, 0xa9, 0x54, 0xa9, 0x4e, 0x6c, 0xa9, 0xd4, 0xaa, 0x15, 0x9d,
+	0xd3, 0x17, 0xe5, 0xcb, 0x69, 0xe1, 0x16, 0x7b, 0x6d, 0xd8, 0x43, 0xbb, 0x8b, 0xf1, 0xe5, 0xdf,
+	0xb4, 0x7f, 0x27, 0xfd, 0x49, 0xfd, 0x50, 0xed, 0xec, 0x1d, 0x70, 0x80, 0x6d, 0xfc, 0xa1, 0x9f,
+	0xd8, 0x7d, 0x76, 0x76, 0x76, 0xde, 0x9e, 0xb9, 0x01, 0x76, 0xd5, 0x90, 0x4b, 0xb6, 0xdf, 0x8d,
+	0x07, 0x83, 0x58, 0xa4, 0x3f, 0x7b, 0x43, 0x19, 0xeb, 0x98, 0x54, 0xf1, 0x68, 0xcf, 0x62, 0x7e,
+	0x09, 0x8a, 0x47, 0x83, 0xa1, 0x4e, 0xfc, 0x97, 0x50, 0x3b, 0xd0, 0x9a, 0x29, 0x4d, 0x35, 0x8f,
+	0xc5, 0x21, 0xd5, 0x94, 0x10, 0x58, 0xd7, 0xc9, 0x90, 0x79, 0x4e, 0xc3, 0x69, 0x96, 0x03, 0x5c,
+	0x1b, 0x2c, 0xa2, 0x9a, 0x7a, 0x6b, 0x0d, 0xa7, 0x59, 0x0d, 0x70, 0xed, 0xbf, 0x00, 0xf7, 0x94,
+	0xf5, 0x59, 0x57, 0xc7, 0x72, 0xe9, 0x9d, 0x8f, 0xa0, 0x78, 0x45, 0xfb, 0x23, 0x86, 0x97, 0xca,
+	0x81, 0xdd, 0xf8, 0x3f, 0x42, 0x39, 0xbb, 0xa5, 0xc8, 0x33, 0x28, 0x31, 0xa1, 0x25, 0x67, 0xca,
+	0x73, 0x1a, 0x85, 0x66, 0xa5, 0xf5, 0x70, 0x6f, 0xd6, 0xcc, 0xbd, 0x4c, 0x32, 0xc8, 0xc4, 0xfc,
+	0x0f, 0x6b, 0x50, 0xb5, 0x06, 0xb3, 0xe8, 0x24, 0x8e, 0x18, 0xf9, 0x14, 0xca, 0x6a, 0xc8, 0x7b,
+	0x3d, 0x16, 0xf2, 0x28, 0x7d, 0xde, 0xb5, 0x40, 0x3b, 0x22, 0x2d, 0x78, 0x40, 0xa7, 0xde, 0x85,
+	0xc6, 0xec, 0x10, 0xed, 0xb4, 0x26, 0xed, 0xd0, 0xbc, 0xeb, 0x6f, 0x8d, 0xd9, 0x4f, 0x81, 0x74,
+	0x99, 0xd4, 0xa1, 0x62, 0x92, 0xd3, 0x7e, 0x28, 0x46, 0x83, 0x0e, 0x93, 0x5e, 0x01, 0x2f, 0xd4,
+	0xcd, 0xc9, 0x29, 0x1e, 0x9c, 0x20, 0x4e, 0xbe, 0x84, 0x2d, 0x94, 0x16, 0xb1, 0x0e, 0x69, 0x4f,
+	0x33, 0xe9, 0xad, 0x37, 0x9c, 0x66, 0x21, 0xa8, 0x1a, 0xf4, 0x24, 0xd6, 0x07, 0x06, 0x23, 0xcf,
+	0xe1, 0xa1, 0x60, 0xe3, 0x70, 0x89, 0xde, 0xa2, 0x35, 0x44, 0xb0, 0xf1, 0xeb, 0x79, 0xd5, 0xdf,
+	0x02, 0x99, 0x5c, 0x9a, 0xaa, 0xdf, 0x40, 0xf5, 0xb5, 0xf4, 0xc2, 0xe4, 0x85, 0x17, 0x50, 0x56,
+	0x59, 0x58, 0xbd, 0xd2, 0xad, 0xb1, 0x9c, 0x0a, 0xfa, 0x1f, 0x36, 0x60, 0x3b, 0x60, 0x67, 0x5c,
+	0x69, 0x89, 0x41, 0x38, 0x12, 0x5a, 0x26, 0x79, 0x5d, 0xce, 0x8a, 0xba, 0x4c, 0x22, 0x86, 0x54,
+	0x32, 0xa1, 0x4d, 0x22, 0x6c, 0x7c, 0x5d, 0x0b, 0xb4, 0xa3, 0x7c, 0x96, 0x0a, 0x73, 0x59, 0xaa,
+	0x43, 0x41, 0xeb, 0x3e, 0x06, 0xae, 0x18, 0x98, 0x25, 0xf9, 0x0a, 0xb6, 0x7a, 0x2c, 0x62, 0x92,
+	0x6a, 0xa6, 0xc2, 0x31, 0xd7, 0xe7, 0x5e, 0xb1, 0x51, 0x68, 0x96, 0x83, 0xcd, 0x09, 0xfa, 0x07,
+	0xd7, 0xe7, 0x64, 0x17, 0x5c, 0x53, 0x17, 0x89, 0x51, 0xba, 0x81, 0x4a, 0xb1, 0x4e, 0x92, 0x76,
+	0x64, 0x8a, 0x8f, 0x46, 0x03, 0x2e, 0xbc, 0x52, 0xc3, 0x69, 0xba, 0x81, 0xdd, 0x90, 0xcf, 0x01,
+	0xa2, 0x78, 0x2c, 0x94, 0x96, 0x8c, 0x0e, 0x3c, 0x17, 0x8f, 0x66, 0x10, 0xd2, 0x80, 0x0a, 0x2a,
+	0x38, 0xba, 0x1e, 0x72, 0x99, 0x78, 0x65, 0x8c, 0xf5, 0x2c, 0x64, 0x1c, 0x89, 0x84, 0x0a, 0x05,
+	0x1d, 0x30, 0xe5, 0x01, 0x1a, 0xe5, 0x46, 0x42, 0x9d, 0x98, 0x3d, 0xf9, 0x06, 0x6a, 0x92, 0x5d,
+	0x71, 0x65, 0x6a, 0x2d, 0xcd, 0x6f, 0x05, 0x55, 0x6c, 0x65, 0x70, 0x9a, 0xda, 0x77, 0x50, 0xbb,
+	0x18, 0xeb, 0x50, 0x5d, 0xf1, 0x28, 0xec, 0xf6, 0x29, 0x1f, 0x28, 0xaf, 0x8a, 0x71, 0x6e, 0xe5,
+	0xe3, 0xbc, 0x90, 0x9b, 0xbd, 0x5f, 0xc6, 0xfa, 0xf4, 0x8a, 0x47, 0xaf, 0xf1, 0x12, 0x42, 0xc1,
+	0xe6, 0xc5, 0x2c, 0x46, 0x9e, 0xc0, 0xf6, 0x44, 0x37, 0x1d, 0x45, 0x9c, 0x89, 0x2e, 0xf3, 0x36,
+	0xd1, 0xd2, 0x5a, 0x2a, 0x79, 0x90, 0xc2, 0xa6, 0xc4, 0xae, 0xbf, 0x7b, 0xf6, 0xd2, 0x0a, 0x5f,
+	0xb2, 0xc4, 0x92, 0x63, 0x0b, 0x43, 0x59, 0x33, 0x27, 0x46, 0xfa, 0x98, 0x25, 0x19, 0x31, 0x32,
+	0xd7, 0x43, 0xcd, 0x06, 0xc3, 0xbe, 0xc9, 0x83, 0x57, 0x43, 0xcd, 0xf5, 0x34, 0x06, 0x6f, 0x33,
+	0x9c, 0xb4, 0x61, 0x7b, 0xaa, 0x5a, 0x8d, 0x3a, 0x17, 0xac, 0xab, 0xbd, 0x7a, 0xc3, 0x69, 0x56,
+	0x5a, 0x8f, 0xf2, 0x4e, 0xfe, 0x69, 0xde, 0xf9, 0xbd, 0x7d, 0x78, 0x6a, 0x85, 0xa6, 0x0f, 0xa7,
+	0x80, 0x69, 0x2e, 0xe7, 0x5c, 0x68, 0x6f, 0xdb, 0x36, 0x17, 0xb3, 0x26, 0xfb, 0xb0, 0x93, 0x95,
+	0x5e, 0xc8, 0xae, 0x87, 0x92, 0x29, 0x13, 0x5e, 0x8f, 0xa0, 0x08, 0xc9, 0x8e, 0x8e, 0x26, 0x27,
+	0xe4, 0x31, 0x54, 0x32, 0xa3, 0x4d, 0xb9, 0xec, 0xa0, 0x20, 0x64, 0x50, 0x3b, 0xfa, 0xe4, 0x27,
+	0x20, 0x8b, 0xc1, 0x35, 0xb5, 0x79, 0xc9, 0x92, 0xb4, 0xb1, 0x98, 0xe5, 0xf2, 0xb6, 0xf6, 0xc3,
+	0xda, 0xf7, 0x8e, 0xff, 0x97, 0x03, 0xb5, 0x39, 0x67, 0xcc, 0xb3, 0xd6, 0x4d, 0x8c, 0x5b, 0xaa,
+	0x07, 0x2c, 0x64, 0x02, 0x46, 0x7c, 0xa8, 0xc6, 0xf2, 0x8c, 0x0a, 0xfe, 0x1e, 0xb3, 0xec, 0xad,
+	0x61, 0x3c, 0x73, 0x98, 0x71, 0x76, 0x76, 0x4f, 0xfb, 0xe1, 0x48, 0x70, 0xed, 0x15, 0x50, 0x94,
+	0xe4, 0x8f, 0x7e, 0x13, 0x5c, 0x13, 0x0f, 0x4a, 0xdd, 0x78, 0x64, 0x1c, 0xf0, 0xd6, 0x51, 0x28,
+	0xdb, 0xfa, 0x7f, 0x17, 0xe1, 0xc1, 0x42, 0x55, 0xfd, 0x4a, 0xd5, 0x25, 0xf9, 0x2c, 0xcf, 0x7a,
+	0x43, 0x8d, 0xdb, 0xd8, 0xed, 0xde, 0xc6, 0x6e, 0x77, 0x39, 0xbb, 0xdd, 0x9b, 0xd9, 0x6d, 0x0e,
+	0xef, 0x60, 0xb7, 0xfb, 0x3f, 0xb0, 0xdb, 0xbd, 0x95, 0xdd, 0xe8, 0xc8, 0x5d, 0xec, 0x76, 0x17,
+	0xd8, 0xfd, 0xf5, 0x32, 0x76, 0xa3, 0x83, 0x2b, 0x31, 0xd5, 0x48, 0xde, 0x83, 0xa9, 0xee, 0xea,
+	0x4c, 0x35, 0xc2, 0x8b, 0x4c, 0x7d, 0x72, 0x13, 0x53, 0xdd, 0xdb, 0xa9, 0xe8, 0xde, 0x4d, 0x45,
+	0x77, 0x55, 0x2a, 0xba, 0xb3, 0x54, 0xf4, 0xdf, 0xc0, 0xce, 0x7c, 0x8d, 0x72, 0xa6, 0xc8, 0xcb,
+	0xf9, 0x69, 0xe1, 0xf1, 0x1d, 0xdd, 0x72, 0x3a, 0x36, 0x1c, 0x43, 0xc5, 0x7c, 0x2e, 0x79, 0x8f,
+	0x77, 0xa9, 0xc6, 0xa1, 0x21, 0x62, 0x32, 0xec, 0x24, 0x9a, 0xd9, 0x5a, 0xaf, 0x06, 0x6e, 0xc4,
+	0xe4, 0x2b, 0xb3, 0x47, 0xf3, 0x28, 0x17, 0x9a, 0x61, 0xa0, 0xd3, 0x62, 0x87, 0x14, 0x3a, 0x66,
+	0x89, 0xff, 0x1e, 0xca, 0x6f, 0x46, 0x9d, 0x3e, 0xef, 0x1e, 0xb3, 0x84, 0x3c, 0x02, 0x18, 0x5e,
+	0xf2, 0xeb, 0x9c, 0xae, 0xb2, 0x41, 0xac, 0x32, 0xd3, 0x3f, 0x26, 0xdf, 0x43, 0xb3, 0x34, 0x6f,
+	0x4f, 0xbf, 0xe6, 0x05, 0xfc, 0x3c, 0xb8, 0x22, 0xfb, 0x8c, 0xcf, 0xbd, 0xbd, 0xbe, 0xf0, 0xf6,
+	0x3f, 0x0e, 0x6c, 0xbc, 0x1a, 0x89, 0xa8, 0xcf, 0x4c, 0x99, 0x69, 0x39, 0x52, 0x3a, 0x8c, 0xe2,
+	0x01, 0xe5, 0x62, 0x3a, 0xff, 0x6c, 0x22, 0x7c, 0x88, 0x68, 0x3b, 0x22, 0x2f, 0xc0, 0x95, 0x71,
+	0xac, 0xc3, 0x2e, 0x55, 0xd8, 0x5d, 0x2a, 0xad, 0xdd, 0x7c, 0xdc, 0x66, 0x22, 0x13, 0x94, 0x8c,
+	0xe8, 0x6b, 0xaa, 0xc8, 0x01, 0xd4, 0xb1, 0x38, 0xf9, 0x99, 0xe0, 0xe2, 0xcc, 0x58, 0xa3, 0xb0,
+	0xe1, 0x54, 0x5a, 0x1f, 0xe7, 0x6f, 0x4f, 0x42, 0x11, 0x6c, 0x99, 0xa2, 0xb5, 0xf2, 0xc7, 0x2c,
+	0x51, 0xe4, 0x0b, 0xa8, 0x4a, 0xd6, 0x93, 0x4c, 0x9d, 0x87, 0x58, 0x34, 0x76, 0x32, 0xaa, 0xa4,
+	0xd8, 0xcf, 0x5c, 0x68, 0x5f, 0x03, 0x58, 0x6f, 0xb0, 0x05, 0xed, 0xce, 0x58, 0x6a, 0x3b, 0xd0,
+	0xc4, 0x9c, 0xe6, 0x12, 0x73, 0x6c, 0x66, 0xee, 0x7a, 0xd5, 0xf6, 0xa3, 0xdc, 0xab, 0xff, 0x3a,
+	0x50, 0x9f, 0x1d, 0x22, 0xf1, 0xf1, 0x1b, 0x67, 0x45, 0x6b, 0xc9, 0x3d, 0x66, 0x45, 0x6b, 0xd7,
+	0x2a, 0xb3, 0xa2, 0xb5, 0x6d, 0xd5, 0x59, 0xd1, 0x56, 0xc3, 0x3d, 0x66, 0x45, 0xdb, 0x56, 0xe7,
+	0x67, 0xc5, 0x57, 0x4f, 0xdf, 0x3d, 0x39, 0xe3, 0xfa, 0x7c, 0xd4, 0x31, 0x29, 0xdc, 0xb7, 0x8d,
+	0x7a, 0xdf, 0xfe, 0x73, 0xc0, 0xff, 0x0a, 0xfb, 0xb3, 0xff, 0x22, 0x3a, 0x1b, 0x88, 0x3d, 0xff,
+	0x6f, 0x00, 0x5c, 0x6d, 0x49, 0x5d, 0x5c, 0x0c, 0x00, 0x00,
 }
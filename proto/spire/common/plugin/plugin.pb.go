@@ -24,7 +24,7 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-//* Represents the plugin-specific configuration string.
+// * Represents the plugin-specific configuration string.
 type ConfigureRequest struct {
 	//* The configuration for the plugin.
 	Configuration string `protobuf:"bytes,1,opt,name=configuration,proto3" json:"configuration,omitempty"`
@@ -74,9 +74,11 @@ func (m *ConfigureRequest) GetGlobalConfig() *ConfigureRequest_GlobalConfig {
 	return nil
 }
 
-//* Global configuration nested type.
+// * Global configuration nested type.
 type ConfigureRequest_GlobalConfig struct {
-	TrustDomain          string   `protobuf:"bytes,1,opt,name=trustDomain,proto3" json:"trustDomain,omitempty"`
+	TrustDomain string `protobuf:"bytes,1,opt,name=trustDomain,proto3" json:"trustDomain,omitempty"`
+	//* Clock skew tolerated when validating timestamps, in seconds
+	ClockSkewTolerance   int64    `protobuf:"varint,2,opt,name=clockSkewTolerance,proto3" json:"clockSkewTolerance,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -114,8 +116,15 @@ func (m *ConfigureRequest_GlobalConfig) GetTrustDomain() string {
 	return ""
 }
 
-//* Represents a list of configuration problems
-//found in the configuration string.
+func (m *ConfigureRequest_GlobalConfig) GetClockSkewTolerance() int64 {
+	if m != nil {
+		return m.ClockSkewTolerance
+	}
+	return 0
+}
+
+// * Represents a list of configuration problems
+// found in the configuration string.
 type ConfigureResponse struct {
 	//* A list of errors
 	ErrorList            []string `protobuf:"bytes,1,rep,name=errorList,proto3" json:"errorList,omitempty"`
@@ -156,7 +165,7 @@ func (m *ConfigureResponse) GetErrorList() []string {
 	return nil
 }
 
-//* Represents an empty request.
+// * Represents an empty request.
 type GetPluginInfoRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -188,7 +197,7 @@ func (m *GetPluginInfoRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetPluginInfoRequest proto.InternalMessageInfo
 
-//* Represents the plugin metadata.
+// * Represents the plugin metadata.
 type GetPluginInfoResponse struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Category             string   `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
@@ -385,35 +394,36 @@ func init() {
 }
 
 var fileDescriptor_bcbbaf1bc59558c6 = []byte{
-	// 435 bytes of a gzipped FileDescriptorProto
+	// 453 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x96, 0xdb, 0x90, 0xd6, 0x93, 0x94, 0x9f, 0x05, 0x2a, 0x2b, 0xe2, 0x60, 0x0c, 0x12, 0x39,
-	0xd9, 0x34, 0x3d, 0x70, 0xa7, 0x48, 0x55, 0x05, 0x07, 0x64, 0x24, 0x24, 0xb8, 0xa0, 0x8d, 0x3b,
-	0x71, 0x56, 0x8a, 0x3d, 0x66, 0x77, 0x5c, 0x29, 0x4f, 0xc0, 0x7b, 0xf1, 0x64, 0xc8, 0xbb, 0x6b,
-	0xea, 0x44, 0x51, 0x4f, 0xde, 0xf9, 0x7e, 0xf6, 0x9b, 0x1d, 0xef, 0x42, 0x6c, 0x1a, 0xa5, 0x31,
-	0x2b, 0xa8, 0xaa, 0xa8, 0xce, 0x9a, 0x4d, 0x5b, 0xaa, 0xfe, 0x93, 0x36, 0x9a, 0x98, 0xc4, 0x73,
-	0xab, 0x48, 0x9d, 0x22, 0x75, 0x54, 0xf2, 0x37, 0x80, 0xa7, 0x57, 0x54, 0xaf, 0x54, 0xd9, 0x6a,
-	0xcc, 0xf1, 0x77, 0x8b, 0x86, 0xc5, 0x5b, 0x38, 0x2b, 0x3c, 0x26, 0x59, 0x51, 0x1d, 0x05, 0x71,
-	0x30, 0x0f, 0xf3, 0x5d, 0x50, 0x7c, 0x87, 0x69, 0xb9, 0xa1, 0xa5, 0xdc, 0x38, 0x7f, 0x74, 0x14,
-	0x07, 0xf3, 0xc9, 0x62, 0x91, 0x1e, 0x88, 0x49, 0xf7, 0x23, 0xd2, 0xeb, 0x81, 0x33, 0xdf, 0xd9,
-	0x67, 0xf6, 0x1e, 0xa6, 0x43, 0x56, 0xc4, 0x30, 0x61, 0xdd, 0x1a, 0xfe, 0x44, 0x95, 0x54, 0x7d,
-	0x2f, 0x43, 0x28, 0xb9, 0x80, 0x67, 0x83, 0x00, 0xd3, 0x50, 0x6d, 0x50, 0xbc, 0x82, 0x10, 0xb5,
-	0x26, 0xfd, 0x45, 0x19, 0x8e, 0x82, 0xf8, 0x78, 0x1e, 0xe6, 0xf7, 0x40, 0x72, 0x0e, 0x2f, 0xae,
-	0x91, 0xbf, 0xda, 0xee, 0x6e, 0xea, 0x15, 0xf9, 0xbe, 0x92, 0x3f, 0x47, 0xf0, 0x72, 0x8f, 0xf0,
-	0xfb, 0x09, 0x18, 0xd5, 0xb2, 0x42, 0x9f, 0x6f, 0xd7, 0x62, 0x06, 0xa7, 0x85, 0x64, 0x2c, 0x49,
-	0x6f, 0xed, 0xf1, 0xc3, 0xfc, 0x7f, 0xdd, 0xe9, 0x79, 0xdb, 0x60, 0x74, 0xec, 0xf4, 0xdd, 0xba,
-	0x3b, 0xca, 0x2d, 0x9a, 0x42, 0xab, 0xc6, 0x8e, 0x75, 0xe4, 0x8e, 0x32, 0x80, 0xac, 0x42, 0x32,
-	0x5e, 0x69, 0x94, 0x8c, 0xb7, 0xd1, 0x23, 0xaf, 0xb8, 0x87, 0xba, 0xcc, 0x0d, 0x15, 0xee, 0xbf,
-	0x8c, 0x5d, 0x66, 0x5f, 0x8b, 0x08, 0x4e, 0xee, 0x50, 0x9b, 0x8e, 0x3a, 0xb1, 0x54, 0x5f, 0x8a,
-	0x73, 0x18, 0xcb, 0x96, 0xd7, 0xa4, 0xa3, 0x53, 0x4b, 0xf8, 0xaa, 0x73, 0x14, 0x54, 0x35, 0xb2,
-	0xde, 0x46, 0xa1, 0x73, 0xf8, 0x32, 0x59, 0xc0, 0xe4, 0xa6, 0x56, 0xdc, 0xdf, 0x89, 0x37, 0x70,
-	0xb6, 0x26, 0xc3, 0xbf, 0x0c, 0xea, 0x3b, 0x55, 0xa0, 0xf1, 0x23, 0x9d, 0x76, 0xe0, 0x37, 0x8f,
-	0x25, 0x1f, 0x60, 0xea, 0x3c, 0x7e, 0x66, 0xef, 0xe0, 0x89, 0xbb, 0x00, 0xfb, 0xb6, 0xc7, 0x0e,
-	0xee, 0x8d, 0x8b, 0x1f, 0x00, 0xfd, 0xc8, 0x15, 0x8b, 0xcf, 0x30, 0xb2, 0xdf, 0xf8, 0xe0, 0x5d,
-	0x1a, 0x74, 0x35, 0x7b, 0xfd, 0x80, 0xc2, 0xf5, 0xf0, 0xf1, 0xf2, 0xe7, 0x45, 0xa9, 0x78, 0xdd,
-	0x2e, 0x3b, 0x51, 0x66, 0x1a, 0xb5, 0x5a, 0x61, 0xe6, 0x1e, 0x8b, 0x7d, 0x17, 0xd9, 0x81, 0x87,
-	0xb3, 0x1c, 0x5b, 0xea, 0xf2, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xb3, 0xfd, 0x3d, 0x41, 0x56,
-	0x03, 0x00, 0x00,
+	0x10, 0x96, 0x9b, 0x90, 0xd6, 0x93, 0x94, 0x9f, 0x05, 0x2a, 0x2b, 0xe2, 0x60, 0x0c, 0x12, 0x39,
+	0xd9, 0x6a, 0x7a, 0xe0, 0x4e, 0x91, 0xaa, 0x0a, 0x0e, 0xc8, 0x45, 0x48, 0x70, 0x81, 0xcd, 0x76,
+	0xe2, 0xac, 0x6a, 0x7b, 0xcc, 0xee, 0xba, 0x28, 0x4f, 0xc0, 0xfb, 0xf2, 0x04, 0xc8, 0xbb, 0x6b,
+	0xea, 0x46, 0x11, 0x27, 0x7b, 0xbe, 0x9f, 0x9d, 0x6f, 0xf6, 0x07, 0x62, 0xdd, 0x48, 0x85, 0x99,
+	0xa0, 0xaa, 0xa2, 0x3a, 0x6b, 0xca, 0xb6, 0x90, 0xfd, 0x27, 0x6d, 0x14, 0x19, 0x62, 0x4f, 0xad,
+	0x22, 0x75, 0x8a, 0xd4, 0x51, 0xc9, 0x9f, 0x00, 0x1e, 0x9f, 0x53, 0xbd, 0x96, 0x45, 0xab, 0x30,
+	0xc7, 0x9f, 0x2d, 0x6a, 0xc3, 0x5e, 0xc3, 0xb1, 0xf0, 0x18, 0x37, 0x92, 0xea, 0x28, 0x88, 0x83,
+	0x45, 0x98, 0xdf, 0x07, 0xd9, 0x17, 0x98, 0x15, 0x25, 0xad, 0x78, 0xe9, 0xfc, 0xd1, 0x41, 0x1c,
+	0x2c, 0xa6, 0xcb, 0x65, 0xba, 0xa7, 0x4d, 0xba, 0xdb, 0x22, 0xbd, 0x18, 0x38, 0xf3, 0x7b, 0xeb,
+	0xcc, 0x7f, 0xc0, 0x6c, 0xc8, 0xb2, 0x18, 0xa6, 0x46, 0xb5, 0xda, 0xbc, 0xa7, 0x8a, 0xcb, 0x3e,
+	0xcb, 0x10, 0x62, 0x29, 0x30, 0x51, 0x92, 0xb8, 0xb9, 0xba, 0xc1, 0x5f, 0x9f, 0xa9, 0x44, 0xc5,
+	0x6b, 0x81, 0x36, 0xcf, 0x28, 0xdf, 0xc3, 0x24, 0xa7, 0xf0, 0x64, 0x10, 0x48, 0x37, 0x54, 0x6b,
+	0x64, 0x2f, 0x20, 0x44, 0xa5, 0x48, 0x7d, 0x94, 0xda, 0x44, 0x41, 0x3c, 0x5a, 0x84, 0xf9, 0x1d,
+	0x90, 0x9c, 0xc0, 0xb3, 0x0b, 0x34, 0x9f, 0xec, 0x34, 0x97, 0xf5, 0x9a, 0xfc, 0x1c, 0xc9, 0xef,
+	0x03, 0x78, 0xbe, 0x43, 0xf8, 0xf5, 0x18, 0x8c, 0x6b, 0x5e, 0xa1, 0xcf, 0x6b, 0xff, 0xd9, 0x1c,
+	0x8e, 0x04, 0x37, 0x58, 0x90, 0xda, 0xda, 0x78, 0x61, 0xfe, 0xaf, 0xee, 0xf4, 0x66, 0xdb, 0x60,
+	0x34, 0x72, 0xfa, 0xee, 0xbf, 0x1b, 0xfd, 0x1a, 0xb5, 0x50, 0xb2, 0xb1, 0xc7, 0x30, 0x76, 0xa3,
+	0x0f, 0x20, 0xab, 0xe0, 0x06, 0xcf, 0x15, 0x72, 0x83, 0xd7, 0xd1, 0x03, 0xaf, 0xb8, 0x83, 0xba,
+	0x9e, 0x25, 0x09, 0x77, 0x8e, 0x13, 0xd7, 0xb3, 0xaf, 0x59, 0x04, 0x87, 0xb7, 0xa8, 0x74, 0x47,
+	0x1d, 0x5a, 0xaa, 0x2f, 0xd9, 0x09, 0x4c, 0x78, 0x6b, 0x36, 0xa4, 0xa2, 0x23, 0x4b, 0xf8, 0xaa,
+	0x73, 0x08, 0xaa, 0x1a, 0x5e, 0x6f, 0xa3, 0xd0, 0x39, 0x7c, 0x99, 0x2c, 0x61, 0x7a, 0x59, 0x4b,
+	0xd3, 0xdf, 0xa1, 0x57, 0x70, 0xbc, 0x21, 0x6d, 0xbe, 0x6b, 0x54, 0xb7, 0x52, 0xa0, 0xf6, 0x5b,
+	0x3a, 0xeb, 0xc0, 0x2b, 0x8f, 0x25, 0x6f, 0x61, 0xe6, 0x3c, 0x7e, 0xcf, 0xde, 0xc0, 0x23, 0x77,
+	0x61, 0x76, 0x6d, 0x0f, 0x1d, 0xdc, 0x1b, 0x97, 0x5f, 0x01, 0xfa, 0x2d, 0x97, 0x86, 0x7d, 0x80,
+	0xb1, 0xfd, 0xc6, 0x7b, 0xef, 0xde, 0x20, 0xd5, 0xfc, 0xe5, 0x7f, 0x14, 0x2e, 0xc3, 0xbb, 0xb3,
+	0x6f, 0xa7, 0x85, 0x34, 0x9b, 0x76, 0xd5, 0x89, 0x32, 0xdd, 0xc8, 0xf5, 0x1a, 0x33, 0xf7, 0xb8,
+	0xec, 0x3b, 0xca, 0xf6, 0x3c, 0xb4, 0xd5, 0xc4, 0x52, 0x67, 0x7f, 0x07, 0x00, 0x34, 0x43, 0xf2,
+	0x4f, 0x86, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
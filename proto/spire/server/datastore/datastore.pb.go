@@ -1225,9 +1225,13 @@ func (m *FetchAttestedNodeResponse) GetNode() *common.AttestedNode {
 }
 
 type CountAttestedNodesRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ByAttestationType    string               `protobuf:"bytes,1,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	ByBanned             *wrappers.BoolValue  `protobuf:"bytes,2,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
+	ByExpiresBefore      *wrappers.Int64Value `protobuf:"bytes,3,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	ByExpiresAfter       *wrappers.Int64Value `protobuf:"bytes,4,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *CountAttestedNodesRequest) Reset()         { *m = CountAttestedNodesRequest{} }
@@ -1255,6 +1259,34 @@ func (m *CountAttestedNodesRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CountAttestedNodesRequest proto.InternalMessageInfo
 
+func (m *CountAttestedNodesRequest) GetByAttestationType() string {
+	if m != nil {
+		return m.ByAttestationType
+	}
+	return ""
+}
+
+func (m *CountAttestedNodesRequest) GetByBanned() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByBanned
+	}
+	return nil
+}
+
+func (m *CountAttestedNodesRequest) GetByExpiresBefore() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresBefore
+	}
+	return nil
+}
+
+func (m *CountAttestedNodesRequest) GetByExpiresAfter() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return nil
+}
+
 type CountAttestedNodesResponse struct {
 	Nodes                int32    `protobuf:"varint,1,opt,name=nodes,proto3" json:"nodes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1340,6 +1372,7 @@ type ListAttestedNodesRequest struct {
 	BySelectorMatch      *BySelectors         `protobuf:"bytes,4,opt,name=by_selector_match,json=bySelectorMatch,proto3" json:"by_selector_match,omitempty"`
 	ByBanned             *wrappers.BoolValue  `protobuf:"bytes,5,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
 	FetchSelectors       bool                 `protobuf:"varint,6,opt,name=fetch_selectors,json=fetchSelectors,proto3" json:"fetch_selectors,omitempty"`
+	ByExpiresAfter       *wrappers.Int64Value `protobuf:"bytes,7,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -1412,6 +1445,13 @@ func (m *ListAttestedNodesRequest) GetFetchSelectors() bool {
 	return false
 }
 
+func (m *ListAttestedNodesRequest) GetByExpiresAfter() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return nil
+}
+
 type ListAttestedNodesResponse struct {
 	Nodes                []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
 	Pagination           *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
@@ -2332,10 +2372,20 @@ type JoinToken struct {
 	// Token value
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	// Expiration in seconds since unix epoch
-	Expiry               int64    `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Expiry int64 `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// Number of times the token can be used. Zero means the token can be
+	// used once.
+	MaxUses int32 `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// Number of times the token has been used
+	Uses int32 `protobuf:"varint,4,opt,name=uses,proto3" json:"uses,omitempty"`
+	// CIDRs of the addresses allowed to use the token. Empty means any
+	// address is allowed.
+	AllowedCidrs []string `protobuf:"bytes,5,rep,name=allowed_cidrs,json=allowedCidrs,proto3" json:"allowed_cidrs,omitempty"`
+	// Selectors assigned to the agents attesting with the token
+	Selectors            []*common.Selector `protobuf:"bytes,6,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *JoinToken) Reset()         { *m = JoinToken{} }
@@ -2377,6 +2427,34 @@ func (m *JoinToken) GetExpiry() int64 {
 	return 0
 }
 
+func (m *JoinToken) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *JoinToken) GetUses() int32 {
+	if m != nil {
+		return m.Uses
+	}
+	return 0
+}
+
+func (m *JoinToken) GetAllowedCidrs() []string {
+	if m != nil {
+		return m.AllowedCidrs
+	}
+	return nil
+}
+
+func (m *JoinToken) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
 type CreateJoinTokenRequest struct {
 	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -2611,6 +2689,86 @@ func (m *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
 	return nil
 }
 
+type UseJoinTokenRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UseJoinTokenRequest) Reset()         { *m = UseJoinTokenRequest{} }
+func (m *UseJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*UseJoinTokenRequest) ProtoMessage()    {}
+func (*UseJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{60}
+}
+
+func (m *UseJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UseJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *UseJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UseJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *UseJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UseJoinTokenRequest.Merge(m, src)
+}
+func (m *UseJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_UseJoinTokenRequest.Size(m)
+}
+func (m *UseJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UseJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UseJoinTokenRequest proto.InternalMessageInfo
+
+func (m *UseJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type UseJoinTokenResponse struct {
+	// The token after being used, or unset if the token does not exist or
+	// cannot be used anymore.
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UseJoinTokenResponse) Reset()         { *m = UseJoinTokenResponse{} }
+func (m *UseJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*UseJoinTokenResponse) ProtoMessage()    {}
+func (*UseJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{61}
+}
+
+func (m *UseJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UseJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *UseJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UseJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *UseJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UseJoinTokenResponse.Merge(m, src)
+}
+func (m *UseJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_UseJoinTokenResponse.Size(m)
+}
+func (m *UseJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UseJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UseJoinTokenResponse proto.InternalMessageInfo
+
+func (m *UseJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
 type PruneJoinTokensRequest struct {
 	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2622,7 +2780,7 @@ func (m *PruneJoinTokensRequest) Reset()         { *m = PruneJoinTokensRequest{}
 func (m *PruneJoinTokensRequest) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensRequest) ProtoMessage()    {}
 func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{60}
+	return fileDescriptor_4d9f80f01a852be0, []int{62}
 }
 
 func (m *PruneJoinTokensRequest) XXX_Unmarshal(b []byte) error {
@@ -2660,7 +2818,7 @@ func (m *PruneJoinTokensResponse) Reset()         { *m = PruneJoinTokensResponse
 func (m *PruneJoinTokensResponse) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensResponse) ProtoMessage()    {}
 func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{61}
+	return fileDescriptor_4d9f80f01a852be0, []int{63}
 }
 
 func (m *PruneJoinTokensResponse) XXX_Unmarshal(b []byte) error {
@@ -2681,283 +2839,1541 @@ func (m *PruneJoinTokensResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PruneJoinTokensResponse proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("spire.server.datastore.DeleteBundleRequest_Mode", DeleteBundleRequest_Mode_name, DeleteBundleRequest_Mode_value)
-	proto.RegisterEnum("spire.server.datastore.BySelectors_MatchBehavior", BySelectors_MatchBehavior_name, BySelectors_MatchBehavior_value)
-	proto.RegisterType((*CreateBundleRequest)(nil), "spire.server.datastore.CreateBundleRequest")
-	proto.RegisterType((*CreateBundleResponse)(nil), "spire.server.datastore.CreateBundleResponse")
-	proto.RegisterType((*FetchBundleRequest)(nil), "spire.server.datastore.FetchBundleRequest")
-	proto.RegisterType((*FetchBundleResponse)(nil), "spire.server.datastore.FetchBundleResponse")
-	proto.RegisterType((*CountBundlesRequest)(nil), "spire.server.datastore.CountBundlesRequest")
-	proto.RegisterType((*CountBundlesResponse)(nil), "spire.server.datastore.CountBundlesResponse")
-	proto.RegisterType((*ListBundlesRequest)(nil), "spire.server.datastore.ListBundlesRequest")
-	proto.RegisterType((*ListBundlesResponse)(nil), "spire.server.datastore.ListBundlesResponse")
-	proto.RegisterType((*UpdateBundleRequest)(nil), "spire.server.datastore.UpdateBundleRequest")
-	proto.RegisterType((*UpdateBundleResponse)(nil), "spire.server.datastore.UpdateBundleResponse")
-	proto.RegisterType((*SetBundleRequest)(nil), "spire.server.datastore.SetBundleRequest")
-	proto.RegisterType((*SetBundleResponse)(nil), "spire.server.datastore.SetBundleResponse")
-	proto.RegisterType((*AppendBundleRequest)(nil), "spire.server.datastore.AppendBundleRequest")
-	proto.RegisterType((*AppendBundleResponse)(nil), "spire.server.datastore.AppendBundleResponse")
-	proto.RegisterType((*DeleteBundleRequest)(nil), "spire.server.datastore.DeleteBundleRequest")
-	proto.RegisterType((*DeleteBundleResponse)(nil), "spire.server.datastore.DeleteBundleResponse")
-	proto.RegisterType((*PruneBundleRequest)(nil), "spire.server.datastore.PruneBundleRequest")
-	proto.RegisterType((*PruneBundleResponse)(nil), "spire.server.datastore.PruneBundleResponse")
-	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
-	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
-	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
-	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.server.datastore.GetNodeSelectorsRequest")
-	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.server.datastore.GetNodeSelectorsResponse")
-	proto.RegisterType((*ListNodeSelectorsRequest)(nil), "spire.server.datastore.ListNodeSelectorsRequest")
-	proto.RegisterType((*ListNodeSelectorsResponse)(nil), "spire.server.datastore.ListNodeSelectorsResponse")
-	proto.RegisterType((*CreateAttestedNodeResponse)(nil), "spire.server.datastore.CreateAttestedNodeResponse")
-	proto.RegisterType((*FetchAttestedNodeRequest)(nil), "spire.server.datastore.FetchAttestedNodeRequest")
-	proto.RegisterType((*FetchAttestedNodeResponse)(nil), "spire.server.datastore.FetchAttestedNodeResponse")
-	proto.RegisterType((*CountAttestedNodesRequest)(nil), "spire.server.datastore.CountAttestedNodesRequest")
-	proto.RegisterType((*CountAttestedNodesResponse)(nil), "spire.server.datastore.CountAttestedNodesResponse")
-	proto.RegisterType((*CreateAttestedNodeRequest)(nil), "spire.server.datastore.CreateAttestedNodeRequest")
-	proto.RegisterType((*ListAttestedNodesRequest)(nil), "spire.server.datastore.ListAttestedNodesRequest")
-	proto.RegisterType((*ListAttestedNodesResponse)(nil), "spire.server.datastore.ListAttestedNodesResponse")
-	proto.RegisterType((*UpdateAttestedNodeRequest)(nil), "spire.server.datastore.UpdateAttestedNodeRequest")
-	proto.RegisterType((*UpdateAttestedNodeResponse)(nil), "spire.server.datastore.UpdateAttestedNodeResponse")
-	proto.RegisterType((*DeleteAttestedNodeRequest)(nil), "spire.server.datastore.DeleteAttestedNodeRequest")
-	proto.RegisterType((*DeleteAttestedNodeResponse)(nil), "spire.server.datastore.DeleteAttestedNodeResponse")
-	proto.RegisterType((*CreateRegistrationEntryRequest)(nil), "spire.server.datastore.CreateRegistrationEntryRequest")
-	proto.RegisterType((*CreateRegistrationEntryResponse)(nil), "spire.server.datastore.CreateRegistrationEntryResponse")
-	proto.RegisterType((*FetchRegistrationEntryRequest)(nil), "spire.server.datastore.FetchRegistrationEntryRequest")
-	proto.RegisterType((*FetchRegistrationEntryResponse)(nil), "spire.server.datastore.FetchRegistrationEntryResponse")
-	proto.RegisterType((*BySelectors)(nil), "spire.server.datastore.BySelectors")
-	proto.RegisterType((*Pagination)(nil), "spire.server.datastore.Pagination")
-	proto.RegisterType((*CountRegistrationEntriesRequest)(nil), "spire.server.datastore.CountRegistrationEntriesRequest")
-	proto.RegisterType((*CountRegistrationEntriesResponse)(nil), "spire.server.datastore.CountRegistrationEntriesResponse")
-	proto.RegisterType((*ListRegistrationEntriesRequest)(nil), "spire.server.datastore.ListRegistrationEntriesRequest")
-	proto.RegisterType((*ListRegistrationEntriesResponse)(nil), "spire.server.datastore.ListRegistrationEntriesResponse")
-	proto.RegisterType((*UpdateRegistrationEntryRequest)(nil), "spire.server.datastore.UpdateRegistrationEntryRequest")
-	proto.RegisterType((*UpdateRegistrationEntryResponse)(nil), "spire.server.datastore.UpdateRegistrationEntryResponse")
-	proto.RegisterType((*DeleteRegistrationEntryRequest)(nil), "spire.server.datastore.DeleteRegistrationEntryRequest")
-	proto.RegisterType((*DeleteRegistrationEntryResponse)(nil), "spire.server.datastore.DeleteRegistrationEntryResponse")
-	proto.RegisterType((*PruneRegistrationEntriesRequest)(nil), "spire.server.datastore.PruneRegistrationEntriesRequest")
-	proto.RegisterType((*PruneRegistrationEntriesResponse)(nil), "spire.server.datastore.PruneRegistrationEntriesResponse")
-	proto.RegisterType((*JoinToken)(nil), "spire.server.datastore.JoinToken")
-	proto.RegisterType((*CreateJoinTokenRequest)(nil), "spire.server.datastore.CreateJoinTokenRequest")
-	proto.RegisterType((*CreateJoinTokenResponse)(nil), "spire.server.datastore.CreateJoinTokenResponse")
-	proto.RegisterType((*FetchJoinTokenRequest)(nil), "spire.server.datastore.FetchJoinTokenRequest")
-	proto.RegisterType((*FetchJoinTokenResponse)(nil), "spire.server.datastore.FetchJoinTokenResponse")
-	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.server.datastore.DeleteJoinTokenRequest")
-	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.server.datastore.DeleteJoinTokenResponse")
-	proto.RegisterType((*PruneJoinTokensRequest)(nil), "spire.server.datastore.PruneJoinTokensRequest")
-	proto.RegisterType((*PruneJoinTokensResponse)(nil), "spire.server.datastore.PruneJoinTokensResponse")
+type FederationRelationship struct {
+	// Trust domain ID (e.g. spiffe://example.org) of the federated trust domain
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// URL of the bundle endpoint of the federated trust domain
+	BundleEndpointUrl string `protobuf:"bytes,2,opt,name=bundle_endpoint_url,json=bundleEndpointUrl,proto3" json:"bundle_endpoint_url,omitempty"`
+	// Bundle endpoint profile (i.e. "https_web" or "https_spiffe")
+	BundleEndpointProfile string `protobuf:"bytes,3,opt,name=bundle_endpoint_profile,json=bundleEndpointProfile,proto3" json:"bundle_endpoint_profile,omitempty"`
+	// Expected SPIFFE ID of the bundle endpoint server. Only used by the
+	// "https_spiffe" profile.
+	EndpointSpiffeId     string   `protobuf:"bytes,4,opt,name=endpoint_spiffe_id,json=endpointSpiffeId,proto3" json:"endpoint_spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("spire/server/datastore/datastore.proto", fileDescriptor_4d9f80f01a852be0)
+func (m *FederationRelationship) Reset()         { *m = FederationRelationship{} }
+func (m *FederationRelationship) String() string { return proto.CompactTextString(m) }
+func (*FederationRelationship) ProtoMessage()    {}
+func (*FederationRelationship) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{64}
 }
 
-var fileDescriptor_4d9f80f01a852be0 = []byte{
-	// 2102 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x5f, 0x73, 0xdb, 0xc6,
-	0x11, 0x2f, 0xf5, 0xcf, 0xe2, 0x52, 0x7f, 0x8f, 0x8e, 0x44, 0xd1, 0xa9, 0xe4, 0x20, 0x95, 0x9b,
-	0xc4, 0x0e, 0x29, 0x2b, 0xb6, 0x95, 0xb4, 0x6e, 0x13, 0x92, 0x62, 0x14, 0xb6, 0xb2, 0xe3, 0x01,
-	0x99, 0xd6, 0xe3, 0x4c, 0x8b, 0x00, 0xe2, 0x91, 0x42, 0x4c, 0x02, 0x28, 0x70, 0xb4, 0xcd, 0xb8,
-	0xef, 0x9d, 0x66, 0xa6, 0x33, 0xed, 0x37, 0xe8, 0x17, 0xe8, 0x63, 0xdf, 0xfb, 0x1d, 0xfa, 0x71,
-	0xfa, 0xd2, 0xc1, 0xdd, 0x81, 0x00, 0x88, 0x3b, 0xf0, 0x8f, 0xf4, 0x64, 0x61, 0x6f, 0xff, 0xfc,
-	0xf6, 0x6e, 0x6f, 0x6f, 0x77, 0x4d, 0xb8, 0xe3, 0x39, 0xa6, 0x8b, 0xcb, 0x1e, 0x76, 0x5f, 0x61,
-	0xb7, 0xdc, 0xd6, 0x89, 0xee, 0x11, 0xdb, 0xc5, 0xe1, 0x5f, 0x25, 0xc7, 0xb5, 0x89, 0x8d, 0x76,
-	0x28, 0x5f, 0x89, 0xf1, 0x95, 0x46, 0xab, 0xc5, 0x83, 0xae, 0x6d, 0x77, 0x7b, 0xb8, 0x4c, 0xb9,
-	0x8c, 0x41, 0xa7, 0x4c, 0xcc, 0x3e, 0xf6, 0x88, 0xde, 0x77, 0x98, 0x60, 0x71, 0x7f, 0x9c, 0xe1,
-	0xb5, 0xab, 0x3b, 0x0e, 0x76, 0x3d, 0xbe, 0x7e, 0x9b, 0x01, 0xb8, 0xb0, 0xfb, 0x7d, 0xdb, 0x2a,
-	0x3b, 0xbd, 0x41, 0xd7, 0x0c, 0xfe, 0xe1, 0x1c, 0x7b, 0x31, 0x0e, 0xf6, 0x0f, 0x5b, 0x52, 0x6a,
-	0x90, 0xaf, 0xb9, 0x58, 0x27, 0xb8, 0x3a, 0xb0, 0xda, 0x3d, 0xac, 0xe2, 0x3f, 0x0d, 0xb0, 0x47,
-	0xd0, 0x3d, 0x58, 0x31, 0x28, 0xa1, 0x90, 0xb9, 0x9d, 0xf9, 0x20, 0x77, 0x7c, 0xb3, 0xc4, 0xd0,
-	0x73, 0x59, 0xce, 0xcc, 0x79, 0x94, 0x53, 0xb8, 0x19, 0x57, 0xe2, 0x39, 0xb6, 0xe5, 0xe1, 0x19,
-	0xb5, 0x3c, 0x06, 0xf4, 0x25, 0x26, 0x17, 0x97, 0x71, 0x24, 0x77, 0x60, 0x93, 0xb8, 0x03, 0x8f,
-	0x68, 0x6d, 0xbb, 0xaf, 0x9b, 0x96, 0x66, 0xb6, 0xa9, 0xb2, 0xac, 0xba, 0x4e, 0xc9, 0xa7, 0x94,
-	0xda, 0x68, 0xfb, 0x8e, 0xc4, 0xa4, 0xe7, 0x82, 0xf0, 0x0e, 0xe4, 0x6b, 0xf6, 0xc0, 0x22, 0x8c,
-	0xec, 0x71, 0x0c, 0xca, 0x11, 0xdc, 0x8c, 0x93, 0xb9, 0xf2, 0x02, 0xdc, 0x60, 0x82, 0x1e, 0xd5,
-	0xbe, 0xac, 0x06, 0x9f, 0xca, 0x73, 0x40, 0xe7, 0xa6, 0x37, 0xa6, 0x07, 0x55, 0x01, 0x1c, 0xbd,
-	0x6b, 0x5a, 0x3a, 0x31, 0x6d, 0x8b, 0x03, 0x52, 0x4a, 0xe2, 0xb8, 0x28, 0x3d, 0x1b, 0x71, 0xaa,
-	0x11, 0x29, 0xe5, 0xaf, 0x19, 0xc8, 0xc7, 0x54, 0x73, 0x2c, 0xa5, 0x28, 0x96, 0x45, 0xa9, 0xa7,
-	0x01, 0xd3, 0x18, 0x96, 0x85, 0xb9, 0xb0, 0xfc, 0x19, 0xf2, 0xdf, 0x38, 0xed, 0xab, 0x05, 0x0f,
-	0x3a, 0x01, 0x30, 0x2d, 0x67, 0x40, 0xb4, 0xbe, 0xee, 0xbd, 0xe4, 0x40, 0x0a, 0x22, 0x89, 0x27,
-	0xba, 0xf7, 0x52, 0xcd, 0x52, 0x5e, 0xff, 0x4f, 0x3f, 0xea, 0xe2, 0xd6, 0xe7, 0x3a, 0xf2, 0x2f,
-	0x60, 0xab, 0x89, 0xc9, 0x55, 0xa2, 0xbf, 0x02, 0xdb, 0x11, 0x0d, 0x73, 0x81, 0xa8, 0x41, 0xbe,
-	0xe2, 0x38, 0xd8, 0x6a, 0x5f, 0xf1, 0x16, 0xc6, 0x95, 0xcc, 0x05, 0xe5, 0xdf, 0x19, 0xc8, 0x9f,
-	0xe2, 0x1e, 0x1e, 0x3f, 0xd4, 0x29, 0xef, 0x21, 0x3a, 0x85, 0xa5, 0xbe, 0xdd, 0xc6, 0xf4, 0x20,
-	0x37, 0x8e, 0x8f, 0x64, 0x11, 0x25, 0x30, 0x51, 0x7a, 0x62, 0xb7, 0xb1, 0x4a, 0xa5, 0x95, 0x23,
-	0x58, 0xf2, 0xbf, 0xd0, 0x1a, 0xac, 0xaa, 0xf5, 0x66, 0x4b, 0x6d, 0xd4, 0x5a, 0x5b, 0x3f, 0x41,
-	0x00, 0x2b, 0xa7, 0xf5, 0xf3, 0x7a, 0xab, 0xbe, 0x95, 0x41, 0x1b, 0x00, 0xa7, 0x8d, 0x66, 0xf3,
-	0xeb, 0x5a, 0xa3, 0xd2, 0xaa, 0x6f, 0x2d, 0xf8, 0xde, 0xc7, 0x75, 0xce, 0xe5, 0xfd, 0x05, 0xa0,
-	0x67, 0xee, 0xc0, 0x9a, 0xd3, 0xf7, 0x43, 0xd8, 0xc0, 0x6f, 0x7c, 0xed, 0x9e, 0x66, 0xe0, 0x8e,
-	0xed, 0xb2, 0x5d, 0x58, 0x54, 0xd7, 0x39, 0xb5, 0x4a, 0x89, 0xca, 0x63, 0xc8, 0xc7, 0x8c, 0x70,
-	0xa4, 0x87, 0xb0, 0xc1, 0x50, 0x68, 0x17, 0x97, 0xba, 0xd5, 0xc5, 0xcc, 0xc8, 0xaa, 0xba, 0xce,
-	0xa8, 0x35, 0x46, 0x54, 0x0c, 0x58, 0x7f, 0x6a, 0xb7, 0x71, 0x13, 0xf7, 0xf0, 0x05, 0xb1, 0x5d,
-	0x0f, 0xdd, 0x82, 0xac, 0xe7, 0x98, 0x9d, 0x0e, 0x0e, 0x71, 0xad, 0x32, 0x42, 0xa3, 0x8d, 0x1e,
-	0x40, 0xd6, 0x0b, 0x38, 0x0b, 0x0b, 0x34, 0x31, 0xec, 0xc4, 0x77, 0x20, 0x50, 0xa4, 0x86, 0x8c,
-	0xca, 0x1f, 0x61, 0xb7, 0x89, 0x49, 0xcc, 0x4c, 0xb0, 0x17, 0xb5, 0xa8, 0x42, 0xb6, 0xa5, 0x87,
-	0xb2, 0x43, 0x8e, 0x2b, 0x88, 0xe8, 0x2f, 0x42, 0x21, 0xa9, 0x9f, 0x6d, 0x83, 0xf2, 0x07, 0xd8,
-	0x3d, 0x93, 0xd8, 0x4e, 0xf5, 0xf4, 0x10, 0x36, 0x88, 0xdd, 0xc3, 0xae, 0x4e, 0xb0, 0xe6, 0x11,
-	0xbd, 0xc7, 0x36, 0x7f, 0x55, 0x5d, 0x0f, 0xa8, 0x4d, 0x9f, 0xa8, 0x68, 0x50, 0x38, 0x93, 0x98,
-	0xbe, 0x1e, 0xdf, 0xde, 0x40, 0xc1, 0xcf, 0xcf, 0x42, 0x07, 0x92, 0x18, 0x33, 0x02, 0x8c, 0xe8,
-	0x21, 0xac, 0xbe, 0xd2, 0x7b, 0x66, 0x5b, 0xd3, 0x49, 0x61, 0x91, 0xc2, 0x28, 0x96, 0x58, 0x11,
-	0x50, 0x0a, 0x8a, 0x80, 0x52, 0x2b, 0xa8, 0x12, 0xd4, 0x1b, 0x94, 0xb7, 0x42, 0x94, 0xef, 0x60,
-	0x4f, 0x60, 0x59, 0xec, 0xdb, 0xe2, 0x5c, 0xbe, 0x9d, 0x43, 0x91, 0x3d, 0xf4, 0x15, 0x42, 0xb0,
-	0x47, 0x70, 0xdb, 0xe7, 0x8c, 0x3c, 0x41, 0x4b, 0x96, 0x7f, 0xf5, 0x33, 0x1c, 0x72, 0x2c, 0xcc,
-	0x62, 0x12, 0x94, 0x4f, 0x39, 0x81, 0x02, 0x7d, 0xb2, 0xe3, 0xca, 0x26, 0x1f, 0xb5, 0xf2, 0x5b,
-	0xd8, 0x13, 0x08, 0xce, 0x89, 0xe2, 0x16, 0xec, 0xd1, 0xc7, 0x3d, 0xba, 0x34, 0x7a, 0xf9, 0x8f,
-	0xa1, 0x28, 0x5a, 0xe4, 0xa6, 0x6e, 0xc2, 0xb2, 0xaf, 0x22, 0x78, 0xfd, 0xd9, 0x87, 0x8f, 0x4e,
-	0xb4, 0x49, 0xcc, 0xaf, 0x59, 0xd1, 0xfd, 0xb8, 0xc8, 0xc2, 0x49, 0x84, 0x0e, 0x9d, 0xc1, 0xb6,
-	0x31, 0xd4, 0xc6, 0x52, 0x0e, 0xd3, 0x7c, 0x2b, 0x11, 0x30, 0x0d, 0x8b, 0x3c, 0x7a, 0xf0, 0x3b,
-	0xbd, 0x37, 0xc0, 0xea, 0xa6, 0x31, 0xac, 0x47, 0x33, 0xd2, 0x75, 0x14, 0x03, 0xa8, 0x04, 0x79,
-	0x63, 0xa8, 0xe9, 0x14, 0x27, 0xa5, 0x68, 0x64, 0xe8, 0x60, 0x1a, 0xbf, 0x59, 0x75, 0xdb, 0x18,
-	0x56, 0xc2, 0x95, 0xd6, 0xd0, 0xc1, 0xe8, 0x6b, 0x0a, 0x3e, 0x88, 0x2d, 0xad, 0xaf, 0x93, 0x8b,
-	0xcb, 0xc2, 0x12, 0x35, 0xfd, 0xbe, 0xcc, 0x74, 0x75, 0x18, 0x86, 0xe5, 0xa6, 0x31, 0xfa, 0x78,
-	0xe2, 0xcb, 0xa2, 0x13, 0xc8, 0x1a, 0x43, 0xcd, 0xd0, 0x2d, 0x0b, 0xb7, 0x0b, 0xcb, 0x92, 0x6b,
-	0x53, 0xb5, 0xed, 0x1e, 0xdb, 0x84, 0x55, 0x63, 0x58, 0xa5, 0xbc, 0xe8, 0xe7, 0xb0, 0xd9, 0xf1,
-	0xc3, 0x49, 0x0b, 0x2f, 0xc8, 0x0a, 0xbd, 0x96, 0x1b, 0x94, 0x3c, 0x32, 0xa9, 0xfc, 0x23, 0xc3,
-	0x6e, 0x98, 0x38, 0x1a, 0x8e, 0xc2, 0x68, 0x58, 0x9c, 0x70, 0xb6, 0x8c, 0xf1, 0x5a, 0x6a, 0xb0,
-	0x7f, 0x2d, 0xc0, 0x1e, 0x2b, 0x83, 0x66, 0xbd, 0x46, 0xe8, 0x1e, 0xa0, 0x0b, 0xec, 0x12, 0xcd,
-	0xc3, 0xae, 0xa9, 0xf7, 0x34, 0x6b, 0xd0, 0x37, 0xb0, 0x4b, 0x61, 0x64, 0xd5, 0x2d, 0x7f, 0xa5,
-	0x49, 0x17, 0x9e, 0x52, 0x3a, 0xfa, 0x19, 0x6c, 0x50, 0x6e, 0xcb, 0x26, 0x9a, 0xde, 0x21, 0xd8,
-	0xa5, 0x47, 0xbb, 0xa8, 0xae, 0xf9, 0xd4, 0xa7, 0x36, 0xa9, 0xf8, 0x34, 0xf4, 0x09, 0xec, 0x58,
-	0xf8, 0xb5, 0x26, 0xd0, 0xbb, 0x44, 0xf5, 0xe6, 0x2d, 0xfc, 0xba, 0x36, 0xae, 0xfa, 0x2e, 0xa0,
-	0x91, 0x50, 0xa8, 0x7e, 0x99, 0xaa, 0xdf, 0xe4, 0x02, 0x23, 0x0b, 0xbf, 0x8a, 0xd5, 0x8b, 0x2b,
-	0x74, 0xd3, 0xf6, 0xe5, 0x7b, 0x3d, 0x5e, 0x35, 0x9e, 0x43, 0x51, 0xb4, 0x5d, 0x73, 0x26, 0x8f,
-	0x4f, 0x61, 0x8f, 0x55, 0x1d, 0x33, 0xe7, 0xb0, 0x73, 0x28, 0x8a, 0x24, 0xe7, 0xc4, 0xf1, 0x7b,
-	0xd8, 0x67, 0x39, 0x47, 0xc5, 0x5d, 0xd3, 0x23, 0x2e, 0x8d, 0x8d, 0xba, 0x45, 0xdc, 0x61, 0x00,
-	0xe6, 0x21, 0x2c, 0x63, 0xff, 0x9b, 0xab, 0x3c, 0x88, 0xab, 0x4c, 0x8a, 0x31, 0x6e, 0xe5, 0x39,
-	0x1c, 0x48, 0x15, 0x73, 0xac, 0x73, 0x6a, 0xfe, 0x05, 0xfc, 0x94, 0x26, 0x71, 0x29, 0xe2, 0x3d,
-	0x58, 0xa5, 0x9c, 0xe1, 0xee, 0xdd, 0xa0, 0xdf, 0x8d, 0xb6, 0xef, 0xae, 0x4c, 0xf6, 0x6a, 0xa0,
-	0xfe, 0x93, 0x81, 0x5c, 0x24, 0xc9, 0xc4, 0xcb, 0xa7, 0xcc, 0x94, 0xe5, 0x13, 0x3a, 0x83, 0x65,
-	0x96, 0xce, 0x58, 0x11, 0x7c, 0x7f, 0x8a, 0x74, 0x56, 0xa2, 0x39, 0xac, 0x8a, 0x2f, 0xf5, 0x57,
-	0xa6, 0xed, 0xaa, 0x4c, 0x5e, 0x39, 0x86, 0xf5, 0x18, 0x1d, 0x6d, 0x42, 0xee, 0x49, 0xa5, 0x55,
-	0xfb, 0x4a, 0xab, 0x3f, 0xaf, 0xd0, 0x92, 0x78, 0x0b, 0xd6, 0x18, 0xa1, 0xf9, 0x4d, 0xb5, 0x59,
-	0x6f, 0x6d, 0x65, 0x94, 0xcf, 0x01, 0xc2, 0x54, 0xe1, 0x3f, 0x51, 0xc4, 0x7e, 0x89, 0x2d, 0xbe,
-	0x83, 0xec, 0xc3, 0x8f, 0x4c, 0x47, 0xef, 0x62, 0xcd, 0x33, 0x7f, 0x60, 0x65, 0xd2, 0xb2, 0xba,
-	0xea, 0x13, 0x9a, 0xe6, 0x0f, 0x58, 0x79, 0x0f, 0x0e, 0xe8, 0x9b, 0x37, 0xbe, 0x49, 0x66, 0xf8,
-	0x2c, 0x3e, 0x86, 0xdb, 0x72, 0x96, 0xb0, 0x39, 0xc6, 0x8c, 0x14, 0x34, 0xc7, 0xfc, 0x53, 0xf9,
-	0xef, 0x02, 0xec, 0xfb, 0x69, 0x54, 0x6e, 0x00, 0xfd, 0x1a, 0xd6, 0x8c, 0xa1, 0xe6, 0xe8, 0x2e,
-	0xb6, 0x48, 0x70, 0xfe, 0xb9, 0xe3, 0x77, 0x13, 0xe9, 0xbc, 0x49, 0x5c, 0xd3, 0xea, 0xb2, 0x84,
-	0x0e, 0xc6, 0xf0, 0x19, 0x15, 0x68, 0xb4, 0xd1, 0x97, 0x54, 0x3e, 0x5a, 0xf9, 0x4e, 0xfd, 0xae,
-	0xe4, 0x8c, 0xc8, 0xf9, 0x33, 0x1c, 0xe1, 0x2d, 0x5e, 0x9c, 0x0e, 0x47, 0x33, 0x48, 0xb1, 0xf1,
-	0x0c, 0xbf, 0x34, 0xd7, 0xc3, 0x9a, 0x2c, 0x1a, 0x97, 0x45, 0x85, 0xed, 0x3f, 0x33, 0x70, 0x20,
-	0xdd, 0x55, 0x7e, 0x26, 0x9f, 0x45, 0xcf, 0x64, 0x71, 0x9a, 0x7b, 0x11, 0xf0, 0x5f, 0xcb, 0x5b,
-	0xf5, 0xf7, 0x0c, 0xec, 0xb3, 0xe4, 0x7b, 0xcd, 0x69, 0x0a, 0x9d, 0xc0, 0x52, 0x64, 0x7c, 0xf0,
-	0xfe, 0x04, 0x29, 0xfa, 0x26, 0x50, 0x01, 0x3f, 0xbf, 0x49, 0x11, 0x5d, 0x2d, 0x95, 0xfc, 0x12,
-	0xf6, 0x59, 0x82, 0x9f, 0x27, 0xc1, 0x3d, 0x87, 0x03, 0xa9, 0xf0, 0xd5, 0x60, 0x7d, 0x05, 0x07,
-	0xb4, 0xf9, 0x4c, 0xb9, 0x7c, 0xc9, 0x36, 0x36, 0x23, 0x6a, 0x63, 0x15, 0xb8, 0x2d, 0xd7, 0xc4,
-	0x9b, 0xb9, 0xcf, 0x20, 0xfb, 0x1b, 0xdb, 0xb4, 0x5a, 0x34, 0xeb, 0x88, 0x73, 0xd1, 0x0e, 0xac,
-	0x50, 0xbd, 0x43, 0xde, 0x2c, 0xf3, 0x2f, 0xe5, 0x05, 0xec, 0xb0, 0x97, 0x67, 0xa4, 0x20, 0xc0,
-	0xf7, 0x05, 0xc0, 0xf7, 0xb6, 0x69, 0x69, 0xa1, 0xb2, 0xdc, 0xf1, 0x7b, 0xb2, 0x50, 0x0c, 0xa5,
-	0xb3, 0xdf, 0x07, 0x7f, 0x2a, 0xdf, 0xc2, 0x6e, 0x42, 0x37, 0xdf, 0xd6, 0xab, 0x2b, 0xff, 0x18,
-	0xde, 0xa1, 0x8f, 0x53, 0x02, 0xb7, 0xd0, 0x7f, 0xdf, 0xcf, 0x71, 0xf6, 0x6b, 0x83, 0x52, 0x82,
-	0x1d, 0x16, 0x46, 0x53, 0x62, 0xf9, 0x16, 0x76, 0x13, 0xfc, 0xd7, 0x06, 0xe6, 0x73, 0xd8, 0xa1,
-	0xf1, 0x32, 0x5a, 0x9c, 0x35, 0xe0, 0xf6, 0x60, 0x37, 0xa1, 0x80, 0xa1, 0x3b, 0xfe, 0xdf, 0xbb,
-	0x90, 0x3d, 0xd5, 0x89, 0xde, 0xf4, 0xcd, 0x23, 0x13, 0xd6, 0xa2, 0xf3, 0x68, 0x74, 0x57, 0x86,
-	0x53, 0x30, 0xfa, 0x2e, 0xde, 0x9b, 0x8e, 0x99, 0x6f, 0x4b, 0x07, 0x72, 0x91, 0xb1, 0x33, 0xfa,
-	0x48, 0x26, 0x9c, 0x9c, 0x6c, 0x17, 0xef, 0x4e, 0xc5, 0xcb, 0xed, 0xf8, 0x2e, 0x45, 0x46, 0xd0,
-	0x29, 0x2e, 0x25, 0xe7, 0xd7, 0x29, 0x2e, 0x89, 0xa6, 0xda, 0x1d, 0xc8, 0x45, 0x06, 0xcc, 0x72,
-	0x97, 0x92, 0x03, 0x6e, 0xb9, 0x4b, 0xa2, 0x89, 0xb5, 0x09, 0x6b, 0xd1, 0xf9, 0xad, 0xdc, 0x25,
-	0xc1, 0x8c, 0x59, 0xee, 0x92, 0x70, 0x24, 0xfc, 0x1d, 0x64, 0x47, 0x23, 0x5a, 0xf4, 0x81, 0x4c,
-	0x74, 0x7c, 0x0e, 0x5c, 0xfc, 0x70, 0x0a, 0xce, 0xd0, 0x99, 0xe8, 0xf0, 0x55, 0xee, 0x8c, 0x60,
-	0xce, 0x2b, 0x77, 0x46, 0x38, 0xcf, 0x35, 0x61, 0x2d, 0x3a, 0xe9, 0x94, 0x9b, 0x12, 0xcc, 0x58,
-	0xe5, 0xa6, 0x84, 0xc3, 0xd3, 0x0e, 0xe4, 0x22, 0x93, 0x4a, 0x79, 0x28, 0x24, 0x67, 0xa6, 0xf2,
-	0x50, 0x10, 0x8d, 0x3e, 0xdf, 0x02, 0x4a, 0x8e, 0x4c, 0xd0, 0xfd, 0xf4, 0x9b, 0x28, 0x68, 0xb9,
-	0x8a, 0xc7, 0xb3, 0x88, 0x70, 0xe3, 0x6f, 0x60, 0x3b, 0x31, 0x4d, 0x42, 0x47, 0xa9, 0x97, 0x53,
-	0x64, 0xfa, 0xfe, 0x0c, 0x12, 0x11, 0xb7, 0x13, 0xd3, 0xa5, 0x14, 0xb7, 0x65, 0x63, 0xaa, 0x14,
-	0xb7, 0xe5, 0xc3, 0xab, 0x37, 0xb0, 0x9d, 0x98, 0x65, 0xc8, 0xdd, 0x96, 0xcd, 0xa0, 0xe4, 0x6e,
-	0xcb, 0x07, 0x25, 0x6f, 0x01, 0x25, 0x5b, 0x70, 0xb9, 0xdb, 0xd2, 0xe9, 0x86, 0xdc, 0xed, 0x94,
-	0x0e, 0xff, 0x2d, 0xa0, 0x64, 0xdf, 0x2d, 0x37, 0x2e, 0xed, 0xee, 0xe5, 0xc6, 0x53, 0xda, 0xfa,
-	0x01, 0xfd, 0xcf, 0xa6, 0xf8, 0xf8, 0xbe, 0x9c, 0x92, 0x64, 0x44, 0x43, 0xe4, 0xe2, 0xd1, 0xf4,
-	0x02, 0xa1, 0xd9, 0xb3, 0xa9, 0xcd, 0x9e, 0xcd, 0x6a, 0x56, 0x3a, 0x4e, 0xe7, 0x11, 0x16, 0xb7,
-	0x9b, 0x1a, 0x61, 0x42, 0xc3, 0xf7, 0x67, 0x90, 0xe0, 0x96, 0x7f, 0xcc, 0x04, 0x05, 0x5e, 0xa2,
-	0x0e, 0x46, 0x8f, 0xd2, 0x53, 0x84, 0xac, 0x5a, 0x2f, 0x9e, 0xcc, 0x2c, 0xc7, 0xc1, 0xfc, 0x25,
-	0xc3, 0x2b, 0xbc, 0x24, 0x96, 0x87, 0xa9, 0x39, 0x43, 0x0a, 0xe5, 0xd1, 0xac, 0x62, 0x1c, 0xc9,
-	0xdf, 0x32, 0x50, 0x90, 0xf5, 0xed, 0xe8, 0x24, 0x35, 0x87, 0xc8, 0xdb, 0x85, 0xe2, 0xa7, 0xb3,
-	0x0b, 0x46, 0x8e, 0x49, 0xd2, 0xb2, 0xca, 0x8f, 0x29, 0x7d, 0x72, 0x20, 0x3f, 0xa6, 0x49, 0xbd,
-	0xb1, 0x0f, 0x46, 0xd2, 0x0a, 0xca, 0xc1, 0xa4, 0x77, 0xb3, 0x72, 0x30, 0x93, 0x7a, 0x4e, 0x1f,
-	0x8c, 0xa4, 0x01, 0x94, 0x83, 0x49, 0x6f, 0x37, 0xe5, 0x60, 0x26, 0x75, 0x9a, 0x7e, 0xd8, 0xc8,
-	0x3a, 0x3d, 0x79, 0xd8, 0x4c, 0xe8, 0x32, 0xe5, 0x61, 0x33, 0xa9, 0xa9, 0x44, 0x2e, 0x6c, 0x8e,
-	0x75, 0x6f, 0xa8, 0x94, 0x7e, 0x39, 0xc7, 0xdb, 0x9f, 0x62, 0x79, 0x6a, 0x7e, 0x6e, 0xd3, 0x86,
-	0x8d, 0x78, 0x97, 0x86, 0x3e, 0x4e, 0xbd, 0x84, 0x09, 0x8b, 0xa5, 0x69, 0xd9, 0x43, 0x27, 0xc7,
-	0x5a, 0x31, 0xb9, 0x93, 0xe2, 0x1e, 0x4f, 0xee, 0xa4, 0xac, 0xc7, 0x73, 0x61, 0x73, 0xac, 0xc1,
-	0x92, 0xdb, 0x14, 0xb7, 0x72, 0x72, 0x9b, 0x92, 0xce, 0x0d, 0xbd, 0x80, 0x6c, 0xcd, 0xb6, 0x3a,
-	0x66, 0x77, 0xe0, 0x62, 0x74, 0x18, 0x1f, 0x62, 0xf0, 0x1f, 0x31, 0x8d, 0xd6, 0x03, 0x23, 0x77,
-	0x26, 0xb1, 0x8d, 0xca, 0xd7, 0xf5, 0x33, 0x4c, 0x9e, 0xd1, 0xe5, 0x86, 0xd5, 0xb1, 0xd1, 0x87,
-	0x42, 0xc1, 0x18, 0x4f, 0x60, 0xe3, 0xa3, 0x69, 0x58, 0x99, 0x9d, 0xea, 0xa3, 0x17, 0x0f, 0xba,
-	0x26, 0xb9, 0x1c, 0x18, 0x3e, 0x77, 0x99, 0x4d, 0x0b, 0xcb, 0xec, 0x37, 0x57, 0x74, 0x42, 0x58,
-	0x16, 0xff, 0x44, 0xcc, 0x58, 0xa1, 0xab, 0x9f, 0xfc, 0x3f, 0x00, 0x00, 0xff, 0xff, 0x6e, 0x81,
-	0x95, 0x15, 0x43, 0x26, 0x00, 0x00,
+func (m *FederationRelationship) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederationRelationship.Unmarshal(m, b)
+}
+func (m *FederationRelationship) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederationRelationship.Marshal(b, m, deterministic)
+}
+func (m *FederationRelationship) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederationRelationship.Merge(m, src)
+}
+func (m *FederationRelationship) XXX_Size() int {
+	return xxx_messageInfo_FederationRelationship.Size(m)
+}
+func (m *FederationRelationship) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederationRelationship.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+var xxx_messageInfo_FederationRelationship proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (m *FederationRelationship) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
 
-// DataStoreClient is the client API for DataStore service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DataStoreClient interface {
-	// Creates a bundle
-	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error)
-	// Fetches a specific bundle
-	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
-	// Counts bundles (optionally filtered)
-	CountBundles(ctx context.Context, in *CountBundlesRequest, opts ...grpc.CallOption) (*CountBundlesResponse, error)
-	// Lists bundles (optionally filtered)
-	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
-	// Updates a specific bundle
-	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error)
-	// Sets bundle contents (creates if it does not exist)
-	SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error)
-	// Appends contents from a specific bundle (creates if it does not exist)
-	AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error)
-	// Deletes a specific bundle
-	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error)
-	// Prunes all expired certificates and JWT signing keys from a bundle
-	PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error)
-	// Creates an attested node
-	CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error)
-	// Fetches a specific attested node
-	FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error)
-	// Counts attested nodes
-	CountAttestedNodes(ctx context.Context, in *CountAttestedNodesRequest, opts ...grpc.CallOption) (*CountAttestedNodesResponse, error)
-	// Lists attested nodes (optionally filtered)
-	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
-	// Updates a specific attested node
-	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
-	// Deletes a specific attested node
-	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
-	// Sets the set of selectors for a specific node id
-	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
-	// Gets the set of node selectors for a specific node id
-	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
-	// Lists all node selectors
-	ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error)
-	// Creates a registration entry
-	CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error)
-	// Fetches a specific registration entry
-	FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error)
-	// Counts registration entries
-	CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error)
-	// Lists registration entries (optionally filtered)
-	ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error)
-	// Updates a specific registration entry
-	UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error)
-	// Deletes a specific registration entry
-	DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error)
-	// Prunes all registration entries that expire before the specified timestamp
-	PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error)
-	// Creates a join token
-	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
-	// Fetches a specific join token
-	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
-	// Delete a specific join token
-	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
-	// Prunes all join tokens that expire before the specified timestamp
-	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
+func (m *FederationRelationship) GetBundleEndpointUrl() string {
+	if m != nil {
+		return m.BundleEndpointUrl
+	}
+	return ""
+}
+
+func (m *FederationRelationship) GetBundleEndpointProfile() string {
+	if m != nil {
+		return m.BundleEndpointProfile
+	}
+	return ""
+}
+
+func (m *FederationRelationship) GetEndpointSpiffeId() string {
+	if m != nil {
+		return m.EndpointSpiffeId
+	}
+	return ""
+}
+
+type FederationRelationshipMask struct {
+	// bundle_endpoint_url field mask
+	BundleEndpointUrl bool `protobuf:"varint,1,opt,name=bundle_endpoint_url,json=bundleEndpointUrl,proto3" json:"bundle_endpoint_url,omitempty"`
+	// bundle_endpoint_profile field mask (includes endpoint_spiffe_id)
+	BundleEndpointProfile bool     `protobuf:"varint,2,opt,name=bundle_endpoint_profile,json=bundleEndpointProfile,proto3" json:"bundle_endpoint_profile,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *FederationRelationshipMask) Reset()         { *m = FederationRelationshipMask{} }
+func (m *FederationRelationshipMask) String() string { return proto.CompactTextString(m) }
+func (*FederationRelationshipMask) ProtoMessage()    {}
+func (*FederationRelationshipMask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{65}
+}
+
+func (m *FederationRelationshipMask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederationRelationshipMask.Unmarshal(m, b)
+}
+func (m *FederationRelationshipMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederationRelationshipMask.Marshal(b, m, deterministic)
+}
+func (m *FederationRelationshipMask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederationRelationshipMask.Merge(m, src)
+}
+func (m *FederationRelationshipMask) XXX_Size() int {
+	return xxx_messageInfo_FederationRelationshipMask.Size(m)
+}
+func (m *FederationRelationshipMask) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederationRelationshipMask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederationRelationshipMask proto.InternalMessageInfo
+
+func (m *FederationRelationshipMask) GetBundleEndpointUrl() bool {
+	if m != nil {
+		return m.BundleEndpointUrl
+	}
+	return false
+}
+
+func (m *FederationRelationshipMask) GetBundleEndpointProfile() bool {
+	if m != nil {
+		return m.BundleEndpointProfile
+	}
+	return false
+}
+
+type CreateFederationRelationshipRequest struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *CreateFederationRelationshipRequest) Reset()         { *m = CreateFederationRelationshipRequest{} }
+func (m *CreateFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateFederationRelationshipRequest) ProtoMessage()    {}
+func (*CreateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{66}
+}
+
+func (m *CreateFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *CreateFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFederationRelationshipRequest.Merge(m, src)
+}
+func (m *CreateFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateFederationRelationshipRequest.Size(m)
+}
+func (m *CreateFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *CreateFederationRelationshipRequest) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type CreateFederationRelationshipResponse struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *CreateFederationRelationshipResponse) Reset()         { *m = CreateFederationRelationshipResponse{} }
+func (m *CreateFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateFederationRelationshipResponse) ProtoMessage()    {}
+func (*CreateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{67}
+}
+
+func (m *CreateFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *CreateFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFederationRelationshipResponse.Merge(m, src)
+}
+func (m *CreateFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateFederationRelationshipResponse.Size(m)
+}
+func (m *CreateFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *CreateFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type FetchFederationRelationshipRequest struct {
+	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchFederationRelationshipRequest) Reset()         { *m = FetchFederationRelationshipRequest{} }
+func (m *FetchFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchFederationRelationshipRequest) ProtoMessage()    {}
+func (*FetchFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{68}
+}
+
+func (m *FetchFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *FetchFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchFederationRelationshipRequest.Merge(m, src)
+}
+func (m *FetchFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchFederationRelationshipRequest.Size(m)
+}
+func (m *FetchFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *FetchFederationRelationshipRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+type FetchFederationRelationshipResponse struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *FetchFederationRelationshipResponse) Reset()         { *m = FetchFederationRelationshipResponse{} }
+func (m *FetchFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchFederationRelationshipResponse) ProtoMessage()    {}
+func (*FetchFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{69}
+}
+
+func (m *FetchFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *FetchFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchFederationRelationshipResponse.Merge(m, src)
+}
+func (m *FetchFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchFederationRelationshipResponse.Size(m)
+}
+func (m *FetchFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *FetchFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type ListFederationRelationshipsRequest struct {
+	Pagination           *Pagination `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListFederationRelationshipsRequest) Reset()         { *m = ListFederationRelationshipsRequest{} }
+func (m *ListFederationRelationshipsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsRequest) ProtoMessage()    {}
+func (*ListFederationRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{70}
+}
+
+func (m *ListFederationRelationshipsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsRequest.Merge(m, src)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Size(m)
+}
+func (m *ListFederationRelationshipsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsRequest proto.InternalMessageInfo
+
+func (m *ListFederationRelationshipsRequest) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type ListFederationRelationshipsResponse struct {
+	FederationRelationships []*FederationRelationship `protobuf:"bytes,1,rep,name=federation_relationships,json=federationRelationships,proto3" json:"federation_relationships,omitempty"`
+	Pagination              *Pagination               `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}                  `json:"-"`
+	XXX_unrecognized        []byte                    `json:"-"`
+	XXX_sizecache           int32                     `json:"-"`
+}
+
+func (m *ListFederationRelationshipsResponse) Reset()         { *m = ListFederationRelationshipsResponse{} }
+func (m *ListFederationRelationshipsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsResponse) ProtoMessage()    {}
+func (*ListFederationRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{71}
+}
+
+func (m *ListFederationRelationshipsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsResponse.Merge(m, src)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Size(m)
+}
+func (m *ListFederationRelationshipsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsResponse proto.InternalMessageInfo
+
+func (m *ListFederationRelationshipsResponse) GetFederationRelationships() []*FederationRelationship {
+	if m != nil {
+		return m.FederationRelationships
+	}
+	return nil
+}
+
+func (m *ListFederationRelationshipsResponse) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type UpdateFederationRelationshipRequest struct {
+	FederationRelationship *FederationRelationship     `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	InputMask              *FederationRelationshipMask `protobuf:"bytes,2,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                    `json:"-"`
+	XXX_unrecognized       []byte                      `json:"-"`
+	XXX_sizecache          int32                       `json:"-"`
+}
+
+func (m *UpdateFederationRelationshipRequest) Reset()         { *m = UpdateFederationRelationshipRequest{} }
+func (m *UpdateFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateFederationRelationshipRequest) ProtoMessage()    {}
+func (*UpdateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{72}
+}
+
+func (m *UpdateFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateFederationRelationshipRequest.Merge(m, src)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateFederationRelationshipRequest.Size(m)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *UpdateFederationRelationshipRequest) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+func (m *UpdateFederationRelationshipRequest) GetInputMask() *FederationRelationshipMask {
+	if m != nil {
+		return m.InputMask
+	}
+	return nil
+}
+
+type UpdateFederationRelationshipResponse struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *UpdateFederationRelationshipResponse) Reset()         { *m = UpdateFederationRelationshipResponse{} }
+func (m *UpdateFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateFederationRelationshipResponse) ProtoMessage()    {}
+func (*UpdateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{73}
+}
+
+func (m *UpdateFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateFederationRelationshipResponse.Merge(m, src)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateFederationRelationshipResponse.Size(m)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *UpdateFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type DeleteFederationRelationshipRequest struct {
+	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteFederationRelationshipRequest) Reset()         { *m = DeleteFederationRelationshipRequest{} }
+func (m *DeleteFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederationRelationshipRequest) ProtoMessage()    {}
+func (*DeleteFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{74}
+}
+
+func (m *DeleteFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederationRelationshipRequest.Merge(m, src)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Size(m)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *DeleteFederationRelationshipRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+type DeleteFederationRelationshipResponse struct {
+	FederationRelationship *FederationRelationship `protobuf:"bytes,1,opt,name=federation_relationship,json=federationRelationship,proto3" json:"federation_relationship,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                `json:"-"`
+	XXX_unrecognized       []byte                  `json:"-"`
+	XXX_sizecache          int32                   `json:"-"`
+}
+
+func (m *DeleteFederationRelationshipResponse) Reset()         { *m = DeleteFederationRelationshipResponse{} }
+func (m *DeleteFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederationRelationshipResponse) ProtoMessage()    {}
+func (*DeleteFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{75}
+}
+
+func (m *DeleteFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederationRelationshipResponse.Merge(m, src)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederationRelationshipResponse.Size(m)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *DeleteFederationRelationshipResponse) GetFederationRelationship() *FederationRelationship {
+	if m != nil {
+		return m.FederationRelationship
+	}
+	return nil
+}
+
+type EntryTemplate struct {
+	// Unique ID of the template
+	TemplateId string `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	// Shape of the SPIFFE ID paths of the entries using the template, where
+	// "*" segments match any segment
+	SpiffeIdPath string `protobuf:"bytes,2,opt,name=spiffe_id_path,json=spiffeIdPath,proto3" json:"spiffe_id_path,omitempty"`
+	// TTL of the entries using the template
+	Ttl int32 `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Trust domain IDs the entries using the template federate with
+	FederatesWith []string `protobuf:"bytes,4,rep,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
+	// DNS name templates of the entries using the template
+	DnsNameTemplates     []string `protobuf:"bytes,5,rep,name=dns_name_templates,json=dnsNameTemplates,proto3" json:"dns_name_templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EntryTemplate) Reset()         { *m = EntryTemplate{} }
+func (m *EntryTemplate) String() string { return proto.CompactTextString(m) }
+func (*EntryTemplate) ProtoMessage()    {}
+func (*EntryTemplate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{76}
+}
+
+func (m *EntryTemplate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntryTemplate.Unmarshal(m, b)
+}
+func (m *EntryTemplate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntryTemplate.Marshal(b, m, deterministic)
+}
+func (m *EntryTemplate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntryTemplate.Merge(m, src)
+}
+func (m *EntryTemplate) XXX_Size() int {
+	return xxx_messageInfo_EntryTemplate.Size(m)
+}
+func (m *EntryTemplate) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntryTemplate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntryTemplate proto.InternalMessageInfo
+
+func (m *EntryTemplate) GetTemplateId() string {
+	if m != nil {
+		return m.TemplateId
+	}
+	return ""
+}
+
+func (m *EntryTemplate) GetSpiffeIdPath() string {
+	if m != nil {
+		return m.SpiffeIdPath
+	}
+	return ""
+}
+
+func (m *EntryTemplate) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *EntryTemplate) GetFederatesWith() []string {
+	if m != nil {
+		return m.FederatesWith
+	}
+	return nil
+}
+
+func (m *EntryTemplate) GetDnsNameTemplates() []string {
+	if m != nil {
+		return m.DnsNameTemplates
+	}
+	return nil
+}
+
+type EntryTemplateMask struct {
+	// spiffe_id_path field mask
+	SpiffeIdPath bool `protobuf:"varint,1,opt,name=spiffe_id_path,json=spiffeIdPath,proto3" json:"spiffe_id_path,omitempty"`
+	// ttl field mask
+	Ttl bool `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// federates_with field mask
+	FederatesWith bool `protobuf:"varint,3,opt,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
+	// dns_name_templates field mask
+	DnsNameTemplates     bool     `protobuf:"varint,4,opt,name=dns_name_templates,json=dnsNameTemplates,proto3" json:"dns_name_templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EntryTemplateMask) Reset()         { *m = EntryTemplateMask{} }
+func (m *EntryTemplateMask) String() string { return proto.CompactTextString(m) }
+func (*EntryTemplateMask) ProtoMessage()    {}
+func (*EntryTemplateMask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{77}
+}
+
+func (m *EntryTemplateMask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntryTemplateMask.Unmarshal(m, b)
+}
+func (m *EntryTemplateMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntryTemplateMask.Marshal(b, m, deterministic)
+}
+func (m *EntryTemplateMask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntryTemplateMask.Merge(m, src)
+}
+func (m *EntryTemplateMask) XXX_Size() int {
+	return xxx_messageInfo_EntryTemplateMask.Size(m)
+}
+func (m *EntryTemplateMask) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntryTemplateMask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntryTemplateMask proto.InternalMessageInfo
+
+func (m *EntryTemplateMask) GetSpiffeIdPath() bool {
+	if m != nil {
+		return m.SpiffeIdPath
+	}
+	return false
+}
+
+func (m *EntryTemplateMask) GetTtl() bool {
+	if m != nil {
+		return m.Ttl
+	}
+	return false
+}
+
+func (m *EntryTemplateMask) GetFederatesWith() bool {
+	if m != nil {
+		return m.FederatesWith
+	}
+	return false
+}
+
+func (m *EntryTemplateMask) GetDnsNameTemplates() bool {
+	if m != nil {
+		return m.DnsNameTemplates
+	}
+	return false
+}
+
+type CreateEntryTemplateRequest struct {
+	EntryTemplate        *EntryTemplate `protobuf:"bytes,1,opt,name=entry_template,json=entryTemplate,proto3" json:"entry_template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CreateEntryTemplateRequest) Reset()         { *m = CreateEntryTemplateRequest{} }
+func (m *CreateEntryTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateEntryTemplateRequest) ProtoMessage()    {}
+func (*CreateEntryTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{78}
+}
+
+func (m *CreateEntryTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateEntryTemplateRequest.Unmarshal(m, b)
+}
+func (m *CreateEntryTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateEntryTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateEntryTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateEntryTemplateRequest.Merge(m, src)
+}
+func (m *CreateEntryTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateEntryTemplateRequest.Size(m)
+}
+func (m *CreateEntryTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateEntryTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateEntryTemplateRequest proto.InternalMessageInfo
+
+func (m *CreateEntryTemplateRequest) GetEntryTemplate() *EntryTemplate {
+	if m != nil {
+		return m.EntryTemplate
+	}
+	return nil
+}
+
+type CreateEntryTemplateResponse struct {
+	EntryTemplate        *EntryTemplate `protobuf:"bytes,1,opt,name=entry_template,json=entryTemplate,proto3" json:"entry_template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CreateEntryTemplateResponse) Reset()         { *m = CreateEntryTemplateResponse{} }
+func (m *CreateEntryTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateEntryTemplateResponse) ProtoMessage()    {}
+func (*CreateEntryTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{79}
+}
+
+func (m *CreateEntryTemplateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateEntryTemplateResponse.Unmarshal(m, b)
+}
+func (m *CreateEntryTemplateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateEntryTemplateResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateEntryTemplateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateEntryTemplateResponse.Merge(m, src)
+}
+func (m *CreateEntryTemplateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateEntryTemplateResponse.Size(m)
+}
+func (m *CreateEntryTemplateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateEntryTemplateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateEntryTemplateResponse proto.InternalMessageInfo
+
+func (m *CreateEntryTemplateResponse) GetEntryTemplate() *EntryTemplate {
+	if m != nil {
+		return m.EntryTemplate
+	}
+	return nil
+}
+
+type FetchEntryTemplateRequest struct {
+	TemplateId           string   `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchEntryTemplateRequest) Reset()         { *m = FetchEntryTemplateRequest{} }
+func (m *FetchEntryTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchEntryTemplateRequest) ProtoMessage()    {}
+func (*FetchEntryTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{80}
+}
+
+func (m *FetchEntryTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchEntryTemplateRequest.Unmarshal(m, b)
+}
+func (m *FetchEntryTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchEntryTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchEntryTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchEntryTemplateRequest.Merge(m, src)
+}
+func (m *FetchEntryTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchEntryTemplateRequest.Size(m)
+}
+func (m *FetchEntryTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchEntryTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchEntryTemplateRequest proto.InternalMessageInfo
+
+func (m *FetchEntryTemplateRequest) GetTemplateId() string {
+	if m != nil {
+		return m.TemplateId
+	}
+	return ""
+}
+
+type FetchEntryTemplateResponse struct {
+	EntryTemplate        *EntryTemplate `protobuf:"bytes,1,opt,name=entry_template,json=entryTemplate,proto3" json:"entry_template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *FetchEntryTemplateResponse) Reset()         { *m = FetchEntryTemplateResponse{} }
+func (m *FetchEntryTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchEntryTemplateResponse) ProtoMessage()    {}
+func (*FetchEntryTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{81}
+}
+
+func (m *FetchEntryTemplateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchEntryTemplateResponse.Unmarshal(m, b)
+}
+func (m *FetchEntryTemplateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchEntryTemplateResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchEntryTemplateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchEntryTemplateResponse.Merge(m, src)
+}
+func (m *FetchEntryTemplateResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchEntryTemplateResponse.Size(m)
+}
+func (m *FetchEntryTemplateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchEntryTemplateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchEntryTemplateResponse proto.InternalMessageInfo
+
+func (m *FetchEntryTemplateResponse) GetEntryTemplate() *EntryTemplate {
+	if m != nil {
+		return m.EntryTemplate
+	}
+	return nil
+}
+
+type ListEntryTemplatesRequest struct {
+	Pagination           *Pagination `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListEntryTemplatesRequest) Reset()         { *m = ListEntryTemplatesRequest{} }
+func (m *ListEntryTemplatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListEntryTemplatesRequest) ProtoMessage()    {}
+func (*ListEntryTemplatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{82}
+}
+
+func (m *ListEntryTemplatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntryTemplatesRequest.Unmarshal(m, b)
+}
+func (m *ListEntryTemplatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntryTemplatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListEntryTemplatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntryTemplatesRequest.Merge(m, src)
+}
+func (m *ListEntryTemplatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListEntryTemplatesRequest.Size(m)
+}
+func (m *ListEntryTemplatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntryTemplatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEntryTemplatesRequest proto.InternalMessageInfo
+
+func (m *ListEntryTemplatesRequest) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type ListEntryTemplatesResponse struct {
+	EntryTemplates       []*EntryTemplate `protobuf:"bytes,1,rep,name=entry_templates,json=entryTemplates,proto3" json:"entry_templates,omitempty"`
+	Pagination           *Pagination      `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListEntryTemplatesResponse) Reset()         { *m = ListEntryTemplatesResponse{} }
+func (m *ListEntryTemplatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListEntryTemplatesResponse) ProtoMessage()    {}
+func (*ListEntryTemplatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{83}
+}
+
+func (m *ListEntryTemplatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntryTemplatesResponse.Unmarshal(m, b)
+}
+func (m *ListEntryTemplatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntryTemplatesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListEntryTemplatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntryTemplatesResponse.Merge(m, src)
+}
+func (m *ListEntryTemplatesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListEntryTemplatesResponse.Size(m)
+}
+func (m *ListEntryTemplatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntryTemplatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEntryTemplatesResponse proto.InternalMessageInfo
+
+func (m *ListEntryTemplatesResponse) GetEntryTemplates() []*EntryTemplate {
+	if m != nil {
+		return m.EntryTemplates
+	}
+	return nil
+}
+
+func (m *ListEntryTemplatesResponse) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type UpdateEntryTemplateRequest struct {
+	EntryTemplate        *EntryTemplate     `protobuf:"bytes,1,opt,name=entry_template,json=entryTemplate,proto3" json:"entry_template,omitempty"`
+	InputMask            *EntryTemplateMask `protobuf:"bytes,2,opt,name=input_mask,json=inputMask,proto3" json:"input_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UpdateEntryTemplateRequest) Reset()         { *m = UpdateEntryTemplateRequest{} }
+func (m *UpdateEntryTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateEntryTemplateRequest) ProtoMessage()    {}
+func (*UpdateEntryTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{84}
+}
+
+func (m *UpdateEntryTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateEntryTemplateRequest.Unmarshal(m, b)
+}
+func (m *UpdateEntryTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateEntryTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateEntryTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateEntryTemplateRequest.Merge(m, src)
+}
+func (m *UpdateEntryTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateEntryTemplateRequest.Size(m)
+}
+func (m *UpdateEntryTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateEntryTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateEntryTemplateRequest proto.InternalMessageInfo
+
+func (m *UpdateEntryTemplateRequest) GetEntryTemplate() *EntryTemplate {
+	if m != nil {
+		return m.EntryTemplate
+	}
+	return nil
+}
+
+func (m *UpdateEntryTemplateRequest) GetInputMask() *EntryTemplateMask {
+	if m != nil {
+		return m.InputMask
+	}
+	return nil
+}
+
+type UpdateEntryTemplateResponse struct {
+	EntryTemplate *EntryTemplate `protobuf:"bytes,1,opt,name=entry_template,json=entryTemplate,proto3" json:"entry_template,omitempty"`
+	// Number of registration entries updated along with the template
+	EntriesUpdated       int32    `protobuf:"varint,2,opt,name=entries_updated,json=entriesUpdated,proto3" json:"entries_updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateEntryTemplateResponse) Reset()         { *m = UpdateEntryTemplateResponse{} }
+func (m *UpdateEntryTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateEntryTemplateResponse) ProtoMessage()    {}
+func (*UpdateEntryTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{85}
+}
+
+func (m *UpdateEntryTemplateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateEntryTemplateResponse.Unmarshal(m, b)
+}
+func (m *UpdateEntryTemplateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateEntryTemplateResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateEntryTemplateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateEntryTemplateResponse.Merge(m, src)
+}
+func (m *UpdateEntryTemplateResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateEntryTemplateResponse.Size(m)
+}
+func (m *UpdateEntryTemplateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateEntryTemplateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateEntryTemplateResponse proto.InternalMessageInfo
+
+func (m *UpdateEntryTemplateResponse) GetEntryTemplate() *EntryTemplate {
+	if m != nil {
+		return m.EntryTemplate
+	}
+	return nil
+}
+
+func (m *UpdateEntryTemplateResponse) GetEntriesUpdated() int32 {
+	if m != nil {
+		return m.EntriesUpdated
+	}
+	return 0
+}
+
+type DeleteEntryTemplateRequest struct {
+	TemplateId           string   `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteEntryTemplateRequest) Reset()         { *m = DeleteEntryTemplateRequest{} }
+func (m *DeleteEntryTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteEntryTemplateRequest) ProtoMessage()    {}
+func (*DeleteEntryTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{86}
+}
+
+func (m *DeleteEntryTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteEntryTemplateRequest.Unmarshal(m, b)
+}
+func (m *DeleteEntryTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteEntryTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteEntryTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteEntryTemplateRequest.Merge(m, src)
+}
+func (m *DeleteEntryTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteEntryTemplateRequest.Size(m)
+}
+func (m *DeleteEntryTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteEntryTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteEntryTemplateRequest proto.InternalMessageInfo
+
+func (m *DeleteEntryTemplateRequest) GetTemplateId() string {
+	if m != nil {
+		return m.TemplateId
+	}
+	return ""
+}
+
+type DeleteEntryTemplateResponse struct {
+	EntryTemplate        *EntryTemplate `protobuf:"bytes,1,opt,name=entry_template,json=entryTemplate,proto3" json:"entry_template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *DeleteEntryTemplateResponse) Reset()         { *m = DeleteEntryTemplateResponse{} }
+func (m *DeleteEntryTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteEntryTemplateResponse) ProtoMessage()    {}
+func (*DeleteEntryTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{87}
+}
+
+func (m *DeleteEntryTemplateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteEntryTemplateResponse.Unmarshal(m, b)
+}
+func (m *DeleteEntryTemplateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteEntryTemplateResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteEntryTemplateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteEntryTemplateResponse.Merge(m, src)
+}
+func (m *DeleteEntryTemplateResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteEntryTemplateResponse.Size(m)
+}
+func (m *DeleteEntryTemplateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteEntryTemplateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteEntryTemplateResponse proto.InternalMessageInfo
+
+func (m *DeleteEntryTemplateResponse) GetEntryTemplate() *EntryTemplate {
+	if m != nil {
+		return m.EntryTemplate
+	}
+	return nil
+}
+
+type BackupRequest struct {
+	// Path of the file the backup is written to
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{88}
+}
+
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupRequest.Size(m)
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+func (m *BackupRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type BackupResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupResponse) Reset()         { *m = BackupResponse{} }
+func (m *BackupResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupResponse) ProtoMessage()    {}
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{89}
+}
+
+func (m *BackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupResponse.Unmarshal(m, b)
+}
+func (m *BackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupResponse.Merge(m, src)
+}
+func (m *BackupResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupResponse.Size(m)
+}
+func (m *BackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("spire.server.datastore.DeleteBundleRequest_Mode", DeleteBundleRequest_Mode_name, DeleteBundleRequest_Mode_value)
+	proto.RegisterEnum("spire.server.datastore.BySelectors_MatchBehavior", BySelectors_MatchBehavior_name, BySelectors_MatchBehavior_value)
+	proto.RegisterType((*CreateBundleRequest)(nil), "spire.server.datastore.CreateBundleRequest")
+	proto.RegisterType((*CreateBundleResponse)(nil), "spire.server.datastore.CreateBundleResponse")
+	proto.RegisterType((*FetchBundleRequest)(nil), "spire.server.datastore.FetchBundleRequest")
+	proto.RegisterType((*FetchBundleResponse)(nil), "spire.server.datastore.FetchBundleResponse")
+	proto.RegisterType((*CountBundlesRequest)(nil), "spire.server.datastore.CountBundlesRequest")
+	proto.RegisterType((*CountBundlesResponse)(nil), "spire.server.datastore.CountBundlesResponse")
+	proto.RegisterType((*ListBundlesRequest)(nil), "spire.server.datastore.ListBundlesRequest")
+	proto.RegisterType((*ListBundlesResponse)(nil), "spire.server.datastore.ListBundlesResponse")
+	proto.RegisterType((*UpdateBundleRequest)(nil), "spire.server.datastore.UpdateBundleRequest")
+	proto.RegisterType((*UpdateBundleResponse)(nil), "spire.server.datastore.UpdateBundleResponse")
+	proto.RegisterType((*SetBundleRequest)(nil), "spire.server.datastore.SetBundleRequest")
+	proto.RegisterType((*SetBundleResponse)(nil), "spire.server.datastore.SetBundleResponse")
+	proto.RegisterType((*AppendBundleRequest)(nil), "spire.server.datastore.AppendBundleRequest")
+	proto.RegisterType((*AppendBundleResponse)(nil), "spire.server.datastore.AppendBundleResponse")
+	proto.RegisterType((*DeleteBundleRequest)(nil), "spire.server.datastore.DeleteBundleRequest")
+	proto.RegisterType((*DeleteBundleResponse)(nil), "spire.server.datastore.DeleteBundleResponse")
+	proto.RegisterType((*PruneBundleRequest)(nil), "spire.server.datastore.PruneBundleRequest")
+	proto.RegisterType((*PruneBundleResponse)(nil), "spire.server.datastore.PruneBundleResponse")
+	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
+	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
+	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
+	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.server.datastore.GetNodeSelectorsRequest")
+	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.server.datastore.GetNodeSelectorsResponse")
+	proto.RegisterType((*ListNodeSelectorsRequest)(nil), "spire.server.datastore.ListNodeSelectorsRequest")
+	proto.RegisterType((*ListNodeSelectorsResponse)(nil), "spire.server.datastore.ListNodeSelectorsResponse")
+	proto.RegisterType((*CreateAttestedNodeResponse)(nil), "spire.server.datastore.CreateAttestedNodeResponse")
+	proto.RegisterType((*FetchAttestedNodeRequest)(nil), "spire.server.datastore.FetchAttestedNodeRequest")
+	proto.RegisterType((*FetchAttestedNodeResponse)(nil), "spire.server.datastore.FetchAttestedNodeResponse")
+	proto.RegisterType((*CountAttestedNodesRequest)(nil), "spire.server.datastore.CountAttestedNodesRequest")
+	proto.RegisterType((*CountAttestedNodesResponse)(nil), "spire.server.datastore.CountAttestedNodesResponse")
+	proto.RegisterType((*CreateAttestedNodeRequest)(nil), "spire.server.datastore.CreateAttestedNodeRequest")
+	proto.RegisterType((*ListAttestedNodesRequest)(nil), "spire.server.datastore.ListAttestedNodesRequest")
+	proto.RegisterType((*ListAttestedNodesResponse)(nil), "spire.server.datastore.ListAttestedNodesResponse")
+	proto.RegisterType((*UpdateAttestedNodeRequest)(nil), "spire.server.datastore.UpdateAttestedNodeRequest")
+	proto.RegisterType((*UpdateAttestedNodeResponse)(nil), "spire.server.datastore.UpdateAttestedNodeResponse")
+	proto.RegisterType((*DeleteAttestedNodeRequest)(nil), "spire.server.datastore.DeleteAttestedNodeRequest")
+	proto.RegisterType((*DeleteAttestedNodeResponse)(nil), "spire.server.datastore.DeleteAttestedNodeResponse")
+	proto.RegisterType((*CreateRegistrationEntryRequest)(nil), "spire.server.datastore.CreateRegistrationEntryRequest")
+	proto.RegisterType((*CreateRegistrationEntryResponse)(nil), "spire.server.datastore.CreateRegistrationEntryResponse")
+	proto.RegisterType((*FetchRegistrationEntryRequest)(nil), "spire.server.datastore.FetchRegistrationEntryRequest")
+	proto.RegisterType((*FetchRegistrationEntryResponse)(nil), "spire.server.datastore.FetchRegistrationEntryResponse")
+	proto.RegisterType((*BySelectors)(nil), "spire.server.datastore.BySelectors")
+	proto.RegisterType((*Pagination)(nil), "spire.server.datastore.Pagination")
+	proto.RegisterType((*CountRegistrationEntriesRequest)(nil), "spire.server.datastore.CountRegistrationEntriesRequest")
+	proto.RegisterType((*CountRegistrationEntriesResponse)(nil), "spire.server.datastore.CountRegistrationEntriesResponse")
+	proto.RegisterType((*ListRegistrationEntriesRequest)(nil), "spire.server.datastore.ListRegistrationEntriesRequest")
+	proto.RegisterType((*ListRegistrationEntriesResponse)(nil), "spire.server.datastore.ListRegistrationEntriesResponse")
+	proto.RegisterType((*UpdateRegistrationEntryRequest)(nil), "spire.server.datastore.UpdateRegistrationEntryRequest")
+	proto.RegisterType((*UpdateRegistrationEntryResponse)(nil), "spire.server.datastore.UpdateRegistrationEntryResponse")
+	proto.RegisterType((*DeleteRegistrationEntryRequest)(nil), "spire.server.datastore.DeleteRegistrationEntryRequest")
+	proto.RegisterType((*DeleteRegistrationEntryResponse)(nil), "spire.server.datastore.DeleteRegistrationEntryResponse")
+	proto.RegisterType((*PruneRegistrationEntriesRequest)(nil), "spire.server.datastore.PruneRegistrationEntriesRequest")
+	proto.RegisterType((*PruneRegistrationEntriesResponse)(nil), "spire.server.datastore.PruneRegistrationEntriesResponse")
+	proto.RegisterType((*JoinToken)(nil), "spire.server.datastore.JoinToken")
+	proto.RegisterType((*CreateJoinTokenRequest)(nil), "spire.server.datastore.CreateJoinTokenRequest")
+	proto.RegisterType((*CreateJoinTokenResponse)(nil), "spire.server.datastore.CreateJoinTokenResponse")
+	proto.RegisterType((*FetchJoinTokenRequest)(nil), "spire.server.datastore.FetchJoinTokenRequest")
+	proto.RegisterType((*FetchJoinTokenResponse)(nil), "spire.server.datastore.FetchJoinTokenResponse")
+	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.server.datastore.DeleteJoinTokenRequest")
+	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.server.datastore.DeleteJoinTokenResponse")
+	proto.RegisterType((*UseJoinTokenRequest)(nil), "spire.server.datastore.UseJoinTokenRequest")
+	proto.RegisterType((*UseJoinTokenResponse)(nil), "spire.server.datastore.UseJoinTokenResponse")
+	proto.RegisterType((*PruneJoinTokensRequest)(nil), "spire.server.datastore.PruneJoinTokensRequest")
+	proto.RegisterType((*PruneJoinTokensResponse)(nil), "spire.server.datastore.PruneJoinTokensResponse")
+	proto.RegisterType((*FederationRelationship)(nil), "spire.server.datastore.FederationRelationship")
+	proto.RegisterType((*FederationRelationshipMask)(nil), "spire.server.datastore.FederationRelationshipMask")
+	proto.RegisterType((*CreateFederationRelationshipRequest)(nil), "spire.server.datastore.CreateFederationRelationshipRequest")
+	proto.RegisterType((*CreateFederationRelationshipResponse)(nil), "spire.server.datastore.CreateFederationRelationshipResponse")
+	proto.RegisterType((*FetchFederationRelationshipRequest)(nil), "spire.server.datastore.FetchFederationRelationshipRequest")
+	proto.RegisterType((*FetchFederationRelationshipResponse)(nil), "spire.server.datastore.FetchFederationRelationshipResponse")
+	proto.RegisterType((*ListFederationRelationshipsRequest)(nil), "spire.server.datastore.ListFederationRelationshipsRequest")
+	proto.RegisterType((*ListFederationRelationshipsResponse)(nil), "spire.server.datastore.ListFederationRelationshipsResponse")
+	proto.RegisterType((*UpdateFederationRelationshipRequest)(nil), "spire.server.datastore.UpdateFederationRelationshipRequest")
+	proto.RegisterType((*UpdateFederationRelationshipResponse)(nil), "spire.server.datastore.UpdateFederationRelationshipResponse")
+	proto.RegisterType((*DeleteFederationRelationshipRequest)(nil), "spire.server.datastore.DeleteFederationRelationshipRequest")
+	proto.RegisterType((*DeleteFederationRelationshipResponse)(nil), "spire.server.datastore.DeleteFederationRelationshipResponse")
+	proto.RegisterType((*EntryTemplate)(nil), "spire.server.datastore.EntryTemplate")
+	proto.RegisterType((*EntryTemplateMask)(nil), "spire.server.datastore.EntryTemplateMask")
+	proto.RegisterType((*CreateEntryTemplateRequest)(nil), "spire.server.datastore.CreateEntryTemplateRequest")
+	proto.RegisterType((*CreateEntryTemplateResponse)(nil), "spire.server.datastore.CreateEntryTemplateResponse")
+	proto.RegisterType((*FetchEntryTemplateRequest)(nil), "spire.server.datastore.FetchEntryTemplateRequest")
+	proto.RegisterType((*FetchEntryTemplateResponse)(nil), "spire.server.datastore.FetchEntryTemplateResponse")
+	proto.RegisterType((*ListEntryTemplatesRequest)(nil), "spire.server.datastore.ListEntryTemplatesRequest")
+	proto.RegisterType((*ListEntryTemplatesResponse)(nil), "spire.server.datastore.ListEntryTemplatesResponse")
+	proto.RegisterType((*UpdateEntryTemplateRequest)(nil), "spire.server.datastore.UpdateEntryTemplateRequest")
+	proto.RegisterType((*UpdateEntryTemplateResponse)(nil), "spire.server.datastore.UpdateEntryTemplateResponse")
+	proto.RegisterType((*DeleteEntryTemplateRequest)(nil), "spire.server.datastore.DeleteEntryTemplateRequest")
+	proto.RegisterType((*DeleteEntryTemplateResponse)(nil), "spire.server.datastore.DeleteEntryTemplateResponse")
+	proto.RegisterType((*BackupRequest)(nil), "spire.server.datastore.BackupRequest")
+	proto.RegisterType((*BackupResponse)(nil), "spire.server.datastore.BackupResponse")
+}
+
+func init() {
+	proto.RegisterFile("spire/server/datastore/datastore.proto", fileDescriptor_4d9f80f01a852be0)
+}
+
+var fileDescriptor_4d9f80f01a852be0 = []byte{
+	// 2950 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5b, 0xdd, 0x6e, 0xdc, 0xc6,
+	0xf5, 0xff, 0x53, 0x2b, 0xc9, 0xda, 0x23, 0x69, 0x25, 0x8f, 0x1c, 0x69, 0x45, 0xe7, 0x6f, 0x39,
+	0x54, 0x9c, 0xc6, 0xb1, 0xb2, 0xb2, 0xd7, 0x89, 0x95, 0xc6, 0x4a, 0x13, 0x7d, 0x45, 0x51, 0x2b,
+	0x3b, 0xee, 0xae, 0xdc, 0x18, 0x09, 0x5a, 0x86, 0x2b, 0x8e, 0x24, 0xc6, 0xbb, 0xe4, 0x96, 0x9c,
+	0xb5, 0xbd, 0x69, 0x91, 0xab, 0x02, 0x45, 0x0b, 0xf4, 0x0b, 0x08, 0x0a, 0xf4, 0xae, 0x2f, 0x50,
+	0xa0, 0x17, 0xed, 0x65, 0xd1, 0x02, 0x7d, 0x82, 0xa2, 0x45, 0xef, 0xfb, 0x26, 0x05, 0x67, 0x86,
+	0x4b, 0x72, 0x39, 0xc3, 0x25, 0x57, 0x6b, 0xa3, 0x57, 0x5a, 0x0e, 0xcf, 0xc7, 0xef, 0xcc, 0x9c,
+	0x39, 0x67, 0x78, 0xce, 0x08, 0x5e, 0xf3, 0xda, 0x96, 0x8b, 0xd7, 0x3d, 0xec, 0x3e, 0xc1, 0xee,
+	0xba, 0x69, 0x10, 0xc3, 0x23, 0x8e, 0x8b, 0xc3, 0x5f, 0x95, 0xb6, 0xeb, 0x10, 0x07, 0x2d, 0x52,
+	0xba, 0x0a, 0xa3, 0xab, 0xf4, 0xde, 0xaa, 0x2b, 0xa7, 0x8e, 0x73, 0xda, 0xc4, 0xeb, 0x94, 0xaa,
+	0xd1, 0x39, 0x59, 0x27, 0x56, 0x0b, 0x7b, 0xc4, 0x68, 0xb5, 0x19, 0xa3, 0x7a, 0xa5, 0x9f, 0xe0,
+	0xa9, 0x6b, 0xb4, 0xdb, 0xd8, 0xf5, 0xf8, 0xfb, 0xab, 0x0c, 0xc0, 0xb1, 0xd3, 0x6a, 0x39, 0xf6,
+	0x7a, 0xbb, 0xd9, 0x39, 0xb5, 0x82, 0x3f, 0x9c, 0x62, 0x39, 0x46, 0xc1, 0xfe, 0xb0, 0x57, 0xda,
+	0x0e, 0x2c, 0xec, 0xb8, 0xd8, 0x20, 0x78, 0xbb, 0x63, 0x9b, 0x4d, 0x5c, 0xc3, 0x3f, 0xec, 0x60,
+	0x8f, 0xa0, 0x35, 0x98, 0x6c, 0xd0, 0x81, 0xb2, 0x72, 0x55, 0x79, 0x7d, 0xba, 0x7a, 0xa9, 0xc2,
+	0xd0, 0x73, 0x5e, 0x4e, 0xcc, 0x69, 0xb4, 0x5d, 0xb8, 0x14, 0x17, 0xe2, 0xb5, 0x1d, 0xdb, 0xc3,
+	0x39, 0xa5, 0x6c, 0x02, 0xfa, 0x10, 0x93, 0xe3, 0xb3, 0x38, 0x92, 0xd7, 0x60, 0x8e, 0xb8, 0x1d,
+	0x8f, 0xe8, 0xa6, 0xd3, 0x32, 0x2c, 0x5b, 0xb7, 0x4c, 0x2a, 0xac, 0x58, 0x9b, 0xa5, 0xc3, 0xbb,
+	0x74, 0xf4, 0xc0, 0xf4, 0x0d, 0x89, 0x71, 0x0f, 0x05, 0xe1, 0x25, 0x58, 0xd8, 0x71, 0x3a, 0x36,
+	0x61, 0xc3, 0x1e, 0xc7, 0xa0, 0xdd, 0x84, 0x4b, 0xf1, 0x61, 0x2e, 0xbc, 0x0c, 0x17, 0x18, 0xa3,
+	0x47, 0xa5, 0x4f, 0xd4, 0x82, 0x47, 0xed, 0x11, 0xa0, 0x43, 0xcb, 0xeb, 0x93, 0x83, 0xb6, 0x01,
+	0xda, 0xc6, 0xa9, 0x65, 0x1b, 0xc4, 0x72, 0x6c, 0x0e, 0x48, 0xab, 0x88, 0xfd, 0xa2, 0xf2, 0xa0,
+	0x47, 0x59, 0x8b, 0x70, 0x69, 0x3f, 0x53, 0x60, 0x21, 0x26, 0x9a, 0x63, 0xa9, 0x44, 0xb1, 0x14,
+	0xa4, 0x96, 0x06, 0x44, 0x7d, 0x58, 0xc6, 0x86, 0xc2, 0xf2, 0x63, 0x58, 0x78, 0xd8, 0x36, 0xcf,
+	0xe7, 0x3c, 0x68, 0x03, 0xc0, 0xb2, 0xdb, 0x1d, 0xa2, 0xb7, 0x0c, 0xef, 0x31, 0x07, 0x52, 0x16,
+	0x71, 0xdc, 0x33, 0xbc, 0xc7, 0xb5, 0x22, 0xa5, 0xf5, 0x7f, 0xfa, 0x5e, 0x17, 0xd7, 0x3e, 0xd4,
+	0x92, 0x7f, 0x00, 0xf3, 0x75, 0x4c, 0xce, 0xe3, 0xfd, 0x5b, 0x70, 0x31, 0x22, 0x61, 0x28, 0x10,
+	0x3b, 0xb0, 0xb0, 0xd5, 0x6e, 0x63, 0xdb, 0x3c, 0xe7, 0x2e, 0x8c, 0x0b, 0x19, 0x0a, 0xca, 0x9f,
+	0x15, 0x58, 0xd8, 0xc5, 0x4d, 0x4c, 0xf0, 0x50, 0xfb, 0x10, 0xed, 0xc2, 0x78, 0xcb, 0x31, 0x31,
+	0x5d, 0xc8, 0x52, 0xf5, 0xa6, 0xcc, 0xa3, 0x04, 0x2a, 0x2a, 0xf7, 0x1c, 0x13, 0xd7, 0x28, 0xb7,
+	0x76, 0x13, 0xc6, 0xfd, 0x27, 0x34, 0x03, 0x53, 0xb5, 0xbd, 0xfa, 0x51, 0xed, 0x60, 0xe7, 0x68,
+	0xfe, 0xff, 0x10, 0xc0, 0xe4, 0xee, 0xde, 0xe1, 0xde, 0xd1, 0xde, 0xbc, 0x82, 0x4a, 0x00, 0xbb,
+	0x07, 0xf5, 0xfa, 0xc7, 0x3b, 0x07, 0x5b, 0x47, 0x7b, 0xf3, 0x63, 0xbe, 0xf5, 0x71, 0x99, 0x43,
+	0x59, 0x7f, 0x0c, 0xe8, 0x81, 0xdb, 0xb1, 0x87, 0xb4, 0xfd, 0x1a, 0x94, 0xf0, 0x33, 0x5f, 0xba,
+	0xa7, 0x37, 0xf0, 0x89, 0xe3, 0xb2, 0x59, 0x28, 0xd4, 0x66, 0xf9, 0xe8, 0x36, 0x1d, 0xd4, 0x36,
+	0x61, 0x21, 0xa6, 0x84, 0x23, 0xbd, 0x06, 0x25, 0x86, 0x42, 0x3f, 0x3e, 0x33, 0xec, 0x53, 0xcc,
+	0x94, 0x4c, 0xd5, 0x66, 0xd9, 0xe8, 0x0e, 0x1b, 0xd4, 0x1a, 0x30, 0x7b, 0xdf, 0x31, 0x71, 0x1d,
+	0x37, 0xf1, 0x31, 0x71, 0x5c, 0x0f, 0x5d, 0x86, 0xa2, 0xd7, 0xb6, 0x4e, 0x4e, 0x70, 0x88, 0x6b,
+	0x8a, 0x0d, 0x1c, 0x98, 0xe8, 0x2d, 0x28, 0x7a, 0x01, 0x65, 0x79, 0x8c, 0x06, 0x86, 0xc5, 0xf8,
+	0x0c, 0x04, 0x82, 0x6a, 0x21, 0xa1, 0xf6, 0x03, 0x58, 0xaa, 0x63, 0x12, 0x53, 0x13, 0xcc, 0xc5,
+	0x4e, 0x54, 0x20, 0x9b, 0xd2, 0x6b, 0xb2, 0x45, 0x8e, 0x0b, 0x88, 0xc8, 0x57, 0xa1, 0x9c, 0x94,
+	0xcf, 0xa6, 0x41, 0xfb, 0x3e, 0x2c, 0xed, 0x4b, 0x74, 0xa7, 0x5a, 0x7a, 0x0d, 0x4a, 0xc4, 0x69,
+	0x62, 0xd7, 0x20, 0x58, 0xf7, 0x88, 0xd1, 0x64, 0x93, 0x3f, 0x55, 0x9b, 0x0d, 0x46, 0xeb, 0xfe,
+	0xa0, 0xa6, 0x43, 0x79, 0x5f, 0xa2, 0x7a, 0x34, 0xb6, 0x3d, 0x83, 0xb2, 0x1f, 0x9f, 0x85, 0x06,
+	0x24, 0x31, 0x2a, 0x02, 0x8c, 0xe8, 0x6d, 0x98, 0x7a, 0x62, 0x34, 0x2d, 0x53, 0x37, 0x48, 0xb9,
+	0x40, 0x61, 0xa8, 0x15, 0x76, 0x08, 0xa8, 0x04, 0x87, 0x80, 0xca, 0x51, 0x70, 0x4a, 0xa8, 0x5d,
+	0xa0, 0xb4, 0x5b, 0x44, 0xfb, 0x1c, 0x96, 0x05, 0x9a, 0xc5, 0xb6, 0x15, 0x86, 0xb2, 0xed, 0x10,
+	0x54, 0x96, 0xe8, 0xb7, 0x08, 0xc1, 0x1e, 0xc1, 0xa6, 0x4f, 0x19, 0x49, 0x41, 0xe3, 0xb6, 0x63,
+	0x32, 0x9b, 0x7c, 0xc8, 0x31, 0x37, 0x8b, 0x71, 0x50, 0x3a, 0x6d, 0x03, 0xca, 0x34, 0x65, 0xc7,
+	0x85, 0x0d, 0x5e, 0x6a, 0xed, 0x3b, 0xb0, 0x2c, 0x60, 0x1c, 0x12, 0xc5, 0xd7, 0x63, 0xb0, 0x4c,
+	0xb3, 0x7b, 0xf4, 0x5d, 0x6f, 0xc5, 0x2a, 0xb0, 0xd0, 0xe8, 0xea, 0x06, 0x7d, 0x45, 0x93, 0x9e,
+	0x4e, 0xba, 0x6d, 0xcc, 0x11, 0x5d, 0x6c, 0x74, 0xb7, 0xc2, 0x37, 0x47, 0xdd, 0xb6, 0x9f, 0xcd,
+	0x8a, 0x8d, 0xae, 0xde, 0x30, 0x6c, 0x1b, 0x9b, 0xe5, 0x31, 0xc9, 0xda, 0x6d, 0x3b, 0x4e, 0xf3,
+	0x7b, 0x46, 0xb3, 0x83, 0x6b, 0x53, 0x8d, 0xee, 0x36, 0xa5, 0x45, 0xfb, 0x70, 0xb1, 0xd1, 0xd5,
+	0xfb, 0xc2, 0x07, 0x5b, 0xfc, 0xcb, 0x09, 0x01, 0x07, 0x36, 0xb9, 0xf3, 0x16, 0x93, 0x30, 0xd7,
+	0xe8, 0xee, 0x45, 0xa3, 0x0b, 0xda, 0x83, 0xf9, 0x88, 0x20, 0xe3, 0x84, 0x60, 0xb7, 0x3c, 0x3e,
+	0x58, 0x4e, 0xa9, 0x27, 0x67, 0xcb, 0x67, 0xd1, 0xaa, 0xa0, 0x8a, 0x66, 0x85, 0x4f, 0xf2, 0x25,
+	0x98, 0xf0, 0x27, 0x2f, 0x38, 0xf7, 0xb0, 0x07, 0x7f, 0x5d, 0x44, 0xee, 0x11, 0xcc, 0x64, 0xbe,
+	0x75, 0xf9, 0x47, 0x81, 0x6d, 0x24, 0xe1, 0xb2, 0x08, 0x67, 0x4b, 0x19, 0x62, 0xb6, 0x46, 0x70,
+	0x0c, 0x92, 0xf9, 0x48, 0x41, 0xe6, 0x23, 0x1f, 0x53, 0xf0, 0xc1, 0xae, 0xd2, 0x5b, 0x06, 0x39,
+	0x3e, 0xe3, 0x4b, 0xb4, 0x2a, 0x53, 0xbd, 0xdd, 0x0d, 0x37, 0xe4, 0x5c, 0xa3, 0xf7, 0x70, 0xcf,
+	0xe7, 0x8d, 0x3b, 0xdd, 0x44, 0x0e, 0xa7, 0xfb, 0x06, 0xcc, 0x9d, 0xf8, 0x1b, 0x49, 0x0f, 0x43,
+	0xc3, 0x24, 0x0d, 0x48, 0x25, 0x3a, 0x1c, 0xe6, 0x18, 0x91, 0x53, 0x5d, 0xc8, 0xef, 0x54, 0xbf,
+	0x51, 0x58, 0x88, 0x12, 0x3b, 0xd5, 0xcd, 0xd0, 0xa9, 0x0a, 0x03, 0x5c, 0x84, 0x11, 0x8e, 0xe4,
+	0x10, 0xfb, 0x87, 0x31, 0x58, 0x66, 0xe7, 0xc8, 0xbc, 0x71, 0x08, 0xad, 0x01, 0x3a, 0xc6, 0x2e,
+	0xd1, 0x3d, 0xec, 0x5a, 0x46, 0x53, 0xb7, 0x3b, 0xad, 0x06, 0x76, 0x29, 0x8c, 0x62, 0x6d, 0xde,
+	0x7f, 0x53, 0xa7, 0x2f, 0xee, 0xd3, 0x71, 0xf4, 0x2a, 0x94, 0x28, 0xb5, 0xed, 0x10, 0x3e, 0x83,
+	0x05, 0x7a, 0x3a, 0x98, 0xf1, 0x47, 0xef, 0x3b, 0x84, 0x4e, 0x11, 0xba, 0x0d, 0x8b, 0x36, 0x7e,
+	0xaa, 0x0b, 0xe4, 0x8e, 0x53, 0xb9, 0x0b, 0x36, 0x7e, 0xba, 0xd3, 0x2f, 0xfa, 0x06, 0xa0, 0x1e,
+	0x53, 0x28, 0x7e, 0x82, 0x8a, 0x9f, 0xe3, 0x0c, 0x3d, 0x0d, 0xef, 0xc5, 0x0e, 0xdc, 0x93, 0x74,
+	0xd2, 0xae, 0xc8, 0xe7, 0xba, 0xff, 0xd8, 0x7d, 0x08, 0xaa, 0x68, 0xba, 0x86, 0x8c, 0xbe, 0xef,
+	0xc0, 0x32, 0x3b, 0xb6, 0xe5, 0x4e, 0x02, 0x87, 0xa0, 0x8a, 0x38, 0x87, 0xc4, 0xf1, 0x09, 0x5c,
+	0x61, 0xa1, 0xab, 0x86, 0x4f, 0x2d, 0x8f, 0xb8, 0xd4, 0x37, 0xf6, 0x6c, 0xe2, 0x76, 0x03, 0x30,
+	0x6f, 0xc3, 0x04, 0xf6, 0x9f, 0xb9, 0xc8, 0x95, 0xb8, 0xc8, 0x24, 0x1b, 0xa3, 0xd6, 0x1e, 0xc1,
+	0x8a, 0x54, 0x30, 0xc7, 0x3a, 0xa4, 0xe4, 0x77, 0xe1, 0xff, 0x69, 0x16, 0x94, 0x22, 0x5e, 0x86,
+	0x29, 0x4a, 0x19, 0xce, 0xde, 0x05, 0xfa, 0x7c, 0x60, 0xfa, 0xe6, 0xca, 0x78, 0xcf, 0x07, 0xea,
+	0x6f, 0x0a, 0x4c, 0x47, 0x62, 0x55, 0xfc, 0xfc, 0xa9, 0x64, 0x3c, 0x7f, 0xa2, 0x7d, 0x98, 0x60,
+	0x51, 0x91, 0x7d, 0x45, 0xdc, 0xca, 0x10, 0x15, 0x2b, 0x34, 0x14, 0x6e, 0xe3, 0x33, 0xe3, 0x89,
+	0xe5, 0xb8, 0x35, 0xc6, 0xaf, 0x55, 0x61, 0x36, 0x36, 0x8e, 0xe6, 0x60, 0xfa, 0xde, 0xd6, 0xd1,
+	0xce, 0x47, 0xfa, 0xde, 0xa3, 0x2d, 0xfa, 0x4d, 0x31, 0x0f, 0x33, 0x6c, 0xa0, 0xfe, 0x70, 0xbb,
+	0xbe, 0x77, 0x34, 0xaf, 0x68, 0xef, 0x03, 0x84, 0xa1, 0xc2, 0xcf, 0x74, 0xc4, 0x79, 0x8c, 0x6d,
+	0x3e, 0x83, 0xec, 0xc1, 0xf7, 0xcc, 0xb6, 0x71, 0x8a, 0x75, 0xcf, 0xfa, 0x92, 0x9d, 0x33, 0x27,
+	0x6a, 0x53, 0xfe, 0x40, 0xdd, 0xfa, 0x12, 0x6b, 0xaf, 0xc0, 0x0a, 0x4d, 0x9d, 0xfd, 0x93, 0x64,
+	0x85, 0x15, 0x85, 0x4d, 0xb8, 0x2a, 0x27, 0x09, 0xab, 0x0b, 0x98, 0x0d, 0x05, 0xd5, 0x05, 0xfe,
+	0xa8, 0xfd, 0x73, 0x0c, 0xae, 0xf8, 0x61, 0x54, 0xae, 0x00, 0x7d, 0x0b, 0x66, 0x1a, 0x5d, 0xbd,
+	0x6d, 0xb8, 0xd8, 0x26, 0xc1, 0xfa, 0x4f, 0x57, 0x5f, 0x4e, 0x04, 0xeb, 0x3a, 0x71, 0x2d, 0xfb,
+	0x94, 0x45, 0x6b, 0x68, 0x74, 0x1f, 0x50, 0x86, 0x03, 0x13, 0x7d, 0x48, 0xf9, 0xa3, 0x9f, 0x0e,
+	0x99, 0xd3, 0xd3, 0x74, 0x98, 0x9e, 0x3c, 0x8e, 0x23, 0xdc, 0xc5, 0x85, 0x6c, 0x38, 0xea, 0x41,
+	0x88, 0x8d, 0x47, 0xf8, 0xf1, 0xa1, 0xf2, 0x73, 0xf2, 0xd4, 0x3d, 0x21, 0xfa, 0x32, 0xf8, 0xbd,
+	0x02, 0x2b, 0xd2, 0x59, 0xe5, 0x6b, 0xf2, 0xcd, 0xe8, 0x9a, 0x14, 0xb2, 0xec, 0x8b, 0x80, 0x7e,
+	0x24, 0xb9, 0xea, 0xd7, 0x0a, 0x5c, 0x61, 0xc1, 0x77, 0xc4, 0x61, 0x0a, 0x6d, 0xc0, 0x78, 0xa4,
+	0xfe, 0xb2, 0x3a, 0x80, 0x8b, 0xe6, 0x04, 0xca, 0xe0, 0xc7, 0x37, 0x29, 0xa2, 0xf3, 0x85, 0x92,
+	0xbb, 0x70, 0x85, 0x05, 0xf8, 0x61, 0x02, 0xdc, 0x23, 0x58, 0x91, 0x32, 0x9f, 0x0f, 0xd6, 0x47,
+	0xb0, 0x42, 0xbf, 0xde, 0x53, 0x36, 0x5f, 0xb2, 0x0e, 0xa0, 0x88, 0xea, 0x00, 0x1a, 0x5c, 0x95,
+	0x4b, 0xe2, 0x5f, 0xc3, 0x7f, 0x57, 0xa0, 0xf8, 0x6d, 0xc7, 0xb2, 0x8f, 0x68, 0xd8, 0x11, 0x07,
+	0xa3, 0x45, 0x98, 0xa4, 0x82, 0xbb, 0xbc, 0xdc, 0xc0, 0x9f, 0xfc, 0xe9, 0x69, 0x19, 0xcf, 0xf4,
+	0x8e, 0x87, 0x3d, 0xba, 0xef, 0x26, 0x6a, 0x17, 0x5a, 0xc6, 0xb3, 0x87, 0x1e, 0xf6, 0x10, 0x82,
+	0x71, 0x3a, 0x3c, 0x4e, 0x87, 0xe9, 0x6f, 0xb4, 0x0a, 0xb3, 0x46, 0xb3, 0xe9, 0x3c, 0xc5, 0xa6,
+	0x7e, 0x6c, 0x99, 0xae, 0x57, 0x9e, 0xb8, 0x5a, 0x78, 0xbd, 0x58, 0x9b, 0xe1, 0x83, 0x3b, 0x96,
+	0xd9, 0x1f, 0xcf, 0x27, 0xb3, 0xd6, 0x13, 0x3e, 0x85, 0x45, 0x96, 0x04, 0x7b, 0xa6, 0x04, 0x53,
+	0xf5, 0x01, 0xc0, 0x17, 0x8e, 0x65, 0xeb, 0xa1, 0x59, 0xd3, 0xd5, 0x57, 0x64, 0xbb, 0x22, 0xe4,
+	0x2e, 0x7e, 0x11, 0xfc, 0xd4, 0x3e, 0x83, 0xa5, 0x84, 0x6c, 0xbe, 0xc2, 0xe7, 0x17, 0xfe, 0x26,
+	0xbc, 0x44, 0xf3, 0x64, 0x02, 0xb7, 0x70, 0x25, 0x7c, 0x3b, 0xfb, 0xc9, 0x47, 0x06, 0xa5, 0x02,
+	0x8b, 0xcc, 0xa3, 0x33, 0x62, 0xf9, 0x0c, 0x96, 0x12, 0xf4, 0x23, 0x03, 0x73, 0x03, 0x16, 0x1e,
+	0x7a, 0x59, 0x91, 0x3c, 0x82, 0x4b, 0x0f, 0xbd, 0xe7, 0x02, 0xe3, 0x7d, 0x58, 0xa4, 0x3b, 0xa8,
+	0xf7, 0x32, 0xef, 0x16, 0x5c, 0x86, 0xa5, 0x84, 0x00, 0xbe, 0xf3, 0xfe, 0xad, 0xf8, 0x8b, 0x69,
+	0x62, 0xb6, 0x2f, 0x6b, 0xb8, 0x49, 0xff, 0x7a, 0x67, 0x56, 0x3b, 0x73, 0x3d, 0xd0, 0xff, 0x30,
+	0x64, 0x15, 0x3d, 0x6c, 0x9b, 0x6d, 0xc7, 0xb2, 0x89, 0xde, 0x71, 0x9b, 0xfc, 0x03, 0xe1, 0x22,
+	0x7b, 0xb5, 0xc7, 0xdf, 0x3c, 0x74, 0x9b, 0xe8, 0x0e, 0x2c, 0xf5, 0xd3, 0xb7, 0x5d, 0xe7, 0xc4,
+	0x6a, 0x06, 0x1f, 0x93, 0x2f, 0xc5, 0x79, 0x1e, 0xb0, 0x97, 0xfe, 0x77, 0x48, 0x8f, 0x21, 0x4c,
+	0xb5, 0xec, 0x7b, 0x61, 0x3e, 0x78, 0x13, 0xa4, 0x54, 0xed, 0x27, 0x0a, 0xa8, 0x62, 0xc3, 0xfc,
+	0xb0, 0x2e, 0x03, 0xcd, 0x0a, 0x55, 0xf9, 0x40, 0xb3, 0x02, 0x9c, 0x18, 0xb4, 0xf6, 0x4b, 0x05,
+	0x56, 0xd9, 0xc6, 0x15, 0x83, 0x09, 0x56, 0xf2, 0x14, 0x96, 0x4e, 0x7a, 0x04, 0xba, 0x1b, 0xa1,
+	0xe0, 0x2e, 0x53, 0x91, 0xb9, 0x8c, 0x44, 0xee, 0xe2, 0x89, 0x70, 0x5c, 0xfb, 0x95, 0x02, 0xaf,
+	0xa6, 0x03, 0xe2, 0x7e, 0xfb, 0xc2, 0x10, 0x1d, 0x82, 0x46, 0xc3, 0x49, 0xfa, 0x04, 0x65, 0xed,
+	0x90, 0xf9, 0x13, 0x9e, 0x2a, 0xee, 0x45, 0x9b, 0x77, 0x06, 0x9a, 0x7f, 0xde, 0x12, 0x73, 0x8d,
+	0xb4, 0x69, 0xf6, 0x2f, 0x05, 0x56, 0x53, 0x55, 0x71, 0xd3, 0x2d, 0x28, 0x4b, 0x4c, 0x0f, 0xce,
+	0x7b, 0x79, 0x6d, 0x5f, 0x12, 0xdb, 0x3e, 0x9a, 0xe3, 0xe0, 0x7f, 0x14, 0x58, 0x65, 0x87, 0xaf,
+	0xff, 0x8d, 0x2d, 0x84, 0xbe, 0x2b, 0xe8, 0xe5, 0x55, 0xf3, 0xc9, 0xee, 0x2f, 0x37, 0xf8, 0xbb,
+	0x32, 0xdd, 0xc6, 0x17, 0xed, 0xb6, 0xf7, 0x60, 0x95, 0x25, 0xd6, 0xd1, 0x6c, 0x4b, 0xdf, 0xc0,
+	0x74, 0x79, 0x2f, 0xda, 0xc0, 0xbf, 0x28, 0x30, 0x4b, 0x8f, 0xbc, 0x47, 0xb8, 0xd5, 0x6e, 0x1a,
+	0x04, 0xa3, 0x15, 0x98, 0x26, 0xfc, 0x77, 0x68, 0x07, 0x04, 0x43, 0x07, 0xa6, 0x5f, 0xdb, 0xea,
+	0x25, 0x1e, 0xbd, 0x6d, 0x90, 0x33, 0x9e, 0xe4, 0x66, 0x82, 0x72, 0xcd, 0x03, 0x83, 0x9c, 0xa1,
+	0x79, 0x28, 0x10, 0xd2, 0xe4, 0x67, 0x51, 0xff, 0xa7, 0x9f, 0xa6, 0x39, 0x08, 0xec, 0xe9, 0x4f,
+	0x2d, 0xe2, 0xd7, 0x41, 0xfd, 0x43, 0xe7, 0x6c, 0x6f, 0xf4, 0x13, 0x8b, 0x9c, 0xf9, 0x09, 0xce,
+	0xb4, 0x3d, 0xdd, 0x36, 0x5a, 0x58, 0x0f, 0xb4, 0x06, 0xe7, 0xd3, 0x79, 0xd3, 0xf6, 0xee, 0x1b,
+	0x2d, 0x1c, 0x80, 0xf5, 0xfc, 0x0f, 0xb9, 0x8b, 0x31, 0xfc, 0x34, 0xaf, 0x25, 0x21, 0xb2, 0x94,
+	0x26, 0x84, 0xc8, 0x32, 0x97, 0x04, 0x62, 0x81, 0x7d, 0x3d, 0x66, 0x81, 0x38, 0x4e, 0x49, 0x93,
+	0x10, 0xbf, 0x08, 0x1a, 0x29, 0x31, 0x9c, 0x81, 0xeb, 0x1c, 0x42, 0x89, 0x7d, 0xd7, 0x04, 0x82,
+	0x06, 0x35, 0xa3, 0xe2, 0x52, 0x66, 0x71, 0xf4, 0x51, 0x7b, 0x0c, 0x97, 0x85, 0xba, 0xb8, 0x5b,
+	0x8d, 0x56, 0xd9, 0x26, 0x6f, 0xcd, 0x08, 0xed, 0x1a, 0xe4, 0x46, 0xfe, 0xb4, 0x88, 0xb8, 0x9f,
+	0x0b, 0x52, 0x9d, 0x95, 0xa2, 0x63, 0x34, 0x23, 0x4d, 0x3a, 0x7f, 0x54, 0x40, 0x15, 0x69, 0xe0,
+	0xd6, 0xdc, 0x87, 0xb9, 0xb8, 0x35, 0x03, 0xdb, 0x72, 0x71, 0x73, 0x4a, 0x31, 0x73, 0x46, 0x93,
+	0x50, 0xfe, 0xa4, 0x04, 0xc5, 0xdd, 0xe7, 0xef, 0x97, 0xe8, 0x23, 0x41, 0xb2, 0xb8, 0x9e, 0x49,
+	0x52, 0x7f, 0x8e, 0xf8, 0x5a, 0x81, 0xcb, 0x42, 0xd8, 0xcf, 0xc3, 0x71, 0xfc, 0xa6, 0x09, 0xaf,
+	0xe9, 0xe8, 0x1d, 0xaa, 0xd4, 0xe4, 0x15, 0xc0, 0x12, 0x1f, 0x66, 0x50, 0x4c, 0xed, 0xbd, 0xa0,
+	0x42, 0x3d, 0xdc, 0x66, 0x78, 0x0c, 0x97, 0x85, 0xec, 0xcf, 0x65, 0x37, 0xac, 0xc2, 0xec, 0xb6,
+	0x71, 0xfc, 0xb8, 0xd3, 0x4b, 0x5f, 0x08, 0xc6, 0x7b, 0x41, 0xb2, 0x58, 0xa3, 0xbf, 0xb5, 0x79,
+	0x28, 0x05, 0x44, 0x0c, 0x44, 0xf5, 0xaf, 0xd7, 0xa1, 0xb8, 0x6b, 0x10, 0xa3, 0xee, 0x6b, 0x40,
+	0x16, 0xcc, 0x44, 0xef, 0x81, 0xa1, 0x1b, 0x32, 0x28, 0x82, 0x2b, 0x67, 0xea, 0x5a, 0x36, 0x62,
+	0x6e, 0xfd, 0x09, 0x4c, 0x47, 0xae, 0x7b, 0xa1, 0x37, 0xe4, 0xa9, 0xaf, 0xff, 0x46, 0x99, 0x7a,
+	0x23, 0x13, 0x6d, 0xef, 0x44, 0x38, 0x13, 0xbd, 0xfa, 0x95, 0x62, 0x52, 0xf2, 0xde, 0x98, 0xba,
+	0x96, 0x8d, 0x38, 0x34, 0x29, 0x72, 0xb1, 0x4b, 0x6e, 0x52, 0xf2, 0x62, 0x99, 0x7a, 0x23, 0x13,
+	0x6d, 0x68, 0x52, 0xf4, 0xde, 0x94, 0xdc, 0x24, 0xc1, 0xdd, 0x2e, 0x75, 0x2d, 0x1b, 0x31, 0x57,
+	0xf5, 0x39, 0x14, 0x7b, 0x57, 0xa3, 0xd0, 0xeb, 0x32, 0xd6, 0xfe, 0xfb, 0x57, 0xea, 0xf5, 0x0c,
+	0x94, 0xa1, 0x31, 0xd1, 0x4b, 0x4f, 0x72, 0x63, 0x04, 0xf7, 0xab, 0xd4, 0xb5, 0x6c, 0xc4, 0xa1,
+	0xaa, 0xe8, 0x0d, 0x23, 0xb9, 0x2a, 0xc1, 0xdd, 0x26, 0x75, 0x2d, 0x1b, 0x71, 0xe8, 0x0a, 0x91,
+	0x1b, 0x42, 0x72, 0x57, 0x48, 0xde, 0x55, 0x52, 0x6f, 0x64, 0xa2, 0xe5, 0x7a, 0x7e, 0x04, 0x28,
+	0xd9, 0xb0, 0x47, 0xb7, 0xd2, 0x77, 0xa2, 0xa0, 0x53, 0xa7, 0x56, 0xf3, 0xb0, 0x70, 0xe5, 0xcf,
+	0xe0, 0x62, 0xe2, 0x16, 0x07, 0xba, 0x99, 0xba, 0x39, 0x45, 0xaa, 0x6f, 0xe5, 0xe0, 0x88, 0x98,
+	0x9d, 0xb8, 0xdb, 0x90, 0x62, 0xb6, 0xec, 0x76, 0x88, 0x5a, 0xcd, 0xc3, 0x12, 0x9a, 0x9d, 0x68,
+	0x81, 0xcb, 0xcd, 0x96, 0xdd, 0x80, 0x50, 0x6f, 0xe5, 0xe0, 0x08, 0xcd, 0x4e, 0x76, 0x6e, 0xe5,
+	0x66, 0x4b, 0x9b, 0xe2, 0x6a, 0x35, 0x0f, 0x4b, 0xa8, 0x3c, 0xd9, 0xae, 0x95, 0x2b, 0x97, 0x36,
+	0x85, 0xd5, 0x6a, 0x1e, 0x16, 0xae, 0xbc, 0x43, 0x2f, 0x79, 0xc6, 0xaf, 0xcd, 0xad, 0xa7, 0x04,
+	0x19, 0xd1, 0xe5, 0x2d, 0xf5, 0x66, 0x76, 0x86, 0x50, 0xed, 0x7e, 0x66, 0xb5, 0xfb, 0x79, 0xd5,
+	0x4a, 0xaf, 0xb1, 0x71, 0x0f, 0x8b, 0xeb, 0x4d, 0xf5, 0x30, 0xa1, 0xe2, 0x5b, 0x39, 0x38, 0xb8,
+	0xe6, 0x9f, 0x2b, 0x41, 0x31, 0x3e, 0xd1, 0x3e, 0x41, 0x77, 0xd2, 0x43, 0x84, 0xac, 0xc9, 0xa3,
+	0x6e, 0xe4, 0xe6, 0xe3, 0x60, 0x7e, 0xaa, 0xf0, 0x6a, 0x7c, 0x12, 0xcb, 0xdb, 0xa9, 0x31, 0x43,
+	0x0a, 0xe5, 0x4e, 0x5e, 0x36, 0x8e, 0xe4, 0x17, 0x0a, 0x94, 0x65, 0xed, 0x5e, 0xb4, 0x91, 0x1a,
+	0x43, 0xe4, 0x5d, 0x26, 0xf5, 0x9d, 0xfc, 0x8c, 0x91, 0x65, 0x92, 0x74, 0x3a, 0xe5, 0xcb, 0x94,
+	0xde, 0x70, 0x56, 0x37, 0x72, 0xf3, 0x45, 0xc0, 0x48, 0x3a, 0x88, 0x72, 0x30, 0xe9, 0x4d, 0x50,
+	0x75, 0x23, 0x37, 0x5f, 0x04, 0x8c, 0xa4, 0x6f, 0x28, 0x07, 0x93, 0xde, 0xa5, 0x54, 0x37, 0x72,
+	0xf3, 0x45, 0xdc, 0x46, 0xd6, 0x20, 0x94, 0xbb, 0xcd, 0x80, 0xe6, 0xa4, 0xfa, 0x4e, 0x7e, 0x46,
+	0x8e, 0xc7, 0x85, 0xb9, 0xbe, 0x4e, 0x1b, 0xaa, 0xa4, 0x6f, 0xce, 0xfe, 0x06, 0x91, 0xba, 0x9e,
+	0x99, 0x9e, 0xeb, 0x74, 0xa0, 0x14, 0xef, 0xa8, 0xa1, 0x37, 0x53, 0x37, 0x61, 0x42, 0x63, 0x25,
+	0x2b, 0x79, 0x68, 0x64, 0x5f, 0xdb, 0x4c, 0x6e, 0xa4, 0xb8, 0x1f, 0xa7, 0xae, 0x67, 0xa6, 0x8f,
+	0x9c, 0xc8, 0x23, 0x0d, 0xb2, 0x94, 0x13, 0x79, 0xb2, 0xe7, 0xa6, 0xae, 0x65, 0x23, 0x0e, 0xcd,
+	0xeb, 0x6b, 0x78, 0xc9, 0xcd, 0x13, 0xb7, 0xd6, 0xd4, 0xf5, 0xcc, 0xf4, 0x5c, 0xe7, 0xef, 0x14,
+	0x78, 0x39, 0xad, 0xb1, 0x82, 0xee, 0xa6, 0x7b, 0x45, 0x6a, 0x9d, 0x55, 0xdd, 0x1c, 0x8e, 0x99,
+	0x63, 0xfb, 0xad, 0x02, 0x97, 0x53, 0x9a, 0x22, 0xe8, 0xdd, 0x54, 0xf7, 0x49, 0x47, 0x76, 0x77,
+	0x28, 0xde, 0x08, 0xb0, 0x94, 0x96, 0x85, 0x1c, 0xd8, 0xe0, 0x96, 0x8a, 0x7a, 0x77, 0x28, 0xde,
+	0xc8, 0x6a, 0xa6, 0x15, 0xe4, 0xe5, 0xab, 0x99, 0xa1, 0x55, 0xa1, 0x6e, 0x0e, 0xc7, 0x1c, 0xc1,
+	0x96, 0x56, 0x4b, 0x97, 0x63, 0xcb, 0x50, 0xd1, 0x57, 0x37, 0x87, 0x63, 0xe6, 0xd8, 0xbe, 0x0a,
+	0xfe, 0xd3, 0x2e, 0x5e, 0x5a, 0x1f, 0xf0, 0xe5, 0x24, 0x2a, 0x1d, 0xa9, 0xb7, 0x73, 0xf1, 0x84,
+	0x07, 0xf0, 0x64, 0x6d, 0x15, 0xa5, 0x7f, 0x3d, 0x09, 0xb5, 0x57, 0xf3, 0xb0, 0x84, 0xca, 0x93,
+	0xa5, 0x50, 0x94, 0x7a, 0xc2, 0x14, 0x16, 0x66, 0xd5, 0x6a, 0x1e, 0x96, 0x70, 0xe6, 0x05, 0xd5,
+	0x41, 0x34, 0xe0, 0x2b, 0x26, 0xdf, 0xcc, 0xa7, 0x95, 0x1f, 0xbf, 0x0a, 0xfe, 0xa3, 0x2a, 0xa3,
+	0x7e, 0x79, 0xd1, 0x50, 0xbd, 0x9d, 0x8b, 0x87, 0xeb, 0xff, 0x04, 0x26, 0x59, 0xd9, 0x0e, 0x49,
+	0x6b, 0x83, 0xb1, 0xda, 0x9f, 0xfa, 0xda, 0x20, 0x32, 0x2e, 0xf8, 0x53, 0x28, 0xee, 0x38, 0xf6,
+	0x89, 0x75, 0xda, 0x71, 0x31, 0xba, 0x16, 0xbf, 0x06, 0xc4, 0xff, 0x01, 0xb5, 0xf7, 0xbe, 0x5f,
+	0xb6, 0x94, 0xac, 0x57, 0x02, 0x99, 0xdd, 0xc7, 0xe4, 0x01, 0x7d, 0x7d, 0x60, 0x9f, 0x38, 0xe8,
+	0xba, 0x90, 0x31, 0x46, 0x13, 0xe8, 0x78, 0x23, 0x0b, 0x29, 0xd3, 0xb3, 0x7d, 0xe7, 0xd3, 0xb7,
+	0x4e, 0x2d, 0x72, 0xd6, 0x69, 0xf8, 0xd4, 0xeb, 0xac, 0x17, 0xb4, 0xce, 0xfe, 0x5f, 0x96, 0x5e,
+	0x4e, 0x5c, 0x17, 0xff, 0x7b, 0x6f, 0x63, 0x92, 0xbe, 0xbd, 0xfd, 0xdf, 0x01, 0x00, 0x06, 0x80,
+	0x10, 0x91, 0xff, 0x3b, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DataStoreClient is the client API for DataStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DataStoreClient interface {
+	// Creates a bundle
+	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error)
+	// Fetches a specific bundle
+	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
+	// Counts bundles (optionally filtered)
+	CountBundles(ctx context.Context, in *CountBundlesRequest, opts ...grpc.CallOption) (*CountBundlesResponse, error)
+	// Lists bundles (optionally filtered)
+	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
+	// Updates a specific bundle
+	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error)
+	// Sets bundle contents (creates if it does not exist)
+	SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error)
+	// Appends contents from a specific bundle (creates if it does not exist)
+	AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error)
+	// Deletes a specific bundle
+	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error)
+	// Prunes all expired certificates and JWT signing keys from a bundle
+	PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error)
+	// Creates an attested node
+	CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error)
+	// Fetches a specific attested node
+	FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error)
+	// Counts attested nodes
+	CountAttestedNodes(ctx context.Context, in *CountAttestedNodesRequest, opts ...grpc.CallOption) (*CountAttestedNodesResponse, error)
+	// Lists attested nodes (optionally filtered)
+	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
+	// Updates a specific attested node
+	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
+	// Deletes a specific attested node
+	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
+	// Sets the set of selectors for a specific node id
+	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
+	// Gets the set of node selectors for a specific node id
+	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
+	// Lists all node selectors
+	ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error)
+	// Creates a registration entry
+	CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error)
+	// Fetches a specific registration entry
+	FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error)
+	// Counts registration entries
+	CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error)
+	// Lists registration entries (optionally filtered)
+	ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error)
+	// Updates a specific registration entry
+	UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error)
+	// Deletes a specific registration entry
+	DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error)
+	// Prunes all registration entries that expire before the specified timestamp
+	PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error)
+	// Creates a join token
+	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
+	// Fetches a specific join token
+	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
+	// Delete a specific join token
+	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
+	// Uses a join token once, deleting it when it cannot be used anymore
+	UseJoinToken(ctx context.Context, in *UseJoinTokenRequest, opts ...grpc.CallOption) (*UseJoinTokenResponse, error)
+	// Prunes all join tokens that expire before the specified timestamp
+	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
+	// Creates a federation relationship
+	CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest, opts ...grpc.CallOption) (*CreateFederationRelationshipResponse, error)
+	// Fetches a specific federation relationship
+	FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest, opts ...grpc.CallOption) (*FetchFederationRelationshipResponse, error)
+	// Lists federation relationships
+	ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error)
+	// Updates a specific federation relationship
+	UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*UpdateFederationRelationshipResponse, error)
+	// Deletes a specific federation relationship
+	DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*DeleteFederationRelationshipResponse, error)
+	// Creates an entry template
+	CreateEntryTemplate(ctx context.Context, in *CreateEntryTemplateRequest, opts ...grpc.CallOption) (*CreateEntryTemplateResponse, error)
+	// Fetches a specific entry template
+	FetchEntryTemplate(ctx context.Context, in *FetchEntryTemplateRequest, opts ...grpc.CallOption) (*FetchEntryTemplateResponse, error)
+	// Lists entry templates
+	ListEntryTemplates(ctx context.Context, in *ListEntryTemplatesRequest, opts ...grpc.CallOption) (*ListEntryTemplatesResponse, error)
+	// Updates a specific entry template and the registration entries using it
+	UpdateEntryTemplate(ctx context.Context, in *UpdateEntryTemplateRequest, opts ...grpc.CallOption) (*UpdateEntryTemplateResponse, error)
+	// Deletes a specific entry template that no registration entry uses
+	DeleteEntryTemplate(ctx context.Context, in *DeleteEntryTemplateRequest, opts ...grpc.CallOption) (*DeleteEntryTemplateResponse, error)
+	// Writes a consistent copy of the database to a file while it is in use
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
 	// Applies the plugin configuration
 	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
@@ -3089,144 +4505,252 @@ func (c *dataStoreClient) ListAttestedNodes(ctx context.Context, in *ListAtteste
 	return out, nil
 }
 
-func (c *dataStoreClient) UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error) {
-	out := new(UpdateAttestedNodeResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateAttestedNode", in, out, opts...)
+func (c *dataStoreClient) UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error) {
+	out := new(UpdateAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateAttestedNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error) {
+	out := new(DeleteAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteAttestedNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error) {
+	out := new(SetNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetNodeSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error) {
+	out := new(GetNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/GetNodeSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error) {
+	out := new(ListNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListNodeSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error) {
+	out := new(CreateRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateRegistrationEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error) {
+	out := new(FetchRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchRegistrationEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error) {
+	out := new(CountRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CountRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error) {
+	out := new(ListRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error) {
+	out := new(UpdateRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateRegistrationEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error) {
+	out := new(DeleteRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteRegistrationEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error) {
+	out := new(PruneRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error) {
+	out := new(CreateJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateJoinToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error) {
-	out := new(DeleteAttestedNodeResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteAttestedNode", in, out, opts...)
+func (c *dataStoreClient) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error) {
+	out := new(FetchJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchJoinToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error) {
-	out := new(SetNodeSelectorsResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetNodeSelectors", in, out, opts...)
+func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
+	out := new(DeleteJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteJoinToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error) {
-	out := new(GetNodeSelectorsResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/GetNodeSelectors", in, out, opts...)
+func (c *dataStoreClient) UseJoinToken(ctx context.Context, in *UseJoinTokenRequest, opts ...grpc.CallOption) (*UseJoinTokenResponse, error) {
+	out := new(UseJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UseJoinToken", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error) {
-	out := new(ListNodeSelectorsResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListNodeSelectors", in, out, opts...)
+func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
+	out := new(PruneJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error) {
-	out := new(CreateRegistrationEntryResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateRegistrationEntry", in, out, opts...)
+func (c *dataStoreClient) CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest, opts ...grpc.CallOption) (*CreateFederationRelationshipResponse, error) {
+	out := new(CreateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateFederationRelationship", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error) {
-	out := new(FetchRegistrationEntryResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchRegistrationEntry", in, out, opts...)
+func (c *dataStoreClient) FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest, opts ...grpc.CallOption) (*FetchFederationRelationshipResponse, error) {
+	out := new(FetchFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchFederationRelationship", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error) {
-	out := new(CountRegistrationEntriesResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CountRegistrationEntries", in, out, opts...)
+func (c *dataStoreClient) ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error) {
+	out := new(ListFederationRelationshipsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListFederationRelationships", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error) {
-	out := new(ListRegistrationEntriesResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListRegistrationEntries", in, out, opts...)
+func (c *dataStoreClient) UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*UpdateFederationRelationshipResponse, error) {
+	out := new(UpdateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateFederationRelationship", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error) {
-	out := new(UpdateRegistrationEntryResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateRegistrationEntry", in, out, opts...)
+func (c *dataStoreClient) DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*DeleteFederationRelationshipResponse, error) {
+	out := new(DeleteFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteFederationRelationship", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error) {
-	out := new(DeleteRegistrationEntryResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteRegistrationEntry", in, out, opts...)
+func (c *dataStoreClient) CreateEntryTemplate(ctx context.Context, in *CreateEntryTemplateRequest, opts ...grpc.CallOption) (*CreateEntryTemplateResponse, error) {
+	out := new(CreateEntryTemplateResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateEntryTemplate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error) {
-	out := new(PruneRegistrationEntriesResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneRegistrationEntries", in, out, opts...)
+func (c *dataStoreClient) FetchEntryTemplate(ctx context.Context, in *FetchEntryTemplateRequest, opts ...grpc.CallOption) (*FetchEntryTemplateResponse, error) {
+	out := new(FetchEntryTemplateResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchEntryTemplate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error) {
-	out := new(CreateJoinTokenResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateJoinToken", in, out, opts...)
+func (c *dataStoreClient) ListEntryTemplates(ctx context.Context, in *ListEntryTemplatesRequest, opts ...grpc.CallOption) (*ListEntryTemplatesResponse, error) {
+	out := new(ListEntryTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListEntryTemplates", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error) {
-	out := new(FetchJoinTokenResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchJoinToken", in, out, opts...)
+func (c *dataStoreClient) UpdateEntryTemplate(ctx context.Context, in *UpdateEntryTemplateRequest, opts ...grpc.CallOption) (*UpdateEntryTemplateResponse, error) {
+	out := new(UpdateEntryTemplateResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateEntryTemplate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
-	out := new(DeleteJoinTokenResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteJoinToken", in, out, opts...)
+func (c *dataStoreClient) DeleteEntryTemplate(ctx context.Context, in *DeleteEntryTemplateRequest, opts ...grpc.CallOption) (*DeleteEntryTemplateResponse, error) {
+	out := new(DeleteEntryTemplateResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteEntryTemplate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
-	out := new(PruneJoinTokensResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
+func (c *dataStoreClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/Backup", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3309,8 +4833,32 @@ type DataStoreServer interface {
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+	// Uses a join token once, deleting it when it cannot be used anymore
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
+	// Creates a federation relationship
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
+	// Fetches a specific federation relationship
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
+	// Lists federation relationships
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
+	// Updates a specific federation relationship
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
+	// Deletes a specific federation relationship
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
+	// Creates an entry template
+	CreateEntryTemplate(context.Context, *CreateEntryTemplateRequest) (*CreateEntryTemplateResponse, error)
+	// Fetches a specific entry template
+	FetchEntryTemplate(context.Context, *FetchEntryTemplateRequest) (*FetchEntryTemplateResponse, error)
+	// Lists entry templates
+	ListEntryTemplates(context.Context, *ListEntryTemplatesRequest) (*ListEntryTemplatesResponse, error)
+	// Updates a specific entry template and the registration entries using it
+	UpdateEntryTemplate(context.Context, *UpdateEntryTemplateRequest) (*UpdateEntryTemplateResponse, error)
+	// Deletes a specific entry template that no registration entry uses
+	DeleteEntryTemplate(context.Context, *DeleteEntryTemplateRequest) (*DeleteEntryTemplateResponse, error)
+	// Writes a consistent copy of the database to a file while it is in use
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
 	// Applies the plugin configuration
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
@@ -3405,9 +4953,45 @@ func (*UnimplementedDataStoreServer) FetchJoinToken(ctx context.Context, req *Fe
 func (*UnimplementedDataStoreServer) DeleteJoinToken(ctx context.Context, req *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteJoinToken not implemented")
 }
+func (*UnimplementedDataStoreServer) UseJoinToken(ctx context.Context, req *UseJoinTokenRequest) (*UseJoinTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseJoinToken not implemented")
+}
 func (*UnimplementedDataStoreServer) PruneJoinTokens(ctx context.Context, req *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PruneJoinTokens not implemented")
 }
+func (*UnimplementedDataStoreServer) CreateFederationRelationship(ctx context.Context, req *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) FetchFederationRelationship(ctx context.Context, req *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) ListFederationRelationships(ctx context.Context, req *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederationRelationships not implemented")
+}
+func (*UnimplementedDataStoreServer) UpdateFederationRelationship(ctx context.Context, req *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) DeleteFederationRelationship(ctx context.Context, req *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) CreateEntryTemplate(ctx context.Context, req *CreateEntryTemplateRequest) (*CreateEntryTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEntryTemplate not implemented")
+}
+func (*UnimplementedDataStoreServer) FetchEntryTemplate(ctx context.Context, req *FetchEntryTemplateRequest) (*FetchEntryTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchEntryTemplate not implemented")
+}
+func (*UnimplementedDataStoreServer) ListEntryTemplates(ctx context.Context, req *ListEntryTemplatesRequest) (*ListEntryTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntryTemplates not implemented")
+}
+func (*UnimplementedDataStoreServer) UpdateEntryTemplate(ctx context.Context, req *UpdateEntryTemplateRequest) (*UpdateEntryTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntryTemplate not implemented")
+}
+func (*UnimplementedDataStoreServer) DeleteEntryTemplate(ctx context.Context, req *DeleteEntryTemplateRequest) (*DeleteEntryTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntryTemplate not implemented")
+}
+func (*UnimplementedDataStoreServer) Backup(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
 func (*UnimplementedDataStoreServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
@@ -3923,6 +5507,24 @@ func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_UseJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UseJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UseJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UseJoinToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UseJoinToken(ctx, req.(*UseJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PruneJoinTokensRequest)
 	if err := dec(in); err != nil {
@@ -3941,6 +5543,204 @@ func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_CreateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateFederationRelationship(ctx, req.(*CreateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchFederationRelationship(ctx, req.(*FetchFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListFederationRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederationRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListFederationRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListFederationRelationships",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListFederationRelationships(ctx, req.(*ListFederationRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UpdateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateFederationRelationship(ctx, req.(*UpdateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/DeleteFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteFederationRelationship(ctx, req.(*DeleteFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateEntryTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEntryTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateEntryTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateEntryTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateEntryTemplate(ctx, req.(*CreateEntryTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchEntryTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchEntryTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchEntryTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchEntryTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchEntryTemplate(ctx, req.(*FetchEntryTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListEntryTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntryTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListEntryTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListEntryTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListEntryTemplates(ctx, req.(*ListEntryTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateEntryTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEntryTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateEntryTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UpdateEntryTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateEntryTemplate(ctx, req.(*UpdateEntryTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteEntryTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEntryTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteEntryTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/DeleteEntryTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteEntryTemplate(ctx, req.(*DeleteEntryTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/Backup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).Backup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(plugin.ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -4093,10 +5893,58 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteJoinToken",
 			Handler:    _DataStore_DeleteJoinToken_Handler,
 		},
+		{
+			MethodName: "UseJoinToken",
+			Handler:    _DataStore_UseJoinToken_Handler,
+		},
 		{
 			MethodName: "PruneJoinTokens",
 			Handler:    _DataStore_PruneJoinTokens_Handler,
 		},
+		{
+			MethodName: "CreateFederationRelationship",
+			Handler:    _DataStore_CreateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "FetchFederationRelationship",
+			Handler:    _DataStore_FetchFederationRelationship_Handler,
+		},
+		{
+			MethodName: "ListFederationRelationships",
+			Handler:    _DataStore_ListFederationRelationships_Handler,
+		},
+		{
+			MethodName: "UpdateFederationRelationship",
+			Handler:    _DataStore_UpdateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "DeleteFederationRelationship",
+			Handler:    _DataStore_DeleteFederationRelationship_Handler,
+		},
+		{
+			MethodName: "CreateEntryTemplate",
+			Handler:    _DataStore_CreateEntryTemplate_Handler,
+		},
+		{
+			MethodName: "FetchEntryTemplate",
+			Handler:    _DataStore_FetchEntryTemplate_Handler,
+		},
+		{
+			MethodName: "ListEntryTemplates",
+			Handler:    _DataStore_ListEntryTemplates_Handler,
+		},
+		{
+			MethodName: "UpdateEntryTemplate",
+			Handler:    _DataStore_UpdateEntryTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteEntryTemplate",
+			Handler:    _DataStore_DeleteEntryTemplate_Handler,
+		},
+		{
+			MethodName: "Backup",
+			Handler:    _DataStore_Backup_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _DataStore_Configure_Handler,
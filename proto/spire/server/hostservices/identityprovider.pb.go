@@ -73,9 +73,12 @@ func (m *X509Identity) GetPrivateKey() []byte {
 }
 
 type FetchX509IdentityRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// If true, the bundles of the federated trust domains are returned
+	// along with the bundle of the trust domain.
+	IncludeFederatedBundles bool     `protobuf:"varint,1,opt,name=include_federated_bundles,json=includeFederatedBundles,proto3" json:"include_federated_bundles,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
 }
 
 func (m *FetchX509IdentityRequest) Reset()         { *m = FetchX509IdentityRequest{} }
@@ -103,12 +106,20 @@ func (m *FetchX509IdentityRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_FetchX509IdentityRequest proto.InternalMessageInfo
 
+func (m *FetchX509IdentityRequest) GetIncludeFederatedBundles() bool {
+	if m != nil {
+		return m.IncludeFederatedBundles
+	}
+	return false
+}
+
 type FetchX509IdentityResponse struct {
-	Identity             *X509Identity  `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
-	Bundle               *common.Bundle `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Identity             *X509Identity    `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	Bundle               *common.Bundle   `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	FederatedBundles     []*common.Bundle `protobuf:"bytes,3,rep,name=federated_bundles,json=federatedBundles,proto3" json:"federated_bundles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *FetchX509IdentityResponse) Reset()         { *m = FetchX509IdentityResponse{} }
@@ -150,6 +161,13 @@ func (m *FetchX509IdentityResponse) GetBundle() *common.Bundle {
 	return nil
 }
 
+func (m *FetchX509IdentityResponse) GetFederatedBundles() []*common.Bundle {
+	if m != nil {
+		return m.FederatedBundles
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*X509Identity)(nil), "spire.server.hostservices.X509Identity")
 	proto.RegisterType((*FetchX509IdentityRequest)(nil), "spire.server.hostservices.FetchX509IdentityRequest")
@@ -161,26 +179,29 @@ func init() {
 }
 
 var fileDescriptor_40e70df5e9c153f0 = []byte{
-	// 303 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0x41, 0x4b, 0xfb, 0x40,
-	0x10, 0xc5, 0xd9, 0xff, 0x1f, 0x8a, 0x4e, 0x7b, 0xd0, 0xc5, 0x43, 0x1a, 0x10, 0x43, 0x2e, 0xe6,
-	0x20, 0xd9, 0x92, 0x2a, 0xd2, 0x6b, 0x0b, 0x82, 0x08, 0x22, 0x39, 0x89, 0x97, 0xd2, 0x24, 0x53,
-	0xb3, 0x68, 0xb3, 0xeb, 0xee, 0x26, 0x90, 0x8b, 0x1f, 0xc1, 0x83, 0x9f, 0x58, 0x92, 0x6c, 0xa5,
-	0xa2, 0x11, 0x3c, 0x25, 0xcc, 0xcc, 0xef, 0xf1, 0xde, 0xcc, 0xc2, 0x44, 0x4b, 0xae, 0x90, 0x69,
-	0x54, 0x15, 0x2a, 0x96, 0x0b, 0x6d, 0x9a, 0x5f, 0x9e, 0xa2, 0x66, 0x3c, 0xc3, 0xc2, 0x70, 0x53,
-	0x4b, 0x25, 0x2a, 0x9e, 0xa1, 0x0a, 0xa5, 0x12, 0x46, 0xd0, 0x71, 0x4b, 0x84, 0x1d, 0x11, 0xee,
-	0x12, 0x6e, 0xd7, 0x62, 0xa9, 0xd8, 0x6c, 0x44, 0x61, 0x3f, 0x1d, 0xe5, 0xdf, 0xc2, 0xe8, 0xfe,
-	0x62, 0x32, 0xbb, 0xb6, 0x9a, 0xf4, 0x18, 0x20, 0x45, 0x65, 0x96, 0x69, 0xbe, 0xe2, 0x85, 0x43,
-	0xbc, 0xff, 0xc1, 0x28, 0xde, 0x6f, 0x2a, 0x8b, 0xa6, 0x40, 0x4f, 0x60, 0x28, 0x15, 0xaf, 0x56,
-	0x06, 0x97, 0x4f, 0x58, 0x3b, 0xff, 0x3c, 0x12, 0x8c, 0x62, 0xb0, 0xa5, 0x1b, 0xac, 0x7d, 0x17,
-	0x9c, 0x2b, 0x34, 0x69, 0xbe, 0x2b, 0x1a, 0xe3, 0x4b, 0x89, 0xda, 0xf8, 0x6f, 0x04, 0xc6, 0x3f,
-	0x34, 0xb5, 0x14, 0x85, 0x46, 0xba, 0x80, 0xbd, 0x6d, 0x32, 0x87, 0x78, 0x24, 0x18, 0x46, 0xa7,
-	0x61, 0x6f, 0xa4, 0xf0, 0x8b, 0xc4, 0x27, 0x48, 0xcf, 0x60, 0x90, 0x94, 0x45, 0xf6, 0x8c, 0xad,
-	0xb5, 0x61, 0x74, 0x64, 0x25, 0x6c, 0xe6, 0x79, 0xdb, 0x8b, 0xed, 0x4c, 0xf4, 0x4e, 0xe0, 0x60,
-	0x2b, 0x72, 0x67, 0xb7, 0x49, 0x5f, 0xe1, 0xf0, 0x9b, 0x49, 0x3a, 0xfd, 0xc5, 0x4a, 0x5f, 0x5e,
-	0xf7, 0xfc, 0x6f, 0x50, 0xb7, 0x87, 0xf9, 0xec, 0xe1, 0xf2, 0x91, 0x9b, 0xbc, 0x4c, 0x1a, 0xd3,
-	0x4c, 0x4b, 0xbe, 0x5e, 0x23, 0xeb, 0x0e, 0xd8, 0x9e, 0x8c, 0xf5, 0xbe, 0x8c, 0x64, 0xd0, 0x0e,
-	0x4c, 0x3f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xd3, 0xe3, 0x62, 0xc4, 0x3d, 0x02, 0x00, 0x00,
+	// 347 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xc1, 0x4a, 0xfb, 0x40,
+	0x10, 0xc6, 0xc9, 0xbf, 0x50, 0xfa, 0x9f, 0xf6, 0xd0, 0x2e, 0x82, 0x69, 0x41, 0x0c, 0xbd, 0xd8,
+	0x83, 0x64, 0x4b, 0xab, 0x48, 0xbd, 0xd9, 0x42, 0x41, 0x04, 0x91, 0x1c, 0x44, 0xbc, 0x84, 0x36,
+	0x99, 0x98, 0xc5, 0x36, 0x1b, 0x77, 0x37, 0x85, 0x5e, 0x7c, 0x08, 0xdf, 0xcc, 0x37, 0x92, 0x64,
+	0x37, 0x52, 0xad, 0x11, 0x3c, 0x25, 0xcc, 0xcc, 0xef, 0x63, 0xbe, 0x6f, 0x07, 0x86, 0x32, 0x65,
+	0x02, 0xa9, 0x44, 0xb1, 0x41, 0x41, 0x63, 0x2e, 0x55, 0xfe, 0xcb, 0x02, 0x94, 0x94, 0x85, 0x98,
+	0x28, 0xa6, 0xb6, 0xa9, 0xe0, 0x1b, 0x16, 0xa2, 0x70, 0x53, 0xc1, 0x15, 0x27, 0xdd, 0x82, 0x70,
+	0x35, 0xe1, 0xee, 0x12, 0x3d, 0xdd, 0xa2, 0x01, 0x5f, 0xaf, 0x79, 0x62, 0x3e, 0x9a, 0xea, 0xdf,
+	0x42, 0xeb, 0xe1, 0x7c, 0x38, 0xb9, 0x36, 0x9a, 0xe4, 0x08, 0x20, 0x40, 0xa1, 0xfc, 0x20, 0x5e,
+	0xb0, 0xc4, 0xb6, 0x9c, 0xda, 0xa0, 0xe5, 0xfd, 0xcf, 0x2b, 0xb3, 0xbc, 0x40, 0x8e, 0xa1, 0x99,
+	0x0a, 0xb6, 0x59, 0x28, 0xf4, 0x9f, 0x71, 0x6b, 0xff, 0x73, 0xac, 0x41, 0xcb, 0x03, 0x53, 0xba,
+	0xc1, 0x6d, 0xff, 0x1e, 0xec, 0x39, 0xaa, 0x20, 0xde, 0x15, 0xf5, 0xf0, 0x25, 0x43, 0xa9, 0xc8,
+	0x25, 0x74, 0x59, 0x12, 0xac, 0xb2, 0x10, 0xfd, 0x08, 0x43, 0x14, 0x0b, 0x85, 0xa1, 0xbf, 0xcc,
+	0x92, 0x70, 0x85, 0xd2, 0xb6, 0x1c, 0x6b, 0xd0, 0xf0, 0x0e, 0xcd, 0xc0, 0xbc, 0xec, 0x4f, 0x75,
+	0xbb, 0xff, 0x6e, 0x41, 0xf7, 0x07, 0x61, 0x99, 0xf2, 0x44, 0x22, 0x99, 0x41, 0xa3, 0x4c, 0xa5,
+	0x10, 0x6a, 0x8e, 0x4e, 0xdc, 0xca, 0x38, 0xdc, 0x2f, 0x12, 0x9f, 0x20, 0x39, 0x85, 0xba, 0x5e,
+	0xa6, 0xb0, 0xd5, 0x1c, 0x1d, 0x18, 0x09, 0x93, 0x97, 0xde, 0xc4, 0x33, 0x33, 0xe4, 0x0a, 0x3a,
+	0xfb, 0x26, 0x6a, 0x4e, 0xad, 0x12, 0x6c, 0x47, 0xdf, 0x3c, 0x8d, 0xde, 0x2c, 0x68, 0x97, 0x7b,
+	0xdc, 0x99, 0xc7, 0x24, 0xaf, 0xd0, 0xd9, 0xf3, 0x49, 0xc6, 0xbf, 0xb8, 0xa9, 0x8a, 0xbb, 0x77,
+	0xf6, 0x37, 0x48, 0x47, 0x39, 0x9d, 0x3c, 0x5e, 0x3c, 0x31, 0x15, 0x67, 0xcb, 0x7c, 0x7d, 0x2a,
+	0x53, 0x16, 0x45, 0x48, 0xf5, 0xfd, 0x14, 0x17, 0x43, 0x2b, 0x0f, 0x73, 0x59, 0x2f, 0x06, 0xc6,
+	0x1f, 0x03, 0x00, 0x3f, 0x50, 0x22, 0x81, 0xbc, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
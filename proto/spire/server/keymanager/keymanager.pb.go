@@ -34,6 +34,7 @@ const (
 	KeyType_RSA_1024             KeyType = 3
 	KeyType_RSA_2048             KeyType = 4
 	KeyType_RSA_4096             KeyType = 5
+	KeyType_ED25519              KeyType = 6
 )
 
 var KeyType_name = map[int32]string{
@@ -43,6 +44,7 @@ var KeyType_name = map[int32]string{
 	3: "RSA_1024",
 	4: "RSA_2048",
 	5: "RSA_4096",
+	6: "ED25519",
 }
 
 var KeyType_value = map[string]int32{
@@ -52,6 +54,7 @@ var KeyType_value = map[string]int32{
 	"RSA_1024":             3,
 	"RSA_2048":             4,
 	"RSA_4096":             5,
+	"ED25519":              6,
 }
 
 func (x KeyType) String() string {
@@ -607,56 +610,56 @@ func init() {
 }
 
 var fileDescriptor_084159595519e72a = []byte{
-	// 774 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x6d, 0x6f, 0xd2, 0x50,
-	0x14, 0xa6, 0x8c, 0xd7, 0x53, 0x60, 0xcd, 0x75, 0x53, 0x82, 0x46, 0x49, 0x8d, 0x0b, 0x9b, 0x13,
-	0x58, 0x07, 0x38, 0xe3, 0x27, 0xc6, 0xd8, 0x20, 0x6c, 0x8e, 0x94, 0x99, 0xb8, 0xc5, 0xa4, 0xe9,
-	0xc6, 0xa5, 0x6d, 0x80, 0xb6, 0xf6, 0x16, 0x63, 0x13, 0xff, 0x97, 0x7f, 0xc8, 0x1f, 0xe1, 0x47,
-	0xd3, 0xf6, 0x96, 0x97, 0x4d, 0x36, 0x8c, 0x7e, 0xea, 0x39, 0xe7, 0x3e, 0xcf, 0x79, 0xee, 0x3d,
-	0xa7, 0xf7, 0x1e, 0x28, 0x10, 0x53, 0xb3, 0x70, 0x89, 0x60, 0xeb, 0x2b, 0xb6, 0x4a, 0x43, 0xec,
-	0x8c, 0x65, 0x5d, 0x56, 0x16, 0xcc, 0xa2, 0x69, 0x19, 0xb6, 0x81, 0x9e, 0x78, 0xc8, 0xa2, 0x8f,
-	0x2c, 0xce, 0x96, 0x73, 0x79, 0x3f, 0xc5, 0x8d, 0x31, 0x1e, 0x1b, 0x7a, 0xc9, 0x1c, 0x4d, 0x14,
-	0x2d, 0xf8, 0xf8, 0x54, 0x5e, 0x87, 0x64, 0x77, 0x72, 0x3d, 0xd2, 0x6e, 0x3a, 0xd8, 0x41, 0x19,
-	0x08, 0x6b, 0xfd, 0x2c, 0x93, 0x67, 0x0a, 0x49, 0x31, 0xac, 0xf5, 0x51, 0x05, 0x22, 0xb6, 0x63,
-	0xe2, 0x6c, 0x38, 0xcf, 0x14, 0x32, 0x42, 0xbe, 0xb8, 0x44, 0xa6, 0xd8, 0xc1, 0xce, 0x85, 0x63,
-	0x62, 0xd1, 0x43, 0xa3, 0xa7, 0x90, 0x34, 0x87, 0xda, 0x37, 0xa9, 0x2f, 0xdb, 0x72, 0x76, 0x2d,
-	0xcf, 0x14, 0x52, 0x62, 0xc2, 0x0d, 0x1c, 0xc9, 0xb6, 0xcc, 0xab, 0x80, 0x4e, 0xb0, 0x8e, 0x2d,
-	0xd9, 0xc6, 0x1d, 0xec, 0x88, 0xf8, 0xcb, 0x04, 0x13, 0x1b, 0x6d, 0x42, 0x6c, 0x88, 0x1d, 0x69,
-	0x2a, 0x1e, 0x1d, 0x62, 0xa7, 0xdd, 0x47, 0xef, 0x21, 0xe1, 0x86, 0xff, 0x6a, 0x0f, 0xf1, 0xa1,
-	0x6f, 0xf0, 0x9f, 0xe0, 0xd1, 0x82, 0x12, 0x31, 0x0d, 0x9d, 0x60, 0x54, 0x07, 0x30, 0xbd, 0x03,
-	0x4b, 0x43, 0xec, 0x78, 0x72, 0xac, 0xc0, 0x2f, 0xcd, 0x3a, 0xad, 0x8d, 0x98, 0x34, 0x03, 0x93,
-	0xdf, 0x75, 0x33, 0xdb, 0xb3, 0xa5, 0x7b, 0x0f, 0xc1, 0x5f, 0xc2, 0xc6, 0x22, 0xfa, 0xff, 0x6d,
-	0xe4, 0xf1, 0x62, 0x6a, 0x42, 0x77, 0xc2, 0x7f, 0x86, 0xcd, 0x5b, 0x71, 0xaa, 0xd9, 0x00, 0x76,
-	0xa6, 0x49, 0xb2, 0x4c, 0x7e, 0x6d, 0x45, 0x51, 0x98, 0x8a, 0x12, 0xfe, 0x3b, 0x40, 0xb7, 0xd7,
-	0x3b, 0x37, 0x6d, 0xcd, 0xd0, 0x09, 0x7a, 0x01, 0x2c, 0x91, 0x47, 0xb6, 0x34, 0xc2, 0xba, 0x62,
-	0xab, 0xde, 0x39, 0xa2, 0x22, 0xb8, 0xa1, 0x53, 0x2f, 0x82, 0xce, 0x20, 0xa3, 0xca, 0x44, 0x95,
-	0xe4, 0x91, 0x62, 0x58, 0x9a, 0xad, 0x8e, 0x69, 0x2b, 0xb7, 0x96, 0xca, 0xb6, 0x64, 0xa2, 0xd6,
-	0x03, 0xb4, 0x98, 0x56, 0xe7, 0x5d, 0xfe, 0x27, 0x03, 0xeb, 0x3d, 0x4d, 0xd1, 0xdd, 0xbf, 0xe9,
-	0x81, 0xdf, 0x07, 0x41, 0x64, 0xee, 0x1f, 0xf4, 0x6c, 0x74, 0xfe, 0x6f, 0xbb, 0x69, 0x85, 0x6e,
-	0xed, 0x07, 0x1d, 0x03, 0x6b, 0x12, 0x22, 0x19, 0x7e, 0x39, 0xb2, 0x11, 0xaf, 0x8f, 0x2f, 0x97,
-	0x97, 0x74, 0x5a, 0xb9, 0x56, 0x48, 0x04, 0x93, 0x10, 0xea, 0x1d, 0xa6, 0x81, 0x25, 0x9a, 0xa2,
-	0x63, 0xcb, 0x4d, 0x45, 0xf8, 0x32, 0x70, 0xb3, 0x53, 0xd2, 0xee, 0x3d, 0x83, 0xa4, 0x0b, 0x91,
-	0xed, 0x89, 0x85, 0xbd, 0x93, 0xa6, 0xc4, 0x59, 0x60, 0x47, 0x81, 0x38, 0xbd, 0x03, 0x28, 0x0b,
-	0x1b, 0x1f, 0x3f, 0xf4, 0xba, 0xcd, 0x46, 0xfb, 0xb8, 0xdd, 0x3c, 0x92, 0x3a, 0xcd, 0x4b, 0xe9,
-	0xe2, 0xb2, 0xdb, 0xe4, 0x42, 0x88, 0x85, 0x78, 0xb3, 0x21, 0x75, 0x85, 0x6a, 0x8d, 0x63, 0x02,
-	0x67, 0xff, 0xa0, 0xc2, 0x85, 0x51, 0x0a, 0x12, 0x62, 0xaf, 0x2e, 0xed, 0x95, 0x85, 0x0a, 0xb7,
-	0x16, 0x78, 0x42, 0xb9, 0x72, 0xc0, 0x45, 0x02, 0xaf, 0x52, 0x7e, 0x57, 0xe3, 0xa2, 0x3b, 0x3f,
-	0x18, 0x48, 0x2f, 0x14, 0x05, 0x3d, 0x87, 0xdc, 0xbc, 0x5e, 0xab, 0xde, 0x6b, 0x49, 0xf5, 0xd3,
-	0x93, 0x73, 0xb1, 0x7d, 0xd1, 0x3a, 0xe3, 0x42, 0x08, 0x20, 0xd6, 0x6b, 0xd5, 0x05, 0xa1, 0xc2,
-	0x45, 0x02, 0xbb, 0x5a, 0xe3, 0xa2, 0xd4, 0x76, 0xf5, 0x63, 0xd4, 0xae, 0xee, 0x09, 0x5c, 0xdc,
-	0xd5, 0x73, 0xe3, 0x92, 0xcb, 0x80, 0x99, 0x57, 0xad, 0x71, 0xec, 0xd4, 0x73, 0x59, 0xa9, 0xa9,
-	0xe7, 0xf2, 0xd2, 0x28, 0x03, 0xe0, 0xe7, 0xf0, 0x98, 0x99, 0x79, 0xbf, 0x5a, 0xe3, 0xd6, 0x85,
-	0x5f, 0x11, 0x80, 0x0e, 0x76, 0xce, 0xfc, 0x5e, 0x20, 0x15, 0xd8, 0xb9, 0x17, 0x02, 0xbd, 0x5e,
-	0xda, 0xb4, 0xbb, 0x2f, 0x56, 0x6e, 0x77, 0x35, 0x30, 0xed, 0xdc, 0x10, 0x52, 0xf3, 0x17, 0x12,
-	0xdd, 0xc7, 0xbe, 0xf3, 0xb0, 0xe4, 0xde, 0xac, 0x88, 0xa6, 0x62, 0x3a, 0xa4, 0x17, 0x6e, 0x3f,
-	0x5a, 0x8d, 0x1f, 0xbc, 0x1e, 0xb9, 0xe2, 0xaa, 0x70, 0xaa, 0x27, 0x41, 0x22, 0xf8, 0x55, 0x51,
-	0x61, 0x29, 0xf7, 0xd6, 0x9d, 0xcd, 0x6d, 0xaf, 0x80, 0xa4, 0x02, 0x57, 0x90, 0x6c, 0x18, 0xfa,
-	0x40, 0x53, 0x26, 0x16, 0x46, 0xaf, 0x28, 0xcf, 0x9f, 0x69, 0x45, 0x3a, 0xcc, 0xa6, 0xeb, 0x41,
-	0xfa, 0xad, 0x87, 0x60, 0x34, 0xf7, 0xc0, 0x2f, 0x96, 0xb7, 0xdc, 0xd6, 0x07, 0x06, 0xda, 0xfe,
-	0x23, 0x71, 0x01, 0x13, 0x68, 0xec, 0xac, 0x02, 0xf5, 0x75, 0x0e, 0xdf, 0x5e, 0x55, 0x15, 0xcd,
-	0x56, 0x27, 0xd7, 0x2e, 0xba, 0x44, 0x4c, 0x6d, 0x30, 0xc0, 0x25, 0x7f, 0x3a, 0x7b, 0x83, 0xb8,
-	0xb4, 0x64, 0xd8, 0x5f, 0xc7, 0xbc, 0xe5, 0xfd, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x25, 0x56,
-	0x93, 0x04, 0x0e, 0x08, 0x00, 0x00,
+	// 782 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x6d, 0x6f, 0xda, 0x48,
+	0x10, 0xc6, 0x84, 0xf0, 0x32, 0x06, 0x62, 0xed, 0x25, 0x77, 0x88, 0x3b, 0x5d, 0x91, 0xab, 0x46,
+	0x24, 0x4d, 0x81, 0x38, 0x98, 0x26, 0xea, 0x27, 0x42, 0x48, 0x40, 0x24, 0x0d, 0x32, 0xa9, 0xd4,
+	0x44, 0x95, 0x2c, 0x27, 0x2c, 0xb6, 0x05, 0xd8, 0xae, 0xd7, 0x54, 0xb5, 0xd4, 0xff, 0xd5, 0x3f,
+	0xd4, 0x1f, 0xd1, 0x8f, 0xd5, 0xfa, 0x85, 0x97, 0xa4, 0x24, 0x54, 0xed, 0x27, 0xcf, 0xcc, 0x3e,
+	0xcf, 0x3c, 0xb3, 0x33, 0xeb, 0x5d, 0x28, 0x12, 0x4b, 0xb7, 0x71, 0x99, 0x60, 0xfb, 0x13, 0xb6,
+	0xcb, 0x43, 0xec, 0x8e, 0x15, 0x43, 0x51, 0x17, 0xcc, 0x92, 0x65, 0x9b, 0x8e, 0x89, 0xfe, 0xf1,
+	0x90, 0x25, 0x1f, 0x59, 0x9a, 0x2d, 0xe7, 0x0b, 0x7e, 0x8a, 0x3b, 0x73, 0x3c, 0x36, 0x8d, 0xb2,
+	0x35, 0x9a, 0xa8, 0x7a, 0xf8, 0xf1, 0xa9, 0xbc, 0x01, 0xa9, 0xee, 0xe4, 0x76, 0xa4, 0xdf, 0x75,
+	0xb0, 0x8b, 0xb2, 0x10, 0xd5, 0xfb, 0x39, 0xa6, 0xc0, 0x14, 0x53, 0x52, 0x54, 0xef, 0xa3, 0x2a,
+	0xc4, 0x1c, 0xd7, 0xc2, 0xb9, 0x68, 0x81, 0x29, 0x66, 0x85, 0x42, 0x69, 0x89, 0x4c, 0xa9, 0x83,
+	0xdd, 0x2b, 0xd7, 0xc2, 0x92, 0x87, 0x46, 0xff, 0x42, 0xca, 0x1a, 0xea, 0x9f, 0xe5, 0xbe, 0xe2,
+	0x28, 0xb9, 0xb5, 0x02, 0x53, 0x4c, 0x4b, 0x49, 0x1a, 0x38, 0x51, 0x1c, 0x85, 0xd7, 0x00, 0x9d,
+	0x61, 0x03, 0xdb, 0x8a, 0x83, 0x3b, 0xd8, 0x95, 0xf0, 0xc7, 0x09, 0x26, 0x0e, 0xda, 0x82, 0xf8,
+	0x10, 0xbb, 0xf2, 0x54, 0x7c, 0x7d, 0x88, 0xdd, 0x76, 0x1f, 0xbd, 0x81, 0x24, 0x0d, 0xff, 0x52,
+	0x0d, 0x89, 0xa1, 0x6f, 0xf0, 0xef, 0xe1, 0xaf, 0x05, 0x25, 0x62, 0x99, 0x06, 0xc1, 0xa8, 0x0e,
+	0x60, 0x79, 0x1b, 0x96, 0x87, 0xd8, 0xf5, 0xe4, 0x58, 0x81, 0x5f, 0x9a, 0x75, 0xda, 0x1b, 0x29,
+	0x65, 0x85, 0x26, 0xbf, 0x47, 0x33, 0x3b, 0xb3, 0xa5, 0x47, 0x37, 0xc1, 0x5f, 0xc3, 0xe6, 0x22,
+	0xfa, 0xcf, 0x15, 0xf2, 0xf7, 0x62, 0x6a, 0x12, 0x54, 0xc2, 0x7f, 0x80, 0xad, 0x7b, 0xf1, 0x40,
+	0xb3, 0x01, 0xec, 0x4c, 0x93, 0xe4, 0x98, 0xc2, 0xda, 0x8a, 0xa2, 0x30, 0x15, 0x25, 0xfc, 0x17,
+	0x80, 0x6e, 0xaf, 0x77, 0x69, 0x39, 0xba, 0x69, 0x10, 0xf4, 0x0c, 0x58, 0xa2, 0x8c, 0x1c, 0x79,
+	0x84, 0x0d, 0xd5, 0xd1, 0xbc, 0x7d, 0xac, 0x4b, 0x40, 0x43, 0xe7, 0x5e, 0x04, 0x5d, 0x40, 0x56,
+	0x53, 0x88, 0x26, 0x2b, 0x23, 0xd5, 0xb4, 0x75, 0x47, 0x1b, 0x07, 0xa3, 0xdc, 0x5e, 0x2a, 0xdb,
+	0x52, 0x88, 0x56, 0x0f, 0xd1, 0x52, 0x46, 0x9b, 0x77, 0xf9, 0x6f, 0x0c, 0x6c, 0xf4, 0x74, 0xd5,
+	0xa0, 0xa7, 0xe9, 0x89, 0xe3, 0x83, 0x20, 0x36, 0x77, 0x06, 0x3d, 0x1b, 0x5d, 0xfe, 0x5e, 0x35,
+	0xad, 0xc8, 0xbd, 0x7a, 0xd0, 0x29, 0xb0, 0x16, 0x21, 0xb2, 0xe9, 0xb7, 0x23, 0x17, 0xf3, 0xe6,
+	0xf8, 0x7c, 0x79, 0x4b, 0xa7, 0x9d, 0x6b, 0x45, 0x24, 0xb0, 0x08, 0x09, 0xbc, 0xe3, 0x0c, 0xb0,
+	0x44, 0x57, 0x0d, 0x6c, 0xd3, 0x54, 0x84, 0xaf, 0x00, 0x37, 0xdb, 0x65, 0x30, 0xbd, 0xff, 0x20,
+	0x45, 0x21, 0x8a, 0x33, 0xb1, 0xb1, 0xb7, 0xd3, 0xb4, 0x34, 0x0b, 0xec, 0x3a, 0x90, 0x08, 0xfe,
+	0x01, 0x94, 0x83, 0xcd, 0x77, 0x6f, 0x7b, 0xdd, 0x66, 0xa3, 0x7d, 0xda, 0x6e, 0x9e, 0xc8, 0x9d,
+	0xe6, 0xb5, 0x7c, 0x75, 0xdd, 0x6d, 0x72, 0x11, 0xc4, 0x42, 0xa2, 0xd9, 0x90, 0xbb, 0x82, 0x58,
+	0xe3, 0x98, 0xd0, 0x39, 0x38, 0xac, 0x72, 0x51, 0x94, 0x86, 0xa4, 0xd4, 0xab, 0xcb, 0xfb, 0x15,
+	0xa1, 0xca, 0xad, 0x85, 0x9e, 0x50, 0xa9, 0x1e, 0x72, 0xb1, 0xd0, 0xab, 0x56, 0x8e, 0x6a, 0xdc,
+	0xba, 0x47, 0x3b, 0x11, 0x44, 0x71, 0xff, 0x88, 0x8b, 0xef, 0x7e, 0x65, 0x20, 0xb3, 0xd0, 0x21,
+	0xf4, 0x3f, 0xe4, 0xe7, 0xc5, 0x5b, 0xf5, 0x5e, 0x4b, 0xae, 0x9f, 0x9f, 0x5d, 0x4a, 0xed, 0xab,
+	0xd6, 0x05, 0x17, 0x41, 0x00, 0xf1, 0x5e, 0xab, 0x2e, 0x08, 0x55, 0x2e, 0x16, 0xda, 0x22, 0x4d,
+	0xeb, 0xdb, 0xb4, 0x98, 0x78, 0x60, 0x8b, 0xfb, 0x02, 0x97, 0xa0, 0xe2, 0x34, 0x2e, 0x53, 0x06,
+	0xcc, 0x3c, 0xb1, 0xc6, 0xb1, 0x53, 0x8f, 0xb2, 0xd2, 0x53, 0x8f, 0xf2, 0x32, 0x28, 0x0b, 0xe0,
+	0xe7, 0xf0, 0x98, 0xd9, 0x79, 0x5f, 0xac, 0x71, 0x1b, 0xc2, 0xf7, 0x18, 0x40, 0x07, 0xbb, 0x17,
+	0xfe, 0x60, 0x90, 0x06, 0xec, 0xdc, 0x75, 0x81, 0x5e, 0x2e, 0x9d, 0xe0, 0xc3, 0xeb, 0x2b, 0xbf,
+	0xb7, 0x1a, 0x38, 0x18, 0xe3, 0x10, 0xd2, 0xf3, 0x7f, 0x27, 0x7a, 0x8c, 0xfd, 0xe0, 0x96, 0xc9,
+	0xbf, 0x5a, 0x11, 0x1d, 0x88, 0x19, 0x90, 0x99, 0x8f, 0x13, 0xb4, 0x1a, 0x3f, 0xbc, 0x4a, 0xf2,
+	0xa5, 0x55, 0xe1, 0x81, 0x9e, 0x0c, 0xc9, 0xf0, 0xdc, 0xa2, 0xe2, 0x52, 0xee, 0xbd, 0x1f, 0x38,
+	0xbf, 0xb3, 0x02, 0x32, 0x10, 0xb8, 0x81, 0x54, 0xc3, 0x34, 0x06, 0xba, 0x3a, 0xb1, 0x31, 0x7a,
+	0x11, 0xf0, 0xfc, 0x07, 0xae, 0x14, 0xbc, 0x6c, 0xd3, 0xf5, 0x30, 0xfd, 0xf6, 0x53, 0xb0, 0x20,
+	0xf7, 0xc0, 0x6f, 0x96, 0xb7, 0xdc, 0x36, 0x06, 0x26, 0xda, 0xf9, 0x29, 0x71, 0x01, 0x13, 0x6a,
+	0xec, 0xae, 0x02, 0xf5, 0x75, 0x8e, 0x5f, 0xdf, 0x88, 0xaa, 0xee, 0x68, 0x93, 0x5b, 0x8a, 0x2e,
+	0x13, 0x4b, 0x1f, 0x0c, 0x70, 0xd9, 0x7f, 0xaa, 0xbd, 0x57, 0xb9, 0xbc, 0xe4, 0xe5, 0xbf, 0x8d,
+	0x7b, 0xcb, 0x07, 0x3f, 0x06, 0x00, 0xa2, 0x30, 0x5b, 0x7c, 0x1b, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
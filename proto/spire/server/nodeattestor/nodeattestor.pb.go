@@ -30,7 +30,8 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type AttestRequest struct {
 	//* A type which contains attestation data for specific platform.
 	AttestationData *common.AttestationData `protobuf:"bytes,1,opt,name=attestation_data,json=attestationData,proto3" json:"attestation_data,omitempty"`
-	//* Challenge response
+	//* Response of the agent to the challenge of the previous
+	//AttestResponse. Only set on the requests following a challenge.
 	Response             []byte   `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -80,7 +81,10 @@ func (m *AttestRequest) GetResponse() []byte {
 type AttestResponse struct {
 	//* SPIFFE ID of the attested node
 	AgentId string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	//* Challenge required for attestation
+	//* Challenge to relay to the agent. If set, the server sends the agent
+	//response in the next AttestRequest. The size of challenges and
+	//responses, the number of challenges and the duration of each round are
+	//bounded by the server.
 	Challenge []byte `protobuf:"bytes,3,opt,name=challenge,proto3" json:"challenge,omitempty"`
 	//* Optional list of selectors
 	Selectors            []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
@@ -184,7 +188,9 @@ const _ = grpc.SupportPackageIsVersion6
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type NodeAttestorClient interface {
-	//* Attesta a node.
+	//* Attests a node. The first request holds the attestation data. The
+	//plugin answers every request with either a challenge, starting another
+	//round, or the agent ID, completing the attestation.
 	Attest(ctx context.Context, opts ...grpc.CallOption) (NodeAttestor_AttestClient, error)
 	//* Responsible for configuration of the plugin.
 	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
@@ -251,7 +257,9 @@ func (c *nodeAttestorClient) GetPluginInfo(ctx context.Context, in *plugin.GetPl
 
 // NodeAttestorServer is the server API for NodeAttestor service.
 type NodeAttestorServer interface {
-	//* Attesta a node.
+	//* Attests a node. The first request holds the attestation data. The
+	//plugin answers every request with either a challenge, starting another
+	//round, or the agent ID, completing the attestation.
 	Attest(NodeAttestor_AttestServer) error
 	//* Responsible for configuration of the plugin.
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
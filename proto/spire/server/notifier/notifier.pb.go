@@ -107,6 +107,10 @@ func (m *BundleUpdated) GetBundle() *common.Bundle {
 type NotifyRequest struct {
 	// Types that are valid to be assigned to Event:
 	//	*NotifyRequest_BundleUpdated
+	//	*NotifyRequest_CaPrepared
+	//	*NotifyRequest_CaActivated
+	//	*NotifyRequest_CaTainted
+	//	*NotifyRequest_ReconcileBundle
 	Event                isNotifyRequest_Event `protobuf_oneof:"event"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
@@ -146,8 +150,32 @@ type NotifyRequest_BundleUpdated struct {
 	BundleUpdated *BundleUpdated `protobuf:"bytes,1,opt,name=bundle_updated,json=bundleUpdated,proto3,oneof"`
 }
 
+type NotifyRequest_CaPrepared struct {
+	CaPrepared *CAPrepared `protobuf:"bytes,2,opt,name=ca_prepared,json=caPrepared,proto3,oneof"`
+}
+
+type NotifyRequest_CaActivated struct {
+	CaActivated *CAActivated `protobuf:"bytes,3,opt,name=ca_activated,json=caActivated,proto3,oneof"`
+}
+
+type NotifyRequest_CaTainted struct {
+	CaTainted *CATainted `protobuf:"bytes,4,opt,name=ca_tainted,json=caTainted,proto3,oneof"`
+}
+
+type NotifyRequest_ReconcileBundle struct {
+	ReconcileBundle *ReconcileBundle `protobuf:"bytes,5,opt,name=reconcile_bundle,json=reconcileBundle,proto3,oneof"`
+}
+
 func (*NotifyRequest_BundleUpdated) isNotifyRequest_Event() {}
 
+func (*NotifyRequest_CaPrepared) isNotifyRequest_Event() {}
+
+func (*NotifyRequest_CaActivated) isNotifyRequest_Event() {}
+
+func (*NotifyRequest_CaTainted) isNotifyRequest_Event() {}
+
+func (*NotifyRequest_ReconcileBundle) isNotifyRequest_Event() {}
+
 func (m *NotifyRequest) GetEvent() isNotifyRequest_Event {
 	if m != nil {
 		return m.Event
@@ -162,14 +190,49 @@ func (m *NotifyRequest) GetBundleUpdated() *BundleUpdated {
 	return nil
 }
 
+func (m *NotifyRequest) GetCaPrepared() *CAPrepared {
+	if x, ok := m.GetEvent().(*NotifyRequest_CaPrepared); ok {
+		return x.CaPrepared
+	}
+	return nil
+}
+
+func (m *NotifyRequest) GetCaActivated() *CAActivated {
+	if x, ok := m.GetEvent().(*NotifyRequest_CaActivated); ok {
+		return x.CaActivated
+	}
+	return nil
+}
+
+func (m *NotifyRequest) GetCaTainted() *CATainted {
+	if x, ok := m.GetEvent().(*NotifyRequest_CaTainted); ok {
+		return x.CaTainted
+	}
+	return nil
+}
+
+func (m *NotifyRequest) GetReconcileBundle() *ReconcileBundle {
+	if x, ok := m.GetEvent().(*NotifyRequest_ReconcileBundle); ok {
+		return x.ReconcileBundle
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*NotifyRequest) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*NotifyRequest_BundleUpdated)(nil),
+		(*NotifyRequest_CaPrepared)(nil),
+		(*NotifyRequest_CaActivated)(nil),
+		(*NotifyRequest_CaTainted)(nil),
+		(*NotifyRequest_ReconcileBundle)(nil),
 	}
 }
 
 type NotifyResponse struct {
+	// The number of external copies of the trust bundle that were found out
+	// of sync and repaired while handling a ReconcileBundle event.
+	DriftedCopies        int32    `protobuf:"varint,1,opt,name=drifted_copies,json=driftedCopies,proto3" json:"drifted_copies,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -200,6 +263,13 @@ func (m *NotifyResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_NotifyResponse proto.InternalMessageInfo
 
+func (m *NotifyResponse) GetDriftedCopies() int32 {
+	if m != nil {
+		return m.DriftedCopies
+	}
+	return 0
+}
+
 type NotifyAndAdviseRequest struct {
 	// Types that are valid to be assigned to Event:
 	//	*NotifyAndAdviseRequest_BundleLoaded
@@ -296,6 +366,186 @@ func (m *NotifyAndAdviseResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_NotifyAndAdviseResponse proto.InternalMessageInfo
 
+type CAPrepared struct {
+	X509Ca               []byte            `protobuf:"bytes,1,opt,name=x509_ca,json=x509Ca,proto3" json:"x509_ca,omitempty"`
+	JwtKey               *common.PublicKey `protobuf:"bytes,2,opt,name=jwt_key,json=jwtKey,proto3" json:"jwt_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CAPrepared) Reset()         { *m = CAPrepared{} }
+func (m *CAPrepared) String() string { return proto.CompactTextString(m) }
+func (*CAPrepared) ProtoMessage()    {}
+func (*CAPrepared) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c27428e9e6d193e9, []int{6}
+}
+
+func (m *CAPrepared) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CAPrepared.Unmarshal(m, b)
+}
+func (m *CAPrepared) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CAPrepared.Marshal(b, m, deterministic)
+}
+func (m *CAPrepared) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CAPrepared.Merge(m, src)
+}
+func (m *CAPrepared) XXX_Size() int {
+	return xxx_messageInfo_CAPrepared.Size(m)
+}
+func (m *CAPrepared) XXX_DiscardUnknown() {
+	xxx_messageInfo_CAPrepared.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CAPrepared proto.InternalMessageInfo
+
+func (m *CAPrepared) GetX509Ca() []byte {
+	if m != nil {
+		return m.X509Ca
+	}
+	return nil
+}
+
+func (m *CAPrepared) GetJwtKey() *common.PublicKey {
+	if m != nil {
+		return m.JwtKey
+	}
+	return nil
+}
+
+type CAActivated struct {
+	X509Ca               []byte            `protobuf:"bytes,1,opt,name=x509_ca,json=x509Ca,proto3" json:"x509_ca,omitempty"`
+	JwtKey               *common.PublicKey `protobuf:"bytes,2,opt,name=jwt_key,json=jwtKey,proto3" json:"jwt_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CAActivated) Reset()         { *m = CAActivated{} }
+func (m *CAActivated) String() string { return proto.CompactTextString(m) }
+func (*CAActivated) ProtoMessage()    {}
+func (*CAActivated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c27428e9e6d193e9, []int{7}
+}
+
+func (m *CAActivated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CAActivated.Unmarshal(m, b)
+}
+func (m *CAActivated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CAActivated.Marshal(b, m, deterministic)
+}
+func (m *CAActivated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CAActivated.Merge(m, src)
+}
+func (m *CAActivated) XXX_Size() int {
+	return xxx_messageInfo_CAActivated.Size(m)
+}
+func (m *CAActivated) XXX_DiscardUnknown() {
+	xxx_messageInfo_CAActivated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CAActivated proto.InternalMessageInfo
+
+func (m *CAActivated) GetX509Ca() []byte {
+	if m != nil {
+		return m.X509Ca
+	}
+	return nil
+}
+
+func (m *CAActivated) GetJwtKey() *common.PublicKey {
+	if m != nil {
+		return m.JwtKey
+	}
+	return nil
+}
+
+type CATainted struct {
+	X509Ca               []byte            `protobuf:"bytes,1,opt,name=x509_ca,json=x509Ca,proto3" json:"x509_ca,omitempty"`
+	JwtKey               *common.PublicKey `protobuf:"bytes,2,opt,name=jwt_key,json=jwtKey,proto3" json:"jwt_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CATainted) Reset()         { *m = CATainted{} }
+func (m *CATainted) String() string { return proto.CompactTextString(m) }
+func (*CATainted) ProtoMessage()    {}
+func (*CATainted) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c27428e9e6d193e9, []int{8}
+}
+
+func (m *CATainted) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CATainted.Unmarshal(m, b)
+}
+func (m *CATainted) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CATainted.Marshal(b, m, deterministic)
+}
+func (m *CATainted) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CATainted.Merge(m, src)
+}
+func (m *CATainted) XXX_Size() int {
+	return xxx_messageInfo_CATainted.Size(m)
+}
+func (m *CATainted) XXX_DiscardUnknown() {
+	xxx_messageInfo_CATainted.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CATainted proto.InternalMessageInfo
+
+func (m *CATainted) GetX509Ca() []byte {
+	if m != nil {
+		return m.X509Ca
+	}
+	return nil
+}
+
+func (m *CATainted) GetJwtKey() *common.PublicKey {
+	if m != nil {
+		return m.JwtKey
+	}
+	return nil
+}
+
+type ReconcileBundle struct {
+	Bundle               *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ReconcileBundle) Reset()         { *m = ReconcileBundle{} }
+func (m *ReconcileBundle) String() string { return proto.CompactTextString(m) }
+func (*ReconcileBundle) ProtoMessage()    {}
+func (*ReconcileBundle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c27428e9e6d193e9, []int{9}
+}
+
+func (m *ReconcileBundle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileBundle.Unmarshal(m, b)
+}
+func (m *ReconcileBundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileBundle.Marshal(b, m, deterministic)
+}
+func (m *ReconcileBundle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileBundle.Merge(m, src)
+}
+func (m *ReconcileBundle) XXX_Size() int {
+	return xxx_messageInfo_ReconcileBundle.Size(m)
+}
+func (m *ReconcileBundle) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileBundle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileBundle proto.InternalMessageInfo
+
+func (m *ReconcileBundle) GetBundle() *common.Bundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BundleLoaded)(nil), "spire.server.notifier.BundleLoaded")
 	proto.RegisterType((*BundleUpdated)(nil), "spire.server.notifier.BundleUpdated")
@@ -303,6 +553,10 @@ func init() {
 	proto.RegisterType((*NotifyResponse)(nil), "spire.server.notifier.NotifyResponse")
 	proto.RegisterType((*NotifyAndAdviseRequest)(nil), "spire.server.notifier.NotifyAndAdviseRequest")
 	proto.RegisterType((*NotifyAndAdviseResponse)(nil), "spire.server.notifier.NotifyAndAdviseResponse")
+	proto.RegisterType((*CAPrepared)(nil), "spire.server.notifier.CAPrepared")
+	proto.RegisterType((*CAActivated)(nil), "spire.server.notifier.CAActivated")
+	proto.RegisterType((*CATainted)(nil), "spire.server.notifier.CATainted")
+	proto.RegisterType((*ReconcileBundle)(nil), "spire.server.notifier.ReconcileBundle")
 }
 
 func init() {
@@ -310,31 +564,45 @@ func init() {
 }
 
 var fileDescriptor_c27428e9e6d193e9 = []byte{
-	// 381 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x4f, 0x4f, 0xea, 0x40,
-	0x14, 0xc5, 0x21, 0x2f, 0x8f, 0xf7, 0xde, 0x7d, 0x14, 0xcd, 0xc4, 0x7f, 0x74, 0x45, 0x2a, 0x18,
-	0x35, 0xda, 0x26, 0x10, 0x77, 0xba, 0x00, 0x17, 0xa2, 0x51, 0x62, 0x9a, 0xb0, 0x61, 0x43, 0xa8,
-	0xbd, 0xad, 0x93, 0xc0, 0x4c, 0xed, 0x1f, 0x12, 0x3f, 0x89, 0x5f, 0xd7, 0xd0, 0x3b, 0x55, 0x8a,
-	0x08, 0xb8, 0x6a, 0x67, 0xce, 0xef, 0x9e, 0x73, 0xe7, 0x66, 0x06, 0xea, 0x51, 0xc0, 0x43, 0xb4,
-	0x22, 0x0c, 0xa7, 0x18, 0x5a, 0x42, 0xc6, 0xdc, 0xe3, 0x73, 0x3f, 0x66, 0x10, 0xca, 0x58, 0xb2,
-	0xdd, 0x94, 0x32, 0x89, 0x32, 0x33, 0x51, 0xaf, 0x52, 0xf1, 0x93, 0x9c, 0x4c, 0xa4, 0x50, 0x1f,
-	0xaa, 0xd0, 0x6b, 0x39, 0x29, 0x18, 0x27, 0x3e, 0xcf, 0x3e, 0x44, 0x18, 0x97, 0x50, 0xee, 0x24,
-	0xc2, 0x1d, 0xe3, 0xbd, 0x1c, 0xb9, 0xe8, 0xb2, 0x33, 0x28, 0x39, 0xe9, 0xfa, 0xa0, 0x58, 0x2b,
-	0x1e, 0xff, 0x6f, 0xee, 0x98, 0x14, 0xaa, 0x6c, 0x89, 0xb5, 0x15, 0x63, 0x5c, 0x81, 0x46, 0x3b,
-	0xfd, 0xc0, 0x1d, 0xc5, 0x3f, 0x2e, 0xf7, 0x41, 0xeb, 0xcd, 0x4e, 0xf1, 0x6a, 0xe3, 0x4b, 0x82,
-	0x51, 0xcc, 0x1e, 0xa0, 0x42, 0xd2, 0x30, 0x21, 0x43, 0x65, 0x53, 0x37, 0x97, 0x1e, 0xdd, 0xcc,
-	0x85, 0x77, 0x0b, 0xb6, 0xe6, 0xcc, 0x6f, 0x74, 0xfe, 0xc0, 0x6f, 0x9c, 0xa2, 0x88, 0x8d, 0x6d,
-	0xa8, 0x64, 0x41, 0x51, 0x20, 0x45, 0x84, 0xc6, 0x04, 0xf6, 0x68, 0xa7, 0x2d, 0xdc, 0xb6, 0x3b,
-	0xe5, 0x11, 0x66, 0x3d, 0xdc, 0x81, 0x72, 0x19, 0x8e, 0xd3, 0x91, 0xa8, 0x16, 0x0e, 0x57, 0xb6,
-	0x40, 0xd3, 0xeb, 0x16, 0xec, 0xb2, 0x33, 0xb7, 0xfe, 0x6c, 0xa0, 0x0a, 0xfb, 0x5f, 0xe2, 0xa8,
-	0x93, 0xe6, 0xdb, 0x2f, 0xf8, 0xdb, 0x53, 0x6e, 0xac, 0x0f, 0x25, 0xe2, 0xd8, 0x77, 0x47, 0xce,
-	0x0d, 0x4c, 0x6f, 0xac, 0xa1, 0x28, 0x83, 0x05, 0xb0, 0xb5, 0x10, 0xcf, 0xce, 0x57, 0x56, 0x2e,
-	0x4e, 0x45, 0x37, 0x37, 0xc5, 0x55, 0xe2, 0x00, 0xfe, 0x5d, 0x4b, 0xe1, 0x71, 0x3f, 0x09, 0x91,
-	0x35, 0xf2, 0xb7, 0x40, 0x5d, 0xc0, 0x0f, 0x3d, 0xcb, 0x38, 0x5a, 0x87, 0x29, 0x6f, 0x0f, 0xb4,
-	0x1b, 0x8c, 0x1f, 0x53, 0xf9, 0x56, 0x78, 0x92, 0x9d, 0x2c, 0x2d, 0xcc, 0x31, 0x59, 0xc6, 0xe9,
-	0x26, 0x28, 0xe5, 0x74, 0x2e, 0x06, 0x2d, 0x9f, 0xc7, 0xcf, 0x89, 0x33, 0xa3, 0xad, 0x28, 0xe0,
-	0x9e, 0x87, 0x16, 0xbd, 0xa8, 0xf4, 0xf1, 0x58, 0x4b, 0x5f, 0xad, 0x53, 0x4a, 0xc5, 0xd6, 0x7b,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xe1, 0x06, 0x0b, 0xf9, 0xd5, 0x03, 0x00, 0x00,
+	// 595 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x5f, 0x6f, 0xd3, 0x3e,
+	0x14, 0xcd, 0x7e, 0xfb, 0xad, 0x63, 0xb7, 0xff, 0x90, 0x05, 0x74, 0xeb, 0x53, 0x09, 0xeb, 0x04,
+	0x08, 0xd2, 0x69, 0xd3, 0x84, 0x90, 0x40, 0xa8, 0x2d, 0xd2, 0x02, 0x83, 0xa9, 0x32, 0x0c, 0xd0,
+	0x5e, 0x22, 0xc7, 0x71, 0x8a, 0x47, 0x9b, 0x84, 0xc4, 0xe9, 0xe8, 0x97, 0xe0, 0x95, 0xaf, 0x8b,
+	0x6a, 0x3b, 0x5d, 0x53, 0xda, 0xfd, 0x91, 0xf6, 0x94, 0x5e, 0xdf, 0x73, 0xcf, 0xb1, 0xef, 0xb9,
+	0x76, 0x61, 0x3b, 0x89, 0x78, 0xcc, 0x5a, 0x09, 0x8b, 0x47, 0x2c, 0x6e, 0x05, 0xa1, 0xe0, 0x3e,
+	0x9f, 0xf9, 0x61, 0x45, 0x71, 0x28, 0x42, 0x74, 0x5f, 0xa2, 0x2c, 0x85, 0xb2, 0xb2, 0x64, 0x7d,
+	0x4b, 0x15, 0xd3, 0x70, 0x38, 0x0c, 0x03, 0xfd, 0x51, 0x15, 0xf5, 0x46, 0x2e, 0x15, 0x0d, 0xd2,
+	0x3e, 0xcf, 0x3e, 0x0a, 0x61, 0xbe, 0x82, 0x52, 0x27, 0x0d, 0xbc, 0x01, 0xfb, 0x10, 0x12, 0x8f,
+	0x79, 0xe8, 0x19, 0x14, 0x5c, 0x19, 0x6f, 0xae, 0x34, 0x56, 0x1e, 0x17, 0xf7, 0xee, 0x59, 0x4a,
+	0x54, 0xd3, 0x2a, 0x2c, 0xd6, 0x18, 0xf3, 0x35, 0x94, 0xd5, 0xca, 0x49, 0xe4, 0x11, 0x71, 0xe3,
+	0xf2, 0xdf, 0xab, 0x50, 0x3e, 0x9e, 0x1c, 0x63, 0x8c, 0xd9, 0xcf, 0x94, 0x25, 0x02, 0x7d, 0x84,
+	0x8a, 0xca, 0x39, 0xa9, 0x62, 0xd4, 0x3c, 0xdb, 0xd6, 0xc2, 0xb3, 0x5b, 0x39, 0x75, 0xdb, 0xc0,
+	0x65, 0x37, 0xb7, 0x9d, 0xb7, 0x50, 0xa4, 0xc4, 0x89, 0x62, 0x16, 0x91, 0x98, 0x79, 0x9b, 0xff,
+	0x49, 0xae, 0x87, 0x4b, 0xb8, 0xba, 0xed, 0x9e, 0x06, 0xda, 0x06, 0x06, 0x4a, 0xb2, 0x08, 0x1d,
+	0x42, 0x89, 0x12, 0x87, 0x50, 0xc1, 0x47, 0x72, 0x4b, 0xab, 0x92, 0xc6, 0x5c, 0x4a, 0xd3, 0xce,
+	0x90, 0xb6, 0x81, 0x8b, 0x94, 0x4c, 0x43, 0xd4, 0x06, 0xa0, 0xc4, 0x11, 0x84, 0x07, 0x13, 0x9a,
+	0xff, 0x25, 0x4d, 0x63, 0x29, 0xcd, 0x67, 0x85, 0xb3, 0x0d, 0xbc, 0x41, 0x89, 0x0e, 0xd0, 0x27,
+	0xb8, 0x1b, 0x33, 0x1a, 0x06, 0x94, 0x0f, 0x98, 0xa3, 0x5b, 0xbd, 0x26, 0x89, 0x76, 0x96, 0x10,
+	0xe1, 0x0c, 0xae, 0x7a, 0x65, 0x1b, 0xb8, 0x1a, 0xe7, 0x97, 0x3a, 0xeb, 0xb0, 0xc6, 0x46, 0x2c,
+	0x10, 0xe6, 0x0b, 0xa8, 0x64, 0x7e, 0x24, 0x51, 0x18, 0x24, 0x0c, 0x35, 0xa1, 0xe2, 0xc5, 0xdc,
+	0x17, 0xcc, 0x73, 0x68, 0x18, 0x71, 0x96, 0x48, 0x43, 0xd6, 0x70, 0x59, 0xaf, 0x76, 0xe5, 0xa2,
+	0x39, 0x84, 0x07, 0xaa, 0xb0, 0x1d, 0x78, 0x6d, 0x6f, 0xc4, 0x13, 0x96, 0x39, 0xfa, 0x1e, 0xb4,
+	0x27, 0xce, 0x40, 0x4e, 0x98, 0x36, 0xf4, 0xd1, 0xa5, 0x86, 0xaa, 0x61, 0xb4, 0x0d, 0x5c, 0x72,
+	0x67, 0xe2, 0x8b, 0x7d, 0x6e, 0x41, 0xed, 0x1f, 0x39, 0xb5, 0x61, 0xf3, 0x2b, 0xc0, 0x85, 0x91,
+	0xa8, 0x06, 0xeb, 0xbf, 0x0e, 0x76, 0x5f, 0x3a, 0x94, 0x48, 0xdd, 0x12, 0x2e, 0x4c, 0xc2, 0x2e,
+	0x41, 0xbb, 0xb0, 0x7e, 0x76, 0x2e, 0x9c, 0x1f, 0x6c, 0xac, 0xa7, 0xa2, 0x96, 0x9f, 0xd4, 0x5e,
+	0xea, 0x0e, 0x38, 0x3d, 0x62, 0x63, 0x5c, 0x38, 0x3b, 0x17, 0x47, 0x6c, 0x6c, 0x7e, 0x83, 0xe2,
+	0x8c, 0xb5, 0xb7, 0xc9, 0xfc, 0x05, 0x36, 0xa6, 0x6e, 0xdf, 0x26, 0xef, 0x1b, 0xa8, 0xce, 0x99,
+	0x7f, 0xb3, 0xfb, 0xb9, 0xf7, 0x67, 0x15, 0xee, 0x1c, 0x6b, 0x67, 0xd0, 0x09, 0x14, 0x54, 0xcf,
+	0xd1, 0xb2, 0xcb, 0x98, 0xbb, 0xca, 0xf5, 0xe6, 0x15, 0x28, 0x3d, 0x60, 0x11, 0x54, 0xe7, 0xac,
+	0x44, 0xcf, 0x2f, 0xad, 0x9c, 0x9f, 0xb0, 0xba, 0x75, 0x5d, 0xb8, 0x56, 0x3c, 0x85, 0x8d, 0x6e,
+	0x18, 0xf8, 0xbc, 0x9f, 0xc6, 0x0c, 0x35, 0xf3, 0x0d, 0xd0, 0x6f, 0xe3, 0x34, 0x9f, 0x69, 0xec,
+	0x5c, 0x05, 0xd3, 0xdc, 0x3e, 0x94, 0x0f, 0x99, 0xe8, 0xc9, 0xf4, 0xbb, 0xc0, 0x0f, 0xd1, 0x93,
+	0x85, 0x85, 0x39, 0x4c, 0xa6, 0xf1, 0xf4, 0x3a, 0x50, 0xa5, 0xd3, 0x39, 0x38, 0xdd, 0xef, 0x73,
+	0xf1, 0x3d, 0x75, 0x27, 0xe8, 0x56, 0x12, 0x71, 0xdf, 0x67, 0x2d, 0xf5, 0xd8, 0xcb, 0x77, 0xbd,
+	0xb5, 0xf0, 0x0f, 0xc5, 0x2d, 0xc8, 0xe4, 0xfe, 0xdf, 0x01, 0x00, 0x55, 0xf3, 0x93, 0x7b, 0x70,
+	0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
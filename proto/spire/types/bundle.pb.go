@@ -99,7 +99,10 @@ func (m *Bundle) GetSequenceNumber() uint64 {
 
 type X509Certificate struct {
 	// The ASN.1 DER encoded bytes of the X.509 certificate.
-	Asn1                 []byte   `protobuf:"bytes,1,opt,name=asn1,proto3" json:"asn1,omitempty"`
+	Asn1 []byte `protobuf:"bytes,1,opt,name=asn1,proto3" json:"asn1,omitempty"`
+	// Whether the authority has been tainted. SVIDs signed by a tainted
+	// authority should be rotated.
+	Tainted              bool     `protobuf:"varint,2,opt,name=tainted,proto3" json:"tainted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -137,6 +140,13 @@ func (m *X509Certificate) GetAsn1() []byte {
 	return nil
 }
 
+func (m *X509Certificate) GetTainted() bool {
+	if m != nil {
+		return m.Tainted
+	}
+	return false
+}
+
 type JWTKey struct {
 	// The PKIX encoded public key.
 	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
@@ -144,7 +154,10 @@ type JWTKey struct {
 	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
 	// When the key expires (seconds since Unix epoch). If zero, the key does
 	// not expire.
-	ExpiresAt            int64    `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ExpiresAt int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Whether the authority has been tainted. JWT-SVIDs signed by a tainted
+	// authority should be rotated.
+	Tainted              bool     `protobuf:"varint,4,opt,name=tainted,proto3" json:"tainted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -196,6 +209,13 @@ func (m *JWTKey) GetExpiresAt() int64 {
 	return 0
 }
 
+func (m *JWTKey) GetTainted() bool {
+	if m != nil {
+		return m.Tainted
+	}
+	return false
+}
+
 type BundleMask struct {
 	// x509_authorities field mask.
 	X509Authorities bool `protobuf:"varint,2,opt,name=x509_authorities,json=x509Authorities,proto3" json:"x509_authorities,omitempty"`
@@ -275,29 +295,30 @@ func init() {
 }
 
 var fileDescriptor_b2e29b9c8a236a2b = []byte{
-	// 380 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x5d, 0x6b, 0xe2, 0x40,
-	0x14, 0x86, 0x89, 0xd1, 0xa0, 0x13, 0x31, 0xcb, 0x2c, 0x0b, 0xb9, 0xd8, 0x85, 0x18, 0x58, 0x8c,
-	0x2c, 0x24, 0xee, 0x2e, 0x5e, 0x08, 0x7b, 0xa3, 0xbb, 0xb0, 0x5f, 0xb4, 0x17, 0xa1, 0xd0, 0x52,
-	0x28, 0x21, 0x1f, 0x27, 0xcd, 0x18, 0x4d, 0xd2, 0x99, 0x09, 0x9a, 0xff, 0xd4, 0xdf, 0x58, 0x4a,
-	0x26, 0x96, 0xaa, 0xf5, 0xc2, 0xbb, 0xf0, 0x9c, 0x73, 0x5e, 0x4e, 0x9e, 0x39, 0x48, 0x67, 0x05,
-	0xa1, 0xe0, 0xf0, 0xaa, 0x00, 0xe6, 0x04, 0x65, 0x16, 0xad, 0xc0, 0x2e, 0x68, 0xce, 0x73, 0xac,
-	0x8a, 0x8a, 0x2d, 0x2a, 0xe6, 0x93, 0x84, 0x94, 0x85, 0xa8, 0xe2, 0x21, 0xea, 0x73, 0x5a, 0x32,
-	0xee, 0x45, 0xf9, 0xda, 0x27, 0x99, 0x2e, 0x19, 0x92, 0xd5, 0x73, 0x55, 0xc1, 0x7e, 0x09, 0x84,
-	0x7f, 0xa3, 0x77, 0xdb, 0xe9, 0x64, 0xe6, 0xf9, 0x25, 0x4f, 0x72, 0x4a, 0x38, 0x01, 0xa6, 0xb7,
-	0x0c, 0xd9, 0x52, 0xbf, 0x7d, 0xb4, 0xf7, 0x52, 0xed, 0x9b, 0xe9, 0x64, 0xf6, 0x13, 0x28, 0x27,
-	0x31, 0x09, 0x7d, 0x0e, 0xae, 0x56, 0x4f, 0xcd, 0x5f, 0x87, 0xf0, 0x0f, 0xa4, 0x2d, 0x37, 0xfc,
-	0x20, 0x47, 0x16, 0x39, 0xef, 0x0f, 0x72, 0xfe, 0x5d, 0x5f, 0xfd, 0x87, 0xca, 0x1d, 0x2c, 0x37,
-	0x7c, 0x7f, 0x7a, 0x88, 0xfa, 0x14, 0x62, 0x0a, 0x2c, 0xf1, 0x12, 0x92, 0x71, 0xbd, 0x6d, 0x48,
-	0x96, 0xec, 0xaa, 0x3b, 0xf6, 0x87, 0x64, 0x1c, 0x8f, 0x90, 0xc6, 0xe0, 0xa1, 0x84, 0x2c, 0x04,
-	0x2f, 0x2b, 0xd7, 0x01, 0x50, 0xbd, 0x63, 0x48, 0x56, 0xdb, 0x1d, 0xbc, 0xe0, 0x4b, 0x41, 0xcd,
-	0xcf, 0x48, 0x3b, 0xda, 0x16, 0x63, 0xd4, 0xf6, 0x59, 0xf6, 0x55, 0x08, 0xe8, 0xbb, 0xe2, 0xdb,
-	0xbc, 0x43, 0x4a, 0xb3, 0x0c, 0xfe, 0x84, 0x50, 0x51, 0x06, 0x2b, 0x12, 0x7a, 0x29, 0x54, 0xbb,
-	0x9e, 0x5e, 0x43, 0xea, 0xf2, 0x07, 0xa4, 0xa4, 0x50, 0x79, 0x24, 0xd2, 0x5b, 0xc2, 0x5f, 0x27,
-	0x85, 0xea, 0x6f, 0x54, 0x4f, 0xc1, 0xb6, 0xfe, 0x33, 0xe6, 0xf9, 0x5c, 0x97, 0xc5, 0xc2, 0xbd,
-	0x1d, 0x99, 0x73, 0xf3, 0x51, 0x42, 0xa8, 0x79, 0x86, 0x0b, 0x9f, 0xa5, 0x78, 0x7c, 0xd2, 0xb3,
-	0x64, 0x75, 0xdf, 0x9a, 0x1c, 0x9d, 0x32, 0x59, 0x77, 0x9e, 0x23, 0xad, 0x7b, 0x96, 0xb4, 0xee,
-	0xb1, 0xb4, 0xc5, 0x97, 0xdb, 0xf1, 0x3d, 0xe1, 0x49, 0x19, 0xd8, 0x61, 0xbe, 0x76, 0x58, 0x41,
-	0xe2, 0x18, 0x9c, 0xe6, 0xe0, 0xc4, 0x8d, 0x39, 0x7b, 0xc7, 0x17, 0x28, 0x02, 0x7d, 0x7f, 0x0e,
-	0x00, 0x00, 0xff, 0xff, 0x28, 0x30, 0x16, 0x52, 0x92, 0x02, 0x00, 0x00,
+	// 396 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x5f, 0x6b, 0xd4, 0x40,
+	0x14, 0xc5, 0x49, 0x77, 0xbb, 0xee, 0xde, 0x2c, 0x8d, 0x8c, 0x08, 0xf3, 0xa0, 0x90, 0xee, 0x4b,
+	0x53, 0x84, 0xa4, 0x2a, 0x7d, 0x28, 0x08, 0xd2, 0x2a, 0xf8, 0x0f, 0x7d, 0x18, 0x04, 0xc5, 0x97,
+	0x90, 0x3f, 0x37, 0x66, 0x9a, 0xee, 0x24, 0xce, 0xdc, 0xb0, 0xcd, 0x77, 0xf2, 0x33, 0x8a, 0x64,
+	0xb2, 0xc5, 0x6c, 0xed, 0x43, 0xdf, 0x92, 0xdf, 0x99, 0x73, 0x66, 0xe6, 0xcc, 0x05, 0x6e, 0x1a,
+	0xa9, 0x31, 0xa2, 0xae, 0x41, 0x13, 0xa5, 0xad, 0xca, 0xaf, 0x30, 0x6c, 0x74, 0x4d, 0x35, 0x73,
+	0xad, 0x12, 0x5a, 0x65, 0xf5, 0xc7, 0x81, 0xd9, 0x85, 0x55, 0xd9, 0x21, 0x2c, 0x49, 0xb7, 0x86,
+	0xe2, 0xbc, 0x5e, 0x27, 0x52, 0x71, 0xc7, 0x77, 0x82, 0x85, 0x70, 0x2d, 0x7b, 0x6b, 0x11, 0x7b,
+	0x07, 0x0f, 0xaf, 0x4f, 0x4f, 0xce, 0xe2, 0xa4, 0xa5, 0xb2, 0xd6, 0x92, 0x24, 0x1a, 0xbe, 0xe7,
+	0x4f, 0x02, 0xf7, 0xc5, 0x93, 0x70, 0x94, 0x1a, 0x7e, 0x3f, 0x3d, 0x39, 0x7b, 0x83, 0x9a, 0x64,
+	0x21, 0xb3, 0x84, 0x50, 0x78, 0xbd, 0xeb, 0xfc, 0x9f, 0x89, 0xbd, 0x02, 0xef, 0x72, 0x43, 0x3b,
+	0x39, 0x13, 0x9b, 0xf3, 0x68, 0x27, 0xe7, 0xe3, 0xb7, 0xaf, 0x9f, 0xb0, 0x13, 0x07, 0x97, 0x1b,
+	0x1a, 0xbb, 0x0f, 0x61, 0xa9, 0xb1, 0xd0, 0x68, 0xca, 0xb8, 0x94, 0x8a, 0xf8, 0xd4, 0x77, 0x82,
+	0x89, 0x70, 0xb7, 0xec, 0xbd, 0x54, 0xc4, 0x8e, 0xc0, 0x33, 0xf8, 0xab, 0x45, 0x95, 0x61, 0xac,
+	0xda, 0x75, 0x8a, 0x9a, 0xef, 0xfb, 0x4e, 0x30, 0x15, 0x07, 0x37, 0xf8, 0x8b, 0xa5, 0xab, 0xd7,
+	0xe0, 0xdd, 0x3a, 0x2d, 0x63, 0x30, 0x4d, 0x8c, 0x7a, 0x6e, 0x0b, 0x58, 0x0a, 0xfb, 0xcd, 0x38,
+	0x3c, 0xa0, 0x44, 0x2a, 0xc2, 0x9c, 0xef, 0xf9, 0x4e, 0x30, 0x17, 0x37, 0xbf, 0xab, 0x0d, 0xcc,
+	0x86, 0x63, 0xb2, 0xa7, 0x00, 0x4d, 0x9b, 0x5e, 0xc9, 0x2c, 0xae, 0xb0, 0xdb, 0xba, 0x17, 0x03,
+	0xe9, 0xe5, 0xc7, 0x30, 0xab, 0xb0, 0x8b, 0xe5, 0x90, 0xb0, 0x10, 0xfb, 0x15, 0x76, 0x1f, 0xf2,
+	0xde, 0x85, 0xd7, 0xfd, 0x9d, 0x4d, 0x9c, 0x10, 0x9f, 0xd8, 0xab, 0x2c, 0xb6, 0xe4, 0x9c, 0xc6,
+	0x1b, 0x4f, 0x77, 0x37, 0xfe, 0xed, 0x00, 0x0c, 0x4f, 0xf7, 0x39, 0x31, 0x15, 0x3b, 0xbe, 0xf3,
+	0x6d, 0x7a, 0xc7, 0x7f, 0xed, 0x1f, 0xdd, 0xd5, 0x7e, 0xbf, 0xf2, 0x3e, 0x45, 0xcf, 0xef, 0x55,
+	0xf4, 0xfc, 0x76, 0xd1, 0x17, 0xcf, 0x7e, 0x1c, 0xff, 0x94, 0x54, 0xb6, 0x69, 0x98, 0xd5, 0xeb,
+	0xc8, 0x34, 0xb2, 0x28, 0x30, 0x1a, 0x86, 0xd4, 0xce, 0x65, 0x34, 0x1a, 0xd8, 0x74, 0x66, 0xd1,
+	0xcb, 0xbf, 0x03, 0x00, 0x89, 0xc6, 0x30, 0xfd, 0xc6, 0x02, 0x00, 0x00,
 }
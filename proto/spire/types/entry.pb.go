@@ -54,7 +54,41 @@ type Entry struct {
 	// A list of DNS names associated with the identity described by this entry.
 	DnsNames []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
 	// Revision number is bumped every time the entry is updated
-	RevisionNumber       int64    `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	RevisionNumber int64 `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	// Additional static claims included in JWT-SVIDs minted for the identity
+	// described by this entry. Registered JWT claims cannot be overridden.
+	JwtSvidClaims map[string]string `protobuf:"bytes,12,rep,name=jwt_svid_claims,json=jwtSvidClaims,proto3" json:"jwt_svid_claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Audience used for JWT-SVIDs minted for the identity described by this
+	// entry when the request does not specify one.
+	JwtSvidAudience []string `protobuf:"bytes,13,rep,name=jwt_svid_audience,json=jwtSvidAudience,proto3" json:"jwt_svid_audience,omitempty"`
+	// Key type of X509-SVIDs minted for the identity described by this
+	// entry. One of "ec-p256", "ec-p384", "rsa-2048" or "rsa-4096". When
+	// empty, agents use their default key type.
+	X509SvidKeyType string `protobuf:"bytes,14,opt,name=x509_svid_key_type,json=x509SvidKeyType,proto3" json:"x509_svid_key_type,omitempty"`
+	// Go text/template strings rendered into additional DNS names of
+	// X509-SVIDs minted for the identity described by this entry (e.g.
+	// "{{ index .PathSegments 1 }}.svc"). Templates are evaluated against
+	// the SPIFFE ID and parent ID of the entry.
+	DnsNameTemplates []string `protobuf:"bytes,15,rep,name=dns_name_templates,json=dnsNameTemplates,proto3" json:"dns_name_templates,omitempty"`
+	// Subject of X509-SVIDs minted for the identity described by this entry.
+	// The common name is replaced by the first DNS name, if any.
+	X509SvidSubject *X509SVIDSubject `protobuf:"bytes,16,opt,name=x509_svid_subject,json=x509SvidSubject,proto3" json:"x509_svid_subject,omitempty"`
+	// An operator-specified string used to provide guidance on how this
+	// identity should be used by a workload when more than one SVID is
+	// returned. For example, `internal` and `external` to indicate an SVID
+	// for internal or external use, respectively. The hint is returned to
+	// workloads in the Workload API responses.
+	Hint string `protobuf:"bytes,17,opt,name=hint,proto3" json:"hint,omitempty"`
+	// Expression over the selectors of a workload that must be satisfied,
+	// in addition to the selectors of the entry, for the entry to match the
+	// workload. Selectors are combined with `&&`, `||` and `!`, grouped
+	// with parentheses, and selector values may use `*` wildcards (e.g.
+	// `!k8s:sa:admin && (k8s:pod-label:app:web || k8s:pod-image:web-*)`).
+	SelectorExpression string `protobuf:"bytes,18,opt,name=selector_expression,json=selectorExpression,proto3" json:"selector_expression,omitempty"`
+	// The ID of the entry template the entry uses, if any. The ttl,
+	// federates_with and dns_name_templates of the entry are set from the
+	// template, and its SPIFFE ID path must match the shape of the template.
+	TemplateId           string   `protobuf:"bytes,19,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -162,6 +196,130 @@ func (m *Entry) GetRevisionNumber() int64 {
 	return 0
 }
 
+func (m *Entry) GetJwtSvidClaims() map[string]string {
+	if m != nil {
+		return m.JwtSvidClaims
+	}
+	return nil
+}
+
+func (m *Entry) GetJwtSvidAudience() []string {
+	if m != nil {
+		return m.JwtSvidAudience
+	}
+	return nil
+}
+
+func (m *Entry) GetX509SvidKeyType() string {
+	if m != nil {
+		return m.X509SvidKeyType
+	}
+	return ""
+}
+
+func (m *Entry) GetDnsNameTemplates() []string {
+	if m != nil {
+		return m.DnsNameTemplates
+	}
+	return nil
+}
+
+func (m *Entry) GetX509SvidSubject() *X509SVIDSubject {
+	if m != nil {
+		return m.X509SvidSubject
+	}
+	return nil
+}
+
+func (m *Entry) GetHint() string {
+	if m != nil {
+		return m.Hint
+	}
+	return ""
+}
+
+func (m *Entry) GetSelectorExpression() string {
+	if m != nil {
+		return m.SelectorExpression
+	}
+	return ""
+}
+
+func (m *Entry) GetTemplateId() string {
+	if m != nil {
+		return m.TemplateId
+	}
+	return ""
+}
+
+// Subject fields of an X509-SVID.
+type X509SVIDSubject struct {
+	// Common name.
+	CommonName string `protobuf:"bytes,1,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	// Organizations.
+	Organization []string `protobuf:"bytes,2,rep,name=organization,proto3" json:"organization,omitempty"`
+	// Organizational units.
+	OrganizationalUnit []string `protobuf:"bytes,3,rep,name=organizational_unit,json=organizationalUnit,proto3" json:"organizational_unit,omitempty"`
+	// Countries.
+	Country              []string `protobuf:"bytes,4,rep,name=country,proto3" json:"country,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVIDSubject) Reset()         { *m = X509SVIDSubject{} }
+func (m *X509SVIDSubject) String() string { return proto.CompactTextString(m) }
+func (*X509SVIDSubject) ProtoMessage()    {}
+func (*X509SVIDSubject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1701a8d1ba9b5bc, []int{1}
+}
+
+func (m *X509SVIDSubject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509SVIDSubject.Unmarshal(m, b)
+}
+func (m *X509SVIDSubject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509SVIDSubject.Marshal(b, m, deterministic)
+}
+func (m *X509SVIDSubject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVIDSubject.Merge(m, src)
+}
+func (m *X509SVIDSubject) XXX_Size() int {
+	return xxx_messageInfo_X509SVIDSubject.Size(m)
+}
+func (m *X509SVIDSubject) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVIDSubject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVIDSubject proto.InternalMessageInfo
+
+func (m *X509SVIDSubject) GetCommonName() string {
+	if m != nil {
+		return m.CommonName
+	}
+	return ""
+}
+
+func (m *X509SVIDSubject) GetOrganization() []string {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+func (m *X509SVIDSubject) GetOrganizationalUnit() []string {
+	if m != nil {
+		return m.OrganizationalUnit
+	}
+	return nil
+}
+
+func (m *X509SVIDSubject) GetCountry() []string {
+	if m != nil {
+		return m.Country
+	}
+	return nil
+}
+
 // Field mask for Entry fields
 type EntryMask struct {
 	// spiffe_id field mask
@@ -183,7 +341,23 @@ type EntryMask struct {
 	// dns_names field mask
 	DnsNames bool `protobuf:"varint,10,opt,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
 	// revision_number field mask
-	RevisionNumber       bool     `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	RevisionNumber bool `protobuf:"varint,11,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	// jwt_svid_claims field mask
+	JwtSvidClaims bool `protobuf:"varint,12,opt,name=jwt_svid_claims,json=jwtSvidClaims,proto3" json:"jwt_svid_claims,omitempty"`
+	// jwt_svid_audience field mask
+	JwtSvidAudience bool `protobuf:"varint,13,opt,name=jwt_svid_audience,json=jwtSvidAudience,proto3" json:"jwt_svid_audience,omitempty"`
+	// x509_svid_key_type field mask
+	X509SvidKeyType bool `protobuf:"varint,14,opt,name=x509_svid_key_type,json=x509SvidKeyType,proto3" json:"x509_svid_key_type,omitempty"`
+	// dns_name_templates field mask
+	DnsNameTemplates bool `protobuf:"varint,15,opt,name=dns_name_templates,json=dnsNameTemplates,proto3" json:"dns_name_templates,omitempty"`
+	// x509_svid_subject field mask
+	X509SvidSubject bool `protobuf:"varint,16,opt,name=x509_svid_subject,json=x509SvidSubject,proto3" json:"x509_svid_subject,omitempty"`
+	// hint field mask
+	Hint bool `protobuf:"varint,17,opt,name=hint,proto3" json:"hint,omitempty"`
+	// selector_expression field mask
+	SelectorExpression bool `protobuf:"varint,18,opt,name=selector_expression,json=selectorExpression,proto3" json:"selector_expression,omitempty"`
+	// template_id field mask
+	TemplateId           bool     `protobuf:"varint,19,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -193,7 +367,7 @@ func (m *EntryMask) Reset()         { *m = EntryMask{} }
 func (m *EntryMask) String() string { return proto.CompactTextString(m) }
 func (*EntryMask) ProtoMessage()    {}
 func (*EntryMask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d1701a8d1ba9b5bc, []int{1}
+	return fileDescriptor_d1701a8d1ba9b5bc, []int{2}
 }
 
 func (m *EntryMask) XXX_Unmarshal(b []byte) error {
@@ -284,8 +458,66 @@ func (m *EntryMask) GetRevisionNumber() bool {
 	return false
 }
 
+func (m *EntryMask) GetJwtSvidClaims() bool {
+	if m != nil {
+		return m.JwtSvidClaims
+	}
+	return false
+}
+
+func (m *EntryMask) GetJwtSvidAudience() bool {
+	if m != nil {
+		return m.JwtSvidAudience
+	}
+	return false
+}
+
+func (m *EntryMask) GetX509SvidKeyType() bool {
+	if m != nil {
+		return m.X509SvidKeyType
+	}
+	return false
+}
+
+func (m *EntryMask) GetDnsNameTemplates() bool {
+	if m != nil {
+		return m.DnsNameTemplates
+	}
+	return false
+}
+
+func (m *EntryMask) GetX509SvidSubject() bool {
+	if m != nil {
+		return m.X509SvidSubject
+	}
+	return false
+}
+
+func (m *EntryMask) GetHint() bool {
+	if m != nil {
+		return m.Hint
+	}
+	return false
+}
+
+func (m *EntryMask) GetSelectorExpression() bool {
+	if m != nil {
+		return m.SelectorExpression
+	}
+	return false
+}
+
+func (m *EntryMask) GetTemplateId() bool {
+	if m != nil {
+		return m.TemplateId
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Entry)(nil), "spire.types.Entry")
+	proto.RegisterMapType((map[string]string)(nil), "spire.types.Entry.JwtSvidClaimsEntry")
+	proto.RegisterType((*X509SVIDSubject)(nil), "spire.types.X509SVIDSubject")
 	proto.RegisterType((*EntryMask)(nil), "spire.types.EntryMask")
 }
 
@@ -294,30 +526,51 @@ func init() {
 }
 
 var fileDescriptor_d1701a8d1ba9b5bc = []byte{
-	// 399 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x93, 0xd1, 0xca, 0xd3, 0x30,
-	0x14, 0xc7, 0x69, 0x6b, 0x67, 0x72, 0x86, 0x53, 0x82, 0x62, 0xd8, 0x54, 0xca, 0x40, 0xac, 0x08,
-	0x2d, 0x6c, 0x4f, 0xa0, 0xb8, 0x41, 0x2f, 0x1c, 0x52, 0x2f, 0x04, 0x6f, 0x4a, 0xb7, 0x64, 0x2e,
-	0xb8, 0xa6, 0x25, 0xc9, 0x9c, 0x7b, 0x03, 0xdf, 0xc8, 0xd7, 0x93, 0xa4, 0x9b, 0x76, 0xd3, 0x6f,
-	0xdf, 0xd5, 0x77, 0xd7, 0xfc, 0xff, 0xff, 0x73, 0x72, 0xfa, 0xeb, 0x29, 0x3c, 0xd5, 0x8d, 0x50,
-	0x3c, 0x35, 0x87, 0x86, 0xeb, 0x94, 0x4b, 0xa3, 0x0e, 0x49, 0xa3, 0x6a, 0x53, 0x93, 0xbe, 0x33,
-	0x12, 0x67, 0x0c, 0x87, 0xdd, 0x94, 0xe6, 0x5b, 0xbe, 0x32, 0xb5, 0x6a, 0x83, 0x17, 0x5e, 0x23,
-	0xd6, 0x6b, 0x2e, 0x58, 0xeb, 0x8d, 0x7f, 0x06, 0x10, 0xce, 0x6c, 0x53, 0x32, 0x00, 0x5f, 0x30,
-	0xea, 0x45, 0x5e, 0x8c, 0x73, 0x5f, 0x30, 0x32, 0x01, 0xdc, 0x66, 0x0b, 0xc1, 0xa8, 0x1f, 0x79,
-	0x71, 0x7f, 0xf2, 0x24, 0xe9, 0x5c, 0x99, 0x7c, 0xfa, 0x98, 0xcd, 0xe7, 0xb3, 0xec, 0x7d, 0x8e,
-	0xda, 0x5c, 0xe6, 0x6a, 0x9a, 0x52, 0x71, 0x69, 0x6c, 0x4d, 0x70, 0xb5, 0xa6, 0xcd, 0x65, 0x8c,
-	0x4c, 0x01, 0x9f, 0xe6, 0xd5, 0xf4, 0x5e, 0x14, 0xfc, 0x5b, 0x73, 0x74, 0xf3, 0xbf, 0x39, 0xf2,
-	0x08, 0x02, 0x63, 0xb6, 0x34, 0x8c, 0xbc, 0x38, 0xcc, 0xed, 0x23, 0x79, 0x09, 0x83, 0x35, 0x67,
-	0x5c, 0x95, 0x86, 0xeb, 0x62, 0x2f, 0xcc, 0x86, 0xf6, 0xa2, 0x20, 0xc6, 0xf9, 0x83, 0x3f, 0xea,
-	0x67, 0x61, 0x36, 0xe4, 0x31, 0x84, 0x25, 0xab, 0x84, 0xa4, 0xf7, 0x23, 0x2f, 0x46, 0x79, 0x7b,
-	0x20, 0x2f, 0x00, 0x58, 0xbd, 0x97, 0xda, 0x28, 0x5e, 0x56, 0x14, 0x39, 0xab, 0xa3, 0x90, 0xe7,
-	0x00, 0xfc, 0x87, 0x1d, 0x49, 0x17, 0xa5, 0xa1, 0x38, 0xf2, 0xe2, 0x20, 0xc7, 0x47, 0xe5, 0xad,
-	0x21, 0x23, 0xc0, 0x4c, 0xea, 0x42, 0x96, 0x15, 0xd7, 0x14, 0xdc, 0xb5, 0x88, 0x49, 0xbd, 0xb0,
-	0x67, 0xf2, 0x0a, 0x1e, 0x2a, 0xfe, 0x5d, 0x68, 0x51, 0xcb, 0x42, 0xee, 0xaa, 0x25, 0x57, 0xb4,
-	0xef, 0x1a, 0x0c, 0x4e, 0xf2, 0xc2, 0xa9, 0xe3, 0x5f, 0x3e, 0x60, 0xf7, 0x29, 0x3e, 0x94, 0xfa,
-	0x9b, 0xed, 0x79, 0x8e, 0x1f, 0x75, 0x38, 0x8f, 0x2e, 0x39, 0xa3, 0x0e, 0xd0, 0x67, 0xe7, 0x40,
-	0xad, 0xf9, 0x7f, 0x72, 0xe8, 0x66, 0x72, 0xd6, 0xbc, 0x23, 0x72, 0xe8, 0x0a, 0x39, 0xf7, 0x22,
-	0xb7, 0x91, 0x43, 0x97, 0xe4, 0xde, 0xbd, 0xf9, 0xf2, 0xfa, 0xab, 0x30, 0x9b, 0xdd, 0x32, 0x59,
-	0xd5, 0xd5, 0x71, 0xc3, 0xd3, 0x76, 0xe9, 0xdd, 0x96, 0xa7, 0x9d, 0x1f, 0x60, 0xd9, 0x73, 0xd2,
-	0xf4, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x01, 0x46, 0x78, 0xe8, 0x58, 0x03, 0x00, 0x00,
+	// 724 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x95, 0xdd, 0x4e, 0x13, 0x41,
+	0x14, 0xc7, 0xb3, 0x2d, 0x85, 0xdd, 0x53, 0x68, 0x61, 0xd0, 0x38, 0x01, 0xd4, 0xa6, 0x09, 0x5a,
+	0xc1, 0xb4, 0x06, 0x62, 0xa2, 0x5e, 0x89, 0x02, 0xb1, 0x1a, 0x88, 0x59, 0xf0, 0x23, 0xde, 0x6c,
+	0xb6, 0xdd, 0x81, 0x0e, 0x74, 0x67, 0x37, 0x3b, 0xb3, 0x2d, 0xf5, 0x6d, 0x7c, 0x23, 0x1f, 0xc7,
+	0x4b, 0x33, 0x67, 0x77, 0x61, 0x4b, 0xf9, 0xf2, 0xc2, 0xbb, 0x9d, 0xff, 0xf9, 0xcf, 0x9c, 0x39,
+	0x67, 0xcf, 0x2f, 0x03, 0x0f, 0x64, 0xc8, 0x23, 0xd6, 0x52, 0xa3, 0x90, 0xc9, 0x16, 0x13, 0x2a,
+	0x1a, 0x35, 0xc3, 0x28, 0x50, 0x01, 0x29, 0x63, 0xa0, 0x89, 0x81, 0xa5, 0xa5, 0xbc, 0x4b, 0xb2,
+	0x3e, 0xeb, 0xaa, 0x20, 0x4a, 0x8c, 0x97, 0x62, 0x21, 0x3f, 0x3a, 0x62, 0xdc, 0x4b, 0x62, 0xf5,
+	0xdf, 0xd3, 0x50, 0xda, 0xd1, 0x87, 0x92, 0x0a, 0x14, 0xb8, 0x47, 0x8d, 0x9a, 0xd1, 0xb0, 0xec,
+	0x02, 0xf7, 0xc8, 0x06, 0x58, 0x89, 0xd7, 0xe1, 0x1e, 0x2d, 0xd4, 0x8c, 0x46, 0x79, 0xe3, 0x7e,
+	0x33, 0x97, 0xb2, 0x79, 0xf0, 0xb9, 0xbd, 0xbb, 0xbb, 0xd3, 0xde, 0xb6, 0xcd, 0xc4, 0xd7, 0xc6,
+	0x3d, 0xa1, 0x1b, 0x31, 0xa1, 0xf4, 0x9e, 0xe2, 0x8d, 0x7b, 0x12, 0x5f, 0xdb, 0x23, 0x9b, 0x60,
+	0x65, 0xf7, 0x95, 0x74, 0xaa, 0x56, 0x9c, 0xdc, 0x93, 0x46, 0xed, 0x0b, 0x1f, 0x99, 0x87, 0xa2,
+	0x52, 0x7d, 0x5a, 0xaa, 0x19, 0x8d, 0x92, 0xad, 0x3f, 0xc9, 0x2a, 0x54, 0x8e, 0x98, 0xc7, 0x22,
+	0x57, 0x31, 0xe9, 0x0c, 0xb9, 0xea, 0xd1, 0xe9, 0x5a, 0xb1, 0x61, 0xd9, 0x73, 0xe7, 0xea, 0x37,
+	0xae, 0x7a, 0xe4, 0x1e, 0x94, 0x5c, 0xcf, 0xe7, 0x82, 0xce, 0xd4, 0x8c, 0x86, 0x69, 0x27, 0x0b,
+	0xf2, 0x08, 0xc0, 0x0b, 0x86, 0x42, 0xaa, 0x88, 0xb9, 0x3e, 0x35, 0x31, 0x94, 0x53, 0xc8, 0x43,
+	0x00, 0x76, 0xa6, 0xaf, 0x24, 0x1d, 0x57, 0x51, 0xab, 0x66, 0x34, 0x8a, 0xb6, 0x95, 0x2a, 0x5b,
+	0x8a, 0x2c, 0x83, 0xe5, 0x09, 0xe9, 0x08, 0xd7, 0x67, 0x92, 0x02, 0xa6, 0x35, 0x3d, 0x21, 0xf7,
+	0xf5, 0x9a, 0x3c, 0x85, 0x6a, 0xc4, 0x06, 0x5c, 0xf2, 0x40, 0x38, 0x22, 0xf6, 0x3b, 0x2c, 0xa2,
+	0x65, 0x3c, 0xa0, 0x92, 0xc9, 0xfb, 0xa8, 0x92, 0x3d, 0xa8, 0x9e, 0x0c, 0x95, 0x23, 0x07, 0xdc,
+	0x73, 0xba, 0x7d, 0x97, 0xfb, 0x92, 0xce, 0x62, 0x3b, 0x56, 0xc7, 0xda, 0x81, 0x7f, 0xab, 0xf9,
+	0x71, 0xa8, 0x0e, 0x06, 0xdc, 0x7b, 0x8f, 0x3e, 0x94, 0xec, 0xb9, 0x93, 0xbc, 0x46, 0xd6, 0x60,
+	0xe1, 0xfc, 0x38, 0x37, 0xf6, 0x38, 0x13, 0x5d, 0x46, 0xe7, 0xf0, 0x72, 0xd5, 0xd4, 0xb9, 0x95,
+	0xca, 0x64, 0x1d, 0xc8, 0xd9, 0xcb, 0x17, 0xaf, 0x13, 0xf3, 0x29, 0x1b, 0x39, 0x3a, 0x15, 0xad,
+	0xe0, 0x2c, 0x54, 0x75, 0x44, 0xbb, 0x3f, 0xb1, 0xd1, 0xe1, 0x28, 0x64, 0xe4, 0x39, 0x90, 0xac,
+	0x5a, 0x47, 0x31, 0x3f, 0xec, 0xeb, 0xe6, 0xd2, 0x2a, 0x9e, 0x3c, 0x9f, 0x96, 0x7d, 0x98, 0xe9,
+	0xe4, 0x03, 0x2c, 0x5c, 0x1c, 0x2d, 0xe3, 0xce, 0x09, 0xeb, 0x2a, 0x3a, 0x8f, 0xa3, 0xb1, 0x32,
+	0x56, 0xd7, 0x77, 0x9d, 0xe6, 0x6b, 0x7b, 0xfb, 0x20, 0xf1, 0x5c, 0xe4, 0x4d, 0x05, 0x42, 0x60,
+	0xaa, 0xc7, 0x85, 0xa2, 0x0b, 0x78, 0x2d, 0xfc, 0x26, 0x2d, 0x58, 0xcc, 0x86, 0xc2, 0x61, 0x67,
+	0x61, 0xc4, 0xa4, 0x6e, 0x28, 0x25, 0x68, 0x21, 0x59, 0x68, 0xe7, 0x3c, 0x42, 0x1e, 0x43, 0x39,
+	0xbb, 0xb3, 0x9e, 0xd1, 0x45, 0x34, 0x42, 0x26, 0xb5, 0xbd, 0xa5, 0xb7, 0x40, 0x26, 0x7b, 0xab,
+	0xe7, 0xed, 0x94, 0x8d, 0x52, 0x3a, 0xf4, 0xa7, 0x1e, 0xa4, 0x81, 0xdb, 0x8f, 0x19, 0xa2, 0x61,
+	0xd9, 0xc9, 0xe2, 0x4d, 0xe1, 0x95, 0x51, 0xff, 0x65, 0x40, 0xf5, 0x52, 0x31, 0x3a, 0x6d, 0x37,
+	0xf0, 0x7d, 0x3d, 0x02, 0xae, 0xcf, 0xd2, 0x73, 0x20, 0x91, 0x74, 0xbf, 0x48, 0x1d, 0x66, 0x83,
+	0xe8, 0xd8, 0x15, 0xfc, 0xa7, 0xab, 0x74, 0x05, 0x05, 0x6c, 0xe7, 0x98, 0xa6, 0x8b, 0xcd, 0xaf,
+	0xdd, 0xbe, 0x13, 0x0b, 0xae, 0x68, 0x11, 0xad, 0x64, 0x3c, 0xf4, 0x45, 0x70, 0x45, 0x28, 0xcc,
+	0x74, 0x83, 0x58, 0x17, 0x80, 0x60, 0x59, 0x76, 0xb6, 0xac, 0xff, 0x99, 0x02, 0x0b, 0x2b, 0xdb,
+	0x73, 0xe5, 0xa9, 0x9e, 0xdf, 0x71, 0xd4, 0xcd, 0x1c, 0xd3, 0xcb, 0x97, 0x99, 0x36, 0x73, 0xf0,
+	0xae, 0x8c, 0xc3, 0xab, 0x83, 0x57, 0x53, 0x6a, 0x5e, 0x4f, 0xa9, 0x0e, 0xfe, 0x27, 0x4a, 0xcd,
+	0x1b, 0x28, 0xc5, 0x42, 0x6e, 0xa3, 0xd4, 0x9c, 0xa0, 0xf4, 0xc9, 0x55, 0x94, 0x62, 0x09, 0x77,
+	0xc2, 0x4f, 0x3b, 0xff, 0x01, 0x3f, 0xf3, 0xee, 0xf8, 0x69, 0xf3, 0x24, 0x7e, 0x6b, 0xd7, 0xe1,
+	0x67, 0xde, 0x0c, 0x98, 0x79, 0x3b, 0x60, 0xe6, 0x5d, 0x01, 0x33, 0xf3, 0x80, 0xbd, 0x5b, 0xff,
+	0xf1, 0xec, 0x98, 0xab, 0x5e, 0xdc, 0x69, 0x76, 0x03, 0x3f, 0x7d, 0x8e, 0x5a, 0xc9, 0x0b, 0x85,
+	0x4f, 0x52, 0x2b, 0xf7, 0x5a, 0x75, 0xa6, 0x51, 0xda, 0xfc, 0x3b, 0x00, 0x79, 0xab, 0x94, 0x3e,
+	0x05, 0x07, 0x00, 0x00,
 }
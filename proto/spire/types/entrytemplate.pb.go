@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: spire/types/entrytemplate.proto
+
+package types
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// An entry template, or identity class, holds the policy shared by the
+// entries that reference it. Updating a template updates all of its entries
+// at once.
+type EntryTemplate struct {
+	// Required. The unique name of the template (e.g. "web").
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The shape of the SPIFFE ID paths of the entries using the template.
+	// Path segments set to `*` match any single segment (e.g.
+	// "/ns/*/sa/*"). When empty, any path is accepted.
+	SpiffeIdPath string `protobuf:"bytes,2,opt,name=spiffe_id_path,json=spiffeIdPath,proto3" json:"spiffe_id_path,omitempty"`
+	// The time to live for identities issued for the entries using the
+	// template (in seconds).
+	Ttl int32 `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// The names of trust domains the identities described by the entries
+	// using the template federate with.
+	FederatesWith []string `protobuf:"bytes,4,rep,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
+	// Go text/template strings rendered into additional DNS names of
+	// X509-SVIDs minted for the entries using the template (see
+	// Entry.dns_name_templates).
+	DnsNameTemplates     []string `protobuf:"bytes,5,rep,name=dns_name_templates,json=dnsNameTemplates,proto3" json:"dns_name_templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EntryTemplate) Reset()         { *m = EntryTemplate{} }
+func (m *EntryTemplate) String() string { return proto.CompactTextString(m) }
+func (*EntryTemplate) ProtoMessage()    {}
+func (*EntryTemplate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2832cc38ccc834bb, []int{0}
+}
+
+func (m *EntryTemplate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntryTemplate.Unmarshal(m, b)
+}
+func (m *EntryTemplate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntryTemplate.Marshal(b, m, deterministic)
+}
+func (m *EntryTemplate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntryTemplate.Merge(m, src)
+}
+func (m *EntryTemplate) XXX_Size() int {
+	return xxx_messageInfo_EntryTemplate.Size(m)
+}
+func (m *EntryTemplate) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntryTemplate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntryTemplate proto.InternalMessageInfo
+
+func (m *EntryTemplate) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *EntryTemplate) GetSpiffeIdPath() string {
+	if m != nil {
+		return m.SpiffeIdPath
+	}
+	return ""
+}
+
+func (m *EntryTemplate) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *EntryTemplate) GetFederatesWith() []string {
+	if m != nil {
+		return m.FederatesWith
+	}
+	return nil
+}
+
+func (m *EntryTemplate) GetDnsNameTemplates() []string {
+	if m != nil {
+		return m.DnsNameTemplates
+	}
+	return nil
+}
+
+// Field mask for EntryTemplate fields
+type EntryTemplateMask struct {
+	// spiffe_id_path field mask
+	SpiffeIdPath bool `protobuf:"varint,2,opt,name=spiffe_id_path,json=spiffeIdPath,proto3" json:"spiffe_id_path,omitempty"`
+	// ttl field mask
+	Ttl bool `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// federates_with field mask
+	FederatesWith bool `protobuf:"varint,4,opt,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
+	// dns_name_templates field mask
+	DnsNameTemplates     bool     `protobuf:"varint,5,opt,name=dns_name_templates,json=dnsNameTemplates,proto3" json:"dns_name_templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EntryTemplateMask) Reset()         { *m = EntryTemplateMask{} }
+func (m *EntryTemplateMask) String() string { return proto.CompactTextString(m) }
+func (*EntryTemplateMask) ProtoMessage()    {}
+func (*EntryTemplateMask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2832cc38ccc834bb, []int{1}
+}
+
+func (m *EntryTemplateMask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntryTemplateMask.Unmarshal(m, b)
+}
+func (m *EntryTemplateMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntryTemplateMask.Marshal(b, m, deterministic)
+}
+func (m *EntryTemplateMask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntryTemplateMask.Merge(m, src)
+}
+func (m *EntryTemplateMask) XXX_Size() int {
+	return xxx_messageInfo_EntryTemplateMask.Size(m)
+}
+func (m *EntryTemplateMask) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntryTemplateMask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntryTemplateMask proto.InternalMessageInfo
+
+func (m *EntryTemplateMask) GetSpiffeIdPath() bool {
+	if m != nil {
+		return m.SpiffeIdPath
+	}
+	return false
+}
+
+func (m *EntryTemplateMask) GetTtl() bool {
+	if m != nil {
+		return m.Ttl
+	}
+	return false
+}
+
+func (m *EntryTemplateMask) GetFederatesWith() bool {
+	if m != nil {
+		return m.FederatesWith
+	}
+	return false
+}
+
+func (m *EntryTemplateMask) GetDnsNameTemplates() bool {
+	if m != nil {
+		return m.DnsNameTemplates
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*EntryTemplate)(nil), "spire.types.EntryTemplate")
+	proto.RegisterType((*EntryTemplateMask)(nil), "spire.types.EntryTemplateMask")
+}
+
+func init() {
+	proto.RegisterFile("spire/types/entrytemplate.proto", fileDescriptor_2832cc38ccc834bb)
+}
+
+var fileDescriptor_2832cc38ccc834bb = []byte{
+	// 255 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0xd1, 0xc1, 0x4a, 0xc4, 0x30,
+	0x10, 0x80, 0x61, 0xda, 0xba, 0xb2, 0x1b, 0xdd, 0xb2, 0xe6, 0x94, 0x9b, 0x65, 0x51, 0xa8, 0x28,
+	0xcd, 0xc1, 0x37, 0x10, 0x3c, 0x78, 0x50, 0xa4, 0x08, 0x82, 0x97, 0x90, 0x35, 0x53, 0x13, 0xdc,
+	0xb6, 0xa1, 0x33, 0x22, 0xfb, 0x36, 0x3e, 0x80, 0x0f, 0x29, 0x4d, 0x5d, 0x51, 0xa4, 0xb0, 0xb7,
+	0x30, 0xf3, 0x1f, 0x3e, 0x32, 0xec, 0x18, 0xbd, 0xeb, 0x40, 0xd2, 0xc6, 0x03, 0x4a, 0x68, 0xa8,
+	0xdb, 0x10, 0xd4, 0x7e, 0xad, 0x09, 0x0a, 0xdf, 0xb5, 0xd4, 0xf2, 0x83, 0x10, 0x14, 0x21, 0x58,
+	0x7e, 0x46, 0x6c, 0x7e, 0xdd, 0x47, 0x0f, 0xdf, 0x11, 0x4f, 0x59, 0xec, 0x8c, 0x88, 0xb2, 0x28,
+	0x9f, 0x95, 0xb1, 0x33, 0xfc, 0x84, 0xa5, 0xe8, 0x5d, 0x55, 0x81, 0x72, 0x46, 0x79, 0x4d, 0x56,
+	0xc4, 0x61, 0x77, 0x38, 0x4c, 0x6f, 0xcc, 0xbd, 0x26, 0xcb, 0x17, 0x2c, 0x21, 0x5a, 0x8b, 0x24,
+	0x8b, 0xf2, 0x49, 0xd9, 0x3f, 0xf9, 0x29, 0x4b, 0x2b, 0x30, 0xd0, 0x69, 0x02, 0x54, 0xef, 0x8e,
+	0xac, 0xd8, 0xcb, 0x92, 0x7c, 0x56, 0xce, 0x7f, 0xa6, 0x8f, 0x8e, 0x2c, 0xbf, 0x60, 0xdc, 0x34,
+	0xa8, 0x1a, 0x5d, 0x83, 0xda, 0x42, 0x51, 0x4c, 0x42, 0xba, 0x30, 0x0d, 0xde, 0xe9, 0x1a, 0xb6,
+	0x36, 0x5c, 0x7e, 0x44, 0xec, 0xe8, 0x0f, 0xf7, 0x56, 0xe3, 0xeb, 0x08, 0x71, 0x3a, 0x4e, 0x9c,
+	0x8e, 0x13, 0xfb, 0xe5, 0x8e, 0xc4, 0x3e, 0xfd, 0x47, 0xbc, 0x3a, 0x7f, 0x3a, 0x7b, 0x71, 0x64,
+	0xdf, 0x56, 0xc5, 0x73, 0x5b, 0xcb, 0x41, 0x20, 0x87, 0x9b, 0x84, 0xff, 0x97, 0xbf, 0xee, 0xb3,
+	0xda, 0x0f, 0xa3, 0xcb, 0xaf, 0x01, 0x00, 0xa9, 0xaa, 0x31, 0x8f, 0xb5, 0x01, 0x00, 0x00,
+}
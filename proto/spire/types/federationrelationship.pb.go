@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: spire/types/federationrelationship.proto
+
+package types
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type FederationRelationship struct {
+	// Required. The trust domain name (e.g., "example.org") to federate with.
+	TrustDomain string `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain,proto3" json:"trust_domain,omitempty"`
+	// Required. URL of the SPIFFE bundle endpoint that provides the trust
+	// bundle to federate with. Must use the HTTPS protocol.
+	BundleEndpointUrl string `protobuf:"bytes,2,opt,name=bundle_endpoint_url,json=bundleEndpointUrl,proto3" json:"bundle_endpoint_url,omitempty"`
+	// Required. The endpoint profile type.
+	//
+	// Types that are valid to be assigned to BundleEndpointProfile:
+	//	*FederationRelationship_HttpsWeb
+	//	*FederationRelationship_HttpsSpiffe
+	BundleEndpointProfile isFederationRelationship_BundleEndpointProfile `protobuf_oneof:"bundle_endpoint_profile"`
+	XXX_NoUnkeyedLiteral  struct{}                                       `json:"-"`
+	XXX_unrecognized      []byte                                         `json:"-"`
+	XXX_sizecache         int32                                          `json:"-"`
+}
+
+func (m *FederationRelationship) Reset()         { *m = FederationRelationship{} }
+func (m *FederationRelationship) String() string { return proto.CompactTextString(m) }
+func (*FederationRelationship) ProtoMessage()    {}
+func (*FederationRelationship) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4915ab3cd689aa8, []int{0}
+}
+
+func (m *FederationRelationship) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederationRelationship.Unmarshal(m, b)
+}
+func (m *FederationRelationship) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederationRelationship.Marshal(b, m, deterministic)
+}
+func (m *FederationRelationship) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederationRelationship.Merge(m, src)
+}
+func (m *FederationRelationship) XXX_Size() int {
+	return xxx_messageInfo_FederationRelationship.Size(m)
+}
+func (m *FederationRelationship) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederationRelationship.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederationRelationship proto.InternalMessageInfo
+
+func (m *FederationRelationship) GetTrustDomain() string {
+	if m != nil {
+		return m.TrustDomain
+	}
+	return ""
+}
+
+func (m *FederationRelationship) GetBundleEndpointUrl() string {
+	if m != nil {
+		return m.BundleEndpointUrl
+	}
+	return ""
+}
+
+type isFederationRelationship_BundleEndpointProfile interface {
+	isFederationRelationship_BundleEndpointProfile()
+}
+
+type FederationRelationship_HttpsWeb struct {
+	HttpsWeb *HTTPSWebProfile `protobuf:"bytes,3,opt,name=https_web,json=httpsWeb,proto3,oneof"`
+}
+
+type FederationRelationship_HttpsSpiffe struct {
+	HttpsSpiffe *HTTPSSPIFFEProfile `protobuf:"bytes,4,opt,name=https_spiffe,json=httpsSpiffe,proto3,oneof"`
+}
+
+func (*FederationRelationship_HttpsWeb) isFederationRelationship_BundleEndpointProfile() {}
+
+func (*FederationRelationship_HttpsSpiffe) isFederationRelationship_BundleEndpointProfile() {}
+
+func (m *FederationRelationship) GetBundleEndpointProfile() isFederationRelationship_BundleEndpointProfile {
+	if m != nil {
+		return m.BundleEndpointProfile
+	}
+	return nil
+}
+
+func (m *FederationRelationship) GetHttpsWeb() *HTTPSWebProfile {
+	if x, ok := m.GetBundleEndpointProfile().(*FederationRelationship_HttpsWeb); ok {
+		return x.HttpsWeb
+	}
+	return nil
+}
+
+func (m *FederationRelationship) GetHttpsSpiffe() *HTTPSSPIFFEProfile {
+	if x, ok := m.GetBundleEndpointProfile().(*FederationRelationship_HttpsSpiffe); ok {
+		return x.HttpsSpiffe
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*FederationRelationship) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*FederationRelationship_HttpsWeb)(nil),
+		(*FederationRelationship_HttpsSpiffe)(nil),
+	}
+}
+
+type HTTPSWebProfile struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HTTPSWebProfile) Reset()         { *m = HTTPSWebProfile{} }
+func (m *HTTPSWebProfile) String() string { return proto.CompactTextString(m) }
+func (*HTTPSWebProfile) ProtoMessage()    {}
+func (*HTTPSWebProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4915ab3cd689aa8, []int{1}
+}
+
+func (m *HTTPSWebProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HTTPSWebProfile.Unmarshal(m, b)
+}
+func (m *HTTPSWebProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HTTPSWebProfile.Marshal(b, m, deterministic)
+}
+func (m *HTTPSWebProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HTTPSWebProfile.Merge(m, src)
+}
+func (m *HTTPSWebProfile) XXX_Size() int {
+	return xxx_messageInfo_HTTPSWebProfile.Size(m)
+}
+func (m *HTTPSWebProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_HTTPSWebProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HTTPSWebProfile proto.InternalMessageInfo
+
+type HTTPSSPIFFEProfile struct {
+	// Required. Specifies the expected SPIFFE ID of the SPIFFE bundle endpoint
+	// server.
+	EndpointSpiffeId     string   `protobuf:"bytes,1,opt,name=endpoint_spiffe_id,json=endpointSpiffeId,proto3" json:"endpoint_spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HTTPSSPIFFEProfile) Reset()         { *m = HTTPSSPIFFEProfile{} }
+func (m *HTTPSSPIFFEProfile) String() string { return proto.CompactTextString(m) }
+func (*HTTPSSPIFFEProfile) ProtoMessage()    {}
+func (*HTTPSSPIFFEProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4915ab3cd689aa8, []int{2}
+}
+
+func (m *HTTPSSPIFFEProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HTTPSSPIFFEProfile.Unmarshal(m, b)
+}
+func (m *HTTPSSPIFFEProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HTTPSSPIFFEProfile.Marshal(b, m, deterministic)
+}
+func (m *HTTPSSPIFFEProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HTTPSSPIFFEProfile.Merge(m, src)
+}
+func (m *HTTPSSPIFFEProfile) XXX_Size() int {
+	return xxx_messageInfo_HTTPSSPIFFEProfile.Size(m)
+}
+func (m *HTTPSSPIFFEProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_HTTPSSPIFFEProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HTTPSSPIFFEProfile proto.InternalMessageInfo
+
+func (m *HTTPSSPIFFEProfile) GetEndpointSpiffeId() string {
+	if m != nil {
+		return m.EndpointSpiffeId
+	}
+	return ""
+}
+
+type FederationRelationshipMask struct {
+	// bundle_endpoint_url field mask.
+	BundleEndpointUrl bool `protobuf:"varint,2,opt,name=bundle_endpoint_url,json=bundleEndpointUrl,proto3" json:"bundle_endpoint_url,omitempty"`
+	// bundle_endpoint_profile field mask.
+	BundleEndpointProfile bool     `protobuf:"varint,3,opt,name=bundle_endpoint_profile,json=bundleEndpointProfile,proto3" json:"bundle_endpoint_profile,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *FederationRelationshipMask) Reset()         { *m = FederationRelationshipMask{} }
+func (m *FederationRelationshipMask) String() string { return proto.CompactTextString(m) }
+func (*FederationRelationshipMask) ProtoMessage()    {}
+func (*FederationRelationshipMask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4915ab3cd689aa8, []int{3}
+}
+
+func (m *FederationRelationshipMask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederationRelationshipMask.Unmarshal(m, b)
+}
+func (m *FederationRelationshipMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederationRelationshipMask.Marshal(b, m, deterministic)
+}
+func (m *FederationRelationshipMask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederationRelationshipMask.Merge(m, src)
+}
+func (m *FederationRelationshipMask) XXX_Size() int {
+	return xxx_messageInfo_FederationRelationshipMask.Size(m)
+}
+func (m *FederationRelationshipMask) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederationRelationshipMask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederationRelationshipMask proto.InternalMessageInfo
+
+func (m *FederationRelationshipMask) GetBundleEndpointUrl() bool {
+	if m != nil {
+		return m.BundleEndpointUrl
+	}
+	return false
+}
+
+func (m *FederationRelationshipMask) GetBundleEndpointProfile() bool {
+	if m != nil {
+		return m.BundleEndpointProfile
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*FederationRelationship)(nil), "spire.types.FederationRelationship")
+	proto.RegisterType((*HTTPSWebProfile)(nil), "spire.types.HTTPSWebProfile")
+	proto.RegisterType((*HTTPSSPIFFEProfile)(nil), "spire.types.HTTPSSPIFFEProfile")
+	proto.RegisterType((*FederationRelationshipMask)(nil), "spire.types.FederationRelationshipMask")
+}
+
+func init() {
+	proto.RegisterFile("spire/types/federationrelationship.proto", fileDescriptor_b4915ab3cd689aa8)
+}
+
+var fileDescriptor_b4915ab3cd689aa8 = []byte{
+	// 322 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xc1, 0x4b, 0xc3, 0x30,
+	0x14, 0xc6, 0xed, 0x14, 0xd9, 0xd2, 0x81, 0x2e, 0xa2, 0x56, 0x11, 0x9c, 0x3d, 0x4d, 0x94, 0x16,
+	0x14, 0xbc, 0x78, 0x1b, 0xdb, 0xd8, 0x0e, 0xc2, 0xe8, 0x26, 0x03, 0x2f, 0x65, 0x35, 0xaf, 0x2e,
+	0xd8, 0x35, 0x21, 0x49, 0x11, 0xef, 0x5e, 0xfc, 0xaf, 0x85, 0x97, 0x39, 0xeb, 0x36, 0x3d, 0x05,
+	0xde, 0xfb, 0x7e, 0xef, 0x7d, 0xf9, 0x78, 0xa4, 0xa5, 0x25, 0x57, 0x10, 0x9a, 0x77, 0x09, 0x3a,
+	0x4c, 0x81, 0x81, 0x9a, 0x1a, 0x2e, 0x72, 0x05, 0x19, 0xbe, 0x7a, 0xc6, 0x65, 0x20, 0x95, 0x30,
+	0x82, 0xba, 0xa8, 0x0c, 0x50, 0xe9, 0x7f, 0x56, 0xc8, 0x51, 0x6f, 0xa9, 0x8e, 0x4a, 0x6a, 0x7a,
+	0x41, 0xea, 0x46, 0x15, 0xda, 0xc4, 0x4c, 0xcc, 0xa7, 0x3c, 0xf7, 0x9c, 0xa6, 0xd3, 0xaa, 0x45,
+	0x2e, 0xd6, 0x3a, 0x58, 0xa2, 0x01, 0x39, 0x48, 0x8a, 0x9c, 0x65, 0x10, 0x43, 0xce, 0xa4, 0xe0,
+	0xb9, 0x89, 0x0b, 0x95, 0x79, 0x15, 0x54, 0x36, 0x6c, 0xab, 0xbb, 0xe8, 0x3c, 0xaa, 0x8c, 0xde,
+	0x93, 0xda, 0xcc, 0x18, 0xa9, 0xe3, 0x37, 0x48, 0xbc, 0xed, 0xa6, 0xd3, 0x72, 0x6f, 0xce, 0x82,
+	0x92, 0x9d, 0xa0, 0x3f, 0x1e, 0x0f, 0x47, 0x13, 0x48, 0x86, 0x4a, 0xa4, 0x3c, 0x83, 0xfe, 0x56,
+	0x54, 0x45, 0x60, 0x02, 0x09, 0xed, 0x90, 0xba, 0x85, 0xb5, 0xe4, 0x69, 0x0a, 0xde, 0x0e, 0xf2,
+	0xe7, 0xeb, 0xfc, 0x68, 0x38, 0xe8, 0xf5, 0xba, 0x3f, 0x23, 0x5c, 0xc4, 0x46, 0x48, 0xb5, 0x4f,
+	0xc8, 0xf1, 0xaa, 0x65, 0x69, 0x95, 0x7e, 0x83, 0xec, 0xad, 0xec, 0xf7, 0xdb, 0x84, 0xae, 0x8f,
+	0xa4, 0xd7, 0x84, 0x2e, 0x61, 0x6b, 0x26, 0xe6, 0x6c, 0x91, 0xcf, 0xfe, 0x77, 0xc7, 0xee, 0x1b,
+	0x30, 0xff, 0xc3, 0x21, 0xa7, 0x9b, 0x23, 0x7e, 0x98, 0xea, 0xd7, 0xff, 0x32, 0xac, 0x6e, 0xca,
+	0xf0, 0xee, 0xcf, 0x0f, 0x60, 0xa2, 0xd5, 0xe8, 0xf0, 0x37, 0xb3, 0x30, 0xdd, 0xbe, 0x7a, 0xba,
+	0x7c, 0xe1, 0x66, 0x56, 0x24, 0xc1, 0xb3, 0x98, 0x87, 0xd6, 0x76, 0x68, 0x8f, 0x06, 0xef, 0x22,
+	0x2c, 0x1d, 0x50, 0xb2, 0x8b, 0xa5, 0xdb, 0xaf, 0x01, 0x00, 0x08, 0xff, 0x75, 0x56, 0x56, 0x02,
+	0x00, 0x00,
+}
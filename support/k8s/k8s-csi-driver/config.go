@@ -0,0 +1,84 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/hashicorp/hcl"
+	"github.com/zeebo/errs"
+)
+
+const (
+	defaultLogLevel      = "info"
+	defaultDriverName    = "csi.spiffe.io"
+	defaultCSISocketPath = "/spiffe-csi/csi.sock"
+
+	// nodeNameEnv is the environment variable the node ID is read from when
+	// it is not configured, usually set from the spec.nodeName field of the
+	// driver pod.
+	nodeNameEnv = "NODE_NAME"
+)
+
+type Config struct {
+	LogFormat string `hcl:"log_format"`
+	LogLevel  string `hcl:"log_level"`
+	LogPath   string `hcl:"log_path"`
+
+	// DriverName is the name the driver is registered with in Kubernetes,
+	// i.e. the name of the CSIDriver object.
+	DriverName string `hcl:"driver_name"`
+
+	// NodeID identifies the node the driver runs on. If unset, the value
+	// of the NODE_NAME environment variable is used.
+	NodeID string `hcl:"node_id"`
+
+	// CSISocketPath is the path of the socket the kubelet reaches the
+	// driver through.
+	CSISocketPath string `hcl:"csi_socket_path"`
+
+	// WorkloadAPISocketDir is the directory holding the Workload API socket
+	// of the agent. It is mounted read-only into the pods.
+	WorkloadAPISocketDir string `hcl:"workload_api_socket_dir"`
+
+	// SELinuxLabel, if set, is the SELinux label applied to the Workload
+	// API socket directory so confined containers can reach the socket
+	// (e.g. system_u:object_r:container_file_t:s0).
+	SELinuxLabel string `hcl:"selinux_label"`
+}
+
+func LoadConfig(path string) (*Config, error) {
+	hclBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errs.New("unable to load configuration: %v", err)
+	}
+	return ParseConfig(string(hclBytes))
+}
+
+func ParseConfig(hclConfig string) (*Config, error) {
+	c := new(Config)
+	if err := hcl.Decode(c, hclConfig); err != nil {
+		return nil, errs.New("unable to decode configuration: %v", err)
+	}
+
+	if c.LogLevel == "" {
+		c.LogLevel = defaultLogLevel
+	}
+	if c.DriverName == "" {
+		c.DriverName = defaultDriverName
+	}
+	if c.CSISocketPath == "" {
+		c.CSISocketPath = defaultCSISocketPath
+	}
+	if c.NodeID == "" {
+		c.NodeID = os.Getenv(nodeNameEnv)
+	}
+
+	if c.NodeID == "" {
+		return nil, errs.New("node_id must be configured or the %s environment variable set", nodeNameEnv)
+	}
+	if c.WorkloadAPISocketDir == "" {
+		return nil, errs.New("workload_api_socket_dir must be configured")
+	}
+
+	return c, nil
+}
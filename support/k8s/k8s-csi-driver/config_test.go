@@ -0,0 +1,132 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	require := require.New(t)
+
+	dir := spiretest.TempDir(t)
+
+	confPath := filepath.Join(dir, "test.conf")
+
+	_, err := LoadConfig(confPath)
+	require.Error(err)
+	require.Contains(err.Error(), "unable to load configuration:")
+
+	err = ioutil.WriteFile(confPath, []byte(`
+		node_id = "node-1"
+		workload_api_socket_dir = "/run/spire/sockets"
+	`), 0600)
+	require.NoError(err)
+
+	config, err := LoadConfig(confPath)
+	require.NoError(err)
+
+	require.Equal(&Config{
+		LogLevel:             defaultLogLevel,
+		DriverName:           defaultDriverName,
+		NodeID:               "node-1",
+		CSISocketPath:        defaultCSISocketPath,
+		WorkloadAPISocketDir: "/run/spire/sockets",
+	}, config)
+}
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		in      string
+		nodeEnv string
+		out     *Config
+		err     string
+	}{
+		{
+			name: "malformed HCL",
+			in:   `BAD`,
+			err:  "unable to decode configuration",
+		},
+		{
+			name: "no node ID",
+			in: `
+				workload_api_socket_dir = "/run/spire/sockets"
+			`,
+			err: "node_id must be configured or the NODE_NAME environment variable set",
+		},
+		{
+			name: "no socket directory",
+			in: `
+				node_id = "node-1"
+			`,
+			err: "workload_api_socket_dir must be configured",
+		},
+		{
+			name: "node ID from the environment",
+			in: `
+				workload_api_socket_dir = "/run/spire/sockets"
+			`,
+			nodeEnv: "node-2",
+			out: &Config{
+				LogLevel:             defaultLogLevel,
+				DriverName:           defaultDriverName,
+				NodeID:               "node-2",
+				CSISocketPath:        defaultCSISocketPath,
+				WorkloadAPISocketDir: "/run/spire/sockets",
+			},
+		},
+		{
+			name: "all options",
+			in: `
+				log_level = "debug"
+				driver_name = "csi.example.org"
+				node_id = "node-1"
+				csi_socket_path = "/csi/csi.sock"
+				workload_api_socket_dir = "/run/spire/sockets"
+				selinux_label = "system_u:object_r:container_file_t:s0"
+			`,
+			nodeEnv: "node-2",
+			out: &Config{
+				LogLevel:             "debug",
+				DriverName:           "csi.example.org",
+				NodeID:               "node-1",
+				CSISocketPath:        "/csi/csi.sock",
+				WorkloadAPISocketDir: "/run/spire/sockets",
+				SELinuxLabel:         "system_u:object_r:container_file_t:s0",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			setNodeNameEnv(t, testCase.nodeEnv)
+
+			actual, err := ParseConfig(testCase.in)
+			if testCase.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.out, actual)
+		})
+	}
+}
+
+func setNodeNameEnv(t *testing.T, value string) {
+	old, ok := os.LookupEnv(nodeNameEnv)
+	require.NoError(t, os.Setenv(nodeNameEnv, value))
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(nodeNameEnv, old)
+		} else {
+			os.Unsetenv(nodeNameEnv)
+		}
+	})
+}
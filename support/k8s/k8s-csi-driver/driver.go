@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/version"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Mounter mounts the Workload API socket directory into the pod volumes
+type Mounter interface {
+	// BindMount mounts the source directory read-only onto the target
+	// directory
+	BindMount(source, target string) error
+
+	// Unmount unmounts the target directory
+	Unmount(target string) error
+
+	// IsMountPoint returns whether the target directory is a mount point
+	IsMountPoint(target string) (bool, error)
+
+	// SetLabel sets the SELinux label of the path
+	SetLabel(path, label string) error
+}
+
+type DriverConfig struct {
+	Log                  logrus.FieldLogger
+	Name                 string
+	NodeID               string
+	WorkloadAPISocketDir string
+	SELinuxLabel         string
+	Mounter              Mounter
+}
+
+// Driver is a node-only CSI driver that mounts the Workload API socket
+// directory of the agent into the pods as an ephemeral inline volume, which
+// replaces hostPath volumes.
+//
+// The directory holding the socket is mounted rather than the socket
+// itself, so the socket the agent creates again when it restarts is still
+// reachable through the existing mounts.
+type Driver struct {
+	csi.UnimplementedIdentityServer
+	csi.UnimplementedNodeServer
+
+	c DriverConfig
+
+	// mu serializes the mount operations, which the kubelet may issue
+	// concurrently for the same target
+	mu sync.Mutex
+}
+
+func NewDriver(config DriverConfig) *Driver {
+	return &Driver{
+		c: config,
+	}
+}
+
+func (d *Driver) GetPluginInfo(context.Context, *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
+	return &csi.GetPluginInfoResponse{
+		Name:          d.c.Name,
+		VendorVersion: version.Version(),
+	}, nil
+}
+
+func (d *Driver) GetPluginCapabilities(context.Context, *csi.GetPluginCapabilitiesRequest) (*csi.GetPluginCapabilitiesResponse, error) {
+	// Only the node service is provided
+	return &csi.GetPluginCapabilitiesResponse{}, nil
+}
+
+// Probe reports the driver as ready once the Workload API socket directory
+// exists.
+func (d *Driver) Probe(context.Context, *csi.ProbeRequest) (*csi.ProbeResponse, error) {
+	info, err := os.Stat(d.c.WorkloadAPISocketDir)
+	ready := err == nil && info.IsDir()
+	if !ready {
+		d.c.Log.WithError(err).Warn("Workload API socket directory is not available")
+	}
+	return &csi.ProbeResponse{
+		Ready: &wrappers.BoolValue{Value: ready},
+	}, nil
+}
+
+func (d *Driver) NodeGetCapabilities(context.Context, *csi.NodeGetCapabilitiesRequest) (*csi.NodeGetCapabilitiesResponse, error) {
+	// Volumes are neither staged, expanded nor measured
+	return &csi.NodeGetCapabilitiesResponse{}, nil
+}
+
+func (d *Driver) NodeGetInfo(context.Context, *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
+	return &csi.NodeGetInfoResponse{
+		NodeId: d.c.NodeID,
+	}, nil
+}
+
+// NodePublishVolume mounts the Workload API socket directory onto the
+// target path. It is idempotent: when the target is already mounted from
+// the current socket directory nothing is done, while a mount of a socket
+// directory that has since been replaced, e.g. when the agent was
+// redeployed, is remounted.
+func (d *Driver) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (*csi.NodePublishVolumeResponse, error) {
+	switch {
+	case req.VolumeId == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing volume ID")
+	case req.TargetPath == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing target path")
+	case req.VolumeCapability == nil:
+		return nil, status.Error(codes.InvalidArgument, "request missing volume capability")
+	case req.VolumeCapability.GetMount() == nil:
+		return nil, status.Error(codes.InvalidArgument, "only mount volumes are supported")
+	case !req.Readonly:
+		return nil, status.Error(codes.InvalidArgument, "pod.spec.volumes[].csi.readOnly must be set to true")
+	}
+
+	log := d.c.Log.WithFields(logrus.Fields{
+		"volume_id":   req.VolumeId,
+		"target_path": req.TargetPath,
+	})
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := os.MkdirAll(req.TargetPath, 0755); err != nil {
+		log.WithError(err).Error("Failed to create target path")
+		return nil, status.Errorf(codes.Internal, "unable to create target path: %v", err)
+	}
+
+	mounted, err := d.c.Mounter.IsMountPoint(req.TargetPath)
+	if err != nil {
+		log.WithError(err).Error("Failed to check the target path")
+		return nil, status.Errorf(codes.Internal, "unable to check the target path: %v", err)
+	}
+	if mounted {
+		if d.isCurrentSocketDir(req.TargetPath) {
+			log.Debug("Volume already published")
+			return &csi.NodePublishVolumeResponse{}, nil
+		}
+		log.Info("Remounting replaced Workload API socket directory")
+		if err := d.c.Mounter.Unmount(req.TargetPath); err != nil {
+			log.WithError(err).Error("Failed to unmount stale volume")
+			return nil, status.Errorf(codes.Internal, "unable to unmount stale volume: %v", err)
+		}
+	}
+
+	if d.c.SELinuxLabel != "" {
+		if err := d.c.Mounter.SetLabel(d.c.WorkloadAPISocketDir, d.c.SELinuxLabel); err != nil {
+			log.WithError(err).Error("Failed to label the Workload API socket directory")
+			return nil, status.Errorf(codes.Internal, "unable to label the Workload API socket directory: %v", err)
+		}
+	}
+
+	if err := d.c.Mounter.BindMount(d.c.WorkloadAPISocketDir, req.TargetPath); err != nil {
+		log.WithError(err).Error("Failed to mount the Workload API socket directory")
+		return nil, status.Errorf(codes.Internal, "unable to mount the Workload API socket directory: %v", err)
+	}
+
+	log.Info("Volume published")
+	return &csi.NodePublishVolumeResponse{}, nil
+}
+
+// NodeUnpublishVolume unmounts and removes the target path. It succeeds if
+// the volume was already unpublished.
+func (d *Driver) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpublishVolumeRequest) (*csi.NodeUnpublishVolumeResponse, error) {
+	switch {
+	case req.VolumeId == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing volume ID")
+	case req.TargetPath == "":
+		return nil, status.Error(codes.InvalidArgument, "request missing target path")
+	}
+
+	log := d.c.Log.WithFields(logrus.Fields{
+		"volume_id":   req.VolumeId,
+		"target_path": req.TargetPath,
+	})
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, err := os.Stat(req.TargetPath); os.IsNotExist(err) {
+		log.Debug("Volume already unpublished")
+		return &csi.NodeUnpublishVolumeResponse{}, nil
+	}
+
+	mounted, err := d.c.Mounter.IsMountPoint(req.TargetPath)
+	if err != nil {
+		log.WithError(err).Error("Failed to check the target path")
+		return nil, status.Errorf(codes.Internal, "unable to check the target path: %v", err)
+	}
+	if mounted {
+		if err := d.c.Mounter.Unmount(req.TargetPath); err != nil {
+			log.WithError(err).Error("Failed to unmount volume")
+			return nil, status.Errorf(codes.Internal, "unable to unmount volume: %v", err)
+		}
+	}
+
+	if err := os.Remove(req.TargetPath); err != nil && !os.IsNotExist(err) {
+		log.WithError(err).Error("Failed to remove target path")
+		return nil, status.Errorf(codes.Internal, "unable to remove target path: %v", err)
+	}
+
+	log.Info("Volume unpublished")
+	return &csi.NodeUnpublishVolumeResponse{}, nil
+}
+
+// isCurrentSocketDir returns whether the mounted target path exposes the
+// current Workload API socket directory, as opposed to one that has been
+// removed and created again since it was mounted.
+func (d *Driver) isCurrentSocketDir(targetPath string) bool {
+	targetInfo, err := os.Stat(targetPath)
+	if err != nil {
+		return false
+	}
+	sourceInfo, err := os.Stat(d.c.WorkloadAPISocketDir)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(targetInfo, sourceInfo)
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var ctx = context.Background()
+
+func TestGetPluginInfo(t *testing.T) {
+	d, _ := newTestDriver(t)
+
+	resp, err := d.GetPluginInfo(ctx, &csi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "csi.spiffe.io", resp.Name)
+	assert.NotEmpty(t, resp.VendorVersion)
+}
+
+func TestProbe(t *testing.T) {
+	d, _ := newTestDriver(t)
+
+	resp, err := d.Probe(ctx, &csi.ProbeRequest{})
+	require.NoError(t, err)
+	assert.True(t, resp.Ready.Value)
+
+	require.NoError(t, os.Remove(d.c.WorkloadAPISocketDir))
+	resp, err = d.Probe(ctx, &csi.ProbeRequest{})
+	require.NoError(t, err)
+	assert.False(t, resp.Ready.Value)
+}
+
+func TestNodeGetInfo(t *testing.T) {
+	d, _ := newTestDriver(t)
+
+	resp, err := d.NodeGetInfo(ctx, &csi.NodeGetInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", resp.NodeId)
+}
+
+func TestNodePublishVolumeValidation(t *testing.T) {
+	d, _ := newTestDriver(t)
+
+	for _, tt := range []struct {
+		name   string
+		modify func(req *csi.NodePublishVolumeRequest)
+		err    string
+	}{
+		{
+			name:   "missing volume ID",
+			modify: func(req *csi.NodePublishVolumeRequest) { req.VolumeId = "" },
+			err:    "request missing volume ID",
+		},
+		{
+			name:   "missing target path",
+			modify: func(req *csi.NodePublishVolumeRequest) { req.TargetPath = "" },
+			err:    "request missing target path",
+		},
+		{
+			name:   "missing volume capability",
+			modify: func(req *csi.NodePublishVolumeRequest) { req.VolumeCapability = nil },
+			err:    "request missing volume capability",
+		},
+		{
+			name: "block volume",
+			modify: func(req *csi.NodePublishVolumeRequest) {
+				req.VolumeCapability.AccessType = &csi.VolumeCapability_Block{
+					Block: &csi.VolumeCapability_BlockVolume{},
+				}
+			},
+			err: "only mount volumes are supported",
+		},
+		{
+			name:   "not read-only",
+			modify: func(req *csi.NodePublishVolumeRequest) { req.Readonly = false },
+			err:    "pod.spec.volumes[].csi.readOnly must be set to true",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := newPublishRequest(t)
+			tt.modify(req)
+			_, err := d.NodePublishVolume(ctx, req)
+			spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, tt.err)
+		})
+	}
+}
+
+func TestNodePublishVolume(t *testing.T) {
+	d, mounter := newTestDriver(t)
+	d.c.SELinuxLabel = "system_u:object_r:container_file_t:s0"
+
+	req := newPublishRequest(t)
+	_, err := d.NodePublishVolume(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mounter.mounts)
+	assert.Equal(t, map[string]string{d.c.WorkloadAPISocketDir: "system_u:object_r:container_file_t:s0"}, mounter.labels)
+	assertPublished(t, d, req.TargetPath)
+
+	// Publishing again is a no-op
+	_, err = d.NodePublishVolume(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mounter.mounts)
+	assert.Equal(t, 0, mounter.unmounts)
+
+	// The volume is remounted once the socket directory is replaced, which
+	// is simulated by moving to another directory since the fake mounts
+	// follow the path of the source
+	d.c.WorkloadAPISocketDir = filepath.Join(spiretest.TempDir(t), "sockets")
+	require.NoError(t, os.Mkdir(d.c.WorkloadAPISocketDir, 0755))
+	_, err = d.NodePublishVolume(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, mounter.mounts)
+	assert.Equal(t, 1, mounter.unmounts)
+	assertPublished(t, d, req.TargetPath)
+}
+
+func TestNodePublishVolumeMountFails(t *testing.T) {
+	d, mounter := newTestDriver(t)
+	mounter.mountErr = errors.New("oh no")
+
+	_, err := d.NodePublishVolume(ctx, newPublishRequest(t))
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "unable to mount the Workload API socket directory: oh no")
+}
+
+func TestNodeUnpublishVolume(t *testing.T) {
+	d, mounter := newTestDriver(t)
+
+	req := newPublishRequest(t)
+	_, err := d.NodePublishVolume(ctx, req)
+	require.NoError(t, err)
+
+	_, err = d.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   req.VolumeId,
+		TargetPath: req.TargetPath,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, mounter.unmounts)
+	_, err = os.Lstat(req.TargetPath)
+	assert.True(t, os.IsNotExist(err), "target path was not removed")
+
+	// Unpublishing again succeeds
+	_, err = d.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{
+		VolumeId:   req.VolumeId,
+		TargetPath: req.TargetPath,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, mounter.unmounts)
+}
+
+func TestNodeUnpublishVolumeValidation(t *testing.T) {
+	d, _ := newTestDriver(t)
+
+	_, err := d.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{TargetPath: "/target"})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "request missing volume ID")
+
+	_, err = d.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{VolumeId: "volume"})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "request missing target path")
+}
+
+func newTestDriver(t *testing.T) (*Driver, *fakeMounter) {
+	socketDir := filepath.Join(spiretest.TempDir(t), "sockets")
+	require.NoError(t, os.Mkdir(socketDir, 0755))
+
+	log, _ := test.NewNullLogger()
+	mounter := &fakeMounter{}
+	return NewDriver(DriverConfig{
+		Log:                  log,
+		Name:                 "csi.spiffe.io",
+		NodeID:               "node-1",
+		WorkloadAPISocketDir: socketDir,
+		Mounter:              mounter,
+	}), mounter
+}
+
+func newPublishRequest(t *testing.T) *csi.NodePublishVolumeRequest {
+	return &csi.NodePublishVolumeRequest{
+		VolumeId:   "volume",
+		TargetPath: filepath.Join(spiretest.TempDir(t), "pod", "volume"),
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{
+				Mount: &csi.VolumeCapability_MountVolume{},
+			},
+		},
+		Readonly: true,
+	}
+}
+
+func assertPublished(t *testing.T, d *Driver, targetPath string) {
+	assert.True(t, d.isCurrentSocketDir(targetPath), "target path does not expose the socket directory")
+}
+
+// fakeMounter "mounts" directories by replacing the target with a symlink
+// to the source, which os.Stat resolves like a bind mount.
+type fakeMounter struct {
+	mounts   int
+	unmounts int
+	labels   map[string]string
+	mountErr error
+}
+
+func (m *fakeMounter) BindMount(source, target string) error {
+	if m.mountErr != nil {
+		return m.mountErr
+	}
+	if err := os.Remove(target); err != nil {
+		return err
+	}
+	m.mounts++
+	return os.Symlink(source, target)
+}
+
+func (m *fakeMounter) Unmount(target string) error {
+	if err := os.Remove(target); err != nil {
+		return err
+	}
+	m.unmounts++
+	return os.Mkdir(target, 0755)
+}
+
+func (m *fakeMounter) IsMountPoint(target string) (bool, error) {
+	info, err := os.Lstat(target)
+	if err != nil {
+		return false, err
+	}
+	return info.Mode()&os.ModeSymlink != 0, nil
+}
+
+func (m *fakeMounter) SetLabel(path, label string) error {
+	if m.labels == nil {
+		m.labels = make(map[string]string)
+	}
+	m.labels[path] = label
+	return nil
+}
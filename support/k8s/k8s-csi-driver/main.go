@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc"
+)
+
+var (
+	configFlag = flag.String("config", "k8s-csi-driver.conf", "configuration file")
+)
+
+func main() {
+	flag.Parse()
+	if err := run(*configFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string) error {
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	log, err := log.NewLogger(log.WithLevel(config.LogLevel), log.WithFormat(config.LogFormat), log.WithOutputFile(config.LogPath))
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer log.Close()
+
+	driver := NewDriver(DriverConfig{
+		Log:                  log,
+		Name:                 config.DriverName,
+		NodeID:               config.NodeID,
+		WorkloadAPISocketDir: config.WorkloadAPISocketDir,
+		SELinuxLabel:         config.SELinuxLabel,
+		Mounter:              newMounter(),
+	})
+
+	// Remove the socket left behind by a previous instance of the driver
+	if err := os.Remove(config.CSISocketPath); err != nil && !os.IsNotExist(err) {
+		return errs.New("unable to remove stale CSI socket: %v", err)
+	}
+	listener, err := net.Listen("unix", config.CSISocketPath)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer()
+	csi.RegisterIdentityServer(server, driver)
+	csi.RegisterNodeServer(server, driver)
+
+	log.WithField("socket", config.CSISocketPath).Info("Serving CSI")
+	return server.Serve(listener)
+}
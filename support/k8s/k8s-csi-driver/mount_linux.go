@@ -0,0 +1,74 @@
+// +build linux
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const selinuxXattr = "security.selinux"
+
+// mountInfoUnescaper unescapes the octal sequences the kernel uses for the
+// whitespace and backslashes of the paths in /proc/self/mountinfo
+var mountInfoUnescaper = strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+
+type linuxMounter struct{}
+
+func newMounter() Mounter {
+	return linuxMounter{}
+}
+
+func (linuxMounter) BindMount(source, target string) error {
+	if err := unix.Mount(source, target, "none", unix.MS_BIND, ""); err != nil {
+		return fmt.Errorf("unable to bind mount %q onto %q: %w", source, target, err)
+	}
+	// The read-only flag is ignored when the bind mount is created, so the
+	// mount is made read-only by remounting it
+	if err := unix.Mount("none", target, "none", unix.MS_BIND|unix.MS_REMOUNT|unix.MS_RDONLY, ""); err != nil {
+		_ = unix.Unmount(target, 0)
+		return fmt.Errorf("unable to remount %q read-only: %w", target, err)
+	}
+	return nil
+}
+
+func (linuxMounter) Unmount(target string) error {
+	if err := unix.Unmount(target, 0); err != nil {
+		return fmt.Errorf("unable to unmount %q: %w", target, err)
+	}
+	return nil
+}
+
+func (linuxMounter) IsMountPoint(target string) (bool, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	target = filepath.Clean(target)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// The mount point is the fifth field (see proc(5))
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		if mountInfoUnescaper.Replace(fields[4]) == target {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+func (linuxMounter) SetLabel(path, label string) error {
+	if err := unix.Lsetxattr(path, selinuxXattr, []byte(label), 0); err != nil {
+		return fmt.Errorf("unable to set SELinux label of %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// +build !linux
+
+package main
+
+import (
+	"errors"
+)
+
+var errMountUnsupported = errors.New("mounting volumes is only supported on Linux")
+
+type unsupportedMounter struct{}
+
+func newMounter() Mounter {
+	return unsupportedMounter{}
+}
+
+func (unsupportedMounter) BindMount(source, target string) error {
+	return errMountUnsupported
+}
+
+func (unsupportedMounter) Unmount(target string) error {
+	return errMountUnsupported
+}
+
+func (unsupportedMounter) IsMountPoint(target string) (bool, error) {
+	return false, errMountUnsupported
+}
+
+func (unsupportedMounter) SetLabel(path, label string) error {
+	return errMountUnsupported
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/logger"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire/support/k8s/k8s-workload-registrar/idtemplate"
 
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
@@ -47,9 +48,11 @@ type CommonMode struct {
 	Cluster            string   `hcl:"cluster"`
 	PodLabel           string   `hcl:"pod_label"`
 	PodAnnotation      string   `hcl:"pod_annotation"`
+	IdentityTemplate   string   `hcl:"identity_template"`
 	Mode               string   `hcl:"mode"`
 	DisabledNamespaces []string `hcl:"disabled_namespaces"`
 	registrationAPI    RegistrationAPIConnections
+	identityTemplate   *idtemplate.Template
 }
 
 func (c *CommonMode) ParseConfig(hclConfig string) error {
@@ -80,6 +83,16 @@ func (c *CommonMode) ParseConfig(hclConfig string) error {
 	if c.PodLabel != "" && c.PodAnnotation != "" {
 		return errs.New("workload registration mode specification is incorrect, can't specify both pod_label and pod_annotation")
 	}
+	if c.IdentityTemplate != "" {
+		if c.PodLabel != "" || c.PodAnnotation != "" {
+			return errs.New("workload registration mode specification is incorrect, can't specify identity_template with pod_label or pod_annotation")
+		}
+		identityTemplate, err := idtemplate.New(c.TrustDomain, c.IdentityTemplate)
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		c.identityTemplate = identityTemplate
+	}
 	if c.Mode != modeCRD && c.Mode != modeWebhook && c.Mode != modeReconcile {
 		return errs.New("invalid mode \"%s\", valid values are %s, %s and %s", c.Mode, modeCRD, modeWebhook, modeReconcile)
 	}
@@ -95,7 +108,12 @@ func defaultDisabledNamespaces() []string {
 }
 
 func (c *CommonMode) SetupLogger() (*log.Logger, error) {
-	return log.NewLogger(log.WithLevel(c.LogLevel), log.WithFormat(c.LogFormat), log.WithOutputFile(c.LogPath))
+	logger, err := log.NewLogger(log.WithLevel(c.LogLevel), log.WithFormat(c.LogFormat), log.WithOutputFile(c.LogPath))
+	if err != nil {
+		return nil, err
+	}
+	setRunningLogger(logger)
+	return logger, nil
 }
 
 func (c *CommonMode) RegistrationClient(ctx context.Context, dialLogger logger.Logger) (registration.RegistrationClient, error) {
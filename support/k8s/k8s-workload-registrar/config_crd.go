@@ -121,6 +121,7 @@ func (c *CRDMode) Run(ctx context.Context) error {
 			Cluster:            c.Cluster,
 			Ctx:                ctx,
 			DisabledNamespaces: c.DisabledNamespaces,
+			IdentityTemplate:   c.identityTemplate,
 			Log:                log,
 			PodLabel:           c.PodLabel,
 			PodAnnotation:      c.PodAnnotation,
@@ -113,6 +113,9 @@ func (c *ReconcileMode) Run(ctx context.Context) error {
 		mode = controllers.PodReconcilerModeAnnotation
 		value = c.PodAnnotation
 	}
+	if c.identityTemplate != nil {
+		mode = controllers.PodReconcilerModeTemplate
+	}
 	if err = controllers.NewPodReconciler(
 		mgr.GetClient(),
 		ctrl.Log.WithName("controllers").WithName("Pod"),
@@ -122,6 +125,7 @@ func (c *ReconcileMode) Run(ctx context.Context) error {
 		spireClient,
 		mode,
 		value,
+		c.identityTemplate,
 		c.ClusterDNSZone,
 		c.AddPodDNSNames,
 		c.DisabledNamespaces,
@@ -147,6 +147,21 @@ func TestLoadMode(t *testing.T) {
 			`,
 			err: "workload registration mode specification is incorrect, can't specify both pod_label and pod_annotation",
 		},
+		{
+			name: "identity template with pod label",
+			in: testMinimalConfig + `
+				pod_label = "PODLABEL"
+				identity_template = "spiffe://TRUSTDOMAIN/ns/{{.Namespace}}"
+			`,
+			err: "can't specify identity_template with pod_label or pod_annotation",
+		},
+		{
+			name: "malformed identity template",
+			in: testMinimalConfig + `
+				identity_template = "spiffe://TRUSTDOMAIN/ns/{{.Namespace"
+			`,
+			err: "invalid identity template",
+		},
 	}
 
 	for _, testCase := range testCases {
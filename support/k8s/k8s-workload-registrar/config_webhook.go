@@ -67,6 +67,7 @@ func (c *WebhookMode) Run(ctx context.Context) error {
 		Cluster:            c.Cluster,
 		PodLabel:           c.PodLabel,
 		PodAnnotation:      c.PodAnnotation,
+		IdentityTemplate:   c.identityTemplate,
 		DisabledNamespaces: disabledNamespacesMap,
 	})
 
@@ -12,6 +12,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/support/k8s/k8s-workload-registrar/idtemplate"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -27,6 +28,7 @@ type ControllerConfig struct {
 	Cluster            string
 	PodLabel           string
 	PodAnnotation      string
+	IdentityTemplate   *idtemplate.Template
 	DisabledNamespaces map[string]bool
 }
 
@@ -106,6 +108,21 @@ func (c *Controller) reviewAdmission(ctx context.Context, req *admv1beta1.Admiss
 
 // podSpiffeID returns the desired spiffe ID for the pod, or nil if it should be ignored
 func (c *Controller) podSpiffeID(pod *corev1.Pod) string {
+	if c.c.IdentityTemplate != nil {
+		// the controller has been configured with an identity template. if
+		// the template cannot be rendered for the pod (e.g. the pod lacks a
+		// label used by the template), ignore the pod altogether.
+		spiffeID, err := c.c.IdentityTemplate.PodSpiffeID(pod)
+		if err != nil {
+			c.c.Log.WithError(err).WithFields(logrus.Fields{
+				"ns":  pod.Namespace,
+				"pod": pod.Name,
+			}).Debug("Ignoring pod")
+			return ""
+		}
+		return spiffeID
+	}
+
 	if c.c.PodLabel != "" {
 		// the controller has been configured with a pod label. if the pod
 		// has that label, use the value to construct the pod entry. otherwise
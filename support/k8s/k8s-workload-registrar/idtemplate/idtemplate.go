@@ -0,0 +1,98 @@
+// Package idtemplate renders the SPIFFE ID of a pod from a template over the
+// pod metadata.
+package idtemplate
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodData is the data available to identity templates
+type PodData struct {
+	// TrustDomain is the trust domain name of the registrar (e.g. example.org)
+	TrustDomain string
+
+	// Namespace is the namespace of the pod
+	Namespace string
+
+	// ServiceAccount is the name of the service account of the pod
+	ServiceAccount string
+
+	// PodName is the name of the pod
+	PodName string
+
+	// PodUID is the UID of the pod
+	PodUID string
+
+	// NodeName is the name of the node the pod is scheduled on
+	NodeName string
+
+	// Labels are the labels of the pod
+	Labels map[string]string
+
+	// Annotations are the annotations of the pod
+	Annotations map[string]string
+}
+
+// Template renders SPIFFE IDs for pods
+type Template struct {
+	trustDomain spiffeid.TrustDomain
+	tmpl        *template.Template
+}
+
+// New parses an identity template. The template must render a SPIFFE ID in
+// the given trust domain, e.g.:
+//
+//	spiffe://example.org/ns/{{.Namespace}}/sa/{{.ServiceAccount}}/app/{{.Labels.app}}
+func New(trustDomain, text string) (*Template, error) {
+	td, err := spiffeid.TrustDomainFromString(trustDomain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust domain: %v", err)
+	}
+
+	// Referencing a label or annotation the pod does not have is an error, so
+	// pods lacking the metadata used by the template are not registered.
+	tmpl, err := template.New("identity").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity template: %v", err)
+	}
+
+	return &Template{
+		trustDomain: td,
+		tmpl:        tmpl,
+	}, nil
+}
+
+// PodSpiffeID renders the SPIFFE ID of the given pod. It fails if the
+// template references metadata the pod does not have, or if the result is
+// not a valid SPIFFE ID in the trust domain.
+func (t *Template) PodSpiffeID(pod *corev1.Pod) (string, error) {
+	data := PodData{
+		TrustDomain:    t.trustDomain.String(),
+		Namespace:      pod.Namespace,
+		ServiceAccount: pod.Spec.ServiceAccountName,
+		PodName:        pod.Name,
+		PodUID:         string(pod.UID),
+		NodeName:       pod.Spec.NodeName,
+		Labels:         pod.Labels,
+		Annotations:    pod.Annotations,
+	}
+
+	buf := new(bytes.Buffer)
+	if err := t.tmpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("unable to render identity template: %v", err)
+	}
+
+	id, err := spiffeid.FromString(buf.String())
+	if err != nil {
+		return "", fmt.Errorf("identity template rendered an invalid SPIFFE ID %q: %v", buf.String(), err)
+	}
+	if !id.MemberOf(t.trustDomain) {
+		return "", fmt.Errorf("identity template rendered SPIFFE ID %q which is not a member of trust domain %q", id, t.trustDomain)
+	}
+	return id.String(), nil
+}
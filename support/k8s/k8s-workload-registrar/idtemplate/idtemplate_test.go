@@ -0,0 +1,82 @@
+package idtemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New("", "spiffe://example.org/{{.Namespace}}")
+	assert.Error(t, err)
+
+	_, err = New("example.org", "spiffe://example.org/{{.Namespace")
+	assert.Contains(t, err.Error(), "invalid identity template")
+}
+
+func TestPodSpiffeID(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo-123",
+			Namespace:   "ns",
+			UID:         "uid",
+			Labels:      map[string]string{"app": "foo"},
+			Annotations: map[string]string{"spiffe.io/team": "bar"},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "sa",
+			NodeName:           "node",
+		},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		template  string
+		expectID  string
+		expectErr string
+	}{
+		{
+			name:     "namespace, service account and label",
+			template: "spiffe://{{.TrustDomain}}/ns/{{.Namespace}}/sa/{{.ServiceAccount}}/app/{{.Labels.app}}",
+			expectID: "spiffe://example.org/ns/ns/sa/sa/app/foo",
+		},
+		{
+			name:     "pod, node and annotation",
+			template: `spiffe://example.org/{{index .Annotations "spiffe.io/team"}}/{{.NodeName}}/{{.PodName}}/{{.PodUID}}`,
+			expectID: "spiffe://example.org/bar/node/foo-123/uid",
+		},
+		{
+			name:      "missing label",
+			template:  "spiffe://example.org/app/{{.Labels.other}}",
+			expectErr: "unable to render identity template",
+		},
+		{
+			name:      "invalid SPIFFE ID",
+			template:  "{{.Namespace}}",
+			expectErr: `identity template rendered an invalid SPIFFE ID "ns"`,
+		},
+		{
+			name:      "other trust domain",
+			template:  "spiffe://other.org/{{.Namespace}}",
+			expectErr: `identity template rendered SPIFFE ID "spiffe://other.org/ns" which is not a member of trust domain "example.org"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := New("example.org", tt.template)
+			require.NoError(t, err)
+
+			id, err := tmpl.PodSpiffeID(pod)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectID, id)
+		})
+	}
+}
@@ -27,5 +27,9 @@ func run(ctx context.Context, configPath string) error {
 
 	defer mode.Close()
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go reloadOnSignal(ctx, configPath, mode)
+
 	return mode.Run(ctx)
 }
@@ -59,6 +59,11 @@ type SpiffeIDStatus struct {
 }
 
 // SpiffeID is the Schema for the SpiffeIds API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SPIFFE ID",type=string,JSONPath=`.spec.spiffeId`
+// +kubebuilder:printcolumn:name="Parent ID",type=string,JSONPath=`.spec.parentId`
+// +kubebuilder:printcolumn:name="Entry ID",type=string,JSONPath=`.status.entryId`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type SpiffeID struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
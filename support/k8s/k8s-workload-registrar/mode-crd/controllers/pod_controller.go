@@ -19,6 +19,7 @@ import (
 	"context"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/support/k8s/k8s-workload-registrar/idtemplate"
 	spiffeidv1beta1 "github.com/spiffe/spire/support/k8s/k8s-workload-registrar/mode-crd/api/spiffeid/v1beta1"
 
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,7 @@ type PodReconcilerConfig struct {
 	Cluster            string
 	Ctx                context.Context
 	DisabledNamespaces []string
+	IdentityTemplate   *idtemplate.Template
 	Log                logrus.FieldLogger
 	PodLabel           string
 	PodAnnotation      string
@@ -158,6 +160,21 @@ func (r *PodReconciler) updateorCreatePodEntry(ctx context.Context, pod *corev1.
 
 // podSpiffeID returns the desired spiffe ID for the pod, or nil if it should be ignored
 func (r *PodReconciler) podSpiffeID(pod *corev1.Pod) string {
+	if r.c.IdentityTemplate != nil {
+		// the controller has been configured with an identity template. if
+		// the template cannot be rendered for the pod (e.g. the pod lacks a
+		// label used by the template), ignore the pod altogether.
+		spiffeID, err := r.c.IdentityTemplate.PodSpiffeID(pod)
+		if err != nil {
+			r.c.Log.WithError(err).WithFields(logrus.Fields{
+				"name":      pod.Name,
+				"namespace": pod.Namespace,
+			}).Debug("Ignoring pod")
+			return ""
+		}
+		return spiffeID
+	}
+
 	if r.c.PodLabel != "" {
 		// the controller has been configured with a pod label. if the pod
 		// has that label, use the value to construct the pod entry. otherwise
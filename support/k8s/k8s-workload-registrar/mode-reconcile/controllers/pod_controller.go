@@ -22,8 +22,10 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/proto/spire/api/server/entry/v1"
 	spiretypes "github.com/spiffe/spire/proto/spire/types"
+	"github.com/spiffe/spire/support/k8s/k8s-workload-registrar/idtemplate"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -43,6 +45,7 @@ const (
 	PodReconcilerModeServiceAccount PodReconcilerMode = iota
 	PodReconcilerModeLabel
 	PodReconcilerModeAnnotation
+	PodReconcilerModeTemplate
 )
 
 // PodReconciler reconciles a Pod object
@@ -51,6 +54,7 @@ type PodReconciler struct {
 	TrustDomain        string
 	Mode               PodReconcilerMode
 	Value              string
+	IdentityTemplate   *idtemplate.Template
 	RootID             spiretypes.SPIFFEID
 	SpireClient        entry.EntryClient
 	ClusterDNSZone     string
@@ -238,6 +242,13 @@ func (r *PodReconciler) makeSpiffeIDForPod(pod *corev1.Pod) *spiretypes.SPIFFEID
 		if val, ok := pod.GetAnnotations()[r.Value]; ok {
 			spiffeID = r.makeID(path.Join("/", val))
 		}
+	case PodReconcilerModeTemplate:
+		// Pods for which the template cannot be rendered are ignored
+		if val, err := r.IdentityTemplate.PodSpiffeID(pod); err == nil {
+			if id, err := spiffeid.FromString(val); err == nil {
+				spiffeID = r.makeID(id.Path())
+			}
+		}
 	}
 	return spiffeID
 }
@@ -352,7 +363,7 @@ func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager, builder *ctrlBuilder.
 	return nil
 }
 
-func NewPodReconciler(client client.Client, log logr.Logger, scheme *runtime.Scheme, trustDomain string, rootID spiretypes.SPIFFEID, spireClient entry.EntryClient, mode PodReconcilerMode, value string, clusterDNSZone string, addPodDNSNames bool, disabledNamespaces []string) *BaseReconciler {
+func NewPodReconciler(client client.Client, log logr.Logger, scheme *runtime.Scheme, trustDomain string, rootID spiretypes.SPIFFEID, spireClient entry.EntryClient, mode PodReconcilerMode, value string, identityTemplate *idtemplate.Template, clusterDNSZone string, addPodDNSNames bool, disabledNamespaces []string) *BaseReconciler {
 	disabledNamespacesMap := make(map[string]bool, len(disabledNamespaces))
 	for _, ns := range disabledNamespaces {
 		disabledNamespacesMap[ns] = true
@@ -371,6 +382,7 @@ func NewPodReconciler(client client.Client, log logr.Logger, scheme *runtime.Sch
 			TrustDomain:        trustDomain,
 			Mode:               mode,
 			Value:              value,
+			IdentityTemplate:   identityTemplate,
 			ClusterDNSZone:     clusterDNSZone,
 			AddPodDNSNames:     addPodDNSNames,
 			DisabledNamespaces: disabledNamespacesMap,
@@ -95,6 +95,7 @@ func (s *PodControllerTestSuite) TestAddChangeRemovePod() {
 				}, s.entryClient,
 				tt.m,
 				"spiffe",
+				nil,
 				"",
 				false,
 				[]string{},
@@ -196,6 +197,7 @@ func (s *PodControllerTestSuite) TestAddDnsNames() {
 		s.entryClient,
 		PodReconcilerModeServiceAccount,
 		"",
+		nil,
 		"cluster.local",
 		true,
 		[]string{},
@@ -308,6 +310,7 @@ func (s *PodControllerTestSuite) TestDottedPodNamesDns() {
 		s.entryClient,
 		PodReconcilerModeServiceAccount,
 		"",
+		nil,
 		"cluster.local",
 		true,
 		[]string{},
@@ -398,6 +401,7 @@ func (s *PodControllerTestSuite) TestDottedServiceNamesDns() {
 		s.entryClient,
 		PodReconcilerModeServiceAccount,
 		"",
+		nil,
 		"cluster.local",
 		true,
 		[]string{},
@@ -480,6 +484,7 @@ func (s *PodControllerTestSuite) TestSkipsDisabledNamespace() {
 		s.entryClient,
 		PodReconcilerModeServiceAccount,
 		"",
+		nil,
 		"cluster.local",
 		true,
 		[]string{"bar"},
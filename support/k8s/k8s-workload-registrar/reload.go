@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// reloadableSettings are the settings that are applied without restarting
+// the registrar when the configuration is reloaded.
+var reloadableSettings = map[string]bool{
+	"log_level": true,
+}
+
+// runningLogger is the logger set up by the running mode. The reconcile mode
+// logs through controller-runtime instead, so it doesn't set one up.
+var runningLogger struct {
+	sync.Mutex
+	log *log.Logger
+}
+
+func setRunningLogger(logger *log.Logger) {
+	runningLogger.Lock()
+	defer runningLogger.Unlock()
+	runningLogger.log = logger
+}
+
+func getRunningLogger() *log.Logger {
+	runningLogger.Lock()
+	defer runningLogger.Unlock()
+	return runningLogger.log
+}
+
+// reloadOnSignal reloads the configuration every time the process receives
+// a SIGHUP. The log level is applied without a restart, and changes to any
+// other setting are reported as requiring one.
+func reloadOnSignal(ctx context.Context, configPath string, mode Mode) {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGHUP)
+	defer signal.Stop(signalCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signalCh:
+		}
+
+		logger := getRunningLogger()
+		reloaded, err := LoadMode(configPath)
+		if err != nil {
+			if logger != nil {
+				logger.WithError(err).Error("Failed to reload configuration; keeping current configuration")
+			} else {
+				fmt.Fprintf(os.Stderr, "Failed to reload configuration; keeping current configuration: %v\n", err)
+			}
+			continue
+		}
+
+		applied, restartRequired := reloadMode(mode, reloaded, logger)
+		if logger != nil {
+			logger.WithFields(logrus.Fields{
+				telemetry.AppliedSettings:         applied,
+				telemetry.RestartRequiredSettings: restartRequired,
+			}).Info("Configuration reloaded")
+		}
+	}
+}
+
+// reloadMode applies the log level of the reloaded configuration to the
+// logger, if any, and returns the applied settings and the settings that
+// changed since the registrar started and require a restart.
+func reloadMode(initial, reloaded Mode, logger *log.Logger) (applied, restartRequired []string) {
+	if reflect.TypeOf(initial) != reflect.TypeOf(reloaded) {
+		return nil, []string{"mode"}
+	}
+
+	for _, setting := range common_cli.ChangedConfigKeys(initial, reloaded) {
+		if !reloadableSettings[setting] || logger == nil {
+			restartRequired = append(restartRequired, setting)
+		}
+	}
+
+	if logger != nil {
+		levels, err := log.ParseLevels(reloadedLogLevel(reloaded), nil)
+		if err == nil && levels.Level != logger.GetLevels().Level {
+			logger.SetLevels(levels)
+			applied = append(applied, "log_level")
+		}
+	}
+	return applied, restartRequired
+}
+
+func reloadedLogLevel(mode Mode) string {
+	switch m := mode.(type) {
+	case *WebhookMode:
+		return m.LogLevel
+	case *CRDMode:
+		return m.LogLevel
+	case *ReconcileMode:
+		return m.LogLevel
+	}
+	return defaultLogLevel
+}
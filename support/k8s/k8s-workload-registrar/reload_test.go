@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadMode(t *testing.T) {
+	logger, err := log.NewLogger(log.WithLevel("info"))
+	require.NoError(t, err)
+
+	initial := &WebhookMode{CommonMode: CommonMode{LogLevel: "info", Cluster: "CLUSTER"}, Addr: ":8443"}
+
+	applied, restartRequired := reloadMode(initial, initial, logger)
+	require.Empty(t, applied)
+	require.Empty(t, restartRequired)
+
+	reloaded := &WebhookMode{CommonMode: CommonMode{LogLevel: "debug", Cluster: "OTHER"}, Addr: ":8443"}
+	applied, restartRequired = reloadMode(initial, reloaded, logger)
+	require.Equal(t, []string{"log_level"}, applied)
+	require.Equal(t, []string{"cluster"}, restartRequired)
+	require.Equal(t, logrus.DebugLevel, logger.GetLevels().Level)
+
+	// Without a logger, the log level requires a restart
+	applied, restartRequired = reloadMode(initial, reloaded, nil)
+	require.Empty(t, applied)
+	require.Equal(t, []string{"cluster", "log_level"}, restartRequired)
+
+	applied, restartRequired = reloadMode(initial, &CRDMode{CommonMode: initial.CommonMode}, logger)
+	require.Empty(t, applied)
+	require.Equal(t, []string{"mode"}, restartRequired)
+}
@@ -62,7 +62,7 @@ func (h *Handler) serveWellKnown(w http.ResponseWriter, r *http.Request) {
 		AuthorizationEndpoint:            "",
 		ResponseTypesSupported:           []string{"id_token"},
 		SubjectTypesSupported:            []string{},
-		IDTokenSigningAlgValuesSupported: []string{"RS256", "ES256", "ES384"},
+		IDTokenSigningAlgValuesSupported: []string{"RS256", "PS256", "ES256", "ES384", "EdDSA"},
 	}
 
 	docBytes, err := json.MarshalIndent(doc, "", "  ")
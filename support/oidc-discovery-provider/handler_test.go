@@ -37,8 +37,10 @@ func TestHandler(t *testing.T) {
   "subject_types_supported": [],
   "id_token_signing_alg_values_supported": [
     "RS256",
+    "PS256",
     "ES256",
-    "ES384"
+    "ES384",
+    "EdDSA"
   ]
 }`,
 		},
@@ -4,6 +4,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
 )
 
@@ -27,6 +28,10 @@ func (c *Catalog) SetWorkloadAttestors(workloadAttestors ...catalog.WorkloadAtte
 	c.WorkloadAttestors = workloadAttestors
 }
 
+func (c *Catalog) SetSVIDStores(svidStores ...catalog.SVIDStore) {
+	c.SVIDStores = svidStores
+}
+
 func KeyManager(keyManager keymanager.KeyManager) catalog.KeyManager {
 	return catalog.KeyManager{
 		KeyManager: keyManager,
@@ -47,6 +52,13 @@ func WorkloadAttestor(name string, workloadAttestor workloadattestor.WorkloadAtt
 	}
 }
 
+func SVIDStore(name string, svidStore svidstore.SVIDStore) catalog.SVIDStore {
+	return catalog.SVIDStore{
+		PluginInfo: pluginInfo{name: name, typ: svidstore.Type},
+		SVIDStore:  svidStore,
+	}
+}
+
 type pluginInfo struct {
 	name string
 	typ  string
@@ -72,6 +72,13 @@ func (s *DataStore) AppendBundle(ctx context.Context, req *datastore.AppendBundl
 	return s.ds.AppendBundle(ctx, req)
 }
 
+func (s *DataStore) Backup(ctx context.Context, req *datastore.BackupRequest) (*datastore.BackupResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.Backup(ctx, req)
+}
+
 func (s *DataStore) CountBundles(ctx context.Context, req *datastore.CountBundlesRequest) (*datastore.CountBundlesResponse, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -258,6 +265,13 @@ func (s *DataStore) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJo
 	return s.ds.DeleteJoinToken(ctx, req)
 }
 
+func (s *DataStore) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.UseJoinToken(ctx, req)
+}
+
 func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -265,6 +279,90 @@ func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoi
 	return s.ds.PruneJoinTokens(ctx, req)
 }
 
+func (s *DataStore) CreateFederationRelationship(ctx context.Context, req *datastore.CreateFederationRelationshipRequest) (*datastore.CreateFederationRelationshipResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.CreateFederationRelationship(ctx, req)
+}
+
+func (s *DataStore) FetchFederationRelationship(ctx context.Context, req *datastore.FetchFederationRelationshipRequest) (*datastore.FetchFederationRelationshipResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.FetchFederationRelationship(ctx, req)
+}
+
+func (s *DataStore) ListFederationRelationships(ctx context.Context, req *datastore.ListFederationRelationshipsRequest) (*datastore.ListFederationRelationshipsResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	resp, err := s.ds.ListFederationRelationships(ctx, req)
+	if err == nil {
+		// Sorting helps unit-tests have deterministic assertions.
+		sort.Slice(resp.FederationRelationships, func(i, j int) bool {
+			return resp.FederationRelationships[i].TrustDomainId < resp.FederationRelationships[j].TrustDomainId
+		})
+	}
+	return resp, err
+}
+
+func (s *DataStore) UpdateFederationRelationship(ctx context.Context, req *datastore.UpdateFederationRelationshipRequest) (*datastore.UpdateFederationRelationshipResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.UpdateFederationRelationship(ctx, req)
+}
+
+func (s *DataStore) DeleteFederationRelationship(ctx context.Context, req *datastore.DeleteFederationRelationshipRequest) (*datastore.DeleteFederationRelationshipResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.DeleteFederationRelationship(ctx, req)
+}
+
+func (s *DataStore) CreateEntryTemplate(ctx context.Context, req *datastore.CreateEntryTemplateRequest) (*datastore.CreateEntryTemplateResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.CreateEntryTemplate(ctx, req)
+}
+
+func (s *DataStore) FetchEntryTemplate(ctx context.Context, req *datastore.FetchEntryTemplateRequest) (*datastore.FetchEntryTemplateResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.FetchEntryTemplate(ctx, req)
+}
+
+func (s *DataStore) ListEntryTemplates(ctx context.Context, req *datastore.ListEntryTemplatesRequest) (*datastore.ListEntryTemplatesResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	resp, err := s.ds.ListEntryTemplates(ctx, req)
+	if err == nil {
+		// Sorting helps unit-tests have deterministic assertions.
+		sort.Slice(resp.EntryTemplates, func(i, j int) bool {
+			return resp.EntryTemplates[i].TemplateId < resp.EntryTemplates[j].TemplateId
+		})
+	}
+	return resp, err
+}
+
+func (s *DataStore) UpdateEntryTemplate(ctx context.Context, req *datastore.UpdateEntryTemplateRequest) (*datastore.UpdateEntryTemplateResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.UpdateEntryTemplate(ctx, req)
+}
+
+func (s *DataStore) DeleteEntryTemplate(ctx context.Context, req *datastore.DeleteEntryTemplateRequest) (*datastore.DeleteEntryTemplateResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.DeleteEntryTemplate(ctx, req)
+}
+
 func (s *DataStore) SetNextError(err error) {
 	s.errs = []error{err}
 }
@@ -10,8 +10,9 @@ import (
 )
 
 type IdentityProvider struct {
-	mu      sync.Mutex
-	bundles []*common.Bundle
+	mu               sync.Mutex
+	bundles          []*common.Bundle
+	federatedBundles []*common.Bundle
 }
 
 func New() *IdentityProvider {
@@ -30,9 +31,13 @@ func (c *IdentityProvider) FetchX509Identity(ctx context.Context, req *hostservi
 	c.bundles = c.bundles[1:]
 
 	// TODO: support sending back the identity
-	return &hostservices.FetchX509IdentityResponse{
+	resp := &hostservices.FetchX509IdentityResponse{
 		Bundle: bundle,
-	}, nil
+	}
+	if req.IncludeFederatedBundles {
+		resp.FederatedBundles = c.federatedBundles
+	}
+	return resp, nil
 }
 
 func (c *IdentityProvider) AppendBundle(bundle *common.Bundle) {
@@ -40,3 +45,10 @@ func (c *IdentityProvider) AppendBundle(bundle *common.Bundle) {
 	defer c.mu.Unlock()
 	c.bundles = append(c.bundles, bundle)
 }
+
+// SetFederatedBundles sets the federated bundles returned when requested.
+func (c *IdentityProvider) SetFederatedBundles(bundles ...*common.Bundle) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.federatedBundles = bundles
+}
@@ -106,3 +106,17 @@ func (mr *MockClientMockRecorder) RenewSVID(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewSVID", reflect.TypeOf((*MockClient)(nil).RenewSVID), arg0, arg1)
 }
+
+// WatchEntries mocks base method
+func (m *MockClient) WatchEntries(arg0 context.Context, arg1 func()) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchEntries", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchEntries indicates an expected call of WatchEntries
+func (mr *MockClientMockRecorder) WatchEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchEntries", reflect.TypeOf((*MockClient)(nil).WatchEntries), arg0, arg1)
+}